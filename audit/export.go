@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format selects the export encoding.
+type Format string
+
+const (
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+var ErrFormatUnsupported = errors.New("audit: export format is unsupported")
+
+// CSVHeader lists the CSV columns written by the CSV encoder. Metadata is
+// serialised as a JSON object.
+var CSVHeader = []string{"id", "occurred_at", "entity_type", "entity_id", "action", "actor_id", "environment_key", "metadata"}
+
+// ParseFormat normalises a format name.
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case FormatNDJSON, FormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrFormatUnsupported, value)
+	}
+}
+
+// Encoder writes audit events in an export format. Call Flush once every
+// event has been encoded.
+type Encoder interface {
+	Encode(event Event) error
+	Flush() error
+}
+
+// NewEncoder returns an encoder writing the format to w.
+func NewEncoder(w io.Writer, format Format) (Encoder, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrFormatUnsupported, format)
+	}
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(event Event) error {
+	return e.enc.Encode(event)
+}
+
+func (e *ndjsonEncoder) Flush() error { return nil }
+
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(event Event) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	metadata := ""
+	if len(event.Metadata) > 0 {
+		raw, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return err
+		}
+		metadata = string(raw)
+	}
+	return e.w.Write([]string{
+		event.ID.String(),
+		event.OccurredAt.UTC().Format(time.RFC3339Nano),
+		event.EntityType,
+		event.EntityID,
+		event.Action,
+		event.ActorID,
+		event.EnvironmentKey,
+		metadata,
+	})
+}
+
+func (e *csvEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.w.Write(CSVHeader)
+}
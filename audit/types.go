@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Query limits applied when Limit is zero or too large.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+var (
+	ErrCursorInvalid       = errors.New("audit: cursor is invalid")
+	ErrPurgeCutoffRequired = errors.New("audit: purge cutoff is required")
+	ErrPurgeUnsupported    = errors.New("audit: log cannot remove events before a cutoff")
+)
+
+// Event records a change applied by the scheduler worker or an admin
+// service. ActorID and EnvironmentKey are empty when the change was not
+// attributed to a user or environment.
+type Event struct {
+	bun.BaseModel `bun:"table:audit_events,alias:ae" json:"-"`
+
+	ID             uuid.UUID      `bun:",pk,type:uuid" json:"id"`
+	EntityType     string         `bun:"entity_type,notnull" json:"entity_type"`
+	EntityID       string         `bun:"entity_id,notnull" json:"entity_id"`
+	Action         string         `bun:"action,notnull" json:"action"`
+	ActorID        string         `bun:"actor_id" json:"actor_id,omitempty"`
+	EnvironmentKey string         `bun:"environment_key" json:"environment_key,omitempty"`
+	OccurredAt     time.Time      `bun:"occurred_at,notnull" json:"occurred_at"`
+	Metadata       map[string]any `bun:"metadata,type:jsonb" json:"metadata,omitempty"`
+}
+
+// Query filters audit events. Empty fields match every value. From is
+// inclusive and To is exclusive. Results are ordered by OccurredAt, oldest
+// first; pass the NextCursor of a page as Cursor to read the next one.
+type Query struct {
+	EntityType     string
+	EntityID       string
+	ActorID        string
+	Action         string
+	EnvironmentKey string
+	From           time.Time
+	To             time.Time
+	Cursor         string
+	Limit          int
+}
+
+// Page is one page of query results. NextCursor is empty on the last page.
+type Page struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// Log reads and prunes recorded audit events.
+type Log interface {
+	Query(ctx context.Context, query Query) (Page, error)
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// Matches reports whether the event passes the query filters. The cursor and
+// limit are not considered.
+func (q Query) Matches(event Event) bool {
+	if q.EntityType != "" && event.EntityType != q.EntityType {
+		return false
+	}
+	if q.EntityID != "" && event.EntityID != q.EntityID {
+		return false
+	}
+	if q.ActorID != "" && event.ActorID != q.ActorID {
+		return false
+	}
+	if q.Action != "" && event.Action != q.Action {
+		return false
+	}
+	if q.EnvironmentKey != "" && event.EnvironmentKey != q.EnvironmentKey {
+		return false
+	}
+	if !q.From.IsZero() && event.OccurredAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !event.OccurredAt.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// EffectiveLimit returns the page size, applying DefaultLimit and MaxLimit.
+func (q Query) EffectiveLimit() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultLimit
+	case q.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return q.Limit
+	}
+}
+
+// Cursor is the position after which the next page starts.
+type Cursor struct {
+	OccurredAt time.Time
+	ID         uuid.UUID
+}
+
+// CursorAfter returns the opaque cursor pointing past the event.
+func CursorAfter(event Event) string {
+	raw := strconv.FormatInt(event.OccurredAt.UTC().UnixNano(), 10) + ":" + event.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor produced by CursorAfter.
+func ParseCursor(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return Cursor{}, ErrCursorInvalid
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return Cursor{}, ErrCursorInvalid
+	}
+	unix, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrCursorInvalid
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, ErrCursorInvalid
+	}
+	return Cursor{OccurredAt: time.Unix(0, unix).UTC(), ID: parsed}, nil
+}
+
+// Covers reports whether the event sorts at or before the cursor position,
+// i.e. whether it was already returned on an earlier page.
+func (c Cursor) Covers(event Event) bool {
+	occurred := event.OccurredAt.UTC()
+	if occurred.Equal(c.OccurredAt) {
+		return event.ID.String() <= c.ID.String()
+	}
+	return occurred.Before(c.OccurredAt)
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	event := Event{
+		ID:         uuid.MustParse("00000000-0000-0000-0000-0000000000a2"),
+		OccurredAt: time.Date(2024, 6, 1, 12, 0, 0, 123000, time.UTC),
+	}
+	cursor, err := ParseCursor(CursorAfter(event))
+	if err != nil {
+		t.Fatalf("parse cursor: %v", err)
+	}
+	if cursor.ID != event.ID || !cursor.OccurredAt.Equal(event.OccurredAt) {
+		t.Fatalf("unexpected cursor %+v", cursor)
+	}
+	if !cursor.Covers(event) {
+		t.Fatalf("expected cursor to cover its own event")
+	}
+	later := Event{ID: uuid.MustParse("00000000-0000-0000-0000-0000000000a3"), OccurredAt: event.OccurredAt}
+	if cursor.Covers(later) {
+		t.Fatalf("expected cursor not to cover a later event with the same timestamp")
+	}
+
+	for _, value := range []string{"", "!!", "MTIz"} {
+		if _, err := ParseCursor(value); !errors.Is(err, ErrCursorInvalid) {
+			t.Fatalf("expected ErrCursorInvalid for %q, got %v", value, err)
+		}
+	}
+}
+
+func TestQueryMatchesAndLimit(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := Event{EntityType: "content", EntityID: "c-1", Action: "publish", ActorID: "u-1", EnvironmentKey: "production", OccurredAt: at}
+
+	if !(Query{EntityType: "content", ActorID: "u-1", From: at, To: at.Add(time.Second)}).Matches(event) {
+		t.Fatalf("expected query to match")
+	}
+	if (Query{To: at}).Matches(event) {
+		t.Fatalf("expected To to be exclusive")
+	}
+	if (Query{EnvironmentKey: "staging"}).Matches(event) {
+		t.Fatalf("expected environment filter to reject event")
+	}
+	if (Query{}).EffectiveLimit() != DefaultLimit || (Query{Limit: MaxLimit + 1}).EffectiveLimit() != MaxLimit {
+		t.Fatalf("unexpected effective limits")
+	}
+	if _, err := ParseFormat("NDJSON"); err != nil {
+		t.Fatalf("expected ndjson to parse: %v", err)
+	}
+	if _, err := ParseFormat("xml"); !errors.Is(err, ErrFormatUnsupported) {
+		t.Fatalf("expected ErrFormatUnsupported, got %v", err)
+	}
+}
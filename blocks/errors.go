@@ -23,6 +23,7 @@ var (
 	ErrInstancePositionInvalid       = errors.New("blocks: position cannot be negative")
 	ErrInstanceUpdaterRequired       = errors.New("blocks: updated_by is required")
 	ErrInstanceSoftDeleteUnsupported = errors.New("blocks: soft delete not supported for instances")
+	ErrInstanceNotTrashed            = errors.New("blocks: instance not found in trash")
 
 	ErrTranslationContentRequired       = errors.New("blocks: translation content required")
 	ErrTranslationExists                = errors.New("blocks: translation already exists for locale")
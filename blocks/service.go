@@ -25,6 +25,9 @@ type Service interface {
 	ListGlobalInstances(ctx context.Context) ([]*Instance, error)
 	UpdateInstance(ctx context.Context, input UpdateInstanceInput) (*Instance, error)
 	DeleteInstance(ctx context.Context, req DeleteInstanceRequest) error
+	ListTrashedInstances(ctx context.Context, req ListTrashedInstancesRequest) ([]*Instance, error)
+	RestoreDeletedInstance(ctx context.Context, req RestoreInstanceRequest) (*Instance, error)
+	RestoreInstanceSnapshot(ctx context.Context, req RestoreInstanceSnapshotRequest) (*Instance, error)
 
 	AddTranslation(ctx context.Context, input AddTranslationInput) (*Translation, error)
 	UpdateTranslation(ctx context.Context, input UpdateTranslationInput) (*Translation, error)
@@ -68,6 +71,9 @@ type UpdateDefinitionInput struct {
 	EditorStyleURL   *string
 	FrontendStyleURL *string
 	EnvironmentKey   *string
+	// Migration migrates payloads from the previous schema version. When nil
+	// and the schema version changes, suggested operations are stored.
+	Migration []SchemaMigrationOperation
 }
 
 // CreateDefinitionVersionInput captures schema version updates for a definition.
@@ -75,6 +81,9 @@ type CreateDefinitionVersionInput struct {
 	DefinitionID uuid.UUID
 	Schema       map[string]any
 	Defaults     map[string]any
+	// Migration migrates payloads from the previous schema version. When nil,
+	// suggested operations are stored.
+	Migration []SchemaMigrationOperation
 }
 
 // DeleteDefinitionRequest captures block definition deletion inputs.
@@ -113,6 +122,30 @@ type DeleteInstanceRequest struct {
 	HardDelete bool
 }
 
+// ListTrashedInstancesRequest pages through the trashed instances of an
+// environment. An empty Environment selects the default environment and a zero
+// Limit returns every remaining instance.
+type ListTrashedInstancesRequest struct {
+	Environment string
+	Limit       int
+	Offset      int
+}
+
+// RestoreInstanceRequest brings a soft-deleted instance and its translations back from the trash.
+type RestoreInstanceRequest struct {
+	ID         uuid.UUID
+	RestoredBy uuid.UUID
+}
+
+// RestoreInstanceSnapshotRequest recreates an instance from a previously
+// captured copy, keeping its ID, translations and versions. A live instance
+// with the same ID is replaced.
+type RestoreInstanceSnapshotRequest struct {
+	Instance   *Instance
+	Versions   []*InstanceVersion
+	RestoredBy uuid.UUID
+}
+
 // AddTranslationInput captures localized content additions.
 type AddTranslationInput struct {
 	BlockInstanceID    uuid.UUID
@@ -42,10 +42,16 @@ type DefinitionVersion struct {
 	SchemaVersion string         `bun:"schema_version,notnull" json:"schema_version"`
 	Schema        map[string]any `bun:"schema,type:jsonb,notnull" json:"schema"`
 	Defaults      map[string]any `bun:"defaults,type:jsonb" json:"defaults,omitempty"`
-	CreatedAt     time.Time      `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
-	UpdatedAt     time.Time      `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+	// Migration lists the operations that migrate payloads from the previous
+	// schema version to this one.
+	Migration []SchemaMigrationOperation `bun:"migration,type:jsonb" json:"migration,omitempty"`
+	CreatedAt time.Time                  `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time                  `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
 }
 
+// SchemaMigrationOperation is a declarative payload migration step.
+type SchemaMigrationOperation = domain.SchemaMigrationOperation
+
 // Instance captures a concrete usage of a block definition on a page or region.
 type Instance struct {
 	bun.BaseModel `bun:"table:block_instances,alias:bi"`
@@ -66,6 +72,10 @@ type Instance struct {
 	DeletedAt        *time.Time     `bun:"deleted_at,nullzero" json:"deleted_at,omitempty"`
 	CreatedAt        time.Time      `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
 	UpdatedAt        time.Time      `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+	// Experiment and Variant identify the experiment variant overlaid on
+	// Configuration when a page was read for a visitor bucketed into a variant.
+	Experiment string `bun:"-" json:"experiment,omitempty"`
+	Variant    string `bun:"-" json:"variant,omitempty"`
 
 	Definition   *Definition        `bun:"rel:belongs-to,join:definition_id=id" json:"definition,omitempty"`
 	Translations []*Translation     `bun:"rel:has-many,join:id=block_instance_id" json:"translations,omitempty"`
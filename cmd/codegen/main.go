@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms"
+	"github.com/goliatone/go-cms/internal/codegen"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+// sourceOptions selects where schemas are read from.
+type sourceOptions struct {
+	BundlePath string
+	Driver     string
+	DSN        string
+	Env        string
+}
+
+// sourceLoader loads the schema bundle; tests replace it with a stub.
+var sourceLoader = loadBundle
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		log.Fatalf("codegen: %v", err)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("codegen", flag.ContinueOnError)
+	bundlePath := fs.String("bundle", "", "Read schemas from an exported bundle file")
+	driver := fs.String("driver", "", "Database driver used to read schemas (sqlite3, postgres)")
+	dsn := fs.String("dsn", "", "Database DSN used to read schemas")
+	env := fs.String("env", "", "Environment key to read schemas from")
+	exportPath := fs.String("export-bundle", "", "Write the schema bundle as JSON to this path")
+	goPath := fs.String("go", "", "Write generated Go code to this path")
+	pkg := fs.String("package", "cmstypes", "Package name of the generated Go code")
+	tsPath := fs.String("ts", "", "Write generated TypeScript declarations to this path")
+	check := fs.Bool("check", false, "Fail when generated files differ from the files on disk instead of writing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := sourceOptions{
+		BundlePath: strings.TrimSpace(*bundlePath),
+		Driver:     strings.ToLower(strings.TrimSpace(*driver)),
+		DSN:        strings.TrimSpace(*dsn),
+		Env:        strings.TrimSpace(*env),
+	}
+	if (opts.BundlePath == "") == (opts.DSN == "") {
+		return errors.New("exactly one of bundle or dsn is required")
+	}
+	outputs := map[string]func(*codegen.Bundle) ([]byte, error){}
+	if path := strings.TrimSpace(*exportPath); path != "" {
+		outputs[path] = codegen.MarshalBundle
+	}
+	if path := strings.TrimSpace(*goPath); path != "" {
+		outputs[path] = func(bundle *codegen.Bundle) ([]byte, error) {
+			return codegen.GenerateGo(bundle, codegen.GoOptions{Package: *pkg})
+		}
+	}
+	if path := strings.TrimSpace(*tsPath); path != "" {
+		outputs[path] = codegen.GenerateTypeScript
+	}
+	if len(outputs) == 0 {
+		return errors.New("at least one of go, ts or export-bundle is required")
+	}
+
+	bundle, err := sourceLoader(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, path := range sortedKeys(outputs) {
+		generated, err := outputs[path](bundle)
+		if err != nil {
+			return fmt.Errorf("generate %s: %w", path, err)
+		}
+		if *check {
+			current, err := os.ReadFile(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			if !bytes.Equal(current, generated) {
+				stale = append(stale, path)
+			}
+			continue
+		}
+		if err := writeFile(path, generated); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "wrote %s\n", path)
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("generated files are out of date: %s", strings.Join(stale, ", "))
+	}
+	if *check {
+		fmt.Fprintln(out, "generated files are up to date")
+	}
+	return nil
+}
+
+func loadBundle(ctx context.Context, opts sourceOptions) (*codegen.Bundle, error) {
+	if opts.BundlePath != "" {
+		file, err := os.Open(opts.BundlePath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return codegen.ReadBundle(file)
+	}
+
+	db, err := openDB(opts.Driver, opts.DSN)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	cfg := cms.DefaultConfig()
+	if opts.Env != "" {
+		cfg.Features.Environments = true
+	}
+	module, err := cms.New(cfg, cms.WithBunDB(db))
+	if err != nil {
+		return nil, fmt.Errorf("initialise cms module: %w", err)
+	}
+	var env []string
+	if opts.Env != "" {
+		env = append(env, opts.Env)
+	}
+	return codegen.Collect(ctx, module.ContentTypes(), module.Blocks(), env...)
+}
+
+func openDB(driver, dsn string) (*bun.DB, error) {
+	switch driver {
+	case "", "sqlite3", "sqlite":
+		sqlDB, err := sql.Open("sqlite3", dsn)
+		if err != nil {
+			return nil, err
+		}
+		return bun.NewDB(sqlDB, sqlitedialect.New()), nil
+	case "postgres", "pg":
+		sqlDB, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, err
+		}
+		return bun.NewDB(sqlDB, pgdialect.New()), nil
+	default:
+		return nil, fmt.Errorf("unsupported driver %q", driver)
+	}
+}
+
+func writeFile(path string, data []byte) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/codegen"
+)
+
+func TestRunWritesAndChecksGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath, err := filepath.Abs(filepath.Join("..", "..", "internal", "codegen", "testdata", "bundle.json"))
+	if err != nil {
+		t.Fatalf("abs: %v", err)
+	}
+	goPath := filepath.Join(dir, "cmstypes", "cmstypes.go")
+	tsPath := filepath.Join(dir, "cmstypes.d.ts")
+	args := []string{"-bundle", bundlePath, "-go", goPath, "-ts", tsPath}
+
+	var out bytes.Buffer
+	if err := run(context.Background(), args, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	generated, err := os.ReadFile(goPath)
+	if err != nil || !strings.Contains(string(generated), "func GetArticle(") {
+		t.Fatalf("expected generated Go accessors, err %v", err)
+	}
+
+	out.Reset()
+	if err := run(context.Background(), append(args, "-check"), &out); err != nil {
+		t.Fatalf("check on fresh output: %v", err)
+	}
+	if err := os.WriteFile(tsPath, []byte("// edited\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err = run(context.Background(), append(args, "-check"), &out)
+	if err == nil || !strings.Contains(err.Error(), tsPath) || strings.Contains(err.Error(), goPath) {
+		t.Fatalf("expected stale TypeScript file reported, got %v", err)
+	}
+}
+
+func TestRunExportsBundleFromLoader(t *testing.T) {
+	original := sourceLoader
+	t.Cleanup(func() { sourceLoader = original })
+	var received sourceOptions
+	sourceLoader = func(_ context.Context, opts sourceOptions) (*codegen.Bundle, error) {
+		received = opts
+		return &codegen.Bundle{BlockDefinitions: []codegen.SchemaEntry{{Slug: "hero", Schema: map[string]any{"type": "object"}}}}, nil
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "schemas.json")
+	args := []string{"-driver", "Postgres", "-dsn", "postgres://cms", "-env", "staging", "-export-bundle", exportPath}
+	if err := run(context.Background(), args, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if received.Driver != "postgres" || received.Env != "staging" {
+		t.Fatalf("unexpected source options %+v", received)
+	}
+	file, err := os.Open(exportPath)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer file.Close()
+	bundle, err := codegen.ReadBundle(file)
+	if err != nil || len(bundle.BlockDefinitions) != 1 {
+		t.Fatalf("expected exported bundle, got %+v (%v)", bundle, err)
+	}
+}
+
+func TestRunRequiresSingleSource(t *testing.T) {
+	if err := run(context.Background(), []string{"-go", "out.go"}, &bytes.Buffer{}); err == nil {
+		t.Fatalf("expected error without a source")
+	}
+	if err := run(context.Background(), []string{"-bundle", "b.json", "-dsn", "x", "-go", "out.go"}, &bytes.Buffer{}); err == nil {
+		t.Fatalf("expected error with two sources")
+	}
+}
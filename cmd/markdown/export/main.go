@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/goliatone/go-cms/cmd/markdown/internal/bootstrap"
+	markdowncmd "github.com/goliatone/go-cms/internal/commands/markdown"
+)
+
+var moduleBuilder = bootstrap.BuildModule
+
+func main() {
+	if err := runExport(os.Args[1:]); err != nil {
+		log.Fatalf("markdown export: %v", err)
+	}
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("markdown-export", flag.ExitOnError)
+	contentDir := fs.String("content-dir", "content", "Path to the markdown content root")
+	locales := fs.String("locales", "", "Comma separated list of locales to export (defaults to every translation)")
+	localePatterns := fs.String("locale-patterns", "", "Comma separated locale=glob pairs used to name per-locale files")
+	defaultLocale := fs.String("default-locale", "en", "Default locale written without a locale prefix")
+	directory := fs.String("directory", ".", "Directory to export into, relative to the content root")
+	contentType := fs.String("content-type", "", "Only export content of this content type ID")
+	environment := fs.String("env", "", "Environment key to export content from")
+	author := fs.String("author", "", "Author ID recorded when export checksums are stored")
+	dryRun := fs.Bool("dry-run", false, "List files that would be written without touching disk or content")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	patterns, err := bootstrap.ParseLocalePatterns(*localePatterns)
+	if err != nil {
+		return fmt.Errorf("parse locale-patterns: %w", err)
+	}
+
+	module, err := moduleBuilder(bootstrap.Options{
+		ContentDir:     *contentDir,
+		Recursive:      true,
+		LocalePatterns: patterns,
+		DefaultLocale:  *defaultLocale,
+	})
+	if err != nil {
+		return fmt.Errorf("bootstrap module: %w", err)
+	}
+	if module == nil || module.Service == nil {
+		return fmt.Errorf("markdown service not configured; ensure Features.Markdown is enabled")
+	}
+
+	cmd := markdowncmd.ExportDirectoryCommand{
+		Directory:      *directory,
+		EnvironmentKey: *environment,
+		Locales:        bootstrap.SplitLocales(*locales),
+		DryRun:         *dryRun,
+	}
+	if cmd.ContentTypeID, err = bootstrap.ParseUUID(*contentType); err != nil {
+		return fmt.Errorf("parse content-type: %w", err)
+	}
+	if cmd.AuthorID, err = bootstrap.ParseUUID(*author); err != nil {
+		return fmt.Errorf("parse author: %w", err)
+	}
+
+	handler := markdowncmd.NewExportDirectoryHandler(module.Service, module.Logger, markdowncmd.FeatureGates{
+		MarkdownEnabled: func() bool { return true },
+	})
+	if err := handler.Execute(context.Background(), cmd); err != nil {
+		return fmt.Errorf("execute export command: %w", err)
+	}
+	fmt.Fprintln(os.Stdout, "markdown export command executed successfully")
+
+	return nil
+}
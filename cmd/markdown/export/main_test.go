@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-cms/cmd/markdown/internal/bootstrap"
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/google/uuid"
+)
+
+type stubMarkdownExportService struct {
+	exportCalls int
+	exportDir   string
+	options     interfaces.ExportOptions
+}
+
+func (s *stubMarkdownExportService) Load(context.Context, string, interfaces.LoadOptions) (*interfaces.Document, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) LoadDirectory(context.Context, string, interfaces.LoadOptions) ([]*interfaces.Document, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) Render(context.Context, []byte, interfaces.ParseOptions) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) RenderDocument(context.Context, *interfaces.Document, interfaces.ParseOptions) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) Import(context.Context, *interfaces.Document, interfaces.ImportOptions) (*interfaces.ImportResult, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) ImportDirectory(context.Context, string, interfaces.ImportOptions) (*interfaces.ImportResult, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) Sync(context.Context, string, interfaces.SyncOptions) (*interfaces.SyncResult, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownExportService) ExportDirectory(_ context.Context, dir string, opts interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	s.exportCalls++
+	s.exportDir = dir
+	s.options = opts
+	return &interfaces.ExportResult{}, nil
+}
+
+func TestRunExportUsesCommandHandler(t *testing.T) {
+	original := moduleBuilder
+	defer func() { moduleBuilder = original }()
+
+	svc := &stubMarkdownExportService{}
+	var captured bootstrap.Options
+	moduleBuilder = func(opts bootstrap.Options) (*bootstrap.Module, error) {
+		captured = opts
+		return &bootstrap.Module{
+			Service: svc,
+			Logger:  logging.NoOp(),
+		}, nil
+	}
+
+	contentType := uuid.New()
+	if err := runExport([]string{
+		"-directory", "docs",
+		"-content-type", contentType.String(),
+		"-locales", "en,es",
+		"-locale-patterns", "es=es/*.md",
+	}); err != nil {
+		t.Fatalf("runExport returned error: %v", err)
+	}
+	if svc.exportCalls != 1 || svc.exportDir != "docs" {
+		t.Fatalf("expected export of docs, got %d calls for %q", svc.exportCalls, svc.exportDir)
+	}
+	if svc.options.ContentTypeID != contentType || len(svc.options.Locales) != 2 {
+		t.Fatalf("unexpected export options %+v", svc.options)
+	}
+	if captured.LocalePatterns["es"] != "es/*.md" {
+		t.Fatalf("expected locale patterns forwarded, got %v", captured.LocalePatterns)
+	}
+
+	if err := runExport([]string{"-locale-patterns", "broken"}); err == nil {
+		t.Fatal("expected invalid locale pattern to fail")
+	}
+}
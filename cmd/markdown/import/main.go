@@ -33,6 +33,8 @@ func runImport(args []string) error {
 	contentType := fs.String("content-type", "", "Content type ID to associate with imported documents")
 	author := fs.String("author", "", "Author ID recorded on imported content")
 	dryRun := fs.Bool("dry-run", false, "Preview changes without persisting content")
+	createPages := fs.Bool("create-pages", false, "Create pages for imported documents, nested by directory")
+	template := fs.String("template", "", "Template ID used for pages whose frontmatter does not name a template")
 
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -59,7 +61,8 @@ func runImport(args []string) error {
 	ctx := context.Background()
 
 	importOpts := interfaces.ImportOptions{
-		DryRun: *dryRun,
+		DryRun:      *dryRun,
+		CreatePages: *createPages,
 	}
 
 	if id, err := bootstrap.ParseUUID(*contentType); err != nil {
@@ -77,6 +80,12 @@ func runImport(args []string) error {
 		importOpts.AuthorID = id
 	}
 
+	if id, err := bootstrap.ParseUUID(*template); err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	} else {
+		importOpts.TemplateID = id
+	}
+
 	handler := markdowncmd.NewImportDirectoryHandler(module.Service, module.Logger, markdowncmd.FeatureGates{
 		MarkdownEnabled: func() bool { return true },
 	})
@@ -85,6 +94,8 @@ func runImport(args []string) error {
 		ContentTypeID: importOpts.ContentTypeID,
 		AuthorID:      importOpts.AuthorID,
 		DryRun:        importOpts.DryRun,
+		CreatePages:   importOpts.CreatePages,
+		TemplateID:    importOpts.TemplateID,
 	}
 	if err := handler.Execute(ctx, cmd); err != nil {
 		return fmt.Errorf("execute import command: %w", err)
@@ -45,6 +45,14 @@ func (s *stubMarkdownService) Sync(context.Context, string, interfaces.SyncOptio
 	return nil, nil
 }
 
+func (*stubMarkdownService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (*stubMarkdownService) ExportDirectory(context.Context, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
 func TestRunImportUsesCommandHandler(t *testing.T) {
 	original := moduleBuilder
 	defer func() { moduleBuilder = original }()
@@ -107,6 +107,26 @@ func SplitLocales(value string) []string {
 	return locales
 }
 
+// ParseLocalePatterns parses a comma separated list of locale=glob pairs.
+func ParseLocalePatterns(value string) (map[string]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	patterns := map[string]string{}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		locale, pattern, ok := strings.Cut(part, "=")
+		locale, pattern = strings.TrimSpace(locale), strings.TrimSpace(pattern)
+		if !ok || locale == "" || pattern == "" {
+			return nil, fmt.Errorf("invalid locale pattern %q (expected locale=glob)", part)
+		}
+		patterns[locale] = pattern
+	}
+	return patterns, nil
+}
+
 // ParseUUID converts the supplied string into a UUID, returning uuid.Nil when the input is empty.
 func ParseUUID(value string) (uuid.UUID, error) {
 	trimmed := strings.TrimSpace(value)
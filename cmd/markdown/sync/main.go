@@ -4,11 +4,17 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/goliatone/go-cms/cmd/markdown/internal/bootstrap"
 	markdowncmd "github.com/goliatone/go-cms/internal/commands/markdown"
+	"github.com/goliatone/go-cms/markdown"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
 )
@@ -33,8 +39,14 @@ func runSync(args []string) error {
 	contentType := fs.String("content-type", "", "Content type ID to associate with imported documents")
 	author := fs.String("author", "", "Author ID recorded on imported content")
 	dryRun := fs.Bool("dry-run", false, "Preview changes without persisting content")
+	createPages := fs.Bool("create-pages", false, "Create pages for imported documents, nested by directory")
+	template := fs.String("template", "", "Template ID used for pages whose frontmatter does not name a template")
 	deleteOrphans := fs.Bool("delete-orphaned", false, "Delete CMS content that no longer has matching markdown files")
 	updateExisting := fs.Bool("update-existing", true, "Update CMS entries when markdown documents change")
+	watch := fs.Bool("watch", false, "Keep running and re-import translation groups whose files change")
+	interval := fs.Duration("interval", time.Second, "Polling interval used in watch mode")
+	debounce := fs.Duration("debounce", 300*time.Millisecond, "How long changes must settle before a watch sync runs")
+	buildPages := fs.Bool("build-pages", false, "Rebuild static pages for affected documents in watch mode")
 
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -61,7 +73,8 @@ func runSync(args []string) error {
 	ctx := context.Background()
 
 	importOpts := interfaces.ImportOptions{
-		DryRun: *dryRun,
+		DryRun:      *dryRun,
+		CreatePages: *createPages,
 	}
 
 	if id, err := bootstrap.ParseUUID(*contentType); err != nil {
@@ -79,12 +92,44 @@ func runSync(args []string) error {
 		importOpts.AuthorID = id
 	}
 
+	if id, err := bootstrap.ParseUUID(*template); err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	} else {
+		importOpts.TemplateID = id
+	}
+
 	syncOpts := interfaces.SyncOptions{
 		ImportOptions:  importOpts,
 		DeleteOrphaned: *deleteOrphans,
 		UpdateExisting: *updateExisting,
 	}
 
+	if *watch {
+		watcher, ok := module.Service.(markdownWatcher)
+		if !ok {
+			return fmt.Errorf("markdown service does not support watch mode")
+		}
+		watchOpts := markdown.WatchOptions{
+			Sync:     syncOpts,
+			Interval: *interval,
+			Debounce: *debounce,
+			OnChange: func(report markdown.WatchReport) { printWatchReport(os.Stdout, report) },
+		}
+		if *buildPages {
+			if module.Module == nil {
+				return fmt.Errorf("build-pages requires the generator service")
+			}
+			watchOpts.Builder = module.Module.Generator()
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		fmt.Fprintf(os.Stdout, "watching %s (interval %s, debounce %s)\n", *directory, *interval, *debounce)
+		if err := watcher.Watch(ctx, *directory, watchOpts); err != nil {
+			return fmt.Errorf("watch: %w", err)
+		}
+		return nil
+	}
+
 	handler := markdowncmd.NewSyncDirectoryHandler(module.Service, module.Logger, markdowncmd.FeatureGates{
 		MarkdownEnabled: func() bool { return true },
 	})
@@ -93,6 +138,8 @@ func runSync(args []string) error {
 		ContentTypeID:  importOpts.ContentTypeID,
 		AuthorID:       importOpts.AuthorID,
 		DryRun:         importOpts.DryRun,
+		CreatePages:    importOpts.CreatePages,
+		TemplateID:     importOpts.TemplateID,
 		DeleteOrphaned: syncOpts.DeleteOrphaned,
 		UpdateExisting: syncOpts.UpdateExisting,
 	}
@@ -103,3 +150,30 @@ func runSync(args []string) error {
 
 	return nil
 }
+
+// markdownWatcher is implemented by the markdown service when watch mode is available.
+type markdownWatcher interface {
+	Watch(ctx context.Context, dir string, opts markdown.WatchOptions) error
+}
+
+// printWatchReport writes one line per changed file followed by a summary line.
+func printWatchReport(w io.Writer, report markdown.WatchReport) {
+	stamp := report.StartedAt.Format(time.RFC3339)
+	for _, change := range report.Changes {
+		fmt.Fprintf(w, "%s %-8s %s slug=%s\n", stamp, change.Op, change.Path, change.Slug)
+	}
+	kind := "sync"
+	if report.Initial {
+		kind = "initial"
+	}
+	summary := fmt.Sprintf("%s %s slugs=[%s]", stamp, kind, strings.Join(report.Slugs, ","))
+	if report.Result != nil {
+		summary += fmt.Sprintf(" created=%d updated=%d deleted=%d skipped=%d",
+			report.Result.Created, report.Result.Updated, report.Result.Deleted, report.Result.Skipped)
+	}
+	summary += fmt.Sprintf(" built=%d errors=%d duration=%s", len(report.BuiltPages), len(report.Errors), report.Duration.Round(time.Millisecond))
+	fmt.Fprintln(w, summary)
+	for _, err := range report.Errors {
+		fmt.Fprintf(w, "%s error %v\n", stamp, err)
+	}
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/goliatone/go-cms/cmd/markdown/internal/bootstrap"
 	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/markdown"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
 )
@@ -45,6 +50,14 @@ func (s *stubMarkdownSyncService) Sync(_ context.Context, dir string, _ interfac
 	return &interfaces.SyncResult{}, nil
 }
 
+func (*stubMarkdownSyncService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (*stubMarkdownSyncService) ExportDirectory(context.Context, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
 func TestRunSyncUsesCommandHandler(t *testing.T) {
 	original := moduleBuilder
 	defer func() { moduleBuilder = original }()
@@ -71,3 +84,72 @@ func TestRunSyncUsesCommandHandler(t *testing.T) {
 		t.Fatalf("expected sync directory docs, got %s", svc.syncDir)
 	}
 }
+
+type stubMarkdownWatchService struct {
+	stubMarkdownSyncService
+	watchDir  string
+	watchOpts markdown.WatchOptions
+}
+
+func (s *stubMarkdownWatchService) Watch(_ context.Context, dir string, opts markdown.WatchOptions) error {
+	s.watchDir = dir
+	s.watchOpts = opts
+	return nil
+}
+
+func TestRunSyncWatchModeUsesWatcher(t *testing.T) {
+	original := moduleBuilder
+	defer func() { moduleBuilder = original }()
+
+	svc := &stubMarkdownWatchService{}
+	moduleBuilder = func(bootstrap.Options) (*bootstrap.Module, error) {
+		return &bootstrap.Module{
+			Service: svc,
+			Logger:  logging.NoOp(),
+		}, nil
+	}
+
+	if err := runSync([]string{
+		"-directory", "docs",
+		"-content-type", uuid.New().String(),
+		"-watch",
+		"-interval", "2s",
+		"-debounce", "50ms",
+		"-delete-orphaned",
+	}); err != nil {
+		t.Fatalf("runSync returned error: %v", err)
+	}
+	if svc.syncCalls != 0 {
+		t.Fatalf("expected one-shot sync skipped in watch mode")
+	}
+	if svc.watchDir != "docs" || svc.watchOpts.Interval != 2*time.Second || svc.watchOpts.Debounce != 50*time.Millisecond {
+		t.Fatalf("unexpected watch arguments %s %#v", svc.watchDir, svc.watchOpts)
+	}
+	if !svc.watchOpts.Sync.DeleteOrphaned || svc.watchOpts.OnChange == nil {
+		t.Fatalf("expected sync options and change log wired, got %#v", svc.watchOpts)
+	}
+}
+
+func TestPrintWatchReportListsChanges(t *testing.T) {
+	var buf bytes.Buffer
+	printWatchReport(&buf, markdown.WatchReport{
+		StartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Changes: []markdown.FileChange{
+			{Path: "en/about.md", Op: markdown.FileModified, Slug: "about"},
+		},
+		Slugs:      []string{"about"},
+		Result:     &interfaces.SyncResult{Updated: 1},
+		BuiltPages: []uuid.UUID{uuid.New()},
+		Errors:     []error{errors.New("boom")},
+	})
+	out := buf.String()
+	for _, want := range []string{
+		"modified en/about.md slug=about",
+		"sync slugs=[about] created=0 updated=1 deleted=0 skipped=0 built=1 errors=1",
+		"error boom",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/goliatone/go-cms"
+	staticcmd "github.com/goliatone/go-cms/internal/commands/static"
+	"github.com/goliatone/go-cms/internal/di"
+	"github.com/goliatone/go-cms/internal/generator"
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	command "github.com/goliatone/go-command"
+	"github.com/google/uuid"
+)
+
+var moduleBuilder = buildModule
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatalf("static: %v", err)
+	}
+}
+
+// moduleOptions captures the configuration shared by every subcommand.
+type moduleOptions struct {
+	OutputDir           string
+	BaseURL             string
+	TranslationsEnabled *bool
+	RequireTranslations *bool
+	// Storage overrides the generator storage provider; serve uses memory.
+	Storage interfaces.StorageProvider
+	// Hooks receive lifecycle events emitted by the CMS services.
+	Hooks lifecycle.Hooks
+	// Sites enables the sites feature for per-site builds.
+	Sites bool
+}
+
+// moduleResources exposes the command handlers and the module backing them.
+type moduleResources struct {
+	module   *cms.Module
+	handlers handlerSet
+}
+
+type handlerSet struct {
+	build   command.Commander[staticcmd.BuildSiteCommand]
+	diff    command.Commander[staticcmd.DiffSiteCommand]
+	clean   command.Commander[staticcmd.CleanSiteCommand]
+	sitemap command.Commander[staticcmd.BuildSitemapCommand]
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing subcommand (build, diff, clean, sitemap, serve)")
+	}
+	switch args[0] {
+	case "build":
+		return runBuild(args[1:])
+	case "diff":
+		return runDiff(args[1:])
+	case "clean":
+		return runClean(args[1:])
+	case "sitemap":
+		return runSitemap(args[1:])
+	case "serve":
+		return runServe(args[1:])
+	default:
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+}
+
+type commonFlags struct {
+	output              *string
+	baseURL             *string
+	translationsEnabled *bool
+	requireTranslations *bool
+}
+
+func registerCommonFlags(fs *flag.FlagSet) commonFlags {
+	return commonFlags{
+		output:              fs.String("output", "", "Output directory (defaults to config value)"),
+		baseURL:             fs.String("base-url", "", "Site base URL (defaults to config value)"),
+		translationsEnabled: fs.Bool("translations-enabled", true, "Enable translations (set false for monolingual mode)"),
+		requireTranslations: fs.Bool("require-translations", true, "Require at least one translation when translations are enabled"),
+	}
+}
+
+func (f commonFlags) options() moduleOptions {
+	return moduleOptions{
+		OutputDir:           *f.output,
+		BaseURL:             *f.baseURL,
+		TranslationsEnabled: f.translationsEnabled,
+		RequireTranslations: f.requireTranslations,
+	}
+}
+
+func runBuild(args []string) error {
+	fs := flag.NewFlagSet("static-build", flag.ContinueOnError)
+	common := registerCommonFlags(fs)
+	pages := fs.String("page", "", "Comma separated page UUIDs to build")
+	locales := fs.String("locale", "", "Comma separated locales to include")
+	force := fs.Bool("force", false, "Force rebuild (ignore manifest cache)")
+	dryRun := fs.Bool("dry-run", false, "Execute without writing artifacts")
+	assets := fs.Bool("assets", false, "Copy theme assets only")
+	site := fs.String("site", "", "Site key to build (output goes to <output>/<site>)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pageIDs, err := parsePageIDs(*pages)
+	if err != nil {
+		return err
+	}
+	options := common.options()
+	options.Sites = strings.TrimSpace(*site) != ""
+	resources, err := moduleBuilder(options)
+	if err != nil {
+		return fmt.Errorf("bootstrap module: %w", err)
+	}
+	if resources == nil || resources.handlers.build == nil {
+		return errors.New("build handler not configured")
+	}
+	return resources.handlers.build.Execute(context.Background(), staticcmd.BuildSiteCommand{
+		PageIDs:        pageIDs,
+		Locales:        splitList(*locales),
+		Force:          *force,
+		DryRun:         *dryRun,
+		AssetsOnly:     *assets,
+		Site:           strings.TrimSpace(*site),
+		ResultCallback: logResult,
+	})
+}
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("static-diff", flag.ContinueOnError)
+	common := registerCommonFlags(fs)
+	pages := fs.String("page", "", "Comma separated page UUIDs to diff")
+	locales := fs.String("locale", "", "Comma separated locales to include")
+	force := fs.Bool("force", false, "Ignore the manifest cache when comparing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pageIDs, err := parsePageIDs(*pages)
+	if err != nil {
+		return err
+	}
+	resources, err := moduleBuilder(common.options())
+	if err != nil {
+		return fmt.Errorf("bootstrap module: %w", err)
+	}
+	if resources == nil || resources.handlers.diff == nil {
+		return errors.New("diff handler not configured")
+	}
+	return resources.handlers.diff.Execute(context.Background(), staticcmd.DiffSiteCommand{
+		PageIDs:        pageIDs,
+		Locales:        splitList(*locales),
+		Force:          *force,
+		ResultCallback: logResult,
+	})
+}
+
+func runClean(args []string) error {
+	fs := flag.NewFlagSet("static-clean", flag.ContinueOnError)
+	common := registerCommonFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resources, err := moduleBuilder(common.options())
+	if err != nil {
+		return fmt.Errorf("bootstrap module: %w", err)
+	}
+	if resources == nil || resources.handlers.clean == nil {
+		return errors.New("clean handler not configured")
+	}
+	if err := resources.handlers.clean.Execute(context.Background(), staticcmd.CleanSiteCommand{}); err != nil {
+		return err
+	}
+	log.Printf("module=static operation=clean status=ok")
+	return nil
+}
+
+func runSitemap(args []string) error {
+	fs := flag.NewFlagSet("static-sitemap", flag.ContinueOnError)
+	common := registerCommonFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resources, err := moduleBuilder(common.options())
+	if err != nil {
+		return fmt.Errorf("bootstrap module: %w", err)
+	}
+	if resources == nil || resources.handlers.sitemap == nil {
+		return errors.New("sitemap handler not configured")
+	}
+	return resources.handlers.sitemap.Execute(context.Background(), staticcmd.BuildSitemapCommand{
+		ResultCallback: logResult,
+	})
+}
+
+// runServe builds the site into memory and serves it with live reload until
+// interrupted. CMS changes rebuild affected pages through lifecycle hooks and
+// watched theme directories are polled for template and asset edits.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("static-serve", flag.ContinueOnError)
+	common := registerCommonFlags(fs)
+	addr := fs.String("addr", "127.0.0.1:8080", "Address the development server listens on")
+	watch := fs.String("watch", "", "Comma separated theme directories polled for template and asset changes")
+	interval := fs.Duration("interval", 500*time.Millisecond, "Polling interval for watched directories")
+	debounce := fs.Duration("debounce", 300*time.Millisecond, "How long CMS changes settle before a rebuild runs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	storage := generator.NewMemoryStorage()
+	var active atomic.Pointer[generator.DevServer]
+	opts := common.options()
+	opts.Storage = storage
+	opts.Hooks = lifecycle.Hooks{lifecycle.HookFunc(func(ctx context.Context, event lifecycle.Event) error {
+		if server := active.Load(); server != nil {
+			return server.Notify(ctx, event)
+		}
+		return nil
+	})}
+
+	resources, err := moduleBuilder(opts)
+	if err != nil {
+		return fmt.Errorf("bootstrap module: %w", err)
+	}
+	if resources == nil || resources.module == nil || resources.module.Generator() == nil {
+		return errors.New("generator service not configured")
+	}
+	cfg := resources.module.Container().Config
+	server := generator.NewDevServer(resources.module.Generator(), storage,
+		generator.WithDevServerOutputDir(cfg.Generator.OutputDir),
+		generator.WithDevServerWatch(splitList(*watch)...),
+		generator.WithDevServerPollInterval(*interval),
+		generator.WithDevServerRebuildDebounce(*debounce),
+		generator.WithDevServerLogger(logging.GeneratorLogger(resources.module.Container().LoggerProvider())),
+	)
+	active.Store(server)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	result, err := server.Build(ctx, generator.BuildOptions{Force: true})
+	logResult(staticcmd.ResultEnvelope{Result: result, Metadata: map[string]any{"operation": "serve"}})
+	if err != nil {
+		// Render failures are shown in the browser overlay; keep serving.
+		log.Printf("module=static operation=serve err=%v", err)
+	}
+
+	go func() {
+		if err := server.Watch(ctx); err != nil {
+			log.Printf("module=static operation=serve watch_err=%v", err)
+		}
+	}()
+
+	httpServer := &http.Server{Addr: *addr, Handler: server, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Close(shutdownCtx)
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("module=static operation=serve addr=http://%s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
+
+func buildModule(opts moduleOptions) (*moduleResources, error) {
+	cfg := cms.DefaultConfig()
+	cfg.Generator.Enabled = true
+	if output := strings.TrimSpace(opts.OutputDir); output != "" {
+		cfg.Generator.OutputDir = output
+	}
+	if baseURL := strings.TrimSpace(opts.BaseURL); baseURL != "" {
+		cfg.Generator.BaseURL = baseURL
+	}
+	if opts.TranslationsEnabled != nil {
+		cfg.I18N.Enabled = *opts.TranslationsEnabled
+	}
+	if opts.RequireTranslations != nil {
+		cfg.I18N.RequireTranslations = *opts.RequireTranslations
+	}
+	cfg.Features.Sites = opts.Sites
+
+	diOpts := []di.Option{}
+	if opts.Storage != nil {
+		diOpts = append(diOpts, di.WithGeneratorStorage(opts.Storage))
+	}
+	if len(opts.Hooks) > 0 {
+		diOpts = append(diOpts, di.WithLifecycleHooks(opts.Hooks))
+	}
+
+	module, err := cms.New(cfg, diOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("initialise cms module: %w", err)
+	}
+
+	service := module.Generator()
+	logger := logging.GeneratorLogger(module.Container().LoggerProvider())
+	gates := staticcmd.FeatureGates{
+		GeneratorEnabled: func() bool { return cfg.Generator.Enabled },
+		SitemapEnabled:   func() bool { return cfg.Generator.GenerateSitemap },
+	}
+
+	return &moduleResources{
+		module: module,
+		handlers: handlerSet{
+			build:   staticcmd.NewBuildSiteHandler(service, logger, gates),
+			diff:    staticcmd.NewDiffSiteHandler(service, logger, gates),
+			clean:   staticcmd.NewCleanSiteHandler(service, logger, gates),
+			sitemap: staticcmd.NewBuildSitemapHandler(service, logger, gates),
+		},
+	}, nil
+}
+
+// logResult prints the build summary and per-page diagnostics of a command.
+func logResult(envelope staticcmd.ResultEnvelope) {
+	operation, _ := envelope.Metadata["operation"].(string)
+	if operation == "" {
+		operation = "build"
+	}
+	result := envelope.Result
+	if result == nil {
+		fields := []string{}
+		for _, key := range []string{"page_id", "locale"} {
+			if value, ok := envelope.Metadata[key]; ok {
+				fields = append(fields, fmt.Sprintf("%s=%v", key, value))
+			}
+		}
+		log.Printf("module=static operation=%s %s", operation, strings.Join(fields, " "))
+		return
+	}
+
+	metrics := result.Metrics
+	log.Printf("module=static operation=%s summary pages_built=%d pages_skipped=%d assets_built=%d assets_skipped=%d duration=%s dry_run=%t context_ms=%d render_ms=%d persist_ms=%d assets_ms=%d sitemap_ms=%d pages_per_sec=%.2f assets_per_sec=%.2f",
+		operation, result.PagesBuilt, result.PagesSkipped, result.AssetsBuilt, result.AssetsSkipped,
+		result.Duration, result.DryRun,
+		metrics.ContextDuration.Milliseconds(), metrics.RenderDuration.Milliseconds(),
+		metrics.PersistDuration.Milliseconds(), metrics.AssetDuration.Milliseconds(),
+		metrics.SitemapDuration.Milliseconds(), metrics.PagesPerSecond, metrics.AssetsPerSecond)
+
+	for _, diagnostic := range result.Diagnostics {
+		status := "ok"
+		if diagnostic.Skipped {
+			status = "skipped"
+		}
+		if diagnostic.Err != nil {
+			status = "error"
+		}
+		log.Printf("module=static operation=%s page=%s locale=%s route=%s template=%s status=%s",
+			operation, diagnostic.PageID, diagnostic.Locale, diagnostic.Route, diagnostic.Template, status)
+		if diagnostic.Err != nil {
+			log.Printf("module=static operation=%s page=%s locale=%s err=%v",
+				operation, diagnostic.PageID, diagnostic.Locale, diagnostic.Err)
+		}
+	}
+}
+
+func parsePageIDs(value string) ([]uuid.UUID, error) {
+	parts := splitList(value)
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("parse page %q: %w", part, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	return values
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms"
+	"github.com/goliatone/go-cms/wxr"
+	"github.com/google/uuid"
+)
+
+type wxrImporter interface {
+	Import(ctx context.Context, export *wxr.Export, opts wxr.Options) (*wxr.Report, error)
+}
+
+// importerBuilder constructs the importer; tests replace it with a stub.
+var importerBuilder = func(locale string, progress wxr.ProgressStore) (wxrImporter, error) {
+	cfg := cms.DefaultConfig()
+	cfg.DefaultLocale = locale
+	cfg.I18N.Locales = []string{locale}
+	module, err := cms.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("initialise cms module: %w", err)
+	}
+	return module.WXRImporter(progress), nil
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := runImport(ctx, os.Args[1:], os.Stdout); err != nil {
+		log.Fatalf("wxr import: %v", err)
+	}
+}
+
+func runImport(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("wxr-import", flag.ExitOnError)
+	file := fs.String("file", "", "Path to the WordPress WXR export")
+	locale := fs.String("locale", "en", "Locale assigned to imported translations")
+	types := fs.String("types", "", "Comma separated post_type=content-type-id mappings, e.g. post=<uuid>,page=<uuid>")
+	pageTypes := fs.String("page-types", "page", "Comma separated post types imported as pages")
+	template := fs.String("template", "", "Template ID used for imported pages")
+	authors := fs.String("authors", "", "Comma separated login=actor-id author mappings")
+	defaultAuthor := fs.String("default-author", "", "Actor ID used for unmapped authors and menus")
+	env := fs.String("env", "", "Environment key to import into")
+	progressPath := fs.String("progress", "", "Progress file used to resume interrupted imports")
+	reportPath := fs.String("report", "", "Write the mapping report as JSON to this path")
+	dryRun := fs.Bool("dry-run", false, "Report the mapping without writing content")
+	skipMenus := fs.Bool("skip-menus", false, "Do not import navigation menus")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*file) == "" {
+		return fmt.Errorf("file is required")
+	}
+
+	opts := wxr.Options{
+		PageTypes:      splitList(*pageTypes),
+		Locale:         strings.TrimSpace(*locale),
+		EnvironmentKey: strings.TrimSpace(*env),
+		SkipMenus:      *skipMenus,
+		DryRun:         *dryRun,
+	}
+	var err error
+	if opts.ContentTypes, err = parseMappings(*types); err != nil {
+		return fmt.Errorf("parse types: %w", err)
+	}
+	if len(opts.ContentTypes) == 0 {
+		return fmt.Errorf("types is required")
+	}
+	if opts.Authors, err = parseMappings(*authors); err != nil {
+		return fmt.Errorf("parse authors: %w", err)
+	}
+	if opts.TemplateID, err = parseUUID(*template); err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+	if opts.DefaultAuthor, err = parseUUID(*defaultAuthor); err != nil {
+		return fmt.Errorf("parse default-author: %w", err)
+	}
+
+	source, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	export, err := wxr.Parse(source)
+	if err != nil {
+		return err
+	}
+
+	var progress wxr.ProgressStore
+	if path := strings.TrimSpace(*progressPath); path != "" {
+		progress = wxr.NewFileProgressStore(path)
+	}
+	importer, err := importerBuilder(opts.Locale, progress)
+	if err != nil {
+		return err
+	}
+
+	report, importErr := importer.Import(ctx, export, opts)
+	if report != nil {
+		printReport(out, report)
+		if path := strings.TrimSpace(*reportPath); path != "" {
+			if err := writeReport(path, report); err != nil {
+				return err
+			}
+		}
+	}
+	return importErr
+}
+
+func printReport(w io.Writer, report *wxr.Report) {
+	fmt.Fprintf(w, "items=%d created=%d updated=%d resumed=%d skipped=%d unmapped=%d failed=%d attachments=%d\n",
+		len(report.Items),
+		report.Count(wxr.ActionCreated),
+		report.Count(wxr.ActionUpdated),
+		report.Count(wxr.ActionResumed),
+		report.Count(wxr.ActionSkipped),
+		report.Count(wxr.ActionUnmapped),
+		report.Count(wxr.ActionFailed),
+		report.Attachments,
+	)
+	for _, typ := range sortedKeys(report.UnmappedTypes) {
+		fmt.Fprintf(w, "unmapped type %s items=%d\n", typ, report.UnmappedTypes[typ])
+	}
+	for _, login := range report.UnmappedAuthors {
+		fmt.Fprintf(w, "unmapped author %s\n", login)
+	}
+	for _, code := range sortedKeys(report.Menus) {
+		fmt.Fprintf(w, "menu %s items=%d\n", code, report.Menus[code])
+	}
+	for _, item := range report.Items {
+		if item.Action == wxr.ActionFailed {
+			fmt.Fprintf(w, "failed %s %d %s: %s\n", item.Type, item.WordPressID, item.Slug, item.Error)
+		}
+	}
+}
+
+func writeReport(path string, report *wxr.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
+
+func parseMappings(value string) (map[string]uuid.UUID, error) {
+	out := map[string]uuid.UUID{}
+	for _, pair := range splitList(value) {
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid mapping %q, expected key=uuid", pair)
+		}
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("mapping %q: %w", pair, err)
+		}
+		out[strings.TrimSpace(key)] = id
+	}
+	return out, nil
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return uuid.Parse(trimmed)
+	}
+	return uuid.Nil, nil
+}
+
+func splitList(value string) []string {
+	var out []string
+	for part := range strings.SplitSeq(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-cms/wxr"
+	"github.com/google/uuid"
+)
+
+type stubImporter struct {
+	opts   wxr.Options
+	export *wxr.Export
+}
+
+func (s *stubImporter) Import(_ context.Context, export *wxr.Export, opts wxr.Options) (*wxr.Report, error) {
+	s.export, s.opts = export, opts
+	return &wxr.Report{
+		Items: []wxr.ItemReport{
+			{WordPressID: 20, Type: "post", Slug: "hello-world", Action: wxr.ActionCreated},
+			{WordPressID: 50, Type: "product", Slug: "widget", Action: wxr.ActionUnmapped},
+		},
+		UnmappedTypes:   map[string]int{"product": 1},
+		UnmappedAuthors: []string{"editor"},
+		Menus:           map[string]int{"main-menu": 2},
+		Attachments:     1,
+	}, nil
+}
+
+func TestRunImportParsesMappingsAndPrintsReport(t *testing.T) {
+	original := importerBuilder
+	defer func() { importerBuilder = original }()
+
+	stub := &stubImporter{}
+	var progressSet bool
+	importerBuilder = func(locale string, progress wxr.ProgressStore) (wxrImporter, error) {
+		progressSet = progress != nil
+		return stub, nil
+	}
+
+	postType, author := uuid.New(), uuid.New()
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	var out bytes.Buffer
+	err := runImport(context.Background(), []string{
+		"-file", filepath.Join("..", "..", "..", "internal", "wxr", "testdata", "export.xml"),
+		"-types", "post=" + postType.String(),
+		"-authors", "admin=" + author.String(),
+		"-progress", filepath.Join(t.TempDir(), "progress.json"),
+		"-report", reportPath,
+	}, &out)
+	if err != nil {
+		t.Fatalf("runImport: %v", err)
+	}
+
+	if stub.opts.ContentTypes["post"] != postType || stub.opts.Authors["admin"] != author || !progressSet {
+		t.Fatalf("unexpected options: %+v", stub.opts)
+	}
+	if stub.export == nil || len(stub.export.Items) != 8 {
+		t.Fatalf("expected parsed export to be passed through")
+	}
+	for _, want := range []string{"created=1", "unmapped type product items=1", "unmapped author editor", "menu main-menu items=2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	var decoded wxr.Report
+	if err := json.Unmarshal(data, &decoded); err != nil || len(decoded.Items) != 2 {
+		t.Fatalf("expected JSON report, got %v %s", err, data)
+	}
+}
+
+func TestRunImportRequiresTypeMappings(t *testing.T) {
+	err := runImport(context.Background(), []string{"-file", "export.xml"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "types is required") {
+		t.Fatalf("expected types error, got %v", err)
+	}
+}
@@ -1,8 +1,10 @@
 package cms
 
 import (
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/blocks"
 	"github.com/goliatone/go-cms/content"
+	"github.com/goliatone/go-cms/experiments"
 	adminblocks "github.com/goliatone/go-cms/internal/admin/blocks"
 	adminstorage "github.com/goliatone/go-cms/internal/admin/storage"
 	admintranslations "github.com/goliatone/go-cms/internal/admin/translations"
@@ -12,7 +14,10 @@ import (
 	"github.com/goliatone/go-cms/internal/themes"
 	"github.com/goliatone/go-cms/pages"
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/sites"
+	"github.com/goliatone/go-cms/webhooks"
 	"github.com/goliatone/go-cms/widgets"
+	"github.com/goliatone/go-cms/wxr"
 )
 
 // ContentService exports the content service contract for consumers of the cms package.
@@ -147,6 +152,18 @@ type BlockService = blocks.Service
 // WidgetService exports the widgets service contract.
 type WidgetService = widgets.Service
 
+// SiteService exports the sites service contract.
+type SiteService = sites.Service
+
+// ExperimentService exports the experiments service contract.
+type ExperimentService = experiments.Service
+
+// WebhookService exports the webhooks service contract.
+type WebhookService = webhooks.Service
+
+// AuditLog exports the audit log query contract.
+type AuditLog = audit.Log
+
 // ThemeService exports the themes service contract.
 type ThemeService = themes.Service
 
@@ -292,6 +309,26 @@ func (m *Module) Widgets() WidgetService {
 	return m.container.WidgetService()
 }
 
+// Sites returns the configured site service.
+func (m *Module) Sites() SiteService {
+	return m.container.SiteService()
+}
+
+// Experiments returns the configured experiment service.
+func (m *Module) Experiments() ExperimentService {
+	return m.container.ExperimentService()
+}
+
+// Webhooks returns the configured webhook service.
+func (m *Module) Webhooks() WebhookService {
+	return m.container.WebhookService()
+}
+
+// Audit returns the audit log recorded by the scheduler worker and admin services.
+func (m *Module) Audit() AuditLog {
+	return m.container.AuditLog()
+}
+
 // Shortcodes returns the configured shortcode service.
 func (m *Module) Shortcodes() interfaces.ShortcodeService {
 	if m == nil || m.container == nil {
@@ -315,11 +352,23 @@ func (m *Module) Generator() GeneratorService {
 	return m.container.GeneratorService()
 }
 
+// GeneratorRebuilder returns the hook rebuilding static output on change
+// events when Generator.RebuildOnChange is enabled, otherwise nil.
+func (m *Module) GeneratorRebuilder() *generator.Rebuilder {
+	return m.container.GeneratorRebuilder()
+}
+
 // Markdown returns the markdown service when configured.
 func (m *Module) Markdown() interfaces.MarkdownService {
 	return m.container.MarkdownService()
 }
 
+// WXRImporter returns a WordPress export importer bound to the module's content,
+// page and menu services. progress may be nil.
+func (m *Module) WXRImporter(progress wxr.ProgressStore) *wxr.Importer {
+	return m.container.WXRImporter(progress)
+}
+
 // Scheduler returns the scheduler used for publish automation.
 func (m *Module) Scheduler() interfaces.Scheduler {
 	return m.container.Scheduler()
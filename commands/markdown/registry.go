@@ -22,6 +22,7 @@ type CronRegistrar func(command.HandlerConfig, any) error
 type HandlerSet struct {
 	Import *markdowncmd.ImportDirectoryHandler
 	Sync   *markdowncmd.SyncDirectoryHandler
+	Export *markdowncmd.ExportDirectoryHandler
 }
 
 // Option customises handler wiring during registration.
@@ -30,6 +31,7 @@ type Option func(*options)
 type options struct {
 	importHandlerOpts []markdowncmd.ImportDirectoryOption
 	syncHandlerOpts   []markdowncmd.SyncDirectoryOption
+	exportHandlerOpts []markdowncmd.ExportDirectoryOption
 }
 
 // WithImportHandlerOptions forwards options to the ImportDirectoryHandler constructor.
@@ -46,6 +48,13 @@ func WithSyncHandlerOptions(opts ...markdowncmd.SyncDirectoryOption) Option {
 	}
 }
 
+// WithExportHandlerOptions forwards options to the ExportDirectoryHandler constructor.
+func WithExportHandlerOptions(opts ...markdowncmd.ExportDirectoryOption) Option {
+	return func(cfg *options) {
+		cfg.exportHandlerOpts = append(cfg.exportHandlerOpts, opts...)
+	}
+}
+
 // RegisterMarkdownCommands builds Markdown command handlers and registers them with the provided
 // registry. A HandlerSet containing the constructed handlers is returned so callers can wire
 // additional integrations (dispatcher, cron) as needed.
@@ -69,6 +78,7 @@ func RegisterMarkdownCommands(reg CommandRegistry, service interfaces.MarkdownSe
 
 	importHandler := markdowncmd.NewImportDirectoryHandler(service, logger, gates, cfg.importHandlerOpts...)
 	syncHandler := markdowncmd.NewSyncDirectoryHandler(service, logger, gates, cfg.syncHandlerOpts...)
+	exportHandler := markdowncmd.NewExportDirectoryHandler(service, logger, gates, cfg.exportHandlerOpts...)
 
 	if reg != nil {
 		if err := reg.RegisterCommand(importHandler); err != nil {
@@ -77,11 +87,15 @@ func RegisterMarkdownCommands(reg CommandRegistry, service interfaces.MarkdownSe
 		if err := reg.RegisterCommand(syncHandler); err != nil {
 			return nil, err
 		}
+		if err := reg.RegisterCommand(exportHandler); err != nil {
+			return nil, err
+		}
 	}
 
 	return &HandlerSet{
 		Import: importHandler,
 		Sync:   syncHandler,
+		Export: exportHandler,
 	}, nil
 }
 
@@ -49,11 +49,11 @@ func TestRegisterMarkdownCommandsRegistersHandlers(t *testing.T) {
 	if set == nil {
 		t.Fatal("expected handler set returned")
 	}
-	if set.Import == nil || set.Sync == nil {
-		t.Fatalf("expected import and sync handlers, got %#v", set)
+	if set.Import == nil || set.Sync == nil || set.Export == nil {
+		t.Fatalf("expected import, sync and export handlers, got %#v", set)
 	}
-	if len(reg.handlers) != 2 {
-		t.Fatalf("expected two handlers registered, got %d", len(reg.handlers))
+	if len(reg.handlers) != 3 {
+		t.Fatalf("expected three handlers registered, got %d", len(reg.handlers))
 	}
 	if reg.handlers[0] != set.Import {
 		t.Fatalf("expected import handler registered first, got %#v", reg.handlers[0])
@@ -61,6 +61,9 @@ func TestRegisterMarkdownCommandsRegistersHandlers(t *testing.T) {
 	if reg.handlers[1] != set.Sync {
 		t.Fatalf("expected sync handler registered second, got %#v", reg.handlers[1])
 	}
+	if reg.handlers[2] != set.Export {
+		t.Fatalf("expected export handler registered third, got %#v", reg.handlers[2])
+	}
 }
 
 func TestRegisterMarkdownCommandsNilRegistrySkipsRegistration(t *testing.T) {
@@ -239,3 +242,11 @@ func (s *stubMarkdownService) Sync(ctx context.Context, directory string, opts i
 	}
 	return s.syncResult, nil
 }
+
+func (*stubMarkdownService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (*stubMarkdownService) ExportDirectory(context.Context, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
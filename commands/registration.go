@@ -152,6 +152,7 @@ func RegisterContainerCommands(container *di.Container, opts RegistrationOptions
 		} else if handlerSet != nil {
 			register(handlerSet.Import)
 			register(handlerSet.Sync)
+			register(handlerSet.Export)
 		}
 	}
 
@@ -201,7 +202,9 @@ func RegisterContainerCommands(container *di.Container, opts RegistrationOptions
 		if worker := container.JobWorker(); worker != nil {
 			register(auditcmd.NewReplayAuditHandler(worker, auditLogger))
 		}
-		cleanupOpts := []auditcmd.CleanupHandlerOption{}
+		cleanupOpts := []auditcmd.CleanupHandlerOption{
+			auditcmd.CleanupWithRetention(cfg.Audit.Retention),
+		}
 		if expr := strings.TrimSpace(opts.CleanupAuditCron); expr != "" {
 			cleanupOpts = append(cleanupOpts, auditcmd.CleanupWithCronExpression(expr))
 		}
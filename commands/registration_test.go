@@ -162,6 +162,14 @@ func (fakeMarkdownService) Sync(context.Context, string, interfaces.SyncOptions)
 	return nil, nil
 }
 
+func (fakeMarkdownService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (fakeMarkdownService) ExportDirectory(context.Context, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
 type recordingRegistry struct {
 	handlers []any
 }
@@ -22,6 +22,15 @@ var (
 	ErrEnvironmentDefaultMultiple             = runtimeconfig.ErrEnvironmentDefaultMultiple
 	ErrEnvironmentDefaultUnknown              = runtimeconfig.ErrEnvironmentDefaultUnknown
 	ErrEnvironmentPermissionStrategyInvalid   = runtimeconfig.ErrEnvironmentPermissionStrategyInvalid
+	ErrVersionRetentionModeInvalid            = runtimeconfig.ErrVersionRetentionModeInvalid
+	ErrVersionRetentionWindowInvalid          = runtimeconfig.ErrVersionRetentionWindowInvalid
+	ErrVersionPruneIntervalInvalid            = runtimeconfig.ErrVersionPruneIntervalInvalid
+	ErrTrashRetentionInvalid                  = runtimeconfig.ErrTrashRetentionInvalid
+	ErrTrashPurgeIntervalInvalid              = runtimeconfig.ErrTrashPurgeIntervalInvalid
+	ErrWebhookMaxAttemptsInvalid              = runtimeconfig.ErrWebhookMaxAttemptsInvalid
+	ErrWebhookDurationInvalid                 = runtimeconfig.ErrWebhookDurationInvalid
+	ErrAuditRetentionInvalid                  = runtimeconfig.ErrAuditRetentionInvalid
+	ErrAuditPurgeIntervalInvalid              = runtimeconfig.ErrAuditPurgeIntervalInvalid
 )
 
 type (
@@ -37,6 +46,9 @@ type (
 	WidgetConfig              = runtimeconfig.WidgetConfig
 	WidgetDefinitionConfig    = runtimeconfig.WidgetDefinitionConfig
 	RetentionConfig           = runtimeconfig.RetentionConfig
+	TrashConfig               = runtimeconfig.TrashConfig
+	WebhooksConfig            = runtimeconfig.WebhooksConfig
+	AuditConfig               = runtimeconfig.AuditConfig
 	ShortcodeConfig           = runtimeconfig.ShortcodeConfig
 	ShortcodeDefinitionConfig = runtimeconfig.ShortcodeDefinitionConfig
 	ShortcodeSecurityConfig   = runtimeconfig.ShortcodeSecurityConfig
@@ -46,7 +58,11 @@ type (
 	EnvironmentConfig         = runtimeconfig.EnvironmentConfig
 	MarkdownConfig            = runtimeconfig.MarkdownConfig
 	MarkdownParserConfig      = runtimeconfig.MarkdownParserConfig
+	MarkdownFieldMapping      = runtimeconfig.MarkdownFieldMapping
+	MarkdownBlockSplitConfig  = runtimeconfig.MarkdownBlockSplitConfig
+	MarkdownAssetConfig       = runtimeconfig.MarkdownAssetConfig
 	GeneratorConfig           = runtimeconfig.GeneratorConfig
+	GeneratorCollectionConfig = runtimeconfig.GeneratorCollectionConfig
 	LoggingConfig             = runtimeconfig.LoggingConfig
 	ActivityConfig            = runtimeconfig.ActivityConfig
 	WorkflowConfig            = runtimeconfig.WorkflowConfig
@@ -25,6 +25,7 @@ var (
 	ErrTranslationInvariantViolation         = errors.New("content: translation invariant violation")
 	ErrContentSchemaInvalid                  = errors.New("content: schema validation failed")
 	ErrContentSoftDeleteUnsupported          = errors.New("content: soft delete not supported")
+	ErrContentNotTrashed                     = errors.New("content: content not found in trash")
 	ErrContentIDRequired                     = errors.New("content: content id required")
 	ErrContentMetadataInvalid                = errors.New("content: metadata invalid")
 	ErrVersioningDisabled                    = errors.New("content: versioning feature disabled")
@@ -2,9 +2,12 @@ package content
 
 import (
 	"context"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/goliatone/go-cms/domain"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
 )
@@ -29,6 +32,8 @@ type Service interface {
 	PreviewDraft(ctx context.Context, req PreviewContentDraftRequest) (*ContentPreview, error)
 	ListVersions(ctx context.Context, contentID uuid.UUID) ([]*ContentVersion, error)
 	RestoreVersion(ctx context.Context, req RestoreContentVersionRequest) (*ContentVersion, error)
+	ListTrashed(ctx context.Context, req ListTrashedContentRequest) ([]*Content, error)
+	RestoreDeleted(ctx context.Context, req RestoreContentRequest) (*Content, error)
 }
 
 // TranslationCreator exposes first-class translation creation without forcing
@@ -84,6 +89,10 @@ const (
 	contentListContentTypePrefix    ContentListOption = "content:list:content_type:"
 	contentListFamilyPrefix         ContentListOption = "content:list:family:"
 	contentListFamiliesPrefix       ContentListOption = "content:list:families:"
+	contentListStatusPrefix         ContentListOption = "content:list:status:"
+	contentListRecentFirst          ContentListOption = "content:list:order:recent"
+	contentListLimitPrefix          ContentListOption = "content:list:limit:"
+	contentListLocalesPrefix        ContentListOption = "content:list:locales:"
 )
 
 // WithTranslations preloads translations when listing or fetching content records.
@@ -157,6 +166,48 @@ func WithFamilyIDs(ids ...uuid.UUID) ContentListOption {
 	return contentListFamiliesPrefix + strings.Join(values, ",")
 }
 
+// WithStatus scopes list reads to content records in the supplied status.
+func WithStatus(status string) ContentListOption {
+	normalized := strings.ToLower(strings.TrimSpace(status))
+	if normalized == "" {
+		return ""
+	}
+	return contentListStatusPrefix + normalized
+}
+
+// WithRecentFirst orders list reads by publish time, falling back to the last
+// update, newest first.
+func WithRecentFirst() ContentListOption {
+	return contentListRecentFirst
+}
+
+// WithLimit caps the number of records a list read returns. Values <= 0 leave
+// the read unbounded.
+func WithLimit(limit int) ContentListOption {
+	if limit <= 0 {
+		return ""
+	}
+	return contentListLimitPrefix + strconv.Itoa(limit)
+}
+
+// WithTranslationLocales restricts the translations loaded by WithTranslations
+// to the supplied locale codes. Empty codes are omitted and duplicates are
+// collapsed.
+func WithTranslationLocales(codes ...string) ContentListOption {
+	values := make([]string, 0, len(codes))
+	for _, code := range codes {
+		normalized := strings.ToLower(strings.TrimSpace(code))
+		if normalized == "" || slices.Contains(values, normalized) {
+			continue
+		}
+		values = append(values, normalized)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return contentListLocalesPrefix + strings.Join(values, ",")
+}
+
 // SupportsContentListOption reports whether this package recognizes and can
 // execute the supplied list option. Dynamic options are validated as well as
 // recognized so malformed tokens cannot be mistaken for environment keys.
@@ -166,12 +217,14 @@ func SupportsContentListOption(option ContentListOption) bool {
 		return false
 	}
 	switch token {
-	case contentListWithTranslations:
+	case contentListWithTranslations, contentListRecentFirst:
 		return true
 	}
 	for _, prefix := range []ContentListOption{
 		contentListProjectionPrefix,
 		contentListProjectionModePrefix,
+		contentListStatusPrefix,
+		contentListLocalesPrefix,
 	} {
 		if value, ok := strings.CutPrefix(token, prefix); ok {
 			return strings.TrimSpace(value) != ""
@@ -186,6 +239,10 @@ func SupportsContentListOption(option ContentListOption) bool {
 			return err == nil && id != uuid.Nil
 		}
 	}
+	if value, ok := strings.CutPrefix(token, contentListLimitPrefix); ok {
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		return err == nil && limit > 0
+	}
 	if value, ok := strings.CutPrefix(token, contentListFamiliesPrefix); ok {
 		found := false
 		for rawID := range strings.SplitSeq(value, ",") {
@@ -206,6 +263,7 @@ type CreateContentRequest struct {
 	Slug                     string
 	Status                   string
 	EnvironmentKey           string
+	SiteID                   *uuid.UUID
 	CreatedBy                uuid.UUID
 	UpdatedBy                uuid.UUID
 	Metadata                 map[string]any
@@ -241,6 +299,24 @@ type DeleteContentRequest struct {
 	HardDelete bool
 }
 
+// ListTrashedContentRequest pages through the trashed entries of an
+// environment. An empty Environment selects the default environment and a zero
+// Limit returns every remaining entry.
+type ListTrashedContentRequest struct {
+	Environment string
+	Limit       int
+	Offset      int
+}
+
+// RestoreContentRequest brings a soft-deleted entry back from the trash.
+// OnConflict decides what happens when the slug was reused after deletion and
+// defaults to domain.RestoreConflictFail.
+type RestoreContentRequest struct {
+	ID         uuid.UUID
+	RestoredBy uuid.UUID
+	OnConflict domain.RestoreConflict
+}
+
 // TranslationConflictStrategy controls duplicate handling when creating translations.
 type TranslationConflictStrategy string
 
@@ -355,6 +431,10 @@ type UpdateContentTypeRequest struct {
 	EnvironmentKey       string
 	UpdatedBy            uuid.UUID
 	AllowBreakingChanges bool
+	// Migration upgrades existing payloads to the new schema version. When
+	// nil and the schema has breaking changes, suggested operations are
+	// stored instead; pass an empty slice to store none.
+	Migration []SchemaMigrationOperation
 }
 
 // DeleteContentTypeRequest captures details required to delete a content type.
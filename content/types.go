@@ -45,16 +45,24 @@ type ContentType struct {
 }
 
 // ContentTypeSchemaSnapshot captures schema metadata for version history.
+// Migration holds the operations that upgrade payloads from the previous
+// snapshot's version to this one.
 type ContentTypeSchemaSnapshot struct {
-	Version      string         `json:"version"`
-	Schema       map[string]any `json:"schema"`
-	UISchema     map[string]any `json:"ui_schema,omitempty"`
-	Capabilities map[string]any `json:"capabilities,omitempty"`
-	Status       string         `json:"status,omitempty"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	UpdatedBy    *uuid.UUID     `json:"updated_by,omitempty"`
+	Version      string                     `json:"version"`
+	Schema       map[string]any             `json:"schema"`
+	UISchema     map[string]any             `json:"ui_schema,omitempty"`
+	Capabilities map[string]any             `json:"capabilities,omitempty"`
+	Status       string                     `json:"status,omitempty"`
+	Migration    []SchemaMigrationOperation `json:"migration,omitempty"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+	UpdatedBy    *uuid.UUID                 `json:"updated_by,omitempty"`
 }
 
+// SchemaMigrationOperation is a declarative payload transformation (rename,
+// move, copy, delete, set_default, wrap_array, map_values, coerce) applied
+// when payloads are upgraded between schema versions.
+type SchemaMigrationOperation = domain.SchemaMigrationOperation
+
 // Content is the canonical record for translatable entries.
 type Content struct {
 	bun.BaseModel `bun:"table:contents,alias:c"`
@@ -72,6 +80,7 @@ type Content struct {
 	PublishedAt      *time.Time            `bun:"published_at,nullzero" json:"published_at,omitempty"`
 	PublishedBy      *uuid.UUID            `bun:"published_by,type:uuid" json:"published_by,omitempty"`
 	EnvironmentID    uuid.UUID             `bun:"environment_id,type:uuid" json:"environment_id,omitempty"`
+	SiteID           *uuid.UUID            `bun:"site_id,type:uuid" json:"site_id,omitempty"`
 	CreatedBy        uuid.UUID             `bun:"created_by,notnull,type:uuid" json:"created_by"`
 	UpdatedBy        uuid.UUID             `bun:"updated_by,notnull,type:uuid" json:"updated_by"`
 	DeletedAt        *time.Time            `bun:"deleted_at,nullzero" json:"deleted_at,omitempty"`
@@ -0,0 +1,22 @@
+-- Promotion batches: history of environment promotions with rollback state
+CREATE TABLE promotion_batches (
+    id UUID PRIMARY KEY,
+    source_environment_id UUID NOT NULL,
+    source_environment_key TEXT NOT NULL,
+    target_environment_id UUID NOT NULL,
+    target_environment_key TEXT NOT NULL,
+    scope TEXT,
+    status TEXT NOT NULL,
+    options JSONB,
+    summary JSONB,
+    items JSONB,
+    errors JSONB,
+    records JSONB,
+    created_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rolled_back_by UUID,
+    rolled_back_at TIMESTAMP
+);
+
+CREATE INDEX idx_promotion_batches_target_created ON promotion_batches(target_environment_id, created_at DESC);
+CREATE INDEX idx_promotion_batches_source_created ON promotion_batches(source_environment_id, created_at DESC);
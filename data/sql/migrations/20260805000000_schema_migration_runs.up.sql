@@ -0,0 +1,19 @@
+-- Schema migration runs: checkpoints and failures of bulk payload migrations
+CREATE TABLE schema_migration_runs (
+    id UUID PRIMARY KEY,
+    kind TEXT NOT NULL,
+    target_id UUID NOT NULL,
+    target_slug TEXT NOT NULL,
+    target_version TEXT,
+    status TEXT NOT NULL,
+    options JSONB,
+    checkpoint JSONB,
+    summary JSONB,
+    failures JSONB,
+    created_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_schema_migration_runs_target_created ON schema_migration_runs(target_id, created_at DESC);
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_menus_env_site_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_env_code ON menus(environment_id, code);
+DROP INDEX IF EXISTS idx_pages_env_site_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pages_env_slug ON pages(environment_id, slug) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_widget_area_definitions_site;
+DROP INDEX IF EXISTS idx_menus_site;
+DROP INDEX IF EXISTS idx_pages_site;
+DROP INDEX IF EXISTS idx_contents_site;
+
+ALTER TABLE widget_area_definitions DROP COLUMN IF EXISTS site_id;
+ALTER TABLE menus DROP COLUMN IF EXISTS site_id;
+ALTER TABLE pages DROP COLUMN IF EXISTS site_id;
+ALTER TABLE contents DROP COLUMN IF EXISTS site_id;
+
+DROP INDEX IF EXISTS idx_sites_default;
+DROP TABLE IF EXISTS sites;
@@ -0,0 +1,37 @@
+-- Sites: multiple delivery sites served from one CMS instance
+CREATE TABLE IF NOT EXISTS sites (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    hostnames JSONB,
+    base_url TEXT,
+    default_locale TEXT,
+    locales JSONB,
+    theme TEXT,
+    theme_variant TEXT,
+    menu_locations JSONB,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sites_default ON sites(is_default) WHERE is_default;
+
+-- Add nullable site_id columns; NULL means shared across all sites.
+ALTER TABLE contents ADD COLUMN IF NOT EXISTS site_id UUID;
+ALTER TABLE pages ADD COLUMN IF NOT EXISTS site_id UUID;
+ALTER TABLE menus ADD COLUMN IF NOT EXISTS site_id UUID;
+ALTER TABLE widget_area_definitions ADD COLUMN IF NOT EXISTS site_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_contents_site ON contents(site_id);
+CREATE INDEX IF NOT EXISTS idx_pages_site ON pages(site_id);
+CREATE INDEX IF NOT EXISTS idx_menus_site ON menus(site_id);
+CREATE INDEX IF NOT EXISTS idx_widget_area_definitions_site ON widget_area_definitions(site_id);
+
+-- Slugs and menu codes are unique per site; shared records compare as one scope.
+DROP INDEX IF EXISTS idx_pages_env_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pages_env_site_slug ON pages(environment_id, COALESCE(site_id, '00000000-0000-0000-0000-000000000000'::uuid), slug) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_menus_env_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_env_site_code ON menus(environment_id, COALESCE(site_id, '00000000-0000-0000-0000-000000000000'::uuid), code);
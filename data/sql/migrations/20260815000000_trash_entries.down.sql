@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_trash_entries_type_env;
+DROP INDEX IF EXISTS idx_trash_entries_deleted_at;
+DROP INDEX IF EXISTS idx_trash_entries_resource;
+DROP TABLE IF EXISTS trash_entries;
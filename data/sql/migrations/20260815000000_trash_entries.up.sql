@@ -0,0 +1,14 @@
+-- Trash: snapshots of soft-deleted records kept until restored or purged
+CREATE TABLE IF NOT EXISTS trash_entries (
+    id UUID PRIMARY KEY,
+    resource_type TEXT NOT NULL,
+    resource_id UUID NOT NULL,
+    environment_id UUID,
+    snapshot JSONB NOT NULL,
+    deleted_by UUID,
+    deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trash_entries_resource ON trash_entries(resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_trash_entries_deleted_at ON trash_entries(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_trash_entries_type_env ON trash_entries(resource_type, environment_id, deleted_at);
@@ -0,0 +1,7 @@
+ALTER TABLE widget_instances DROP COLUMN IF EXISTS published_by;
+ALTER TABLE widget_instances DROP COLUMN IF EXISTS published_at;
+ALTER TABLE widget_instances DROP COLUMN IF EXISTS published_version;
+ALTER TABLE widget_instances DROP COLUMN IF EXISTS current_version;
+
+DROP INDEX IF EXISTS idx_widget_versions_widget_instance_id;
+DROP TABLE IF EXISTS widget_versions;
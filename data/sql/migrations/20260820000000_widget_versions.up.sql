@@ -0,0 +1,20 @@
+-- Widget versions: draft and published snapshots of widget instances
+CREATE TABLE IF NOT EXISTS widget_versions (
+    id UUID PRIMARY KEY,
+    widget_instance_id UUID NOT NULL REFERENCES widget_instances(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    snapshot JSONB NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    published_by UUID,
+    UNIQUE(widget_instance_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_widget_versions_widget_instance_id ON widget_versions(widget_instance_id);
+
+ALTER TABLE widget_instances ADD COLUMN IF NOT EXISTS current_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE widget_instances ADD COLUMN IF NOT EXISTS published_version INTEGER;
+ALTER TABLE widget_instances ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+ALTER TABLE widget_instances ADD COLUMN IF NOT EXISTS published_by UUID;
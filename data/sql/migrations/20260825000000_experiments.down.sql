@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_experiments_target;
+DROP TABLE IF EXISTS experiments;
@@ -0,0 +1,20 @@
+-- Experiments: weighted A/B variants for widget and block instances
+CREATE TABLE IF NOT EXISTS experiments (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id UUID NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    variants JSONB NOT NULL DEFAULT '[]'::jsonb,
+    promoted_variant TEXT,
+    started_at TIMESTAMP,
+    stopped_at TIMESTAMP,
+    promoted_at TIMESTAMP,
+    created_by UUID,
+    updated_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiments_target ON experiments(target_type, target_id);
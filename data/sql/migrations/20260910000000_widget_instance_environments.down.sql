@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_widget_instances_environment;
+
+ALTER TABLE widget_instances DROP COLUMN IF EXISTS environment_id;
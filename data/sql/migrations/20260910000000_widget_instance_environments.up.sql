@@ -0,0 +1,5 @@
+-- Widget instance environments: scope area widgets per environment.
+-- NULL marks instances created before scoping; they belong to the default environment.
+ALTER TABLE widget_instances ADD COLUMN IF NOT EXISTS environment_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_widget_instances_environment ON widget_instances(environment_id);
@@ -0,0 +1,22 @@
+-- Promotion batches: history of environment promotions with rollback state
+CREATE TABLE promotion_batches (
+    id TEXT PRIMARY KEY,
+    source_environment_id TEXT NOT NULL,
+    source_environment_key TEXT NOT NULL,
+    target_environment_id TEXT NOT NULL,
+    target_environment_key TEXT NOT NULL,
+    scope TEXT,
+    status TEXT NOT NULL,
+    options TEXT,
+    summary TEXT,
+    items TEXT,
+    errors TEXT,
+    records TEXT,
+    created_by TEXT,
+    created_at TEXT NOT NULL,
+    rolled_back_by TEXT,
+    rolled_back_at TEXT
+);
+
+CREATE INDEX idx_promotion_batches_target_created ON promotion_batches(target_environment_id, created_at DESC);
+CREATE INDEX idx_promotion_batches_source_created ON promotion_batches(source_environment_id, created_at DESC);
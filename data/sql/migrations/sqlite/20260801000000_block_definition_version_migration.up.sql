@@ -0,0 +1,2 @@
+ALTER TABLE block_definition_versions
+    ADD COLUMN migration TEXT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS schema_migration_runs;
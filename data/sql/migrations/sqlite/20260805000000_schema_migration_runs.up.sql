@@ -0,0 +1,19 @@
+-- Schema migration runs: checkpoints and failures of bulk payload migrations
+CREATE TABLE schema_migration_runs (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    target_slug TEXT NOT NULL,
+    target_version TEXT,
+    status TEXT NOT NULL,
+    options TEXT,
+    checkpoint TEXT,
+    summary TEXT,
+    failures TEXT,
+    created_by TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    completed_at TEXT
+);
+
+CREATE INDEX idx_schema_migration_runs_target_created ON schema_migration_runs(target_id, created_at DESC);
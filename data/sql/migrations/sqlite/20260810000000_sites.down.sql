@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_menus_env_site_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_env_code ON menus(environment_id, code);
+DROP INDEX IF EXISTS idx_pages_env_site_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pages_env_slug ON pages(environment_id, slug) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_widget_area_definitions_site;
+DROP INDEX IF EXISTS idx_menus_site;
+DROP INDEX IF EXISTS idx_pages_site;
+DROP INDEX IF EXISTS idx_contents_site;
+
+DROP INDEX IF EXISTS idx_sites_default;
+DROP TABLE IF EXISTS sites;
+
+-- SQLite does not support dropping columns via ALTER TABLE.
+-- No-op for site_id columns.
@@ -0,0 +1,37 @@
+-- Sites: multiple delivery sites served from one CMS instance
+CREATE TABLE IF NOT EXISTS sites (
+    id TEXT PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    hostnames TEXT,
+    base_url TEXT,
+    default_locale TEXT,
+    locales TEXT,
+    theme TEXT,
+    theme_variant TEXT,
+    menu_locations TEXT,
+    is_active INTEGER NOT NULL DEFAULT 1,
+    is_default INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sites_default ON sites(is_default) WHERE is_default = 1;
+
+-- Add nullable site_id columns; NULL means shared across all sites.
+ALTER TABLE contents ADD COLUMN site_id TEXT;
+ALTER TABLE pages ADD COLUMN site_id TEXT;
+ALTER TABLE menus ADD COLUMN site_id TEXT;
+ALTER TABLE widget_area_definitions ADD COLUMN site_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_contents_site ON contents(site_id);
+CREATE INDEX IF NOT EXISTS idx_pages_site ON pages(site_id);
+CREATE INDEX IF NOT EXISTS idx_menus_site ON menus(site_id);
+CREATE INDEX IF NOT EXISTS idx_widget_area_definitions_site ON widget_area_definitions(site_id);
+
+-- Slugs and menu codes are unique per site; shared records compare as one scope.
+DROP INDEX IF EXISTS idx_pages_env_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pages_env_site_slug ON pages(environment_id, COALESCE(site_id, ''), slug) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_menus_env_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_menus_env_site_code ON menus(environment_id, COALESCE(site_id, ''), code);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_widget_versions_widget_instance_id;
+DROP TABLE IF EXISTS widget_versions;
+
+-- SQLite does not support dropping columns via ALTER TABLE.
+-- No-op for widget_instances version columns.
@@ -0,0 +1,20 @@
+-- Widget versions: draft and published snapshots of widget instances
+CREATE TABLE IF NOT EXISTS widget_versions (
+    id TEXT PRIMARY KEY,
+    widget_instance_id TEXT NOT NULL REFERENCES widget_instances(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    snapshot TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    published_by TEXT,
+    UNIQUE(widget_instance_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_widget_versions_widget_instance_id ON widget_versions(widget_instance_id);
+
+ALTER TABLE widget_instances ADD COLUMN current_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE widget_instances ADD COLUMN published_version INTEGER;
+ALTER TABLE widget_instances ADD COLUMN published_at TIMESTAMP;
+ALTER TABLE widget_instances ADD COLUMN published_by TEXT;
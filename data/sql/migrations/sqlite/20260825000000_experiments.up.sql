@@ -0,0 +1,20 @@
+-- Experiments: weighted A/B variants for widget and block instances
+CREATE TABLE IF NOT EXISTS experiments (
+    id TEXT PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    variants TEXT NOT NULL DEFAULT '[]',
+    promoted_variant TEXT,
+    started_at TIMESTAMP,
+    stopped_at TIMESTAMP,
+    promoted_at TIMESTAMP,
+    created_by TEXT,
+    updated_by TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiments_target ON experiments(target_type, target_id);
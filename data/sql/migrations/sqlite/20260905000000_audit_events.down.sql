@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_audit_events_actor;
+DROP INDEX IF EXISTS idx_audit_events_entity;
+DROP INDEX IF EXISTS idx_audit_events_occurred;
+DROP TABLE IF EXISTS audit_events;
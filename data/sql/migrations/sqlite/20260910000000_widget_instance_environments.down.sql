@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_widget_instances_environment;
+
+-- SQLite does not support dropping columns via ALTER TABLE.
+-- No-op for widget_instances.environment_id.
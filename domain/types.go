@@ -15,3 +15,31 @@ const (
 	// StatusScheduled marks content that has a future publish time configured.
 	StatusScheduled = internaldomain.StatusScheduled
 )
+
+// RestoreConflict selects how restoring a deleted record handles a slug that
+// was reused after deletion.
+type RestoreConflict = internaldomain.RestoreConflict
+
+const (
+	// RestoreConflictFail rejects the restore while another record holds the slug.
+	RestoreConflictFail = internaldomain.RestoreConflictFail
+	// RestoreConflictRename restores the record under the first free suffixed slug.
+	RestoreConflictRename = internaldomain.RestoreConflictRename
+)
+
+// SchemaMigrationOperation is a declarative payload transformation between two
+// schema versions. Paths use dot notation; a segment ending in "[]" applies
+// the rest of the path to every element of that array (e.g. "items[].title").
+type SchemaMigrationOperation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	// To is the destination path for move and copy, or the new field name
+	// for rename.
+	To string `json:"to,omitempty"`
+	// Value is written by set_default when the field is missing or null.
+	Value any `json:"value,omitempty"`
+	// Values maps old enum values to new ones for map_values.
+	Values map[string]any `json:"values,omitempty"`
+	// Type is the JSON type targeted by coerce.
+	Type string `json:"type,omitempty"`
+}
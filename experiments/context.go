@@ -0,0 +1,27 @@
+package experiments
+
+import (
+	"context"
+	"strings"
+)
+
+type visitorKeyContextKey struct{}
+
+// WithVisitorKey returns a copy of ctx carrying the visitor key used to bucket
+// visitors into variants. Page reads pick it up for blocks and widget areas.
+func WithVisitorKey(ctx context.Context, key string) context.Context {
+	key = strings.TrimSpace(key)
+	if ctx == nil || key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, visitorKeyContextKey{}, key)
+}
+
+// VisitorKeyFromContext returns the visitor key stored by WithVisitorKey.
+func VisitorKeyFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key, _ := ctx.Value(visitorKeyContextKey{}).(string)
+	return key
+}
@@ -0,0 +1,84 @@
+package experiments
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Service manages experiments and assigns visitors to variants.
+type Service interface {
+	CreateExperiment(ctx context.Context, input CreateExperimentInput) (*Experiment, error)
+	GetExperiment(ctx context.Context, id uuid.UUID) (*Experiment, error)
+	ListExperiments(ctx context.Context) ([]*Experiment, error)
+	DeleteExperiment(ctx context.Context, id uuid.UUID) error
+	StartExperiment(ctx context.Context, req TransitionExperimentRequest) (*Experiment, error)
+	StopExperiment(ctx context.Context, req TransitionExperimentRequest) (*Experiment, error)
+	PromoteVariant(ctx context.Context, req PromoteVariantRequest) (*Experiment, error)
+	SelectVariant(ctx context.Context, input SelectVariantInput) (*Assignment, error)
+}
+
+// Selector assigns visitors to the variants of running experiments. Widget
+// area resolution and page block resolution depend on this narrow contract.
+type Selector interface {
+	SelectVariant(ctx context.Context, input SelectVariantInput) (*Assignment, error)
+}
+
+// CreateExperimentInput captures the information required to define an
+// experiment. New experiments start in draft.
+type CreateExperimentInput struct {
+	Key        string
+	Name       string
+	TargetType TargetType
+	TargetID   uuid.UUID
+	Variants   []Variant
+	CreatedBy  uuid.UUID
+}
+
+// TransitionExperimentRequest starts or stops an experiment.
+type TransitionExperimentRequest struct {
+	ID    uuid.UUID
+	Actor uuid.UUID
+}
+
+// PromoteVariantRequest makes a variant the new base configuration of the
+// experiment target and ends the experiment.
+type PromoteVariantRequest struct {
+	ID         uuid.UUID
+	VariantKey string
+	PromotedBy uuid.UUID
+}
+
+// SelectVariantInput identifies a target instance and the visitor viewing it.
+type SelectVariantInput struct {
+	TargetType TargetType
+	TargetID   uuid.UUID
+	VisitorKey string
+}
+
+// Assignment is the variant a visitor was bucketed into.
+type Assignment struct {
+	ExperimentID  uuid.UUID
+	ExperimentKey string
+	VariantKey    string
+	Configuration map[string]any
+}
+
+var (
+	ErrFeatureDisabled                = errors.New("experiments: feature disabled")
+	ErrExperimentKeyRequired          = errors.New("experiments: key is required")
+	ErrExperimentKeyInvalid           = errors.New("experiments: key is invalid")
+	ErrExperimentKeyExists            = errors.New("experiments: key already exists")
+	ErrExperimentNotFound             = errors.New("experiments: experiment not found")
+	ErrExperimentTargetRequired       = errors.New("experiments: target is required")
+	ErrExperimentTargetInvalid        = errors.New("experiments: target type is invalid")
+	ErrExperimentTargetUnavailable    = errors.New("experiments: target service not configured")
+	ErrExperimentTargetBusy           = errors.New("experiments: target already has a running experiment")
+	ErrExperimentVariantsRequired     = errors.New("experiments: at least two variants are required")
+	ErrExperimentVariantKeyInvalid    = errors.New("experiments: variant keys must be unique and non-empty")
+	ErrExperimentVariantWeightInvalid = errors.New("experiments: variant weights must be non-negative with a positive total")
+	ErrExperimentVariantNotFound      = errors.New("experiments: variant not found")
+	ErrExperimentInvalidTransition    = errors.New("experiments: invalid status transition")
+	ErrExperimentRepositoryRequired   = errors.New("experiments: repository required")
+)
@@ -0,0 +1,70 @@
+package experiments
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Status describes where an experiment is in its lifecycle.
+type Status string
+
+const (
+	StatusDraft    Status = "draft"
+	StatusRunning  Status = "running"
+	StatusStopped  Status = "stopped"
+	StatusPromoted Status = "promoted"
+)
+
+// TargetType identifies the kind of record an experiment varies.
+type TargetType string
+
+const (
+	TargetWidgetInstance TargetType = "widget_instance"
+	TargetBlockInstance  TargetType = "block_instance"
+)
+
+// Variant is one arm of an experiment. Configuration is overlaid on the base
+// configuration of the target: top-level keys replace the base values. A
+// variant without configuration renders the target unchanged.
+type Variant struct {
+	Key           string         `json:"key"`
+	Weight        int            `json:"weight"`
+	Configuration map[string]any `json:"configuration,omitempty"`
+}
+
+// Experiment splits visitors of a widget or block instance across weighted
+// variants.
+type Experiment struct {
+	bun.BaseModel `bun:"table:experiments,alias:exp"`
+
+	ID              uuid.UUID  `bun:",pk,type:uuid" json:"id"`
+	Key             string     `bun:"key,notnull" json:"key"`
+	Name            string     `bun:"name,notnull" json:"name"`
+	TargetType      TargetType `bun:"target_type,notnull" json:"target_type"`
+	TargetID        uuid.UUID  `bun:"target_id,notnull,type:uuid" json:"target_id"`
+	Status          Status     `bun:"status,notnull" json:"status"`
+	Variants        []Variant  `bun:"variants,type:jsonb,notnull" json:"variants"`
+	PromotedVariant string     `bun:"promoted_variant" json:"promoted_variant,omitempty"`
+	StartedAt       *time.Time `bun:"started_at,nullzero" json:"started_at,omitempty"`
+	StoppedAt       *time.Time `bun:"stopped_at,nullzero" json:"stopped_at,omitempty"`
+	PromotedAt      *time.Time `bun:"promoted_at,nullzero" json:"promoted_at,omitempty"`
+	CreatedBy       uuid.UUID  `bun:"created_by,type:uuid" json:"created_by"`
+	UpdatedBy       uuid.UUID  `bun:"updated_by,type:uuid" json:"updated_by"`
+	CreatedAt       time.Time  `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	UpdatedAt       time.Time  `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+}
+
+// Variant returns the variant with the given key.
+func (e *Experiment) Variant(key string) (*Variant, bool) {
+	if e == nil {
+		return nil, false
+	}
+	for i := range e.Variants {
+		if e.Variants[i].Key == key {
+			return &e.Variants[i], true
+		}
+	}
+	return nil, false
+}
@@ -1,6 +1,12 @@
 package generator
 
-import internal "github.com/goliatone/go-cms/internal/generator"
+import (
+	"time"
+
+	internal "github.com/goliatone/go-cms/internal/generator"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+)
 
 type (
 	Service              = internal.Service
@@ -20,9 +26,43 @@ type (
 	ThemeContext         = internal.ThemeContext
 	TemplateHelpers      = internal.TemplateHelpers
 	LocaleSpec           = internal.LocaleSpec
+	LocaleAlternate      = internal.LocaleAlternate
 	DependencyMetadata   = internal.DependencyMetadata
 	AssetResolver        = internal.AssetResolver
 	NoOpAssetResolver    = internal.NoOpAssetResolver
+	CollectionConfig     = internal.CollectionConfig
+	CollectionContext    = internal.CollectionContext
+	CollectionItem       = internal.CollectionItem
+	Pagination           = internal.Pagination
+	PageLink             = internal.PageLink
+	ArchiveLink          = internal.ArchiveLink
+	DependentBuilder     = internal.DependentBuilder
+	Rebuilder            = internal.Rebuilder
+	RebuildOption        = internal.RebuildOption
+	MemoryStorage        = internal.MemoryStorage
+	MemoryFile           = internal.MemoryFile
+	DevBuilder           = internal.DevBuilder
+	DevServer            = internal.DevServer
+	DevServerOption      = internal.DevServerOption
+	DevDiagnostic        = internal.DevDiagnostic
+)
+
+const (
+	CollectionGroupYear  = internal.CollectionGroupYear
+	CollectionGroupMonth = internal.CollectionGroupMonth
+
+	DependencyContent     = internal.DependencyContent
+	DependencyContentType = internal.DependencyContentType
+	DependencyPage        = internal.DependencyPage
+	DependencyMenu        = internal.DependencyMenu
+	DependencyWidget      = internal.DependencyWidget
+	DependencyBlock       = internal.DependencyBlock
+	DependencyTemplate    = internal.DependencyTemplate
+	DependencyAsset       = internal.DependencyAsset
+
+	HrefLangDefault = internal.HrefLangDefault
+
+	DevServerEventsPath = internal.DevServerEventsPath
 )
 
 var (
@@ -37,3 +77,59 @@ func NewService(cfg Config, deps Dependencies) Service {
 func NewDisabledService() Service {
 	return internal.NewDisabledService()
 }
+
+func NewRebuilder(builder DependentBuilder, opts ...RebuildOption) *Rebuilder {
+	return internal.NewRebuilder(builder, opts...)
+}
+
+func DependencyKey(kind, id string) string {
+	return internal.DependencyKey(kind, id)
+}
+
+func DependencyKeysForEvent(event lifecycle.Event) []string {
+	return internal.DependencyKeysForEvent(event)
+}
+
+func WithRebuildDebounce(d time.Duration) RebuildOption {
+	return internal.WithRebuildDebounce(d)
+}
+
+func WithRebuildMaxDelay(d time.Duration) RebuildOption {
+	return internal.WithRebuildMaxDelay(d)
+}
+
+func WithRebuildLogger(logger interfaces.Logger) RebuildOption {
+	return internal.WithRebuildLogger(logger)
+}
+
+func WithRebuildCallback(fn func([]string, *BuildResult, error)) RebuildOption {
+	return internal.WithRebuildCallback(fn)
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return internal.NewMemoryStorage()
+}
+
+func NewDevServer(builder DevBuilder, storage *MemoryStorage, opts ...DevServerOption) *DevServer {
+	return internal.NewDevServer(builder, storage, opts...)
+}
+
+func WithDevServerOutputDir(dir string) DevServerOption {
+	return internal.WithDevServerOutputDir(dir)
+}
+
+func WithDevServerWatch(paths ...string) DevServerOption {
+	return internal.WithDevServerWatch(paths...)
+}
+
+func WithDevServerPollInterval(interval time.Duration) DevServerOption {
+	return internal.WithDevServerPollInterval(interval)
+}
+
+func WithDevServerRebuildDebounce(debounce time.Duration) DevServerOption {
+	return internal.WithDevServerRebuildDebounce(debounce)
+}
+
+func WithDevServerLogger(logger interfaces.Logger) DevServerOption {
+	return internal.WithDevServerLogger(logger)
+}
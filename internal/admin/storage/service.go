@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/internal/permissions"
 	"github.com/goliatone/go-cms/internal/runtimeconfig"
 	"github.com/goliatone/go-cms/internal/storageconfig"
 	"github.com/goliatone/go-cms/pkg/storage"
@@ -292,6 +293,12 @@ func (s *Service) recordAudit(ctx context.Context, event jobs.AuditEvent) {
 	if event.OccurredAt.IsZero() {
 		event.OccurredAt = s.clock()
 	}
+	if event.ActorID == "" {
+		event.ActorID = permissions.ActorIDFromContext(ctx)
+	}
+	if event.EnvironmentKey == "" {
+		event.EnvironmentKey = permissions.EnvironmentKeyFromContext(ctx)
+	}
 	_ = s.audit.Record(ctx, event)
 }
 
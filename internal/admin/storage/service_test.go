@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/internal/permissions"
 	"github.com/goliatone/go-cms/internal/runtimeconfig"
 	"github.com/goliatone/go-cms/internal/storageconfig"
+	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/goliatone/go-cms/pkg/storage"
 )
 
@@ -379,3 +381,41 @@ func TestService_Schemas(t *testing.T) {
 		t.Fatalf("unexpected profile schema")
 	}
 }
+
+type stubSession struct {
+	interfaces.RoleCapableSession
+	userID string
+}
+
+func (s stubSession) GetUserID() string { return s.userID }
+
+func TestService_ApplyConfigAuditCarriesRequestActorAndEnvironment(t *testing.T) {
+	repo := storageconfig.NewMemoryRepository()
+	recorder := jobs.NewInMemoryAuditRecorder()
+	svc := NewService(repo, recorder, WithClock(func() time.Time { return fixedTime }))
+
+	ctx := permissions.WithSession(context.Background(), stubSession{userID: "user-42"})
+	ctx = permissions.WithEnvironmentKey(ctx, "staging")
+	cfg := runtimeconfig.StorageConfig{
+		Profiles: []storage.Profile{{
+			Name:     "primary",
+			Provider: "bun",
+			Config:   storage.Config{Name: "primary", Driver: "bun", DSN: "postgres://primary"},
+			Default:  true,
+		}},
+		Aliases: map[string]string{"content": "primary"},
+	}
+	if err := svc.ApplyConfig(ctx, cfg); err != nil {
+		t.Fatalf("ApplyConfig() error = %v", err)
+	}
+
+	events := recorder.Events()
+	if len(events) == 0 {
+		t.Fatalf("expected audit events")
+	}
+	for _, event := range events {
+		if event.ActorID != "user-42" || event.EnvironmentKey != "staging" {
+			t.Fatalf("expected actor and environment from the request context, got %+v", event)
+		}
+	}
+}
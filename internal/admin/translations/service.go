@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/internal/permissions"
 	"github.com/goliatone/go-cms/internal/translationconfig"
 )
 
@@ -124,5 +125,11 @@ func (s *Service) recordAudit(ctx context.Context, event jobs.AuditEvent) {
 	if event.OccurredAt.IsZero() {
 		event.OccurredAt = s.clock()
 	}
+	if event.ActorID == "" {
+		event.ActorID = permissions.ActorIDFromContext(ctx)
+	}
+	if event.EnvironmentKey == "" {
+		event.EnvironmentKey = permissions.EnvironmentKeyFromContext(ctx)
+	}
 	_ = s.audit.Record(ctx, event)
 }
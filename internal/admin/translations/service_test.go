@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/internal/permissions"
 	"github.com/goliatone/go-cms/internal/translationconfig"
+	"github.com/goliatone/go-cms/pkg/interfaces"
 )
 
 var fixedTime = time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
@@ -92,3 +94,35 @@ func TestService_RequiresRepository(t *testing.T) {
 		t.Fatalf("expected ErrRepositoryRequired, got %v", err)
 	}
 }
+
+type stubSession struct {
+	interfaces.RoleCapableSession
+	userID string
+}
+
+func (s stubSession) GetUserID() string { return s.userID }
+
+func TestService_AuditEventsCarryRequestActorAndEnvironment(t *testing.T) {
+	repo := translationconfig.NewMemoryRepository()
+	recorder := jobs.NewInMemoryAuditRecorder()
+	svc := NewService(repo, recorder, WithClock(func() time.Time { return fixedTime }))
+
+	ctx := permissions.WithSession(context.Background(), stubSession{userID: "user-42"})
+	ctx = permissions.WithEnvironmentKey(ctx, "staging")
+	if err := svc.ApplySettings(ctx, translationconfig.Settings{TranslationsEnabled: true}); err != nil {
+		t.Fatalf("ApplySettings() error = %v", err)
+	}
+	if err := svc.Reset(ctx); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	events := recorder.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 audit events, got %d", len(events))
+	}
+	for _, event := range events {
+		if event.ActorID != "user-42" || event.EnvironmentKey != "staging" {
+			t.Fatalf("expected actor and environment from the request context, got %+v", event)
+		}
+	}
+}
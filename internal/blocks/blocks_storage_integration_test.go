@@ -2,10 +2,12 @@ package blocks_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/pkg/testsupport"
 	repocache "github.com/goliatone/go-repository-cache/cache"
 	"github.com/google/uuid"
@@ -82,6 +84,91 @@ func TestBlocksService_WithBunStorageAndCache(t *testing.T) {
 	}
 }
 
+func TestBlocksService_SoftDeleteWithBunStorageIsAtomic(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	registerBlockModels(t, bunDB)
+	if _, err := bunDB.NewCreateTable().Model((*trash.Entry)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create trash table: %v", err)
+	}
+
+	instRepo := blocks.NewBunInstanceRepository(bunDB)
+	trRepo := blocks.NewBunTranslationRepository(bunDB)
+	trashRepo := trash.NewBunRepository(bunDB)
+	svc := blocks.NewService(blocks.NewBunDefinitionRepository(bunDB), instRepo, trRepo, blocks.WithTrashRepository(trashRepo))
+
+	def, err := svc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	inst, err := svc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID: def.ID,
+		Region:       "hero",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	if _, err := svc.AddTranslation(ctx, blocks.AddTranslationInput{
+		BlockInstanceID: inst.ID,
+		LocaleID:        uuid.MustParse("00000000-0000-0000-0000-000000000201"),
+		Content:         map[string]any{"title": "Hello"},
+	}); err != nil {
+		t.Fatalf("add translation: %v", err)
+	}
+
+	if err := svc.DeleteInstance(ctx, blocks.DeleteInstanceRequest{ID: inst.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	var notFound *blocks.NotFoundError
+	if _, err := instRepo.GetByID(ctx, inst.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected instance to be removed, got %v", err)
+	}
+	if translations, err := trRepo.ListByInstance(ctx, inst.ID); err == nil && len(translations) != 0 {
+		t.Fatalf("expected translations to be removed, got %d", len(translations))
+	}
+	if trashed, err := svc.ListTrashedInstances(ctx, blocks.ListTrashedInstancesRequest{Limit: 10}); err != nil || len(trashed) != 1 {
+		t.Fatalf("expected trashed instance in the default environment, got %d (%v)", len(trashed), err)
+	}
+	if staging, err := svc.ListTrashedInstances(ctx, blocks.ListTrashedInstancesRequest{Environment: "staging"}); err != nil || len(staging) != 0 {
+		t.Fatalf("expected no trashed instances in another environment, got %d (%v)", len(staging), err)
+	}
+	restored, err := svc.RestoreDeletedInstance(ctx, blocks.RestoreInstanceRequest{ID: inst.ID})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if len(restored.Translations) != 1 {
+		t.Fatalf("expected restored translation, got %d", len(restored.Translations))
+	}
+
+	// A failed delete rolls the snapshot back with it.
+	missing := uuid.New()
+	entry, err := trash.NewEntry(trash.ResourceBlockInstance, missing, map[string]string{"region": "hero"}, uuid.Nil, time.Now())
+	if err != nil {
+		t.Fatalf("new entry: %v", err)
+	}
+	if err := instRepo.DeleteToTrash(ctx, missing, entry); !errors.As(err, &notFound) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourceBlockInstance, missing); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected the snapshot to be rolled back, got %v", err)
+	}
+}
+
 func registerBlockModels(t *testing.T, db *bun.DB) {
 	t.Helper()
 	ctx := context.Background()
@@ -113,6 +200,7 @@ func registerBlockModels(t *testing.T, db *bun.DB) {
 			schema_version TEXT NOT NULL,
 			schema TEXT NOT NULL,
 			defaults TEXT,
+			migration TEXT,
 			created_at TEXT,
 			updated_at TEXT
 		)`,
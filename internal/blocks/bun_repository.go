@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-errors"
 	"github.com/goliatone/go-repository-bun"
 	"github.com/goliatone/go-repository-cache/cache"
@@ -188,6 +189,7 @@ func (r *BunDefinitionVersionRepository) Update(ctx context.Context, version *De
 
 // BunInstanceRepository implements InstanceRepository with optional caching.
 type BunInstanceRepository struct {
+	db   *bun.DB
 	repo repository.Repository[*Instance]
 }
 
@@ -202,7 +204,7 @@ func NewBunInstanceRepositoryWithCache(db *bun.DB, cacheService cache.CacheServi
 	if cacheService != nil && serializer != nil {
 		base = repositorycache.New(base, cacheService, serializer)
 	}
-	return &BunInstanceRepository{repo: base}
+	return &BunInstanceRepository{db: db, repo: base}
 }
 
 func (r *BunInstanceRepository) Create(ctx context.Context, instance *Instance) (*Instance, error) {
@@ -269,6 +271,47 @@ func (r *BunInstanceRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return r.repo.Delete(ctx, &Instance{ID: id})
 }
 
+// DeleteToTrash stores the trash entry and removes the instance with its
+// translations and versions in one transaction, so a failed delete never
+// leaves a snapshot next to a partially removed instance.
+func (r *BunInstanceRepository) DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error {
+	if r.db == nil {
+		return fmt.Errorf("block instance repository: database not configured")
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := trash.Insert(ctx, tx, entry); err != nil {
+			return fmt.Errorf("insert trash entry: %w", err)
+		}
+		if _, err := tx.NewDelete().
+			Model((*Translation)(nil)).
+			Where("?TableAlias.block_instance_id = ?", id).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete block translations: %w", err)
+		}
+		if _, err := tx.NewDelete().
+			Model((*InstanceVersion)(nil)).
+			Where("?TableAlias.block_instance_id = ?", id).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete block versions: %w", err)
+		}
+		result, err := tx.NewDelete().
+			Model((*Instance)(nil)).
+			Where("?TableAlias.id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete block instance: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("block instance delete rows affected: %w", err)
+		}
+		if affected == 0 {
+			return &NotFoundError{Resource: "block_instance", Key: id.String()}
+		}
+		return nil
+	})
+}
+
 // BunInstanceVersionRepository implements InstanceVersionRepository with optional caching.
 type BunInstanceVersionRepository struct {
 	repo repository.Repository[*InstanceVersion]
@@ -358,6 +401,16 @@ func (r *BunInstanceVersionRepository) Update(ctx context.Context, version *Inst
 	return updated, nil
 }
 
+func (r *BunInstanceVersionRepository) DeleteVersions(ctx context.Context, instanceID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	return r.repo.DeleteMany(ctx,
+		repository.DeleteBy("block_instance_id", "=", instanceID.String()),
+		repository.DeleteColumnIn("version", numbers),
+	)
+}
+
 // BunTranslationRepository implements TranslationRepository with optional caching.
 type BunTranslationRepository struct {
 	repo repository.Repository[*Translation]
@@ -259,7 +259,7 @@ func (b *EmbeddedBlockBridge) MigrateEmbeddedBlocks(ctx context.Context, locale
 		if err := b.validateImmutableType(ctx, locale, idx, blockType, block, defsByID); err != nil {
 			return nil, err
 		}
-		migrated, err := b.migrateEmbeddedBlock(def, block)
+		migrated, err := b.migrateEmbeddedBlock(ctx, def, block)
 		if err != nil {
 			return nil, err
 		}
@@ -1251,7 +1251,7 @@ func (b *EmbeddedBlockBridge) definitionSchemaVersion(ctx context.Context, def *
 	return record.Schema, nil
 }
 
-func (b *EmbeddedBlockBridge) migrateEmbeddedBlock(def *Definition, block map[string]any) (map[string]any, error) {
+func (b *EmbeddedBlockBridge) migrateEmbeddedBlock(ctx context.Context, def *Definition, block map[string]any) (map[string]any, error) {
 	if def == nil {
 		return nil, ErrEmbeddedBlockDefinitionMissing
 	}
@@ -1271,7 +1271,10 @@ func (b *EmbeddedBlockBridge) migrateEmbeddedBlock(def *Definition, block map[st
 	payload := sanitizeEmbeddedBlockPayload(block)
 	migrated := payload
 	if hasCurrent && current.String() != target.String() {
-		migrator := b.schemaMigrator()
+		migrator, err := b.schemaMigrator(ctx, def, target.Slug)
+		if err != nil {
+			return nil, err
+		}
 		if migrator == nil {
 			return nil, ErrBlockSchemaMigrationRequired
 		}
@@ -1291,14 +1294,14 @@ func (b *EmbeddedBlockBridge) migrateEmbeddedBlock(def *Definition, block map[st
 	return applySchemaVersion(migrated, target), nil
 }
 
-func (b *EmbeddedBlockBridge) schemaMigrator() *Migrator {
+func (b *EmbeddedBlockBridge) schemaMigrator(ctx context.Context, def *Definition, slug string) (*Migrator, error) {
 	if b == nil {
-		return nil
+		return nil, nil
 	}
 	if svc, ok := b.blocks.(*service); ok && svc != nil {
-		return svc.schemaMigrator
+		return svc.definitionMigrator(ctx, def, slug)
 	}
-	return nil
+	return nil, nil
 }
 
 func (b *EmbeddedBlockBridge) validateImmutableType(ctx context.Context, locale string, index int, blockType string, block map[string]any, defsByID map[uuid.UUID]*Definition) error {
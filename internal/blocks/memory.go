@@ -10,6 +10,7 @@ import (
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/media"
+	cmsschema "github.com/goliatone/go-cms/internal/schema"
 	"github.com/google/uuid"
 )
 
@@ -456,6 +457,32 @@ func (m *memoryInstanceVersionRepository) Update(_ context.Context, version *Ins
 	return nil, &NotFoundError{Resource: "block_version", Key: versionKey(version.BlockInstanceID, version.Version)}
 }
 
+func (m *memoryInstanceVersionRepository) DeleteVersions(_ context.Context, instanceID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	drop := make(map[int]struct{}, len(numbers))
+	for _, number := range numbers {
+		drop[number] = struct{}{}
+	}
+	queue := m.byInstance[instanceID]
+	kept := make([]*InstanceVersion, 0, len(queue))
+	for _, version := range queue {
+		if version == nil {
+			continue
+		}
+		if _, ok := drop[version.Version]; ok {
+			continue
+		}
+		kept = append(kept, version)
+	}
+	m.byInstance[instanceID] = kept
+	return nil
+}
+
 // NewMemoryTranslationRepository constructs an "in memory" translation repository.
 func NewMemoryTranslationRepository() TranslationRepository {
 	return &memoryTranslationRepository{
@@ -576,6 +603,7 @@ func cloneDefinitionVersion(src *DefinitionVersion) *DefinitionVersion {
 	if src.Defaults != nil {
 		cloned.Defaults = maps.Clone(src.Defaults)
 	}
+	cloned.Migration = cmsschema.CloneMigrationOperations(src.Migration)
 	return &cloned
 }
 
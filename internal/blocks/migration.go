@@ -1,6 +1,13 @@
 package blocks
 
-import cmsschema "github.com/goliatone/go-cms/internal/schema"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	cmsschema "github.com/goliatone/go-cms/internal/schema"
+	"github.com/goliatone/go-cms/internal/validation"
+)
 
 // MigrationFunc transforms a block payload between schema versions.
 type MigrationFunc func(map[string]any) (map[string]any, error)
@@ -40,3 +47,85 @@ func (m *Migrator) Migrate(slug, from, to string, payload map[string]any) (map[s
 	}
 	return m.inner.Migrate(slug, from, to, payload)
 }
+
+// RegisterOperations adds a migration step built from declarative operations.
+func (m *Migrator) RegisterOperations(slug, from, to string, ops []SchemaMigrationOperation) error {
+	if m == nil {
+		return cmsschema.ErrInvalidSchemaVersion
+	}
+	if m.inner == nil {
+		m.inner = cmsschema.NewMigrator()
+	}
+	return m.inner.RegisterOperations(slug, from, to, ops)
+}
+
+// Extend returns a copy of the migrator with additional steps for slug.
+// Steps already registered take precedence over the extra ones.
+func (m *Migrator) Extend(slug string, steps ...cmsschema.MigrationStep) *Migrator {
+	var inner *cmsschema.Migrator
+	if m != nil {
+		inner = m.inner
+	}
+	return &Migrator{inner: inner.Extend(slug, steps...)}
+}
+
+// DefinitionMigrator extends base with the operations stored on the
+// definition's schema versions. Steps registered on base take precedence.
+func DefinitionMigrator(base *Migrator, definition *Definition, versions []*DefinitionVersion) *Migrator {
+	if definition == nil {
+		return base
+	}
+	return extendDefinitionMigrator(base, definitionSlug(definition), versions)
+}
+
+// MigrateDefinitionPayload migrates a block payload to the definition's
+// current schema version. The boolean reports whether migration steps ran.
+func MigrateDefinitionPayload(migrator *Migrator, definition *Definition, payload map[string]any) (map[string]any, bool, error) {
+	if definition == nil {
+		return nil, false, ErrDefinitionIDRequired
+	}
+	target, err := resolveDefinitionSchemaVersion(definition.Schema, definitionSlug(definition))
+	if err != nil {
+		return nil, false, ErrDefinitionSchemaVersionInvalid
+	}
+	migrated, didMigrate, err := migratePayload(migrator, definitionSlug(definition), target, payload)
+	if err != nil || !didMigrate || definition.Schema == nil {
+		return migrated, didMigrate, err
+	}
+	if err := validation.ValidateMigrationPayload(definition.Schema, stripSchemaVersion(migrated)); err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrBlockSchemaValidationFailed, err)
+	}
+	return migrated, true, nil
+}
+
+func extendDefinitionMigrator(base *Migrator, slug string, versions []*DefinitionVersion) *Migrator {
+	steps := definitionVersionMigrationSteps(versions)
+	if len(steps) == 0 {
+		return base
+	}
+	return base.Extend(strings.TrimSpace(slug), steps...)
+}
+
+func definitionVersionMigrationSteps(versions []*DefinitionVersion) []cmsschema.MigrationStep {
+	ordered := make([]*DefinitionVersion, 0, len(versions))
+	for _, version := range versions {
+		if version != nil {
+			ordered = append(ordered, version)
+		}
+	}
+	if len(ordered) < 2 {
+		return nil
+	}
+	slices.SortFunc(ordered, func(a, b *DefinitionVersion) int {
+		return compareSchemaVersions(a.SchemaVersion, b.SchemaVersion)
+	})
+	history := make([]cmsschema.VersionedSchema, 0, len(ordered))
+	for _, version := range ordered {
+		history = append(history, cmsschema.VersionedSchema{
+			Version:   version.SchemaVersion,
+			Schema:    version.Schema,
+			Migration: version.Migration,
+		})
+	}
+	return cmsschema.HistoryMigrationSteps(history)
+}
@@ -2,6 +2,16 @@ package blocks
 
 import "strings"
 
+// Definition migration statuses reported alongside schema versions.
+const (
+	// MigrationStatusCurrent marks definitions whose stored payloads match the schema version.
+	MigrationStatusCurrent = "current"
+	// MigrationStatusMigrating marks definitions with a bulk payload migration in progress.
+	MigrationStatusMigrating = "migrating"
+	// MigrationStatusFailed marks definitions whose last bulk migration left records behind.
+	MigrationStatusFailed = "failed"
+)
+
 // ResolveDefinitionMigrationStatus determines the current migration status for a schema.
 func ResolveDefinitionMigrationStatus(schema map[string]any, schemaVersion string) string {
 	status := schemaMigrationStatusFromSchema(schema)
@@ -15,7 +25,7 @@ func ResolveDefinitionMigrationStatus(schema map[string]any, schemaVersion strin
 	if metaVersion != "" && strings.TrimSpace(schemaVersion) != "" && metaVersion != schemaVersion {
 		return "mismatch"
 	}
-	return "current"
+	return MigrationStatusCurrent
 }
 
 func schemaVersionFromSchema(schema map[string]any) string {
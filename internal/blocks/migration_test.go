@@ -108,3 +108,76 @@ func TestPublishDraftRejectsInvalidMigratedPayload(t *testing.T) {
 		t.Fatalf("expected validation failure, got %v", err)
 	}
 }
+
+func TestPublishDraftRunsStoredDefinitionMigration(t *testing.T) {
+	ctx := context.Background()
+	svc := newBlockService(blocks.WithVersioningEnabled(true))
+
+	def, err := svc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name: "banner",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"heading": map[string]any{"type": "string"},
+			},
+			"metadata": map[string]any{"schema_version": "banner@v1.0.0"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+
+	instance, err := svc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID: def.ID,
+		Region:       "main",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	draft, err := svc.CreateDraft(ctx, blocks.CreateInstanceDraftRequest{
+		InstanceID: instance.ID,
+		Snapshot: blocks.BlockVersionSnapshot{
+			Translations: []blocks.BlockVersionTranslationSnapshot{{
+				Locale:  "en",
+				Content: map[string]any{"_schema": "banner@v1.0.0", "heading": "Hello"},
+			}},
+		},
+		CreatedBy: uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	version, err := svc.CreateDefinitionVersion(ctx, blocks.CreateDefinitionVersionInput{
+		DefinitionID: def.ID,
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+			},
+			"required": []any{"title"},
+			"metadata": map[string]any{"schema_version": "banner@v2.0.0"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create definition version: %v", err)
+	}
+	if len(version.Migration) == 0 || version.Migration[0].Op != "rename" || version.Migration[0].To != "title" {
+		t.Fatalf("expected suggested rename to be stored, got %+v", version.Migration)
+	}
+
+	published, err := svc.PublishDraft(ctx, blocks.PublishInstanceDraftRequest{
+		InstanceID:  instance.ID,
+		Version:     draft.Version,
+		PublishedBy: uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("publish draft: %v", err)
+	}
+	payload := published.Snapshot.Translations[0].Content
+	if payload["_schema"] != "banner@v2.0.0" || payload["title"] != "Hello" {
+		t.Fatalf("expected migrated payload, got %+v", payload)
+	}
+}
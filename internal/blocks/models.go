@@ -10,6 +10,7 @@ type (
 	InstanceVersion                 = cmsblocks.InstanceVersion
 	BlockVersionSnapshot            = cmsblocks.BlockVersionSnapshot
 	BlockVersionTranslationSnapshot = cmsblocks.BlockVersionTranslationSnapshot
+	SchemaMigrationOperation        = cmsblocks.SchemaMigrationOperation
 )
 
 var BlockVersionSnapshotSchema = cmsblocks.BlockVersionSnapshotSchema
@@ -3,20 +3,23 @@ package blocks
 import cmsblocks "github.com/goliatone/go-cms/blocks"
 
 type (
-	Service                       = cmsblocks.Service
-	RegisterDefinitionInput       = cmsblocks.RegisterDefinitionInput
-	UpdateDefinitionInput         = cmsblocks.UpdateDefinitionInput
-	CreateDefinitionVersionInput  = cmsblocks.CreateDefinitionVersionInput
-	DeleteDefinitionRequest       = cmsblocks.DeleteDefinitionRequest
-	CreateInstanceInput           = cmsblocks.CreateInstanceInput
-	UpdateInstanceInput           = cmsblocks.UpdateInstanceInput
-	DeleteInstanceRequest         = cmsblocks.DeleteInstanceRequest
-	AddTranslationInput           = cmsblocks.AddTranslationInput
-	UpdateTranslationInput        = cmsblocks.UpdateTranslationInput
-	DeleteTranslationRequest      = cmsblocks.DeleteTranslationRequest
-	CreateInstanceDraftRequest    = cmsblocks.CreateInstanceDraftRequest
-	PublishInstanceDraftRequest   = cmsblocks.PublishInstanceDraftRequest
-	RestoreInstanceVersionRequest = cmsblocks.RestoreInstanceVersionRequest
+	Service                        = cmsblocks.Service
+	RegisterDefinitionInput        = cmsblocks.RegisterDefinitionInput
+	UpdateDefinitionInput          = cmsblocks.UpdateDefinitionInput
+	CreateDefinitionVersionInput   = cmsblocks.CreateDefinitionVersionInput
+	DeleteDefinitionRequest        = cmsblocks.DeleteDefinitionRequest
+	CreateInstanceInput            = cmsblocks.CreateInstanceInput
+	UpdateInstanceInput            = cmsblocks.UpdateInstanceInput
+	DeleteInstanceRequest          = cmsblocks.DeleteInstanceRequest
+	ListTrashedInstancesRequest    = cmsblocks.ListTrashedInstancesRequest
+	RestoreInstanceRequest         = cmsblocks.RestoreInstanceRequest
+	RestoreInstanceSnapshotRequest = cmsblocks.RestoreInstanceSnapshotRequest
+	AddTranslationInput            = cmsblocks.AddTranslationInput
+	UpdateTranslationInput         = cmsblocks.UpdateTranslationInput
+	DeleteTranslationRequest       = cmsblocks.DeleteTranslationRequest
+	CreateInstanceDraftRequest     = cmsblocks.CreateInstanceDraftRequest
+	PublishInstanceDraftRequest    = cmsblocks.PublishInstanceDraftRequest
+	RestoreInstanceVersionRequest  = cmsblocks.RestoreInstanceVersionRequest
 )
 
 var (
@@ -40,6 +43,7 @@ var (
 	ErrInstancePositionInvalid       = cmsblocks.ErrInstancePositionInvalid
 	ErrInstanceUpdaterRequired       = cmsblocks.ErrInstanceUpdaterRequired
 	ErrInstanceSoftDeleteUnsupported = cmsblocks.ErrInstanceSoftDeleteUnsupported
+	ErrInstanceNotTrashed            = cmsblocks.ErrInstanceNotTrashed
 
 	ErrTranslationContentRequired       = cmsblocks.ErrTranslationContentRequired
 	ErrTranslationExists                = cmsblocks.ErrTranslationExists
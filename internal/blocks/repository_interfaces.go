@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/google/uuid"
 )
 
@@ -37,6 +38,15 @@ type InstanceRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// InstanceTrasher is implemented by instance repositories that can store a
+// trash entry and remove an instance with its translations and versions in a
+// single transaction. Repositories without transactional support return
+// errors.ErrUnsupported and the service falls back to writing the entry
+// through its trash repository.
+type InstanceTrasher interface {
+	DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error
+}
+
 // InstanceVersionRepository exposes persistence operations for block instance versions.
 type InstanceVersionRepository interface {
 	Create(ctx context.Context, version *InstanceVersion) (*InstanceVersion, error)
@@ -44,6 +54,7 @@ type InstanceVersionRepository interface {
 	GetVersion(ctx context.Context, instanceID uuid.UUID, number int) (*InstanceVersion, error)
 	GetLatest(ctx context.Context, instanceID uuid.UUID) (*InstanceVersion, error)
 	Update(ctx context.Context, version *InstanceVersion) (*InstanceVersion, error)
+	DeleteVersions(ctx context.Context, instanceID uuid.UUID, numbers []int) error
 }
 
 // TranslationRepository exposes persistence operations for block translations.
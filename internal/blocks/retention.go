@@ -0,0 +1,113 @@
+package blocks
+
+import (
+	"context"
+
+	"github.com/goliatone/go-cms/internal/domain"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/google/uuid"
+)
+
+// pruneVersions removes the versions of an instance that the retention policy
+// no longer keeps and returns the removed version numbers.
+func (s *service) pruneVersions(ctx context.Context, instance *Instance, actor uuid.UUID) ([]int, error) {
+	versions, err := s.versions.ListByInstance(ctx, instance.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]retention.Version, 0, len(versions))
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		candidates = append(candidates, retention.Version{
+			Number:    version.Version,
+			CreatedAt: version.CreatedAt,
+			Published: version.PublishedAt != nil || version.Status == domain.StatusPublished,
+		})
+	}
+	numbers := s.retention.Select(candidates, retention.Protected(instance.CurrentVersion, instance.PublishedVersion), s.now())
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	if err := s.versions.DeleteVersions(ctx, instance.ID, numbers); err != nil {
+		return nil, err
+	}
+
+	s.emitActivity(ctx, actor, "prune_versions", "block_instance", instance.ID, map[string]any{
+		"versions":      numbers,
+		"definition_id": instance.DefinitionID.String(),
+	})
+	return numbers, nil
+}
+
+// PruneAllVersions applies the retention policy to the instances of every
+// block definition in every environment.
+func (s *service) PruneAllVersions(ctx context.Context) ([]retention.Pruned, error) {
+	if !s.versioningEnabled || s.versions == nil || !s.retention.Prunes() {
+		return nil, nil
+	}
+	envIDs, err := s.environmentIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []retention.Pruned
+	for _, envID := range envIDs {
+		definitions, err := s.definitions.List(ctx, envID.String())
+		if err != nil {
+			return pruned, err
+		}
+		for _, definition := range definitions {
+			if definition == nil {
+				continue
+			}
+			instances, err := s.instances.ListByDefinition(ctx, definition.ID)
+			if err != nil {
+				return pruned, err
+			}
+			for _, instance := range instances {
+				if instance == nil {
+					continue
+				}
+				numbers, err := s.pruneVersions(ctx, instance, uuid.Nil)
+				if err != nil {
+					return pruned, err
+				}
+				if len(numbers) > 0 {
+					pruned = append(pruned, retention.Pruned{
+						Resource: retention.ResourceBlockInstance,
+						EntityID: instance.ID,
+						Versions: numbers,
+					})
+				}
+			}
+		}
+	}
+	return pruned, nil
+}
+
+// environmentIDs lists the environments the service stores definitions in.
+// Without an environment service only the default environment is known.
+func (s *service) environmentIDs(ctx context.Context) ([]uuid.UUID, error) {
+	if s.envSvc == nil {
+		key := cmsenv.NormalizeKey(s.defaultEnvKey)
+		if key == "" {
+			key = cmsenv.DefaultKey
+		}
+		return []uuid.UUID{cmsenv.IDForKey(key)}, nil
+	}
+	envs, err := s.envSvc.ListEnvironments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(envs))
+	for _, env := range envs {
+		if env != nil {
+			ids = append(ids, env.ID)
+		}
+	}
+	return ids, nil
+}
+
+var _ retention.Source = (*service)(nil)
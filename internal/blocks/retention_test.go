@@ -0,0 +1,140 @@
+package blocks_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/google/uuid"
+)
+
+func TestServiceRetentionPrunesInstanceVersions(t *testing.T) {
+	cases := []struct {
+		name       string
+		mode       retention.Mode
+		afterWrite []int
+	}{
+		{name: "prune on write", mode: retention.ModePrune, afterWrite: []int{3, 4}},
+		{name: "scheduled", mode: retention.ModeScheduled, afterWrite: []int{1, 2, 3, 4}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newBlockService(
+				blocks.WithVersioningEnabled(true),
+				blocks.WithVersionRetentionPolicy(retention.Policy{Mode: tc.mode, KeepLast: 2}),
+			)
+			def, err := svc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+				Name:   "hero",
+				Schema: map[string]any{"fields": []any{"title"}},
+			})
+			if err != nil {
+				t.Fatalf("register definition: %v", err)
+			}
+			authorID := uuid.New()
+			instance, err := svc.CreateInstance(ctx, blocks.CreateInstanceInput{
+				DefinitionID:  def.ID,
+				Region:        "hero",
+				Configuration: map[string]any{"variant": "primary"},
+				CreatedBy:     authorID,
+				UpdatedBy:     authorID,
+			})
+			if err != nil {
+				t.Fatalf("create instance: %v", err)
+			}
+			for range 4 {
+				if _, err := svc.UpdateInstance(ctx, blocks.UpdateInstanceInput{
+					InstanceID:    instance.ID,
+					Configuration: map[string]any{"variant": "secondary"},
+					UpdatedBy:     authorID,
+				}); err != nil {
+					t.Fatalf("update instance: %v", err)
+				}
+			}
+			assertInstanceVersions(t, svc, instance.ID, tc.afterWrite)
+
+			pruned, err := svc.(retention.Source).PruneAllVersions(ctx)
+			if err != nil {
+				t.Fatalf("prune all versions: %v", err)
+			}
+			if tc.mode == retention.ModeScheduled && (len(pruned) != 1 || pruned[0].Resource != retention.ResourceBlockInstance) {
+				t.Fatalf("unexpected prune report %+v", pruned)
+			}
+			assertInstanceVersions(t, svc, instance.ID, []int{3, 4})
+		})
+	}
+}
+
+func TestServiceRetentionPruneFailureKeepsWrite(t *testing.T) {
+	ctx := context.Background()
+	versions := &failingPruneVersionRepository{InstanceVersionRepository: blocks.NewMemoryInstanceVersionRepository()}
+	svc := newBlockService(
+		blocks.WithVersioningEnabled(true),
+		blocks.WithVersionRetentionPolicy(retention.Policy{Mode: retention.ModePrune, KeepLast: 1}),
+		blocks.WithInstanceVersionRepository(versions),
+	)
+	def, err := svc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	authorID := uuid.New()
+	instance, err := svc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID:  def.ID,
+		Region:        "hero",
+		Configuration: map[string]any{"variant": "primary"},
+		CreatedBy:     authorID,
+		UpdatedBy:     authorID,
+	})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	if _, err := svc.UpdateInstance(ctx, blocks.UpdateInstanceInput{
+		InstanceID:    instance.ID,
+		Configuration: map[string]any{"variant": "secondary"},
+		UpdatedBy:     authorID,
+	}); err != nil {
+		t.Fatalf("update instance: %v", err)
+	}
+	if _, err := svc.CreateDraft(ctx, blocks.CreateInstanceDraftRequest{
+		InstanceID: instance.ID,
+		Snapshot:   blocks.BlockVersionSnapshot{Configuration: map[string]any{"variant": "draft"}},
+		CreatedBy:  authorID,
+	}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	if versions.attempts == 0 {
+		t.Fatal("expected pruning to be attempted")
+	}
+	assertInstanceVersions(t, svc, instance.ID, []int{1, 2})
+}
+
+type failingPruneVersionRepository struct {
+	blocks.InstanceVersionRepository
+	attempts int
+}
+
+func (r *failingPruneVersionRepository) DeleteVersions(context.Context, uuid.UUID, []int) error {
+	r.attempts++
+	return errors.New("prune unavailable")
+}
+
+func assertInstanceVersions(t *testing.T, svc blocks.Service, instanceID uuid.UUID, want []int) {
+	t.Helper()
+	versions, err := svc.ListVersions(context.Background(), instanceID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	got := make([]int, 0, len(versions))
+	for _, version := range versions {
+		got = append(got, version.Version)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected versions %v, got %v", want, got)
+	}
+}
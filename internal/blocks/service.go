@@ -11,12 +11,16 @@ import (
 	"github.com/goliatone/go-cms/internal/domain"
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/identity"
+	"github.com/goliatone/go-cms/internal/logging"
 	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/internal/retention"
 	cmsschema "github.com/goliatone/go-cms/internal/schema"
 	"github.com/goliatone/go-cms/internal/translationconfig"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/internal/validation"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/goliatone/go-slug"
 	"github.com/google/uuid"
 )
@@ -117,6 +121,15 @@ func WithInstanceVersionRepository(repo InstanceVersionRepository) ServiceOption
 }
 
 // WithShortcodeService wires the shortcode renderer used to process translation content.
+// WithTrashRepository enables soft deletes of instances through the trash.
+func WithTrashRepository(repo trash.Repository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.trash = repo
+		}
+	}
+}
+
 func WithShortcodeService(svc interfaces.ShortcodeService) ServiceOption {
 	return func(s *service) {
 		s.shortcodes = svc
@@ -132,6 +145,16 @@ func WithActivityEmitter(emitter *activity.Emitter) ServiceOption {
 	}
 }
 
+// WithLifecycleEmitter wires the emitter notified when block instances or
+// their translations change.
+func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
+	return func(s *service) {
+		if emitter != nil {
+			s.lifecycle = emitter
+		}
+	}
+}
+
 // WithVersioningEnabled toggles versioning workflows for block instances.
 func WithVersioningEnabled(enabled bool) ServiceOption {
 	return func(s *service) {
@@ -145,7 +168,18 @@ func WithVersionRetentionLimit(limit int) ServiceOption {
 		if limit < 0 {
 			limit = 0
 		}
-		s.versionRetentionLimit = limit
+		s.retention.KeepLast = limit
+	}
+}
+
+// WithVersionRetentionPolicy configures how instance version history is
+// limited. It replaces any limit set by WithVersionRetentionLimit.
+func WithVersionRetentionPolicy(policy retention.Policy) ServiceOption {
+	return func(s *service) {
+		if policy.KeepLast < 0 {
+			policy.KeepLast = 0
+		}
+		s.retention = policy
 	}
 }
 
@@ -179,30 +213,42 @@ func WithDefinitionSlugNormalizer(normalizer slug.Normalizer) ServiceOption {
 	}
 }
 
+// WithLogger assigns the logger used by the service. When omitted, a no-op logger is used.
+func WithLogger(logger interfaces.Logger) ServiceOption {
+	return func(s *service) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
 type service struct {
-	definitions           DefinitionRepository
-	definitionVersions    DefinitionVersionRepository
-	instances             InstanceRepository
-	translations          TranslationRepository
-	versions              InstanceVersionRepository
-	now                   func() time.Time
-	id                    IDGenerator
-	idCustom              bool
-	registry              *Registry
-	schemaMigrator        *Migrator
-	media                 media.Service
-	versioningEnabled     bool
-	versionRetentionLimit int
-	shortcodes            interfaces.ShortcodeService
-	requireTranslations   bool
-	translationsEnabled   bool
-	translationState      *translationconfig.State
-	slugger               slug.Normalizer
-	activity              *activity.Emitter
-	envSvc                cmsenv.Service
-	defaultEnvKey         string
-	requireExplicitEnv    bool
-	requireActiveEnv      bool
+	definitions         DefinitionRepository
+	definitionVersions  DefinitionVersionRepository
+	instances           InstanceRepository
+	translations        TranslationRepository
+	versions            InstanceVersionRepository
+	now                 func() time.Time
+	id                  IDGenerator
+	idCustom            bool
+	registry            *Registry
+	schemaMigrator      *Migrator
+	media               media.Service
+	versioningEnabled   bool
+	retention           retention.Policy
+	shortcodes          interfaces.ShortcodeService
+	requireTranslations bool
+	translationsEnabled bool
+	translationState    *translationconfig.State
+	slugger             slug.Normalizer
+	activity            *activity.Emitter
+	lifecycle           *lifecycle.Emitter
+	envSvc              cmsenv.Service
+	defaultEnvKey       string
+	requireExplicitEnv  bool
+	requireActiveEnv    bool
+	trash               trash.Repository
+	logger              interfaces.Logger
 }
 
 func NewService(defRepo DefinitionRepository, instRepo InstanceRepository, trRepo TranslationRepository, opts ...ServiceOption) Service {
@@ -216,7 +262,9 @@ func NewService(defRepo DefinitionRepository, instRepo InstanceRepository, trRep
 		translationsEnabled: true,
 		slugger:             slug.Default(),
 		activity:            activity.NewEmitter(nil, activity.Config{}),
+		lifecycle:           lifecycle.NewEmitter(nil, lifecycle.Config{}),
 		defaultEnvKey:       cmsenv.DefaultKey,
+		logger:              logging.ModuleLogger(nil, "cms.blocks"),
 	}
 
 	for _, opt := range opts {
@@ -234,6 +282,16 @@ func NewService(defRepo DefinitionRepository, instRepo InstanceRepository, trRep
 	return s
 }
 
+func (s *service) opLogger(ctx context.Context, operation string, extra map[string]any) interfaces.Logger {
+	logger := s.logger
+	if ctx != nil {
+		logger = logger.WithContext(ctx)
+	}
+	fields := map[string]any{"operation": operation}
+	maps.Copy(fields, extra)
+	return logging.WithFields(logger, fields)
+}
+
 func (s *service) translationsRequired() bool {
 	enabled := s.translationsEnabled
 	required := s.requireTranslations
@@ -276,6 +334,26 @@ func (s *service) emitActivity(ctx context.Context, actor uuid.UUID, verb, objec
 	_ = s.activity.Emit(ctx, event)
 }
 
+// emitInstanceLifecycle reports a change to the rendered output of a block
+// instance. Dependent caches and builds key on the instance ID.
+func (s *service) emitInstanceLifecycle(ctx context.Context, instanceID uuid.UUID, transition string, meta map[string]any) {
+	if s.lifecycle == nil || !s.lifecycle.Enabled() || instanceID == uuid.Nil {
+		return
+	}
+	event := lifecycle.Event{
+		ResourceType: "block",
+		RecordID:     instanceID.String(),
+		Transition:   transition,
+		Metadata:     meta,
+	}
+	if raw, ok := meta["environment_id"].(string); ok {
+		if parsed, err := uuid.Parse(raw); err == nil {
+			event.EnvironmentKey = s.environmentKeyForID(ctx, parsed)
+		}
+	}
+	_ = s.lifecycle.Emit(ctx, event)
+}
+
 func (s *service) resolveEnvironment(ctx context.Context, key string) (uuid.UUID, string, error) {
 	trimmed := strings.TrimSpace(key)
 	if trimmed == "" && s.requireExplicitEnv {
@@ -429,7 +507,7 @@ func (s *service) RegisterDefinition(ctx context.Context, input RegisterDefiniti
 		return nil, err
 	}
 	if s.definitionVersions != nil {
-		if _, err := s.upsertDefinitionVersion(ctx, created, normalizedSchema, input.Defaults, version, true); err != nil {
+		if _, err := s.upsertDefinitionVersion(ctx, created, normalizedSchema, input.Defaults, version, nil, true); err != nil {
 			return nil, err
 		}
 	}
@@ -459,6 +537,9 @@ func (s *service) UpdateDefinition(ctx context.Context, input UpdateDefinitionIn
 	if input.ID == uuid.Nil {
 		return nil, ErrDefinitionIDRequired
 	}
+	if err := cmsschema.ValidateMigrationOperations(input.Migration); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionSchemaInvalid, err)
+	}
 	definition, err := s.definitions.GetByID(ctx, input.ID)
 	if err != nil {
 		return nil, err
@@ -581,7 +662,7 @@ func (s *service) UpdateDefinition(ctx context.Context, input UpdateDefinitionIn
 		return nil, err
 	}
 	if s.definitionVersions != nil && (schemaUpdated || defaultsUpdated) {
-		if _, err := s.upsertDefinitionVersion(ctx, updated, normalizedSchema, updated.Defaults, version, false); err != nil {
+		if _, err := s.upsertDefinitionVersion(ctx, updated, normalizedSchema, updated.Defaults, version, input.Migration, false); err != nil {
 			return nil, err
 		}
 	}
@@ -620,6 +701,9 @@ func (s *service) CreateDefinitionVersion(ctx context.Context, input CreateDefin
 	if input.Schema == nil {
 		return nil, ErrDefinitionSchemaRequired
 	}
+	if err := cmsschema.ValidateMigrationOperations(input.Migration); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDefinitionSchemaInvalid, err)
+	}
 	definition, err := s.definitions.GetByID(ctx, input.DefinitionID)
 	if err != nil {
 		return nil, err
@@ -645,7 +729,7 @@ func (s *service) CreateDefinitionVersion(ctx context.Context, input CreateDefin
 			return nil, err
 		}
 	}
-	created, err := s.upsertDefinitionVersion(ctx, definition, normalizedSchema, input.Defaults, version, true)
+	created, err := s.upsertDefinitionVersion(ctx, definition, normalizedSchema, input.Defaults, version, input.Migration, true)
 	if err != nil {
 		return nil, err
 	}
@@ -736,6 +820,7 @@ func (s *service) CreateInstance(ctx context.Context, input CreateInstanceInput)
 		meta["environment_id"] = definition.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, pickActor(input.CreatedBy, input.UpdatedBy), "create", "block_instance", created.ID, meta)
+	s.emitInstanceLifecycle(ctx, created.ID, "create", meta)
 
 	return created, nil
 }
@@ -820,7 +905,7 @@ func (s *service) UpdateInstance(ctx context.Context, input UpdateInstanceInput)
 		return nil, err
 	}
 
-	if err := s.persistVersion(ctx, preparedVersion); err != nil {
+	if err := s.persistVersion(ctx, instance, preparedVersion); err != nil {
 		return nil, err
 	}
 
@@ -847,6 +932,7 @@ func (s *service) UpdateInstance(ctx context.Context, input UpdateInstanceInput)
 		meta["environment_id"] = definition.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, input.UpdatedBy, verb, "block_instance", updated.ID, meta)
+	s.emitInstanceLifecycle(ctx, updated.ID, verb, meta)
 
 	return enriched, nil
 }
@@ -855,7 +941,7 @@ func (s *service) DeleteInstance(ctx context.Context, req DeleteInstanceRequest)
 	if req.ID == uuid.Nil {
 		return ErrInstanceIDRequired
 	}
-	if !req.HardDelete {
+	if !req.HardDelete && s.trash == nil {
 		return ErrInstanceSoftDeleteUnsupported
 	}
 
@@ -871,12 +957,11 @@ func (s *service) DeleteInstance(ctx context.Context, req DeleteInstanceRequest)
 			return err
 		}
 	}
-	for _, tr := range translations {
-		if err := s.translations.Delete(ctx, tr.ID); err != nil {
+	if !req.HardDelete {
+		if err := s.trashInstance(ctx, record, translations, req.DeletedBy); err != nil {
 			return err
 		}
-	}
-	if err := s.instances.Delete(ctx, req.ID); err != nil {
+	} else if err := s.deleteInstanceRows(ctx, req.ID, translations); err != nil {
 		return err
 	}
 	meta := map[string]any{
@@ -889,6 +974,7 @@ func (s *service) DeleteInstance(ctx context.Context, req DeleteInstanceRequest)
 		meta["environment_id"] = definition.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, pickActor(req.DeletedBy, record.UpdatedBy, record.CreatedBy), "delete", "block_instance", record.ID, meta)
+	s.emitInstanceLifecycle(ctx, record.ID, "delete", meta)
 	return nil
 }
 
@@ -952,10 +1038,19 @@ func (s *service) AddTranslation(ctx context.Context, input AddTranslationInput)
 	if _, err := s.instances.Update(ctx, instance); err != nil {
 		return nil, err
 	}
-	if err := s.persistVersion(ctx, preparedVersion); err != nil {
+	if err := s.persistVersion(ctx, instance, preparedVersion); err != nil {
 		return nil, err
 	}
 
+	meta := map[string]any{
+		"instance_id": input.BlockInstanceID.String(),
+		"locale_id":   input.LocaleID.String(),
+	}
+	if definition.EnvironmentID != uuid.Nil {
+		meta["environment_id"] = definition.EnvironmentID.String()
+	}
+	s.emitInstanceLifecycle(ctx, input.BlockInstanceID, "update", meta)
+
 	return s.hydrateTranslation(ctx, created)
 }
 
@@ -1031,7 +1126,7 @@ func (s *service) UpdateTranslation(ctx context.Context, input UpdateTranslation
 	if _, err := s.instances.Update(ctx, instance); err != nil {
 		return nil, err
 	}
-	if err := s.persistVersion(ctx, preparedVersion); err != nil {
+	if err := s.persistVersion(ctx, instance, preparedVersion); err != nil {
 		return nil, err
 	}
 
@@ -1048,6 +1143,7 @@ func (s *service) UpdateTranslation(ctx context.Context, input UpdateTranslation
 		meta["environment_id"] = definition.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, input.UpdatedBy, "update", "block_translation", record.ID, meta)
+	s.emitInstanceLifecycle(ctx, input.BlockInstanceID, "update", meta)
 
 	return record, nil
 }
@@ -1103,7 +1199,7 @@ func (s *service) DeleteTranslation(ctx context.Context, req DeleteTranslationRe
 	if _, err := s.instances.Update(ctx, instance); err != nil {
 		return err
 	}
-	if err := s.persistVersion(ctx, preparedVersion); err != nil {
+	if err := s.persistVersion(ctx, instance, preparedVersion); err != nil {
 		return err
 	}
 	meta := map[string]any{
@@ -1114,6 +1210,7 @@ func (s *service) DeleteTranslation(ctx context.Context, req DeleteTranslationRe
 		meta["environment_id"] = definition.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, pickActor(req.DeletedBy, instance.UpdatedBy, instance.CreatedBy), "delete", "block_translation", target.ID, meta)
+	s.emitInstanceLifecycle(ctx, req.BlockInstanceID, "update", meta)
 	return nil
 }
 
@@ -1135,7 +1232,7 @@ func (s *service) CreateDraft(ctx context.Context, req CreateInstanceDraftReques
 		return nil, err
 	}
 
-	if s.versionRetentionLimit > 0 && len(versions) >= s.versionRetentionLimit {
+	if s.retention.Rejects(len(versions)) {
 		return nil, ErrInstanceVersionRetentionExceeded
 	}
 
@@ -1172,6 +1269,13 @@ func (s *service) CreateDraft(ctx context.Context, req CreateInstanceDraftReques
 	if _, err := s.instances.Update(ctx, instance); err != nil {
 		return nil, err
 	}
+	if s.retention.PrunesOnWrite() {
+		if _, err := s.pruneVersions(ctx, instance, instance.UpdatedBy); err != nil {
+			s.opLogger(ctx, "blocks.instance.create_draft", map[string]any{
+				"instance_id": instance.ID,
+			}).Warn("block version prune failed", "error", err)
+		}
+	}
 
 	return cloneInstanceVersion(created), nil
 }
@@ -1201,7 +1305,7 @@ func (s *service) PublishDraft(ctx context.Context, req PublishInstanceDraftRequ
 	}
 
 	if definition, defErr := s.definitions.GetByID(ctx, instance.DefinitionID); defErr == nil {
-		migrated, migratedAny, err := s.migrateSnapshot(definition, version.Snapshot)
+		migrated, migratedAny, err := s.migrateSnapshot(ctx, definition, version.Snapshot)
 		if err != nil {
 			return nil, err
 		}
@@ -1269,6 +1373,7 @@ func (s *service) PublishDraft(ctx context.Context, req PublishInstanceDraftRequ
 		meta["environment_id"] = definition.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, req.PublishedBy, "publish", "block_instance", instance.ID, meta)
+	s.emitInstanceLifecycle(ctx, instance.ID, "publish", meta)
 
 	return cloneInstanceVersion(updatedVersion), nil
 }
@@ -1369,7 +1474,7 @@ func (s *service) prepareInstanceVersion(ctx context.Context, instance *Instance
 	if err != nil {
 		return nil, err
 	}
-	if s.versionRetentionLimit > 0 && len(records) >= s.versionRetentionLimit {
+	if s.retention.Rejects(len(records)) {
 		return nil, ErrInstanceVersionRetentionExceeded
 	}
 
@@ -1396,12 +1501,21 @@ func (s *service) prepareInstanceVersion(ctx context.Context, instance *Instance
 	return version, nil
 }
 
-func (s *service) persistVersion(ctx context.Context, version *InstanceVersion) error {
+func (s *service) persistVersion(ctx context.Context, instance *Instance, version *InstanceVersion) error {
 	if version == nil || s.versions == nil {
 		return nil
 	}
-	_, err := s.versions.Create(ctx, version)
-	return err
+	if _, err := s.versions.Create(ctx, version); err != nil {
+		return err
+	}
+	if s.retention.PrunesOnWrite() {
+		if _, err := s.pruneVersions(ctx, instance, version.CreatedBy); err != nil {
+			s.opLogger(ctx, "blocks.instance.persist_version", map[string]any{
+				"instance_id": instance.ID,
+			}).Warn("block version prune failed", "error", err)
+		}
+	}
+	return nil
 }
 
 func (s *service) buildInstanceSnapshot(ctx context.Context, instance *Instance) (BlockVersionSnapshot, error) {
@@ -1441,7 +1555,7 @@ func (s *service) prepareTranslationPayload(definition *Definition, content map[
 	return applySchemaVersion(clean, version), nil
 }
 
-func (s *service) migrateSnapshot(definition *Definition, snapshot BlockVersionSnapshot) (BlockVersionSnapshot, bool, error) {
+func (s *service) migrateSnapshot(ctx context.Context, definition *Definition, snapshot BlockVersionSnapshot) (BlockVersionSnapshot, bool, error) {
 	if definition == nil {
 		return snapshot, false, nil
 	}
@@ -1452,10 +1566,14 @@ func (s *service) migrateSnapshot(definition *Definition, snapshot BlockVersionS
 	if err != nil {
 		return snapshot, false, ErrDefinitionSchemaVersionInvalid
 	}
+	migrator, err := s.definitionMigrator(ctx, definition, definitionSlug(definition))
+	if err != nil {
+		return snapshot, false, err
+	}
 	updated := cloneBlockVersionSnapshot(snapshot)
 	migratedAny := false
 	for idx, tr := range updated.Translations {
-		migrated, didMigrate, err := s.migratePayload(definitionSlug(definition), target, tr.Content)
+		migrated, didMigrate, err := migratePayload(migrator, definitionSlug(definition), target, tr.Content)
 		if err != nil {
 			return snapshot, false, err
 		}
@@ -1467,19 +1585,19 @@ func (s *service) migrateSnapshot(definition *Definition, snapshot BlockVersionS
 	return updated, migratedAny, nil
 }
 
-func (s *service) migratePayload(slug string, target cmsschema.Version, payload map[string]any) (map[string]any, bool, error) {
+func migratePayload(migrator *Migrator, slug string, target cmsschema.Version, payload map[string]any) (map[string]any, bool, error) {
 	current, ok := cmsschema.RootSchemaVersion(payload)
 	if !ok || current.String() == target.String() {
 		return applySchemaVersion(stripSchemaVersion(payload), target), false, nil
 	}
-	if s.schemaMigrator == nil {
+	if migrator == nil {
 		return nil, false, ErrBlockSchemaMigrationRequired
 	}
 	if current.Slug != "" && current.Slug != target.Slug {
 		return nil, false, ErrDefinitionSchemaVersionInvalid
 	}
 	trimmed := stripSchemaVersion(payload)
-	migrated, err := s.schemaMigrator.Migrate(strings.TrimSpace(slug), current.String(), target.String(), trimmed)
+	migrated, err := migrator.Migrate(strings.TrimSpace(slug), current.String(), target.String(), trimmed)
 	if err != nil {
 		return nil, false, fmt.Errorf("%w: %v", ErrBlockSchemaMigrationRequired, err)
 	}
@@ -1502,7 +1620,7 @@ func (s *service) validateSnapshot(definition *Definition, snapshot BlockVersion
 	return nil
 }
 
-func (s *service) upsertDefinitionVersion(ctx context.Context, definition *Definition, schema map[string]any, defaults map[string]any, version cmsschema.Version, createOnly bool) (*DefinitionVersion, error) {
+func (s *service) upsertDefinitionVersion(ctx context.Context, definition *Definition, schema map[string]any, defaults map[string]any, version cmsschema.Version, migration []SchemaMigrationOperation, createOnly bool) (*DefinitionVersion, error) {
 	if s.definitionVersions == nil {
 		return nil, ErrDefinitionVersioningDisabled
 	}
@@ -1521,6 +1639,9 @@ func (s *service) upsertDefinitionVersion(ctx context.Context, definition *Defin
 		existing.SchemaVersion = version.String()
 		existing.Schema = maps.Clone(schema)
 		existing.Defaults = maps.Clone(defaults)
+		if migration != nil {
+			existing.Migration = cmsschema.CloneMigrationOperations(migration)
+		}
 		existing.UpdatedAt = s.now()
 		return s.definitionVersions.Update(ctx, existing)
 	}
@@ -1529,6 +1650,12 @@ func (s *service) upsertDefinitionVersion(ctx context.Context, definition *Defin
 		return nil, err
 	}
 
+	if migration == nil {
+		migration, err = s.suggestDefinitionMigration(ctx, definition.ID, version, schema)
+		if err != nil {
+			return nil, err
+		}
+	}
 	record := &DefinitionVersion{
 		ID:            s.id(),
 		DefinitionID:  definition.ID,
@@ -1538,9 +1665,47 @@ func (s *service) upsertDefinitionVersion(ctx context.Context, definition *Defin
 		CreatedAt:     s.now(),
 		UpdatedAt:     s.now(),
 	}
+	if len(migration) > 0 {
+		record.Migration = cmsschema.CloneMigrationOperations(migration)
+	}
 	return s.definitionVersions.Create(ctx, record)
 }
 
+// suggestDefinitionMigration proposes operations from the closest earlier
+// definition version to the new schema.
+func (s *service) suggestDefinitionMigration(ctx context.Context, definitionID uuid.UUID, version cmsschema.Version, schema map[string]any) ([]SchemaMigrationOperation, error) {
+	versions, err := s.definitionVersions.ListByDefinition(ctx, definitionID)
+	if err != nil {
+		return nil, err
+	}
+	var previous *DefinitionVersion
+	for _, candidate := range versions {
+		if candidate == nil || compareSchemaVersions(candidate.SchemaVersion, version.String()) >= 0 {
+			continue
+		}
+		if previous == nil || compareSchemaVersions(candidate.SchemaVersion, previous.SchemaVersion) > 0 {
+			previous = candidate
+		}
+	}
+	if previous == nil {
+		return nil, nil
+	}
+	return cmsschema.SuggestMigrationOperations(previous.Schema, schema), nil
+}
+
+// definitionMigrator extends the registered migrator with the operations
+// stored on the definition's schema versions, keyed by slug.
+func (s *service) definitionMigrator(ctx context.Context, definition *Definition, slug string) (*Migrator, error) {
+	if definition == nil || s.definitionVersions == nil {
+		return s.schemaMigrator, nil
+	}
+	versions, err := s.definitionVersions.ListByDefinition(ctx, definition.ID)
+	if err != nil {
+		return nil, err
+	}
+	return extendDefinitionMigrator(s.schemaMigrator, slug, versions), nil
+}
+
 func validateMediaBindings(bindings media.BindingSet) error {
 	for slot, entries := range bindings {
 		for _, binding := range entries {
@@ -1611,7 +1776,7 @@ func (s *service) renderShortcodeValue(ctx context.Context, value any, locale st
 		if !containsShortcodeSyntax(v) {
 			return v, nil
 		}
-		output, err := s.shortcodes.Process(ctx, v, interfaces.ShortcodeProcessOptions{Locale: locale})
+		output, err := s.shortcodes.Process(ctx, v, interfaces.ShortcodeProcessOptions{Locale: locale, Trusted: true})
 		if err != nil {
 			return nil, err
 		}
@@ -1787,6 +1952,6 @@ func (s *service) applyRegistry(ctx context.Context) {
 		if err != nil {
 			continue
 		}
-		_, _ = s.upsertDefinitionVersion(ctx, definition, normalizedSchema, def.Defaults, version, false)
+		_, _ = s.upsertDefinitionVersion(ctx, definition, normalizedSchema, def.Defaults, version, nil, false)
 	}
 }
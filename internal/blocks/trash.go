@@ -0,0 +1,239 @@
+package blocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+// trashInstance snapshots the instance with its translations and versions and
+// removes the live rows. The entry carries the environment of the instance
+// definition. Repositories implementing InstanceTrasher store the
+// snapshot in the same transaction as the delete; otherwise the entry is
+// removed again when a later delete fails.
+func (s *service) trashInstance(ctx context.Context, record *Instance, translations []*Translation, deletedBy uuid.UUID) error {
+	snapshot := *record
+	snapshot.Definition = nil
+	snapshot.Translations = translations
+	if s.versions != nil {
+		versions, err := s.versions.ListByInstance(ctx, record.ID)
+		if err != nil {
+			var nf *NotFoundError
+			if !errors.As(err, &nf) {
+				return err
+			}
+		}
+		snapshot.Versions = versions
+	}
+	now := s.now()
+	snapshot.DeletedAt = &now
+	entry, err := trash.NewEntry(trash.ResourceBlockInstance, record.ID, &snapshot, deletedBy, now)
+	if err != nil {
+		return err
+	}
+	if definition, err := s.definitions.GetByID(ctx, record.DefinitionID); err == nil && definition != nil {
+		entry.EnvironmentID = definition.EnvironmentID
+	}
+
+	if trasher, ok := s.instances.(InstanceTrasher); ok {
+		err := trasher.DeleteToTrash(ctx, record.ID, entry)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+
+	stored, err := s.trash.Create(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if err := s.deleteInstanceRows(ctx, record.ID, translations); err != nil {
+		_ = s.trash.Delete(ctx, stored.ID)
+		return err
+	}
+	return nil
+}
+
+// deleteInstanceRows removes the instance and its translations.
+func (s *service) deleteInstanceRows(ctx context.Context, id uuid.UUID, translations []*Translation) error {
+	for _, tr := range translations {
+		if err := s.translations.Delete(ctx, tr.ID); err != nil {
+			return err
+		}
+	}
+	return s.instances.Delete(ctx, id)
+}
+
+// ListTrashedInstances returns a page of soft-deleted instances of the
+// environment, most recently deleted first.
+func (s *service) ListTrashedInstances(ctx context.Context, req ListTrashedInstancesRequest) ([]*Instance, error) {
+	if s.trash == nil {
+		return nil, ErrInstanceSoftDeleteUnsupported
+	}
+	envID, _, err := s.resolveEnvironment(ctx, req.Environment)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.trash.List(ctx, trash.ListQuery{
+		ResourceType:  trash.ResourceBlockInstance,
+		EnvironmentID: envID,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Instance, 0, len(entries))
+	for _, entry := range entries {
+		var record Instance
+		if err := trash.Decode(entry, &record); err != nil {
+			return nil, err
+		}
+		record.Versions = nil
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+// RestoreDeletedInstance recreates a trashed instance with its translations.
+// Versions that did not survive the delete are recreated from the snapshot.
+func (s *service) RestoreDeletedInstance(ctx context.Context, req RestoreInstanceRequest) (*Instance, error) {
+	if req.ID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	if s.trash == nil {
+		return nil, ErrInstanceSoftDeleteUnsupported
+	}
+	entry, err := s.trash.GetByResource(ctx, trash.ResourceBlockInstance, req.ID)
+	if err != nil {
+		if errors.Is(err, trash.ErrEntryNotFound) {
+			return nil, ErrInstanceNotTrashed
+		}
+		return nil, err
+	}
+	var record Instance
+	if err := trash.Decode(entry, &record); err != nil {
+		return nil, err
+	}
+	created, err := s.restoreInstanceRecord(ctx, &record, record.Versions, req.RestoredBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.trash.Delete(ctx, entry.ID); err != nil && !errors.Is(err, trash.ErrEntryNotFound) {
+		return nil, err
+	}
+	return created, nil
+}
+
+// RestoreInstanceSnapshot recreates an instance from a captured copy with its
+// original ID, translations and versions. A live instance with the same ID is
+// removed first.
+func (s *service) RestoreInstanceSnapshot(ctx context.Context, req RestoreInstanceSnapshotRequest) (*Instance, error) {
+	if req.Instance == nil || req.Instance.ID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	if _, err := s.instances.GetByID(ctx, req.Instance.ID); err == nil {
+		if err := s.DeleteInstance(ctx, DeleteInstanceRequest{ID: req.Instance.ID, DeletedBy: req.RestoredBy, HardDelete: true}); err != nil {
+			return nil, err
+		}
+	} else {
+		var nf *NotFoundError
+		if !errors.As(err, &nf) {
+			return nil, err
+		}
+	}
+	record := *req.Instance
+	record.Definition = nil
+	record.Translations = make([]*Translation, 0, len(req.Instance.Translations))
+	for _, tr := range req.Instance.Translations {
+		if tr == nil {
+			continue
+		}
+		copied := *tr
+		copied.ResolvedMedia = nil
+		record.Translations = append(record.Translations, &copied)
+	}
+	return s.restoreInstanceRecord(ctx, &record, req.Versions, req.RestoredBy)
+}
+
+// restoreInstanceRecord inserts record under its own ID together with its
+// translations and any versions that are not already stored.
+func (s *service) restoreInstanceRecord(ctx context.Context, record *Instance, versions []*InstanceVersion, restoredBy uuid.UUID) (*Instance, error) {
+	definition, err := s.definitions.GetByID(ctx, record.DefinitionID)
+	if err != nil {
+		return nil, ErrInstanceDefinitionRequired
+	}
+
+	translations := record.Translations
+	record.Translations = nil
+	record.Versions = nil
+	record.DeletedAt = nil
+	record.UpdatedAt = s.now()
+	if restoredBy != uuid.Nil {
+		record.UpdatedBy = restoredBy
+	}
+
+	created, err := s.instances.Create(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	for _, tr := range translations {
+		if tr == nil {
+			continue
+		}
+		tr.DeletedAt = nil
+		if _, err := s.translations.Create(ctx, tr); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.restoreInstanceVersions(ctx, created.ID, versions); err != nil {
+		return nil, err
+	}
+
+	meta := map[string]any{
+		"region":    created.Region,
+		"position":  created.Position,
+		"page_id":   created.PageID,
+		"is_global": created.IsGlobal,
+	}
+	if definition != nil && definition.EnvironmentID != uuid.Nil {
+		meta["environment_id"] = definition.EnvironmentID.String()
+	}
+	s.emitActivity(ctx, pickActor(restoredBy, record.UpdatedBy), "restore", "block_instance", created.ID, meta)
+	s.emitInstanceLifecycle(ctx, created.ID, "restore", meta)
+
+	created.Translations = translations
+	return created, nil
+}
+
+func (s *service) restoreInstanceVersions(ctx context.Context, instanceID uuid.UUID, versions []*InstanceVersion) error {
+	if s.versions == nil || len(versions) == 0 {
+		return nil
+	}
+	existing, err := s.versions.ListByInstance(ctx, instanceID)
+	if err != nil {
+		var nf *NotFoundError
+		if !errors.As(err, &nf) {
+			return err
+		}
+	}
+	present := make(map[int]struct{}, len(existing))
+	for _, version := range existing {
+		if version != nil {
+			present[version.Version] = struct{}{}
+		}
+	}
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		if _, ok := present[version.Version]; ok {
+			continue
+		}
+		if _, err := s.versions.Create(ctx, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
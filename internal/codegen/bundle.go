@@ -0,0 +1,120 @@
+package codegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms/blocks"
+	"github.com/goliatone/go-cms/content"
+)
+
+// Bundle is the exported set of schemas code is generated from.
+type Bundle struct {
+	ContentTypes     []SchemaEntry `json:"content_types"`
+	BlockDefinitions []SchemaEntry `json:"block_definitions"`
+}
+
+// SchemaEntry describes a content type or block definition schema.
+type SchemaEntry struct {
+	Slug          string         `json:"slug"`
+	Name          string         `json:"name,omitempty"`
+	SchemaVersion string         `json:"schema_version,omitempty"`
+	Schema        map[string]any `json:"schema"`
+}
+
+// ContentTypeLister lists content types, typically content.ContentTypeService.
+type ContentTypeLister interface {
+	List(ctx context.Context, env ...string) ([]*content.ContentType, error)
+}
+
+// BlockDefinitionLister lists block definitions, typically blocks.Service.
+type BlockDefinitionLister interface {
+	ListDefinitions(ctx context.Context, env ...string) ([]*blocks.Definition, error)
+}
+
+var ErrBundleEmpty = errors.New("codegen: bundle has no content types or block definitions")
+
+// Collect reads content type and block definition schemas from running
+// services. Either lister may be nil.
+func Collect(ctx context.Context, contentTypes ContentTypeLister, definitions BlockDefinitionLister, env ...string) (*Bundle, error) {
+	bundle := &Bundle{}
+	if contentTypes != nil {
+		records, err := contentTypes.List(ctx, env...)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record == nil || record.DeletedAt != nil {
+				continue
+			}
+			bundle.ContentTypes = append(bundle.ContentTypes, SchemaEntry{
+				Slug:          record.Slug,
+				Name:          record.Name,
+				SchemaVersion: record.SchemaVersion,
+				Schema:        record.Schema,
+			})
+		}
+	}
+	if definitions != nil {
+		records, err := definitions.ListDefinitions(ctx, env...)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record == nil || record.DeletedAt != nil {
+				continue
+			}
+			slug := record.Slug
+			if strings.TrimSpace(slug) == "" {
+				slug = record.Name
+			}
+			bundle.BlockDefinitions = append(bundle.BlockDefinitions, SchemaEntry{
+				Slug:          slug,
+				Name:          record.Name,
+				SchemaVersion: record.SchemaVersion,
+				Schema:        record.Schema,
+			})
+		}
+	}
+	bundle.sort()
+	return bundle, nil
+}
+
+// ReadBundle decodes an exported bundle.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	bundle.sort()
+	return &bundle, nil
+}
+
+// MarshalBundle encodes a bundle with stable ordering and indentation.
+func MarshalBundle(bundle *Bundle) ([]byte, error) {
+	if bundle == nil {
+		return nil, ErrBundleEmpty
+	}
+	sorted := *bundle
+	sorted.ContentTypes = slices.Clone(bundle.ContentTypes)
+	sorted.BlockDefinitions = slices.Clone(bundle.BlockDefinitions)
+	sorted.sort()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sorted); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *Bundle) sort() {
+	bySlug := func(a, b SchemaEntry) int { return strings.Compare(a.Slug, b.Slug) }
+	slices.SortStableFunc(b.ContentTypes, bySlug)
+	slices.SortStableFunc(b.BlockDefinitions, bySlug)
+}
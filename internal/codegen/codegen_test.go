@@ -0,0 +1,138 @@
+package codegen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-cms/blocks"
+	"github.com/goliatone/go-cms/content"
+)
+
+func loadTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", "bundle.json"))
+	if err != nil {
+		t.Fatalf("open bundle: %v", err)
+	}
+	defer file.Close()
+	bundle, err := ReadBundle(file)
+	if err != nil {
+		t.Fatalf("read bundle: %v", err)
+	}
+	return bundle
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Fatalf("%s mismatch (rerun with UPDATE_GOLDEN=1 to refresh)\n got:\n%s", name, got)
+	}
+}
+
+func TestGenerateGoMatchesGolden(t *testing.T) {
+	out, err := GenerateGo(loadTestBundle(t), GoOptions{Package: "cmstypes"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	assertGolden(t, "cmstypes.go.golden", out)
+}
+
+func TestGenerateTypeScriptMatchesGolden(t *testing.T) {
+	out, err := GenerateTypeScript(loadTestBundle(t))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	assertGolden(t, "cmstypes.d.ts.golden", out)
+}
+
+func TestGenerateIsDeterministicAcrossInputOrder(t *testing.T) {
+	bundle := loadTestBundle(t)
+	reversed := &Bundle{
+		ContentTypes:     []SchemaEntry{bundle.ContentTypes[1], bundle.ContentTypes[0]},
+		BlockDefinitions: []SchemaEntry{bundle.BlockDefinitions[1], bundle.BlockDefinitions[0]},
+	}
+	for i := 0; i < 5; i++ {
+		first, err := GenerateGo(bundle, GoOptions{})
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		second, err := GenerateGo(reversed, GoOptions{})
+		if err != nil {
+			t.Fatalf("generate reversed: %v", err)
+		}
+		if !bytes.Equal(first, second) {
+			t.Fatalf("expected identical output regardless of input order")
+		}
+	}
+}
+
+func TestGenerateRejectsConflictingNames(t *testing.T) {
+	bundle := &Bundle{ContentTypes: []SchemaEntry{
+		{Slug: "news-item", Schema: map[string]any{"type": "object"}},
+		{Slug: "news_item", Schema: map[string]any{"type": "object"}},
+	}}
+	if _, err := GenerateGo(bundle, GoOptions{}); err == nil || !strings.Contains(err.Error(), "NewsItem") {
+		t.Fatalf("expected name conflict error, got %v", err)
+	}
+	if _, err := GenerateTypeScript(&Bundle{}); !errors.Is(err, ErrBundleEmpty) {
+		t.Fatalf("expected ErrBundleEmpty, got %v", err)
+	}
+}
+
+type stubContentTypes []*content.ContentType
+
+func (s stubContentTypes) List(context.Context, ...string) ([]*content.ContentType, error) {
+	return s, nil
+}
+
+type stubDefinitions []*blocks.Definition
+
+func (s stubDefinitions) ListDefinitions(context.Context, ...string) ([]*blocks.Definition, error) {
+	return s, nil
+}
+
+func TestCollectBuildsSortedBundle(t *testing.T) {
+	bundle, err := Collect(context.Background(),
+		stubContentTypes{
+			{Slug: "page", Name: "Page", SchemaVersion: "page@v1.0.0", Schema: map[string]any{"type": "object"}},
+			{Slug: "article", Name: "Article", Schema: map[string]any{"type": "object"}},
+		},
+		stubDefinitions{{Name: "Hero", Schema: map[string]any{"type": "object"}}},
+	)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(bundle.ContentTypes) != 2 || bundle.ContentTypes[0].Slug != "article" {
+		t.Fatalf("expected content types sorted by slug, got %+v", bundle.ContentTypes)
+	}
+	if len(bundle.BlockDefinitions) != 1 || bundle.BlockDefinitions[0].Slug != "Hero" {
+		t.Fatalf("expected block slug to fall back to name, got %+v", bundle.BlockDefinitions)
+	}
+
+	encoded, err := MarshalBundle(bundle)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded, err := ReadBundle(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if decoded.ContentTypes[1].SchemaVersion != "page@v1.0.0" {
+		t.Fatalf("expected bundle round trip, got %+v", decoded.ContentTypes)
+	}
+}
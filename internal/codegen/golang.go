@@ -0,0 +1,308 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+)
+
+const generatedHeader = "Code generated by go-cms codegen. DO NOT EDIT."
+
+// GoOptions configures Go code generation.
+type GoOptions struct {
+	// Package is the package clause of the generated file.
+	Package string
+}
+
+// GenerateGo emits Go structs with decode/encode helpers for every content
+// type and block definition in the bundle, plus typed accessors for
+// content.Service reads. Output is gofmt-formatted and stable for a bundle.
+func GenerateGo(bundle *Bundle, opts GoOptions) ([]byte, error) {
+	pkg := strings.TrimSpace(opts.Package)
+	if pkg == "" {
+		pkg = "cmstypes"
+	}
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("codegen: invalid Go package name %q", pkg)
+	}
+	model, err := buildModel(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &goWriter{}
+	hasContent := len(model.contentTypes) > 0
+	w.line("// " + generatedHeader)
+	w.line("")
+	w.line("package " + pkg)
+	w.line("")
+	w.line("import (")
+	if hasContent {
+		w.line(`"context"`)
+	}
+	w.line(`"encoding/json"`)
+	if hasContent {
+		w.line(`"errors"`)
+		w.line(`"fmt"`)
+		w.line(`"strings"`)
+		w.line("")
+		w.line(`"github.com/goliatone/go-cms/content"`)
+		w.line(`"github.com/google/uuid"`)
+	}
+	w.line(")")
+	w.line("")
+
+	if hasContent {
+		w.line("var (")
+		w.line("// ErrTranslationNotFound is returned when a record has no translation for the requested locale.")
+		w.line(fmt.Sprintf("ErrTranslationNotFound = errors.New(%q)", pkg+": translation not found"))
+		w.line("// ErrContentTypeMismatch is returned when a record belongs to another content type.")
+		w.line(fmt.Sprintf("ErrContentTypeMismatch = errors.New(%q)", pkg+": content type mismatch"))
+		w.line(")")
+		w.line("")
+	}
+	w.line("// EmbeddedBlock is a block payload embedded in content. Check Type and decode")
+	w.line("// it with the matching Decode*Block helper.")
+	w.line("type EmbeddedBlock map[string]any")
+	w.line("")
+	w.line("// Type returns the block type discriminator.")
+	w.line("func (b EmbeddedBlock) Type() string {")
+	w.line(`value, _ := b["_type"].(string)`)
+	w.line("return value")
+	w.line("}")
+
+	for _, ct := range model.contentTypes {
+		w.contentType(ct)
+	}
+	for _, block := range model.blocks {
+		w.block(block)
+	}
+	w.helpers(hasContent)
+
+	formatted, err := format.Source([]byte(w.buf.String()))
+	if err != nil {
+		return nil, fmt.Errorf("codegen: format Go output: %w", err)
+	}
+	return formatted, nil
+}
+
+type goWriter struct {
+	buf strings.Builder
+}
+
+func (w *goWriter) line(value string) {
+	w.buf.WriteString(value)
+	w.buf.WriteByte('\n')
+}
+
+func (w *goWriter) contentType(m *schemaModel) {
+	id := m.ident
+	w.line("")
+	w.line(fmt.Sprintf("// %s schema identifiers.", id))
+	w.line("const (")
+	w.line(fmt.Sprintf("%sSlug = %q", id, m.slug))
+	w.line(fmt.Sprintf("%sSchemaVersion = %q", id, m.schemaVersion))
+	w.line(")")
+	w.line("")
+	w.comment(fmt.Sprintf("%s is the payload of the %q content type.", id, m.displayName), m.root.description)
+	w.object(m.root)
+	w.nested(m)
+
+	w.line("")
+	w.line(fmt.Sprintf("// Decode%s decodes a stored %s payload.", id, id))
+	w.line(fmt.Sprintf("func Decode%s(payload map[string]any) (%s, error) {", id, id))
+	w.line(fmt.Sprintf("var out %s", id))
+	w.line("err := decodePayload(payload, &out)")
+	w.line("return out, err")
+	w.line("}")
+	w.line("")
+	w.line(fmt.Sprintf("// Encode returns the payload stamped with %sSchemaVersion.", id))
+	w.line(fmt.Sprintf("func (v %s) Encode() (map[string]any, error) {", id))
+	w.line(fmt.Sprintf("return encodePayload(v, %sSchemaVersion)", id))
+	w.line("}")
+	w.line("")
+	w.line(fmt.Sprintf("// %sFromContent decodes the locale translation of record as %s. An", id, id))
+	w.line("// empty locale selects the first translation.")
+	w.line(fmt.Sprintf("func %sFromContent(record *content.Content, locale string) (%s, error) {", id, id))
+	w.line(fmt.Sprintf("if record != nil && record.Type != nil && record.Type.Slug != %sSlug {", id))
+	w.line(fmt.Sprintf("return %s{}, fmt.Errorf(\"%%w: %%s\", ErrContentTypeMismatch, record.Type.Slug)", id))
+	w.line("}")
+	w.line("payload, err := translationPayload(record, locale)")
+	w.line("if err != nil {")
+	w.line(fmt.Sprintf("return %s{}, err", id))
+	w.line("}")
+	w.line(fmt.Sprintf("return Decode%s(payload)", id))
+	w.line("}")
+	w.line("")
+	w.line(fmt.Sprintf("// Get%s reads record id through svc and decodes its locale translation.", id))
+	w.line(fmt.Sprintf("func Get%s(ctx context.Context, svc content.Service, id uuid.UUID, locale string) (%s, error) {", id, id))
+	w.line("record, err := svc.Get(ctx, id, content.WithTranslations())")
+	w.line("if err != nil {")
+	w.line(fmt.Sprintf("return %s{}, err", id))
+	w.line("}")
+	w.line(fmt.Sprintf("return %sFromContent(record, locale)", id))
+	w.line("}")
+}
+
+func (w *goWriter) block(m *schemaModel) {
+	id := m.ident
+	w.line("")
+	w.line(fmt.Sprintf("// %s schema identifiers.", id))
+	w.line("const (")
+	w.line(fmt.Sprintf("%sType = %q", id, m.slug))
+	w.line(fmt.Sprintf("%sSchemaVersion = %q", id, m.schemaVersion))
+	w.line(")")
+	w.line("")
+	w.comment(fmt.Sprintf("%s is the payload of the %q block.", id, m.displayName), m.root.description)
+	w.object(m.root)
+	w.nested(m)
+
+	w.line("")
+	w.line(fmt.Sprintf("// Decode%s decodes a block translation or embedded block payload.", id))
+	w.line(fmt.Sprintf("func Decode%s(payload map[string]any) (%s, error) {", id, id))
+	w.line(fmt.Sprintf("var out %s", id))
+	w.line("err := decodePayload(payload, &out)")
+	w.line("return out, err")
+	w.line("}")
+	w.line("")
+	w.line(fmt.Sprintf("// Encode returns the payload stamped with %sSchemaVersion.", id))
+	w.line(fmt.Sprintf("func (v %s) Encode() (map[string]any, error) {", id))
+	w.line(fmt.Sprintf("return encodePayload(v, %sSchemaVersion)", id))
+	w.line("}")
+	w.line("")
+	w.line(fmt.Sprintf("// Embed returns the payload as an embedded block tagged with %sType.", id))
+	w.line(fmt.Sprintf("func (v %s) Embed() (EmbeddedBlock, error) {", id))
+	w.line("payload, err := v.Encode()")
+	w.line("if err != nil {")
+	w.line("return nil, err")
+	w.line("}")
+	w.line(fmt.Sprintf(`payload["_type"] = %sType`, id))
+	w.line("return EmbeddedBlock(payload), nil")
+	w.line("}")
+}
+
+func (w *goWriter) nested(m *schemaModel) {
+	for _, obj := range m.objects {
+		w.line("")
+		w.comment(fmt.Sprintf("%s is a nested object of %s.", obj.name, m.ident), obj.description)
+		w.object(obj)
+	}
+	for _, enum := range m.enums {
+		w.line("")
+		w.line(fmt.Sprintf("// %s enumerates the allowed values of a field of %s.", enum.name, m.ident))
+		w.line(fmt.Sprintf("type %s string", enum.name))
+		w.line("")
+		w.line("const (")
+		used := map[string]bool{}
+		for idx, value := range enum.values {
+			name := enum.name + exportedName(value)
+			if name == enum.name || used[name] {
+				name = fmt.Sprintf("%sValue%d", enum.name, idx+1)
+			}
+			used[name] = true
+			w.line(fmt.Sprintf("%s %s = %q", name, enum.name, value))
+		}
+		w.line(")")
+	}
+}
+
+func (w *goWriter) comment(summary, description string) {
+	w.line("// " + summary)
+	if description != "" {
+		w.line("//")
+		for _, line := range strings.Split(description, "\n") {
+			w.line(strings.TrimRight("// "+line, " "))
+		}
+	}
+}
+
+func (w *goWriter) object(obj *objectType) {
+	w.line(fmt.Sprintf("type %s struct {", obj.name))
+	for _, f := range obj.fields {
+		if f.description != "" {
+			for _, line := range strings.Split(f.description, "\n") {
+				w.line(strings.TrimRight("// "+line, " "))
+			}
+		}
+		tag := f.jsonName
+		if !f.required {
+			tag += ",omitempty"
+		}
+		w.line(fmt.Sprintf("%s %s `json:%q`", f.name, goType(f.typ, f.required), tag))
+	}
+	w.line("}")
+}
+
+// goType renders t; optional and nullable scalars and structs become pointers.
+func goType(t *typeRef, required bool) string {
+	var base string
+	pointer := !required || t.nullable
+	switch t.kind {
+	case kindString:
+		base = "string"
+	case kindInteger:
+		base = "int"
+	case kindNumber:
+		base = "float64"
+	case kindBoolean:
+		base = "bool"
+	case kindEnum, kindObject:
+		base = t.name
+	case kindArray:
+		return "[]" + goType(t.elem, true)
+	case kindMap:
+		return "map[string]" + goType(t.elem, true)
+	case kindBlocks:
+		return "[]EmbeddedBlock"
+	default:
+		return "any"
+	}
+	if pointer {
+		return "*" + base
+	}
+	return base
+}
+
+func (w *goWriter) helpers(hasContent bool) {
+	w.line("")
+	w.line("func decodePayload(payload map[string]any, out any) error {")
+	w.line("raw, err := json.Marshal(payload)")
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	w.line("return json.Unmarshal(raw, out)")
+	w.line("}")
+	w.line("")
+	w.line("func encodePayload(value any, schemaVersion string) (map[string]any, error) {")
+	w.line("raw, err := json.Marshal(value)")
+	w.line("if err != nil {")
+	w.line("return nil, err")
+	w.line("}")
+	w.line("out := map[string]any{}")
+	w.line("if err := json.Unmarshal(raw, &out); err != nil {")
+	w.line("return nil, err")
+	w.line("}")
+	w.line(`if schemaVersion != "" {`)
+	w.line(`out["_schema"] = schemaVersion`)
+	w.line("}")
+	w.line("return out, nil")
+	w.line("}")
+	if !hasContent {
+		return
+	}
+	w.line("")
+	w.line("func translationPayload(record *content.Content, locale string) (map[string]any, error) {")
+	w.line("if record != nil {")
+	w.line("for _, tr := range record.Translations {")
+	w.line("if tr == nil {")
+	w.line("continue")
+	w.line("}")
+	w.line(`if locale == "" || (tr.Locale != nil && strings.EqualFold(tr.Locale.Code, locale)) {`)
+	w.line("return tr.Content, nil")
+	w.line("}")
+	w.line("}")
+	w.line("}")
+	w.line(`return nil, fmt.Errorf("%w: %q", ErrTranslationNotFound, locale)`)
+	w.line("}")
+}
@@ -0,0 +1,422 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/goliatone/go-cms/internal/schema"
+)
+
+type typeKind int
+
+const (
+	kindAny typeKind = iota
+	kindString
+	kindInteger
+	kindNumber
+	kindBoolean
+	kindArray
+	kindMap
+	kindObject
+	kindEnum
+	kindBlocks
+)
+
+// typeRef is the language-neutral shape of a schema node.
+type typeRef struct {
+	kind     typeKind
+	elem     *typeRef
+	name     string
+	nullable bool
+}
+
+type field struct {
+	name        string
+	jsonName    string
+	typ         *typeRef
+	required    bool
+	description string
+}
+
+type objectType struct {
+	name        string
+	description string
+	fields      []field
+}
+
+type enumType struct {
+	name   string
+	values []string
+}
+
+type modelKind string
+
+const (
+	modelContentType modelKind = "content type"
+	modelBlock       modelKind = "block"
+)
+
+// schemaModel is the resolved type graph for one content type or block.
+type schemaModel struct {
+	kind          modelKind
+	slug          string
+	displayName   string
+	ident         string
+	schemaVersion string
+	root          *objectType
+	objects       []*objectType
+	enums         []*enumType
+	// allowedBlocks narrows embedded blocks to the listed block slugs; nil
+	// allows every block.
+	allowedBlocks []string
+}
+
+type bundleModel struct {
+	contentTypes []*schemaModel
+	blocks       []*schemaModel
+}
+
+func buildModel(bundle *Bundle) (*bundleModel, error) {
+	if bundle == nil || (len(bundle.ContentTypes) == 0 && len(bundle.BlockDefinitions) == 0) {
+		return nil, ErrBundleEmpty
+	}
+	sorted := *bundle
+	sorted.ContentTypes = slices.Clone(bundle.ContentTypes)
+	sorted.BlockDefinitions = slices.Clone(bundle.BlockDefinitions)
+	sorted.sort()
+
+	names := map[string]string{}
+	claim := func(name, owner string) error {
+		if previous, ok := names[name]; ok {
+			return fmt.Errorf("codegen: type name %s generated for both %s and %s", name, previous, owner)
+		}
+		names[name] = owner
+		return nil
+	}
+	for _, reserved := range []string{"EmbeddedBlock", "ErrTranslationNotFound", "ErrContentTypeMismatch"} {
+		names[reserved] = "generated helpers"
+	}
+
+	out := &bundleModel{}
+	blockSlugs := make([]string, 0, len(sorted.BlockDefinitions))
+	for _, entry := range sorted.BlockDefinitions {
+		model, err := newSchemaModel(modelBlock, entry, blockIdent(entry.Slug), claim)
+		if err != nil {
+			return nil, err
+		}
+		out.blocks = append(out.blocks, model)
+		blockSlugs = append(blockSlugs, entry.Slug)
+	}
+	for _, entry := range sorted.ContentTypes {
+		model, err := newSchemaModel(modelContentType, entry, exportedName(entry.Slug), claim)
+		if err != nil {
+			return nil, err
+		}
+		availability := schema.ExtractMetadata(entry.Schema).BlockAvailability
+		if !availability.Empty() {
+			model.allowedBlocks = []string{}
+			for _, slug := range blockSlugs {
+				if availability.Allows(slug) {
+					model.allowedBlocks = append(model.allowedBlocks, slug)
+				}
+			}
+		}
+		out.contentTypes = append(out.contentTypes, model)
+	}
+	return out, nil
+}
+
+func newSchemaModel(kind modelKind, entry SchemaEntry, ident string, claim func(name, owner string) error) (*schemaModel, error) {
+	slug := strings.TrimSpace(entry.Slug)
+	if slug == "" {
+		return nil, fmt.Errorf("codegen: %s slug required", kind)
+	}
+	if ident == "" {
+		return nil, fmt.Errorf("codegen: %s %q has no usable identifier", kind, slug)
+	}
+	version := strings.TrimSpace(entry.SchemaVersion)
+	if version == "" {
+		version = schema.ExtractMetadata(entry.Schema).SchemaVersion
+	}
+	name := strings.TrimSpace(entry.Name)
+	if name == "" {
+		name = slug
+	}
+	model := &schemaModel{
+		kind:          kind,
+		slug:          slug,
+		displayName:   name,
+		ident:         ident,
+		schemaVersion: version,
+	}
+	owner := fmt.Sprintf("%s %q", kind, slug)
+	builder := &modelBuilder{model: model, claim: func(name string) error { return claim(name, owner) }}
+	for _, name := range helperNames(kind, ident) {
+		if err := builder.claim(name); err != nil {
+			return nil, err
+		}
+	}
+	root, err := builder.object(ident, entry.Schema, kind == modelContentType)
+	if err != nil {
+		return nil, err
+	}
+	model.root = root
+	return model, nil
+}
+
+// helperNames lists the identifiers generated for a schema besides its
+// nested types.
+func helperNames(kind modelKind, ident string) []string {
+	if kind == modelBlock {
+		return []string{ident, ident + "Type", ident + "SchemaVersion", "Decode" + ident}
+	}
+	return []string{ident, ident + "Slug", ident + "SchemaVersion", ident + "FromContent", "Decode" + ident, "Get" + ident}
+}
+
+type modelBuilder struct {
+	model *schemaModel
+	claim func(name string) error
+}
+
+// nestedName claims name for a nested type, appending a counter when a
+// helper or another schema already uses it.
+func (b *modelBuilder) nestedName(name string) string {
+	candidate := name
+	for i := 2; b.claim(candidate) != nil; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	return candidate
+}
+
+func (b *modelBuilder) object(name string, node map[string]any, root bool) (*objectType, error) {
+	obj := &objectType{name: name, description: stringValue(node["description"])}
+	properties, _ := node["properties"].(map[string]any)
+	required := requiredSet(node["required"])
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	used := map[string]int{}
+	for _, key := range keys {
+		child, _ := properties[key].(map[string]any)
+		fieldName := exportedName(key)
+		if fieldName == "" || fieldName == "Encode" || fieldName == "Embed" {
+			fieldName += "Field"
+		}
+		if count := used[fieldName]; count > 0 {
+			used[fieldName]++
+			fieldName = fmt.Sprintf("%s%d", fieldName, count+1)
+		} else {
+			used[fieldName] = 1
+		}
+		var typ *typeRef
+		var err error
+		if root && key == "blocks" && nodeType(child) == "array" {
+			typ = &typeRef{kind: kindBlocks}
+		} else {
+			typ, err = b.resolve(name+fieldName, child)
+			if err != nil {
+				return nil, err
+			}
+		}
+		obj.fields = append(obj.fields, field{
+			name:        fieldName,
+			jsonName:    key,
+			typ:         typ,
+			required:    required[key],
+			description: stringValue(child["description"]),
+		})
+	}
+	return obj, nil
+}
+
+func (b *modelBuilder) resolve(name string, node map[string]any) (*typeRef, error) {
+	if node == nil {
+		return &typeRef{kind: kindAny}, nil
+	}
+	nullable := isNullable(node)
+	switch nodeType(node) {
+	case "string":
+		values, ok := stringEnum(node["enum"])
+		if !ok {
+			return &typeRef{kind: kindString, nullable: nullable}, nil
+		}
+		name = b.nestedName(name)
+		b.model.enums = append(b.model.enums, &enumType{name: name, values: values})
+		return &typeRef{kind: kindEnum, name: name, nullable: nullable}, nil
+	case "integer":
+		return &typeRef{kind: kindInteger, nullable: nullable}, nil
+	case "number":
+		return &typeRef{kind: kindNumber, nullable: nullable}, nil
+	case "boolean":
+		return &typeRef{kind: kindBoolean, nullable: nullable}, nil
+	case "array":
+		items, _ := node["items"].(map[string]any)
+		elem, err := b.resolve(name+"Item", items)
+		if err != nil {
+			return nil, err
+		}
+		return &typeRef{kind: kindArray, elem: elem, nullable: nullable}, nil
+	case "object":
+		if properties, ok := node["properties"].(map[string]any); ok && len(properties) > 0 {
+			name = b.nestedName(name)
+			obj, err := b.object(name, node, false)
+			if err != nil {
+				return nil, err
+			}
+			b.model.objects = append(b.model.objects, obj)
+			return &typeRef{kind: kindObject, name: name, nullable: nullable}, nil
+		}
+		if additional, ok := node["additionalProperties"].(map[string]any); ok {
+			elem, err := b.resolve(name+"Value", additional)
+			if err != nil {
+				return nil, err
+			}
+			return &typeRef{kind: kindMap, elem: elem, nullable: nullable}, nil
+		}
+		return &typeRef{kind: kindMap, elem: &typeRef{kind: kindAny}, nullable: nullable}, nil
+	default:
+		return &typeRef{kind: kindAny}, nil
+	}
+}
+
+// nodeType returns the single non-null JSON schema type of node, inferring
+// object and array nodes that omit "type". Unions resolve to "".
+func nodeType(node map[string]any) string {
+	if node == nil {
+		return ""
+	}
+	switch typed := node["type"].(type) {
+	case string:
+		return typed
+	case []any:
+		var found string
+		for _, entry := range typed {
+			value, _ := entry.(string)
+			if value == "" || value == "null" {
+				continue
+			}
+			if found != "" {
+				return ""
+			}
+			found = value
+		}
+		return found
+	}
+	if _, ok := node["properties"]; ok {
+		return "object"
+	}
+	if _, ok := node["items"]; ok {
+		return "array"
+	}
+	if _, ok := stringEnum(node["enum"]); ok {
+		return "string"
+	}
+	return ""
+}
+
+func isNullable(node map[string]any) bool {
+	if nullable, ok := node["nullable"].(bool); ok && nullable {
+		return true
+	}
+	if types, ok := node["type"].([]any); ok {
+		return slices.Contains(types, any("null"))
+	}
+	return false
+}
+
+func stringEnum(raw any) ([]string, bool) {
+	values, ok := raw.([]any)
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		if !slices.Contains(out, str) {
+			out = append(out, str)
+		}
+	}
+	return out, true
+}
+
+func requiredSet(raw any) map[string]bool {
+	out := map[string]bool{}
+	switch typed := raw.(type) {
+	case []any:
+		for _, entry := range typed {
+			if value, ok := entry.(string); ok {
+				out[value] = true
+			}
+		}
+	case []string:
+		for _, value := range typed {
+			out[value] = true
+		}
+	}
+	return out
+}
+
+func stringValue(raw any) string {
+	value, _ := raw.(string)
+	return strings.TrimSpace(value)
+}
+
+func blockIdent(slug string) string {
+	name := exportedName(slug)
+	if name == "" || strings.HasSuffix(name, "Block") {
+		return name
+	}
+	return name + "Block"
+}
+
+var commonInitialisms = map[string]string{
+	"api": "API", "css": "CSS", "html": "HTML", "http": "HTTP", "https": "HTTPS",
+	"id": "ID", "ip": "IP", "json": "JSON", "seo": "SEO", "sku": "SKU",
+	"uri": "URI", "url": "URL", "uuid": "UUID",
+}
+
+// exportedName converts a slug or JSON key to an exported identifier.
+func exportedName(value string) string {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var out strings.Builder
+	for _, part := range parts {
+		for _, word := range splitCamel(part) {
+			if initialism, ok := commonInitialisms[strings.ToLower(word)]; ok {
+				out.WriteString(initialism)
+				continue
+			}
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			out.WriteString(string(runes))
+		}
+	}
+	name := out.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// splitCamel splits camelCase words so "heroImageUrl" yields hero, Image, Url.
+func splitCamel(value string) []string {
+	var words []string
+	start := 0
+	runes := []rune(value)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
@@ -0,0 +1,196 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// GenerateTypeScript emits a TypeScript declaration file describing every
+// content type and block payload in the bundle. Output is stable for a bundle.
+func GenerateTypeScript(bundle *Bundle) ([]byte, error) {
+	model, err := buildModel(bundle)
+	if err != nil {
+		return nil, err
+	}
+	blockIdents := make(map[string]string, len(model.blocks))
+	for _, block := range model.blocks {
+		blockIdents[block.slug] = block.ident
+	}
+
+	w := &tsWriter{blockIdents: blockIdents}
+	w.line("// " + generatedHeader)
+	for _, ct := range model.contentTypes {
+		w.line("")
+		w.doc(fmt.Sprintf("Payload of the %q content type (%s).", ct.displayName, versionLabel(ct)), ct.root.description, "")
+		w.schema(ct)
+	}
+	for _, block := range model.blocks {
+		w.line("")
+		w.doc(fmt.Sprintf("Payload of the %q block (%s).", block.displayName, versionLabel(block)), block.root.description, "")
+		w.schema(block)
+	}
+
+	w.line("")
+	w.doc("Block payload embedded in content, discriminated by `_type`.", "", "")
+	if len(model.blocks) == 0 {
+		w.line("export type EmbeddedBlock = { _type: string; _schema?: string } & Record<string, unknown>;")
+	} else {
+		w.line("export type EmbeddedBlock =")
+		for idx, block := range model.blocks {
+			suffix := ""
+			if idx == len(model.blocks)-1 {
+				suffix = ";"
+			}
+			w.line(fmt.Sprintf("  | ({ _type: %s; _schema?: string } & %s)%s", strconv.Quote(block.slug), block.ident, suffix))
+		}
+	}
+
+	if len(model.contentTypes) > 0 {
+		w.line("")
+		w.doc("Content type payloads keyed by content type slug.", "", "")
+		w.line("export interface ContentTypes {")
+		for _, ct := range model.contentTypes {
+			w.line(fmt.Sprintf("  %s: %s;", tsKey(ct.slug), ct.ident))
+		}
+		w.line("}")
+	}
+	if len(model.blocks) > 0 {
+		w.line("")
+		w.doc("Block payloads keyed by block type.", "", "")
+		w.line("export interface Blocks {")
+		for _, block := range model.blocks {
+			w.line(fmt.Sprintf("  %s: %s;", tsKey(block.slug), block.ident))
+		}
+		w.line("}")
+	}
+	return []byte(w.buf.String()), nil
+}
+
+type tsWriter struct {
+	buf         strings.Builder
+	blockIdents map[string]string
+	allowed     []string
+}
+
+func (w *tsWriter) line(value string) {
+	w.buf.WriteString(value)
+	w.buf.WriteByte('\n')
+}
+
+func (w *tsWriter) doc(summary, description, indent string) {
+	if description == "" {
+		w.line(fmt.Sprintf("%s/** %s */", indent, summary))
+		return
+	}
+	w.line(indent + "/**")
+	if summary != "" {
+		w.line(indent + " * " + summary)
+		w.line(indent + " *")
+	}
+	for _, line := range strings.Split(description, "\n") {
+		w.line(strings.TrimRight(indent+" * "+strings.ReplaceAll(line, "*/", "*\\/"), " "))
+	}
+	w.line(indent + " */")
+}
+
+func (w *tsWriter) schema(m *schemaModel) {
+	w.allowed = m.allowedBlocks
+	w.object(m.root)
+	for _, obj := range m.objects {
+		w.line("")
+		w.doc(fmt.Sprintf("Nested object of %s.", m.ident), obj.description, "")
+		w.object(obj)
+	}
+	for _, enum := range m.enums {
+		w.line("")
+		values := make([]string, 0, len(enum.values))
+		for _, value := range enum.values {
+			values = append(values, strconv.Quote(value))
+		}
+		w.line(fmt.Sprintf("export type %s = %s;", enum.name, strings.Join(values, " | ")))
+	}
+	w.allowed = nil
+}
+
+func (w *tsWriter) object(obj *objectType) {
+	w.line(fmt.Sprintf("export interface %s {", obj.name))
+	for _, f := range obj.fields {
+		if f.description != "" {
+			w.doc("", f.description, "  ")
+		}
+		optional := ""
+		if !f.required {
+			optional = "?"
+		}
+		w.line(fmt.Sprintf("  %s%s: %s;", tsKey(f.jsonName), optional, w.tsType(f.typ)))
+	}
+	w.line("}")
+}
+
+func (w *tsWriter) tsType(t *typeRef) string {
+	var base string
+	switch t.kind {
+	case kindString:
+		base = "string"
+	case kindInteger, kindNumber:
+		base = "number"
+	case kindBoolean:
+		base = "boolean"
+	case kindEnum, kindObject:
+		base = t.name
+	case kindArray:
+		base = arrayOf(w.tsType(t.elem))
+	case kindMap:
+		base = fmt.Sprintf("Record<string, %s>", w.tsType(t.elem))
+	case kindBlocks:
+		base = arrayOf(w.blocksType())
+	default:
+		return "unknown"
+	}
+	if t.nullable {
+		return base + " | null"
+	}
+	return base
+}
+
+// blocksType narrows EmbeddedBlock to the blocks the content type allows.
+func (w *tsWriter) blocksType() string {
+	if w.allowed == nil {
+		return "EmbeddedBlock"
+	}
+	types := make([]string, 0, len(w.allowed))
+	for _, slug := range w.allowed {
+		if _, ok := w.blockIdents[slug]; ok {
+			types = append(types, strconv.Quote(slug))
+		}
+	}
+	if len(types) == 0 {
+		return "never"
+	}
+	return fmt.Sprintf("Extract<EmbeddedBlock, { _type: %s }>", strings.Join(types, " | "))
+}
+
+func arrayOf(elem string) string {
+	if tsIdentifier.MatchString(elem) || strings.HasSuffix(elem, "[]") && !strings.Contains(elem, " ") {
+		return elem + "[]"
+	}
+	return fmt.Sprintf("Array<%s>", elem)
+}
+
+func tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func versionLabel(m *schemaModel) string {
+	if m.schemaVersion == "" {
+		return "unversioned"
+	}
+	return m.schemaVersion
+}
@@ -1,8 +1,11 @@
 package auditcmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -124,8 +127,8 @@ func TestCleanupAuditHandlerClearsEvents(t *testing.T) {
 	if err := handler.Execute(context.Background(), CleanupAuditCommand{}); err != nil {
 		t.Fatalf("cleanup execute: %v", err)
 	}
-	if log.listCalls != 1 {
-		t.Fatalf("expected list to be called once, got %d", log.listCalls)
+	if log.listCalls != 0 {
+		t.Fatalf("expected cleanup not to list events, got %d list calls", log.listCalls)
 	}
 	if log.clearCalls != 1 {
 		t.Fatalf("expected clear calls 1, got %d", log.clearCalls)
@@ -137,7 +140,7 @@ func TestCleanupAuditHandlerPropagatesErrors(t *testing.T) {
 	log := &stubAuditLog{listErr: listErr}
 	handler := NewCleanupAuditHandler(log, logging.NoOp())
 
-	err := handler.Execute(context.Background(), CleanupAuditCommand{})
+	err := handler.Execute(context.Background(), CleanupAuditCommand{DryRun: true})
 	if err == nil {
 		t.Fatal("expected list error")
 	}
@@ -192,3 +195,158 @@ func TestCleanupAuditHandlerCronOverride(t *testing.T) {
 		t.Fatalf("expected cron max retries %d, got %d", cfg.MaxRetries, got.MaxRetries)
 	}
 }
+
+func TestExportAuditHandlerWritesFilteredNDJSON(t *testing.T) {
+	recorder := jobs.NewInMemoryAuditRecorder()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for idx, event := range []jobs.AuditEvent{
+		{EntityType: "content", EntityID: "1", Action: "publish", ActorID: "u-1"},
+		{EntityType: "content", EntityID: "2", Action: "publish", ActorID: "u-2"},
+		{EntityType: "page", EntityID: "3", Action: "publish", ActorID: "u-1"},
+		{EntityType: "content", EntityID: "4", Action: "unpublish", ActorID: "u-1"},
+	} {
+		event.OccurredAt = base.Add(time.Duration(idx) * time.Minute)
+		if err := recorder.Record(context.Background(), event); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	handler := NewExportAuditHandler(recorder, logging.NoOp(), ExportWithWriter(&buf))
+
+	err := handler.Execute(context.Background(), ExportAuditCommand{
+		Format:     "ndjson",
+		EntityType: "content",
+		ActorID:    "u-1",
+	})
+	if err != nil {
+		t.Fatalf("export execute: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two ndjson lines, got %q", buf.String())
+	}
+	var first jobs.AuditEvent
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("decode ndjson: %v", err)
+	}
+	if first.EntityID != "1" || first.ActorID != "u-1" || !first.OccurredAt.Equal(base) {
+		t.Fatalf("unexpected exported event %+v", first)
+	}
+}
+
+func TestExportAuditHandlerWritesCSVFromListOnlyLog(t *testing.T) {
+	log := &stubAuditLog{
+		events: []jobs.AuditEvent{
+			{EntityType: "content", EntityID: "1", Action: "publish", OccurredAt: time.Now(), Metadata: map[string]any{"job_id": "j-1"}},
+			{EntityType: "page", EntityID: "2", Action: "publish", OccurredAt: time.Now()},
+		},
+	}
+	var buf bytes.Buffer
+	handler := NewExportAuditHandler(log, logging.NoOp(), ExportWithWriter(&buf))
+
+	if err := handler.Execute(context.Background(), ExportAuditCommand{Format: "csv", EntityType: "content"}); err != nil {
+		t.Fatalf("export execute: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "id,occurred_at,entity_type") {
+		t.Fatalf("expected header and one row, got %q", buf.String())
+	}
+	if !strings.Contains(lines[1], ",content,1,publish,") || !strings.Contains(lines[1], `"{""job_id"":""j-1""}"`) {
+		t.Fatalf("unexpected csv row %q", lines[1])
+	}
+}
+
+func TestExportAuditCommandValidatesFormatAndRange(t *testing.T) {
+	if err := (ExportAuditCommand{Format: "xml"}).Validate(); err == nil {
+		t.Fatal("expected unsupported format to fail validation")
+	}
+	since := time.Now()
+	until := since.Add(-time.Hour)
+	if err := (ExportAuditCommand{Since: &since, Until: &until}).Validate(); err == nil {
+		t.Fatal("expected inverted range to fail validation")
+	}
+}
+
+func TestCleanupAuditHandlerCronPurgesPastRetention(t *testing.T) {
+	recorder := jobs.NewInMemoryAuditRecorder()
+	now := time.Now()
+	for _, occurredAt := range []time.Time{now.Add(-72 * time.Hour), now.Add(-time.Hour)} {
+		if err := recorder.Record(context.Background(), jobs.AuditEvent{EntityType: "content", EntityID: "1", OccurredAt: occurredAt}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	handler := NewCleanupAuditHandler(recorder, logging.NoOp(), CleanupWithRetention(24*time.Hour))
+
+	if err := handler.CronHandler()(); err != nil {
+		t.Fatalf("cron handler execute: %v", err)
+	}
+	if events := recorder.Events(); len(events) != 1 || events[0].OccurredAt.Before(now.Add(-24*time.Hour)) {
+		t.Fatalf("expected only the recent event to remain, got %+v", events)
+	}
+}
+
+func TestCleanupAuditHandlerRetentionRefusesToClearWithoutPurger(t *testing.T) {
+	log := &stubAuditLog{
+		events: []jobs.AuditEvent{{EntityType: "content", EntityID: "1", OccurredAt: time.Now()}},
+	}
+	handler := NewCleanupAuditHandler(log, logging.NoOp(), CleanupWithRetention(24*time.Hour))
+
+	if err := handler.CronHandler()(); !errors.Is(err, ErrPurgeUnsupported) {
+		t.Fatalf("expected ErrPurgeUnsupported, got %v", err)
+	}
+	before := time.Now().Add(-time.Hour)
+	if err := handler.Execute(context.Background(), CleanupAuditCommand{Before: &before, DryRun: true}); !errors.Is(err, ErrPurgeUnsupported) {
+		t.Fatalf("expected ErrPurgeUnsupported for dry run, got %v", err)
+	}
+	if log.clearCalls != 0 {
+		t.Fatalf("expected the log not to be cleared, got %d clear calls", log.clearCalls)
+	}
+}
+
+type countingAuditRecorder struct {
+	*jobs.InMemoryAuditRecorder
+	listCalls  int
+	countCalls int
+}
+
+func (r *countingAuditRecorder) List(ctx context.Context) ([]jobs.AuditEvent, error) {
+	r.listCalls++
+	return r.InMemoryAuditRecorder.List(ctx)
+}
+
+func (r *countingAuditRecorder) Count(ctx context.Context, before time.Time) (int, error) {
+	r.countCalls++
+	return r.InMemoryAuditRecorder.Count(ctx, before)
+}
+
+func TestCleanupAuditHandlerPurgesAndCountsWithoutListing(t *testing.T) {
+	recorder := &countingAuditRecorder{InMemoryAuditRecorder: jobs.NewInMemoryAuditRecorder()}
+	now := time.Now()
+	for _, occurredAt := range []time.Time{now.Add(-72 * time.Hour), now.Add(-48 * time.Hour), now.Add(-time.Hour)} {
+		if err := recorder.Record(context.Background(), jobs.AuditEvent{EntityType: "content", EntityID: "1", OccurredAt: occurredAt}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	handler := NewCleanupAuditHandler(recorder, logging.NoOp())
+	before := now.Add(-24 * time.Hour)
+
+	if err := handler.Execute(context.Background(), CleanupAuditCommand{Before: &before, DryRun: true}); err != nil {
+		t.Fatalf("cleanup dry run: %v", err)
+	}
+	if recorder.countCalls != 2 || len(recorder.Events()) != 3 {
+		t.Fatalf("expected dry run to count without removing, got %d count calls and %d events", recorder.countCalls, len(recorder.Events()))
+	}
+
+	if err := handler.Execute(context.Background(), CleanupAuditCommand{Before: &before}); err != nil {
+		t.Fatalf("cleanup execute: %v", err)
+	}
+	if recorder.countCalls != 2 {
+		t.Fatalf("expected purge not to count events, got %d count calls", recorder.countCalls)
+	}
+	if recorder.listCalls != 0 {
+		t.Fatalf("expected cleanup not to list events, got %d list calls", recorder.listCalls)
+	}
+	if events := recorder.Events(); len(events) != 1 {
+		t.Fatalf("expected only the recent event to remain, got %d", len(events))
+	}
+}
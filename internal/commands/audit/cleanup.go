@@ -6,6 +6,7 @@ import (
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/internal/commands"
 	"github.com/goliatone/go-cms/internal/logging"
 	"github.com/goliatone/go-cms/pkg/interfaces"
@@ -14,15 +15,36 @@ import (
 
 const cleanupAuditMessageType = "cms.audit.cleanup"
 
+// ErrPurgeUnsupported is returned when a cleanup with a cutoff runs against an
+// audit log that cannot remove events selectively.
+var ErrPurgeUnsupported = audit.ErrPurgeUnsupported
+
 // AuditCleaner extends AuditLog with cleanup capabilities.
 type AuditCleaner interface {
 	AuditLog
 	Clear(ctx context.Context) error
 }
 
-// CleanupAuditCommand removes recorded audit events. When DryRun is true only the event count is reported.
+// AuditPurger is implemented by audit logs that can remove events older than
+// a cutoff. Cleanups with a cutoff fail with ErrPurgeUnsupported on logs
+// without it rather than clearing them.
+type AuditPurger interface {
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// AuditCounter is implemented by audit logs that can count events without
+// loading them. A zero cutoff counts every event. Dry runs list the log when
+// it is missing.
+type AuditCounter interface {
+	Count(ctx context.Context, before time.Time) (int, error)
+}
+
+// CleanupAuditCommand removes recorded audit events. When Before is set only
+// events that occurred before it are removed. When DryRun is true only the
+// event count is reported.
 type CleanupAuditCommand struct {
-	DryRun bool `json:"dry_run,omitempty"`
+	DryRun bool       `json:"dry_run,omitempty"`
+	Before *time.Time `json:"before,omitempty"`
 }
 
 // Type implements command.Message.
@@ -36,6 +58,7 @@ func (CleanupAuditCommand) Validate() error {
 type cleanupHandlerConfig struct {
 	cronConfig command.HandlerConfig
 	timeout    time.Duration
+	retention  time.Duration
 }
 
 // CleanupHandlerOption customises the cleanup handler.
@@ -57,6 +80,16 @@ func CleanupWithCronExpression(expression string) CleanupHandlerOption {
 	}
 }
 
+// CleanupWithRetention makes scheduled cleanups keep events newer than the
+// retention window instead of clearing the log.
+func CleanupWithRetention(retention time.Duration) CleanupHandlerOption {
+	return func(cfg *cleanupHandlerConfig) {
+		if retention > 0 {
+			cfg.retention = retention
+		}
+	}
+}
+
 // CleanupWithTimeout overrides the default execution timeout.
 func CleanupWithTimeout(timeout time.Duration) CleanupHandlerOption {
 	return func(cfg *cleanupHandlerConfig) {
@@ -70,6 +103,8 @@ type CleanupAuditHandler struct {
 	logger     interfaces.Logger
 	cronConfig command.HandlerConfig
 	timeout    time.Duration
+	retention  time.Duration
+	now        func() time.Time
 }
 
 // NewCleanupAuditHandler constructs a handler that delegates to the provided cleaner instance.
@@ -91,6 +126,8 @@ func NewCleanupAuditHandler(cleaner AuditCleaner, logger interfaces.Logger, opts
 		logger:     commands.EnsureLogger(logger),
 		cronConfig: cfg.cronConfig,
 		timeout:    cfg.timeout,
+		retention:  cfg.retention,
+		now:        time.Now,
 	}
 }
 
@@ -107,37 +144,91 @@ func (h *CleanupAuditHandler) Execute(ctx context.Context, msg CleanupAuditComma
 		return commands.WrapContextError(err)
 	}
 
-	events, err := h.cleaner.List(ctx)
-	if err != nil {
-		return commands.WrapExecuteError(err)
-	}
-
 	logger := logging.WithFields(h.logger, map[string]any{
 		"operation": "audit.cleanup",
 	})
 
+	purger, canPurge := h.cleaner.(AuditPurger)
+	if msg.Before != nil && !canPurge {
+		return commands.WrapExecuteError(ErrPurgeUnsupported)
+	}
 	if msg.DryRun {
+		return h.dryRun(ctx, logger, msg.Before)
+	}
+
+	if msg.Before != nil {
+		removed, err := purger.Purge(ctx, *msg.Before)
+		if err != nil {
+			return commands.WrapExecuteError(err)
+		}
 		logging.WithFields(logger, map[string]any{
-			"dry_run":        true,
-			"existing_count": len(events),
-		}).Debug("audit.command.cleanup.dry_run")
+			"before":  msg.Before.Format(time.RFC3339),
+			"removed": removed,
+		}).Debug("audit.command.cleanup.removed")
 		return nil
 	}
 
 	if err := h.cleaner.Clear(ctx); err != nil {
 		return commands.WrapExecuteError(err)
 	}
+	logger.Debug("audit.command.cleanup.cleared")
+	return nil
+}
 
-	logging.WithFields(logger, map[string]any{
-		"removed": len(events),
-	}).Debug("audit.command.cleanup.removed")
+// dryRun reports how many events a cleanup would remove without removing
+// them.
+func (h *CleanupAuditHandler) dryRun(ctx context.Context, logger interfaces.Logger, before *time.Time) error {
+	existing, err := h.count(ctx, time.Time{})
+	if err != nil {
+		return commands.WrapExecuteError(err)
+	}
+	fields := map[string]any{
+		"dry_run":        true,
+		"existing_count": existing,
+	}
+	if before != nil {
+		expired, err := h.count(ctx, *before)
+		if err != nil {
+			return commands.WrapExecuteError(err)
+		}
+		fields["before"] = before.Format(time.RFC3339)
+		fields["expired_count"] = expired
+	}
+	logging.WithFields(logger, fields).Debug("audit.command.cleanup.dry_run")
 	return nil
 }
 
+// count reports how many events occurred before the cutoff, or every event
+// when the cutoff is zero. Logs without AuditCounter are listed and counted.
+func (h *CleanupAuditHandler) count(ctx context.Context, before time.Time) (int, error) {
+	if counter, ok := h.cleaner.(AuditCounter); ok {
+		return counter.Count(ctx, before)
+	}
+	events, err := h.cleaner.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if before.IsZero() {
+		return len(events), nil
+	}
+	count := 0
+	for _, event := range events {
+		if event.OccurredAt.Before(before) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // CronHandler satisfies command.CronCommand by binding cleanup execution to a cron runner.
 func (h *CleanupAuditHandler) CronHandler() func() error {
 	return func() error {
-		return h.Execute(context.Background(), CleanupAuditCommand{})
+		msg := CleanupAuditCommand{}
+		if h.retention > 0 {
+			before := h.now().Add(-h.retention)
+			msg.Before = &before
+		}
+		return h.Execute(context.Background(), msg)
 	}
 }
 
@@ -156,6 +247,6 @@ func (h *CleanupAuditHandler) CLIOptions() command.CLIConfig {
 	return command.CLIConfig{
 		Path:        []string{"audit", "cleanup"},
 		Group:       "audit",
-		Description: "Remove recorded audit events; supports dry-run",
+		Description: "Remove recorded audit events, optionally only those before a cutoff; supports dry-run",
 	}
 }
@@ -2,9 +2,14 @@ package auditcmd
 
 import (
 	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/internal/commands"
 	"github.com/goliatone/go-cms/internal/jobs"
 	"github.com/goliatone/go-cms/internal/logging"
@@ -19,9 +24,26 @@ type AuditLog interface {
 	List(ctx context.Context) ([]jobs.AuditEvent, error)
 }
 
-// ExportAuditCommand retrieves recorded audit events and emits them through the logger.
+// AuditQuerier is implemented by audit logs that can filter and paginate
+// events themselves. Logs without it are listed and filtered in memory.
+type AuditQuerier interface {
+	Query(ctx context.Context, query jobs.AuditQuery) (jobs.AuditPage, error)
+}
+
+// ExportAuditCommand retrieves recorded audit events matching the filters.
+// Without a Format the events are emitted through the logger; "ndjson" and
+// "csv" write them to Output, or to the handler's writer when Output is empty.
 type ExportAuditCommand struct {
-	MaxRecords *int `json:"max_records,omitempty"`
+	MaxRecords     *int       `json:"max_records,omitempty"`
+	Format         string     `json:"format,omitempty"`
+	Output         string     `json:"output,omitempty"`
+	EntityType     string     `json:"entity_type,omitempty"`
+	EntityID       string     `json:"entity_id,omitempty"`
+	ActorID        string     `json:"actor_id,omitempty"`
+	Action         string     `json:"action,omitempty"`
+	EnvironmentKey string     `json:"environment_key,omitempty"`
+	Since          *time.Time `json:"since,omitempty"`
+	Until          *time.Time `json:"until,omitempty"`
 }
 
 // Type implements command.Message.
@@ -29,23 +51,46 @@ func (ExportAuditCommand) Type() string { return exportAuditMessageType }
 
 // Validate ensures the command payload is well-formed.
 func (m ExportAuditCommand) Validate() error {
-	return validation.ValidateStruct(&m,
-		validation.Field(&m.MaxRecords, validation.By(func(value any) error {
-			if m.MaxRecords == nil {
-				return nil
-			}
-			if *m.MaxRecords < 0 {
-				return validation.NewError("cms.audit.export.max_records_invalid", "max_records must be zero or positive")
-			}
-			return nil
-		})),
-	)
+	errs := validation.Errors{}
+	if m.MaxRecords != nil && *m.MaxRecords < 0 {
+		errs["max_records"] = validation.NewError("cms.audit.export.max_records_invalid", "max_records must be zero or positive")
+	}
+	if strings.TrimSpace(m.Format) != "" {
+		if _, err := audit.ParseFormat(m.Format); err != nil {
+			errs["format"] = validation.NewError("cms.audit.export.format_invalid", "format must be ndjson or csv")
+		}
+	}
+	if m.Since != nil && m.Until != nil && !m.Since.Before(*m.Until) {
+		errs["until"] = validation.NewError("cms.audit.export.range_invalid", "until must be after since")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
 }
 
-// ExportAuditHandler logs recorded audit events up to the provided limit.
+func (m ExportAuditCommand) query() jobs.AuditQuery {
+	query := jobs.AuditQuery{
+		EntityType:     strings.TrimSpace(m.EntityType),
+		EntityID:       strings.TrimSpace(m.EntityID),
+		ActorID:        strings.TrimSpace(m.ActorID),
+		Action:         strings.TrimSpace(m.Action),
+		EnvironmentKey: strings.TrimSpace(m.EnvironmentKey),
+	}
+	if m.Since != nil {
+		query.From = *m.Since
+	}
+	if m.Until != nil {
+		query.To = *m.Until
+	}
+	return query
+}
+
+// ExportAuditHandler exports recorded audit events up to the provided limit.
 type ExportAuditHandler struct {
 	log     AuditLog
 	logger  interfaces.Logger
+	writer  io.Writer
 	timeout time.Duration
 }
 
@@ -59,11 +104,22 @@ func ExportWithTimeout(timeout time.Duration) ExportHandlerOption {
 	}
 }
 
+// ExportWithWriter overrides the destination for NDJSON and CSV exports that
+// do not name an output file. Defaults to standard output.
+func ExportWithWriter(writer io.Writer) ExportHandlerOption {
+	return func(h *ExportAuditHandler) {
+		if writer != nil {
+			h.writer = writer
+		}
+	}
+}
+
 // NewExportAuditHandler constructs a handler wired to the provided audit log implementation.
 func NewExportAuditHandler(log AuditLog, logger interfaces.Logger, opts ...ExportHandlerOption) *ExportAuditHandler {
 	handler := &ExportAuditHandler{
 		log:     log,
 		logger:  commands.EnsureLogger(logger),
+		writer:  os.Stdout,
 		timeout: commands.DefaultCommandTimeout,
 	}
 	for _, opt := range opts {
@@ -75,7 +131,7 @@ func NewExportAuditHandler(log AuditLog, logger interfaces.Logger, opts ...Expor
 }
 
 // Execute satisfies command.Commander[ExportAuditCommand].
-func (h *ExportAuditHandler) Execute(ctx context.Context, msg ExportAuditCommand) error {
+func (h *ExportAuditHandler) Execute(ctx context.Context, msg ExportAuditCommand) (err error) {
 	if err := commands.WrapValidationError(command.ValidateMessage(msg)); err != nil {
 		return err
 	}
@@ -87,38 +143,122 @@ func (h *ExportAuditHandler) Execute(ctx context.Context, msg ExportAuditCommand
 		return commands.WrapContextError(err)
 	}
 
-	events, err := h.log.List(ctx)
+	limit := -1
+	if msg.MaxRecords != nil {
+		limit = *msg.MaxRecords
+	}
+	events, total, err := h.collect(ctx, msg.query(), limit)
 	if err != nil {
 		return commands.WrapExecuteError(err)
 	}
-	limit := len(events)
-	if msg.MaxRecords != nil && *msg.MaxRecords >= 0 && *msg.MaxRecords < limit {
-		limit = *msg.MaxRecords
-	}
 
 	baseLogger := logging.WithFields(h.logger, map[string]any{
 		"operation": "audit.export",
 	})
 
-	for idx := 0; idx < limit; idx++ {
-		event := events[idx]
-		logging.WithFields(baseLogger, map[string]any{
-			"index":       idx,
-			"entity_type": event.EntityType,
-			"entity_id":   event.EntityID,
-			"action":      event.Action,
-			"occurred_at": event.OccurredAt.Format(time.RFC3339),
-			"metadata":    event.Metadata,
-		}).Debug("audit.command.export.event")
-	}
-
-	logging.WithFields(baseLogger, map[string]any{
-		"exported": limit,
-		"total":    len(events),
-	}).Info("audit.command.export.completed")
+	if strings.TrimSpace(msg.Format) == "" {
+		for idx, event := range events {
+			logging.WithFields(baseLogger, map[string]any{
+				"index":           idx,
+				"entity_type":     event.EntityType,
+				"entity_id":       event.EntityID,
+				"action":          event.Action,
+				"actor_id":        event.ActorID,
+				"environment_key": event.EnvironmentKey,
+				"occurred_at":     event.OccurredAt.Format(time.RFC3339),
+				"metadata":        event.Metadata,
+			}).Debug("audit.command.export.event")
+		}
+	} else {
+		format, _ := audit.ParseFormat(msg.Format)
+		writer := h.writer
+		if output := strings.TrimSpace(msg.Output); output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return commands.WrapExecuteError(err)
+			}
+			defer func() {
+				if closeErr := file.Close(); closeErr != nil && err == nil {
+					err = commands.WrapExecuteError(closeErr)
+				}
+			}()
+			writer = file
+		}
+		if err := writeEvents(writer, format, events); err != nil {
+			return commands.WrapExecuteError(err)
+		}
+	}
+
+	fields := map[string]any{
+		"exported": len(events),
+	}
+	if total >= 0 {
+		fields["total"] = total
+	}
+	if format := strings.TrimSpace(msg.Format); format != "" {
+		fields["format"] = format
+	}
+	logging.WithFields(baseLogger, fields).Info("audit.command.export.completed")
 	return nil
 }
 
+// collect returns up to limit matching events (all when limit is negative)
+// and the number of matching events, or -1 when the log pages through
+// results and the total is unknown.
+func (h *ExportAuditHandler) collect(ctx context.Context, query jobs.AuditQuery, limit int) ([]jobs.AuditEvent, int, error) {
+	if querier, ok := h.log.(AuditQuerier); ok {
+		events := make([]jobs.AuditEvent, 0)
+		for limit < 0 || len(events) < limit {
+			query.Limit = audit.MaxLimit
+			if limit >= 0 {
+				query.Limit = min(limit-len(events), audit.MaxLimit)
+			}
+			page, err := querier.Query(ctx, query)
+			if err != nil {
+				return nil, 0, err
+			}
+			events = append(events, page.Events...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		return events, -1, nil
+	}
+
+	listed, err := h.log.List(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	events := make([]jobs.AuditEvent, 0, len(listed))
+	for _, event := range listed {
+		if query.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	total := len(events)
+	if limit >= 0 && limit < len(events) {
+		events = events[:limit]
+	}
+	return events, total, nil
+}
+
+func writeEvents(writer io.Writer, format audit.Format, events []jobs.AuditEvent) error {
+	if writer == nil {
+		return errors.New("audit export: writer is required")
+	}
+	encoder, err := audit.NewEncoder(writer, format)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return encoder.Flush()
+}
+
 // CLIHandler satisfies command.CLICommand by returning the handler.
 func (h *ExportAuditHandler) CLIHandler() any {
 	return h
@@ -129,6 +269,6 @@ func (h *ExportAuditHandler) CLIOptions() command.CLIConfig {
 	return command.CLIConfig{
 		Path:        []string{"audit", "export"},
 		Group:       "audit",
-		Description: "Export audit events to the configured logger",
+		Description: "Export audit events to the logger or as NDJSON/CSV",
 	}
 }
@@ -55,6 +55,14 @@ func (s *stubContentService) Delete(context.Context, content.DeleteContentReques
 	return errors.New("not implemented")
 }
 
+func (s *stubContentService) ListTrashed(context.Context, content.ListTrashedContentRequest) ([]*content.Content, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *stubContentService) RestoreDeleted(context.Context, content.RestoreContentRequest) (*content.Content, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (s *stubContentService) DeleteTranslation(context.Context, content.DeleteContentTranslationRequest) error {
 	return errors.New("not implemented")
 }
@@ -14,6 +14,7 @@ import (
 const (
 	importOperation = "markdown.import_directory"
 	syncOperation   = "markdown.sync_directory"
+	exportOperation = "markdown.export_directory"
 )
 
 var (
@@ -24,6 +25,7 @@ var (
 var (
 	_ command.Commander[ImportDirectoryCommand] = (*ImportDirectoryHandler)(nil)
 	_ command.Commander[SyncDirectoryCommand]   = (*SyncDirectoryHandler)(nil)
+	_ command.Commander[ExportDirectoryCommand] = (*ExportDirectoryHandler)(nil)
 )
 
 // ImportDirectoryHandler orchestrates Markdown directory imports.
@@ -81,6 +83,8 @@ func (h *ImportDirectoryHandler) Execute(ctx context.Context, msg ImportDirector
 		AuthorID:                        msg.AuthorID,
 		DryRun:                          msg.DryRun,
 		ContentAllowMissingTranslations: msg.ContentAllowMissingTranslations,
+		CreatePages:                     msg.CreatePages,
+		TemplateID:                      msg.TemplateID,
 	}
 
 	result, err := h.service.ImportDirectory(ctx, msg.Directory, importOpts)
@@ -168,6 +172,8 @@ func (h *SyncDirectoryHandler) Execute(ctx context.Context, msg SyncDirectoryCom
 		AuthorID:                        msg.AuthorID,
 		DryRun:                          msg.DryRun,
 		ContentAllowMissingTranslations: msg.ContentAllowMissingTranslations,
+		CreatePages:                     msg.CreatePages,
+		TemplateID:                      msg.TemplateID,
 	}
 
 	syncOpts := interfaces.SyncOptions{
@@ -208,3 +214,90 @@ func (h *SyncDirectoryHandler) CLIOptions() command.CLIConfig {
 		Description: "Synchronise markdown content from a directory",
 	}
 }
+
+// ExportDirectoryHandler orchestrates Markdown export workflows.
+type ExportDirectoryHandler struct {
+	service interfaces.MarkdownService
+	logger  interfaces.Logger
+	gates   FeatureGates
+	timeout time.Duration
+}
+
+// ExportDirectoryOption customises the export handler.
+type ExportDirectoryOption func(*ExportDirectoryHandler)
+
+// ExportDirectoryWithTimeout overrides the default execution timeout.
+func ExportDirectoryWithTimeout(timeout time.Duration) ExportDirectoryOption {
+	return func(h *ExportDirectoryHandler) {
+		h.timeout = timeout
+	}
+}
+
+// NewExportDirectoryHandler creates a handler bound to the supplied Markdown service.
+func NewExportDirectoryHandler(service interfaces.MarkdownService, logger interfaces.Logger, gates FeatureGates, opts ...ExportDirectoryOption) *ExportDirectoryHandler {
+	handler := &ExportDirectoryHandler{
+		service: service,
+		logger:  commands.EnsureLogger(logger),
+		gates:   gates,
+		timeout: commands.DefaultCommandTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(handler)
+		}
+	}
+	return handler
+}
+
+// Execute satisfies command.Commander[ExportDirectoryCommand].
+func (h *ExportDirectoryHandler) Execute(ctx context.Context, msg ExportDirectoryCommand) error {
+	if err := commands.WrapValidationError(command.ValidateMessage(msg)); err != nil {
+		return err
+	}
+	ctx = commands.EnsureContext(ctx)
+	ctx, cancel := commands.WithCommandTimeout(ctx, h.timeout)
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		return commands.WrapContextError(err)
+	}
+	if !h.gates.markdownEnabled() {
+		return commands.WrapExecuteError(ErrMarkdownFeatureDisabled)
+	}
+
+	exportOpts := interfaces.ExportOptions{
+		ContentTypeID:  msg.ContentTypeID,
+		EnvironmentKey: msg.EnvironmentKey,
+		Locales:        append([]string(nil), msg.Locales...),
+		AuthorID:       msg.AuthorID,
+		DryRun:         msg.DryRun,
+	}
+
+	result, err := h.service.ExportDirectory(ctx, msg.Directory, exportOpts)
+	if err != nil {
+		return commands.WrapExecuteError(err)
+	}
+	if result != nil {
+		logging.WithFields(h.logger, map[string]any{
+			"written_count":   len(result.Written),
+			"unchanged_count": len(result.Unchanged),
+			"error_count":     len(result.Errors),
+			"dry_run":         msg.DryRun,
+		}).Info("markdown.command.export_directory.completed")
+	}
+	return nil
+}
+
+// CLIHandler exposes the export handler for CLI registration.
+func (h *ExportDirectoryHandler) CLIHandler() any {
+	return h
+}
+
+// CLIOptions describes the CLI metadata for markdown export.
+func (h *ExportDirectoryHandler) CLIOptions() command.CLIConfig {
+	return command.CLIConfig{
+		Path:        []string{"markdown", "export"},
+		Group:       "markdown",
+		Description: "Export CMS content to markdown files",
+	}
+}
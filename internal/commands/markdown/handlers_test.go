@@ -17,6 +17,11 @@ type importCall struct {
 	options   interfaces.ImportOptions
 }
 
+type exportCall struct {
+	directory string
+	options   interfaces.ExportOptions
+}
+
 type syncCall struct {
 	directory string
 	options   interfaces.SyncOptions
@@ -25,6 +30,7 @@ type syncCall struct {
 type stubMarkdownService struct {
 	importCalls []importCall
 	syncCalls   []syncCall
+	exportCalls []exportCall
 
 	importResult *interfaces.ImportResult
 	syncResult   *interfaces.SyncResult
@@ -75,6 +81,18 @@ func (s *stubMarkdownService) Sync(ctx context.Context, directory string, opts i
 	return s.syncResult, nil
 }
 
+func (s *stubMarkdownService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (s *stubMarkdownService) ExportDirectory(_ context.Context, directory string, opts interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	s.exportCalls = append(s.exportCalls, exportCall{
+		directory: directory,
+		options:   opts,
+	})
+	return &interfaces.ExportResult{Written: []string{"about.md"}}, nil
+}
+
 type captureLogger struct {
 	fields       []map[string]any
 	infoMessages []string
@@ -122,12 +140,15 @@ func TestImportDirectoryHandlerInvokesService(t *testing.T) {
 
 	contentTypeID := uuid.New()
 	authorID := uuid.New()
+	templateID := uuid.New()
 
 	cmd := ImportDirectoryCommand{
 		Directory:                       "content/en",
 		ContentTypeID:                   contentTypeID,
 		AuthorID:                        authorID,
 		ContentAllowMissingTranslations: true,
+		CreatePages:                     true,
+		TemplateID:                      templateID,
 		DryRun:                          true,
 	}
 
@@ -148,6 +169,9 @@ func TestImportDirectoryHandlerInvokesService(t *testing.T) {
 	if call.options.AuthorID != authorID {
 		t.Fatalf("expected author %s, got %s", authorID, call.options.AuthorID)
 	}
+	if !call.options.CreatePages || call.options.TemplateID != templateID {
+		t.Fatalf("expected page options forwarded, got %#v", call.options)
+	}
 	if !call.options.ContentAllowMissingTranslations {
 		t.Fatalf("expected content allow missing translations option set")
 	}
@@ -308,3 +332,39 @@ func TestSyncDirectoryHandlerFeatureDisabled(t *testing.T) {
 		t.Fatalf("expected no sync calls, got %d", len(service.syncCalls))
 	}
 }
+
+func TestExportDirectoryHandlerInvokesService(t *testing.T) {
+	service := &stubMarkdownService{}
+	handler := NewExportDirectoryHandler(service, logging.NoOp(), FeatureGates{
+		MarkdownEnabled: func() bool { return true },
+	})
+
+	contentTypeID := uuid.New()
+	cmd := ExportDirectoryCommand{
+		Directory:      "docs",
+		ContentTypeID:  contentTypeID,
+		EnvironmentKey: "staging",
+		Locales:        []string{"en"},
+		DryRun:         true,
+	}
+	if err := handler.Execute(context.Background(), cmd); err != nil {
+		t.Fatalf("execute export directory: %v", err)
+	}
+	if len(service.exportCalls) != 1 {
+		t.Fatalf("expected export call, got %d", len(service.exportCalls))
+	}
+	call := service.exportCalls[0]
+	if call.directory != "docs" || call.options.ContentTypeID != contentTypeID || call.options.EnvironmentKey != "staging" || !call.options.DryRun {
+		t.Fatalf("unexpected export call %+v", call)
+	}
+	if len(call.options.Locales) != 1 || call.options.Locales[0] != "en" {
+		t.Fatalf("expected locales forwarded, got %v", call.options.Locales)
+	}
+
+	disabled := NewExportDirectoryHandler(service, logging.NoOp(), FeatureGates{
+		MarkdownEnabled: func() bool { return false },
+	})
+	if err := disabled.Execute(context.Background(), cmd); !errors.Is(err, ErrMarkdownFeatureDisabled) {
+		t.Fatalf("expected feature disabled error, got %v", err)
+	}
+}
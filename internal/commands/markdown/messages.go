@@ -10,6 +10,7 @@ import (
 const (
 	importDirectoryMessageType = "cms.markdown.import_directory"
 	syncDirectoryMessageType   = "cms.markdown.sync_directory"
+	exportDirectoryMessageType = "cms.markdown.export_directory"
 )
 
 // ImportDirectoryCommand triggers a filesystem walk for Markdown documents
@@ -25,6 +26,10 @@ type ImportDirectoryCommand struct {
 	AuthorID uuid.UUID `json:"author_id,omitempty"`
 	// ContentAllowMissingTranslations bypasses translation validation when creating content records.
 	ContentAllowMissingTranslations bool `json:"content_allow_missing_translations,omitempty"`
+	// CreatePages also creates a page per document, nested by directory.
+	CreatePages bool `json:"create_pages,omitempty"`
+	// TemplateID is the page template used when a document does not name one.
+	TemplateID uuid.UUID `json:"template_id,omitempty"`
 	// DryRun toggles preview mode to collect import diffs without persisting changes.
 	DryRun bool `json:"dry_run,omitempty"`
 }
@@ -60,6 +65,10 @@ type SyncDirectoryCommand struct {
 	AuthorID uuid.UUID `json:"author_id,omitempty"`
 	// ContentAllowMissingTranslations bypasses translation validation when creating content records.
 	ContentAllowMissingTranslations bool `json:"content_allow_missing_translations,omitempty"`
+	// CreatePages also creates a page per document, nested by directory.
+	CreatePages bool `json:"create_pages,omitempty"`
+	// TemplateID is the page template used when a document does not name one.
+	TemplateID uuid.UUID `json:"template_id,omitempty"`
 	// DryRun toggles preview mode to collect import diffs without persisting changes.
 	DryRun bool `json:"dry_run,omitempty"`
 	// DeleteOrphaned removes CMS records without matching Markdown files when true.
@@ -86,3 +95,39 @@ func (cmd SyncDirectoryCommand) Validate() error {
 	}
 	return nil
 }
+
+// ExportDirectoryCommand writes CMS content back to Markdown files under the
+// provided Directory, mirroring markdown.Service ExportDirectory semantics.
+type ExportDirectoryCommand struct {
+	// Directory selects the path, relative to the Markdown content root, that files are written to.
+	Directory string `json:"directory"`
+	// ContentTypeID limits the export to a single content type when set.
+	ContentTypeID uuid.UUID `json:"content_type_id,omitempty"`
+	// AuthorID is recorded as the updater when export checksums are stored on content.
+	AuthorID uuid.UUID `json:"author_id,omitempty"`
+	// EnvironmentKey selects the environment content is read from.
+	EnvironmentKey string `json:"environment_key,omitempty"`
+	// Locales restricts the exported translations; empty exports every locale.
+	Locales []string `json:"locales,omitempty"`
+	// DryRun reports the files that would be written without touching disk or content.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// Type implements command.Message.
+func (ExportDirectoryCommand) Type() string { return exportDirectoryMessageType }
+
+// Validate ensures directory input is present before handlers execute.
+func (cmd ExportDirectoryCommand) Validate() error {
+	err := validation.ValidateStruct(&cmd,
+		validation.Field(&cmd.Directory, validation.Required, validation.By(func(value any) error {
+			if strings.TrimSpace(value.(string)) == "" {
+				return validation.NewError("cms.markdown.export_directory.directory_required", "directory is required")
+			}
+			return nil
+		})),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -25,3 +25,15 @@ func TestSyncDirectoryCommandValidateRequiresDirectory(t *testing.T) {
 		t.Fatalf("unexpected error when directory provided: %v", err)
 	}
 }
+
+func TestExportDirectoryCommandValidateRequiresDirectory(t *testing.T) {
+	cmd := ExportDirectoryCommand{}
+	if err := cmd.Validate(); err == nil {
+		t.Fatal("expected error when directory missing")
+	}
+
+	cmd.Directory = "."
+	if err := cmd.Validate(); err != nil {
+		t.Fatalf("unexpected error when directory provided: %v", err)
+	}
+}
@@ -57,6 +57,14 @@ func (s *stubPageService) Delete(ctx context.Context, req pages.DeletePageReques
 	return nil
 }
 
+func (s *stubPageService) ListTrashed(context.Context, pages.ListTrashedPagesRequest) ([]*pages.Page, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *stubPageService) RestoreDeleted(context.Context, pages.RestorePageRequest) (*pages.Page, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (s *stubPageService) UpdateTranslation(context.Context, pages.UpdatePageTranslationRequest) (*pages.PageTranslation, error) {
 	return nil, errors.New("not implemented")
 }
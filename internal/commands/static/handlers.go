@@ -79,7 +79,8 @@ func (h *BuildSiteHandler) Execute(ctx context.Context, msg BuildSiteCommand) er
 		return nil
 	}
 
-	if len(msg.PageIDs) == 1 && len(msg.Locales) == 1 {
+	site := strings.TrimSpace(msg.Site)
+	if len(msg.PageIDs) == 1 && len(msg.Locales) == 1 && site == "" {
 		pageID := msg.PageIDs[0]
 		locale := strings.TrimSpace(msg.Locales[0])
 		if err := h.service.BuildPage(ctx, pageID, locale); err != nil {
@@ -101,6 +102,7 @@ func (h *BuildSiteHandler) Execute(ctx context.Context, msg BuildSiteCommand) er
 		Force:      msg.Force,
 		DryRun:     msg.DryRun,
 		AssetsOnly: msg.AssetsOnly,
+		Site:       site,
 	}
 	if len(msg.PageIDs) > 0 {
 		options.PageIDs = append([]uuid.UUID(nil), msg.PageIDs...)
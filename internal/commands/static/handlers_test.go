@@ -345,6 +345,10 @@ func (f *fakeGeneratorService) BuildSitemap(ctx context.Context) error {
 	return generator.ErrNotImplemented
 }
 
+func (f *fakeGeneratorService) BuildDependents(context.Context, []string) (*generator.BuildResult, error) {
+	return nil, generator.ErrNotImplemented
+}
+
 func (f *fakeGeneratorService) Clean(ctx context.Context) error {
 	if f.cleanFunc != nil {
 		return f.cleanFunc(ctx)
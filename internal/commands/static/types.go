@@ -33,6 +33,7 @@ type BuildSiteCommand struct {
 	Force          bool           `json:"force,omitempty"`
 	DryRun         bool           `json:"dry_run,omitempty"`
 	AssetsOnly     bool           `json:"assets_only,omitempty"`
+	Site           string         `json:"site,omitempty"`
 	ResultCallback ResultCallback `json:"-"`
 }
 
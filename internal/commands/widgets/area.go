@@ -23,6 +23,7 @@ type RefreshWidgetAreaCommand struct {
 	FallbackLocaleIDs []uuid.UUID `json:"fallback_locale_ids,omitempty"`
 	Audience          []string    `json:"audience,omitempty"`
 	Segments          []string    `json:"segments,omitempty"`
+	EnvironmentKey    string      `json:"environment_key,omitempty"`
 }
 
 // Type implements command.Message.
@@ -91,10 +92,11 @@ func (h *RefreshWidgetAreaHandler) Execute(ctx context.Context, msg RefreshWidge
 	}
 
 	input := widgets.ResolveAreaInput{
-		AreaCode: strings.TrimSpace(msg.AreaCode),
-		Audience: append([]string(nil), msg.Audience...),
-		Segments: append([]string(nil), msg.Segments...),
-		Now:      time.Now().UTC(),
+		AreaCode:       strings.TrimSpace(msg.AreaCode),
+		Audience:       append([]string(nil), msg.Audience...),
+		Segments:       append([]string(nil), msg.Segments...),
+		Now:            time.Now().UTC(),
+		EnvironmentKey: strings.TrimSpace(msg.EnvironmentKey),
 	}
 	if msg.LocaleID != nil {
 		input.LocaleID = msg.LocaleID
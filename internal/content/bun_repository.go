@@ -7,6 +7,7 @@ import (
 	"time"
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/trash"
 	goerrors "github.com/goliatone/go-errors"
 	"github.com/goliatone/go-repository-bun"
 	"github.com/goliatone/go-repository-cache/cache"
@@ -133,6 +134,16 @@ func (r *BunContentRepository) List(ctx context.Context, env ...ContentListOptio
 		if len(opts.familyIDs) > 0 {
 			q = q.Where("EXISTS (SELECT 1 FROM content_translations ct_family WHERE ct_family.content_id = ?TableAlias.id AND ct_family.family_id IN (?))", bun.List(opts.familyIDs))
 		}
+		if opts.status != "" {
+			q = q.Where("LOWER(?TableAlias.status) = ?", opts.status)
+		}
+		if opts.recentFirst {
+			q = q.OrderExpr("COALESCE(?TableAlias.published_at, ?TableAlias.updated_at) DESC").
+				OrderExpr("?TableAlias.id ASC")
+		}
+		if opts.limit > 0 {
+			q = q.Limit(opts.limit)
+		}
 		return q
 	}))
 	if err != nil {
@@ -155,8 +166,12 @@ func (r *BunContentRepository) List(ctx context.Context, env ...ContentListOptio
 
 	translations, _, err := r.translations.List(ctx,
 		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
-			return q.Where("?TableAlias.content_id IN (?)", bun.In(ids)).
+			q = q.Where("?TableAlias.content_id IN (?)", bun.In(ids)).
 				Relation("Locale")
+			if len(opts.translationLocales) > 0 {
+				q = q.Where("?TableAlias.locale_id IN (SELECT id FROM locales WHERE LOWER(code) IN (?))", bun.In(opts.translationLocales))
+			}
+			return q
 		}),
 	)
 	if err != nil {
@@ -277,6 +292,23 @@ func (r *BunContentRepository) ReplaceTranslations(ctx context.Context, contentI
 	})
 }
 
+// UpdateTranslationFields overwrites the content column of one translation,
+// leaving the parent record and its versions untouched.
+func (r *BunContentRepository) UpdateTranslationFields(ctx context.Context, translationID uuid.UUID, fields map[string]any) error {
+	if r.db == nil {
+		return fmt.Errorf("content repository: database not configured")
+	}
+	_, err := r.translations.Update(ctx, &ContentTranslation{
+		ID:        translationID,
+		Content:   cloneMap(fields),
+		UpdatedAt: time.Now().UTC(),
+	},
+		repository.UpdateByID(translationID.String()),
+		repository.UpdateColumns("content", "updated_at"),
+	)
+	return err
+}
+
 // ListTranslations returns translations for a content record.
 func (r *BunContentRepository) ListTranslations(ctx context.Context, contentID uuid.UUID) ([]*ContentTranslation, error) {
 	if r.db == nil {
@@ -303,38 +335,89 @@ func (r *BunContentRepository) Delete(ctx context.Context, id uuid.UUID, hardDel
 	}
 
 	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		if _, err := tx.NewDelete().
-			Model((*ContentTranslation)(nil)).
-			Where("?TableAlias.content_id = ?", id).
-			Exec(ctx); err != nil {
-			return fmt.Errorf("delete translations: %w", err)
-		}
+		return deleteContentGraph(ctx, tx, id)
+	})
+}
 
-		if _, err := tx.NewDelete().
-			Model((*ContentVersion)(nil)).
-			Where("?TableAlias.content_id = ?", id).
-			Exec(ctx); err != nil {
-			return fmt.Errorf("delete versions: %w", err)
+// DeleteToTrash stores the trash entry and removes the content graph in one
+// transaction, so a failed delete never leaves a dangling snapshot.
+func (r *BunContentRepository) DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error {
+	if r.db == nil {
+		return fmt.Errorf("content repository: database not configured")
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := trash.Insert(ctx, tx, entry); err != nil {
+			return fmt.Errorf("insert trash entry: %w", err)
 		}
+		return deleteContentGraph(ctx, tx, id)
+	})
+}
 
-		result, err := tx.NewDelete().
-			Model((*Content)(nil)).
-			Where("?TableAlias.id = ?", id).
-			Exec(ctx)
-		if err != nil {
-			return fmt.Errorf("delete content: %w", err)
+// RestoreFromTrash inserts the entry with its translations and versions and
+// removes the trash entry in one transaction.
+func (r *BunContentRepository) RestoreFromTrash(ctx context.Context, entryID uuid.UUID, record *Content) error {
+	if r.db == nil {
+		return fmt.Errorf("content repository: database not configured")
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(record).Exec(ctx); err != nil {
+			return fmt.Errorf("insert content: %w", err)
 		}
-		affected, err := result.RowsAffected()
-		if err != nil {
-			return fmt.Errorf("delete content rows affected: %w", err)
+		for _, tr := range record.Translations {
+			if tr == nil {
+				continue
+			}
+			if _, err := tx.NewInsert().Model(tr).Exec(ctx); err != nil {
+				return fmt.Errorf("insert translation: %w", err)
+			}
 		}
-		if affected == 0 {
-			return &NotFoundError{Resource: "content", Key: id.String()}
+		for _, version := range record.Versions {
+			if version == nil {
+				continue
+			}
+			if _, err := tx.NewInsert().Model(version).Exec(ctx); err != nil {
+				return fmt.Errorf("insert version: %w", err)
+			}
+		}
+		if err := trash.Remove(ctx, tx, entryID); err != nil {
+			return fmt.Errorf("remove trash entry: %w", err)
 		}
 		return nil
 	})
 }
 
+func deleteContentGraph(ctx context.Context, tx bun.Tx, id uuid.UUID) error {
+	if _, err := tx.NewDelete().
+		Model((*ContentTranslation)(nil)).
+		Where("?TableAlias.content_id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("delete translations: %w", err)
+	}
+
+	if _, err := tx.NewDelete().
+		Model((*ContentVersion)(nil)).
+		Where("?TableAlias.content_id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("delete versions: %w", err)
+	}
+
+	result, err := tx.NewDelete().
+		Model((*Content)(nil)).
+		Where("?TableAlias.id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete content: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete content rows affected: %w", err)
+	}
+	if affected == 0 {
+		return &NotFoundError{Resource: "content", Key: id.String()}
+	}
+	return nil
+}
+
 func (r *BunContentRepository) CreateVersion(ctx context.Context, version *ContentVersion) (*ContentVersion, error) {
 	created, err := r.versions.Create(ctx, version)
 	if err != nil {
@@ -414,6 +497,16 @@ func (r *BunContentRepository) UpdateVersion(ctx context.Context, version *Conte
 	return updated, nil
 }
 
+func (r *BunContentRepository) DeleteVersions(ctx context.Context, contentID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	return r.versions.DeleteMany(ctx,
+		repository.DeleteBy("content_id", "=", contentID.String()),
+		repository.DeleteColumnIn("version", numbers),
+	)
+}
+
 type BunContentTypeRepository struct {
 	repo repository.Repository[*ContentType]
 }
@@ -242,6 +242,55 @@ func TestContentServiceListWithContentTypeIDScopesBeforeTranslations(t *testing.
 	}
 }
 
+func TestContentServiceListForwardsStatusLimitAndLocales(t *testing.T) {
+	ctx := context.Background()
+	fixture := newContentListFixture(t)
+
+	for _, entry := range []struct {
+		slug   string
+		status string
+	}{
+		{slug: "first", status: "published"},
+		{slug: "second", status: "published"},
+		{slug: "hidden", status: "draft"},
+	} {
+		if _, err := fixture.svc.Create(ctx, content.CreateContentRequest{
+			ContentTypeID:  fixture.defaultType.ID,
+			Slug:           entry.slug,
+			Status:         entry.status,
+			EnvironmentKey: "default",
+			CreatedBy:      uuid.New(),
+			UpdatedBy:      uuid.New(),
+			Translations: []content.ContentTranslationInput{
+				{Locale: "en", Title: entry.slug, Content: map[string]any{"body": entry.slug}},
+			},
+		}); err != nil {
+			t.Fatalf("create %s: %v", entry.slug, err)
+		}
+	}
+
+	published, err := fixture.svc.List(ctx, "default", content.WithStatus("published"), content.WithRecentFirst(), content.WithTranslations())
+	if err != nil {
+		t.Fatalf("list published content: %v", err)
+	}
+	if len(published) != 2 {
+		t.Fatalf("expected two published records, got %d", len(published))
+	}
+	for _, record := range published {
+		if record.Status != "published" || len(record.Translations) != 1 {
+			t.Fatalf("unexpected record %s (%s) with %d translations", record.Slug, record.Status, len(record.Translations))
+		}
+	}
+
+	limited, err := fixture.svc.List(ctx, "default", content.WithStatus("published"), content.WithLimit(1), content.WithTranslations(), content.WithTranslationLocales("fr"))
+	if err != nil {
+		t.Fatalf("list limited content: %v", err)
+	}
+	if len(limited) != 1 || len(limited[0].Translations) != 0 {
+		t.Fatalf("expected one record without fr translations, got %d", len(limited))
+	}
+}
+
 func TestContentServiceGetDefaultOmitsTranslations(t *testing.T) {
 	ctx := context.Background()
 	fixture := newContentListFixture(t)
@@ -888,3 +888,88 @@ func keys[V any](m map[string]V) []string {
 	}
 	return out
 }
+
+func TestBunContentRepository_ListPushesFiltersToQuery(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { closeSQLDB(t, sqlDB) })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+
+	registerContentModels(t, bunDB)
+	seedContentEntities(t, bunDB)
+	if _, err := bunDB.NewInsert().Model(&content.Locale{ID: uuid.New(), Code: "es", Display: "Spanish", IsActive: true}).Exec(ctx); err != nil {
+		t.Fatalf("insert locale: %v", err)
+	}
+
+	contentRepo := content.NewBunContentRepository(bunDB)
+	svc := content.NewService(contentRepo, content.NewBunContentTypeRepository(bunDB), content.NewBunLocaleRepository(bunDB))
+	typeID := mustUUID("00000000-0000-0000-0000-000000000210")
+	authorID := uuid.New()
+
+	base := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	envKey := ""
+	for i, entry := range []struct {
+		slug   string
+		status string
+	}{
+		{slug: "oldest", status: "published"},
+		{slug: "draft", status: "draft"},
+		{slug: "newest", status: "published"},
+	} {
+		created, err := svc.Create(ctx, content.CreateContentRequest{
+			ContentTypeID: typeID,
+			Slug:          entry.slug,
+			Status:        entry.status,
+			CreatedBy:     authorID,
+			UpdatedBy:     authorID,
+			Translations: []content.ContentTranslationInput{
+				{Locale: "en", Title: entry.slug + " en", Content: map[string]any{"body": "en"}},
+				{Locale: "es", Title: entry.slug + " es", Content: map[string]any{"body": "es"}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("create %s: %v", entry.slug, err)
+		}
+		envKey = created.EnvironmentID.String()
+		if _, err := bunDB.NewUpdate().Table("contents").
+			Set("published_at = ?", base.Add(time.Duration(i)*time.Hour)).
+			Set("updated_at = ?", base.Add(time.Duration(i)*time.Hour)).
+			Where("id = ?", created.ID).
+			Exec(ctx); err != nil {
+			t.Fatalf("set %s recency: %v", entry.slug, err)
+		}
+	}
+
+	listed, err := contentRepo.List(ctx,
+		envKey,
+		content.WithContentTypeID(typeID),
+		content.WithStatus("published"),
+		content.WithRecentFirst(),
+		content.WithLimit(1),
+		content.WithTranslations(),
+		content.WithTranslationLocales("es"),
+	)
+	if err != nil {
+		t.Fatalf("list content: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Slug != "newest" {
+		t.Fatalf("expected only the newest published entry, got %+v", listed)
+	}
+	if len(listed[0].Translations) != 1 || listed[0].Translations[0].Title != "newest es" {
+		t.Fatalf("expected only the es translation, got %+v", listed[0].Translations)
+	}
+
+	all, err := contentRepo.List(ctx, envKey, content.WithStatus("published"), content.WithRecentFirst())
+	if err != nil {
+		t.Fatalf("list published content: %v", err)
+	}
+	if len(all) != 2 || all[0].Slug != "newest" || all[1].Slug != "oldest" {
+		t.Fatalf("expected published entries newest first, got %d records", len(all))
+	}
+}
@@ -318,14 +318,24 @@ func (s *contentTypeService) Update(ctx context.Context, req UpdateContentTypeRe
 		return nil, err
 	}
 
+	if err := schema.ValidateMigrationOperations(req.Migration); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrContentTypeSchemaInvalid, err)
+	}
 	compatibility := schema.CompatibilityResult{Compatible: true, ChangeLevel: schema.ChangeNone}
+	migration := schema.CloneMigrationOperations(req.Migration)
 	if req.Schema != nil {
 		if _, _, err := schema.EnsureSchemaVersion(cloneMap(record.Schema), record.Slug); err != nil {
 			return nil, ErrContentTypeSchemaVersion
 		}
 		compatibility = schema.CheckSchemaCompatibility(previousSchema, record.Schema)
-		if len(compatibility.BreakingChanges) > 0 && record.Status == ContentTypeStatusActive && !req.AllowBreakingChanges {
-			return nil, &schemaCompatibilityError{Result: compatibility}
+		if len(compatibility.BreakingChanges) > 0 {
+			suggested := schema.SuggestMigrationOperations(previousSchema, record.Schema)
+			if record.Status == ContentTypeStatusActive && !req.AllowBreakingChanges {
+				return nil, &schemaCompatibilityError{Result: compatibility, Suggested: suggested}
+			}
+			if migration == nil {
+				migration = suggested
+			}
 		}
 	}
 
@@ -367,7 +377,11 @@ func (s *contentTypeService) Update(ctx context.Context, req UpdateContentTypeRe
 				UpdatedAt:    previousUpdatedAt,
 			})
 		}
-		record.SchemaHistory = appendSchemaHistory(record.SchemaHistory, newContentTypeSchemaSnapshot(record, req.UpdatedBy, record.UpdatedAt))
+		snapshot := newContentTypeSchemaSnapshot(record, req.UpdatedBy, record.UpdatedAt)
+		if len(migration) > 0 {
+			snapshot.Migration = migration
+		}
+		record.SchemaHistory = appendSchemaHistory(record.SchemaHistory, snapshot)
 	}
 	updated, err := s.repo.Update(ctx, record)
 	if err != nil {
@@ -704,7 +718,8 @@ func validateContentTypeSchema(_ context.Context, record *ContentType) error {
 }
 
 type schemaCompatibilityError struct {
-	Result schema.CompatibilityResult
+	Result    schema.CompatibilityResult
+	Suggested []schema.MigrationOperation
 }
 
 func (e *schemaCompatibilityError) Error() string {
@@ -727,6 +742,16 @@ func (e *schemaCompatibilityError) Unwrap() error {
 	return ErrContentTypeSchemaBreaking
 }
 
+// SuggestedSchemaMigration returns the migration operations proposed for the
+// breaking schema change reported by err, if any.
+func SuggestedSchemaMigration(err error) []SchemaMigrationOperation {
+	var compatErr *schemaCompatibilityError
+	if !errors.As(err, &compatErr) || compatErr == nil {
+		return nil
+	}
+	return schema.CloneMigrationOperations(compatErr.Suggested)
+}
+
 func resolveContentTypeVersion(payload map[string]any, slug string, storedVersion string) (schema.Version, error) {
 	if strings.TrimSpace(storedVersion) != "" {
 		version, err := schema.ParseVersion(storedVersion)
@@ -2,6 +2,7 @@ package content
 
 import (
 	"slices"
+	"strconv"
 	"strings"
 
 	cmscontent "github.com/goliatone/go-cms/content"
@@ -22,6 +23,10 @@ const (
 	contentListContentTypePrefix    ContentListOption = "content:list:content_type:"
 	contentListFamilyPrefix         ContentListOption = "content:list:family:"
 	contentListFamiliesPrefix       ContentListOption = "content:list:families:"
+	contentListStatusPrefix         ContentListOption = "content:list:status:"
+	contentListRecentFirst          ContentListOption = "content:list:order:recent"
+	contentListLimitPrefix          ContentListOption = "content:list:limit:"
+	contentListLocalesPrefix        ContentListOption = "content:list:locales:"
 )
 
 // WithTranslations preloads translations when listing content records.
@@ -60,6 +65,28 @@ func WithFamilyIDs(ids ...uuid.UUID) ContentListOption {
 	return cmscontent.WithFamilyIDs(ids...)
 }
 
+// WithStatus scopes list reads to content records in the supplied status.
+func WithStatus(status string) ContentListOption {
+	return cmscontent.WithStatus(status)
+}
+
+// WithRecentFirst orders list reads by publish time, falling back to the last
+// update, newest first.
+func WithRecentFirst() ContentListOption {
+	return cmscontent.WithRecentFirst()
+}
+
+// WithLimit caps the number of records a list read returns.
+func WithLimit(limit int) ContentListOption {
+	return cmscontent.WithLimit(limit)
+}
+
+// WithTranslationLocales restricts the translations loaded by WithTranslations
+// to the supplied locale codes.
+func WithTranslationLocales(codes ...string) ContentListOption {
+	return cmscontent.WithTranslationLocales(codes...)
+}
+
 type contentListOptions struct {
 	envKey              string
 	includeTranslations bool
@@ -68,6 +95,10 @@ type contentListOptions struct {
 	projectionModeSet   bool
 	contentTypeID       uuid.UUID
 	familyIDs           []uuid.UUID
+	status              string
+	recentFirst         bool
+	limit               int
+	translationLocales  []string
 }
 
 func parseContentListOptions(args ...ContentListOption) contentListOptions {
@@ -80,6 +111,8 @@ func parseContentListOptions(args ...ContentListOption) contentListOptions {
 		switch token {
 		case contentListWithTranslations:
 			opts.includeTranslations = true
+		case contentListRecentFirst:
+			opts.recentFirst = true
 		default:
 			if after, ok := strings.CutPrefix(token, contentListProjectionPrefix); ok {
 				opts.projection = strings.ToLower(strings.TrimSpace(after))
@@ -113,6 +146,25 @@ func parseContentListOptions(args ...ContentListOption) contentListOptions {
 				}
 				continue
 			}
+			if after, ok := strings.CutPrefix(token, contentListStatusPrefix); ok {
+				opts.status = strings.ToLower(strings.TrimSpace(after))
+				continue
+			}
+			if after, ok := strings.CutPrefix(token, contentListLimitPrefix); ok {
+				if limit, err := strconv.Atoi(strings.TrimSpace(after)); err == nil && limit > 0 {
+					opts.limit = limit
+				}
+				continue
+			}
+			if after, ok := strings.CutPrefix(token, contentListLocalesPrefix); ok {
+				for code := range strings.SplitSeq(after, ",") {
+					code = strings.ToLower(strings.TrimSpace(code))
+					if code != "" && !slices.Contains(opts.translationLocales, code) {
+						opts.translationLocales = append(opts.translationLocales, code)
+					}
+				}
+				continue
+			}
 			if opts.envKey == "" {
 				opts.envKey = token
 			}
@@ -130,3 +182,37 @@ func appendUniqueContentFamilyID(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
 	}
 	return append(ids, id)
 }
+
+// repositoryArgs returns the filter, order and limit options forwarded to the
+// content repository alongside the environment key.
+func (o contentListOptions) repositoryArgs() []ContentListOption {
+	var out []ContentListOption
+	if o.contentTypeID != uuid.Nil {
+		out = append(out, WithContentTypeID(o.contentTypeID))
+	}
+	if o.status != "" {
+		out = append(out, WithStatus(o.status))
+	}
+	if o.recentFirst {
+		out = append(out, WithRecentFirst())
+	}
+	if o.limit > 0 {
+		out = append(out, WithLimit(o.limit))
+	}
+	if len(o.translationLocales) > 0 {
+		out = append(out, WithTranslationLocales(o.translationLocales...))
+	}
+	return out
+}
+
+// matchesTranslationLocale reports whether translation is in one of locales.
+// An empty locale list matches every translation.
+func matchesTranslationLocale(translation *ContentTranslation, locales []string) bool {
+	if len(locales) == 0 {
+		return true
+	}
+	if translation == nil || translation.Locale == nil {
+		return false
+	}
+	return slices.Contains(locales, strings.ToLower(strings.TrimSpace(translation.Locale.Code)))
+}
@@ -25,6 +25,10 @@ func TestServiceAdvertisesOnlyExecutableContentListOptions(t *testing.T) {
 		WithContentTypeID(first),
 		WithTranslations(),
 		WithDerivedFields(),
+		WithStatus("published"),
+		WithRecentFirst(),
+		WithLimit(3),
+		WithTranslationLocales("en", "es"),
 	} {
 		if !svc.SupportsContentListOption(option) {
 			t.Fatalf("expected supported option %q", option)
@@ -36,6 +40,10 @@ func TestServiceAdvertisesOnlyExecutableContentListOptions(t *testing.T) {
 		"content:list:families:not-a-uuid",
 		ContentListOption("content:list:families:" + first.String() + ",bad"),
 		"content:list:unknown:value",
+		"content:list:limit:0",
+		"content:list:limit:many",
+		WithLimit(0),
+		WithStatus(" "),
 	} {
 		if svc.SupportsContentListOption(option) {
 			t.Fatalf("expected unsupported option %q", option)
@@ -97,15 +97,46 @@ func (m *MemoryContentRepository) List(_ context.Context, env ...ContentListOpti
 		if len(opts.familyIDs) > 0 && !contentHasAnyFamilyID(rec, opts.familyIDs) {
 			continue
 		}
+		if opts.status != "" && !strings.EqualFold(rec.Status, opts.status) {
+			continue
+		}
 		cloned := m.attachVersions(cloneContent(rec))
 		if !opts.includeTranslations {
 			cloned.Translations = nil
+		} else if len(opts.translationLocales) > 0 {
+			translations := make([]*ContentTranslation, 0, len(cloned.Translations))
+			for _, tr := range cloned.Translations {
+				if matchesTranslationLocale(tr, opts.translationLocales) {
+					translations = append(translations, tr)
+				}
+			}
+			cloned.Translations = translations
 		}
 		out = append(out, cloned)
 	}
+	if opts.recentFirst {
+		sort.SliceStable(out, func(i, j int) bool {
+			left, right := contentRecency(out[i]), contentRecency(out[j])
+			if !left.Equal(right) {
+				return left.After(right)
+			}
+			return out[i].ID.String() < out[j].ID.String()
+		})
+	}
+	if opts.limit > 0 && len(out) > opts.limit {
+		out = out[:opts.limit]
+	}
 	return out, nil
 }
 
+// contentRecency is the time WithRecentFirst orders by.
+func contentRecency(record *Content) time.Time {
+	if record.PublishedAt != nil {
+		return *record.PublishedAt
+	}
+	return record.UpdatedAt
+}
+
 func contentHasAnyFamilyID(record *Content, familyIDs []uuid.UUID) bool {
 	for _, familyID := range familyIDs {
 		if contentHasFamilyID(record, familyID) {
@@ -246,6 +277,23 @@ func (m *MemoryContentRepository) ListTranslations(_ context.Context, contentID
 	return cloneContentTranslations(record.Translations), nil
 }
 
+// UpdateTranslationFields overwrites the content of one translation in place.
+func (m *MemoryContentRepository) UpdateTranslationFields(_ context.Context, translationID uuid.UUID, fields map[string]any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, record := range m.contents {
+		for _, tr := range record.Translations {
+			if tr != nil && tr.ID == translationID {
+				tr.Content = cloneMap(fields)
+				tr.UpdatedAt = time.Now().UTC()
+				return nil
+			}
+		}
+	}
+	return &NotFoundError{Resource: "content_translation", Key: translationID.String()}
+}
+
 // Delete removes the content record and its associated versions when hard delete is requested.
 func (m *MemoryContentRepository) Delete(_ context.Context, id uuid.UUID, hardDelete bool) error {
 	m.mu.Lock()
@@ -334,6 +382,33 @@ func (m *MemoryContentRepository) UpdateVersion(_ context.Context, version *Cont
 	return nil, &NotFoundError{Resource: "content_version", Key: version.ContentID.String()}
 }
 
+// DeleteVersions removes the listed version numbers of a content entity.
+func (m *MemoryContentRepository) DeleteVersions(_ context.Context, contentID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	drop := make(map[int]struct{}, len(numbers))
+	for _, number := range numbers {
+		drop[number] = struct{}{}
+	}
+	queue := m.versions[contentID]
+	kept := make([]*ContentVersion, 0, len(queue))
+	for _, version := range queue {
+		if version == nil {
+			continue
+		}
+		if _, ok := drop[version.Version]; ok {
+			continue
+		}
+		kept = append(kept, version)
+	}
+	m.versions[contentID] = kept
+	return nil
+}
+
 func cloneContent(src *Content) *Content {
 	if src == nil {
 		return nil
@@ -6,6 +6,7 @@ type (
 	Locale                            = cmscontent.Locale
 	ContentType                       = cmscontent.ContentType
 	ContentTypeSchemaSnapshot         = cmscontent.ContentTypeSchemaSnapshot
+	SchemaMigrationOperation          = cmscontent.SchemaMigrationOperation
 	Content                           = cmscontent.Content
 	ContentTranslation                = cmscontent.ContentTranslation
 	ContentVersion                    = cmscontent.ContentVersion
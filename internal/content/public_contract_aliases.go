@@ -12,6 +12,8 @@ type (
 	ContentTranslationInput              = cmscontent.ContentTranslationInput
 	UpdateContentRequest                 = cmscontent.UpdateContentRequest
 	DeleteContentRequest                 = cmscontent.DeleteContentRequest
+	ListTrashedContentRequest            = cmscontent.ListTrashedContentRequest
+	RestoreContentRequest                = cmscontent.RestoreContentRequest
 	CreateContentTranslationRequest      = cmscontent.CreateContentTranslationRequest
 	UpdateContentTranslationRequest      = cmscontent.UpdateContentTranslationRequest
 	DeleteContentTranslationRequest      = cmscontent.DeleteContentTranslationRequest
@@ -61,6 +63,7 @@ var (
 	ErrTranslationInvariantViolation         = cmscontent.ErrTranslationInvariantViolation
 	ErrContentSchemaInvalid                  = cmscontent.ErrContentSchemaInvalid
 	ErrContentSoftDeleteUnsupported          = cmscontent.ErrContentSoftDeleteUnsupported
+	ErrContentNotTrashed                     = cmscontent.ErrContentNotTrashed
 	ErrContentIDRequired                     = cmscontent.ErrContentIDRequired
 	ErrContentMetadataInvalid                = cmscontent.ErrContentMetadataInvalid
 	ErrVersioningDisabled                    = cmscontent.ErrVersioningDisabled
@@ -0,0 +1,163 @@
+package content_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	repocache "github.com/goliatone/go-repository-cache/cache"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestServiceRetentionPrunesOldVersionsOnWrite(t *testing.T) {
+	ctx := context.Background()
+	contentStore := content.NewMemoryContentRepository()
+	svc, record := newRetentionContentFixture(t, contentStore, retention.Policy{Mode: retention.ModePrune, KeepLast: 2})
+
+	first := createRetentionDraft(t, svc, record.ID)
+	if _, err := svc.PublishDraft(ctx, content.PublishContentDraftRequest{ContentID: record.ID, Version: first.Version}); err != nil {
+		t.Fatalf("publish draft: %v", err)
+	}
+	for range 4 {
+		createRetentionDraft(t, svc, record.ID)
+	}
+
+	assertContentVersions(t, contentStore, record.ID, []int{1, 4, 5})
+}
+
+func TestServiceScheduledRetentionPrunesAllContent(t *testing.T) {
+	contentStore := content.NewMemoryContentRepository()
+	svc, record := newRetentionContentFixture(t, contentStore, retention.Policy{Mode: retention.ModeScheduled, KeepLast: 1})
+
+	for range 3 {
+		createRetentionDraft(t, svc, record.ID)
+	}
+	assertContentVersions(t, contentStore, record.ID, []int{1, 2, 3})
+
+	source, ok := svc.(retention.Source)
+	if !ok {
+		t.Fatalf("expected content service to implement retention.Source")
+	}
+	pruned, err := source.PruneAllVersions(context.Background())
+	if err != nil {
+		t.Fatalf("prune all versions: %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].EntityID != record.ID || pruned[0].Resource != retention.ResourceContent {
+		t.Fatalf("unexpected prune report %+v", pruned)
+	}
+	assertContentVersions(t, contentStore, record.ID, []int{3})
+}
+
+func newRetentionContentFixture(t *testing.T, contentStore *content.MemoryContentRepository, policy retention.Policy) (content.Service, *content.Content) {
+	t.Helper()
+	typeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+	contentTypeID := uuid.New()
+	seedContentType(t, typeStore, &content.ContentType{ID: contentTypeID, Name: "article"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	svc := content.NewService(
+		contentStore,
+		typeStore,
+		localeStore,
+		content.WithClock(func() time.Time { return time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC) }),
+		content.WithVersioningEnabled(true),
+		content.WithVersionRetentionPolicy(policy),
+	)
+	record, err := svc.Create(context.Background(), content.CreateContentRequest{
+		ContentTypeID: contentTypeID,
+		Slug:          "retained-article",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Retained"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+	return svc, record
+}
+
+func createRetentionDraft(t *testing.T, svc content.Service, contentID uuid.UUID) *content.ContentVersion {
+	t.Helper()
+	draft, err := svc.CreateDraft(context.Background(), content.CreateContentDraftRequest{
+		ContentID: contentID,
+		Snapshot: content.ContentVersionSnapshot{
+			Translations: []content.ContentVersionTranslationSnapshot{{Locale: "en", Title: "Draft", Content: map[string]any{"body": "text"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	return draft
+}
+
+func assertContentVersions(t *testing.T, repo content.ContentRepository, contentID uuid.UUID, want []int) {
+	t.Helper()
+	versions, err := repo.ListVersions(context.Background(), contentID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	got := make([]int, 0, len(versions))
+	for _, version := range versions {
+		got = append(got, version.Version)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected versions %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected versions %v, got %v", want, got)
+		}
+	}
+}
+
+func TestBunContentRepositoryDeleteVersions(t *testing.T) {
+	ctx := context.Background()
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { closeSQLDB(t, sqlDB) })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	registerContentModels(t, bunDB)
+	if _, err := bunDB.NewCreateTable().Model((*content.ContentVersion)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create content versions table: %v", err)
+	}
+
+	cacheService, err := repocache.NewCacheService(repocache.DefaultConfig())
+	if err != nil {
+		t.Fatalf("new cache service: %v", err)
+	}
+	repo := content.NewBunContentRepositoryWithCache(bunDB, cacheService, repocache.NewDefaultKeySerializer())
+
+	contentID := uuid.New()
+	otherID := uuid.New()
+	for _, id := range []uuid.UUID{contentID, otherID} {
+		for number := 1; number <= 3; number++ {
+			if _, err := repo.CreateVersion(ctx, &content.ContentVersion{
+				ID:        uuid.New(),
+				ContentID: id,
+				Version:   number,
+				Status:    "draft",
+				CreatedBy: uuid.New(),
+				CreatedAt: time.Now().UTC(),
+			}); err != nil {
+				t.Fatalf("create version: %v", err)
+			}
+		}
+	}
+	assertContentVersions(t, repo, contentID, []int{1, 2, 3})
+
+	if err := repo.DeleteVersions(ctx, contentID, []int{1, 2}); err != nil {
+		t.Fatalf("delete versions: %v", err)
+	}
+	assertContentVersions(t, repo, contentID, []int{3})
+	assertContentVersions(t, repo, otherID, []int{1, 2, 3})
+}
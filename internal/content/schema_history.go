@@ -3,6 +3,7 @@ package content
 import (
 	"time"
 
+	"github.com/goliatone/go-cms/internal/schema"
 	"github.com/google/uuid"
 )
 
@@ -49,6 +50,7 @@ func cloneSchemaHistory(history []ContentTypeSchemaSnapshot) []ContentTypeSchema
 			UISchema:     cloneMap(snapshot.UISchema),
 			Capabilities: cloneMap(snapshot.Capabilities),
 			Status:       snapshot.Status,
+			Migration:    schema.CloneMigrationOperations(snapshot.Migration),
 			UpdatedAt:    snapshot.UpdatedAt,
 		}
 		if snapshot.UpdatedBy != nil {
@@ -58,3 +60,47 @@ func cloneSchemaHistory(history []ContentTypeSchemaSnapshot) []ContentTypeSchema
 	}
 	return out
 }
+
+// schemaHistoryMigrationSteps converts stored schema history into migration
+// steps between consecutive versions.
+func schemaHistoryMigrationSteps(history []ContentTypeSchemaSnapshot) []schema.MigrationStep {
+	if len(history) < 2 {
+		return nil
+	}
+	versions := make([]schema.VersionedSchema, 0, len(history))
+	for _, snapshot := range history {
+		versions = append(versions, schema.VersionedSchema{
+			Version:   snapshot.Version,
+			Schema:    snapshot.Schema,
+			Migration: snapshot.Migration,
+		})
+	}
+	return schema.HistoryMigrationSteps(versions)
+}
+
+// ContentTypeMigrator extends base with the migration steps stored in the
+// content type's schema history. Steps registered on base take precedence.
+func ContentTypeMigrator(base *schema.Migrator, contentType *ContentType) *schema.Migrator {
+	if contentType == nil {
+		return base
+	}
+	steps := schemaHistoryMigrationSteps(contentType.SchemaHistory)
+	if len(steps) == 0 {
+		return base
+	}
+	return base.Extend(contentType.Slug, steps...)
+}
+
+// MigrateContentPayload migrates a translation payload to the content type's
+// current schema version and validates the result. The boolean reports
+// whether migration steps ran.
+func MigrateContentPayload(migrator *schema.Migrator, contentType *ContentType, payload map[string]any) (map[string]any, bool, error) {
+	if contentType == nil {
+		return nil, false, ErrContentTypeRequired
+	}
+	target, err := resolveContentSchemaVersion(contentType.Schema, contentType.Slug)
+	if err != nil {
+		return nil, false, err
+	}
+	return migratePayload(migrator, contentType.Slug, contentType.Schema, target, payload, true)
+}
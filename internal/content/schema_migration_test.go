@@ -266,3 +266,100 @@ func TestPublishDraftFailsOnInvalidMigrationPayload(t *testing.T) {
 		t.Fatalf("expected ErrContentSchemaInvalid got %v", err)
 	}
 }
+
+func TestPublishDraftRunsStoredSchemaMigration(t *testing.T) {
+	ctx := context.Background()
+	contentRepo := content.NewMemoryContentRepository()
+	typeRepo := content.NewMemoryContentTypeRepository()
+	localeRepo := content.NewMemoryLocaleRepository()
+	localeRepo.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	typeSvc := content.NewContentTypeService(typeRepo)
+	active := string(content.ContentTypeStatusActive)
+	contentType, err := typeSvc.Create(ctx, content.CreateContentTypeRequest{
+		Name:   "Article",
+		Status: active,
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+
+	svc := content.NewService(contentRepo, typeRepo, localeRepo, content.WithVersioningEnabled(true))
+	author := uuid.New()
+	created, err := svc.Create(ctx, content.CreateContentRequest{
+		ContentTypeID: contentType.ID,
+		Slug:          "news",
+		CreatedBy:     author,
+		UpdatedBy:     author,
+		Translations: []content.ContentTranslationInput{
+			{Locale: "en", Title: "News", Content: map[string]any{"title": "Hello"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+	draft, err := svc.CreateDraft(ctx, content.CreateContentDraftRequest{
+		ContentID: created.ID,
+		Snapshot: content.ContentVersionSnapshot{
+			Translations: []content.ContentVersionTranslationSnapshot{
+				{Locale: "en", Title: "Draft", Content: map[string]any{"_schema": "article@v1.0.0", "title": "Hello"}},
+			},
+		},
+		CreatedBy: author,
+	})
+	if err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	renamed := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"headline": map[string]any{"type": "string"},
+		},
+	}
+	_, err = typeSvc.Update(ctx, content.UpdateContentTypeRequest{ID: contentType.ID, Schema: renamed, UpdatedBy: author})
+	if !errors.Is(err, content.ErrContentTypeSchemaBreaking) {
+		t.Fatalf("expected ErrContentTypeSchemaBreaking, got %v", err)
+	}
+	suggested := content.SuggestedSchemaMigration(err)
+	if len(suggested) != 1 || suggested[0].Op != "rename" || suggested[0].Path != "title" || suggested[0].To != "headline" {
+		t.Fatalf("expected rename suggestion, got %+v", suggested)
+	}
+
+	updated, err := typeSvc.Update(ctx, content.UpdateContentTypeRequest{
+		ID:                   contentType.ID,
+		Schema:               renamed,
+		Migration:            suggested,
+		AllowBreakingChanges: true,
+		UpdatedBy:            author,
+	})
+	if err != nil {
+		t.Fatalf("update content type: %v", err)
+	}
+	latest := updated.SchemaHistory[len(updated.SchemaHistory)-1]
+	if latest.Version != "article@v2.0.0" || len(latest.Migration) != 1 {
+		t.Fatalf("expected stored migration on article@v2.0.0, got %+v", latest)
+	}
+
+	published, err := svc.PublishDraft(ctx, content.PublishContentDraftRequest{
+		ContentID:   created.ID,
+		Version:     draft.Version,
+		PublishedBy: author,
+	})
+	if err != nil {
+		t.Fatalf("publish draft: %v", err)
+	}
+	payload := published.Snapshot.Translations[0].Content
+	if payload["_schema"] != "article@v2.0.0" || payload["headline"] != "Hello" {
+		t.Fatalf("expected migrated payload, got %+v", payload)
+	}
+	if _, ok := payload["title"]; ok {
+		t.Fatalf("expected title to be renamed")
+	}
+}
@@ -13,9 +13,11 @@ import (
 	"github.com/goliatone/go-cms/internal/domain"
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/internal/retention"
 	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
 	cmsschema "github.com/goliatone/go-cms/internal/schema"
 	"github.com/goliatone/go-cms/internal/translationconfig"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/internal/validation"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/interfaces"
@@ -39,6 +41,23 @@ type ContentRepository interface {
 	GetVersion(ctx context.Context, contentID uuid.UUID, number int) (*ContentVersion, error)
 	GetLatestVersion(ctx context.Context, contentID uuid.UUID) (*ContentVersion, error)
 	UpdateVersion(ctx context.Context, version *ContentVersion) (*ContentVersion, error)
+	DeleteVersions(ctx context.Context, contentID uuid.UUID, numbers []int) error
+}
+
+// ContentTrasher is implemented by content repositories that can move an entry
+// to the trash and back in a single transaction. Repositories without
+// transactional support return errors.ErrUnsupported and the service falls
+// back to writing the entry through its trash repository.
+type ContentTrasher interface {
+	DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error
+	RestoreFromTrash(ctx context.Context, entryID uuid.UUID, record *Content) error
+}
+
+// ContentTranslationFieldsWriter is implemented by content repositories that
+// can overwrite the stored fields of one translation in place. It backs
+// bookkeeping writes that must not create a version or emit lifecycle events.
+type ContentTranslationFieldsWriter interface {
+	UpdateTranslationFields(ctx context.Context, translationID uuid.UUID, fields map[string]any) error
 }
 
 // ContentTypeRepository resolves content types.
@@ -106,7 +125,18 @@ func WithVersionRetentionLimit(limit int) ServiceOption {
 		if limit < 0 {
 			limit = 0
 		}
-		s.versionRetentionLimit = limit
+		s.retention.KeepLast = limit
+	}
+}
+
+// WithVersionRetentionPolicy configures how version history is limited. It
+// replaces any limit set by WithVersionRetentionLimit.
+func WithVersionRetentionPolicy(policy retention.Policy) ServiceOption {
+	return func(s *service) {
+		if policy.KeepLast < 0 {
+			policy.KeepLast = 0
+		}
+		s.retention = policy
 	}
 }
 
@@ -128,6 +158,16 @@ func WithSchemaMigrator(migrator *cmsschema.Migrator) ServiceOption {
 	}
 }
 
+// WithTrashRepository enables soft deletes by snapshotting deleted entries
+// into the trash so they can be listed and restored.
+func WithTrashRepository(repo trash.Repository) ServiceOption {
+	return func(svc *service) {
+		if repo != nil {
+			svc.trash = repo
+		}
+	}
+}
+
 // WithScheduler overrides the scheduler used to register publish/unpublish jobs.
 func WithScheduler(scheduler interfaces.Scheduler) ServiceOption {
 	return func(svc *service) {
@@ -260,7 +300,7 @@ type service struct {
 	id                        IDGenerator
 	slugger                   slug.Normalizer
 	versioningEnabled         bool
-	versionRetentionLimit     int
+	retention                 retention.Policy
 	scheduler                 interfaces.Scheduler
 	schedulingEnabled         bool
 	logger                    interfaces.Logger
@@ -278,6 +318,7 @@ type service struct {
 	requireExplicitEnv        bool
 	requireActiveEnv          bool
 	projectionTranslationMode ProjectionTranslationMode
+	trash                     trash.Repository
 }
 
 func (s *service) SupportsContentListOption(option cmsapi.ContentListOption) bool {
@@ -648,6 +689,7 @@ func (s *service) Create(ctx context.Context, req CreateContentRequest) (*Conten
 		ID:            s.id(),
 		ContentTypeID: req.ContentTypeID,
 		EnvironmentID: envID,
+		SiteID:        cloneUUIDPointer(req.SiteID),
 		Status:        chooseStatus(req.Status),
 		Slug:          slugValue,
 		Metadata:      entryMetadata,
@@ -803,10 +845,7 @@ func (s *service) List(ctx context.Context, env ...ContentListOption) ([]*Conten
 	if err != nil {
 		return nil, err
 	}
-	listArgs := []ContentListOption{envID.String()}
-	if opts.contentTypeID != uuid.Nil {
-		listArgs = append(listArgs, WithContentTypeID(opts.contentTypeID))
-	}
+	listArgs := append([]ContentListOption{envID.String()}, opts.repositoryArgs()...)
 	translationsRequested := s.shouldLoadTranslations(opts, mode)
 	translationsLoaded := translationsRequested && s.translationsEnabledFlag()
 	if translationsLoaded {
@@ -957,7 +996,7 @@ func (s *service) Delete(ctx context.Context, req DeleteContentRequest) error {
 	if req.ID == uuid.Nil {
 		return ErrContentIDRequired
 	}
-	if !req.HardDelete {
+	if !req.HardDelete && s.trash == nil {
 		return ErrContentSoftDeleteUnsupported
 	}
 
@@ -983,17 +1022,29 @@ func (s *service) Delete(ctx context.Context, req DeleteContentRequest) error {
 		}
 	}
 
-	if err := s.contents.Delete(ctx, req.ID, true); err != nil {
-		logger.Error("content repository delete failed", "error", err)
-		return err
+	var entry *trash.Entry
+	if req.HardDelete {
+		if err := s.contents.Delete(ctx, req.ID, true); err != nil {
+			logger.Error("content repository delete failed", "error", err)
+			return err
+		}
+	} else {
+		entry, err = s.trashContent(ctx, record, req.DeletedBy)
+		if err != nil {
+			logger.Error("content trash failed", "error", err)
+			return err
+		}
 	}
 
-	logger.Info("content deleted")
+	logger.Info("content deleted", "trashed", entry != nil)
 	meta := map[string]any{
 		"slug":    record.Slug,
 		"status":  record.Status,
 		"locales": collectContentLocalesFromTranslations(record.Translations),
 	}
+	if entry != nil {
+		meta["trashed"] = true
+	}
 	if record.EnvironmentID != uuid.Nil {
 		meta["environment_id"] = record.EnvironmentID.String()
 	}
@@ -1655,8 +1706,8 @@ func (s *service) CreateDraft(ctx context.Context, req CreateContentDraftRequest
 	}
 	logger.Debug("content versions loaded", "count", len(versions))
 
-	if s.versionRetentionLimit > 0 && len(versions) >= s.versionRetentionLimit {
-		logger.Warn("content version retention limit reached", "limit", s.versionRetentionLimit)
+	if s.retention.Rejects(len(versions)) {
+		logger.Warn("content version retention limit reached", "limit", s.retention.KeepLast)
 		return nil, ErrContentVersionRetentionExceeded
 	}
 
@@ -1705,6 +1756,12 @@ func (s *service) CreateDraft(ctx context.Context, req CreateContentDraftRequest
 	})
 	logger.Info("content draft created")
 
+	if s.retention.PrunesOnWrite() {
+		if _, err := s.pruneVersions(ctx, contentRecord, contentRecord.UpdatedBy); err != nil {
+			logger.Warn("content version prune failed", "error", err)
+		}
+	}
+
 	return cloneContentVersion(created), nil
 }
 
@@ -2033,11 +2090,12 @@ func (s *service) migrateContentSnapshot(contentType *ContentType, snapshot Cont
 		return snapshot, nil
 	}
 	updated := cloneContentVersionSnapshot(snapshot)
+	migrator := ContentTypeMigrator(s.schemaMigrator, contentType)
 	for idx, tr := range updated.Translations {
 		if tr.Content == nil {
 			tr.Content = map[string]any{}
 		}
-		migrated, _, err := s.migratePayload(contentType.Slug, contentType.Schema, targetVersion, tr.Content, strict)
+		migrated, _, err := migratePayload(migrator, contentType.Slug, contentType.Schema, targetVersion, tr.Content, strict)
 		if err != nil {
 			return snapshot, err
 		}
@@ -2046,19 +2104,19 @@ func (s *service) migrateContentSnapshot(contentType *ContentType, snapshot Cont
 	return updated, nil
 }
 
-func (s *service) migratePayload(slug string, schema map[string]any, target cmsschema.Version, payload map[string]any, strict bool) (map[string]any, bool, error) {
+func migratePayload(migrator *cmsschema.Migrator, slug string, schema map[string]any, target cmsschema.Version, payload map[string]any, strict bool) (map[string]any, bool, error) {
 	current, ok := cmsschema.RootSchemaVersion(payload)
 	if !ok || current.String() == target.String() {
 		return applySchemaVersion(stripSchemaVersion(payload), target), false, nil
 	}
-	if s.schemaMigrator == nil {
+	if migrator == nil {
 		return nil, false, ErrContentSchemaMigrationRequired
 	}
 	if current.Slug != "" && target.Slug != "" && current.Slug != target.Slug {
 		return nil, false, fmt.Errorf("%w: schema slug mismatch", ErrContentSchemaMigrationRequired)
 	}
 	trimmed := stripSchemaVersion(payload)
-	migrated, err := s.schemaMigrator.Migrate(slug, current.String(), target.String(), trimmed)
+	migrated, err := migrator.Migrate(slug, current.String(), target.String(), trimmed)
 	if err != nil {
 		return nil, false, fmt.Errorf("%w: %w", ErrContentSchemaMigrationRequired, err)
 	}
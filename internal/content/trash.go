@@ -0,0 +1,241 @@
+package content
+
+import (
+	"context"
+	"errors"
+
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+// maxRestoreRenameAttempts bounds the suffixed slugs tried by RestoreConflictRename.
+const maxRestoreRenameAttempts = 100
+
+// trashContent snapshots the entry together with its translations and
+// versions and removes the live rows. Repositories implementing ContentTrasher
+// store the snapshot in the same transaction as the delete.
+func (s *service) trashContent(ctx context.Context, record *Content, deletedBy uuid.UUID) (*trash.Entry, error) {
+	if err := s.loadTranslations(ctx, record); err != nil {
+		return nil, err
+	}
+	versions, err := s.contents.ListVersions(ctx, record.ID)
+	if err != nil {
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+	}
+	now := s.now()
+	snapshot := *record
+	snapshot.Type = nil
+	snapshot.Versions = versions
+	snapshot.DeletedAt = &now
+	for _, tr := range snapshot.Translations {
+		if tr != nil {
+			tr.Locale = nil
+			tr.DeletedAt = &now
+		}
+	}
+	entry, err := trash.NewEntry(trash.ResourceContent, record.ID, &snapshot, deletedBy, now)
+	if err != nil {
+		return nil, err
+	}
+	entry.EnvironmentID = record.EnvironmentID
+
+	if trasher, ok := s.contents.(ContentTrasher); ok {
+		err := trasher.DeleteToTrash(ctx, record.ID, entry)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			if err != nil {
+				return nil, err
+			}
+			return entry, nil
+		}
+	}
+
+	stored, err := s.trash.Create(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contents.Delete(ctx, record.ID, true); err != nil {
+		_ = s.trash.Delete(ctx, stored.ID)
+		return nil, err
+	}
+	return stored, nil
+}
+
+// ListTrashed returns a page of soft-deleted entries of the environment, most
+// recently deleted first. Versions are omitted from the listing.
+func (s *service) ListTrashed(ctx context.Context, req ListTrashedContentRequest) ([]*Content, error) {
+	if s.trash == nil {
+		return nil, ErrContentSoftDeleteUnsupported
+	}
+	envID, _, err := s.resolveEnvironment(ctx, req.Environment)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.trash.List(ctx, trash.ListQuery{
+		ResourceType:  trash.ResourceContent,
+		EnvironmentID: envID,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Content, 0, len(entries))
+	for _, entry := range entries {
+		var record Content
+		if err := trash.Decode(entry, &record); err != nil {
+			return nil, err
+		}
+		record.Versions = nil
+		s.attachContentType(ctx, &record)
+		records = append(records, s.decorateContent(&record))
+	}
+	return records, nil
+}
+
+// RestoreDeleted recreates a trashed entry with its translations and versions.
+func (s *service) RestoreDeleted(ctx context.Context, req RestoreContentRequest) (*Content, error) {
+	if req.ID == uuid.Nil {
+		return nil, ErrContentIDRequired
+	}
+	if s.trash == nil {
+		return nil, ErrContentSoftDeleteUnsupported
+	}
+	logger := s.opLogger(ctx, "content.restore", map[string]any{
+		"content_id": req.ID,
+	})
+
+	entry, err := s.trash.GetByResource(ctx, trash.ResourceContent, req.ID)
+	if err != nil {
+		if errors.Is(err, trash.ErrEntryNotFound) {
+			return nil, ErrContentNotTrashed
+		}
+		return nil, err
+	}
+	var record Content
+	if err := trash.Decode(entry, &record); err != nil {
+		return nil, err
+	}
+	if err := s.ensureEnvironmentActive(ctx, record.EnvironmentID); err != nil {
+		return nil, err
+	}
+	if _, err := s.contentTypes.GetByID(ctx, record.ContentTypeID); err != nil {
+		return nil, ErrContentTypeRequired
+	}
+
+	originalSlug := record.Slug
+	record.Slug, err = s.resolveRestoreSlug(ctx, &record, req.OnConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	record.DeletedAt = nil
+	record.UpdatedAt = now
+	if req.RestoredBy != uuid.Nil {
+		record.UpdatedBy = req.RestoredBy
+	}
+	for _, tr := range record.Translations {
+		if tr != nil {
+			tr.DeletedAt = nil
+		}
+	}
+
+	if err := s.restoreFromTrash(ctx, entry.ID, &record); err != nil {
+		logger.Error("content restore failed", "error", err)
+		return nil, err
+	}
+	record.Versions = nil
+
+	logger.Info("content restored", "slug", record.Slug)
+	meta := map[string]any{
+		"slug":    record.Slug,
+		"status":  record.Status,
+		"locales": collectContentLocalesFromTranslations(record.Translations),
+	}
+	if record.Slug != originalSlug {
+		meta["original_slug"] = originalSlug
+	}
+	if record.EnvironmentID != uuid.Nil {
+		meta["environment_id"] = record.EnvironmentID.String()
+	}
+	s.emitActivity(ctx, pickActor(req.RestoredBy, record.UpdatedBy), "restore", "content", record.ID, meta)
+
+	s.attachContentType(ctx, &record)
+	s.emitLifecycle(ctx, s.contentLifecycleEvent(ctx, &record, record.Type, "restore", uuid.Nil, "", meta))
+	return s.decorateContent(&record), nil
+}
+
+// restoreFromTrash stores the entry with its translations and versions again
+// and removes the trash entry. Without ContentTrasher support the entry is
+// deleted again when a later step fails, so the restore can be retried.
+func (s *service) restoreFromTrash(ctx context.Context, entryID uuid.UUID, record *Content) error {
+	if trasher, ok := s.contents.(ContentTrasher); ok {
+		err := trasher.RestoreFromTrash(ctx, entryID, record)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+
+	clone := *record
+	clone.Versions = nil
+	if _, err := s.contents.Create(ctx, &clone); err != nil {
+		return err
+	}
+	err := func() error {
+		for _, version := range record.Versions {
+			if version == nil {
+				continue
+			}
+			if _, err := s.contents.CreateVersion(ctx, version); err != nil {
+				return err
+			}
+		}
+		if err := s.trash.Delete(ctx, entryID); err != nil && !errors.Is(err, trash.ErrEntryNotFound) {
+			return err
+		}
+		return nil
+	}()
+	if err != nil {
+		_ = s.contents.Delete(ctx, record.ID, true)
+		return err
+	}
+	return nil
+}
+
+// resolveRestoreSlug returns the slug a restored entry can use, applying the
+// conflict strategy when another entry of the same type took the slug.
+func (s *service) resolveRestoreSlug(ctx context.Context, record *Content, strategy domain.RestoreConflict) (string, error) {
+	taken := func(candidate string) (bool, error) {
+		existing, err := s.contents.GetBySlug(ctx, candidate, record.ContentTypeID, record.EnvironmentID.String())
+		if err != nil {
+			var notFound *NotFoundError
+			if errors.As(err, &notFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return existing != nil, nil
+	}
+	conflict, err := taken(record.Slug)
+	if err != nil || !conflict {
+		return record.Slug, err
+	}
+	if strategy != domain.RestoreConflictRename {
+		return "", ErrSlugExists
+	}
+	for attempt := 1; attempt <= maxRestoreRenameAttempts; attempt++ {
+		candidate := trash.SuffixedSlug(record.Slug, attempt)
+		conflict, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !conflict {
+			return candidate, nil
+		}
+	}
+	return "", ErrSlugExists
+}
@@ -0,0 +1,335 @@
+package content_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestServiceSoftDeleteAndRestore(t *testing.T) {
+	contentStore := content.NewMemoryContentRepository()
+	typeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+	trashStore := trash.NewMemoryRepository()
+
+	contentTypeID := uuid.New()
+	seedContentType(t, typeStore, &content.ContentType{ID: contentTypeID, Name: "page"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "es", Display: "Spanish"})
+
+	hook := &lifecycle.CaptureHook{}
+	emitter := lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})
+	svc := content.NewService(contentStore, typeStore, localeStore,
+		content.WithTrashRepository(trashStore),
+		content.WithLifecycleEmitter(emitter),
+	)
+	ctx := context.Background()
+	actor := uuid.New()
+
+	record, err := svc.Create(ctx, content.CreateContentRequest{
+		ContentTypeID: contentTypeID,
+		Slug:          "about",
+		CreatedBy:     actor,
+		UpdatedBy:     actor,
+		Translations: []content.ContentTranslationInput{
+			{Locale: "en", Title: "About"},
+			{Locale: "es", Title: "Acerca"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	if err := svc.Delete(ctx, content.DeleteContentRequest{ID: record.ID, DeletedBy: actor}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	var notFound *content.NotFoundError
+	if _, err := svc.Get(ctx, record.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected trashed content to be hidden, got %v", err)
+	}
+
+	trashed, err := svc.ListTrashed(ctx, content.ListTrashedContentRequest{})
+	if err != nil {
+		t.Fatalf("list trashed: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != record.ID || trashed[0].DeletedAt == nil {
+		t.Fatalf("expected trashed entry, got %+v", trashed)
+	}
+
+	restored, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: record.ID, RestoredBy: actor})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.ID != record.ID || restored.Slug != "about" || restored.DeletedAt != nil {
+		t.Fatalf("unexpected restored record %+v", restored)
+	}
+
+	fetched, err := svc.Get(ctx, record.ID, content.WithTranslations())
+	if err != nil {
+		t.Fatalf("get restored: %v", err)
+	}
+	if len(fetched.Translations) != 2 {
+		t.Fatalf("expected translations to be restored, got %d", len(fetched.Translations))
+	}
+	transitions := make([]string, 0, len(hook.Events))
+	for _, event := range hook.Events {
+		transitions = append(transitions, event.Transition)
+	}
+	if got := strings.Join(transitions, ","); got != "create,delete,restore" {
+		t.Fatalf("expected create, delete and restore lifecycle events, got %s", got)
+	}
+	if last := hook.Events[len(hook.Events)-1]; last.RecordID != record.ID.String() || len(last.Locales) != 2 {
+		t.Fatalf("unexpected restore lifecycle event %+v", last)
+	}
+
+	if _, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: record.ID}); !errors.Is(err, content.ErrContentNotTrashed) {
+		t.Fatalf("expected ErrContentNotTrashed, got %v", err)
+	}
+}
+
+func TestServiceRestoreDeletedSlugConflict(t *testing.T) {
+	contentStore := content.NewMemoryContentRepository()
+	typeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+
+	contentTypeID := uuid.New()
+	seedContentType(t, typeStore, &content.ContentType{ID: contentTypeID, Name: "page"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	svc := content.NewService(contentStore, typeStore, localeStore, content.WithTrashRepository(trash.NewMemoryRepository()))
+	ctx := context.Background()
+
+	create := func() *content.Content {
+		t.Helper()
+		record, err := svc.Create(ctx, content.CreateContentRequest{
+			ContentTypeID: contentTypeID,
+			Slug:          "pricing",
+			CreatedBy:     uuid.New(),
+			UpdatedBy:     uuid.New(),
+			Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Pricing"}},
+		})
+		if err != nil {
+			t.Fatalf("create content: %v", err)
+		}
+		return record
+	}
+
+	original := create()
+	if err := svc.Delete(ctx, content.DeleteContentRequest{ID: original.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	create()
+
+	if _, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: original.ID}); !errors.Is(err, content.ErrSlugExists) {
+		t.Fatalf("expected ErrSlugExists, got %v", err)
+	}
+
+	restored, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{
+		ID:         original.ID,
+		OnConflict: domain.RestoreConflictRename,
+	})
+	if err != nil {
+		t.Fatalf("restore with rename: %v", err)
+	}
+	if restored.Slug != "pricing-restored" {
+		t.Fatalf("expected renamed slug, got %q", restored.Slug)
+	}
+}
+
+func TestServiceListTrashedRequiresTrashRepository(t *testing.T) {
+	svc := content.NewService(content.NewMemoryContentRepository(), content.NewMemoryContentTypeRepository(), content.NewMemoryLocaleRepository())
+	if _, err := svc.ListTrashed(context.Background(), content.ListTrashedContentRequest{}); !errors.Is(err, content.ErrContentSoftDeleteUnsupported) {
+		t.Fatalf("expected ErrContentSoftDeleteUnsupported, got %v", err)
+	}
+}
+
+func TestServiceSoftDeleteWithBunStorageStoresSnapshotInDeleteTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { closeSQLDB(t, sqlDB) })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	registerContentModels(t, bunDB)
+	seedContentEntities(t, bunDB)
+	for _, model := range []any{(*content.ContentVersion)(nil), (*trash.Entry)(nil)} {
+		if _, err := bunDB.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
+			t.Fatalf("create table %T: %v", model, err)
+		}
+	}
+
+	contentRepo := content.NewBunContentRepository(bunDB)
+	trashRepo := trash.NewBunRepository(bunDB)
+	svc := content.NewService(contentRepo, content.NewBunContentTypeRepository(bunDB), content.NewBunLocaleRepository(bunDB), content.WithTrashRepository(trashRepo))
+
+	ids := make([]uuid.UUID, 0, 3)
+	for _, slug := range []string{"first", "second", "third"} {
+		record, err := svc.Create(ctx, content.CreateContentRequest{
+			ContentTypeID: mustUUID("00000000-0000-0000-0000-000000000210"),
+			Slug:          slug,
+			CreatedBy:     uuid.New(),
+			UpdatedBy:     uuid.New(),
+			Translations:  []content.ContentTranslationInput{{Locale: "en", Title: slug}},
+		})
+		if err != nil {
+			t.Fatalf("create %s: %v", slug, err)
+		}
+		if err := svc.Delete(ctx, content.DeleteContentRequest{ID: record.ID}); err != nil {
+			t.Fatalf("soft delete %s: %v", slug, err)
+		}
+		ids = append(ids, record.ID)
+	}
+
+	trashed, err := svc.ListTrashed(ctx, content.ListTrashedContentRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("list trashed: %v", err)
+	}
+	if len(trashed) != 2 {
+		t.Fatalf("expected a page of 2 trashed entries, got %d", len(trashed))
+	}
+	rest, err := svc.ListTrashed(ctx, content.ListTrashedContentRequest{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("list trashed offset: %v", err)
+	}
+	if len(rest) != 1 {
+		t.Fatalf("expected the last trashed entry on the second page, got %d", len(rest))
+	}
+	staging, err := svc.ListTrashed(ctx, content.ListTrashedContentRequest{Environment: "staging"})
+	if err != nil {
+		t.Fatalf("list trashed staging: %v", err)
+	}
+	if len(staging) != 0 {
+		t.Fatalf("expected no trashed entries in another environment, got %d", len(staging))
+	}
+
+	// A failed delete rolls the snapshot back with it.
+	missing := uuid.New()
+	entry, err := trash.NewEntry(trash.ResourceContent, missing, map[string]string{"slug": "missing"}, uuid.Nil, time.Now())
+	if err != nil {
+		t.Fatalf("new entry: %v", err)
+	}
+	var notFound *content.NotFoundError
+	if err := contentRepo.DeleteToTrash(ctx, missing, entry); !errors.As(err, &notFound) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourceContent, missing); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected the snapshot to be rolled back, got %v", err)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourceContent, ids[0]); err != nil {
+		t.Fatalf("expected trashed snapshot to be stored: %v", err)
+	}
+
+	if _, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: ids[0]}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	translations, err := contentRepo.ListTranslations(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("list restored translations: %v", err)
+	}
+	if len(translations) != 1 || translations[0].Title != "first" {
+		t.Fatalf("expected restored translations, got %+v", translations)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourceContent, ids[0]); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected the snapshot to be removed on restore, got %v", err)
+	}
+
+	// A failed restore leaves no entry behind.
+	orphan := &content.Content{
+		ID:            uuid.New(),
+		ContentTypeID: mustUUID("00000000-0000-0000-0000-000000000210"),
+		Slug:          "orphan",
+		Status:        "draft",
+	}
+	if err := contentRepo.RestoreFromTrash(ctx, uuid.New(), orphan); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+	if _, err := contentRepo.GetByID(ctx, orphan.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected restored entry to be rolled back, got %v", err)
+	}
+}
+
+type failingVersionContentRepository struct {
+	content.ContentRepository
+	err error
+}
+
+func (r *failingVersionContentRepository) CreateVersion(ctx context.Context, version *content.ContentVersion) (*content.ContentVersion, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.ContentRepository.CreateVersion(ctx, version)
+}
+
+func TestServiceRestoreDeletedRemovesPartialRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := &failingVersionContentRepository{ContentRepository: content.NewMemoryContentRepository()}
+	typeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+	trashStore := trash.NewMemoryRepository()
+
+	contentTypeID := uuid.New()
+	seedContentType(t, typeStore, &content.ContentType{ID: contentTypeID, Name: "page"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	svc := content.NewService(repo, typeStore, localeStore,
+		content.WithTrashRepository(trashStore),
+		content.WithVersioningEnabled(true),
+	)
+	record, err := svc.Create(ctx, content.CreateContentRequest{
+		ContentTypeID: contentTypeID,
+		Slug:          "careers",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Careers"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+	if _, err := svc.CreateDraft(ctx, content.CreateContentDraftRequest{
+		ContentID: record.ID,
+		Snapshot:  content.ContentVersionSnapshot{Fields: map[string]any{"title": "Careers"}},
+		CreatedBy: uuid.New(),
+	}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	if err := svc.Delete(ctx, content.DeleteContentRequest{ID: record.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+
+	repo.err = errors.New("version insert failed")
+	if _, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: record.ID}); !errors.Is(err, repo.err) {
+		t.Fatalf("expected version insert failure, got %v", err)
+	}
+	var notFound *content.NotFoundError
+	if _, err := svc.Get(ctx, record.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected partial restore to be removed, got %v", err)
+	}
+
+	repo.err = nil
+	restored, err := svc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: record.ID})
+	if err != nil {
+		t.Fatalf("retry restore: %v", err)
+	}
+	versions, err := svc.ListVersions(ctx, restored.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("expected the version to be restored, got %d", len(versions))
+	}
+}
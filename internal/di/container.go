@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/internal/adapters/noop"
 	storageadapter "github.com/goliatone/go-cms/internal/adapters/storage"
 	adminblocks "github.com/goliatone/go-cms/internal/admin/blocks"
@@ -20,6 +21,7 @@ import (
 	"github.com/goliatone/go-cms/internal/blocks"
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/experiments"
 	"github.com/goliatone/go-cms/internal/generator"
 	"github.com/goliatone/go-cms/internal/i18n"
 	"github.com/goliatone/go-cms/internal/identity"
@@ -32,15 +34,20 @@ import (
 	"github.com/goliatone/go-cms/internal/menus"
 	"github.com/goliatone/go-cms/internal/pages"
 	"github.com/goliatone/go-cms/internal/permissions"
+	"github.com/goliatone/go-cms/internal/retention"
 	"github.com/goliatone/go-cms/internal/runtimeconfig"
 	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
 	shortcode "github.com/goliatone/go-cms/internal/shortcode"
+	"github.com/goliatone/go-cms/internal/sites"
 	"github.com/goliatone/go-cms/internal/storageconfig"
 	"github.com/goliatone/go-cms/internal/themes"
 	"github.com/goliatone/go-cms/internal/translationconfig"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/internal/webhooks"
 	"github.com/goliatone/go-cms/internal/widgets"
 	"github.com/goliatone/go-cms/internal/workflow"
 	workflowsimple "github.com/goliatone/go-cms/internal/workflow/simple"
+	"github.com/goliatone/go-cms/internal/wxr"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/activity/usersink"
 	"github.com/goliatone/go-cms/pkg/interfaces"
@@ -98,11 +105,25 @@ type Container struct {
 	memoryContentTypeRepo *content.MemoryContentTypeRepository
 	memoryLocaleRepo      *content.MemoryLocaleRepository
 	memoryEnvironmentRepo environments.EnvironmentRepository
+	memorySiteRepo        sites.SiteRepository
+	memoryExperimentRepo  experiments.ExperimentRepository
+	memoryTrashRepo       trash.Repository
+
+	memoryWebhookSubscriptionRepo webhooks.SubscriptionRepository
+	memoryWebhookDeliveryRepo     webhooks.DeliveryRepository
+	memoryAuditRecorder           *jobs.InMemoryAuditRecorder
 
 	contentRepo     *contentRepositoryProxy
 	contentTypeRepo *contentTypeRepositoryProxy
 	localeRepo      *localeRepositoryProxy
 	environmentRepo *environmentRepositoryProxy
+	siteRepo        *siteRepositoryProxy
+	experimentRepo  *experimentRepositoryProxy
+	trashRepo       *trashRepositoryProxy
+
+	webhookSubscriptionRepo *webhookSubscriptionRepositoryProxy
+	webhookDeliveryRepo     *webhookDeliveryRepositoryProxy
+	auditStore              *auditRecorderProxy
 
 	memoryPageRepo *pages.MemoryPageRepository
 	pageRepo       *pageRepositoryProxy
@@ -129,6 +150,7 @@ type Container struct {
 	memoryWidgetTranslationRepo widgets.TranslationRepository
 	memoryWidgetAreaRepo        widgets.AreaDefinitionRepository
 	memoryWidgetPlacementRepo   widgets.AreaPlacementRepository
+	memoryWidgetVersionRepo     widgets.InstanceVersionRepository
 	memoryThemeRepo             themes.ThemeRepository
 	memoryTemplateRepo          themes.TemplateRepository
 
@@ -146,6 +168,7 @@ type Container struct {
 	widgetTranslationRepo widgets.TranslationRepository
 	widgetAreaRepo        widgets.AreaDefinitionRepository
 	widgetPlacementRepo   widgets.AreaPlacementRepository
+	widgetVersionRepo     widgets.InstanceVersionRepository
 
 	themeRepo    themes.ThemeRepository
 	templateRepo themes.TemplateRepository
@@ -153,6 +176,9 @@ type Container struct {
 	contentSvc             content.Service
 	contentTypeSvc         content.ContentTypeService
 	environmentSvc         environments.Service
+	siteSvc                sites.Service
+	experimentSvc          experiments.Service
+	webhookSvc             webhooks.Service
 	pageSvc                pages.Service
 	adminPageReadSvc       interfaces.AdminPageReadService
 	adminContentReadSvc    interfaces.AdminContentReadService
@@ -176,6 +202,7 @@ type Container struct {
 	shortcodeMetrics       interfaces.ShortcodeMetrics
 	shortcodeCaches        map[string]interfaces.CacheProvider
 	shortcodeCacheResolved bool
+	shortcodeDeps          *shortcode.DependencyTracker
 
 	activityHooks    activity.Hooks
 	activityEmitter  *activity.Emitter
@@ -183,12 +210,15 @@ type Container struct {
 	lifecycleEmitter *lifecycle.Emitter
 
 	generatorSvc           generator.Service
+	generatorRebuilder     *generator.Rebuilder
 	generatorStorage       interfaces.StorageProvider
 	generatorAssetResolver generator.AssetResolver
 	generatorHooks         generator.Hooks
 
 	auditRecorder jobs.AuditRecorder
 	jobWorker     *jobs.Worker
+	trashPurger   *trash.Purger
+	versionPruner *retention.Pruner
 
 	workflowEngine          interfaces.WorkflowEngine
 	workflowDefinitionStore interfaces.WorkflowDefinitionStore
@@ -390,6 +420,27 @@ func WithEnvironmentService(svc environments.Service) Option {
 	}
 }
 
+// WithExperimentService overrides the default experiment service binding.
+func WithExperimentService(svc experiments.Service) Option {
+	return func(c *Container) {
+		c.experimentSvc = svc
+	}
+}
+
+// WithWebhookService overrides the default webhook service binding.
+func WithWebhookService(svc webhooks.Service) Option {
+	return func(c *Container) {
+		c.webhookSvc = svc
+	}
+}
+
+// WithSiteService overrides the default site service binding.
+func WithSiteService(svc sites.Service) Option {
+	return func(c *Container) {
+		c.siteSvc = svc
+	}
+}
+
 // WithPageService overrides the default page service binding.
 func WithPageService(svc pages.Service) Option {
 	return func(c *Container) {
@@ -524,6 +575,12 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 	memoryContentTypeRepo := content.NewMemoryContentTypeRepository()
 	memoryLocaleRepo := content.NewMemoryLocaleRepository()
 	memoryEnvironmentRepo := environments.NewMemoryRepository()
+	memorySiteRepo := sites.NewMemoryRepository()
+	memoryExperimentRepo := experiments.NewMemoryRepository()
+	memoryTrashRepo := trash.NewMemoryRepository()
+	memoryWebhookSubscriptionRepo := webhooks.NewMemorySubscriptionRepository()
+	memoryWebhookDeliveryRepo := webhooks.NewMemoryDeliveryRepository()
+	memoryAuditRecorder := jobs.NewInMemoryAuditRecorder()
 	memoryPageRepo := pages.NewMemoryPageRepository()
 
 	memoryBlockDefRepo := blocks.NewMemoryDefinitionRepository()
@@ -543,6 +600,7 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 	memoryWidgetTranslationRepo := widgets.NewMemoryTranslationRepository()
 	memoryWidgetAreaRepo := widgets.NewMemoryAreaDefinitionRepository()
 	memoryWidgetPlacementRepo := widgets.NewMemoryAreaPlacementRepository()
+	memoryWidgetVersionRepo := widgets.NewMemoryInstanceVersionRepository()
 
 	memoryThemeRepo := themes.NewMemoryThemeRepository()
 	memoryTemplateRepo := themes.NewMemoryTemplateRepository()
@@ -573,12 +631,25 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		memoryContentTypeRepo: memoryContentTypeRepo,
 		memoryLocaleRepo:      memoryLocaleRepo,
 		memoryEnvironmentRepo: memoryEnvironmentRepo,
+		memorySiteRepo:        memorySiteRepo,
+		memoryExperimentRepo:  memoryExperimentRepo,
+		memoryTrashRepo:       memoryTrashRepo,
 		memoryPageRepo:        memoryPageRepo,
 
+		memoryWebhookSubscriptionRepo: memoryWebhookSubscriptionRepo,
+		memoryWebhookDeliveryRepo:     memoryWebhookDeliveryRepo,
+		webhookSubscriptionRepo:       newWebhookSubscriptionRepositoryProxy(memoryWebhookSubscriptionRepo),
+		webhookDeliveryRepo:           newWebhookDeliveryRepositoryProxy(memoryWebhookDeliveryRepo),
+		memoryAuditRecorder:           memoryAuditRecorder,
+		auditStore:                    newAuditRecorderProxy(memoryAuditRecorder),
+
 		contentRepo:     newContentRepositoryProxy(memoryContentRepo),
 		contentTypeRepo: newContentTypeRepositoryProxy(memoryContentTypeRepo),
 		localeRepo:      newLocaleRepositoryProxy(memoryLocaleRepo),
 		environmentRepo: newEnvironmentRepositoryProxy(memoryEnvironmentRepo),
+		siteRepo:        newSiteRepositoryProxy(memorySiteRepo),
+		experimentRepo:  newExperimentRepositoryProxy(memoryExperimentRepo),
+		trashRepo:       newTrashRepositoryProxy(memoryTrashRepo),
 		pageRepo:        newPageRepositoryProxy(memoryPageRepo),
 
 		memoryBlockDefinitionRepo:        memoryBlockDefRepo,
@@ -597,6 +668,7 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		memoryWidgetTranslationRepo: memoryWidgetTranslationRepo,
 		memoryWidgetAreaRepo:        memoryWidgetAreaRepo,
 		memoryWidgetPlacementRepo:   memoryWidgetPlacementRepo,
+		memoryWidgetVersionRepo:     memoryWidgetVersionRepo,
 		memoryThemeRepo:             memoryThemeRepo,
 		memoryTemplateRepo:          memoryTemplateRepo,
 
@@ -616,6 +688,7 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		widgetTranslationRepo: memoryWidgetTranslationRepo,
 		widgetAreaRepo:        memoryWidgetAreaRepo,
 		widgetPlacementRepo:   memoryWidgetPlacementRepo,
+		widgetVersionRepo:     memoryWidgetVersionRepo,
 		themeRepo:             memoryThemeRepo,
 		templateRepo:          memoryTemplateRepo,
 
@@ -632,7 +705,23 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 	if err := c.configureLoggerProvider(); err != nil {
 		return nil, err
 	}
+	if c.Config.Features.Webhooks {
+		dispatcher := containerWebhookDispatcher{container: c}
+		c.activityHooks = append(c.activityHooks, webhooks.ActivityHook{Dispatcher: dispatcher})
+		c.lifecycleHooks = append(c.lifecycleHooks, webhooks.LifecycleHook{Dispatcher: dispatcher})
+	}
 	c.configureActivityEmitter()
+	if c.Config.Features.Shortcodes {
+		c.shortcodeDeps = shortcode.NewDependencyTracker()
+		c.lifecycleHooks = append(c.lifecycleHooks, c.shortcodeDeps)
+	}
+	if c.Config.Generator.Enabled && c.Config.Generator.RebuildOnChange {
+		c.generatorRebuilder = generator.NewRebuilder(generatorDependents{c},
+			generator.WithRebuildDebounce(c.Config.Generator.RebuildDebounce),
+			generator.WithRebuildLogger(logging.GeneratorLogger(c.loggerProvider)),
+		)
+		c.lifecycleHooks = append(c.lifecycleHooks, c.generatorRebuilder)
+	}
 	c.configureLifecycleEmitter()
 	if err := c.configureEnvironmentPermissionScope(); err != nil {
 		return nil, err
@@ -654,8 +743,9 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 	}
 
 	if c.auditRecorder == nil {
-		c.auditRecorder = jobs.NewInMemoryAuditRecorder()
+		c.auditRecorder = c.auditStore
 	}
+	c.configureWebhookService()
 	c.configureStorageAdminService()
 	c.configureTranslationAdminService()
 
@@ -677,18 +767,28 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		c.ensureDefaultEnvironment(context.Background())
 	}
 
+	if c.siteSvc == nil {
+		if c.Config.Features.Sites {
+			c.siteSvc = sites.NewService(c.siteRepo)
+		} else {
+			c.siteSvc = sites.NewDisabledService()
+		}
+	}
+
 	if c.blockSvc == nil {
 		blockOpts := []blocks.ServiceOption{
 			blocks.WithMediaService(c.mediaSvc),
 			blocks.WithVersioningEnabled(c.Config.Features.Versioning),
-			blocks.WithVersionRetentionLimit(c.Config.Retention.Blocks),
+			blocks.WithVersionRetentionPolicy(c.versionRetentionPolicy(c.Config.Retention.Blocks)),
 			blocks.WithActivityEmitter(c.activityEmitter),
+			blocks.WithLifecycleEmitter(c.lifecycleEmitter),
 			blocks.WithDefaultEnvironmentKey(c.Config.Environments.DefaultKey),
 			blocks.WithRequireExplicitEnvironment(c.Config.Environments.RequireExplicit),
 			blocks.WithRequireActiveEnvironment(c.Config.Environments.RequireActive),
 			blocks.WithRequireTranslations(requireTranslations),
 			blocks.WithTranslationsEnabled(translationsEnabled),
 			blocks.WithTranslationState(c.translationState),
+			blocks.WithLogger(logging.ModuleLogger(c.loggerProvider, "cms.blocks")),
 		}
 		if c.environmentSvc != nil {
 			blockOpts = append(blockOpts, blocks.WithEnvironmentService(c.environmentSvc))
@@ -705,6 +805,9 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		if c.Config.Features.Shortcodes {
 			blockOpts = append(blockOpts, blocks.WithShortcodeService(c.ShortcodeService()))
 		}
+		if c.Config.Features.Trash {
+			blockOpts = append(blockOpts, blocks.WithTrashRepository(c.trashRepo))
+		}
 		c.blockSvc = blocks.NewService(
 			c.blockDefinitionRepo,
 			c.blockRepo,
@@ -733,7 +836,7 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 	if c.contentSvc == nil {
 		contentOpts := []content.ServiceOption{
 			content.WithVersioningEnabled(c.Config.Features.Versioning),
-			content.WithVersionRetentionLimit(c.Config.Retention.Content),
+			content.WithVersionRetentionPolicy(c.versionRetentionPolicy(c.Config.Retention.Content)),
 			content.WithScheduler(c.scheduler),
 			content.WithSchedulingEnabled(c.Config.Features.Scheduling),
 			content.WithLogger(logging.ContentLogger(c.loggerProvider)),
@@ -758,6 +861,9 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		if c.embeddedBlockBridge != nil {
 			contentOpts = append(contentOpts, content.WithEmbeddedBlocksResolver(c.embeddedBlockBridge))
 		}
+		if c.Config.Features.Trash {
+			contentOpts = append(contentOpts, content.WithTrashRepository(c.trashRepo))
+		}
 		c.contentSvc = content.NewService(c.contentRepo, c.contentTypeRepo, c.localeRepo, contentOpts...)
 	}
 
@@ -784,7 +890,7 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		if !c.Config.Features.Themes {
 			c.themeSvc = themes.NewNoOpService()
 		} else {
-			c.themeSvc = themes.NewService(c.themeRepo, c.templateRepo)
+			c.themeSvc = themes.NewService(c.themeRepo, c.templateRepo, themes.WithLifecycleEmitter(c.lifecycleEmitter))
 		}
 	}
 
@@ -798,6 +904,17 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 			serviceOptions := []widgets.ServiceOption{
 				widgets.WithRegistry(registry),
 				widgets.WithActivityEmitter(c.activityEmitter),
+				widgets.WithLifecycleEmitter(c.lifecycleEmitter),
+				widgets.WithVersioningEnabled(c.Config.Features.Versioning),
+				widgets.WithVersionRetentionPolicy(c.versionRetentionPolicy(c.Config.Retention.Widgets)),
+				widgets.WithLogger(logging.ModuleLogger(c.loggerProvider, "cms.widgets")),
+				widgets.WithDefaultEnvironmentKey(c.Config.Environments.DefaultKey),
+			}
+			if c.environmentSvc != nil {
+				serviceOptions = append(serviceOptions, widgets.WithEnvironmentService(c.environmentSvc))
+			}
+			if c.widgetVersionRepo != nil {
+				serviceOptions = append(serviceOptions, widgets.WithInstanceVersionRepository(c.widgetVersionRepo))
 			}
 			if c.widgetAreaRepo != nil {
 				serviceOptions = append(serviceOptions, widgets.WithAreaDefinitionRepository(c.widgetAreaRepo))
@@ -808,6 +925,12 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 			if c.Config.Features.Shortcodes {
 				serviceOptions = append(serviceOptions, widgets.WithShortcodeService(c.ShortcodeService()))
 			}
+			if c.Config.Features.Trash {
+				serviceOptions = append(serviceOptions, widgets.WithTrashRepository(c.trashRepo))
+			}
+			if c.Config.Features.Experiments {
+				serviceOptions = append(serviceOptions, widgets.WithVariantSelector(containerVariantSelector{container: c}))
+			}
 
 			c.widgetSvc = widgets.NewService(
 				c.widgetDefinitionRepo,
@@ -818,11 +941,26 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		}
 	}
 
+	if c.experimentSvc == nil {
+		if c.Config.Features.Experiments {
+			experimentOpts := []experiments.ServiceOption{
+				experiments.WithActivityEmitter(c.activityEmitter),
+				experiments.WithWidgetService(c.widgetSvc),
+			}
+			if c.blockSvc != nil {
+				experimentOpts = append(experimentOpts, experiments.WithBlockService(c.blockSvc, c.blockRepo))
+			}
+			c.experimentSvc = experiments.NewService(c.experimentRepo, experimentOpts...)
+		} else {
+			c.experimentSvc = experiments.NewDisabledService()
+		}
+	}
+
 	if c.pageSvc == nil {
 		pageOpts := []pages.ServiceOption{
 			pages.WithMediaService(c.mediaSvc),
 			pages.WithPageVersioningEnabled(c.Config.Features.Versioning),
-			pages.WithPageVersionRetentionLimit(c.Config.Retention.Pages),
+			pages.WithPageVersionRetentionPolicy(c.versionRetentionPolicy(c.Config.Retention.Pages)),
 			pages.WithSchedulingEnabled(c.Config.Features.Scheduling),
 			pages.WithScheduler(c.scheduler),
 			pages.WithLogger(logging.PagesLogger(c.loggerProvider)),
@@ -851,6 +989,12 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		if c.themeSvc != nil {
 			pageOpts = append(pageOpts, pages.WithThemeService(c.themeSvc))
 		}
+		if c.Config.Features.Experiments {
+			pageOpts = append(pageOpts, pages.WithVariantSelector(c.experimentSvc))
+		}
+		if c.Config.Features.Trash {
+			pageOpts = append(pageOpts, pages.WithTrashRepository(c.trashRepo))
+		}
 		c.pageSvc = pages.NewService(c.pageRepo, c.contentRepo, c.localeRepo, pageOpts...)
 	}
 
@@ -861,6 +1005,7 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 			menus.WithTranslationsEnabled(translationsEnabled),
 			menus.WithTranslationState(c.translationState),
 			menus.WithActivityEmitter(c.activityEmitter),
+			menus.WithLifecycleEmitter(c.lifecycleEmitter),
 			menus.WithAuditRecorder(c.auditRecorder),
 			menus.WithDefaultEnvironmentKey(c.Config.Environments.DefaultKey),
 			menus.WithRequireExplicitEnvironment(c.Config.Environments.RequireExplicit),
@@ -897,6 +1042,9 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 		if c.menuViewProfileRepo != nil {
 			menuOpts = append(menuOpts, menus.WithMenuViewProfileRepository(c.menuViewProfileRepo))
 		}
+		if c.Config.Features.Trash {
+			menuOpts = append(menuOpts, menus.WithTrashRepository(c.trashRepo))
+		}
 		c.menuSvc = menus.NewService(
 			c.menuRepo,
 			c.menuItemRepo,
@@ -905,11 +1053,49 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 			menuOpts...,
 		)
 	}
+	if c.Config.Features.Trash && c.trashPurger == nil {
+		purger, err := trash.NewPurger(c.trashRepo, c.Config.Trash.Retention)
+		if err != nil {
+			return nil, err
+		}
+		c.trashPurger = purger
+	}
+	if c.Config.Features.Versioning && c.versionPruner == nil {
+		var sources []retention.Source
+		for _, svc := range []any{c.contentSvc, c.pageSvc, c.blockSvc, c.widgetSvc} {
+			if source, ok := svc.(retention.Source); ok {
+				sources = append(sources, source)
+			}
+		}
+		c.versionPruner = retention.NewPruner(sources...)
+	}
 	if c.jobWorker == nil {
-		c.jobWorker = jobs.NewWorker(c.scheduler, c.contentRepo,
+		workerOpts := []jobs.Option{
 			jobs.WithAuditRecorder(c.auditRecorder),
 			jobs.WithActivityEmitter(c.activityEmitter),
-		)
+		}
+		if c.trashPurger != nil {
+			workerOpts = append(workerOpts, jobs.WithTrashPurger(c.trashPurger))
+		}
+		if c.versionPruner != nil {
+			workerOpts = append(workerOpts, jobs.WithVersionPruner(c.versionPruner))
+		}
+		if c.Config.Features.Webhooks && c.webhookSvc != nil {
+			workerOpts = append(workerOpts, jobs.WithWebhookDeliverer(c.webhookSvc))
+		}
+		if c.Config.Audit.Retention > 0 {
+			workerOpts = append(workerOpts, jobs.WithAuditRetention(c.Config.Audit.Retention))
+		}
+		c.jobWorker = jobs.NewWorker(c.scheduler, c.contentRepo, workerOpts...)
+	}
+	if err := c.scheduleTrashPurge(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := c.scheduleVersionPrune(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := c.scheduleAuditPurge(context.Background()); err != nil {
+		return nil, err
 	}
 
 	if c.generatorSvc == nil {
@@ -931,6 +1117,9 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 				Menus:            maps.Clone(c.Config.Generator.Menus),
 				RenderTimeout:    c.Config.Generator.RenderTimeout,
 				AssetCopyTimeout: c.Config.Generator.AssetCopyTimeout,
+				Collections:      generatorCollections(c.Config.Generator.Collections),
+				LocaleFallbacks:  maps.Clone(c.Config.Generator.LocaleFallbacks),
+				SitemapMaxURLs:   c.Config.Generator.SitemapMaxURLs,
 				Theming: generator.ThemingConfig{
 					DefaultTheme:      c.Config.Themes.DefaultTheme,
 					DefaultVariant:    c.Config.Themes.DefaultVariant,
@@ -954,6 +1143,12 @@ func NewContainer(cfg runtimeconfig.Config, opts ...Option) (*Container, error)
 				Logger:       logging.GeneratorLogger(c.loggerProvider),
 				Shortcodes:   c.ShortcodeService(),
 			}
+			if c.Config.Features.Sites {
+				genDeps.Sites = c.SiteService()
+				if c.pageRepo != nil {
+					genDeps.Pages = c.pageRepo
+				}
+			}
 			c.generatorSvc = generator.NewService(genCfg, genDeps)
 		}
 	}
@@ -1144,6 +1339,24 @@ func (c *Container) configureRepositories() {
 		if c.environmentRepo != nil && c.Config.Features.Environments {
 			c.environmentRepo.swap(environments.NewBunEnvironmentRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer))
 		}
+		if c.siteRepo != nil && c.Config.Features.Sites {
+			c.siteRepo.swap(sites.NewBunSiteRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer))
+		}
+		if c.experimentRepo != nil && c.Config.Features.Experiments {
+			c.experimentRepo.swap(experiments.NewBunExperimentRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer))
+		}
+		if c.trashRepo != nil && c.Config.Features.Trash {
+			c.trashRepo.swap(trash.NewBunRepository(c.bunDB))
+		}
+		if c.webhookSubscriptionRepo != nil && c.Config.Features.Webhooks {
+			c.webhookSubscriptionRepo.swap(webhooks.NewBunSubscriptionRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer))
+		}
+		if c.webhookDeliveryRepo != nil && c.Config.Features.Webhooks {
+			c.webhookDeliveryRepo.swap(webhooks.NewBunDeliveryRepository(c.bunDB))
+		}
+		if c.auditStore != nil {
+			c.auditStore.swap(jobs.NewBunAuditRecorder(c.bunDB))
+		}
 		if c.pageRepo != nil {
 			c.pageRepo.swap(pages.NewBunPageRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer))
 		}
@@ -1174,6 +1387,7 @@ func (c *Container) configureRepositories() {
 		c.widgetTranslationRepo = widgets.NewBunTranslationRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer)
 		c.widgetAreaRepo = widgets.NewBunAreaDefinitionRepository(c.bunDB)
 		c.widgetPlacementRepo = widgets.NewBunAreaPlacementRepository(c.bunDB)
+		c.widgetVersionRepo = widgets.NewBunInstanceVersionRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer)
 
 		c.themeRepo = themes.NewBunThemeRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer)
 		c.templateRepo = themes.NewBunTemplateRepositoryWithCache(c.bunDB, c.cacheService, c.keySerializer)
@@ -1193,6 +1407,24 @@ func (c *Container) configureRepositories() {
 	if c.environmentRepo != nil && c.memoryEnvironmentRepo != nil {
 		c.environmentRepo.swap(c.memoryEnvironmentRepo)
 	}
+	if c.siteRepo != nil && c.memorySiteRepo != nil {
+		c.siteRepo.swap(c.memorySiteRepo)
+	}
+	if c.experimentRepo != nil && c.memoryExperimentRepo != nil {
+		c.experimentRepo.swap(c.memoryExperimentRepo)
+	}
+	if c.trashRepo != nil && c.memoryTrashRepo != nil {
+		c.trashRepo.swap(c.memoryTrashRepo)
+	}
+	if c.webhookSubscriptionRepo != nil && c.memoryWebhookSubscriptionRepo != nil {
+		c.webhookSubscriptionRepo.swap(c.memoryWebhookSubscriptionRepo)
+	}
+	if c.webhookDeliveryRepo != nil && c.memoryWebhookDeliveryRepo != nil {
+		c.webhookDeliveryRepo.swap(c.memoryWebhookDeliveryRepo)
+	}
+	if c.auditStore != nil && c.memoryAuditRecorder != nil {
+		c.auditStore.swap(c.memoryAuditRecorder)
+	}
 	if c.pageRepo != nil && c.memoryPageRepo != nil {
 		c.pageRepo.swap(c.memoryPageRepo)
 	}
@@ -1242,6 +1474,9 @@ func (c *Container) configureRepositories() {
 	if c.memoryWidgetPlacementRepo != nil {
 		c.widgetPlacementRepo = c.memoryWidgetPlacementRepo
 	}
+	if c.memoryWidgetVersionRepo != nil {
+		c.widgetVersionRepo = c.memoryWidgetVersionRepo
+	}
 	if c.memoryThemeRepo != nil {
 		c.themeRepo = c.memoryThemeRepo
 	}
@@ -1695,12 +1930,106 @@ func (c *Container) configureScheduler() {
 	}
 }
 
+// configureWebhookService builds the webhook service once the scheduler is
+// known. Without scheduling, deliveries are attempted once; events from the
+// lifecycle and activity hooks are dispatched in the background.
+func (c *Container) configureWebhookService() {
+	if c.webhookSvc != nil {
+		return
+	}
+	if !c.Config.Features.Webhooks {
+		c.webhookSvc = webhooks.NewDisabledService()
+		return
+	}
+	cfg := c.Config.Webhooks
+	opts := []webhooks.ServiceOption{
+		webhooks.WithMaxAttempts(cfg.MaxAttempts),
+		webhooks.WithBackoff(cfg.Backoff, cfg.MaxBackoff),
+		webhooks.WithTimeout(cfg.Timeout),
+	}
+	if c.Config.Features.Scheduling {
+		opts = append(opts, webhooks.WithScheduler(c.scheduler))
+	}
+	c.webhookSvc = webhooks.NewService(c.webhookSubscriptionRepo, c.webhookDeliveryRepo, opts...)
+}
+
+// scheduleTrashPurge enqueues the recurring trash purge job when trash,
+// scheduling and a purge interval are configured.
+func (c *Container) scheduleTrashPurge(ctx context.Context) error {
+	if c.trashPurger == nil || !c.Config.Features.Scheduling || c.scheduler == nil {
+		return nil
+	}
+	interval := c.Config.Trash.PurgeInterval
+	if interval <= 0 || c.trashPurger.Retention() == 0 {
+		return nil
+	}
+	_, err := trash.SchedulePurge(ctx, c.scheduler, time.Now().Add(interval), interval)
+	return err
+}
+
+// versionRetentionPolicy combines a module's version limit with the shared
+// retention settings.
+func (c *Container) versionRetentionPolicy(limit int) retention.Policy {
+	mode, err := retention.ParseMode(strings.ToLower(strings.TrimSpace(c.Config.Retention.Mode)))
+	if err != nil {
+		mode = retention.ModeReject
+	}
+	return retention.Policy{
+		Mode:          mode,
+		KeepLast:      limit,
+		KeepPublished: c.Config.Retention.KeepPublished,
+		KeepWithin:    c.Config.Retention.KeepWithin,
+	}
+}
+
+// scheduleVersionPrune enqueues the recurring version retention job when
+// versioning, scheduling, a pruning mode, and a prune interval are configured.
+func (c *Container) scheduleVersionPrune(ctx context.Context) error {
+	if c.versionPruner == nil || !c.Config.Features.Scheduling || c.scheduler == nil {
+		return nil
+	}
+	interval := c.Config.Retention.PruneInterval
+	if interval <= 0 {
+		return nil
+	}
+	retentionCfg := c.Config.Retention
+	prunes := false
+	for _, limit := range []int{retentionCfg.Content, retentionCfg.Pages, retentionCfg.Blocks, retentionCfg.Widgets} {
+		if c.versionRetentionPolicy(limit).Prunes() {
+			prunes = true
+		}
+	}
+	if !prunes {
+		return nil
+	}
+	_, err := retention.SchedulePrune(ctx, c.scheduler, time.Now().Add(interval), interval)
+	return err
+}
+
+// scheduleAuditPurge enqueues the recurring audit purge job when scheduling,
+// an audit retention, a purge interval, and a recorder that can purge are
+// configured.
+func (c *Container) scheduleAuditPurge(ctx context.Context) error {
+	if c.auditRecorder == nil || !c.Config.Features.Scheduling || c.scheduler == nil {
+		return nil
+	}
+	if _, ok := c.auditRecorder.(jobs.AuditPurger); !ok {
+		return nil
+	}
+	interval := c.Config.Audit.PurgeInterval
+	if interval <= 0 || c.Config.Audit.Retention <= 0 {
+		return nil
+	}
+	_, err := jobs.ScheduleAuditPurge(ctx, c.scheduler, time.Now().Add(interval), interval)
+	return err
+}
+
 func (c *Container) configureMediaService() {
 	if !c.Config.Features.MediaLibrary || c.media == nil {
 		c.mediaSvc = media.NewNoOpService()
 		return
 	}
-	options := []media.ServiceOption{}
+	options := []media.ServiceOption{media.WithLifecycleEmitter(c.lifecycleEmitter)}
 	if c.Config.Features.AdvancedCache && c.cache != nil && c.cacheTTL > 0 {
 		options = append(options, media.WithCache(c.cache, c.cacheTTL))
 	}
@@ -1737,6 +2066,9 @@ func (c *Container) initShortcodeRegistry() interfaces.ShortcodeRegistry {
 	if err := shortcode.RegisterBuiltIns(registry, c.Config.Shortcodes.BuiltIns); err != nil {
 		logger.Error("shortcodes: failed to register built-ins", "error", err)
 	}
+	if err := shortcode.RegisterDataShortcodes(registry, c.shortcodeDataSources(), c.Config.Shortcodes.DataShortcodes); err != nil {
+		logger.Error("shortcodes: failed to register data shortcodes", "error", err)
+	}
 
 	for _, defCfg := range c.Config.Shortcodes.CustomDefinitions {
 		definition, err := convertShortcodeDefinition(defCfg)
@@ -1777,11 +2109,11 @@ func (c *Container) initShortcodeRenderer() interfaces.ShortcodeRenderer {
 	if metrics := c.shortcodeMetrics; metrics != nil {
 		rendererOpts = append(rendererOpts, shortcode.WithRendererMetrics(metrics))
 	}
-	sanitizer := interfaces.ShortcodeSanitizer(shortcode.NewSanitizer())
-	if !c.Config.Shortcodes.Security.SanitizeOutput {
-		sanitizer = shortcode.NoOpSanitizer{}
-	}
-	rendererOpts = append(rendererOpts, shortcode.WithRendererSanitizer(sanitizer))
+	rendererOpts = append(rendererOpts,
+		shortcode.WithRendererSanitizer(c.shortcodeSanitizer()),
+		shortcode.WithRendererRateLimit(c.Config.Shortcodes.Security.RateLimitPerMinute),
+		shortcode.WithRendererDependencies(c.shortcodeDeps),
+	)
 
 	c.shortcodeRenderer = shortcode.NewRenderer(registry, validator, rendererOpts...)
 	return c.shortcodeRenderer
@@ -1811,6 +2143,8 @@ func (c *Container) initShortcodeService() interfaces.ShortcodeService {
 	serviceOpts := []shortcode.ServiceOption{
 		shortcode.WithLogger(logger),
 		shortcode.WithMetrics(metrics),
+		shortcode.WithDefaultSanitizer(c.shortcodeSanitizer()),
+		shortcode.WithCSP(c.Config.Shortcodes.Security.CSPEnabled),
 	}
 	if c.Config.Shortcodes.EnableWordPressSyntax {
 		serviceOpts = append(serviceOpts, shortcode.WithWordPressSyntax(true))
@@ -1823,6 +2157,121 @@ func (c *Container) initShortcodeService() interfaces.ShortcodeService {
 	return c.shortcodeService
 }
 
+// shortcodeDataSources exposes CMS services to data-aware shortcodes. The
+// registry is built before most services, so lookups resolve at render time.
+func (c *Container) shortcodeDataSources() shortcode.DataSources {
+	source := shortcodeDataSource{container: c}
+	sources := shortcode.DataSources{
+		ContentTypes:  source,
+		Content:       source,
+		Entries:       shortcodeContentSource(source),
+		Locales:       source,
+		Menus:         source,
+		DefaultLocale: c.Config.DefaultLocale,
+	}
+	if c.Config.Features.Widgets {
+		sources.Widgets = source
+	}
+	if c.Config.Features.MediaLibrary {
+		sources.Media = source
+	}
+	return sources
+}
+
+// errShortcodeSourceUnavailable reports a data shortcode rendered before its service was configured.
+var errShortcodeSourceUnavailable = errors.New("di: shortcode data source unavailable")
+
+// shortcodeDataSource resolves container services lazily for data-aware shortcodes.
+type shortcodeDataSource struct {
+	container *Container
+}
+
+func (s shortcodeDataSource) GetBySlug(ctx context.Context, slug string, env ...string) (*content.ContentType, error) {
+	if s.container.contentTypeSvc == nil {
+		return nil, fmt.Errorf("%w: content types", errShortcodeSourceUnavailable)
+	}
+	return s.container.contentTypeSvc.GetBySlug(ctx, slug, env...)
+}
+
+func (s shortcodeDataSource) List(ctx context.Context, opts ...content.ContentListOption) ([]*content.Content, error) {
+	if s.container.contentSvc == nil {
+		return nil, fmt.Errorf("%w: content", errShortcodeSourceUnavailable)
+	}
+	return s.container.contentSvc.List(ctx, opts...)
+}
+
+// shortcodeContentSource resolves content entries by slug. It is separate from
+// shortcodeDataSource, whose GetBySlug resolves content types.
+type shortcodeContentSource shortcodeDataSource
+
+func (s shortcodeContentSource) GetBySlug(ctx context.Context, slug string, contentTypeID uuid.UUID, env ...string) (*content.Content, error) {
+	if s.container.contentRepo == nil || s.container.contentSvc == nil {
+		return nil, fmt.Errorf("%w: content", errShortcodeSourceUnavailable)
+	}
+	record, err := s.container.contentRepo.GetBySlug(ctx, slug, contentTypeID, env...)
+	if err != nil {
+		return nil, err
+	}
+	return s.container.contentSvc.Get(ctx, record.ID, content.WithTranslations())
+}
+
+func (s shortcodeDataSource) GetByCode(ctx context.Context, code string) (*content.Locale, error) {
+	if s.container.localeRepo == nil {
+		return nil, fmt.Errorf("%w: locales", errShortcodeSourceUnavailable)
+	}
+	return s.container.localeRepo.GetByCode(ctx, code)
+}
+
+func (s shortcodeDataSource) ResolveArea(ctx context.Context, input widgets.ResolveAreaInput) ([]*widgets.ResolvedWidget, error) {
+	if s.container.widgetSvc == nil {
+		return nil, fmt.Errorf("%w: widgets", errShortcodeSourceUnavailable)
+	}
+	return s.container.widgetSvc.ResolveArea(ctx, input)
+}
+
+func (s shortcodeDataSource) ResolveBindings(ctx context.Context, bindings media.BindingSet, opts media.ResolveOptions) (map[string][]*media.Attachment, error) {
+	if s.container.mediaSvc == nil {
+		return nil, fmt.Errorf("%w: media", errShortcodeSourceUnavailable)
+	}
+	return s.container.mediaSvc.ResolveBindings(ctx, bindings, opts)
+}
+
+func (s shortcodeDataSource) ResolveNavigation(ctx context.Context, location string, locale string, env ...string) ([]shortcode.NavigationItem, error) {
+	if s.container.menuSvc == nil {
+		return nil, fmt.Errorf("%w: menus", errShortcodeSourceUnavailable)
+	}
+	nodes, err := s.container.menuSvc.ResolveNavigationByLocation(ctx, location, locale, env...)
+	if err != nil {
+		return nil, err
+	}
+	return shortcodeNavigationItems(nodes), nil
+}
+
+func shortcodeNavigationItems(nodes []menus.NavigationNode) []shortcode.NavigationItem {
+	items := make([]shortcode.NavigationItem, 0, len(nodes))
+	for _, node := range nodes {
+		label := node.DisplayLabel
+		if strings.TrimSpace(label) == "" {
+			label = node.Label
+		}
+		items = append(items, shortcode.NavigationItem{
+			Label:    label,
+			URL:      node.URL,
+			Children: shortcodeNavigationItems(node.Children),
+		})
+	}
+	return items
+}
+
+// shortcodeSanitizer builds the sanitizer described by the shortcode security config.
+func (c *Container) shortcodeSanitizer() interfaces.ShortcodeSanitizer {
+	security := c.Config.Shortcodes.Security
+	if !security.SanitizeOutput {
+		return shortcode.NoOpSanitizer{}
+	}
+	return shortcode.NewSanitizer(shortcode.WithAllowedDomains(security.AllowedDomains...))
+}
+
 func (c *Container) resolveShortcodeCache() interfaces.CacheProvider {
 	if c.shortcodeCacheResolved {
 		return c.shortcodeCache
@@ -2072,6 +2521,61 @@ func (c *Container) EnvironmentService() environments.Service {
 	return c.environmentSvc
 }
 
+// SiteService returns the configured site service.
+func (c *Container) SiteService() sites.Service {
+	return c.siteSvc
+}
+
+// ExperimentService returns the configured experiment service.
+func (c *Container) ExperimentService() experiments.Service {
+	return c.experimentSvc
+}
+
+// containerVariantSelector defers variant selection to the container's
+// experiment service, which is built after the widget service it serves.
+type containerVariantSelector struct {
+	container *Container
+}
+
+func (s containerVariantSelector) SelectVariant(ctx context.Context, input experiments.SelectVariantInput) (*experiments.Assignment, error) {
+	if s.container == nil || s.container.experimentSvc == nil {
+		return nil, nil
+	}
+	return s.container.experimentSvc.SelectVariant(ctx, input)
+}
+
+// WebhookService returns the configured webhook service.
+func (c *Container) WebhookService() webhooks.Service {
+	return c.webhookSvc
+}
+
+// containerWebhookDispatcher defers dispatch to the container's webhook
+// service, which needs the scheduler and is built after the lifecycle and
+// activity emitters that feed it.
+type containerWebhookDispatcher struct {
+	container *Container
+}
+
+func (d containerWebhookDispatcher) Dispatch(ctx context.Context, event webhooks.Event) ([]*webhooks.Delivery, error) {
+	if d.container == nil || d.container.webhookSvc == nil {
+		return nil, nil
+	}
+	svc := d.container.webhookSvc
+	if d.container.Config.Features.Scheduling {
+		return svc.Dispatch(ctx, event)
+	}
+	// Without a scheduler the service posts each delivery inline, so dispatch
+	// in the background to keep endpoint latency out of the write that
+	// triggered the event.
+	logger := logging.ModuleLogger(d.container.loggerProvider, "cms.webhooks")
+	go func() {
+		if _, err := svc.Dispatch(context.WithoutCancel(ctx), event); err != nil {
+			logger.Warn("webhooks.dispatch_failed", "event", event.Name(), "error", err)
+		}
+	}()
+	return nil, nil
+}
+
 // ContentTypeService returns the configured content type service.
 func (c *Container) ContentTypeService() content.ContentTypeService {
 	return c.contentTypeSvc
@@ -2213,14 +2717,17 @@ func (c *Container) MarkdownService() interfaces.MarkdownService {
 	}
 
 	mdCfg := markdown.Config{
-		BasePath:          c.Config.Markdown.ContentDir,
-		DefaultLocale:     c.Config.Markdown.DefaultLocale,
-		Locales:           append([]string(nil), c.Config.Markdown.Locales...),
-		LocalePatterns:    maps.Clone(c.Config.Markdown.LocalePatterns),
-		Pattern:           c.Config.Markdown.Pattern,
-		Recursive:         c.Config.Markdown.Recursive,
-		Parser:            parseOpts,
-		ProcessShortcodes: c.Config.Markdown.ProcessShortcodes,
+		BasePath:            c.Config.Markdown.ContentDir,
+		DefaultLocale:       c.Config.Markdown.DefaultLocale,
+		Locales:             append([]string(nil), c.Config.Markdown.Locales...),
+		LocalePatterns:      maps.Clone(c.Config.Markdown.LocalePatterns),
+		Pattern:             c.Config.Markdown.Pattern,
+		Recursive:           c.Config.Markdown.Recursive,
+		Parser:              parseOpts,
+		ProcessShortcodes:   c.Config.Markdown.ProcessShortcodes,
+		ContentTypeMappings: markdownFieldMappings(c.Config.Markdown.ContentTypeMappings),
+		AssetLinkMode:       c.Config.Markdown.Assets.LinkMode,
+		AssetBindingKey:     c.Config.Markdown.Assets.BindingKey,
 	}
 
 	options := []markdown.ServiceOption{
@@ -2230,6 +2737,25 @@ func (c *Container) MarkdownService() interfaces.MarkdownService {
 	if contentSvc := c.markdownContentService(); contentSvc != nil {
 		options = append(options, markdown.WithContentService(contentSvc))
 	}
+	if pageSvc := c.markdownPageService(); pageSvc != nil {
+		options = append(options, markdown.WithPageService(pageSvc))
+	}
+	if c.Config.Features.Themes && c.themeSvc != nil {
+		options = append(options, markdown.WithTemplateResolver(markdownTemplateResolver{themes: c.themeSvc}))
+	}
+	if c.contentTypeSvc != nil {
+		options = append(options, markdown.WithContentTypeSchemas(markdownContentTypeSchemas{types: c.contentTypeSvc}))
+	}
+	if c.embeddedBlockBridge != nil {
+		options = append(options, markdown.WithBlockValidator(markdownBlockValidator{resolver: c.embeddedBlockBridge}))
+	}
+	if c.Config.Markdown.Assets.Enabled {
+		if uploader, ok := c.media.(markdown.MediaUploader); ok {
+			options = append(options, markdown.WithMediaUploader(uploader))
+		} else {
+			logging.MarkdownLogger(c.loggerProvider).Warn("markdown: asset import enabled but media provider does not support uploads")
+		}
+	}
 
 	service, err := markdown.NewService(mdCfg, nil, options...)
 	if err != nil {
@@ -2250,10 +2776,33 @@ func (c *Container) markdownContentService() interfaces.ContentService {
 	if svc == nil {
 		return nil
 	}
-	c.markdownContentSvc = newMarkdownContentServiceAdapter(svc)
+	c.markdownContentSvc = newMarkdownContentServiceAdapter(svc, c.contentRepo)
 	return c.markdownContentSvc
 }
 
+func (c *Container) markdownPageService() interfaces.PageService {
+	svc := c.PageService()
+	if svc == nil || c.pageRepo == nil {
+		return nil
+	}
+	return newMarkdownPageServiceAdapter(svc, c.pageRepo, c.localeRepo)
+}
+
+// WXRImporter returns a WordPress export importer wired to the content, page
+// and menu services. progress may be nil when runs do not need to resume.
+func (c *Container) WXRImporter(progress wxr.ProgressStore) *wxr.Importer {
+	cfg := wxr.Config{
+		Content:  c.markdownContentService(),
+		Pages:    c.markdownPageService(),
+		Progress: progress,
+		Logger:   logging.ModuleLogger(c.loggerProvider, "cms.wxr"),
+	}
+	if menuSvc := c.MenuService(); menuSvc != nil {
+		cfg.Menus = menuSvc
+	}
+	return wxr.NewImporter(cfg)
+}
+
 func (c *Container) Scheduler() interfaces.Scheduler {
 	return c.scheduler
 }
@@ -2428,6 +2977,25 @@ func (c *Container) seedLocales(ctx context.Context) {
 	)
 }
 
+// GeneratorRebuilder returns the lifecycle hook rebuilding static output on
+// change events, or nil when Generator.RebuildOnChange is disabled.
+func (c *Container) GeneratorRebuilder() *generator.Rebuilder {
+	if c == nil {
+		return nil
+	}
+	return c.generatorRebuilder
+}
+
+// generatorDependents resolves the generator lazily because lifecycle hooks
+// are registered before the generator service is built.
+type generatorDependents struct {
+	c *Container
+}
+
+func (g generatorDependents) BuildDependents(ctx context.Context, dependencies []string) (*generator.BuildResult, error) {
+	return g.c.GeneratorService().BuildDependents(ctx, dependencies)
+}
+
 // GeneratorService returns the configured static site generator service.
 func (c *Container) GeneratorService() generator.Service {
 	if c == nil {
@@ -2449,11 +3017,53 @@ func (c *Container) AuditRecorder() jobs.AuditRecorder {
 	return c.auditRecorder
 }
 
+// AuditLog returns the configured audit recorder as a queryable audit log.
+func (c *Container) AuditLog() audit.Log {
+	if c.auditRecorder == nil {
+		return nil
+	}
+	return jobs.NewAuditLog(c.auditRecorder)
+}
+
 // JobWorker returns the worker responsible for replay audit commands.
 func (c *Container) JobWorker() *jobs.Worker {
 	return c.jobWorker
 }
 
+// TrashPurger returns the trash purger, or nil when the trash feature is disabled.
+func (c *Container) TrashPurger() *trash.Purger {
+	return c.trashPurger
+}
+
+// VersionPruner returns the version retention pruner, or nil when versioning is disabled.
+func (c *Container) VersionPruner() *retention.Pruner {
+	return c.versionPruner
+}
+
+func generatorCollections(configs []runtimeconfig.GeneratorCollectionConfig) []generator.CollectionConfig {
+	if len(configs) == 0 {
+		return nil
+	}
+	out := make([]generator.CollectionConfig, 0, len(configs))
+	for _, cfg := range configs {
+		out = append(out, generator.CollectionConfig{
+			Name:        cfg.Name,
+			ContentType: cfg.ContentType,
+			Template:    cfg.Template,
+			Route:       cfg.Route,
+			Routes:      maps.Clone(cfg.Routes),
+			ItemRoute:   cfg.ItemRoute,
+			PageSize:    cfg.PageSize,
+			SortBy:      cfg.SortBy,
+			SortOrder:   cfg.SortOrder,
+			Filters:     maps.Clone(cfg.Filters),
+			GroupBy:     cfg.GroupBy,
+			Feed:        cfg.Feed,
+		})
+	}
+	return out
+}
+
 func applyConfiguredWidgetDefinitions(registry *widgets.Registry, definitions []runtimeconfig.WidgetDefinitionConfig) {
 	if registry == nil || len(definitions) == 0 {
 		return
@@ -3,7 +3,12 @@ package di_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,11 +18,16 @@ import (
 	"github.com/goliatone/go-cms/internal/di"
 	"github.com/goliatone/go-cms/internal/domain"
 	"github.com/goliatone/go-cms/internal/generator"
+	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/internal/markdown"
 	"github.com/goliatone/go-cms/internal/media"
 	"github.com/goliatone/go-cms/internal/menus"
 	"github.com/goliatone/go-cms/internal/pages"
+	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
 	"github.com/goliatone/go-cms/internal/themes"
+	"github.com/goliatone/go-cms/internal/webhooks"
 	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/goliatone/go-cms/internal/wxr"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
@@ -367,6 +377,321 @@ func TestContainerWidgetConfigRespectsFeatureFlag(t *testing.T) {
 	}
 }
 
+func TestContainerSchedulesAuditPurgeWhenRetentionConfigured(t *testing.T) {
+	ctx := context.Background()
+	cfg := cms.DefaultConfig()
+	cfg.Features.Versioning = true
+	cfg.Features.Scheduling = true
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	if _, err := container.Scheduler().GetByKey(ctx, cmsscheduler.AuditPurgeJobKey); err == nil {
+		t.Fatalf("expected no audit purge job without a retention")
+	}
+
+	cfg.Audit.Retention = 90 * 24 * time.Hour
+	container, err = di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	job, err := container.Scheduler().GetByKey(ctx, cmsscheduler.AuditPurgeJobKey)
+	if err != nil {
+		t.Fatalf("expected audit purge job to be scheduled: %v", err)
+	}
+	if job.Type != cmsscheduler.JobTypeAuditPurge {
+		t.Fatalf("unexpected purge job type %s", job.Type)
+	}
+
+	recorder := container.AuditRecorder()
+	if err := recorder.Record(ctx, jobs.AuditEvent{EntityType: "content", EntityID: "c-1", Action: "publish", ActorID: "u-1"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	page, err := container.AuditLog().Query(ctx, jobs.AuditQuery{ActorID: "u-1"})
+	if err != nil || len(page.Events) != 1 {
+		t.Fatalf("expected recorded event to be queryable, got %+v %v", page, err)
+	}
+}
+
+func TestContainerTrashEnablesSoftDeleteAndSchedulesPurge(t *testing.T) {
+	cfg := cms.DefaultConfig()
+	cfg.Features.Versioning = true
+	cfg.Features.Scheduling = true
+	cfg.Features.Trash = true
+	cfg.Trash.Retention = 30 * 24 * time.Hour
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	if container.TrashPurger() == nil {
+		t.Fatalf("expected trash purger to be configured")
+	}
+
+	ctx := context.Background()
+	job, err := container.Scheduler().GetByKey(ctx, cmsscheduler.TrashPurgeJobKey)
+	if err != nil {
+		t.Fatalf("expected purge job to be scheduled: %v", err)
+	}
+	if job.Type != cmsscheduler.JobTypeTrashPurge {
+		t.Fatalf("unexpected purge job type %s", job.Type)
+	}
+
+	seeder, ok := container.ContentTypeRepository().(interface {
+		Put(*content.ContentType) error
+	})
+	if !ok {
+		t.Fatalf("content type repository is not seedable")
+	}
+	ctID := uuid.New()
+	if err := seeder.Put(&content.ContentType{ID: ctID, Name: "article", Slug: "article"}); err != nil {
+		t.Fatalf("seed content type: %v", err)
+	}
+
+	contentSvc := container.ContentService()
+	created, err := contentSvc.Create(ctx, content.CreateContentRequest{
+		ContentTypeID: ctID,
+		Slug:          "trash-me",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Trash me"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+	if err := contentSvc.Delete(ctx, content.DeleteContentRequest{ID: created.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	if _, err := contentSvc.RestoreDeleted(ctx, content.RestoreContentRequest{ID: created.ID}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+}
+
+func TestContainerWebhooksDeliverLifecycleEvents(t *testing.T) {
+	events := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events <- r.Header.Get(webhooks.HeaderEvent)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := cms.DefaultConfig()
+	cfg.Features.Versioning = true
+	cfg.Features.Scheduling = true
+	cfg.Features.Webhooks = true
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	ctx := context.Background()
+	webhookSvc := container.WebhookService()
+	if _, err := webhookSvc.CreateSubscription(ctx, webhooks.CreateSubscriptionInput{
+		URL:           server.URL,
+		ResourceTypes: []string{"content"},
+		Transitions:   []string{"create"},
+	}); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+
+	seeder, ok := container.ContentTypeRepository().(interface {
+		Put(*content.ContentType) error
+	})
+	if !ok {
+		t.Fatalf("content type repository is not seedable")
+	}
+	ctID := uuid.New()
+	if err := seeder.Put(&content.ContentType{ID: ctID, Name: "article", Slug: "article"}); err != nil {
+		t.Fatalf("seed content type: %v", err)
+	}
+	if _, err := container.ContentService().Create(ctx, content.CreateContentRequest{
+		ContentTypeID: ctID,
+		Slug:          "hooked",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Hooked"}},
+	}); err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	pending, err := webhookSvc.ListDeliveries(ctx, webhooks.DeliveryFilter{Status: webhooks.DeliveryPending})
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending delivery, got %d", len(pending))
+	}
+	if err := container.JobWorker().Process(ctx); err != nil {
+		t.Fatalf("process jobs: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event != "content.create" {
+			t.Fatalf("expected content.create, got %q", event)
+		}
+	default:
+		t.Fatalf("expected the receiver to get a delivery")
+	}
+	delivered, err := webhookSvc.GetDelivery(ctx, pending[0].ID)
+	if err != nil {
+		t.Fatalf("get delivery: %v", err)
+	}
+	if delivered.Status != webhooks.DeliverySucceeded || delivered.ResponseCode != http.StatusNoContent {
+		t.Fatalf("unexpected delivery %+v", delivered)
+	}
+}
+
+func TestContainerWebhooksDeliverOnceInBackgroundWithoutScheduler(t *testing.T) {
+	release := make(chan struct{})
+	releaseOnce := sync.OnceFunc(func() { close(release) })
+	events := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		events <- r.Header.Get(webhooks.HeaderEvent)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(releaseOnce)
+
+	cfg := cms.DefaultConfig()
+	cfg.Features.Webhooks = true
+	cfg.Features.Activity = true
+	cfg.Activity.Enabled = true
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := container.WebhookService().CreateSubscription(ctx, webhooks.CreateSubscriptionInput{
+		URL:           server.URL,
+		ResourceTypes: []string{"content"},
+		Transitions:   []string{"create"},
+	}); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+
+	seeder, ok := container.ContentTypeRepository().(interface {
+		Put(*content.ContentType) error
+	})
+	if !ok {
+		t.Fatalf("content type repository is not seedable")
+	}
+	ctID := uuid.New()
+	if err := seeder.Put(&content.ContentType{ID: ctID, Name: "article", Slug: "article"}); err != nil {
+		t.Fatalf("seed content type: %v", err)
+	}
+	// The endpoint blocks until release is closed, so Create only returns
+	// while it is held if delivery happens off the write path.
+	created := make(chan error, 1)
+	go func() {
+		_, err := container.ContentService().Create(ctx, content.CreateContentRequest{
+			ContentTypeID: ctID,
+			Slug:          "hooked",
+			CreatedBy:     uuid.New(),
+			UpdatedBy:     uuid.New(),
+			Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Hooked"}},
+		})
+		created <- err
+	}()
+	select {
+	case err := <-created:
+		if err != nil {
+			t.Fatalf("create content: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected create to return while the webhook endpoint is blocked")
+	}
+	releaseOnce()
+
+	select {
+	case event := <-events:
+		if event != "content.create" {
+			t.Fatalf("expected content.create, got %q", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the receiver to get a delivery")
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("expected a single delivery, got a second %q", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestContainerScheduledRetentionPrunesVersions(t *testing.T) {
+	cfg := cms.DefaultConfig()
+	cfg.Features.Versioning = true
+	cfg.Features.Scheduling = true
+	cfg.Retention.Content = 1
+	cfg.Retention.Mode = "scheduled"
+	cfg.Retention.PruneInterval = time.Hour
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	if container.VersionPruner() == nil {
+		t.Fatalf("expected version pruner to be configured")
+	}
+
+	ctx := context.Background()
+	job, err := container.Scheduler().GetByKey(ctx, cmsscheduler.VersionPruneJobKey)
+	if err != nil {
+		t.Fatalf("expected prune job to be scheduled: %v", err)
+	}
+	if job.Type != cmsscheduler.JobTypeVersionPrune {
+		t.Fatalf("unexpected prune job type %s", job.Type)
+	}
+
+	seeder, ok := container.ContentTypeRepository().(interface {
+		Put(*content.ContentType) error
+	})
+	if !ok {
+		t.Fatalf("content type repository is not seedable")
+	}
+	ctID := uuid.New()
+	if err := seeder.Put(&content.ContentType{ID: ctID, Name: "article", Slug: "article"}); err != nil {
+		t.Fatalf("seed content type: %v", err)
+	}
+
+	contentSvc := container.ContentService()
+	created, err := contentSvc.Create(ctx, content.CreateContentRequest{
+		ContentTypeID: ctID,
+		Slug:          "retention-scheduled",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Scheduled"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+	snapshot := content.ContentVersionSnapshot{
+		Translations: []content.ContentVersionTranslationSnapshot{{Locale: "en", Title: "Draft", Content: map[string]any{"body": "draft"}}},
+	}
+	for range 3 {
+		if _, err := contentSvc.CreateDraft(ctx, content.CreateContentDraftRequest{ContentID: created.ID, Snapshot: snapshot}); err != nil {
+			t.Fatalf("create draft: %v", err)
+		}
+	}
+
+	report, err := container.VersionPruner().Prune(ctx)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if report.Total() != 2 {
+		t.Fatalf("expected 2 pruned versions, got %+v", report)
+	}
+	versions, err := contentSvc.ListVersions(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 1 || versions[0].Version != 3 {
+		t.Fatalf("expected only the current version to remain, got %+v", versions)
+	}
+}
+
 func TestContainerContentRetentionLimitTriggersWarning(t *testing.T) {
 	cfg := cms.DefaultConfig()
 	cfg.Features.Versioning = true
@@ -842,6 +1167,33 @@ func TestContainerGeneratorServiceEnabled(t *testing.T) {
 	}
 }
 
+func TestContainerGeneratorRebuilderRequiresOptIn(t *testing.T) {
+	cfg := cms.DefaultConfig()
+	cfg.Features.Themes = true
+	cfg.Generator.Enabled = true
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	if container.GeneratorRebuilder() != nil {
+		t.Fatal("expected no rebuilder without RebuildOnChange")
+	}
+
+	cfg.Generator.RebuildOnChange = true
+	container, err = di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	rebuilder := container.GeneratorRebuilder()
+	if rebuilder == nil {
+		t.Fatal("expected rebuilder when RebuildOnChange is set")
+	}
+	if err := rebuilder.Close(context.Background()); err != nil {
+		t.Fatalf("close rebuilder: %v", err)
+	}
+}
+
 type recordingMediaProvider struct {
 	resolveCalls int
 }
@@ -1107,6 +1459,14 @@ func (fakeMarkdownService) Sync(context.Context, string, interfaces.SyncOptions)
 	return &interfaces.SyncResult{}, nil
 }
 
+func (fakeMarkdownService) Export(context.Context, string, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
+func (fakeMarkdownService) ExportDirectory(context.Context, string, interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	return nil, nil
+}
+
 func TestContainerShortcodeCacheProviderSelection(t *testing.T) {
 	cfg := cms.DefaultConfig()
 	cfg.Features.Shortcodes = true
@@ -1234,3 +1594,301 @@ func (m *shortcodeTestMetrics) IncrementRenderError(string) {}
 func (m *shortcodeTestMetrics) IncrementCacheHit(shortcode string) {
 	m.cacheHits[shortcode]++
 }
+
+func TestContainerRegistersDataShortcodes(t *testing.T) {
+	cfg := cms.DefaultConfig()
+	cfg.Features.Shortcodes = true
+	cfg.Shortcodes.Enabled = true
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	registry := container.ShortcodeRegistry()
+	for _, name := range []string{"content", "content_list", "menu"} {
+		if _, ok := registry.Get(name); !ok {
+			t.Fatalf("expected data shortcode %q to be registered", name)
+		}
+	}
+	if _, ok := registry.Get("media"); ok && !cfg.Features.MediaLibrary {
+		t.Fatalf("expected media shortcode skipped without media library")
+	}
+}
+
+func TestContainerMarkdownImportCreatesPages(t *testing.T) {
+	dir := t.TempDir()
+	writeMarkdownFile(t, dir, "blog/_index.md", "---\ntitle: Blog\nslug: blog\ntemplate: landing\n---\n\nPosts\n")
+	writeMarkdownFile(t, dir, "blog/hello.md", "---\ntitle: Hello\nslug: hello\n---\n\nHi\n")
+
+	cfg := cms.DefaultConfig()
+	cfg.Features.Markdown = true
+	cfg.Features.Themes = true
+	cfg.Markdown.ContentDir = dir
+	cfg.Markdown.DefaultLocale = "en"
+	cfg.Markdown.Locales = []string{"en"}
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	ctx := context.Background()
+
+	themeSvc := container.ThemeService()
+	theme, err := themeSvc.RegisterTheme(ctx, themes.RegisterThemeInput{Name: "aurora", Version: "1.0.0", ThemePath: "themes/aurora"})
+	if err != nil {
+		t.Fatalf("register theme: %v", err)
+	}
+	landing, err := themeSvc.RegisterTemplate(ctx, themes.RegisterTemplateInput{
+		ThemeID:      theme.ID,
+		Name:         "Landing",
+		Slug:         "landing",
+		TemplatePath: "templates/landing.html",
+		Regions:      map[string]themes.TemplateRegion{"main": {Name: "Main", AcceptsBlocks: true}},
+	})
+	if err != nil {
+		t.Fatalf("register template: %v", err)
+	}
+	if _, err := themeSvc.ActivateTheme(ctx, theme.ID); err != nil {
+		t.Fatalf("activate theme: %v", err)
+	}
+
+	contentType, err := container.ContentTypeService().Create(ctx, content.CreateContentTypeRequest{
+		Name:   "Page",
+		Slug:   "page",
+		Schema: map[string]any{"type": "object", "properties": map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+
+	result, err := container.MarkdownService().ImportDirectory(ctx, ".", interfaces.ImportOptions{
+		ContentTypeID: contentType.ID,
+		AuthorID:      uuid.New(),
+		CreatePages:   true,
+		TemplateID:    landing.ID,
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(result.CreatedPageIDs) != 2 {
+		t.Fatalf("expected two pages, got %#v", result)
+	}
+
+	records, err := container.PageService().List(ctx)
+	if err != nil {
+		t.Fatalf("list pages: %v", err)
+	}
+	bySlug := map[string]*pages.Page{}
+	for _, record := range records {
+		bySlug[record.Slug] = record
+	}
+	section, post := bySlug["blog"], bySlug["hello"]
+	if section == nil || post == nil {
+		t.Fatalf("expected blog and hello pages, got %v", bySlug)
+	}
+	if section.TemplateID != landing.ID {
+		t.Fatalf("expected landing template resolved from frontmatter")
+	}
+	if post.ParentID == nil || *post.ParentID != section.ID {
+		t.Fatalf("expected hello nested under blog section")
+	}
+
+	reimport, err := container.MarkdownService().ImportDirectory(ctx, ".", interfaces.ImportOptions{
+		ContentTypeID: contentType.ID,
+		AuthorID:      uuid.New(),
+		CreatePages:   true,
+		TemplateID:    landing.ID,
+	})
+	if err != nil {
+		t.Fatalf("reimport: %v", err)
+	}
+	if len(reimport.CreatedPageIDs) != 0 || len(reimport.Errors) != 0 {
+		t.Fatalf("expected existing pages resolved by slug on reimport, got %#v", reimport)
+	}
+}
+
+func TestContainerMarkdownImportMapsTypedFields(t *testing.T) {
+	dir := t.TempDir()
+	writeMarkdownFile(t, dir, "widget.md", "---\ntitle: Widget\nslug: widget\nprice: \"12.50\"\n---\n\nGreat widget.\n")
+	writeMarkdownFile(t, dir, "gadget.md", "---\ntitle: Gadget\nslug: gadget\nprice: free\n---\n\nFree gadget.\n")
+
+	cfg := cms.DefaultConfig()
+	cfg.Features.Markdown = true
+	cfg.Markdown.ContentDir = dir
+	cfg.Markdown.DefaultLocale = "en"
+	cfg.Markdown.Locales = []string{"en"}
+	cfg.Markdown.ContentTypeMappings = map[string]cms.MarkdownFieldMapping{
+		"product": {Fields: map[string]string{"price": "price"}, BodyField: "description"},
+	}
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	ctx := context.Background()
+
+	contentType, err := container.ContentTypeService().Create(ctx, content.CreateContentTypeRequest{
+		Name: "Product",
+		Slug: "product",
+		Schema: map[string]any{"fields": []any{
+			map[string]any{"name": "price", "type": "number", "required": true},
+			map[string]any{"name": "description", "type": "string"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+
+	_, err = container.MarkdownService().ImportDirectory(ctx, ".", interfaces.ImportOptions{ContentTypeID: contentType.ID, AuthorID: uuid.New()})
+	if err == nil || !strings.Contains(err.Error(), "gadget.md:4: price: expected number") {
+		t.Fatalf("expected gadget price error with line, got %v", err)
+	}
+
+	records, err := container.ContentService().List(ctx, content.WithTranslations())
+	if err != nil {
+		t.Fatalf("list content: %v", err)
+	}
+	if len(records) != 1 || records[0].Slug != "widget" {
+		t.Fatalf("expected only widget imported, got %d records", len(records))
+	}
+	fields := records[0].Translations[0].Content
+	if fields["price"] != 12.5 || !strings.Contains(fields["description"].(string), "Great widget.") {
+		t.Fatalf("expected typed widget fields, got %#v", fields)
+	}
+}
+
+func TestContainerMarkdownImportUploadsLocalImages(t *testing.T) {
+	dir := t.TempDir()
+	writeMarkdownFile(t, dir, "guide.md", "---\ntitle: Guide\nslug: guide\n---\n\n![Diagram](img/diagram.png)\n")
+	writeMarkdownFile(t, dir, "img/diagram.png", "png-bytes")
+
+	cfg := cms.DefaultConfig()
+	cfg.Features.Markdown = true
+	cfg.Markdown.ContentDir = dir
+	cfg.Markdown.DefaultLocale = "en"
+	cfg.Markdown.Locales = []string{"en"}
+	cfg.Markdown.Assets = cms.MarkdownAssetConfig{Enabled: true, LinkMode: markdown.AssetLinkReference}
+
+	provider := &uploadingMediaProvider{}
+	container, err := di.NewContainer(cfg, di.WithMedia(provider))
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	ctx := context.Background()
+
+	contentType, err := container.ContentTypeService().Create(ctx, content.CreateContentTypeRequest{
+		Name:   "Guide",
+		Slug:   "guide",
+		Schema: map[string]any{"type": "object"},
+	})
+	if err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+	if _, err := container.MarkdownService().ImportDirectory(ctx, ".", interfaces.ImportOptions{ContentTypeID: contentType.ID, AuthorID: uuid.New()}); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if provider.uploads != 1 {
+		t.Fatalf("expected one upload, got %d", provider.uploads)
+	}
+
+	records, err := container.ContentService().List(ctx, content.WithTranslations())
+	if err != nil || len(records) != 1 {
+		t.Fatalf("list content: %v (%d records)", err, len(records))
+	}
+	envelope, _ := records[0].Translations[0].Content["markdown"].(map[string]any)
+	if body, _ := envelope["body"].(string); !strings.Contains(body, "(media:img/diagram.png)") {
+		t.Fatalf("expected image link rewritten to a media reference, got %#v", envelope["body"])
+	}
+	if _, ok := records[0].Translations[0].Content["media_bindings"]; !ok {
+		t.Fatalf("expected media bindings stored on the translation")
+	}
+}
+
+type uploadingMediaProvider struct {
+	uploads int
+}
+
+func (*uploadingMediaProvider) Resolve(context.Context, interfaces.MediaResolveRequest) (*interfaces.MediaAsset, error) {
+	return nil, nil
+}
+
+func (*uploadingMediaProvider) ResolveBatch(context.Context, []interfaces.MediaResolveRequest) (map[string]*interfaces.MediaAsset, error) {
+	return nil, nil
+}
+
+func (*uploadingMediaProvider) Invalidate(context.Context, ...interfaces.MediaReference) error {
+	return nil
+}
+
+func (*uploadingMediaProvider) FindByChecksum(context.Context, string) (*interfaces.MediaAsset, error) {
+	return nil, nil
+}
+
+func (p *uploadingMediaProvider) Upload(_ context.Context, upload markdown.MediaUpload) (*interfaces.MediaAsset, error) {
+	p.uploads++
+	return &interfaces.MediaAsset{Reference: interfaces.MediaReference{ID: upload.Path}}, nil
+}
+
+func writeMarkdownFile(t *testing.T, root, rel, source string) {
+	t.Helper()
+	target := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(target, []byte(source), 0o644); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func TestContainerWXRImportCreatesContentPagesAndMenus(t *testing.T) {
+	file, err := os.Open(filepath.Join("..", "wxr", "testdata", "export.xml"))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+	export, err := wxr.Parse(file)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	container, err := di.NewContainer(cms.DefaultConfig())
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	ctx := context.Background()
+	contentType, err := container.ContentTypeService().Create(ctx, content.CreateContentTypeRequest{
+		Name:   "Article",
+		Slug:   "article",
+		Schema: map[string]any{"type": "object"},
+	})
+	if err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+
+	report, err := container.WXRImporter(nil).Import(ctx, export, wxr.Options{
+		ContentTypes:  map[string]uuid.UUID{"post": contentType.ID, "page": contentType.ID},
+		TemplateID:    uuid.New(),
+		Locale:        "en",
+		DefaultAuthor: uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("import: %v (%+v)", err, report.Items)
+	}
+
+	pageList, err := container.PageService().List(ctx)
+	if err != nil {
+		t.Fatalf("list pages: %v", err)
+	}
+	parents := map[string]*uuid.UUID{}
+	for _, page := range pageList {
+		parents[page.Slug] = page.ParentID
+	}
+	if len(pageList) != 2 || parents["about"] != nil || parents["team"] == nil {
+		t.Fatalf("expected team nested under about, got %v", parents)
+	}
+	nav, err := container.MenuService().ResolveNavigation(ctx, "main-menu", "en")
+	if err != nil || len(nav) != 1 || len(nav[0].Children) != 1 {
+		t.Fatalf("expected imported menu tree: %v %+v", err, nav)
+	}
+}
@@ -7,21 +7,29 @@ import (
 	"strings"
 
 	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/markdown"
+	"github.com/goliatone/go-cms/internal/runtimeconfig"
+	cmsschema "github.com/goliatone/go-cms/internal/schema"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
 )
 
 type markdownContentServiceAdapter struct {
 	service content.Service
+	repo    content.ContentRepository
 }
 
-func newMarkdownContentServiceAdapter(service content.Service) interfaces.ContentService {
+// newMarkdownContentServiceAdapter adapts the content service for markdown
+// import and export. repo, when set, backs in-place translation field writes.
+func newMarkdownContentServiceAdapter(service content.Service, repo content.ContentRepository) interfaces.ContentService {
 	if service == nil {
 		return nil
 	}
-	return &markdownContentServiceAdapter{service: service}
+	return &markdownContentServiceAdapter{service: service, repo: repo}
 }
 
+var _ interfaces.ContentTranslationFieldsUpdater = (*markdownContentServiceAdapter)(nil)
+
 func (a *markdownContentServiceAdapter) Create(ctx context.Context, req interfaces.ContentCreateRequest) (*interfaces.ContentRecord, error) {
 	if a == nil || a.service == nil {
 		return nil, errors.New("content service unavailable")
@@ -100,7 +108,10 @@ func (a *markdownContentServiceAdapter) GetBySlug(ctx context.Context, slug stri
 		return nil, err
 	}
 	for _, record := range records {
-		if record != nil && strings.EqualFold(record.Slug, slug) {
+		if record == nil || (opts.ContentTypeID != uuid.Nil && record.ContentTypeID != opts.ContentTypeID) {
+			continue
+		}
+		if strings.EqualFold(record.Slug, slug) {
 			var availableLocales []string
 			if opts.IncludeAvailableLocales && len(record.Translations) == 0 {
 				locales, err := a.service.AvailableLocales(ctx, record.ID, interfaces.TranslationCheckOptions{
@@ -225,6 +236,42 @@ func (a *markdownContentServiceAdapter) UpdateTranslation(ctx context.Context, r
 	return toInterfacesContentTranslation(translation), nil
 }
 
+// UpdateTranslationFields writes the translation fields straight to the
+// repository, so bookkeeping values do not version the entry or emit events.
+func (a *markdownContentServiceAdapter) UpdateTranslationFields(ctx context.Context, req interfaces.ContentUpdateTranslationFieldsRequest) error {
+	if a == nil || a.repo == nil {
+		return errors.ErrUnsupported
+	}
+	writer, ok := a.repo.(content.ContentTranslationFieldsWriter)
+	reader, readable := a.repo.(content.ContentTranslationReader)
+	if !ok || !readable {
+		return errors.ErrUnsupported
+	}
+	translations, err := reader.ListTranslations(ctx, req.ContentID)
+	if err != nil {
+		if errors.Is(err, content.ErrContentTranslationLookupUnsupported) {
+			return errors.ErrUnsupported
+		}
+		return err
+	}
+	for _, tr := range translations {
+		if tr == nil || tr.Locale == nil || !strings.EqualFold(tr.Locale.Code, req.Locale) {
+			continue
+		}
+		fields := cloneFieldMap(req.Fields)
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		if version, ok := tr.Content[cmsschema.RootSchemaKey]; ok {
+			if _, set := fields[cmsschema.RootSchemaKey]; !set {
+				fields[cmsschema.RootSchemaKey] = version
+			}
+		}
+		return writer.UpdateTranslationFields(ctx, tr.ID, fields)
+	}
+	return &content.NotFoundError{Resource: "content_translation", Key: req.Locale}
+}
+
 func (a *markdownContentServiceAdapter) DeleteTranslation(ctx context.Context, req interfaces.ContentDeleteTranslationRequest) error {
 	if a == nil || a.service == nil {
 		return errors.New("content service unavailable")
@@ -402,3 +449,52 @@ func collectLocalesFromContentTranslations(translations []interfaces.ContentTran
 	}
 	return locales
 }
+
+// markdownContentTypeSchemas exposes content type slugs and schemas to the
+// markdown field mapper.
+type markdownContentTypeSchemas struct {
+	types content.ContentTypeService
+}
+
+func (s markdownContentTypeSchemas) ContentTypeSchema(ctx context.Context, id uuid.UUID) (string, map[string]any, error) {
+	record, err := s.types.Get(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	return record.Slug, record.Schema, nil
+}
+
+type markdownEmbeddedBlockValidator interface {
+	ValidateEmbeddedBlocks(ctx context.Context, locale string, blocks []map[string]any, mode content.EmbeddedBlockValidationMode) error
+}
+
+// markdownBlockValidator validates blocks split from markdown bodies with the
+// same strict rules the content service applies on save.
+type markdownBlockValidator struct {
+	resolver markdownEmbeddedBlockValidator
+}
+
+func (v markdownBlockValidator) ValidateBlocks(ctx context.Context, locale string, blocks []map[string]any) error {
+	return v.resolver.ValidateEmbeddedBlocks(ctx, locale, blocks, content.EmbeddedBlockValidationStrict)
+}
+
+func markdownFieldMappings(cfg map[string]runtimeconfig.MarkdownFieldMapping) map[string]markdown.FieldMapping {
+	if len(cfg) == 0 {
+		return nil
+	}
+	out := make(map[string]markdown.FieldMapping, len(cfg))
+	for key, mapping := range cfg {
+		out[key] = markdown.FieldMapping{
+			Fields:    maps.Clone(mapping.Fields),
+			BodyField: mapping.BodyField,
+			Blocks: markdown.BlockSplit{
+				Mode:       mapping.Blocks.Mode,
+				Level:      mapping.Blocks.Level,
+				Type:       mapping.Blocks.Type,
+				TitleField: mapping.Blocks.TitleField,
+				BodyField:  mapping.Blocks.BodyField,
+			},
+		}
+	}
+	return out
+}
@@ -201,7 +201,7 @@ func newMarkdownContentFixture(t *testing.T, translations []content.ContentTrans
 
 	adapter := newMarkdownContentServiceAdapter(&stubContentService{
 		records: []*content.Content{record},
-	})
+	}, nil)
 	return markdownContentFixture{
 		ctx:  ctx,
 		svc:  adapter,
@@ -241,6 +241,14 @@ func (s *stubContentService) Delete(context.Context, content.DeleteContentReques
 	return errors.New("stub content service")
 }
 
+func (s *stubContentService) ListTrashed(context.Context, content.ListTrashedContentRequest) ([]*content.Content, error) {
+	return nil, errors.New("stub content service")
+}
+
+func (s *stubContentService) RestoreDeleted(context.Context, content.RestoreContentRequest) (*content.Content, error) {
+	return nil, errors.New("stub content service")
+}
+
 func (s *stubContentService) UpdateTranslation(context.Context, content.UpdateContentTranslationRequest) (*content.ContentTranslation, error) {
 	return nil, errors.New("stub content service")
 }
@@ -272,3 +280,59 @@ func (s *stubContentService) ListVersions(context.Context, uuid.UUID) ([]*conten
 func (s *stubContentService) RestoreVersion(context.Context, content.RestoreContentVersionRequest) (*content.ContentVersion, error) {
 	return nil, errors.New("stub content service")
 }
+
+func TestMarkdownContentAdapterUpdateTranslationFieldsSkipsVersioning(t *testing.T) {
+	ctx := context.Background()
+	repo := content.NewMemoryContentRepository()
+	contentID := uuid.New()
+	translationID := uuid.New()
+	if _, err := repo.Create(ctx, &content.Content{
+		ID:     contentID,
+		Slug:   "faq",
+		Status: string(domain.StatusPublished),
+		Translations: []*content.ContentTranslation{{
+			ID:        translationID,
+			ContentID: contentID,
+			Locale:    &content.Locale{ID: uuid.New(), Code: "en"},
+			Title:     "FAQ",
+			Content:   map[string]any{"body": "Answers.", "_schema": "article@v1"},
+		}},
+	}); err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	adapter, ok := newMarkdownContentServiceAdapter(&stubContentService{}, repo).(interfaces.ContentTranslationFieldsUpdater)
+	if !ok {
+		t.Fatalf("expected adapter to implement ContentTranslationFieldsUpdater")
+	}
+	err := adapter.UpdateTranslationFields(ctx, interfaces.ContentUpdateTranslationFieldsRequest{
+		ContentID: contentID,
+		Locale:    "EN",
+		Fields:    map[string]any{"body": "Answers.", "markdown": map[string]any{"checksum": "abc"}},
+	})
+	if err != nil {
+		t.Fatalf("update translation fields: %v", err)
+	}
+
+	translations, err := repo.ListTranslations(ctx, contentID)
+	if err != nil {
+		t.Fatalf("list translations: %v", err)
+	}
+	fields := translations[0].Content
+	if fields["markdown"] == nil || fields["_schema"] != "article@v1" || translations[0].Title != "FAQ" {
+		t.Fatalf("expected checksum stored with schema preserved, got %#v", translations[0])
+	}
+	versions, err := repo.ListVersions(ctx, contentID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 0 {
+		t.Fatalf("expected no versions, got %d", len(versions))
+	}
+
+	err = adapter.UpdateTranslationFields(ctx, interfaces.ContentUpdateTranslationFieldsRequest{ContentID: contentID, Locale: "fr"})
+	var notFound *content.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected not found for missing locale, got %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/themes"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/google/uuid"
+)
+
+type markdownLocaleLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*content.Locale, error)
+}
+
+// markdownPageSlugLookup resolves a single page by slug so sync does not list
+// every page of the environment per document.
+type markdownPageSlugLookup interface {
+	GetBySlug(ctx context.Context, slug string, env ...string) (*pages.Page, error)
+}
+
+type markdownPageServiceAdapter struct {
+	service pages.Service
+	slugs   markdownPageSlugLookup
+	locales markdownLocaleLookup
+}
+
+func newMarkdownPageServiceAdapter(service pages.Service, slugs markdownPageSlugLookup, locales markdownLocaleLookup) interfaces.PageService {
+	if service == nil {
+		return nil
+	}
+	return &markdownPageServiceAdapter{service: service, slugs: slugs, locales: locales}
+}
+
+func (a *markdownPageServiceAdapter) Create(ctx context.Context, req interfaces.PageCreateRequest) (*interfaces.PageRecord, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	record, err := a.service.Create(ctx, pages.CreatePageRequest{
+		ContentID:                req.ContentID,
+		TemplateID:               req.TemplateID,
+		ParentID:                 req.ParentID,
+		Slug:                     req.Slug,
+		Status:                   req.Status,
+		CreatedBy:                req.CreatedBy,
+		UpdatedBy:                req.UpdatedBy,
+		Translations:             toPageTranslationInputs(req.Translations),
+		AllowMissingTranslations: req.AllowMissingTranslations,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.toPageRecord(ctx, record, interfaces.PageReadOptions{}), nil
+}
+
+func (a *markdownPageServiceAdapter) Update(ctx context.Context, req interfaces.PageUpdateRequest) (*interfaces.PageRecord, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	record, err := a.service.Update(ctx, pages.UpdatePageRequest{
+		ID:                       req.ID,
+		TemplateID:               req.TemplateID,
+		Status:                   req.Status,
+		UpdatedBy:                req.UpdatedBy,
+		Translations:             toPageTranslationInputs(req.Translations),
+		AllowMissingTranslations: req.AllowMissingTranslations,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.toPageRecord(ctx, record, interfaces.PageReadOptions{}), nil
+}
+
+func (a *markdownPageServiceAdapter) GetBySlug(ctx context.Context, slug string, opts interfaces.PageReadOptions) (*interfaces.PageRecord, error) {
+	if a == nil || a.service == nil || a.slugs == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	var lookupEnv []string
+	if envKey := strings.TrimSpace(opts.EnvironmentKey); envKey != "" {
+		lookupEnv = append(lookupEnv, envKey)
+	}
+	found, err := a.slugs.GetBySlug(ctx, slug, lookupEnv...)
+	if err != nil {
+		var notFound *pages.PageNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	record, err := a.service.Get(ctx, found.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := a.toPageRecord(ctx, record, opts)
+	if !opts.AllowMissingTranslations && result.Translation.Meta.MissingRequestedLocale {
+		return nil, interfaces.ErrTranslationMissing
+	}
+	return result, nil
+}
+
+func (a *markdownPageServiceAdapter) List(ctx context.Context, opts interfaces.PageReadOptions) ([]*interfaces.PageRecord, error) {
+	records, err := a.list(ctx, opts.EnvironmentKey)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*interfaces.PageRecord, 0, len(records))
+	for _, record := range records {
+		if record != nil {
+			out = append(out, a.toPageRecord(ctx, record, opts))
+		}
+	}
+	return out, nil
+}
+
+func (a *markdownPageServiceAdapter) CheckTranslations(ctx context.Context, id uuid.UUID, required []string, opts interfaces.TranslationCheckOptions) ([]string, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	return a.service.CheckTranslations(ctx, id, required, opts)
+}
+
+func (a *markdownPageServiceAdapter) AvailableLocales(ctx context.Context, id uuid.UUID, opts interfaces.TranslationCheckOptions) ([]string, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	return a.service.AvailableLocales(ctx, id, opts)
+}
+
+func (a *markdownPageServiceAdapter) Delete(ctx context.Context, req interfaces.PageDeleteRequest) error {
+	if a == nil || a.service == nil {
+		return errors.New("page service unavailable")
+	}
+	return a.service.Delete(ctx, pages.DeletePageRequest{
+		ID:         req.ID,
+		DeletedBy:  req.DeletedBy,
+		HardDelete: req.HardDelete,
+	})
+}
+
+func (a *markdownPageServiceAdapter) UpdateTranslation(ctx context.Context, req interfaces.PageUpdateTranslationRequest) (*interfaces.PageTranslation, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	translation, err := a.service.UpdateTranslation(ctx, pages.UpdatePageTranslationRequest{
+		PageID:    req.PageID,
+		Locale:    req.Locale,
+		Title:     req.Title,
+		Path:      req.Path,
+		Summary:   req.Summary,
+		UpdatedBy: req.UpdatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := a.toPageTranslation(ctx, translation)
+	return &out, nil
+}
+
+func (a *markdownPageServiceAdapter) DeleteTranslation(ctx context.Context, req interfaces.PageDeleteTranslationRequest) error {
+	if a == nil || a.service == nil {
+		return errors.New("page service unavailable")
+	}
+	return a.service.DeleteTranslation(ctx, pages.DeletePageTranslationRequest{
+		PageID:    req.PageID,
+		Locale:    req.Locale,
+		DeletedBy: req.DeletedBy,
+	})
+}
+
+func (a *markdownPageServiceAdapter) Move(ctx context.Context, req interfaces.PageMoveRequest) (*interfaces.PageRecord, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	record, err := a.service.Move(ctx, pages.MovePageRequest{
+		PageID:      req.PageID,
+		NewParentID: req.NewParentID,
+		ActorID:     req.ActorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.toPageRecord(ctx, record, interfaces.PageReadOptions{}), nil
+}
+
+func (a *markdownPageServiceAdapter) Duplicate(ctx context.Context, req interfaces.PageDuplicateRequest) (*interfaces.PageRecord, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	record, err := a.service.Duplicate(ctx, pages.DuplicatePageRequest{
+		PageID:    req.PageID,
+		Slug:      req.Slug,
+		ParentID:  req.ParentID,
+		Status:    req.Status,
+		CreatedBy: req.CreatedBy,
+		UpdatedBy: req.UpdatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.toPageRecord(ctx, record, interfaces.PageReadOptions{}), nil
+}
+
+func (a *markdownPageServiceAdapter) list(ctx context.Context, envKey string) ([]*pages.Page, error) {
+	if a == nil || a.service == nil {
+		return nil, errors.New("page service unavailable")
+	}
+	envKey = strings.TrimSpace(envKey)
+	if envKey == "" {
+		return a.service.List(ctx)
+	}
+	return a.service.List(ctx, envKey)
+}
+
+func (a *markdownPageServiceAdapter) toPageRecord(ctx context.Context, record *pages.Page, opts interfaces.PageReadOptions) *interfaces.PageRecord {
+	if record == nil {
+		return nil
+	}
+	translations := make([]interfaces.PageTranslation, 0, len(record.Translations))
+	for _, tr := range record.Translations {
+		if tr != nil {
+			translations = append(translations, a.toPageTranslation(ctx, tr))
+		}
+	}
+	return &interfaces.PageRecord{
+		ID:          record.ID,
+		ContentID:   record.ContentID,
+		TemplateID:  record.TemplateID,
+		ParentID:    record.ParentID,
+		Slug:        record.Slug,
+		Status:      record.Status,
+		Translation: buildPageTranslationBundle(translations, opts, record.PrimaryLocale),
+	}
+}
+
+// toPageTranslation fills the locale code from the locale repository when the
+// stored translation only carries a locale ID.
+func (a *markdownPageServiceAdapter) toPageTranslation(ctx context.Context, tr *pages.PageTranslation) interfaces.PageTranslation {
+	locale := strings.TrimSpace(tr.Locale)
+	if locale == "" && a.locales != nil && tr.LocaleID != uuid.Nil {
+		if resolved, err := a.locales.GetByID(ctx, tr.LocaleID); err == nil && resolved != nil {
+			locale = resolved.Code
+		}
+	}
+	return interfaces.PageTranslation{
+		ID:       tr.ID,
+		FamilyID: tr.FamilyID,
+		Locale:   locale,
+		Title:    tr.Title,
+		Path:     tr.Path,
+		Summary:  tr.Summary,
+	}
+}
+
+func toPageTranslationInputs(inputs []interfaces.PageTranslationInput) []pages.PageTranslationInput {
+	out := make([]pages.PageTranslationInput, 0, len(inputs))
+	for _, tr := range inputs {
+		out = append(out, pages.PageTranslationInput{
+			Locale:  tr.Locale,
+			Title:   tr.Title,
+			Path:    tr.Path,
+			Summary: tr.Summary,
+		})
+	}
+	return out
+}
+
+func buildPageTranslationBundle(translations []interfaces.PageTranslation, opts interfaces.PageReadOptions, primaryLocale string) interfaces.TranslationBundle[interfaces.PageTranslation] {
+	requestedLocale := strings.TrimSpace(opts.Locale)
+	fallbackLocale := strings.TrimSpace(opts.FallbackLocale)
+	meta := interfaces.TranslationMeta{
+		RequestedLocale: requestedLocale,
+		PrimaryLocale:   strings.TrimSpace(primaryLocale),
+	}
+	if opts.IncludeAvailableLocales {
+		for _, tr := range translations {
+			if code := strings.TrimSpace(tr.Locale); code != "" {
+				meta.AvailableLocales = append(meta.AvailableLocales, code)
+			}
+		}
+	}
+
+	find := func(locale string) *interfaces.PageTranslation {
+		for _, tr := range translations {
+			if locale != "" && strings.EqualFold(strings.TrimSpace(tr.Locale), locale) {
+				copy := tr
+				return &copy
+			}
+		}
+		return nil
+	}
+
+	var requested, resolved *interfaces.PageTranslation
+	if requested = find(requestedLocale); requested != nil {
+		resolved = requested
+		meta.ResolvedLocale = requested.Locale
+	} else if requestedLocale != "" && fallbackLocale != "" {
+		if resolved = find(fallbackLocale); resolved != nil {
+			meta.ResolvedLocale = resolved.Locale
+			meta.FallbackUsed = true
+		}
+	}
+	meta.MissingRequestedLocale = requestedLocale != "" && requested == nil
+
+	return interfaces.TranslationBundle[interfaces.PageTranslation]{
+		Meta:      meta,
+		Requested: requested,
+		Resolved:  resolved,
+	}
+}
+
+// markdownTemplateResolver maps frontmatter template names onto templates of
+// the active themes, matching slug, name or template path.
+type markdownTemplateResolver struct {
+	themes themes.Service
+}
+
+func (r markdownTemplateResolver) ResolveTemplate(ctx context.Context, name string) (uuid.UUID, error) {
+	name = strings.TrimSpace(name)
+	if r.themes == nil || name == "" {
+		return uuid.Nil, nil
+	}
+	active, err := r.themes.ListActiveThemes(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, theme := range active {
+		if theme == nil {
+			continue
+		}
+		templates, err := r.themes.ListTemplates(ctx, theme.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		for _, tpl := range templates {
+			if tpl == nil {
+				continue
+			}
+			tplPath := tpl.TemplatePath
+			if strings.EqualFold(tpl.Slug, name) || strings.EqualFold(tpl.Name, name) ||
+				strings.EqualFold(tplPath, name) || strings.EqualFold(strings.TrimSuffix(tplPath, path.Ext(tplPath)), name) {
+				return tpl.ID, nil
+			}
+		}
+	}
+	return uuid.Nil, nil
+}
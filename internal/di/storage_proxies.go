@@ -2,13 +2,21 @@ package di
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/internal/blocks"
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/experiments"
+	"github.com/goliatone/go-cms/internal/jobs"
 	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/sites"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/internal/webhooks"
 	"github.com/google/uuid"
 )
 
@@ -73,10 +81,34 @@ func (p *contentRepositoryProxy) ListTranslations(ctx context.Context, contentID
 	return reader.ListTranslations(ctx, contentID)
 }
 
+func (p *contentRepositoryProxy) UpdateTranslationFields(ctx context.Context, translationID uuid.UUID, fields map[string]any) error {
+	writer, ok := p.current().(content.ContentTranslationFieldsWriter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return writer.UpdateTranslationFields(ctx, translationID, fields)
+}
+
 func (p *contentRepositoryProxy) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
 	return p.current().Delete(ctx, id, hardDelete)
 }
 
+func (p *contentRepositoryProxy) DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error {
+	trasher, ok := p.current().(content.ContentTrasher)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return trasher.DeleteToTrash(ctx, id, entry)
+}
+
+func (p *contentRepositoryProxy) RestoreFromTrash(ctx context.Context, entryID uuid.UUID, record *content.Content) error {
+	trasher, ok := p.current().(content.ContentTrasher)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return trasher.RestoreFromTrash(ctx, entryID, record)
+}
+
 func (p *contentRepositoryProxy) CreateVersion(ctx context.Context, version *content.ContentVersion) (*content.ContentVersion, error) {
 	return p.current().CreateVersion(ctx, version)
 }
@@ -97,6 +129,10 @@ func (p *contentRepositoryProxy) UpdateVersion(ctx context.Context, version *con
 	return p.current().UpdateVersion(ctx, version)
 }
 
+func (p *contentRepositoryProxy) DeleteVersions(ctx context.Context, contentID uuid.UUID, numbers []int) error {
+	return p.current().DeleteVersions(ctx, contentID, numbers)
+}
+
 // contentTypeRepositoryProxy swaps content type repositories on demand.
 type contentTypeRepositoryProxy struct {
 	mu   sync.RWMutex
@@ -264,6 +300,290 @@ func (p *environmentRepositoryProxy) Delete(ctx context.Context, id uuid.UUID) e
 	return p.current().Delete(ctx, id)
 }
 
+// siteRepositoryProxy routes calls to the current site repository implementation.
+type siteRepositoryProxy struct {
+	mu   sync.RWMutex
+	repo sites.SiteRepository
+}
+
+func newSiteRepositoryProxy(repo sites.SiteRepository) *siteRepositoryProxy {
+	return &siteRepositoryProxy{repo: repo}
+}
+
+func (p *siteRepositoryProxy) swap(repo sites.SiteRepository) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if repo != nil {
+		p.repo = repo
+	}
+}
+
+func (p *siteRepositoryProxy) current() sites.SiteRepository {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.repo
+}
+
+func (p *siteRepositoryProxy) Create(ctx context.Context, site *sites.Site) (*sites.Site, error) {
+	return p.current().Create(ctx, site)
+}
+
+func (p *siteRepositoryProxy) Update(ctx context.Context, site *sites.Site) (*sites.Site, error) {
+	return p.current().Update(ctx, site)
+}
+
+func (p *siteRepositoryProxy) GetByID(ctx context.Context, id uuid.UUID) (*sites.Site, error) {
+	return p.current().GetByID(ctx, id)
+}
+
+func (p *siteRepositoryProxy) GetByKey(ctx context.Context, key string) (*sites.Site, error) {
+	return p.current().GetByKey(ctx, key)
+}
+
+func (p *siteRepositoryProxy) List(ctx context.Context) ([]*sites.Site, error) {
+	return p.current().List(ctx)
+}
+
+func (p *siteRepositoryProxy) GetDefault(ctx context.Context) (*sites.Site, error) {
+	return p.current().GetDefault(ctx)
+}
+
+func (p *siteRepositoryProxy) Delete(ctx context.Context, id uuid.UUID) error {
+	return p.current().Delete(ctx, id)
+}
+
+// trashRepositoryProxy routes calls to the current trash repository implementation.
+type trashRepositoryProxy struct {
+	mu   sync.RWMutex
+	repo trash.Repository
+}
+
+func newTrashRepositoryProxy(repo trash.Repository) *trashRepositoryProxy {
+	return &trashRepositoryProxy{repo: repo}
+}
+
+func (p *trashRepositoryProxy) swap(repo trash.Repository) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if repo != nil {
+		p.repo = repo
+	}
+}
+
+func (p *trashRepositoryProxy) current() trash.Repository {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.repo
+}
+
+func (p *trashRepositoryProxy) Create(ctx context.Context, entry *trash.Entry) (*trash.Entry, error) {
+	return p.current().Create(ctx, entry)
+}
+
+func (p *trashRepositoryProxy) GetByResource(ctx context.Context, resourceType string, resourceID uuid.UUID) (*trash.Entry, error) {
+	return p.current().GetByResource(ctx, resourceType, resourceID)
+}
+
+func (p *trashRepositoryProxy) List(ctx context.Context, query trash.ListQuery) ([]*trash.Entry, error) {
+	return p.current().List(ctx, query)
+}
+
+func (p *trashRepositoryProxy) Delete(ctx context.Context, id uuid.UUID) error {
+	return p.current().Delete(ctx, id)
+}
+
+func (p *trashRepositoryProxy) DeleteBefore(ctx context.Context, cutoff time.Time) ([]*trash.Entry, error) {
+	return p.current().DeleteBefore(ctx, cutoff)
+}
+
+// experimentRepositoryProxy routes calls to the current experiment repository implementation.
+type experimentRepositoryProxy struct {
+	mu   sync.RWMutex
+	repo experiments.ExperimentRepository
+}
+
+func newExperimentRepositoryProxy(repo experiments.ExperimentRepository) *experimentRepositoryProxy {
+	return &experimentRepositoryProxy{repo: repo}
+}
+
+func (p *experimentRepositoryProxy) swap(repo experiments.ExperimentRepository) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if repo != nil {
+		p.repo = repo
+	}
+}
+
+func (p *experimentRepositoryProxy) current() experiments.ExperimentRepository {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.repo
+}
+
+func (p *experimentRepositoryProxy) Create(ctx context.Context, experiment *experiments.Experiment) (*experiments.Experiment, error) {
+	return p.current().Create(ctx, experiment)
+}
+
+func (p *experimentRepositoryProxy) Update(ctx context.Context, experiment *experiments.Experiment) (*experiments.Experiment, error) {
+	return p.current().Update(ctx, experiment)
+}
+
+func (p *experimentRepositoryProxy) GetByID(ctx context.Context, id uuid.UUID) (*experiments.Experiment, error) {
+	return p.current().GetByID(ctx, id)
+}
+
+func (p *experimentRepositoryProxy) GetByKey(ctx context.Context, key string) (*experiments.Experiment, error) {
+	return p.current().GetByKey(ctx, key)
+}
+
+func (p *experimentRepositoryProxy) List(ctx context.Context) ([]*experiments.Experiment, error) {
+	return p.current().List(ctx)
+}
+
+func (p *experimentRepositoryProxy) ListByTarget(ctx context.Context, targetType experiments.TargetType, targetID uuid.UUID) ([]*experiments.Experiment, error) {
+	return p.current().ListByTarget(ctx, targetType, targetID)
+}
+
+func (p *experimentRepositoryProxy) Delete(ctx context.Context, id uuid.UUID) error {
+	return p.current().Delete(ctx, id)
+}
+
+// webhookSubscriptionRepositoryProxy routes calls to the current webhook subscription repository implementation.
+type webhookSubscriptionRepositoryProxy struct {
+	mu   sync.RWMutex
+	repo webhooks.SubscriptionRepository
+}
+
+func newWebhookSubscriptionRepositoryProxy(repo webhooks.SubscriptionRepository) *webhookSubscriptionRepositoryProxy {
+	return &webhookSubscriptionRepositoryProxy{repo: repo}
+}
+
+func (p *webhookSubscriptionRepositoryProxy) swap(repo webhooks.SubscriptionRepository) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if repo != nil {
+		p.repo = repo
+	}
+}
+
+func (p *webhookSubscriptionRepositoryProxy) current() webhooks.SubscriptionRepository {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.repo
+}
+
+func (p *webhookSubscriptionRepositoryProxy) Create(ctx context.Context, subscription *webhooks.Subscription) (*webhooks.Subscription, error) {
+	return p.current().Create(ctx, subscription)
+}
+
+func (p *webhookSubscriptionRepositoryProxy) Update(ctx context.Context, subscription *webhooks.Subscription) (*webhooks.Subscription, error) {
+	return p.current().Update(ctx, subscription)
+}
+
+func (p *webhookSubscriptionRepositoryProxy) GetByID(ctx context.Context, id uuid.UUID) (*webhooks.Subscription, error) {
+	return p.current().GetByID(ctx, id)
+}
+
+func (p *webhookSubscriptionRepositoryProxy) List(ctx context.Context) ([]*webhooks.Subscription, error) {
+	return p.current().List(ctx)
+}
+
+func (p *webhookSubscriptionRepositoryProxy) Delete(ctx context.Context, id uuid.UUID) error {
+	return p.current().Delete(ctx, id)
+}
+
+// webhookDeliveryRepositoryProxy routes calls to the current webhook delivery repository implementation.
+type webhookDeliveryRepositoryProxy struct {
+	mu   sync.RWMutex
+	repo webhooks.DeliveryRepository
+}
+
+func newWebhookDeliveryRepositoryProxy(repo webhooks.DeliveryRepository) *webhookDeliveryRepositoryProxy {
+	return &webhookDeliveryRepositoryProxy{repo: repo}
+}
+
+func (p *webhookDeliveryRepositoryProxy) swap(repo webhooks.DeliveryRepository) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if repo != nil {
+		p.repo = repo
+	}
+}
+
+func (p *webhookDeliveryRepositoryProxy) current() webhooks.DeliveryRepository {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.repo
+}
+
+func (p *webhookDeliveryRepositoryProxy) Create(ctx context.Context, delivery *webhooks.Delivery) (*webhooks.Delivery, error) {
+	return p.current().Create(ctx, delivery)
+}
+
+func (p *webhookDeliveryRepositoryProxy) Update(ctx context.Context, delivery *webhooks.Delivery) (*webhooks.Delivery, error) {
+	return p.current().Update(ctx, delivery)
+}
+
+func (p *webhookDeliveryRepositoryProxy) GetByID(ctx context.Context, id uuid.UUID) (*webhooks.Delivery, error) {
+	return p.current().GetByID(ctx, id)
+}
+
+func (p *webhookDeliveryRepositoryProxy) List(ctx context.Context, filter webhooks.DeliveryFilter) ([]*webhooks.Delivery, error) {
+	return p.current().List(ctx, filter)
+}
+
+// auditRecorderProxy routes calls to the current audit recorder implementation.
+type auditRecorderProxy struct {
+	mu       sync.RWMutex
+	recorder jobs.AuditRecorder
+}
+
+func newAuditRecorderProxy(recorder jobs.AuditRecorder) *auditRecorderProxy {
+	return &auditRecorderProxy{recorder: recorder}
+}
+
+func (p *auditRecorderProxy) swap(recorder jobs.AuditRecorder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if recorder != nil {
+		p.recorder = recorder
+	}
+}
+
+func (p *auditRecorderProxy) current() jobs.AuditRecorder {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.recorder
+}
+
+func (p *auditRecorderProxy) Record(ctx context.Context, event jobs.AuditEvent) error {
+	return p.current().Record(ctx, event)
+}
+
+func (p *auditRecorderProxy) List(ctx context.Context) ([]jobs.AuditEvent, error) {
+	return p.current().List(ctx)
+}
+
+func (p *auditRecorderProxy) Clear(ctx context.Context) error {
+	return p.current().Clear(ctx)
+}
+
+func (p *auditRecorderProxy) Query(ctx context.Context, query jobs.AuditQuery) (jobs.AuditPage, error) {
+	return jobs.NewAuditLog(p.current()).Query(ctx, query)
+}
+
+func (p *auditRecorderProxy) Purge(ctx context.Context, before time.Time) (int, error) {
+	return jobs.NewAuditLog(p.current()).Purge(ctx, before)
+}
+
+func (p *auditRecorderProxy) Count(ctx context.Context, before time.Time) (int, error) {
+	purger, ok := p.current().(jobs.AuditPurger)
+	if !ok {
+		return 0, audit.ErrPurgeUnsupported
+	}
+	return purger.Count(ctx, before)
+}
+
 // pageRepositoryProxy routes calls to the current page repository implementation.
 type pageRepositoryProxy struct {
 	mu   sync.RWMutex
@@ -316,6 +636,22 @@ func (p *pageRepositoryProxy) Delete(ctx context.Context, id uuid.UUID, hardDele
 	return p.current().Delete(ctx, id, hardDelete)
 }
 
+func (p *pageRepositoryProxy) DeleteToTrash(ctx context.Context, ids []uuid.UUID, entry *trash.Entry) error {
+	trasher, ok := p.current().(pages.PageTrasher)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return trasher.DeleteToTrash(ctx, ids, entry)
+}
+
+func (p *pageRepositoryProxy) RestoreFromTrash(ctx context.Context, entryID uuid.UUID, records []*pages.Page) error {
+	trasher, ok := p.current().(pages.PageTrasher)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return trasher.RestoreFromTrash(ctx, entryID, records)
+}
+
 func (p *pageRepositoryProxy) CreateVersion(ctx context.Context, version *pages.PageVersion) (*pages.PageVersion, error) {
 	return p.current().CreateVersion(ctx, version)
 }
@@ -336,6 +672,10 @@ func (p *pageRepositoryProxy) UpdateVersion(ctx context.Context, version *pages.
 	return p.current().UpdateVersion(ctx, version)
 }
 
+func (p *pageRepositoryProxy) DeleteVersions(ctx context.Context, pageID uuid.UUID, numbers []int) error {
+	return p.current().DeleteVersions(ctx, pageID, numbers)
+}
+
 // blockDefinitionRepositoryProxy routes calls to the current block definition repository.
 type blockDefinitionRepositoryProxy struct {
 	mu   sync.RWMutex
@@ -480,6 +820,14 @@ func (p *blockInstanceRepositoryProxy) Delete(ctx context.Context, id uuid.UUID)
 	return p.current().Delete(ctx, id)
 }
 
+func (p *blockInstanceRepositoryProxy) DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error {
+	trasher, ok := p.current().(blocks.InstanceTrasher)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return trasher.DeleteToTrash(ctx, id, entry)
+}
+
 // blockTranslationRepositoryProxy routes calls to the current block translation repository.
 type blockTranslationRepositoryProxy struct {
 	mu   sync.RWMutex
@@ -567,3 +915,7 @@ func (p *blockVersionRepositoryProxy) GetLatest(ctx context.Context, instanceID
 func (p *blockVersionRepositoryProxy) Update(ctx context.Context, version *blocks.InstanceVersion) (*blocks.InstanceVersion, error) {
 	return p.current().Update(ctx, version)
 }
+
+func (p *blockVersionRepositoryProxy) DeleteVersions(ctx context.Context, instanceID uuid.UUID, numbers []int) error {
+	return p.current().DeleteVersions(ctx, instanceID, numbers)
+}
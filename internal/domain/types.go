@@ -13,3 +13,13 @@ const (
 	// StatusScheduled marks content that has a future publish time configured
 	StatusScheduled Status = "scheduled"
 )
+
+// RestoreConflict selects how restoring a deleted record handles a slug that was reused after deletion
+type RestoreConflict string
+
+const (
+	// RestoreConflictFail rejects the restore while another record holds the slug
+	RestoreConflictFail RestoreConflict = "fail"
+	// RestoreConflictRename restores the record under the first free suffixed slug
+	RestoreConflictRename RestoreConflict = "rename"
+)
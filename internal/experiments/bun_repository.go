@@ -0,0 +1,106 @@
+package experiments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goliatone/go-errors"
+	repository "github.com/goliatone/go-repository-bun"
+	"github.com/goliatone/go-repository-cache/cache"
+	repositorycache "github.com/goliatone/go-repository-cache/repositorycache"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BunExperimentRepository implements ExperimentRepository with optional caching.
+type BunExperimentRepository struct {
+	repo repository.Repository[*Experiment]
+}
+
+// NewBunExperimentRepository creates an experiment repository without caching.
+func NewBunExperimentRepository(db *bun.DB) *BunExperimentRepository {
+	return NewBunExperimentRepositoryWithCache(db, nil, nil)
+}
+
+// NewBunExperimentRepositoryWithCache creates an experiment repository with caching support.
+func NewBunExperimentRepositoryWithCache(db *bun.DB, cacheService cache.CacheService, serializer cache.KeySerializer) *BunExperimentRepository {
+	base := NewExperimentRepository(db)
+	if cacheService != nil && serializer != nil {
+		base = repositorycache.New(base, cacheService, serializer)
+	}
+	return &BunExperimentRepository{repo: base}
+}
+
+func (r *BunExperimentRepository) Create(ctx context.Context, experiment *Experiment) (*Experiment, error) {
+	return r.repo.Create(ctx, experiment)
+}
+
+func (r *BunExperimentRepository) Update(ctx context.Context, experiment *Experiment) (*Experiment, error) {
+	updated, err := r.repo.Update(ctx, experiment,
+		repository.UpdateByID(experiment.ID.String()),
+		repository.UpdateColumns(
+			"name",
+			"status",
+			"variants",
+			"promoted_variant",
+			"started_at",
+			"stopped_at",
+			"promoted_at",
+			"updated_by",
+			"updated_at",
+		),
+	)
+	if err != nil {
+		return nil, mapRepositoryError(err, "experiment", experiment.ID.String())
+	}
+	return updated, nil
+}
+
+func (r *BunExperimentRepository) GetByID(ctx context.Context, id uuid.UUID) (*Experiment, error) {
+	record, err := r.repo.GetByID(ctx, id.String())
+	if err != nil {
+		return nil, mapRepositoryError(err, "experiment", id.String())
+	}
+	return record, nil
+}
+
+func (r *BunExperimentRepository) GetByKey(ctx context.Context, key string) (*Experiment, error) {
+	record, err := r.repo.GetByIdentifier(ctx, normalizeKey(key))
+	if err != nil {
+		return nil, mapRepositoryError(err, "experiment", key)
+	}
+	return record, nil
+}
+
+func (r *BunExperimentRepository) List(ctx context.Context) ([]*Experiment, error) {
+	records, _, err := r.repo.List(ctx, repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.OrderExpr("?TableAlias.key ASC")
+	}))
+	return records, err
+}
+
+func (r *BunExperimentRepository) ListByTarget(ctx context.Context, targetType TargetType, targetID uuid.UUID) ([]*Experiment, error) {
+	records, _, err := r.repo.List(ctx, repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("?TableAlias.target_type = ?", string(targetType)).
+			Where("?TableAlias.target_id = ?", targetID).
+			OrderExpr("?TableAlias.key ASC")
+	}))
+	return records, err
+}
+
+func (r *BunExperimentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.repo.Delete(ctx, &Experiment{ID: id}); err != nil {
+		return mapRepositoryError(err, "experiment", id.String())
+	}
+	return nil
+}
+
+func mapRepositoryError(err error, resource, key string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.IsCategory(err, repository.CategoryDatabaseNotFound) {
+		return &NotFoundError{Resource: resource, Key: key}
+	}
+	return fmt.Errorf("%s repository error: %w", resource, err)
+}
@@ -0,0 +1,108 @@
+package experiments_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/experiments"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestBunExperimentRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+
+	if _, err := bunDB.NewCreateTable().Model((*experiments.Experiment)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create experiments table: %v", err)
+	}
+
+	repo := experiments.NewBunExperimentRepository(bunDB)
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	target := uuid.MustParse("00000000-0000-0000-0000-00000000f101")
+
+	experiment := &experiments.Experiment{
+		ID:         uuid.MustParse("00000000-0000-0000-0000-00000000f001"),
+		Key:        "hero-copy",
+		Name:       "Hero copy",
+		TargetType: experiments.TargetBlockInstance,
+		TargetID:   target,
+		Status:     experiments.StatusDraft,
+		Variants: []experiments.Variant{
+			{Key: "control", Weight: 1},
+			{Key: "short", Weight: 1, Configuration: map[string]any{"title": "Short"}},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := repo.Create(ctx, experiment); err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	if _, err := repo.Create(ctx, &experiments.Experiment{
+		ID:         uuid.MustParse("00000000-0000-0000-0000-00000000f002"),
+		Key:        "sidebar-cta",
+		Name:       "Sidebar CTA",
+		TargetType: experiments.TargetWidgetInstance,
+		TargetID:   uuid.MustParse("00000000-0000-0000-0000-00000000f102"),
+		Status:     experiments.StatusDraft,
+		Variants:   []experiments.Variant{{Key: "a", Weight: 1}, {Key: "b", Weight: 1}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		t.Fatalf("create second experiment: %v", err)
+	}
+
+	byKey, err := repo.GetByKey(ctx, "HERO-COPY")
+	if err != nil {
+		t.Fatalf("get by key: %v", err)
+	}
+	if len(byKey.Variants) != 2 || byKey.Variants[1].Configuration["title"] != "Short" {
+		t.Fatalf("unexpected variants %+v", byKey.Variants)
+	}
+
+	startedAt := now.Add(time.Hour)
+	byKey.Status = experiments.StatusRunning
+	byKey.StartedAt = &startedAt
+	if _, err := repo.Update(ctx, byKey); err != nil {
+		t.Fatalf("update experiment: %v", err)
+	}
+
+	targeted, err := repo.ListByTarget(ctx, experiments.TargetBlockInstance, target)
+	if err != nil {
+		t.Fatalf("list by target: %v", err)
+	}
+	if len(targeted) != 1 || targeted[0].Status != experiments.StatusRunning || targeted[0].StartedAt == nil {
+		t.Fatalf("unexpected target experiments %+v", targeted)
+	}
+	if others, err := repo.ListByTarget(ctx, experiments.TargetWidgetInstance, target); err != nil || len(others) != 0 {
+		t.Fatalf("expected target type to scope the lookup, got %d (%v)", len(others), err)
+	}
+
+	all, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("list experiments: %v", err)
+	}
+	if len(all) != 2 || all[0].Key != "hero-copy" || all[1].Key != "sidebar-cta" {
+		t.Fatalf("unexpected experiment order %+v", all)
+	}
+
+	if err := repo.Delete(ctx, experiment.ID); err != nil {
+		t.Fatalf("delete experiment: %v", err)
+	}
+	var nf *experiments.NotFoundError
+	if _, err := repo.GetByID(ctx, experiment.ID); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError after delete, got %v", err)
+	}
+}
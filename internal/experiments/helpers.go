@@ -0,0 +1,151 @@
+package experiments
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var experimentKeyPattern = regexp.MustCompile(`^[a-z0-9_.-]+$`)
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+func validTargetType(targetType TargetType) bool {
+	return targetType == TargetWidgetInstance || targetType == TargetBlockInstance
+}
+
+// normalizeVariants trims variant keys and checks that keys are unique and
+// that weights are non-negative with a positive total.
+func normalizeVariants(variants []Variant) ([]Variant, error) {
+	if len(variants) < 2 {
+		return nil, ErrExperimentVariantsRequired
+	}
+	out := make([]Variant, 0, len(variants))
+	total := 0
+	for _, variant := range variants {
+		key := strings.TrimSpace(variant.Key)
+		if key == "" || slices.ContainsFunc(out, func(existing Variant) bool { return existing.Key == key }) {
+			return nil, ErrExperimentVariantKeyInvalid
+		}
+		if variant.Weight < 0 {
+			return nil, ErrExperimentVariantWeightInvalid
+		}
+		total += variant.Weight
+		out = append(out, Variant{
+			Key:           key,
+			Weight:        variant.Weight,
+			Configuration: cloneConfiguration(variant.Configuration),
+		})
+	}
+	if total <= 0 {
+		return nil, ErrExperimentVariantWeightInvalid
+	}
+	return out, nil
+}
+
+// bucketVariant deterministically maps a visitor to a variant. The bucket is
+// derived from the experiment ID and visitor key so a visitor keeps seeing the
+// same variant for as long as the weights are unchanged.
+func bucketVariant(experiment *Experiment, visitorKey string) (*Variant, bool) {
+	total := 0
+	for _, variant := range experiment.Variants {
+		total += max(variant.Weight, 0)
+	}
+	if total <= 0 {
+		return nil, false
+	}
+	sum := sha256.Sum256([]byte(experiment.ID.String() + ":" + visitorKey))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for i := range experiment.Variants {
+		weight := max(experiment.Variants[i].Weight, 0)
+		if bucket < weight {
+			return &experiment.Variants[i], true
+		}
+		bucket -= weight
+	}
+	return nil, false
+}
+
+// overlayConfiguration returns base with the top-level keys of overlay
+// replacing the base values.
+func overlayConfiguration(base, overlay map[string]any) map[string]any {
+	merged := cloneConfiguration(base)
+	if merged == nil {
+		merged = make(map[string]any, len(overlay))
+	}
+	for key, value := range overlay {
+		merged[key] = cloneValue(value)
+	}
+	return merged
+}
+
+func cloneConfiguration(src map[string]any) map[string]any {
+	if src == nil {
+		return nil
+	}
+	out := make(map[string]any, len(src))
+	for key, value := range src {
+		out[key] = cloneValue(value)
+	}
+	return out
+}
+
+func cloneValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return cloneConfiguration(typed)
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = cloneValue(item)
+		}
+		return out
+	case map[string]string:
+		return maps.Clone(typed)
+	case []string:
+		return slices.Clone(typed)
+	default:
+		return value
+	}
+}
+
+func cloneVariants(src []Variant) []Variant {
+	if src == nil {
+		return nil
+	}
+	out := make([]Variant, len(src))
+	for i, variant := range src {
+		out[i] = Variant{
+			Key:           variant.Key,
+			Weight:        variant.Weight,
+			Configuration: cloneConfiguration(variant.Configuration),
+		}
+	}
+	return out
+}
+
+func cloneExperiment(experiment *Experiment) *Experiment {
+	if experiment == nil {
+		return nil
+	}
+	cloned := *experiment
+	cloned.Variants = cloneVariants(experiment.Variants)
+	cloned.StartedAt = cloneTime(experiment.StartedAt)
+	cloned.StoppedAt = cloneTime(experiment.StoppedAt)
+	cloned.PromotedAt = cloneTime(experiment.PromotedAt)
+	return &cloned
+}
+
+func cloneTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
@@ -0,0 +1,124 @@
+package experiments
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type memoryRepository struct {
+	mu    sync.RWMutex
+	byID  map[uuid.UUID]*Experiment
+	byKey map[string]uuid.UUID
+}
+
+// NewMemoryRepository constructs an in-memory experiment repository.
+func NewMemoryRepository() ExperimentRepository {
+	return &memoryRepository{
+		byID:  make(map[uuid.UUID]*Experiment),
+		byKey: make(map[string]uuid.UUID),
+	}
+}
+
+func (m *memoryRepository) Create(_ context.Context, experiment *Experiment) (*Experiment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cloned := cloneExperiment(experiment)
+	cloned.Key = normalizeKey(cloned.Key)
+	m.byID[cloned.ID] = cloned
+	if cloned.Key != "" {
+		m.byKey[cloned.Key] = cloned.ID
+	}
+	return cloneExperiment(cloned), nil
+}
+
+func (m *memoryRepository) Update(_ context.Context, experiment *Experiment) (*Experiment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.byID[experiment.ID]
+	if !ok {
+		return nil, &NotFoundError{Resource: "experiment", Key: experiment.ID.String()}
+	}
+	cloned := cloneExperiment(experiment)
+	cloned.Key = normalizeKey(cloned.Key)
+	m.byID[cloned.ID] = cloned
+	if existing.Key != "" && existing.Key != cloned.Key {
+		delete(m.byKey, existing.Key)
+	}
+	if cloned.Key != "" {
+		m.byKey[cloned.Key] = cloned.ID
+	}
+	return cloneExperiment(cloned), nil
+}
+
+func (m *memoryRepository) GetByID(_ context.Context, id uuid.UUID) (*Experiment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.byID[id]
+	if !ok {
+		return nil, &NotFoundError{Resource: "experiment", Key: id.String()}
+	}
+	return cloneExperiment(record), nil
+}
+
+func (m *memoryRepository) GetByKey(_ context.Context, key string) (*Experiment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	normalized := normalizeKey(key)
+	id, ok := m.byKey[normalized]
+	if !ok {
+		return nil, &NotFoundError{Resource: "experiment", Key: normalized}
+	}
+	return cloneExperiment(m.byID[id]), nil
+}
+
+func (m *memoryRepository) List(_ context.Context) ([]*Experiment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	records := make([]*Experiment, 0, len(m.byID))
+	for _, record := range m.byID {
+		records = append(records, cloneExperiment(record))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	return records, nil
+}
+
+func (m *memoryRepository) ListByTarget(_ context.Context, targetType TargetType, targetID uuid.UUID) ([]*Experiment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var records []*Experiment
+	for _, record := range m.byID {
+		if record.TargetType == targetType && record.TargetID == targetID {
+			records = append(records, cloneExperiment(record))
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	return records, nil
+}
+
+func (m *memoryRepository) Delete(_ context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.byID[id]
+	if !ok {
+		return &NotFoundError{Resource: "experiment", Key: id.String()}
+	}
+	delete(m.byID, id)
+	if record.Key != "" {
+		delete(m.byKey, record.Key)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package experiments
+
+import cmsexperiments "github.com/goliatone/go-cms/experiments"
+
+type (
+	Experiment                  = cmsexperiments.Experiment
+	Variant                     = cmsexperiments.Variant
+	Status                      = cmsexperiments.Status
+	TargetType                  = cmsexperiments.TargetType
+	Service                     = cmsexperiments.Service
+	Selector                    = cmsexperiments.Selector
+	CreateExperimentInput       = cmsexperiments.CreateExperimentInput
+	TransitionExperimentRequest = cmsexperiments.TransitionExperimentRequest
+	PromoteVariantRequest       = cmsexperiments.PromoteVariantRequest
+	SelectVariantInput          = cmsexperiments.SelectVariantInput
+	Assignment                  = cmsexperiments.Assignment
+)
+
+const (
+	StatusDraft          = cmsexperiments.StatusDraft
+	StatusRunning        = cmsexperiments.StatusRunning
+	StatusStopped        = cmsexperiments.StatusStopped
+	StatusPromoted       = cmsexperiments.StatusPromoted
+	TargetWidgetInstance = cmsexperiments.TargetWidgetInstance
+	TargetBlockInstance  = cmsexperiments.TargetBlockInstance
+)
+
+var (
+	ErrFeatureDisabled                = cmsexperiments.ErrFeatureDisabled
+	ErrExperimentKeyRequired          = cmsexperiments.ErrExperimentKeyRequired
+	ErrExperimentKeyInvalid           = cmsexperiments.ErrExperimentKeyInvalid
+	ErrExperimentKeyExists            = cmsexperiments.ErrExperimentKeyExists
+	ErrExperimentNotFound             = cmsexperiments.ErrExperimentNotFound
+	ErrExperimentTargetRequired       = cmsexperiments.ErrExperimentTargetRequired
+	ErrExperimentTargetInvalid        = cmsexperiments.ErrExperimentTargetInvalid
+	ErrExperimentTargetUnavailable    = cmsexperiments.ErrExperimentTargetUnavailable
+	ErrExperimentTargetBusy           = cmsexperiments.ErrExperimentTargetBusy
+	ErrExperimentVariantsRequired     = cmsexperiments.ErrExperimentVariantsRequired
+	ErrExperimentVariantKeyInvalid    = cmsexperiments.ErrExperimentVariantKeyInvalid
+	ErrExperimentVariantWeightInvalid = cmsexperiments.ErrExperimentVariantWeightInvalid
+	ErrExperimentVariantNotFound      = cmsexperiments.ErrExperimentVariantNotFound
+	ErrExperimentInvalidTransition    = cmsexperiments.ErrExperimentInvalidTransition
+	ErrExperimentRepositoryRequired   = cmsexperiments.ErrExperimentRepositoryRequired
+)
@@ -0,0 +1,26 @@
+package experiments
+
+import (
+	repository "github.com/goliatone/go-repository-bun"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// NewExperimentRepository creates a repository for experiment records.
+func NewExperimentRepository(db *bun.DB) repository.Repository[*Experiment] {
+	return repository.MustNewRepository(db, repository.ModelHandlers[*Experiment]{
+		NewRecord: func() *Experiment { return &Experiment{} },
+		GetID: func(experiment *Experiment) uuid.UUID {
+			return experiment.ID
+		},
+		SetID: func(experiment *Experiment, id uuid.UUID) {
+			experiment.ID = id
+		},
+		GetIdentifier: func() string {
+			return "key"
+		},
+		GetIdentifierValue: func(experiment *Experiment) string {
+			return experiment.Key
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package experiments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ExperimentRepository exposes persistence operations for experiments.
+type ExperimentRepository interface {
+	Create(ctx context.Context, experiment *Experiment) (*Experiment, error)
+	Update(ctx context.Context, experiment *Experiment) (*Experiment, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Experiment, error)
+	GetByKey(ctx context.Context, key string) (*Experiment, error)
+	List(ctx context.Context) ([]*Experiment, error)
+	ListByTarget(ctx context.Context, targetType TargetType, targetID uuid.UUID) ([]*Experiment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NotFoundError is returned when an experiment cannot be located.
+type NotFoundError struct {
+	Resource string
+	Key      string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s not found", e.Resource)
+	}
+	return fmt.Sprintf("%s %q not found", e.Resource, e.Key)
+}
@@ -0,0 +1,421 @@
+package experiments
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/google/uuid"
+)
+
+// IDGenerator produces unique identifiers.
+type IDGenerator func() uuid.UUID
+
+// BlockInstanceReader loads block instances so promotions can overlay a
+// variant on the current block configuration.
+type BlockInstanceReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*blocks.Instance, error)
+}
+
+// ServiceOption configures service behaviour.
+type ServiceOption func(*service)
+
+// WithIDGenerator overrides the ID generator.
+func WithIDGenerator(generator IDGenerator) ServiceOption {
+	return func(s *service) {
+		if generator != nil {
+			s.id = generator
+		}
+	}
+}
+
+// WithNow overrides the time source (primarily for tests).
+func WithNow(now func() time.Time) ServiceOption {
+	return func(s *service) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+// WithActivityEmitter wires the emitter receiving lifecycle and exposure events.
+func WithActivityEmitter(emitter *activity.Emitter) ServiceOption {
+	return func(s *service) {
+		if emitter != nil {
+			s.activity = emitter
+		}
+	}
+}
+
+// WithWidgetService enables experiments on widget instances.
+func WithWidgetService(svc widgets.Service) ServiceOption {
+	return func(s *service) {
+		if svc != nil {
+			s.widgets = svc
+		}
+	}
+}
+
+// WithBlockService enables experiments on block instances. The reader loads
+// the block configuration a promoted variant is overlaid on.
+func WithBlockService(svc blocks.Service, instances BlockInstanceReader) ServiceOption {
+	return func(s *service) {
+		if svc != nil && instances != nil {
+			s.blocks = svc
+			s.blockInstances = instances
+		}
+	}
+}
+
+type service struct {
+	repo           ExperimentRepository
+	widgets        widgets.Service
+	blocks         blocks.Service
+	blockInstances BlockInstanceReader
+	activity       *activity.Emitter
+	id             IDGenerator
+	now            func() time.Time
+}
+
+// NewService constructs an experiment service instance.
+func NewService(repo ExperimentRepository, opts ...ServiceOption) Service {
+	if repo == nil {
+		panic(ErrExperimentRepositoryRequired)
+	}
+	s := &service{
+		repo:     repo,
+		activity: activity.NewEmitter(nil, activity.Config{}),
+		id:       uuid.New,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) CreateExperiment(ctx context.Context, input CreateExperimentInput) (*Experiment, error) {
+	key := normalizeKey(input.Key)
+	if key == "" {
+		return nil, ErrExperimentKeyRequired
+	}
+	if !experimentKeyPattern.MatchString(key) {
+		return nil, ErrExperimentKeyInvalid
+	}
+	if existing, err := s.repo.GetByKey(ctx, key); err == nil && existing != nil {
+		return nil, ErrExperimentKeyExists
+	} else if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if input.TargetID == uuid.Nil {
+		return nil, ErrExperimentTargetRequired
+	}
+	if !validTargetType(input.TargetType) {
+		return nil, ErrExperimentTargetInvalid
+	}
+	if _, err := s.targetConfiguration(ctx, input.TargetType, input.TargetID); err != nil && !errors.Is(err, ErrExperimentTargetUnavailable) {
+		return nil, err
+	}
+	variants, err := normalizeVariants(input.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = key
+	}
+	now := s.now().UTC()
+	created, err := s.repo.Create(ctx, &Experiment{
+		ID:         s.id(),
+		Key:        key,
+		Name:       name,
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		Status:     StatusDraft,
+		Variants:   variants,
+		CreatedBy:  input.CreatedBy,
+		UpdatedBy:  input.CreatedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.emitActivity(ctx, input.CreatedBy, "create", created, nil)
+	return created, nil
+}
+
+func (s *service) GetExperiment(ctx context.Context, id uuid.UUID) (*Experiment, error) {
+	record, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	return record, nil
+}
+
+func (s *service) ListExperiments(ctx context.Context) ([]*Experiment, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *service) DeleteExperiment(ctx context.Context, id uuid.UUID) error {
+	return translateRepoError(s.repo.Delete(ctx, id))
+}
+
+// StartExperiment starts bucketing visitors. Draft and stopped experiments can
+// be started as long as no other experiment runs on the same target.
+func (s *service) StartExperiment(ctx context.Context, req TransitionExperimentRequest) (*Experiment, error) {
+	experiment, err := s.GetExperiment(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != StatusDraft && experiment.Status != StatusStopped {
+		return nil, ErrExperimentInvalidTransition
+	}
+	if running, err := s.runningExperiment(ctx, experiment.TargetType, experiment.TargetID); err != nil {
+		return nil, err
+	} else if running != nil && running.ID != experiment.ID {
+		return nil, ErrExperimentTargetBusy
+	}
+
+	now := s.now().UTC()
+	experiment.Status = StatusRunning
+	experiment.StartedAt = &now
+	experiment.StoppedAt = nil
+	return s.transition(ctx, experiment, req.Actor, "start", now)
+}
+
+// StopExperiment stops bucketing visitors; the target renders its base
+// configuration again.
+func (s *service) StopExperiment(ctx context.Context, req TransitionExperimentRequest) (*Experiment, error) {
+	experiment, err := s.GetExperiment(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != StatusRunning {
+		return nil, ErrExperimentInvalidTransition
+	}
+
+	now := s.now().UTC()
+	experiment.Status = StatusStopped
+	experiment.StoppedAt = &now
+	return s.transition(ctx, experiment, req.Actor, "stop", now)
+}
+
+// PromoteVariant overlays the winning variant on the target's configuration
+// and ends the experiment.
+func (s *service) PromoteVariant(ctx context.Context, req PromoteVariantRequest) (*Experiment, error) {
+	experiment, err := s.GetExperiment(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != StatusRunning && experiment.Status != StatusStopped {
+		return nil, ErrExperimentInvalidTransition
+	}
+	variant, ok := experiment.Variant(strings.TrimSpace(req.VariantKey))
+	if !ok {
+		return nil, ErrExperimentVariantNotFound
+	}
+
+	if len(variant.Configuration) > 0 {
+		base, err := s.targetConfiguration(ctx, experiment.TargetType, experiment.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.updateTargetConfiguration(ctx, experiment, overlayConfiguration(base, variant.Configuration), req.PromotedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now().UTC()
+	experiment.Status = StatusPromoted
+	experiment.PromotedVariant = variant.Key
+	experiment.PromotedAt = &now
+	if experiment.StoppedAt == nil {
+		experiment.StoppedAt = &now
+	}
+	return s.transition(ctx, experiment, req.PromotedBy, "promote", now)
+}
+
+// SelectVariant buckets a visitor into the running experiment of a target.
+// It returns nil when the target has no running experiment or the visitor is
+// anonymous. Every assignment is recorded as an "expose" activity event.
+func (s *service) SelectVariant(ctx context.Context, input SelectVariantInput) (*Assignment, error) {
+	visitorKey := strings.TrimSpace(input.VisitorKey)
+	if visitorKey == "" || input.TargetID == uuid.Nil {
+		return nil, nil
+	}
+	experiment, err := s.runningExperiment(ctx, input.TargetType, input.TargetID)
+	if err != nil || experiment == nil {
+		return nil, err
+	}
+	variant, ok := bucketVariant(experiment, visitorKey)
+	if !ok {
+		return nil, nil
+	}
+
+	s.emitActivity(ctx, uuid.Nil, "expose", experiment, map[string]any{
+		"variant":     variant.Key,
+		"visitor_key": visitorKey,
+	})
+	return &Assignment{
+		ExperimentID:  experiment.ID,
+		ExperimentKey: experiment.Key,
+		VariantKey:    variant.Key,
+		Configuration: cloneConfiguration(variant.Configuration),
+	}, nil
+}
+
+func (s *service) transition(ctx context.Context, experiment *Experiment, actor uuid.UUID, verb string, now time.Time) (*Experiment, error) {
+	if actor != uuid.Nil {
+		experiment.UpdatedBy = actor
+	}
+	experiment.UpdatedAt = now
+	updated, err := s.repo.Update(ctx, experiment)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	var meta map[string]any
+	if updated.PromotedVariant != "" {
+		meta = map[string]any{"variant": updated.PromotedVariant}
+	}
+	s.emitActivity(ctx, actor, verb, updated, meta)
+	return updated, nil
+}
+
+func (s *service) runningExperiment(ctx context.Context, targetType TargetType, targetID uuid.UUID) (*Experiment, error) {
+	records, err := s.repo.ListByTarget(ctx, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record != nil && record.Status == StatusRunning {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *service) targetConfiguration(ctx context.Context, targetType TargetType, targetID uuid.UUID) (map[string]any, error) {
+	switch targetType {
+	case TargetWidgetInstance:
+		if s.widgets == nil {
+			return nil, ErrExperimentTargetUnavailable
+		}
+		instance, err := s.widgets.GetInstance(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		return instance.Configuration, nil
+	case TargetBlockInstance:
+		if s.blockInstances == nil {
+			return nil, ErrExperimentTargetUnavailable
+		}
+		instance, err := s.blockInstances.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		return instance.Configuration, nil
+	default:
+		return nil, ErrExperimentTargetInvalid
+	}
+}
+
+func (s *service) updateTargetConfiguration(ctx context.Context, experiment *Experiment, configuration map[string]any, actor uuid.UUID) error {
+	switch experiment.TargetType {
+	case TargetWidgetInstance:
+		_, err := s.widgets.UpdateInstance(ctx, widgets.UpdateInstanceInput{
+			InstanceID:    experiment.TargetID,
+			Configuration: configuration,
+			UpdatedBy:     actor,
+		})
+		return err
+	case TargetBlockInstance:
+		_, err := s.blocks.UpdateInstance(ctx, blocks.UpdateInstanceInput{
+			InstanceID:    experiment.TargetID,
+			Configuration: configuration,
+			UpdatedBy:     actor,
+		})
+		return err
+	default:
+		return ErrExperimentTargetInvalid
+	}
+}
+
+func (s *service) emitActivity(ctx context.Context, actor uuid.UUID, verb string, experiment *Experiment, meta map[string]any) {
+	if s.activity == nil || !s.activity.Enabled() || experiment == nil {
+		return
+	}
+	fields := map[string]any{
+		"key":         experiment.Key,
+		"status":      string(experiment.Status),
+		"target_type": string(experiment.TargetType),
+		"target_id":   experiment.TargetID.String(),
+	}
+	for k, v := range meta {
+		fields[k] = v
+	}
+	_ = s.activity.Emit(ctx, activity.Event{
+		Verb:       verb,
+		ActorID:    actor.String(),
+		ObjectType: "experiment",
+		ObjectID:   experiment.ID.String(),
+		Metadata:   fields,
+	})
+}
+
+func isNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
+
+func translateRepoError(err error) error {
+	if err != nil && isNotFound(err) {
+		return ErrExperimentNotFound
+	}
+	return err
+}
+
+type disabledService struct{}
+
+// NewDisabledService returns a Service that fails all operations with
+// ErrFeatureDisabled. SelectVariant never assigns a variant.
+func NewDisabledService() Service {
+	return disabledService{}
+}
+
+func (disabledService) CreateExperiment(context.Context, CreateExperimentInput) (*Experiment, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) GetExperiment(context.Context, uuid.UUID) (*Experiment, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) ListExperiments(context.Context) ([]*Experiment, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) DeleteExperiment(context.Context, uuid.UUID) error {
+	return ErrFeatureDisabled
+}
+
+func (disabledService) StartExperiment(context.Context, TransitionExperimentRequest) (*Experiment, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) StopExperiment(context.Context, TransitionExperimentRequest) (*Experiment, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) PromoteVariant(context.Context, PromoteVariantRequest) (*Experiment, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) SelectVariant(context.Context, SelectVariantInput) (*Assignment, error) {
+	return nil, nil
+}
@@ -0,0 +1,302 @@
+package experiments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/google/uuid"
+)
+
+func TestServiceExperimentLifecycle(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	widgetSvc, instance := seedWidgetInstance(t)
+	svc := NewService(NewMemoryRepository(), WithWidgetService(widgetSvc))
+
+	experiment, err := svc.CreateExperiment(ctx, CreateExperimentInput{
+		Key:        " Newsletter-Headline ",
+		TargetType: TargetWidgetInstance,
+		TargetID:   instance.ID,
+		Variants: []Variant{
+			{Key: "control", Weight: 50},
+			{Key: "bold", Weight: 50, Configuration: map[string]any{"headline": "Join 10k readers"}},
+		},
+		CreatedBy: userID,
+	})
+	if err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	if experiment.Key != "newsletter-headline" || experiment.Name != "newsletter-headline" || experiment.Status != StatusDraft {
+		t.Fatalf("unexpected experiment %+v", experiment)
+	}
+
+	if _, err := svc.StopExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID}); !errors.Is(err, ErrExperimentInvalidTransition) {
+		t.Fatalf("expected ErrExperimentInvalidTransition stopping a draft, got %v", err)
+	}
+	started, err := svc.StartExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID, Actor: userID})
+	if err != nil {
+		t.Fatalf("start experiment: %v", err)
+	}
+	if started.Status != StatusRunning || started.StartedAt == nil {
+		t.Fatalf("expected running experiment, got %+v", started)
+	}
+
+	rival, err := svc.CreateExperiment(ctx, CreateExperimentInput{
+		Key:        "newsletter-cta",
+		TargetType: TargetWidgetInstance,
+		TargetID:   instance.ID,
+		Variants:   []Variant{{Key: "a", Weight: 1}, {Key: "b", Weight: 1}},
+	})
+	if err != nil {
+		t.Fatalf("create rival: %v", err)
+	}
+	if _, err := svc.StartExperiment(ctx, TransitionExperimentRequest{ID: rival.ID}); !errors.Is(err, ErrExperimentTargetBusy) {
+		t.Fatalf("expected ErrExperimentTargetBusy, got %v", err)
+	}
+
+	stopped, err := svc.StopExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID, Actor: userID})
+	if err != nil {
+		t.Fatalf("stop experiment: %v", err)
+	}
+	if stopped.Status != StatusStopped || stopped.StoppedAt == nil {
+		t.Fatalf("expected stopped experiment, got %+v", stopped)
+	}
+
+	if _, err := svc.PromoteVariant(ctx, PromoteVariantRequest{ID: experiment.ID, VariantKey: "missing"}); !errors.Is(err, ErrExperimentVariantNotFound) {
+		t.Fatalf("expected ErrExperimentVariantNotFound, got %v", err)
+	}
+	promoted, err := svc.PromoteVariant(ctx, PromoteVariantRequest{ID: experiment.ID, VariantKey: "bold", PromotedBy: userID})
+	if err != nil {
+		t.Fatalf("promote variant: %v", err)
+	}
+	if promoted.Status != StatusPromoted || promoted.PromotedVariant != "bold" || promoted.PromotedAt == nil {
+		t.Fatalf("expected promoted experiment, got %+v", promoted)
+	}
+	updated, err := widgetSvc.GetInstance(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("get widget instance: %v", err)
+	}
+	if updated.Configuration["headline"] != "Join 10k readers" || updated.Configuration["cta"] != "Subscribe" {
+		t.Fatalf("expected promoted configuration on the base instance, got %+v", updated.Configuration)
+	}
+	if _, err := svc.StartExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID}); !errors.Is(err, ErrExperimentInvalidTransition) {
+		t.Fatalf("expected promoted experiment to be final, got %v", err)
+	}
+}
+
+func TestServiceCreateExperimentValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryRepository())
+	target := uuid.New()
+	variants := []Variant{{Key: "a", Weight: 1}, {Key: "b", Weight: 1}}
+
+	cases := []struct {
+		name  string
+		input CreateExperimentInput
+		want  error
+	}{
+		{"key required", CreateExperimentInput{TargetType: TargetBlockInstance, TargetID: target, Variants: variants}, ErrExperimentKeyRequired},
+		{"key invalid", CreateExperimentInput{Key: "no spaces", TargetType: TargetBlockInstance, TargetID: target, Variants: variants}, ErrExperimentKeyInvalid},
+		{"target required", CreateExperimentInput{Key: "exp", TargetType: TargetBlockInstance, Variants: variants}, ErrExperimentTargetRequired},
+		{"target type", CreateExperimentInput{Key: "exp", TargetType: "page", TargetID: target, Variants: variants}, ErrExperimentTargetInvalid},
+		{"one variant", CreateExperimentInput{Key: "exp", TargetType: TargetBlockInstance, TargetID: target, Variants: variants[:1]}, ErrExperimentVariantsRequired},
+		{"duplicate variant", CreateExperimentInput{Key: "exp", TargetType: TargetBlockInstance, TargetID: target, Variants: []Variant{{Key: "a", Weight: 1}, {Key: " a ", Weight: 1}}}, ErrExperimentVariantKeyInvalid},
+		{"zero weights", CreateExperimentInput{Key: "exp", TargetType: TargetBlockInstance, TargetID: target, Variants: []Variant{{Key: "a"}, {Key: "b"}}}, ErrExperimentVariantWeightInvalid},
+		{"negative weight", CreateExperimentInput{Key: "exp", TargetType: TargetBlockInstance, TargetID: target, Variants: []Variant{{Key: "a", Weight: 2}, {Key: "b", Weight: -1}}}, ErrExperimentVariantWeightInvalid},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := svc.CreateExperiment(ctx, tc.input); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	if _, err := svc.CreateExperiment(ctx, CreateExperimentInput{Key: "exp", TargetType: TargetBlockInstance, TargetID: target, Variants: variants}); err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	if _, err := svc.CreateExperiment(ctx, CreateExperimentInput{Key: "EXP", TargetType: TargetBlockInstance, TargetID: target, Variants: variants}); !errors.Is(err, ErrExperimentKeyExists) {
+		t.Fatalf("expected ErrExperimentKeyExists, got %v", err)
+	}
+	if _, err := svc.GetExperiment(ctx, uuid.New()); !errors.Is(err, ErrExperimentNotFound) {
+		t.Fatalf("expected ErrExperimentNotFound, got %v", err)
+	}
+}
+
+func TestServiceSelectVariant(t *testing.T) {
+	ctx := context.Background()
+	hook := &activity.CaptureHook{}
+	svc := NewService(NewMemoryRepository(), WithActivityEmitter(activity.NewEmitter(activity.Hooks{hook}, activity.Config{Enabled: true})))
+	target := uuid.New()
+
+	experiment, err := svc.CreateExperiment(ctx, CreateExperimentInput{
+		Key:        "hero-copy",
+		TargetType: TargetBlockInstance,
+		TargetID:   target,
+		Variants: []Variant{
+			{Key: "control", Weight: 3},
+			{Key: "short", Weight: 1, Configuration: map[string]any{"title": "Short"}},
+			{Key: "paused", Weight: 0, Configuration: map[string]any{"title": "Never"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	input := SelectVariantInput{TargetType: TargetBlockInstance, TargetID: target, VisitorKey: "visitor-1"}
+	if assignment, err := svc.SelectVariant(ctx, input); err != nil || assignment != nil {
+		t.Fatalf("expected no assignment for a draft experiment, got %+v (%v)", assignment, err)
+	}
+	if _, err := svc.StartExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID}); err != nil {
+		t.Fatalf("start experiment: %v", err)
+	}
+
+	first, err := svc.SelectVariant(ctx, input)
+	if err != nil || first == nil {
+		t.Fatalf("expected assignment, got %+v (%v)", first, err)
+	}
+	for range 5 {
+		again, err := svc.SelectVariant(ctx, input)
+		if err != nil || again.VariantKey != first.VariantKey {
+			t.Fatalf("expected sticky assignment %q, got %+v (%v)", first.VariantKey, again, err)
+		}
+	}
+	if anonymous, err := svc.SelectVariant(ctx, SelectVariantInput{TargetType: TargetBlockInstance, TargetID: target}); err != nil || anonymous != nil {
+		t.Fatalf("expected no assignment without a visitor key, got %+v (%v)", anonymous, err)
+	}
+
+	counts := map[string]int{}
+	for i := range 4000 {
+		assignment, err := svc.SelectVariant(ctx, SelectVariantInput{
+			TargetType: TargetBlockInstance,
+			TargetID:   target,
+			VisitorKey: fmt.Sprintf("visitor-%d", i),
+		})
+		if err != nil {
+			t.Fatalf("select variant: %v", err)
+		}
+		counts[assignment.VariantKey]++
+	}
+	if counts["paused"] != 0 {
+		t.Fatalf("expected zero-weight variant to never be selected, got %d", counts["paused"])
+	}
+	if short := counts["short"]; short < 800 || short > 1200 {
+		t.Fatalf("expected roughly a quarter of visitors in the short variant, got %v", counts)
+	}
+
+	exposure := hook.Events[len(hook.Events)-1]
+	if exposure.Verb != "expose" || exposure.ObjectType != "experiment" || exposure.ObjectID != experiment.ID.String() {
+		t.Fatalf("unexpected exposure event %+v", exposure)
+	}
+	if exposure.Metadata["visitor_key"] != "visitor-3999" || exposure.Metadata["key"] != "hero-copy" {
+		t.Fatalf("unexpected exposure metadata %+v", exposure.Metadata)
+	}
+}
+
+func TestServicePromoteBlockVariant(t *testing.T) {
+	ctx := context.Background()
+	instanceRepo := blocks.NewMemoryInstanceRepository()
+	blockSvc := blocks.NewService(blocks.NewMemoryDefinitionRepository(), instanceRepo, blocks.NewMemoryTranslationRepository())
+	def, err := blockSvc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title", "subtitle"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	pageID := uuid.New()
+	instance, err := blockSvc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID:  def.ID,
+		PageID:        &pageID,
+		Region:        "hero",
+		Configuration: map[string]any{"title": "Welcome", "subtitle": "Hello"},
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create block instance: %v", err)
+	}
+
+	svc := NewService(NewMemoryRepository())
+	experiment, err := svc.CreateExperiment(ctx, CreateExperimentInput{
+		Key:        "hero-title",
+		TargetType: TargetBlockInstance,
+		TargetID:   instance.ID,
+		Variants:   []Variant{{Key: "control", Weight: 1}, {Key: "direct", Weight: 1, Configuration: map[string]any{"title": "Start now"}}},
+	})
+	if err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	if _, err := svc.StartExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID}); err != nil {
+		t.Fatalf("start experiment: %v", err)
+	}
+	if _, err := svc.PromoteVariant(ctx, PromoteVariantRequest{ID: experiment.ID, VariantKey: "direct"}); !errors.Is(err, ErrExperimentTargetUnavailable) {
+		t.Fatalf("expected ErrExperimentTargetUnavailable without a block service, got %v", err)
+	}
+
+	svc = NewService(NewMemoryRepository(), WithBlockService(blockSvc, instanceRepo))
+	experiment, err = svc.CreateExperiment(ctx, CreateExperimentInput{
+		Key:        "hero-title",
+		TargetType: TargetBlockInstance,
+		TargetID:   instance.ID,
+		Variants:   []Variant{{Key: "control", Weight: 1}, {Key: "direct", Weight: 1, Configuration: map[string]any{"title": "Start now"}}},
+	})
+	if err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	if _, err := svc.StartExperiment(ctx, TransitionExperimentRequest{ID: experiment.ID}); err != nil {
+		t.Fatalf("start experiment: %v", err)
+	}
+	if _, err := svc.PromoteVariant(ctx, PromoteVariantRequest{ID: experiment.ID, VariantKey: "direct", PromotedBy: uuid.New()}); err != nil {
+		t.Fatalf("promote variant: %v", err)
+	}
+	updated, err := instanceRepo.GetByID(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("get block instance: %v", err)
+	}
+	if updated.Configuration["title"] != "Start now" || updated.Configuration["subtitle"] != "Hello" {
+		t.Fatalf("expected promoted block configuration, got %+v", updated.Configuration)
+	}
+}
+
+func TestDisabledServiceNeverAssigns(t *testing.T) {
+	svc := NewDisabledService()
+	if _, err := svc.CreateExperiment(context.Background(), CreateExperimentInput{}); !errors.Is(err, ErrFeatureDisabled) {
+		t.Fatalf("expected ErrFeatureDisabled, got %v", err)
+	}
+	if assignment, err := svc.SelectVariant(context.Background(), SelectVariantInput{TargetID: uuid.New(), VisitorKey: "v"}); err != nil || assignment != nil {
+		t.Fatalf("expected no assignment, got %+v (%v)", assignment, err)
+	}
+}
+
+func seedWidgetInstance(t *testing.T) (widgets.Service, *widgets.Instance) {
+	t.Helper()
+	ctx := context.Background()
+	svc := widgets.NewService(widgets.NewMemoryDefinitionRepository(), widgets.NewMemoryInstanceRepository(), widgets.NewMemoryTranslationRepository())
+	def, err := svc.RegisterDefinition(ctx, widgets.RegisterDefinitionInput{
+		Name: "newsletter",
+		Schema: map[string]any{"fields": []any{
+			map[string]any{"name": "headline"},
+			map[string]any{"name": "cta"},
+		}},
+		Defaults: map[string]any{"cta": "Subscribe"},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	userID := uuid.New()
+	instance, err := svc.CreateInstance(ctx, widgets.CreateInstanceInput{
+		DefinitionID:  def.ID,
+		Configuration: map[string]any{"headline": "Stay in the loop"},
+		CreatedBy:     userID,
+		UpdatedBy:     userID,
+	})
+	if err != nil {
+		t.Fatalf("create widget instance: %v", err)
+	}
+	return svc, instance
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// HrefLangDefault is the hreflang value of the alternate used when no locale
+// matches the visitor.
+const HrefLangDefault = "x-default"
+
+// LocaleAlternate links a page to one localized variant of the same
+// translation family.
+type LocaleAlternate struct {
+	Locale string
+	Route  string
+	URL    string
+}
+
+// assignPageAlternates resolves the canonical URL and hreflang alternates for
+// the localized variants of one page. Variants rendering fallback content
+// point their canonical URL at the locale the content came from and are left
+// out of the alternates.
+func (s *service) assignPageAlternates(family []*PageData, locales localeSet) {
+	variants := make(map[uuid.UUID]LocaleAlternate, len(family))
+	for _, data := range family {
+		route := localizeRoute(safeTranslationPath(data.Translation), data.Locale.Code, locales.defaultCode)
+		variants[data.Locale.LocaleID] = LocaleAlternate{
+			Locale: data.Locale.Code,
+			Route:  route,
+			URL:    absoluteURL(s.cfg.BaseURL, route),
+		}
+	}
+
+	native := make([]LocaleAlternate, 0, len(family))
+	for _, data := range family {
+		data.Canonical = variants[data.Locale.LocaleID]
+		if data.ContentTranslation != nil && data.ContentTranslation.LocaleID != data.Locale.LocaleID {
+			if source, ok := variants[data.ContentTranslation.LocaleID]; ok {
+				data.Canonical = source
+				continue
+			}
+		}
+		native = append(native, data.Canonical)
+	}
+
+	alternates := buildAlternates(locales.defaultCode, locales.ordered, native)
+	for _, data := range family {
+		if data.Canonical.Locale == data.Locale.Code {
+			data.Alternates = slices.Clone(alternates)
+		}
+		data.Metadata = withAlternateSources(data.Metadata, data.Canonical, data.Alternates)
+	}
+}
+
+// assignCollectionAlternates links collection pages sharing an ID across
+// locales.
+func assignCollectionAlternates(pages []*CollectionPageData, defaultLocale string, locales []LocaleSpec, baseURL string) {
+	families := map[uuid.UUID][]*CollectionPageData{}
+	for _, page := range pages {
+		page.Canonical = LocaleAlternate{Locale: page.Locale.Code, Route: page.Route, URL: absoluteURL(baseURL, page.Route)}
+		families[page.ID] = append(families[page.ID], page)
+	}
+	for _, family := range families {
+		variants := make([]LocaleAlternate, 0, len(family))
+		for _, page := range family {
+			variants = append(variants, page.Canonical)
+		}
+		alternates := buildAlternates(defaultLocale, locales, variants)
+		for _, page := range family {
+			page.Alternates = slices.Clone(alternates)
+			page.Metadata = withAlternateSources(page.Metadata, page.Canonical, page.Alternates)
+		}
+	}
+}
+
+// buildAlternates orders variants by the configured locales and appends the
+// x-default entry, which targets the default locale when it is present. A
+// single variant has no alternates.
+func buildAlternates(defaultLocale string, locales []LocaleSpec, variants []LocaleAlternate) []LocaleAlternate {
+	if len(variants) < 2 {
+		return nil
+	}
+	sorted := slices.Clone(variants)
+	slices.SortStableFunc(sorted, func(a, b LocaleAlternate) int {
+		return cmp.Or(
+			cmp.Compare(localeIndex(locales, a.Locale), localeIndex(locales, b.Locale)),
+			strings.Compare(a.Locale, b.Locale),
+		)
+	})
+	fallback := sorted[0]
+	for _, variant := range sorted {
+		if strings.EqualFold(variant.Locale, defaultLocale) {
+			fallback = variant
+			break
+		}
+	}
+	fallback.Locale = HrefLangDefault
+	return append(sorted, fallback)
+}
+
+func localeIndex(locales []LocaleSpec, code string) int {
+	for i, spec := range locales {
+		if strings.EqualFold(spec.Code, code) {
+			return i
+		}
+	}
+	return len(locales)
+}
+
+// withAlternateSources folds the canonical and alternate routes into the
+// dependency hash so incremental builds re-render pages whose translation
+// family changed.
+func withAlternateSources(metadata DependencyMetadata, canonical LocaleAlternate, alternates []LocaleAlternate) DependencyMetadata {
+	sources := maps.Clone(metadata.Sources)
+	if sources == nil {
+		sources = map[string]string{}
+	}
+	sources["canonical"] = canonical.Route
+	parts := make([]string, 0, len(alternates))
+	for _, alternate := range alternates {
+		parts = append(parts, alternate.Locale+"="+alternate.Route)
+	}
+	sources["alternates"] = joinParts(parts...)
+	metadata.Sources = sources
+	metadata.Hash = hashSources(sources)
+	return metadata
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/google/uuid"
+)
+
+func TestBuildExposesLocaleAlternates(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.GenerateSitemap = true
+	renderer := &recordingRenderer{}
+	storage := &recordingStorage{}
+
+	if _, err := newRebuildTestService(fixtures, renderer, storage, now).Build(context.Background(), BuildOptions{}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	page := renderedPageContext(t, renderer, "/es/empresa")
+	if page.Canonical.URL != "https://example.com/es/empresa" {
+		t.Fatalf("unexpected canonical: %+v", page.Canonical)
+	}
+	if got := alternateURLs(page.Alternates); got != "en=https://example.com/company,es=https://example.com/es/empresa,x-default=https://example.com/company" {
+		t.Fatalf("unexpected alternates: %s", got)
+	}
+
+	sitemap := string(storage.files["dist/sitemap.xml"])
+	for _, link := range []string{
+		`<xhtml:link rel="alternate" hreflang="es" href="https://example.com/es/empresa"/>`,
+		`<xhtml:link rel="alternate" hreflang="x-default" href="https://example.com/vision"/>`,
+	} {
+		if !strings.Contains(sitemap, link) {
+			t.Fatalf("expected %s in sitemap:\n%s", link, sitemap)
+		}
+	}
+}
+
+func TestBuildRendersLocaleFallbacksWithSourceCanonical(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Locales.records["fr"] = &content.Locale{ID: uuid.New(), Code: "fr"}
+	fixtures.Config.Locales = []string{"en", "es", "fr"}
+	fixtures.Config.LocaleFallbacks = map[string][]string{"fr": {"es"}}
+	fixtures.Config.GenerateSitemap = true
+	renderer := &recordingRenderer{}
+	storage := &recordingStorage{}
+
+	if _, err := newRebuildTestService(fixtures, renderer, storage, now).Build(context.Background(), BuildOptions{}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	fallback := renderedPageContext(t, renderer, "/fr/empresa")
+	if fallback.Locale.Code != "fr" || fallback.ContentTranslation.Title != "Empresa" {
+		t.Fatalf("expected Spanish content under the French locale, got %s %q", fallback.Locale.Code, fallback.ContentTranslation.Title)
+	}
+	if fallback.Canonical.URL != "https://example.com/es/empresa" || len(fallback.Alternates) != 0 {
+		t.Fatalf("expected canonical to the source locale without alternates, got %+v %+v", fallback.Canonical, fallback.Alternates)
+	}
+	if _, ok := storage.files["dist/fr/empresa/index.html"]; !ok {
+		t.Fatalf("expected fallback page output")
+	}
+
+	native := renderedPageContext(t, renderer, "/company")
+	if strings.Contains(alternateURLs(native.Alternates), "fr=") {
+		t.Fatalf("expected fallback locale excluded from alternates: %s", alternateURLs(native.Alternates))
+	}
+	if strings.Contains(string(storage.files["dist/sitemap.xml"]), "/fr/") {
+		t.Fatalf("expected non-canonical pages omitted from sitemap:\n%s", storage.files["dist/sitemap.xml"])
+	}
+}
+
+func TestBuildWritesSitemapIndexPastURLLimit(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.GenerateSitemap = true
+	fixtures.Config.SitemapMaxURLs = 1
+	storage := &recordingStorage{}
+
+	if _, err := newRebuildTestService(fixtures, &recordingRenderer{}, storage, now).Build(context.Background(), BuildOptions{}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	index := string(storage.files["dist/sitemap.xml"])
+	if !strings.Contains(index, "<sitemapindex") {
+		t.Fatalf("expected sitemap index:\n%s", index)
+	}
+	for _, name := range []string{"sitemap-en.xml", "sitemap-en-2.xml", "sitemap-es.xml", "sitemap-es-2.xml"} {
+		if !strings.Contains(index, "<loc>https://example.com/"+name+"</loc>") {
+			t.Fatalf("expected %s in index:\n%s", name, index)
+		}
+		if _, ok := storage.files["dist/"+name]; !ok {
+			t.Fatalf("expected %s to be written", name)
+		}
+	}
+	spanish := string(storage.files["dist/sitemap-es.xml"]) + string(storage.files["dist/sitemap-es-2.xml"])
+	if strings.Count(spanish, "<loc>") != 2 || strings.Contains(spanish, "<loc>https://example.com/company</loc>") {
+		t.Fatalf("expected only Spanish URLs in the Spanish sitemaps:\n%s", spanish)
+	}
+}
+
+func renderedPageContext(t *testing.T, renderer *recordingRenderer, route string) PageRenderingContext {
+	t.Helper()
+	renderer.mu.Lock()
+	defer renderer.mu.Unlock()
+	for _, call := range renderer.calls {
+		if call.ctx.Page.Canonical.Route == route || (call.ctx.Page.Translation != nil && call.ctx.Page.Translation.Path == route) {
+			return call.ctx.Page
+		}
+	}
+	t.Fatalf("page %s not rendered", route)
+	return PageRenderingContext{}
+}
+
+func alternateURLs(alternates []LocaleAlternate) string {
+	parts := make([]string, 0, len(alternates))
+	for _, alternate := range alternates {
+		parts = append(parts, alternate.Locale+"="+alternate.URL)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,606 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/google/uuid"
+)
+
+const defaultCollectionPageSize = 10
+
+// Collection grouping modes.
+const (
+	CollectionGroupYear  = "year"
+	CollectionGroupMonth = "month"
+)
+
+var errCollectionInvalid = errors.New("generator: invalid collection")
+
+// collectionNamespace seeds the deterministic IDs used to track collection
+// pages in the incremental manifest.
+var collectionNamespace = uuid.MustParse("6f0f5d4e-8a51-4c39-9d47-3d6f9b0f7a21")
+
+// CollectionConfig describes a paginated listing of content entries rendered
+// through a theme template, such as a blog index or per-year archives.
+//
+// Route is the first page of the listing; later pages are written to
+// "<route>/page/<n>". Routes overrides the route per locale code. Routes of
+// non-default locales are prefixed with the locale code when missing. When GroupBy is set,
+// one paginated listing is rendered per group and the route may use the
+// {year} and {month} placeholders (they are appended when missing).
+//
+// Filters match content status ("status"), content metadata or translation
+// fields; a list value matches when it contains the filter value. Only
+// visible (published) entries are listed unless a status filter is given.
+// ItemRoute builds item links for entries without a path, e.g. "/blog/{slug}".
+type CollectionConfig struct {
+	Name        string
+	ContentType string
+	Template    string
+	Route       string
+	Routes      map[string]string
+	ItemRoute   string
+	PageSize    int
+	SortBy      string
+	SortOrder   string
+	Filters     map[string]string
+	GroupBy     string
+	Feed        bool
+}
+
+// CollectionContext is passed to templates rendering a collection page.
+type CollectionContext struct {
+	Name       string
+	Group      string
+	Items      []CollectionItem
+	Pagination Pagination
+	Archives   []ArchiveLink
+}
+
+// CollectionItem is a single content entry listed on a collection page.
+type CollectionItem struct {
+	Content     *content.Content
+	Translation *content.ContentTranslation
+	Title       string
+	Summary     string
+	URL         string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// Pagination describes the position of a collection page within its listing.
+type Pagination struct {
+	Page       int
+	TotalPages int
+	PageSize   int
+	TotalItems int
+	First      string
+	Last       string
+	Prev       string
+	Next       string
+	Pages      []PageLink
+}
+
+// HasPrev reports whether a previous page exists.
+func (p Pagination) HasPrev() bool {
+	return p.Prev != ""
+}
+
+// HasNext reports whether a following page exists.
+func (p Pagination) HasNext() bool {
+	return p.Next != ""
+}
+
+// PageLink links to one page of a paginated listing.
+type PageLink struct {
+	Number  int
+	URL     string
+	Current bool
+}
+
+// ArchiveLink links to the first page of a grouped collection listing.
+type ArchiveLink struct {
+	Group string
+	URL   string
+	Count int
+}
+
+// CollectionPageData is a single rendered page of a collection for a locale.
+type CollectionPageData struct {
+	// ID identifies the page within its collection and is shared by the
+	// localized variants of the same page.
+	ID           uuid.UUID
+	Collection   string
+	Locale       LocaleSpec
+	Route        string
+	Template     string
+	Context      CollectionContext
+	Menus        map[string][]menus.NavigationNode
+	Metadata     DependencyMetadata
+	Dependencies []string
+	Canonical    LocaleAlternate
+	Alternates   []LocaleAlternate
+}
+
+// loadCollections expands configured collections into per-locale pages.
+func (s *service) loadCollections(ctx context.Context, locales localeSet, caches *buildCaches, scope *buildScope) ([]*CollectionPageData, error) {
+	if len(s.cfg.Collections) == 0 {
+		return nil, nil
+	}
+	var out []*CollectionPageData
+	for _, cfg := range s.cfg.Collections {
+		if err := validateCollection(cfg); err != nil {
+			return nil, err
+		}
+		if scope != nil && !scope.includesCollection(cfg.Name) {
+			continue
+		}
+		contentType, err := s.deps.ContentTypes.GetBySlug(ctx, cfg.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("generator: collection %q content type %q: %w", cfg.Name, cfg.ContentType, err)
+		}
+		if contentType == nil {
+			return nil, fmt.Errorf("generator: collection %q content type %q not found: %w", cfg.Name, cfg.ContentType, errCollectionInvalid)
+		}
+		records, err := s.listContent(ctx, content.WithTranslations(), content.WithContentTypeID(contentType.ID))
+		if err != nil {
+			return nil, err
+		}
+		for _, locale := range locales.ordered {
+			menuSet, err := caches.menus.resolveAll(ctx, s.deps.Menus, locale.Code)
+			if err != nil {
+				return nil, err
+			}
+			items := collectionItems(cfg, contentType.ID, records, locale, locales)
+			for _, page := range planCollectionPages(cfg, locale, locales.defaultCode, items) {
+				page.Menus = menuSet
+				page.Metadata.Sources["menus"] = hashMenus(menuSet)
+				page.Metadata.Hash = hashSources(page.Metadata.Sources)
+				page.Dependencies = s.collectionDependencies(cfg, page)
+				out = append(out, page)
+			}
+		}
+	}
+	assignCollectionAlternates(out, locales.defaultCode, locales.ordered, s.cfg.BaseURL)
+	return out, nil
+}
+
+func validateCollection(cfg CollectionConfig) error {
+	switch {
+	case strings.TrimSpace(cfg.Name) == "":
+		return fmt.Errorf("%w: name is required", errCollectionInvalid)
+	case strings.TrimSpace(cfg.ContentType) == "":
+		return fmt.Errorf("%w: collection %q requires a content type", errCollectionInvalid, cfg.Name)
+	case strings.TrimSpace(cfg.Template) == "":
+		return fmt.Errorf("%w: collection %q requires a template", errCollectionInvalid, cfg.Name)
+	}
+	switch cfg.GroupBy {
+	case "", CollectionGroupYear, CollectionGroupMonth:
+	default:
+		return fmt.Errorf("%w: collection %q has unknown group %q", errCollectionInvalid, cfg.Name, cfg.GroupBy)
+	}
+	return nil
+}
+
+// collectionItems filters and sorts the entries listed by cfg in locale.
+func collectionItems(cfg CollectionConfig, typeID uuid.UUID, records []*content.Content, locale LocaleSpec, locales localeSet) []CollectionItem {
+	_, statusFiltered := cfg.Filters["status"]
+	var items []CollectionItem
+	for _, record := range records {
+		if record == nil || record.ContentTypeID != typeID {
+			continue
+		}
+		if !statusFiltered && !record.IsVisible {
+			continue
+		}
+		translations := indexContentTranslations(record.Translations)
+		translation := translations[locale.LocaleID]
+		if translation == nil {
+			continue
+		}
+		if !matchesCollectionFilters(cfg.Filters, record, translation) {
+			continue
+		}
+		title := strings.TrimSpace(translation.Title)
+		if title == "" {
+			title = record.Slug
+		}
+		summary := ""
+		if translation.Summary != nil {
+			summary = normalizeWhitespace(*translation.Summary)
+		}
+		items = append(items, CollectionItem{
+			Content:     record,
+			Translation: translation,
+			Title:       title,
+			Summary:     summary,
+			URL:         collectionItemURL(cfg, record, translation, locale, locales.defaultCode),
+			PublishedAt: firstNonZeroTime(contentPublishedAt(record), record.CreatedAt),
+			UpdatedAt:   maxTime(record.UpdatedAt, translation.UpdatedAt),
+		})
+	}
+	sortCollectionItems(items, cfg.SortBy, !strings.EqualFold(cfg.SortOrder, "asc"))
+	return items
+}
+
+func matchesCollectionFilters(filters map[string]string, record *content.Content, translation *content.ContentTranslation) bool {
+	for key, want := range filters {
+		if key == "status" {
+			if !strings.EqualFold(record.Status, want) {
+				return false
+			}
+			continue
+		}
+		value, ok := record.Metadata[key]
+		if !ok {
+			value, ok = translation.Content[key]
+		}
+		if !ok || !filterValueMatches(value, want) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterValueMatches(value any, want string) bool {
+	switch typed := value.(type) {
+	case []string:
+		return slices.Contains(typed, want)
+	case []any:
+		for _, entry := range typed {
+			if fmt.Sprint(entry) == want {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(value) == want
+	}
+}
+
+func sortCollectionItems(items []CollectionItem, sortBy string, desc bool) {
+	key := func(item CollectionItem) string {
+		switch sortBy {
+		case "title":
+			return strings.ToLower(item.Title)
+		case "slug":
+			return item.Content.Slug
+		case "updated_at":
+			return item.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		case "created_at":
+			return item.Content.CreatedAt.UTC().Format(time.RFC3339Nano)
+		default:
+			return item.PublishedAt.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	slices.SortStableFunc(items, func(a, b CollectionItem) int {
+		cmp := strings.Compare(key(a), key(b))
+		if cmp == 0 {
+			cmp = strings.Compare(a.Content.Slug, b.Content.Slug)
+		}
+		if desc {
+			return -cmp
+		}
+		return cmp
+	})
+}
+
+func collectionItemURL(cfg CollectionConfig, record *content.Content, translation *content.ContentTranslation, locale LocaleSpec, defaultLocale string) string {
+	if route := firstNonEmpty(pathFromTranslation(translation), pathFromMetadata(record.Metadata)); route != "" {
+		return route
+	}
+	if strings.TrimSpace(cfg.ItemRoute) == "" {
+		return ""
+	}
+	route := strings.ReplaceAll(cfg.ItemRoute, "{slug}", record.Slug)
+	return localizeRoute(route, locale.Code, defaultLocale)
+}
+
+// planCollectionPages splits items into groups and pages.
+func planCollectionPages(cfg CollectionConfig, locale LocaleSpec, defaultLocale string, items []CollectionItem) []*CollectionPageData {
+	baseRoute := collectionRoute(cfg, locale.Code, defaultLocale)
+	pageSize := cfg.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultCollectionPageSize
+	}
+
+	type group struct {
+		key   string
+		route string
+		items []CollectionItem
+	}
+	var groups []*group
+	if cfg.GroupBy == "" {
+		groups = append(groups, &group{route: baseRoute, items: items})
+	} else {
+		byKey := map[string]*group{}
+		for _, item := range items {
+			key, year, month := groupKey(cfg.GroupBy, item.PublishedAt)
+			g := byKey[key]
+			if g == nil {
+				route := strings.NewReplacer("{year}", year, "{month}", month).Replace(baseRoute)
+				g = &group{key: key, route: route}
+				byKey[key] = g
+				groups = append(groups, g)
+			}
+			g.items = append(g.items, item)
+		}
+		slices.SortFunc(groups, func(a, b *group) int { return strings.Compare(b.key, a.key) })
+	}
+
+	archives := make([]ArchiveLink, 0, len(groups))
+	if cfg.GroupBy != "" {
+		for _, g := range groups {
+			archives = append(archives, ArchiveLink{Group: g.key, URL: g.route, Count: len(g.items)})
+		}
+	}
+
+	var out []*CollectionPageData
+	for _, g := range groups {
+		totalPages := max(1, (len(g.items)+pageSize-1)/pageSize)
+		for number := 1; number <= totalPages; number++ {
+			start := (number - 1) * pageSize
+			end := min(start+pageSize, len(g.items))
+			pageItems := g.items[start:end]
+			pagination := buildPagination(g.route, number, totalPages, pageSize, len(g.items))
+			route := pagination.Pages[number-1].URL
+			out = append(out, &CollectionPageData{
+				ID:         uuid.NewSHA1(collectionNamespace, []byte(cfg.Name+"|"+g.key+"|"+strconv.Itoa(number))),
+				Collection: cfg.Name,
+				Locale:     locale,
+				Route:      route,
+				Template:   cfg.Template,
+				Context: CollectionContext{
+					Name:       cfg.Name,
+					Group:      g.key,
+					Items:      pageItems,
+					Pagination: pagination,
+					Archives:   archives,
+				},
+				Metadata: collectionMetadata(cfg, route, pagination, pageItems, archives),
+			})
+		}
+	}
+	return out
+}
+
+func groupKey(mode string, published time.Time) (key, year, month string) {
+	published = published.UTC()
+	year = strconv.Itoa(published.Year())
+	month = fmt.Sprintf("%02d", int(published.Month()))
+	if mode == CollectionGroupMonth {
+		return year + "/" + month, year, month
+	}
+	return year, year, month
+}
+
+// collectionRoute returns the localized route of the first page, ensuring
+// grouped collections carry their placeholders.
+func collectionRoute(cfg CollectionConfig, locale, defaultLocale string) string {
+	route, ok := cfg.Routes[locale]
+	if !ok {
+		route = cfg.Route
+	}
+	route = "/" + strings.Trim(strings.TrimSpace(route), "/")
+	switch cfg.GroupBy {
+	case CollectionGroupYear:
+		if !strings.Contains(route, "{year}") {
+			route = path.Join(route, "{year}")
+		}
+	case CollectionGroupMonth:
+		if !strings.Contains(route, "{year}") {
+			route = path.Join(route, "{year}")
+		}
+		if !strings.Contains(route, "{month}") {
+			route = path.Join(route, "{month}")
+		}
+	}
+	return localizeRoute(route, locale, defaultLocale)
+}
+
+func localizeRoute(route, locale, defaultLocale string) string {
+	route = "/" + strings.TrimLeft(strings.TrimSpace(route), "/")
+	if locale == "" || strings.EqualFold(locale, defaultLocale) {
+		return route
+	}
+	prefix := "/" + locale
+	if route == prefix || strings.HasPrefix(route, prefix+"/") {
+		return route
+	}
+	if route == "/" {
+		return prefix
+	}
+	return prefix + route
+}
+
+func buildPagination(route string, current, total, size, items int) Pagination {
+	link := func(number int) string {
+		if number == 1 {
+			return route
+		}
+		return path.Join(route, "page", strconv.Itoa(number))
+	}
+	pagination := Pagination{
+		Page:       current,
+		TotalPages: total,
+		PageSize:   size,
+		TotalItems: items,
+		First:      link(1),
+		Last:       link(total),
+		Pages:      make([]PageLink, 0, total),
+	}
+	if current > 1 {
+		pagination.Prev = link(current - 1)
+	}
+	if current < total {
+		pagination.Next = link(current + 1)
+	}
+	for number := 1; number <= total; number++ {
+		pagination.Pages = append(pagination.Pages, PageLink{Number: number, URL: link(number), Current: number == current})
+	}
+	return pagination
+}
+
+func collectionMetadata(cfg CollectionConfig, route string, pagination Pagination, items []CollectionItem, archives []ArchiveLink) DependencyMetadata {
+	entries := make([]string, 0, len(items))
+	var lastModified time.Time
+	for _, item := range items {
+		entries = append(entries, item.Content.ID.String()+"@"+item.UpdatedAt.UTC().Format(time.RFC3339Nano)+"@"+item.URL)
+		lastModified = maxTime(lastModified, item.UpdatedAt)
+	}
+	groups := make([]string, 0, len(archives))
+	for _, archive := range archives {
+		groups = append(groups, archive.Group+"="+strconv.Itoa(archive.Count))
+	}
+	return DependencyMetadata{
+		Sources: map[string]string{
+			"collection": hashStrings([]string{cfg.Name, cfg.Template, route}),
+			"pagination": hashStrings([]string{strconv.Itoa(pagination.Page), strconv.Itoa(pagination.TotalPages), strconv.Itoa(pagination.TotalItems)}),
+			"items":      hashStrings(entries),
+			"archives":   hashStrings(groups),
+		},
+		LastModified: lastModified,
+	}
+}
+
+// renderCollectionPage renders one collection page through its template.
+func (s *service) renderCollectionPage(
+	ctx context.Context,
+	siteMeta SiteMetadata,
+	buildCtx *BuildContext,
+	data *CollectionPageData,
+	manifest *buildManifest,
+	baseDir string,
+	force bool,
+) renderOutcome {
+	renderCtx, cancel := withTimeout(ctx, s.cfg.RenderTimeout)
+	defer cancel()
+	outcome := renderOutcome{
+		diagnostic: RenderDiagnostic{
+			PageID:   data.ID,
+			Locale:   data.Locale.Code,
+			Route:    data.Route,
+			Template: data.Template,
+		},
+	}
+	if err := renderCtx.Err(); err != nil {
+		outcome.err, outcome.diagnostic.Err = err, err
+		return outcome
+	}
+
+	if s.cfg.Incremental && manifest != nil && !force {
+		output := joinOutputPath(baseDir, buildOutputPath(data.Route, data.Locale.Code, buildCtx.DefaultLocale))
+		if manifest.shouldSkipPage(data.ID, data.Locale.Code, data.Metadata.Hash, output) {
+			outcome.skipped, outcome.diagnostic.Skipped = true, true
+			return outcome
+		}
+	}
+
+	collection := data.Context
+	templateCtx := TemplateContext{
+		Site: siteMeta,
+		Page: PageRenderingContext{
+			Menus:      data.Menus,
+			Locale:     data.Locale,
+			Metadata:   data.Metadata,
+			Canonical:  data.Canonical,
+			Alternates: data.Alternates,
+		},
+		Collection: &collection,
+		Build: BuildMetadata{
+			GeneratedAt: buildCtx.GeneratedAt,
+			Options:     buildCtx.Options,
+		},
+		Theme:   buildThemeContext(nil, s.cfg.Theming),
+		Helpers: newTemplateHelpers(siteMeta.DefaultLocale, data.Locale, siteMeta.BaseURL),
+	}
+
+	type renderResult struct {
+		html string
+		err  error
+	}
+	start := time.Now()
+	results := make(chan renderResult, 1)
+	go func() {
+		html, err := s.deps.Renderer.RenderTemplate(data.Template, templateCtx)
+		results <- renderResult{html: html, err: err}
+	}()
+	var html string
+	select {
+	case <-renderCtx.Done():
+		outcome.diagnostic.Duration = time.Since(start)
+		err := fmt.Errorf("generator: render collection %q template %q (%s %s) timed out: %w", data.Collection, data.Template, data.Locale.Code, data.Route, renderCtx.Err())
+		outcome.err, outcome.diagnostic.Err = err, err
+		return outcome
+	case res := <-results:
+		outcome.diagnostic.Duration = time.Since(start)
+		if res.err != nil {
+			wrapped := fmt.Errorf("generator: render collection %q template %q (%s %s): %w", data.Collection, data.Template, data.Locale.Code, data.Route, res.err)
+			outcome.err, outcome.diagnostic.Err = wrapped, wrapped
+			return outcome
+		}
+		html = res.html
+	}
+	outcome.page = RenderedPage{
+		PageID:       data.ID,
+		Collection:   data.Collection,
+		Locale:       data.Locale.Code,
+		Route:        data.Route,
+		Template:     data.Template,
+		HTML:         html,
+		Metadata:     data.Metadata,
+		Duration:     outcome.diagnostic.Duration,
+		Dependencies: data.Dependencies,
+		Canonical:    data.Canonical.Route,
+	}
+	return outcome
+}
+
+// buildCollectionFeeds returns one feed per collection and locale for
+// collections with Feed enabled, written next to the first listing page.
+func (s *service) buildCollectionFeeds(buildCtx *BuildContext) []feedDocument {
+	enabled := map[string]bool{}
+	for _, cfg := range s.cfg.Collections {
+		if cfg.Feed && cfg.GroupBy == "" {
+			enabled[cfg.Name] = true
+		}
+	}
+	var docs []feedDocument
+	for _, data := range buildCtx.Collections {
+		if !enabled[data.Collection] || data.Context.Pagination.Page != 1 {
+			continue
+		}
+		doc := feedDocument{
+			Locale:     data.Locale,
+			Collection: data.Collection,
+			Dir:        path.Dir(buildOutputPath(data.Route, data.Locale.Code, buildCtx.DefaultLocale)),
+			Link:       data.Route,
+		}
+		for _, item := range data.Context.Items {
+			if item.URL == "" {
+				continue
+			}
+			doc.Items = append(doc.Items, feedItem{
+				Title:       item.Title,
+				Summary:     item.Summary,
+				Link:        absoluteURL(s.cfg.BaseURL, item.URL),
+				GUID:        fmt.Sprintf("%s:%s", item.Content.ID.String(), data.Locale.Code),
+				PublishedAt: item.PublishedAt,
+				UpdatedAt:   item.UpdatedAt,
+			})
+		}
+		if len(doc.Items) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
@@ -0,0 +1,300 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/google/uuid"
+)
+
+func TestBuildRendersPaginatedCollections(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	addCollectionArticles(fixtures, now)
+	fixtures.Config.GenerateSitemap = true
+	fixtures.Config.GenerateFeeds = true
+	fixtures.Config.Collections = []CollectionConfig{{
+		Name:        "blog",
+		ContentType: "article",
+		Template:    "themes/list.html",
+		Route:       "/blog",
+		ItemRoute:   "/blog/{slug}",
+		PageSize:    2,
+		Feed:        true,
+	}}
+
+	renderer := &collectionRenderer{}
+	storage := &recordingStorage{}
+	svc := newCollectionTestService(fixtures, renderer, storage, now)
+
+	result, err := svc.Build(ctx, BuildOptions{})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	first := renderer.collection(t, "en", "/blog")
+	if first.Pagination.TotalPages != 2 || first.Pagination.TotalItems != 4 {
+		t.Fatalf("unexpected pagination: %+v", first.Pagination)
+	}
+	if first.Pagination.HasPrev() || first.Pagination.Next != "/blog/page/2" {
+		t.Fatalf("unexpected first page links: %+v", first.Pagination)
+	}
+	if got := itemSlugs(first.Items); got != "post-4,post-3" {
+		t.Fatalf("expected newest items first, got %s", got)
+	}
+	if first.Items[0].URL != "/blog/post-4" {
+		t.Fatalf("expected item route, got %q", first.Items[0].URL)
+	}
+
+	second := renderer.collection(t, "en", "/blog/page/2")
+	if second.Pagination.Prev != "/blog" || second.Pagination.HasNext() || !second.Pagination.Pages[1].Current {
+		t.Fatalf("unexpected second page links: %+v", second.Pagination)
+	}
+	if got := itemSlugs(second.Items); got != "post-2,post-1" {
+		t.Fatalf("unexpected second page items: %s", got)
+	}
+
+	spanish := renderer.collection(t, "es", "/es/blog")
+	if got := itemSlugs(spanish.Items); got != "post-1" {
+		t.Fatalf("expected localized listing, got %s", got)
+	}
+	if spanish.Items[0].URL != "/es/blog/post-1" {
+		t.Fatalf("expected localized item route, got %q", spanish.Items[0].URL)
+	}
+	if got := alternateURLs(renderer.pageContext(t, "es", "/es/blog").Alternates); got != "en=https://example.com/blog,es=https://example.com/es/blog,x-default=https://example.com/blog" {
+		t.Fatalf("expected localized listings linked as alternates, got %s", got)
+	}
+
+	if result.PagesBuilt != fixtures.LocalizedCount()+3 {
+		t.Fatalf("expected pages and collection pages built, got %d", result.PagesBuilt)
+	}
+	for _, output := range []string{"dist/blog/index.html", "dist/blog/page/2/index.html", "dist/es/blog/index.html", "dist/blog/feed.xml", "dist/blog/feed.atom.xml"} {
+		if _, ok := storage.files[output]; !ok {
+			t.Fatalf("expected %s to be written", output)
+		}
+	}
+	if !strings.Contains(string(storage.files["dist/blog/feed.xml"]), "https://example.com/blog/post-4") {
+		t.Fatalf("expected collection feed to list items:\n%s", storage.files["dist/blog/feed.xml"])
+	}
+	if !strings.Contains(string(storage.files["dist/sitemap.xml"]), "https://example.com/blog/page/2") {
+		t.Fatalf("expected collection pages in sitemap:\n%s", storage.files["dist/sitemap.xml"])
+	}
+}
+
+func TestBuildSkipsUnchangedCollectionPages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	articles := addCollectionArticles(fixtures, now)
+	fixtures.Config.Incremental = true
+	fixtures.Config.Locales = []string{"en"}
+	fixtures.Config.Collections = []CollectionConfig{{
+		Name:        "blog",
+		ContentType: "article",
+		Template:    "themes/list.html",
+		Route:       "/blog",
+		PageSize:    2,
+	}}
+
+	storage := &recordingStorage{}
+	if _, err := newCollectionTestService(fixtures, &collectionRenderer{}, storage, now).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("initial build: %v", err)
+	}
+
+	renderer := &collectionRenderer{}
+	result, err := newCollectionTestService(fixtures, renderer, storage, now.Add(time.Hour)).Build(ctx, BuildOptions{})
+	if err != nil {
+		t.Fatalf("incremental build: %v", err)
+	}
+	if result.PagesBuilt != 0 || len(renderer.collections()) != 0 {
+		t.Fatalf("expected unchanged collection pages to be skipped, built %d", result.PagesBuilt)
+	}
+
+	articles[0].UpdatedAt = now.Add(time.Minute)
+	renderer = &collectionRenderer{}
+	if _, err := newCollectionTestService(fixtures, renderer, storage, now.Add(2*time.Hour)).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	rebuilt := renderer.collections()
+	if len(rebuilt) != 1 || rebuilt[0].route != "/blog/page/2" {
+		t.Fatalf("expected only the page listing the updated item to rebuild, got %+v", rebuilt)
+	}
+}
+
+func TestBuildGroupsCollectionArchivesByYear(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	articles := addCollectionArticles(fixtures, now)
+	articles[0].PublishedAt = new(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+	fixtures.Config.Locales = []string{"en"}
+	fixtures.Config.Collections = []CollectionConfig{{
+		Name:        "archive",
+		ContentType: "article",
+		Template:    "themes/archive.html",
+		Route:       "/archive",
+		GroupBy:     CollectionGroupYear,
+	}}
+
+	renderer := &collectionRenderer{}
+	if _, err := newCollectionTestService(fixtures, renderer, &recordingStorage{}, now).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	current := renderer.collection(t, "en", "/archive/2024")
+	if current.Group != "2024" || itemSlugs(current.Items) != "post-4,post-3,post-2" {
+		t.Fatalf("unexpected 2024 archive: %s %s", current.Group, itemSlugs(current.Items))
+	}
+	previous := renderer.collection(t, "en", "/archive/2023")
+	if itemSlugs(previous.Items) != "post-1" {
+		t.Fatalf("unexpected 2023 archive: %s", itemSlugs(previous.Items))
+	}
+	if len(current.Archives) != 2 || current.Archives[1].URL != "/archive/2023" || current.Archives[1].Count != 1 {
+		t.Fatalf("unexpected archive links: %+v", current.Archives)
+	}
+}
+
+func TestBuildRejectsInvalidCollection(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.Collections = []CollectionConfig{{Name: "blog", ContentType: "article", Template: "themes/list.html", GroupBy: "week"}}
+
+	_, err := newCollectionTestService(fixtures, &collectionRenderer{}, &recordingStorage{}, now).Build(context.Background(), BuildOptions{})
+	if err == nil || !strings.Contains(err.Error(), "unknown group") {
+		t.Fatalf("expected invalid collection error, got %v", err)
+	}
+}
+
+func newCollectionTestService(fixtures renderFixtures, renderer *collectionRenderer, storage *recordingStorage, now time.Time) *service {
+	svc := NewService(fixtures.Config, Dependencies{
+		Content:      fixtures.Content,
+		ContentTypes: fixtures.ContentTypes,
+		Menus:        fixtures.Menus,
+		Themes:       fixtures.Themes,
+		Locales:      fixtures.Locales,
+		Renderer:     renderer,
+		Storage:      storage,
+		Logger:       logging.NoOp(),
+	}).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+// addCollectionArticles registers an article type with four published posts
+// (post-1 oldest, the only one translated to Spanish) and one draft.
+func addCollectionArticles(fixtures renderFixtures, now time.Time) []*content.Content {
+	articleTypeID := uuid.New()
+	articleType := &content.ContentType{ID: articleTypeID, Slug: "article"}
+	fixtures.ContentTypes.bySlug["article"] = articleType
+	fixtures.ContentTypes.byID[articleTypeID] = articleType
+
+	localeEN := fixtures.Locales.records["en"].ID
+	localeES := fixtures.Locales.records["es"].ID
+	var articles []*content.Content
+	for i := 1; i <= 5; i++ {
+		id := uuid.New()
+		record := &content.Content{
+			ID:            id,
+			ContentTypeID: articleTypeID,
+			Slug:          fmt.Sprintf("post-%d", i),
+			Status:        "published",
+			PublishedAt:   new(now.AddDate(0, 0, -10+i)),
+			UpdatedAt:     now.Add(-time.Hour),
+			IsVisible:     i < 5,
+			Translations: []*content.ContentTranslation{{
+				ID:        uuid.New(),
+				ContentID: id,
+				LocaleID:  localeEN,
+				Title:     fmt.Sprintf("Post %d", i),
+				UpdatedAt: now.Add(-time.Hour),
+			}},
+		}
+		if i == 5 {
+			record.Status = "draft"
+		}
+		if i == 1 {
+			record.Translations = append(record.Translations, &content.ContentTranslation{
+				ID:        uuid.New(),
+				ContentID: id,
+				LocaleID:  localeES,
+				Title:     "Entrada 1",
+				UpdatedAt: now.Add(-time.Hour),
+			})
+		}
+		fixtures.Content.records[id] = record
+		fixtures.Content.listing = append(fixtures.Content.listing, record)
+		articles = append(articles, record)
+	}
+	return articles
+}
+
+func itemSlugs(items []CollectionItem) string {
+	slugs := make([]string, 0, len(items))
+	for _, item := range items {
+		slugs = append(slugs, item.Content.Slug)
+	}
+	return strings.Join(slugs, ",")
+}
+
+type collectionRender struct {
+	locale string
+	route  string
+	ctx    CollectionContext
+	page   PageRenderingContext
+}
+
+type collectionRenderer struct {
+	recordingRenderer
+	mu       sync.Mutex
+	rendered []collectionRender
+}
+
+func (r *collectionRenderer) RenderTemplate(name string, data any, out ...io.Writer) (string, error) {
+	ctx, ok := data.(TemplateContext)
+	if !ok {
+		return "", fmt.Errorf("unexpected render data type %T", data)
+	}
+	if ctx.Collection == nil {
+		return r.recordingRenderer.RenderTemplate(name, data, out...)
+	}
+	route := ctx.Collection.Pagination.Pages[ctx.Collection.Pagination.Page-1].URL
+	r.mu.Lock()
+	r.rendered = append(r.rendered, collectionRender{locale: ctx.Page.Locale.Code, route: route, ctx: *ctx.Collection, page: ctx.Page})
+	r.mu.Unlock()
+	return fmt.Sprintf("<html data-collection=%q data-route=%q></html>", ctx.Collection.Name, route), nil
+}
+
+func (r *collectionRenderer) collections() []collectionRender {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]collectionRender(nil), r.rendered...)
+}
+
+func (r *collectionRenderer) collection(t *testing.T, locale, route string) CollectionContext {
+	t.Helper()
+	return r.render(t, locale, route).ctx
+}
+
+func (r *collectionRenderer) pageContext(t *testing.T, locale, route string) PageRenderingContext {
+	t.Helper()
+	return r.render(t, locale, route).page
+}
+
+func (r *collectionRenderer) render(t *testing.T, locale, route string) collectionRender {
+	t.Helper()
+	for _, entry := range r.collections() {
+		if entry.locale == locale && entry.route == route {
+			return entry
+		}
+	}
+	t.Fatalf("collection page %s %s not rendered; got %+v", locale, route, r.collections())
+	return collectionRender{}
+}
@@ -35,6 +35,7 @@ type BuildContext struct {
 	DefaultLocale string
 	Locales       []LocaleSpec
 	Pages         []*PageData
+	Collections   []*CollectionPageData
 	MenuAliases   map[string]string
 	Options       BuildOptions
 }
@@ -60,6 +61,9 @@ type PageData struct {
 	Theme              *themes.Theme
 	ThemeSelection     *gotheme.Selection
 	Metadata           DependencyMetadata
+	Dependencies       []string
+	Canonical          LocaleAlternate
+	Alternates         []LocaleAlternate
 }
 
 // DependencyMetadata tracks hashes and timestamps for incremental builds.
@@ -70,6 +74,12 @@ type DependencyMetadata struct {
 }
 
 func (s *service) loadContext(ctx context.Context, opts BuildOptions) (*BuildContext, error) {
+	return s.loadScopedContext(ctx, opts, nil)
+}
+
+// loadScopedContext loads the build context, limited to the pages and
+// collections selected by scope when it is set.
+func (s *service) loadScopedContext(ctx context.Context, opts BuildOptions, scope *buildScope) (*BuildContext, error) {
 	if s.deps.Content == nil {
 		return nil, errContentServiceRequired
 	}
@@ -85,9 +95,16 @@ func (s *service) loadContext(ctx context.Context, opts BuildOptions) (*BuildCon
 		return nil, err
 	}
 
-	pagesToBuild, err := s.loadPages(ctx, opts.PageIDs)
-	if err != nil {
-		return nil, err
+	pageIDs := opts.PageIDs
+	if scope != nil {
+		pageIDs = scope.pageIDs()
+	}
+	var pagesToBuild []*pages.Page
+	if scope == nil || len(pageIDs) > 0 {
+		pagesToBuild, err = s.loadPages(ctx, pageIDs)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	caches := newBuildCaches(s.cfg.Menus)
@@ -106,7 +123,22 @@ func (s *service) loadContext(ctx context.Context, opts BuildOptions) (*BuildCon
 		if err != nil {
 			return nil, err
 		}
-		pageContexts = append(pageContexts, localized...)
+		s.assignPageAlternates(localized, localeSet)
+		for _, data := range localized {
+			if scope != nil && !scope.includesPage(data.Page.ID, data.Locale.Code) {
+				continue
+			}
+			data.Dependencies = s.pageDependencies(data)
+			pageContexts = append(pageContexts, data)
+		}
+	}
+
+	var collections []*CollectionPageData
+	if scope != nil || len(opts.PageIDs) == 0 {
+		collections, err = s.loadCollections(ctx, localeSet, caches, scope)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	context := &BuildContext{
@@ -114,6 +146,7 @@ func (s *service) loadContext(ctx context.Context, opts BuildOptions) (*BuildCon
 		DefaultLocale: localeSet.defaultCode,
 		Locales:       localeSet.ordered,
 		Pages:         pageContexts,
+		Collections:   collections,
 		MenuAliases:   maps.Clone(s.cfg.Menus),
 		Options:       opts,
 	}
@@ -125,6 +158,9 @@ type localeSet struct {
 	byID        map[uuid.UUID]LocaleSpec
 	defaultCode string
 	defaultID   uuid.UUID
+	// fallbacks lists, per locale, the locales whose content is rendered when
+	// the page has no translation of its own.
+	fallbacks map[uuid.UUID][]LocaleSpec
 }
 
 func (s *service) resolveLocales(ctx context.Context, opts BuildOptions) (localeSet, error) {
@@ -203,7 +239,7 @@ func (s *service) resolveLocales(ctx context.Context, opts BuildOptions) (locale
 				return localeSet{}, err
 			}
 			set.defaultID = record.ID
-			return set, nil
+			return s.withLocaleFallbacks(ctx, set)
 		}
 		record, err := s.deps.Locales.GetByCode(ctx, defaultLocale)
 		if err != nil {
@@ -225,6 +261,36 @@ func (s *service) resolveLocales(ctx context.Context, opts BuildOptions) (locale
 		set.ordered = reorderWithDefaultFirst(set.ordered, set.defaultID)
 	}
 
+	return s.withLocaleFallbacks(ctx, set)
+}
+
+func (s *service) withLocaleFallbacks(ctx context.Context, set localeSet) (localeSet, error) {
+	if len(s.cfg.LocaleFallbacks) == 0 {
+		return set, nil
+	}
+	set.fallbacks = make(map[uuid.UUID][]LocaleSpec, len(set.ordered))
+	for _, spec := range set.ordered {
+		for code, chain := range s.cfg.LocaleFallbacks {
+			if !strings.EqualFold(strings.TrimSpace(code), spec.Code) {
+				continue
+			}
+			for _, fallback := range chain {
+				fallback = strings.TrimSpace(fallback)
+				if fallback == "" || strings.EqualFold(fallback, spec.Code) {
+					continue
+				}
+				record, err := s.deps.Locales.GetByCode(ctx, fallback)
+				if err != nil {
+					return localeSet{}, err
+				}
+				set.fallbacks[spec.LocaleID] = append(set.fallbacks[spec.LocaleID], LocaleSpec{
+					Code:      record.Code,
+					LocaleID:  record.ID,
+					IsDefault: strings.EqualFold(record.Code, set.defaultCode),
+				})
+			}
+		}
+	}
 	return set, nil
 }
 
@@ -257,13 +323,17 @@ func (s *service) loadPages(ctx context.Context, ids []uuid.UUID) ([]*pages.Page
 		return nil, nil
 	}
 	if len(ids) == 0 {
-		records, err := s.deps.Content.List(ctx, content.WithTranslations())
+		records, err := s.listContent(ctx, content.WithTranslations())
 		if err != nil {
 			return nil, err
 		}
 		return buildPagesFromContent(records, pageTypeID), nil
 	}
 
+	pageSites, err := s.pageSites(ctx)
+	if err != nil {
+		return nil, err
+	}
 	unique := make(map[uuid.UUID]struct{}, len(ids))
 	var result []*pages.Page
 	for _, id := range ids {
@@ -276,12 +346,17 @@ func (s *service) loadPages(ctx context.Context, ids []uuid.UUID) ([]*pages.Page
 		unique[id] = struct{}{}
 		record, err := s.deps.Content.Get(ctx, id, content.WithTranslations())
 		if err != nil {
+			var notFound *content.NotFoundError
+			if errors.As(err, &notFound) {
+				// Deleted pages are skipped so scoped rebuilds can remove their output.
+				continue
+			}
 			return nil, err
 		}
 		if record == nil {
 			continue
 		}
-		if !isPageContent(record, pageTypeID) {
+		if !isPageContent(record, pageTypeID) || !s.includesSite(recordSiteID(record, pageSites)) {
 			continue
 		}
 		if page := pageFromContentEntry(record); page != nil {
@@ -409,6 +484,7 @@ func pageFromContentEntry(record *content.Content) *pages.Page {
 		PublishedAt:      record.PublishedAt,
 		PublishedBy:      record.PublishedBy,
 		EnvironmentID:    record.EnvironmentID,
+		SiteID:           record.SiteID,
 		CreatedBy:        record.CreatedBy,
 		UpdatedBy:        record.UpdatedBy,
 		CreatedAt:        record.CreatedAt,
@@ -631,6 +707,14 @@ func (s *service) buildPageData(
 		return nil, nil
 	}
 
+	if page.Blocks == nil && s.deps.Blocks != nil {
+		instances, err := s.deps.Blocks.ListPageInstances(ctx, page.ID)
+		if err != nil {
+			return nil, err
+		}
+		page.Blocks = instances
+	}
+
 	template, err := caches.template(ctx, s.deps.Themes, page.TemplateID)
 	if err != nil {
 		return nil, err
@@ -656,27 +740,10 @@ func (s *service) buildPageData(
 	contentTranslations := indexContentTranslations(contentRecord.Translations)
 
 	var localized []*PageData
-	for localeID, translation := range pageTranslations {
-		localeSpec, ok := locales.byID[localeID]
-		if !ok {
-			continue
-		}
-		if strings.TrimSpace(translation.Path) == "" {
-			continue
-		}
-
-		contentTranslation := contentTranslations[localeID]
-		if contentTranslation == nil && locales.defaultID != uuid.Nil {
-			contentTranslation = contentTranslations[locales.defaultID]
-		}
-		if contentTranslation == nil {
-			// Without content translation, generating the page is risky; skip the locale.
-			continue
-		}
-
+	appendLocale := func(localeSpec LocaleSpec, translation *pages.PageTranslation, contentTranslation *content.ContentTranslation) error {
 		menuSet, err := caches.menus.resolveAll(ctx, s.deps.Menus, localeSpec.Code)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		metadata := computeDependencyMetadata(page, translation, contentRecord, contentTranslation, menuSet, template, theme)
@@ -696,6 +763,50 @@ func (s *service) buildPageData(
 			ThemeSelection:     selection,
 			Metadata:           metadata,
 		})
+		return nil
+	}
+
+	for localeID, translation := range pageTranslations {
+		localeSpec, ok := locales.byID[localeID]
+		if !ok {
+			continue
+		}
+		if strings.TrimSpace(translation.Path) == "" {
+			continue
+		}
+
+		contentTranslation := contentTranslations[localeID]
+		if contentTranslation == nil && locales.defaultID != uuid.Nil {
+			contentTranslation = contentTranslations[locales.defaultID]
+		}
+		if contentTranslation == nil {
+			// Without content translation, generating the page is risky; skip the locale.
+			continue
+		}
+		if err := appendLocale(localeSpec, translation, contentTranslation); err != nil {
+			return nil, err
+		}
+	}
+
+	// Locales with configured fallbacks render the first fallback translation
+	// under their own locale prefix.
+	for _, localeSpec := range locales.ordered {
+		if _, ok := pageTranslations[localeSpec.LocaleID]; ok {
+			continue
+		}
+		for _, fallback := range locales.fallbacks[localeSpec.LocaleID] {
+			source := pageTranslations[fallback.LocaleID]
+			contentTranslation := contentTranslations[fallback.LocaleID]
+			if source == nil || contentTranslation == nil || strings.TrimSpace(source.Path) == "" {
+				continue
+			}
+			translation := *source
+			translation.Path = localizeRoute(delocalizeRoute(source.Path, fallback.Code), localeSpec.Code, locales.defaultCode)
+			if err := appendLocale(localeSpec, &translation, contentTranslation); err != nil {
+				return nil, err
+			}
+			break
+		}
 	}
 
 	return localized, nil
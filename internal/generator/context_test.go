@@ -10,6 +10,7 @@ import (
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/logging"
 	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/sites"
 	"github.com/goliatone/go-cms/internal/themes"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
@@ -380,7 +381,7 @@ func (s *stubContentService) Create(context.Context, content.CreateContentReques
 func (s *stubContentService) Get(_ context.Context, id uuid.UUID, _ ...content.ContentGetOption) (*content.Content, error) {
 	rec, ok := s.records[id]
 	if !ok {
-		return nil, errUnsupported
+		return nil, &content.NotFoundError{Resource: "content", Key: id.String()}
 	}
 	return rec, nil
 }
@@ -408,6 +409,14 @@ func (s *stubContentService) Delete(context.Context, content.DeleteContentReques
 	return errUnsupported
 }
 
+func (s *stubContentService) ListTrashed(context.Context, content.ListTrashedContentRequest) ([]*content.Content, error) {
+	return nil, errUnsupported
+}
+
+func (s *stubContentService) RestoreDeleted(context.Context, content.RestoreContentRequest) (*content.Content, error) {
+	return nil, errUnsupported
+}
+
 func (s *stubContentService) UpdateTranslation(context.Context, content.UpdateContentTranslationRequest) (*content.ContentTranslation, error) {
 	return nil, errUnsupported
 }
@@ -497,6 +506,7 @@ func (s *stubContentTypeService) Find(context.Context, string, ...string) ([]*co
 
 type stubMenusService struct {
 	calls map[string]int
+	sites []string
 }
 
 func newStubMenuService() *stubMenusService {
@@ -577,8 +587,11 @@ func (s *stubMenusService) GetMenuItemByExternalCode(context.Context, string, st
 	return nil, errUnsupported
 }
 
-func (s *stubMenusService) ResolveNavigation(_ context.Context, menuCode string, locale string, _ ...string) ([]menus.NavigationNode, error) {
+func (s *stubMenusService) ResolveNavigation(ctx context.Context, menuCode string, locale string, _ ...string) ([]menus.NavigationNode, error) {
 	s.calls[locale]++
+	if site, ok := sites.FromContext(ctx); ok {
+		s.sites = append(s.sites, site.Key)
+	}
 	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte(menuCode+"-"+locale))
 	return []menus.NavigationNode{
 		{
@@ -609,6 +622,14 @@ func (s *stubMenusService) DeleteMenuItem(context.Context, menus.DeleteMenuItemR
 	return errUnsupported
 }
 
+func (s *stubMenusService) ListTrashedMenuItems(context.Context, menus.ListTrashedMenuItemsRequest) ([]*menus.MenuItem, error) {
+	return nil, errUnsupported
+}
+
+func (s *stubMenusService) RestoreDeletedMenuItem(context.Context, menus.RestoreMenuItemRequest) (*menus.MenuItem, error) {
+	return nil, errUnsupported
+}
+
 func (s *stubMenusService) BulkReorderMenuItems(context.Context, menus.BulkReorderMenuItemsInput) ([]*menus.MenuItem, error) {
 	return nil, errUnsupported
 }
@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/google/uuid"
+)
+
+// Dependency kinds recorded in the reverse dependency index. Keys combine a
+// kind and an identifier, e.g. "content:<uuid>" or "menu:primary".
+const (
+	DependencyContent     = "content"
+	DependencyContentType = "content_type"
+	DependencyPage        = "page"
+	DependencyMenu        = "menu"
+	DependencyWidget      = "widget"
+	DependencyBlock       = "block"
+	DependencyTemplate    = "template"
+	DependencyAsset       = "asset"
+)
+
+// dependencyAliases maps lifecycle resource types onto dependency kinds.
+var dependencyAliases = map[string]string{
+	"content":      DependencyContent,
+	"content_type": DependencyContentType,
+	"page":         DependencyPage,
+	"menu":         DependencyMenu,
+	"menu_item":    DependencyMenu,
+	"widget":       DependencyWidget,
+	"block":        DependencyBlock,
+	"template":     DependencyTemplate,
+	"theme":        DependencyAsset,
+	"asset":        DependencyAsset,
+}
+
+// DependencyKey builds the index key for a dependency kind and identifier.
+func DependencyKey(kind, id string) string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	id = strings.ToLower(strings.TrimSpace(id))
+	if kind == "" || id == "" {
+		return ""
+	}
+	return kind + ":" + id
+}
+
+// DependencyKeysForEvent returns the dependency keys touched by a lifecycle
+// event. Content events also touch their content type so collections listing
+// the type are refreshed. Menu events identify the menu by the "menu_code"
+// metadata entry, falling back to the record ID.
+func DependencyKeysForEvent(event lifecycle.Event) []string {
+	kind, ok := dependencyAliases[strings.ToLower(strings.TrimSpace(event.ResourceType))]
+	if !ok {
+		return nil
+	}
+	id := event.RecordID
+	if kind == DependencyMenu {
+		if code, ok := event.Metadata["menu_code"].(string); ok && strings.TrimSpace(code) != "" {
+			id = code
+		}
+	}
+	keys := []string{DependencyKey(kind, id)}
+	if kind == DependencyContent || kind == DependencyPage {
+		keys = append(keys, DependencyKey(DependencyContentType, event.ContentTypeSlug))
+	}
+	return normalizeDependencyKeys(keys)
+}
+
+func normalizeDependencyKeys(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		kind, id, ok := strings.Cut(key, ":")
+		if !ok {
+			continue
+		}
+		if normalized := DependencyKey(kind, id); normalized != "" {
+			out = append(out, normalized)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// pageDependencies lists the records a rendered page/locale pair depends on.
+func (s *service) pageDependencies(data *PageData) []string {
+	keys := []string{
+		DependencyKey(DependencyPage, data.Page.ID.String()),
+		DependencyKey(DependencyContent, data.Page.ContentID.String()),
+	}
+	if data.Content != nil {
+		keys = append(keys, DependencyKey(DependencyContent, data.Content.ID.String()))
+	}
+	for _, block := range data.Blocks {
+		if block != nil {
+			keys = append(keys, DependencyKey(DependencyBlock, block.ID.String()))
+		}
+	}
+	for _, entries := range data.Widgets {
+		for _, entry := range entries {
+			if entry != nil && entry.Instance != nil {
+				keys = append(keys, DependencyKey(DependencyWidget, entry.Instance.ID.String()))
+			}
+		}
+	}
+	keys = append(keys, s.menuDependencies(data.Menus)...)
+	if data.Template != nil {
+		keys = append(keys, DependencyKey(DependencyTemplate, data.Template.ID.String()))
+	}
+	if data.Theme != nil {
+		keys = append(keys, DependencyKey(DependencyAsset, data.Theme.ID.String()))
+	}
+	return normalizeDependencyKeys(keys)
+}
+
+// collectionDependencies lists the records a collection page depends on. The
+// content type key covers entries that are not listed yet.
+func (s *service) collectionDependencies(cfg CollectionConfig, data *CollectionPageData) []string {
+	keys := []string{DependencyKey(DependencyContentType, cfg.ContentType)}
+	for _, item := range data.Context.Items {
+		keys = append(keys, DependencyKey(DependencyContent, item.Content.ID.String()))
+	}
+	keys = append(keys, s.menuDependencies(data.Menus)...)
+	return normalizeDependencyKeys(keys)
+}
+
+func (s *service) menuDependencies(menuSet map[string][]menus.NavigationNode) []string {
+	keys := make([]string, 0, len(menuSet))
+	for alias := range menuSet {
+		keys = append(keys, DependencyKey(DependencyMenu, firstNonEmpty(s.cfg.Menus[alias], alias)))
+	}
+	return keys
+}
+
+// buildScope narrows a build to the page/locale pairs and collections
+// affected by changed dependencies.
+type buildScope struct {
+	// pages maps page IDs to the locales to rebuild; a "*" entry selects
+	// every locale.
+	pages       map[uuid.UUID]map[string]struct{}
+	collections map[string]struct{}
+}
+
+func newBuildScope() *buildScope {
+	return &buildScope{
+		pages:       map[uuid.UUID]map[string]struct{}{},
+		collections: map[string]struct{}{},
+	}
+}
+
+func (b *buildScope) addPage(id uuid.UUID, locale string) {
+	if id == uuid.Nil {
+		return
+	}
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "" {
+		b.pages[id] = map[string]struct{}{"*": {}}
+		return
+	}
+	locales, ok := b.pages[id]
+	if !ok {
+		locales = map[string]struct{}{}
+		b.pages[id] = locales
+	}
+	locales[locale] = struct{}{}
+}
+
+func (b *buildScope) includesPage(id uuid.UUID, locale string) bool {
+	locales, ok := b.pages[id]
+	if !ok {
+		return false
+	}
+	if _, all := locales["*"]; all {
+		return true
+	}
+	_, ok = locales[strings.ToLower(strings.TrimSpace(locale))]
+	return ok
+}
+
+func (b *buildScope) includesCollection(name string) bool {
+	_, ok := b.collections[name]
+	return ok
+}
+
+func (b *buildScope) pageIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(b.pages))
+	for id := range b.pages {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	return ids
+}
+
+func (b *buildScope) empty() bool {
+	return len(b.pages) == 0 && len(b.collections) == 0
+}
@@ -0,0 +1,461 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+)
+
+// DevServerEventsPath is the server-sent events endpoint the live-reload
+// script connects to.
+const DevServerEventsPath = "/__cms/livereload"
+
+const defaultDevPollInterval = 500 * time.Millisecond
+
+// DevBuilder is the generator surface used by the development server,
+// typically the generator Service.
+type DevBuilder interface {
+	DependentBuilder
+	Build(ctx context.Context, opts BuildOptions) (*BuildResult, error)
+	BuildAssets(ctx context.Context) error
+}
+
+// DevDiagnostic describes a render failure shown in the browser overlay.
+type DevDiagnostic struct {
+	PageID   string `json:"page_id,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+	Route    string `json:"route,omitempty"`
+	Template string `json:"template,omitempty"`
+	Error    string `json:"error"`
+}
+
+// DevServerOption customises a DevServer.
+type DevServerOption func(*DevServer)
+
+// WithDevServerOutputDir sets the generator output directory the server maps
+// request paths onto. It must match Config.OutputDir.
+func WithDevServerOutputDir(dir string) DevServerOption {
+	return func(s *DevServer) {
+		s.outputDir = strings.Trim(strings.TrimSpace(dir), "/")
+	}
+}
+
+// WithDevServerWatch adds theme directories polled for template and asset
+// changes.
+func WithDevServerWatch(paths ...string) DevServerOption {
+	return func(s *DevServer) {
+		for _, p := range paths {
+			if trimmed := strings.TrimSpace(p); trimmed != "" {
+				s.watchPaths = append(s.watchPaths, trimmed)
+			}
+		}
+	}
+}
+
+// WithDevServerPollInterval sets how often watched directories are scanned.
+func WithDevServerPollInterval(interval time.Duration) DevServerOption {
+	return func(s *DevServer) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+	}
+}
+
+// WithDevServerRebuildDebounce sets the window used to coalesce CMS change
+// events into one rebuild.
+func WithDevServerRebuildDebounce(debounce time.Duration) DevServerOption {
+	return func(s *DevServer) {
+		s.debounce = debounce
+	}
+}
+
+// WithDevServerLogger sets the logger used for rebuild and watch output.
+func WithDevServerLogger(logger interfaces.Logger) DevServerOption {
+	return func(s *DevServer) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// DevServer serves generator output from MemoryStorage for local
+// development. HTML responses get a live-reload script that listens on
+// DevServerEventsPath and shows render diagnostics in an overlay. CMS changes
+// arrive through Notify, which makes the server a lifecycle hook; theme files
+// are picked up by Watch.
+type DevServer struct {
+	builder      DevBuilder
+	storage      *MemoryStorage
+	outputDir    string
+	watchPaths   []string
+	pollInterval time.Duration
+	debounce     time.Duration
+	logger       interfaces.Logger
+	rebuilder    *Rebuilder
+
+	mu          sync.Mutex
+	clients     map[chan devEvent]struct{}
+	diagnostics []DevDiagnostic
+}
+
+type devEvent struct {
+	name string
+	data []byte
+}
+
+// NewDevServer constructs a development server for the generator writing to
+// storage.
+func NewDevServer(builder DevBuilder, storage *MemoryStorage, opts ...DevServerOption) *DevServer {
+	server := &DevServer{
+		builder:      builder,
+		storage:      storage,
+		pollInterval: defaultDevPollInterval,
+		debounce:     defaultRebuildDebounce,
+		logger:       logging.NoOp(),
+		clients:      map[chan devEvent]struct{}{},
+		diagnostics:  []DevDiagnostic{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(server)
+		}
+	}
+	if server.storage == nil {
+		server.storage = NewMemoryStorage()
+	}
+	server.rebuilder = NewRebuilder(builder,
+		WithRebuildDebounce(server.debounce),
+		WithRebuildLogger(server.logger),
+		WithRebuildCallback(func(_ []string, result *BuildResult, err error) {
+			server.publish(result, err)
+		}),
+	)
+	return server
+}
+
+// Build runs a generator build and reloads connected browsers.
+func (s *DevServer) Build(ctx context.Context, opts BuildOptions) (*BuildResult, error) {
+	result, err := s.builder.Build(ctx, opts)
+	s.publish(result, err)
+	return result, err
+}
+
+// Notify queues a rebuild of the pages affected by a lifecycle event.
+func (s *DevServer) Notify(ctx context.Context, event lifecycle.Event) error {
+	return s.rebuilder.Notify(ctx, event)
+}
+
+// Diagnostics returns the render failures of the latest build.
+func (s *DevServer) Diagnostics() []DevDiagnostic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DevDiagnostic(nil), s.diagnostics...)
+}
+
+// Close flushes pending rebuilds and disconnects live-reload clients.
+func (s *DevServer) Close(ctx context.Context) error {
+	err := s.rebuilder.Close(ctx)
+	s.mu.Lock()
+	for client := range s.clients {
+		close(client)
+		delete(s.clients, client)
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// Watch polls the watched directories until ctx is cancelled. Template
+// changes force a full rebuild; other files re-copy theme assets.
+func (s *DevServer) Watch(ctx context.Context) error {
+	if len(s.watchPaths) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+	previous, err := snapshotFiles(s.watchPaths)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		current, err := snapshotFiles(s.watchPaths)
+		if err != nil {
+			s.logger.Warn("generator.devserver.watch_failed", "error", err)
+			continue
+		}
+		changed := diffSnapshots(previous, current)
+		previous = current
+		if len(changed) == 0 {
+			continue
+		}
+		s.logger.Info("generator.devserver.files_changed", "files", changed)
+		if slices.ContainsFunc(changed, isTemplateFile) {
+			_, _ = s.Build(ctx, BuildOptions{Force: true})
+			continue
+		}
+		s.publish(nil, s.builder.BuildAssets(ctx))
+	}
+}
+
+// ServeHTTP serves generated artifacts and the live-reload event stream.
+func (s *DevServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == DevServerEventsPath {
+		s.serveEvents(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name, file, ok := s.lookup(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(name))
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(file.Data)
+	}
+	body := file.Data
+	if strings.HasPrefix(contentType, "text/html") {
+		body = injectLiveReload(body, s.Diagnostics())
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+func (s *DevServer) lookup(requestPath string) (string, MemoryFile, bool) {
+	rel := strings.TrimPrefix(path.Clean("/"+requestPath), "/")
+	candidates := []string{rel, path.Join(rel, "index.html")}
+	if rel == "" {
+		candidates = []string{"index.html"}
+	}
+	for _, candidate := range candidates {
+		name := joinOutputPath(s.outputDir, candidate)
+		if file, ok := s.storage.File(name); ok {
+			return name, file, true
+		}
+	}
+	return "", MemoryFile{}, false
+}
+
+func (s *DevServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	events := make(chan devEvent, 4)
+	s.mu.Lock()
+	s.clients[events] = struct{}{}
+	diagnostics, _ := json.Marshal(s.diagnostics)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		if _, ok := s.clients[events]; ok {
+			delete(s.clients, events)
+			close(events)
+		}
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	writeDevEvent(w, devEvent{name: "diagnostics", data: diagnostics})
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			writeDevEvent(w, event)
+			flusher.Flush()
+		}
+	}
+}
+
+// publish records the diagnostics of a build and tells browsers to reload.
+func (s *DevServer) publish(result *BuildResult, err error) {
+	diagnostics := collectDevDiagnostics(result, err)
+	data, _ := json.Marshal(diagnostics)
+	s.mu.Lock()
+	s.diagnostics = diagnostics
+	for client := range s.clients {
+		select {
+		case client <- devEvent{name: "reload", data: data}:
+		default:
+			// Slow clients miss the event; they reload on the next one.
+		}
+	}
+	s.mu.Unlock()
+}
+
+func collectDevDiagnostics(result *BuildResult, err error) []DevDiagnostic {
+	diagnostics := []DevDiagnostic{}
+	reported := map[string]struct{}{}
+	if result != nil {
+		for _, diagnostic := range result.Diagnostics {
+			if diagnostic.Err == nil {
+				continue
+			}
+			message := diagnostic.Err.Error()
+			reported[message] = struct{}{}
+			diagnostics = append(diagnostics, DevDiagnostic{
+				PageID:   diagnostic.PageID.String(),
+				Locale:   diagnostic.Locale,
+				Route:    diagnostic.Route,
+				Template: diagnostic.Template,
+				Error:    message,
+			})
+		}
+		for _, buildErr := range result.Errors {
+			if buildErr == nil {
+				continue
+			}
+			if _, ok := reported[buildErr.Error()]; ok {
+				continue
+			}
+			reported[buildErr.Error()] = struct{}{}
+			diagnostics = append(diagnostics, DevDiagnostic{Error: buildErr.Error()})
+		}
+	}
+	if err != nil && len(diagnostics) == 0 {
+		diagnostics = append(diagnostics, DevDiagnostic{Error: err.Error()})
+	}
+	return diagnostics
+}
+
+func writeDevEvent(w http.ResponseWriter, event devEvent) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data)
+}
+
+// injectLiveReload inserts the live-reload script, seeded with the current
+// diagnostics, before the closing body tag.
+func injectLiveReload(page []byte, diagnostics []DevDiagnostic) []byte {
+	seed, _ := json.Marshal(diagnostics)
+	script := strings.NewReplacer(
+		"{{events}}", DevServerEventsPath,
+		"{{diagnostics}}", string(seed),
+	).Replace(liveReloadScript)
+	html := string(page)
+	if index := strings.LastIndex(strings.ToLower(html), "</body>"); index >= 0 {
+		return []byte(html[:index] + script + html[index:])
+	}
+	return []byte(html + script)
+}
+
+const liveReloadScript = `<script data-cms-livereload>
+(function () {
+  var overlayId = "cms-dev-overlay";
+  function render(diagnostics) {
+    var overlay = document.getElementById(overlayId);
+    if (!diagnostics || diagnostics.length === 0) {
+      if (overlay) overlay.remove();
+      return;
+    }
+    if (!overlay) {
+      overlay = document.createElement("div");
+      overlay.id = overlayId;
+      overlay.style.cssText = "position:fixed;inset:0;z-index:2147483647;overflow:auto;padding:24px;background:rgba(20,0,0,.9);color:#fdd;font:14px/1.5 monospace;white-space:pre-wrap";
+      overlay.addEventListener("click", function () { overlay.remove(); });
+      document.body.appendChild(overlay);
+    }
+    overlay.textContent = diagnostics.map(function (d) {
+      var where = [d.route, d.locale, d.template].filter(Boolean).join(" · ");
+      return (where ? where + "\n" : "") + d.error;
+    }).join("\n\n");
+  }
+  render({{diagnostics}});
+  var source = new EventSource("{{events}}");
+  source.addEventListener("diagnostics", function (e) { render(JSON.parse(e.data)); });
+  source.addEventListener("reload", function () { window.location.reload(); });
+})();
+</script>
+`
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func snapshotFiles(roots []string) (map[string]fileStamp, error) {
+	snapshot := map[string]fileStamp{}
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			snapshot[name] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("generator: scan %s: %w", root, err)
+		}
+	}
+	return snapshot, nil
+}
+
+func diffSnapshots(previous, current map[string]fileStamp) []string {
+	var changed []string
+	for name, stamp := range current {
+		if old, ok := previous[name]; !ok || old != stamp {
+			changed = append(changed, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+func isTemplateFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".html", ".htm", ".tmpl", ".tpl", ".gohtml", ".jet":
+		return true
+	}
+	return false
+}
+
+var _ lifecycle.Hook = (*DevServer)(nil)
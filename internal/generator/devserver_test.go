@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMemoryStorageServesGeneratedOutput(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	storage := NewMemoryStorage()
+
+	svc := NewService(fixtures.Config, Dependencies{
+		Content:      fixtures.Content,
+		ContentTypes: fixtures.ContentTypes,
+		Menus:        fixtures.Menus,
+		Themes:       fixtures.Themes,
+		Locales:      fixtures.Locales,
+		Renderer:     &recordingRenderer{},
+		Storage:      storage,
+	})
+	server := NewDevServer(svc, storage, WithDevServerOutputDir(fixtures.Config.OutputDir))
+	if _, err := server.Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/es/empresa", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", recorder.Code)
+	}
+	body := recorder.Body.String()
+	if !strings.Contains(body, `data-path="/es/empresa"`) {
+		t.Fatalf("expected rendered page, got %q", body)
+	}
+	if !strings.Contains(body, "data-cms-livereload") || !strings.Contains(body, DevServerEventsPath) {
+		t.Fatalf("expected live-reload script in html response, got %q", body)
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown path, got %d", recorder.Code)
+	}
+}
+
+func TestDevServerReportsDiagnosticsAndReloads(t *testing.T) {
+	builder := &stubDevBuilder{}
+	storage := NewMemoryStorage()
+	server := NewDevServer(builder, storage, WithDevServerRebuildDebounce(0))
+	t.Cleanup(func() { _ = server.Close(context.Background()) })
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	resp, err := ts.Client().Get(ts.URL + DevServerEventsPath)
+	if err != nil {
+		t.Fatalf("connect events: %v", err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+	if name, data := readDevEvent(t, events); name != "diagnostics" || data != "[]" {
+		t.Fatalf("expected empty diagnostics on connect, got %s %s", name, data)
+	}
+
+	pageID := uuid.New()
+	builder.result = &BuildResult{
+		Diagnostics: []RenderDiagnostic{{
+			PageID:   pageID,
+			Locale:   "en",
+			Route:    "/about",
+			Template: "page.tpl",
+			Err:      errors.New("render page.tpl: missing field"),
+		}},
+		Errors: []error{errors.New("render page.tpl: missing field")},
+	}
+	if _, err := server.Build(context.Background(), BuildOptions{}); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	name, data := readDevEvent(t, events)
+	if name != "reload" {
+		t.Fatalf("expected reload event, got %s", name)
+	}
+	if !strings.Contains(data, `"route":"/about"`) || !strings.Contains(data, "missing field") {
+		t.Fatalf("expected diagnostic payload, got %s", data)
+	}
+	diagnostics := server.Diagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].PageID != pageID.String() {
+		t.Fatalf("expected one diagnostic for %s, got %+v", pageID, diagnostics)
+	}
+}
+
+func readDevEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+	var name, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			return name, data
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+type stubDevBuilder struct {
+	result *BuildResult
+}
+
+func (s *stubDevBuilder) Build(context.Context, BuildOptions) (*BuildResult, error) {
+	if s.result == nil {
+		return &BuildResult{}, nil
+	}
+	return s.result, nil
+}
+
+func (s *stubDevBuilder) BuildDependents(ctx context.Context, _ []string) (*BuildResult, error) {
+	return s.Build(ctx, BuildOptions{})
+}
+
+func (*stubDevBuilder) BuildAssets(context.Context) error { return nil }
@@ -23,9 +23,29 @@ type feedItem struct {
 	UpdatedAt   time.Time
 }
 
+// feedDocument lists the items of one feed. Collection feeds set Collection
+// and are written to Dir instead of the site-wide feed locations.
 type feedDocument struct {
-	Locale LocaleSpec
-	Items  []feedItem
+	Locale     LocaleSpec
+	Items      []feedItem
+	Collection string
+	Title      string
+	Dir        string
+	Link       string
+}
+
+func (doc feedDocument) rssPath() string {
+	if doc.Collection != "" {
+		return path.Join(doc.Dir, "feed.xml")
+	}
+	return path.Join("feeds", fmt.Sprintf("%s.rss.xml", doc.Locale.Code))
+}
+
+func (doc feedDocument) atomPath() string {
+	if doc.Collection != "" {
+		return path.Join(doc.Dir, "feed.atom.xml")
+	}
+	return path.Join("feeds", fmt.Sprintf("%s.atom.xml", doc.Locale.Code))
 }
 
 func (s *service) buildFeedDocuments(buildCtx *BuildContext) []feedDocument {
@@ -154,7 +174,7 @@ func (s *service) writeFeeds(
 			continue
 		}
 		rssContent := buildRSSFeed(siteMeta, doc, buildCtx.GeneratedAt)
-		rssPath := joinOutputPath(baseDir, path.Join("feeds", fmt.Sprintf("%s.rss.xml", doc.Locale.Code)))
+		rssPath := joinOutputPath(baseDir, doc.rssPath())
 		if err := ensureDir(ctx, writer, dirCache, path.Dir(rssPath)); err != nil {
 			return total, err
 		}
@@ -173,7 +193,7 @@ func (s *service) writeFeeds(
 		total++
 
 		atomContent := buildAtomFeed(siteMeta, doc, buildCtx.GeneratedAt)
-		atomPath := joinOutputPath(baseDir, path.Join("feeds", fmt.Sprintf("%s.atom.xml", doc.Locale.Code)))
+		atomPath := joinOutputPath(baseDir, doc.atomPath())
 		if err := ensureDir(ctx, writer, dirCache, path.Dir(atomPath)); err != nil {
 			return total, err
 		}
@@ -191,7 +211,7 @@ func (s *service) writeFeeds(
 		}
 		total++
 
-		if doc.Locale.IsDefault {
+		if doc.Locale.IsDefault && doc.Collection == "" {
 			if !defaultRSSWritten {
 				defaultRSSWritten = true
 				defaultRSSPath := joinOutputPath(baseDir, "feed.xml")
@@ -241,6 +261,10 @@ func buildRSSFeed(site SiteMetadata, doc feedDocument, generatedAt time.Time) st
 	baseLink := baseURLWithFallback(site.BaseURL)
 	title := feedTitleForLocale(site, doc.Locale)
 	description := feedDescriptionForLocale(site, doc.Locale)
+	if doc.Collection != "" {
+		title = fmt.Sprintf("%s - %s", firstNonEmpty(doc.Title, doc.Collection), title)
+		baseLink = absoluteURL(site.BaseURL, doc.Link)
+	}
 
 	var builder strings.Builder
 	builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
@@ -273,8 +297,12 @@ func buildRSSFeed(site SiteMetadata, doc feedDocument, generatedAt time.Time) st
 
 func buildAtomFeed(site SiteMetadata, doc feedDocument, generatedAt time.Time) string {
 	baseLink := baseURLWithFallback(site.BaseURL)
-	feedID := fmt.Sprintf("%s/feeds/%s.atom.xml", baseLink, doc.Locale.Code)
+	feedID := fmt.Sprintf("%s/%s", baseLink, doc.atomPath())
 	title := feedTitleForLocale(site, doc.Locale)
+	if doc.Collection != "" {
+		title = fmt.Sprintf("%s - %s", firstNonEmpty(doc.Title, doc.Collection), title)
+		baseLink = absoluteURL(site.BaseURL, doc.Link)
+	}
 
 	var builder strings.Builder
 	builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
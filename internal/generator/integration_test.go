@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/goliatone/go-cms/internal/blocks"
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/di"
 	ditesting "github.com/goliatone/go-cms/internal/di/testing"
@@ -392,6 +393,146 @@ func TestIntegrationBuildFeedsIncrementalWithSQLite(t *testing.T) {
 	}
 }
 
+func TestIntegrationBlockUpdateRebuildsDependentPage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+
+	cfg := runtimeconfig.DefaultConfig()
+	cfg.Cache.Enabled = false
+	cfg.Features.Themes = true
+	cfg.Generator.Enabled = true
+	cfg.Generator.OutputDir = "dist"
+	cfg.Generator.BaseURL = "https://example.test"
+	cfg.Generator.GenerateSitemap = false
+	cfg.Generator.GenerateRobots = false
+	cfg.Generator.GenerateFeeds = false
+	cfg.Generator.CopyAssets = false
+	cfg.Generator.Incremental = true
+	cfg.Generator.RebuildOnChange = true
+	cfg.Generator.RebuildDebounce = time.Hour
+	cfg.Generator.Menus = map[string]string{}
+
+	storage := newRecordingStorage()
+	container, _, err := ditesting.NewGeneratorContainer(cfg,
+		di.WithTemplate(blockRenderer{}),
+		di.WithGeneratorStorage(storage),
+	)
+	if err != nil {
+		t.Fatalf("build container: %v", err)
+	}
+	rebuilder := container.GeneratorRebuilder()
+	if rebuilder == nil {
+		t.Fatal("expected generator rebuilder")
+	}
+	t.Cleanup(func() { _ = rebuilder.Close(ctx) })
+
+	template, _ := registerThemeFixtures(t, ctx, container.ThemeService().(themes.Service))
+	envID := cmsenv.IDForKey(cmsenv.DefaultKey)
+	contentTypeID := uuid.New()
+	if _, err := container.ContentTypeRepository().Create(ctx, &content.ContentType{
+		ID:            contentTypeID,
+		Name:          "page",
+		Slug:          "page",
+		Status:        content.ContentTypeStatusActive,
+		EnvironmentID: envID,
+	}); err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+	enLocale, err := container.LocaleRepository().GetByCode(ctx, "en")
+	if err != nil {
+		t.Fatalf("lookup en locale: %v", err)
+	}
+	createPage := func(slug string) uuid.UUID {
+		id := uuid.New()
+		record := &content.Content{
+			ID:             id,
+			ContentTypeID:  contentTypeID,
+			CurrentVersion: 1,
+			Status:         "published",
+			Slug:           slug,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			EnvironmentID:  envID,
+			Metadata:       map[string]any{"template_id": template.ID.String()},
+			IsVisible:      true,
+			Translations: []*content.ContentTranslation{{
+				ID:        uuid.New(),
+				ContentID: id,
+				LocaleID:  enLocale.ID,
+				Title:     slug,
+				Content:   map[string]any{"path": "/" + slug},
+				CreatedAt: now,
+				UpdatedAt: now,
+			}},
+		}
+		if _, err := container.ContentRepository().Create(ctx, record); err != nil {
+			t.Fatalf("create content %s: %v", slug, err)
+		}
+		return id
+	}
+	companyID := createPage("company")
+	createPage("about")
+
+	editorID := uuid.New()
+	blockSvc := container.BlockService()
+	definition, err := blockSvc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"headline"}},
+	})
+	if err != nil {
+		t.Fatalf("register block definition: %v", err)
+	}
+	instance, err := blockSvc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID:  definition.ID,
+		PageID:        &companyID,
+		Region:        "main",
+		Configuration: map[string]any{"headline": "Welcome"},
+		CreatedBy:     editorID,
+		UpdatedBy:     editorID,
+	})
+	if err != nil {
+		t.Fatalf("create block instance: %v", err)
+	}
+
+	// Without a manifest the queued setup events trigger a full build.
+	if err := rebuilder.Flush(ctx); err != nil {
+		t.Fatalf("initial rebuild: %v", err)
+	}
+	companyOutput := path.Join(cfg.Generator.OutputDir, "company", "index.html")
+	if got := string(storage.lookup(companyOutput)); !strings.Contains(got, "Welcome") {
+		t.Fatalf("expected initial build to render the block, got %q", got)
+	}
+
+	written := len(storage.ExecCalls())
+	if _, err := blockSvc.UpdateInstance(ctx, blocks.UpdateInstanceInput{
+		InstanceID:    instance.ID,
+		Configuration: map[string]any{"headline": "Updated"},
+		UpdatedBy:     editorID,
+	}); err != nil {
+		t.Fatalf("update block instance: %v", err)
+	}
+	if err := rebuilder.Flush(ctx); err != nil {
+		t.Fatalf("rebuild after block update: %v", err)
+	}
+
+	var pageWrites []string
+	for _, call := range storage.ExecCalls()[written:] {
+		if call.Query != storageOpWrite || len(call.Args) < 4 {
+			continue
+		}
+		if category, _ := call.Args[3].(string); category == "page" {
+			target, _ := call.Args[0].(string)
+			pageWrites = append(pageWrites, target)
+		}
+	}
+	if len(pageWrites) != 1 || pageWrites[0] != companyOutput {
+		t.Fatalf("expected only the page using the block to be rebuilt, got %v", pageWrites)
+	}
+	if got := string(storage.lookup(companyOutput)); !strings.Contains(got, "Updated") {
+		t.Fatalf("expected rebuilt page to render the updated block, got %q", got)
+	}
+}
+
 func registerThemeFixtures(t *testing.T, ctx context.Context, svc themes.Service) (*themes.Template, *themes.Theme) {
 	t.Helper()
 	theme, err := svc.RegisterTheme(ctx, themes.RegisterThemeInput{
@@ -473,6 +614,7 @@ func registerBlockTables(t *testing.T, db *bun.DB) {
 			schema_version TEXT NOT NULL,
 			schema TEXT NOT NULL,
 			defaults TEXT,
+			migration TEXT,
 			created_at TEXT,
 			updated_at TEXT
 		)`,
@@ -778,3 +920,33 @@ func (integrationRenderer) RegisterFilter(string, func(any, any) (any, error)) e
 }
 
 func (integrationRenderer) GlobalContext(any) error { return nil }
+
+// blockRenderer renders the configuration of the page's blocks so block
+// edits change the output.
+type blockRenderer struct{}
+
+func (blockRenderer) Render(name string, data any, out ...io.Writer) (string, error) {
+	return blockRenderer{}.RenderTemplate(name, data, out...)
+}
+
+func (blockRenderer) RenderTemplate(name string, data any, _ ...io.Writer) (string, error) {
+	ctx, ok := data.(generator.TemplateContext)
+	if !ok {
+		return "", fmt.Errorf("unexpected template context %T", data)
+	}
+	var body strings.Builder
+	for _, block := range ctx.Page.Blocks {
+		fmt.Fprintf(&body, "<section>%v</section>", block.Configuration["headline"])
+	}
+	return fmt.Sprintf("<html><body>%s:%s</body></html>", name, body.String()), nil
+}
+
+func (blockRenderer) RenderString(templateContent string, data any, out ...io.Writer) (string, error) {
+	return blockRenderer{}.RenderTemplate(templateContent, data, out...)
+}
+
+func (blockRenderer) RegisterFilter(string, func(any, any) (any, error)) error {
+	return nil
+}
+
+func (blockRenderer) GlobalContext(any) error { return nil }
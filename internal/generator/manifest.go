@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,6 +23,9 @@ type buildManifest struct {
 	Pages       map[string]manifestPage    `json:"pages"`
 	Assets      map[string]manifestAsset   `json:"assets"`
 	Metadata    map[string]json.RawMessage `json:"metadata,omitempty"`
+	// Dependents is the reverse dependency index: dependency key to the
+	// page keys whose output depends on it.
+	Dependents map[string][]string `json:"dependents,omitempty"`
 }
 
 type manifestPage struct {
@@ -34,6 +38,9 @@ type manifestPage struct {
 	Checksum     string    `json:"checksum"`
 	LastModified time.Time `json:"last_modified"`
 	RenderedAt   time.Time `json:"rendered_at"`
+	Collection   string    `json:"collection,omitempty"`
+	Dependencies []string  `json:"dependencies,omitempty"`
+	Canonical    string    `json:"canonical,omitempty"`
 }
 
 type manifestAsset struct {
@@ -76,6 +83,9 @@ func parseManifest(data []byte) (*buildManifest, error) {
 	if manifest.Version == 0 {
 		manifest.Version = manifestFileVersion
 	}
+	if manifest.Dependents == nil {
+		manifest.reindexDependents()
+	}
 	return &manifest, nil
 }
 
@@ -123,7 +133,93 @@ func (m *buildManifest) setPage(entry manifestPage) {
 		m.Pages = map[string]manifestPage{}
 	}
 	key := strings.ToLower(strings.TrimSpace(entry.PageID)) + "::" + strings.ToLower(strings.TrimSpace(entry.Locale))
+	if previous, ok := m.Pages[key]; ok {
+		m.unlinkDependents(key, previous.Dependencies)
+	}
 	m.Pages[key] = entry
+	m.linkDependents(key, entry.Dependencies)
+}
+
+// removePage drops a page entry and its reverse index links.
+func (m *buildManifest) removePage(key string) (manifestPage, bool) {
+	if m == nil {
+		return manifestPage{}, false
+	}
+	entry, ok := m.Pages[key]
+	if !ok {
+		return manifestPage{}, false
+	}
+	m.unlinkDependents(key, entry.Dependencies)
+	delete(m.Pages, key)
+	return entry, true
+}
+
+// setDependencies refreshes the dependencies recorded for an existing page
+// entry, reporting whether they changed.
+func (m *buildManifest) setDependencies(pageID uuid.UUID, locale string, deps []string) bool {
+	entry, ok := m.lookupPage(pageID, locale)
+	if !ok || slices.Equal(entry.Dependencies, deps) {
+		return false
+	}
+	entry.Dependencies = deps
+	m.setPage(entry)
+	return true
+}
+
+// dependents returns the sorted page keys depending on any of keys.
+func (m *buildManifest) dependents(keys []string) []string {
+	if m == nil {
+		return nil
+	}
+	var out []string
+	for _, key := range keys {
+		out = append(out, m.Dependents[key]...)
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func (m *buildManifest) linkDependents(pageKey string, deps []string) {
+	if len(deps) == 0 {
+		return
+	}
+	if m.Dependents == nil {
+		m.Dependents = map[string][]string{}
+	}
+	for _, dep := range deps {
+		list := m.Dependents[dep]
+		if idx, found := slices.BinarySearch(list, pageKey); !found {
+			m.Dependents[dep] = slices.Insert(list, idx, pageKey)
+		}
+	}
+}
+
+func (m *buildManifest) unlinkDependents(pageKey string, deps []string) {
+	for _, dep := range deps {
+		list := m.Dependents[dep]
+		if idx, found := slices.BinarySearch(list, pageKey); found {
+			list = slices.Delete(list, idx, idx+1)
+		}
+		if len(list) == 0 {
+			delete(m.Dependents, dep)
+			continue
+		}
+		m.Dependents[dep] = list
+	}
+}
+
+// reindexDependents rebuilds the reverse index from page entries, used when
+// loading manifests written before the index existed.
+func (m *buildManifest) reindexDependents() {
+	m.Dependents = map[string][]string{}
+	keys := make([]string, 0, len(m.Pages))
+	for key := range m.Pages {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		m.linkDependents(key, m.Pages[key].Dependencies)
+	}
 }
 
 func (m *buildManifest) shouldSkipPage(pageID uuid.UUID, locale, hash, output string) bool {
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+// MemoryStorage keeps generator artifacts in memory. It backs the
+// development server and suits tests that inspect generated output.
+type MemoryStorage struct {
+	mu    sync.RWMutex
+	files map[string]MemoryFile
+}
+
+// MemoryFile is an artifact held by MemoryStorage.
+type MemoryFile struct {
+	Data        []byte
+	ContentType string
+	Category    string
+}
+
+// NewMemoryStorage constructs an empty in-memory storage provider.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{files: map[string]MemoryFile{}}
+}
+
+// File returns the artifact stored at path.
+func (m *MemoryStorage) File(path string) (MemoryFile, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	file, ok := m.files[path]
+	return file, ok
+}
+
+// Paths lists the stored artifact paths in lexical order.
+func (m *MemoryStorage) Paths() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Sorted(maps.Keys(m.files))
+}
+
+// Exec applies generator write and remove operations.
+func (m *MemoryStorage) Exec(_ context.Context, query string, args ...any) (interfaces.Result, error) {
+	switch query {
+	case storageOpEnsureDir:
+		return memoryResult{}, nil
+	case storageOpWrite:
+		if len(args) < 2 {
+			return nil, errors.New("generator: memory write requires path and content")
+		}
+		target, _ := args[0].(string)
+		reader, _ := args[1].(io.Reader)
+		if strings.TrimSpace(target) == "" || reader == nil {
+			return nil, errors.New("generator: memory write requires path and content")
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("generator: memory write %s: %w", target, err)
+		}
+		file := MemoryFile{Data: data}
+		if len(args) > 3 {
+			file.Category, _ = args[3].(string)
+		}
+		if len(args) > 4 {
+			file.ContentType, _ = args[4].(string)
+		}
+		m.mu.Lock()
+		m.files[target] = file
+		m.mu.Unlock()
+		return memoryResult{affected: 1}, nil
+	case storageOpRemove:
+		if len(args) < 1 {
+			return nil, errors.New("generator: memory remove requires path")
+		}
+		target, _ := args[0].(string)
+		prefix := strings.TrimRight(target, "/") + "/"
+		var removed int64
+		m.mu.Lock()
+		for path := range m.files {
+			if path == target || strings.HasPrefix(path, prefix) {
+				delete(m.files, path)
+				removed++
+			}
+		}
+		m.mu.Unlock()
+		return memoryResult{affected: removed}, nil
+	default:
+		return nil, fmt.Errorf("generator: memory storage does not support %q", query)
+	}
+}
+
+// Query serves generator reads, returning a single row with the file bytes.
+func (m *MemoryStorage) Query(_ context.Context, query string, args ...any) (interfaces.Rows, error) {
+	if query != storageOpRead {
+		return nil, fmt.Errorf("generator: memory storage does not support %q", query)
+	}
+	if len(args) < 1 {
+		return nil, errors.New("generator: memory read requires path")
+	}
+	target, _ := args[0].(string)
+	file, ok := m.File(target)
+	if !ok {
+		return &memoryRows{}, nil
+	}
+	return &memoryRows{data: [][]byte{slices.Clone(file.Data)}}, nil
+}
+
+// Transaction runs fn against the storage; writes are applied immediately.
+func (m *MemoryStorage) Transaction(_ context.Context, fn func(tx interfaces.Transaction) error) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(memoryTx{m})
+}
+
+type memoryTx struct {
+	*MemoryStorage
+}
+
+func (memoryTx) Commit() error { return nil }
+
+func (memoryTx) Rollback() error { return nil }
+
+type memoryResult struct {
+	affected int64
+}
+
+func (r memoryResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+func (memoryResult) LastInsertId() (int64, error) { return 0, nil }
+
+type memoryRows struct {
+	data  [][]byte
+	index int
+}
+
+func (r *memoryRows) Next() bool {
+	if r.index >= len(r.data) {
+		return false
+	}
+	r.index++
+	return true
+}
+
+func (r *memoryRows) Scan(dest ...any) error {
+	if r.index == 0 || r.index > len(r.data) {
+		return errors.New("generator: memory rows scan without row")
+	}
+	if len(dest) != 1 {
+		return errors.New("generator: memory rows scan expects one destination")
+	}
+	target, ok := dest[0].(*[]byte)
+	if !ok {
+		return fmt.Errorf("generator: memory rows cannot scan into %T", dest[0])
+	}
+	*target = slices.Clone(r.data[r.index-1])
+	return nil
+}
+
+func (*memoryRows) Close() error { return nil }
+
+var _ interfaces.StorageProvider = (*MemoryStorage)(nil)
@@ -43,3 +43,16 @@ func buildOutputPath(route string, locale string, defaultLocale string) string {
 	}
 	return path.Join(locale, routePart, "index.html")
 }
+
+// delocalizeRoute strips a leading locale segment from route.
+func delocalizeRoute(route, locale string) string {
+	route = "/" + strings.TrimLeft(strings.TrimSpace(route), "/")
+	segments := strings.SplitN(strings.TrimPrefix(route, "/"), "/", 2)
+	if locale == "" || !strings.EqualFold(segments[0], locale) {
+		return route
+	}
+	if len(segments) == 1 {
+		return "/"
+	}
+	return "/" + segments[1]
+}
@@ -0,0 +1,444 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/google/uuid"
+)
+
+const defaultRebuildDebounce = 500 * time.Millisecond
+
+// BuildDependents rebuilds the page/locale pairs and collection pages that
+// depend on the given dependency keys (see DependencyKey), then regenerates
+// the sitemap and feeds. Pages that no longer render are removed. Content and
+// page keys also select pages missing from the index so new pages are built.
+// Unchanged pages are still skipped on incremental builds unless an asset key
+// forces a render. Without a manifest it falls back to a full build.
+func (s *service) BuildDependents(ctx context.Context, dependencies []string) (*BuildResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	keys := normalizeDependencyKeys(dependencies)
+	if len(keys) == 0 {
+		return &BuildResult{}, nil
+	}
+	manifest, err := s.loadManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil || len(manifest.Pages) == 0 {
+		return s.Build(ctx, BuildOptions{})
+	}
+
+	scope := newBuildScope()
+	force := false
+	for _, key := range manifest.dependents(keys) {
+		entry := manifest.Pages[key]
+		if entry.Collection != "" {
+			scope.collections[entry.Collection] = struct{}{}
+			continue
+		}
+		if id, err := uuid.Parse(entry.PageID); err == nil {
+			scope.addPage(id, entry.Locale)
+		}
+	}
+	for _, key := range keys {
+		kind, id, _ := strings.Cut(key, ":")
+		switch kind {
+		case DependencyContent, DependencyPage:
+			if pageID, err := uuid.Parse(id); err == nil {
+				if _, known := manifest.Dependents[key]; !known {
+					scope.addPage(pageID, "")
+				}
+			}
+		case DependencyContentType:
+			for _, cfg := range s.cfg.Collections {
+				if strings.EqualFold(cfg.ContentType, id) {
+					scope.collections[cfg.Name] = struct{}{}
+				}
+			}
+		case DependencyAsset:
+			force = true
+		}
+	}
+	if scope.empty() {
+		return &BuildResult{}, nil
+	}
+	return s.build(ctx, BuildOptions{Force: force}, scope)
+}
+
+// removeStalePages deletes outputs and manifest entries of scoped pages that
+// no longer render, such as unpublished or deleted pages.
+func (s *service) removeStalePages(ctx context.Context, buildCtx *BuildContext, manifest *buildManifest, scope *buildScope) (int, error) {
+	if manifest == nil {
+		return 0, nil
+	}
+	current := map[string]struct{}{}
+	for _, data := range buildCtx.Pages {
+		current[manifest.pageKey(data.Page.ID, data.Locale.Code)] = struct{}{}
+	}
+	for _, data := range buildCtx.Collections {
+		current[manifest.pageKey(data.ID, data.Locale.Code)] = struct{}{}
+	}
+
+	var stale []string
+	for key, entry := range manifest.Pages {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		if entry.Collection != "" {
+			if scope.includesCollection(entry.Collection) {
+				stale = append(stale, key)
+			}
+			continue
+		}
+		if id, err := uuid.Parse(entry.PageID); err == nil && scope.includesPage(id, entry.Locale) {
+			stale = append(stale, key)
+		}
+	}
+	slices.Sort(stale)
+
+	var errs []error
+	removed := 0
+	for _, key := range stale {
+		entry, _ := manifest.removePage(key)
+		removed++
+		if s.deps.Storage == nil || strings.TrimSpace(entry.Output) == "" {
+			continue
+		}
+		if _, err := s.deps.Storage.Exec(ctx, storageOpRemove, entry.Output); err != nil {
+			errs = append(errs, fmt.Errorf("generator: remove stale output %s: %w", entry.Output, err))
+		}
+	}
+	return removed, errors.Join(errs...)
+}
+
+// loadFeedContext returns a copy of buildCtx listing every visible page with
+// the fields feeds need, so scoped builds regenerate complete feeds without
+// resolving blocks, widgets or menus for unaffected pages.
+func (s *service) loadFeedContext(ctx context.Context, buildCtx *BuildContext, manifest *buildManifest) (*BuildContext, error) {
+	pageTypeID, err := s.pageContentTypeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feedCtx := *buildCtx
+	feedCtx.Pages = nil
+	if pageTypeID == uuid.Nil {
+		return &feedCtx, nil
+	}
+	records, err := s.listContent(ctx, content.WithTranslations())
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[uuid.UUID]LocaleSpec, len(buildCtx.Locales))
+	defaultID := uuid.Nil
+	for _, spec := range buildCtx.Locales {
+		locales[spec.LocaleID] = spec
+		if spec.IsDefault {
+			defaultID = spec.LocaleID
+		}
+	}
+	for _, record := range records {
+		if !isPageContent(record, pageTypeID) {
+			continue
+		}
+		page := pageFromContentEntry(record)
+		if page == nil || !page.IsVisible {
+			continue
+		}
+		contentTranslations := indexContentTranslations(record.Translations)
+		for _, translation := range page.Translations {
+			spec, ok := locales[translation.LocaleID]
+			if !ok || strings.TrimSpace(translation.Path) == "" {
+				continue
+			}
+			contentTranslation := contentTranslations[translation.LocaleID]
+			if contentTranslation == nil {
+				contentTranslation = contentTranslations[defaultID]
+			}
+			if contentTranslation == nil {
+				continue
+			}
+			lastModified := maxTime(page.UpdatedAt, translation.UpdatedAt, record.UpdatedAt, contentTranslation.UpdatedAt)
+			if entry, ok := manifest.lookupPage(page.ID, spec.Code); ok && !entry.LastModified.IsZero() {
+				lastModified = entry.LastModified
+			}
+			feedCtx.Pages = append(feedCtx.Pages, &PageData{
+				Page:               page,
+				Content:            record,
+				Locale:             spec,
+				Translation:        translation,
+				ContentTranslation: contentTranslation,
+				Metadata:           DependencyMetadata{LastModified: lastModified},
+			})
+		}
+	}
+	return &feedCtx, nil
+}
+
+// recordDependencies refreshes the dependencies of manifest entries for
+// pages in the build context, including pages skipped as unchanged.
+func (s *service) recordDependencies(buildCtx *BuildContext, manifest *buildManifest) bool {
+	if manifest == nil {
+		return false
+	}
+	changed := false
+	for _, data := range buildCtx.Pages {
+		if manifest.setDependencies(data.Page.ID, data.Locale.Code, data.Dependencies) {
+			changed = true
+		}
+	}
+	for _, data := range buildCtx.Collections {
+		if manifest.setDependencies(data.ID, data.Locale.Code, data.Dependencies) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func recordRenderedPages(manifest *buildManifest, rendered []RenderedPage, renderedAt time.Time) {
+	for _, page := range rendered {
+		if page.PageID == uuid.Nil || strings.TrimSpace(page.Checksum) == "" {
+			continue
+		}
+		manifest.setPage(manifestPage{
+			PageID:       page.PageID.String(),
+			Locale:       page.Locale,
+			Route:        page.Route,
+			Output:       page.Output,
+			Template:     page.Template,
+			Hash:         page.Metadata.Hash,
+			Checksum:     page.Checksum,
+			LastModified: page.Metadata.LastModified,
+			RenderedAt:   renderedAt,
+			Collection:   page.Collection,
+			Dependencies: page.Dependencies,
+			Canonical:    page.Canonical,
+		})
+	}
+}
+
+// DependentBuilder rebuilds the output affected by changed dependencies,
+// typically the generator Service.
+type DependentBuilder interface {
+	BuildDependents(ctx context.Context, dependencies []string) (*BuildResult, error)
+}
+
+// RebuildOption customises a Rebuilder.
+type RebuildOption func(*Rebuilder)
+
+// WithRebuildDebounce sets how long the queue waits for further events
+// before rebuilding. Bursts arriving within the window share one rebuild.
+func WithRebuildDebounce(d time.Duration) RebuildOption {
+	return func(r *Rebuilder) {
+		if d > 0 {
+			r.debounce = d
+		}
+	}
+}
+
+// WithRebuildMaxDelay caps how long a continuous burst can postpone a
+// rebuild. Defaults to ten times the debounce window.
+func WithRebuildMaxDelay(d time.Duration) RebuildOption {
+	return func(r *Rebuilder) {
+		if d > 0 {
+			r.maxDelay = d
+		}
+	}
+}
+
+// WithRebuildLogger sets the logger used to report rebuild outcomes.
+func WithRebuildLogger(logger interfaces.Logger) RebuildOption {
+	return func(r *Rebuilder) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
+
+// WithRebuildCallback registers a function receiving every rebuild outcome.
+func WithRebuildCallback(fn func([]string, *BuildResult, error)) RebuildOption {
+	return func(r *Rebuilder) {
+		r.callback = fn
+	}
+}
+
+// Rebuilder is a lifecycle.Hook that queues the dependency keys of change
+// events and rebuilds the affected output in the background. Events arriving
+// while a rebuild runs are coalesced into the next one.
+type Rebuilder struct {
+	builder  DependentBuilder
+	debounce time.Duration
+	maxDelay time.Duration
+	logger   interfaces.Logger
+	callback func([]string, *BuildResult, error)
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	first   time.Time
+	timer   *time.Timer
+	running bool
+	closed  bool
+	idle    *sync.Cond
+}
+
+// NewRebuilder constructs a Rebuilder delegating rebuilds to builder.
+func NewRebuilder(builder DependentBuilder, opts ...RebuildOption) *Rebuilder {
+	r := &Rebuilder{
+		builder:  builder,
+		debounce: defaultRebuildDebounce,
+		logger:   logging.NoOp(),
+		pending:  map[string]struct{}{},
+	}
+	r.idle = sync.NewCond(&r.mu)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	if r.maxDelay <= 0 {
+		r.maxDelay = 10 * r.debounce
+	}
+	return r
+}
+
+// Notify implements lifecycle.Hook by queueing the event's dependency keys.
+func (r *Rebuilder) Notify(_ context.Context, event lifecycle.Event) error {
+	r.Enqueue(DependencyKeysForEvent(event)...)
+	return nil
+}
+
+// Enqueue queues dependency keys for the next rebuild.
+func (r *Rebuilder) Enqueue(keys ...string) {
+	keys = normalizeDependencyKeys(keys)
+	if len(keys) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	if len(r.pending) == 0 {
+		r.first = time.Now()
+	}
+	for _, key := range keys {
+		r.pending[key] = struct{}{}
+	}
+	if r.running {
+		return
+	}
+	r.schedule()
+}
+
+// schedule arms the debounce timer, never delaying past maxDelay from the
+// first queued event. Callers hold r.mu.
+func (r *Rebuilder) schedule() {
+	wait := min(r.debounce, max(0, r.maxDelay-time.Since(r.first)))
+	if r.timer == nil {
+		r.timer = time.AfterFunc(wait, r.run)
+		return
+	}
+	r.timer.Reset(wait)
+}
+
+// Flush rebuilds queued dependencies immediately and waits for any running
+// rebuild to finish.
+func (r *Rebuilder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	for r.running {
+		r.idle.Wait()
+	}
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	keys := r.take()
+	r.mu.Unlock()
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.rebuild(ctx, keys)
+	r.mu.Lock()
+	r.finish()
+	r.mu.Unlock()
+	return err
+}
+
+// Close stops accepting events, flushes queued dependencies and waits for
+// the final rebuild.
+func (r *Rebuilder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	return r.Flush(ctx)
+}
+
+func (r *Rebuilder) run() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	keys := r.take()
+	r.mu.Unlock()
+	if len(keys) == 0 {
+		return
+	}
+	_, _ = r.rebuild(context.Background(), keys)
+	r.mu.Lock()
+	r.finish()
+	r.mu.Unlock()
+}
+
+// take claims the pending keys and marks a rebuild as running. Callers hold r.mu.
+func (r *Rebuilder) take() []string {
+	if len(r.pending) == 0 {
+		return nil
+	}
+	keys := slices.Sorted(maps.Keys(r.pending))
+	clear(r.pending)
+	r.running = true
+	return keys
+}
+
+// finish clears the running flag and schedules events queued meanwhile.
+// Callers hold r.mu.
+func (r *Rebuilder) finish() {
+	r.running = false
+	r.idle.Broadcast()
+	if len(r.pending) > 0 && !r.closed {
+		r.schedule()
+	}
+}
+
+func (r *Rebuilder) rebuild(ctx context.Context, keys []string) (*BuildResult, error) {
+	start := time.Now()
+	result, err := r.builder.BuildDependents(ctx, keys)
+	fields := []any{"dependencies", len(keys), "duration", time.Since(start)}
+	if result != nil {
+		fields = append(fields, "pages_built", result.PagesBuilt, "pages_skipped", result.PagesSkipped)
+	}
+	if err != nil {
+		r.logger.Error("generator rebuild failed", append(fields, "error", err)...)
+	} else {
+		r.logger.Info("generator rebuild completed", fields...)
+	}
+	if r.callback != nil {
+		r.callback(keys, result, err)
+	}
+	return result, err
+}
+
+var _ lifecycle.Hook = (*Rebuilder)(nil)
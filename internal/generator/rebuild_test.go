@@ -0,0 +1,270 @@
+package generator
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+)
+
+func TestBuildDependentsRebuildsOnlyAffectedPages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.Incremental = true
+	fixtures.Config.GenerateSitemap = true
+	storage := &recordingStorage{}
+
+	if _, err := newRebuildTestService(fixtures, &recordingRenderer{}, storage, now).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("initial build: %v", err)
+	}
+	manifest, err := parseManifest(storage.files["dist/"+manifestFileName])
+	if err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	if got := len(manifest.Dependents[DependencyKey(DependencyMenu, "main-nav")]); got != fixtures.LocalizedCount() {
+		t.Fatalf("expected every page to depend on the main menu, got %d", got)
+	}
+
+	company := fixtures.Content.records[fixtures.PageIDs[0]]
+	company.UpdatedAt = now
+	company.Translations[0].Title = "Company (updated)"
+
+	renderer := &recordingRenderer{}
+	result, err := newRebuildTestService(fixtures, renderer, storage, now.Add(time.Minute)).
+		BuildDependents(ctx, []string{DependencyKey(DependencyContent, company.ID.String())})
+	if err != nil {
+		t.Fatalf("build dependents: %v", err)
+	}
+	if result.PagesBuilt != 2 {
+		t.Fatalf("expected both locales of the changed page rebuilt, got %d", result.PagesBuilt)
+	}
+	for _, call := range renderer.calls {
+		if path := call.ctx.Page.Translation.Path; path != "/company" && path != "/es/empresa" {
+			t.Fatalf("unexpected render of %s", path)
+		}
+	}
+	sitemap := string(storage.files["dist/sitemap.xml"])
+	if !strings.Contains(sitemap, "https://example.com/vision") {
+		t.Fatalf("expected unaffected pages to stay in the sitemap:\n%s", sitemap)
+	}
+}
+
+func TestBuildDependentsRemovesUnpublishedPages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.Incremental = true
+	fixtures.Config.GenerateSitemap = true
+	storage := &recordingStorage{}
+
+	if _, err := newRebuildTestService(fixtures, &recordingRenderer{}, storage, now).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("initial build: %v", err)
+	}
+	if _, ok := storage.files["dist/vision/index.html"]; !ok {
+		t.Fatalf("expected vision page output")
+	}
+
+	vision := fixtures.Content.records[fixtures.PageIDs[1]]
+	vision.IsVisible = false
+	vision.Status = "draft"
+
+	renderer := &recordingRenderer{}
+	if _, err := newRebuildTestService(fixtures, renderer, storage, now.Add(time.Minute)).
+		BuildDependents(ctx, []string{DependencyKey(DependencyContent, vision.ID.String())}); err != nil {
+		t.Fatalf("build dependents: %v", err)
+	}
+	renderer.assertCalls(t, 0)
+	for _, output := range []string{"dist/vision/index.html", "dist/es/vision/index.html"} {
+		if _, ok := storage.files[output]; ok {
+			t.Fatalf("expected %s to be removed", output)
+		}
+	}
+	if _, ok := storage.files["dist/company/index.html"]; !ok {
+		t.Fatalf("expected unaffected output to remain")
+	}
+	manifest, err := parseManifest(storage.files["dist/"+manifestFileName])
+	if err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	if _, ok := manifest.lookupPage(vision.ID, "en"); ok {
+		t.Fatalf("expected manifest entry to be removed")
+	}
+	if strings.Contains(string(storage.files["dist/sitemap.xml"]), "/vision") {
+		t.Fatalf("expected unpublished page dropped from sitemap")
+	}
+}
+
+func TestBuildDependentsRebuildsRestoredPages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.Incremental = true
+	storage := &recordingStorage{}
+
+	if _, err := newRebuildTestService(fixtures, &recordingRenderer{}, storage, now).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("initial build: %v", err)
+	}
+
+	vision := fixtures.Content.records[fixtures.PageIDs[1]]
+	event := lifecycle.Event{ResourceType: "content", RecordID: vision.ID.String(), Transition: "delete"}
+	delete(fixtures.Content.records, vision.ID)
+	if _, err := newRebuildTestService(fixtures, &recordingRenderer{}, storage, now.Add(time.Minute)).
+		BuildDependents(ctx, DependencyKeysForEvent(event)); err != nil {
+		t.Fatalf("build dependents after delete: %v", err)
+	}
+	if _, ok := storage.files["dist/vision/index.html"]; ok {
+		t.Fatalf("expected deleted page output to be removed")
+	}
+
+	fixtures.Content.records[vision.ID] = vision
+	event.Transition = "restore"
+	renderer := &recordingRenderer{}
+	result, err := newRebuildTestService(fixtures, renderer, storage, now.Add(2*time.Minute)).
+		BuildDependents(ctx, DependencyKeysForEvent(event))
+	if err != nil {
+		t.Fatalf("build dependents after restore: %v", err)
+	}
+	if result.PagesBuilt != 2 {
+		t.Fatalf("expected both locales of the restored page rebuilt, got %d", result.PagesBuilt)
+	}
+	for _, output := range []string{"dist/vision/index.html", "dist/es/vision/index.html"} {
+		if _, ok := storage.files[output]; !ok {
+			t.Fatalf("expected %s to be written again", output)
+		}
+	}
+}
+
+func TestBuildDependentsRefreshesCollectionsForNewEntries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	articles := addCollectionArticles(fixtures, now)
+	fixtures.Config.Incremental = true
+	fixtures.Config.Locales = []string{"en"}
+	fixtures.Config.Collections = []CollectionConfig{{
+		Name:        "blog",
+		ContentType: "article",
+		Template:    "themes/list.html",
+		Route:       "/blog",
+		PageSize:    2,
+	}}
+	storage := &recordingStorage{}
+
+	// The draft is excluded from the first build.
+	draft := articles[4]
+	if _, err := newCollectionTestService(fixtures, &collectionRenderer{}, storage, now).Build(ctx, BuildOptions{}); err != nil {
+		t.Fatalf("initial build: %v", err)
+	}
+
+	draft.IsVisible, draft.Status = true, "published"
+	renderer := &collectionRenderer{}
+	svc := newCollectionTestService(fixtures, renderer, storage, now.Add(time.Minute))
+	result, err := svc.BuildDependents(ctx, []string{
+		DependencyKey(DependencyContent, draft.ID.String()),
+		DependencyKey(DependencyContentType, "article"),
+	})
+	if err != nil {
+		t.Fatalf("build dependents: %v", err)
+	}
+	renderer.assertCalls(t, 0)
+	routes := make([]string, 0, len(renderer.collections()))
+	for _, entry := range renderer.collections() {
+		routes = append(routes, entry.route)
+	}
+	slices.Sort(routes)
+	if strings.Join(routes, ",") != "/blog,/blog/page/2,/blog/page/3" {
+		t.Fatalf("expected shifted collection pages rebuilt, got %v (built %d)", routes, result.PagesBuilt)
+	}
+}
+
+func TestRebuilderCoalescesEvents(t *testing.T) {
+	builder := &recordingDependentBuilder{started: make(chan struct{}), release: make(chan struct{})}
+	done := make(chan []string, 4)
+	rebuilder := NewRebuilder(builder,
+		WithRebuildDebounce(20*time.Millisecond),
+		WithRebuildCallback(func(keys []string, _ *BuildResult, _ error) { done <- keys }),
+	)
+
+	ctx := context.Background()
+	_ = rebuilder.Notify(ctx, lifecycle.Event{ResourceType: "content", RecordID: "A", ContentTypeSlug: "article"})
+	_ = rebuilder.Notify(ctx, lifecycle.Event{ResourceType: "content", RecordID: "b"})
+	_ = rebuilder.Notify(ctx, lifecycle.Event{ResourceType: "menu", RecordID: "1", Metadata: map[string]any{"menu_code": "main"}})
+	_ = rebuilder.Notify(ctx, lifecycle.Event{ResourceType: "unknown", RecordID: "x"})
+
+	builder.waitStarted(t)
+	// Events arriving while the rebuild runs are queued for the next one.
+	_ = rebuilder.Notify(ctx, lifecycle.Event{ResourceType: "widget", RecordID: "w"})
+	close(builder.release)
+
+	first := <-done
+	if strings.Join(first, ",") != "content:a,content:b,content_type:article,menu:main" {
+		t.Fatalf("unexpected coalesced keys: %v", first)
+	}
+	select {
+	case second := <-done:
+		if strings.Join(second, ",") != "widget:w" {
+			t.Fatalf("unexpected follow-up keys: %v", second)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected queued events to trigger a follow-up rebuild")
+	}
+	if err := rebuilder.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if calls := builder.callCount(); calls != 2 {
+		t.Fatalf("expected 2 rebuilds, got %d", calls)
+	}
+}
+
+func newRebuildTestService(fixtures renderFixtures, renderer *recordingRenderer, storage *recordingStorage, now time.Time) *service {
+	svc := NewService(fixtures.Config, Dependencies{
+		Content:      fixtures.Content,
+		ContentTypes: fixtures.ContentTypes,
+		Menus:        fixtures.Menus,
+		Themes:       fixtures.Themes,
+		Locales:      fixtures.Locales,
+		Renderer:     renderer,
+		Storage:      storage,
+	}).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+type recordingDependentBuilder struct {
+	mu      sync.Mutex
+	calls   [][]string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *recordingDependentBuilder) BuildDependents(_ context.Context, keys []string) (*BuildResult, error) {
+	b.mu.Lock()
+	b.calls = append(b.calls, keys)
+	first := len(b.calls) == 1
+	b.mu.Unlock()
+	if first {
+		close(b.started)
+	}
+	<-b.release
+	return &BuildResult{}, nil
+}
+
+func (b *recordingDependentBuilder) waitStarted(t *testing.T) {
+	t.Helper()
+	select {
+	case <-b.started:
+	case <-time.After(time.Second):
+		t.Fatalf("rebuild did not start")
+	}
+}
+
+func (b *recordingDependentBuilder) callCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.calls)
+}
@@ -10,17 +10,22 @@ import (
 	"github.com/goliatone/go-cms/internal/pages"
 	"github.com/goliatone/go-cms/internal/themes"
 	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/goliatone/go-cms/pkg/interfaces"
 	gotheme "github.com/goliatone/go-theme"
 	"github.com/google/uuid"
 )
 
 // TemplateContext captures the data contract passed to TemplateRenderer implementations.
 type TemplateContext struct {
-	Site    SiteMetadata
-	Page    PageRenderingContext
-	Build   BuildMetadata
-	Theme   ThemeContext
-	Helpers TemplateHelpers
+	Site       SiteMetadata
+	Page       PageRenderingContext
+	Collection *CollectionContext
+	Build      BuildMetadata
+	Theme      ThemeContext
+	Helpers    TemplateHelpers
+	// CSP holds the nonce and sources required by the page's shortcodes. It is
+	// nil unless the shortcode service has CSP support enabled.
+	CSP interfaces.ShortcodeCSP
 }
 
 // SiteMetadata exposes locale-aware information required by templates.
@@ -51,6 +56,12 @@ type PageRenderingContext struct {
 	Theme              *themes.Theme
 	Locale             LocaleSpec
 	Metadata           DependencyMetadata
+	// Canonical is the preferred URL for the page. Locales rendering fallback
+	// content point at the locale the content came from.
+	Canonical LocaleAlternate
+	// Alternates lists the localized variants of the page followed by an
+	// x-default entry; it is empty when the page exists in a single locale.
+	Alternates []LocaleAlternate
 }
 
 // ThemeContext surfaces go-theme selection data to templates.
@@ -155,17 +166,24 @@ func buildThemeContext(selection *gotheme.Selection, cfg ThemingConfig) ThemeCon
 	}
 }
 
-// RenderedPage captures the rendered HTML output for a page.
+// RenderedPage captures the rendered HTML output for a page. Collection is set
+// for collection listing pages.
 type RenderedPage struct {
-	PageID   uuid.UUID
-	Locale   string
-	Route    string
-	Output   string
-	Template string
-	HTML     string
-	Metadata DependencyMetadata
-	Duration time.Duration
-	Checksum string
+	PageID       uuid.UUID
+	Collection   string
+	Locale       string
+	Route        string
+	Output       string
+	Template     string
+	HTML         string
+	Metadata     DependencyMetadata
+	Duration     time.Duration
+	Checksum     string
+	Dependencies []string
+	Canonical    string
+	// CSP is the Content-Security-Policy value required by the page's
+	// shortcodes, empty when none was collected.
+	CSP string
 }
 
 // RenderDiagnostic records rendering timing and errors for individual pages.
@@ -11,6 +11,7 @@ import (
 	"maps"
 	"path"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,6 +23,7 @@ import (
 	"github.com/goliatone/go-cms/internal/logging"
 	"github.com/goliatone/go-cms/internal/menus"
 	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/sites"
 	"github.com/goliatone/go-cms/internal/themes"
 	"github.com/goliatone/go-cms/internal/widgets"
 	"github.com/goliatone/go-cms/pkg/interfaces"
@@ -44,6 +46,7 @@ type Service interface {
 	BuildPage(ctx context.Context, pageID uuid.UUID, locale string) error
 	BuildAssets(ctx context.Context) error
 	BuildSitemap(ctx context.Context) error
+	BuildDependents(ctx context.Context, dependencies []string) (*BuildResult, error)
 	Clean(ctx context.Context) error
 }
 
@@ -64,6 +67,16 @@ type Config struct {
 	RenderTimeout    time.Duration
 	AssetCopyTimeout time.Duration
 	Theming          ThemingConfig
+	Collections      []CollectionConfig
+	// LocaleFallbacks maps a locale code to the locales whose content is
+	// rendered, in order, before the default locale when the page has no
+	// translation of its own. Pages rendering fallback content declare the
+	// source locale as canonical.
+	LocaleFallbacks map[string][]string
+	// SitemapMaxURLs caps the URLs per sitemap file. Larger sites get a
+	// sitemap index referencing per-locale sitemaps. Defaults to 50,000, the
+	// protocol limit.
+	SitemapMaxURLs int
 }
 
 // ThemingConfig configures how themes are selected and exposed to templates.
@@ -81,6 +94,9 @@ type BuildOptions struct {
 	DryRun     bool
 	Force      bool
 	AssetsOnly bool
+	// Site builds a single site by key. Output goes to OutputDir/<key> and
+	// content scoped to other sites is skipped.
+	Site string
 }
 
 // BuildResult reports aggregated build metadata.
@@ -129,6 +145,8 @@ type Dependencies struct {
 	Hooks        Hooks
 	Logger       interfaces.Logger
 	Shortcodes   interfaces.ShortcodeService
+	Sites        SiteLookup
+	Pages        PageLookup
 }
 
 // Hooks expose lifecycle callbacks for build operations.
@@ -174,6 +192,7 @@ type service struct {
 	hooks         Hooks
 	logger        interfaces.Logger
 	themeSelector *themeSelector
+	site          *sites.Site
 }
 
 func (s *service) baseLogger(ctx context.Context) interfaces.Logger {
@@ -196,6 +215,24 @@ func (s *service) operationLogger(ctx context.Context, operation string, extra m
 type disabledService struct{}
 
 func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, error) {
+	if key := strings.TrimSpace(opts.Site); key != "" {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		scoped, err := s.forSite(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		// Menu lookups resolve the site's own menus ahead of shared ones.
+		return scoped.build(sites.WithSite(ctx, scoped.site), opts, nil)
+	}
+	return s.build(ctx, opts, nil)
+}
+
+// build runs a full build, or a scoped rebuild of the pages and collections
+// selected by scope. Scoped builds remove outputs of selected pages that no
+// longer render and regenerate sitemap and feeds for the whole site.
+func (s *service) build(ctx context.Context, opts BuildOptions, scope *buildScope) (*BuildResult, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -213,7 +250,7 @@ func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, e
 	metrics := BuildMetrics{}
 
 	contextStart := time.Now()
-	buildCtx, err := s.loadContext(ctx, opts)
+	buildCtx, err := s.loadScopedContext(ctx, opts, scope)
 	metrics.ContextDuration = time.Since(contextStart)
 	if err != nil {
 		s.operationLogger(ctx, "build", map[string]any{"phase": "context"}).Error("generator context load failed", "error", err)
@@ -236,6 +273,9 @@ func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, e
 		"locale_count": len(buildCtx.Locales),
 		"page_targets": len(buildCtx.Pages),
 	}
+	if len(buildCtx.Collections) > 0 {
+		logFields["collection_pages"] = len(buildCtx.Collections)
+	}
 	if len(opts.Locales) > 0 {
 		logFields["requested_locales"] = len(opts.Locales)
 	}
@@ -311,10 +351,27 @@ func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, e
 			errorsSlice = append(errorsSlice, err)
 		}
 	}
+	for _, data := range buildCtx.Collections {
+		if err := ctx.Err(); err != nil {
+			errorsSlice = append(errorsSlice, err)
+			break
+		}
+		collect(s.renderCollectionPage(ctx, siteMeta, buildCtx, data, manifest, baseDir, opts.Force))
+	}
 	metrics.RenderDuration = time.Since(renderStart)
 
+	partial := scope != nil || len(opts.PageIDs) > 0
+	manifestDirty := false
 	var writer artifactWriter
 	if !opts.DryRun {
+		if scope != nil {
+			removed, err := s.removeStalePages(ctx, buildCtx, manifest, scope)
+			if err != nil {
+				errorsSlice = append(errorsSlice, err)
+			}
+			manifestDirty = removed > 0
+		}
+
 		writer = newArtifactWriter(s.deps.Storage)
 		persistStart := time.Now()
 		if err := s.persistPages(ctx, writer, buildCtx, rendered); err != nil {
@@ -339,7 +396,7 @@ func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, e
 
 		if s.cfg.GenerateSitemap {
 			sitemapStart := time.Now()
-			sitemapPages := s.mergeRenderedForSitemap(buildCtx, rendered, manifest)
+			sitemapPages := s.mergeRenderedForSitemap(buildCtx, rendered, manifest, partial)
 			if err := s.writeSitemap(ctx, writer, siteMeta, buildCtx, sitemapPages); err != nil {
 				errorsSlice = append(errorsSlice, err)
 			} else {
@@ -349,10 +406,17 @@ func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, e
 
 		if s.cfg.GenerateFeeds {
 			feedStart := time.Now()
-			feedDocs := s.buildFeedDocuments(buildCtx)
-			written, err := s.writeFeeds(ctx, writer, siteMeta, buildCtx, feedDocs)
-			if err != nil {
-				errorsSlice = append(errorsSlice, err)
+			feedCtx, feedErr := buildCtx, error(nil)
+			if partial {
+				feedCtx, feedErr = s.loadFeedContext(ctx, buildCtx, manifest)
+			}
+			var written int
+			if feedErr == nil {
+				feedDocs := append(s.buildFeedDocuments(feedCtx), s.buildCollectionFeeds(buildCtx)...)
+				written, feedErr = s.writeFeeds(ctx, writer, siteMeta, buildCtx, feedDocs)
+			}
+			if feedErr != nil {
+				errorsSlice = append(errorsSlice, feedErr)
 			} else {
 				result.FeedsBuilt += written
 				metrics.FeedDuration = time.Since(feedStart)
@@ -369,28 +433,15 @@ func (s *service) Build(ctx context.Context, opts BuildOptions) (*BuildResult, e
 		}
 	}
 
-	if manifest != nil && len(rendered) > 0 && len(errorsSlice) == 0 {
+	if !opts.DryRun && s.recordDependencies(buildCtx, manifest) {
+		manifestDirty = true
+	}
+	if manifest != nil && (len(rendered) > 0 || manifestDirty) && len(errorsSlice) == 0 {
 		if writer == nil {
 			writer = newArtifactWriter(s.deps.Storage)
 		}
 		manifest.GeneratedAt = buildCtx.GeneratedAt
-		for _, page := range rendered {
-			if page.PageID == uuid.Nil || strings.TrimSpace(page.Checksum) == "" {
-				continue
-			}
-			entry := manifestPage{
-				PageID:       page.PageID.String(),
-				Locale:       page.Locale,
-				Route:        page.Route,
-				Output:       page.Output,
-				Template:     page.Template,
-				Hash:         page.Metadata.Hash,
-				Checksum:     page.Checksum,
-				LastModified: page.Metadata.LastModified,
-				RenderedAt:   buildCtx.GeneratedAt,
-			}
-			manifest.setPage(entry)
-		}
+		recordRenderedPages(manifest, rendered, buildCtx.GeneratedAt)
 		if err := s.persistManifest(ctx, writer, manifest); err != nil {
 			errorsSlice = append(errorsSlice, err)
 		}
@@ -606,6 +657,8 @@ func (s *service) renderPage(
 			Theme:              data.Theme,
 			Locale:             data.Locale,
 			Metadata:           data.Metadata,
+			Canonical:          data.Canonical,
+			Alternates:         data.Alternates,
 		},
 		Build: BuildMetadata{
 			GeneratedAt: buildCtx.GeneratedAt,
@@ -652,105 +705,125 @@ func (s *service) renderPage(
 	}
 
 	outcome.page = RenderedPage{
-		PageID:   data.Page.ID,
-		Locale:   data.Locale.Code,
-		Route:    route,
-		Template: templateName,
-		HTML:     rendered,
-		Metadata: data.Metadata,
-		Duration: outcome.diagnostic.Duration,
+		PageID:       data.Page.ID,
+		Locale:       data.Locale.Code,
+		Route:        route,
+		Template:     templateName,
+		HTML:         rendered,
+		Metadata:     data.Metadata,
+		Duration:     outcome.diagnostic.Duration,
+		Dependencies: data.Dependencies,
+		Canonical:    data.Canonical.Route,
+	}
+	if templateCtx.CSP != nil {
+		outcome.page.CSP = templateCtx.CSP.Header()
 	}
 	return outcome
 }
 
+// renderShortcodesInTemplateContext renders the shortcodes in the page content
+// and summaries. Builds are trusted renders and never rate limited. A value
+// whose shortcodes fail to render is logged and kept as raw content, so one
+// broken shortcode does not fail the build.
 func (s *service) renderShortcodesInTemplateContext(ctx context.Context, tmpl *TemplateContext) {
 	svc := s.deps.Shortcodes
 	if svc == nil || tmpl == nil {
 		return
 	}
 
-	locale := tmpl.Page.Locale.Code
+	fields := map[string]any{"locale": tmpl.Page.Locale.Code}
+	if tmpl.Page.Page != nil {
+		fields["page_id"] = tmpl.Page.Page.ID
+	}
+	logger := s.operationLogger(ctx, "generator.shortcodes", fields)
+
+	opts := &interfaces.ShortcodeProcessOptions{Locale: tmpl.Page.Locale.Code, Trusted: true}
 	if tmpl.Page.ContentTranslation != nil && tmpl.Page.ContentTranslation.Content != nil {
-		_ = s.renderShortcodesInMap(ctx, tmpl.Page.ContentTranslation.Content, locale)
+		s.renderShortcodesInMap(ctx, logger, tmpl.Page.ContentTranslation.Content, opts)
 	}
 
 	if tmpl.Page.ContentTranslation != nil && tmpl.Page.ContentTranslation.Summary != nil {
-		if processed, err := svc.Process(ctx, *tmpl.Page.ContentTranslation.Summary, interfaces.ShortcodeProcessOptions{Locale: locale}); err == nil {
-			summary := processed
-			tmpl.Page.ContentTranslation.Summary = &summary
-		}
+		processed := s.renderShortcodesOrRaw(ctx, logger, *tmpl.Page.ContentTranslation.Summary, opts)
+		tmpl.Page.ContentTranslation.Summary = &processed
 	}
 
 	if tmpl.Page.Translation != nil && tmpl.Page.Translation.Summary != nil {
-		if processed, err := svc.Process(ctx, *tmpl.Page.Translation.Summary, interfaces.ShortcodeProcessOptions{Locale: locale}); err == nil {
-			summary := processed
-			tmpl.Page.Translation.Summary = &summary
-		}
+		processed := s.renderShortcodesOrRaw(ctx, logger, *tmpl.Page.Translation.Summary, opts)
+		tmpl.Page.Translation.Summary = &processed
 	}
+	tmpl.CSP = opts.CSP
 }
 
-func (s *service) renderShortcodesInMap(ctx context.Context, values map[string]any, locale string) error {
-	svc := s.deps.Shortcodes
-	if svc == nil || values == nil {
-		return nil
+// renderShortcodesOrRaw renders the shortcodes in input, returning input
+// unchanged when rendering fails.
+func (s *service) renderShortcodesOrRaw(ctx context.Context, logger interfaces.Logger, input string, opts *interfaces.ShortcodeProcessOptions) string {
+	output, err := s.processShortcodes(ctx, input, opts)
+	if err != nil {
+		logger.Warn("generator shortcode render failed", "error", err)
+		return input
+	}
+	return output
+}
+
+// processShortcodes renders the shortcodes in input. Services implementing
+// ShortcodeCSPService collect the CSP sources into opts.CSP, so every call for
+// a page shares one nonce and policy.
+func (s *service) processShortcodes(ctx context.Context, input string, opts *interfaces.ShortcodeProcessOptions) (string, error) {
+	cspSvc, ok := s.deps.Shortcodes.(interfaces.ShortcodeCSPService)
+	if !ok {
+		return s.deps.Shortcodes.Process(ctx, input, *opts)
+	}
+	result, err := cspSvc.ProcessWithCSP(ctx, input, *opts)
+	if err != nil {
+		return "", err
+	}
+	if result.CSP != nil {
+		opts.CSP = result.CSP
+	}
+	return result.Content, nil
+}
+
+func (s *service) renderShortcodesInMap(ctx context.Context, logger interfaces.Logger, values map[string]any, opts *interfaces.ShortcodeProcessOptions) {
+	if s.deps.Shortcodes == nil || values == nil {
+		return
 	}
 	for key, value := range values {
-		rendered, err := s.renderShortcodeValue(ctx, value, locale)
-		if err != nil {
-			return err
-		}
-		values[key] = rendered
+		values[key] = s.renderShortcodeValue(ctx, logger, value, opts)
 	}
-	return nil
 }
 
-func (s *service) renderShortcodeValue(ctx context.Context, value any, locale string) (any, error) {
-	svc := s.deps.Shortcodes
-	if svc == nil {
-		return value, nil
+func (s *service) renderShortcodeValue(ctx context.Context, logger interfaces.Logger, value any, opts *interfaces.ShortcodeProcessOptions) any {
+	if s.deps.Shortcodes == nil {
+		return value
 	}
 
 	switch v := value.(type) {
 	case string:
 		if !containsShortcodeSyntax(v) {
-			return v, nil
-		}
-		output, err := svc.Process(ctx, v, interfaces.ShortcodeProcessOptions{Locale: locale})
-		if err != nil {
-			return nil, err
+			return v
 		}
-		return output, nil
+		return s.renderShortcodesOrRaw(ctx, logger, v, opts)
 	case []any:
 		out := make([]any, len(v))
 		for i, item := range v {
-			rendered, err := s.renderShortcodeValue(ctx, item, locale)
-			if err != nil {
-				return nil, err
-			}
-			out[i] = rendered
+			out[i] = s.renderShortcodeValue(ctx, logger, item, opts)
 		}
-		return out, nil
+		return out
 	case []string:
 		out := make([]string, len(v))
 		for i, item := range v {
-			rendered, err := s.renderShortcodeValue(ctx, item, locale)
-			if err != nil {
-				return nil, err
-			}
-			if str, ok := rendered.(string); ok {
+			if str, ok := s.renderShortcodeValue(ctx, logger, item, opts).(string); ok {
 				out[i] = str
 			} else {
 				out[i] = item
 			}
 		}
-		return out, nil
+		return out
 	case map[string]any:
-		if err := s.renderShortcodesInMap(ctx, v, locale); err != nil {
-			return nil, err
-		}
-		return v, nil
+		s.renderShortcodesInMap(ctx, logger, v, opts)
+		return v
 	default:
-		return value, nil
+		return value
 	}
 }
 
@@ -794,6 +867,9 @@ func (s *service) persistPages(
 			"route":    route,
 			"template": pages[i].Template,
 		}
+		if pages[i].Collection != "" {
+			metadata["collection"] = pages[i].Collection
+		}
 		if s.cfg.Incremental {
 			metadata["incremental"] = "true"
 		}
@@ -944,10 +1020,14 @@ func (s *service) copyAssets(
 	return summary, nil
 }
 
+// mergeRenderedForSitemap lists the sitemap entries of the build context,
+// preferring fresh renders over manifest entries. Partial builds also keep
+// every other page recorded in the manifest.
 func (s *service) mergeRenderedForSitemap(
 	buildCtx *BuildContext,
 	rendered []RenderedPage,
 	manifest *buildManifest,
+	partial bool,
 ) []RenderedPage {
 	if buildCtx == nil {
 		return append([]RenderedPage(nil), rendered...)
@@ -980,7 +1060,8 @@ func (s *service) mergeRenderedForSitemap(
 					Hash:         entry.Hash,
 					LastModified: entry.LastModified,
 				},
-				Checksum: entry.Checksum,
+				Checksum:  entry.Checksum,
+				Canonical: entry.Canonical,
 			})
 			continue
 		}
@@ -993,11 +1074,63 @@ func (s *service) mergeRenderedForSitemap(
 			}
 		}
 		sitemap = append(sitemap, RenderedPage{
-			PageID:   data.Page.ID,
-			Locale:   data.Locale.Code,
-			Route:    safeTranslationPath(data.Translation),
-			Template: templateName,
-			Metadata: data.Metadata,
+			PageID:    data.Page.ID,
+			Locale:    data.Locale.Code,
+			Route:     safeTranslationPath(data.Translation),
+			Template:  templateName,
+			Metadata:  data.Metadata,
+			Canonical: data.Canonical.Route,
+		})
+	}
+	for _, data := range buildCtx.Collections {
+		key := manifest.pageKey(data.ID, data.Locale.Code)
+		if page, ok := renderedByKey[key]; ok {
+			sitemap = append(sitemap, page)
+			continue
+		}
+		sitemap = append(sitemap, RenderedPage{
+			PageID:     data.ID,
+			Collection: data.Collection,
+			Locale:     data.Locale.Code,
+			Route:      data.Route,
+			Template:   data.Template,
+			Metadata:   data.Metadata,
+			Canonical:  data.Canonical.Route,
+		})
+	}
+	if !partial {
+		return sitemap
+	}
+	covered := make(map[string]struct{}, len(sitemap))
+	for _, page := range sitemap {
+		covered[manifest.pageKey(page.PageID, page.Locale)] = struct{}{}
+	}
+	keys := make([]string, 0, len(manifest.Pages))
+	for key := range manifest.Pages {
+		if _, ok := covered[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		entry := manifest.Pages[key]
+		pageID, err := uuid.Parse(entry.PageID)
+		if err != nil {
+			continue
+		}
+		sitemap = append(sitemap, RenderedPage{
+			PageID:     pageID,
+			Collection: entry.Collection,
+			Locale:     entry.Locale,
+			Route:      entry.Route,
+			Output:     entry.Output,
+			Template:   entry.Template,
+			Metadata: DependencyMetadata{
+				Hash:         entry.Hash,
+				LastModified: entry.LastModified,
+			},
+			Checksum:  entry.Checksum,
+			Canonical: entry.Canonical,
 		})
 	}
 	return sitemap
@@ -1126,26 +1259,30 @@ func (s *service) writeSitemap(
 	buildCtx *BuildContext,
 	pages []RenderedPage,
 ) error {
-	content := buildSitemap(siteMeta.BaseURL, pages, buildCtx.GeneratedAt)
+	files := buildSitemaps(siteMeta.BaseURL, buildCtx.DefaultLocale, buildCtx.Locales, pages, buildCtx.GeneratedAt, s.cfg.SitemapMaxURLs)
 	baseDir := strings.Trim(strings.TrimSpace(s.cfg.OutputDir), "/")
-	destRel := "sitemap.xml"
-	fullPath := joinOutputPath(baseDir, destRel)
-	if err := ensureDir(ctx, writer, map[string]struct{}{}, path.Dir(fullPath)); err != nil {
-		return err
-	}
-	checksum := computeHashFromString(content)
-	req := writeFileRequest{
-		Path:        fullPath,
-		Content:     strings.NewReader(content),
-		Size:        int64(len(content)),
-		Category:    categorySitemap,
-		ContentType: "application/xml",
-		Checksum:    checksum,
-		Metadata: map[string]string{
-			"generated_at": buildCtx.GeneratedAt.UTC().Format(time.RFC3339),
-		},
+	dirs := map[string]struct{}{}
+	for _, file := range files {
+		fullPath := joinOutputPath(baseDir, file.Name)
+		if err := ensureDir(ctx, writer, dirs, path.Dir(fullPath)); err != nil {
+			return err
+		}
+		req := writeFileRequest{
+			Path:        fullPath,
+			Content:     strings.NewReader(file.Content),
+			Size:        int64(len(file.Content)),
+			Category:    categorySitemap,
+			ContentType: "application/xml",
+			Checksum:    computeHashFromString(file.Content),
+			Metadata: map[string]string{
+				"generated_at": buildCtx.GeneratedAt.UTC().Format(time.RFC3339),
+			},
+		}
+		if err := writer.WriteFile(ctx, req); err != nil {
+			return err
+		}
 	}
-	return writer.WriteFile(ctx, req)
+	return nil
 }
 
 func (s *service) writeRobots(
@@ -1310,23 +1447,7 @@ func (s *service) BuildPage(ctx context.Context, pageID uuid.UUID, locale string
 			errorsSlice = append(errorsSlice, err)
 		}
 		manifest.GeneratedAt = buildCtx.GeneratedAt
-		for _, page := range rendered {
-			if page.PageID == uuid.Nil || strings.TrimSpace(page.Checksum) == "" {
-				continue
-			}
-			entry := manifestPage{
-				PageID:       page.PageID.String(),
-				Locale:       page.Locale,
-				Route:        page.Route,
-				Output:       page.Output,
-				Template:     page.Template,
-				Hash:         page.Metadata.Hash,
-				Checksum:     page.Checksum,
-				LastModified: page.Metadata.LastModified,
-				RenderedAt:   buildCtx.GeneratedAt,
-			}
-			manifest.setPage(entry)
-		}
+		recordRenderedPages(manifest, rendered, buildCtx.GeneratedAt)
 		if err := s.persistManifest(ctx, writer, manifest); err != nil {
 			errorsSlice = append(errorsSlice, err)
 		}
@@ -1420,7 +1541,7 @@ func (s *service) BuildSitemap(ctx context.Context) error {
 	}
 
 	writer := newArtifactWriter(s.deps.Storage)
-	sitemapPages := s.mergeRenderedForSitemap(buildCtx, nil, manifest)
+	sitemapPages := s.mergeRenderedForSitemap(buildCtx, nil, manifest, false)
 	var writeErr error
 	if len(sitemapPages) > 0 {
 		writeErr = s.writeSitemap(ctx, writer, siteMeta, buildCtx, sitemapPages)
@@ -1487,6 +1608,10 @@ func (disabledService) BuildSitemap(context.Context) error {
 	return ErrServiceDisabled
 }
 
+func (disabledService) BuildDependents(context.Context, []string) (*BuildResult, error) {
+	return nil, ErrServiceDisabled
+}
+
 func (disabledService) Clean(context.Context) error {
 	return ErrServiceDisabled
 }
@@ -178,6 +178,81 @@ func TestRenderShortcodesInTemplateContext(t *testing.T) {
 	}
 }
 
+func TestRenderShortcodesInTemplateContextCollectsCSP(t *testing.T) {
+	validator := shortcodepkg.NewValidator()
+	registry := shortcodepkg.NewRegistry(validator)
+	if err := shortcodepkg.RegisterBuiltIns(registry, nil); err != nil {
+		t.Fatalf("RegisterBuiltIns: %v", err)
+	}
+	renderer := shortcodepkg.NewRenderer(registry, validator)
+	svc := &service{
+		deps: Dependencies{Shortcodes: shortcodepkg.NewService(registry, renderer, shortcodepkg.WithCSP(true))},
+	}
+
+	summary := "Summary {{< alert type=\"info\" >}}Notice{{< /alert >}}"
+	tmpl := TemplateContext{
+		Page: PageRenderingContext{
+			Locale: LocaleSpec{Code: "en"},
+			ContentTranslation: &content.ContentTranslation{
+				Content: map[string]any{"body": "Watch {{< youtube id=\"dQw4w9WgXcQ\" >}}"},
+				Summary: &summary,
+			},
+		},
+	}
+
+	svc.renderShortcodesInTemplateContext(context.Background(), &tmpl)
+
+	if tmpl.CSP == nil || tmpl.CSP.Nonce() == "" {
+		t.Fatalf("expected a page CSP with a nonce, got %+v", tmpl.CSP)
+	}
+	header := tmpl.CSP.Header()
+	if !strings.Contains(header, "'nonce-"+tmpl.CSP.Nonce()+"'") || !strings.Contains(header, "frame-src https://www.youtube.com") {
+		t.Fatalf("expected nonce and youtube frame source in %q", header)
+	}
+}
+
+func TestRenderShortcodesInTemplateContextIsTrustedAndKeepsRawOnError(t *testing.T) {
+	validator := shortcodepkg.NewValidator()
+	registry := shortcodepkg.NewRegistry(validator)
+	if err := shortcodepkg.RegisterBuiltIns(registry, nil); err != nil {
+		t.Fatalf("RegisterBuiltIns: %v", err)
+	}
+	renderer := shortcodepkg.NewRenderer(registry, validator, shortcodepkg.WithRendererRateLimit(1))
+	svc := &service{
+		deps: Dependencies{Shortcodes: shortcodepkg.NewService(registry, renderer)},
+	}
+
+	tmpl := TemplateContext{
+		Page: PageRenderingContext{
+			Locale: LocaleSpec{Code: "en"},
+			ContentTranslation: &content.ContentTranslation{
+				Content: map[string]any{
+					"first":  "{{< alert type=\"info\" >}}One{{< /alert >}}",
+					"second": "{{< alert type=\"info\" >}}Two{{< /alert >}}",
+				},
+			},
+		},
+	}
+	svc.renderShortcodesInTemplateContext(context.Background(), &tmpl)
+	for key, value := range tmpl.Page.ContentTranslation.Content {
+		if strings.Contains(value.(string), "{{<") {
+			t.Fatalf("expected build renders to bypass the rate limit, %s kept raw: %v", key, value)
+		}
+	}
+
+	tmpl.Page.ContentTranslation.Content = map[string]any{
+		"body":  "{{< unknown >}}",
+		"notes": "{{< alert type=\"info\" >}}Three{{< /alert >}}",
+	}
+	svc.renderShortcodesInTemplateContext(context.Background(), &tmpl)
+	if body := tmpl.Page.ContentTranslation.Content["body"]; body != "{{< unknown >}}" {
+		t.Fatalf("expected failed shortcode to keep raw content, got %v", body)
+	}
+	if notes := tmpl.Page.ContentTranslation.Content["notes"].(string); !strings.Contains(notes, "Three") || strings.Contains(notes, "{{<") {
+		t.Fatalf("expected other values to render, got %q", notes)
+	}
+}
+
 func TestBuildSkipsPagesWithoutTranslations(t *testing.T) {
 	t.Parallel()
 
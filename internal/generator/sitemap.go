@@ -2,33 +2,99 @@ package generator
 
 import (
 	"fmt"
+	"html"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// sitemapURLLimit is the maximum number of URLs the sitemap protocol allows
+// in a single sitemap file.
+const sitemapURLLimit = 50000
+
 type sitemapEntry struct {
 	Location   string
 	LastMod    time.Time
+	Locale     string
+	Alternates []LocaleAlternate
 	Priority   string
 	ChangeFreq string
 }
 
-func buildSitemap(baseURL string, pages []RenderedPage, fallback time.Time) string {
-	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
-	if base == "" {
-		base = "http://localhost"
+type sitemapFile struct {
+	Name    string
+	Content string
+}
+
+// buildSitemaps renders the sitemap files for the provided pages. Sites within
+// the URL limit get a single sitemap.xml. Larger sites get one sitemap per
+// locale, split further when a locale exceeds the limit, referenced from a
+// sitemap.xml index.
+func buildSitemaps(baseURL, defaultLocale string, locales []LocaleSpec, pages []RenderedPage, fallback time.Time, limit int) []sitemapFile {
+	if limit <= 0 {
+		limit = sitemapURLLimit
+	}
+	base := baseURLWithFallback(baseURL)
+	entries := buildSitemapEntries(base, defaultLocale, locales, pages, fallback)
+	if len(entries) <= limit {
+		return []sitemapFile{{Name: "sitemap.xml", Content: renderURLSet(entries)}}
 	}
 
+	byLocale := map[string][]sitemapEntry{}
+	var order []string
+	for _, entry := range entries {
+		if _, ok := byLocale[entry.Locale]; !ok {
+			order = append(order, entry.Locale)
+		}
+		byLocale[entry.Locale] = append(byLocale[entry.Locale], entry)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return localeIndex(locales, order[i]) < localeIndex(locales, order[j])
+	})
+
+	var (
+		files []sitemapFile
+		index []sitemapEntry
+	)
+	for _, locale := range order {
+		group := byLocale[locale]
+		for chunk := 0; chunk*limit < len(group); chunk++ {
+			part := group[chunk*limit : min((chunk+1)*limit, len(group))]
+			name := fmt.Sprintf("sitemap-%s.xml", locale)
+			if chunk > 0 {
+				name = fmt.Sprintf("sitemap-%s-%d.xml", locale, chunk+1)
+			}
+			var lastMod time.Time
+			for _, entry := range part {
+				if entry.LastMod.After(lastMod) {
+					lastMod = entry.LastMod
+				}
+			}
+			files = append(files, sitemapFile{Name: name, Content: renderURLSet(part)})
+			index = append(index, sitemapEntry{Location: base + "/" + name, LastMod: lastMod})
+		}
+	}
+	return append([]sitemapFile{{Name: "sitemap.xml", Content: renderSitemapIndex(index)}}, files...)
+}
+
+// buildSitemapEntries lists the canonical URLs of the provided pages, linking
+// the localized variants of each page as hreflang alternates. Pages whose
+// canonical URL points at another locale are omitted.
+func buildSitemapEntries(base, defaultLocale string, locales []LocaleSpec, pages []RenderedPage, fallback time.Time) []sitemapEntry {
 	entries := make([]sitemapEntry, 0, len(pages))
+	families := make([]uuid.UUID, 0, len(pages))
+	variants := map[uuid.UUID][]LocaleAlternate{}
 	seen := map[string]struct{}{}
 	for _, page := range pages {
-		route := strings.TrimSpace(page.Route)
-		if route == "" {
-			route = "/"
+		locale := strings.TrimSpace(page.Locale)
+		if locale == "" {
+			locale = defaultLocale
 		}
-		if !strings.HasPrefix(route, "/") {
-			route = "/" + route
+		route := localizeRoute(page.Route, locale, defaultLocale)
+		if canonical := strings.TrimSpace(page.Canonical); canonical != "" && canonical != route {
+			continue
 		}
 		location := base + route
 		if _, ok := seen[location]; ok {
@@ -42,28 +108,60 @@ func buildSitemap(baseURL string, pages []RenderedPage, fallback time.Time) stri
 		entries = append(entries, sitemapEntry{
 			Location: location,
 			LastMod:  lastMod,
+			Locale:   strings.ToLower(locale),
 		})
+		families = append(families, page.PageID)
+		if page.PageID != uuid.Nil {
+			variants[page.PageID] = append(variants[page.PageID], LocaleAlternate{Locale: locale, Route: route, URL: location})
+		}
+	}
+	for i := range entries {
+		if families[i] != uuid.Nil {
+			entries[i].Alternates = buildAlternates(defaultLocale, locales, variants[families[i]])
+		}
 	}
 
 	sort.Slice(entries, func(i, j int) bool {
 		return entries[i].Location < entries[j].Location
 	})
+	return entries
+}
 
+func renderURLSet(entries []sitemapEntry) string {
 	var builder strings.Builder
 	builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
-	builder.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	builder.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">` + "\n")
 	for _, entry := range entries {
 		builder.WriteString("  <url>\n")
-		builder.WriteString(fmt.Sprintf("    <loc>%s</loc>\n", entry.Location))
+		builder.WriteString(fmt.Sprintf("    <loc>%s</loc>\n", html.EscapeString(entry.Location)))
 		if !entry.LastMod.IsZero() {
 			builder.WriteString(fmt.Sprintf("    <lastmod>%s</lastmod>\n", entry.LastMod.UTC().Format(time.RFC3339)))
 		}
+		for _, alternate := range entry.Alternates {
+			builder.WriteString(fmt.Sprintf("    <xhtml:link rel=\"alternate\" hreflang=\"%s\" href=\"%s\"/>\n", html.EscapeString(alternate.Locale), html.EscapeString(alternate.URL)))
+		}
 		builder.WriteString("  </url>\n")
 	}
 	builder.WriteString(`</urlset>` + "\n")
 	return builder.String()
 }
 
+func renderSitemapIndex(entries []sitemapEntry) string {
+	var builder strings.Builder
+	builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	builder.WriteString(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	for _, entry := range entries {
+		builder.WriteString("  <sitemap>\n")
+		builder.WriteString(fmt.Sprintf("    <loc>%s</loc>\n", html.EscapeString(entry.Location)))
+		if !entry.LastMod.IsZero() {
+			builder.WriteString(fmt.Sprintf("    <lastmod>%s</lastmod>\n", entry.LastMod.UTC().Format(time.RFC3339)))
+		}
+		builder.WriteString("  </sitemap>\n")
+	}
+	builder.WriteString(`</sitemapindex>` + "\n")
+	return builder.String()
+}
+
 func buildRobots(baseURL string, includeSitemap bool) string {
 	var builder strings.Builder
 	builder.WriteString("User-agent: *\n")
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/sites"
+	"github.com/google/uuid"
+)
+
+var errSiteLookupRequired = errors.New("generator: site lookup is required for site builds")
+
+// SiteLookup resolves sites for per-site builds.
+type SiteLookup interface {
+	GetSiteByKey(ctx context.Context, key string) (*sites.Site, error)
+}
+
+// PageLookup lists page records so site builds honour the site each page is
+// scoped to rather than the site of its content.
+type PageLookup interface {
+	List(ctx context.Context, env ...string) ([]*pages.Page, error)
+}
+
+// forSite returns a copy of the service configured for the given site. The
+// site's base URL, locales, theme and menu locations override the generator
+// configuration, output is written under OutputDir/<site key>, and pages and
+// content pinned to other sites are skipped.
+func (s *service) forSite(ctx context.Context, key string) (*service, error) {
+	if s.deps.Sites == nil {
+		return nil, errSiteLookupRequired
+	}
+	site, err := s.deps.Sites.GetSiteByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := s.cfg
+	cfg.OutputDir = path.Join(cfg.OutputDir, site.Key)
+	if site.BaseURL != "" {
+		cfg.BaseURL = site.BaseURL
+	}
+	if site.DefaultLocale != "" {
+		cfg.DefaultLocale = site.DefaultLocale
+	}
+	if len(site.Locales) > 0 {
+		cfg.Locales = slices.Clone(site.Locales)
+	}
+	if len(site.MenuLocations) > 0 {
+		cfg.Menus = maps.Clone(cfg.Menus)
+		if cfg.Menus == nil {
+			cfg.Menus = make(map[string]string, len(site.MenuLocations))
+		}
+		maps.Copy(cfg.Menus, site.MenuLocations)
+	}
+	if theme := strings.TrimSpace(site.Theme); theme != "" {
+		cfg.Theming.DefaultTheme = theme
+	}
+	if variant := strings.TrimSpace(site.ThemeVariant); variant != "" {
+		cfg.Theming.DefaultVariant = variant
+	}
+
+	clone := *s
+	clone.cfg = cfg
+	clone.site = site
+	clone.themeSelector = newThemeSelector(cfg.Theming, nil)
+	return &clone, nil
+}
+
+// includesSite reports whether a record scoped to siteID belongs in the
+// current build. Unscoped builds include every record.
+func (s *service) includesSite(siteID *uuid.UUID) bool {
+	return s.site == nil || s.site.Includes(siteID)
+}
+
+// listContent lists content entries visible to the current site build.
+func (s *service) listContent(ctx context.Context, opts ...content.ContentListOption) ([]*content.Content, error) {
+	records, err := s.deps.Content.List(ctx, opts...)
+	if err != nil || s.site == nil {
+		return records, err
+	}
+	pageSites, err := s.pageSites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := records[:0:0]
+	for _, record := range records {
+		if record != nil && s.includesSite(recordSiteID(record, pageSites)) {
+			out = append(out, record)
+		}
+	}
+	return out, nil
+}
+
+// pageSites maps content IDs to the site of the page records built from them.
+// It is nil for unscoped builds and when no page lookup is configured.
+func (s *service) pageSites(ctx context.Context) (map[uuid.UUID]*uuid.UUID, error) {
+	if s.site == nil || s.deps.Pages == nil {
+		return nil, nil
+	}
+	records, err := s.deps.Pages.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uuid.UUID]*uuid.UUID, len(records))
+	for _, record := range records {
+		if record != nil && record.ContentID != uuid.Nil {
+			out[record.ContentID] = record.SiteID
+		}
+	}
+	return out, nil
+}
+
+// recordSiteID returns the site a content record is built for. Page records
+// may pin shared content to a site, so their site wins over the content's.
+func recordSiteID(record *content.Content, pageSites map[uuid.UUID]*uuid.UUID) *uuid.UUID {
+	if siteID, ok := pageSites[record.ID]; ok {
+		return siteID
+	}
+	return record.SiteID
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/sites"
+	"github.com/google/uuid"
+)
+
+func TestBuildSiteScopesOutputAndContent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+	fixtures.Config.GenerateSitemap = true
+
+	blog := &sites.Site{
+		ID:            uuid.New(),
+		Key:           "blog",
+		BaseURL:       "https://blog.example.com",
+		DefaultLocale: "en",
+		Locales:       []string{"en"},
+		MenuLocations: map[string]string{"footer": "blog-footer"},
+	}
+	otherSiteID := uuid.New()
+	fixtures.Content.records[fixtures.PageIDs[1]].SiteID = &otherSiteID
+
+	storage := &recordingStorage{}
+	renderer := &recordingRenderer{}
+	svc := newRebuildTestService(fixtures, renderer, storage, now)
+	svc.deps.Sites = stubSiteLookup{blog.Key: blog}
+
+	result, err := svc.Build(ctx, BuildOptions{Site: "blog"})
+	if err != nil {
+		t.Fatalf("build site: %v", err)
+	}
+	if result.PagesBuilt != 1 {
+		t.Fatalf("expected only the shared page in the site locale, got %d", result.PagesBuilt)
+	}
+	if _, ok := storage.files["dist/blog/company/index.html"]; !ok {
+		t.Fatalf("expected shared page under the site output directory")
+	}
+	for name := range storage.files {
+		if !strings.HasPrefix(name, "dist/blog/") {
+			t.Fatalf("unexpected output outside site directory: %s", name)
+		}
+		if strings.Contains(name, "vision") {
+			t.Fatalf("expected page pinned to another site to be skipped: %s", name)
+		}
+	}
+	sitemap := string(storage.files["dist/blog/sitemap.xml"])
+	if !strings.Contains(sitemap, "https://blog.example.com/company") {
+		t.Fatalf("expected sitemap to use the site base url:\n%s", sitemap)
+	}
+	if renderer.calls[0].ctx.Site.MenuAliases["footer"] != "blog-footer" {
+		t.Fatalf("expected site menu locations in template context")
+	}
+	if svc.cfg.OutputDir != "dist" || svc.site != nil {
+		t.Fatalf("expected site build to leave the base service untouched")
+	}
+}
+
+func TestBuildSiteScopesPagesByPageRecordSite(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fixtures := newRenderFixtures(now)
+
+	blog := &sites.Site{ID: uuid.New(), Key: "blog", DefaultLocale: "en", Locales: []string{"en"}}
+	otherSiteID := uuid.New()
+
+	storage := &recordingStorage{}
+	svc := newRebuildTestService(fixtures, &recordingRenderer{}, storage, now)
+	svc.deps.Sites = stubSiteLookup{blog.Key: blog}
+	svc.deps.Pages = stubPageLookup{
+		{ID: uuid.New(), ContentID: fixtures.PageIDs[0], SiteID: &blog.ID},
+		{ID: uuid.New(), ContentID: fixtures.PageIDs[1], SiteID: &otherSiteID},
+	}
+
+	result, err := svc.Build(ctx, BuildOptions{Site: "blog"})
+	if err != nil {
+		t.Fatalf("build site: %v", err)
+	}
+	if result.PagesBuilt != 1 {
+		t.Fatalf("expected only the page scoped to the site, got %d", result.PagesBuilt)
+	}
+	for name := range storage.files {
+		if strings.Contains(name, "vision") {
+			t.Fatalf("expected shared content pinned to another site by its page to be skipped: %s", name)
+		}
+	}
+	if len(fixtures.Menus.sites) == 0 {
+		t.Fatalf("expected menus to be resolved")
+	}
+	for _, key := range fixtures.Menus.sites {
+		if key != blog.Key {
+			t.Fatalf("expected menus to resolve for the built site, got %q", key)
+		}
+	}
+}
+
+func TestBuildSiteRequiresLookup(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	svc := newRebuildTestService(newRenderFixtures(now), &recordingRenderer{}, &recordingStorage{}, now)
+
+	if _, err := svc.Build(context.Background(), BuildOptions{Site: "blog"}); !errors.Is(err, errSiteLookupRequired) {
+		t.Fatalf("expected site lookup error, got %v", err)
+	}
+
+	svc.deps.Sites = stubSiteLookup{}
+	if _, err := svc.Build(context.Background(), BuildOptions{Site: "blog"}); !errors.Is(err, sites.ErrSiteNotFound) {
+		t.Fatalf("expected site not found, got %v", err)
+	}
+}
+
+type stubSiteLookup map[string]*sites.Site
+
+func (s stubSiteLookup) GetSiteByKey(_ context.Context, key string) (*sites.Site, error) {
+	if site, ok := s[key]; ok {
+		return site, nil
+	}
+	return nil, sites.ErrSiteNotFound
+}
+
+type stubPageLookup []*pages.Page
+
+func (s stubPageLookup) List(context.Context, ...string) ([]*pages.Page, error) {
+	return s, nil
+}
@@ -293,6 +293,86 @@ func TestAdminAPI_PromotionEndpoints(t *testing.T) {
 	if promo.lastContent == nil || promo.lastContent.ContentID != contentID || promo.lastContent.TargetEnvironment != "staging" {
 		t.Fatalf("expected content promotion request to target staging")
 	}
+
+	pageID := uuid.New()
+	doJSONRequest(t, mux, http.MethodPost, "/admin/api/pages/"+pageID.String()+"/promote?to=prod", map[string]any{
+		"options": map[string]any{"mode": "upsert", "auto_promote_dependencies": true},
+	}, http.StatusOK)
+	if promo.lastPage == nil || promo.lastPage.PageID != pageID || promo.lastPage.TargetEnvironment != "prod" || !promo.lastPage.Options.AutoPromoteDependencies {
+		t.Fatalf("expected page promotion request to target prod, got %+v", promo.lastPage)
+	}
+
+	menuID := uuid.New()
+	doJSONRequest(t, mux, http.MethodPost, "/admin/api/menus/"+menuID.String()+"/promote?to=prod", nil, http.StatusOK)
+	if promo.lastMenu == nil || promo.lastMenu.MenuID != menuID {
+		t.Fatalf("expected menu promotion request, got %+v", promo.lastMenu)
+	}
+
+	doJSONRequest(t, mux, http.MethodPost, "/admin/api/widget-areas/sidebar/promote?from=dev&to=prod", nil, http.StatusOK)
+	if promo.lastArea == nil || promo.lastArea.AreaCode != "sidebar" || promo.lastArea.SourceEnvironment != "dev" || promo.lastArea.TargetEnvironment != "prod" {
+		t.Fatalf("expected widget area promotion request, got %+v", promo.lastArea)
+	}
+
+	definitionID := uuid.New()
+	doJSONRequest(t, mux, http.MethodPost, "/admin/api/blocks/"+definitionID.String()+"/promote?to=prod", nil, http.StatusOK)
+	if promo.lastBlock == nil || promo.lastBlock.DefinitionID != definitionID {
+		t.Fatalf("expected block definition promotion request, got %+v", promo.lastBlock)
+	}
+
+	doJSONRequest(t, mux, http.MethodGet, "/admin/api/promotions?target=prod&limit=10", nil, http.StatusOK)
+	if promo.lastList == nil || promo.lastList.TargetEnvironment != "prod" || promo.lastList.Limit != 10 {
+		t.Fatalf("expected promotion history request for prod, got %+v", promo.lastList)
+	}
+	batchID := uuid.New()
+	doJSONRequest(t, mux, http.MethodGet, "/admin/api/promotions/"+batchID.String(), nil, http.StatusNotFound)
+	doJSONRequest(t, mux, http.MethodPost, "/admin/api/promotions/"+batchID.String()+"/rollback", nil, http.StatusConflict)
+	rollbackResp := doJSONRequest(t, mux, http.MethodPost, "/admin/api/promotions/"+batchID.String()+"/rollback", map[string]any{"force": true}, http.StatusOK)
+	var rollback promotions.RollbackResult
+	decodeJSONBody(t, rollbackResp, &rollback)
+	if rollback.Batch == nil || rollback.Batch.Status != promotions.BatchRolledBack {
+		t.Fatalf("expected rolled back batch, got %+v", rollback)
+	}
+}
+
+func TestAdminAPI_CompareEnvironmentsEndpoint(t *testing.T) {
+	promo := &promotionStub{
+		compareResult: &promotions.EnvironmentComparison{
+			SourceEnv: promotions.EnvironmentRef{ID: uuid.New(), Key: "dev"},
+			TargetEnv: promotions.EnvironmentRef{ID: uuid.New(), Key: "prod"},
+			Summary: promotions.ComparisonSummary{
+				ContentTypes: promotions.ComparisonSummaryCounts{Changed: 1},
+			},
+			Items: []promotions.ComparisonItem{
+				{
+					Kind:   promotions.KindContentType,
+					Key:    "article",
+					Status: promotions.ComparisonChanged,
+					Compatibility: &promotions.SchemaCompatibility{
+						Compatible:  false,
+						ChangeLevel: "major",
+					},
+				},
+			},
+		},
+	}
+
+	mux, _ := setupAdminAPI(t, WithPromotionService(promo))
+
+	resp := doJSONRequest(t, mux, http.MethodGet, "/admin/api/environments/dev/compare/prod?kinds=content_types,pages&include_unchanged=true", nil, http.StatusOK)
+	var result promotions.EnvironmentComparison
+	decodeJSONBody(t, resp, &result)
+	if len(result.Items) != 1 || result.Items[0].Compatibility == nil || result.Items[0].Compatibility.ChangeLevel != "major" {
+		t.Fatalf("expected compatibility details in response, got %+v", result.Items)
+	}
+	if promo.lastCompare == nil || promo.lastCompare.SourceEnvironment != "dev" || promo.lastCompare.TargetEnvironment != "prod" {
+		t.Fatalf("expected comparison service to receive source and target env")
+	}
+	if len(promo.lastCompare.Kinds) != 2 || promo.lastCompare.Kinds[1] != "pages" {
+		t.Fatalf("expected kinds to be parsed, got %v", promo.lastCompare.Kinds)
+	}
+	if !promo.lastCompare.IncludeUnchanged {
+		t.Fatalf("expected include_unchanged to be parsed")
+	}
 }
 
 type testServices struct {
@@ -342,9 +422,17 @@ type promotionStub struct {
 	lastEnv     *promotions.PromoteEnvironmentRequest
 	lastType    *promotions.PromoteContentTypeRequest
 	lastContent *promotions.PromoteContentEntryRequest
-
-	envResult  *promotions.PromoteEnvironmentResult
-	itemResult *promotions.PromoteItem
+	lastCompare *promotions.CompareEnvironmentsRequest
+	lastBlock   *promotions.PromoteBlockDefinitionRequest
+	lastPage    *promotions.PromotePageRequest
+	lastMenu    *promotions.PromoteMenuRequest
+	lastArea    *promotions.PromoteWidgetAreaRequest
+	lastList    *promotions.ListPromotionsRequest
+	lastRevert  *promotions.RollbackOptions
+
+	envResult     *promotions.PromoteEnvironmentResult
+	itemResult    *promotions.PromoteItem
+	compareResult *promotions.EnvironmentComparison
 }
 
 func (s *promotionStub) PromoteEnvironment(ctx context.Context, req promotions.PromoteEnvironmentRequest) (*promotions.PromoteEnvironmentResult, error) {
@@ -368,6 +456,52 @@ func (s *promotionStub) PromoteContentEntry(ctx context.Context, req promotions.
 	return s.itemResult, nil
 }
 
+func (s *promotionStub) PromoteBlockDefinition(ctx context.Context, req promotions.PromoteBlockDefinitionRequest) (*promotions.PromoteItem, error) {
+	s.lastBlock = &req
+	return &promotions.PromoteItem{Kind: promotions.KindBlockDefinition, SourceID: req.DefinitionID, TargetID: uuid.New(), Status: "created"}, nil
+}
+
+func (s *promotionStub) PromotePage(ctx context.Context, req promotions.PromotePageRequest) (*promotions.PromoteItem, error) {
+	s.lastPage = &req
+	return &promotions.PromoteItem{Kind: promotions.KindPage, SourceID: req.PageID, TargetID: uuid.New(), Status: "created"}, nil
+}
+
+func (s *promotionStub) PromoteMenu(ctx context.Context, req promotions.PromoteMenuRequest) (*promotions.PromoteItem, error) {
+	s.lastMenu = &req
+	return &promotions.PromoteItem{Kind: promotions.KindMenu, SourceID: req.MenuID, TargetID: uuid.New(), Status: "created"}, nil
+}
+
+func (s *promotionStub) PromoteWidgetArea(ctx context.Context, req promotions.PromoteWidgetAreaRequest) (*promotions.PromoteItem, error) {
+	s.lastArea = &req
+	id := uuid.New()
+	return &promotions.PromoteItem{Kind: promotions.KindWidgetArea, SourceID: id, TargetID: id, Status: "created"}, nil
+}
+
+func (s *promotionStub) CompareEnvironments(ctx context.Context, req promotions.CompareEnvironmentsRequest) (*promotions.EnvironmentComparison, error) {
+	s.lastCompare = &req
+	if s.compareResult == nil {
+		return &promotions.EnvironmentComparison{}, nil
+	}
+	return s.compareResult, nil
+}
+
+func (s *promotionStub) ListPromotions(ctx context.Context, req promotions.ListPromotionsRequest) ([]*promotions.PromotionBatch, error) {
+	s.lastList = &req
+	return []*promotions.PromotionBatch{{ID: uuid.New(), Status: promotions.BatchCompleted}}, nil
+}
+
+func (s *promotionStub) GetPromotion(ctx context.Context, batchID uuid.UUID) (*promotions.PromotionBatch, error) {
+	return nil, promotions.ErrPromotionNotFound
+}
+
+func (s *promotionStub) RollbackPromotion(ctx context.Context, batchID uuid.UUID, opts promotions.RollbackOptions) (*promotions.RollbackResult, error) {
+	s.lastRevert = &opts
+	if !opts.Force {
+		return nil, promotions.ErrPromotionTargetChanged
+	}
+	return &promotions.RollbackResult{Batch: &promotions.PromotionBatch{ID: batchID, Status: promotions.BatchRolledBack}}, nil
+}
+
 func doJSONRequest(t *testing.T, mux *http.ServeMux, method, path string, body any, wantStatus int) *httptest.ResponseRecorder {
 	t.Helper()
 	var buf bytes.Buffer
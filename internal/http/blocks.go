@@ -30,19 +30,20 @@ type blockCreatePayload struct {
 }
 
 type blockUpdatePayload struct {
-	Name             *string        `json:"name,omitempty"`
-	Slug             *string        `json:"slug,omitempty"`
-	Description      *string        `json:"description,omitempty"`
-	Icon             *string        `json:"icon,omitempty"`
-	Category         *string        `json:"category,omitempty"`
-	Status           *string        `json:"status,omitempty"`
-	Schema           map[string]any `json:"schema,omitempty"`
-	UISchema         map[string]any `json:"ui_schema,omitempty"`
-	Defaults         map[string]any `json:"defaults,omitempty"`
-	EditorStyleURL   *string        `json:"editor_style_url,omitempty"`
-	FrontendStyleURL *string        `json:"frontend_style_url,omitempty"`
-	Environment      *string        `json:"environment,omitempty"`
-	EnvironmentID    *uuid.UUID     `json:"environment_id,omitempty"`
+	Name             *string                     `json:"name,omitempty"`
+	Slug             *string                     `json:"slug,omitempty"`
+	Description      *string                     `json:"description,omitempty"`
+	Icon             *string                     `json:"icon,omitempty"`
+	Category         *string                     `json:"category,omitempty"`
+	Status           *string                     `json:"status,omitempty"`
+	Schema           map[string]any              `json:"schema,omitempty"`
+	UISchema         map[string]any              `json:"ui_schema,omitempty"`
+	Defaults         map[string]any              `json:"defaults,omitempty"`
+	EditorStyleURL   *string                     `json:"editor_style_url,omitempty"`
+	FrontendStyleURL *string                     `json:"frontend_style_url,omitempty"`
+	Migration        []schema.MigrationOperation `json:"migration,omitempty"`
+	Environment      *string                     `json:"environment,omitempty"`
+	EnvironmentID    *uuid.UUID                  `json:"environment_id,omitempty"`
 }
 
 type blockDefinitionResponse struct {
@@ -297,6 +298,7 @@ func (api *AdminAPI) handleBlockUpdate(w http.ResponseWriter, r *http.Request) {
 		Defaults:         payload.Defaults,
 		EditorStyleURL:   payload.EditorStyleURL,
 		FrontendStyleURL: payload.FrontendStyleURL,
+		Migration:        payload.Migration,
 		EnvironmentKey:   envKey,
 	}
 	updated, err := api.blocks.UpdateDefinition(r.Context(), req)
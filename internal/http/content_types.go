@@ -32,19 +32,20 @@ type contentTypeCreatePayload struct {
 }
 
 type contentTypeUpdatePayload struct {
-	Name                 *string        `json:"name,omitempty"`
-	Slug                 *string        `json:"slug,omitempty"`
-	Description          *string        `json:"description,omitempty"`
-	Schema               map[string]any `json:"schema,omitempty"`
-	UISchema             map[string]any `json:"ui_schema,omitempty"`
-	Capabilities         map[string]any `json:"capabilities,omitempty"`
-	Icon                 *string        `json:"icon,omitempty"`
-	Status               *string        `json:"status,omitempty"`
-	AllowBreakingChanges bool           `json:"allow_breaking_changes,omitempty"`
-	Environment          *string        `json:"environment,omitempty"`
-	EnvironmentID        *uuid.UUID     `json:"environment_id,omitempty"`
-	UpdatedBy            *uuid.UUID     `json:"updated_by,omitempty"`
-	ActorID              *uuid.UUID     `json:"actor_id,omitempty"`
+	Name                 *string                     `json:"name,omitempty"`
+	Slug                 *string                     `json:"slug,omitempty"`
+	Description          *string                     `json:"description,omitempty"`
+	Schema               map[string]any              `json:"schema,omitempty"`
+	UISchema             map[string]any              `json:"ui_schema,omitempty"`
+	Capabilities         map[string]any              `json:"capabilities,omitempty"`
+	Icon                 *string                     `json:"icon,omitempty"`
+	Status               *string                     `json:"status,omitempty"`
+	AllowBreakingChanges bool                        `json:"allow_breaking_changes,omitempty"`
+	Migration            []schema.MigrationOperation `json:"migration,omitempty"`
+	Environment          *string                     `json:"environment,omitempty"`
+	EnvironmentID        *uuid.UUID                  `json:"environment_id,omitempty"`
+	UpdatedBy            *uuid.UUID                  `json:"updated_by,omitempty"`
+	ActorID              *uuid.UUID                  `json:"actor_id,omitempty"`
 }
 
 type contentTypePublishPayload struct {
@@ -319,6 +320,7 @@ func (api *AdminAPI) handleContentTypeUpdate(w http.ResponseWriter, r *http.Requ
 		EnvironmentKey:       envKey,
 		UpdatedBy:            actor,
 		AllowBreakingChanges: payload.AllowBreakingChanges,
+		Migration:            payload.Migration,
 	}
 	updated, err := api.contentTypes.Update(r.Context(), req)
 	if err != nil {
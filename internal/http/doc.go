@@ -9,7 +9,10 @@
 //   - Menus: /menus, /menus/{id}
 //   - Block library: /blocks, /blocks/{id}
 //   - Promotions: /environments/{source}/promote/{target},
-//     /content-types/{id}/promote, /content/{id}/promote
+//     /content-types/{id}/promote, /content/{id}/promote, /blocks/{id}/promote,
+//     /pages/{id}/promote, /menus/{id}/promote
+//   - Promotion history: /promotions, /promotions/{id}, /promotions/{id}/rollback
+//   - Environment comparison: /environments/{source}/compare/{target}
 //
 // Host applications can register handlers on their own mux/router as needed.
 package http
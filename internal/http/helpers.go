@@ -15,14 +15,16 @@ import (
 	"github.com/goliatone/go-cms/internal/pages"
 	"github.com/goliatone/go-cms/internal/permissions"
 	"github.com/goliatone/go-cms/internal/promotions"
+	"github.com/goliatone/go-cms/internal/schema"
 	"github.com/goliatone/go-cms/internal/validation"
 	"github.com/google/uuid"
 )
 
 type errorResponse struct {
-	Error   string                       `json:"error"`
-	Message string                       `json:"message,omitempty"`
-	Issues  []validation.ValidationIssue `json:"issues,omitempty"`
+	Error              string                       `json:"error"`
+	Message            string                       `json:"message,omitempty"`
+	Issues             []validation.ValidationIssue `json:"issues,omitempty"`
+	SuggestedMigration []schema.MigrationOperation  `json:"suggested_migration,omitempty"`
 }
 
 var errBadRequest = errors.New("bad_request")
@@ -102,6 +104,13 @@ func mapError(err error) (int, errorResponse) {
 		}
 	}
 
+	if errors.Is(err, promotions.ErrPromotionNotFound) {
+		return http.StatusNotFound, errorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		}
+	}
+
 	var pageNotFound *pages.PageNotFoundError
 	if errors.As(err, &pageNotFound) {
 		return http.StatusNotFound, errorResponse{
@@ -133,7 +142,11 @@ func mapError(err error) (int, errorResponse) {
 		errors.Is(err, pages.ErrParentNotFound) ||
 		errors.Is(err, pages.ErrTemplateUnknown) ||
 		errors.Is(err, pages.ErrPageTranslationNotFound) ||
-		errors.Is(err, pages.ErrSourceNotFound) {
+		errors.Is(err, pages.ErrSourceNotFound) ||
+		errors.Is(err, content.ErrContentNotTrashed) ||
+		errors.Is(err, pages.ErrPageNotTrashed) ||
+		errors.Is(err, blocks.ErrInstanceNotTrashed) ||
+		errors.Is(err, menus.ErrMenuItemNotTrashed) {
 		return http.StatusNotFound, errorResponse{
 			Error:   "not_found",
 			Message: err.Error(),
@@ -162,8 +175,15 @@ func mapError(err error) (int, errorResponse) {
 		}
 	}
 
-	if errors.Is(err, content.ErrContentTypeSchemaBreaking) ||
-		errors.Is(err, content.ErrContentTypeStatusChange) ||
+	if errors.Is(err, content.ErrContentTypeSchemaBreaking) {
+		return http.StatusConflict, errorResponse{
+			Error:              "conflict",
+			Message:            err.Error(),
+			SuggestedMigration: content.SuggestedSchemaMigration(err),
+		}
+	}
+
+	if errors.Is(err, content.ErrContentTypeStatusChange) ||
 		errors.Is(err, blocks.ErrDefinitionInUse) ||
 		errors.Is(err, blocks.ErrDefinitionVersionExists) ||
 		errors.Is(err, pages.ErrSlugExists) ||
@@ -174,7 +194,10 @@ func mapError(err error) (int, errorResponse) {
 		errors.Is(err, pages.ErrPageParentCycle) ||
 		errors.Is(err, pages.ErrPageDuplicateSlug) ||
 		errors.Is(err, menus.ErrMenuInUse) ||
-		errors.Is(err, menus.ErrMenuItemHasChildren) {
+		errors.Is(err, menus.ErrMenuItemHasChildren) ||
+		errors.Is(err, menus.ErrMenuItemRestoreConflict) ||
+		errors.Is(err, promotions.ErrPromotionRolledBack) ||
+		errors.Is(err, promotions.ErrPromotionTargetChanged) {
 		return http.StatusConflict, errorResponse{
 			Error:   "conflict",
 			Message: err.Error(),
@@ -218,6 +241,7 @@ func mapError(err error) (int, errorResponse) {
 		errors.Is(err, pages.ErrInvalidLocale) ||
 		errors.Is(err, pages.ErrPageRequired) ||
 		errors.Is(err, pages.ErrPageSoftDeleteUnsupported) ||
+		errors.Is(err, menus.ErrMenuItemSoftDeleteUnsupported) ||
 		errors.Is(err, menus.ErrMenuCodeRequired) ||
 		errors.Is(err, menus.ErrMenuCodeInvalid) ||
 		errors.Is(err, menus.ErrMenuItemParentInvalid) ||
@@ -242,6 +266,7 @@ func mapError(err error) (int, errorResponse) {
 		errors.Is(err, promotions.ErrContentTypeRequiredForSlugs) ||
 		errors.Is(err, promotions.ErrContentTypeEnvMismatch) ||
 		errors.Is(err, promotions.ErrContentTypeFilterMismatch) ||
+		errors.Is(err, promotions.ErrUnknownComparisonKind) ||
 		errors.Is(err, blocks.ErrDefinitionNameRequired) ||
 		errors.Is(err, blocks.ErrDefinitionSchemaRequired) ||
 		errors.Is(err, blocks.ErrDefinitionSchemaVersionInvalid) ||
@@ -252,7 +277,8 @@ func mapError(err error) (int, errorResponse) {
 		}
 	}
 
-	if errors.Is(err, errEnvironmentServiceUnavailable) {
+	if errors.Is(err, errEnvironmentServiceUnavailable) ||
+		errors.Is(err, promotions.ErrPromotionHistoryUnavailable) {
 		return http.StatusServiceUnavailable, errorResponse{
 			Error:   "service_unavailable",
 			Message: err.Error(),
@@ -296,6 +322,30 @@ func parseBoolQuery(value string, defaultValue bool) bool {
 	return parsed
 }
 
+func parseIntQuery(value string, defaultValue int) int {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(trimmed)
+	if err != nil || parsed < 0 {
+		return defaultValue
+	}
+	return parsed
+}
+
+func splitListQuery(values []string) []string {
+	var out []string
+	for _, value := range values {
+		for part := range strings.SplitSeq(value, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				out = append(out, trimmed)
+			}
+		}
+	}
+	return out
+}
+
 func resolveActorID(primary, secondary *uuid.UUID) uuid.UUID {
 	if primary != nil && *primary != uuid.Nil {
 		return *primary
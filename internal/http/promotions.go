@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -18,6 +19,11 @@ type promoteEnvironmentPayload struct {
 	ContentSlugs         []string                  `json:"content_slugs,omitempty"`
 	ContentEntryTypeID   *uuid.UUID                `json:"content_entry_type_id,omitempty"`
 	ContentEntryTypeSlug string                    `json:"content_entry_type_slug,omitempty"`
+	BlockDefinitionSlugs []string                  `json:"block_definition_slugs,omitempty"`
+	PageIDs              []uuid.UUID               `json:"page_ids,omitempty"`
+	PageSlugs            []string                  `json:"page_slugs,omitempty"`
+	MenuCodes            []string                  `json:"menu_codes,omitempty"`
+	WidgetAreaCodes      []string                  `json:"widget_area_codes,omitempty"`
 	Options              promotions.PromoteOptions `json:"options"`
 }
 
@@ -33,16 +39,43 @@ type promoteContentPayload struct {
 	Options             promotions.PromoteOptions `json:"options"`
 }
 
+type rollbackPromotionPayload struct {
+	Force   bool       `json:"force,omitempty"`
+	ActorID *uuid.UUID `json:"actor_id,omitempty"`
+}
+
+type promoteWidgetAreaPayload struct {
+	SourceEnvironment   string                    `json:"source_environment,omitempty"`
+	TargetEnvironment   string                    `json:"target_environment,omitempty"`
+	TargetEnvironmentID *uuid.UUID                `json:"target_environment_id,omitempty"`
+	Options             promotions.PromoteOptions `json:"options"`
+}
+
+type promoteRecordPayload struct {
+	TargetEnvironment   string                    `json:"target_environment,omitempty"`
+	TargetEnvironmentID *uuid.UUID                `json:"target_environment_id,omitempty"`
+	Options             promotions.PromoteOptions `json:"options"`
+}
+
 func (api *AdminAPI) registerPromotionRoutes(mux *http.ServeMux, base string) {
 	if mux == nil {
 		return
 	}
 	envRoot := joinPath(base, "environments")
 	mux.HandleFunc("POST "+envRoot+"/{source}/promote/{target}", api.handlePromoteEnvironment)
+	mux.HandleFunc("GET "+envRoot+"/{source}/compare/{target}", api.handleCompareEnvironments)
 	contentTypeRoot := joinPath(base, "content-types")
 	mux.HandleFunc("POST "+contentTypeRoot+"/{id}/promote", api.handlePromoteContentType)
 	contentRoot := joinPath(base, "content")
 	mux.HandleFunc("POST "+contentRoot+"/{id}/promote", api.handlePromoteContentEntry)
+	mux.HandleFunc("POST "+joinPath(base, "blocks")+"/{id}/promote", api.handlePromoteBlockDefinition)
+	mux.HandleFunc("POST "+joinPath(base, "pages")+"/{id}/promote", api.handlePromotePage)
+	mux.HandleFunc("POST "+joinPath(base, "menus")+"/{id}/promote", api.handlePromoteMenu)
+	mux.HandleFunc("POST "+joinPath(base, "widget-areas")+"/{code}/promote", api.handlePromoteWidgetArea)
+	historyRoot := joinPath(base, "promotions")
+	mux.HandleFunc("GET "+historyRoot, api.handleListPromotions)
+	mux.HandleFunc("GET "+historyRoot+"/{id}", api.handleGetPromotion)
+	mux.HandleFunc("POST "+historyRoot+"/{id}/rollback", api.handleRollbackPromotion)
 }
 
 func (api *AdminAPI) handlePromoteEnvironment(w http.ResponseWriter, r *http.Request) {
@@ -71,6 +104,11 @@ func (api *AdminAPI) handlePromoteEnvironment(w http.ResponseWriter, r *http.Req
 		ContentSlugs:         payload.ContentSlugs,
 		ContentEntryTypeID:   payload.ContentEntryTypeID,
 		ContentEntryTypeSlug: strings.TrimSpace(payload.ContentEntryTypeSlug),
+		BlockDefinitionSlugs: payload.BlockDefinitionSlugs,
+		PageIDs:              payload.PageIDs,
+		PageSlugs:            payload.PageSlugs,
+		MenuCodes:            payload.MenuCodes,
+		WidgetAreaCodes:      payload.WidgetAreaCodes,
 		Options:              payload.Options,
 	}
 	result, err := api.promotions.PromoteEnvironment(r.Context(), req)
@@ -81,6 +119,97 @@ func (api *AdminAPI) handlePromoteEnvironment(w http.ResponseWriter, r *http.Req
 	writeJSON(w, http.StatusOK, result)
 }
 
+func (api *AdminAPI) handleCompareEnvironments(w http.ResponseWriter, r *http.Request) {
+	if api == nil || api.promotions == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
+		return
+	}
+	source := strings.TrimSpace(r.PathValue("source"))
+	target := strings.TrimSpace(r.PathValue("target"))
+	if source == "" || target == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "source and target required"})
+		return
+	}
+	query := r.URL.Query()
+	req := promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: source,
+		TargetEnvironment: target,
+		Kinds:             splitListQuery(query["kinds"]),
+		IncludeUnchanged:  parseBoolQuery(query.Get("include_unchanged"), false),
+	}
+	result, err := api.promotions.CompareEnvironments(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (api *AdminAPI) handleListPromotions(w http.ResponseWriter, r *http.Request) {
+	if api == nil || api.promotions == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
+		return
+	}
+	query := r.URL.Query()
+	req := promotions.ListPromotionsRequest{
+		SourceEnvironment: strings.TrimSpace(query.Get("source")),
+		TargetEnvironment: strings.TrimSpace(query.Get("target")),
+		Limit:             parseIntQuery(query.Get("limit"), 0),
+		Offset:            parseIntQuery(query.Get("offset"), 0),
+	}
+	result, err := api.promotions.ListPromotions(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (api *AdminAPI) handleGetPromotion(w http.ResponseWriter, r *http.Request) {
+	if api == nil || api.promotions == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
+		return
+	}
+	id, err := parseUUID(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "invalid id"})
+		return
+	}
+	result, err := api.promotions.GetPromotion(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (api *AdminAPI) handleRollbackPromotion(w http.ResponseWriter, r *http.Request) {
+	if api == nil || api.promotions == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
+		return
+	}
+	id, err := parseUUID(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "invalid id"})
+		return
+	}
+	var payload rollbackPromotionPayload
+	if err := decodeJSON(r, &payload); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: err.Error()})
+		return
+	}
+	opts := promotions.RollbackOptions{
+		Force:   parseBoolQuery(r.URL.Query().Get("force"), payload.Force),
+		ActorID: resolveActorID(payload.ActorID, nil),
+	}
+	result, err := api.promotions.RollbackPromotion(r.Context(), id, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (api *AdminAPI) handlePromoteContentType(w http.ResponseWriter, r *http.Request) {
 	if api == nil || api.promotions == nil {
 		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
@@ -162,3 +291,104 @@ func (api *AdminAPI) handlePromoteContentEntry(w http.ResponseWriter, r *http.Re
 	}
 	writeJSON(w, http.StatusOK, result)
 }
+
+func (api *AdminAPI) handlePromoteBlockDefinition(w http.ResponseWriter, r *http.Request) {
+	api.handlePromoteRecord(w, r, func(ctx context.Context, id uuid.UUID, target string, opts promotions.PromoteOptions) (*promotions.PromoteItem, error) {
+		return api.promotions.PromoteBlockDefinition(ctx, promotions.PromoteBlockDefinitionRequest{DefinitionID: id, TargetEnvironment: target, Options: opts})
+	})
+}
+
+func (api *AdminAPI) handlePromotePage(w http.ResponseWriter, r *http.Request) {
+	api.handlePromoteRecord(w, r, func(ctx context.Context, id uuid.UUID, target string, opts promotions.PromoteOptions) (*promotions.PromoteItem, error) {
+		return api.promotions.PromotePage(ctx, promotions.PromotePageRequest{PageID: id, TargetEnvironment: target, Options: opts})
+	})
+}
+
+func (api *AdminAPI) handlePromoteMenu(w http.ResponseWriter, r *http.Request) {
+	api.handlePromoteRecord(w, r, func(ctx context.Context, id uuid.UUID, target string, opts promotions.PromoteOptions) (*promotions.PromoteItem, error) {
+		return api.promotions.PromoteMenu(ctx, promotions.PromoteMenuRequest{MenuID: id, TargetEnvironment: target, Options: opts})
+	})
+}
+
+func (api *AdminAPI) handlePromoteWidgetArea(w http.ResponseWriter, r *http.Request) {
+	if api == nil || api.promotions == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
+		return
+	}
+	code := strings.TrimSpace(r.PathValue("code"))
+	if code == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "area code required"})
+		return
+	}
+	var payload promoteWidgetAreaPayload
+	if err := decodeJSON(r, &payload); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: err.Error()})
+		return
+	}
+	sourceEnv := strings.TrimSpace(r.URL.Query().Get("from"))
+	if sourceEnv == "" {
+		sourceEnv = strings.TrimSpace(payload.SourceEnvironment)
+	}
+	targetEnv := strings.TrimSpace(r.URL.Query().Get("to"))
+	if targetEnv == "" {
+		key, err := api.resolveEnvironmentKey(r, payload.TargetEnvironment, payload.TargetEnvironmentID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		targetEnv = key
+	}
+	if strings.TrimSpace(targetEnv) == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "target_environment required"})
+		return
+	}
+	result, err := api.promotions.PromoteWidgetArea(r.Context(), promotions.PromoteWidgetAreaRequest{
+		AreaCode:          code,
+		SourceEnvironment: sourceEnv,
+		TargetEnvironment: targetEnv,
+		Options:           payload.Options,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handlePromoteRecord resolves the record ID and target environment shared by
+// single-record promotion endpoints before invoking promote.
+func (api *AdminAPI) handlePromoteRecord(w http.ResponseWriter, r *http.Request, promote func(ctx context.Context, id uuid.UUID, target string, opts promotions.PromoteOptions) (*promotions.PromoteItem, error)) {
+	if api == nil || api.promotions == nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "service_unavailable"})
+		return
+	}
+	id, err := parseUUID(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "invalid id"})
+		return
+	}
+	var payload promoteRecordPayload
+	if err := decodeJSON(r, &payload); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: err.Error()})
+		return
+	}
+	targetEnv := strings.TrimSpace(r.URL.Query().Get("to"))
+	if targetEnv == "" {
+		key, err := api.resolveEnvironmentKey(r, payload.TargetEnvironment, payload.TargetEnvironmentID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		targetEnv = key
+	}
+	if strings.TrimSpace(targetEnv) == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: "target_environment required"})
+		return
+	}
+	result, err := promote(r.Context(), id, targetEnv, payload.Options)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
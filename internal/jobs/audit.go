@@ -3,18 +3,24 @@ package jobs
 import (
 	"context"
 	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/goliatone/go-cms/audit"
+	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/google/uuid"
 )
 
 // AuditEvent captures a change applied by the scheduler worker.
-type AuditEvent struct {
-	EntityType string
-	EntityID   string
-	Action     string
-	OccurredAt time.Time
-	Metadata   map[string]any
-}
+type AuditEvent = audit.Event
+
+type (
+	AuditQuery = audit.Query
+	AuditPage  = audit.Page
+)
 
 // AuditRecorder persists audit events.
 type AuditRecorder interface {
@@ -23,6 +29,52 @@ type AuditRecorder interface {
 	Clear(ctx context.Context) error
 }
 
+// AuditQuerier is implemented by recorders that can filter and paginate
+// events themselves. NewAuditLog lists and filters other recorders in memory.
+type AuditQuerier interface {
+	Query(ctx context.Context, query AuditQuery) (AuditPage, error)
+}
+
+// AuditPurger is implemented by recorders that can remove and count events
+// older than a cutoff without loading them. The audit purge job and
+// audit.Log.Purge fail on recorders without it.
+type AuditPurger interface {
+	Purge(ctx context.Context, before time.Time) (int, error)
+	Count(ctx context.Context, before time.Time) (int, error)
+}
+
+// NewAuditLog exposes a recorder through the audit.Log contract. Recorders
+// that already implement it are returned as is.
+func NewAuditLog(recorder AuditRecorder) audit.Log {
+	if log, ok := recorder.(audit.Log); ok {
+		return log
+	}
+	return auditLog{recorder: recorder}
+}
+
+type auditLog struct {
+	recorder AuditRecorder
+}
+
+func (l auditLog) Query(ctx context.Context, query AuditQuery) (AuditPage, error) {
+	if querier, ok := l.recorder.(AuditQuerier); ok {
+		return querier.Query(ctx, query)
+	}
+	events, err := l.recorder.List(ctx)
+	if err != nil {
+		return AuditPage{}, err
+	}
+	return queryAuditEvents(events, query)
+}
+
+func (l auditLog) Purge(ctx context.Context, before time.Time) (int, error) {
+	purger, ok := l.recorder.(AuditPurger)
+	if !ok {
+		return 0, audit.ErrPurgeUnsupported
+	}
+	return purger.Purge(ctx, before)
+}
+
 // InMemoryAuditRecorder accumulates audit events in-memory for tests.
 type InMemoryAuditRecorder struct {
 	mu     sync.Mutex
@@ -42,7 +94,7 @@ func (r *InMemoryAuditRecorder) Record(_ context.Context, event AuditEvent) erro
 	if r.err != nil {
 		return r.err
 	}
-	copied := event
+	copied := normalizeAuditEvent(event)
 	if copied.Metadata != nil {
 		metadata := make(map[string]any, len(copied.Metadata))
 		maps.Copy(metadata, copied.Metadata)
@@ -81,3 +133,120 @@ func (r *InMemoryAuditRecorder) Clear(context.Context) error {
 	r.events = nil
 	return nil
 }
+
+// Query returns the events matching the query, oldest first.
+func (r *InMemoryAuditRecorder) Query(_ context.Context, query AuditQuery) (AuditPage, error) {
+	r.mu.Lock()
+	events := slices.Clone(r.events)
+	r.mu.Unlock()
+	return queryAuditEvents(events, query)
+}
+
+// Purge removes events that occurred before the cutoff and reports how many
+// were removed.
+func (r *InMemoryAuditRecorder) Purge(_ context.Context, before time.Time) (int, error) {
+	if before.IsZero() {
+		return 0, audit.ErrPurgeCutoffRequired
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.events[:0]
+	for _, event := range r.events {
+		if event.OccurredAt.Before(before) {
+			continue
+		}
+		kept = append(kept, event)
+	}
+	removed := len(r.events) - len(kept)
+	clear(r.events[len(kept):])
+	r.events = kept
+	return removed, nil
+}
+
+// Count reports how many events occurred before the cutoff. A zero cutoff
+// counts every event.
+func (r *InMemoryAuditRecorder) Count(_ context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if before.IsZero() {
+		return len(r.events), nil
+	}
+	count := 0
+	for _, event := range r.events {
+		if event.OccurredAt.Before(before) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ScheduleAuditPurge enqueues the recurring audit purge job. The interval is
+// stored in the payload so the worker can enqueue the next run.
+func ScheduleAuditPurge(ctx context.Context, scheduler interfaces.Scheduler, runAt time.Time, interval time.Duration) (*interfaces.Job, error) {
+	payload := map[string]any{}
+	if interval > 0 {
+		payload["interval"] = interval.String()
+	}
+	return scheduler.Enqueue(ctx, interfaces.JobSpec{
+		Key:     cmsscheduler.AuditPurgeJobKey,
+		Type:    cmsscheduler.JobTypeAuditPurge,
+		RunAt:   runAt,
+		Payload: payload,
+	})
+}
+
+// normalizeAuditEvent assigns an ID and stores timestamps in UTC at the
+// microsecond precision kept by SQL databases, so cursors compare equally
+// across stores.
+func normalizeAuditEvent(event AuditEvent) AuditEvent {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	event.OccurredAt = event.OccurredAt.UTC().Truncate(time.Microsecond)
+	event.ActorID = strings.TrimSpace(event.ActorID)
+	event.EnvironmentKey = strings.TrimSpace(event.EnvironmentKey)
+	return event
+}
+
+func compareAuditEvents(a, b AuditEvent) int {
+	if c := a.OccurredAt.Compare(b.OccurredAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// queryAuditEvents filters and pages events in memory, oldest first.
+func queryAuditEvents(events []AuditEvent, query AuditQuery) (AuditPage, error) {
+	var cursor *audit.Cursor
+	if strings.TrimSpace(query.Cursor) != "" {
+		parsed, err := audit.ParseCursor(query.Cursor)
+		if err != nil {
+			return AuditPage{}, err
+		}
+		cursor = &parsed
+	}
+
+	matched := make([]AuditEvent, 0)
+	for _, event := range events {
+		if !query.Matches(event) || (cursor != nil && cursor.Covers(event)) {
+			continue
+		}
+		matched = append(matched, event)
+	}
+	slices.SortStableFunc(matched, compareAuditEvents)
+	return buildAuditPage(matched, query.EffectiveLimit()), nil
+}
+
+// buildAuditPage trims events to the limit. Callers fetch one extra event so
+// the presence of a next page can be detected.
+func buildAuditPage(events []AuditEvent, limit int) AuditPage {
+	page := AuditPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor = audit.CursorAfter(page.Events[limit-1])
+	}
+	return page
+}
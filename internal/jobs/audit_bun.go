@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-cms/audit"
+	"github.com/uptrace/bun"
+)
+
+var errAuditDatabaseRequired = errors.New("jobs: bun audit recorder requires a database")
+
+// BunAuditRecorder persists audit events in the audit_events table.
+type BunAuditRecorder struct {
+	db *bun.DB
+}
+
+// NewBunAuditRecorder constructs a Bun-backed audit recorder.
+func NewBunAuditRecorder(db *bun.DB) *BunAuditRecorder {
+	return &BunAuditRecorder{db: db}
+}
+
+// Record inserts the supplied event.
+func (r *BunAuditRecorder) Record(ctx context.Context, event AuditEvent) error {
+	if r.db == nil {
+		return errAuditDatabaseRequired
+	}
+	record := normalizeAuditEvent(event)
+	_, err := r.db.NewInsert().Model(&record).Exec(ctx)
+	return err
+}
+
+// List returns every recorded event, oldest first.
+func (r *BunAuditRecorder) List(ctx context.Context) ([]AuditEvent, error) {
+	if r.db == nil {
+		return nil, errAuditDatabaseRequired
+	}
+	var records []AuditEvent
+	err := r.db.NewSelect().
+		Model(&records).
+		OrderExpr("?TableAlias.occurred_at ASC").
+		OrderExpr("?TableAlias.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Clear removes every recorded event.
+func (r *BunAuditRecorder) Clear(ctx context.Context) error {
+	if r.db == nil {
+		return errAuditDatabaseRequired
+	}
+	_, err := r.db.NewDelete().Model((*AuditEvent)(nil)).Where("1 = 1").Exec(ctx)
+	return err
+}
+
+// Query returns the events matching the query, oldest first.
+func (r *BunAuditRecorder) Query(ctx context.Context, query AuditQuery) (AuditPage, error) {
+	if r.db == nil {
+		return AuditPage{}, errAuditDatabaseRequired
+	}
+	limit := query.EffectiveLimit()
+	var records []AuditEvent
+	q := r.db.NewSelect().Model(&records)
+	for column, value := range map[string]string{
+		"entity_type":     query.EntityType,
+		"entity_id":       query.EntityID,
+		"actor_id":        query.ActorID,
+		"action":          query.Action,
+		"environment_key": query.EnvironmentKey,
+	} {
+		if value != "" {
+			q = q.Where("?TableAlias.? = ?", bun.Ident(column), value)
+		}
+	}
+	if !query.From.IsZero() {
+		q = q.Where("?TableAlias.occurred_at >= ?", query.From.UTC())
+	}
+	if !query.To.IsZero() {
+		q = q.Where("?TableAlias.occurred_at < ?", query.To.UTC())
+	}
+	if strings.TrimSpace(query.Cursor) != "" {
+		cursor, err := audit.ParseCursor(query.Cursor)
+		if err != nil {
+			return AuditPage{}, err
+		}
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.occurred_at > ?", cursor.OccurredAt).
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("?TableAlias.occurred_at = ?", cursor.OccurredAt).
+						Where("?TableAlias.id > ?", cursor.ID)
+				})
+		})
+	}
+	err := q.OrderExpr("?TableAlias.occurred_at ASC").
+		OrderExpr("?TableAlias.id ASC").
+		Limit(limit + 1).
+		Scan(ctx)
+	if err != nil {
+		return AuditPage{}, err
+	}
+	return buildAuditPage(records, limit), nil
+}
+
+// Purge deletes events that occurred before the cutoff and reports how many
+// were removed.
+func (r *BunAuditRecorder) Purge(ctx context.Context, before time.Time) (int, error) {
+	if r.db == nil {
+		return 0, errAuditDatabaseRequired
+	}
+	if before.IsZero() {
+		return 0, audit.ErrPurgeCutoffRequired
+	}
+	res, err := r.db.NewDelete().
+		Model((*AuditEvent)(nil)).
+		Where("?TableAlias.occurred_at < ?", before.UTC()).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
+
+// Count reports how many events occurred before the cutoff. A zero cutoff
+// counts every event.
+func (r *BunAuditRecorder) Count(ctx context.Context, before time.Time) (int, error) {
+	if r.db == nil {
+		return 0, errAuditDatabaseRequired
+	}
+	q := r.db.NewSelect().Model((*AuditEvent)(nil))
+	if !before.IsZero() {
+		q = q.Where("?TableAlias.occurred_at < ?", before.UTC())
+	}
+	return q.Count(ctx)
+}
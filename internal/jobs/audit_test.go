@@ -0,0 +1,205 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/audit"
+	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestInMemoryAuditRecorderQuery(t *testing.T) {
+	exerciseAuditRecorder(t, jobs.NewInMemoryAuditRecorder())
+}
+
+func TestBunAuditRecorderQuery(t *testing.T) {
+	ctx := context.Background()
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	if _, err := bunDB.NewCreateTable().Model((*jobs.AuditEvent)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	exerciseAuditRecorder(t, jobs.NewBunAuditRecorder(bunDB))
+}
+
+type queryableAuditRecorder interface {
+	jobs.AuditRecorder
+	jobs.AuditQuerier
+	jobs.AuditPurger
+}
+
+func TestAuditLogFallsBackForBasicRecorders(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder := &basicAuditRecorder{}
+	for i, actor := range []string{"u-1", "u-2", "u-1"} {
+		if err := recorder.Record(ctx, jobs.AuditEvent{ID: uuid.New(), EntityType: "content", EntityID: "c-1", Action: "publish", ActorID: actor, OccurredAt: base.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	log := jobs.NewAuditLog(recorder)
+	page, err := log.Query(ctx, jobs.AuditQuery{ActorID: "u-1", Limit: 1})
+	if err != nil || len(page.Events) != 1 || page.NextCursor == "" {
+		t.Fatalf("expected first page of listed events, got %+v %v", page, err)
+	}
+	next, err := log.Query(ctx, jobs.AuditQuery{ActorID: "u-1", Limit: 1, Cursor: page.NextCursor})
+	if err != nil || len(next.Events) != 1 || !next.Events[0].OccurredAt.Equal(base.Add(2*time.Minute)) {
+		t.Fatalf("expected second page of listed events, got %+v %v", next, err)
+	}
+	if _, err := log.Purge(ctx, base.Add(time.Hour)); !errors.Is(err, audit.ErrPurgeUnsupported) {
+		t.Fatalf("expected ErrPurgeUnsupported, got %v", err)
+	}
+
+	memory := jobs.NewInMemoryAuditRecorder()
+	if log, ok := jobs.NewAuditLog(memory).(*jobs.InMemoryAuditRecorder); !ok || log != memory {
+		t.Fatalf("expected recorders implementing audit.Log to be returned as is")
+	}
+}
+
+// basicAuditRecorder implements only the AuditRecorder contract.
+type basicAuditRecorder struct {
+	events []jobs.AuditEvent
+}
+
+func (r *basicAuditRecorder) Record(_ context.Context, event jobs.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *basicAuditRecorder) List(context.Context) ([]jobs.AuditEvent, error) {
+	return append([]jobs.AuditEvent(nil), r.events...), nil
+}
+
+func (r *basicAuditRecorder) Clear(context.Context) error {
+	r.events = nil
+	return nil
+}
+
+func exerciseAuditRecorder(t *testing.T, recorder queryableAuditRecorder) {
+	t.Helper()
+	ctx := context.Background()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []jobs.AuditEvent{
+		{EntityType: "content", EntityID: "c-1", Action: "publish", ActorID: "u-1", EnvironmentKey: "production", OccurredAt: base},
+		{EntityType: "content", EntityID: "c-2", Action: "publish", ActorID: "u-2", OccurredAt: base.Add(time.Minute)},
+		{EntityType: "menu", EntityID: "m-1", Action: "menu_reset", ActorID: "u-1", OccurredAt: base.Add(2 * time.Minute)},
+		{EntityType: "content", EntityID: "c-1", Action: "unpublish", ActorID: "u-1", EnvironmentKey: "production", OccurredAt: base.Add(3 * time.Minute), Metadata: map[string]any{"job_id": "j-1"}},
+		{EntityType: "content", EntityID: "c-3", Action: "publish", OccurredAt: base.Add(3 * time.Minute)},
+	}
+	for _, event := range events {
+		if err := recorder.Record(ctx, event); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	page, err := recorder.Query(ctx, jobs.AuditQuery{ActorID: "u-1", EntityType: "content"})
+	if err != nil {
+		t.Fatalf("query by actor: %v", err)
+	}
+	if len(page.Events) != 2 || page.NextCursor != "" {
+		t.Fatalf("expected two events for actor u-1, got %+v", page)
+	}
+	if page.Events[0].Action != "publish" || page.Events[1].Action != "unpublish" {
+		t.Fatalf("expected events oldest first, got %+v", page.Events)
+	}
+	if page.Events[1].Metadata["job_id"] != "j-1" || page.Events[1].ID.String() == "" {
+		t.Fatalf("expected metadata and id to round-trip, got %+v", page.Events[1])
+	}
+
+	page, err = recorder.Query(ctx, jobs.AuditQuery{EnvironmentKey: "production", Action: "unpublish"})
+	if err != nil {
+		t.Fatalf("query by environment: %v", err)
+	}
+	if len(page.Events) != 1 || page.Events[0].EntityID != "c-1" {
+		t.Fatalf("expected one production unpublish, got %+v", page.Events)
+	}
+
+	page, err = recorder.Query(ctx, jobs.AuditQuery{From: base.Add(time.Minute), To: base.Add(3 * time.Minute)})
+	if err != nil {
+		t.Fatalf("query by range: %v", err)
+	}
+	if len(page.Events) != 2 {
+		t.Fatalf("expected two events in range, got %+v", page.Events)
+	}
+
+	var seen []string
+	query := jobs.AuditQuery{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("pagination did not terminate")
+		}
+		page, err := recorder.Query(ctx, query)
+		if err != nil {
+			t.Fatalf("query page %d: %v", pages, err)
+		}
+		for _, event := range page.Events {
+			seen = append(seen, event.EntityID+":"+event.Action)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if len(seen) != len(events) {
+		t.Fatalf("expected pagination to visit every event once, got %v", seen)
+	}
+	unique := map[string]bool{}
+	for _, key := range seen {
+		unique[key] = true
+	}
+	if len(unique) != len(events) {
+		t.Fatalf("expected pagination without duplicates, got %v", seen)
+	}
+
+	if _, err := recorder.Query(ctx, jobs.AuditQuery{Cursor: "not-a-cursor"}); !errors.Is(err, audit.ErrCursorInvalid) {
+		t.Fatalf("expected ErrCursorInvalid, got %v", err)
+	}
+
+	if count, err := recorder.Count(ctx, base.Add(2*time.Minute)); err != nil || count != 2 {
+		t.Fatalf("expected two events before the cutoff, got %d %v", count, err)
+	}
+	if count, err := recorder.Count(ctx, time.Time{}); err != nil || count != 5 {
+		t.Fatalf("expected five events in total, got %d %v", count, err)
+	}
+
+	removed, err := recorder.Purge(ctx, base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if removed != 2 {
+		t.Fatalf("expected two events purged, got %d", removed)
+	}
+	remaining, err := recorder.List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(remaining) != 3 || remaining[0].EntityID != "m-1" {
+		t.Fatalf("unexpected events after purge: %+v", remaining)
+	}
+	if _, err := recorder.Purge(ctx, time.Time{}); !errors.Is(err, audit.ErrPurgeCutoffRequired) {
+		t.Fatalf("expected ErrPurgeCutoffRequired, got %v", err)
+	}
+
+	if err := recorder.Clear(ctx); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	remaining, err = recorder.List(ctx)
+	if err != nil || len(remaining) != 0 {
+		t.Fatalf("expected empty log after clear, got %+v %v", remaining, err)
+	}
+}
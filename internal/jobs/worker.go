@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/retention"
 	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/internal/webhooks"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
@@ -19,10 +23,29 @@ type ContentRepository interface {
 	Update(ctx context.Context, record *content.Content) (*content.Content, error)
 }
 
+// TrashPurger removes trash entries past their retention window.
+type TrashPurger interface {
+	Purge(ctx context.Context) (*trash.PurgeResult, error)
+}
+
+// VersionPruner removes versions that retention policies no longer keep.
+type VersionPruner interface {
+	Prune(ctx context.Context) (*retention.Report, error)
+}
+
+// WebhookDeliverer performs queued webhook delivery attempts.
+type WebhookDeliverer interface {
+	Deliver(ctx context.Context, id uuid.UUID) (*webhooks.Delivery, error)
+}
+
 type Worker struct {
 	scheduler interfaces.Scheduler
 	contents  ContentRepository
+	purger    TrashPurger
+	pruner    VersionPruner
+	deliverer WebhookDeliverer
 	audit     AuditRecorder
+	retention time.Duration
 	activity  *activity.Emitter
 	now       func() time.Time
 	batchSize int
@@ -36,6 +59,16 @@ func WithAuditRecorder(recorder AuditRecorder) Option {
 	}
 }
 
+// WithAuditRetention enables handling of the audit purge job, which removes
+// audit events older than the retention window.
+func WithAuditRetention(retention time.Duration) Option {
+	return func(w *Worker) {
+		if retention > 0 {
+			w.retention = retention
+		}
+	}
+}
+
 func WithActivityEmitter(emitter *activity.Emitter) Option {
 	return func(w *Worker) {
 		if emitter != nil {
@@ -44,6 +77,33 @@ func WithActivityEmitter(emitter *activity.Emitter) Option {
 	}
 }
 
+// WithTrashPurger enables handling of the trash purge job.
+func WithTrashPurger(purger TrashPurger) Option {
+	return func(w *Worker) {
+		if purger != nil {
+			w.purger = purger
+		}
+	}
+}
+
+// WithVersionPruner enables handling of the version retention job.
+func WithVersionPruner(pruner VersionPruner) Option {
+	return func(w *Worker) {
+		if pruner != nil {
+			w.pruner = pruner
+		}
+	}
+}
+
+// WithWebhookDeliverer enables handling of webhook delivery jobs.
+func WithWebhookDeliverer(deliverer WebhookDeliverer) Option {
+	return func(w *Worker) {
+		if deliverer != nil {
+			w.deliverer = deliverer
+		}
+	}
+}
+
 func WithClock(clock func() time.Time) Option {
 	return func(w *Worker) {
 		if clock != nil {
@@ -119,6 +179,14 @@ func (w *Worker) handleJob(ctx context.Context, job *interfaces.Job, now time.Ti
 		return w.processContentPublish(ctx, job, now)
 	case cmsscheduler.JobTypeContentUnpublish:
 		return w.processContentUnpublish(ctx, job, now)
+	case cmsscheduler.JobTypeTrashPurge:
+		return w.processTrashPurge(ctx, job, now)
+	case cmsscheduler.JobTypeVersionPrune:
+		return w.processVersionPrune(ctx, job, now)
+	case cmsscheduler.JobTypeWebhookDeliver:
+		return w.processWebhookDelivery(ctx, job, now)
+	case cmsscheduler.JobTypeAuditPurge:
+		return w.processAuditPurge(ctx, job, now)
 	default:
 		return nil
 	}
@@ -161,6 +229,7 @@ func (w *Worker) processContentPublish(ctx context.Context, job *interfaces.Job,
 			EntityType: "content",
 			EntityID:   id.String(),
 			Action:     "publish",
+			ActorID:    auditActorID(triggeredBy),
 			OccurredAt: now,
 			Metadata:   buildAuditMetadata(job, triggeredBy),
 		})
@@ -205,6 +274,7 @@ func (w *Worker) processContentUnpublish(ctx context.Context, job *interfaces.Jo
 			EntityType: "content",
 			EntityID:   id.String(),
 			Action:     "unpublish",
+			ActorID:    auditActorID(triggeredBy),
 			OccurredAt: now,
 			Metadata:   buildAuditMetadata(job, triggeredBy),
 		})
@@ -217,6 +287,141 @@ func (w *Worker) processContentUnpublish(ctx context.Context, job *interfaces.Jo
 	return nil
 }
 
+func (w *Worker) processTrashPurge(ctx context.Context, job *interfaces.Job, now time.Time) error {
+	if w.purger == nil {
+		return errors.New("jobs: trash purger is nil")
+	}
+	result, err := w.purger.Purge(ctx)
+	if err != nil {
+		return err
+	}
+	for _, entry := range result.Removed {
+		meta := buildAuditMetadata(job, nil)
+		meta["deleted_at"] = entry.DeletedAt
+		meta["cutoff"] = result.Cutoff
+		w.recordAudit(ctx, AuditEvent{
+			EntityType: entry.ResourceType,
+			EntityID:   entry.ResourceID.String(),
+			Action:     "purge",
+			OccurredAt: now,
+			Metadata:   meta,
+		})
+	}
+
+	raw, _ := job.Payload["interval"].(string)
+	if raw == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("jobs: invalid trash purge interval %q", raw)
+	}
+	_, err = trash.SchedulePurge(ctx, w.scheduler, now.Add(interval), interval)
+	return err
+}
+
+func (w *Worker) processVersionPrune(ctx context.Context, job *interfaces.Job, now time.Time) error {
+	if w.pruner == nil {
+		return errors.New("jobs: version pruner is nil")
+	}
+	report, err := w.pruner.Prune(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pruned := range report.Pruned {
+		meta := buildAuditMetadata(job, nil)
+		meta["versions"] = pruned.Versions
+		w.recordAudit(ctx, AuditEvent{
+			EntityType: pruned.Resource,
+			EntityID:   pruned.EntityID.String(),
+			Action:     "prune_versions",
+			OccurredAt: now,
+			Metadata:   meta,
+		})
+	}
+
+	raw, _ := job.Payload["interval"].(string)
+	if raw == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("jobs: invalid version prune interval %q", raw)
+	}
+	_, err = retention.SchedulePrune(ctx, w.scheduler, now.Add(interval), interval)
+	return err
+}
+
+func (w *Worker) processWebhookDelivery(ctx context.Context, job *interfaces.Job, now time.Time) error {
+	if w.deliverer == nil {
+		return errors.New("jobs: webhook deliverer is nil")
+	}
+	id, _, err := parseJobIdentifiers(job.Payload, "delivery_id")
+	if err != nil {
+		return err
+	}
+	delivery, err := w.deliverer.Deliver(ctx, id)
+	if err != nil {
+		return err
+	}
+	meta := buildAuditMetadata(job, nil)
+	meta["subscription_id"] = delivery.SubscriptionID.String()
+	meta["event"] = delivery.EventName
+	meta["status"] = string(delivery.Status)
+	meta["attempts"] = delivery.Attempts
+	meta["response_code"] = delivery.ResponseCode
+	w.recordAudit(ctx, AuditEvent{
+		EntityType:     "webhook_delivery",
+		EntityID:       delivery.ID.String(),
+		Action:         "deliver",
+		EnvironmentKey: delivery.Event.EnvironmentKey,
+		OccurredAt:     now,
+		Metadata:       meta,
+	})
+	return nil
+}
+
+func (w *Worker) processAuditPurge(ctx context.Context, job *interfaces.Job, now time.Time) error {
+	if w.audit == nil {
+		return errors.New("jobs: audit recorder is nil")
+	}
+	if w.retention <= 0 {
+		return errors.New("jobs: audit retention is not configured")
+	}
+	purger, ok := w.audit.(AuditPurger)
+	if !ok {
+		return audit.ErrPurgeUnsupported
+	}
+	cutoff := now.Add(-w.retention)
+	removed, err := purger.Purge(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		meta := buildAuditMetadata(job, nil)
+		meta["removed"] = removed
+		meta["cutoff"] = cutoff
+		w.recordAudit(ctx, AuditEvent{
+			EntityType: "audit_log",
+			EntityID:   "audit_events",
+			Action:     "purge",
+			OccurredAt: now,
+			Metadata:   meta,
+		})
+	}
+
+	raw, _ := job.Payload["interval"].(string)
+	if raw == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("jobs: invalid audit purge interval %q", raw)
+	}
+	_, err = ScheduleAuditPurge(ctx, w.scheduler, now.Add(interval), interval)
+	return err
+}
+
 func (w *Worker) recordAudit(ctx context.Context, event AuditEvent) {
 	if w.audit == nil {
 		return
@@ -251,6 +456,13 @@ func parseJobIdentifiers(payload map[string]any, key string) (uuid.UUID, *uuid.U
 	return id, triggeredBy, nil
 }
 
+func auditActorID(triggeredBy *uuid.UUID) string {
+	if triggeredBy == nil {
+		return ""
+	}
+	return triggeredBy.String()
+}
+
 func buildAuditMetadata(job *interfaces.Job, triggeredBy *uuid.UUID) map[string]any {
 	meta := map[string]any{
 		"job_id":   job.ID,
@@ -3,13 +3,20 @@ package jobs_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	cmsaudit "github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/domain"
 	"github.com/goliatone/go-cms/internal/jobs"
+	"github.com/goliatone/go-cms/internal/retention"
 	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/internal/webhooks"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
 )
@@ -186,7 +193,260 @@ func TestSchedulingCancellation(t *testing.T) {
 	}
 }
 
+func TestWorkerProcessTrashPurge(t *testing.T) {
+	ctx := context.Background()
+	scheduler := cmsscheduler.NewInMemory()
+	audit := jobs.NewInMemoryAuditRecorder()
+	repo := trash.NewMemoryRepository()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	expiredID := uuid.New()
+	if _, err := trash.Put(ctx, repo, trash.ResourcePage, expiredID, struct{}{}, uuid.Nil, now.Add(-10*24*time.Hour)); err != nil {
+		t.Fatalf("put expired: %v", err)
+	}
+	keptID := uuid.New()
+	if _, err := trash.Put(ctx, repo, trash.ResourceContent, keptID, struct{}{}, uuid.Nil, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("put kept: %v", err)
+	}
+
+	purger, err := trash.NewPurger(repo, 7*24*time.Hour, trash.WithPurgeClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("new purger: %v", err)
+	}
+	worker := jobs.NewWorker(scheduler, nil,
+		jobs.WithAuditRecorder(audit),
+		jobs.WithTrashPurger(purger),
+		jobs.WithClock(func() time.Time { return now }),
+	)
+
+	if _, err := trash.SchedulePurge(ctx, scheduler, now.Add(-time.Minute), 24*time.Hour); err != nil {
+		t.Fatalf("schedule purge: %v", err)
+	}
+	if err := worker.Process(ctx); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+
+	if _, err := repo.GetByResource(ctx, trash.ResourcePage, expiredID); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected expired entry to be purged, got %v", err)
+	}
+	if _, err := repo.GetByResource(ctx, trash.ResourceContent, keptID); err != nil {
+		t.Fatalf("expected recent entry to remain: %v", err)
+	}
+
+	auditEvents := audit.Events()
+	if len(auditEvents) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(auditEvents))
+	}
+	if auditEvents[0].Action != "purge" || auditEvents[0].EntityType != trash.ResourcePage || auditEvents[0].EntityID != expiredID.String() {
+		t.Fatalf("unexpected audit event %+v", auditEvents[0])
+	}
+
+	next, err := scheduler.GetByKey(ctx, cmsscheduler.TrashPurgeJobKey)
+	if err != nil {
+		t.Fatalf("expected next purge to be scheduled: %v", err)
+	}
+	if !next.RunAt.Equal(now.Add(24*time.Hour)) || next.Status != interfaces.JobStatusPending {
+		t.Fatalf("unexpected next purge job %+v", next)
+	}
+}
+
+type stubVersionSource struct {
+	pruned []retention.Pruned
+}
+
+func (s stubVersionSource) PruneAllVersions(context.Context) ([]retention.Pruned, error) {
+	return s.pruned, nil
+}
+
+func TestWorkerProcessVersionPrune(t *testing.T) {
+	ctx := context.Background()
+	scheduler := cmsscheduler.NewInMemory()
+	audit := jobs.NewInMemoryAuditRecorder()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	pageID := uuid.New()
+	pruner := retention.NewPruner(stubVersionSource{pruned: []retention.Pruned{
+		{Resource: retention.ResourcePage, EntityID: pageID, Versions: []int{1, 2}},
+	}})
+	worker := jobs.NewWorker(scheduler, nil,
+		jobs.WithAuditRecorder(audit),
+		jobs.WithVersionPruner(pruner),
+		jobs.WithClock(func() time.Time { return now }),
+	)
+
+	if _, err := retention.SchedulePrune(ctx, scheduler, now.Add(-time.Minute), 12*time.Hour); err != nil {
+		t.Fatalf("schedule prune: %v", err)
+	}
+	if err := worker.Process(ctx); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+
+	auditEvents := audit.Events()
+	if len(auditEvents) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(auditEvents))
+	}
+	event := auditEvents[0]
+	if event.Action != "prune_versions" || event.EntityType != retention.ResourcePage || event.EntityID != pageID.String() {
+		t.Fatalf("unexpected audit event %+v", event)
+	}
+	if versions, ok := event.Metadata["versions"].([]int); !ok || len(versions) != 2 {
+		t.Fatalf("expected pruned versions in metadata, got %+v", event.Metadata)
+	}
+
+	next, err := scheduler.GetByKey(ctx, cmsscheduler.VersionPruneJobKey)
+	if err != nil {
+		t.Fatalf("expected next prune to be scheduled: %v", err)
+	}
+	if !next.RunAt.Equal(now.Add(12*time.Hour)) || next.Status != interfaces.JobStatusPending {
+		t.Fatalf("unexpected next prune job %+v", next)
+	}
+}
+
+func TestWorkerProcessWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	scheduler := cmsscheduler.NewInMemory(cmsscheduler.WithClock(clock))
+	audit := jobs.NewInMemoryAuditRecorder()
+	svc := webhooks.NewService(webhooks.NewMemorySubscriptionRepository(), webhooks.NewMemoryDeliveryRepository(),
+		webhooks.WithScheduler(scheduler),
+		webhooks.WithNow(clock),
+		webhooks.WithBackoff(30*time.Second, time.Hour),
+	)
+	worker := jobs.NewWorker(scheduler, nil,
+		jobs.WithAuditRecorder(audit),
+		jobs.WithWebhookDeliverer(svc),
+		jobs.WithClock(clock),
+	)
+
+	if _, err := svc.CreateSubscription(ctx, webhooks.CreateSubscriptionInput{URL: server.URL}); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	deliveries, err := svc.Dispatch(ctx, webhooks.Event{ResourceType: "page", RecordID: "p-1", Transition: "publish"})
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("dispatch: %v %+v", err, deliveries)
+	}
+	id := deliveries[0].ID
+
+	if err := worker.Process(ctx); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+	delivery, err := svc.GetDelivery(ctx, id)
+	if err != nil {
+		t.Fatalf("get delivery: %v", err)
+	}
+	if delivery.Status != webhooks.DeliveryPending || delivery.ResponseCode != http.StatusInternalServerError {
+		t.Fatalf("expected pending retry after failure, got %+v", delivery)
+	}
+
+	now = now.Add(30 * time.Second)
+	if err := worker.Process(ctx); err != nil {
+		t.Fatalf("process retry: %v", err)
+	}
+	delivery, err = svc.GetDelivery(ctx, id)
+	if err != nil {
+		t.Fatalf("get delivery: %v", err)
+	}
+	if delivery.Status != webhooks.DeliverySucceeded || delivery.Attempts != 2 {
+		t.Fatalf("expected delivery to succeed on retry, got %+v", delivery)
+	}
+
+	auditEvents := audit.Events()
+	if len(auditEvents) != 2 {
+		t.Fatalf("expected 2 audit events, got %d", len(auditEvents))
+	}
+	last := auditEvents[1]
+	if last.EntityType != "webhook_delivery" || last.EntityID != id.String() || last.Metadata["status"] != string(webhooks.DeliverySucceeded) {
+		t.Fatalf("unexpected audit event %+v", last)
+	}
+}
+
 //go:fix inline
 func ptrTime(value time.Time) *time.Time {
 	return new(value)
 }
+
+func TestWorkerProcessAuditPurge(t *testing.T) {
+	ctx := context.Background()
+	scheduler := cmsscheduler.NewInMemory()
+	audit := jobs.NewInMemoryAuditRecorder()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, occurredAt := range []time.Time{now.Add(-48 * time.Hour), now.Add(-36 * time.Hour), now.Add(-time.Hour)} {
+		if err := audit.Record(ctx, jobs.AuditEvent{EntityType: "content", EntityID: "c-1", Action: "publish", OccurredAt: occurredAt}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	worker := jobs.NewWorker(scheduler, nil,
+		jobs.WithAuditRecorder(audit),
+		jobs.WithAuditRetention(24*time.Hour),
+		jobs.WithClock(func() time.Time { return now }),
+	)
+
+	if _, err := jobs.ScheduleAuditPurge(ctx, scheduler, now.Add(-time.Minute), 6*time.Hour); err != nil {
+		t.Fatalf("schedule purge: %v", err)
+	}
+	if err := worker.Process(ctx); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+
+	auditEvents := audit.Events()
+	if len(auditEvents) != 2 {
+		t.Fatalf("expected the recent event and the purge record, got %+v", auditEvents)
+	}
+	event := auditEvents[1]
+	if event.EntityType != "audit_log" || event.Action != "purge" || event.Metadata["removed"] != 2 {
+		t.Fatalf("unexpected purge audit event %+v", event)
+	}
+
+	next, err := scheduler.GetByKey(ctx, cmsscheduler.AuditPurgeJobKey)
+	if err != nil {
+		t.Fatalf("expected next purge to be scheduled: %v", err)
+	}
+	if !next.RunAt.Equal(now.Add(6*time.Hour)) || next.Status != interfaces.JobStatusPending {
+		t.Fatalf("unexpected next purge job %+v", next)
+	}
+}
+
+func TestWorkerAuditPurgeFailsForRecordersThatCannotPurge(t *testing.T) {
+	ctx := context.Background()
+	scheduler := cmsscheduler.NewInMemory()
+	recorder := &basicAuditRecorder{}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if err := recorder.Record(ctx, jobs.AuditEvent{EntityType: "content", EntityID: "c-1", Action: "publish", OccurredAt: now.Add(-48 * time.Hour)}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	worker := jobs.NewWorker(scheduler, nil,
+		jobs.WithAuditRecorder(recorder),
+		jobs.WithAuditRetention(24*time.Hour),
+		jobs.WithClock(func() time.Time { return now }),
+	)
+
+	if _, err := jobs.ScheduleAuditPurge(ctx, scheduler, now.Add(-time.Minute), 6*time.Hour); err != nil {
+		t.Fatalf("schedule purge: %v", err)
+	}
+	if err := worker.Process(ctx); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+
+	job, err := scheduler.GetByKey(ctx, cmsscheduler.AuditPurgeJobKey)
+	if err != nil {
+		t.Fatalf("get purge job: %v", err)
+	}
+	if job.Status == interfaces.JobStatusCompleted || job.LastError != cmsaudit.ErrPurgeUnsupported.Error() {
+		t.Fatalf("expected purge job to fail with ErrPurgeUnsupported, got %+v", job)
+	}
+	if len(recorder.events) != 1 {
+		t.Fatalf("expected the log to be left untouched, got %d events", len(recorder.events))
+	}
+}
@@ -0,0 +1,232 @@
+package markdown
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+const (
+	// AssetLinkURL rewrites local image links to the resolved media URL.
+	AssetLinkURL = "url"
+	// AssetLinkReference rewrites local image links to stable "media:<id>" references.
+	AssetLinkReference = "reference"
+
+	defaultAssetBindingKey = "body_images"
+	mediaReferenceScheme   = "media:"
+)
+
+// ErrAssetMissing reports a local image reference whose file does not exist.
+var ErrAssetMissing = errors.New("local asset not found")
+
+// MediaUpload describes a local file the importer stores in the media library.
+// Path is relative to the content root and Checksum is the SHA-256 of Data.
+type MediaUpload struct {
+	Path     string
+	Name     string
+	MimeType string
+	Checksum string
+	Data     []byte
+}
+
+// MediaUploader stores local assets through the configured media provider.
+// FindByChecksum returns nil without error when no asset matches, letting the
+// importer reuse assets uploaded by earlier runs.
+type MediaUploader interface {
+	FindByChecksum(ctx context.Context, checksum string) (*interfaces.MediaAsset, error)
+	Upload(ctx context.Context, upload MediaUpload) (*interfaces.MediaAsset, error)
+}
+
+var imageLink = regexp.MustCompile(`!\[([^\]]*)\]\(\s*(<[^>]*>|[^)\s]+)((?:\s+"[^"]*")?\s*)\)`)
+
+// assetResolver uploads local images referenced by documents and rewrites
+// their links. Uploads are cached by checksum for the lifetime of the importer.
+type assetResolver struct {
+	uploader MediaUploader
+	files    fs.FS
+	renderer BodyRenderer
+	linkMode string
+	key      string
+
+	mu     sync.Mutex
+	cached map[string]*interfaces.MediaAsset
+}
+
+func newAssetResolver(uploader MediaUploader, files fs.FS, renderer BodyRenderer, linkMode, key string) *assetResolver {
+	if uploader == nil || files == nil {
+		return nil
+	}
+	if strings.TrimSpace(key) == "" {
+		key = defaultAssetBindingKey
+	}
+	if renderer == nil {
+		renderer = parserRenderer{parser: NewGoldmarkParser(interfaces.ParseOptions{})}
+	}
+	return &assetResolver{
+		uploader: uploader,
+		files:    files,
+		renderer: renderer,
+		linkMode: linkMode,
+		key:      key,
+		cached:   map[string]*interfaces.MediaAsset{},
+	}
+}
+
+// resolve returns a copy of doc whose local image links point at the media
+// library and whose HTML is re-rendered, along with one binding per referenced
+// asset. Missing files are reported as DocumentErrors; dry runs check files but
+// never upload.
+func (r *assetResolver) resolve(ctx context.Context, doc *interfaces.Document, parse interfaces.ParseOptions, dryRun bool) (*interfaces.Document, media.BindingSet, error) {
+	var (
+		errs     []error
+		bindings []media.Binding
+		seen     = map[string]struct{}{}
+		rewrote  bool
+	)
+	lines := strings.Split(string(doc.Body), "\n")
+	fenced := false
+	for idx, line := range lines {
+		if isFence(line) {
+			fenced = !fenced
+		}
+		if fenced || !strings.Contains(line, "![") {
+			continue
+		}
+		lineNo := max(doc.BodyLine, 1) + idx
+		lines[idx] = imageLink.ReplaceAllStringFunc(line, func(match string) string {
+			parts := imageLink.FindStringSubmatch(match)
+			target := strings.Trim(parts[2], "<>")
+			assetPath, ok := localAssetPath(doc.FilePath, target)
+			if !ok {
+				return match
+			}
+			data, err := fs.ReadFile(r.files, assetPath)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					err = fmt.Errorf("%w: %s", ErrAssetMissing, target)
+				}
+				errs = append(errs, &DocumentError{Path: doc.FilePath, Line: lineNo, Field: "image", Err: err})
+				return match
+			}
+			if dryRun {
+				return match
+			}
+			asset, err := r.upload(ctx, assetPath, data)
+			if err != nil {
+				errs = append(errs, &DocumentError{Path: doc.FilePath, Line: lineNo, Field: "image", Err: err})
+				return match
+			}
+			link := r.link(asset)
+			if link == "" {
+				return match
+			}
+			if _, ok := seen[assetPath]; !ok {
+				seen[assetPath] = struct{}{}
+				bindings = append(bindings, media.Binding{
+					Slot:      r.key,
+					Reference: asset.Reference,
+					Locale:    doc.Locale,
+					Position:  len(bindings),
+					Metadata: map[string]any{
+						"source": assetPath,
+						"alt":    parts[1],
+					},
+				})
+			}
+			rewrote = true
+			return "![" + parts[1] + "](" + link + parts[3] + ")"
+		})
+	}
+	if !rewrote {
+		return doc, nil, errors.Join(errs...)
+	}
+	resolved := *doc
+	resolved.Body = []byte(strings.Join(lines, "\n"))
+	parse.ShortcodeOptions.Locale = doc.Locale
+	html, err := r.renderer.Render(ctx, resolved.Body, parse)
+	if err != nil {
+		errs = append(errs, &DocumentError{Path: doc.FilePath, Line: doc.BodyLine, Err: err})
+		return doc, nil, errors.Join(errs...)
+	}
+	resolved.BodyHTML = html
+	return &resolved, media.BindingSet{r.key: bindings}, errors.Join(errs...)
+}
+
+// upload returns the asset stored for the file checksum, uploading it when
+// neither this importer nor the provider has seen it before.
+func (r *assetResolver) upload(ctx context.Context, assetPath string, data []byte) (*interfaces.MediaAsset, error) {
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if asset, ok := r.cached[checksum]; ok {
+		return asset, nil
+	}
+	asset, err := r.uploader.FindByChecksum(ctx, checksum)
+	if err != nil {
+		return nil, fmt.Errorf("lookup asset %s: %w", assetPath, err)
+	}
+	if asset == nil {
+		asset, err = r.uploader.Upload(ctx, MediaUpload{
+			Path:     assetPath,
+			Name:     path.Base(assetPath),
+			MimeType: mime.TypeByExtension(path.Ext(assetPath)),
+			Checksum: checksum,
+			Data:     data,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("upload asset %s: %w", assetPath, err)
+		}
+	}
+	if asset == nil {
+		return nil, fmt.Errorf("upload asset %s: provider returned no asset", assetPath)
+	}
+	r.cached[checksum] = asset
+	return asset, nil
+}
+
+func (r *assetResolver) link(asset *interfaces.MediaAsset) string {
+	reference := asset.Reference.ID
+	if reference == "" {
+		reference = asset.Reference.Path
+	}
+	if r.linkMode != AssetLinkReference && asset.Source != nil && asset.Source.URL != "" {
+		return asset.Source.URL
+	}
+	if reference == "" {
+		return ""
+	}
+	return mediaReferenceScheme + reference
+}
+
+// localAssetPath resolves a relative image target against the document
+// directory. URLs, site-absolute paths, anchors and paths escaping the content
+// root are not local assets.
+func localAssetPath(docPath, target string) (string, bool) {
+	if target == "" || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") || strings.Contains(target, ":") {
+		return "", false
+	}
+	if cut, _, ok := strings.Cut(target, "?"); ok {
+		target = cut
+	}
+	if cut, _, ok := strings.Cut(target, "#"); ok {
+		target = cut
+	}
+	resolved := path.Join(path.Dir(docPath), target)
+	if !fs.ValidPath(resolved) || resolved == "." {
+		return "", false
+	}
+	return resolved, true
+}
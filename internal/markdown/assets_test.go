@@ -0,0 +1,153 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+func TestImportUploadsLocalImagesOnce(t *testing.T) {
+	base := t.TempDir()
+	writeAssetFile(t, base, "en/img/diagram.png", "png-bytes")
+	writeMappedFile(t, base, "en/post.md", "", "![Flow](./img/diagram.png \"Flow chart\")\n\n![Logo](https://example.com/logo.png)\n")
+	writeMappedFile(t, base, "es/post.md", "", "![Flujo](../en/img/diagram.png)\n")
+
+	uploader := &stubMediaUploader{}
+	contentStub := &recordingContentService{stubContentService: newStubContentService()}
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en", "es"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil, WithContentService(contentStub), WithMediaUploader(uploader))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	if _, err := svc.ImportDirectory(context.Background(), ".", interfaces.ImportOptions{ContentTypeID: uuid.New()}); err != nil {
+		t.Fatalf("ImportDirectory: %v", err)
+	}
+	if len(uploader.uploads) != 1 || uploader.uploads[0].Path != "en/img/diagram.png" || uploader.uploads[0].MimeType != "image/png" {
+		t.Fatalf("expected diagram uploaded once, got %#v", uploader.uploads)
+	}
+
+	req := contentStub.creates[0]
+	for _, tr := range req.Translations {
+		envelope := tr.Fields["markdown"].(map[string]any)
+		body, html := envelope["body"].(string), envelope["body_html"].(string)
+		if !strings.Contains(body, "(https://cdn.test/en/img/diagram.png") || !strings.Contains(html, `src="https://cdn.test/en/img/diagram.png"`) {
+			t.Fatalf("expected %s links rewritten, got %q / %q", tr.Locale, body, html)
+		}
+		bindings, _ := tr.Fields["media_bindings"].(media.BindingSet)
+		if len(bindings["body_images"]) != 1 || bindings["body_images"][0].Locale != tr.Locale || bindings["body_images"][0].Reference.ID != "asset-1" {
+			t.Fatalf("expected %s media binding, got %#v", tr.Locale, tr.Fields["media_bindings"])
+		}
+	}
+	if !strings.Contains(req.Translations[0].Fields["markdown"].(map[string]any)["body"].(string), "https://example.com/logo.png") {
+		t.Fatalf("expected remote image left untouched")
+	}
+	if byLocale, _ := req.Metadata["media_bindings"].(map[string]media.BindingSet); len(byLocale) != 2 {
+		t.Fatalf("expected bindings recorded per locale in metadata, got %#v", req.Metadata["media_bindings"])
+	}
+
+	refSvc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en", "es"},
+		Pattern:       "*.md",
+		Recursive:     true,
+		AssetLinkMode: AssetLinkReference,
+	}, nil, WithContentService(newStubContentService()), WithMediaUploader(uploader))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	doc, err := refSvc.Load(context.Background(), "es/post.md", interfaces.LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	resolved, _, err := refSvc.importer.assets.resolve(context.Background(), doc, interfaces.ParseOptions{}, false)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !strings.Contains(string(resolved.Body), "(media:asset-1)") || len(uploader.uploads) != 1 {
+		t.Fatalf("expected stable reference reusing the uploaded asset, got %q", resolved.Body)
+	}
+}
+
+func TestImportReportsMissingLocalImages(t *testing.T) {
+	base := t.TempDir()
+	writeMappedFile(t, base, "en/broken.md", "", "Intro.\n\n![Missing](img/nope.png)\n")
+
+	uploader := &stubMediaUploader{}
+	contentStub := &recordingContentService{stubContentService: newStubContentService()}
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil, WithContentService(contentStub), WithMediaUploader(uploader))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	opts := interfaces.ImportOptions{ContentTypeID: uuid.New(), DryRun: true}
+	result, err := svc.ImportDirectory(context.Background(), ".", opts)
+	if !errors.Is(err, ErrAssetMissing) {
+		t.Fatalf("expected dry run to report ErrAssetMissing, got %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Error() != "markdown importer: en/broken.md:8: image: local asset not found: img/nope.png" {
+		t.Fatalf("expected missing asset reported with its line, got %v", result.Errors)
+	}
+
+	opts.DryRun = false
+	if _, err := svc.ImportDirectory(context.Background(), ".", opts); !errors.Is(err, ErrAssetMissing) {
+		t.Fatalf("expected ErrAssetMissing, got %v", err)
+	}
+	if len(contentStub.creates) != 0 || len(uploader.uploads) != 0 {
+		t.Fatalf("expected nothing persisted, got %d creates and %d uploads", len(contentStub.creates), len(uploader.uploads))
+	}
+}
+
+func writeAssetFile(tb testing.TB, base, rel, data string) {
+	tb.Helper()
+	target := filepath.Join(base, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		tb.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(target, []byte(data), 0o644); err != nil {
+		tb.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+type stubMediaUploader struct {
+	uploads []MediaUpload
+	stored  map[string]*interfaces.MediaAsset
+}
+
+func (u *stubMediaUploader) FindByChecksum(_ context.Context, checksum string) (*interfaces.MediaAsset, error) {
+	return u.stored[checksum], nil
+}
+
+func (u *stubMediaUploader) Upload(_ context.Context, upload MediaUpload) (*interfaces.MediaAsset, error) {
+	u.uploads = append(u.uploads, upload)
+	asset := &interfaces.MediaAsset{
+		Reference: interfaces.MediaReference{ID: "asset-" + strconv.Itoa(len(u.uploads)), Path: upload.Path},
+		Source:    &interfaces.MediaResource{URL: "https://cdn.test/" + upload.Path},
+	}
+	if u.stored == nil {
+		u.stored = map[string]*interfaces.MediaAsset{}
+	}
+	u.stored[upload.Checksum] = asset
+	return asset, nil
+}
@@ -0,0 +1,253 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+const (
+	// BlockSplitHeadings starts a new block at every heading of the configured level.
+	BlockSplitHeadings = "headings"
+	// BlockSplitDirectives turns fenced ":::type key=value" directives into blocks.
+	BlockSplitDirectives = "directives"
+)
+
+// BlockSplit configures how a document body is split into embedded blocks.
+// Type names the block type used for heading sections and, in directive mode,
+// for Markdown outside directives; directive mode rejects such content when
+// Type is empty. TitleField and BodyField default to "title" and "body".
+type BlockSplit struct {
+	Mode       string
+	Level      int
+	Type       string
+	TitleField string
+	BodyField  string
+}
+
+var (
+	headingLine   = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	directiveOpen = regexp.MustCompile(`^:::\s*([A-Za-z][\w-]*)\s*(.*)$`)
+	directiveAttr = regexp.MustCompile(`^([A-Za-z_][\w-]*)=("(?:[^"\\]|\\.)*"|\S+)\s*`)
+)
+
+// bodySection is a slice of the document body destined for one block.
+type bodySection struct {
+	line   int
+	typ    string
+	values map[string]any
+	body   []string
+}
+
+// buildBlocks splits the document body and renders each section into an
+// embedded block, validating blocks one at a time so errors keep their line.
+func (m *fieldMapper) buildBlocks(ctx context.Context, doc *interfaces.Document) ([]map[string]any, []int, error) {
+	split := m.mapping.Blocks
+	titleField := firstNonEmpty(split.TitleField, "title")
+	bodyField := firstNonEmpty(split.BodyField, "body")
+	bodyLine := max(doc.BodyLine, 1)
+
+	var sections []bodySection
+	var errs []error
+	switch split.Mode {
+	case BlockSplitHeadings:
+		if strings.TrimSpace(split.Type) == "" {
+			return nil, nil, &DocumentError{Path: doc.FilePath, Line: bodyLine, Err: errors.New("heading blocks require a block type")}
+		}
+		sections = splitHeadings(string(doc.Body), bodyLine, split, titleField)
+	case BlockSplitDirectives:
+		var splitErrs []sectionError
+		sections, splitErrs = splitDirectives(string(doc.Body), bodyLine, split.Type)
+		for _, err := range splitErrs {
+			errs = append(errs, &DocumentError{Path: doc.FilePath, Line: err.line, Err: err.err})
+		}
+	default:
+		return nil, nil, &DocumentError{Path: doc.FilePath, Line: bodyLine, Err: fmt.Errorf("unknown block split mode %q", split.Mode)}
+	}
+
+	blocks := make([]map[string]any, 0, len(sections))
+	lines := make([]int, 0, len(sections))
+	parse := m.parse
+	parse.ShortcodeOptions.Locale = doc.Locale
+	for _, section := range sections {
+		block := map[string]any{"_type": section.typ}
+		for key, value := range section.values {
+			block[key] = value
+		}
+		if markdown := strings.TrimSpace(strings.Join(section.body, "\n")); markdown != "" {
+			html, err := m.renderer.Render(ctx, []byte(markdown+"\n"), parse)
+			if err != nil {
+				errs = append(errs, &DocumentError{Path: doc.FilePath, Line: section.line, Err: err})
+				continue
+			}
+			block[bodyField] = string(html)
+		}
+		if m.blocks != nil {
+			if err := m.blocks.ValidateBlocks(ctx, doc.Locale, []map[string]any{block}); err != nil {
+				errs = append(errs, &DocumentError{Path: doc.FilePath, Line: section.line, Field: section.typ, Err: err})
+				continue
+			}
+		}
+		blocks = append(blocks, block)
+		lines = append(lines, section.line)
+	}
+	return blocks, lines, errors.Join(errs...)
+}
+
+// splitHeadings starts a section at each heading of the configured level,
+// ignoring headings inside fenced code. Content before the first heading
+// becomes an untitled section.
+func splitHeadings(body string, bodyLine int, split BlockSplit, titleField string) []bodySection {
+	level := split.Level
+	if level <= 0 {
+		level = 2
+	}
+	var sections []bodySection
+	current := bodySection{line: bodyLine, typ: split.Type}
+	fenced := false
+	for idx, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if isFence(line) {
+			fenced = !fenced
+		}
+		if match := headingLine.FindStringSubmatch(line); !fenced && match != nil && len(match[1]) == level {
+			if current.values != nil || hasContent(current.body) {
+				sections = append(sections, current)
+			}
+			current = bodySection{
+				line:   bodyLine + idx,
+				typ:    split.Type,
+				values: map[string]any{titleField: match[2]},
+			}
+			continue
+		}
+		current.body = append(current.body, line)
+	}
+	if current.values != nil || hasContent(current.body) {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+type sectionError struct {
+	line int
+	err  error
+}
+
+// splitDirectives extracts ":::type" ... ":::" fences into sections. Markdown
+// between directives becomes a section of defaultType when one is configured.
+func splitDirectives(body string, bodyLine int, defaultType string) ([]bodySection, []sectionError) {
+	var (
+		sections []bodySection
+		errs     []sectionError
+		open     *bodySection
+		fenced   bool
+	)
+	loose := bodySection{line: bodyLine, typ: defaultType}
+	flushLoose := func(next int) {
+		if hasContent(loose.body) {
+			if defaultType == "" {
+				errs = append(errs, sectionError{line: loose.line + leadingBlank(loose.body), err: errors.New("content outside a block directive")})
+			} else {
+				sections = append(sections, loose)
+			}
+		}
+		loose = bodySection{line: next, typ: defaultType}
+	}
+
+	for idx, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		lineNo := bodyLine + idx
+		if isFence(line) {
+			fenced = !fenced
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fenced || !strings.HasPrefix(trimmed, ":::"):
+		case open == nil:
+			match := directiveOpen.FindStringSubmatch(trimmed)
+			if match == nil {
+				errs = append(errs, sectionError{line: lineNo, err: fmt.Errorf("invalid block directive %q", trimmed)})
+				continue
+			}
+			values, err := parseDirectiveAttributes(match[2])
+			if err != nil {
+				errs = append(errs, sectionError{line: lineNo, err: err})
+			}
+			flushLoose(lineNo + 1)
+			open = &bodySection{line: lineNo, typ: match[1], values: values}
+			continue
+		case trimmed == ":::":
+			sections = append(sections, *open)
+			open = nil
+			loose.line = lineNo + 1
+			continue
+		default:
+			errs = append(errs, sectionError{line: lineNo, err: fmt.Errorf("nested block directive %q inside %q", trimmed, open.typ)})
+			continue
+		}
+		if open != nil {
+			open.body = append(open.body, line)
+		} else {
+			loose.body = append(loose.body, line)
+		}
+	}
+	if open != nil {
+		errs = append(errs, sectionError{line: open.line, err: fmt.Errorf("block directive %q is not closed", open.typ)})
+	}
+	flushLoose(0)
+	return sections, errs
+}
+
+// parseDirectiveAttributes reads key=value pairs, decoding values as YAML
+// scalars so numbers and booleans keep their type.
+func parseDirectiveAttributes(input string) (map[string]any, error) {
+	values := map[string]any{}
+	rest := strings.TrimSpace(input)
+	for rest != "" {
+		match := directiveAttr.FindStringSubmatch(rest)
+		if match == nil {
+			return values, fmt.Errorf("invalid directive attribute %q", rest)
+		}
+		var value any
+		if err := yaml.Unmarshal([]byte(match[2]), &value); err != nil {
+			value = match[2]
+		}
+		values[match[1]] = value
+		rest = rest[len(match[0]):]
+	}
+	return values, nil
+}
+
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+func hasContent(lines []string) bool {
+	return leadingBlank(lines) < len(lines)
+}
+
+func leadingBlank(lines []string) int {
+	for idx, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return idx
+		}
+	}
+	return len(lines)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,432 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+// ErrContentNotFound is returned when an export targets a slug without content.
+var ErrContentNotFound = errors.New("markdown exporter: content not found")
+
+// reservedFieldKeys are translation fields that never become front matter.
+var reservedFieldKeys = map[string]struct{}{"markdown": {}, "locale": {}, "body": {}, "content": {}}
+
+// ExporterConfig encapsulates dependencies required to write content as markdown.
+type ExporterConfig struct {
+	Content        interfaces.ContentService
+	Logger         interfaces.Logger
+	BasePath       string
+	DefaultLocale  string
+	LocalePatterns map[string]string
+}
+
+// Exporter writes content entries back to markdown files with YAML front matter.
+type Exporter struct {
+	content        interfaces.ContentService
+	logger         interfaces.Logger
+	basePath       string
+	defaultLocale  string
+	localePatterns map[string]string
+}
+
+// NewExporter builds an Exporter from the supplied configuration.
+func NewExporter(cfg ExporterConfig) *Exporter {
+	basePath := cfg.BasePath
+	if strings.TrimSpace(basePath) == "" {
+		basePath = "."
+	}
+	return &Exporter{
+		content:        cfg.Content,
+		logger:         cfg.Logger,
+		basePath:       basePath,
+		defaultLocale:  cfg.DefaultLocale,
+		localePatterns: cfg.LocalePatterns,
+	}
+}
+
+// ExportSlug writes every translation of the content identified by slug.
+func (e *Exporter) ExportSlug(ctx context.Context, slug string, dir string, opts interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	if e.content == nil {
+		return nil, ErrContentServiceRequired
+	}
+	record, err := e.content.GetBySlug(ctx, slug, interfaces.ContentReadOptions{
+		AllowMissingTranslations: true,
+		EnvironmentKey:           opts.EnvironmentKey,
+	})
+	if err != nil || record == nil {
+		return nil, fmt.Errorf("%w: %s", ErrContentNotFound, slug)
+	}
+	result := newExportResult()
+	if err := e.exportRecord(ctx, record, dir, opts, result); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+	return result, firstError(result.Errors)
+}
+
+// ExportAll writes every content entry matching opts into dir.
+func (e *Exporter) ExportAll(ctx context.Context, dir string, opts interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	if e.content == nil {
+		return nil, ErrContentServiceRequired
+	}
+	records, err := e.content.List(ctx, interfaces.ContentReadOptions{
+		EnvironmentKey: opts.EnvironmentKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("markdown exporter: list content: %w", err)
+	}
+	slices.SortFunc(records, func(a, b *interfaces.ContentRecord) int {
+		return strings.Compare(a.Slug, b.Slug)
+	})
+
+	result := newExportResult()
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		if opts.ContentTypeID != uuid.Nil && record.ContentType != opts.ContentTypeID {
+			continue
+		}
+		if err := e.exportRecord(ctx, record, dir, opts, result); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	return result, firstError(result.Errors)
+}
+
+func (e *Exporter) exportRecord(ctx context.Context, record *interfaces.ContentRecord, dir string, opts interfaces.ExportOptions, result *interfaces.ExportResult) error {
+	locales, err := e.content.AvailableLocales(ctx, record.ID, interfaces.TranslationCheckOptions{
+		Environment: opts.EnvironmentKey,
+	})
+	if err != nil {
+		return fmt.Errorf("markdown exporter: locales for %s: %w", record.Slug, err)
+	}
+	sourcePaths := documentPaths(record.Metadata)
+
+	for _, locale := range locales {
+		if len(opts.Locales) > 0 && !containsFold(opts.Locales, locale) {
+			continue
+		}
+		localized, err := e.content.GetBySlug(ctx, record.Slug, interfaces.ContentReadOptions{
+			Locale:                   locale,
+			AllowMissingTranslations: true,
+			EnvironmentKey:           opts.EnvironmentKey,
+		})
+		if err != nil {
+			return fmt.Errorf("markdown exporter: load %s (%s): %w", record.Slug, locale, err)
+		}
+		if localized == nil || localized.Translation.Requested == nil {
+			continue
+		}
+		translation := localized.Translation.Requested
+
+		source, err := renderExport(record, translation)
+		if err != nil {
+			return fmt.Errorf("markdown exporter: render %s (%s): %w", record.Slug, locale, err)
+		}
+		sourcePath := sourcePaths[strings.ToLower(locale)]
+		if markdownFields, ok := translation.Fields["markdown"].(map[string]any); ok && sourcePath == "" {
+			sourcePath, _ = markdownFields["path"].(string)
+		}
+		path := e.exportPath(sourcePath, record.Slug, locale, dir)
+		sum := sha256.Sum256(source)
+		checksum := hex.EncodeToString(sum[:])
+
+		existing, readErr := os.ReadFile(filepath.Join(e.basePath, filepath.FromSlash(path)))
+		fileCurrent := readErr == nil && bytes.Equal(existing, source)
+		checksumCurrent := checksumFromFields(translation.Fields) == checksum
+
+		if fileCurrent && checksumCurrent {
+			result.Unchanged = append(result.Unchanged, path)
+			continue
+		}
+		if opts.DryRun {
+			result.Written = append(result.Written, path)
+			continue
+		}
+		if !fileCurrent {
+			if err := e.writeFile(path, source); err != nil {
+				return err
+			}
+		}
+		if !checksumCurrent {
+			if err := e.recordChecksum(ctx, record.ID, translation, path, checksum, opts.AuthorID); err != nil {
+				return err
+			}
+		}
+		result.Written = append(result.Written, path)
+	}
+	return nil
+}
+
+// recordChecksum stores the exported file checksum and path on the
+// translation so the next Sync treats the file as unchanged. The rest of the
+// markdown envelope is left as imported; exports always render from the
+// current translation fields. Content services implementing
+// ContentTranslationFieldsUpdater write the fields in place, without a new
+// version or lifecycle events; others fall back to UpdateTranslation.
+func (e *Exporter) recordChecksum(ctx context.Context, contentID uuid.UUID, translation *interfaces.ContentTranslation, path, checksum string, author uuid.UUID) error {
+	fields := cloneMap(translation.Fields)
+	markdownFields := map[string]any{}
+	if existing, ok := fields["markdown"].(map[string]any); ok {
+		markdownFields = cloneMap(existing)
+	}
+	markdownFields["checksum"] = checksum
+	markdownFields["path"] = path
+	fields["markdown"] = markdownFields
+
+	if updater, ok := e.content.(interfaces.ContentTranslationFieldsUpdater); ok {
+		err := updater.UpdateTranslationFields(ctx, interfaces.ContentUpdateTranslationFieldsRequest{
+			ContentID: contentID,
+			Locale:    translation.Locale,
+			Fields:    fields,
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return fmt.Errorf("markdown exporter: record checksum %s: %w", path, err)
+		}
+	}
+
+	_, err := e.content.UpdateTranslation(ctx, interfaces.ContentUpdateTranslationRequest{
+		ContentID: contentID,
+		Locale:    translation.Locale,
+		Title:     translation.Title,
+		Summary:   translation.Summary,
+		Fields:    fields,
+		UpdatedBy: author,
+	})
+	if err != nil {
+		return fmt.Errorf("markdown exporter: record checksum %s: %w", path, err)
+	}
+	return nil
+}
+
+func (e *Exporter) writeFile(path string, source []byte) error {
+	target := filepath.Join(e.basePath, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("markdown exporter: create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(target, source, 0o644); err != nil {
+		return fmt.Errorf("markdown exporter: write %s: %w", path, err)
+	}
+	return nil
+}
+
+// exportPath picks the file for a translation. Files that were imported keep
+// their original path; otherwise the locale pattern (relative to the content
+// root) names the file, falling back to <dir>/<slug>.md for the default locale
+// and <locale>/<dir>/<slug>.md for the rest so the loader detects the locale.
+func (e *Exporter) exportPath(sourcePath, slug, locale, dir string) string {
+	dir = filepath.ToSlash(filepath.Clean(strings.TrimSpace(dir)))
+	if dir == "" {
+		dir = "."
+	}
+	if sourcePath != "" && (dir == "." || strings.HasPrefix(sourcePath, dir+"/")) {
+		return sourcePath
+	}
+	if pattern := strings.TrimSpace(e.localePatterns[locale]); pattern != "" {
+		pattern = strings.ReplaceAll(filepath.ToSlash(pattern), "**/", "")
+		if strings.Count(pattern, "*") == 1 && !strings.ContainsAny(pattern, "?[") {
+			return strings.Replace(pattern, "*", slug, 1)
+		}
+	}
+	name := slug + ".md"
+	if e.defaultLocale == "" || strings.EqualFold(locale, e.defaultLocale) {
+		return filepath.ToSlash(filepath.Join(dir, name))
+	}
+	return filepath.ToSlash(filepath.Join(locale, dir, name))
+}
+
+type exportFrontMatter struct {
+	Title    string         `yaml:"title,omitempty"`
+	Slug     string         `yaml:"slug"`
+	Summary  string         `yaml:"summary,omitempty"`
+	Status   string         `yaml:"status,omitempty"`
+	Template string         `yaml:"template,omitempty"`
+	Tags     []string       `yaml:"tags,omitempty"`
+	Author   string         `yaml:"author,omitempty"`
+	Date     *time.Time     `yaml:"date,omitempty"`
+	Draft    bool           `yaml:"draft,omitempty"`
+	Custom   map[string]any `yaml:",inline"`
+}
+
+func renderExport(record *interfaces.ContentRecord, translation *interfaces.ContentTranslation) ([]byte, error) {
+	meta := exportFrontMatter{
+		Title:  translation.Title,
+		Slug:   record.Slug,
+		Status: record.Status,
+		Custom: map[string]any{},
+	}
+	if translation.Summary != nil {
+		meta.Summary = *translation.Summary
+	}
+
+	// Front matter recorded on import fills the standard keys and custom
+	// values; the current translation fields override them so admin edits
+	// reach the file.
+	if markdownFields, ok := translation.Fields["markdown"].(map[string]any); ok {
+		raw, _ := markdownFields["frontmatter"].(map[string]any)
+		meta.Template, _ = raw["template"].(string)
+		meta.Author, _ = raw["author"].(string)
+		meta.Tags = stringSlice(raw["tags"])
+		meta.Draft, _ = raw["draft"].(bool)
+		meta.Date = timeValue(raw["date"])
+		if custom, ok := markdownFields["custom"].(map[string]any); ok {
+			meta.Custom = cloneMap(custom)
+		}
+	}
+	for key, value := range translation.Fields {
+		if _, reserved := reservedFieldKeys[key]; !reserved {
+			meta.Custom[key] = value
+		}
+	}
+
+	header, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	body, err := exportBodyMarkdown(translation.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n")
+	if body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(body)
+		if !strings.HasSuffix(body, "\n") {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// exportBodyMarkdown returns the markdown body for a translation. The current
+// body field wins, converted from HTML when needed. The markdown recorded on
+// import is written verbatim while the body field still holds the imported
+// HTML, and for entries imported without a mapped body field.
+func exportBodyMarkdown(fields map[string]any) (string, error) {
+	markdownFields, _ := fields["markdown"].(map[string]any)
+	importedBody, _ := markdownFields["body"].(string)
+	importedHTML, _ := markdownFields["body_html"].(string)
+
+	if body := exportBody(fields); body != "" {
+		if importedBody != "" && body == importedHTML {
+			return importedBody, nil
+		}
+		if looksLikeHTML(body) {
+			return HTMLToMarkdown(body)
+		}
+		return body, nil
+	}
+	if importedBody != "" {
+		return importedBody, nil
+	}
+	if strings.TrimSpace(importedHTML) != "" {
+		return HTMLToMarkdown(importedHTML)
+	}
+	return "", nil
+}
+
+func exportBody(fields map[string]any) string {
+	for _, key := range []string{"body", "content"} {
+		if value, ok := fields[key].(string); ok && strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func looksLikeHTML(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	return strings.HasPrefix(trimmed, "<") && strings.Contains(trimmed, ">")
+}
+
+// documentPaths indexes the source file recorded by the importer per locale.
+func documentPaths(metadata map[string]any) map[string]string {
+	paths := map[string]string{}
+	var documents []map[string]any
+	switch value := metadata["documents"].(type) {
+	case []map[string]any:
+		documents = value
+	case []any:
+		for _, item := range value {
+			if doc, ok := item.(map[string]any); ok {
+				documents = append(documents, doc)
+			}
+		}
+	}
+	for _, doc := range documents {
+		path, _ := doc["path"].(string)
+		locale, _ := doc["locale"].(string)
+		if path != "" && locale != "" {
+			paths[strings.ToLower(locale)] = filepath.ToSlash(path)
+		}
+	}
+	return paths
+}
+
+func stringSlice(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return append([]string(nil), v...)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func timeValue(value any) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		if !v.IsZero() {
+			return &v
+		}
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil && !parsed.IsZero() {
+			return &parsed
+		}
+	}
+	return nil
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), target) {
+			return true
+		}
+	}
+	return false
+}
+
+func newExportResult() *interfaces.ExportResult {
+	return &interfaces.ExportResult{
+		Written:   []string{},
+		Unchanged: []string{},
+		Errors:    []error{},
+	}
+}
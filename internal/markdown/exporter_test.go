@@ -0,0 +1,251 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+func TestExportAfterImportMakesSyncNoop(t *testing.T) {
+	base := t.TempDir()
+	copyFixture(t, filepath.Join("testdata", "site", "en", "about.md"), filepath.Join(base, "en", "about.md"))
+
+	contentStub := newStubContentService()
+	svc := newExportService(t, base, contentStub, nil)
+	importOpts := interfaces.ImportOptions{ContentTypeID: uuid.New(), AuthorID: uuid.New()}
+
+	if _, err := svc.ImportDirectory(context.Background(), ".", importOpts); err != nil {
+		t.Fatalf("ImportDirectory: %v", err)
+	}
+
+	result, err := svc.ExportDirectory(context.Background(), ".", interfaces.ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportDirectory: %v", err)
+	}
+	if len(result.Written) != 1 || result.Written[0] != "en/about.md" {
+		t.Fatalf("expected en/about.md written, got %#v", result)
+	}
+
+	written, err := os.ReadFile(filepath.Join(base, "en", "about.md"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	for _, want := range []string{"title: About EN", "slug: about", "author: John Smith", "# About (EN)", "English content."} {
+		if !strings.Contains(string(written), want) {
+			t.Fatalf("expected %q in export:\n%s", want, written)
+		}
+	}
+
+	syncRes, err := svc.Sync(context.Background(), ".", interfaces.SyncOptions{
+		ImportOptions:  importOpts,
+		UpdateExisting: true,
+	})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if syncRes.Created != 0 || syncRes.Updated != 0 || syncRes.Skipped == 0 {
+		t.Fatalf("expected sync to be a no-op after export, got %#v", syncRes)
+	}
+
+	again, err := svc.ExportDirectory(context.Background(), ".", interfaces.ExportOptions{})
+	if err != nil {
+		t.Fatalf("second ExportDirectory: %v", err)
+	}
+	if len(again.Written) != 0 || len(again.Unchanged) != 1 {
+		t.Fatalf("expected second export unchanged, got %#v", again)
+	}
+}
+
+func TestExportConvertsHTMLAndUsesLocalePatterns(t *testing.T) {
+	base := t.TempDir()
+	contentStub := newStubContentService()
+	id := uuid.New()
+	contentStub.records["welcome"] = &interfaces.ContentRecord{ID: id, Slug: "welcome", Status: "published", Metadata: map[string]any{}}
+	contentStub.translations[id] = []interfaces.ContentTranslation{
+		{ID: uuid.New(), Locale: "en", Title: "Welcome", Fields: map[string]any{
+			"body": "<h2>Hello</h2><p>Some <strong>bold</strong> text with a <a href=\"/docs\">link</a>.</p><ul><li>one</li><li>two</li></ul>",
+		}},
+		{ID: uuid.New(), Locale: "es", Title: "Bienvenido", Fields: map[string]any{"body": "Hola"}},
+	}
+
+	svc := newExportService(t, base, contentStub, map[string]string{"es": "**/*.es.md"})
+
+	preview, err := svc.Export(context.Background(), "welcome", "pages", interfaces.ExportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry-run Export: %v", err)
+	}
+	if len(preview.Written) != 2 {
+		t.Fatalf("expected two planned files, got %#v", preview)
+	}
+	if _, err := os.Stat(filepath.Join(base, "pages")); !os.IsNotExist(err) {
+		t.Fatalf("expected dry run to leave disk untouched")
+	}
+
+	result, err := svc.Export(context.Background(), "welcome", "pages", interfaces.ExportOptions{Locales: []string{"es"}})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if len(result.Written) != 1 || result.Written[0] != "welcome.es.md" {
+		t.Fatalf("expected locale pattern path, got %#v", result)
+	}
+
+	if _, err := svc.Export(context.Background(), "welcome", "pages", interfaces.ExportOptions{Locales: []string{"en"}}); err != nil {
+		t.Fatalf("Export en: %v", err)
+	}
+	written, err := os.ReadFile(filepath.Join(base, "pages", "welcome.md"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	want := "## Hello\n\nSome **bold** text with a [link](/docs).\n\n- one\n- two\n"
+	if !strings.HasSuffix(string(written), want) {
+		t.Fatalf("expected converted markdown body, got:\n%s", written)
+	}
+
+	if _, err := svc.Export(context.Background(), "missing", "pages", interfaces.ExportOptions{}); err == nil {
+		t.Fatalf("expected error for unknown slug")
+	}
+}
+
+func TestExportTwiceCarriesAdminEdits(t *testing.T) {
+	base := t.TempDir()
+	contentStub := newStubContentService()
+	id := uuid.New()
+	contentStub.records["pricing"] = &interfaces.ContentRecord{ID: id, Slug: "pricing", Status: "published", Metadata: map[string]any{}}
+	contentStub.translations[id] = []interfaces.ContentTranslation{
+		{ID: uuid.New(), Locale: "en", Title: "Pricing", Fields: map[string]any{"body": "First body.", "price": 10}},
+	}
+	svc := newExportService(t, base, contentStub, nil)
+	ctx := context.Background()
+
+	if _, err := svc.Export(ctx, "pricing", ".", interfaces.ExportOptions{}); err != nil {
+		t.Fatalf("first Export: %v", err)
+	}
+	stored := contentStub.translations[id][0].Fields
+	envelope, _ := stored["markdown"].(map[string]any)
+	if len(envelope) != 2 || envelope["checksum"] == nil || envelope["path"] != "pricing.md" {
+		t.Fatalf("expected only checksum and path recorded, got %#v", envelope)
+	}
+
+	fields := cloneMapAny(stored)
+	fields["body"] = "Edited body."
+	if _, err := contentStub.UpdateTranslation(ctx, interfaces.ContentUpdateTranslationRequest{
+		ContentID: id,
+		Locale:    "en",
+		Title:     "Pricing",
+		Fields:    fields,
+	}); err != nil {
+		t.Fatalf("admin edit: %v", err)
+	}
+
+	result, err := svc.Export(ctx, "pricing", ".", interfaces.ExportOptions{})
+	if err != nil {
+		t.Fatalf("second Export: %v", err)
+	}
+	if len(result.Written) != 1 {
+		t.Fatalf("expected the edited translation to be written, got %#v", result)
+	}
+	written, err := os.ReadFile(filepath.Join(base, "pricing.md"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	for _, want := range []string{"price: 10", "Edited body."} {
+		if !strings.Contains(string(written), want) {
+			t.Fatalf("expected %q in second export:\n%s", want, written)
+		}
+	}
+	if strings.Contains(string(written), "First body.") {
+		t.Fatalf("expected the stale body to be replaced:\n%s", written)
+	}
+}
+
+func newExportService(tb testing.TB, base string, contentSvc *stubContentService, patterns map[string]string) *Service {
+	tb.Helper()
+	svc, err := NewService(Config{
+		BasePath:       base,
+		DefaultLocale:  "en",
+		Locales:        []string{"en", "es"},
+		LocalePatterns: patterns,
+		Pattern:        "*.md",
+		Recursive:      true,
+	}, nil, WithContentService(contentSvc))
+	if err != nil {
+		tb.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+func copyFixture(tb testing.TB, src, dst string) {
+	tb.Helper()
+	data, err := os.ReadFile(src)
+	if err != nil {
+		tb.Fatalf("read fixture: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		tb.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		tb.Fatalf("write fixture: %v", err)
+	}
+}
+
+func TestExportRecordsChecksumWithFieldsUpdater(t *testing.T) {
+	base := t.TempDir()
+	contentStub := &fieldsUpdaterContentService{stubContentService: newStubContentService()}
+	id := uuid.New()
+	contentStub.records["faq"] = &interfaces.ContentRecord{ID: id, Slug: "faq", Status: "published", Metadata: map[string]any{}}
+	contentStub.translations[id] = []interfaces.ContentTranslation{
+		{ID: uuid.New(), Locale: "en", Title: "FAQ", Fields: map[string]any{"body": "Answers."}},
+	}
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil, WithContentService(contentStub))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	if _, err := svc.Export(context.Background(), "faq", ".", interfaces.ExportOptions{}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if contentStub.fieldUpdates != 1 || contentStub.translationUpdates != 0 {
+		t.Fatalf("expected checksum recorded via fields update only, got fields=%d translations=%d", contentStub.fieldUpdates, contentStub.translationUpdates)
+	}
+	tr := contentStub.translations[id][0]
+	envelope, _ := tr.Fields["markdown"].(map[string]any)
+	if envelope["checksum"] == nil || tr.Fields["body"] != "Answers." || tr.Title != "FAQ" {
+		t.Fatalf("expected checksum recorded alongside existing fields, got %#v", tr)
+	}
+}
+
+type fieldsUpdaterContentService struct {
+	*stubContentService
+	fieldUpdates       int
+	translationUpdates int
+}
+
+func (s *fieldsUpdaterContentService) UpdateTranslation(ctx context.Context, req interfaces.ContentUpdateTranslationRequest) (*interfaces.ContentTranslation, error) {
+	s.translationUpdates++
+	return s.stubContentService.UpdateTranslation(ctx, req)
+}
+
+func (s *fieldsUpdaterContentService) UpdateTranslationFields(_ context.Context, req interfaces.ContentUpdateTranslationFieldsRequest) error {
+	s.fieldUpdates++
+	translations := s.translations[req.ContentID]
+	for idx, tr := range translations {
+		if strings.EqualFold(tr.Locale, req.Locale) {
+			translations[idx].Fields = cloneMapAny(req.Fields)
+			return nil
+		}
+	}
+	return errors.New("translation not found")
+}
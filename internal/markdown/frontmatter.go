@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"maps"
+	"strings"
 	"time"
 
 	"github.com/adrg/frontmatter"
+	"gopkg.in/yaml.v3"
 
 	"github.com/goliatone/go-cms/pkg/interfaces"
 )
@@ -34,16 +36,65 @@ func BuildDocument(path string, locale string, source []byte, modified time.Time
 	if err != nil {
 		return nil, err
 	}
+	lines, bodyLine := frontMatterLines(source)
+	frontmatter.Lines = lines
 
 	return &interfaces.Document{
 		FilePath:     path,
 		Locale:       locale,
 		FrontMatter:  frontmatter,
 		Body:         body,
+		BodyLine:     bodyLine,
 		LastModified: modified,
 	}, nil
 }
 
+// frontMatterLines maps YAML frontmatter keys to their source lines and
+// returns the line where the body starts. Other frontmatter formats report no
+// key lines and a body line of 1.
+func frontMatterLines(source []byte) (map[string]int, int) {
+	lines := strings.Split(string(source), "\n")
+	if strings.TrimSpace(strings.TrimPrefix(lines[0], "\ufeff")) != "---" {
+		return nil, 1
+	}
+	for idx := 1; idx < len(lines); idx++ {
+		delimiter := strings.TrimSpace(lines[idx])
+		if delimiter != "---" && delimiter != "..." {
+			continue
+		}
+		keys := map[string]int{}
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(strings.Join(lines[1:idx], "\n")), &node); err == nil {
+			collectKeyLines(&node, "", keys)
+		}
+		return keys, idx + 2
+	}
+	return nil, 1
+}
+
+// collectKeyLines walks mapping nodes, offsetting lines by the opening
+// delimiter.
+func collectKeyLines(node *yaml.Node, prefix string, keys map[string]int) {
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			collectKeyLines(child, prefix, keys)
+		}
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		key, value := node.Content[idx], node.Content[idx+1]
+		name := key.Value
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		keys[name] = key.Line + 1
+		collectKeyLines(value, name, keys)
+	}
+}
+
 type frontMatterEnvelope struct {
 	Title    string         `yaml:"title"`
 	Slug     string         `yaml:"slug"`
@@ -0,0 +1,226 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var collapseBlankLines = regexp.MustCompile(`\n{3,}`)
+
+// HTMLToMarkdown converts rendered HTML back into CommonMark. It covers the
+// constructs the Goldmark parser emits (headings, paragraphs, emphasis, links,
+// images, lists, quotes and code); unknown elements keep only their text.
+func HTMLToMarkdown(source string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(source), &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: "body"})
+	if err != nil {
+		return "", fmt.Errorf("markdown export: parse html: %w", err)
+	}
+	var w htmlMarkdownWriter
+	for _, node := range nodes {
+		w.block(node, "")
+	}
+	out := collapseBlankLines.ReplaceAllString(w.String(), "\n\n")
+	return strings.TrimSpace(out) + "\n", nil
+}
+
+type htmlMarkdownWriter struct {
+	strings.Builder
+}
+
+func (w *htmlMarkdownWriter) block(n *html.Node, prefix string) {
+	switch n.Type {
+	case html.TextNode:
+		if text := strings.TrimSpace(n.Data); text != "" {
+			w.WriteString(prefix + collapseWhitespace(n.Data))
+		}
+		return
+	case html.ElementNode:
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			w.block(c, prefix)
+		}
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		w.WriteString(prefix + strings.Repeat("#", level) + " " + inlineText(n) + "\n\n")
+	case atom.P:
+		w.WriteString(prefixLines(inlineText(n), prefix) + "\n\n")
+	case atom.Hr:
+		w.WriteString(prefix + "---\n\n")
+	case atom.Pre:
+		lang := ""
+		code := n
+		if child := firstElement(n, atom.Code); child != nil {
+			code = child
+			for _, class := range strings.Fields(attr(child, "class")) {
+				if after, ok := strings.CutPrefix(class, "language-"); ok {
+					lang = after
+				}
+			}
+		}
+		body := strings.TrimRight(textContent(code), "\n")
+		w.WriteString(prefix + "```" + lang + "\n" + prefixLines(body, prefix) + "\n" + prefix + "```\n\n")
+	case atom.Blockquote:
+		var inner htmlMarkdownWriter
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			inner.block(c, "")
+		}
+		w.WriteString(prefixLines(strings.TrimSpace(inner.String()), prefix+"> ") + "\n\n")
+	case atom.Ul, atom.Ol:
+		w.list(n, prefix)
+		w.WriteString("\n")
+	default:
+		if isInline(n) {
+			w.WriteString(prefix + inlineText(n) + "\n\n")
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			w.block(c, prefix)
+		}
+	}
+}
+
+func (w *htmlMarkdownWriter) list(n *html.Node, prefix string) {
+	index := 1
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", index)
+		}
+		index++
+
+		var text strings.Builder
+		var nested []*html.Node
+		for c := item.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.DataAtom == atom.Ul || c.DataAtom == atom.Ol) {
+				nested = append(nested, c)
+				continue
+			}
+			if c.Type == html.ElementNode && c.DataAtom == atom.P {
+				text.WriteString(inlineText(c))
+				continue
+			}
+			text.WriteString(inline(c))
+		}
+		w.WriteString(prefix + marker + strings.TrimSpace(collapseWhitespace(text.String())) + "\n")
+		for _, child := range nested {
+			w.list(child, prefix+strings.Repeat(" ", len(marker)))
+		}
+	}
+}
+
+func inlineText(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(inline(c))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseWhitespace(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+	switch n.DataAtom {
+	case atom.Strong, atom.B:
+		return "**" + inlineText(n) + "**"
+	case atom.Em, atom.I:
+		return "_" + inlineText(n) + "_"
+	case atom.Del, atom.S:
+		return "~~" + inlineText(n) + "~~"
+	case atom.Code:
+		return "`" + textContent(n) + "`"
+	case atom.Br:
+		return "  \n"
+	case atom.A:
+		href := attr(n, "href")
+		if title := attr(n, "title"); title != "" {
+			return fmt.Sprintf("[%s](%s %q)", inlineText(n), href, title)
+		}
+		return fmt.Sprintf("[%s](%s)", inlineText(n), href)
+	case atom.Img:
+		return fmt.Sprintf("![%s](%s)", attr(n, "alt"), attr(n, "src"))
+	default:
+		return inlineText(n)
+	}
+}
+
+func isInline(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Strong, atom.B, atom.Em, atom.I, atom.Del, atom.S, atom.Code, atom.A, atom.Img, atom.Span, atom.Br:
+		return true
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+func firstElement(n *html.Node, a atom.Atom) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			return c
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func collapseWhitespace(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		if value != "" {
+			return " "
+		}
+		return ""
+	}
+	out := strings.Join(fields, " ")
+	if strings.TrimLeft(value, " \t\n\r") != value {
+		out = " " + out
+	}
+	if strings.TrimRight(value, " \t\n\r") != value {
+		out += " "
+	}
+	return out
+}
+
+func prefixLines(value, prefix string) string {
+	if prefix == "" {
+		return value
+	}
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -5,11 +5,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"slices"
 	"strings"
 
 	"github.com/google/uuid"
 
+	"github.com/goliatone/go-cms/internal/media"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 )
 
@@ -20,22 +23,52 @@ var (
 )
 
 // ImporterConfig encapsulates dependencies required to persist markdown documents.
+// Pages and Templates are only required when ImportOptions.CreatePages is set.
+// Mappings are keyed by content type ID or slug; Schemas resolves the slug and
+// schema, Blocks validates split body blocks and Renderer renders them. When
+// Media and Assets are set, local images are uploaded and their links rewritten
+// according to AssetLinkMode; bindings are recorded under AssetBindingKey.
 type ImporterConfig struct {
-	Content interfaces.ContentService
-	Logger  interfaces.Logger
+	Content   interfaces.ContentService
+	Pages     interfaces.PageService
+	Templates TemplateResolver
+	Mappings  map[string]FieldMapping
+	Schemas   ContentTypeSchemas
+	Blocks    BlockValidator
+	Renderer  BodyRenderer
+	Media     MediaUploader
+	Assets    fs.FS
+	// AssetLinkMode is AssetLinkURL (default) or AssetLinkReference.
+	AssetLinkMode   string
+	AssetBindingKey string
+	Logger          interfaces.Logger
 }
 
 // Importer orchestrates conversion of markdown documents into content and pages.
 type Importer struct {
-	content interfaces.ContentService
-	logger  interfaces.Logger
+	content   interfaces.ContentService
+	pages     interfaces.PageService
+	templates TemplateResolver
+	mappings  map[string]FieldMapping
+	schemas   ContentTypeSchemas
+	blocks    BlockValidator
+	renderer  BodyRenderer
+	assets    *assetResolver
+	logger    interfaces.Logger
 }
 
 // NewImporter builds an Importer from the supplied configuration.
 func NewImporter(cfg ImporterConfig) *Importer {
 	return &Importer{
-		content: cfg.Content,
-		logger:  cfg.Logger,
+		content:   cfg.Content,
+		pages:     cfg.Pages,
+		templates: cfg.Templates,
+		mappings:  cfg.Mappings,
+		schemas:   cfg.Schemas,
+		blocks:    cfg.Blocks,
+		renderer:  cfg.Renderer,
+		assets:    newAssetResolver(cfg.Media, cfg.Assets, cfg.Renderer, cfg.AssetLinkMode, cfg.AssetBindingKey),
+		logger:    cfg.Logger,
 	}
 }
 
@@ -44,10 +77,21 @@ func (i *Importer) ImportDocument(ctx context.Context, doc *interfaces.Document,
 	if i.content == nil {
 		return nil, ErrContentServiceRequired
 	}
+	mapper, err := i.resolveMapper(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	slug := groupKey(doc)
 	group := []*interfaces.Document{doc}
 	acc := newImportAccumulator()
-	if err := i.applyGroup(ctx, groupKey(doc), group, opts, acc); err != nil {
+	id, err := i.applyGroup(ctx, slug, group, opts, mapper, acc)
+	if err != nil {
 		acc.addError(err)
+	} else if opts.CreatePages {
+		grouped := map[string][]*interfaces.Document{slug: group}
+		if err := i.importPages(ctx, grouped, map[string]uuid.UUID{slug: id}, opts, acc); err != nil {
+			acc.addError(err)
+		}
 	}
 	return acc.result(), firstError(errSlice(acc.errors))
 }
@@ -58,11 +102,25 @@ func (i *Importer) ImportDocuments(ctx context.Context, docs []*interfaces.Docum
 		return nil, ErrContentServiceRequired
 	}
 
+	mapper, err := i.resolveMapper(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	grouped := groupBySlug(docs)
 	acc := newImportAccumulator()
+	contentIDs := map[string]uuid.UUID{}
 	for slug, group := range grouped {
 		group = sortDocuments(group)
-		if err := i.applyGroup(ctx, slug, group, opts, acc); err != nil {
+		id, err := i.applyGroup(ctx, slug, group, opts, mapper, acc)
+		if err != nil {
+			acc.addError(err)
+			continue
+		}
+		contentIDs[slug] = id
+	}
+	if opts.CreatePages {
+		if err := i.importPages(ctx, grouped, contentIDs, opts, acc); err != nil {
 			acc.addError(err)
 		}
 	}
@@ -75,39 +133,112 @@ func (i *Importer) SyncDocuments(ctx context.Context, docs []*interfaces.Documen
 		return nil, ErrContentServiceRequired
 	}
 
+	mapper, err := i.resolveMapper(ctx, opts.ImportOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	grouped := groupBySlug(docs)
 	acc := newSyncAccumulator()
+	i.syncGroups(ctx, grouped, mapper, opts, acc)
 
+	if opts.DeleteOrphaned {
+		if err := i.deleteOrphaned(ctx, grouped, opts, acc); err != nil {
+			acc.addError(err)
+		}
+	}
+
+	return acc.result(), firstError(errSlice(acc.errors))
+}
+
+// SyncGroups re-imports only the supplied translation groups, as watch mode
+// does after detecting file changes. Slugs mapped to no documents were
+// removed from disk and are deleted when DeleteOrphaned is set; content
+// outside the supplied groups is left untouched.
+func (i *Importer) SyncGroups(ctx context.Context, groups map[string][]*interfaces.Document, opts interfaces.SyncOptions) (*interfaces.SyncResult, error) {
+	if i.content == nil {
+		return nil, ErrContentServiceRequired
+	}
+	mapper, err := i.resolveMapper(ctx, opts.ImportOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	present := map[string][]*interfaces.Document{}
+	var removed []string
+	for slug, group := range groups {
+		if len(group) == 0 {
+			removed = append(removed, slug)
+			continue
+		}
+		present[slug] = group
+	}
+
+	acc := newSyncAccumulator()
+	i.syncGroups(ctx, present, mapper, opts, acc)
+
+	if opts.DeleteOrphaned && len(removed) > 0 {
+		if err := i.deleteRemoved(ctx, present, removed, opts, acc); err != nil {
+			acc.addError(err)
+		}
+	}
+
+	return acc.result(), firstError(errSlice(acc.errors))
+}
+
+func (i *Importer) syncGroups(ctx context.Context, grouped map[string][]*interfaces.Document, mapper *fieldMapper, opts interfaces.SyncOptions, acc *syncAccumulator) {
+	contentIDs := map[string]uuid.UUID{}
 	for slug, group := range grouped {
 		group = sortDocuments(group)
 		res := newImportAccumulator()
-		if err := i.applyGroup(ctx, slug, group, opts.ImportOptions, res); err != nil {
+		id, err := i.applyGroup(ctx, slug, group, opts.ImportOptions, mapper, res)
+		if err != nil {
 			res.addError(err)
+		} else {
+			contentIDs[slug] = id
 		}
 		acc.merge(res.result())
 	}
 
-	if opts.DeleteOrphaned {
-		if err := i.deleteOrphaned(ctx, grouped, opts, acc); err != nil {
-			acc.addError(err)
+	if opts.CreatePages {
+		res := newImportAccumulator()
+		if err := i.importPages(ctx, grouped, contentIDs, opts.ImportOptions, res); err != nil {
+			res.addError(err)
 		}
+		acc.merge(res.result())
 	}
-
-	return acc.result(), firstError(errSlice(acc.errors))
 }
 
-func (i *Importer) applyGroup(ctx context.Context, slug string, docs []*interfaces.Document, opts interfaces.ImportOptions, acc *importAccumulator) error {
+// applyGroup creates or updates the content for a slug and returns its ID
+// (uuid.Nil when a dry run skipped creation). When mapper is set, fields and
+// blocks follow the content type mapping and every document error is joined.
+func (i *Importer) applyGroup(ctx context.Context, slug string, docs []*interfaces.Document, opts interfaces.ImportOptions, mapper *fieldMapper, acc *importAccumulator) (uuid.UUID, error) {
 	if slug == "" {
-		return ErrSlugMissing
+		return uuid.Nil, ErrSlugMissing
 	}
 
 	contentTranslations := make([]interfaces.ContentTranslationInput, 0, len(docs))
 	titleFallback := fallbackTitle(slug)
 	status := selectStatus(docs)
+	var mappingErrs []error
+	mediaBindings := map[string]media.BindingSet{}
 
 	for _, doc := range docs {
 		if err := validateDocument(doc); err != nil {
-			return err
+			return uuid.Nil, err
+		}
+
+		var bindings media.BindingSet
+		if i.assets != nil {
+			resolved, found, err := i.assets.resolve(ctx, doc, interfaces.ParseOptions{ProcessShortcodes: opts.ProcessShortcodes}, opts.DryRun)
+			if err != nil && !opts.DryRun {
+				mappingErrs = append(mappingErrs, err)
+				continue
+			}
+			if err != nil {
+				acc.addError(err)
+			}
+			doc, bindings = resolved, found
 		}
 
 		title := strings.TrimSpace(doc.FrontMatter.Title)
@@ -116,82 +247,95 @@ func (i *Importer) applyGroup(ctx context.Context, slug string, docs []*interfac
 		}
 
 		fields := buildContentFields(doc)
+		var blocks []map[string]any
+		if mapper != nil {
+			var err error
+			if fields, blocks, err = mapper.mapDocument(ctx, doc); err != nil {
+				mappingErrs = append(mappingErrs, err)
+				continue
+			}
+		}
+		if len(bindings) > 0 {
+			mediaBindings[doc.Locale] = bindings
+			if mapper == nil || mapper.allows("media_bindings") {
+				fields["media_bindings"] = bindings
+			}
+		}
 		contentTranslations = append(contentTranslations, interfaces.ContentTranslationInput{
 			Locale:  doc.Locale,
 			Title:   title,
 			Summary: optionalString(doc.FrontMatter.Summary),
 			Fields:  fields,
+			Blocks:  blocks,
 		})
 	}
+	if len(mappingErrs) > 0 {
+		return uuid.Nil, errors.Join(mappingErrs...)
+	}
 
 	existing, err := i.content.GetBySlug(ctx, slug, interfaces.ContentReadOptions{
 		EnvironmentKey: opts.EnvironmentKey,
 	})
 	if err != nil && existing != nil {
-		return fmt.Errorf("markdown importer: content lookup %s: %w", slug, err)
+		return uuid.Nil, fmt.Errorf("markdown importer: content lookup %s: %w", slug, err)
 	}
 
 	if existing == nil {
 		if opts.DryRun {
 			acc.skip(uuid.Nil)
-			return nil
+			return uuid.Nil, nil
 		}
 
 		createReq := interfaces.ContentCreateRequest{
-			ContentTypeID: opts.ContentTypeID,
-			Slug:          slug,
-			Status:        status,
-			CreatedBy:     opts.AuthorID,
-			UpdatedBy:     opts.AuthorID,
-			Translations:  contentTranslations,
-			Metadata: map[string]any{
-				"source":    "markdown",
-				"documents": documentMetadata(docs),
-			},
+			ContentTypeID:            opts.ContentTypeID,
+			Slug:                     slug,
+			Status:                   status,
+			CreatedBy:                opts.AuthorID,
+			UpdatedBy:                opts.AuthorID,
+			Translations:             contentTranslations,
+			Metadata:                 contentMetadata(docs, mediaBindings),
 			AllowMissingTranslations: opts.ContentAllowMissingTranslations,
 		}
 
 		record, createErr := i.content.Create(ctx, createReq)
 		if createErr != nil {
-			return fmt.Errorf("markdown importer: create content %s: %w", slug, createErr)
+			return uuid.Nil, fmt.Errorf("markdown importer: create content %s: %w", slug, createErr)
 		}
 		acc.created(record.ID)
-		return nil
+		return record.ID, nil
 	}
 
 	existingTranslations, availableLocales, err := fetchExistingTranslations(ctx, i.content, slug, existing.ID, contentTranslations, opts.EnvironmentKey)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
-	changedTranslations := diffTranslations(existingTranslations, availableLocales, contentTranslations)
+	checksums := translationChecksums{incoming: documentChecksums(docs), stored: metadataChecksums(existing.Metadata)}
+	changedTranslations := diffTranslations(existingTranslations, availableLocales, contentTranslations, checksums)
 	if !changedTranslations {
 		acc.skip(existing.ID)
-		return nil
+		return existing.ID, nil
 	}
 
 	if opts.DryRun {
 		acc.skip(existing.ID)
-		return nil
+		return existing.ID, nil
 	}
 
 	updateReq := interfaces.ContentUpdateRequest{
-		ID:           existing.ID,
-		Status:       status,
-		UpdatedBy:    opts.AuthorID,
-		Translations: contentTranslations,
-		Metadata: map[string]any{
-			"source":    "markdown",
-			"documents": documentMetadata(docs),
-		},
+		ID:                       existing.ID,
+		Status:                   status,
+		UpdatedBy:                opts.AuthorID,
+		Translations:             contentTranslations,
+		Metadata:                 contentMetadata(docs, mediaBindings),
 		AllowMissingTranslations: opts.ContentAllowMissingTranslations,
 	}
 
 	updated, updateErr := i.content.Update(ctx, updateReq)
 	if updateErr != nil {
-		return fmt.Errorf("markdown importer: update content %s: %w", slug, updateErr)
+		return uuid.Nil, fmt.Errorf("markdown importer: update content %s: %w", slug, updateErr)
 	}
 	acc.updated(updated.ID)
-	return nil
+	return updated.ID, nil
 }
 
 func (i *Importer) deleteOrphaned(ctx context.Context, docs map[string][]*interfaces.Document, opts interfaces.SyncOptions, acc *syncAccumulator) error {
@@ -207,10 +351,57 @@ func (i *Importer) deleteOrphaned(ctx context.Context, docs map[string][]*interf
 		docSlugs[slug] = struct{}{}
 	}
 
+	orphans := make([]*interfaces.ContentRecord, 0)
 	for _, record := range existing {
 		if _, ok := docSlugs[record.Slug]; ok {
 			continue
 		}
+		orphans = append(orphans, record)
+	}
+	return i.deleteContent(ctx, docSlugs, orphans, opts, acc)
+}
+
+// deleteRemoved deletes the content (and pages) of slugs whose files were
+// all removed.
+func (i *Importer) deleteRemoved(ctx context.Context, docs map[string][]*interfaces.Document, removed []string, opts interfaces.SyncOptions, acc *syncAccumulator) error {
+	docSlugs := make(map[string]struct{}, len(docs))
+	for slug := range docs {
+		docSlugs[slug] = struct{}{}
+	}
+
+	orphans := make([]*interfaces.ContentRecord, 0, len(removed))
+	for _, slug := range removed {
+		record, err := i.content.GetBySlug(ctx, slug, interfaces.ContentReadOptions{
+			EnvironmentKey:           opts.EnvironmentKey,
+			AllowMissingTranslations: true,
+		})
+		if err != nil {
+			return fmt.Errorf("markdown importer: content lookup %s: %w", slug, err)
+		}
+		if record != nil {
+			orphans = append(orphans, record)
+		}
+	}
+	return i.deleteContent(ctx, docSlugs, orphans, opts, acc)
+}
+
+func (i *Importer) deleteContent(ctx context.Context, docSlugs map[string]struct{}, orphans []*interfaces.ContentRecord, opts interfaces.SyncOptions, acc *syncAccumulator) error {
+	if len(orphans) == 0 {
+		return nil
+	}
+	orphanIDs := make(map[uuid.UUID]struct{}, len(orphans))
+	for _, record := range orphans {
+		orphanIDs[record.ID] = struct{}{}
+	}
+
+	// Pages reference their content, so they are removed first.
+	if opts.CreatePages {
+		if err := i.deleteOrphanedPages(ctx, docSlugs, orphanIDs, opts, acc); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range orphans {
 		if opts.DryRun {
 			acc.deleted++
 			continue
@@ -325,15 +516,22 @@ func selectStatus(docs []*interfaces.Document) string {
 
 func buildContentFields(doc *interfaces.Document) map[string]any {
 	return map[string]any{
-		"markdown": map[string]any{
-			"body":        string(doc.Body),
-			"body_html":   string(doc.BodyHTML),
-			"checksum":    hex.EncodeToString(doc.Checksum),
-			"frontmatter": doc.FrontMatter.Raw,
-			"custom":      doc.FrontMatter.Custom,
-		},
-		"locale": doc.Locale,
+		"markdown": markdownEnvelope(doc),
+		"locale":   doc.Locale,
+	}
+}
+
+// contentMetadata records the source documents and, when local images were
+// imported, the media bindings of every locale.
+func contentMetadata(docs []*interfaces.Document, bindings map[string]media.BindingSet) map[string]any {
+	metadata := map[string]any{
+		"source":    "markdown",
+		"documents": documentMetadata(docs),
+	}
+	if len(bindings) > 0 {
+		metadata["media_bindings"] = maps.Clone(bindings)
 	}
+	return metadata
 }
 
 func documentMetadata(docs []*interfaces.Document) []map[string]any {
@@ -355,7 +553,48 @@ func documentMetadata(docs []*interfaces.Document) []map[string]any {
 	return out
 }
 
-func diffTranslations(existing map[string]*interfaces.ContentTranslation, availableLocales []string, inputs []interfaces.ContentTranslationInput) bool {
+// translationChecksums holds per-locale file checksums used when mapped
+// fields carry no markdown envelope: incoming from the documents, stored from
+// the content metadata written by the previous import.
+type translationChecksums struct {
+	incoming map[string]string
+	stored   map[string]string
+}
+
+func documentChecksums(docs []*interfaces.Document) map[string]string {
+	out := map[string]string{}
+	for _, doc := range docs {
+		if doc != nil {
+			out[strings.ToLower(strings.TrimSpace(doc.Locale))] = hex.EncodeToString(doc.Checksum)
+		}
+	}
+	return out
+}
+
+func metadataChecksums(metadata map[string]any) map[string]string {
+	out := map[string]string{}
+	var entries []map[string]any
+	switch typed := metadata["documents"].(type) {
+	case []map[string]any:
+		entries = typed
+	case []any:
+		for _, entry := range typed {
+			if doc, ok := entry.(map[string]any); ok {
+				entries = append(entries, doc)
+			}
+		}
+	}
+	for _, entry := range entries {
+		locale, _ := entry["locale"].(string)
+		checksum, _ := entry["checksum"].(string)
+		if locale != "" {
+			out[strings.ToLower(strings.TrimSpace(locale))] = checksum
+		}
+	}
+	return out
+}
+
+func diffTranslations(existing map[string]*interfaces.ContentTranslation, availableLocales []string, inputs []interfaces.ContentTranslationInput, checksums translationChecksums) bool {
 	current := map[string]*interfaces.ContentTranslation{}
 	for locale, tr := range existing {
 		if tr == nil {
@@ -387,7 +626,7 @@ func diffTranslations(existing map[string]*interfaces.ContentTranslation, availa
 		if stringValue(in.Summary) != stringValue(currentTr.Summary) {
 			return true
 		}
-		if checksumFromFields(in.Fields) != checksumFromFields(currentTr.Fields) {
+		if checksumOr(in.Fields, checksums.incoming[localeKey]) != checksumOr(currentTr.Fields, checksums.stored[localeKey]) {
 			return true
 		}
 	}
@@ -405,6 +644,15 @@ func diffTranslations(existing map[string]*interfaces.ContentTranslation, availa
 	return false
 }
 
+// checksumOr prefers the checksum stored in the markdown envelope and falls
+// back to the supplied document checksum.
+func checksumOr(fields map[string]any, fallback string) string {
+	if checksum := checksumFromFields(fields); checksum != "" {
+		return checksum
+	}
+	return fallback
+}
+
 func checksumFromFields(fields map[string]any) string {
 	markdown, ok := fields["markdown"].(map[string]any)
 	if !ok {
@@ -430,18 +678,22 @@ func optionalString(value string) *string {
 }
 
 type importAccumulator struct {
-	createdIDs []uuid.UUID
-	updatedIDs []uuid.UUID
-	skippedIDs []uuid.UUID
-	errors     []error
+	createdIDs     []uuid.UUID
+	updatedIDs     []uuid.UUID
+	skippedIDs     []uuid.UUID
+	createdPageIDs []uuid.UUID
+	updatedPageIDs []uuid.UUID
+	errors         []error
 }
 
 func newImportAccumulator() *importAccumulator {
 	return &importAccumulator{
-		createdIDs: []uuid.UUID{},
-		updatedIDs: []uuid.UUID{},
-		skippedIDs: []uuid.UUID{},
-		errors:     []error{},
+		createdIDs:     []uuid.UUID{},
+		updatedIDs:     []uuid.UUID{},
+		skippedIDs:     []uuid.UUID{},
+		createdPageIDs: []uuid.UUID{},
+		updatedPageIDs: []uuid.UUID{},
+		errors:         []error{},
 	}
 }
 
@@ -463,27 +715,42 @@ func (a *importAccumulator) skip(id uuid.UUID) {
 	}
 }
 
-func (a *importAccumulator) addError(err error) {
-	if err != nil {
-		a.errors = append(a.errors, err)
+func (a *importAccumulator) pageCreated(id uuid.UUID) {
+	if id != uuid.Nil {
+		a.createdPageIDs = append(a.createdPageIDs, id)
+	}
+}
+
+func (a *importAccumulator) pageUpdated(id uuid.UUID) {
+	if id != uuid.Nil {
+		a.updatedPageIDs = append(a.updatedPageIDs, id)
 	}
 }
 
+func (a *importAccumulator) addError(err error) {
+	a.errors = append(a.errors, splitErrors(err)...)
+}
+
 func (a *importAccumulator) result() *interfaces.ImportResult {
 	return &interfaces.ImportResult{
 		CreatedContentIDs: a.createdIDs,
 		UpdatedContentIDs: a.updatedIDs,
 		SkippedContentIDs: a.skippedIDs,
+		CreatedPageIDs:    a.createdPageIDs,
+		UpdatedPageIDs:    a.updatedPageIDs,
 		Errors:            a.errors,
 	}
 }
 
 type syncAccumulator struct {
-	created int
-	updated int
-	deleted int
-	skipped int
-	errors  []error
+	created      int
+	updated      int
+	deleted      int
+	skipped      int
+	pagesCreated int
+	pagesUpdated int
+	pagesDeleted int
+	errors       []error
 }
 
 func newSyncAccumulator() *syncAccumulator {
@@ -499,25 +766,45 @@ func (s *syncAccumulator) merge(res *interfaces.ImportResult) {
 	s.created += len(res.CreatedContentIDs)
 	s.updated += len(res.UpdatedContentIDs)
 	s.skipped += len(res.SkippedContentIDs)
+	s.pagesCreated += len(res.CreatedPageIDs)
+	s.pagesUpdated += len(res.UpdatedPageIDs)
 	s.errors = append(s.errors, res.Errors...)
 }
 
 func (s *syncAccumulator) addError(err error) {
-	if err != nil {
-		s.errors = append(s.errors, err)
-	}
+	s.errors = append(s.errors, splitErrors(err)...)
 }
 
 func (s *syncAccumulator) result() *interfaces.SyncResult {
 	return &interfaces.SyncResult{
-		Created: s.created,
-		Updated: s.updated,
-		Skipped: s.skipped,
-		Errors:  s.errors,
-		Deleted: s.deleted,
+		Created:      s.created,
+		Updated:      s.updated,
+		Skipped:      s.skipped,
+		Errors:       s.errors,
+		Deleted:      s.deleted,
+		PagesCreated: s.pagesCreated,
+		PagesUpdated: s.pagesUpdated,
+		PagesDeleted: s.pagesDeleted,
 	}
 }
 
+// splitErrors flattens joined document errors so each file position is
+// reported as its own entry.
+func splitErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var out []error
+	for _, inner := range joined.Unwrap() {
+		out = append(out, splitErrors(inner)...)
+	}
+	return out
+}
+
 func errSlice(errs []error) []error {
 	filtered := make([]error, 0, len(errs))
 	for _, err := range errs {
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/goliatone/go-cms/internal/util"
 	"github.com/goliatone/go-cms/pkg/interfaces"
@@ -97,25 +98,65 @@ func (l *Loader) LoadFile(ctx context.Context, path string, opts LoadParams) (*D
 
 // LoadDirectory discovers Markdown files under dir and returns parsed documents.
 func (l *Loader) LoadDirectory(ctx context.Context, dir string, opts LoadParams) ([]*DocumentResult, error) {
+	var results []*DocumentResult
+	err := l.walk(ctx, dir, opts, func(rel string, _ fs.DirEntry) error {
+		result, err := l.LoadFile(ctx, rel, opts)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Document.FilePath < results[j].Document.FilePath
+	})
+
+	return results, nil
+}
+
+// FileStamp captures the filesystem attributes polled to detect changes.
+type FileStamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// Scan lists the Markdown files under dir with their stamps without reading
+// file contents.
+func (l *Loader) Scan(ctx context.Context, dir string, opts LoadParams) (map[string]FileStamp, error) {
+	stamps := map[string]FileStamp{}
+	err := l.walk(ctx, dir, opts, func(rel string, d fs.DirEntry) error {
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("markdown loader stat %s: %w", rel, err)
+		}
+		stamps[rel] = FileStamp{ModTime: info.ModTime(), Size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stamps, nil
+}
+
+// walk visits every file under dir that matches the configured pattern.
+func (l *Loader) walk(ctx context.Context, dir string, opts LoadParams, visit func(rel string, d fs.DirEntry) error) error {
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return ctx.Err()
 	default:
 	}
 
 	root, err := l.makeRelative(dir)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
 	root = filepath.Clean(root)
-	if root == "." {
-		root = "."
-	}
-
-	var results []*DocumentResult
 
-	walkErr := fs.WalkDir(l.fs, root, func(path string, d fs.DirEntry, walkErr error) error {
+	return fs.WalkDir(l.fs, root, func(path string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
@@ -135,24 +176,8 @@ func (l *Loader) LoadDirectory(ctx context.Context, dir string, opts LoadParams)
 		if !l.matchesPattern(rel, opts.Pattern) {
 			return nil
 		}
-
-		result, err := l.LoadFile(ctx, rel, opts)
-		if err != nil {
-			return err
-		}
-		results = append(results, result)
-		return nil
-	})
-
-	if walkErr != nil {
-		return nil, walkErr
-	}
-
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Document.FilePath < results[j].Document.FilePath
+		return visit(rel, d)
 	})
-
-	return results, nil
 }
 
 func (l *Loader) shouldRecurse(root, current string, override *bool) bool {
@@ -0,0 +1,442 @@
+package markdown
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/internal/validation"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+var ErrFieldNotInSchema = errors.New("field is not defined by the content type schema")
+
+// FieldMapping routes frontmatter keys and the document body of one content
+// type onto its schema fields.
+type FieldMapping struct {
+	// Fields maps frontmatter keys (dot paths for nested keys) to schema fields.
+	Fields map[string]string
+	// BodyField receives the rendered body HTML when the body is not split
+	// into blocks.
+	BodyField string
+	// Blocks optionally splits the body into embedded blocks.
+	Blocks BlockSplit
+}
+
+// ContentTypeSchemas resolves the slug and schema of the content type targeted
+// by an import so mappings can be matched and values coerced and validated.
+type ContentTypeSchemas interface {
+	ContentTypeSchema(ctx context.Context, id uuid.UUID) (slug string, schema map[string]any, err error)
+}
+
+// BlockValidator validates embedded blocks produced from a document body
+// against the block registry.
+type BlockValidator interface {
+	ValidateBlocks(ctx context.Context, locale string, blocks []map[string]any) error
+}
+
+// BodyRenderer renders Markdown fragments, such as split body blocks, to HTML.
+type BodyRenderer interface {
+	Render(ctx context.Context, markdown []byte, opts interfaces.ParseOptions) ([]byte, error)
+}
+
+// DocumentError reports an import failure at a position in a source file.
+type DocumentError struct {
+	Path  string
+	Line  int
+	Field string
+	Err   error
+}
+
+func (e *DocumentError) Error() string {
+	location := e.Path
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", e.Path, e.Line)
+	}
+	if e.Field != "" {
+		return fmt.Sprintf("markdown importer: %s: %s: %v", location, e.Field, e.Err)
+	}
+	return fmt.Sprintf("markdown importer: %s: %v", location, e.Err)
+}
+
+func (e *DocumentError) Unwrap() error {
+	return e.Err
+}
+
+// fieldMapper applies a FieldMapping to the documents of a single import run.
+type fieldMapper struct {
+	mapping    FieldMapping
+	slug       string
+	schema     map[string]any
+	properties map[string]any
+	strict     bool
+	renderer   BodyRenderer
+	blocks     BlockValidator
+	parse      interfaces.ParseOptions
+}
+
+// resolveMapper returns the mapper configured for the imported content type,
+// or nil when documents use the default markdown field layout.
+func (i *Importer) resolveMapper(ctx context.Context, opts interfaces.ImportOptions) (*fieldMapper, error) {
+	if len(i.mappings) == 0 {
+		return nil, nil
+	}
+	var (
+		slug   string
+		schema map[string]any
+	)
+	if i.schemas != nil && opts.ContentTypeID != uuid.Nil {
+		var err error
+		slug, schema, err = i.schemas.ContentTypeSchema(ctx, opts.ContentTypeID)
+		if err != nil {
+			return nil, fmt.Errorf("markdown importer: resolve content type %s: %w", opts.ContentTypeID, err)
+		}
+	}
+	mapping, ok := i.mappings[opts.ContentTypeID.String()]
+	if !ok && slug != "" {
+		mapping, ok = i.mappings[slug]
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	mapper := &fieldMapper{
+		mapping:  mapping,
+		slug:     slug,
+		schema:   schema,
+		renderer: i.renderer,
+		blocks:   i.blocks,
+		parse: interfaces.ParseOptions{
+			ProcessShortcodes: opts.ProcessShortcodes,
+		},
+	}
+	if normalized := validation.NormalizeSchema(schema); normalized != nil {
+		mapper.properties, _ = normalized["properties"].(map[string]any)
+		mapper.strict = normalized["additionalProperties"] == false
+	}
+	if mapping.Blocks.Mode != "" && mapper.renderer == nil {
+		mapper.renderer = parserRenderer{parser: NewGoldmarkParser(interfaces.ParseOptions{})}
+	}
+	return mapper, nil
+}
+
+// allows reports whether the schema accepts the given top-level field.
+func (m *fieldMapper) allows(field string) bool {
+	if _, ok := m.properties[field]; ok {
+		return true
+	}
+	return !m.strict
+}
+
+// mapDocument builds translation fields and embedded blocks for a document.
+// Every failure is reported as a DocumentError and joined into the result.
+func (m *fieldMapper) mapDocument(ctx context.Context, doc *interfaces.Document) (map[string]any, []map[string]any, error) {
+	var errs []error
+	fail := func(line int, field string, err error) {
+		errs = append(errs, &DocumentError{Path: doc.FilePath, Line: line, Field: field, Err: err})
+	}
+
+	fields := map[string]any{}
+	lines := map[string]int{}
+
+	keys := make([]string, 0, len(m.mapping.Fields))
+	for key := range m.mapping.Fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		target := strings.TrimSpace(m.mapping.Fields[key])
+		if target == "" {
+			continue
+		}
+		line := doc.FrontMatter.Lines[key]
+		value, ok := lookupFrontMatter(doc.FrontMatter.Raw, key)
+		if !ok {
+			continue
+		}
+		if !m.allows(target) {
+			fail(line, key, fmt.Errorf("%w: %s", ErrFieldNotInSchema, target))
+			continue
+		}
+		propSchema, _ := m.properties[target].(map[string]any)
+		coerced, err := coerceValue(value, propSchema)
+		if err != nil {
+			fail(line, key, err)
+			continue
+		}
+		fields[target] = coerced
+		lines[target] = line
+	}
+
+	var blocks []map[string]any
+	var blockLines []int
+	if m.mapping.Blocks.Mode != "" {
+		var err error
+		blocks, blockLines, err = m.buildBlocks(ctx, doc)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	} else if field := strings.TrimSpace(m.mapping.BodyField); field != "" {
+		if m.allows(field) {
+			fields[field] = string(doc.BodyHTML)
+			lines[field] = doc.BodyLine
+		} else {
+			fail(doc.BodyLine, "body", fmt.Errorf("%w: %s", ErrFieldNotInSchema, field))
+		}
+	}
+
+	if m.allows("markdown") {
+		fields["markdown"] = markdownEnvelope(doc)
+	}
+	if m.allows("locale") {
+		if _, ok := fields["locale"]; !ok {
+			fields["locale"] = doc.Locale
+		}
+	}
+
+	if len(errs) == 0 && m.schema != nil {
+		for _, issue := range m.validate(fields, blocks) {
+			line, field := issuePosition(issue.Location, lines, blockLines)
+			fail(line, field, errors.New(issue.Message))
+		}
+	}
+	return fields, blocks, errors.Join(errs...)
+}
+
+// validate checks the mapped payload against the content type schema using the
+// JSON form the content service validates.
+func (m *fieldMapper) validate(fields map[string]any, blocks []map[string]any) []validation.ValidationIssue {
+	payload := make(map[string]any, len(fields)+1)
+	for key, value := range fields {
+		payload[key] = value
+	}
+	if len(blocks) > 0 {
+		payload["blocks"] = blocks
+	}
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return []validation.ValidationIssue{{Message: err.Error()}}
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return []validation.ValidationIssue{{Message: err.Error()}}
+	}
+	return validation.Issues(validation.ValidatePayload(m.schema, normalized))
+}
+
+var instanceSegment = regexp.MustCompile(`[^/#]+`)
+
+// issuePosition maps a schema issue location such as "/price" or
+// "/blocks/1/title" back to a source line and field.
+func issuePosition(location string, lines map[string]int, blockLines []int) (int, string) {
+	segments := instanceSegment.FindAllString(location, -1)
+	if len(segments) == 0 {
+		return 1, ""
+	}
+	field := segments[0]
+	if field == "blocks" && len(segments) > 1 {
+		if idx, err := strconv.Atoi(segments[1]); err == nil && idx >= 0 && idx < len(blockLines) {
+			return blockLines[idx], strings.Join(segments, ".")
+		}
+	}
+	if line, ok := lines[field]; ok {
+		return line, field
+	}
+	return 1, field
+}
+
+func markdownEnvelope(doc *interfaces.Document) map[string]any {
+	return map[string]any{
+		"body":        string(doc.Body),
+		"body_html":   string(doc.BodyHTML),
+		"checksum":    hex.EncodeToString(doc.Checksum),
+		"frontmatter": doc.FrontMatter.Raw,
+		"custom":      doc.FrontMatter.Custom,
+	}
+}
+
+// lookupFrontMatter resolves dot-separated keys against nested frontmatter maps.
+func lookupFrontMatter(raw map[string]any, key string) (any, bool) {
+	if value, ok := raw[key]; ok {
+		return value, true
+	}
+	var current any = raw
+	for _, part := range strings.Split(key, ".") {
+		next, ok := normalizeYAMLValue(current).(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = next[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// coerceValue converts a frontmatter value into the JSON type declared by the
+// property schema. Values without a declared type are normalised only.
+func coerceValue(value any, schema map[string]any) (any, error) {
+	value = normalizeYAMLValue(value)
+	if value == nil {
+		return nil, nil
+	}
+	format, _ := schema["format"].(string)
+	switch schemaType(schema) {
+	case "string":
+		switch typed := value.(type) {
+		case string:
+			return typed, nil
+		case time.Time:
+			if format == "date" {
+				return typed.Format(time.DateOnly), nil
+			}
+			return typed.Format(time.RFC3339), nil
+		case bool, int, int64, uint64, float64:
+			return fmt.Sprint(typed), nil
+		}
+		return nil, fmt.Errorf("expected string, got %T", value)
+	case "integer":
+		switch typed := value.(type) {
+		case int:
+			return typed, nil
+		case int64:
+			return int(typed), nil
+		case uint64:
+			return int(typed), nil
+		case float64:
+			if typed == float64(int(typed)) {
+				return int(typed), nil
+			}
+		case string:
+			if parsed, err := strconv.Atoi(strings.TrimSpace(typed)); err == nil {
+				return parsed, nil
+			}
+		}
+		return nil, fmt.Errorf("expected integer, got %v", value)
+	case "number":
+		switch typed := value.(type) {
+		case int:
+			return float64(typed), nil
+		case int64:
+			return float64(typed), nil
+		case uint64:
+			return float64(typed), nil
+		case float64:
+			return typed, nil
+		case string:
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(typed), 64); err == nil {
+				return parsed, nil
+			}
+		}
+		return nil, fmt.Errorf("expected number, got %v", value)
+	case "boolean":
+		switch typed := value.(type) {
+		case bool:
+			return typed, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(typed)) {
+			case "true", "yes", "on", "1":
+				return true, nil
+			case "false", "no", "off", "0":
+				return false, nil
+			}
+		}
+		return nil, fmt.Errorf("expected boolean, got %v", value)
+	case "array":
+		var items []any
+		switch typed := value.(type) {
+		case []any:
+			items = typed
+		case string:
+			for _, part := range strings.Split(typed, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					items = append(items, part)
+				}
+			}
+		default:
+			items = []any{typed}
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		out := make([]any, 0, len(items))
+		for idx, item := range items {
+			coerced, err := coerceValue(item, itemSchema)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", idx, err)
+			}
+			out = append(out, coerced)
+		}
+		return out, nil
+	case "object":
+		if typed, ok := value.(map[string]any); ok {
+			return typed, nil
+		}
+		return nil, fmt.Errorf("expected object, got %T", value)
+	}
+	if typed, ok := value.(time.Time); ok {
+		return typed.Format(time.RFC3339), nil
+	}
+	return value, nil
+}
+
+func schemaType(schema map[string]any) string {
+	switch typed := schema["type"].(type) {
+	case string:
+		return typed
+	case []any:
+		for _, entry := range typed {
+			if name, ok := entry.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeYAMLValue converts YAML decoder shapes (interface-keyed maps,
+// string slices) into their JSON equivalents.
+func normalizeYAMLValue(value any) any {
+	switch typed := value.(type) {
+	case map[any]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[fmt.Sprint(key)] = normalizeYAMLValue(item)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[key] = normalizeYAMLValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for idx, item := range typed {
+			out[idx] = normalizeYAMLValue(item)
+		}
+		return out
+	case []string:
+		out := make([]any, len(typed))
+		for idx, item := range typed {
+			out[idx] = item
+		}
+		return out
+	}
+	return value
+}
+
+type parserRenderer struct {
+	parser interfaces.MarkdownParser
+}
+
+func (r parserRenderer) Render(_ context.Context, markdown []byte, opts interfaces.ParseOptions) ([]byte, error) {
+	return r.parser.ParseWithOptions(markdown, opts)
+}
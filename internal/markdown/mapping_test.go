@@ -0,0 +1,219 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+var productSchema = map[string]any{
+	"fields": []any{
+		map[string]any{"name": "price", "type": "number", "required": true},
+		map[string]any{"name": "stock", "type": "integer"},
+		map[string]any{"name": "hero_image", "type": "string"},
+		map[string]any{"name": "featured", "type": "boolean"},
+		map[string]any{"name": "body", "type": "string"},
+		map[string]any{"name": "blocks", "type": "array"},
+	},
+}
+
+func TestImportMapsFrontMatterOntoSchemaFields(t *testing.T) {
+	base := t.TempDir()
+	writeMappedFile(t, base, "en/widget.md", "price: \"19.90\"\nstock: 3\nhero:\n  image: /img/widget.png\nfeatured: yes\n", "Widget body.\n")
+
+	contentStub := &recordingContentService{stubContentService: newStubContentService()}
+	svc := newMappingService(t, base, contentStub, FieldMapping{
+		Fields:    map[string]string{"price": "price", "stock": "stock", "hero.image": "hero_image", "featured": "featured"},
+		BodyField: "body",
+	}, nil)
+
+	opts := interfaces.ImportOptions{ContentTypeID: productTypeID, AuthorID: uuid.New()}
+	if _, err := svc.ImportDirectory(context.Background(), ".", opts); err != nil {
+		t.Fatalf("ImportDirectory: %v", err)
+	}
+
+	fields := contentStub.creates[0].Translations[0].Fields
+	if fields["price"] != 19.9 || fields["stock"] != 3 || fields["hero_image"] != "/img/widget.png" || fields["featured"] != true {
+		t.Fatalf("expected coerced schema fields, got %#v", fields)
+	}
+	if body, _ := fields["body"].(string); !strings.Contains(body, "<p>Widget body.</p>") {
+		t.Fatalf("expected rendered body field, got %#v", fields["body"])
+	}
+	if _, ok := fields["markdown"]; ok {
+		t.Fatalf("expected markdown envelope omitted for a closed schema")
+	}
+
+	syncRes, err := svc.Sync(context.Background(), ".", interfaces.SyncOptions{ImportOptions: opts, UpdateExisting: true})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if syncRes.Updated != 0 || syncRes.Skipped != 1 {
+		t.Fatalf("expected unchanged mapped document skipped, got %#v", syncRes)
+	}
+}
+
+func TestImportReportsMappingErrorsWithLines(t *testing.T) {
+	base := t.TempDir()
+	writeMappedFile(t, base, "en/broken.md", "price: cheap\nstock: 1.5\n", "Body.\n")
+	writeMappedFile(t, base, "en/missing.md", "featured: true\n", "Body.\n")
+
+	svc := newMappingService(t, base, &recordingContentService{stubContentService: newStubContentService()}, FieldMapping{
+		Fields: map[string]string{"price": "price", "stock": "stock", "featured": "featured"},
+	}, nil)
+
+	result, err := svc.ImportDirectory(context.Background(), ".", interfaces.ImportOptions{ContentTypeID: productTypeID})
+	if err == nil {
+		t.Fatalf("expected mapping error")
+	}
+	var docErr *DocumentError
+	if !errors.As(err, &docErr) {
+		t.Fatalf("expected DocumentError, got %T", err)
+	}
+
+	messages := map[string]bool{}
+	for _, e := range result.Errors {
+		messages[e.Error()] = true
+	}
+	for _, want := range []string{
+		"markdown importer: en/broken.md:5: price: expected number, got cheap",
+		"markdown importer: en/broken.md:6: stock: expected integer, got 1.5",
+	} {
+		if !messages[want] {
+			t.Fatalf("expected %q in %v", want, result.Errors)
+		}
+	}
+	found := false
+	for message := range messages {
+		found = found || strings.HasPrefix(message, "markdown importer: en/missing.md:1: ") && strings.Contains(message, "price")
+	}
+	if !found {
+		t.Fatalf("expected missing required field reported at frontmatter start, got %v", result.Errors)
+	}
+}
+
+func TestImportSplitsBodyIntoBlocks(t *testing.T) {
+	base := t.TempDir()
+	writeMappedFile(t, base, "en/landing.md", "price: 5\n", strings.Join([]string{
+		"Intro text.",
+		"",
+		":::hero title=\"Big sale\" count=3",
+		"Everything **must** go.",
+		":::",
+		"",
+		":::carousel",
+		":::",
+		"",
+	}, "\n"))
+
+	validator := stubBlockValidator{"hero": true, "text": true}
+	contentStub := &recordingContentService{stubContentService: newStubContentService()}
+	mapping := FieldMapping{
+		Fields: map[string]string{"price": "price"},
+		Blocks: BlockSplit{Mode: BlockSplitDirectives, Type: "text"},
+	}
+	svc := newMappingService(t, base, contentStub, mapping, validator)
+
+	_, err := svc.ImportDirectory(context.Background(), ".", interfaces.ImportOptions{ContentTypeID: productTypeID})
+	if err == nil || !strings.Contains(err.Error(), "en/landing.md:13: carousel: block type carousel is not registered") {
+		t.Fatalf("expected unregistered block reported at its line, got %v", err)
+	}
+
+	validator["carousel"] = true
+	if _, err := svc.ImportDirectory(context.Background(), ".", interfaces.ImportOptions{ContentTypeID: productTypeID}); err != nil {
+		t.Fatalf("ImportDirectory: %v", err)
+	}
+	blocks := contentStub.creates[0].Translations[0].Blocks
+	if len(blocks) != 3 || blocks[0]["_type"] != "text" || blocks[1]["_type"] != "hero" || blocks[2]["_type"] != "carousel" {
+		t.Fatalf("expected text, hero and carousel blocks, got %#v", blocks)
+	}
+	if blocks[1]["title"] != "Big sale" || blocks[1]["count"] != 3 || !strings.Contains(fmt.Sprint(blocks[1]["body"]), "<strong>must</strong>") {
+		t.Fatalf("expected directive attributes and rendered body, got %#v", blocks[1])
+	}
+
+	sections := splitHeadings("Lead.\n## One\nFirst.\n```\n## not a heading\n```\n### Sub\n## Two\n", 10, BlockSplit{Type: "section"}, "title")
+	if len(sections) != 3 || sections[1].values["title"] != "One" || sections[1].line != 11 || sections[2].line != 17 {
+		t.Fatalf("unexpected heading sections %#v", sections)
+	}
+
+	_, errs := splitDirectives(":::hero\nopen\n", 4, "")
+	if len(errs) != 1 || errs[0].line != 4 || !strings.Contains(errs[0].err.Error(), "not closed") {
+		t.Fatalf("expected unclosed directive error, got %#v", errs)
+	}
+}
+
+var productTypeID = uuid.MustParse("7b3e2f5c-1d2a-4c1b-9e8f-5a6b7c8d9e0f")
+
+func newMappingService(tb testing.TB, base string, contentSvc interfaces.ContentService, mapping FieldMapping, blocks BlockValidator) *Service {
+	tb.Helper()
+	opts := []ServiceOption{
+		WithContentService(contentSvc),
+		WithContentTypeSchemas(stubContentTypeSchemas{productTypeID: productSchema}),
+	}
+	if blocks != nil {
+		opts = append(opts, WithBlockValidator(blocks))
+	}
+	svc, err := NewService(Config{
+		BasePath:            base,
+		DefaultLocale:       "en",
+		Locales:             []string{"en"},
+		Pattern:             "*.md",
+		Recursive:           true,
+		ContentTypeMappings: map[string]FieldMapping{"product": mapping},
+	}, nil, opts...)
+	if err != nil {
+		tb.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+func writeMappedFile(tb testing.TB, base, rel, frontMatter, body string) {
+	tb.Helper()
+	target := filepath.Join(base, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		tb.Fatalf("mkdir: %v", err)
+	}
+	slug := strings.TrimSuffix(filepath.Base(rel), ".md")
+	source := "---\ntitle: " + slug + "\nslug: " + slug + "\nstatus: published\n" + frontMatter + "---\n" + body
+	if err := os.WriteFile(target, []byte(source), 0o644); err != nil {
+		tb.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+type recordingContentService struct {
+	*stubContentService
+	creates []interfaces.ContentCreateRequest
+}
+
+func (s *recordingContentService) Create(ctx context.Context, req interfaces.ContentCreateRequest) (*interfaces.ContentRecord, error) {
+	s.creates = append(s.creates, req)
+	return s.stubContentService.Create(ctx, req)
+}
+
+type stubContentTypeSchemas map[uuid.UUID]map[string]any
+
+func (s stubContentTypeSchemas) ContentTypeSchema(_ context.Context, id uuid.UUID) (string, map[string]any, error) {
+	schema, ok := s[id]
+	if !ok {
+		return "", nil, errors.New("content type not found")
+	}
+	return "product", schema, nil
+}
+
+type stubBlockValidator map[string]bool
+
+func (v stubBlockValidator) ValidateBlocks(_ context.Context, _ string, blocks []map[string]any) error {
+	for _, block := range blocks {
+		if typ, _ := block["_type"].(string); !v[typ] {
+			return fmt.Errorf("block type %s is not registered", typ)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+var (
+	ErrPageServiceRequired = errors.New("markdown importer: page service is required to create pages")
+	ErrTemplateRequired    = errors.New("markdown importer: page template could not be resolved")
+)
+
+// sectionIndexName marks a file that describes its directory (a section page).
+const sectionIndexName = "_index"
+
+// TemplateResolver maps a frontmatter template name onto a registered theme
+// template ID. Implementations return uuid.Nil when no template matches.
+type TemplateResolver interface {
+	ResolveTemplate(ctx context.Context, name string) (uuid.UUID, error)
+}
+
+// pagePlan describes the page derived from a group of localized documents.
+type pagePlan struct {
+	slug      string
+	contentID uuid.UUID
+	key       string
+	parentKey string
+	section   bool
+	depth     int
+	docs      []*interfaces.Document
+}
+
+// importPages creates or updates a page per imported slug. Pages are applied
+// parents first so children can reference the section page of their directory.
+func (i *Importer) importPages(ctx context.Context, grouped map[string][]*interfaces.Document, contentIDs map[string]uuid.UUID, opts interfaces.ImportOptions, acc *importAccumulator) error {
+	if i.pages == nil {
+		return ErrPageServiceRequired
+	}
+
+	plans := buildPagePlans(grouped, contentIDs)
+	sections := map[string]string{}
+	for _, plan := range plans {
+		if plan.section {
+			sections[plan.key] = plan.slug
+		}
+	}
+
+	pageIDs := map[string]uuid.UUID{}
+	failed := map[string]struct{}{}
+	lookup := &sectionLookup{pages: i.pages, env: opts.EnvironmentKey}
+
+	for _, plan := range plans {
+		parentID, err := i.resolvePageParent(ctx, plan, sections, pageIDs, failed, lookup)
+		if err != nil {
+			failed[plan.slug] = struct{}{}
+			acc.addError(err)
+			continue
+		}
+		id, err := i.applyPage(ctx, plan, parentID, opts, acc)
+		if err != nil {
+			failed[plan.slug] = struct{}{}
+			acc.addError(err)
+			continue
+		}
+		pageIDs[plan.slug] = id
+	}
+	return nil
+}
+
+func (i *Importer) resolvePageParent(ctx context.Context, plan pagePlan, sections map[string]string, pageIDs map[string]uuid.UUID, failed map[string]struct{}, lookup *sectionLookup) (*uuid.UUID, error) {
+	if plan.section && plan.key == "" {
+		return nil, nil
+	}
+	for key := plan.parentKey; ; key = parentKey(key) {
+		if slug, ok := sections[key]; ok && slug != plan.slug {
+			if _, broken := failed[slug]; broken {
+				return nil, fmt.Errorf("markdown importer: parent page %s for %s unavailable", slug, plan.slug)
+			}
+			if id := pageIDs[slug]; id != uuid.Nil {
+				return &id, nil
+			}
+			return nil, nil
+		}
+		id, err := lookup.find(ctx, key, plan.docs[0].Locale, plan.slug)
+		if err != nil {
+			return nil, err
+		}
+		if id != uuid.Nil {
+			return &id, nil
+		}
+		if key == "" {
+			return nil, nil
+		}
+	}
+}
+
+func (i *Importer) applyPage(ctx context.Context, plan pagePlan, parentID *uuid.UUID, opts interfaces.ImportOptions, acc *importAccumulator) (uuid.UUID, error) {
+	templateID, err := i.resolvePageTemplate(ctx, plan, opts)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	translations := pageTranslations(plan)
+
+	existing, err := i.pages.GetBySlug(ctx, plan.slug, interfaces.PageReadOptions{
+		EnvironmentKey: opts.EnvironmentKey,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("markdown importer: page lookup %s: %w", plan.slug, err)
+	}
+
+	if existing == nil {
+		if opts.DryRun || plan.contentID == uuid.Nil {
+			return uuid.Nil, nil
+		}
+		record, createErr := i.pages.Create(ctx, interfaces.PageCreateRequest{
+			ContentID:    plan.contentID,
+			TemplateID:   templateID,
+			ParentID:     parentID,
+			Slug:         plan.slug,
+			Status:       selectStatus(plan.docs),
+			CreatedBy:    opts.AuthorID,
+			UpdatedBy:    opts.AuthorID,
+			Translations: translations,
+			Metadata: map[string]any{
+				"source": "markdown",
+				"path":   plan.key,
+			},
+			AllowMissingTranslations: opts.ContentAllowMissingTranslations,
+		})
+		if createErr != nil {
+			return uuid.Nil, fmt.Errorf("markdown importer: create page %s: %w", plan.slug, createErr)
+		}
+		acc.pageCreated(record.ID)
+		return record.ID, nil
+	}
+
+	changed, err := i.pageChanged(ctx, existing, templateID, translations, opts.EnvironmentKey)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	moved := !sameParent(existing.ParentID, parentID)
+	if opts.DryRun || (!changed && !moved) {
+		return existing.ID, nil
+	}
+
+	if changed {
+		if _, err := i.pages.Update(ctx, interfaces.PageUpdateRequest{
+			ID:           existing.ID,
+			TemplateID:   &templateID,
+			Status:       selectStatus(plan.docs),
+			UpdatedBy:    opts.AuthorID,
+			Translations: translations,
+			Metadata: map[string]any{
+				"source": "markdown",
+				"path":   plan.key,
+			},
+			AllowMissingTranslations: opts.ContentAllowMissingTranslations,
+		}); err != nil {
+			return uuid.Nil, fmt.Errorf("markdown importer: update page %s: %w", plan.slug, err)
+		}
+	}
+	if moved {
+		if _, err := i.pages.Move(ctx, interfaces.PageMoveRequest{
+			PageID:      existing.ID,
+			NewParentID: parentID,
+			ActorID:     opts.AuthorID,
+		}); err != nil {
+			return uuid.Nil, fmt.Errorf("markdown importer: move page %s: %w", plan.slug, err)
+		}
+	}
+	acc.pageUpdated(existing.ID)
+	return existing.ID, nil
+}
+
+// resolvePageTemplate prefers the template named in frontmatter and falls back
+// to ImportOptions.TemplateID. A named template that cannot be found is an error.
+func (i *Importer) resolvePageTemplate(ctx context.Context, plan pagePlan, opts interfaces.ImportOptions) (uuid.UUID, error) {
+	name := ""
+	for _, doc := range plan.docs {
+		if name = strings.TrimSpace(doc.FrontMatter.Template); name != "" {
+			break
+		}
+	}
+	if name != "" && i.templates != nil {
+		id, err := i.templates.ResolveTemplate(ctx, name)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("markdown importer: resolve template %q for %s: %w", name, plan.slug, err)
+		}
+		if id == uuid.Nil {
+			return uuid.Nil, fmt.Errorf("%w: %q for %s", ErrTemplateRequired, name, plan.slug)
+		}
+		return id, nil
+	}
+	if opts.TemplateID != uuid.Nil {
+		return opts.TemplateID, nil
+	}
+	return uuid.Nil, fmt.Errorf("%w: %s", ErrTemplateRequired, plan.slug)
+}
+
+func (i *Importer) pageChanged(ctx context.Context, existing *interfaces.PageRecord, templateID uuid.UUID, inputs []interfaces.PageTranslationInput, envKey string) (bool, error) {
+	if existing.TemplateID != templateID {
+		return true, nil
+	}
+	available, err := i.pages.AvailableLocales(ctx, existing.ID, interfaces.TranslationCheckOptions{
+		Environment: envKey,
+	})
+	if err != nil {
+		return false, fmt.Errorf("markdown importer: page locales %s: %w", existing.Slug, err)
+	}
+	if len(available) != len(inputs) {
+		return true, nil
+	}
+	for _, input := range inputs {
+		record, err := i.pages.GetBySlug(ctx, existing.Slug, interfaces.PageReadOptions{
+			Locale:                   input.Locale,
+			AllowMissingTranslations: true,
+			EnvironmentKey:           envKey,
+		})
+		if err != nil {
+			return false, fmt.Errorf("markdown importer: page lookup %s (%s): %w", existing.Slug, input.Locale, err)
+		}
+		if record == nil || record.Translation.Requested == nil {
+			return true, nil
+		}
+		current := record.Translation.Requested
+		if current.Title != input.Title || current.Path != input.Path || stringValue(current.Summary) != stringValue(input.Summary) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteOrphanedPages removes pages backed by orphaned content, children first
+// so parent references never dangle.
+func (i *Importer) deleteOrphanedPages(ctx context.Context, docSlugs map[string]struct{}, orphanContent map[uuid.UUID]struct{}, opts interfaces.SyncOptions, acc *syncAccumulator) error {
+	if i.pages == nil {
+		return ErrPageServiceRequired
+	}
+	existing, err := i.pages.List(ctx, interfaces.PageReadOptions{
+		EnvironmentKey: opts.EnvironmentKey,
+	})
+	if err != nil {
+		return fmt.Errorf("markdown importer: list pages: %w", err)
+	}
+
+	parents := make(map[uuid.UUID]*uuid.UUID, len(existing))
+	var orphans []*interfaces.PageRecord
+	for _, record := range existing {
+		if record == nil {
+			continue
+		}
+		parents[record.ID] = record.ParentID
+		if _, ok := docSlugs[record.Slug]; ok {
+			continue
+		}
+		if _, ok := orphanContent[record.ContentID]; ok {
+			orphans = append(orphans, record)
+		}
+	}
+	slices.SortStableFunc(orphans, func(a, b *interfaces.PageRecord) int {
+		return pageDepth(parents, b.ID) - pageDepth(parents, a.ID)
+	})
+
+	for _, record := range orphans {
+		if !opts.DryRun {
+			if err := i.pages.Delete(ctx, interfaces.PageDeleteRequest{
+				ID:         record.ID,
+				DeletedBy:  opts.AuthorID,
+				HardDelete: true,
+			}); err != nil {
+				return fmt.Errorf("markdown importer: delete page %s: %w", record.Slug, err)
+			}
+		}
+		acc.pagesDeleted++
+	}
+	return nil
+}
+
+// sectionLookup finds existing section pages by their default path when the
+// section file is not part of the current import.
+type sectionLookup struct {
+	pages  interfaces.PageService
+	env    string
+	loaded map[string][]*interfaces.PageRecord
+}
+
+func (l *sectionLookup) find(ctx context.Context, key, locale, self string) (uuid.UUID, error) {
+	if l.loaded == nil {
+		l.loaded = map[string][]*interfaces.PageRecord{}
+	}
+	records, ok := l.loaded[locale]
+	if !ok {
+		var err error
+		records, err = l.pages.List(ctx, interfaces.PageReadOptions{
+			Locale:                   locale,
+			AllowMissingTranslations: true,
+			EnvironmentKey:           l.env,
+		})
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("markdown importer: list pages: %w", err)
+		}
+		l.loaded[locale] = records
+	}
+	target := "/" + key
+	for _, record := range records {
+		if record != nil && record.Slug != self && record.Translation.Requested != nil && record.Translation.Requested.Path == target {
+			return record.ID, nil
+		}
+	}
+	return uuid.Nil, nil
+}
+
+func buildPagePlans(grouped map[string][]*interfaces.Document, contentIDs map[string]uuid.UUID) []pagePlan {
+	plans := make([]pagePlan, 0, len(grouped))
+	for slug, docs := range grouped {
+		if slug == "" || len(docs) == 0 || docs[0] == nil {
+			continue
+		}
+		rel := localeRelativePath(docs[0])
+		dir, name := path.Split(rel)
+		dir = strings.TrimSuffix(dir, "/")
+
+		plan := pagePlan{
+			slug:      slug,
+			contentID: contentIDs[slug],
+			docs:      docs,
+		}
+		if name == sectionIndexName {
+			plan.section = true
+			plan.key = dir
+			plan.parentKey = parentKey(dir)
+		} else {
+			plan.key = rel
+			plan.parentKey = dir
+		}
+		plan.depth = pathDepth(plan.key)
+		plans = append(plans, plan)
+	}
+	slices.SortFunc(plans, func(a, b pagePlan) int {
+		if a.depth != b.depth {
+			return a.depth - b.depth
+		}
+		if a.section != b.section {
+			if a.section {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.slug, b.slug)
+	})
+	return plans
+}
+
+func pageTranslations(plan pagePlan) []interfaces.PageTranslationInput {
+	out := make([]interfaces.PageTranslationInput, 0, len(plan.docs))
+	for _, doc := range plan.docs {
+		title := strings.TrimSpace(doc.FrontMatter.Title)
+		if title == "" {
+			title = fallbackTitle(plan.slug)
+		}
+		out = append(out, interfaces.PageTranslationInput{
+			Locale:  doc.Locale,
+			Title:   title,
+			Path:    documentPagePath(doc, plan),
+			Summary: optionalString(doc.FrontMatter.Summary),
+		})
+	}
+	return out
+}
+
+// documentPagePath uses the frontmatter `path` key when present and otherwise
+// mirrors the file location relative to the locale root.
+func documentPagePath(doc *interfaces.Document, plan pagePlan) string {
+	if custom, ok := doc.FrontMatter.Custom["path"].(string); ok && strings.TrimSpace(custom) != "" {
+		return "/" + strings.Trim(strings.TrimSpace(custom), "/")
+	}
+	rel := localeRelativePath(doc)
+	if plan.section {
+		rel = strings.TrimSuffix(strings.TrimSuffix(rel, sectionIndexName), "/")
+	}
+	return "/" + rel
+}
+
+// localeRelativePath strips the locale directory, locale suffix and extension
+// from a document path: "es/blog/post.md" and "blog/post.es.md" both become
+// "blog/post".
+func localeRelativePath(doc *interfaces.Document) string {
+	rel := path.Clean(filepath.ToSlash(doc.FilePath))
+	rel = strings.TrimPrefix(rel, "./")
+	locale := strings.ToLower(strings.TrimSpace(doc.Locale))
+	if first, rest, ok := strings.Cut(rel, "/"); ok && locale != "" && strings.ToLower(first) == locale {
+		rel = rest
+	}
+	rel = strings.TrimSuffix(rel, path.Ext(rel))
+	if locale != "" && strings.HasSuffix(strings.ToLower(rel), "."+locale) {
+		rel = rel[:len(rel)-len(locale)-1]
+	}
+	return rel
+}
+
+func parentKey(key string) string {
+	parent := path.Dir(key)
+	if parent == "." || parent == "/" {
+		return ""
+	}
+	return parent
+}
+
+func pathDepth(key string) int {
+	if key == "" {
+		return 0
+	}
+	return strings.Count(key, "/") + 1
+}
+
+func pageDepth(parents map[uuid.UUID]*uuid.UUID, id uuid.UUID) int {
+	depth := 0
+	seen := map[uuid.UUID]struct{}{}
+	for current := parents[id]; current != nil; current = parents[*current] {
+		if _, loop := seen[*current]; loop {
+			break
+		}
+		seen[*current] = struct{}{}
+		depth++
+	}
+	return depth
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,344 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+func TestImportCreatesPageHierarchy(t *testing.T) {
+	base := t.TempDir()
+	writeSiteFile(t, base, "en/_index.md", "home", "Home", "")
+	writeSiteFile(t, base, "en/about.md", "about", "About", "")
+	writeSiteFile(t, base, "en/blog/_index.md", "blog", "Blog", "template: list\n")
+	writeSiteFile(t, base, "en/blog/post.md", "post", "Post", "")
+	writeSiteFile(t, base, "es/blog/post.md", "post", "Entrada", "path: /blog/entrada\n")
+
+	listTemplate := uuid.New()
+	pageStub := newStubPageService()
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en", "es"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil,
+		WithContentService(newStubContentService()),
+		WithPageService(pageStub),
+		WithTemplateResolver(stubTemplateResolver{"list": listTemplate}),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	opts := interfaces.ImportOptions{
+		ContentTypeID: uuid.New(),
+		AuthorID:      uuid.New(),
+		CreatePages:   true,
+		TemplateID:    uuid.New(),
+	}
+	result, err := svc.ImportDirectory(context.Background(), ".", opts)
+	if err != nil {
+		t.Fatalf("ImportDirectory: %v", err)
+	}
+	if len(result.CreatedPageIDs) != 4 {
+		t.Fatalf("expected four pages, got %#v", result)
+	}
+
+	home, blog, post, about := pageStub.bySlug["home"], pageStub.bySlug["blog"], pageStub.bySlug["post"], pageStub.bySlug["about"]
+	if home.ParentID != nil {
+		t.Fatalf("expected home page at the root")
+	}
+	if about.ParentID == nil || *about.ParentID != home.ID || blog.ParentID == nil || *blog.ParentID != home.ID {
+		t.Fatalf("expected about and blog nested under home")
+	}
+	if post.ParentID == nil || *post.ParentID != blog.ID {
+		t.Fatalf("expected post nested under blog section")
+	}
+	if blog.TemplateID != listTemplate || post.TemplateID != opts.TemplateID {
+		t.Fatalf("expected frontmatter template resolved and default used otherwise")
+	}
+	paths := pageStub.paths(post.ID)
+	if paths["en"] != "/blog/post" || paths["es"] != "/blog/entrada" {
+		t.Fatalf("unexpected localized paths %#v", paths)
+	}
+	if pageStub.paths(home.ID)["en"] != "/" || pageStub.paths(blog.ID)["en"] != "/blog" {
+		t.Fatalf("expected section paths derived from directories")
+	}
+
+	writeSiteFile(t, base, "en/_index.md", "home", "Home", "template: missing\n")
+	if _, err := svc.ImportDirectory(context.Background(), ".", opts); !errors.Is(err, ErrTemplateRequired) {
+		t.Fatalf("expected unresolved template error, got %v", err)
+	}
+}
+
+func TestImportReportsPageLookupErrors(t *testing.T) {
+	base := t.TempDir()
+	writeSiteFile(t, base, "en/about.md", "about", "About", "")
+
+	lookupErr := errors.New("database unavailable")
+	pageStub := newStubPageService()
+	pageStub.lookupErr = lookupErr
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil,
+		WithContentService(newStubContentService()),
+		WithPageService(pageStub),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	result, err := svc.ImportDirectory(context.Background(), ".", interfaces.ImportOptions{
+		ContentTypeID: uuid.New(),
+		AuthorID:      uuid.New(),
+		CreatePages:   true,
+		TemplateID:    uuid.New(),
+	})
+	reported := errors.Is(err, lookupErr)
+	if result != nil {
+		for _, resultErr := range result.Errors {
+			reported = reported || errors.Is(resultErr, lookupErr)
+		}
+	}
+	if !reported {
+		t.Fatalf("expected page lookup error to be reported, got %v (%#v)", err, result)
+	}
+	if len(pageStub.bySlug) != 0 {
+		t.Fatalf("expected no page created after a failed lookup, got %d", len(pageStub.bySlug))
+	}
+}
+
+func TestSyncRemovesOrphanedPages(t *testing.T) {
+	base := t.TempDir()
+	writeSiteFile(t, base, "en/docs/_index.md", "docs", "Docs", "")
+	writeSiteFile(t, base, "en/docs/guide.md", "guide", "Guide", "")
+	writeSiteFile(t, base, "en/docs/old/_index.md", "old", "Old", "")
+	writeSiteFile(t, base, "en/docs/old/legacy.md", "legacy", "Legacy", "")
+
+	pageStub := newStubPageService()
+	contentStub := newStubContentService()
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil, WithContentService(contentStub), WithPageService(pageStub))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	syncOpts := interfaces.SyncOptions{
+		ImportOptions: interfaces.ImportOptions{
+			ContentTypeID: uuid.New(),
+			AuthorID:      uuid.New(),
+			CreatePages:   true,
+			TemplateID:    uuid.New(),
+		},
+		DeleteOrphaned: true,
+		UpdateExisting: true,
+	}
+	first, err := svc.Sync(context.Background(), ".", syncOpts)
+	if err != nil {
+		t.Fatalf("initial Sync: %v", err)
+	}
+	if first.PagesCreated != 4 {
+		t.Fatalf("expected four pages created, got %#v", first)
+	}
+
+	again, err := svc.Sync(context.Background(), ".", syncOpts)
+	if err != nil {
+		t.Fatalf("repeat Sync: %v", err)
+	}
+	if again.PagesCreated != 0 || again.PagesUpdated != 0 || again.PagesDeleted != 0 {
+		t.Fatalf("expected repeat sync to leave pages untouched, got %#v", again)
+	}
+
+	if err := os.RemoveAll(filepath.Join(base, "en", "docs", "old")); err != nil {
+		t.Fatalf("remove section: %v", err)
+	}
+	result, err := svc.Sync(context.Background(), ".", syncOpts)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if result.PagesDeleted != 2 || result.Deleted != 2 {
+		t.Fatalf("expected section and child removed, got %#v", result)
+	}
+	if _, ok := pageStub.bySlug["legacy"]; ok {
+		t.Fatalf("expected orphaned page removed")
+	}
+	if got := pageStub.deleteOrder; len(got) != 2 || got[0] != "legacy" || got[1] != "old" {
+		t.Fatalf("expected children deleted before parents, got %v", got)
+	}
+	if _, ok := pageStub.bySlug["guide"]; !ok {
+		t.Fatalf("expected remaining pages kept")
+	}
+}
+
+func writeSiteFile(tb testing.TB, base, rel, slug, title, extra string) {
+	tb.Helper()
+	target := filepath.Join(base, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		tb.Fatalf("mkdir: %v", err)
+	}
+	source := "---\ntitle: " + title + "\nslug: " + slug + "\nstatus: published\n" + extra + "---\n\n# " + title + "\n"
+	if err := os.WriteFile(target, []byte(source), 0o644); err != nil {
+		tb.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+type stubTemplateResolver map[string]uuid.UUID
+
+func (r stubTemplateResolver) ResolveTemplate(_ context.Context, name string) (uuid.UUID, error) {
+	return r[name], nil
+}
+
+type stubPageService struct {
+	bySlug      map[string]*interfaces.PageRecord
+	translation map[uuid.UUID][]interfaces.PageTranslation
+	deleteOrder []string
+	lookupErr   error
+}
+
+func newStubPageService() *stubPageService {
+	return &stubPageService{
+		bySlug:      map[string]*interfaces.PageRecord{},
+		translation: map[uuid.UUID][]interfaces.PageTranslation{},
+	}
+}
+
+func (s *stubPageService) paths(id uuid.UUID) map[string]string {
+	out := map[string]string{}
+	for _, tr := range s.translation[id] {
+		out[tr.Locale] = tr.Path
+	}
+	return out
+}
+
+func (s *stubPageService) setTranslations(id uuid.UUID, inputs []interfaces.PageTranslationInput) {
+	translations := make([]interfaces.PageTranslation, 0, len(inputs))
+	for _, in := range inputs {
+		translations = append(translations, interfaces.PageTranslation{ID: uuid.New(), Locale: in.Locale, Title: in.Title, Path: in.Path, Summary: in.Summary})
+	}
+	s.translation[id] = translations
+}
+
+func (s *stubPageService) read(record *interfaces.PageRecord, locale string) *interfaces.PageRecord {
+	out := *record
+	out.Translation = interfaces.TranslationBundle[interfaces.PageTranslation]{}
+	for _, tr := range s.translation[record.ID] {
+		if locale != "" && strings.EqualFold(tr.Locale, locale) {
+			copyTr := tr
+			out.Translation.Requested = &copyTr
+			out.Translation.Resolved = &copyTr
+		}
+	}
+	return &out
+}
+
+func (s *stubPageService) Create(_ context.Context, req interfaces.PageCreateRequest) (*interfaces.PageRecord, error) {
+	record := &interfaces.PageRecord{
+		ID:         uuid.New(),
+		ContentID:  req.ContentID,
+		TemplateID: req.TemplateID,
+		ParentID:   req.ParentID,
+		Slug:       req.Slug,
+		Status:     req.Status,
+		Metadata:   req.Metadata,
+	}
+	s.bySlug[req.Slug] = record
+	s.setTranslations(record.ID, req.Translations)
+	return s.read(record, ""), nil
+}
+
+func (s *stubPageService) Update(_ context.Context, req interfaces.PageUpdateRequest) (*interfaces.PageRecord, error) {
+	for _, record := range s.bySlug {
+		if record.ID != req.ID {
+			continue
+		}
+		if req.TemplateID != nil {
+			record.TemplateID = *req.TemplateID
+		}
+		record.Status = req.Status
+		s.setTranslations(record.ID, req.Translations)
+		return s.read(record, ""), nil
+	}
+	return nil, errors.New("page not found")
+}
+
+func (s *stubPageService) GetBySlug(_ context.Context, slug string, opts interfaces.PageReadOptions) (*interfaces.PageRecord, error) {
+	if s.lookupErr != nil {
+		return nil, s.lookupErr
+	}
+	record, ok := s.bySlug[slug]
+	if !ok {
+		return nil, nil
+	}
+	return s.read(record, opts.Locale), nil
+}
+
+func (s *stubPageService) List(_ context.Context, opts interfaces.PageReadOptions) ([]*interfaces.PageRecord, error) {
+	out := make([]*interfaces.PageRecord, 0, len(s.bySlug))
+	for _, record := range s.bySlug {
+		out = append(out, s.read(record, opts.Locale))
+	}
+	return out, nil
+}
+
+func (s *stubPageService) CheckTranslations(context.Context, uuid.UUID, []string, interfaces.TranslationCheckOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubPageService) AvailableLocales(_ context.Context, id uuid.UUID, _ interfaces.TranslationCheckOptions) ([]string, error) {
+	locales := []string{}
+	for _, tr := range s.translation[id] {
+		locales = append(locales, tr.Locale)
+	}
+	return locales, nil
+}
+
+func (s *stubPageService) Delete(_ context.Context, req interfaces.PageDeleteRequest) error {
+	for slug, record := range s.bySlug {
+		if record.ID == req.ID {
+			delete(s.bySlug, slug)
+			delete(s.translation, record.ID)
+			s.deleteOrder = append(s.deleteOrder, slug)
+			return nil
+		}
+	}
+	return errors.New("page not found")
+}
+
+func (s *stubPageService) UpdateTranslation(context.Context, interfaces.PageUpdateTranslationRequest) (*interfaces.PageTranslation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *stubPageService) DeleteTranslation(context.Context, interfaces.PageDeleteTranslationRequest) error {
+	return errors.New("not implemented")
+}
+
+func (s *stubPageService) Move(_ context.Context, req interfaces.PageMoveRequest) (*interfaces.PageRecord, error) {
+	for _, record := range s.bySlug {
+		if record.ID == req.PageID {
+			record.ParentID = req.NewParentID
+			return s.read(record, ""), nil
+		}
+	}
+	return nil, errors.New("page not found")
+}
+
+func (s *stubPageService) Duplicate(context.Context, interfaces.PageDuplicateRequest) (*interfaces.PageRecord, error) {
+	return nil, errors.New("not implemented")
+}
@@ -15,15 +15,22 @@ import (
 )
 
 // Config controls how the Markdown service discovers and parses files.
+// ContentTypeMappings maps content type IDs or slugs to the rules used to
+// route frontmatter and body content onto typed schema fields. AssetLinkMode
+// and AssetBindingKey control how local images are rewritten and bound when a
+// MediaUploader is configured.
 type Config struct {
-	BasePath          string
-	DefaultLocale     string
-	Locales           []string
-	LocalePatterns    map[string]string
-	Pattern           string
-	Recursive         bool
-	Parser            interfaces.ParseOptions
-	ProcessShortcodes bool
+	BasePath            string
+	DefaultLocale       string
+	Locales             []string
+	LocalePatterns      map[string]string
+	Pattern             string
+	Recursive           bool
+	Parser              interfaces.ParseOptions
+	ProcessShortcodes   bool
+	ContentTypeMappings map[string]FieldMapping
+	AssetLinkMode       string
+	AssetBindingKey     string
 }
 
 // Service implements interfaces.MarkdownService for filesystem-backed documents.
@@ -32,8 +39,14 @@ type Service struct {
 	parser     interfaces.MarkdownParser
 	loader     *Loader
 	content    interfaces.ContentService
+	pages      interfaces.PageService
+	templates  TemplateResolver
+	schemas    ContentTypeSchemas
+	blocks     BlockValidator
+	media      MediaUploader
 	logger     interfaces.Logger
 	importer   *Importer
+	exporter   *Exporter
 	shortcodes interfaces.ShortcodeService
 }
 
@@ -47,6 +60,45 @@ func WithContentService(svc interfaces.ContentService) ServiceOption {
 	}
 }
 
+// WithPageService wires the page service used when imports create pages.
+func WithPageService(svc interfaces.PageService) ServiceOption {
+	return func(s *Service) {
+		s.pages = svc
+	}
+}
+
+// WithTemplateResolver wires the lookup used to map frontmatter templates onto
+// registered theme templates.
+func WithTemplateResolver(resolver TemplateResolver) ServiceOption {
+	return func(s *Service) {
+		s.templates = resolver
+	}
+}
+
+// WithContentTypeSchemas wires the lookup used to match field mappings and
+// validate mapped fields against content type schemas.
+func WithContentTypeSchemas(schemas ContentTypeSchemas) ServiceOption {
+	return func(s *Service) {
+		s.schemas = schemas
+	}
+}
+
+// WithBlockValidator wires the validator applied to blocks split from
+// document bodies.
+func WithBlockValidator(validator BlockValidator) ServiceOption {
+	return func(s *Service) {
+		s.blocks = validator
+	}
+}
+
+// WithMediaUploader wires the uploader used to import local images referenced
+// from documents into the media library.
+func WithMediaUploader(uploader MediaUploader) ServiceOption {
+	return func(s *Service) {
+		s.media = uploader
+	}
+}
+
 // WithLogger attaches a logger for importer diagnostics.
 func WithLogger(logger interfaces.Logger) ServiceOption {
 	return func(s *Service) {
@@ -97,8 +149,25 @@ func NewService(cfg Config, parser interfaces.MarkdownParser, opts ...ServiceOpt
 	}
 
 	svc.importer = NewImporter(ImporterConfig{
-		Content: svc.content,
-		Logger:  svc.logger,
+		Content:         svc.content,
+		Pages:           svc.pages,
+		Templates:       svc.templates,
+		Mappings:        cfg.ContentTypeMappings,
+		Schemas:         svc.schemas,
+		Blocks:          svc.blocks,
+		Renderer:        svc,
+		Media:           svc.media,
+		Assets:          filesystem,
+		AssetLinkMode:   cfg.AssetLinkMode,
+		AssetBindingKey: cfg.AssetBindingKey,
+		Logger:          svc.logger,
+	})
+	svc.exporter = NewExporter(ExporterConfig{
+		Content:        svc.content,
+		Logger:         svc.logger,
+		BasePath:       cfg.BasePath,
+		DefaultLocale:  cfg.DefaultLocale,
+		LocalePatterns: cfg.LocalePatterns,
 	})
 
 	return svc, nil
@@ -180,7 +249,8 @@ func (s *Service) Import(ctx context.Context, doc *interfaces.Document, opts int
 	if err := s.renderDocument(ctx, doc, interfaces.ParseOptions{
 		ProcessShortcodes: opts.ProcessShortcodes,
 		ShortcodeOptions: interfaces.ShortcodeProcessOptions{
-			Locale: doc.Locale,
+			Locale:  doc.Locale,
+			Trusted: true,
 		},
 	}); err != nil {
 		logging.WithFields(logger, map[string]any{
@@ -194,7 +264,7 @@ func (s *Service) Import(ctx context.Context, doc *interfaces.Document, opts int
 		logging.WithFields(logger, map[string]any{
 			"error": err,
 		}).Error("markdown.service.import.failed")
-		return nil, err
+		return result, err
 	}
 
 	logging.WithFields(logger, map[string]any{
@@ -230,7 +300,7 @@ func (s *Service) ImportDirectory(ctx context.Context, dir string, opts interfac
 		logging.WithFields(logger, map[string]any{
 			"error": err,
 		}).Error("markdown.service.import_directory.failed")
-		return nil, err
+		return result, err
 	}
 
 	logging.WithFields(logger, map[string]any{
@@ -267,7 +337,7 @@ func (s *Service) Sync(ctx context.Context, dir string, opts interfaces.SyncOpti
 		logging.WithFields(logger, map[string]any{
 			"error": err,
 		}).Error("markdown.service.sync.failed")
-		return nil, err
+		return result, err
 	}
 
 	logging.WithFields(logger, map[string]any{
@@ -284,6 +354,54 @@ func (s *Service) Sync(ctx context.Context, dir string, opts interfaces.SyncOpti
 	return result, nil
 }
 
+// Export writes the content identified by slug to markdown files under dir.
+func (s *Service) Export(ctx context.Context, slug string, dir string, opts interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	if s.exporter == nil {
+		return nil, errors.New("markdown service: exporter not configured")
+	}
+	logger := logging.WithMarkdownContext(s.logger, dir, "", "export")
+	result, err := s.exporter.ExportSlug(ctx, strings.TrimSpace(slug), s.normalisePath(dir), opts)
+	if err != nil {
+		logging.WithFields(logger, map[string]any{
+			"slug":  slug,
+			"error": err,
+		}).Error("markdown.service.export.failed")
+		return result, err
+	}
+
+	logging.WithFields(logger, map[string]any{
+		"slug":      slug,
+		"written":   len(result.Written),
+		"unchanged": len(result.Unchanged),
+		"dry_run":   opts.DryRun,
+	}).Info("markdown.service.export.completed")
+
+	return result, nil
+}
+
+// ExportDirectory writes every content entry matching opts to markdown files under dir.
+func (s *Service) ExportDirectory(ctx context.Context, dir string, opts interfaces.ExportOptions) (*interfaces.ExportResult, error) {
+	if s.exporter == nil {
+		return nil, errors.New("markdown service: exporter not configured")
+	}
+	logger := logging.WithMarkdownContext(s.logger, dir, "", "export_directory")
+	result, err := s.exporter.ExportAll(ctx, s.normalisePath(dir), opts)
+	if err != nil {
+		logging.WithFields(logger, map[string]any{
+			"error": err,
+		}).Error("markdown.service.export_directory.failed")
+		return result, err
+	}
+
+	logging.WithFields(logger, map[string]any{
+		"written":   len(result.Written),
+		"unchanged": len(result.Unchanged),
+		"dry_run":   opts.DryRun,
+	}).Info("markdown.service.export_directory.completed")
+
+	return result, nil
+}
+
 func (s *Service) renderDocument(ctx context.Context, doc *interfaces.Document, overrides interfaces.ParseOptions) error {
 	if doc == nil {
 		return nil
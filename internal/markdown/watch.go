@@ -0,0 +1,298 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+const (
+	defaultWatchInterval = time.Second
+	defaultWatchDebounce = 300 * time.Millisecond
+)
+
+// File change operations reported by Watch.
+const (
+	FileAdded    = "added"
+	FileModified = "modified"
+	FileDeleted  = "deleted"
+)
+
+// PageBuilder rebuilds static output for a page, typically generator.Service.
+type PageBuilder interface {
+	BuildPage(ctx context.Context, pageID uuid.UUID, locale string) error
+}
+
+// WatchOptions configures polling-based incremental sync of a directory.
+// Interval controls how often file stamps are polled and Debounce how long
+// changes must settle before a sync runs. Sync.DeleteOrphaned only removes
+// content whose files were all deleted while watching.
+type WatchOptions struct {
+	Sync     interfaces.SyncOptions
+	Interval time.Duration
+	Debounce time.Duration
+	// Builder, when set, rebuilds the pages of every affected slug.
+	Builder PageBuilder
+	// OnChange receives a report after the initial sync and every
+	// incremental sync.
+	OnChange func(WatchReport)
+}
+
+// FileChange describes one file that changed between syncs.
+type FileChange struct {
+	Path string
+	Op   string
+	Slug string
+}
+
+// WatchReport summarises one sync triggered by Watch.
+type WatchReport struct {
+	Initial    bool
+	StartedAt  time.Time
+	Duration   time.Duration
+	Changes    []FileChange
+	Slugs      []string
+	Result     *interfaces.SyncResult
+	BuiltPages []uuid.UUID
+	Errors     []error
+}
+
+// watchState tracks file stamps and document checksums between polls.
+type watchState struct {
+	stamps    map[string]FileStamp
+	checksums map[string]string
+	slugs     map[string]string
+}
+
+// Watch syncs dir once and then polls it until ctx is cancelled, re-importing
+// only the translation groups touched by added, modified or deleted files.
+func (s *Service) Watch(ctx context.Context, dir string, opts WatchOptions) error {
+	if s.importer == nil {
+		return errors.New("markdown service: importer not configured")
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = defaultWatchDebounce
+	}
+	logger := logging.WithMarkdownContext(s.logger, dir, "", "watch")
+	params := LoadParams{}
+
+	state := &watchState{}
+	stamps, err := s.loader.Scan(ctx, s.normalisePath(dir), params)
+	if err != nil {
+		return err
+	}
+	initial, err := s.watchSync(ctx, dir, state, opts, true)
+	if err != nil {
+		return err
+	}
+	state.stamps = stamps
+	s.reportWatch(logger, opts, initial)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastChange time.Time
+	pending := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := s.loader.Scan(ctx, s.normalisePath(dir), params)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logging.WithFields(logger, map[string]any{"error": err}).Error("markdown.service.watch.scan_failed")
+			continue
+		}
+		if !sameStamps(state.stamps, current) {
+			state.stamps = current
+			lastChange = time.Now()
+			pending = true
+			continue
+		}
+		if !pending || time.Since(lastChange) < debounce {
+			continue
+		}
+		pending = false
+
+		report, err := s.watchSync(ctx, dir, state, opts, false)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			report.Errors = append(report.Errors, err)
+		}
+		if len(report.Changes) > 0 || len(report.Errors) > 0 {
+			s.reportWatch(logger, opts, report)
+		}
+	}
+}
+
+// watchSync loads the directory, diffs document checksums against the last
+// sync and re-imports the affected slugs. The initial sync imports every group.
+func (s *Service) watchSync(ctx context.Context, dir string, state *watchState, opts WatchOptions, initial bool) (report WatchReport, err error) {
+	report = WatchReport{Initial: initial, StartedAt: time.Now()}
+	defer func() { report.Duration = time.Since(report.StartedAt) }()
+
+	docs, err := s.LoadDirectory(ctx, dir, interfaces.LoadOptions{
+		Parser: interfaces.ParseOptions{ProcessShortcodes: opts.Sync.ProcessShortcodes},
+	})
+	if err != nil {
+		return report, err
+	}
+
+	checksums := make(map[string]string, len(docs))
+	slugs := make(map[string]string, len(docs))
+	grouped := map[string][]*interfaces.Document{}
+	for _, doc := range docs {
+		checksums[doc.FilePath] = fmt.Sprintf("%x", doc.Checksum)
+		slugs[doc.FilePath] = groupKey(doc)
+		grouped[groupKey(doc)] = append(grouped[groupKey(doc)], doc)
+	}
+
+	affected := map[string]struct{}{}
+	if initial {
+		for slug := range grouped {
+			affected[slug] = struct{}{}
+		}
+	} else {
+		report.Changes = diffChecksums(state, checksums, slugs)
+		for _, change := range report.Changes {
+			affected[change.Slug] = struct{}{}
+			if previous, ok := state.slugs[change.Path]; ok {
+				affected[previous] = struct{}{}
+			}
+		}
+	}
+	state.checksums, state.slugs = checksums, slugs
+	if len(affected) == 0 {
+		return report, nil
+	}
+
+	groups := make(map[string][]*interfaces.Document, len(affected))
+	for slug := range affected {
+		report.Slugs = append(report.Slugs, slug)
+		groups[slug] = grouped[slug]
+	}
+	slices.Sort(report.Slugs)
+
+	result, err := s.importer.SyncGroups(ctx, groups, opts.Sync)
+	report.Result = result
+	if result != nil {
+		report.Errors = append(report.Errors, result.Errors...)
+	} else if err != nil {
+		report.Errors = append(report.Errors, err)
+	}
+
+	if opts.Builder != nil && !opts.Sync.DryRun {
+		s.buildWatchedPages(ctx, groups, opts, &report)
+	}
+	return report, nil
+}
+
+// diffChecksums compares freshly loaded checksums with the previous sync so
+// touched-but-unchanged files are ignored.
+func diffChecksums(state *watchState, checksums, slugs map[string]string) []FileChange {
+	var changes []FileChange
+	for path, checksum := range checksums {
+		previous, ok := state.checksums[path]
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Op: FileAdded, Slug: slugs[path]})
+		case previous != checksum:
+			changes = append(changes, FileChange{Path: path, Op: FileModified, Slug: slugs[path]})
+		}
+	}
+	for path := range state.checksums {
+		if _, ok := checksums[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Op: FileDeleted, Slug: state.slugs[path]})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FileChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return changes
+}
+
+// buildWatchedPages rebuilds each locale of the pages matching affected slugs.
+func (s *Service) buildWatchedPages(ctx context.Context, groups map[string][]*interfaces.Document, opts WatchOptions, report *WatchReport) {
+	if s.pages == nil {
+		report.Errors = append(report.Errors, ErrPageServiceRequired)
+		return
+	}
+	for _, slug := range report.Slugs {
+		docs := groups[slug]
+		if len(docs) == 0 {
+			continue
+		}
+		page, err := s.pages.GetBySlug(ctx, slug, interfaces.PageReadOptions{
+			EnvironmentKey:           opts.Sync.EnvironmentKey,
+			AllowMissingTranslations: true,
+		})
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("markdown watch: page lookup %s: %w", slug, err))
+			continue
+		}
+		if page == nil {
+			continue
+		}
+		for _, doc := range docs {
+			if err := opts.Builder.BuildPage(ctx, page.ID, doc.Locale); err != nil {
+				report.Errors = append(report.Errors, fmt.Errorf("markdown watch: build page %s (%s): %w", slug, doc.Locale, err))
+				continue
+			}
+		}
+		report.BuiltPages = append(report.BuiltPages, page.ID)
+	}
+}
+
+func (s *Service) reportWatch(logger interfaces.Logger, opts WatchOptions, report WatchReport) {
+	fields := map[string]any{
+		"initial":     report.Initial,
+		"changes":     len(report.Changes),
+		"slugs":       report.Slugs,
+		"built_pages": len(report.BuiltPages),
+		"errors":      len(report.Errors),
+		"duration_ms": report.Duration.Milliseconds(),
+	}
+	if report.Result != nil {
+		fields["created"] = report.Result.Created
+		fields["updated"] = report.Result.Updated
+		fields["deleted"] = report.Result.Deleted
+		fields["skipped"] = report.Result.Skipped
+	}
+	logging.WithFields(logger, fields).Info("markdown.service.watch.synced")
+	if opts.OnChange != nil {
+		opts.OnChange(report)
+	}
+}
+
+func sameStamps(a, b map[string]FileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		other, ok := b[path]
+		if !ok || !other.ModTime.Equal(stamp.ModTime) || other.Size != stamp.Size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package markdown
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+func TestWatchReimportsOnlyChangedGroups(t *testing.T) {
+	base := t.TempDir()
+	writeSiteFile(t, base, "en/alpha.md", "alpha", "Alpha", "")
+	writeSiteFile(t, base, "en/beta.md", "beta", "Beta", "")
+	writeSiteFile(t, base, "es/beta.md", "beta", "Beta ES", "")
+
+	pageStub := newStubPageService()
+	svc, err := NewService(Config{
+		BasePath:      base,
+		DefaultLocale: "en",
+		Locales:       []string{"en", "es"},
+		Pattern:       "*.md",
+		Recursive:     true,
+	}, nil, WithContentService(newStubContentService()), WithPageService(pageStub))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	builder := &stubPageBuilder{}
+	reports := make(chan WatchReport, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.Watch(ctx, ".", WatchOptions{
+			Sync: interfaces.SyncOptions{
+				ImportOptions: interfaces.ImportOptions{
+					ContentTypeID: uuid.New(),
+					AuthorID:      uuid.New(),
+					CreatePages:   true,
+					TemplateID:    uuid.New(),
+				},
+				DeleteOrphaned: true,
+				UpdateExisting: true,
+			},
+			Interval: 5 * time.Millisecond,
+			Debounce: 15 * time.Millisecond,
+			Builder:  builder,
+			OnChange: func(report WatchReport) { reports <- report },
+		})
+	}()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("Watch: %v", err)
+		}
+	}()
+
+	initial := nextReport(t, reports)
+	if !initial.Initial || initial.Result.Created != 2 || len(initial.BuiltPages) != 2 {
+		t.Fatalf("expected initial sync of both groups, got %#v", initial)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(base, "en", "alpha.md"), future, future); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+	writeSiteFile(t, base, "es/beta.md", "beta", "Beta actualizada", "")
+
+	changed := nextReport(t, reports)
+	if len(changed.Changes) != 1 || changed.Changes[0].Path != "es/beta.md" || changed.Changes[0].Op != FileModified {
+		t.Fatalf("expected only the edited file reported, got %#v", changed.Changes)
+	}
+	if len(changed.Slugs) != 1 || changed.Slugs[0] != "beta" || changed.Result.Updated != 1 {
+		t.Fatalf("expected beta group re-imported, got %#v", changed)
+	}
+	if builder.builds["es"] != 2 || builder.builds["en"] != 3 {
+		t.Fatalf("expected beta rebuilt for both locales, got %v", builder.builds)
+	}
+
+	if err := os.Remove(filepath.Join(base, "en", "alpha.md")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	removed := nextReport(t, reports)
+	if len(removed.Changes) != 1 || removed.Changes[0].Op != FileDeleted || removed.Changes[0].Slug != "alpha" {
+		t.Fatalf("expected alpha deletion reported, got %#v", removed.Changes)
+	}
+	if removed.Result.Deleted != 1 || removed.Result.PagesDeleted != 1 {
+		t.Fatalf("expected alpha content and page removed, got %#v", removed.Result)
+	}
+	if _, ok := pageStub.bySlug["beta"]; !ok {
+		t.Fatalf("expected untouched groups kept")
+	}
+}
+
+func nextReport(tb testing.TB, reports <-chan WatchReport) WatchReport {
+	tb.Helper()
+	select {
+	case report := <-reports:
+		return report
+	case <-time.After(5 * time.Second):
+		tb.Fatalf("timed out waiting for watch report")
+		return WatchReport{}
+	}
+}
+
+type stubPageBuilder struct {
+	builds map[string]int
+}
+
+func (b *stubPageBuilder) BuildPage(_ context.Context, _ uuid.UUID, locale string) error {
+	if b.builds == nil {
+		b.builds = map[string]int{}
+	}
+	b.builds[locale]++
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 )
 
 var (
@@ -58,9 +59,20 @@ func WithDefaultCacheTTL(ttl time.Duration) ServiceOption {
 	}
 }
 
+// WithLifecycleEmitter wires the emitter notified with a "media" event for
+// each reference passed to Invalidate.
+func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
+	return func(s *service) {
+		if emitter != nil {
+			s.lifecycle = emitter
+		}
+	}
+}
+
 type service struct {
 	provider            interfaces.MediaProvider
 	cache               interfaces.CacheProvider
+	lifecycle           *lifecycle.Emitter
 	defaultCacheTTL     time.Duration
 	defaultSignedURLTTL time.Duration
 }
@@ -69,6 +81,7 @@ type service struct {
 func NewService(provider interfaces.MediaProvider, opts ...ServiceOption) Service {
 	s := &service{
 		provider:            provider,
+		lifecycle:           lifecycle.NewEmitter(nil, lifecycle.Config{}),
 		defaultCacheTTL:     5 * time.Minute,
 		defaultSignedURLTTL: 15 * time.Minute,
 	}
@@ -126,7 +139,35 @@ func (s *service) Invalidate(ctx context.Context, bindings BindingSet) error {
 	if len(refs) == 0 {
 		return nil
 	}
-	return s.provider.Invalidate(ctx, refs...)
+	if err := s.provider.Invalidate(ctx, refs...); err != nil {
+		return err
+	}
+	s.emitInvalidated(ctx, refs)
+	return nil
+}
+
+// emitInvalidated notifies lifecycle hooks once per invalidated media ID.
+func (s *service) emitInvalidated(ctx context.Context, refs []interfaces.MediaReference) {
+	if s.lifecycle == nil || !s.lifecycle.Enabled() {
+		return
+	}
+	seen := make(map[string]struct{}, len(refs))
+	for _, ref := range refs {
+		id := strings.TrimSpace(ref.ID)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		_ = s.lifecycle.Emit(ctx, lifecycle.Event{
+			ResourceType: "media",
+			RecordID:     id,
+			Transition:   "invalidate",
+			Locale:       ref.Locale,
+		})
+	}
 }
 
 func (s *service) resolveBinding(ctx context.Context, binding Binding, opts ResolveOptions) (*Attachment, error) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/goliatone/go-cms/internal/media"
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 )
 
 func TestServiceResolveBindings(t *testing.T) {
@@ -147,6 +148,27 @@ func TestServiceInvalidateClearsCache(t *testing.T) {
 	}
 }
 
+func TestServiceInvalidateEmitsLifecycleEvents(t *testing.T) {
+	provider := &stubProvider{}
+	hook := &lifecycle.CaptureHook{}
+	svc := media.NewService(provider, media.WithLifecycleEmitter(lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})))
+	bindings := media.BindingSet{
+		"hero":    {{Slot: "hero", Reference: interfaces.MediaReference{ID: "asset-1"}}},
+		"gallery": {{Slot: "gallery", Reference: interfaces.MediaReference{ID: "asset-1"}}, {Slot: "gallery", Reference: interfaces.MediaReference{Path: "/uploads/a.png"}}},
+	}
+
+	if err := svc.Invalidate(context.Background(), bindings); err != nil {
+		t.Fatalf("invalidate: %v", err)
+	}
+	if len(hook.Events) != 1 {
+		t.Fatalf("expected one event per media id, got %+v", hook.Events)
+	}
+	event := hook.Events[0]
+	if event.ResourceType != "media" || event.RecordID != "asset-1" || event.Transition != "invalidate" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
 type stubProvider struct {
 	assets      map[string]*interfaces.MediaAsset
 	resolves    map[string]int
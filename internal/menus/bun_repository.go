@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
 	goerrors "github.com/goliatone/go-errors"
 	repository "github.com/goliatone/go-repository-bun"
 	cache "github.com/goliatone/go-repository-cache/cache"
@@ -76,31 +77,31 @@ func (r *BunMenuRepository) GetByCode(ctx context.Context, code string, env ...s
 		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
 			return applyEnvironmentFilter(q, normalizedEnv)
 		}),
-		repository.SelectPaginate(1, 0),
 	)
 	if err != nil {
 		return nil, mapRepositoryError(err, "menu", code)
 	}
-	if len(records) == 0 {
+	record, ok := cmssites.Select(ctx, records, menuSiteID)
+	if !ok {
 		return nil, &NotFoundError{Resource: "menu", Key: code}
 	}
-	return records[0], nil
+	return record, nil
 }
 
 func (r *BunMenuRepository) GetByLocation(ctx context.Context, location string, env ...string) (*Menu, error) {
 	if r == nil || r.db == nil {
 		return nil, &NotFoundError{Resource: "menu", Key: location}
 	}
-	record := new(Menu)
-	q := r.db.NewSelect().Model(record).Where("location = ?", location).Limit(1)
+	var records []*Menu
+	q := r.db.NewSelect().Model(&records).Where("location = ?", location)
 	q = applyEnvironmentFilter(q, normalizeEnvironmentKey(env...))
-	err := q.Scan(ctx)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, &NotFoundError{Resource: "menu", Key: location}
-		}
+	if err := q.Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, mapRepositoryError(err, "menu", location)
 	}
+	record, ok := cmssites.Select(ctx, records, menuSiteID)
+	if !ok {
+		return nil, &NotFoundError{Resource: "menu", Key: location}
+	}
 	return record, nil
 }
 
@@ -8,22 +8,23 @@ import (
 	"sync"
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
 	"github.com/google/uuid"
 )
 
 type memoryMenuRepository struct {
 	mu         sync.RWMutex
 	byID       map[uuid.UUID]*Menu
-	byCode     map[string]uuid.UUID
-	byLocation map[string]uuid.UUID
+	byCode     map[string][]uuid.UUID
+	byLocation map[string][]uuid.UUID
 }
 
 // NewMemoryMenuRepository constructs an in-memory repository for menus
 func NewMemoryMenuRepository() MenuRepository {
 	return &memoryMenuRepository{
 		byID:       make(map[uuid.UUID]*Menu),
-		byCode:     make(map[string]uuid.UUID),
-		byLocation: make(map[string]uuid.UUID),
+		byCode:     make(map[string][]uuid.UUID),
+		byLocation: make(map[string][]uuid.UUID),
 	}
 }
 
@@ -34,12 +35,7 @@ func (m *memoryMenuRepository) Create(_ context.Context, menu *Menu) (*Menu, err
 	cloned := cloneMenu(menu)
 	cloned.EnvironmentID = resolveEnvironmentID(cloned.EnvironmentID, "")
 	m.byID[cloned.ID] = cloned
-	if cloned.Code != "" {
-		m.byCode[menuCodeKey(cloned.EnvironmentID, cloned.Code)] = cloned.ID
-	}
-	if cloned.Location != "" {
-		m.byLocation[menuLocationKey(cloned.EnvironmentID, cloned.Location)] = cloned.ID
-	}
+	m.index(cloned)
 	return cloneMenu(cloned), nil
 }
 
@@ -54,28 +50,63 @@ func (m *memoryMenuRepository) GetByID(_ context.Context, id uuid.UUID) (*Menu,
 	return cloneMenu(record), nil
 }
 
-func (m *memoryMenuRepository) GetByCode(_ context.Context, code string, env ...string) (*Menu, error) {
+func (m *memoryMenuRepository) GetByCode(ctx context.Context, code string, env ...string) (*Menu, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	envID := resolveEnvironmentID(uuid.Nil, resolveEnvironmentKey(env...))
-	id, ok := m.byCode[menuCodeKey(envID, code)]
+	record, ok := m.selectForSite(ctx, m.byCode[menuCodeKey(envID, code)])
 	if !ok {
 		return nil, &NotFoundError{Resource: "menu", Key: code}
 	}
-	return cloneMenu(m.byID[id]), nil
+	return cloneMenu(record), nil
 }
 
-func (m *memoryMenuRepository) GetByLocation(_ context.Context, location string, env ...string) (*Menu, error) {
+func (m *memoryMenuRepository) GetByLocation(ctx context.Context, location string, env ...string) (*Menu, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	envID := resolveEnvironmentID(uuid.Nil, resolveEnvironmentKey(env...))
-	id, ok := m.byLocation[menuLocationKey(envID, location)]
+	record, ok := m.selectForSite(ctx, m.byLocation[menuLocationKey(envID, location)])
 	if !ok {
 		return nil, &NotFoundError{Resource: "menu", Key: location}
 	}
-	return cloneMenu(m.byID[id]), nil
+	return cloneMenu(record), nil
+}
+
+// selectForSite picks the menu visible to the site carried by ctx from menus
+// sharing a code or location.
+func (m *memoryMenuRepository) selectForSite(ctx context.Context, ids []uuid.UUID) (*Menu, bool) {
+	records := make([]*Menu, 0, len(ids))
+	for _, id := range ids {
+		if record := m.byID[id]; record != nil {
+			records = append(records, record)
+		}
+	}
+	return cmssites.Select(ctx, records, menuSiteID)
+}
+
+func (m *memoryMenuRepository) index(menu *Menu) {
+	if menu.Code != "" {
+		key := menuCodeKey(menu.EnvironmentID, menu.Code)
+		m.byCode[key] = appendUniqueUUID(m.byCode[key], menu.ID)
+	}
+	if menu.Location != "" {
+		key := menuLocationKey(menu.EnvironmentID, menu.Location)
+		m.byLocation[key] = appendUniqueUUID(m.byLocation[key], menu.ID)
+	}
+}
+
+func (m *memoryMenuRepository) unindex(menu *Menu) {
+	envID := resolveEnvironmentID(menu.EnvironmentID, "")
+	if menu.Code != "" {
+		key := menuCodeKey(envID, menu.Code)
+		m.byCode[key] = removeUUID(m.byCode[key], menu.ID)
+	}
+	if menu.Location != "" {
+		key := menuLocationKey(envID, menu.Location)
+		m.byLocation[key] = removeUUID(m.byLocation[key], menu.ID)
+	}
 }
 
 func (m *memoryMenuRepository) List(_ context.Context, env ...string) ([]*Menu, error) {
@@ -105,25 +136,12 @@ func (m *memoryMenuRepository) Update(_ context.Context, menu *Menu) (*Menu, err
 		return nil, &NotFoundError{Resource: "menu", Key: menu.ID.String()}
 	}
 
-	oldCode := existing.Code
-	oldLocation := existing.Location
 	cloned := cloneMenu(menu)
 	cloned.EnvironmentID = resolveEnvironmentID(cloned.EnvironmentID, "")
 
+	m.unindex(existing)
 	m.byID[cloned.ID] = cloned
-
-	if oldCode != "" && oldCode != cloned.Code {
-		delete(m.byCode, menuCodeKey(resolveEnvironmentID(existing.EnvironmentID, ""), oldCode))
-	}
-	if cloned.Code != "" {
-		m.byCode[menuCodeKey(cloned.EnvironmentID, cloned.Code)] = cloned.ID
-	}
-	if oldLocation != "" && oldLocation != cloned.Location {
-		delete(m.byLocation, menuLocationKey(resolveEnvironmentID(existing.EnvironmentID, ""), oldLocation))
-	}
-	if cloned.Location != "" {
-		m.byLocation[menuLocationKey(cloned.EnvironmentID, cloned.Location)] = cloned.ID
-	}
+	m.index(cloned)
 
 	return cloneMenu(cloned), nil
 }
@@ -137,12 +155,7 @@ func (m *memoryMenuRepository) Delete(_ context.Context, id uuid.UUID) error {
 		return &NotFoundError{Resource: "menu", Key: id.String()}
 	}
 	delete(m.byID, id)
-	if existing.Code != "" {
-		delete(m.byCode, menuCodeKey(resolveEnvironmentID(existing.EnvironmentID, ""), existing.Code))
-	}
-	if existing.Location != "" {
-		delete(m.byLocation, menuLocationKey(resolveEnvironmentID(existing.EnvironmentID, ""), existing.Location))
-	}
+	m.unindex(existing)
 	return nil
 }
 
@@ -181,6 +194,10 @@ func matchesEnvironment(recordID, targetID uuid.UUID) bool {
 	return recordID == targetID
 }
 
+func menuSiteID(menu *Menu) *uuid.UUID {
+	return menu.SiteID
+}
+
 func menuCodeKey(envID uuid.UUID, code string) string {
 	return envID.String() + "|" + strings.TrimSpace(code)
 }
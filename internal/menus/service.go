@@ -13,12 +13,16 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/identity"
 	"github.com/goliatone/go-cms/internal/jobs"
 	"github.com/goliatone/go-cms/internal/pages"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
 	"github.com/goliatone/go-cms/internal/translationconfig"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/google/uuid"
 )
 
@@ -48,6 +52,8 @@ type Service interface {
 	UpsertMenuItem(ctx context.Context, input UpsertMenuItemInput) (*MenuItem, error)
 	UpdateMenuItem(ctx context.Context, input UpdateMenuItemInput) (*MenuItem, error)
 	DeleteMenuItem(ctx context.Context, req DeleteMenuItemRequest) error
+	ListTrashedMenuItems(ctx context.Context, req ListTrashedMenuItemsRequest) ([]*MenuItem, error)
+	RestoreDeletedMenuItem(ctx context.Context, req RestoreMenuItemRequest) (*MenuItem, error)
 	BulkReorderMenuItems(ctx context.Context, input BulkReorderMenuItemsInput) ([]*MenuItem, error)
 	ReconcileMenu(ctx context.Context, req ReconcileMenuRequest) (*ReconcileResult, error)
 
@@ -70,6 +76,7 @@ type CreateMenuInput struct {
 	CreatedBy      uuid.UUID
 	UpdatedBy      uuid.UUID
 	EnvironmentKey string
+	SiteID         *uuid.UUID
 }
 
 // UpsertMenuInput captures the information required to create or update a menu by code.
@@ -82,6 +89,7 @@ type UpsertMenuInput struct {
 	TranslationID  *uuid.UUID
 	Actor          uuid.UUID
 	EnvironmentKey string
+	SiteID         *uuid.UUID
 }
 
 type UpsertMenuLocationBindingInput struct {
@@ -255,10 +263,32 @@ type ResetMenuCounts struct {
 }
 
 // DeleteMenuItemRequest captures the data required to remove a menu item.
+// Without HardDelete the item subtree is moved to the trash when one is configured.
 type DeleteMenuItemRequest struct {
 	ItemID          uuid.UUID
 	DeletedBy       uuid.UUID
 	CascadeChildren bool
+	HardDelete      bool
+}
+
+// ListTrashedMenuItemsRequest pages through the trashed menu item subtrees of
+// an environment. An empty Environment selects the default environment; a
+// MenuID narrows the listing to one menu and implies its environment. A zero
+// Limit returns every remaining subtree.
+type ListTrashedMenuItemsRequest struct {
+	MenuID      uuid.UUID
+	Environment string
+	Limit       int
+	Offset      int
+}
+
+// RestoreMenuItemRequest brings a soft-deleted menu item and its children back
+// from the trash. OnConflict selects how external codes and canonical keys
+// claimed since the delete are handled.
+type RestoreMenuItemRequest struct {
+	ItemID     uuid.UUID
+	RestoredBy uuid.UUID
+	OnConflict domain.RestoreConflict
 }
 
 // ReconcileMenuRequest triggers a parent-link reconciliation pass for a menu.
@@ -354,6 +384,9 @@ var (
 	ErrMenuViewProfileNotFound             = errors.New("menus: menu view profile not found")
 	ErrMenuStatusInvalid                   = errors.New("menus: menu status is invalid")
 	ErrMenuViewModeInvalid                 = errors.New("menus: menu view profile mode is invalid")
+	ErrMenuItemSoftDeleteUnsupported       = errors.New("menus: soft delete not supported for menu items")
+	ErrMenuItemNotTrashed                  = errors.New("menus: menu item not found in trash")
+	ErrMenuItemRestoreConflict             = errors.New("menus: external code or canonical key already in use")
 )
 
 // LocaleRepository resolves locales by code.
@@ -551,6 +584,16 @@ func WithActivityEmitter(emitter *activity.Emitter) ServiceOption {
 	}
 }
 
+// WithLifecycleEmitter wires the emitter notified when menus, their items or
+// their location bindings change.
+func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
+	return func(s *service) {
+		if emitter != nil {
+			s.lifecycle = emitter
+		}
+	}
+}
+
 // WithMenuUsageResolver injects a dependency that reports active menu bindings.
 func WithMenuUsageResolver(resolver MenuUsageResolver) ServiceOption {
 	return func(s *service) {
@@ -691,6 +734,7 @@ type service struct {
 	translationsEnabled    bool
 	translationState       *translationconfig.State
 	activity               *activity.Emitter
+	lifecycle              *lifecycle.Emitter
 	forgivingBootstrap     bool
 	reconcileOnResolve     bool
 	menuIDDeriver          MenuIDDeriver
@@ -700,12 +744,22 @@ type service struct {
 	requireActiveEnv       bool
 	maxDepth               int
 	duplicateBindingPolicy string
+	trash                  trash.Repository
 }
 
 type cacheInvalidator interface {
 	InvalidateCache(ctx context.Context) error
 }
 
+// WithTrashRepository enables soft deletes of menu items through the trash.
+func WithTrashRepository(repo trash.Repository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.trash = repo
+		}
+	}
+}
+
 // NewService constructs a menu service instance.
 func NewService(menuRepo MenuRepository, itemRepo MenuItemRepository, trRepo MenuItemTranslationRepository, localeRepo LocaleRepository, opts ...ServiceOption) Service {
 	s := &service{
@@ -721,6 +775,7 @@ func NewService(menuRepo MenuRepository, itemRepo MenuItemRepository, trRepo Men
 		requireTranslations:    true,
 		translationsEnabled:    true,
 		activity:               activity.NewEmitter(nil, activity.Config{}),
+		lifecycle:              lifecycle.NewEmitter(nil, lifecycle.Config{}),
 		defaultEnvKey:          cmsenv.DefaultKey,
 		maxDepth:               16,
 		duplicateBindingPolicy: MenuBindingPolicySingle,
@@ -762,6 +817,44 @@ func (s *service) emitActivity(ctx context.Context, actor uuid.UUID, verb, objec
 	}
 }
 
+// emitMenuLifecycle notifies lifecycle hooks that a menu or one of its items
+// changed. The menu code travels in the "menu_code" metadata entry so
+// consumers keyed by code, such as the static generator, can match it.
+func (s *service) emitMenuLifecycle(ctx context.Context, resourceType string, recordID uuid.UUID, transition string, menu *Menu) {
+	if s.lifecycle == nil || !s.lifecycle.Enabled() || recordID == uuid.Nil || menu == nil {
+		return
+	}
+	event := lifecycle.Event{
+		ResourceType: resourceType,
+		RecordID:     recordID.String(),
+		Transition:   transition,
+		OccurredAt:   s.now(),
+		Metadata: map[string]any{
+			"menu_id":   menu.ID.String(),
+			"menu_code": menu.Code,
+		},
+	}
+	if location := strings.TrimSpace(menu.Location); location != "" {
+		event.Metadata["location"] = location
+	}
+	if menu.EnvironmentID != uuid.Nil {
+		event.EnvironmentKey = s.environmentKeyForID(ctx, menu.EnvironmentID)
+	}
+	_ = s.lifecycle.Emit(ctx, event)
+}
+
+// emitMenuItemLifecycle loads the item's menu and emits a menu_item event.
+func (s *service) emitMenuItemLifecycle(ctx context.Context, itemID uuid.UUID, transition string, menuID uuid.UUID) {
+	if s.lifecycle == nil || !s.lifecycle.Enabled() {
+		return
+	}
+	menu, err := s.menus.GetByID(ctx, menuID)
+	if err != nil {
+		return
+	}
+	s.emitMenuLifecycle(ctx, "menu_item", itemID, transition, menu)
+}
+
 func (s *service) resolveEnvironment(ctx context.Context, key string) (uuid.UUID, string, error) {
 	trimmed := strings.TrimSpace(key)
 	if trimmed == "" && s.requireExplicitEnv {
@@ -838,19 +931,22 @@ func (s *service) isDefaultEnvironmentKey(key string) bool {
 	return normalized == defaultKey
 }
 
-func (s *service) menuIdentityKey(code, envKey string) string {
+func (s *service) menuIdentityKey(code, envKey string, siteID *uuid.UUID) string {
 	trimmed := strings.TrimSpace(code)
 	if trimmed == "" {
 		return ""
 	}
+	if siteID != nil && *siteID != uuid.Nil {
+		trimmed = siteID.String() + "/" + trimmed
+	}
 	if s.isDefaultEnvironmentKey(envKey) {
 		return trimmed
 	}
 	return cmsenv.NormalizeKey(envKey) + ":" + trimmed
 }
 
-func (s *service) menuIDForCode(code, envKey string) uuid.UUID {
-	key := s.menuIdentityKey(code, envKey)
+func (s *service) menuIDForCode(code, envKey string, siteID *uuid.UUID) uuid.UUID {
+	key := s.menuIdentityKey(code, envKey, siteID)
 	if key == "" {
 		return s.nextID()
 	}
@@ -863,6 +959,20 @@ func (s *service) menuIDForCode(code, envKey string) uuid.UUID {
 	return s.nextID()
 }
 
+// scopedMenuByCode returns the menu with code scoped exactly to siteID, where
+// a nil siteID selects the shared menu. Menus of other sites, and shared
+// menus when siteID is set, are reported as not found.
+func (s *service) scopedMenuByCode(ctx context.Context, code string, envID uuid.UUID, siteID *uuid.UUID) (*Menu, error) {
+	menu, err := s.menus.GetByCode(cmssites.WithSiteID(ctx, siteID), code, envID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !cmssites.SameScope(menu.SiteID, siteID) {
+		return nil, &NotFoundError{Resource: "menu", Key: code}
+	}
+	return menu, nil
+}
+
 func pickEnvironmentKey(env ...string) string {
 	if len(env) == 0 {
 		return ""
@@ -895,7 +1005,7 @@ func (s *service) CreateMenu(ctx context.Context, input CreateMenuInput) (*Menu,
 		return nil, err
 	}
 
-	if _, err := s.menus.GetByCode(ctx, code, envID.String()); err == nil {
+	if _, err := s.scopedMenuByCode(ctx, code, envID, input.SiteID); err == nil {
 		return nil, ErrMenuCodeExists
 	} else if err != nil {
 		var notFound *NotFoundError
@@ -905,7 +1015,7 @@ func (s *service) CreateMenu(ctx context.Context, input CreateMenuInput) (*Menu,
 	}
 
 	now := s.now()
-	menuID := s.menuIDForCode(code, envKey)
+	menuID := s.menuIDForCode(code, envKey, input.SiteID)
 	status, err := normalizeMenuStatus(input.Status)
 	if err != nil {
 		return nil, err
@@ -929,6 +1039,7 @@ func (s *service) CreateMenu(ctx context.Context, input CreateMenuInput) (*Menu,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 		EnvironmentID: envID,
+		SiteID:        cloneUUIDPointer(input.SiteID),
 	}
 
 	created, err := s.menus.Create(ctx, menu)
@@ -942,6 +1053,7 @@ func (s *service) CreateMenu(ctx context.Context, input CreateMenuInput) (*Menu,
 		meta["environment_id"] = created.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, pickActor(input.CreatedBy, input.UpdatedBy), "create", "menu", created.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu", created.ID, "create", created)
 	return created, nil
 }
 
@@ -960,7 +1072,7 @@ func (s *service) GetOrCreateMenu(ctx context.Context, input CreateMenuInput) (*
 		return nil, err
 	}
 
-	existing, err := s.menus.GetByCode(ctx, code, envID.String())
+	existing, err := s.scopedMenuByCode(ctx, code, envID, input.SiteID)
 	if err == nil {
 		changed := false
 		location := strings.TrimSpace(input.Location)
@@ -1005,7 +1117,7 @@ func (s *service) GetOrCreateMenu(ctx context.Context, input CreateMenuInput) (*
 	}
 
 	now := s.now()
-	menuID := s.menuIDForCode(code, envKey)
+	menuID := s.menuIDForCode(code, envKey, input.SiteID)
 	status, err := normalizeMenuStatus(input.Status)
 	if err != nil {
 		return nil, err
@@ -1029,6 +1141,7 @@ func (s *service) GetOrCreateMenu(ctx context.Context, input CreateMenuInput) (*
 		CreatedAt:     now,
 		UpdatedAt:     now,
 		EnvironmentID: envID,
+		SiteID:        cloneUUIDPointer(input.SiteID),
 	}
 
 	created, err := s.menus.Create(ctx, menu)
@@ -1040,11 +1153,12 @@ func (s *service) GetOrCreateMenu(ctx context.Context, input CreateMenuInput) (*
 			meta["environment_id"] = created.EnvironmentID.String()
 		}
 		s.emitActivity(ctx, pickActor(input.CreatedBy, input.UpdatedBy), "create", "menu", created.ID, meta)
+		s.emitMenuLifecycle(ctx, "menu", created.ID, "create", created)
 		return created, nil
 	}
 
 	// If the create failed because another caller created the menu concurrently, return the winner.
-	existing, getErr := s.menus.GetByCode(ctx, code, envID.String())
+	existing, getErr := s.scopedMenuByCode(ctx, code, envID, input.SiteID)
 	if getErr == nil {
 		return existing, nil
 	}
@@ -1067,7 +1181,14 @@ func (s *service) UpsertMenu(ctx context.Context, input UpsertMenuInput) (*Menu,
 		return nil, err
 	}
 
-	existing, err := s.menus.GetByCode(ctx, code, envID.String())
+	// Site-scoped upserts only match that site's menu; unscoped upserts keep
+	// the site of whichever menu the context resolves.
+	var existing *Menu
+	if input.SiteID != nil && *input.SiteID != uuid.Nil {
+		existing, err = s.scopedMenuByCode(ctx, code, envID, input.SiteID)
+	} else {
+		existing, err = s.menus.GetByCode(ctx, code, envID.String())
+	}
 	if err != nil {
 		var notFound *NotFoundError
 		if !errors.As(err, &notFound) {
@@ -1083,6 +1204,7 @@ func (s *service) UpsertMenu(ctx context.Context, input UpsertMenuInput) (*Menu,
 			CreatedBy:      input.Actor,
 			UpdatedBy:      input.Actor,
 			EnvironmentKey: input.EnvironmentKey,
+			SiteID:         input.SiteID,
 		})
 		if err != nil {
 			return nil, err
@@ -1101,6 +1223,9 @@ func (s *service) UpsertMenu(ctx context.Context, input UpsertMenuInput) (*Menu,
 	if input.TranslationID != nil {
 		existing.FamilyID = cloneUUIDPointer(input.TranslationID)
 	}
+	if input.SiteID != nil {
+		existing.SiteID = cloneUUIDPointer(input.SiteID)
+	}
 	if status, statusErr := normalizeMenuStatus(input.Status); statusErr != nil {
 		return nil, statusErr
 	} else if status != "" {
@@ -1123,6 +1248,7 @@ func (s *service) UpsertMenu(ctx context.Context, input UpsertMenuInput) (*Menu,
 		meta["environment_id"] = updated.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, input.Actor, "update", "menu", updated.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu", updated.ID, "update", updated)
 	return updated, nil
 }
 
@@ -1216,14 +1342,19 @@ func (s *service) UpsertMenuLocationBinding(ctx context.Context, input UpsertMen
 		record.PublishedAt = &published
 	}
 
+	var saved *MenuLocationBinding
 	if exists {
-		updated, err := s.bindings.Update(ctx, record)
-		if err != nil {
-			return nil, err
-		}
-		return updated, nil
+		saved, err = s.bindings.Update(ctx, record)
+	} else {
+		saved, err = s.bindings.Create(ctx, record)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return s.bindings.Create(ctx, record)
+	if menu, err := s.menus.GetByCode(ctx, menuCode, envID.String()); err == nil {
+		s.emitMenuLifecycle(ctx, "menu", menu.ID, "bind", menu)
+	}
+	return saved, nil
 }
 
 func (s *service) UpsertMenuViewProfile(ctx context.Context, input UpsertMenuViewProfileInput) (*MenuViewProfile, error) {
@@ -1605,6 +1736,7 @@ func (s *service) DeleteMenu(ctx context.Context, req DeleteMenuRequest) error {
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, req.DeletedBy, "delete", "menu", menu.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu", menu.ID, "delete", menu)
 	return nil
 }
 
@@ -1653,6 +1785,7 @@ func (s *service) ResetMenuByCode(ctx context.Context, code string, actor uuid.U
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, actor, "reset", "menu", menu.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu", menu.ID, "reset", menu)
 	s.emitMenuResetAudit(ctx, actor, menu, force, &counts, nil)
 
 	return nil
@@ -1849,6 +1982,7 @@ func (s *service) AddMenuItem(ctx context.Context, input AddMenuItemInput) (*Men
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, pickActor(input.CreatedBy, input.UpdatedBy), "create", "menu_item", created.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu_item", created.ID, "create", menu)
 
 	if s.forgivingBootstrap {
 		actor := pickActor(input.UpdatedBy, input.CreatedBy)
@@ -2004,10 +2138,15 @@ func (s *service) emitMenuResetAudit(ctx context.Context, actor uuid.UUID, menu
 		metadata["error"] = resetErr.Error()
 	}
 
+	actorID := ""
+	if actor != uuid.Nil {
+		actorID = actor.String()
+	}
 	if err := s.audit.Record(ctx, jobs.AuditEvent{
 		EntityType: "menu",
 		EntityID:   menu.ID.String(),
 		Action:     action,
+		ActorID:    actorID,
 		OccurredAt: s.now().UTC(),
 		Metadata:   metadata,
 	}); err != nil {
@@ -2236,6 +2375,7 @@ func (s *service) UpdateMenuItem(ctx context.Context, input UpdateMenuItemInput)
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, input.UpdatedBy, verb, "menu_item", updated.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu_item", updated.ID, verb, menu)
 
 	return updated, nil
 }
@@ -2245,7 +2385,8 @@ func (s *service) ReconcileMenu(ctx context.Context, req ReconcileMenuRequest) (
 	if req.MenuID == uuid.Nil {
 		return nil, ErrMenuNotFound
 	}
-	if _, err := s.menus.GetByID(ctx, req.MenuID); err != nil {
+	menu, err := s.menus.GetByID(ctx, req.MenuID)
+	if err != nil {
 		var notFound *NotFoundError
 		if errors.As(err, &notFound) {
 			return nil, ErrMenuNotFound
@@ -2343,6 +2484,7 @@ func (s *service) ReconcileMenu(ctx context.Context, req ReconcileMenuRequest) (
 	if err := s.InvalidateCache(ctx); err != nil {
 		return nil, err
 	}
+	s.emitMenuLifecycle(ctx, "menu", menu.ID, "reconcile", menu)
 
 	remaining := countPendingParentRefs(items)
 	return &ReconcileResult{Resolved: resolved, Remaining: remaining}, nil
@@ -2363,7 +2505,18 @@ func (s *service) DeleteMenuItem(ctx context.Context, req DeleteMenuItemRequest)
 		return err
 	}
 
+	var entry *trash.Entry
+	trashed := !req.HardDelete && s.trash != nil
+	if trashed {
+		entry, err = s.trashMenuItem(ctx, item, req.DeletedBy, req.CascadeChildren)
+		if err != nil {
+			return err
+		}
+	}
 	if err := s.deleteMenuItemRecursive(ctx, item, req.DeletedBy, req.CascadeChildren); err != nil {
+		if entry != nil {
+			_ = s.trash.Delete(ctx, entry.ID)
+		}
 		return err
 	}
 	meta := map[string]any{
@@ -2371,10 +2524,14 @@ func (s *service) DeleteMenuItem(ctx context.Context, req DeleteMenuItemRequest)
 		"parent_id": item.ParentID,
 		"position":  item.Position,
 	}
+	if trashed {
+		meta["trashed"] = true
+	}
 	if menu, err := s.menus.GetByID(ctx, item.MenuID); err == nil && menu != nil && menu.EnvironmentID != uuid.Nil {
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, req.DeletedBy, "delete", "menu_item", item.ID, meta)
+	s.emitMenuItemLifecycle(ctx, item.ID, "delete", item.MenuID)
 
 	return s.InvalidateCache(ctx)
 }
@@ -2498,6 +2655,9 @@ func (s *service) BulkReorderMenuItems(ctx context.Context, input BulkReorderMen
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, input.UpdatedBy, "reorder", "menu", input.MenuID, meta)
+	if menu, err := s.menus.GetByID(ctx, input.MenuID); err == nil {
+		s.emitMenuLifecycle(ctx, "menu", menu.ID, "reorder", menu)
+	}
 
 	return result, nil
 }
@@ -2591,6 +2751,7 @@ func (s *service) AddMenuItemTranslation(ctx context.Context, input AddMenuItemT
 		meta["environment_id"] = menu.EnvironmentID.String()
 	}
 	s.emitActivity(ctx, pickActor(item.UpdatedBy, item.CreatedBy), "create", "menu_item_translation", created.ID, meta)
+	s.emitMenuItemLifecycle(ctx, item.ID, "update", item.MenuID)
 
 	return created, nil
 }
@@ -2660,6 +2821,7 @@ func (s *service) UpsertMenuItemTranslation(ctx context.Context, input UpsertMen
 		if err != nil {
 			return nil, err
 		}
+		s.emitMenuItemLifecycle(ctx, item.ID, "update", item.MenuID)
 		return created, nil
 	}
 
@@ -2673,6 +2835,7 @@ func (s *service) UpsertMenuItemTranslation(ctx context.Context, input UpsertMen
 	if err != nil {
 		return nil, err
 	}
+	s.emitMenuItemLifecycle(ctx, item.ID, "update", item.MenuID)
 	return updated, nil
 }
 
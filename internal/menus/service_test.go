@@ -15,7 +15,9 @@ import (
 	"github.com/goliatone/go-cms/internal/jobs"
 	"github.com/goliatone/go-cms/internal/menus"
 	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/sites"
 	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/goliatone/go-cms/pkg/testsupport"
 	urlkit "github.com/goliatone/go-urlkit"
 	"github.com/google/uuid"
@@ -116,6 +118,54 @@ func TestService_GetOrCreateMenu_ReturnsExisting(t *testing.T) {
 	}
 }
 
+func TestService_MenuCodesAreScopedPerSite(t *testing.T) {
+	ctx := context.Background()
+	svc := newService(t)
+	blogID := uuid.New()
+	shopID := uuid.New()
+
+	shared, err := svc.CreateMenu(ctx, menus.CreateMenuInput{Code: "primary"})
+	if err != nil {
+		t.Fatalf("CreateMenu shared: %v", err)
+	}
+	blog, err := svc.CreateMenu(ctx, menus.CreateMenuInput{Code: "primary", SiteID: &blogID})
+	if err != nil {
+		t.Fatalf("CreateMenu blog: %v", err)
+	}
+	if blog.ID == shared.ID {
+		t.Fatalf("expected site menu to get its own id")
+	}
+	if _, err := svc.CreateMenu(ctx, menus.CreateMenuInput{Code: "primary", SiteID: &blogID}); !errors.Is(err, menus.ErrMenuCodeExists) {
+		t.Fatalf("expected ErrMenuCodeExists within the site, got %v", err)
+	}
+	again, err := svc.GetOrCreateMenu(ctx, menus.CreateMenuInput{Code: "primary", SiteID: &blogID})
+	if err != nil {
+		t.Fatalf("GetOrCreateMenu blog: %v", err)
+	}
+	if again.ID != blog.ID {
+		t.Fatalf("expected GetOrCreateMenu to return the site menu, got %v", again.ID)
+	}
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want uuid.UUID
+	}{
+		{"unscoped", ctx, shared.ID},
+		{"blog", sites.WithSite(ctx, &sites.Site{ID: blogID, Key: "blog"}), blog.ID},
+		{"shop", sites.WithSite(ctx, &sites.Site{ID: shopID, Key: "shop"}), shared.ID},
+	}
+	for _, tc := range cases {
+		menu, err := svc.GetMenuByCode(tc.ctx, "primary")
+		if err != nil {
+			t.Fatalf("%s: GetMenuByCode: %v", tc.name, err)
+		}
+		if menu.ID != tc.want {
+			t.Fatalf("%s: expected menu %v, got %v", tc.name, tc.want, menu.ID)
+		}
+	}
+}
+
 func TestService_AddMenuItem_ShiftsSiblings(t *testing.T) {
 	ctx := context.Background()
 	fixture := loadServiceFixture(t)
@@ -848,6 +898,48 @@ func TestService_AddMenuItemTranslationEmitsActivity(t *testing.T) {
 	}
 }
 
+func TestService_MenuWritesEmitLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	fixture := loadServiceFixture(t)
+	hook := &lifecycle.CaptureHook{}
+	emitter := lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})
+	service := newServiceWithLocales(t, fixture.locales(), func(menus.AddMenuItemInput) uuid.UUID { return uuid.New() }, nil, menus.WithLifecycleEmitter(emitter))
+
+	menu, err := service.CreateMenu(ctx, menus.CreateMenuInput{Code: "primary"})
+	if err != nil {
+		t.Fatalf("CreateMenu: %v", err)
+	}
+	item, err := service.AddMenuItem(ctx, menus.AddMenuItemInput{
+		MenuID:       menu.ID,
+		Target:       map[string]any{"type": "page", "slug": "about"},
+		Translations: fixture.translations("about"),
+	})
+	if err != nil {
+		t.Fatalf("AddMenuItem: %v", err)
+	}
+	if _, err := service.UpsertMenuItemTranslation(ctx, menus.UpsertMenuItemTranslationInput{ItemID: item.ID, Locale: "es", Label: "Acerca de"}); err != nil {
+		t.Fatalf("UpsertMenuItemTranslation: %v", err)
+	}
+	if err := service.DeleteMenuItem(ctx, menus.DeleteMenuItemRequest{ItemID: item.ID}); err != nil {
+		t.Fatalf("DeleteMenuItem: %v", err)
+	}
+
+	var got []string
+	for _, event := range hook.Events {
+		if event.Metadata["menu_code"] != "primary" {
+			t.Fatalf("expected menu_code metadata on %+v", event)
+		}
+		got = append(got, event.ResourceType+":"+event.Transition)
+	}
+	want := []string{"menu:create", "menu_item:create", "menu_item:update", "menu_item:delete"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected lifecycle events %v, want %v", got, want)
+	}
+	if hook.Events[1].RecordID != item.ID.String() {
+		t.Fatalf("expected item event to carry the item id, got %s", hook.Events[1].RecordID)
+	}
+}
+
 func TestService_AddMenuItem_PageValidation(t *testing.T) {
 	ctx := context.Background()
 	fixture := loadServiceFixture(t)
@@ -0,0 +1,276 @@
+package menus
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+// maxRestoreRenameAttempts bounds the suffixed external codes tried by RestoreConflictRename.
+const maxRestoreRenameAttempts = 100
+
+// trashedMenuItem is the trash snapshot of a deleted menu item subtree. Items
+// are stored parent first and carry their translations.
+type trashedMenuItem struct {
+	Items []*MenuItem `json:"items"`
+}
+
+// trashMenuItem snapshots the item with its descendants and translations. The
+// entry carries the environment of the item's menu.
+func (s *service) trashMenuItem(ctx context.Context, item *MenuItem, deletedBy uuid.UUID, cascade bool) (*trash.Entry, error) {
+	subtree := []*MenuItem{item}
+	for i := 0; i < len(subtree); i++ {
+		children, err := s.items.ListChildren(ctx, subtree[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(children) > 0 && !cascade {
+			return nil, ErrMenuItemHasChildren
+		}
+		subtree = append(subtree, children...)
+	}
+
+	now := s.now()
+	snapshot := trashedMenuItem{Items: make([]*MenuItem, 0, len(subtree))}
+	for _, record := range subtree {
+		translations, err := s.translations.ListByMenuItem(ctx, record.ID)
+		if err != nil {
+			return nil, err
+		}
+		clone := *record
+		clone.Menu = nil
+		clone.Parent = nil
+		clone.Children = nil
+		clone.Translations = translations
+		clone.DeletedAt = &now
+		snapshot.Items = append(snapshot.Items, &clone)
+	}
+	entry, err := trash.NewEntry(trash.ResourceMenuItem, item.ID, &snapshot, deletedBy, now)
+	if err != nil {
+		return nil, err
+	}
+	if menu, err := s.menus.GetByID(ctx, item.MenuID); err == nil && menu != nil {
+		entry.EnvironmentID = menu.EnvironmentID
+	}
+	return s.trash.Create(ctx, entry)
+}
+
+// ListTrashedMenuItems returns a page of the root items of soft-deleted
+// subtrees in the environment, most recently deleted first. Listings narrowed
+// to one menu are paged after filtering.
+func (s *service) ListTrashedMenuItems(ctx context.Context, req ListTrashedMenuItemsRequest) ([]*MenuItem, error) {
+	if s.trash == nil {
+		return nil, ErrMenuItemSoftDeleteUnsupported
+	}
+	query := trash.ListQuery{ResourceType: trash.ResourceMenuItem, Limit: req.Limit, Offset: req.Offset}
+	if req.MenuID != uuid.Nil {
+		menu, err := s.menus.GetByID(ctx, req.MenuID)
+		if err != nil {
+			return nil, err
+		}
+		query.EnvironmentID = menu.EnvironmentID
+		query.Limit, query.Offset = 0, 0
+	} else {
+		envID, _, err := s.resolveEnvironment(ctx, req.Environment)
+		if err != nil {
+			return nil, err
+		}
+		query.EnvironmentID = envID
+	}
+	entries, err := s.trash.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*MenuItem, 0, len(entries))
+	for _, entry := range entries {
+		var snapshot trashedMenuItem
+		if err := trash.Decode(entry, &snapshot); err != nil {
+			return nil, err
+		}
+		if len(snapshot.Items) == 0 || snapshot.Items[0] == nil {
+			continue
+		}
+		root := snapshot.Items[0]
+		if req.MenuID != uuid.Nil && root.MenuID != req.MenuID {
+			continue
+		}
+		records = append(records, root)
+	}
+	if req.MenuID != uuid.Nil {
+		records = pageMenuItems(records, req.Limit, req.Offset)
+	}
+	return records, nil
+}
+
+// pageMenuItems applies limit and offset to an already filtered listing.
+func pageMenuItems(records []*MenuItem, limit, offset int) []*MenuItem {
+	if offset > 0 {
+		if offset >= len(records) {
+			return []*MenuItem{}
+		}
+		records = records[offset:]
+	}
+	if limit > 0 && limit < len(records) {
+		records = records[:limit]
+	}
+	return records
+}
+
+// RestoreDeletedMenuItem recreates a trashed menu item subtree with its
+// translations. The restored root is appended after its current siblings.
+func (s *service) RestoreDeletedMenuItem(ctx context.Context, req RestoreMenuItemRequest) (*MenuItem, error) {
+	if req.ItemID == uuid.Nil {
+		return nil, ErrMenuItemNotFound
+	}
+	if s.trash == nil {
+		return nil, ErrMenuItemSoftDeleteUnsupported
+	}
+	entry, err := s.trash.GetByResource(ctx, trash.ResourceMenuItem, req.ItemID)
+	if err != nil {
+		if errors.Is(err, trash.ErrEntryNotFound) {
+			return nil, ErrMenuItemNotTrashed
+		}
+		return nil, err
+	}
+	var snapshot trashedMenuItem
+	if err := trash.Decode(entry, &snapshot); err != nil {
+		return nil, err
+	}
+	if len(snapshot.Items) == 0 || snapshot.Items[0] == nil {
+		return nil, ErrMenuItemNotTrashed
+	}
+	root := snapshot.Items[0]
+
+	menu, err := s.menus.GetByID(ctx, root.MenuID)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, ErrMenuNotFound
+		}
+		return nil, err
+	}
+	if root.ParentID != nil {
+		parent, err := s.items.GetByID(ctx, *root.ParentID)
+		if err != nil || parent == nil || parent.MenuID != root.MenuID {
+			return nil, ErrMenuItemParentInvalid
+		}
+	}
+	for _, item := range snapshot.Items {
+		if err := s.resolveRestoreConflicts(ctx, item, req.OnConflict); err != nil {
+			return nil, err
+		}
+	}
+
+	siblings, err := s.fetchSiblings(ctx, root.MenuID, root.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	root.Position = len(siblings)
+
+	now := s.now()
+	for _, item := range snapshot.Items {
+		translations := item.Translations
+		item.Translations = nil
+		item.DeletedAt = nil
+		item.UpdatedAt = now
+		if req.RestoredBy != uuid.Nil {
+			item.UpdatedBy = req.RestoredBy
+		}
+		if _, err := s.items.Create(ctx, item); err != nil {
+			return nil, err
+		}
+		for _, tr := range translations {
+			if tr == nil {
+				continue
+			}
+			if _, err := s.translations.Create(ctx, tr); err != nil {
+				return nil, err
+			}
+		}
+		item.Translations = translations
+	}
+	if err := s.trash.Delete(ctx, entry.ID); err != nil && !errors.Is(err, trash.ErrEntryNotFound) {
+		return nil, err
+	}
+
+	meta := map[string]any{
+		"menu_id":     root.MenuID.String(),
+		"parent_id":   root.ParentID,
+		"position":    root.Position,
+		"descendants": len(snapshot.Items) - 1,
+	}
+	if menu != nil && menu.EnvironmentID != uuid.Nil {
+		meta["environment_id"] = menu.EnvironmentID.String()
+	}
+	s.emitActivity(ctx, req.RestoredBy, "restore", "menu_item", root.ID, meta)
+	s.emitMenuLifecycle(ctx, "menu_item", root.ID, "restore", menu)
+
+	if err := s.InvalidateCache(ctx); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// resolveRestoreConflicts checks the item external code and canonical key
+// against live items of the menu. Renaming suffixes the external code and
+// drops the canonical key so it is derived again.
+func (s *service) resolveRestoreConflicts(ctx context.Context, item *MenuItem, strategy domain.RestoreConflict) error {
+	codeTaken := func(code string) (bool, error) {
+		existing, err := s.items.GetByMenuAndExternalCode(ctx, item.MenuID, code)
+		if err != nil {
+			var notFound *NotFoundError
+			if errors.As(err, &notFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return existing != nil, nil
+	}
+
+	if code := strings.TrimSpace(item.ExternalCode); code != "" {
+		taken, err := codeTaken(code)
+		if err != nil {
+			return err
+		}
+		if taken {
+			if strategy != domain.RestoreConflictRename {
+				return ErrMenuItemRestoreConflict
+			}
+			renamed := ""
+			for attempt := 1; attempt <= maxRestoreRenameAttempts && renamed == ""; attempt++ {
+				candidate := trash.SuffixedSlug(code, attempt)
+				taken, err := codeTaken(candidate)
+				if err != nil {
+					return err
+				}
+				if !taken {
+					renamed = candidate
+				}
+			}
+			if renamed == "" {
+				return ErrMenuItemRestoreConflict
+			}
+			item.ExternalCode = renamed
+		}
+	}
+
+	if item.CanonicalKey != nil && strings.TrimSpace(*item.CanonicalKey) != "" {
+		existing, err := s.items.GetByMenuAndCanonicalKey(ctx, item.MenuID, *item.CanonicalKey)
+		if err != nil {
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				return err
+			}
+		} else if existing != nil {
+			if strategy != domain.RestoreConflictRename {
+				return ErrMenuItemRestoreConflict
+			}
+			item.CanonicalKey = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package menus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+func TestService_DeleteMenuItem_TrashAndRestoreSubtree(t *testing.T) {
+	ctx := context.Background()
+	fixture := loadServiceFixture(t)
+
+	localeRepo := content.NewMemoryLocaleRepository()
+	for _, loc := range fixture.locales() {
+		locale := loc
+		localeRepo.Put(&locale)
+	}
+	itemRepo := menus.NewMemoryMenuItemRepository()
+	service := menus.NewService(
+		menus.NewMemoryMenuRepository(),
+		itemRepo,
+		menus.NewMemoryMenuItemTranslationRepository(),
+		localeRepo,
+		menus.WithTrashRepository(trash.NewMemoryRepository()),
+	)
+
+	menu, err := service.CreateMenu(ctx, menus.CreateMenuInput{Code: "primary"})
+	if err != nil {
+		t.Fatalf("CreateMenu: %v", err)
+	}
+	parent, err := service.AddMenuItem(ctx, menus.AddMenuItemInput{
+		MenuID:       menu.ID,
+		ExternalCode: "docs",
+		Target:       map[string]any{"type": "page", "slug": "docs"},
+		Translations: fixture.translations("parent"),
+	})
+	if err != nil {
+		t.Fatalf("AddMenuItem parent: %v", err)
+	}
+	child, err := service.AddMenuItem(ctx, menus.AddMenuItemInput{
+		MenuID:       menu.ID,
+		ParentID:     &parent.ID,
+		Target:       map[string]any{"type": "page", "slug": "install"},
+		Translations: fixture.translations("child"),
+	})
+	if err != nil {
+		t.Fatalf("AddMenuItem child: %v", err)
+	}
+
+	if err := service.DeleteMenuItem(ctx, menus.DeleteMenuItemRequest{ItemID: parent.ID, CascadeChildren: true}); err != nil {
+		t.Fatalf("DeleteMenuItem: %v", err)
+	}
+	if _, err := itemRepo.GetByID(ctx, child.ID); err == nil {
+		t.Fatalf("expected child to be removed with its parent")
+	}
+
+	trashed, err := service.ListTrashedMenuItems(ctx, menus.ListTrashedMenuItemsRequest{MenuID: menu.ID})
+	if err != nil {
+		t.Fatalf("ListTrashedMenuItems: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != parent.ID {
+		t.Fatalf("expected trashed root item, got %+v", trashed)
+	}
+	if all, err := service.ListTrashedMenuItems(ctx, menus.ListTrashedMenuItemsRequest{}); err != nil || len(all) != 1 {
+		t.Fatalf("expected trashed item in the default environment, got %d (%v)", len(all), err)
+	}
+	if rest, err := service.ListTrashedMenuItems(ctx, menus.ListTrashedMenuItemsRequest{MenuID: menu.ID, Offset: 1}); err != nil || len(rest) != 0 {
+		t.Fatalf("expected an empty second page, got %d (%v)", len(rest), err)
+	}
+
+	if _, err := service.AddMenuItem(ctx, menus.AddMenuItemInput{
+		MenuID:       menu.ID,
+		ExternalCode: "docs",
+		Target:       map[string]any{"type": "page", "slug": "docs-v2"},
+		Translations: fixture.translations("sibling"),
+	}); err != nil {
+		t.Fatalf("AddMenuItem replacement: %v", err)
+	}
+
+	if _, err := service.RestoreDeletedMenuItem(ctx, menus.RestoreMenuItemRequest{ItemID: parent.ID}); !errors.Is(err, menus.ErrMenuItemRestoreConflict) {
+		t.Fatalf("expected ErrMenuItemRestoreConflict, got %v", err)
+	}
+
+	restored, err := service.RestoreDeletedMenuItem(ctx, menus.RestoreMenuItemRequest{
+		ItemID:     parent.ID,
+		RestoredBy: uuid.New(),
+		OnConflict: domain.RestoreConflictRename,
+	})
+	if err != nil {
+		t.Fatalf("RestoreDeletedMenuItem: %v", err)
+	}
+	if restored.ExternalCode != "docs-restored" || restored.Position != 1 {
+		t.Fatalf("expected renamed root appended after siblings, got code=%q position=%d", restored.ExternalCode, restored.Position)
+	}
+
+	menuState, err := service.GetMenu(ctx, menu.ID)
+	if err != nil {
+		t.Fatalf("GetMenu: %v", err)
+	}
+	if len(menuState.Items) != 2 || menuState.Items[1].ID != parent.ID {
+		t.Fatalf("expected restored item at the end of the menu, got %+v", menuState.Items)
+	}
+	if len(menuState.Items[1].Children) != 1 || menuState.Items[1].Children[0].ID != child.ID {
+		t.Fatalf("expected child to be restored under its parent")
+	}
+	if len(menuState.Items[1].Children[0].Translations) == 0 {
+		t.Fatalf("expected child translations to be restored")
+	}
+
+	if _, err := service.RestoreDeletedMenuItem(ctx, menus.RestoreMenuItemRequest{ItemID: parent.ID}); !errors.Is(err, menus.ErrMenuItemNotTrashed) {
+		t.Fatalf("expected ErrMenuItemNotTrashed, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BunRunRepository persists bulk migration runs using a Bun-backed database.
+type BunRunRepository struct {
+	db *bun.DB
+}
+
+// NewBunRunRepository constructs a Bun-backed migration run repository.
+func NewBunRunRepository(db *bun.DB) *BunRunRepository {
+	return &BunRunRepository{db: db}
+}
+
+func (r *BunRunRepository) Create(ctx context.Context, run *Run) (*Run, error) {
+	if r.db == nil {
+		return nil, errors.New("migrations: bun repository requires a database")
+	}
+	model := modelFromRun(run)
+	if _, err := r.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, model.ID)
+}
+
+func (r *BunRunRepository) Update(ctx context.Context, run *Run) (*Run, error) {
+	if r.db == nil {
+		return nil, errors.New("migrations: bun repository requires a database")
+	}
+	model := modelFromRun(run)
+	res, err := r.db.NewUpdate().
+		Model(model).
+		Column("target_version", "status", "options", "checkpoint", "summary", "failures", "updated_at", "completed_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return nil, ErrRunNotFound
+	}
+	return r.GetByID(ctx, model.ID)
+}
+
+func (r *BunRunRepository) GetByID(ctx context.Context, id uuid.UUID) (*Run, error) {
+	if r.db == nil {
+		return nil, errors.New("migrations: bun repository requires a database")
+	}
+	var model runModel
+	if err := r.db.NewSelect().Model(&model).Where("?TableAlias.id = ?", id).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRunNotFound
+		}
+		return nil, err
+	}
+	return modelToRun(&model), nil
+}
+
+func (r *BunRunRepository) List(ctx context.Context, filter RunFilter) ([]*Run, error) {
+	if r.db == nil {
+		return nil, errors.New("migrations: bun repository requires a database")
+	}
+	var models []runModel
+	query := r.db.NewSelect().Model(&models).
+		OrderExpr("?TableAlias.created_at DESC").
+		OrderExpr("?TableAlias.id DESC")
+	if filter.Kind != "" {
+		query = query.Where("?TableAlias.kind = ?", string(filter.Kind))
+	}
+	if filter.TargetID != nil {
+		query = query.Where("?TableAlias.target_id = ?", *filter.TargetID)
+	}
+	if filter.Status != "" {
+		query = query.Where("?TableAlias.status = ?", string(filter.Status))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	out := make([]*Run, 0, len(models))
+	for i := range models {
+		out = append(out, modelToRun(&models[i]))
+	}
+	return out, nil
+}
+
+type runModel struct {
+	bun.BaseModel `bun:"table:schema_migration_runs,alias:smr"`
+
+	ID            uuid.UUID     `bun:",pk,type:uuid"`
+	Kind          string        `bun:"kind,notnull"`
+	TargetID      uuid.UUID     `bun:"target_id,type:uuid,notnull"`
+	TargetSlug    string        `bun:"target_slug,notnull"`
+	TargetVersion string        `bun:"target_version"`
+	Status        string        `bun:"status,notnull"`
+	Options       RunOptions    `bun:"options,type:jsonb"`
+	Checkpoint    RunCheckpoint `bun:"checkpoint,type:jsonb"`
+	Summary       RunSummary    `bun:"summary,type:jsonb"`
+	Failures      []RunFailure  `bun:"failures,type:jsonb"`
+	CreatedBy     uuid.UUID     `bun:"created_by,type:uuid"`
+	CreatedAt     time.Time     `bun:"created_at,notnull"`
+	UpdatedAt     time.Time     `bun:"updated_at,notnull"`
+	CompletedAt   *time.Time    `bun:"completed_at"`
+}
+
+func modelFromRun(run *Run) *runModel {
+	return &runModel{
+		ID:            run.ID,
+		Kind:          string(run.Kind),
+		TargetID:      run.TargetID,
+		TargetSlug:    run.TargetSlug,
+		TargetVersion: run.TargetVersion,
+		Status:        string(run.Status),
+		Options:       run.Options,
+		Checkpoint:    run.Checkpoint,
+		Summary:       run.Summary,
+		Failures:      run.Failures,
+		CreatedBy:     run.CreatedBy,
+		CreatedAt:     run.CreatedAt,
+		UpdatedAt:     run.UpdatedAt,
+		CompletedAt:   run.CompletedAt,
+	}
+}
+
+func modelToRun(model *runModel) *Run {
+	return &Run{
+		ID:            model.ID,
+		Kind:          RunKind(model.Kind),
+		TargetID:      model.TargetID,
+		TargetSlug:    model.TargetSlug,
+		TargetVersion: model.TargetVersion,
+		Status:        RunStatus(model.Status),
+		Options:       model.Options,
+		Checkpoint:    model.Checkpoint,
+		Summary:       model.Summary,
+		Failures:      model.Failures,
+		CreatedBy:     model.CreatedBy,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+		CompletedAt:   model.CompletedAt,
+	}
+}
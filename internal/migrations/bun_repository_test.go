@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestBunRunRepository_CRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewBunRunRepository(db)
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, ErrRunNotFound) {
+		t.Fatalf("expected ErrRunNotFound, got %v", err)
+	}
+
+	targetID := uuid.New()
+	base := time.Date(2026, 8, 5, 12, 0, 0, 0, time.UTC)
+	older, err := repo.Create(ctx, &Run{
+		ID:            uuid.New(),
+		Kind:          KindContentType,
+		TargetID:      targetID,
+		TargetSlug:    "article",
+		TargetVersion: "article@v2.0.0",
+		Status:        RunRunning,
+		Options:       RunOptions{BatchSize: 50, Throttle: time.Second},
+		Checkpoint:    RunCheckpoint{Phase: PhaseContentEntries},
+		CreatedAt:     base,
+		UpdatedAt:     base,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if older.Options.BatchSize != 50 || older.Options.Throttle != time.Second {
+		t.Fatalf("Create() returned %+v", older)
+	}
+	if _, err := repo.Create(ctx, &Run{
+		ID:         uuid.New(),
+		Kind:       KindBlockDefinition,
+		TargetID:   uuid.New(),
+		TargetSlug: "hero",
+		Status:     RunCompleted,
+		CreatedAt:  base.Add(time.Hour),
+		UpdatedAt:  base.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	completedAt := base.Add(2 * time.Hour)
+	recordID := uuid.New()
+	older.Status = RunPartial
+	older.Checkpoint = RunCheckpoint{Phase: PhaseContentEntries, LastID: recordID}
+	older.Summary = RunSummary{Scanned: 2, Migrated: 1, Failed: 1}
+	older.Failures = []RunFailure{{Phase: PhaseContentEntries, Kind: RecordContentTranslation, RecordID: recordID, Locale: "en", Error: "invalid"}}
+	older.UpdatedAt = completedAt
+	older.CompletedAt = &completedAt
+	updated, err := repo.Update(ctx, older)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Status != RunPartial || updated.Checkpoint.LastID != recordID || len(updated.Failures) != 1 || updated.CompletedAt == nil {
+		t.Fatalf("Update() returned %+v", updated)
+	}
+
+	all, err := repo.List(ctx, RunFilter{})
+	if err != nil || len(all) != 2 || all[0].Kind != KindBlockDefinition {
+		t.Fatalf("List() = %d runs, err %v", len(all), err)
+	}
+	filtered, err := repo.List(ctx, RunFilter{TargetID: &targetID, Status: RunPartial})
+	if err != nil || len(filtered) != 1 || filtered[0].ID != older.ID {
+		t.Fatalf("List(filter) = %+v, err %v", filtered, err)
+	}
+	if _, err := repo.Update(ctx, &Run{ID: uuid.New(), Status: RunCompleted}); !errors.Is(err, ErrRunNotFound) {
+		t.Fatalf("expected ErrRunNotFound on update, got %v", err)
+	}
+}
+
+func newTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+
+	sqldb, err := sql.Open("sqlite3", "file:migration_runs_test?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = sqldb.Close() })
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := db.NewCreateTable().Model((*runModel)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return db
+}
@@ -59,6 +59,14 @@ func (r *Registry) Register(slug, from, to string, fn MigrationFunc) error {
 	return r.migrator.Register(normalizedSlug, fromVersion.String(), toVersion.String(), cmsschema.MigrationFunc(fn))
 }
 
+// RegisterOperations adds a migration step built from declarative operations.
+func (r *Registry) RegisterOperations(slug, from, to string, ops []cmsschema.MigrationOperation) error {
+	if err := cmsschema.ValidateMigrationOperations(ops); err != nil {
+		return err
+	}
+	return r.Register(slug, from, to, MigrationFunc(cmsschema.OperationsMigration(ops)))
+}
+
 // Migrate applies registered migration steps.
 func (r *Registry) Migrate(slug, from, to string, payload map[string]any) (map[string]any, error) {
 	if r == nil || r.migrator == nil {
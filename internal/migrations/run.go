@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RunKind identifies the schema owner a bulk migration run rewrites.
+type RunKind string
+
+const (
+	// KindContentType migrates the entries of a content type.
+	KindContentType RunKind = "content_type"
+	// KindBlockDefinition migrates the instances and embedded blocks of a block definition.
+	KindBlockDefinition RunKind = "block_definition"
+)
+
+// RunStatus describes the state of a bulk migration run.
+type RunStatus string
+
+const (
+	// RunRunning marks runs in progress, or runs whose process stopped before
+	// recording an outcome.
+	RunRunning RunStatus = "running"
+	// RunInterrupted marks runs stopped by context cancellation; they can be resumed.
+	RunInterrupted RunStatus = "interrupted"
+	// RunCompleted marks runs that migrated every record.
+	RunCompleted RunStatus = "completed"
+	// RunPartial marks finished runs that recorded per-record failures.
+	RunPartial RunStatus = "partial"
+)
+
+// Phases walked by bulk migration runs. Records are visited in ID order
+// within each phase.
+const (
+	PhaseContentEntries = "content_entries"
+	PhaseBlockInstances = "block_instances"
+	PhaseEmbeddedBlocks = "embedded_blocks"
+)
+
+// Record kinds reported in run failures.
+const (
+	RecordContentTranslation = "content_translation"
+	RecordContentVersion     = "content_version"
+	RecordBlockTranslation   = "block_translation"
+	RecordBlockVersion       = "block_version"
+)
+
+const defaultRunBatchSize = 100
+
+var (
+	ErrRunKindInvalid      = errors.New("migrations: run kind invalid")
+	ErrRunTargetRequired   = errors.New("migrations: run target required")
+	ErrRunNotFound         = errors.New("migrations: run not found")
+	ErrRunInProgress       = errors.New("migrations: run already in progress for target")
+	ErrRunFinished         = errors.New("migrations: run already finished")
+	ErrRunnerNotConfigured = errors.New("migrations: runner repositories not configured for run kind")
+)
+
+// RunOptions controls how a bulk migration run walks and writes records.
+type RunOptions struct {
+	// BatchSize is the number of records processed between checkpoints.
+	BatchSize int `json:"batch_size,omitempty"`
+	// Throttle pauses between batches to limit database load.
+	Throttle time.Duration `json:"throttle,omitempty"`
+	// DryRun migrates and validates payloads without writing them.
+	DryRun  bool      `json:"dry_run,omitempty"`
+	ActorID uuid.UUID `json:"actor_id,omitempty"`
+}
+
+// RunRequest starts a bulk migration run for a content type or block definition.
+type RunRequest struct {
+	Kind     RunKind    `json:"kind"`
+	TargetID uuid.UUID  `json:"target_id"`
+	Options  RunOptions `json:"options"`
+}
+
+// RunCheckpoint records the last record a run finished.
+type RunCheckpoint struct {
+	Phase  string    `json:"phase,omitempty"`
+	LastID uuid.UUID `json:"last_id,omitempty"`
+}
+
+// RunSummary counts the records visited by a run. A record is a content entry
+// or block instance together with its translations and version snapshots.
+type RunSummary struct {
+	Scanned   int `json:"scanned"`
+	Migrated  int `json:"migrated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// RunFailure describes a payload that could not be migrated. Records with a
+// failure are left untouched.
+type RunFailure struct {
+	Phase    string    `json:"phase"`
+	Kind     string    `json:"kind"`
+	RecordID uuid.UUID `json:"record_id"`
+	Locale   string    `json:"locale,omitempty"`
+	Version  int       `json:"version,omitempty"`
+	Error    string    `json:"error"`
+}
+
+// Run captures a persisted bulk migration run and its checkpoint.
+type Run struct {
+	ID            uuid.UUID     `json:"id"`
+	Kind          RunKind       `json:"kind"`
+	TargetID      uuid.UUID     `json:"target_id"`
+	TargetSlug    string        `json:"target_slug"`
+	TargetVersion string        `json:"target_version"`
+	Status        RunStatus     `json:"status"`
+	Options       RunOptions    `json:"options"`
+	Checkpoint    RunCheckpoint `json:"checkpoint"`
+	Summary       RunSummary    `json:"summary"`
+	Failures      []RunFailure  `json:"failures,omitempty"`
+	CreatedBy     uuid.UUID     `json:"created_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	CompletedAt   *time.Time    `json:"completed_at,omitempty"`
+}
+
+// Finished reports whether the run recorded an outcome.
+func (r *Run) Finished() bool {
+	return r != nil && (r.Status == RunCompleted || r.Status == RunPartial)
+}
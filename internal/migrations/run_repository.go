@@ -0,0 +1,113 @@
+package migrations
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// RunRepository persists bulk migration runs.
+type RunRepository interface {
+	Create(ctx context.Context, run *Run) (*Run, error)
+	Update(ctx context.Context, run *Run) (*Run, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Run, error)
+	List(ctx context.Context, filter RunFilter) ([]*Run, error)
+}
+
+// RunFilter narrows run listings. Results are ordered newest first.
+type RunFilter struct {
+	Kind     RunKind
+	TargetID *uuid.UUID
+	Status   RunStatus
+	Limit    int
+	Offset   int
+}
+
+type memoryRunRepository struct {
+	mu   sync.RWMutex
+	runs map[uuid.UUID]*Run
+}
+
+// NewMemoryRunRepository constructs an in-memory migration run repository.
+func NewMemoryRunRepository() RunRepository {
+	return &memoryRunRepository{runs: make(map[uuid.UUID]*Run)}
+}
+
+func (m *memoryRunRepository) Create(_ context.Context, run *Run) (*Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cloned := cloneRun(run)
+	m.runs[cloned.ID] = cloned
+	return cloneRun(cloned), nil
+}
+
+func (m *memoryRunRepository) Update(_ context.Context, run *Run) (*Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.runs[run.ID]; !ok {
+		return nil, ErrRunNotFound
+	}
+	cloned := cloneRun(run)
+	m.runs[cloned.ID] = cloned
+	return cloneRun(cloned), nil
+}
+
+func (m *memoryRunRepository) GetByID(_ context.Context, id uuid.UUID) (*Run, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	run, ok := m.runs[id]
+	if !ok {
+		return nil, ErrRunNotFound
+	}
+	return cloneRun(run), nil
+}
+
+func (m *memoryRunRepository) List(_ context.Context, filter RunFilter) ([]*Run, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]*Run, 0, len(m.runs))
+	for _, run := range m.runs {
+		if filter.Kind != "" && run.Kind != filter.Kind {
+			continue
+		}
+		if filter.TargetID != nil && run.TargetID != *filter.TargetID {
+			continue
+		}
+		if filter.Status != "" && run.Status != filter.Status {
+			continue
+		}
+		out = append(out, cloneRun(run))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID.String() > out[j].ID.String()
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	if filter.Offset > 0 {
+		if filter.Offset >= len(out) {
+			return []*Run{}, nil
+		}
+		out = out[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(out) {
+		out = out[:filter.Limit]
+	}
+	return out, nil
+}
+
+func cloneRun(run *Run) *Run {
+	if run == nil {
+		return nil
+	}
+	cloned := *run
+	cloned.Failures = slices.Clone(run.Failures)
+	if run.CompletedAt != nil {
+		completedAt := *run.CompletedAt
+		cloned.CompletedAt = &completedAt
+	}
+	return &cloned
+}
@@ -0,0 +1,702 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/schema"
+	"github.com/google/uuid"
+)
+
+// Runner rewrites the stored payloads of a content type or block definition
+// to its current schema version in resumable, checkpointed batches.
+type Runner interface {
+	Start(ctx context.Context, req RunRequest) (*Run, error)
+	Resume(ctx context.Context, id uuid.UUID) (*Run, error)
+	GetRun(ctx context.Context, id uuid.UUID) (*Run, error)
+	ListRuns(ctx context.Context, filter RunFilter) ([]*Run, error)
+}
+
+// RunnerOption configures the bulk migration runner.
+type RunnerOption func(*runner)
+
+// WithRunRepository overrides the repository used to persist runs and checkpoints.
+func WithRunRepository(repo RunRepository) RunnerOption {
+	return func(r *runner) {
+		if repo != nil {
+			r.runs = repo
+		}
+	}
+}
+
+// WithContentRepositories wires the repositories walked by content type runs
+// and by the embedded blocks phase of block definition runs.
+func WithContentRepositories(contentTypes content.ContentTypeRepository, contents content.ContentRepository) RunnerOption {
+	return func(r *runner) {
+		if contentTypes != nil {
+			r.contentTypes = contentTypes
+		}
+		if contents != nil {
+			r.contents = contents
+		}
+	}
+}
+
+// WithBlockRepositories wires the repositories walked by block definition runs.
+// The instance version repository is optional.
+func WithBlockRepositories(definitions blocks.DefinitionRepository, definitionVersions blocks.DefinitionVersionRepository, instances blocks.InstanceRepository, translations blocks.TranslationRepository, versions blocks.InstanceVersionRepository) RunnerOption {
+	return func(r *runner) {
+		if definitions != nil {
+			r.definitions = definitions
+		}
+		if definitionVersions != nil {
+			r.definitionVersions = definitionVersions
+		}
+		if instances != nil {
+			r.instances = instances
+		}
+		if translations != nil {
+			r.blockTranslations = translations
+		}
+		if versions != nil {
+			r.instanceVersions = versions
+		}
+	}
+}
+
+// WithContentMigrator wires the code-registered content schema migrations.
+// Operations stored in content type schema history are applied on top.
+func WithContentMigrator(migrator *schema.Migrator) RunnerOption {
+	return func(r *runner) {
+		if migrator != nil {
+			r.contentMigrator = migrator
+		}
+	}
+}
+
+// WithBlockMigrator wires the code-registered block schema migrations.
+// Operations stored on definition versions are applied on top.
+func WithBlockMigrator(migrator *blocks.Migrator) RunnerOption {
+	return func(r *runner) {
+		if migrator != nil {
+			r.blockMigrator = migrator
+		}
+	}
+}
+
+// WithEmbeddedBlocksResolver wires the resolver used to migrate blocks
+// embedded in content payloads.
+func WithEmbeddedBlocksResolver(resolver content.EmbeddedBlocksResolver) RunnerOption {
+	return func(r *runner) {
+		if resolver != nil {
+			r.embeddedBlocks = resolver
+		}
+	}
+}
+
+// WithClock overrides the clock used for timestamps.
+func WithClock(clock func() time.Time) RunnerOption {
+	return func(r *runner) {
+		if clock != nil {
+			r.now = clock
+		}
+	}
+}
+
+// WithIDGenerator overrides the ID generator used for new runs.
+func WithIDGenerator(generator func() uuid.UUID) RunnerOption {
+	return func(r *runner) {
+		if generator != nil {
+			r.id = generator
+		}
+	}
+}
+
+type runner struct {
+	runs               RunRepository
+	contentTypes       content.ContentTypeRepository
+	contents           content.ContentRepository
+	definitions        blocks.DefinitionRepository
+	definitionVersions blocks.DefinitionVersionRepository
+	instances          blocks.InstanceRepository
+	blockTranslations  blocks.TranslationRepository
+	instanceVersions   blocks.InstanceVersionRepository
+	contentMigrator    *schema.Migrator
+	blockMigrator      *blocks.Migrator
+	embeddedBlocks     content.EmbeddedBlocksResolver
+	now                func() time.Time
+	id                 func() uuid.UUID
+}
+
+// NewRunner constructs a bulk migration runner. Runs are kept in memory
+// unless a run repository is provided.
+func NewRunner(opts ...RunnerOption) Runner {
+	r := &runner{
+		runs: NewMemoryRunRepository(),
+		now:  func() time.Time { return time.Now().UTC() },
+		id:   uuid.New,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// payloadMigration migrates one localized payload of a record.
+type payloadMigration func(ctx context.Context, locale string, payload map[string]any) (map[string]any, error)
+
+// runTarget holds the resolved schema owner of a run.
+type runTarget struct {
+	contentType *content.ContentType
+	definition  *blocks.Definition
+	slug        string
+	version     string
+	migrate     payloadMigration
+}
+
+func (r *runner) Start(ctx context.Context, req RunRequest) (*Run, error) {
+	if req.Kind != KindContentType && req.Kind != KindBlockDefinition {
+		return nil, ErrRunKindInvalid
+	}
+	if req.TargetID == uuid.Nil {
+		return nil, ErrRunTargetRequired
+	}
+	target, err := r.resolveTarget(ctx, req.Kind, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Options.DryRun {
+		active, err := r.runs.List(ctx, RunFilter{Kind: req.Kind, TargetID: &req.TargetID, Status: RunRunning})
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range active {
+			if !run.Options.DryRun {
+				return nil, ErrRunInProgress
+			}
+		}
+	}
+	if req.Options.BatchSize <= 0 {
+		req.Options.BatchSize = defaultRunBatchSize
+	}
+
+	now := r.now()
+	run, err := r.runs.Create(ctx, &Run{
+		ID:            r.id(),
+		Kind:          req.Kind,
+		TargetID:      req.TargetID,
+		TargetSlug:    target.slug,
+		TargetVersion: target.version,
+		Status:        RunRunning,
+		Options:       req.Options,
+		Checkpoint:    RunCheckpoint{Phase: r.phases(req.Kind)[0]},
+		CreatedBy:     req.Options.ActorID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.execute(ctx, run, target)
+}
+
+func (r *runner) Resume(ctx context.Context, id uuid.UUID) (*Run, error) {
+	run, err := r.runs.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run.Finished() {
+		return run, ErrRunFinished
+	}
+	target, err := r.resolveTarget(ctx, run.Kind, run.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	// Resumed runs migrate to the schema version current at resume time.
+	run.TargetSlug = target.slug
+	run.TargetVersion = target.version
+	run.Status = RunRunning
+	return r.execute(ctx, run, target)
+}
+
+func (r *runner) GetRun(ctx context.Context, id uuid.UUID) (*Run, error) {
+	return r.runs.GetByID(ctx, id)
+}
+
+func (r *runner) ListRuns(ctx context.Context, filter RunFilter) ([]*Run, error) {
+	return r.runs.List(ctx, filter)
+}
+
+func (r *runner) phases(kind RunKind) []string {
+	if kind == KindContentType {
+		return []string{PhaseContentEntries}
+	}
+	if r.contents != nil && r.embeddedBlocks != nil {
+		return []string{PhaseBlockInstances, PhaseEmbeddedBlocks}
+	}
+	return []string{PhaseBlockInstances}
+}
+
+func (r *runner) resolveTarget(ctx context.Context, kind RunKind, id uuid.UUID) (*runTarget, error) {
+	switch kind {
+	case KindContentType:
+		if r.contentTypes == nil || r.contents == nil {
+			return nil, ErrRunnerNotConfigured
+		}
+		contentType, err := r.contentTypes.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		migrator := content.ContentTypeMigrator(r.contentMigrator, contentType)
+		return &runTarget{
+			contentType: contentType,
+			slug:        contentType.Slug,
+			version:     contentType.SchemaVersion,
+			migrate: func(ctx context.Context, locale string, payload map[string]any) (map[string]any, error) {
+				migrated, _, err := content.MigrateContentPayload(migrator, contentType, payload)
+				if err != nil {
+					return nil, err
+				}
+				return r.migrateEmbeddedBlocks(ctx, locale, migrated, nil)
+			},
+		}, nil
+	case KindBlockDefinition:
+		if r.definitions == nil || r.instances == nil || r.blockTranslations == nil {
+			return nil, ErrRunnerNotConfigured
+		}
+		definition, err := r.definitions.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		var versions []*blocks.DefinitionVersion
+		if r.definitionVersions != nil {
+			versions, err = r.definitionVersions.ListByDefinition(ctx, definition.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		migrator := blocks.DefinitionMigrator(r.blockMigrator, definition, versions)
+		return &runTarget{
+			definition: definition,
+			slug:       definition.Slug,
+			version:    definition.SchemaVersion,
+			migrate: func(_ context.Context, _ string, payload map[string]any) (map[string]any, error) {
+				migrated, _, err := blocks.MigrateDefinitionPayload(migrator, definition, payload)
+				return migrated, err
+			},
+		}, nil
+	default:
+		return nil, ErrRunKindInvalid
+	}
+}
+
+func (r *runner) execute(ctx context.Context, run *Run, target *runTarget) (*Run, error) {
+	if target.definition != nil && !run.Options.DryRun {
+		if err := r.setDefinitionStatus(ctx, target.definition.ID, blocks.MigrationStatusMigrating); err != nil {
+			return r.interrupt(ctx, run, err)
+		}
+	}
+
+	phases := r.phases(run.Kind)
+	start := max(slices.Index(phases, run.Checkpoint.Phase), 0)
+	for _, phase := range phases[start:] {
+		if run.Checkpoint.Phase != phase {
+			run.Checkpoint = RunCheckpoint{Phase: phase}
+		}
+		if err := r.runPhase(ctx, run, target, phase); err != nil {
+			return r.interrupt(ctx, run, err)
+		}
+	}
+
+	now := r.now()
+	run.Status = RunCompleted
+	if len(run.Failures) > 0 {
+		run.Status = RunPartial
+	}
+	run.UpdatedAt = now
+	run.CompletedAt = &now
+	updated, err := r.runs.Update(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.definition != nil && !run.Options.DryRun {
+		status := blocks.MigrationStatusFailed
+		if len(run.Failures) == 0 {
+			status = blocks.ResolveDefinitionMigrationStatus(target.definition.Schema, target.definition.SchemaVersion)
+		}
+		if err := r.setDefinitionStatus(ctx, target.definition.ID, status); err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+// interrupt records a stopped run so it can be resumed from its checkpoint.
+func (r *runner) interrupt(ctx context.Context, run *Run, cause error) (*Run, error) {
+	run.Status = RunInterrupted
+	run.UpdatedAt = r.now()
+	updated, err := r.runs.Update(context.WithoutCancel(ctx), run)
+	if err != nil {
+		return nil, errors.Join(cause, err)
+	}
+	return updated, cause
+}
+
+func (r *runner) setDefinitionStatus(ctx context.Context, id uuid.UUID, status string) error {
+	definition, err := r.definitions.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if definition.MigrationStatus == status {
+		return nil
+	}
+	definition.MigrationStatus = status
+	definition.UpdatedAt = r.now()
+	_, err = r.definitions.Update(ctx, definition)
+	return err
+}
+
+func (r *runner) runPhase(ctx context.Context, run *Run, target *runTarget, phase string) error {
+	ids, err := r.phaseRecordIDs(ctx, target, phase)
+	if err != nil {
+		return err
+	}
+	if last := run.Checkpoint.LastID; last != uuid.Nil {
+		ids = slices.DeleteFunc(ids, func(id uuid.UUID) bool { return compareIDs(id, last) <= 0 })
+	}
+
+	for batch := range slices.Chunk(ids, run.Options.BatchSize) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, id := range batch {
+			failures, changed, err := r.migrateRecord(ctx, run, target, phase, id)
+			if err != nil {
+				return err
+			}
+			run.Summary.Scanned++
+			switch {
+			case len(failures) > 0:
+				run.Summary.Failed++
+				run.Failures = append(run.Failures, failures...)
+			case changed:
+				run.Summary.Migrated++
+			default:
+				run.Summary.Unchanged++
+			}
+		}
+		run.Checkpoint = RunCheckpoint{Phase: phase, LastID: batch[len(batch)-1]}
+		run.UpdatedAt = r.now()
+		updated, err := r.runs.Update(ctx, run)
+		if err != nil {
+			return err
+		}
+		*run = *updated
+		if err := throttle(ctx, run.Options.Throttle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *runner) phaseRecordIDs(ctx context.Context, target *runTarget, phase string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	switch phase {
+	case PhaseContentEntries:
+		records, err := r.contents.List(ctx, contentListOptions(target.contentType.EnvironmentID, content.WithContentTypeID(target.contentType.ID))...)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record != nil && record.ContentTypeID == target.contentType.ID {
+				ids = append(ids, record.ID)
+			}
+		}
+	case PhaseBlockInstances:
+		instances, err := r.instances.ListByDefinition(ctx, target.definition.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances {
+			if instance != nil {
+				ids = append(ids, instance.ID)
+			}
+		}
+	case PhaseEmbeddedBlocks:
+		records, err := r.contents.List(ctx, contentListOptions(target.definition.EnvironmentID)...)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record != nil {
+				ids = append(ids, record.ID)
+			}
+		}
+	}
+	slices.SortFunc(ids, compareIDs)
+	return ids, nil
+}
+
+func (r *runner) migrateRecord(ctx context.Context, run *Run, target *runTarget, phase string, id uuid.UUID) ([]RunFailure, bool, error) {
+	switch phase {
+	case PhaseContentEntries:
+		return r.migrateContentRecord(ctx, run, phase, id, target.migrate)
+	case PhaseEmbeddedBlocks:
+		slug := target.definition.Slug
+		migrate := func(ctx context.Context, locale string, payload map[string]any) (map[string]any, error) {
+			return r.migrateEmbeddedBlocks(ctx, locale, payload, func(block map[string]any) bool {
+				blockType, _ := block[content.EmbeddedBlockTypeKey].(string)
+				return strings.EqualFold(strings.TrimSpace(blockType), slug)
+			})
+		}
+		return r.migrateContentRecord(ctx, run, phase, id, migrate)
+	case PhaseBlockInstances:
+		return r.migrateBlockInstance(ctx, run, phase, id, target.migrate)
+	default:
+		return nil, false, fmt.Errorf("migrations: unknown run phase %q", phase)
+	}
+}
+
+// migrateContentRecord migrates the translations and version snapshots of a
+// content entry. Entries with any failed payload are left untouched.
+func (r *runner) migrateContentRecord(ctx context.Context, run *Run, phase string, id uuid.UUID, migrate payloadMigration) ([]RunFailure, bool, error) {
+	stored, err := r.contentTranslations(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	var failures []RunFailure
+	changed := false
+
+	translations := make([]*content.ContentTranslation, 0, len(stored))
+	for _, tr := range stored {
+		if tr == nil {
+			continue
+		}
+		cloned := *tr
+		locale := contentTranslationLocale(tr)
+		migrated, err := migrate(ctx, locale, tr.Content)
+		if err != nil {
+			failures = append(failures, RunFailure{Phase: phase, Kind: RecordContentTranslation, RecordID: id, Locale: locale, Error: err.Error()})
+			continue
+		}
+		if !reflect.DeepEqual(tr.Content, migrated) {
+			cloned.Content = migrated
+			changed = true
+		}
+		translations = append(translations, &cloned)
+	}
+
+	versions, err := r.contents.ListVersions(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	var changedVersions []*content.ContentVersion
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		snapshot := version.Snapshot
+		snapshot.Translations = slices.Clone(version.Snapshot.Translations)
+		versionChanged := false
+		for idx, tr := range snapshot.Translations {
+			migrated, err := migrate(ctx, tr.Locale, tr.Content)
+			if err != nil {
+				failures = append(failures, RunFailure{Phase: phase, Kind: RecordContentVersion, RecordID: id, Locale: tr.Locale, Version: version.Version, Error: err.Error()})
+				continue
+			}
+			if !reflect.DeepEqual(tr.Content, migrated) {
+				snapshot.Translations[idx].Content = migrated
+				versionChanged = true
+			}
+		}
+		if versionChanged {
+			cloned := *version
+			cloned.Snapshot = snapshot
+			changedVersions = append(changedVersions, &cloned)
+		}
+	}
+
+	changed = changed || len(changedVersions) > 0
+	if len(failures) > 0 || !changed || run.Options.DryRun {
+		return failures, changed, nil
+	}
+	if err := r.contents.ReplaceTranslations(ctx, id, translations); err != nil {
+		return nil, false, err
+	}
+	for _, version := range changedVersions {
+		if _, err := r.contents.UpdateVersion(ctx, version); err != nil {
+			return nil, false, err
+		}
+	}
+	return nil, true, nil
+}
+
+func (r *runner) contentTranslations(ctx context.Context, id uuid.UUID) ([]*content.ContentTranslation, error) {
+	if reader, ok := r.contents.(content.ContentTranslationReader); ok {
+		return reader.ListTranslations(ctx, id)
+	}
+	record, err := r.contents.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return record.Translations, nil
+}
+
+// migrateBlockInstance migrates the translations and version snapshots of a
+// block instance. Instances with any failed payload are left untouched.
+func (r *runner) migrateBlockInstance(ctx context.Context, run *Run, phase string, id uuid.UUID, migrate payloadMigration) ([]RunFailure, bool, error) {
+	translations, err := r.blockTranslations.ListByInstance(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	var failures []RunFailure
+	var changedTranslations []*blocks.Translation
+	for _, tr := range translations {
+		if tr == nil {
+			continue
+		}
+		locale := tr.LocaleID.String()
+		migrated, err := migrate(ctx, locale, tr.Content)
+		if err != nil {
+			failures = append(failures, RunFailure{Phase: phase, Kind: RecordBlockTranslation, RecordID: id, Locale: locale, Error: err.Error()})
+			continue
+		}
+		if !reflect.DeepEqual(tr.Content, migrated) {
+			cloned := *tr
+			cloned.Content = migrated
+			changedTranslations = append(changedTranslations, &cloned)
+		}
+	}
+
+	var changedVersions []*blocks.InstanceVersion
+	if r.instanceVersions != nil {
+		versions, err := r.instanceVersions.ListByInstance(ctx, id)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, version := range versions {
+			if version == nil {
+				continue
+			}
+			snapshot := version.Snapshot
+			snapshot.Translations = slices.Clone(version.Snapshot.Translations)
+			versionChanged := false
+			for idx, tr := range snapshot.Translations {
+				migrated, err := migrate(ctx, tr.Locale, tr.Content)
+				if err != nil {
+					failures = append(failures, RunFailure{Phase: phase, Kind: RecordBlockVersion, RecordID: id, Locale: tr.Locale, Version: version.Version, Error: err.Error()})
+					continue
+				}
+				if !reflect.DeepEqual(tr.Content, migrated) {
+					snapshot.Translations[idx].Content = migrated
+					versionChanged = true
+				}
+			}
+			if versionChanged {
+				cloned := *version
+				cloned.Snapshot = snapshot
+				changedVersions = append(changedVersions, &cloned)
+			}
+		}
+	}
+
+	changed := len(changedTranslations) > 0 || len(changedVersions) > 0
+	if len(failures) > 0 || !changed || run.Options.DryRun {
+		return failures, changed, nil
+	}
+	now := r.now()
+	for _, tr := range changedTranslations {
+		tr.UpdatedAt = now
+		if _, err := r.blockTranslations.Update(ctx, tr); err != nil {
+			return nil, false, err
+		}
+	}
+	for _, version := range changedVersions {
+		if _, err := r.instanceVersions.Update(ctx, version); err != nil {
+			return nil, false, err
+		}
+	}
+	return nil, true, nil
+}
+
+// migrateEmbeddedBlocks migrates the embedded blocks of a content payload
+// that match the filter, or all blocks when match is nil. Payloads are
+// returned as-is when no resolver is configured or no block changed.
+func (r *runner) migrateEmbeddedBlocks(ctx context.Context, locale string, payload map[string]any, match func(map[string]any) bool) (map[string]any, error) {
+	if r.embeddedBlocks == nil {
+		return payload, nil
+	}
+	embedded, ok := content.ExtractEmbeddedBlocks(payload)
+	if !ok || len(embedded) == 0 {
+		return payload, nil
+	}
+	embedded = slices.Clone(embedded)
+	indexes := make([]int, 0, len(embedded))
+	selected := make([]map[string]any, 0, len(embedded))
+	for idx, block := range embedded {
+		if block != nil && (match == nil || match(block)) {
+			indexes = append(indexes, idx)
+			selected = append(selected, block)
+		}
+	}
+	if len(selected) == 0 {
+		return payload, nil
+	}
+	migrated, err := r.embeddedBlocks.MigrateEmbeddedBlocks(ctx, locale, selected)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for i, idx := range indexes {
+		if i < len(migrated) && !reflect.DeepEqual(embedded[idx], migrated[i]) {
+			embedded[idx] = migrated[i]
+			changed = true
+		}
+	}
+	if !changed {
+		return payload, nil
+	}
+	return content.MergeEmbeddedBlocks(payload, embedded), nil
+}
+
+func throttle(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func compareIDs(a, b uuid.UUID) int {
+	return strings.Compare(a.String(), b.String())
+}
+
+func contentListOptions(envID uuid.UUID, opts ...content.ContentListOption) []content.ContentListOption {
+	if envID != uuid.Nil {
+		opts = append(opts, content.ContentListOption(envID.String()))
+	}
+	return opts
+}
+
+func contentTranslationLocale(tr *content.ContentTranslation) string {
+	if tr.Locale != nil && strings.TrimSpace(tr.Locale.Code) != "" {
+		return tr.Locale.Code
+	}
+	return tr.LocaleID.String()
+}
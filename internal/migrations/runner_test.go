@@ -0,0 +1,314 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/migrations"
+	"github.com/goliatone/go-cms/internal/schema"
+	"github.com/google/uuid"
+)
+
+type runnerFixture struct {
+	contents     *content.MemoryContentRepository
+	contentTypes *content.MemoryContentTypeRepository
+	contentType  *content.ContentType
+	migrated     uuid.UUID
+	current      uuid.UUID
+	invalid      uuid.UUID
+}
+
+func newContentRunnerFixture(t *testing.T) *runnerFixture {
+	t.Helper()
+	ctx := context.Background()
+	fx := &runnerFixture{
+		contents:     content.NewMemoryContentRepository(),
+		contentTypes: content.NewMemoryContentTypeRepository(),
+	}
+	contentType, err := fx.contentTypes.Create(ctx, &content.ContentType{
+		ID:   uuid.New(),
+		Name: "Article",
+		Slug: "article",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+			},
+			"metadata": map[string]any{"schema_version": "article@v2.0.0"},
+		},
+		SchemaVersion: "article@v2.0.0",
+	})
+	if err != nil {
+		t.Fatalf("create content type: %v", err)
+	}
+	fx.contentType = contentType
+
+	fx.migrated = fx.createEntry(t, "legacy", map[string]any{"_schema": "article@v1.0.0", "headline": "Hello"})
+	fx.current = fx.createEntry(t, "current", map[string]any{"_schema": "article@v2.0.0", "title": "Current"})
+	fx.invalid = fx.createEntry(t, "invalid", map[string]any{"_schema": "article@v1.0.0", "headline": 42})
+	return fx
+}
+
+func (fx *runnerFixture) createEntry(t *testing.T, slug string, payload map[string]any) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	author := uuid.New()
+	record, err := fx.contents.Create(ctx, &content.Content{
+		ID:            uuid.New(),
+		ContentTypeID: fx.contentType.ID,
+		Slug:          slug,
+		Status:        "draft",
+		CreatedBy:     author,
+		UpdatedBy:     author,
+		Translations: []*content.ContentTranslation{{
+			ID:       uuid.New(),
+			LocaleID: uuid.New(),
+			Title:    slug,
+			Content:  payload,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("create content %s: %v", slug, err)
+	}
+	if _, err := fx.contents.CreateVersion(ctx, &content.ContentVersion{
+		ID:        uuid.New(),
+		ContentID: record.ID,
+		Version:   1,
+		Snapshot: content.ContentVersionSnapshot{
+			Translations: []content.ContentVersionTranslationSnapshot{{Locale: "en", Title: slug, Content: payload}},
+		},
+		CreatedBy: author,
+	}); err != nil {
+		t.Fatalf("create version %s: %v", slug, err)
+	}
+	return record.ID
+}
+
+func (fx *runnerFixture) translation(t *testing.T, id uuid.UUID) map[string]any {
+	t.Helper()
+	translations, err := fx.contents.ListTranslations(context.Background(), id)
+	if err != nil || len(translations) != 1 {
+		t.Fatalf("list translations: %v (%d)", err, len(translations))
+	}
+	return translations[0].Content
+}
+
+func (fx *runnerFixture) snapshot(t *testing.T, id uuid.UUID) map[string]any {
+	t.Helper()
+	version, err := fx.contents.GetVersion(context.Background(), id, 1)
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	return version.Snapshot.Translations[0].Content
+}
+
+func contentMigrator(t *testing.T) *schema.Migrator {
+	t.Helper()
+	registry := migrations.NewRegistry()
+	if err := registry.RegisterOperations("article", "article@v1.0.0", "article@v2.0.0", []schema.MigrationOperation{
+		{Op: schema.MigrationOpRename, Path: "headline", To: "title"},
+	}); err != nil {
+		t.Fatalf("register operations: %v", err)
+	}
+	return registry.Migrator()
+}
+
+func TestRunnerMigratesContentTypeEntries(t *testing.T) {
+	ctx := context.Background()
+	fx := newContentRunnerFixture(t)
+	runner := migrations.NewRunner(
+		migrations.WithContentRepositories(fx.contentTypes, fx.contents),
+		migrations.WithContentMigrator(contentMigrator(t)),
+	)
+
+	run, err := runner.Start(ctx, migrations.RunRequest{Kind: migrations.KindContentType, TargetID: fx.contentType.ID})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if run.Status != migrations.RunPartial || run.CompletedAt == nil {
+		t.Fatalf("expected partial completed run, got %s", run.Status)
+	}
+	expected := migrations.RunSummary{Scanned: 3, Migrated: 1, Unchanged: 1, Failed: 1}
+	if run.Summary != expected {
+		t.Fatalf("unexpected summary %+v", run.Summary)
+	}
+	if len(run.Failures) != 2 || run.Failures[0].RecordID != fx.invalid {
+		t.Fatalf("expected translation and version failures for invalid entry, got %+v", run.Failures)
+	}
+
+	for name, payload := range map[string]map[string]any{
+		"translation": fx.translation(t, fx.migrated),
+		"snapshot":    fx.snapshot(t, fx.migrated),
+	} {
+		if payload["title"] != "Hello" || payload["_schema"] != "article@v2.0.0" {
+			t.Fatalf("expected migrated %s, got %#v", name, payload)
+		}
+		if _, ok := payload["headline"]; ok {
+			t.Fatalf("expected headline removed from %s", name)
+		}
+	}
+	if payload := fx.translation(t, fx.invalid); payload["_schema"] != "article@v1.0.0" {
+		t.Fatalf("expected failed entry untouched, got %#v", payload)
+	}
+
+	if _, err := runner.Resume(ctx, run.ID); !errors.Is(err, migrations.ErrRunFinished) {
+		t.Fatalf("expected ErrRunFinished, got %v", err)
+	}
+}
+
+func TestRunnerDryRunLeavesPayloadsUntouched(t *testing.T) {
+	ctx := context.Background()
+	fx := newContentRunnerFixture(t)
+	runner := migrations.NewRunner(
+		migrations.WithContentRepositories(fx.contentTypes, fx.contents),
+		migrations.WithContentMigrator(contentMigrator(t)),
+	)
+
+	run, err := runner.Start(ctx, migrations.RunRequest{
+		Kind:     migrations.KindContentType,
+		TargetID: fx.contentType.ID,
+		Options:  migrations.RunOptions{DryRun: true},
+	})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if run.Summary.Migrated != 1 || run.Summary.Failed != 1 {
+		t.Fatalf("expected dry run to report pending migrations, got %+v", run.Summary)
+	}
+	if payload := fx.translation(t, fx.migrated); payload["headline"] != "Hello" {
+		t.Fatalf("expected dry run to skip writes, got %#v", payload)
+	}
+}
+
+// cancellingRunRepository cancels the run context after the first checkpoint.
+type cancellingRunRepository struct {
+	migrations.RunRepository
+	cancel  context.CancelFunc
+	updates int
+}
+
+func (r *cancellingRunRepository) Update(ctx context.Context, run *migrations.Run) (*migrations.Run, error) {
+	r.updates++
+	if r.updates == 1 {
+		r.cancel()
+	}
+	return r.RunRepository.Update(ctx, run)
+}
+
+func TestRunnerResumesFromCheckpoint(t *testing.T) {
+	fx := newContentRunnerFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := &cancellingRunRepository{RunRepository: migrations.NewMemoryRunRepository(), cancel: cancel}
+	runner := migrations.NewRunner(
+		migrations.WithRunRepository(repo),
+		migrations.WithContentRepositories(fx.contentTypes, fx.contents),
+		migrations.WithContentMigrator(contentMigrator(t)),
+	)
+
+	run, err := runner.Start(ctx, migrations.RunRequest{
+		Kind:     migrations.KindContentType,
+		TargetID: fx.contentType.ID,
+		Options:  migrations.RunOptions{BatchSize: 1},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+	if run.Status != migrations.RunInterrupted || run.Summary.Scanned != 1 || run.Checkpoint.LastID == uuid.Nil {
+		t.Fatalf("expected interrupted run after one batch, got %+v", run)
+	}
+
+	resumed, err := runner.Resume(context.Background(), run.ID)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if resumed.Summary.Scanned != 3 || resumed.Status != migrations.RunPartial {
+		t.Fatalf("expected resumed run to finish remaining records once, got %+v", resumed.Summary)
+	}
+}
+
+func TestRunnerMigratesBlockDefinitionInstances(t *testing.T) {
+	ctx := context.Background()
+	definitions := blocks.NewMemoryDefinitionRepository()
+	instances := blocks.NewMemoryInstanceRepository()
+	translations := blocks.NewMemoryTranslationRepository()
+	versions := blocks.NewMemoryInstanceVersionRepository()
+
+	definition, err := definitions.Create(ctx, &blocks.Definition{
+		ID:   uuid.New(),
+		Name: "Hero",
+		Slug: "hero",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+			},
+			"metadata": map[string]any{"schema_version": "hero@v2.0.0"},
+		},
+		SchemaVersion: "hero@v2.0.0",
+	})
+	if err != nil {
+		t.Fatalf("create definition: %v", err)
+	}
+	instance, err := instances.Create(ctx, &blocks.Instance{
+		ID:           uuid.New(),
+		DefinitionID: definition.ID,
+		Region:       "main",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	translation, err := translations.Create(ctx, &blocks.Translation{
+		ID:              uuid.New(),
+		BlockInstanceID: instance.ID,
+		LocaleID:        uuid.New(),
+		Content:         map[string]any{"_schema": "hero@v1.0.0", "headline": "Welcome"},
+	})
+	if err != nil {
+		t.Fatalf("create translation: %v", err)
+	}
+
+	migrator := blocks.NewMigrator()
+	if err := migrator.RegisterOperations("hero", "hero@v1.0.0", "hero@v2.0.0", []blocks.SchemaMigrationOperation{
+		{Op: schema.MigrationOpRename, Path: "headline", To: "title"},
+	}); err != nil {
+		t.Fatalf("register operations: %v", err)
+	}
+	runner := migrations.NewRunner(
+		migrations.WithBlockRepositories(definitions, nil, instances, translations, versions),
+		migrations.WithBlockMigrator(migrator),
+	)
+
+	run, err := runner.Start(ctx, migrations.RunRequest{Kind: migrations.KindBlockDefinition, TargetID: definition.ID})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if run.Status != migrations.RunCompleted || run.Summary.Migrated != 1 {
+		t.Fatalf("expected completed run, got %s %+v", run.Status, run.Summary)
+	}
+
+	stored, err := translations.GetByInstanceAndLocale(ctx, instance.ID, translation.LocaleID)
+	if err != nil {
+		t.Fatalf("get translation: %v", err)
+	}
+	if stored.Content["title"] != "Welcome" || stored.Content["_schema"] != "hero@v2.0.0" {
+		t.Fatalf("expected migrated block payload, got %#v", stored.Content)
+	}
+	updated, err := definitions.GetByID(ctx, definition.ID)
+	if err != nil {
+		t.Fatalf("get definition: %v", err)
+	}
+	if updated.MigrationStatus != blocks.MigrationStatusCurrent {
+		t.Fatalf("expected migration status current, got %q", updated.MigrationStatus)
+	}
+
+	runs, err := runner.ListRuns(ctx, migrations.RunFilter{TargetID: &definition.ID})
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("expected one recorded run, got %d (%v)", len(runs), err)
+	}
+}
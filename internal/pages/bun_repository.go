@@ -7,6 +7,8 @@ import (
 	"time"
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
+	"github.com/goliatone/go-cms/internal/trash"
 	goerrors "github.com/goliatone/go-errors"
 	repository "github.com/goliatone/go-repository-bun"
 	"github.com/goliatone/go-repository-cache/cache"
@@ -64,15 +66,15 @@ func (r *BunPageRepository) GetBySlug(ctx context.Context, slug string, env ...s
 		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
 			return applyEnvironmentFilter(q, normalizedEnv)
 		}),
-		repository.SelectPaginate(1, 0),
 	)
 	if err != nil {
 		return nil, mapRepositoryError(err, "page", slug)
 	}
-	if len(records) == 0 {
+	record, ok := cmssites.Select(ctx, records, pageSiteID)
+	if !ok {
 		return nil, &PageNotFoundError{Key: slug}
 	}
-	return records[0], nil
+	return record, nil
 }
 
 func (r *BunPageRepository) List(ctx context.Context, env ...string) ([]*Page, error) {
@@ -247,6 +249,16 @@ func (r *BunPageRepository) UpdateVersion(ctx context.Context, version *PageVers
 	return updated, nil
 }
 
+func (r *BunPageRepository) DeleteVersions(ctx context.Context, pageID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	return r.versions.DeleteMany(ctx,
+		repository.DeleteBy("page_id", "=", pageID.String()),
+		repository.DeleteColumnIn("version", numbers),
+	)
+}
+
 func (r *BunPageRepository) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
 	if !hardDelete {
 		return fmt.Errorf("page repository: soft delete not supported")
@@ -256,38 +268,101 @@ func (r *BunPageRepository) Delete(ctx context.Context, id uuid.UUID, hardDelete
 	}
 
 	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		if _, err := tx.NewDelete().
-			Model((*PageTranslation)(nil)).
-			Where("?TableAlias.page_id = ?", id).
-			Exec(ctx); err != nil {
-			return fmt.Errorf("delete page translations: %w", err)
-		}
+		return deletePageGraph(ctx, tx, id)
+	})
+}
 
-		if _, err := tx.NewDelete().
-			Model((*PageVersion)(nil)).
-			Where("?TableAlias.page_id = ?", id).
-			Exec(ctx); err != nil {
-			return fmt.Errorf("delete page versions: %w", err)
+// DeleteToTrash stores the trash entry and removes the listed pages in one
+// transaction, so a failed delete never leaves a dangling snapshot or a
+// partially removed subtree. Pages are removed in the given order.
+func (r *BunPageRepository) DeleteToTrash(ctx context.Context, ids []uuid.UUID, entry *trash.Entry) error {
+	if r.db == nil {
+		return fmt.Errorf("page repository: database not configured")
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := trash.Insert(ctx, tx, entry); err != nil {
+			return fmt.Errorf("insert trash entry: %w", err)
 		}
-
-		result, err := tx.NewDelete().
-			Model((*Page)(nil)).
-			Where("?TableAlias.id = ?", id).
-			Exec(ctx)
-		if err != nil {
-			return fmt.Errorf("delete page: %w", err)
+		for _, id := range ids {
+			if err := deletePageGraph(ctx, tx, id); err != nil {
+				return err
+			}
 		}
-		affected, err := result.RowsAffected()
-		if err != nil {
-			return fmt.Errorf("page delete rows affected: %w", err)
+		return nil
+	})
+}
+
+// RestoreFromTrash inserts the pages with their translations and versions and
+// removes the trash entry in one transaction. Records are inserted in the
+// given order, so parents must come before their children.
+func (r *BunPageRepository) RestoreFromTrash(ctx context.Context, entryID uuid.UUID, records []*Page) error {
+	if r.db == nil {
+		return fmt.Errorf("page repository: database not configured")
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, record := range records {
+			if record == nil {
+				continue
+			}
+			if _, err := tx.NewInsert().Model(record).Exec(ctx); err != nil {
+				return fmt.Errorf("insert page: %w", err)
+			}
+			for _, tr := range record.Translations {
+				if tr == nil {
+					continue
+				}
+				if _, err := tx.NewInsert().Model(tr).Exec(ctx); err != nil {
+					return fmt.Errorf("insert page translation: %w", err)
+				}
+			}
+			for _, version := range record.Versions {
+				if version == nil {
+					continue
+				}
+				if _, err := tx.NewInsert().Model(version).Exec(ctx); err != nil {
+					return fmt.Errorf("insert page version: %w", err)
+				}
+			}
 		}
-		if affected == 0 {
-			return &PageNotFoundError{Key: id.String()}
+		if err := trash.Remove(ctx, tx, entryID); err != nil {
+			return fmt.Errorf("remove trash entry: %w", err)
 		}
 		return nil
 	})
 }
 
+func deletePageGraph(ctx context.Context, tx bun.Tx, id uuid.UUID) error {
+	if _, err := tx.NewDelete().
+		Model((*PageTranslation)(nil)).
+		Where("?TableAlias.page_id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("delete page translations: %w", err)
+	}
+
+	if _, err := tx.NewDelete().
+		Model((*PageVersion)(nil)).
+		Where("?TableAlias.page_id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("delete page versions: %w", err)
+	}
+
+	result, err := tx.NewDelete().
+		Model((*Page)(nil)).
+		Where("?TableAlias.id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete page: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("page delete rows affected: %w", err)
+	}
+	if affected == 0 {
+		return &PageNotFoundError{Key: id.String()}
+	}
+	return nil
+}
+
 func normalizeEnvironmentKey(env ...string) string {
 	if len(env) == 0 {
 		return ""
@@ -0,0 +1,121 @@
+package pages_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmsexperiments "github.com/goliatone/go-cms/experiments"
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/experiments"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/google/uuid"
+)
+
+func TestPageServiceAppliesBlockExperimentVariants(t *testing.T) {
+	ctx := context.Background()
+	contentStore := content.NewMemoryContentRepository()
+	contentTypeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+	pageStore := pages.NewMemoryPageRepository()
+
+	contentTypeID := uuid.New()
+	seedContentType(t, contentTypeStore, &content.ContentType{ID: contentTypeID, Name: "page"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	contentSvc := content.NewService(contentStore, contentTypeStore, localeStore)
+	contentRecord, err := contentSvc.Create(ctx, content.CreateContentRequest{
+		ContentTypeID: contentTypeID,
+		Slug:          "landing",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Landing"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	blockInstRepo := blocks.NewMemoryInstanceRepository()
+	blockSvc := blocks.NewService(blocks.NewMemoryDefinitionRepository(), blockInstRepo, blocks.NewMemoryTranslationRepository())
+	definition, err := blockSvc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title", "layout"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+
+	experimentSvc := experiments.NewService(experiments.NewMemoryRepository(), experiments.WithBlockService(blockSvc, blockInstRepo))
+	pageSvc := pages.NewService(pageStore, contentStore, localeStore,
+		pages.WithBlockService(blockSvc),
+		pages.WithVariantSelector(experimentSvc),
+		pages.WithPageClock(func() time.Time { return time.Unix(0, 0) }),
+	)
+	page, err := pageSvc.Create(ctx, pages.CreatePageRequest{
+		ContentID:    contentRecord.ID,
+		TemplateID:   uuid.New(),
+		Slug:         "landing",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+		Translations: []pages.PageTranslationInput{{Locale: "en", Title: "Landing", Path: "/landing"}},
+	})
+	if err != nil {
+		t.Fatalf("create page: %v", err)
+	}
+	instance, err := blockSvc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID:  definition.ID,
+		PageID:        &page.ID,
+		Region:        "hero",
+		Configuration: map[string]any{"title": "Welcome", "layout": "full"},
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create block instance: %v", err)
+	}
+
+	experiment, err := experimentSvc.CreateExperiment(ctx, experiments.CreateExperimentInput{
+		Key:        "hero-title",
+		TargetType: experiments.TargetBlockInstance,
+		TargetID:   instance.ID,
+		Variants: []experiments.Variant{
+			{Key: "control", Weight: 0},
+			{Key: "direct", Weight: 1, Configuration: map[string]any{"title": "Start now"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create experiment: %v", err)
+	}
+	if _, err := experimentSvc.StartExperiment(ctx, experiments.TransitionExperimentRequest{ID: experiment.ID}); err != nil {
+		t.Fatalf("start experiment: %v", err)
+	}
+
+	anonymous, err := pageSvc.Get(ctx, page.ID)
+	if err != nil {
+		t.Fatalf("get page: %v", err)
+	}
+	if block := anonymous.Blocks[0]; block.Configuration["title"] != "Welcome" || block.Variant != "" {
+		t.Fatalf("expected base block without a visitor key, got %+v", block)
+	}
+
+	visitor, err := pageSvc.Get(cmsexperiments.WithVisitorKey(ctx, "visitor-1"), page.ID)
+	if err != nil {
+		t.Fatalf("get page for visitor: %v", err)
+	}
+	block := visitor.Blocks[0]
+	if block.Configuration["title"] != "Start now" || block.Configuration["layout"] != "full" {
+		t.Fatalf("expected variant configuration, got %+v", block.Configuration)
+	}
+	if block.Experiment != "hero-title" || block.Variant != "direct" {
+		t.Fatalf("expected experiment metadata, got %q/%q", block.Experiment, block.Variant)
+	}
+
+	stored, err := blockInstRepo.GetByID(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("get stored block: %v", err)
+	}
+	if stored.Configuration["title"] != "Welcome" {
+		t.Fatalf("expected stored block to keep its base configuration, got %+v", stored.Configuration)
+	}
+}
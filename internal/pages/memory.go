@@ -3,12 +3,14 @@ package pages
 import (
 	"context"
 	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/media"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
 	"github.com/google/uuid"
 )
 
@@ -16,7 +18,7 @@ import (
 type MemoryPageRepository struct {
 	mu        sync.RWMutex
 	pages     map[uuid.UUID]*Page
-	slugIndex map[string]uuid.UUID
+	slugIndex map[string][]uuid.UUID
 	versions  map[uuid.UUID][]*PageVersion
 }
 
@@ -24,7 +26,7 @@ type MemoryPageRepository struct {
 func NewMemoryPageRepository() *MemoryPageRepository {
 	return &MemoryPageRepository{
 		pages:     make(map[uuid.UUID]*Page),
-		slugIndex: make(map[string]uuid.UUID),
+		slugIndex: make(map[string][]uuid.UUID),
 		versions:  make(map[uuid.UUID][]*PageVersion),
 	}
 }
@@ -41,7 +43,10 @@ func (m *MemoryPageRepository) Create(_ context.Context, record *Page) (*Page, e
 		m.versions[copied.ID] = nil
 	}
 	m.pages[copied.ID] = copied
-	m.slugIndex[pageSlugKey(copied.EnvironmentID, copied.Slug)] = copied.ID
+	key := pageSlugKey(copied.EnvironmentID, copied.Slug)
+	if !slices.Contains(m.slugIndex[key], copied.ID) {
+		m.slugIndex[key] = append(m.slugIndex[key], copied.ID)
+	}
 	return m.attachVersions(clonePage(copied)), nil
 }
 
@@ -56,16 +61,23 @@ func (m *MemoryPageRepository) GetByID(_ context.Context, id uuid.UUID) (*Page,
 	return m.attachVersions(clonePage(page)), nil
 }
 
-// GetBySlug retrieves a page by slug.
-func (m *MemoryPageRepository) GetBySlug(_ context.Context, slug string, env ...string) (*Page, error) {
+// GetBySlug retrieves the page with slug visible to the site carried by ctx.
+func (m *MemoryPageRepository) GetBySlug(ctx context.Context, slug string, env ...string) (*Page, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	envID := resolveEnvironmentID(uuid.Nil, resolveEnvironmentKey(env...))
-	id, ok := m.slugIndex[pageSlugKey(envID, slug)]
+	ids := m.slugIndex[pageSlugKey(envID, slug)]
+	candidates := make([]*Page, 0, len(ids))
+	for _, id := range ids {
+		if record := m.pages[id]; record != nil {
+			candidates = append(candidates, record)
+		}
+	}
+	record, ok := cmssites.Select(ctx, candidates, pageSiteID)
 	if !ok {
 		return nil, &PageNotFoundError{Key: slug}
 	}
-	return m.attachVersions(clonePage(m.pages[id])), nil
+	return m.attachVersions(clonePage(record)), nil
 }
 
 // List returns every page.
@@ -160,7 +172,10 @@ func (m *MemoryPageRepository) Delete(_ context.Context, id uuid.UUID, hardDelet
 
 	delete(m.pages, id)
 	if slug := record.Slug; slug != "" {
-		delete(m.slugIndex, pageSlugKey(resolveEnvironmentID(record.EnvironmentID, ""), slug))
+		key := pageSlugKey(resolveEnvironmentID(record.EnvironmentID, ""), slug)
+		m.slugIndex[key] = slices.DeleteFunc(m.slugIndex[key], func(candidate uuid.UUID) bool {
+			return candidate == id
+		})
 	}
 	delete(m.versions, id)
 	return nil
@@ -231,6 +246,33 @@ func (m *MemoryPageRepository) UpdateVersion(_ context.Context, version *PageVer
 	return nil, &PageVersionNotFoundError{PageID: version.PageID, Version: version.Version}
 }
 
+// DeleteVersions removes the listed version numbers of a page.
+func (m *MemoryPageRepository) DeleteVersions(_ context.Context, pageID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	drop := make(map[int]struct{}, len(numbers))
+	for _, number := range numbers {
+		drop[number] = struct{}{}
+	}
+	queue := m.versions[pageID]
+	kept := make([]*PageVersion, 0, len(queue))
+	for _, version := range queue {
+		if version == nil {
+			continue
+		}
+		if _, ok := drop[version.Version]; ok {
+			continue
+		}
+		kept = append(kept, version)
+	}
+	m.versions[pageID] = kept
+	return nil
+}
+
 func resolveEnvironmentKey(env ...string) string {
 	if len(env) == 0 {
 		return ""
@@ -266,6 +308,10 @@ func matchesEnvironment(recordID, targetID uuid.UUID) bool {
 	return recordID == targetID
 }
 
+func pageSiteID(page *Page) *uuid.UUID {
+	return page.SiteID
+}
+
 func pageSlugKey(envID uuid.UUID, slug string) string {
 	return envID.String() + "|" + strings.TrimSpace(slug)
 }
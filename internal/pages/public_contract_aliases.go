@@ -9,6 +9,8 @@ type (
 	PageTranslationInput               = cmspages.PageTranslationInput
 	UpdatePageRequest                  = cmspages.UpdatePageRequest
 	DeletePageRequest                  = cmspages.DeletePageRequest
+	ListTrashedPagesRequest            = cmspages.ListTrashedPagesRequest
+	RestorePageRequest                 = cmspages.RestorePageRequest
 	UpdatePageTranslationRequest       = cmspages.UpdatePageTranslationRequest
 	DeletePageTranslationRequest       = cmspages.DeletePageTranslationRequest
 	MovePageRequest                    = cmspages.MovePageRequest
@@ -56,6 +58,7 @@ var (
 	ErrScheduleTimestampInvalid      = cmspages.ErrScheduleTimestampInvalid
 	ErrPageMediaReferenceRequired    = cmspages.ErrPageMediaReferenceRequired
 	ErrPageSoftDeleteUnsupported     = cmspages.ErrPageSoftDeleteUnsupported
+	ErrPageNotTrashed                = cmspages.ErrPageNotTrashed
 	ErrPageTranslationsDisabled      = cmspages.ErrPageTranslationsDisabled
 	ErrPageTranslationNotFound       = cmspages.ErrPageTranslationNotFound
 	ErrPageParentCycle               = cmspages.ErrPageParentCycle
@@ -0,0 +1,105 @@
+package pages
+
+import (
+	"context"
+
+	"github.com/goliatone/go-cms/internal/domain"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/google/uuid"
+)
+
+// pruneVersions removes the versions of a page that the retention
+// policy no longer keeps and returns the removed version numbers.
+func (s *pageService) pruneVersions(ctx context.Context, record *Page, actor uuid.UUID) ([]int, error) {
+	versions, err := s.pages.ListVersions(ctx, record.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]retention.Version, 0, len(versions))
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		candidates = append(candidates, retention.Version{
+			Number:    version.Version,
+			CreatedAt: version.CreatedAt,
+			Published: version.PublishedAt != nil || version.Status == domain.StatusPublished,
+		})
+	}
+	numbers := s.retention.Select(candidates, retention.Protected(record.CurrentVersion, record.PublishedVersion), s.now())
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	if err := s.pages.DeleteVersions(ctx, record.ID, numbers); err != nil {
+		return nil, err
+	}
+
+	s.log(ctx).Debug("page versions pruned", "page_id", record.ID, "versions", numbers)
+	s.emitActivity(ctx, actor, "prune_versions", "page", record.ID, map[string]any{
+		"versions":       numbers,
+		"environment_id": record.EnvironmentID.String(),
+	})
+	return numbers, nil
+}
+
+// PruneAllVersions applies the retention policy to every page in
+// every environment.
+func (s *pageService) PruneAllVersions(ctx context.Context) ([]retention.Pruned, error) {
+	if !s.versioningEnabled || !s.retention.Prunes() {
+		return nil, nil
+	}
+	envIDs, err := s.environmentIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []retention.Pruned
+	for _, envID := range envIDs {
+		records, err := s.pages.List(ctx, envID.String())
+		if err != nil {
+			return pruned, err
+		}
+		for _, record := range records {
+			if record == nil {
+				continue
+			}
+			numbers, err := s.pruneVersions(ctx, record, uuid.Nil)
+			if err != nil {
+				return pruned, err
+			}
+			if len(numbers) > 0 {
+				pruned = append(pruned, retention.Pruned{
+					Resource: retention.ResourcePage,
+					EntityID: record.ID,
+					Versions: numbers,
+				})
+			}
+		}
+	}
+	return pruned, nil
+}
+
+// environmentIDs lists the environments the service stores records in. Without
+// an environment service only the default environment is known.
+func (s *pageService) environmentIDs(ctx context.Context) ([]uuid.UUID, error) {
+	if s.envSvc == nil {
+		key := cmsenv.NormalizeKey(s.defaultEnvKey)
+		if key == "" {
+			key = cmsenv.DefaultKey
+		}
+		return []uuid.UUID{cmsenv.IDForKey(key)}, nil
+	}
+	envs, err := s.envSvc.ListEnvironments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(envs))
+	for _, env := range envs {
+		if env != nil {
+			ids = append(ids, env.ID)
+		}
+	}
+	return ids, nil
+}
+
+var _ retention.Source = (*pageService)(nil)
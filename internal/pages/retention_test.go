@@ -0,0 +1,84 @@
+package pages_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/google/uuid"
+)
+
+func TestPageServiceRetentionPrunesVersionsOutsideWindow(t *testing.T) {
+	contentStore := content.NewMemoryContentRepository()
+	contentTypeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+	pageStore := pages.NewMemoryPageRepository()
+
+	contentTypeID := uuid.New()
+	seedContentType(t, contentTypeStore, &content.ContentType{ID: contentTypeID, Name: "page"})
+	localeStore.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	contentSvc := content.NewService(contentStore, contentTypeStore, localeStore)
+	createdContent, err := contentSvc.Create(context.Background(), content.CreateContentRequest{
+		ContentTypeID: contentTypeID,
+		Slug:          "page-retained",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Retained"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	pageSvc := pages.NewService(
+		pageStore,
+		contentStore,
+		localeStore,
+		pages.WithPageClock(func() time.Time { return now }),
+		pages.WithPageVersioningEnabled(true),
+		pages.WithPageVersionRetentionPolicy(retention.Policy{Mode: retention.ModePrune, KeepWithin: 36 * time.Hour}),
+	)
+
+	ctx := context.Background()
+	page, err := pageSvc.Create(ctx, pages.CreatePageRequest{
+		ContentID:    createdContent.ID,
+		TemplateID:   uuid.New(),
+		Slug:         "retained",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+		Translations: []pages.PageTranslationInput{{Locale: "en", Title: "Retained", Path: "/retained"}},
+	})
+	if err != nil {
+		t.Fatalf("create page: %v", err)
+	}
+
+	for i := range 4 {
+		draft, err := pageSvc.CreateDraft(ctx, pages.CreatePageDraftRequest{PageID: page.ID})
+		if err != nil {
+			t.Fatalf("create draft %d: %v", i+1, err)
+		}
+		if i == 0 {
+			if _, err := pageSvc.PublishDraft(ctx, pages.PublishPagePublishRequest{PageID: page.ID, Version: draft.Version}); err != nil {
+				t.Fatalf("publish draft: %v", err)
+			}
+		}
+		now = now.Add(24 * time.Hour)
+	}
+
+	versions, err := pageStore.ListVersions(ctx, page.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	got := make([]int, 0, len(versions))
+	for _, version := range versions {
+		got = append(got, version.Version)
+	}
+	if want := []int{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected versions %v, got %v", want, got)
+	}
+}
@@ -10,15 +10,19 @@ import (
 	"time"
 
 	cmsapi "github.com/goliatone/go-cms/content"
+	"github.com/goliatone/go-cms/experiments"
 	"github.com/goliatone/go-cms/internal/blocks"
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/domain"
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/logging"
 	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/internal/retention"
 	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
 	"github.com/goliatone/go-cms/internal/themes"
 	"github.com/goliatone/go-cms/internal/translationconfig"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/internal/widgets"
 	"github.com/goliatone/go-cms/internal/workflow"
 	workflowsimple "github.com/goliatone/go-cms/internal/workflow/simple"
@@ -42,6 +46,16 @@ type PageRepository interface {
 	GetVersion(ctx context.Context, pageID uuid.UUID, number int) (*PageVersion, error)
 	GetLatestVersion(ctx context.Context, pageID uuid.UUID) (*PageVersion, error)
 	UpdateVersion(ctx context.Context, version *PageVersion) (*PageVersion, error)
+	DeleteVersions(ctx context.Context, pageID uuid.UUID, numbers []int) error
+}
+
+// PageTrasher is implemented by page repositories that can move a page subtree
+// to the trash and back in a single transaction. Repositories without
+// transactional support return errors.ErrUnsupported and the service falls
+// back to writing the entry through its trash repository.
+type PageTrasher interface {
+	DeleteToTrash(ctx context.Context, ids []uuid.UUID, entry *trash.Entry) error
+	RestoreFromTrash(ctx context.Context, entryID uuid.UUID, records []*Page) error
 }
 
 // PageTranslationReader exposes translation lookups when page records omit translations.
@@ -144,12 +158,13 @@ type pageService struct {
 	blocks                blocks.Service
 	embeddedBlocks        *blocks.EmbeddedBlockBridge
 	widgets               widgets.Service
+	variants              experiments.Selector
 	themes                themes.Service
 	media                 media.Service
 	now                   func() time.Time
 	id                    IDGenerator
 	versioningEnabled     bool
-	versionRetentionLimit int
+	retention             retention.Policy
 	scheduler             interfaces.Scheduler
 	schedulingEnabled     bool
 	logger                interfaces.Logger
@@ -165,6 +180,7 @@ type pageService struct {
 	defaultEnvKey         string
 	requireExplicitEnv    bool
 	requireActiveEnv      bool
+	trash                 trash.Repository
 }
 
 func WithBlockService(service blocks.Service) ServiceOption {
@@ -188,6 +204,16 @@ func WithWidgetService(svc widgets.Service) ServiceOption {
 	}
 }
 
+// WithVariantSelector wires the experiments selector used to bucket visitors
+// into block instance variants when pages are read.
+func WithVariantSelector(selector experiments.Selector) ServiceOption {
+	return func(ps *pageService) {
+		if selector != nil {
+			ps.variants = selector
+		}
+	}
+}
+
 func WithThemeService(svc themes.Service) ServiceOption {
 	return func(ps *pageService) {
 		ps.themes = svc
@@ -282,6 +308,16 @@ func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
 	}
 }
 
+// WithTrashRepository enables soft deletes through the trash. Deleted pages
+// are snapshotted with their descendants and can be restored later.
+func WithTrashRepository(repo trash.Repository) ServiceOption {
+	return func(s *pageService) {
+		if repo != nil {
+			s.trash = repo
+		}
+	}
+}
+
 // WithPageVersioningEnabled toggles versioning specific capabilities.
 func WithPageVersioningEnabled(enabled bool) ServiceOption {
 	return func(s *pageService) {
@@ -295,7 +331,18 @@ func WithPageVersionRetentionLimit(limit int) ServiceOption {
 		if limit < 0 {
 			limit = 0
 		}
-		s.versionRetentionLimit = limit
+		s.retention.KeepLast = limit
+	}
+}
+
+// WithPageVersionRetentionPolicy configures how page version history is
+// limited. It replaces any limit set by WithPageVersionRetentionLimit.
+func WithPageVersionRetentionPolicy(policy retention.Policy) ServiceOption {
+	return func(s *pageService) {
+		if policy.KeepLast < 0 {
+			policy.KeepLast = 0
+		}
+		s.retention = policy
 	}
 }
 
@@ -629,6 +676,10 @@ func (s *pageService) Create(ctx context.Context, req CreatePageRequest) (*Page,
 	if err := s.ensureEnvironmentActive(ctx, envID); err != nil {
 		return nil, err
 	}
+	siteID, err := resolvePageSiteID(req.SiteID, contentRecord.SiteID)
+	if err != nil {
+		return nil, err
+	}
 
 	if s.themes != nil {
 		if _, err := s.themes.GetTemplate(ctx, req.TemplateID); err != nil {
@@ -644,7 +695,7 @@ func (s *pageService) Create(ctx context.Context, req CreatePageRequest) (*Page,
 		}
 	}
 
-	if existing, err := s.pages.GetBySlug(ctx, slug, envID.String()); err == nil && existing != nil {
+	if existing, err := s.scopedPageBySlug(ctx, slug, envID.String(), siteID); err == nil && existing != nil {
 		logger.Warn("page slug already exists", "existing_page_id", existing.ID)
 		return nil, ErrSlugExists
 	} else if err != nil {
@@ -660,6 +711,7 @@ func (s *pageService) Create(ctx context.Context, req CreatePageRequest) (*Page,
 		ID:            s.id(),
 		ContentID:     req.ContentID,
 		EnvironmentID: envID,
+		SiteID:        siteID,
 		ParentID:      req.ParentID,
 		TemplateID:    req.TemplateID,
 		Slug:          slug,
@@ -689,6 +741,7 @@ func (s *pageService) Create(ctx context.Context, req CreatePageRequest) (*Page,
 			logger.Error("page list for conflict check failed", "error", err)
 			return nil, err
 		}
+		existingPages = pagesInSite(existingPages, siteID)
 		seenLocales := map[string]struct{}{}
 		for _, tr := range req.Translations {
 			code := strings.TrimSpace(tr.Locale)
@@ -909,6 +962,7 @@ func (s *pageService) Update(ctx context.Context, req UpdatePageRequest) (*Page,
 			logger.Error("page list failed", "error", err)
 			return nil, err
 		}
+		allPages = pagesInSite(allPages, existing.SiteID)
 
 		translations, err = s.buildPageTranslations(ctx, existing.ID, req.Translations, allPages, existing.Translations, now)
 		if err != nil {
@@ -982,12 +1036,15 @@ func (s *pageService) Update(ctx context.Context, req UpdatePageRequest) (*Page,
 	return record, nil
 }
 
-// Delete removes a page and associated scheduled jobs.
+// Delete removes a page and associated scheduled jobs. Soft deletes move the
+// page and its descendants to the trash when a trash repository is configured.
+// Only the deleted page emits activity and lifecycle events; for soft deletes
+// the metadata reports how many descendants were trashed with it.
 func (s *pageService) Delete(ctx context.Context, req DeletePageRequest) error {
 	if req.ID == uuid.Nil {
 		return ErrPageRequired
 	}
-	if !req.HardDelete {
+	if !req.HardDelete && s.trash == nil {
 		return ErrPageSoftDeleteUnsupported
 	}
 
@@ -1004,27 +1061,32 @@ func (s *pageService) Delete(ctx context.Context, req DeletePageRequest) error {
 		return err
 	}
 
-	if s.scheduler != nil {
-		if err := s.scheduler.CancelByKey(ctx, cmsscheduler.PagePublishJobKey(req.ID)); err != nil && !errors.Is(err, interfaces.ErrJobNotFound) {
-			logger.Warn("page publish job cancel failed", "error", err)
+	var trashed []*Page
+	if !req.HardDelete {
+		trashed, err = s.trashPage(ctx, record, req.DeletedBy, logger)
+		if err != nil {
+			logger.Error("page trash failed", "error", err)
+			return err
 		}
-		if err := s.scheduler.CancelByKey(ctx, cmsscheduler.PageUnpublishJobKey(req.ID)); err != nil && !errors.Is(err, interfaces.ErrJobNotFound) {
-			logger.Warn("page unpublish job cancel failed", "error", err)
+	} else {
+		s.cancelPageSchedules(ctx, req.ID, logger)
+		if err := s.pages.Delete(ctx, req.ID, true); err != nil {
+			logger.Error("page repository delete failed", "error", err)
+			return err
 		}
 	}
 
-	if err := s.pages.Delete(ctx, req.ID, true); err != nil {
-		logger.Error("page repository delete failed", "error", err)
-		return err
-	}
-
-	logger.Info("page deleted")
+	logger.Info("page deleted", "trashed", !req.HardDelete)
 	meta := map[string]any{
 		"slug":    record.Slug,
 		"status":  record.Status,
 		"locales": collectPageLocales(record),
 		"path":    primaryPagePath(record),
 	}
+	if !req.HardDelete {
+		meta["trashed"] = true
+		meta["descendants"] = len(trashed) - 1
+	}
 	if record.EnvironmentID != uuid.Nil {
 		meta["environment_id"] = record.EnvironmentID.String()
 	}
@@ -1034,6 +1096,18 @@ func (s *pageService) Delete(ctx context.Context, req DeletePageRequest) error {
 	return nil
 }
 
+func (s *pageService) cancelPageSchedules(ctx context.Context, pageID uuid.UUID, logger interfaces.Logger) {
+	if s.scheduler == nil {
+		return
+	}
+	if err := s.scheduler.CancelByKey(ctx, cmsscheduler.PagePublishJobKey(pageID)); err != nil && !errors.Is(err, interfaces.ErrJobNotFound) {
+		logger.Warn("page publish job cancel failed", "error", err)
+	}
+	if err := s.scheduler.CancelByKey(ctx, cmsscheduler.PageUnpublishJobKey(pageID)); err != nil && !errors.Is(err, interfaces.ErrJobNotFound) {
+		logger.Warn("page unpublish job cancel failed", "error", err)
+	}
+}
+
 // UpdateTranslation mutates a single localized entry without replacing the full set.
 func (s *pageService) UpdateTranslation(ctx context.Context, req UpdatePageTranslationRequest) (*PageTranslation, error) {
 	if !s.translationsEnabledFlag() {
@@ -1098,6 +1172,7 @@ func (s *pageService) UpdateTranslation(ctx context.Context, req UpdatePageTrans
 		logger.Error("page list failed", "error", err)
 		return nil, err
 	}
+	allPages = pagesInSite(allPages, record.SiteID)
 
 	title := strings.TrimSpace(req.Title)
 	if title == "" {
@@ -1393,7 +1468,7 @@ func (s *pageService) Duplicate(ctx context.Context, req DuplicatePageRequest) (
 		envID = resolvedID
 	}
 
-	slug, err := s.generateDuplicateSlug(ctx, req.Slug, source.Slug, envID.String())
+	slug, err := s.generateDuplicateSlug(ctx, req.Slug, source.Slug, envID.String(), source.SiteID)
 	if err != nil {
 		logger.Error("duplicate slug resolution failed", "error", err)
 		return nil, err
@@ -1432,6 +1507,7 @@ func (s *pageService) Duplicate(ctx context.Context, req DuplicatePageRequest) (
 		logger.Error("page list failed", "error", err)
 		return nil, err
 	}
+	allPages = pagesInSite(allPages, source.SiteID)
 
 	now := s.now()
 	createdBy := selectActor(req.CreatedBy, source.CreatedBy)
@@ -1451,6 +1527,7 @@ func (s *pageService) Duplicate(ctx context.Context, req DuplicatePageRequest) (
 		ID:            newPageID,
 		ContentID:     source.ContentID,
 		EnvironmentID: envID,
+		SiteID:        cloneUUIDPointer(source.SiteID),
 		ParentID:      parentPtr,
 		TemplateID:    source.TemplateID,
 		Slug:          slug,
@@ -1690,8 +1767,8 @@ func (s *pageService) CreateDraft(ctx context.Context, req CreatePageDraftReques
 	}
 	logger.Debug("page versions loaded", "count", len(existing))
 
-	if s.versionRetentionLimit > 0 && len(existing) >= s.versionRetentionLimit {
-		logger.Warn("page version retention limit reached", "limit", s.versionRetentionLimit)
+	if s.retention.Rejects(len(existing)) {
+		logger.Warn("page version retention limit reached", "limit", s.retention.KeepLast)
 		return nil, ErrVersionRetentionExceeded
 	}
 
@@ -1750,6 +1827,12 @@ func (s *pageService) CreateDraft(ctx context.Context, req CreatePageDraftReques
 	logger = logging.WithFields(logger, map[string]any{"version": created.Version})
 	logger.Info("page draft created")
 
+	if s.retention.PrunesOnWrite() {
+		if _, err := s.pruneVersions(ctx, page, page.UpdatedBy); err != nil {
+			logger.Warn("page version prune failed", "error", err)
+		}
+	}
+
 	return clonePageVersion(created), nil
 }
 
@@ -2033,6 +2116,7 @@ func (s *pageService) attachBlocks(ctx context.Context, pages []*Page) ([]*Page,
 		}
 	}
 
+	visitorKey := experiments.VisitorKeyFromContext(ctx)
 	enriched := make([]*Page, 0, len(pages))
 	for _, page := range pages {
 		if page == nil {
@@ -2053,6 +2137,9 @@ func (s *pageService) attachBlocks(ctx context.Context, pages []*Page) ([]*Page,
 				}
 				if len(embedded) > 0 {
 					combined := append(cloneBlockInstances(embedded), cloneBlockInstances(global)...)
+					if err := s.applyBlockVariants(ctx, combined, visitorKey); err != nil {
+						return nil, err
+					}
 					clone.Blocks = combined
 					enriched = append(enriched, &clone)
 					continue
@@ -2074,6 +2161,9 @@ func (s *pageService) attachBlocks(ctx context.Context, pages []*Page) ([]*Page,
 		}
 
 		combined := append(cloneBlockInstances(pageBlocks), cloneBlockInstances(global)...)
+		if err := s.applyBlockVariants(ctx, combined, visitorKey); err != nil {
+			return nil, err
+		}
 		clone.Blocks = combined
 		enriched = append(enriched, &clone)
 	}
@@ -2081,6 +2171,41 @@ func (s *pageService) attachBlocks(ctx context.Context, pages []*Page) ([]*Page,
 	return enriched, nil
 }
 
+// applyBlockVariants overlays the experiment variant the visitor is bucketed
+// into on each block instance configuration. Instances must already be clones.
+func (s *pageService) applyBlockVariants(ctx context.Context, instances []*blocks.Instance, visitorKey string) error {
+	if s.variants == nil || visitorKey == "" {
+		return nil
+	}
+	for _, instance := range instances {
+		if instance == nil {
+			continue
+		}
+		assignment, err := s.variants.SelectVariant(ctx, experiments.SelectVariantInput{
+			TargetType: experiments.TargetBlockInstance,
+			TargetID:   instance.ID,
+			VisitorKey: visitorKey,
+		})
+		if err != nil {
+			return err
+		}
+		if assignment == nil {
+			continue
+		}
+		configuration := deepCloneMap(instance.Configuration)
+		if configuration == nil {
+			configuration = make(map[string]any, len(assignment.Configuration))
+		}
+		for key, value := range deepCloneMap(assignment.Configuration) {
+			configuration[key] = value
+		}
+		instance.Configuration = configuration
+		instance.Experiment = assignment.ExperimentKey
+		instance.Variant = assignment.VariantKey
+	}
+	return nil
+}
+
 func (s *pageService) attachPreviewBlocks(ctx context.Context, page *Page, translations []*content.ContentTranslation) (*Page, error) {
 	if page == nil {
 		return nil, nil
@@ -2283,16 +2408,23 @@ func (s *pageService) attachWidgets(ctx context.Context, pages []*Page) ([]*Page
 			if template != nil && !areaDefinitionApplies(definition, template) {
 				continue
 			}
+			if !areaDefinitionMatchesSite(definition, page.SiteID) {
+				continue
+			}
 			if len(allowedRegions) > 0 {
 				if _, ok := allowedRegions[code]; !ok {
 					continue
 				}
 			}
 
-			resolved, err := s.widgets.ResolveArea(ctx, widgets.ResolveAreaInput{
+			input := widgets.ResolveAreaInput{
 				AreaCode: code,
 				Now:      now,
-			})
+			}
+			if page.EnvironmentID != uuid.Nil {
+				input.EnvironmentKey = page.EnvironmentID.String()
+			}
+			resolved, err := s.widgets.ResolveArea(ctx, input)
 			if err != nil {
 				if errors.Is(err, widgets.ErrFeatureDisabled) || errors.Is(err, widgets.ErrAreaFeatureDisabled) {
 					areaWidgets = nil
@@ -2497,6 +2629,15 @@ func areaDefinitionApplies(definition *widgets.AreaDefinition, template *themes.
 	}
 }
 
+// areaDefinitionMatchesSite reports whether a site-scoped area belongs to the
+// page's site. Areas without a site are shared across all sites.
+func areaDefinitionMatchesSite(definition *widgets.AreaDefinition, siteID *uuid.UUID) bool {
+	if definition.SiteID == nil || *definition.SiteID == uuid.Nil {
+		return true
+	}
+	return siteID != nil && *siteID == *definition.SiteID
+}
+
 func (s *pageService) decoratePage(page *Page) *Page {
 	if page == nil {
 		return nil
@@ -2900,6 +3041,18 @@ func sanitizePath(path string) string {
 	return path
 }
 
+// pagesInSite keeps the pages scoped exactly to siteID; slugs and paths only
+// conflict within a site.
+func pagesInSite(records []*Page, siteID *uuid.UUID) []*Page {
+	out := records[:0:0]
+	for _, record := range records {
+		if record != nil && cmssites.SameScope(record.SiteID, siteID) {
+			out = append(out, record)
+		}
+	}
+	return out
+}
+
 func pathExists(pages []*Page, localeID uuid.UUID, path string) bool {
 	for _, p := range pages {
 		for _, tr := range p.Translations {
@@ -2940,6 +3093,32 @@ func isValidSlug(slug string) bool {
 	return matched
 }
 
+// scopedPageBySlug returns the page with slug scoped exactly to siteID, where
+// a nil siteID selects the shared page. Slugs are unique per site, so pages of
+// other scopes are reported as not found.
+func (s *pageService) scopedPageBySlug(ctx context.Context, slug, env string, siteID *uuid.UUID) (*Page, error) {
+	page, err := s.pages.GetBySlug(cmssites.WithSiteID(ctx, siteID), slug, env)
+	if err != nil {
+		return nil, err
+	}
+	if !cmssites.SameScope(page.SiteID, siteID) {
+		return nil, &PageNotFoundError{Key: slug}
+	}
+	return page, nil
+}
+
+// resolvePageSiteID scopes a page to its content's site. Pages of shared
+// content may be pinned to a site; pages may not move content across sites.
+func resolvePageSiteID(requested, contentSiteID *uuid.UUID) (*uuid.UUID, error) {
+	if requested == nil || *requested == uuid.Nil {
+		return cloneUUIDPointer(contentSiteID), nil
+	}
+	if contentSiteID != nil && *contentSiteID != uuid.Nil && *contentSiteID != *requested {
+		return nil, cmssites.ErrSiteScopeMismatch
+	}
+	return cloneUUIDPointer(requested), nil
+}
+
 func cloneStringPtr(value *string) *string {
 	if value == nil {
 		return nil
@@ -2980,13 +3159,13 @@ func (s *pageService) ensureValidParent(ctx context.Context, pageID uuid.UUID, p
 	return nil
 }
 
-func (s *pageService) generateDuplicateSlug(ctx context.Context, requested, fallback string, env string) (string, error) {
+func (s *pageService) generateDuplicateSlug(ctx context.Context, requested, fallback string, env string, siteID *uuid.UUID) (string, error) {
 	candidate := strings.TrimSpace(requested)
 	if candidate != "" {
 		if !isValidSlug(candidate) {
 			return "", ErrSlugInvalid
 		}
-		if _, err := s.pages.GetBySlug(ctx, candidate, env); err == nil {
+		if _, err := s.scopedPageBySlug(ctx, candidate, env, siteID); err == nil {
 			return "", ErrSlugExists
 		} else {
 			var notFound *PageNotFoundError
@@ -3004,7 +3183,7 @@ func (s *pageService) generateDuplicateSlug(ctx context.Context, requested, fall
 
 	for attempt := range 100 {
 		next := appendCopySuffix(base, attempt)
-		if _, err := s.pages.GetBySlug(ctx, next, env); err != nil {
+		if _, err := s.scopedPageBySlug(ctx, next, env, siteID); err != nil {
 			var notFound *PageNotFoundError
 			if errors.As(err, &notFound) {
 				return next, nil
@@ -0,0 +1,109 @@
+package pages_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/sites"
+	"github.com/google/uuid"
+)
+
+func TestPageServiceSiteScoping(t *testing.T) {
+	ctx := context.Background()
+	contentRepo := content.NewMemoryContentRepository()
+	typeRepo := content.NewMemoryContentTypeRepository()
+	localeRepo := content.NewMemoryLocaleRepository()
+	localeRepo.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English"})
+
+	pageType := &content.ContentType{
+		ID:     uuid.New(),
+		Name:   "Page",
+		Slug:   "page",
+		Schema: map[string]any{"fields": []any{"body"}},
+	}
+	seedContentType(t, typeRepo, pageType)
+
+	contentSvc := content.NewService(contentRepo, typeRepo, localeRepo)
+	pageRepo := pages.NewMemoryPageRepository()
+	pageSvc := pages.NewService(pageRepo, contentRepo, localeRepo)
+
+	blogID := uuid.New()
+	shopID := uuid.New()
+	createContent := func(slug string, siteID *uuid.UUID) *content.Content {
+		t.Helper()
+		record, err := contentSvc.Create(ctx, content.CreateContentRequest{
+			ContentTypeID: pageType.ID,
+			Slug:          slug,
+			SiteID:        siteID,
+			CreatedBy:     uuid.New(),
+			UpdatedBy:     uuid.New(),
+			Translations: []content.ContentTranslationInput{
+				{Locale: "en", Title: slug, Content: map[string]any{"body": slug}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("create content %s: %v", slug, err)
+		}
+		return record
+	}
+	createPage := func(contentID uuid.UUID, slug string, siteID *uuid.UUID) (*pages.Page, error) {
+		return pageSvc.Create(ctx, pages.CreatePageRequest{
+			ContentID:  contentID,
+			TemplateID: uuid.New(),
+			Slug:       slug,
+			SiteID:     siteID,
+			CreatedBy:  uuid.New(),
+			UpdatedBy:  uuid.New(),
+			Translations: []pages.PageTranslationInput{
+				{Locale: "en", Title: slug, Path: "/" + slug},
+			},
+		})
+	}
+
+	blogContent := createContent("blog-post", &blogID)
+	if blogContent.SiteID == nil || *blogContent.SiteID != blogID {
+		t.Fatalf("expected content to keep its site")
+	}
+
+	inherited, err := createPage(blogContent.ID, "blog-post", nil)
+	if err != nil {
+		t.Fatalf("create inherited page: %v", err)
+	}
+	if inherited.SiteID == nil || *inherited.SiteID != blogID {
+		t.Fatalf("expected page to inherit the content site, got %v", inherited.SiteID)
+	}
+
+	if _, err := createPage(blogContent.ID, "blog-post-shop", &shopID); !errors.Is(err, sites.ErrSiteScopeMismatch) {
+		t.Fatalf("expected site scope mismatch, got %v", err)
+	}
+
+	shared := createContent("about", nil)
+	pinned, err := createPage(shared.ID, "about", &shopID)
+	if err != nil {
+		t.Fatalf("create pinned page: %v", err)
+	}
+	if pinned.SiteID == nil || *pinned.SiteID != shopID {
+		t.Fatalf("expected shared content page to be pinned to the shop site, got %v", pinned.SiteID)
+	}
+
+	// Slugs are unique per site: the blog may reuse the shop's "about" slug,
+	// but not twice.
+	blogAbout, err := createPage(shared.ID, "about", &blogID)
+	if err != nil {
+		t.Fatalf("create blog page reusing slug: %v", err)
+	}
+	if _, err := createPage(shared.ID, "about", &blogID); !errors.Is(err, pages.ErrSlugExists) {
+		t.Fatalf("expected slug conflict within the site, got %v", err)
+	}
+
+	resolved, err := pageRepo.GetBySlug(sites.WithSite(ctx, &sites.Site{ID: blogID}), "about")
+	if err != nil {
+		t.Fatalf("get blog page by slug: %v", err)
+	}
+	if resolved.ID != blogAbout.ID {
+		t.Fatalf("expected slug lookup to resolve the blog page, got %v", resolved.ID)
+	}
+}
@@ -0,0 +1,496 @@
+package pages
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/google/uuid"
+)
+
+// maxRestoreRenameAttempts bounds the suffixed slugs and paths tried by RestoreConflictRename.
+const maxRestoreRenameAttempts = 100
+
+// trashedPage is the trash snapshot of a deleted page subtree. Pages are
+// stored parent first; BlockInstances lists the instances moved to the blocks
+// trash alongside the pages.
+type trashedPage struct {
+	Pages          []*Page     `json:"pages"`
+	BlockInstances []uuid.UUID `json:"block_instances,omitempty"`
+}
+
+// trashPage snapshots the page with its descendants, translations, versions and
+// block instances and removes the live rows. Block instances move to the
+// blocks trash first; the page rows and the snapshot are then written together,
+// in one transaction when the repository implements PageTrasher. When that
+// step fails the block instances are restored. It returns the trashed pages,
+// parents first.
+func (s *pageService) trashPage(ctx context.Context, record *Page, deletedBy uuid.UUID, logger interfaces.Logger) ([]*Page, error) {
+	subtree, err := s.collectSubtree(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	snapshot := trashedPage{Pages: make([]*Page, 0, len(subtree))}
+	for _, page := range subtree {
+		translations, err := s.translationsForCheck(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		versions, err := s.pages.ListVersions(ctx, page.ID)
+		if err != nil {
+			return nil, err
+		}
+		clone := *page
+		clone.Content = nil
+		clone.Blocks = nil
+		clone.Widgets = nil
+		clone.Translations = translations
+		clone.Versions = versions
+		clone.DeletedAt = &now
+		for _, tr := range clone.Translations {
+			if tr != nil {
+				tr.DeletedAt = &now
+			}
+		}
+		snapshot.Pages = append(snapshot.Pages, &clone)
+	}
+
+	snapshot.BlockInstances, err = s.trashPageBlocks(ctx, subtree, deletedBy, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := trash.NewEntry(trash.ResourcePage, record.ID, &snapshot, deletedBy, now)
+	if err == nil {
+		entry.EnvironmentID = record.EnvironmentID
+		err = s.deleteToTrash(ctx, snapshot.Pages, entry)
+	}
+	if err != nil {
+		s.undoBlockTrash(ctx, snapshot.BlockInstances, deletedBy, logger)
+		return nil, err
+	}
+	for _, page := range subtree {
+		s.cancelPageSchedules(ctx, page.ID, logger)
+	}
+	return subtree, nil
+}
+
+// deleteToTrash stores entry and removes the snapshot pages deepest first.
+// Without PageTrasher support the entry is written through the trash
+// repository, and pages removed before a failed delete are stored again.
+func (s *pageService) deleteToTrash(ctx context.Context, snapshot []*Page, entry *trash.Entry) error {
+	ids := make([]uuid.UUID, 0, len(snapshot))
+	for i := len(snapshot) - 1; i >= 0; i-- {
+		ids = append(ids, snapshot[i].ID)
+	}
+	if trasher, ok := s.pages.(PageTrasher); ok {
+		err := trasher.DeleteToTrash(ctx, ids, entry)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+
+	stored, err := s.trash.Create(ctx, entry)
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if err := s.pages.Delete(ctx, id, true); err != nil {
+			removed := make([]*Page, 0, i)
+			for j := len(snapshot) - i; j < len(snapshot); j++ {
+				removed = append(removed, restorablePage(snapshot[j]))
+			}
+			_ = s.insertPages(ctx, removed)
+			_ = s.trash.Delete(ctx, stored.ID)
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreFromTrash stores the pages again, parents first, and removes the
+// trash entry. Without PageTrasher support pages stored before a failure are
+// removed again.
+func (s *pageService) restoreFromTrash(ctx context.Context, entryID uuid.UUID, records []*Page) error {
+	if trasher, ok := s.pages.(PageTrasher); ok {
+		err := trasher.RestoreFromTrash(ctx, entryID, records)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+
+	if err := s.insertPages(ctx, records); err != nil {
+		return err
+	}
+	if err := s.trash.Delete(ctx, entryID); err != nil && !errors.Is(err, trash.ErrEntryNotFound) {
+		for i := len(records) - 1; i >= 0; i-- {
+			_ = s.pages.Delete(ctx, records[i].ID, true)
+		}
+		return err
+	}
+	return nil
+}
+
+// insertPages creates the pages with their translations and versions in order.
+// When one fails the pages created so far are deleted again.
+func (s *pageService) insertPages(ctx context.Context, records []*Page) error {
+	for i, record := range records {
+		if err := s.insertPage(ctx, record); err != nil {
+			for j := i; j >= 0; j-- {
+				_ = s.pages.Delete(ctx, records[j].ID, true)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *pageService) insertPage(ctx context.Context, record *Page) error {
+	page := *record
+	page.Translations = nil
+	page.Versions = nil
+	if _, err := s.pages.Create(ctx, &page); err != nil {
+		return err
+	}
+	if err := s.pages.ReplaceTranslations(ctx, record.ID, record.Translations); err != nil {
+		return err
+	}
+	for _, version := range record.Versions {
+		if version == nil {
+			continue
+		}
+		if _, err := s.pages.CreateVersion(ctx, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restorablePage returns a copy of a trashed page with the deletion markers
+// cleared on the page and its translations.
+func restorablePage(page *Page) *Page {
+	clone := *page
+	clone.DeletedAt = nil
+	clone.Translations = make([]*PageTranslation, 0, len(page.Translations))
+	for _, tr := range page.Translations {
+		if tr == nil {
+			continue
+		}
+		copied := *tr
+		copied.DeletedAt = nil
+		clone.Translations = append(clone.Translations, &copied)
+	}
+	return &clone
+}
+
+// trashPageBlocks moves the block instances of the pages to the blocks trash.
+// When one fails the instances trashed so far are restored. Instances are left
+// in place when the blocks service has no trash.
+func (s *pageService) trashPageBlocks(ctx context.Context, subtree []*Page, deletedBy uuid.UUID, logger interfaces.Logger) ([]uuid.UUID, error) {
+	if s.blocks == nil {
+		return nil, nil
+	}
+	var trashed []uuid.UUID
+	for _, page := range subtree {
+		instances, err := s.blocks.ListPageInstances(ctx, page.ID)
+		if err != nil {
+			s.undoBlockTrash(ctx, trashed, deletedBy, logger)
+			return nil, err
+		}
+		for _, instance := range instances {
+			if instance == nil {
+				continue
+			}
+			err := s.blocks.DeleteInstance(ctx, blocks.DeleteInstanceRequest{ID: instance.ID, DeletedBy: deletedBy})
+			if err != nil {
+				if errors.Is(err, blocks.ErrInstanceSoftDeleteUnsupported) {
+					return trashed, nil
+				}
+				s.undoBlockTrash(ctx, trashed, deletedBy, logger)
+				return nil, err
+			}
+			trashed = append(trashed, instance.ID)
+		}
+	}
+	return trashed, nil
+}
+
+// restorePageBlocks restores the trashed block instances of a page subtree,
+// skipping instances that are no longer in the trash. When one fails the
+// instances restored so far are trashed again.
+func (s *pageService) restorePageBlocks(ctx context.Context, ids []uuid.UUID, restoredBy uuid.UUID, logger interfaces.Logger) ([]uuid.UUID, error) {
+	if s.blocks == nil {
+		return nil, nil
+	}
+	restored := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		_, err := s.blocks.RestoreDeletedInstance(ctx, blocks.RestoreInstanceRequest{ID: id, RestoredBy: restoredBy})
+		if err != nil {
+			if errors.Is(err, blocks.ErrInstanceNotTrashed) {
+				continue
+			}
+			logger.Error("page block restore failed", "error", err, "block_instance_id", id)
+			s.undoBlockRestore(ctx, restored, restoredBy, logger)
+			return nil, err
+		}
+		restored = append(restored, id)
+	}
+	return restored, nil
+}
+
+func (s *pageService) undoBlockTrash(ctx context.Context, ids []uuid.UUID, actor uuid.UUID, logger interfaces.Logger) {
+	for _, id := range ids {
+		if _, err := s.blocks.RestoreDeletedInstance(ctx, blocks.RestoreInstanceRequest{ID: id, RestoredBy: actor}); err != nil {
+			logger.Warn("page block trash rollback failed", "error", err, "block_instance_id", id)
+		}
+	}
+}
+
+func (s *pageService) undoBlockRestore(ctx context.Context, ids []uuid.UUID, actor uuid.UUID, logger interfaces.Logger) {
+	for _, id := range ids {
+		if err := s.blocks.DeleteInstance(ctx, blocks.DeleteInstanceRequest{ID: id, DeletedBy: actor}); err != nil {
+			logger.Warn("page block restore rollback failed", "error", err, "block_instance_id", id)
+		}
+	}
+}
+
+// collectSubtree returns the page followed by its descendants, parents before children.
+func (s *pageService) collectSubtree(ctx context.Context, root *Page) ([]*Page, error) {
+	all, err := s.pages.List(ctx, root.EnvironmentID.String())
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[uuid.UUID][]*Page, len(all))
+	for _, page := range all {
+		if page == nil || page.ParentID == nil {
+			continue
+		}
+		children[*page.ParentID] = append(children[*page.ParentID], page)
+	}
+	subtree := []*Page{root}
+	seen := map[uuid.UUID]struct{}{root.ID: {}}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range children[subtree[i].ID] {
+			if _, ok := seen[child.ID]; ok {
+				continue
+			}
+			seen[child.ID] = struct{}{}
+			subtree = append(subtree, child)
+		}
+	}
+	return subtree, nil
+}
+
+// ListTrashed returns a page of the root pages of soft-deleted subtrees in the
+// environment, most recently deleted first.
+func (s *pageService) ListTrashed(ctx context.Context, req ListTrashedPagesRequest) ([]*Page, error) {
+	if s.trash == nil {
+		return nil, ErrPageSoftDeleteUnsupported
+	}
+	envID, _, err := s.resolveEnvironment(ctx, req.Environment)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.trash.List(ctx, trash.ListQuery{
+		ResourceType:  trash.ResourcePage,
+		EnvironmentID: envID,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Page, 0, len(entries))
+	for _, entry := range entries {
+		var snapshot trashedPage
+		if err := trash.Decode(entry, &snapshot); err != nil {
+			return nil, err
+		}
+		if len(snapshot.Pages) == 0 || snapshot.Pages[0] == nil {
+			continue
+		}
+		root := snapshot.Pages[0]
+		root.Versions = nil
+		records = append(records, s.decoratePage(root))
+	}
+	return records, nil
+}
+
+// RestoreDeleted recreates a trashed page subtree with its translations,
+// versions and block instances. Block instances are restored first and moved
+// back to the trash when the pages cannot be stored. Only the root page emits
+// restore activity and lifecycle events; their metadata reports the number of
+// descendants.
+func (s *pageService) RestoreDeleted(ctx context.Context, req RestorePageRequest) (*Page, error) {
+	if req.ID == uuid.Nil {
+		return nil, ErrPageRequired
+	}
+	if s.trash == nil {
+		return nil, ErrPageSoftDeleteUnsupported
+	}
+	logger := s.opLogger(ctx, "pages.restore", map[string]any{
+		"page_id": req.ID,
+	})
+
+	entry, err := s.trash.GetByResource(ctx, trash.ResourcePage, req.ID)
+	if err != nil {
+		if errors.Is(err, trash.ErrEntryNotFound) {
+			return nil, ErrPageNotTrashed
+		}
+		return nil, err
+	}
+	var snapshot trashedPage
+	if err := trash.Decode(entry, &snapshot); err != nil {
+		return nil, err
+	}
+	if len(snapshot.Pages) == 0 || snapshot.Pages[0] == nil {
+		return nil, ErrPageNotTrashed
+	}
+	root := snapshot.Pages[0]
+	if err := s.ensureEnvironmentActive(ctx, root.EnvironmentID); err != nil {
+		return nil, err
+	}
+	if root.ParentID != nil {
+		if _, err := s.pages.GetByID(ctx, *root.ParentID); err != nil {
+			return nil, ErrParentNotFound
+		}
+	}
+	for _, page := range snapshot.Pages {
+		if _, err := s.content.GetByID(ctx, page.ContentID); err != nil {
+			return nil, ErrContentRequired
+		}
+	}
+
+	existing, err := s.pages.List(ctx, root.EnvironmentID.String())
+	if err != nil {
+		return nil, err
+	}
+	originalSlug := root.Slug
+	for _, page := range snapshot.Pages {
+		if err := s.resolveRestoreConflicts(ctx, page, existing, req.OnConflict); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	records := make([]*Page, 0, len(snapshot.Pages))
+	for _, page := range snapshot.Pages {
+		record := restorablePage(page)
+		record.UpdatedAt = now
+		if req.RestoredBy != uuid.Nil {
+			record.UpdatedBy = req.RestoredBy
+		}
+		records = append(records, record)
+	}
+
+	restoredBlocks, err := s.restorePageBlocks(ctx, snapshot.BlockInstances, req.RestoredBy, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.restoreFromTrash(ctx, entry.ID, records); err != nil {
+		logger.Error("page restore failed", "error", err)
+		s.undoBlockRestore(ctx, restoredBlocks, req.RestoredBy, logger)
+		return nil, err
+	}
+	root = records[0]
+
+	logger.Info("page restored", "pages", len(snapshot.Pages))
+	meta := map[string]any{
+		"slug":        root.Slug,
+		"status":      root.Status,
+		"locales":     collectPageLocales(root),
+		"path":        primaryPagePath(root),
+		"descendants": len(snapshot.Pages) - 1,
+	}
+	if root.Slug != originalSlug {
+		meta["original_slug"] = originalSlug
+	}
+	if root.EnvironmentID != uuid.Nil {
+		meta["environment_id"] = root.EnvironmentID.String()
+	}
+	actor := req.RestoredBy
+	if actor == uuid.Nil {
+		actor = root.UpdatedBy
+	}
+	s.emitActivity(ctx, actor, "restore", "page", root.ID, meta)
+	contentRecord, _ := s.content.GetByID(ctx, root.ContentID)
+	s.emitLifecycle(ctx, s.pageLifecycleEvent(ctx, root, contentRecord, "restore", uuid.Nil, "", meta))
+
+	restored, err := s.Get(ctx, root.ID)
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// resolveRestoreConflicts checks the page slug and translation paths against
+// live pages, renaming them when the strategy allows it.
+func (s *pageService) resolveRestoreConflicts(ctx context.Context, page *Page, existing []*Page, strategy domain.RestoreConflict) error {
+	existing = pagesInSite(existing, page.SiteID)
+	env := page.EnvironmentID.String()
+	slugTaken := func(candidate string) (bool, error) {
+		if _, err := s.scopedPageBySlug(ctx, candidate, env, page.SiteID); err != nil {
+			var notFound *PageNotFoundError
+			if errors.As(err, &notFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+	taken, err := slugTaken(page.Slug)
+	if err != nil {
+		return err
+	}
+	if taken {
+		if strategy != domain.RestoreConflictRename {
+			return ErrSlugExists
+		}
+		renamed := ""
+		for attempt := 1; attempt <= maxRestoreRenameAttempts && renamed == ""; attempt++ {
+			candidate := trash.SuffixedSlug(page.Slug, attempt)
+			taken, err := slugTaken(candidate)
+			if err != nil {
+				return err
+			}
+			if !taken {
+				renamed = candidate
+			}
+		}
+		if renamed == "" {
+			return ErrSlugExists
+		}
+		page.Slug = renamed
+	}
+
+	for _, tr := range page.Translations {
+		if tr == nil || !pathExists(existing, tr.LocaleID, tr.Path) {
+			continue
+		}
+		if strategy != domain.RestoreConflictRename {
+			return ErrPathExists
+		}
+		base := strings.TrimSuffix(tr.Path, "/")
+		if base == "" {
+			base = "/" + page.Slug
+		}
+		renamed := ""
+		for attempt := 1; attempt <= maxRestoreRenameAttempts && renamed == ""; attempt++ {
+			candidate := trash.SuffixedSlug(base, attempt)
+			if !pathExists(existing, tr.LocaleID, candidate) {
+				renamed = candidate
+			}
+		}
+		if renamed == "" {
+			return ErrPathExists
+		}
+		tr.Path = renamed
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package pages_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+type pageTrashFixture struct {
+	pageSvc   pages.Service
+	blockSvc  blocks.Service
+	hook      *lifecycle.CaptureHook
+	contentID uuid.UUID
+	localeID  uuid.UUID
+}
+
+func newPageTrashFixture(t *testing.T) pageTrashFixture {
+	t.Helper()
+
+	contentStore := content.NewMemoryContentRepository()
+	contentTypeStore := content.NewMemoryContentTypeRepository()
+	localeStore := content.NewMemoryLocaleRepository()
+	trashStore := trash.NewMemoryRepository()
+
+	contentTypeID := uuid.New()
+	seedContentType(t, contentTypeStore, &content.ContentType{ID: contentTypeID, Name: "page"})
+	localeID := uuid.New()
+	localeStore.Put(&content.Locale{ID: localeID, Code: "en", Display: "English"})
+
+	contentSvc := content.NewService(contentStore, contentTypeStore, localeStore)
+	contentRecord, err := contentSvc.Create(context.Background(), content.CreateContentRequest{
+		ContentTypeID: contentTypeID,
+		Slug:          "docs",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Docs"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	blockSvc := blocks.NewService(
+		blocks.NewMemoryDefinitionRepository(),
+		blocks.NewMemoryInstanceRepository(),
+		blocks.NewMemoryTranslationRepository(),
+		blocks.WithTrashRepository(trashStore),
+	)
+	hook := &lifecycle.CaptureHook{}
+	pageSvc := pages.NewService(
+		pages.NewMemoryPageRepository(),
+		contentStore,
+		localeStore,
+		pages.WithBlockService(blockSvc),
+		pages.WithTrashRepository(trashStore),
+		pages.WithLifecycleEmitter(lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})),
+	)
+	return pageTrashFixture{pageSvc: pageSvc, blockSvc: blockSvc, hook: hook, contentID: contentRecord.ID, localeID: localeID}
+}
+
+func (f pageTrashFixture) createPage(t *testing.T, slug, path string, parentID *uuid.UUID) *pages.Page {
+	t.Helper()
+	page, err := f.pageSvc.Create(context.Background(), pages.CreatePageRequest{
+		ContentID:    f.contentID,
+		TemplateID:   uuid.New(),
+		ParentID:     parentID,
+		Slug:         slug,
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+		Translations: []pages.PageTranslationInput{{Locale: "en", Title: slug, Path: path}},
+	})
+	if err != nil {
+		t.Fatalf("create page %s: %v", slug, err)
+	}
+	return page
+}
+
+func TestPageServiceSoftDeleteRestoresHierarchyAndBlocks(t *testing.T) {
+	ctx := context.Background()
+	fixture := newPageTrashFixture(t)
+
+	parent := fixture.createPage(t, "guides", "/guides", nil)
+	child := fixture.createPage(t, "setup", "/guides/setup", &parent.ID)
+
+	definition, err := fixture.blockSvc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	instance, err := fixture.blockSvc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID: definition.ID,
+		PageID:       &child.ID,
+		Region:       "hero",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create block instance: %v", err)
+	}
+	if _, err := fixture.blockSvc.AddTranslation(ctx, blocks.AddTranslationInput{
+		BlockInstanceID: instance.ID,
+		LocaleID:        fixture.localeID,
+		Content:         map[string]any{"title": "Setup"},
+	}); err != nil {
+		t.Fatalf("add block translation: %v", err)
+	}
+
+	if err := fixture.pageSvc.Delete(ctx, pages.DeletePageRequest{ID: parent.ID, DeletedBy: uuid.New()}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+
+	var notFound *pages.PageNotFoundError
+	if _, err := fixture.pageSvc.Get(ctx, child.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected child page to be trashed with its parent, got %v", err)
+	}
+	instances, err := fixture.blockSvc.ListPageInstances(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("list page instances: %v", err)
+	}
+	if len(instances) != 0 {
+		t.Fatalf("expected block instances to be trashed, got %d", len(instances))
+	}
+
+	trashed, err := fixture.pageSvc.ListTrashed(ctx, pages.ListTrashedPagesRequest{})
+	if err != nil {
+		t.Fatalf("list trashed: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != parent.ID {
+		t.Fatalf("expected only the deleted root to be listed, got %+v", trashed)
+	}
+	if rest, err := fixture.pageSvc.ListTrashed(ctx, pages.ListTrashedPagesRequest{Offset: 1}); err != nil || len(rest) != 0 {
+		t.Fatalf("expected an empty second page, got %d (%v)", len(rest), err)
+	}
+	if staging, err := fixture.pageSvc.ListTrashed(ctx, pages.ListTrashedPagesRequest{Environment: "staging"}); err != nil || len(staging) != 0 {
+		t.Fatalf("expected no trashed pages in another environment, got %d (%v)", len(staging), err)
+	}
+
+	restored, err := fixture.pageSvc.RestoreDeleted(ctx, pages.RestorePageRequest{ID: parent.ID})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.ID != parent.ID || restored.DeletedAt != nil {
+		t.Fatalf("unexpected restored page %+v", restored)
+	}
+	last := fixture.hook.Events[len(fixture.hook.Events)-1]
+	if last.Transition != "restore" || last.RecordID != parent.ID.String() || last.Metadata["descendants"] != 1 {
+		t.Fatalf("expected restore lifecycle event for the root page, got %+v", last)
+	}
+
+	restoredChild, err := fixture.pageSvc.Get(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("get restored child: %v", err)
+	}
+	if restoredChild.ParentID == nil || *restoredChild.ParentID != parent.ID {
+		t.Fatalf("expected child to keep its parent, got %v", restoredChild.ParentID)
+	}
+	if len(restoredChild.Translations) != 1 || restoredChild.Translations[0].Path != "/guides/setup" {
+		t.Fatalf("expected child translation to be restored, got %+v", restoredChild.Translations)
+	}
+
+	instances, err = fixture.blockSvc.ListPageInstances(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("list restored instances: %v", err)
+	}
+	if len(instances) != 1 || instances[0].ID != instance.ID {
+		t.Fatalf("expected block instance to be restored, got %+v", instances)
+	}
+	if len(instances[0].Translations) != 1 {
+		t.Fatalf("expected block translation to be restored, got %d", len(instances[0].Translations))
+	}
+
+	if trashed, err := fixture.pageSvc.ListTrashed(ctx, pages.ListTrashedPagesRequest{}); err != nil || len(trashed) != 0 {
+		t.Fatalf("expected empty trash after restore, got %d (%v)", len(trashed), err)
+	}
+}
+
+func TestPageServiceRestoreDeletedSlugConflict(t *testing.T) {
+	ctx := context.Background()
+	fixture := newPageTrashFixture(t)
+
+	original := fixture.createPage(t, "about", "/about", nil)
+	if err := fixture.pageSvc.Delete(ctx, pages.DeletePageRequest{ID: original.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	fixture.createPage(t, "about", "/about", nil)
+
+	if _, err := fixture.pageSvc.RestoreDeleted(ctx, pages.RestorePageRequest{ID: original.ID}); !errors.Is(err, pages.ErrSlugExists) {
+		t.Fatalf("expected ErrSlugExists, got %v", err)
+	}
+
+	restored, err := fixture.pageSvc.RestoreDeleted(ctx, pages.RestorePageRequest{
+		ID:         original.ID,
+		OnConflict: domain.RestoreConflictRename,
+	})
+	if err != nil {
+		t.Fatalf("restore with rename: %v", err)
+	}
+	if restored.Slug != "about-restored" {
+		t.Fatalf("expected renamed slug, got %q", restored.Slug)
+	}
+	if len(restored.Translations) != 1 || restored.Translations[0].Path != "/about-restored" {
+		t.Fatalf("expected renamed path, got %+v", restored.Translations)
+	}
+}
+
+func TestPageServiceSoftDeleteWithBunStorageIsAtomic(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	registerPageModels(t, bunDB)
+	seedPageEntities(t, bunDB)
+	for _, model := range []any{(*content.ContentVersion)(nil), (*trash.Entry)(nil)} {
+		if _, err := bunDB.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
+			t.Fatalf("create table %T: %v", model, err)
+		}
+	}
+
+	contentRepo := content.NewBunContentRepository(bunDB)
+	localeRepo := content.NewBunLocaleRepository(bunDB)
+	contentRecord, err := content.NewService(contentRepo, content.NewBunContentTypeRepository(bunDB), localeRepo).Create(ctx, content.CreateContentRequest{
+		ContentTypeID: mustUUID("00000000-0000-0000-0000-000000000210"),
+		Slug:          "guides",
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+		Translations:  []content.ContentTranslationInput{{Locale: "en", Title: "Guides"}},
+	})
+	if err != nil {
+		t.Fatalf("create content: %v", err)
+	}
+
+	pageRepo := pages.NewBunPageRepository(bunDB)
+	trashRepo := trash.NewBunRepository(bunDB)
+	pageSvc := pages.NewService(pageRepo, contentRepo, localeRepo, pages.WithTrashRepository(trashRepo))
+	create := func(slug, path string, parentID *uuid.UUID) *pages.Page {
+		page, err := pageSvc.Create(ctx, pages.CreatePageRequest{
+			ContentID:    contentRecord.ID,
+			TemplateID:   uuid.New(),
+			ParentID:     parentID,
+			Slug:         slug,
+			CreatedBy:    uuid.New(),
+			UpdatedBy:    uuid.New(),
+			Translations: []pages.PageTranslationInput{{Locale: "en", Title: slug, Path: path}},
+		})
+		if err != nil {
+			t.Fatalf("create page %s: %v", slug, err)
+		}
+		translation := &pages.PageTranslation{LocaleID: mustUUID("00000000-0000-0000-0000-000000000201"), Title: slug, Path: path}
+		if err := pageRepo.ReplaceTranslations(ctx, page.ID, []*pages.PageTranslation{translation}); err != nil {
+			t.Fatalf("store translations %s: %v", slug, err)
+		}
+		return page
+	}
+	parent := create("guides", "/guides", nil)
+	child := create("setup", "/guides/setup", &parent.ID)
+
+	if err := pageSvc.Delete(ctx, pages.DeletePageRequest{ID: parent.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	var notFound *pages.PageNotFoundError
+	if _, err := pageRepo.GetByID(ctx, child.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected child page to be removed, got %v", err)
+	}
+
+	if _, err := pageSvc.RestoreDeleted(ctx, pages.RestorePageRequest{ID: parent.ID}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	translations, err := pageRepo.ListTranslations(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("list restored translations: %v", err)
+	}
+	if len(translations) != 1 || translations[0].Path != "/guides" {
+		t.Fatalf("expected restored translations, got %+v", translations)
+	}
+	if _, err := pageRepo.GetByID(ctx, child.ID); err != nil {
+		t.Fatalf("expected child page to be restored: %v", err)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourcePage, parent.ID); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected the snapshot to be removed on restore, got %v", err)
+	}
+
+	// A failed delete keeps the pages that were already removed and drops the snapshot.
+	missing := uuid.New()
+	entry, err := trash.NewEntry(trash.ResourcePage, child.ID, map[string]string{"slug": "setup"}, uuid.Nil, time.Now())
+	if err != nil {
+		t.Fatalf("new entry: %v", err)
+	}
+	if err := pageRepo.DeleteToTrash(ctx, []uuid.UUID{child.ID, missing}, entry); !errors.As(err, &notFound) {
+		t.Fatalf("expected PageNotFoundError, got %v", err)
+	}
+	if _, err := pageRepo.GetByID(ctx, child.ID); err != nil {
+		t.Fatalf("expected child page to be rolled back: %v", err)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourcePage, child.ID); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected the snapshot to be rolled back, got %v", err)
+	}
+
+	// A failed restore leaves no page behind.
+	orphan := &pages.Page{
+		ID:         uuid.New(),
+		ContentID:  contentRecord.ID,
+		TemplateID: uuid.New(),
+		Slug:       "orphan",
+		Status:     "draft",
+	}
+	if err := pageRepo.RestoreFromTrash(ctx, uuid.New(), []*pages.Page{orphan}); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+	if _, err := pageRepo.GetByID(ctx, orphan.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected restored page to be rolled back, got %v", err)
+	}
+}
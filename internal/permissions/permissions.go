@@ -234,6 +234,34 @@ func WithSession(ctx context.Context, session interfaces.RoleCapableSession) con
 	return context.WithValue(ctx, checkerKey, session)
 }
 
+// ActorIDFromContext returns the user ID of the claims or session stored by
+// WithClaims or WithSession. It falls back to an "actorId" or "actor_id"
+// string value, as set by host request middleware.
+func ActorIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	switch typed := ctx.Value(checkerKey).(type) {
+	case interfaces.AuthClaims:
+		if id := strings.TrimSpace(typed.UserID()); id != "" {
+			return id
+		}
+		if id := strings.TrimSpace(typed.Subject()); id != "" {
+			return id
+		}
+	case interfaces.Session:
+		if id := strings.TrimSpace(typed.GetUserID()); id != "" {
+			return id
+		}
+	}
+	for _, key := range []string{"actorId", "actor_id"} {
+		if id, ok := ctx.Value(key).(string); ok && strings.TrimSpace(id) != "" {
+			return strings.TrimSpace(id)
+		}
+	}
+	return ""
+}
+
 // CheckerFromContext returns the configured permission checker if available.
 func CheckerFromContext(ctx context.Context) Checker {
 	if ctx == nil {
@@ -0,0 +1,133 @@
+package promotions
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// BatchRepository persists promotion batches.
+type BatchRepository interface {
+	Create(ctx context.Context, batch *PromotionBatch) (*PromotionBatch, error)
+	Update(ctx context.Context, batch *PromotionBatch) (*PromotionBatch, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*PromotionBatch, error)
+	List(ctx context.Context, filter BatchFilter) ([]*PromotionBatch, error)
+}
+
+// BatchFilter narrows batch listings. Results are ordered newest first.
+type BatchFilter struct {
+	SourceEnvironmentID *uuid.UUID
+	TargetEnvironmentID *uuid.UUID
+	Limit               int
+	Offset              int
+}
+
+type memoryBatchRepository struct {
+	mu      sync.RWMutex
+	batches map[uuid.UUID]*PromotionBatch
+}
+
+// NewMemoryBatchRepository constructs an in-memory promotion batch repository.
+func NewMemoryBatchRepository() BatchRepository {
+	return &memoryBatchRepository{batches: make(map[uuid.UUID]*PromotionBatch)}
+}
+
+func (m *memoryBatchRepository) Create(_ context.Context, batch *PromotionBatch) (*PromotionBatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cloned := cloneBatch(batch)
+	m.batches[cloned.ID] = cloned
+	return cloneBatch(cloned), nil
+}
+
+func (m *memoryBatchRepository) Update(_ context.Context, batch *PromotionBatch) (*PromotionBatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.batches[batch.ID]; !ok {
+		return nil, ErrPromotionNotFound
+	}
+	cloned := cloneBatch(batch)
+	m.batches[cloned.ID] = cloned
+	return cloneBatch(cloned), nil
+}
+
+func (m *memoryBatchRepository) GetByID(_ context.Context, id uuid.UUID) (*PromotionBatch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	batch, ok := m.batches[id]
+	if !ok {
+		return nil, ErrPromotionNotFound
+	}
+	return cloneBatch(batch), nil
+}
+
+func (m *memoryBatchRepository) List(_ context.Context, filter BatchFilter) ([]*PromotionBatch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]*PromotionBatch, 0, len(m.batches))
+	for _, batch := range m.batches {
+		if filter.SourceEnvironmentID != nil && batch.SourceEnv.ID != *filter.SourceEnvironmentID {
+			continue
+		}
+		if filter.TargetEnvironmentID != nil && batch.TargetEnv.ID != *filter.TargetEnvironmentID {
+			continue
+		}
+		out = append(out, cloneBatch(batch))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID.String() > out[j].ID.String()
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	if filter.Offset > 0 {
+		if filter.Offset >= len(out) {
+			return []*PromotionBatch{}, nil
+		}
+		out = out[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(out) {
+		out = out[:filter.Limit]
+	}
+	return out, nil
+}
+
+func cloneBatch(batch *PromotionBatch) *PromotionBatch {
+	if batch == nil {
+		return nil
+	}
+	cloned := *batch
+	cloned.Options.PreferPublished = cloneBoolPtr(batch.Options.PreferPublished)
+	cloned.Options.MigrateOnPromote = cloneBoolPtr(batch.Options.MigrateOnPromote)
+	cloned.Items = make([]PromoteItem, len(batch.Items))
+	for i, item := range batch.Items {
+		item.Details = cloneMap(item.Details)
+		item.BatchID = cloneUUIDPtr(item.BatchID)
+		cloned.Items[i] = item
+	}
+	cloned.Errors = make([]PromoteError, len(batch.Errors))
+	for i, entry := range batch.Errors {
+		entry.Details = cloneMap(entry.Details)
+		cloned.Errors[i] = entry
+	}
+	cloned.Records = make([]PromotionRecord, len(batch.Records))
+	for i, record := range batch.Records {
+		record.Before = json.RawMessage(slices.Clone([]byte(record.Before)))
+		cloned.Records[i] = record
+	}
+	cloned.RolledBackBy = cloneUUIDPtr(batch.RolledBackBy)
+	cloned.RolledBackAt = cloneTimePtr(batch.RolledBackAt)
+	return &cloned
+}
+
+func cloneBoolPtr(value *bool) *bool {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
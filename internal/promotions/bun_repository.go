@@ -0,0 +1,156 @@
+package promotions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BunBatchRepository persists promotion batches using a Bun-backed database.
+type BunBatchRepository struct {
+	db *bun.DB
+}
+
+// NewBunBatchRepository constructs a Bun-backed promotion batch repository.
+func NewBunBatchRepository(db *bun.DB) *BunBatchRepository {
+	return &BunBatchRepository{db: db}
+}
+
+func (r *BunBatchRepository) Create(ctx context.Context, batch *PromotionBatch) (*PromotionBatch, error) {
+	if r.db == nil {
+		return nil, errors.New("promotions: bun repository requires a database")
+	}
+	model := modelFromBatch(batch)
+	if _, err := r.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, model.ID)
+}
+
+func (r *BunBatchRepository) Update(ctx context.Context, batch *PromotionBatch) (*PromotionBatch, error) {
+	if r.db == nil {
+		return nil, errors.New("promotions: bun repository requires a database")
+	}
+	model := modelFromBatch(batch)
+	res, err := r.db.NewUpdate().
+		Model(model).
+		Column("status", "summary", "items", "errors", "records", "rolled_back_by", "rolled_back_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return nil, ErrPromotionNotFound
+	}
+	return r.GetByID(ctx, model.ID)
+}
+
+func (r *BunBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*PromotionBatch, error) {
+	if r.db == nil {
+		return nil, errors.New("promotions: bun repository requires a database")
+	}
+	var model batchModel
+	if err := r.db.NewSelect().Model(&model).Where("?TableAlias.id = ?", id).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return modelToBatch(&model), nil
+}
+
+func (r *BunBatchRepository) List(ctx context.Context, filter BatchFilter) ([]*PromotionBatch, error) {
+	if r.db == nil {
+		return nil, errors.New("promotions: bun repository requires a database")
+	}
+	var models []batchModel
+	query := r.db.NewSelect().Model(&models).
+		OrderExpr("?TableAlias.created_at DESC").
+		OrderExpr("?TableAlias.id DESC")
+	if filter.SourceEnvironmentID != nil {
+		query = query.Where("?TableAlias.source_environment_id = ?", *filter.SourceEnvironmentID)
+	}
+	if filter.TargetEnvironmentID != nil {
+		query = query.Where("?TableAlias.target_environment_id = ?", *filter.TargetEnvironmentID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	out := make([]*PromotionBatch, 0, len(models))
+	for i := range models {
+		out = append(out, modelToBatch(&models[i]))
+	}
+	return out, nil
+}
+
+type batchModel struct {
+	bun.BaseModel `bun:"table:promotion_batches,alias:pb"`
+
+	ID                   uuid.UUID         `bun:",pk,type:uuid"`
+	SourceEnvironmentID  uuid.UUID         `bun:"source_environment_id,type:uuid,notnull"`
+	SourceEnvironmentKey string            `bun:"source_environment_key,notnull"`
+	TargetEnvironmentID  uuid.UUID         `bun:"target_environment_id,type:uuid,notnull"`
+	TargetEnvironmentKey string            `bun:"target_environment_key,notnull"`
+	Scope                string            `bun:"scope"`
+	Status               string            `bun:"status,notnull"`
+	Options              PromoteOptions    `bun:"options,type:jsonb"`
+	Summary              PromoteSummary    `bun:"summary,type:jsonb"`
+	Items                []PromoteItem     `bun:"items,type:jsonb"`
+	Errors               []PromoteError    `bun:"errors,type:jsonb"`
+	Records              []PromotionRecord `bun:"records,type:jsonb"`
+	CreatedBy            uuid.UUID         `bun:"created_by,type:uuid"`
+	CreatedAt            time.Time         `bun:"created_at,notnull"`
+	RolledBackBy         *uuid.UUID        `bun:"rolled_back_by,type:uuid"`
+	RolledBackAt         *time.Time        `bun:"rolled_back_at"`
+}
+
+func modelFromBatch(batch *PromotionBatch) *batchModel {
+	return &batchModel{
+		ID:                   batch.ID,
+		SourceEnvironmentID:  batch.SourceEnv.ID,
+		SourceEnvironmentKey: batch.SourceEnv.Key,
+		TargetEnvironmentID:  batch.TargetEnv.ID,
+		TargetEnvironmentKey: batch.TargetEnv.Key,
+		Scope:                string(batch.Scope),
+		Status:               string(batch.Status),
+		Options:              batch.Options,
+		Summary:              batch.Summary,
+		Items:                batch.Items,
+		Errors:               batch.Errors,
+		Records:              batch.Records,
+		CreatedBy:            batch.CreatedBy,
+		CreatedAt:            batch.CreatedAt,
+		RolledBackBy:         batch.RolledBackBy,
+		RolledBackAt:         batch.RolledBackAt,
+	}
+}
+
+func modelToBatch(model *batchModel) *PromotionBatch {
+	return &PromotionBatch{
+		ID:           model.ID,
+		SourceEnv:    EnvironmentRef{ID: model.SourceEnvironmentID, Key: model.SourceEnvironmentKey},
+		TargetEnv:    EnvironmentRef{ID: model.TargetEnvironmentID, Key: model.TargetEnvironmentKey},
+		Scope:        PromoteScope(model.Scope),
+		Options:      model.Options,
+		Status:       BatchStatus(model.Status),
+		Summary:      model.Summary,
+		Items:        model.Items,
+		Errors:       model.Errors,
+		Records:      model.Records,
+		CreatedBy:    model.CreatedBy,
+		CreatedAt:    model.CreatedAt,
+		RolledBackBy: model.RolledBackBy,
+		RolledBackAt: model.RolledBackAt,
+	}
+}
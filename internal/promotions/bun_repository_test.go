@@ -0,0 +1,125 @@
+package promotions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestBunBatchRepository_CRUD(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewBunBatchRepository(db)
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, ErrPromotionNotFound) {
+		t.Fatalf("expected ErrPromotionNotFound, got %v", err)
+	}
+
+	dev := EnvironmentRef{ID: uuid.New(), Key: "dev"}
+	prod := EnvironmentRef{ID: uuid.New(), Key: "prod"}
+	staging := EnvironmentRef{ID: uuid.New(), Key: "staging"}
+	base := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	pageID := uuid.New()
+
+	older, err := repo.Create(ctx, &PromotionBatch{
+		ID:        uuid.New(),
+		SourceEnv: dev,
+		TargetEnv: prod,
+		Scope:     ScopePages,
+		Status:    BatchCompleted,
+		Summary:   PromoteSummary{Pages: PromoteSummaryCounts{Updated: 1}},
+		Items:     []PromoteItem{{Kind: KindPage, SourceID: uuid.New(), TargetID: pageID, Status: "updated"}},
+		Records: []PromotionRecord{{
+			Kind:     KindPage,
+			TargetID: pageID,
+			Action:   RecordUpdated,
+			Before:   json.RawMessage(`{"slug":"about"}`),
+			Checksum: "abc",
+		}},
+		CreatedAt: base,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(older.Records) != 1 || string(older.Records[0].Before) != `{"slug":"about"}` || older.Summary.Pages.Updated != 1 {
+		t.Fatalf("Create() returned %+v", older)
+	}
+	if _, err := repo.Create(ctx, &PromotionBatch{
+		ID:        uuid.New(),
+		SourceEnv: dev,
+		TargetEnv: prod,
+		Scope:     ScopeMenus,
+		Status:    BatchCompleted,
+		CreatedAt: base.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Create() second error = %v", err)
+	}
+	if _, err := repo.Create(ctx, &PromotionBatch{
+		ID:        uuid.New(),
+		SourceEnv: dev,
+		TargetEnv: staging,
+		Scope:     ScopeAll,
+		Status:    BatchPartial,
+		CreatedAt: base.Add(2 * time.Hour),
+	}); err != nil {
+		t.Fatalf("Create() third error = %v", err)
+	}
+
+	listed, err := repo.List(ctx, BatchFilter{TargetEnvironmentID: &prod.ID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed) != 2 || listed[0].Scope != ScopeMenus || listed[1].ID != older.ID {
+		t.Fatalf("List() returned unexpected order %+v", listed)
+	}
+	paged, err := repo.List(ctx, BatchFilter{SourceEnvironmentID: &dev.ID, Limit: 1, Offset: 1})
+	if err != nil || len(paged) != 1 || paged[0].Scope != ScopeMenus {
+		t.Fatalf("List() paging returned %+v (%v)", paged, err)
+	}
+
+	rolledBackAt := base.Add(3 * time.Hour)
+	actor := uuid.New()
+	older.Status = BatchRolledBack
+	older.RolledBackAt = &rolledBackAt
+	older.RolledBackBy = &actor
+	updated, err := repo.Update(ctx, older)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Status != BatchRolledBack || updated.RolledBackBy == nil || *updated.RolledBackBy != actor {
+		t.Fatalf("Update() returned %+v", updated)
+	}
+
+	if _, err := repo.Update(ctx, &PromotionBatch{ID: uuid.New(), Status: BatchRolledBack}); !errors.Is(err, ErrPromotionNotFound) {
+		t.Fatalf("expected ErrPromotionNotFound on update, got %v", err)
+	}
+}
+
+func newTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+
+	sqldb, err := sql.Open("sqlite3", "file:promotions_test?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = sqldb.Close() })
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := db.NewCreateTable().Model((*batchModel)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return db
+}
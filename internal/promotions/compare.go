@@ -0,0 +1,886 @@
+package promotions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/schema"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+// ErrUnknownComparisonKind is returned when a comparison requests an unsupported entity kind.
+var ErrUnknownComparisonKind = errors.New("promotions: unknown comparison kind")
+
+const (
+	fieldChangeAdded    = "added"
+	fieldChangeRemoved  = "removed"
+	fieldChangeModified = "modified"
+)
+
+var comparisonKinds = []string{
+	KindContentType,
+	KindBlockDefinition,
+	KindContentEntry,
+	KindPage,
+	KindMenu,
+	KindWidgetArea,
+}
+
+var comparisonKindAliases = map[string]string{
+	"content_types":     KindContentType,
+	"content_entries":   KindContentEntry,
+	"content":           KindContentEntry,
+	"pages":             KindPage,
+	"menus":             KindMenu,
+	"block_definitions": KindBlockDefinition,
+	"blocks":            KindBlockDefinition,
+	"widget_areas":      KindWidgetArea,
+	"widgets":           KindWidgetArea,
+}
+
+// comparableRecord is the normalized view of a record used to diff environments.
+type comparableRecord struct {
+	id      uuid.UUID
+	fields  map[string]any
+	locales map[string]map[string]any
+	schema  map[string]any
+}
+
+func (s *service) CompareEnvironments(ctx context.Context, req CompareEnvironmentsRequest) (*EnvironmentComparison, error) {
+	if s == nil {
+		return nil, errors.New("promotions: service unavailable")
+	}
+	source, err := s.resolveEnvironment(ctx, req.SourceEnvironment, nil)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.resolveEnvironment(ctx, req.TargetEnvironment, nil)
+	if err != nil {
+		return nil, err
+	}
+	requested, err := normalizeComparisonKinds(req.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnvironmentComparison{
+		SourceEnv: EnvironmentRef{ID: source.ID, Key: source.Key},
+		TargetEnv: EnvironmentRef{ID: target.ID, Key: target.Key},
+	}
+	locales := newLocaleCodeCache(s.locales)
+	for _, kind := range comparisonKinds {
+		if len(requested) > 0 && !requested[kind] {
+			continue
+		}
+		if !s.comparisonAvailable(kind) {
+			if len(requested) > 0 {
+				return nil, fmt.Errorf("promotions: %s comparison unavailable", kind)
+			}
+			continue
+		}
+		sourceRecords, err := s.loadComparableRecords(ctx, kind, source, locales)
+		if err != nil {
+			return nil, err
+		}
+		targetRecords, err := s.loadComparableRecords(ctx, kind, target, locales)
+		if err != nil {
+			return nil, err
+		}
+		counts := result.Summary.countsFor(kind)
+		for _, item := range compareRecordSets(kind, sourceRecords, targetRecords) {
+			updateComparisonSummary(counts, item.Status)
+			if item.Status == ComparisonUnchanged && !req.IncludeUnchanged {
+				continue
+			}
+			result.Items = append(result.Items, item)
+		}
+	}
+	return result, nil
+}
+
+func (s *service) comparisonAvailable(kind string) bool {
+	switch kind {
+	case KindContentType, KindContentEntry:
+		return s.contentTypes != nil && s.contents != nil
+	case KindBlockDefinition:
+		return s.blocks != nil
+	case KindPage:
+		return s.pages != nil
+	case KindMenu:
+		return s.menus != nil && s.menuRecords != nil
+	case KindWidgetArea:
+		return s.widgets != nil
+	default:
+		return false
+	}
+}
+
+func (s *service) loadComparableRecords(ctx context.Context, kind string, env *cmsenv.Environment, locales *localeCodeCache) (map[string]comparableRecord, error) {
+	switch kind {
+	case KindContentType:
+		return s.comparableContentTypes(ctx, env)
+	case KindContentEntry:
+		return s.comparableContentEntries(ctx, env, locales)
+	case KindBlockDefinition:
+		return s.comparableBlockDefinitions(ctx, env)
+	case KindPage:
+		return s.comparablePages(ctx, env, locales)
+	case KindMenu:
+		return s.comparableMenus(ctx, env, locales)
+	case KindWidgetArea:
+		return s.comparableWidgetAreas(ctx, env, locales)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownComparisonKind, kind)
+	}
+}
+
+func (s *service) comparableContentTypes(ctx context.Context, env *cmsenv.Environment) (map[string]comparableRecord, error) {
+	records, err := s.contentTypes.List(ctx, env.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]comparableRecord, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		out[normalizeMatchKey(record.Slug)] = comparableRecord{
+			id: record.ID,
+			fields: map[string]any{
+				"name":           record.Name,
+				"description":    stringValue(record.Description),
+				"icon":           stringValue(record.Icon),
+				"status":         record.Status,
+				"schema_version": record.SchemaVersion,
+				"schema":         record.Schema,
+				"ui_schema":      record.UISchema,
+				"capabilities":   record.Capabilities,
+			},
+			schema: record.Schema,
+		}
+	}
+	return out, nil
+}
+
+func (s *service) comparableContentEntries(ctx context.Context, env *cmsenv.Environment, locales *localeCodeCache) (map[string]comparableRecord, error) {
+	types, err := s.contentTypes.List(ctx, env.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	typeSlugs := make(map[uuid.UUID]string, len(types))
+	for _, ct := range types {
+		if ct != nil {
+			typeSlugs[ct.ID] = ct.Slug
+		}
+	}
+	records, err := s.contents.List(ctx, env.ID.String(), content.WithTranslations())
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]comparableRecord, len(records))
+	for _, record := range records {
+		if record == nil || record.DeletedAt != nil {
+			continue
+		}
+		typeSlug, ok := typeSlugs[record.ContentTypeID]
+		if !ok {
+			continue
+		}
+		localized := make(map[string]map[string]any, len(record.Translations))
+		for _, tr := range record.Translations {
+			if tr == nil || tr.DeletedAt != nil {
+				continue
+			}
+			fallback := ""
+			if tr.Locale != nil {
+				fallback = tr.Locale.Code
+			}
+			localized[locales.code(ctx, tr.LocaleID, fallback)] = map[string]any{
+				"title":   tr.Title,
+				"summary": stringValue(tr.Summary),
+				"content": tr.Content,
+			}
+		}
+		out[siteMatchKey(record.SiteID, contentEntryMatchKey(typeSlug, record.Slug))] = comparableRecord{
+			id: record.ID,
+			fields: map[string]any{
+				"status":   record.Status,
+				"metadata": record.Metadata,
+			},
+			locales: localized,
+		}
+	}
+	return out, nil
+}
+
+func (s *service) comparableBlockDefinitions(ctx context.Context, env *cmsenv.Environment) (map[string]comparableRecord, error) {
+	definitions, err := s.blocks.ListDefinitions(ctx, env.Key)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]comparableRecord, len(definitions))
+	for _, def := range definitions {
+		if def == nil {
+			continue
+		}
+		out[normalizeMatchKey(def.Slug)] = comparableBlockDefinition(def)
+	}
+	return out, nil
+}
+
+func comparableBlockDefinition(def *blocks.Definition) comparableRecord {
+	return comparableRecord{
+		id: def.ID,
+		fields: map[string]any{
+			"name":               def.Name,
+			"description":        stringValue(def.Description),
+			"icon":               stringValue(def.Icon),
+			"category":           stringValue(def.Category),
+			"status":             def.Status,
+			"schema_version":     def.SchemaVersion,
+			"schema":             def.Schema,
+			"ui_schema":          def.UISchema,
+			"defaults":           def.Defaults,
+			"editor_style_url":   stringValue(def.EditorStyleURL),
+			"frontend_style_url": stringValue(def.FrontendStyleURL),
+		},
+		schema: def.Schema,
+	}
+}
+
+func (s *service) comparablePages(ctx context.Context, env *cmsenv.Environment, locales *localeCodeCache) (map[string]comparableRecord, error) {
+	records, err := s.pages.List(ctx, env.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	slugs := make(map[uuid.UUID]string, len(records))
+	for _, record := range records {
+		if record != nil {
+			slugs[record.ID] = pageMatchKey(record)
+		}
+	}
+	contentSlugs, err := s.contentSlugIndex(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]comparableRecord, len(records))
+	for _, record := range records {
+		if record == nil || record.DeletedAt != nil {
+			continue
+		}
+		parent := ""
+		if record.ParentID != nil {
+			parent = slugs[*record.ParentID]
+		}
+		localized := make(map[string]map[string]any, len(record.Translations))
+		for _, tr := range record.Translations {
+			if tr == nil || tr.DeletedAt != nil {
+				continue
+			}
+			localized[locales.code(ctx, tr.LocaleID, tr.Locale)] = map[string]any{
+				"title":           tr.Title,
+				"path":            tr.Path,
+				"summary":         stringValue(tr.Summary),
+				"seo_title":       stringValue(tr.SEOTitle),
+				"seo_description": stringValue(tr.SEODescription),
+			}
+		}
+		out[pageMatchKey(record)] = comparableRecord{
+			id: record.ID,
+			fields: map[string]any{
+				"status":      record.Status,
+				"template_id": record.TemplateID.String(),
+				"parent":      parent,
+				"content":     contentSlugs[record.ContentID],
+			},
+			locales: localized,
+		}
+	}
+	return out, nil
+}
+
+func (s *service) contentSlugIndex(ctx context.Context, env *cmsenv.Environment) (map[uuid.UUID]string, error) {
+	if s.contents == nil || s.contentTypes == nil {
+		return map[uuid.UUID]string{}, nil
+	}
+	types, err := s.contentTypes.List(ctx, env.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	typeSlugs := make(map[uuid.UUID]string, len(types))
+	for _, ct := range types {
+		if ct != nil {
+			typeSlugs[ct.ID] = ct.Slug
+		}
+	}
+	records, err := s.contents.List(ctx, env.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uuid.UUID]string, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		out[record.ID] = siteMatchKey(record.SiteID, contentEntryMatchKey(typeSlugs[record.ContentTypeID], record.Slug))
+	}
+	return out, nil
+}
+
+func (s *service) comparableMenus(ctx context.Context, env *cmsenv.Environment, locales *localeCodeCache) (map[string]comparableRecord, error) {
+	records, err := s.menuRecords.List(ctx, env.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	bindingsByMenu := map[string][]any{}
+	if s.menuBindings != nil {
+		bindings, err := s.menuBindings.List(ctx, env.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range bindings {
+			if binding == nil {
+				continue
+			}
+			key := normalizeMatchKey(binding.MenuCode)
+			bindingsByMenu[key] = append(bindingsByMenu[key], map[string]any{
+				"location":     binding.Location,
+				"locale":       stringValue(binding.Locale),
+				"view_profile": stringValue(binding.ViewProfileCode),
+				"priority":     binding.Priority,
+				"status":       binding.Status,
+			})
+		}
+	}
+	out := make(map[string]comparableRecord, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		menu, err := s.menus.GetMenu(ctx, record.ID)
+		if err != nil {
+			return nil, err
+		}
+		items := map[string]any{}
+		localized := map[string]map[string]any{}
+		flat := flattenMenuItems(menu.Items)
+		keys := menuItemMatchKeys(flat)
+		for _, item := range flat {
+			key := keys[item.ID]
+			parent := ""
+			if item.ParentID != nil {
+				parent = keys[*item.ParentID]
+			}
+			items[key] = map[string]any{
+				"type":        item.Type,
+				"parent":      parent,
+				"position":    item.Position,
+				"target":      item.Target,
+				"icon":        item.Icon,
+				"badge":       item.Badge,
+				"permissions": item.Permissions,
+				"classes":     item.Classes,
+				"collapsible": item.Collapsible,
+				"collapsed":   item.Collapsed,
+				"metadata":    item.Metadata,
+			}
+			for _, tr := range item.Translations {
+				if tr == nil || tr.DeletedAt != nil {
+					continue
+				}
+				fallback := ""
+				if tr.Locale != nil {
+					fallback = tr.Locale.Code
+				}
+				code := locales.code(ctx, tr.LocaleID, fallback)
+				if localized[code] == nil {
+					localized[code] = map[string]any{"items": map[string]any{}}
+				}
+				localized[code]["items"].(map[string]any)[key] = map[string]any{
+					"label":        tr.Label,
+					"label_key":    tr.LabelKey,
+					"group_title":  tr.GroupTitle,
+					"url_override": stringValue(tr.URLOverride),
+				}
+			}
+		}
+		bindings := bindingsByMenu[normalizeMatchKey(record.Code)]
+		sortComparableList(bindings)
+		out[siteMatchKey(record.SiteID, record.Code)] = comparableRecord{
+			id: record.ID,
+			fields: map[string]any{
+				"location":    record.Location,
+				"description": stringValue(record.Description),
+				"status":      record.Status,
+				"bindings":    bindings,
+				"items":       items,
+			},
+			locales: localized,
+		}
+	}
+	return out, nil
+}
+
+// comparableWidgetAreas matches the widgets of each area by definition name
+// and their order among widgets of the same definition, since widget
+// instances carry no code of their own.
+func (s *service) comparableWidgetAreas(ctx context.Context, env *cmsenv.Environment, locales *localeCodeCache) (map[string]comparableRecord, error) {
+	areaDefs, err := s.widgets.ListAreaDefinitions(ctx)
+	if err != nil {
+		if errors.Is(err, widgets.ErrAreaFeatureDisabled) {
+			return map[string]comparableRecord{}, nil
+		}
+		return nil, err
+	}
+	definitions, err := s.widgets.ListDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(definitions))
+	for _, def := range definitions {
+		if def != nil {
+			names[def.ID] = def.Name
+		}
+	}
+	areas, err := s.loadAreaWidgets(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]comparableRecord, len(areas))
+	for _, area := range areaDefs {
+		if area == nil {
+			continue
+		}
+		group := areas[area.Code]
+		if group == nil {
+			continue
+		}
+		keys := make(map[uuid.UUID]string, len(group.instances))
+		ordinals := map[string]int{}
+		items := map[string]any{}
+		localized := map[string]map[string]any{}
+		for _, widget := range group.instances {
+			name := names[widget.DefinitionID]
+			if name == "" {
+				name = widget.DefinitionID.String()
+			}
+			ordinals[name]++
+			key := fmt.Sprintf("%s#%d", name, ordinals[name])
+			keys[widget.ID] = key
+			items[key] = map[string]any{
+				"configuration":    widget.Configuration,
+				"visibility_rules": widget.VisibilityRules,
+				"placement":        widget.Placement,
+				"publish_on":       widget.PublishOn,
+				"unpublish_on":     widget.UnpublishOn,
+			}
+			for _, tr := range widget.Translations {
+				if tr == nil || tr.DeletedAt != nil {
+					continue
+				}
+				code := locales.code(ctx, tr.LocaleID, "")
+				if localized[code] == nil {
+					localized[code] = map[string]any{"widgets": map[string]any{}}
+				}
+				localized[code]["widgets"].(map[string]any)[key] = tr.Content
+			}
+		}
+		placements := map[string]any{}
+		for _, placement := range group.placements {
+			locale := "any"
+			if placement.LocaleID != nil {
+				locale = locales.code(ctx, *placement.LocaleID, "")
+			}
+			list, _ := placements[locale].([]any)
+			placements[locale] = append(list, keys[placement.InstanceID])
+		}
+		out[normalizeMatchKey(area.Code)] = comparableRecord{
+			id: area.ID,
+			fields: map[string]any{
+				"widgets":    items,
+				"placements": placements,
+			},
+			locales: localized,
+		}
+	}
+	return out, nil
+}
+
+func compareRecordSets(kind string, source, target map[string]comparableRecord) []ComparisonItem {
+	keys := make([]string, 0, len(source)+len(target))
+	for key := range source {
+		keys = append(keys, key)
+	}
+	for key := range target {
+		if _, ok := source[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	items := make([]ComparisonItem, 0, len(keys))
+	for _, key := range keys {
+		src, inSource := source[key]
+		tgt, inTarget := target[key]
+		item := ComparisonItem{Kind: kind, Key: key}
+		switch {
+		case inSource && !inTarget:
+			item.Status = ComparisonAdded
+			item.SourceID = uuidPtr(src.id)
+		case !inSource && inTarget:
+			item.Status = ComparisonRemoved
+			item.TargetID = uuidPtr(tgt.id)
+		default:
+			item.SourceID = uuidPtr(src.id)
+			item.TargetID = uuidPtr(tgt.id)
+			item.Changes = diffComparableRecords(src, tgt)
+			if src.schema != nil || tgt.schema != nil {
+				item.Compatibility = buildSchemaCompatibility(schema.CheckSchemaCompatibility(tgt.schema, src.schema))
+			}
+			item.Status = ComparisonUnchanged
+			if len(item.Changes) > 0 || (item.Compatibility != nil && item.Compatibility.ChangeLevel != schema.ChangeNone.String()) {
+				item.Status = ComparisonChanged
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func diffComparableRecords(source, target comparableRecord) []FieldChange {
+	var changes []FieldChange
+	diffValues("", "", normalizeComparable(source.fields), normalizeComparable(target.fields), &changes)
+	localeKeys := make([]string, 0, len(source.locales)+len(target.locales))
+	for code := range source.locales {
+		localeKeys = append(localeKeys, code)
+	}
+	for code := range target.locales {
+		if _, ok := source.locales[code]; !ok {
+			localeKeys = append(localeKeys, code)
+		}
+	}
+	sort.Strings(localeKeys)
+	for _, code := range localeKeys {
+		src, inSource := source.locales[code]
+		tgt, inTarget := target.locales[code]
+		switch {
+		case inSource && !inTarget:
+			changes = append(changes, FieldChange{Path: "translation", Locale: code, Change: fieldChangeAdded})
+		case !inSource && inTarget:
+			changes = append(changes, FieldChange{Path: "translation", Locale: code, Change: fieldChangeRemoved})
+		default:
+			diffValues("", code, normalizeComparable(src), normalizeComparable(tgt), &changes)
+		}
+	}
+	return changes
+}
+
+// diffValues walks nested maps and records leaf differences; arrays and scalars
+// are compared as whole values.
+func diffValues(path, locale string, source, target any, changes *[]FieldChange) {
+	srcMap, srcIsMap := source.(map[string]any)
+	tgtMap, tgtIsMap := target.(map[string]any)
+	if srcIsMap && tgtIsMap {
+		keys := make([]string, 0, len(srcMap)+len(tgtMap))
+		for key := range srcMap {
+			keys = append(keys, key)
+		}
+		for key := range tgtMap {
+			if _, ok := srcMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffValues(joinFieldPath(path, key), locale, srcMap[key], tgtMap[key], changes)
+		}
+		return
+	}
+	if isEmptyComparable(source) && isEmptyComparable(target) {
+		return
+	}
+	if reflect.DeepEqual(source, target) {
+		return
+	}
+	change := FieldChange{Path: path, Locale: locale, Source: source, Target: target}
+	switch {
+	case isEmptyComparable(target):
+		change.Change = fieldChangeAdded
+	case isEmptyComparable(source):
+		change.Change = fieldChangeRemoved
+	default:
+		change.Change = fieldChangeModified
+	}
+	*changes = append(*changes, change)
+}
+
+func buildSchemaCompatibility(result schema.CompatibilityResult) *SchemaCompatibility {
+	out := &SchemaCompatibility{
+		Compatible:  result.Compatible,
+		ChangeLevel: result.ChangeLevel.String(),
+		Warnings:    append([]string(nil), result.Warnings...),
+	}
+	for _, change := range result.BreakingChanges {
+		out.BreakingChanges = append(out.BreakingChanges, SchemaBreakingChange{
+			Type:        change.Type,
+			Field:       change.Field,
+			Description: change.Description,
+		})
+	}
+	return out
+}
+
+func normalizeComparisonKinds(kinds []string) (map[string]bool, error) {
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]bool, len(kinds))
+	for _, raw := range kinds {
+		kind := strings.ToLower(strings.TrimSpace(raw))
+		if kind == "" {
+			continue
+		}
+		if alias, ok := comparisonKindAliases[kind]; ok {
+			kind = alias
+		}
+		if !slices.Contains(comparisonKinds, kind) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownComparisonKind, raw)
+		}
+		out[kind] = true
+	}
+	return out, nil
+}
+
+func (s *ComparisonSummary) countsFor(kind string) *ComparisonSummaryCounts {
+	switch kind {
+	case KindContentType:
+		return &s.ContentTypes
+	case KindContentEntry:
+		return &s.ContentEntries
+	case KindPage:
+		return &s.Pages
+	case KindMenu:
+		return &s.Menus
+	case KindBlockDefinition:
+		return &s.BlockDefinitions
+	case KindWidgetArea:
+		return &s.WidgetAreas
+	default:
+		return nil
+	}
+}
+
+func updateComparisonSummary(counts *ComparisonSummaryCounts, status ComparisonStatus) {
+	if counts == nil {
+		return
+	}
+	switch status {
+	case ComparisonAdded:
+		counts.Added++
+	case ComparisonRemoved:
+		counts.Removed++
+	case ComparisonChanged:
+		counts.Changed++
+	case ComparisonUnchanged:
+		counts.Unchanged++
+	}
+}
+
+// localeCodeCache resolves locale IDs to codes once per comparison.
+type localeCodeCache struct {
+	repo  content.LocaleRepository
+	codes map[uuid.UUID]string
+}
+
+func newLocaleCodeCache(repo content.LocaleRepository) *localeCodeCache {
+	return &localeCodeCache{repo: repo, codes: map[uuid.UUID]string{}}
+}
+
+func (c *localeCodeCache) code(ctx context.Context, id uuid.UUID, fallback string) string {
+	if trimmed := strings.TrimSpace(fallback); trimmed != "" {
+		return strings.ToLower(trimmed)
+	}
+	if code, ok := c.codes[id]; ok {
+		return code
+	}
+	code := id.String()
+	if c.repo != nil && id != uuid.Nil {
+		if locale, err := c.repo.GetByID(ctx, id); err == nil && locale != nil {
+			code = strings.ToLower(strings.TrimSpace(locale.Code))
+		}
+	}
+	c.codes[id] = code
+	return code
+}
+
+func flattenMenuItems(items []*menus.MenuItem) []*menus.MenuItem {
+	var out []*menus.MenuItem
+	var walk func([]*menus.MenuItem)
+	walk = func(nodes []*menus.MenuItem) {
+		for _, item := range nodes {
+			if item == nil || item.DeletedAt != nil {
+				continue
+			}
+			out = append(out, item)
+			walk(item.Children)
+		}
+	}
+	walk(items)
+	return out
+}
+
+// menuItemMatchKeys keys pre-ordered items so parents are resolved before
+// their children.
+func menuItemMatchKeys(items []*menus.MenuItem) map[uuid.UUID]string {
+	keys := make(map[uuid.UUID]string, len(items))
+	for _, item := range items {
+		parent := ""
+		if item.ParentID != nil {
+			parent = keys[*item.ParentID]
+		}
+		keys[item.ID] = menuItemMatchKey(item, parent)
+	}
+	return keys
+}
+
+// menuItemMatchKey prefers the stable external code or canonical key. Items
+// without either, or whose canonical key embeds a record ID, are matched
+// structurally by parent, position and label, since IDs differ between
+// environments.
+func menuItemMatchKey(item *menus.MenuItem, parentKey string) string {
+	if item == nil {
+		return ""
+	}
+	if code := strings.TrimSpace(item.ExternalCode); code != "" {
+		return code
+	}
+	if item.CanonicalKey != nil {
+		if key := strings.TrimSpace(*item.CanonicalKey); key != "" && !containsUUID(key) {
+			return key
+		}
+	}
+	return fmt.Sprintf("%s/%d:%s", parentKey, item.Position, menuItemLabelKey(item))
+}
+
+func containsUUID(key string) bool {
+	for part := range strings.SplitSeq(key, ":") {
+		if _, err := uuid.Parse(part); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// menuItemLabelKey returns the lowest translation label key or label so the
+// result does not depend on translation load order.
+func menuItemLabelKey(item *menus.MenuItem) string {
+	labels := make([]string, 0, len(item.Translations))
+	for _, tr := range item.Translations {
+		if tr == nil || tr.DeletedAt != nil {
+			continue
+		}
+		label := strings.TrimSpace(tr.LabelKey)
+		if label == "" {
+			label = strings.TrimSpace(tr.Label)
+		}
+		if label != "" {
+			labels = append(labels, normalizeMatchKey(label))
+		}
+	}
+	if len(labels) == 0 {
+		return normalizeMatchKey(item.Type)
+	}
+	slices.Sort(labels)
+	return labels[0]
+}
+
+func contentEntryMatchKey(typeSlug, slug string) string {
+	return normalizeMatchKey(typeSlug) + "/" + normalizeMatchKey(slug)
+}
+
+func normalizeMatchKey(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// siteMatchKey matches a site-scoped record across environments by its site
+// and natural key. Shared records keep the bare key.
+func siteMatchKey(siteID *uuid.UUID, key string) string {
+	key = normalizeMatchKey(key)
+	if siteID == nil || *siteID == uuid.Nil {
+		return key
+	}
+	return siteID.String() + "/" + key
+}
+
+func joinFieldPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
+// normalizeComparable round-trips values through JSON so records loaded from
+// different stores compare equal regardless of numeric or slice types.
+func normalizeComparable(value any) any {
+	if value == nil {
+		return nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return value
+	}
+	return out
+}
+
+func isEmptyComparable(value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typed == ""
+	case map[string]any:
+		return len(typed) == 0
+	case []any:
+		return len(typed) == 0
+	default:
+		return false
+	}
+}
+
+func sortComparableList(values []any) {
+	sort.SliceStable(values, func(i, j int) bool {
+		left, _ := json.Marshal(values[i])
+		right, _ := json.Marshal(values[j])
+		return string(left) < string(right)
+	})
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func uuidPtr(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	value := id
+	return &value
+}
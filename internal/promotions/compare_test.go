@@ -0,0 +1,273 @@
+package promotions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/promotions"
+	"github.com/google/uuid"
+)
+
+func TestPromotionService_CompareEnvironmentsReportsSchemaAndContentDiffs(t *testing.T) {
+	ctx := context.Background()
+
+	envSvc := newEnvService(t)
+	typeRepo := content.NewMemoryContentTypeRepository()
+	contentRepo := content.NewMemoryContentRepository()
+	localeRepo := content.NewMemoryLocaleRepository()
+	locale := &content.Locale{ID: uuid.New(), Code: "en", Display: "English", IsActive: true, IsDefault: true}
+	localeRepo.Put(locale)
+	typeSvc := content.NewContentTypeService(typeRepo, content.WithContentTypeEnvironmentService(envSvc))
+
+	devType, err := typeSvc.Create(ctx, content.CreateContentTypeRequest{
+		Name:           "Article",
+		Slug:           "article",
+		Status:         content.ContentTypeStatusActive,
+		Schema:         contentSchema("article", "article@v2.0.0", map[string]any{"title": map[string]any{"type": "number"}}, nil),
+		EnvironmentKey: "dev",
+	})
+	if err != nil {
+		t.Fatalf("create dev content type: %v", err)
+	}
+	prodType, err := typeSvc.Create(ctx, content.CreateContentTypeRequest{
+		Name:           "Article",
+		Slug:           "article",
+		Status:         content.ContentTypeStatusActive,
+		Schema:         contentSchema("article", "article@v1.0.0", map[string]any{"title": map[string]any{"type": "string"}}, nil),
+		EnvironmentKey: "prod",
+	})
+	if err != nil {
+		t.Fatalf("create prod content type: %v", err)
+	}
+	if _, err := typeSvc.Create(ctx, content.CreateContentTypeRequest{
+		Name:           "Event",
+		Slug:           "event",
+		Status:         content.ContentTypeStatusActive,
+		Schema:         contentSchema("event", "event@v1.0.0", map[string]any{"title": map[string]any{"type": "string"}}, nil),
+		EnvironmentKey: "dev",
+	}); err != nil {
+		t.Fatalf("create dev event type: %v", err)
+	}
+
+	seedComparisonContent(t, contentRepo, devType.ID, cmsenv.IDForKey("dev"), locale.ID, "hello", "Hello from dev")
+	seedComparisonContent(t, contentRepo, prodType.ID, cmsenv.IDForKey("prod"), locale.ID, "hello", "Hello from prod")
+	seedComparisonContent(t, contentRepo, prodType.ID, cmsenv.IDForKey("prod"), locale.ID, "legacy", "Legacy")
+
+	promo := promotions.NewService(envSvc, typeRepo, contentRepo, localeRepo)
+	result, err := promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+	})
+	if err != nil {
+		t.Fatalf("compare environments: %v", err)
+	}
+
+	if result.Summary.ContentTypes.Added != 1 || result.Summary.ContentTypes.Changed != 1 {
+		t.Fatalf("unexpected content type summary %+v", result.Summary.ContentTypes)
+	}
+	if result.Summary.ContentEntries.Changed != 1 || result.Summary.ContentEntries.Removed != 1 {
+		t.Fatalf("unexpected content entry summary %+v", result.Summary.ContentEntries)
+	}
+
+	article := findComparisonItem(t, result, promotions.KindContentType, "article")
+	if article.Compatibility == nil || article.Compatibility.Compatible || article.Compatibility.ChangeLevel != "major" {
+		t.Fatalf("expected major incompatible change, got %+v", article.Compatibility)
+	}
+	if len(article.Compatibility.BreakingChanges) == 0 {
+		t.Fatalf("expected breaking changes to be reported")
+	}
+
+	entry := findComparisonItem(t, result, promotions.KindContentEntry, "article/hello")
+	var titleChange *promotions.FieldChange
+	for i := range entry.Changes {
+		if entry.Changes[i].Path == "content.title" && entry.Changes[i].Locale == "en" {
+			titleChange = &entry.Changes[i]
+		}
+	}
+	if titleChange == nil || titleChange.Change != "modified" || titleChange.Source != "Hello from dev" || titleChange.Target != "Hello from prod" {
+		t.Fatalf("expected localized content.title change, got %+v", entry.Changes)
+	}
+
+	legacy := findComparisonItem(t, result, promotions.KindContentEntry, "article/legacy")
+	if legacy.Status != promotions.ComparisonRemoved || legacy.TargetID == nil {
+		t.Fatalf("expected legacy entry to be reported as removed, got %+v", legacy)
+	}
+}
+
+func TestPromotionService_CompareEnvironmentsMenus(t *testing.T) {
+	ctx := context.Background()
+
+	envSvc := newEnvService(t)
+	localeRepo := content.NewMemoryLocaleRepository()
+	localeRepo.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English", IsActive: true, IsDefault: true})
+
+	menuRepo := menus.NewMemoryMenuRepository()
+	bindingRepo := menus.NewMemoryMenuLocationBindingRepository()
+	menuSvc := menus.NewService(
+		menuRepo,
+		menus.NewMemoryMenuItemRepository(),
+		menus.NewMemoryMenuItemTranslationRepository(),
+		localeRepo,
+		menus.WithEnvironmentService(envSvc),
+		menus.WithMenuLocationBindingRepository(bindingRepo),
+	)
+
+	for env, label := range map[string]string{"dev": "Home", "prod": "Start"} {
+		menu, err := menuSvc.CreateMenu(ctx, menus.CreateMenuInput{Code: "main", EnvironmentKey: env})
+		if err != nil {
+			t.Fatalf("create %s menu: %v", env, err)
+		}
+		if _, err := menuSvc.AddMenuItem(ctx, menus.AddMenuItemInput{
+			MenuID:       menu.ID,
+			ExternalCode: "home",
+			Type:         menus.MenuItemTypeItem,
+			Target:       map[string]any{"type": "url", "url": "/"},
+			Translations: []menus.MenuItemTranslationInput{{Locale: "en", Label: label}},
+		}); err != nil {
+			t.Fatalf("add %s menu item: %v", env, err)
+		}
+	}
+	if _, err := menuSvc.CreateMenu(ctx, menus.CreateMenuInput{Code: "footer", EnvironmentKey: "dev"}); err != nil {
+		t.Fatalf("create footer menu: %v", err)
+	}
+
+	promo := promotions.NewService(
+		envSvc,
+		content.NewMemoryContentTypeRepository(),
+		content.NewMemoryContentRepository(),
+		localeRepo,
+		promotions.WithMenuService(menuSvc),
+		promotions.WithMenuRepository(menuRepo),
+		promotions.WithMenuLocationBindingRepository(bindingRepo),
+	)
+	result, err := promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Kinds:             []string{"menus"},
+	})
+	if err != nil {
+		t.Fatalf("compare menus: %v", err)
+	}
+	if result.Summary.Menus.Added != 1 || result.Summary.Menus.Changed != 1 {
+		t.Fatalf("unexpected menu summary %+v", result.Summary.Menus)
+	}
+	main := findComparisonItem(t, result, promotions.KindMenu, "main")
+	if len(main.Changes) != 1 || main.Changes[0].Path != "items.home.label" || main.Changes[0].Locale != "en" {
+		t.Fatalf("expected localized label change, got %+v", main.Changes)
+	}
+
+	if _, err := promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Kinds:             []string{"widgets"},
+	}); err == nil {
+		t.Fatalf("expected unknown comparison kind error")
+	}
+}
+
+func TestPromotionService_CompareEnvironmentsMatchesMenuItemsWithoutCodes(t *testing.T) {
+	ctx := context.Background()
+
+	envSvc := newEnvService(t)
+	localeRepo := content.NewMemoryLocaleRepository()
+	localeRepo.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English", IsActive: true, IsDefault: true})
+
+	menuRepo := menus.NewMemoryMenuRepository()
+	menuSvc := menus.NewService(
+		menuRepo,
+		menus.NewMemoryMenuItemRepository(),
+		menus.NewMemoryMenuItemTranslationRepository(),
+		localeRepo,
+		menus.WithEnvironmentService(envSvc),
+	)
+
+	for _, env := range []string{"dev", "prod"} {
+		menu, err := menuSvc.CreateMenu(ctx, menus.CreateMenuInput{Code: "docs", EnvironmentKey: env})
+		if err != nil {
+			t.Fatalf("create %s menu: %v", env, err)
+		}
+		parent, err := menuSvc.AddMenuItem(ctx, menus.AddMenuItemInput{
+			MenuID:       menu.ID,
+			ExternalCode: "guides",
+			Type:         menus.MenuItemTypeItem,
+			Target:       map[string]any{"type": "url", "url": "/guides"},
+			Translations: []menus.MenuItemTranslationInput{{Locale: "en", Label: "Guides"}},
+		})
+		if err != nil {
+			t.Fatalf("add %s parent item: %v", env, err)
+		}
+		// Group keys embed the environment-specific parent ID.
+		if _, err := menuSvc.AddMenuItem(ctx, menus.AddMenuItemInput{
+			MenuID:       menu.ID,
+			ParentID:     &parent.ID,
+			Type:         menus.MenuItemTypeGroup,
+			Translations: []menus.MenuItemTranslationInput{{Locale: "en", Label: "Reference"}},
+		}); err != nil {
+			t.Fatalf("add %s group item: %v", env, err)
+		}
+	}
+
+	promo := promotions.NewService(
+		envSvc,
+		content.NewMemoryContentTypeRepository(),
+		content.NewMemoryContentRepository(),
+		localeRepo,
+		promotions.WithMenuService(menuSvc),
+		promotions.WithMenuRepository(menuRepo),
+	)
+	result, err := promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Kinds:             []string{"menus"},
+		IncludeUnchanged:  true,
+	})
+	if err != nil {
+		t.Fatalf("compare menus: %v", err)
+	}
+	docs := findComparisonItem(t, result, promotions.KindMenu, "docs")
+	if docs.Status != promotions.ComparisonUnchanged {
+		t.Fatalf("expected identical trees to match, got %s with changes %+v", docs.Status, docs.Changes)
+	}
+}
+
+func seedComparisonContent(t *testing.T, repo *content.MemoryContentRepository, typeID, envID, localeID uuid.UUID, slug, title string) {
+	t.Helper()
+	now := time.Now().UTC()
+	id := uuid.New()
+	if _, err := repo.Create(context.Background(), &content.Content{
+		ID:            id,
+		ContentTypeID: typeID,
+		EnvironmentID: envID,
+		Status:        string(domain.StatusPublished),
+		Slug:          slug,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Translations: []*content.ContentTranslation{{
+			ID:        uuid.New(),
+			ContentID: id,
+			LocaleID:  localeID,
+			Title:     title,
+			Content:   map[string]any{"title": title},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}},
+	}); err != nil {
+		t.Fatalf("seed content %s: %v", slug, err)
+	}
+}
+
+func findComparisonItem(t *testing.T, result *promotions.EnvironmentComparison, kind, key string) promotions.ComparisonItem {
+	t.Helper()
+	for _, item := range result.Items {
+		if item.Kind == kind && item.Key == key {
+			return item
+		}
+	}
+	t.Fatalf("comparison item %s %s not found in %+v", kind, key, result.Items)
+	return promotions.ComparisonItem{}
+}
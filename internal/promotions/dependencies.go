@@ -0,0 +1,115 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goliatone/go-cms/internal/content"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/google/uuid"
+)
+
+// promotionTarget carries the resolved environments and options shared by a
+// promotion and the dependencies it pulls along.
+type promotionTarget struct {
+	source *cmsenv.Environment
+	target *cmsenv.Environment
+	opts   PromoteOptions
+}
+
+func dependencyError(kind, key string) error {
+	return fmt.Errorf("%w: %s %s", ErrDependencyMissing, kind, key)
+}
+
+// promotionPlan records the target IDs planned during a dry-run bulk promotion
+// so later entities can resolve dependencies that were not actually written.
+type promotionPlan struct {
+	targets map[string]uuid.UUID
+}
+
+type promotionPlanKey struct{}
+
+func withPromotionPlan(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(promotionPlanKey{}).(*promotionPlan); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, promotionPlanKey{}, &promotionPlan{targets: map[string]uuid.UUID{}})
+}
+
+func recordPlannedTarget(ctx context.Context, kind, key string, id uuid.UUID) {
+	plan, ok := ctx.Value(promotionPlanKey{}).(*promotionPlan)
+	if !ok || id == uuid.Nil {
+		return
+	}
+	plan.targets[kind+":"+normalizeMatchKey(key)] = id
+}
+
+func plannedTarget(ctx context.Context, kind, key string) (uuid.UUID, bool) {
+	plan, ok := ctx.Value(promotionPlanKey{}).(*promotionPlan)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, found := plan.targets[kind+":"+normalizeMatchKey(key)]
+	return id, found
+}
+
+// resolveTargetContent maps a source content ID onto the entry with the same
+// content type and slug in the target environment.
+func (s *service) resolveTargetContent(ctx context.Context, contentID uuid.UUID, req promotionTarget) (uuid.UUID, error) {
+	if contentID == uuid.Nil {
+		return uuid.Nil, nil
+	}
+	sourceContent, err := s.contents.GetByID(ctx, contentID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	sourceType, err := s.contentTypes.GetByID(ctx, sourceContent.ContentTypeID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	key := contentEntryMatchKey(sourceType.Slug, sourceContent.Slug)
+
+	targetType, err := s.contentTypes.GetBySlug(ctx, sourceType.Slug, req.target.ID.String())
+	if err == nil {
+		existing, err := s.contents.GetBySlug(ctx, sourceContent.Slug, targetType.ID, req.target.ID.String())
+		if err == nil {
+			return existing.ID, nil
+		}
+		if !isContentNotFound(err) {
+			return uuid.Nil, err
+		}
+	} else if !isContentNotFound(err) {
+		return uuid.Nil, err
+	}
+
+	if id, ok := plannedTarget(ctx, KindContentEntry, key); ok {
+		return id, nil
+	}
+	if !req.opts.AutoPromoteDependencies {
+		return uuid.Nil, dependencyError(KindContentEntry, key)
+	}
+	depOpts := req.opts
+	depOpts.AutoPromoteType = true
+	item, err := s.PromoteContentEntry(ctx, PromoteContentEntryRequest{
+		ContentID:         contentID,
+		TargetEnvironment: req.target.Key,
+		Options:           depOpts,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return item.TargetID, nil
+}
+
+func isContentNotFound(err error) bool {
+	var nf *content.NotFoundError
+	return errors.As(err, &nf)
+}
+
+func detailCount(details map[string]any, key string) int {
+	if count, ok := details[key].(int); ok {
+		return count
+	}
+	return 0
+}
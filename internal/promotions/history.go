@@ -0,0 +1,339 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/google/uuid"
+)
+
+// promotionJournal collects the target records written during a promotion run
+// so the run can be persisted as a batch and rolled back later.
+type promotionJournal struct {
+	source  *cmsenv.Environment
+	target  *cmsenv.Environment
+	records []PromotionRecord
+	seen    map[string]struct{}
+}
+
+type promotionJournalKey struct{}
+
+// beginJournal attaches a journal to ctx when batch history is enabled. It
+// returns a nil journal for dry runs and for promotions nested in a run that
+// already owns a journal.
+func (s *service) beginJournal(ctx context.Context, opts PromoteOptions) (context.Context, *promotionJournal) {
+	if s.batches == nil || opts.DryRun {
+		return ctx, nil
+	}
+	if _, ok := ctx.Value(promotionJournalKey{}).(*promotionJournal); ok {
+		return ctx, nil
+	}
+	journal := &promotionJournal{seen: map[string]struct{}{}}
+	return context.WithValue(ctx, promotionJournalKey{}, journal), journal
+}
+
+// journalUpdate captures the state of an existing target record before a
+// promotion overwrites it.
+func (s *service) journalUpdate(ctx context.Context, scope promotionTarget, kind string, sourceID, targetID uuid.UUID) error {
+	journal, ok := s.journalEntry(ctx, scope, kind, targetID)
+	if !ok {
+		return nil
+	}
+	before, found, err := s.loadRecordState(ctx, kind, targetID, scope.target.ID)
+	if err != nil {
+		return err
+	}
+	record := PromotionRecord{Kind: kind, SourceID: sourceID, TargetID: targetID, Action: RecordCreated}
+	if found {
+		record.Action = RecordUpdated
+		record.Before = before
+	}
+	journal.records = append(journal.records, record)
+	return nil
+}
+
+// journalCreate records a target record created by a promotion.
+func (s *service) journalCreate(ctx context.Context, scope promotionTarget, kind string, sourceID, targetID uuid.UUID) {
+	journal, ok := s.journalEntry(ctx, scope, kind, targetID)
+	if !ok {
+		return
+	}
+	journal.records = append(journal.records, PromotionRecord{Kind: kind, SourceID: sourceID, TargetID: targetID, Action: RecordCreated})
+}
+
+func (s *service) journalEntry(ctx context.Context, scope promotionTarget, kind string, targetID uuid.UUID) (*promotionJournal, bool) {
+	journal, ok := ctx.Value(promotionJournalKey{}).(*promotionJournal)
+	if !ok || targetID == uuid.Nil {
+		return nil, false
+	}
+	if journal.source == nil {
+		journal.source = scope.source
+		journal.target = scope.target
+	}
+	key := kind + ":" + targetID.String()
+	if _, exists := journal.seen[key]; exists {
+		return nil, false
+	}
+	journal.seen[key] = struct{}{}
+	return journal, true
+}
+
+// saveBatch fingerprints the state left behind by the run and persists it.
+func (s *service) saveBatch(ctx context.Context, journal *promotionJournal, scope PromoteScope, opts PromoteOptions, result *PromoteEnvironmentResult, runErr error) (*PromotionBatch, error) {
+	records := make([]PromotionRecord, 0, len(journal.records))
+	for _, record := range journal.records {
+		state, found, err := s.loadRecordState(ctx, record.Kind, record.TargetID, journal.target.ID)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			record.Checksum = stateChecksum(state)
+		}
+		records = append(records, record)
+	}
+	status := BatchCompleted
+	switch {
+	case runErr != nil || (len(result.Errors) > 0 && len(result.Items) == 0):
+		status = BatchFailed
+	case len(result.Errors) > 0:
+		status = BatchPartial
+	}
+	batch := &PromotionBatch{
+		ID:        s.id(),
+		SourceEnv: result.SourceEnv,
+		TargetEnv: result.TargetEnv,
+		Scope:     scope,
+		Options:   opts,
+		Status:    status,
+		Summary:   result.Summary,
+		Items:     result.Items,
+		Errors:    result.Errors,
+		Records:   records,
+		CreatedBy: opts.ActorID,
+		CreatedAt: s.now(),
+	}
+	if runErr != nil {
+		batch.Errors = append(batch.Errors, PromoteError{Error: runErr.Error()})
+	}
+	return s.batches.Create(ctx, batch)
+}
+
+// recordSinglePromotion runs a single-record promotion and persists it as a
+// batch when it wrote to the target environment.
+func (s *service) recordSinglePromotion(ctx context.Context, kind string, sourceID uuid.UUID, opts PromoteOptions, promote func(context.Context) (*PromoteItem, error)) (*PromoteItem, error) {
+	ctx, journal := s.beginJournal(ctx, opts)
+	item, err := promote(ctx)
+	if journal == nil || len(journal.records) == 0 {
+		return item, err
+	}
+	result := &PromoteEnvironmentResult{
+		SourceEnv: EnvironmentRef{ID: journal.source.ID, Key: journal.source.Key},
+		TargetEnv: EnvironmentRef{ID: journal.target.ID, Key: journal.target.Key},
+	}
+	if err != nil {
+		result.Errors = append(result.Errors, PromoteError{Kind: kind, SourceID: sourceID, Error: err.Error()})
+	} else if item != nil {
+		result.Items = append(result.Items, *item)
+		if counts := result.Summary.countsFor(kind); counts != nil {
+			updateSummary(counts, item.Status)
+		}
+	}
+	batch, saveErr := s.saveBatch(ctx, journal, scopeForKind(kind), opts, result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if saveErr != nil {
+		return nil, fmt.Errorf("promotions: record promotion batch: %w", saveErr)
+	}
+	if item != nil {
+		item.BatchID = &batch.ID
+	}
+	return item, nil
+}
+
+func (s *service) ListPromotions(ctx context.Context, req ListPromotionsRequest) ([]*PromotionBatch, error) {
+	if s == nil || s.batches == nil {
+		return nil, ErrPromotionHistoryUnavailable
+	}
+	filter := BatchFilter{Limit: req.Limit, Offset: req.Offset}
+	if key := strings.TrimSpace(req.SourceEnvironment); key != "" {
+		env, err := s.resolveEnvironment(ctx, key, nil)
+		if err != nil {
+			return nil, err
+		}
+		filter.SourceEnvironmentID = &env.ID
+	}
+	if key := strings.TrimSpace(req.TargetEnvironment); key != "" {
+		env, err := s.resolveEnvironment(ctx, key, nil)
+		if err != nil {
+			return nil, err
+		}
+		filter.TargetEnvironmentID = &env.ID
+	}
+	return s.batches.List(ctx, filter)
+}
+
+func (s *service) GetPromotion(ctx context.Context, batchID uuid.UUID) (*PromotionBatch, error) {
+	if s == nil || s.batches == nil {
+		return nil, ErrPromotionHistoryUnavailable
+	}
+	return s.batches.GetByID(ctx, batchID)
+}
+
+// RollbackPromotion restores the target records of a batch to their state
+// before the promotion and deletes the records it created. Records are
+// processed in reverse order so dependents are reverted before dependencies.
+// The rollback is refused with ErrPromotionTargetChanged when any record was
+// modified after the promotion, unless opts.Force is set. When a record fails,
+// the records reverted so far are saved with the BatchRollbackPartial status
+// so a retry skips them and resumes with the rest.
+func (s *service) RollbackPromotion(ctx context.Context, batchID uuid.UUID, opts RollbackOptions) (*RollbackResult, error) {
+	if s == nil || s.batches == nil {
+		return nil, ErrPromotionHistoryUnavailable
+	}
+	batch, err := s.batches.GetByID(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status == BatchRolledBack {
+		return nil, ErrPromotionRolledBack
+	}
+
+	present := make([]bool, len(batch.Records))
+	var changed []string
+	for i, record := range batch.Records {
+		if record.RolledBack {
+			continue
+		}
+		state, found, err := s.loadRecordState(ctx, record.Kind, record.TargetID, batch.TargetEnv.ID)
+		if err != nil {
+			return nil, err
+		}
+		present[i] = found
+		if record.Checksum == "" {
+			continue
+		}
+		if !found || stateChecksum(state) != record.Checksum {
+			changed = append(changed, record.Kind+" "+record.TargetID.String())
+		}
+	}
+	if len(changed) > 0 && !opts.Force {
+		return nil, fmt.Errorf("%w: %s", ErrPromotionTargetChanged, strings.Join(changed, ", "))
+	}
+
+	actor := pickActor(opts.ActorID, batch.CreatedBy)
+	result := &RollbackResult{}
+	for i := len(batch.Records) - 1; i >= 0; i-- {
+		record := batch.Records[i]
+		item := RollbackItem{Kind: record.Kind, TargetID: record.TargetID}
+		switch {
+		case record.RolledBack:
+			item.Status = "skipped"
+			item.Message = "already rolled back"
+		case !present[i]:
+			item.Status = "skipped"
+			item.Message = "target record no longer exists"
+		case record.Action == RecordCreated:
+			if err := s.deleteRecord(ctx, record, actor); err != nil {
+				return nil, s.saveRollbackProgress(ctx, batch, fmt.Errorf("promotions: rollback %s %s: %w", record.Kind, record.TargetID, err))
+			}
+			item.Status = "deleted"
+		default:
+			if err := s.restoreRecord(ctx, record, actor); err != nil {
+				return nil, s.saveRollbackProgress(ctx, batch, fmt.Errorf("promotions: rollback %s %s: %w", record.Kind, record.TargetID, err))
+			}
+			item.Status = "restored"
+		}
+		batch.Records[i].RolledBack = true
+		result.Items = append(result.Items, item)
+	}
+
+	now := s.now()
+	batch.Status = BatchRolledBack
+	batch.RolledBackAt = &now
+	if opts.ActorID != uuid.Nil {
+		actorID := opts.ActorID
+		batch.RolledBackBy = &actorID
+	}
+	saved, err := s.batches.Update(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	result.Batch = saved
+	s.emitRollbackActivity(ctx, saved)
+	return result, nil
+}
+
+// saveRollbackProgress stores the records reverted before a failed rollback
+// under the BatchRollbackPartial status and returns the rollback error.
+func (s *service) saveRollbackProgress(ctx context.Context, batch *PromotionBatch, rollbackErr error) error {
+	if !slices.ContainsFunc(batch.Records, func(record PromotionRecord) bool { return record.RolledBack }) {
+		return rollbackErr
+	}
+	batch.Status = BatchRollbackPartial
+	if _, err := s.batches.Update(ctx, batch); err != nil {
+		return errors.Join(rollbackErr, fmt.Errorf("promotions: save rollback progress: %w", err))
+	}
+	return rollbackErr
+}
+
+func (s *service) emitRollbackActivity(ctx context.Context, batch *PromotionBatch) {
+	if s == nil || s.activity == nil || !s.activity.Enabled() {
+		return
+	}
+	_ = s.activity.Emit(ctx, activity.Event{
+		Verb:       "rollback",
+		ObjectType: "promotion_batch",
+		ObjectID:   batch.ID.String(),
+		Metadata: map[string]any{
+			"source_environment_id":  batch.SourceEnv.ID.String(),
+			"source_environment_key": batch.SourceEnv.Key,
+			"target_environment_id":  batch.TargetEnv.ID.String(),
+			"target_environment_key": batch.TargetEnv.Key,
+			"records":                len(batch.Records),
+		},
+	})
+}
+
+func (s *PromoteSummary) countsFor(kind string) *PromoteSummaryCounts {
+	switch kind {
+	case KindContentType:
+		return &s.ContentTypes
+	case KindContentEntry:
+		return &s.ContentEntries
+	case KindBlockDefinition:
+		return &s.BlockDefinitions
+	case KindPage:
+		return &s.Pages
+	case KindMenu:
+		return &s.Menus
+	case KindWidgetArea:
+		return &s.WidgetAreas
+	default:
+		return nil
+	}
+}
+
+func scopeForKind(kind string) PromoteScope {
+	switch kind {
+	case KindContentType:
+		return ScopeContentTypes
+	case KindContentEntry:
+		return ScopeContentEntries
+	case KindBlockDefinition:
+		return ScopeBlockDefinitions
+	case KindPage:
+		return ScopePages
+	case KindMenu:
+		return ScopeMenus
+	case KindWidgetArea:
+		return ScopeWidgets
+	default:
+		return ScopeAll
+	}
+}
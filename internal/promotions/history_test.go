@@ -0,0 +1,405 @@
+package promotions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/promotions"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+func TestPromotionService_RollbackPromotion(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	prodEnv := cmsenv.IDForKey("prod").String()
+
+	about := f.seedPage(t, "about", f.contentIDs["dev"], nil)
+	f.seedPage(t, "contact", f.contentIDs["dev"], nil)
+
+	first, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: about.ID, TargetEnvironment: "prod"})
+	if err != nil {
+		t.Fatalf("promote about: %v", err)
+	}
+	if first.BatchID == nil {
+		t.Fatalf("expected single page promotion to record a batch")
+	}
+
+	f.retitlePage(t, about.ID, "About us")
+	result, err := f.promo.PromoteEnvironment(ctx, promotions.PromoteEnvironmentRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Scope:             promotions.ScopePages,
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil {
+		t.Fatalf("promote pages: %v", err)
+	}
+	if result.BatchID == nil || result.Summary.Pages.Created != 1 || result.Summary.Pages.Updated != 1 {
+		t.Fatalf("unexpected promotion result %+v", result)
+	}
+
+	batches, err := f.promo.ListPromotions(ctx, promotions.ListPromotionsRequest{TargetEnvironment: "prod"})
+	if err != nil || len(batches) != 2 {
+		t.Fatalf("expected two recorded batches, got %d (%v)", len(batches), err)
+	}
+	batch, err := f.promo.GetPromotion(ctx, *result.BatchID)
+	if err != nil {
+		t.Fatalf("get batch: %v", err)
+	}
+	if batch.Status != promotions.BatchCompleted || len(batch.Records) != 2 {
+		t.Fatalf("unexpected batch %+v", batch)
+	}
+	actions := map[string]int{}
+	for _, record := range batch.Records {
+		actions[record.Action]++
+		if record.Action == promotions.RecordUpdated && len(record.Before) == 0 {
+			t.Fatalf("expected before state for updated record %+v", record)
+		}
+	}
+	if actions[promotions.RecordCreated] != 1 || actions[promotions.RecordUpdated] != 1 {
+		t.Fatalf("unexpected record actions %+v", actions)
+	}
+
+	rollback, err := f.promo.RollbackPromotion(ctx, batch.ID, promotions.RollbackOptions{})
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if rollback.Batch.Status != promotions.BatchRolledBack || rollback.Batch.RolledBackAt == nil || len(rollback.Items) != 2 {
+		t.Fatalf("unexpected rollback result %+v", rollback)
+	}
+	prodAbout, err := f.pageRepo.GetBySlug(ctx, "about", prodEnv)
+	if err != nil {
+		t.Fatalf("load prod about: %v", err)
+	}
+	if len(prodAbout.Translations) != 1 || prodAbout.Translations[0].Title != "about" {
+		t.Fatalf("expected about title restored, got %+v", prodAbout.Translations)
+	}
+	if _, err := f.pageRepo.GetBySlug(ctx, "contact", prodEnv); err == nil {
+		t.Fatalf("expected created page removed by rollback")
+	}
+	if _, err := f.promo.RollbackPromotion(ctx, batch.ID, promotions.RollbackOptions{}); !errors.Is(err, promotions.ErrPromotionRolledBack) {
+		t.Fatalf("expected repeated rollback to fail, got %v", err)
+	}
+
+	f.retitlePage(t, prodAbout.ID, "Edited in prod")
+	if _, err := f.promo.RollbackPromotion(ctx, *first.BatchID, promotions.RollbackOptions{}); !errors.Is(err, promotions.ErrPromotionTargetChanged) {
+		t.Fatalf("expected target change conflict, got %v", err)
+	}
+	forced, err := f.promo.RollbackPromotion(ctx, *first.BatchID, promotions.RollbackOptions{Force: true})
+	if err != nil || len(forced.Items) != 1 || forced.Items[0].Status != "deleted" {
+		t.Fatalf("expected forced rollback to delete page, got %+v (%v)", forced, err)
+	}
+	if _, err := f.pageRepo.GetBySlug(ctx, "about", prodEnv); err == nil {
+		t.Fatalf("expected promoted page removed by forced rollback")
+	}
+}
+
+func TestPromotionService_RollbackKeepsPageBlockIDs(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	prodEnv := cmsenv.IDForKey("prod").String()
+
+	about := f.seedPage(t, "about", f.contentIDs["dev"], nil)
+	hero, err := f.blockSvc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:           "Hero",
+		Slug:           "hero",
+		Schema:         blockSchema("hero", "string"),
+		EnvironmentKey: "dev",
+	})
+	if err != nil {
+		t.Fatalf("register block definition: %v", err)
+	}
+	block, err := f.blockSvc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID: hero.ID,
+		PageID:       &about.ID,
+		Region:       "main",
+		CreatedBy:    uuid.New(),
+		UpdatedBy:    uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create block instance: %v", err)
+	}
+	if _, err := f.blockSvc.AddTranslation(ctx, blocks.AddTranslationInput{
+		BlockInstanceID: block.ID,
+		LocaleID:        f.localeID,
+		Content:         map[string]any{"title": "Welcome"},
+	}); err != nil {
+		t.Fatalf("add block translation: %v", err)
+	}
+	widgetDef, err := f.widgetSvc.RegisterDefinition(ctx, widgets.RegisterDefinitionInput{
+		Name:   "newsletter",
+		Schema: map[string]any{"fields": []any{map[string]any{"name": "headline", "type": "text"}}},
+	})
+	if err != nil {
+		t.Fatalf("register widget definition: %v", err)
+	}
+	if _, err := f.widgetSvc.CreateInstance(ctx, widgets.CreateInstanceInput{
+		DefinitionID:    widgetDef.ID,
+		BlockInstanceID: &block.ID,
+		Configuration:   map[string]any{"headline": "Subscribe"},
+		CreatedBy:       uuid.New(),
+		UpdatedBy:       uuid.New(),
+	}); err != nil {
+		t.Fatalf("create widget instance: %v", err)
+	}
+	if _, err := f.pageRepo.CreateVersion(ctx, &pages.PageVersion{
+		ID:      uuid.New(),
+		PageID:  about.ID,
+		Version: 1,
+		Status:  domain.StatusPublished,
+		Snapshot: pages.PageVersionSnapshot{
+			Blocks: []pages.PageBlockPlacement{{Region: "main", BlockID: hero.ID, InstanceID: block.ID}},
+		},
+	}); err != nil {
+		t.Fatalf("create page version: %v", err)
+	}
+	published := 1
+	about.CurrentVersion = 1
+	about.PublishedVersion = &published
+	if _, err := f.pageRepo.Update(ctx, about); err != nil {
+		t.Fatalf("publish page version: %v", err)
+	}
+
+	opts := promotions.PromoteOptions{Mode: promotions.ModeUpsert, PromoteAsPublished: true, AutoPromoteDependencies: true}
+	if _, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: about.ID, TargetEnvironment: "prod", Options: opts}); err != nil {
+		t.Fatalf("promote about: %v", err)
+	}
+	prodAbout, err := f.pageRepo.GetBySlug(ctx, "about", prodEnv)
+	if err != nil {
+		t.Fatalf("load prod about: %v", err)
+	}
+	before := pageBlockAndWidgetIDs(t, f, prodAbout.ID)
+	if len(before) != 2 {
+		t.Fatalf("expected promoted block and widget, got %v", before)
+	}
+
+	second, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: about.ID, TargetEnvironment: "prod", Options: opts})
+	if err != nil {
+		t.Fatalf("promote about again: %v", err)
+	}
+	if _, err := f.promo.RollbackPromotion(ctx, *second.BatchID, promotions.RollbackOptions{}); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	prodAbout, err = f.pageRepo.GetByID(ctx, prodAbout.ID)
+	if err != nil {
+		t.Fatalf("reload prod about: %v", err)
+	}
+	if prodAbout.PublishedVersion == nil {
+		t.Fatalf("expected restored page to keep its published version")
+	}
+	version, err := f.pageRepo.GetVersion(ctx, prodAbout.ID, *prodAbout.PublishedVersion)
+	if err != nil {
+		t.Fatalf("load published version: %v", err)
+	}
+	after := pageBlockAndWidgetIDs(t, f, prodAbout.ID)
+	if len(version.Snapshot.Blocks) != 1 {
+		t.Fatalf("expected one block in published snapshot, got %+v", version.Snapshot.Blocks)
+	}
+	for _, placement := range version.Snapshot.Blocks {
+		if _, ok := after[placement.InstanceID]; !ok {
+			t.Fatalf("published snapshot references missing block instance %s (live %v)", placement.InstanceID, after)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			t.Fatalf("expected rollback to keep instance %s, got %v", id, after)
+		}
+	}
+	if len(after) != len(before) {
+		t.Fatalf("expected rollback to restore %d instances, got %v", len(before), after)
+	}
+}
+
+// pageBlockAndWidgetIDs returns the IDs of the block instances on a page and
+// the widget instances bound to them.
+func pageBlockAndWidgetIDs(t *testing.T, f *structureFixture, pageID uuid.UUID) map[uuid.UUID]struct{} {
+	t.Helper()
+	ctx := context.Background()
+	instances, err := f.blockSvc.ListPageInstances(ctx, pageID)
+	if err != nil {
+		t.Fatalf("list page blocks: %v", err)
+	}
+	ids := map[uuid.UUID]struct{}{}
+	for _, inst := range instances {
+		if len(inst.Translations) == 0 {
+			t.Fatalf("expected block %s to keep its translations", inst.ID)
+		}
+		ids[inst.ID] = struct{}{}
+	}
+	all, err := f.widgetSvc.ListAllInstances(ctx)
+	if err != nil {
+		t.Fatalf("list widgets: %v", err)
+	}
+	for _, widget := range all {
+		if widget.BlockInstanceID == nil {
+			continue
+		}
+		if _, ok := ids[*widget.BlockInstanceID]; ok {
+			ids[widget.ID] = struct{}{}
+		}
+	}
+	return ids
+}
+
+func TestPromotionService_PromotionHistoryDisabled(t *testing.T) {
+	promo := promotions.NewService(
+		newEnvService(t),
+		content.NewMemoryContentTypeRepository(),
+		content.NewMemoryContentRepository(),
+		content.NewMemoryLocaleRepository(),
+	)
+	if _, err := promo.ListPromotions(context.Background(), promotions.ListPromotionsRequest{}); !errors.Is(err, promotions.ErrPromotionHistoryUnavailable) {
+		t.Fatalf("expected history unavailable, got %v", err)
+	}
+}
+
+func (f *structureFixture) retitlePage(t *testing.T, pageID uuid.UUID, title string) {
+	t.Helper()
+	ctx := context.Background()
+	translations, err := f.pageRepo.ListTranslations(ctx, pageID)
+	if err != nil {
+		t.Fatalf("list page translations: %v", err)
+	}
+	updated := make([]*pages.PageTranslation, 0, len(translations))
+	for _, tr := range translations {
+		copied := *tr
+		copied.Title = title
+		updated = append(updated, &copied)
+	}
+	if err := f.pageRepo.ReplaceTranslations(ctx, pageID, updated); err != nil {
+		t.Fatalf("replace page translations: %v", err)
+	}
+}
+
+func TestPromotionService_RollbackSavesPartialProgress(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	prodEnv := cmsenv.IDForKey("prod").String()
+
+	about := f.seedPage(t, "about", f.contentIDs["dev"], nil)
+	if _, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: about.ID, TargetEnvironment: "prod"}); err != nil {
+		t.Fatalf("promote about: %v", err)
+	}
+	f.retitlePage(t, about.ID, "About us")
+	f.seedPage(t, "contact", f.contentIDs["dev"], nil)
+	result, err := f.promo.PromoteEnvironment(ctx, promotions.PromoteEnvironmentRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Scope:             promotions.ScopePages,
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil || result.BatchID == nil {
+		t.Fatalf("promote pages: %+v (%v)", result, err)
+	}
+
+	// Records roll back last to first: the created page goes first and the
+	// updated page, whose captured state is unreadable, fails afterwards.
+	batch, err := f.batches.GetByID(ctx, *result.BatchID)
+	if err != nil {
+		t.Fatalf("get batch: %v", err)
+	}
+	if batch.Records[0].Action != promotions.RecordUpdated {
+		batch.Records[0], batch.Records[1] = batch.Records[1], batch.Records[0]
+	}
+	before := batch.Records[0].Before
+	batch.Records[0].Before = []byte(`{"page":"broken"}`)
+	if _, err := f.batches.Update(ctx, batch); err != nil {
+		t.Fatalf("update batch: %v", err)
+	}
+
+	if _, err := f.promo.RollbackPromotion(ctx, batch.ID, promotions.RollbackOptions{}); err == nil {
+		t.Fatalf("expected rollback to fail on the broken record")
+	}
+	partial, err := f.promo.GetPromotion(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("get partial batch: %v", err)
+	}
+	if partial.Status != promotions.BatchRollbackPartial || !partial.Records[1].RolledBack || partial.Records[0].RolledBack {
+		t.Fatalf("expected partial rollback progress, got %+v", partial)
+	}
+	if _, err := f.pageRepo.GetBySlug(ctx, "contact", prodEnv); err == nil {
+		t.Fatalf("expected created page removed before the failure")
+	}
+
+	partial.Records[0].Before = before
+	if _, err := f.batches.Update(ctx, partial); err != nil {
+		t.Fatalf("repair batch: %v", err)
+	}
+	retried, err := f.promo.RollbackPromotion(ctx, batch.ID, promotions.RollbackOptions{})
+	if err != nil {
+		t.Fatalf("retry rollback: %v", err)
+	}
+	if retried.Batch.Status != promotions.BatchRolledBack || len(retried.Items) != 2 {
+		t.Fatalf("unexpected retried rollback %+v", retried)
+	}
+	if retried.Items[0].Status != "skipped" || retried.Items[1].Status != "restored" {
+		t.Fatalf("expected the deleted page to be skipped on retry, got %+v", retried.Items)
+	}
+}
+
+func TestPromotionService_RollbackRepublishesPageVersion(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	prodEnv := cmsenv.IDForKey("prod").String()
+
+	about := f.seedPage(t, "about", f.contentIDs["dev"], nil)
+	if _, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: about.ID, TargetEnvironment: "prod"}); err != nil {
+		t.Fatalf("promote about: %v", err)
+	}
+	prodAbout, err := f.pageRepo.GetBySlug(ctx, "about", prodEnv)
+	if err != nil {
+		t.Fatalf("load prod about: %v", err)
+	}
+	published := 1
+	prodAbout.CurrentVersion = 1
+	prodAbout.PublishedVersion = &published
+	if _, err := f.pageRepo.Update(ctx, prodAbout); err != nil {
+		t.Fatalf("update prod about: %v", err)
+	}
+	first, err := f.pageRepo.CreateVersion(ctx, &pages.PageVersion{ID: uuid.New(), PageID: prodAbout.ID, Version: 1, Status: domain.StatusPublished})
+	if err != nil {
+		t.Fatalf("create version: %v", err)
+	}
+
+	f.retitlePage(t, about.ID, "About us")
+	result, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{
+		PageID:            about.ID,
+		TargetEnvironment: "prod",
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil || result.BatchID == nil {
+		t.Fatalf("promote retitled about: %+v (%v)", result, err)
+	}
+
+	// Publish a second version in prod, archiving the first.
+	first.Status = domain.StatusArchived
+	if _, err := f.pageRepo.UpdateVersion(ctx, first); err != nil {
+		t.Fatalf("archive version: %v", err)
+	}
+	if _, err := f.pageRepo.CreateVersion(ctx, &pages.PageVersion{ID: uuid.New(), PageID: prodAbout.ID, Version: 2, Status: domain.StatusPublished}); err != nil {
+		t.Fatalf("create second version: %v", err)
+	}
+
+	if _, err := f.promo.RollbackPromotion(ctx, *result.BatchID, promotions.RollbackOptions{Force: true}); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	versions, err := f.pageRepo.ListVersions(ctx, prodAbout.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	statuses := map[int]domain.Status{}
+	for _, version := range versions {
+		statuses[version.Version] = version.Status
+	}
+	if statuses[1] != domain.StatusPublished || statuses[2] != domain.StatusArchived {
+		t.Fatalf("expected version 1 republished and version 2 archived, got %+v", statuses)
+	}
+}
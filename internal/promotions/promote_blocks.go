@@ -0,0 +1,131 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/google/uuid"
+)
+
+func (s *service) PromoteBlockDefinition(ctx context.Context, req PromoteBlockDefinitionRequest) (*PromoteItem, error) {
+	return s.recordSinglePromotion(ctx, KindBlockDefinition, req.DefinitionID, req.Options, func(ctx context.Context) (*PromoteItem, error) {
+		return s.promoteBlockDefinitionRecord(ctx, req)
+	})
+}
+
+func (s *service) promoteBlockDefinitionRecord(ctx context.Context, req PromoteBlockDefinitionRequest) (*PromoteItem, error) {
+	if s == nil || s.blocks == nil {
+		return nil, errors.New("promotions: block definition promotion unavailable")
+	}
+	if req.DefinitionID == uuid.Nil {
+		return nil, blocks.ErrDefinitionIDRequired
+	}
+	opts := normalizeOptions(req.Options)
+
+	source, err := s.blocks.GetDefinition(ctx, req.DefinitionID)
+	if err != nil {
+		return nil, err
+	}
+	sourceEnv, err := s.resolveEnvironmentByID(ctx, source.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := s.resolveEnvironment(ctx, req.TargetEnvironment, req.TargetEnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceEnv.ID == targetEnv.ID {
+		return &PromoteItem{Kind: KindBlockDefinition, SourceID: source.ID, TargetID: source.ID, Status: "skipped", Message: "source and target environments match"}, nil
+	}
+
+	targetDefs, err := s.blocks.ListDefinitions(ctx, targetEnv.Key)
+	if err != nil {
+		return nil, err
+	}
+	existing := blockDefinitionIndex(targetDefs)[normalizeMatchKey(source.Slug)]
+	target, status, err := s.promoteBlockDefinition(ctx, source, existing, promotionTarget{source: sourceEnv, target: targetEnv, opts: opts})
+	if err != nil {
+		return nil, err
+	}
+	if !opts.DryRun {
+		s.emitPromotionActivity(ctx, KindBlockDefinition, "promote", source.ID, target.ID, sourceEnv, targetEnv, opts)
+	}
+
+	details := map[string]any{"slug": source.Slug}
+	if version := strings.TrimSpace(source.SchemaVersion); version != "" {
+		details["schema_version"] = version
+	}
+	if opts.DryRun {
+		details["dry_run"] = true
+	}
+	return &PromoteItem{
+		Kind:     KindBlockDefinition,
+		SourceID: source.ID,
+		TargetID: target.ID,
+		Status:   status,
+		Details:  details,
+	}, nil
+}
+
+// resolveTargetBlockDefinition maps a source definition ID onto the definition
+// with the same slug in the target environment, promoting it when allowed.
+func (s *service) resolveTargetBlockDefinition(ctx context.Context, definitionID uuid.UUID, index map[string]*blocks.Definition, req promotionTarget) (*blocks.Definition, error) {
+	source, err := s.blocks.GetDefinition(ctx, definitionID)
+	if err != nil {
+		return nil, err
+	}
+	key := normalizeMatchKey(source.Slug)
+	if existing := index[key]; existing != nil {
+		return existing, nil
+	}
+	if !req.opts.AutoPromoteDependencies {
+		return nil, dependencyError(KindBlockDefinition, source.Slug)
+	}
+	target, _, err := s.promoteBlockDefinition(ctx, source, nil, req)
+	if err != nil {
+		return nil, err
+	}
+	index[key] = target
+	return target, nil
+}
+
+func (s *service) collectBlockDefinitionIDs(ctx context.Context, envKey string, slugs []string) ([]uuid.UUID, error) {
+	if s.blocks == nil {
+		return nil, errors.New("promotions: block service required")
+	}
+	records, err := s.blocks.ListDefinitions(ctx, envKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(slugs) == 0 {
+		out := make([]uuid.UUID, 0, len(records))
+		for _, record := range records {
+			if record == nil || record.DeletedAt != nil {
+				continue
+			}
+			out = append(out, record.ID)
+		}
+		return out, nil
+	}
+	index := blockDefinitionIndex(records)
+	out := make([]uuid.UUID, 0, len(slugs))
+	for _, slug := range slugs {
+		if def := index[normalizeMatchKey(slug)]; def != nil {
+			out = append(out, def.ID)
+		}
+	}
+	return out, nil
+}
+
+func blockDefinitionIndex(defs []*blocks.Definition) map[string]*blocks.Definition {
+	index := make(map[string]*blocks.Definition, len(defs))
+	for _, def := range defs {
+		if def == nil || def.DeletedAt != nil {
+			continue
+		}
+		index[normalizeMatchKey(def.Slug)] = def
+	}
+	return index
+}
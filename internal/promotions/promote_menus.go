@@ -0,0 +1,296 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/google/uuid"
+)
+
+func (s *service) PromoteMenu(ctx context.Context, req PromoteMenuRequest) (*PromoteItem, error) {
+	return s.recordSinglePromotion(ctx, KindMenu, req.MenuID, req.Options, func(ctx context.Context) (*PromoteItem, error) {
+		return s.promoteMenu(ctx, req)
+	})
+}
+
+func (s *service) promoteMenu(ctx context.Context, req PromoteMenuRequest) (*PromoteItem, error) {
+	if s == nil || s.menus == nil || s.menuRecords == nil {
+		return nil, errors.New("promotions: menu promotion unavailable")
+	}
+	if req.MenuID == uuid.Nil {
+		return nil, menus.ErrMenuNotFound
+	}
+	opts := normalizeOptions(req.Options)
+
+	source, err := s.menuRecords.GetByID(ctx, req.MenuID)
+	if err != nil {
+		return nil, err
+	}
+	sourceEnv, err := s.resolveEnvironmentByID(ctx, source.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := s.resolveEnvironment(ctx, req.TargetEnvironment, req.TargetEnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceEnv.ID == targetEnv.ID {
+		return &PromoteItem{Kind: KindMenu, SourceID: source.ID, TargetID: source.ID, Status: "skipped", Message: "source and target environments match"}, nil
+	}
+	scope := promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}
+
+	hydrated, err := s.menus.GetMenuByCode(ctx, source.Code, sourceEnv.Key)
+	if err != nil {
+		return nil, err
+	}
+	items := flattenMenuItems(hydrated.Items)
+
+	existing, err := s.menuRecords.GetByCode(ctx, source.Code, targetEnv.ID.String())
+	if err != nil {
+		var nf *menus.NotFoundError
+		if !errors.As(err, &nf) {
+			return nil, err
+		}
+		existing = nil
+	}
+	if existing != nil && opts.Mode == ModeStrict {
+		return nil, menus.ErrMenuCodeExists
+	}
+
+	// Resolve page targets before writing so missing dependencies leave the target untouched.
+	targets := make(map[uuid.UUID]map[string]any, len(items))
+	for _, item := range items {
+		target, err := s.remapMenuItemTarget(ctx, item.Target, scope)
+		if err != nil {
+			return nil, err
+		}
+		targets[item.ID] = target
+	}
+
+	var bindings []*menus.MenuLocationBinding
+	if s.menuBindings != nil {
+		all, err := s.menuBindings.List(ctx, sourceEnv.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range all {
+			if binding != nil && strings.EqualFold(strings.TrimSpace(binding.MenuCode), source.Code) {
+				bindings = append(bindings, binding)
+			}
+		}
+	}
+
+	status := "updated"
+	if existing == nil {
+		status = "created"
+	}
+	details := map[string]any{
+		"code":     source.Code,
+		"items":    len(items),
+		"bindings": len(bindings),
+	}
+	if opts.DryRun {
+		targetID := s.id()
+		if existing != nil {
+			targetID = existing.ID
+		}
+		details["dry_run"] = true
+		return &PromoteItem{Kind: KindMenu, SourceID: source.ID, TargetID: targetID, Status: status, Details: details}, nil
+	}
+
+	actor := pickActor(source.UpdatedBy, source.CreatedBy)
+	if existing != nil {
+		if err := s.journalUpdate(ctx, scope, KindMenu, source.ID, existing.ID); err != nil {
+			return nil, err
+		}
+	}
+	target, err := s.menus.UpsertMenu(ctx, menus.UpsertMenuInput{
+		Code:           source.Code,
+		Location:       source.Location,
+		Description:    cloneString(source.Description),
+		Status:         source.Status,
+		Locale:         cloneString(source.Locale),
+		Actor:          actor,
+		EnvironmentKey: targetEnv.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		s.journalCreate(ctx, scope, KindMenu, source.ID, target.ID)
+	} else {
+		// Existing items only merge translations on upsert, so rebuild the tree from the source.
+		if err := s.menus.ResetMenuByCode(ctx, source.Code, actor, true, targetEnv.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.writeMenuItems(ctx, target.ID, targetEnv.Key, items, targets, actor); err != nil {
+		return nil, err
+	}
+
+	for _, binding := range bindings {
+		if _, err := s.menus.UpsertMenuLocationBinding(ctx, menus.UpsertMenuLocationBindingInput{
+			Location:        binding.Location,
+			MenuCode:        target.Code,
+			ViewProfileCode: cloneString(binding.ViewProfileCode),
+			Locale:          cloneString(binding.Locale),
+			Priority:        binding.Priority,
+			Status:          binding.Status,
+			Actor:           actor,
+			EnvironmentKey:  targetEnv.Key,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	s.emitPromotionActivity(ctx, KindMenu, "promote", source.ID, target.ID, sourceEnv, targetEnv, opts)
+	return &PromoteItem{Kind: KindMenu, SourceID: source.ID, TargetID: target.ID, Status: status, Details: details}, nil
+}
+
+// writeMenuItems recreates items in pre-order under menuID, mapping parent
+// references onto the new item IDs. targets overrides item targets by source item ID.
+func (s *service) writeMenuItems(ctx context.Context, menuID uuid.UUID, envKey string, items []*menus.MenuItem, targets map[uuid.UUID]map[string]any, actor uuid.UUID) error {
+	locales := newLocaleCodeCache(s.locales)
+	itemIDs := make(map[uuid.UUID]uuid.UUID, len(items))
+	for _, item := range items {
+		var parentID *uuid.UUID
+		if item.ParentID != nil {
+			mapped, ok := itemIDs[*item.ParentID]
+			if !ok {
+				return fmt.Errorf("promotion: menu item %s parent not promoted", menuItemMatchKey(item, ""))
+			}
+			parentID = &mapped
+		}
+		target, ok := targets[item.ID]
+		if !ok {
+			target = cloneMap(item.Target)
+		}
+		position := item.Position
+		menuRef := menuID
+		created, err := s.menus.UpsertMenuItem(ctx, menus.UpsertMenuItemInput{
+			MenuID:                   &menuRef,
+			EnvironmentKey:           envKey,
+			ExternalCode:             item.ExternalCode,
+			ParentID:                 parentID,
+			Position:                 &position,
+			Type:                     item.Type,
+			Target:                   target,
+			Icon:                     item.Icon,
+			Badge:                    cloneMap(item.Badge),
+			Permissions:              slices.Clone(item.Permissions),
+			Classes:                  slices.Clone(item.Classes),
+			Styles:                   maps.Clone(item.Styles),
+			Collapsible:              item.Collapsible,
+			Collapsed:                item.Collapsed,
+			Metadata:                 cloneMap(item.Metadata),
+			Actor:                    actor,
+			Translations:             menuItemTranslationInputs(ctx, item, locales),
+			AllowMissingTranslations: true,
+		})
+		if err != nil {
+			return err
+		}
+		itemIDs[item.ID] = created.ID
+	}
+	return nil
+}
+
+// remapMenuItemTarget rewrites page targets to reference the page with the same
+// slug in the target environment.
+func (s *service) remapMenuItemTarget(ctx context.Context, target map[string]any, req promotionTarget) (map[string]any, error) {
+	out := cloneMap(target)
+	if out == nil || normalizeMatchKey(fmt.Sprint(out["type"])) != "page" || s.pages == nil {
+		return out, nil
+	}
+	slug := ""
+	if raw, ok := out["slug"].(string); ok {
+		slug = strings.TrimSpace(raw)
+	}
+	var sourceID uuid.UUID
+	if raw, ok := out["page_id"]; ok {
+		if parsed, err := uuid.Parse(strings.TrimSpace(fmt.Sprint(raw))); err == nil {
+			sourceID = parsed
+		}
+	}
+	if sourceID == uuid.Nil && slug != "" {
+		page, err := s.pages.GetBySlug(ctx, slug, req.source.ID.String())
+		if err != nil && !isPageNotFound(err) {
+			return nil, err
+		}
+		if page != nil {
+			sourceID = page.ID
+		}
+	}
+	if sourceID == uuid.Nil {
+		return nil, dependencyError(KindPage, slug)
+	}
+	sourcePage, err := s.pages.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	targetID, err := s.resolveTargetPage(ctx, sourcePage, req)
+	if err != nil {
+		return nil, err
+	}
+	out["page_id"] = targetID.String()
+	out["slug"] = sourcePage.Slug
+	return out, nil
+}
+
+func menuItemTranslationInputs(ctx context.Context, item *menus.MenuItem, locales *localeCodeCache) []menus.MenuItemTranslationInput {
+	if len(item.Translations) == 0 {
+		return nil
+	}
+	out := make([]menus.MenuItemTranslationInput, 0, len(item.Translations))
+	for _, tr := range item.Translations {
+		if tr == nil || tr.DeletedAt != nil {
+			continue
+		}
+		fallback := ""
+		if tr.Locale != nil {
+			fallback = tr.Locale.Code
+		}
+		out = append(out, menus.MenuItemTranslationInput{
+			Locale:        locales.code(ctx, tr.LocaleID, fallback),
+			Label:         tr.Label,
+			LabelKey:      tr.LabelKey,
+			GroupTitle:    tr.GroupTitle,
+			GroupTitleKey: tr.GroupTitleKey,
+			URLOverride:   cloneString(tr.URLOverride),
+		})
+	}
+	return out
+}
+
+func (s *service) collectMenuIDs(ctx context.Context, envID string, codes []string) ([]uuid.UUID, error) {
+	if s.menus == nil || s.menuRecords == nil {
+		return nil, errors.New("promotions: menu service required")
+	}
+	records, err := s.menuRecords.List(ctx, envID)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		wanted[normalizeMatchKey(code)] = struct{}{}
+	}
+	out := make([]uuid.UUID, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		if len(wanted) > 0 {
+			if _, ok := wanted[normalizeMatchKey(record.Code)]; !ok {
+				continue
+			}
+		}
+		out = append(out, record.ID)
+	}
+	return out, nil
+}
@@ -0,0 +1,653 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/internal/pages"
+	cmssites "github.com/goliatone/go-cms/internal/sites"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+func (s *service) PromotePage(ctx context.Context, req PromotePageRequest) (*PromoteItem, error) {
+	return s.recordSinglePromotion(ctx, KindPage, req.PageID, req.Options, func(ctx context.Context) (*PromoteItem, error) {
+		return s.promotePage(ctx, req)
+	})
+}
+
+func (s *service) promotePage(ctx context.Context, req PromotePageRequest) (*PromoteItem, error) {
+	if s == nil || s.pages == nil || s.contents == nil || s.contentTypes == nil {
+		return nil, errors.New("promotions: page promotion unavailable")
+	}
+	if req.PageID == uuid.Nil {
+		return nil, pages.ErrPageRequired
+	}
+	opts := normalizeOptions(req.Options)
+
+	source, err := s.pages.GetByID(ctx, req.PageID)
+	if err != nil {
+		return nil, err
+	}
+	sourceEnv, err := s.resolveEnvironmentByID(ctx, source.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := s.resolveEnvironment(ctx, req.TargetEnvironment, req.TargetEnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceEnv.ID == targetEnv.ID {
+		return &PromoteItem{Kind: KindPage, SourceID: source.ID, TargetID: source.ID, Status: "skipped", Message: "source and target environments match"}, nil
+	}
+	if !strings.EqualFold(strings.TrimSpace(source.Status), string(domain.StatusPublished)) && !opts.AllowDraft {
+		return nil, fmt.Errorf("promotion: page %s is not published", source.Slug)
+	}
+	scope := promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}
+
+	contentID, err := s.resolveTargetContent(ctx, source.ContentID, scope)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := s.resolveTargetParentPage(ctx, source.ParentID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.targetPageBySlug(ctx, source, targetEnv.ID)
+	if err != nil {
+		if !isPageNotFound(err) {
+			return nil, err
+		}
+		existing = nil
+	}
+	if existing != nil && opts.Mode == ModeStrict {
+		return nil, pages.ErrSlugExists
+	}
+
+	actor := pickActor(source.UpdatedBy, source.CreatedBy)
+	now := s.now()
+	created := existing == nil
+	var record pages.Page
+	if created {
+		record = pages.Page{
+			ID:             s.id(),
+			EnvironmentID:  targetEnv.ID,
+			SiteID:         cloneUUIDPtr(source.SiteID),
+			CurrentVersion: 1,
+			CreatedBy:      actor,
+			CreatedAt:      now,
+		}
+	} else {
+		record = *existing
+	}
+	record.ContentID = contentID
+	record.ParentID = parentID
+	record.TemplateID = source.TemplateID
+	record.Slug = source.Slug
+	record.PrimaryLocale = source.PrimaryLocale
+	record.PublishAt = cloneTimePtr(source.PublishAt)
+	record.UnpublishAt = cloneTimePtr(source.UnpublishAt)
+	record.UpdatedBy = actor
+	record.UpdatedAt = now
+	record.Status = string(domain.StatusDraft)
+	record.PublishedAt = nil
+	record.PublishedBy = nil
+	if opts.PromoteAsPublished {
+		record.Status = string(domain.StatusPublished)
+		record.PublishedAt = &now
+		if actor != uuid.Nil {
+			record.PublishedBy = &actor
+		}
+	}
+	record.Translations = s.buildPageTranslations(record.ID, source.Translations)
+
+	if opts.DryRun {
+		recordPlannedTarget(ctx, KindPage, pageMatchKey(source), record.ID)
+		blockCount, widgetCount, err := s.countPageBlocks(ctx, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		return buildPageItem(created, source.ID, record.ID, blockCount, widgetCount, true), nil
+	}
+
+	var saved *pages.Page
+	if created {
+		saved, err = s.pages.Create(ctx, &record)
+		if err != nil {
+			return nil, err
+		}
+		s.journalCreate(ctx, scope, KindPage, source.ID, saved.ID)
+	} else {
+		if err := s.journalUpdate(ctx, scope, KindPage, source.ID, record.ID); err != nil {
+			return nil, err
+		}
+		if err := s.pages.ReplaceTranslations(ctx, record.ID, record.Translations); err != nil {
+			return nil, err
+		}
+		saved, err = s.pages.Update(ctx, &record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	copied, err := s.promotePageBlocks(ctx, source.ID, saved.ID, !created, scope, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.promotePageVersion(ctx, source, saved, copied.ids, opts, actor); err != nil {
+		return nil, err
+	}
+
+	s.emitPromotionActivity(ctx, KindPage, "promote", source.ID, saved.ID, sourceEnv, targetEnv, opts)
+	return buildPageItem(created, source.ID, saved.ID, copied.blocks, copied.widgets, false), nil
+}
+
+// resolveTargetParentPage maps a source parent page onto the page with the same
+// slug in the target environment, promoting it first when allowed.
+func (s *service) resolveTargetParentPage(ctx context.Context, parentID *uuid.UUID, req promotionTarget) (*uuid.UUID, error) {
+	if parentID == nil || *parentID == uuid.Nil {
+		return nil, nil
+	}
+	parent, err := s.pages.GetByID(ctx, *parentID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.resolveTargetPage(ctx, parent, req)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (s *service) resolveTargetPage(ctx context.Context, sourcePage *pages.Page, req promotionTarget) (uuid.UUID, error) {
+	existing, err := s.targetPageBySlug(ctx, sourcePage, req.target.ID)
+	if err == nil {
+		return existing.ID, nil
+	}
+	if !isPageNotFound(err) {
+		return uuid.Nil, err
+	}
+	if id, ok := plannedTarget(ctx, KindPage, pageMatchKey(sourcePage)); ok {
+		return id, nil
+	}
+	if !req.opts.AutoPromoteDependencies {
+		return uuid.Nil, dependencyError(KindPage, sourcePage.Slug)
+	}
+	item, err := s.PromotePage(ctx, PromotePageRequest{
+		PageID:            sourcePage.ID,
+		TargetEnvironment: req.target.Key,
+		Options:           req.opts,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return item.TargetID, nil
+}
+
+// targetPageBySlug returns the page of the target environment with the source
+// page's slug in the same site scope. Slugs are unique per site, so pages of
+// other sites, or shared pages for a site page, are reported as not found.
+func (s *service) targetPageBySlug(ctx context.Context, source *pages.Page, envID uuid.UUID) (*pages.Page, error) {
+	existing, err := s.pages.GetBySlug(cmssites.WithSiteID(ctx, source.SiteID), source.Slug, envID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !cmssites.SameScope(existing.SiteID, source.SiteID) {
+		return nil, &pages.PageNotFoundError{Key: source.Slug}
+	}
+	return existing, nil
+}
+
+// pageMatchKey identifies a page across environments by site and slug.
+func pageMatchKey(page *pages.Page) string {
+	return siteMatchKey(page.SiteID, page.Slug)
+}
+
+func (s *service) buildPageTranslations(pageID uuid.UUID, source []*pages.PageTranslation) []*pages.PageTranslation {
+	if len(source) == 0 {
+		return nil
+	}
+	now := s.now()
+	familyID := pageID
+	out := make([]*pages.PageTranslation, 0, len(source))
+	for _, tr := range source {
+		if tr == nil || tr.DeletedAt != nil {
+			continue
+		}
+		out = append(out, &pages.PageTranslation{
+			ID:             s.id(),
+			PageID:         pageID,
+			LocaleID:       tr.LocaleID,
+			FamilyID:       &familyID,
+			Title:          tr.Title,
+			Path:           tr.Path,
+			SEOTitle:       cloneString(tr.SEOTitle),
+			SEODescription: cloneString(tr.SEODescription),
+			Summary:        cloneString(tr.Summary),
+			MediaBindings:  media.CloneBindingSet(tr.MediaBindings),
+			Locale:         tr.Locale,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	return out
+}
+
+// copiedPageBlocks tracks block and widget instances recreated for a page and
+// the source-to-target ID remapping applied to definitions and instances.
+type copiedPageBlocks struct {
+	ids     map[uuid.UUID]uuid.UUID
+	blocks  int
+	widgets int
+}
+
+// promotePageBlocks recreates the source page block instances, their
+// translations and the widget instances bound to them on the target page.
+// When replace is set, blocks already attached to the target page are removed first.
+func (s *service) promotePageBlocks(ctx context.Context, sourcePageID, targetPageID uuid.UUID, replace bool, req promotionTarget, actor uuid.UUID) (copiedPageBlocks, error) {
+	copied := copiedPageBlocks{ids: map[uuid.UUID]uuid.UUID{}}
+	if s.blocks == nil {
+		return copied, nil
+	}
+	sourceInstances, err := s.blocks.ListPageInstances(ctx, sourcePageID)
+	if err != nil {
+		return copied, err
+	}
+
+	var allWidgets []*widgets.Instance
+	if s.widgets != nil {
+		allWidgets, err = s.widgets.ListAllInstances(ctx)
+		if err != nil {
+			return copied, err
+		}
+	}
+
+	if replace {
+		if err := s.clearPageBlocks(ctx, targetPageID, allWidgets, actor); err != nil {
+			return copied, err
+		}
+	}
+	if len(sourceInstances) == 0 {
+		return copied, nil
+	}
+
+	targetDefs, err := s.blocks.ListDefinitions(ctx, req.target.Key)
+	if err != nil {
+		return copied, err
+	}
+	defIndex := blockDefinitionIndex(targetDefs)
+	return s.copyPageBlocks(ctx, sourceInstances, allWidgets, targetPageID, req.target.Key, actor, func(definitionID uuid.UUID) (uuid.UUID, error) {
+		def, err := s.resolveTargetBlockDefinition(ctx, definitionID, defIndex, req)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return def.ID, nil
+	})
+}
+
+// copyPageBlocks creates the given block instances, their translations and the
+// widget instances bound to them on the target page in envKey. resolveDefinition
+// maps each instance definition onto the definition used for the copy.
+func (s *service) copyPageBlocks(ctx context.Context, instances []*blocks.Instance, boundWidgets []*widgets.Instance, targetPageID uuid.UUID, envKey string, actor uuid.UUID, resolveDefinition func(uuid.UUID) (uuid.UUID, error)) (copiedPageBlocks, error) {
+	copied := copiedPageBlocks{ids: map[uuid.UUID]uuid.UUID{}}
+	for _, inst := range instances {
+		if inst == nil || inst.DeletedAt != nil {
+			continue
+		}
+		definitionID, err := resolveDefinition(inst.DefinitionID)
+		if err != nil {
+			return copied, err
+		}
+		copied.ids[inst.DefinitionID] = definitionID
+		pageID := targetPageID
+		created, err := s.blocks.CreateInstance(ctx, blocks.CreateInstanceInput{
+			DefinitionID:  definitionID,
+			PageID:        &pageID,
+			Region:        inst.Region,
+			Position:      inst.Position,
+			Configuration: cloneMap(inst.Configuration),
+			IsGlobal:      inst.IsGlobal,
+			CreatedBy:     actor,
+			UpdatedBy:     actor,
+		})
+		if err != nil {
+			return copied, err
+		}
+		copied.ids[inst.ID] = created.ID
+		copied.blocks++
+		for _, tr := range inst.Translations {
+			if tr == nil || tr.DeletedAt != nil {
+				continue
+			}
+			if _, err := s.blocks.AddTranslation(ctx, blocks.AddTranslationInput{
+				BlockInstanceID:    created.ID,
+				LocaleID:           tr.LocaleID,
+				Content:            cloneMap(tr.Content),
+				AttributeOverrides: cloneMap(tr.AttributeOverride),
+				MediaBindings:      media.CloneBindingSet(tr.MediaBindings),
+			}); err != nil {
+				return copied, err
+			}
+		}
+	}
+
+	for _, widget := range boundWidgets {
+		if widget == nil || widget.BlockInstanceID == nil {
+			continue
+		}
+		blockID, ok := copied.ids[*widget.BlockInstanceID]
+		if !ok {
+			continue
+		}
+		createdBy := pickActor(actor, widget.UpdatedBy, widget.CreatedBy)
+		created, err := s.widgets.CreateInstance(ctx, widgets.CreateInstanceInput{
+			DefinitionID:    widget.DefinitionID,
+			BlockInstanceID: &blockID,
+			AreaCode:        cloneString(widget.AreaCode),
+			Placement:       cloneMap(widget.Placement),
+			Configuration:   cloneMap(widget.Configuration),
+			VisibilityRules: cloneMap(widget.VisibilityRules),
+			PublishOn:       cloneTimePtr(widget.PublishOn),
+			UnpublishOn:     cloneTimePtr(widget.UnpublishOn),
+			Position:        widget.Position,
+			CreatedBy:       createdBy,
+			UpdatedBy:       createdBy,
+			EnvironmentKey:  envKey,
+		})
+		if err != nil {
+			return copied, err
+		}
+		copied.ids[widget.ID] = created.ID
+		copied.widgets++
+		for _, tr := range widget.Translations {
+			if tr == nil || tr.DeletedAt != nil {
+				continue
+			}
+			if _, err := s.widgets.AddTranslation(ctx, widgets.AddTranslationInput{
+				InstanceID: created.ID,
+				LocaleID:   tr.LocaleID,
+				Content:    cloneMap(tr.Content),
+			}); err != nil {
+				return copied, err
+			}
+		}
+	}
+	return copied, nil
+}
+
+func (s *service) clearPageBlocks(ctx context.Context, pageID uuid.UUID, allWidgets []*widgets.Instance, actor uuid.UUID) error {
+	existing, err := s.blocks.ListPageInstances(ctx, pageID)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	removed := make(map[uuid.UUID]struct{}, len(existing))
+	for _, inst := range existing {
+		if inst != nil {
+			removed[inst.ID] = struct{}{}
+		}
+	}
+	for _, widget := range allWidgets {
+		if widget == nil || widget.BlockInstanceID == nil {
+			continue
+		}
+		if _, ok := removed[*widget.BlockInstanceID]; !ok {
+			continue
+		}
+		if err := s.widgets.DeleteInstance(ctx, widgets.DeleteInstanceRequest{InstanceID: widget.ID, DeletedBy: actor, HardDelete: true}); err != nil {
+			return err
+		}
+	}
+	for id := range removed {
+		if err := s.blocks.DeleteInstance(ctx, blocks.DeleteInstanceRequest{ID: id, DeletedBy: actor, HardDelete: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) countPageBlocks(ctx context.Context, pageID uuid.UUID) (int, int, error) {
+	if s.blocks == nil {
+		return 0, 0, nil
+	}
+	instances, err := s.blocks.ListPageInstances(ctx, pageID)
+	if err != nil {
+		return 0, 0, err
+	}
+	ids := make(map[uuid.UUID]struct{}, len(instances))
+	for _, inst := range instances {
+		if inst != nil && inst.DeletedAt == nil {
+			ids[inst.ID] = struct{}{}
+		}
+	}
+	if s.widgets == nil || len(ids) == 0 {
+		return len(ids), 0, nil
+	}
+	all, err := s.widgets.ListAllInstances(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	widgetCount := 0
+	for _, widget := range all {
+		if widget == nil || widget.BlockInstanceID == nil {
+			continue
+		}
+		if _, ok := ids[*widget.BlockInstanceID]; ok {
+			widgetCount++
+		}
+	}
+	return len(ids), widgetCount, nil
+}
+
+// promotePageVersion copies the selected source page version onto the target
+// page with block and widget placements remapped to the recreated instances.
+func (s *service) promotePageVersion(ctx context.Context, source *pages.Page, target *pages.Page, ids map[uuid.UUID]uuid.UUID, opts PromoteOptions, actor uuid.UUID) error {
+	var (
+		sourceVersion *pages.PageVersion
+		err           error
+	)
+	if source.PublishedVersion != nil && boolValue(opts.PreferPublished, true) {
+		sourceVersion, err = s.pages.GetVersion(ctx, source.ID, *source.PublishedVersion)
+	} else {
+		sourceVersion, err = s.pages.GetLatestVersion(ctx, source.ID)
+	}
+	if err != nil || sourceVersion == nil {
+		// Pages without recorded versions are promoted as-is.
+		return nil
+	}
+
+	versions, err := s.pages.ListVersions(ctx, target.ID)
+	if err != nil {
+		return err
+	}
+	next := 1
+	for _, version := range versions {
+		if version != nil && version.Version >= next {
+			next = version.Version + 1
+		}
+	}
+	promoted := &pages.PageVersion{
+		ID:        s.id(),
+		PageID:    target.ID,
+		Version:   next,
+		Status:    domain.StatusDraft,
+		Snapshot:  remapPageSnapshot(sourceVersion.Snapshot, ids),
+		CreatedBy: actor,
+		CreatedAt: s.now(),
+	}
+	if opts.PromoteAsPublished {
+		promoted.Status = domain.StatusPublished
+		promoted.PublishedAt = target.PublishedAt
+		promoted.PublishedBy = cloneUUIDPtr(target.PublishedBy)
+	}
+	if _, err := s.pages.CreateVersion(ctx, promoted); err != nil {
+		return err
+	}
+
+	updated := *target
+	updated.CurrentVersion = next
+	if opts.PromoteAsPublished {
+		updated.PublishedVersion = &next
+	}
+	_, err = s.pages.Update(ctx, &updated)
+	return err
+}
+
+func remapPageSnapshot(snapshot pages.PageVersionSnapshot, ids map[uuid.UUID]uuid.UUID) pages.PageVersionSnapshot {
+	remap := func(id uuid.UUID) uuid.UUID {
+		if mapped, ok := ids[id]; ok {
+			return mapped
+		}
+		return id
+	}
+	remapPlacements := func(placements []pages.PageBlockPlacement) []pages.PageBlockPlacement {
+		if placements == nil {
+			return nil
+		}
+		out := make([]pages.PageBlockPlacement, len(placements))
+		for i, placement := range placements {
+			out[i] = placement
+			out[i].BlockID = remap(placement.BlockID)
+			out[i].InstanceID = remap(placement.InstanceID)
+			out[i].Snapshot = cloneMap(placement.Snapshot)
+		}
+		return out
+	}
+
+	out := pages.PageVersionSnapshot{
+		Blocks:   remapPlacements(snapshot.Blocks),
+		Metadata: cloneMap(snapshot.Metadata),
+		Media:    media.CloneBindingSet(snapshot.Media),
+	}
+	if snapshot.Regions != nil {
+		out.Regions = make(map[string][]pages.PageBlockPlacement, len(snapshot.Regions))
+		for region, placements := range snapshot.Regions {
+			out.Regions[region] = remapPlacements(placements)
+		}
+	}
+	if snapshot.Widgets != nil {
+		out.Widgets = make(map[string][]pages.WidgetPlacementSnapshot, len(snapshot.Widgets))
+		for area, placements := range snapshot.Widgets {
+			copied := make([]pages.WidgetPlacementSnapshot, len(placements))
+			for i, placement := range placements {
+				copied[i] = placement
+				copied[i].InstanceID = remap(placement.InstanceID)
+				copied[i].Configuration = cloneMap(placement.Configuration)
+			}
+			out.Widgets[area] = copied
+		}
+	}
+	return out
+}
+
+// collectPageIDs returns the selected pages ordered so parents are promoted
+// before their children.
+func (s *service) collectPageIDs(ctx context.Context, envID string, ids []uuid.UUID, slugs []string) ([]uuid.UUID, error) {
+	if s.pages == nil {
+		return nil, errors.New("promotions: page repository required")
+	}
+	records, err := s.pages.List(ctx, envID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*pages.Page, len(records))
+	for _, record := range records {
+		if record != nil && record.DeletedAt == nil {
+			byID[record.ID] = record
+		}
+	}
+
+	selected := map[uuid.UUID]struct{}{}
+	if len(ids) == 0 && len(slugs) == 0 {
+		for id := range byID {
+			selected[id] = struct{}{}
+		}
+	} else {
+		for _, id := range ids {
+			if _, ok := byID[id]; ok {
+				selected[id] = struct{}{}
+			}
+		}
+		wanted := make(map[string]struct{}, len(slugs))
+		for _, slug := range slugs {
+			wanted[normalizeMatchKey(slug)] = struct{}{}
+		}
+		for id, record := range byID {
+			if _, ok := wanted[normalizeMatchKey(record.Slug)]; ok {
+				selected[id] = struct{}{}
+			}
+		}
+	}
+
+	depth := func(record *pages.Page) int {
+		level := 0
+		seen := map[uuid.UUID]struct{}{record.ID: {}}
+		for record.ParentID != nil {
+			parent, ok := byID[*record.ParentID]
+			if !ok {
+				break
+			}
+			if _, cycle := seen[parent.ID]; cycle {
+				break
+			}
+			seen[parent.ID] = struct{}{}
+			record = parent
+			level++
+		}
+		return level
+	}
+
+	ordered := make([]*pages.Page, 0, len(selected))
+	levels := make(map[uuid.UUID]int, len(selected))
+	for id := range selected {
+		record := byID[id]
+		ordered = append(ordered, record)
+		levels[id] = depth(record)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if levels[ordered[i].ID] != levels[ordered[j].ID] {
+			return levels[ordered[i].ID] < levels[ordered[j].ID]
+		}
+		return ordered[i].Slug < ordered[j].Slug
+	})
+	out := make([]uuid.UUID, len(ordered))
+	for i, record := range ordered {
+		out[i] = record.ID
+	}
+	return out, nil
+}
+
+func isPageNotFound(err error) bool {
+	var nf *pages.PageNotFoundError
+	return errors.As(err, &nf)
+}
+
+func buildPageItem(created bool, sourceID, targetID uuid.UUID, blockCount, widgetCount int, dryRun bool) *PromoteItem {
+	status := "updated"
+	if created {
+		status = "created"
+	}
+	details := map[string]any{
+		"block_instances":  blockCount,
+		"widget_instances": widgetCount,
+	}
+	if dryRun {
+		details["dry_run"] = true
+	}
+	return &PromoteItem{
+		Kind:     KindPage,
+		SourceID: sourceID,
+		TargetID: targetID,
+		Status:   status,
+		Details:  details,
+	}
+}
@@ -0,0 +1,399 @@
+package promotions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/promotions"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+type structureFixture struct {
+	promo      promotions.Service
+	pageRepo   *pages.MemoryPageRepository
+	blockSvc   blocks.Service
+	widgetSvc  widgets.Service
+	menuSvc    menus.Service
+	bindings   menus.MenuLocationBindingRepository
+	batches    promotions.BatchRepository
+	contentIDs map[string]uuid.UUID
+	localeID   uuid.UUID
+}
+
+func newStructureFixture(t *testing.T) *structureFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	envSvc := newEnvService(t)
+	typeRepo := content.NewMemoryContentTypeRepository()
+	contentRepo := content.NewMemoryContentRepository()
+	localeRepo := content.NewMemoryLocaleRepository()
+	locale := &content.Locale{ID: uuid.New(), Code: "en", Display: "English", IsActive: true, IsDefault: true}
+	localeRepo.Put(locale)
+	typeSvc := content.NewContentTypeService(typeRepo, content.WithContentTypeEnvironmentService(envSvc))
+
+	contentIDs := map[string]uuid.UUID{}
+	for _, env := range []string{"dev", "prod"} {
+		ct, err := typeSvc.Create(ctx, content.CreateContentTypeRequest{
+			Name:           "Page",
+			Slug:           "page",
+			Status:         content.ContentTypeStatusActive,
+			Schema:         contentSchema("page", "page@v1.0.0", map[string]any{"title": map[string]any{"type": "string"}}, nil),
+			EnvironmentKey: env,
+		})
+		if err != nil {
+			t.Fatalf("create %s content type: %v", env, err)
+		}
+		seedComparisonContent(t, contentRepo, ct.ID, cmsenv.IDForKey(env), locale.ID, "team", "Team")
+		record, err := contentRepo.GetBySlug(ctx, "team", ct.ID, cmsenv.IDForKey(env).String())
+		if err != nil {
+			t.Fatalf("load %s content: %v", env, err)
+		}
+		contentIDs[env] = record.ID
+	}
+
+	pageRepo := pages.NewMemoryPageRepository()
+	blockSvc := newBlockService(t, envSvc)
+	widgetSvc := widgets.NewService(
+		widgets.NewMemoryDefinitionRepository(),
+		widgets.NewMemoryInstanceRepository(),
+		widgets.NewMemoryTranslationRepository(),
+		widgets.WithAreaDefinitionRepository(widgets.NewMemoryAreaDefinitionRepository()),
+		widgets.WithAreaPlacementRepository(widgets.NewMemoryAreaPlacementRepository()),
+		widgets.WithEnvironmentService(envSvc),
+		widgets.WithDefaultEnvironmentKey("dev"),
+	)
+	bindingRepo := menus.NewMemoryMenuLocationBindingRepository()
+	menuRepo := menus.NewMemoryMenuRepository()
+	menuSvc := menus.NewService(
+		menuRepo,
+		menus.NewMemoryMenuItemRepository(),
+		menus.NewMemoryMenuItemTranslationRepository(),
+		localeRepo,
+		menus.WithEnvironmentService(envSvc),
+		menus.WithMenuLocationBindingRepository(bindingRepo),
+		menus.WithPageRepository(pageRepo),
+	)
+
+	batchRepo := promotions.NewMemoryBatchRepository()
+	promo := promotions.NewService(
+		envSvc,
+		typeRepo,
+		contentRepo,
+		localeRepo,
+		promotions.WithBlockService(blockSvc),
+		promotions.WithWidgetService(widgetSvc),
+		promotions.WithPageRepository(pageRepo),
+		promotions.WithMenuService(menuSvc),
+		promotions.WithMenuRepository(menuRepo),
+		promotions.WithMenuLocationBindingRepository(bindingRepo),
+		promotions.WithBatchRepository(batchRepo),
+		promotions.WithDefaultEnvironmentKey("dev"),
+	)
+	return &structureFixture{
+		promo:      promo,
+		pageRepo:   pageRepo,
+		blockSvc:   blockSvc,
+		widgetSvc:  widgetSvc,
+		menuSvc:    menuSvc,
+		bindings:   bindingRepo,
+		batches:    batchRepo,
+		contentIDs: contentIDs,
+		localeID:   locale.ID,
+	}
+}
+
+func (f *structureFixture) seedPage(t *testing.T, slug string, contentID uuid.UUID, parentID *uuid.UUID) *pages.Page {
+	t.Helper()
+	return f.seedSitePage(t, slug, contentID, parentID, nil)
+}
+
+func (f *structureFixture) seedSitePage(t *testing.T, slug string, contentID uuid.UUID, parentID, siteID *uuid.UUID) *pages.Page {
+	t.Helper()
+	now := time.Now().UTC()
+	id := uuid.New()
+	actor := uuid.New()
+	page, err := f.pageRepo.Create(context.Background(), &pages.Page{
+		ID:            id,
+		ContentID:     contentID,
+		ParentID:      parentID,
+		TemplateID:    uuid.New(),
+		Slug:          slug,
+		Status:        string(domain.StatusPublished),
+		EnvironmentID: cmsenv.IDForKey("dev"),
+		SiteID:        siteID,
+		CreatedBy:     actor,
+		UpdatedBy:     actor,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Translations: []*pages.PageTranslation{{
+			ID:        uuid.New(),
+			PageID:    id,
+			LocaleID:  f.localeID,
+			Title:     slug,
+			Path:      "/" + slug,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("seed page %s: %v", slug, err)
+	}
+	return page
+}
+
+func TestPromotionService_PromotePagesAndMenus(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+
+	about := f.seedPage(t, "about", f.contentIDs["dev"], nil)
+	team := f.seedPage(t, "team", f.contentIDs["dev"], &about.ID)
+
+	hero, err := f.blockSvc.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:           "Hero",
+		Slug:           "hero",
+		Schema:         blockSchema("hero", "string"),
+		EnvironmentKey: "dev",
+	})
+	if err != nil {
+		t.Fatalf("register block definition: %v", err)
+	}
+	block, err := f.blockSvc.CreateInstance(ctx, blocks.CreateInstanceInput{
+		DefinitionID:  hero.ID,
+		PageID:        &team.ID,
+		Region:        "main",
+		Configuration: map[string]any{"layout": "wide"},
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create block instance: %v", err)
+	}
+	if _, err := f.blockSvc.AddTranslation(ctx, blocks.AddTranslationInput{
+		BlockInstanceID: block.ID,
+		LocaleID:        f.localeID,
+		Content:         map[string]any{"title": "Meet the team"},
+	}); err != nil {
+		t.Fatalf("add block translation: %v", err)
+	}
+	widgetDef, err := f.widgetSvc.RegisterDefinition(ctx, widgets.RegisterDefinitionInput{
+		Name:   "newsletter",
+		Schema: map[string]any{"fields": []any{map[string]any{"name": "headline", "type": "text"}}},
+	})
+	if err != nil {
+		t.Fatalf("register widget definition: %v", err)
+	}
+	if _, err := f.widgetSvc.CreateInstance(ctx, widgets.CreateInstanceInput{
+		DefinitionID:    widgetDef.ID,
+		BlockInstanceID: &block.ID,
+		Configuration:   map[string]any{"headline": "Subscribe"},
+		CreatedBy:       uuid.New(),
+		UpdatedBy:       uuid.New(),
+	}); err != nil {
+		t.Fatalf("create widget instance: %v", err)
+	}
+
+	menu, err := f.menuSvc.CreateMenu(ctx, menus.CreateMenuInput{Code: "main", EnvironmentKey: "dev"})
+	if err != nil {
+		t.Fatalf("create menu: %v", err)
+	}
+	if _, err := f.menuSvc.AddMenuItem(ctx, menus.AddMenuItemInput{
+		MenuID:       menu.ID,
+		ExternalCode: "team",
+		Type:         menus.MenuItemTypeItem,
+		Target:       map[string]any{"type": "page", "page_id": team.ID.String()},
+		Translations: []menus.MenuItemTranslationInput{{Locale: "en", Label: "Team"}},
+	}); err != nil {
+		t.Fatalf("add menu item: %v", err)
+	}
+	if _, err := f.menuSvc.UpsertMenuLocationBinding(ctx, menus.UpsertMenuLocationBindingInput{
+		Location:       "site.primary",
+		MenuCode:       "main",
+		Status:         "published",
+		EnvironmentKey: "dev",
+	}); err != nil {
+		t.Fatalf("bind menu location: %v", err)
+	}
+
+	result, err := f.promo.PromoteEnvironment(ctx, promotions.PromoteEnvironmentRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Scope:             promotions.ScopePages,
+		Options:           promotions.PromoteOptions{AutoPromoteDependencies: true},
+	})
+	if err != nil {
+		t.Fatalf("promote pages: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected promotion errors %+v", result.Errors)
+	}
+	if result.Summary.Pages.Created != 2 || result.Summary.BlockInstances.Created != 1 || result.Summary.WidgetInstances.Created != 1 {
+		t.Fatalf("unexpected summary %+v", result.Summary)
+	}
+
+	prodEnv := cmsenv.IDForKey("prod").String()
+	prodAbout, err := f.pageRepo.GetBySlug(ctx, "about", prodEnv)
+	if err != nil {
+		t.Fatalf("load promoted parent: %v", err)
+	}
+	prodTeam, err := f.pageRepo.GetBySlug(ctx, "team", prodEnv)
+	if err != nil {
+		t.Fatalf("load promoted page: %v", err)
+	}
+	if prodTeam.ParentID == nil || *prodTeam.ParentID != prodAbout.ID {
+		t.Fatalf("expected parent remapped to %s, got %v", prodAbout.ID, prodTeam.ParentID)
+	}
+	if prodTeam.ContentID != f.contentIDs["prod"] {
+		t.Fatalf("expected content remapped to %s, got %s", f.contentIDs["prod"], prodTeam.ContentID)
+	}
+	if len(prodTeam.Translations) != 1 || prodTeam.Translations[0].Path != "/team" {
+		t.Fatalf("expected translations copied, got %+v", prodTeam.Translations)
+	}
+	prodBlocks, err := f.blockSvc.ListPageInstances(ctx, prodTeam.ID)
+	if err != nil || len(prodBlocks) != 1 {
+		t.Fatalf("expected promoted block instance, got %v (%v)", prodBlocks, err)
+	}
+	if prodBlocks[0].DefinitionID == hero.ID || len(prodBlocks[0].Translations) != 1 {
+		t.Fatalf("expected block remapped to prod definition with translations, got %+v", prodBlocks[0])
+	}
+
+	menuResult, err := f.promo.PromoteEnvironment(ctx, promotions.PromoteEnvironmentRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Scope:             promotions.ScopeMenus,
+	})
+	if err != nil {
+		t.Fatalf("promote menus: %v", err)
+	}
+	if menuResult.Summary.Menus.Created != 1 || len(menuResult.Errors) > 0 {
+		t.Fatalf("unexpected menu result %+v", menuResult)
+	}
+	prodMenu, err := f.menuSvc.GetMenuByCode(ctx, "main", "prod")
+	if err != nil {
+		t.Fatalf("load promoted menu: %v", err)
+	}
+	if len(prodMenu.Items) != 1 || prodMenu.Items[0].Target["page_id"] != prodTeam.ID.String() {
+		t.Fatalf("expected menu item to target prod page, got %+v", prodMenu.Items)
+	}
+	prodBindings, err := f.bindings.List(ctx, prodEnv)
+	if err != nil || len(prodBindings) != 1 || prodBindings[0].Location != "site.primary" {
+		t.Fatalf("expected location binding promoted, got %+v (%v)", prodBindings, err)
+	}
+
+	if _, err := f.promo.PromoteMenu(ctx, promotions.PromoteMenuRequest{MenuID: menu.ID, TargetEnvironment: "prod"}); !errors.Is(err, menus.ErrMenuCodeExists) {
+		t.Fatalf("expected strict mode conflict, got %v", err)
+	}
+	item, err := f.promo.PromoteMenu(ctx, promotions.PromoteMenuRequest{
+		MenuID:            menu.ID,
+		TargetEnvironment: "prod",
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil || item.Status != "updated" {
+		t.Fatalf("expected upsert to update menu, got %+v (%v)", item, err)
+	}
+	prodMenu, err = f.menuSvc.GetMenuByCode(ctx, "main", "prod")
+	if err != nil || len(prodMenu.Items) != 1 {
+		t.Fatalf("expected menu items rebuilt without duplicates, got %+v (%v)", prodMenu, err)
+	}
+}
+
+func TestPromotionService_PromotePageDependencies(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+
+	parent := f.seedPage(t, "docs", f.contentIDs["dev"], nil)
+	child := f.seedPage(t, "install", f.contentIDs["dev"], &parent.ID)
+
+	if _, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: child.ID, TargetEnvironment: "prod"}); !errors.Is(err, promotions.ErrDependencyMissing) {
+		t.Fatalf("expected missing parent dependency, got %v", err)
+	}
+
+	result, err := f.promo.PromoteEnvironment(ctx, promotions.PromoteEnvironmentRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Scope:             promotions.ScopePages,
+		Options:           promotions.PromoteOptions{DryRun: true},
+	})
+	if err != nil {
+		t.Fatalf("dry-run promote pages: %v", err)
+	}
+	if len(result.Errors) > 0 || result.Summary.Pages.Created != 2 {
+		t.Fatalf("expected dry run to plan parent before child, got %+v", result)
+	}
+	if records, _ := f.pageRepo.List(ctx, cmsenv.IDForKey("prod").String()); len(records) != 0 {
+		t.Fatalf("expected no pages persisted during dry run, got %d", len(records))
+	}
+
+	if _, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: parent.ID, TargetEnvironment: "prod"}); err != nil {
+		t.Fatalf("promote parent: %v", err)
+	}
+	if _, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: parent.ID, TargetEnvironment: "prod"}); !errors.Is(err, pages.ErrSlugExists) {
+		t.Fatalf("expected strict mode slug conflict, got %v", err)
+	}
+	item, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{
+		PageID:            parent.ID,
+		TargetEnvironment: "prod",
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil || item.Status != "updated" {
+		t.Fatalf("expected upsert to update page, got %+v (%v)", item, err)
+	}
+}
+
+func TestPromotionService_PromotePageKeepsSiteScope(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	siteA, siteB := uuid.New(), uuid.New()
+
+	aboutA := f.seedSitePage(t, "about", f.contentIDs["dev"], nil, &siteA)
+	aboutB := f.seedSitePage(t, "about", f.contentIDs["dev"], nil, &siteB)
+
+	first, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{PageID: aboutA.ID, TargetEnvironment: "prod"})
+	if err != nil {
+		t.Fatalf("promote site A page: %v", err)
+	}
+	comparison, err := f.promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Kinds:             []string{"pages"},
+	})
+	if err != nil {
+		t.Fatalf("compare environments: %v", err)
+	}
+	if comparison.Summary.Pages.Added != 1 || comparison.Summary.Pages.Removed != 0 {
+		t.Fatalf("expected only the site B page reported as added, got %+v", comparison.Summary.Pages)
+	}
+	if item := findComparisonItem(t, comparison, promotions.KindPage, siteA.String()+"/about"); item.Status == promotions.ComparisonAdded {
+		t.Fatalf("expected site A page matched against its promoted copy, got %+v", item)
+	}
+
+	second, err := f.promo.PromotePage(ctx, promotions.PromotePageRequest{
+		PageID:            aboutB.ID,
+		TargetEnvironment: "prod",
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil {
+		t.Fatalf("promote site B page: %v", err)
+	}
+	if second.Status != "created" || second.TargetID == first.TargetID {
+		t.Fatalf("expected a separate page for site B, got %+v", second)
+	}
+	for site, id := range map[uuid.UUID]uuid.UUID{siteA: first.TargetID, siteB: second.TargetID} {
+		page, err := f.pageRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("load promoted page: %v", err)
+		}
+		if page.SiteID == nil || *page.SiteID != site {
+			t.Fatalf("expected promoted page scoped to site %s, got %v", site, page.SiteID)
+		}
+	}
+}
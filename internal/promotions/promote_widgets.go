@@ -0,0 +1,298 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+// areaWidgets holds the widget instances of one environment placed in an
+// area, ordered by position, together with their placements in that area.
+type areaWidgets struct {
+	instances  []*widgets.Instance
+	placements []*widgets.AreaPlacement
+}
+
+func (s *service) PromoteWidgetArea(ctx context.Context, req PromoteWidgetAreaRequest) (*PromoteItem, error) {
+	area, err := s.widgetAreaDefinition(ctx, req.AreaCode)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordSinglePromotion(ctx, KindWidgetArea, area.ID, req.Options, func(ctx context.Context) (*PromoteItem, error) {
+		return s.promoteWidgetArea(ctx, area, req)
+	})
+}
+
+func (s *service) promoteWidgetArea(ctx context.Context, area *widgets.AreaDefinition, req PromoteWidgetAreaRequest) (*PromoteItem, error) {
+	opts := normalizeOptions(req.Options)
+	sourceEnv, err := s.resolveEnvironment(ctx, req.SourceEnvironment, nil)
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := s.resolveEnvironment(ctx, req.TargetEnvironment, req.TargetEnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceEnv.ID == targetEnv.ID {
+		return &PromoteItem{Kind: KindWidgetArea, SourceID: area.ID, TargetID: area.ID, Status: "skipped", Message: "source and target environments match"}, nil
+	}
+	scope := promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}
+
+	sourceAreas, err := s.loadAreaWidgets(ctx, sourceEnv)
+	if err != nil {
+		return nil, err
+	}
+	targetAreas, err := s.loadAreaWidgets(ctx, targetEnv)
+	if err != nil {
+		return nil, err
+	}
+	source := sourceAreas[area.Code]
+	existing := targetAreas[area.Code]
+	if source == nil && existing == nil {
+		return &PromoteItem{Kind: KindWidgetArea, SourceID: area.ID, TargetID: area.ID, Status: "skipped", Message: "area has no widgets in either environment"}, nil
+	}
+	if existing != nil && opts.Mode == ModeStrict {
+		return nil, ErrWidgetAreaNotEmpty
+	}
+
+	status := "updated"
+	if existing == nil {
+		status = "created"
+	}
+	details := map[string]any{
+		"code":             area.Code,
+		"widget_instances": 0,
+		"placements":       0,
+	}
+	if source != nil {
+		details["widget_instances"] = len(source.instances)
+		details["placements"] = len(source.placements)
+	}
+	if opts.DryRun {
+		details["dry_run"] = true
+		return &PromoteItem{Kind: KindWidgetArea, SourceID: area.ID, TargetID: area.ID, Status: status, Details: details}, nil
+	}
+
+	actor := opts.ActorID
+	if err := s.journalUpdate(ctx, scope, KindWidgetArea, area.ID, area.ID); err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		for _, widget := range existing.instances {
+			if err := s.widgets.DeleteInstance(ctx, widgets.DeleteInstanceRequest{InstanceID: widget.ID, DeletedBy: actor, HardDelete: true}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if source != nil {
+		if err := s.copyAreaWidgets(ctx, source, targetEnv.Key, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	s.emitPromotionActivity(ctx, KindWidgetArea, "promote", area.ID, area.ID, sourceEnv, targetEnv, opts)
+	return &PromoteItem{Kind: KindWidgetArea, SourceID: area.ID, TargetID: area.ID, Status: status, Details: details}, nil
+}
+
+// copyAreaWidgets creates the area widget instances with their translations
+// in envKey and places them in the same order as the source placements.
+func (s *service) copyAreaWidgets(ctx context.Context, source *areaWidgets, envKey string, actor uuid.UUID) error {
+	ids := make(map[uuid.UUID]uuid.UUID, len(source.instances))
+	for _, widget := range source.instances {
+		createdBy := pickActor(actor, widget.UpdatedBy, widget.CreatedBy)
+		created, err := s.widgets.CreateInstance(ctx, widgets.CreateInstanceInput{
+			DefinitionID:    widget.DefinitionID,
+			AreaCode:        cloneString(widget.AreaCode),
+			Placement:       cloneMap(widget.Placement),
+			Configuration:   cloneMap(widget.Configuration),
+			VisibilityRules: cloneMap(widget.VisibilityRules),
+			PublishOn:       cloneTimePtr(widget.PublishOn),
+			UnpublishOn:     cloneTimePtr(widget.UnpublishOn),
+			Position:        widget.Position,
+			CreatedBy:       createdBy,
+			UpdatedBy:       createdBy,
+			EnvironmentKey:  envKey,
+		})
+		if err != nil {
+			return err
+		}
+		ids[widget.ID] = created.ID
+		for _, tr := range widget.Translations {
+			if tr == nil || tr.DeletedAt != nil {
+				continue
+			}
+			if _, err := s.widgets.AddTranslation(ctx, widgets.AddTranslationInput{
+				InstanceID: created.ID,
+				LocaleID:   tr.LocaleID,
+				Content:    cloneMap(tr.Content),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, placement := range source.placements {
+		if _, err := s.widgets.AssignWidgetToArea(ctx, widgets.AssignWidgetToAreaInput{
+			AreaCode:   placement.AreaCode,
+			LocaleID:   cloneUUIDPtr(placement.LocaleID),
+			InstanceID: ids[placement.InstanceID],
+			Metadata:   cloneMap(placement.Metadata),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAreaWidgets groups the area widget instances of env by area code.
+// Instances bound to block instances are left out; they belong to a page.
+func (s *service) loadAreaWidgets(ctx context.Context, env *cmsenv.Environment) (map[string]*areaWidgets, error) {
+	all, err := s.widgets.ListAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]*areaWidgets{}
+	for _, widget := range all {
+		if widget == nil || widget.DeletedAt != nil || widget.BlockInstanceID != nil || !s.widgetInEnvironment(widget, env) {
+			continue
+		}
+		placements, err := s.widgets.ListInstancePlacements(ctx, widget.ID)
+		if err != nil && !errors.Is(err, widgets.ErrAreaFeatureDisabled) {
+			return nil, err
+		}
+		codes := map[string]struct{}{}
+		if widget.AreaCode != nil && strings.TrimSpace(*widget.AreaCode) != "" {
+			codes[strings.TrimSpace(*widget.AreaCode)] = struct{}{}
+		}
+		for _, placement := range placements {
+			if placement != nil {
+				codes[placement.AreaCode] = struct{}{}
+			}
+		}
+		for code := range codes {
+			group := out[code]
+			if group == nil {
+				group = &areaWidgets{}
+				out[code] = group
+			}
+			group.instances = append(group.instances, widget)
+			for _, placement := range placements {
+				if placement != nil && placement.AreaCode == code {
+					group.placements = append(group.placements, placement)
+				}
+			}
+		}
+	}
+	for _, group := range out {
+		sort.Slice(group.placements, func(i, j int) bool {
+			a, b := group.placements[i], group.placements[j]
+			if localeKey(a.LocaleID) != localeKey(b.LocaleID) {
+				return localeKey(a.LocaleID) < localeKey(b.LocaleID)
+			}
+			if a.Position != b.Position {
+				return a.Position < b.Position
+			}
+			return a.ID.String() < b.ID.String()
+		})
+		// Instances follow their first placement so that both environments
+		// list an area's widgets in the order they render.
+		rank := make(map[uuid.UUID]int, len(group.instances))
+		for idx, placement := range group.placements {
+			if _, ok := rank[placement.InstanceID]; !ok {
+				rank[placement.InstanceID] = idx
+			}
+		}
+		sort.Slice(group.instances, func(i, j int) bool {
+			a, b := group.instances[i], group.instances[j]
+			ra, oka := rank[a.ID]
+			rb, okb := rank[b.ID]
+			if oka != okb {
+				return oka
+			}
+			if ra != rb {
+				return ra < rb
+			}
+			if a.Position != b.Position {
+				return a.Position < b.Position
+			}
+			return a.ID.String() < b.ID.String()
+		})
+	}
+	return out, nil
+}
+
+// widgetInEnvironment reports whether widget belongs to env. Instances stored
+// before widgets were environment scoped belong to the default environment.
+func (s *service) widgetInEnvironment(widget *widgets.Instance, env *cmsenv.Environment) bool {
+	if widget.EnvironmentID != uuid.Nil {
+		return widget.EnvironmentID == env.ID
+	}
+	return env.Key == cmsenv.NormalizeKey(s.defaultEnvKey)
+}
+
+func (s *service) widgetAreaDefinition(ctx context.Context, code string) (*widgets.AreaDefinition, error) {
+	if s == nil || s.widgets == nil {
+		return nil, errors.New("promotions: widget area promotion unavailable")
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, widgets.ErrAreaCodeRequired
+	}
+	definitions, err := s.widgets.ListAreaDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, definition := range definitions {
+		if definition != nil && definition.Code == code {
+			return definition, nil
+		}
+	}
+	return nil, widgets.ErrAreaDefinitionNotFound
+}
+
+// collectWidgetAreaCodes lists the defined areas holding widgets in env,
+// limited to codes when given.
+func (s *service) collectWidgetAreaCodes(ctx context.Context, env *cmsenv.Environment, codes []string) ([]string, error) {
+	if s.widgets == nil {
+		return nil, errors.New("promotions: widget service required")
+	}
+	definitions, err := s.widgets.ListAreaDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		wanted[strings.TrimSpace(code)] = struct{}{}
+	}
+	areas, err := s.loadAreaWidgets(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition == nil {
+			continue
+		}
+		if len(wanted) > 0 {
+			if _, ok := wanted[definition.Code]; !ok {
+				continue
+			}
+		} else if areas[definition.Code] == nil {
+			continue
+		}
+		out = append(out, definition.Code)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func localeKey(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
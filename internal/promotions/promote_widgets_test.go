@@ -0,0 +1,193 @@
+package promotions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-cms/internal/promotions"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+func (f *structureFixture) seedAreaWidget(t *testing.T, definitionID uuid.UUID, area, env, headline string) *widgets.Instance {
+	t.Helper()
+	ctx := context.Background()
+	instance, err := f.widgetSvc.CreateInstance(ctx, widgets.CreateInstanceInput{
+		DefinitionID:   definitionID,
+		Configuration:  map[string]any{"headline": headline},
+		CreatedBy:      uuid.New(),
+		UpdatedBy:      uuid.New(),
+		EnvironmentKey: env,
+	})
+	if err != nil {
+		t.Fatalf("create %s widget: %v", env, err)
+	}
+	if _, err := f.widgetSvc.AddTranslation(ctx, widgets.AddTranslationInput{
+		InstanceID: instance.ID,
+		LocaleID:   f.localeID,
+		Content:    map[string]any{"headline": headline + " (en)"},
+	}); err != nil {
+		t.Fatalf("add %s widget translation: %v", env, err)
+	}
+	localeID := f.localeID
+	if _, err := f.widgetSvc.AssignWidgetToArea(ctx, widgets.AssignWidgetToAreaInput{
+		AreaCode:   area,
+		LocaleID:   &localeID,
+		InstanceID: instance.ID,
+	}); err != nil {
+		t.Fatalf("assign %s widget: %v", env, err)
+	}
+	return instance
+}
+
+func (f *structureFixture) areaHeadlines(t *testing.T, area, env string) []any {
+	t.Helper()
+	localeID := f.localeID
+	resolved, err := f.widgetSvc.ResolveArea(context.Background(), widgets.ResolveAreaInput{
+		AreaCode:       area,
+		LocaleID:       &localeID,
+		EnvironmentKey: env,
+	})
+	if err != nil {
+		t.Fatalf("resolve %s area: %v", env, err)
+	}
+	out := make([]any, 0, len(resolved))
+	for _, widget := range resolved {
+		out = append(out, widget.Config["headline"])
+	}
+	return out
+}
+
+func (f *structureFixture) seedSidebar(t *testing.T) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := f.widgetSvc.RegisterAreaDefinition(ctx, widgets.RegisterAreaDefinitionInput{Code: "sidebar", Name: "Sidebar"}); err != nil {
+		t.Fatalf("register area: %v", err)
+	}
+	definition, err := f.widgetSvc.RegisterDefinition(ctx, widgets.RegisterDefinitionInput{
+		Name:   "newsletter",
+		Schema: map[string]any{"fields": []any{map[string]any{"name": "headline", "type": "text"}}},
+	})
+	if err != nil {
+		t.Fatalf("register widget definition: %v", err)
+	}
+	return definition.ID
+}
+
+func TestPromotionService_PromoteWidgetArea(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	definitionID := f.seedSidebar(t)
+
+	f.seedAreaWidget(t, definitionID, "sidebar", "dev", "Subscribe")
+	f.seedAreaWidget(t, definitionID, "sidebar", "dev", "Follow us")
+	f.seedAreaWidget(t, definitionID, "sidebar", "prod", "Old promo")
+
+	if _, err := f.promo.PromoteWidgetArea(ctx, promotions.PromoteWidgetAreaRequest{
+		AreaCode:          "sidebar",
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+	}); !errors.Is(err, promotions.ErrWidgetAreaNotEmpty) {
+		t.Fatalf("expected strict promotion to refuse populated area, got %v", err)
+	}
+
+	item, err := f.promo.PromoteWidgetArea(ctx, promotions.PromoteWidgetAreaRequest{
+		AreaCode:          "sidebar",
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Options:           promotions.PromoteOptions{Mode: promotions.ModeUpsert},
+	})
+	if err != nil {
+		t.Fatalf("promote widget area: %v", err)
+	}
+	if item.Status != "updated" || item.BatchID == nil || item.Details["widget_instances"] != 2 {
+		t.Fatalf("unexpected promotion item %+v", item)
+	}
+
+	got := f.areaHeadlines(t, "sidebar", "prod")
+	if len(got) != 2 || got[0] != "Subscribe (en)" || got[1] != "Follow us (en)" {
+		t.Fatalf("expected promoted widgets in prod, got %v", got)
+	}
+	if dev := f.areaHeadlines(t, "sidebar", "dev"); len(dev) != 2 || dev[0] != "Subscribe (en)" {
+		t.Fatalf("expected dev area untouched, got %v", dev)
+	}
+
+	comparison, err := f.promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Kinds:             []string{"widget_areas"},
+	})
+	if err != nil {
+		t.Fatalf("compare environments: %v", err)
+	}
+	if comparison.Summary.WidgetAreas.Unchanged != 1 || comparison.Summary.WidgetAreas.Changed != 0 {
+		t.Fatalf("expected promoted area to match, got %+v", comparison.Summary.WidgetAreas)
+	}
+
+	rollback, err := f.promo.RollbackPromotion(ctx, *item.BatchID, promotions.RollbackOptions{})
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if rollback.Batch.Status != promotions.BatchRolledBack {
+		t.Fatalf("unexpected rollback result %+v", rollback.Batch)
+	}
+	if got := f.areaHeadlines(t, "sidebar", "prod"); len(got) != 1 || got[0] != "Old promo (en)" {
+		t.Fatalf("expected prod area restored, got %v", got)
+	}
+}
+
+func TestPromotionService_PromoteEnvironmentWidgetsScope(t *testing.T) {
+	ctx := context.Background()
+	f := newStructureFixture(t)
+	definitionID := f.seedSidebar(t)
+	if _, err := f.widgetSvc.RegisterAreaDefinition(ctx, widgets.RegisterAreaDefinitionInput{Code: "footer", Name: "Footer"}); err != nil {
+		t.Fatalf("register footer area: %v", err)
+	}
+
+	f.seedAreaWidget(t, definitionID, "sidebar", "dev", "Subscribe")
+
+	comparison, err := f.promo.CompareEnvironments(ctx, promotions.CompareEnvironmentsRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+	})
+	if err != nil {
+		t.Fatalf("compare environments: %v", err)
+	}
+	if comparison.Summary.WidgetAreas.Added != 1 {
+		t.Fatalf("expected sidebar reported as added, got %+v", comparison.Summary.WidgetAreas)
+	}
+	findComparisonItem(t, comparison, promotions.KindWidgetArea, "sidebar")
+
+	result, err := f.promo.PromoteEnvironment(ctx, promotions.PromoteEnvironmentRequest{
+		SourceEnvironment: "dev",
+		TargetEnvironment: "prod",
+		Scope:             promotions.ScopeWidgets,
+	})
+	if err != nil {
+		t.Fatalf("promote widgets: %v", err)
+	}
+	if result.Summary.WidgetAreas.Created != 1 || result.Summary.WidgetInstances.Created != 1 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected promotion summary %+v", result.Summary)
+	}
+	if got := f.areaHeadlines(t, "sidebar", "prod"); len(got) != 1 || got[0] != "Subscribe (en)" {
+		t.Fatalf("expected sidebar promoted, got %v", got)
+	}
+	if got := f.areaHeadlines(t, "footer", "prod"); len(got) != 0 {
+		t.Fatalf("expected empty footer left alone, got %v", got)
+	}
+
+	batch, err := f.promo.GetPromotion(ctx, *result.BatchID)
+	if err != nil {
+		t.Fatalf("get batch: %v", err)
+	}
+	if len(batch.Records) != 1 || batch.Records[0].Kind != promotions.KindWidgetArea {
+		t.Fatalf("expected one widget area record, got %+v", batch.Records)
+	}
+	if _, err := f.promo.RollbackPromotion(ctx, batch.ID, promotions.RollbackOptions{}); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if got := f.areaHeadlines(t, "sidebar", "prod"); len(got) != 0 {
+		t.Fatalf("expected rollback to clear prod sidebar, got %v", got)
+	}
+}
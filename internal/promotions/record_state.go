@@ -0,0 +1,728 @@
+package promotions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/blocks"
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/domain"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/pages"
+	"github.com/goliatone/go-cms/internal/widgets"
+	"github.com/google/uuid"
+)
+
+// pageState captures a target page together with the block and widget
+// instances attached to it. Block versions travel on each instance; widget
+// versions are keyed by instance ID.
+type pageState struct {
+	Page           *pages.Page                              `json:"page"`
+	Blocks         []*blocks.Instance                       `json:"blocks,omitempty"`
+	Widgets        []*widgets.Instance                      `json:"widgets,omitempty"`
+	WidgetVersions map[uuid.UUID][]*widgets.InstanceVersion `json:"widget_versions,omitempty"`
+}
+
+// menuState captures a target menu, its flattened items and the location
+// bindings that reference it.
+type menuState struct {
+	Menu     *menus.Menu                  `json:"menu"`
+	Items    []*menus.MenuItem            `json:"items,omitempty"`
+	Bindings []*menus.MenuLocationBinding `json:"bindings,omitempty"`
+}
+
+// widgetAreaState captures the widget instances one environment places in an
+// area, with their translations, versions and placements. Widget versions are
+// keyed by instance ID.
+type widgetAreaState struct {
+	EnvironmentID uuid.UUID                                `json:"environment_id"`
+	AreaCode      string                                   `json:"area_code"`
+	Widgets       []*widgets.Instance                      `json:"widgets,omitempty"`
+	Versions      map[uuid.UUID][]*widgets.InstanceVersion `json:"versions,omitempty"`
+	Placements    []*widgets.AreaPlacement                 `json:"placements,omitempty"`
+}
+
+// loadRecordState serializes the current state of a target record. found is
+// false when the record no longer exists. envID selects the target
+// environment for kinds whose records are shared across environments.
+func (s *service) loadRecordState(ctx context.Context, kind string, id uuid.UUID, envID uuid.UUID) (json.RawMessage, bool, error) {
+	var (
+		state any
+		err   error
+	)
+	switch kind {
+	case KindContentType:
+		state, err = s.contentTypes.GetByID(ctx, id)
+	case KindContentEntry:
+		state, err = s.contentEntryState(ctx, id)
+	case KindBlockDefinition:
+		if s.blocks == nil {
+			return nil, false, errors.New("promotions: block service required")
+		}
+		state, err = s.blocks.GetDefinition(ctx, id)
+	case KindPage:
+		state, err = s.pageState(ctx, id)
+	case KindMenu:
+		state, err = s.menuState(ctx, id)
+	case KindWidgetArea:
+		state, err = s.widgetAreaState(ctx, id, envID)
+	default:
+		return nil, false, fmt.Errorf("promotions: unsupported record kind %s", kind)
+	}
+	if err != nil {
+		if isRecordNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return nil, false, err
+	}
+	return payload, true, nil
+}
+
+func (s *service) contentEntryState(ctx context.Context, id uuid.UUID) (*content.Content, error) {
+	record, err := s.contents.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := *record
+	out.Type = nil
+	out.Versions = nil
+	translations := record.Translations
+	if len(translations) == 0 {
+		if reader, ok := s.contents.(content.ContentTranslationReader); ok {
+			if translations, err = reader.ListTranslations(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+	out.Translations = make([]*content.ContentTranslation, 0, len(translations))
+	for _, tr := range translations {
+		if tr == nil {
+			continue
+		}
+		copied := *tr
+		copied.Locale = nil
+		out.Translations = append(out.Translations, &copied)
+	}
+	sort.Slice(out.Translations, func(i, j int) bool {
+		return out.Translations[i].LocaleID.String() < out.Translations[j].LocaleID.String()
+	})
+	return &out, nil
+}
+
+func (s *service) pageState(ctx context.Context, id uuid.UUID) (*pageState, error) {
+	if s.pages == nil {
+		return nil, errors.New("promotions: page repository required")
+	}
+	record, err := s.pages.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	page := *record
+	page.Content = nil
+	page.Versions = nil
+	page.Blocks = nil
+	page.Widgets = nil
+	translations := record.Translations
+	if len(translations) == 0 {
+		if reader, ok := s.pages.(pages.PageTranslationReader); ok {
+			if translations, err = reader.ListTranslations(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+	page.Translations = make([]*pages.PageTranslation, 0, len(translations))
+	for _, tr := range translations {
+		if tr == nil {
+			continue
+		}
+		copied := *tr
+		copied.ResolvedMedia = nil
+		copied.Locale = ""
+		page.Translations = append(page.Translations, &copied)
+	}
+	sort.Slice(page.Translations, func(i, j int) bool {
+		return page.Translations[i].LocaleID.String() < page.Translations[j].LocaleID.String()
+	})
+
+	state := &pageState{Page: &page}
+	if s.blocks == nil {
+		return state, nil
+	}
+	instances, err := s.blocks.ListPageInstances(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	blockIDs := make(map[uuid.UUID]struct{}, len(instances))
+	for _, inst := range instances {
+		if inst == nil || inst.DeletedAt != nil {
+			continue
+		}
+		copied := *inst
+		copied.Definition = nil
+		versions, err := s.blocks.ListVersions(ctx, inst.ID)
+		if err != nil && !errors.Is(err, blocks.ErrVersioningDisabled) && !isRecordNotFound(err) {
+			return nil, err
+		}
+		copied.Versions = versions
+		copied.Translations = make([]*blocks.Translation, 0, len(inst.Translations))
+		for _, tr := range inst.Translations {
+			if tr == nil {
+				continue
+			}
+			trCopy := *tr
+			trCopy.ResolvedMedia = nil
+			copied.Translations = append(copied.Translations, &trCopy)
+		}
+		sort.Slice(copied.Translations, func(i, j int) bool {
+			return copied.Translations[i].LocaleID.String() < copied.Translations[j].LocaleID.String()
+		})
+		state.Blocks = append(state.Blocks, &copied)
+		blockIDs[inst.ID] = struct{}{}
+	}
+	sort.Slice(state.Blocks, func(i, j int) bool {
+		a, b := state.Blocks[i], state.Blocks[j]
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID.String() < b.ID.String()
+	})
+
+	if s.widgets == nil || len(blockIDs) == 0 {
+		return state, nil
+	}
+	all, err := s.widgets.ListAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, widget := range all {
+		if widget == nil || widget.BlockInstanceID == nil {
+			continue
+		}
+		if _, ok := blockIDs[*widget.BlockInstanceID]; !ok {
+			continue
+		}
+		copied := *widget
+		copied.Definition = nil
+		copied.Translations = make([]*widgets.Translation, 0, len(widget.Translations))
+		for _, tr := range widget.Translations {
+			if tr == nil {
+				continue
+			}
+			trCopy := *tr
+			trCopy.Instance = nil
+			copied.Translations = append(copied.Translations, &trCopy)
+		}
+		state.Widgets = append(state.Widgets, &copied)
+		versions, err := s.widgets.ListVersions(ctx, widget.ID)
+		if err != nil && !errors.Is(err, widgets.ErrVersioningDisabled) && !isRecordNotFound(err) {
+			return nil, err
+		}
+		if len(versions) > 0 {
+			if state.WidgetVersions == nil {
+				state.WidgetVersions = map[uuid.UUID][]*widgets.InstanceVersion{}
+			}
+			state.WidgetVersions[widget.ID] = versions
+		}
+	}
+	sort.Slice(state.Widgets, func(i, j int) bool {
+		return state.Widgets[i].ID.String() < state.Widgets[j].ID.String()
+	})
+	return state, nil
+}
+
+func (s *service) menuState(ctx context.Context, id uuid.UUID) (*menuState, error) {
+	if s.menus == nil || s.menuRecords == nil {
+		return nil, errors.New("promotions: menu service required")
+	}
+	record, err := s.menuRecords.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	env, err := s.resolveEnvironmentByID(ctx, record.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	hydrated, err := s.menus.GetMenuByCode(ctx, record.Code, env.Key)
+	if err != nil {
+		return nil, err
+	}
+	menu := *hydrated
+	menu.Items = nil
+	state := &menuState{Menu: &menu}
+	for _, item := range flattenMenuItems(hydrated.Items) {
+		copied := *item
+		copied.Menu = nil
+		copied.Parent = nil
+		copied.Children = nil
+		copied.Translations = make([]*menus.MenuItemTranslation, 0, len(item.Translations))
+		for _, tr := range item.Translations {
+			if tr == nil {
+				continue
+			}
+			trCopy := *tr
+			trCopy.MenuItem = nil
+			trCopy.Locale = nil
+			copied.Translations = append(copied.Translations, &trCopy)
+		}
+		state.Items = append(state.Items, &copied)
+	}
+	bindings, err := s.menuCodeBindings(ctx, env.ID.String(), record.Code)
+	if err != nil {
+		return nil, err
+	}
+	state.Bindings = bindings
+	return state, nil
+}
+
+func (s *service) widgetAreaState(ctx context.Context, areaID, envID uuid.UUID) (*widgetAreaState, error) {
+	if s.widgets == nil {
+		return nil, errors.New("promotions: widget service required")
+	}
+	definitions, err := s.widgets.ListAreaDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var area *widgets.AreaDefinition
+	for _, definition := range definitions {
+		if definition != nil && definition.ID == areaID {
+			area = definition
+			break
+		}
+	}
+	if area == nil {
+		return nil, &widgets.NotFoundError{Resource: "widget_area", Key: areaID.String()}
+	}
+	env, err := s.resolveEnvironmentByID(ctx, envID)
+	if err != nil {
+		return nil, err
+	}
+	areas, err := s.loadAreaWidgets(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	state := &widgetAreaState{EnvironmentID: env.ID, AreaCode: area.Code}
+	group := areas[area.Code]
+	if group == nil {
+		return state, nil
+	}
+	for _, widget := range group.instances {
+		copied := *widget
+		copied.Definition = nil
+		copied.Translations = make([]*widgets.Translation, 0, len(widget.Translations))
+		for _, tr := range widget.Translations {
+			if tr == nil {
+				continue
+			}
+			trCopy := *tr
+			trCopy.Instance = nil
+			copied.Translations = append(copied.Translations, &trCopy)
+		}
+		sort.Slice(copied.Translations, func(i, j int) bool {
+			return copied.Translations[i].LocaleID.String() < copied.Translations[j].LocaleID.String()
+		})
+		state.Widgets = append(state.Widgets, &copied)
+		versions, err := s.widgets.ListVersions(ctx, widget.ID)
+		if err != nil && !errors.Is(err, widgets.ErrVersioningDisabled) && !isRecordNotFound(err) {
+			return nil, err
+		}
+		if len(versions) > 0 {
+			if state.Versions == nil {
+				state.Versions = map[uuid.UUID][]*widgets.InstanceVersion{}
+			}
+			state.Versions[widget.ID] = versions
+		}
+	}
+	// Placement lists are shared by every environment and rewritten whenever
+	// one of them changes, so only the environment's own order is recorded to
+	// keep the checksum stable.
+	positions := map[string]int{}
+	for _, placement := range group.placements {
+		copied := widgets.AreaPlacement{
+			AreaCode:   placement.AreaCode,
+			LocaleID:   cloneUUIDPtr(placement.LocaleID),
+			InstanceID: placement.InstanceID,
+			Metadata:   cloneMap(placement.Metadata),
+		}
+		copied.Position = positions[localeKey(placement.LocaleID)]
+		positions[localeKey(placement.LocaleID)]++
+		state.Placements = append(state.Placements, &copied)
+	}
+	return state, nil
+}
+
+func (s *service) menuCodeBindings(ctx context.Context, envID, code string) ([]*menus.MenuLocationBinding, error) {
+	if s.menuBindings == nil {
+		return nil, nil
+	}
+	all, err := s.menuBindings.List(ctx, envID)
+	if err != nil {
+		return nil, err
+	}
+	var out []*menus.MenuLocationBinding
+	for _, binding := range all {
+		if binding != nil && strings.EqualFold(strings.TrimSpace(binding.MenuCode), code) {
+			out = append(out, binding)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID.String() < out[j].ID.String()
+	})
+	return out, nil
+}
+
+// restoreRecord writes the captured before state back onto the target record.
+func (s *service) restoreRecord(ctx context.Context, record PromotionRecord, actor uuid.UUID) error {
+	switch record.Kind {
+	case KindContentType:
+		var before content.ContentType
+		if err := json.Unmarshal(record.Before, &before); err != nil {
+			return err
+		}
+		_, err := s.contentTypes.Update(ctx, &before)
+		return err
+	case KindContentEntry:
+		var before content.Content
+		if err := json.Unmarshal(record.Before, &before); err != nil {
+			return err
+		}
+		return s.restoreContentEntry(ctx, &before)
+	case KindBlockDefinition:
+		var before blocks.Definition
+		if err := json.Unmarshal(record.Before, &before); err != nil {
+			return err
+		}
+		_, err := s.blocks.UpdateDefinition(ctx, blocks.UpdateDefinitionInput{
+			ID:               before.ID,
+			Name:             &before.Name,
+			Slug:             &before.Slug,
+			Description:      cloneString(before.Description),
+			Icon:             cloneString(before.Icon),
+			Category:         cloneString(before.Category),
+			Status:           &before.Status,
+			Schema:           cloneMap(before.Schema),
+			UISchema:         cloneMap(before.UISchema),
+			Defaults:         cloneMap(before.Defaults),
+			EditorStyleURL:   cloneString(before.EditorStyleURL),
+			FrontendStyleURL: cloneString(before.FrontendStyleURL),
+		})
+		return err
+	case KindPage:
+		var before pageState
+		if err := json.Unmarshal(record.Before, &before); err != nil {
+			return err
+		}
+		return s.restorePage(ctx, &before, actor)
+	case KindMenu:
+		var before menuState
+		if err := json.Unmarshal(record.Before, &before); err != nil {
+			return err
+		}
+		return s.restoreMenu(ctx, &before, actor)
+	case KindWidgetArea:
+		var before widgetAreaState
+		if err := json.Unmarshal(record.Before, &before); err != nil {
+			return err
+		}
+		return s.restoreWidgetArea(ctx, &before, actor)
+	default:
+		return fmt.Errorf("promotions: unsupported record kind %s", record.Kind)
+	}
+}
+
+func (s *service) restoreContentEntry(ctx context.Context, before *content.Content) error {
+	if err := s.contents.ReplaceTranslations(ctx, before.ID, before.Translations); err != nil {
+		return err
+	}
+	if _, err := s.contents.Update(ctx, before); err != nil {
+		return err
+	}
+	versions, err := s.contents.ListVersions(ctx, before.ID)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		status := restoredVersionStatus(version.Version, version.Status, before.CurrentVersion, before.PublishedVersion)
+		if status == version.Status {
+			continue
+		}
+		version.Status = status
+		if _, err := s.contents.UpdateVersion(ctx, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoredVersionStatus returns the status of a version once its record is
+// rolled back to the current and published version numbers: later versions
+// are archived and the published version is published again.
+func restoredVersionStatus(number int, status domain.Status, current int, published *int) domain.Status {
+	switch {
+	case number > current:
+		return domain.StatusArchived
+	case published != nil && number == *published:
+		return domain.StatusPublished
+	}
+	return status
+}
+
+func (s *service) restorePage(ctx context.Context, before *pageState, actor uuid.UUID) error {
+	if before.Page == nil {
+		return errors.New("promotions: page state missing")
+	}
+	page := before.Page
+	if err := s.pages.ReplaceTranslations(ctx, page.ID, page.Translations); err != nil {
+		return err
+	}
+	if _, err := s.pages.Update(ctx, page); err != nil {
+		return err
+	}
+	versions, err := s.pages.ListVersions(ctx, page.ID)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		status := restoredVersionStatus(version.Version, version.Status, page.CurrentVersion, page.PublishedVersion)
+		if status == version.Status {
+			continue
+		}
+		version.Status = status
+		if _, err := s.pages.UpdateVersion(ctx, version); err != nil {
+			return err
+		}
+	}
+	if s.blocks == nil {
+		return nil
+	}
+	allWidgets, err := s.listWidgetInstances(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.clearPageBlocks(ctx, page.ID, allWidgets, actor); err != nil {
+		return err
+	}
+	return s.restorePageBlocks(ctx, before, actor)
+}
+
+// restorePageBlocks recreates the captured block and widget instances under
+// their original IDs, so page version snapshots keep resolving to them.
+func (s *service) restorePageBlocks(ctx context.Context, before *pageState, actor uuid.UUID) error {
+	for _, inst := range before.Blocks {
+		if inst == nil || inst.DeletedAt != nil {
+			continue
+		}
+		record := *inst
+		record.Versions = nil
+		if _, err := s.blocks.RestoreInstanceSnapshot(ctx, blocks.RestoreInstanceSnapshotRequest{
+			Instance:   &record,
+			Versions:   inst.Versions,
+			RestoredBy: actor,
+		}); err != nil {
+			return err
+		}
+	}
+	if s.widgets == nil {
+		return nil
+	}
+	for _, widget := range before.Widgets {
+		if widget == nil {
+			continue
+		}
+		if _, err := s.widgets.RestoreInstanceSnapshot(ctx, widgets.RestoreInstanceSnapshotRequest{
+			Instance:   widget,
+			Versions:   before.WidgetVersions[widget.ID],
+			RestoredBy: actor,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) restoreMenu(ctx context.Context, before *menuState, actor uuid.UUID) error {
+	if before.Menu == nil {
+		return errors.New("promotions: menu state missing")
+	}
+	menu := before.Menu
+	env, err := s.resolveEnvironmentByID(ctx, menu.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	restored, err := s.menus.UpsertMenu(ctx, menus.UpsertMenuInput{
+		Code:           menu.Code,
+		Location:       menu.Location,
+		Description:    cloneString(menu.Description),
+		Status:         menu.Status,
+		Locale:         cloneString(menu.Locale),
+		Actor:          actor,
+		EnvironmentKey: env.Key,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.menus.ResetMenuByCode(ctx, menu.Code, actor, true, env.Key); err != nil {
+		return err
+	}
+	if err := s.writeMenuItems(ctx, restored.ID, env.Key, before.Items, nil, actor); err != nil {
+		return err
+	}
+	if err := s.deleteMenuBindings(ctx, env.ID.String(), menu.Code); err != nil {
+		return err
+	}
+	for _, binding := range before.Bindings {
+		if _, err := s.menus.UpsertMenuLocationBinding(ctx, menus.UpsertMenuLocationBindingInput{
+			Location:        binding.Location,
+			MenuCode:        restored.Code,
+			ViewProfileCode: cloneString(binding.ViewProfileCode),
+			Locale:          cloneString(binding.Locale),
+			Priority:        binding.Priority,
+			Status:          binding.Status,
+			Actor:           actor,
+			EnvironmentKey:  env.Key,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreWidgetArea replaces the widgets the environment places in the area
+// with the captured instances, keeping their IDs, and appends their placements
+// in the captured order.
+func (s *service) restoreWidgetArea(ctx context.Context, before *widgetAreaState, actor uuid.UUID) error {
+	env, err := s.resolveEnvironmentByID(ctx, before.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	areas, err := s.loadAreaWidgets(ctx, env)
+	if err != nil {
+		return err
+	}
+	if current := areas[before.AreaCode]; current != nil {
+		for _, widget := range current.instances {
+			if err := s.widgets.DeleteInstance(ctx, widgets.DeleteInstanceRequest{InstanceID: widget.ID, DeletedBy: actor, HardDelete: true}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, widget := range before.Widgets {
+		if widget == nil {
+			continue
+		}
+		if _, err := s.widgets.RestoreInstanceSnapshot(ctx, widgets.RestoreInstanceSnapshotRequest{
+			Instance:   widget,
+			Versions:   before.Versions[widget.ID],
+			RestoredBy: actor,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, placement := range before.Placements {
+		if placement == nil {
+			continue
+		}
+		if _, err := s.widgets.AssignWidgetToArea(ctx, widgets.AssignWidgetToAreaInput{
+			AreaCode:   placement.AreaCode,
+			LocaleID:   cloneUUIDPtr(placement.LocaleID),
+			InstanceID: placement.InstanceID,
+			Metadata:   cloneMap(placement.Metadata),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRecord removes a target record created by a promotion batch.
+func (s *service) deleteRecord(ctx context.Context, record PromotionRecord, actor uuid.UUID) error {
+	switch record.Kind {
+	case KindContentType:
+		return s.contentTypes.Delete(ctx, record.TargetID, true)
+	case KindContentEntry:
+		return s.contents.Delete(ctx, record.TargetID, true)
+	case KindBlockDefinition:
+		return s.blocks.DeleteDefinition(ctx, blocks.DeleteDefinitionRequest{ID: record.TargetID, HardDelete: true})
+	case KindPage:
+		if s.blocks != nil {
+			allWidgets, err := s.listWidgetInstances(ctx)
+			if err != nil {
+				return err
+			}
+			if err := s.clearPageBlocks(ctx, record.TargetID, allWidgets, actor); err != nil {
+				return err
+			}
+		}
+		return s.pages.Delete(ctx, record.TargetID, true)
+	case KindMenu:
+		menu, err := s.menuRecords.GetByID(ctx, record.TargetID)
+		if err != nil {
+			return err
+		}
+		if err := s.deleteMenuBindings(ctx, menu.EnvironmentID.String(), menu.Code); err != nil {
+			return err
+		}
+		return s.menus.DeleteMenu(ctx, menus.DeleteMenuRequest{MenuID: menu.ID, DeletedBy: actor, Force: true})
+	default:
+		return fmt.Errorf("promotions: unsupported record kind %s", record.Kind)
+	}
+}
+
+func (s *service) deleteMenuBindings(ctx context.Context, envID, code string) error {
+	bindings, err := s.menuCodeBindings(ctx, envID, code)
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if err := s.menuBindings.Delete(ctx, binding.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) listWidgetInstances(ctx context.Context) ([]*widgets.Instance, error) {
+	if s.widgets == nil {
+		return nil, nil
+	}
+	return s.widgets.ListAllInstances(ctx)
+}
+
+func stateChecksum(state json.RawMessage) string {
+	sum := sha256.Sum256(state)
+	return hex.EncodeToString(sum[:])
+}
+
+func isRecordNotFound(err error) bool {
+	if isContentNotFound(err) || isPageNotFound(err) {
+		return true
+	}
+	var blockNF *blocks.NotFoundError
+	if errors.As(err, &blockNF) {
+		return true
+	}
+	var widgetNF *widgets.NotFoundError
+	if errors.As(err, &widgetNF) {
+		return true
+	}
+	var menuNF *menus.NotFoundError
+	return errors.As(err, &menuNF) || errors.Is(err, menus.ErrMenuNotFound)
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/goliatone/go-cms/internal/content"
 	"github.com/goliatone/go-cms/internal/domain"
 	cmsenv "github.com/goliatone/go-cms/internal/environments"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/pages"
 	"github.com/goliatone/go-cms/internal/schema"
 	"github.com/goliatone/go-cms/internal/validation"
+	"github.com/goliatone/go-cms/internal/widgets"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/google/uuid"
 )
@@ -31,6 +34,62 @@ func WithBlockService(svc blocks.Service) ServiceOption {
 	}
 }
 
+// WithWidgetService wires the widget service used to copy widget instances
+// bound to promoted blocks and to promote widget areas.
+func WithWidgetService(svc widgets.Service) ServiceOption {
+	return func(s *service) {
+		if svc != nil {
+			s.widgets = svc
+		}
+	}
+}
+
+// WithPageRepository wires the page repository used for page comparisons and promotions.
+func WithPageRepository(repo pages.PageRepository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.pages = repo
+		}
+	}
+}
+
+// WithMenuService wires the menu service used to load and write menu items.
+func WithMenuService(svc menus.Service) ServiceOption {
+	return func(s *service) {
+		if svc != nil {
+			s.menus = svc
+		}
+	}
+}
+
+// WithMenuRepository wires the menu repository used to enumerate menus per environment.
+func WithMenuRepository(repo menus.MenuRepository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.menuRecords = repo
+		}
+	}
+}
+
+// WithMenuLocationBindingRepository wires the repository used to read menu location bindings.
+func WithMenuLocationBindingRepository(repo menus.MenuLocationBindingRepository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.menuBindings = repo
+		}
+	}
+}
+
+// WithBatchRepository enables promotion history and rollbacks by persisting
+// every promotion run as a batch.
+func WithBatchRepository(repo BatchRepository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.batches = repo
+		}
+	}
+}
+
 // WithSchemaMigrator wires the schema migrator used for content promotions.
 func WithSchemaMigrator(migrator *schema.Migrator) ServiceOption {
 	return func(s *service) {
@@ -118,6 +177,12 @@ type service struct {
 	contents       content.ContentRepository
 	locales        content.LocaleRepository
 	blocks         blocks.Service
+	widgets        widgets.Service
+	pages          pages.PageRepository
+	menus          menus.Service
+	menuRecords    menus.MenuRepository
+	menuBindings   menus.MenuLocationBindingRepository
+	batches        BatchRepository
 	schemaMigrator *schema.Migrator
 	embeddedBlocks content.EmbeddedBlocksResolver
 	activity       *activity.Emitter
@@ -142,6 +207,9 @@ func (s *service) PromoteEnvironment(ctx context.Context, req PromoteEnvironment
 	if scope == "" {
 		scope = ScopeAll
 	}
+	if req.Options.DryRun {
+		ctx = withPromotionPlan(ctx)
+	}
 
 	result := &PromoteEnvironmentResult{
 		SourceEnv: EnvironmentRef{ID: source.ID, Key: source.Key},
@@ -149,10 +217,58 @@ func (s *service) PromoteEnvironment(ctx context.Context, req PromoteEnvironment
 		Summary:   PromoteSummary{},
 	}
 
+	ctx, journal := s.beginJournal(ctx, req.Options)
+	if journal != nil {
+		journal.source = source
+		journal.target = target
+	}
+	runErr := s.promoteEnvironmentScopes(ctx, req, scope, source, target, result)
+	if journal != nil {
+		batch, err := s.saveBatch(ctx, journal, scope, req.Options, result, runErr)
+		if runErr != nil {
+			return nil, runErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("promotions: record promotion batch: %w", err)
+		}
+		result.BatchID = &batch.ID
+	}
+	if runErr != nil {
+		return nil, runErr
+	}
+	return result, nil
+}
+
+// promoteEnvironmentScopes promotes each entity kind selected by scope into
+// target, collecting per-item results and failures on result.
+func (s *service) promoteEnvironmentScopes(ctx context.Context, req PromoteEnvironmentRequest, scope PromoteScope, source, target *cmsenv.Environment, result *PromoteEnvironmentResult) error {
+	if scope == ScopeBlockDefinitions || (scope == ScopeAll && s.blocks != nil) {
+		ids, err := s.collectBlockDefinitionIDs(ctx, source.Key, req.BlockDefinitionSlugs)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			item, err := s.PromoteBlockDefinition(ctx, PromoteBlockDefinitionRequest{
+				DefinitionID:      id,
+				TargetEnvironment: target.Key,
+				Options:           req.Options,
+			})
+			if err != nil {
+				result.Errors = append(result.Errors, PromoteError{Kind: KindBlockDefinition, SourceID: id, Error: err.Error()})
+				result.Summary.BlockDefinitions.Failed++
+				continue
+			}
+			if item != nil {
+				result.Items = append(result.Items, *item)
+				updateSummary(&result.Summary.BlockDefinitions, item.Status)
+			}
+		}
+	}
+
 	if scope == ScopeContentTypes || scope == ScopeAll {
 		ids, err := s.collectContentTypeIDs(ctx, source.ID.String(), req.ContentTypeIDs, req.ContentTypeSlugs)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, id := range ids {
 			item, err := s.PromoteContentType(ctx, PromoteContentTypeRequest{
@@ -161,7 +277,7 @@ func (s *service) PromoteEnvironment(ctx context.Context, req PromoteEnvironment
 				Options:           req.Options,
 			})
 			if err != nil {
-				result.Errors = append(result.Errors, PromoteError{Kind: "content_type", SourceID: id, Error: err.Error()})
+				result.Errors = append(result.Errors, PromoteError{Kind: KindContentType, SourceID: id, Error: err.Error()})
 				result.Summary.ContentTypes.Failed++
 				continue
 			}
@@ -175,11 +291,11 @@ func (s *service) PromoteEnvironment(ctx context.Context, req PromoteEnvironment
 	if scope == ScopeContentEntries || scope == ScopeAll {
 		filter, err := s.resolveContentEntryTypeFilter(ctx, source, req)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		ids, err := s.collectContentEntryIDs(ctx, source.ID.String(), req.ContentIDs, req.ContentSlugs, filter)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, id := range ids {
 			item, err := s.PromoteContentEntry(ctx, PromoteContentEntryRequest{
@@ -188,7 +304,7 @@ func (s *service) PromoteEnvironment(ctx context.Context, req PromoteEnvironment
 				Options:           req.Options,
 			})
 			if err != nil {
-				result.Errors = append(result.Errors, PromoteError{Kind: "content_entry", SourceID: id, Error: err.Error()})
+				result.Errors = append(result.Errors, PromoteError{Kind: KindContentEntry, SourceID: id, Error: err.Error()})
 				result.Summary.ContentEntries.Failed++
 				continue
 			}
@@ -199,10 +315,92 @@ func (s *service) PromoteEnvironment(ctx context.Context, req PromoteEnvironment
 		}
 	}
 
-	return result, nil
+	if scope == ScopePages || (scope == ScopeAll && s.pages != nil) {
+		ids, err := s.collectPageIDs(ctx, source.ID.String(), req.PageIDs, req.PageSlugs)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			item, err := s.PromotePage(ctx, PromotePageRequest{
+				PageID:            id,
+				TargetEnvironment: target.Key,
+				Options:           req.Options,
+			})
+			if err != nil {
+				result.Errors = append(result.Errors, PromoteError{Kind: KindPage, SourceID: id, Error: err.Error()})
+				result.Summary.Pages.Failed++
+				continue
+			}
+			if item != nil {
+				result.Items = append(result.Items, *item)
+				updateSummary(&result.Summary.Pages, item.Status)
+				result.Summary.BlockInstances.Created += detailCount(item.Details, "block_instances")
+				result.Summary.WidgetInstances.Created += detailCount(item.Details, "widget_instances")
+			}
+		}
+	}
+
+	if scope == ScopeMenus || (scope == ScopeAll && s.menus != nil && s.menuRecords != nil) {
+		ids, err := s.collectMenuIDs(ctx, source.ID.String(), req.MenuCodes)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			item, err := s.PromoteMenu(ctx, PromoteMenuRequest{
+				MenuID:            id,
+				TargetEnvironment: target.Key,
+				Options:           req.Options,
+			})
+			if err != nil {
+				result.Errors = append(result.Errors, PromoteError{Kind: KindMenu, SourceID: id, Error: err.Error()})
+				result.Summary.Menus.Failed++
+				continue
+			}
+			if item != nil {
+				result.Items = append(result.Items, *item)
+				updateSummary(&result.Summary.Menus, item.Status)
+			}
+		}
+	}
+
+	if scope == ScopeWidgets || (scope == ScopeAll && s.widgets != nil) {
+		codes, err := s.collectWidgetAreaCodes(ctx, source, req.WidgetAreaCodes)
+		if err != nil {
+			if scope == ScopeAll && (errors.Is(err, widgets.ErrFeatureDisabled) || errors.Is(err, widgets.ErrAreaFeatureDisabled)) {
+				return nil
+			}
+			return err
+		}
+		for _, code := range codes {
+			item, err := s.PromoteWidgetArea(ctx, PromoteWidgetAreaRequest{
+				AreaCode:          code,
+				SourceEnvironment: source.Key,
+				TargetEnvironment: target.Key,
+				Options:           req.Options,
+			})
+			if err != nil {
+				result.Errors = append(result.Errors, PromoteError{Kind: KindWidgetArea, Error: err.Error(), Details: map[string]any{"code": code}})
+				result.Summary.WidgetAreas.Failed++
+				continue
+			}
+			if item != nil {
+				result.Items = append(result.Items, *item)
+				updateSummary(&result.Summary.WidgetAreas, item.Status)
+				result.Summary.WidgetInstances.Created += detailCount(item.Details, "widget_instances")
+			}
+		}
+	}
+
+	return nil
 }
 
 func (s *service) PromoteContentType(ctx context.Context, req PromoteContentTypeRequest) (*PromoteItem, error) {
+	return s.recordSinglePromotion(ctx, KindContentType, req.ContentTypeID, req.Options, func(ctx context.Context) (*PromoteItem, error) {
+		return s.promoteContentType(ctx, req)
+	})
+}
+
+func (s *service) promoteContentType(ctx context.Context, req PromoteContentTypeRequest) (*PromoteItem, error) {
 	if s == nil || s.contentTypes == nil {
 		return nil, errors.New("promotions: content type promotion unavailable")
 	}
@@ -236,7 +434,7 @@ func (s *service) PromoteContentType(ctx context.Context, req PromoteContentType
 		return nil, err
 	}
 
-	if err := s.promoteBlockDefinitions(ctx, sourceSchema, sourceEnv, targetEnv, opts.DryRun); err != nil {
+	if err := s.promoteBlockDefinitions(ctx, sourceSchema, promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}); err != nil {
 		return nil, err
 	}
 
@@ -270,6 +468,7 @@ func (s *service) PromoteContentType(ctx context.Context, req PromoteContentType
 		if err != nil {
 			return nil, err
 		}
+		s.journalCreate(ctx, promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}, KindContentType, source.ID, created.ID)
 		s.emitPromotionActivity(ctx, "content_type", "promote", source.ID, created.ID, sourceEnv, targetEnv, opts)
 		return buildContentTypeItem("created", source.ID, created.ID, sourceVersion, false), nil
 	}
@@ -301,6 +500,9 @@ func (s *service) PromoteContentType(ctx context.Context, req PromoteContentType
 		return buildContentTypeItem("updated", source.ID, target.ID, sourceVersion, true), nil
 	}
 
+	if err := s.journalUpdate(ctx, promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}, KindContentType, source.ID, target.ID); err != nil {
+		return nil, err
+	}
 	saved, err := s.contentTypes.Update(ctx, &updated)
 	if err != nil {
 		return nil, err
@@ -310,6 +512,12 @@ func (s *service) PromoteContentType(ctx context.Context, req PromoteContentType
 }
 
 func (s *service) PromoteContentEntry(ctx context.Context, req PromoteContentEntryRequest) (*PromoteItem, error) {
+	return s.recordSinglePromotion(ctx, KindContentEntry, req.ContentID, req.Options, func(ctx context.Context) (*PromoteItem, error) {
+		return s.promoteContentEntry(ctx, req)
+	})
+}
+
+func (s *service) promoteContentEntry(ctx context.Context, req PromoteContentEntryRequest) (*PromoteItem, error) {
 	if s == nil || s.contents == nil || s.contentTypes == nil {
 		return nil, errors.New("promotions: content promotion unavailable")
 	}
@@ -402,6 +610,7 @@ func (s *service) PromoteContentEntry(ctx context.Context, req PromoteContentEnt
 			ID:            s.id(),
 			ContentTypeID: targetType.ID,
 			EnvironmentID: targetEnv.ID,
+			SiteID:        cloneUUIDPtr(sourceContent.SiteID),
 			Status:        string(domain.StatusDraft),
 			Slug:          sourceContent.Slug,
 			CreatedBy:     actor,
@@ -417,9 +626,11 @@ func (s *service) PromoteContentEntry(ctx context.Context, req PromoteContentEnt
 	}
 
 	if opts.DryRun {
+		recordPlannedTarget(ctx, KindContentEntry, contentEntryMatchKey(sourceType.Slug, sourceContent.Slug), targetContent.ID)
 		return s.finalizeContentPromotion(ctx, sourceContent, targetContent, sourceVersion, snapshot, opts, created, sourceEnv, targetEnv, true)
 	}
 
+	scope := promotionTarget{source: sourceEnv, target: targetEnv, opts: opts}
 	if created {
 		targetContent.Translations = translations
 		createdRecord, err := s.contents.Create(ctx, targetContent)
@@ -427,7 +638,11 @@ func (s *service) PromoteContentEntry(ctx context.Context, req PromoteContentEnt
 			return nil, err
 		}
 		targetContent = createdRecord
+		s.journalCreate(ctx, scope, KindContentEntry, sourceContent.ID, targetContent.ID)
 	} else {
+		if err := s.journalUpdate(ctx, scope, KindContentEntry, sourceContent.ID, targetContent.ID); err != nil {
+			return nil, err
+		}
 		if err := s.contents.ReplaceTranslations(ctx, targetContent.ID, translations); err != nil {
 			return nil, err
 		}
@@ -679,7 +894,7 @@ func (s *service) buildTranslations(ctx context.Context, contentID uuid.UUID, sn
 	return out, nil
 }
 
-func (s *service) promoteBlockDefinitions(ctx context.Context, schemaPayload map[string]any, source *cmsenv.Environment, target *cmsenv.Environment, dryRun bool) error {
+func (s *service) promoteBlockDefinitions(ctx context.Context, schemaPayload map[string]any, scope promotionTarget) error {
 	slugs := extractBlockSlugs(schemaPayload)
 	if len(slugs) == 0 {
 		return nil
@@ -687,28 +902,16 @@ func (s *service) promoteBlockDefinitions(ctx context.Context, schemaPayload map
 	if s.blocks == nil {
 		return fmt.Errorf("promotions: block service required")
 	}
-	sourceDefs, err := s.blocks.ListDefinitions(ctx, source.Key)
+	sourceDefs, err := s.blocks.ListDefinitions(ctx, scope.source.Key)
 	if err != nil {
 		return err
 	}
-	targetDefs, err := s.blocks.ListDefinitions(ctx, target.Key)
+	targetDefs, err := s.blocks.ListDefinitions(ctx, scope.target.Key)
 	if err != nil {
 		return err
 	}
-	sourceIndex := map[string]*blocks.Definition{}
-	for _, def := range sourceDefs {
-		if def == nil {
-			continue
-		}
-		sourceIndex[strings.ToLower(strings.TrimSpace(def.Slug))] = def
-	}
-	targetIndex := map[string]*blocks.Definition{}
-	for _, def := range targetDefs {
-		if def == nil {
-			continue
-		}
-		targetIndex[strings.ToLower(strings.TrimSpace(def.Slug))] = def
-	}
+	sourceIndex := blockDefinitionIndex(sourceDefs)
+	targetIndex := blockDefinitionIndex(targetDefs)
 	for _, slug := range slugs {
 		key := strings.ToLower(strings.TrimSpace(slug))
 		if key == "" {
@@ -718,65 +921,76 @@ func (s *service) promoteBlockDefinitions(ctx context.Context, schemaPayload map
 		if sourceDef == nil {
 			return fmt.Errorf("promotion: block definition %s not found", slug)
 		}
-		preparedSchema, _, err := normalizeDefinitionSchema(sourceDef)
-		if err != nil {
+		if _, _, err := s.promoteBlockDefinition(ctx, sourceDef, targetIndex[key], scope); err != nil {
 			return err
 		}
-		if existing := targetIndex[key]; existing != nil {
-			if dryRun {
-				continue
-			}
-			name := sourceDef.Name
-			slugValue := sourceDef.Slug
-			desc := cloneString(sourceDef.Description)
-			icon := cloneString(sourceDef.Icon)
-			cat := cloneString(sourceDef.Category)
-			status := sourceDef.Status
-			uiSchema := cloneMap(sourceDef.UISchema)
-			defaults := cloneMap(sourceDef.Defaults)
-			editorURL := cloneString(sourceDef.EditorStyleURL)
-			frontendURL := cloneString(sourceDef.FrontendStyleURL)
-			_, err := s.blocks.UpdateDefinition(ctx, blocks.UpdateDefinitionInput{
-				ID:               existing.ID,
-				Name:             &name,
-				Slug:             &slugValue,
-				Description:      desc,
-				Icon:             icon,
-				Category:         cat,
-				Status:           &status,
-				Schema:           preparedSchema,
-				UISchema:         uiSchema,
-				Defaults:         defaults,
-				EditorStyleURL:   editorURL,
-				FrontendStyleURL: frontendURL,
-			})
-			if err != nil {
-				return err
-			}
-			continue
-		}
+	}
+	return nil
+}
+
+// promoteBlockDefinition registers or updates sourceDef in the target
+// environment and returns the target definition with the promotion status.
+func (s *service) promoteBlockDefinition(ctx context.Context, sourceDef *blocks.Definition, existing *blocks.Definition, scope promotionTarget) (*blocks.Definition, string, error) {
+	preparedSchema, _, err := normalizeDefinitionSchema(sourceDef)
+	if err != nil {
+		return nil, "", err
+	}
+	target := scope.target
+	dryRun := scope.opts.DryRun
+	if existing != nil {
 		if dryRun {
-			continue
+			return existing, "updated", nil
+		}
+		if err := s.journalUpdate(ctx, scope, KindBlockDefinition, sourceDef.ID, existing.ID); err != nil {
+			return nil, "", err
 		}
-		_, err = s.blocks.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
-			Name:             sourceDef.Name,
-			Slug:             sourceDef.Slug,
+		name := sourceDef.Name
+		slugValue := sourceDef.Slug
+		status := sourceDef.Status
+		updated, err := s.blocks.UpdateDefinition(ctx, blocks.UpdateDefinitionInput{
+			ID:               existing.ID,
+			Name:             &name,
+			Slug:             &slugValue,
 			Description:      cloneString(sourceDef.Description),
 			Icon:             cloneString(sourceDef.Icon),
 			Category:         cloneString(sourceDef.Category),
-			Status:           sourceDef.Status,
+			Status:           &status,
 			Schema:           preparedSchema,
 			UISchema:         cloneMap(sourceDef.UISchema),
 			Defaults:         cloneMap(sourceDef.Defaults),
 			EditorStyleURL:   cloneString(sourceDef.EditorStyleURL),
 			FrontendStyleURL: cloneString(sourceDef.FrontendStyleURL),
-			EnvironmentKey:   target.Key,
 		})
 		if err != nil {
-			return err
+			return nil, "", err
 		}
+		return updated, "updated", nil
 	}
-	return nil
+	if dryRun {
+		planned := *sourceDef
+		planned.ID = s.id()
+		planned.EnvironmentID = target.ID
+		return &planned, "created", nil
+	}
+	created, err := s.blocks.RegisterDefinition(ctx, blocks.RegisterDefinitionInput{
+		Name:             sourceDef.Name,
+		Slug:             sourceDef.Slug,
+		Description:      cloneString(sourceDef.Description),
+		Icon:             cloneString(sourceDef.Icon),
+		Category:         cloneString(sourceDef.Category),
+		Status:           sourceDef.Status,
+		Schema:           preparedSchema,
+		UISchema:         cloneMap(sourceDef.UISchema),
+		Defaults:         cloneMap(sourceDef.Defaults),
+		EditorStyleURL:   cloneString(sourceDef.EditorStyleURL),
+		FrontendStyleURL: cloneString(sourceDef.FrontendStyleURL),
+		EnvironmentKey:   target.Key,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	s.journalCreate(ctx, scope, KindBlockDefinition, sourceDef.ID, created.ID)
+	return created, "created", nil
 }
 
 func (s *service) resolveEnvironment(ctx context.Context, key string, id *uuid.UUID) (*cmsenv.Environment, error) {
@@ -1119,10 +1333,22 @@ func newSchemaSnapshot(source *content.ContentType, version string, schemaPayloa
 		UISchema:     cloneMap(source.UISchema),
 		Capabilities: cloneMap(source.Capabilities),
 		Status:       source.Status,
+		Migration:    sourceSchemaMigration(source.SchemaHistory, version),
 		UpdatedAt:    now,
 	}
 }
 
+// sourceSchemaMigration returns the migration operations recorded in the
+// source history for version so promoted schemas keep them.
+func sourceSchemaMigration(history []content.ContentTypeSchemaSnapshot, version string) []content.SchemaMigrationOperation {
+	for idx := len(history) - 1; idx >= 0; idx-- {
+		if history[idx].Version == version {
+			return schema.CloneMigrationOperations(history[idx].Migration)
+		}
+	}
+	return nil
+}
+
 func appendSchemaHistory(history []content.ContentTypeSchemaSnapshot, snapshot content.ContentTypeSchemaSnapshot) []content.ContentTypeSchemaSnapshot {
 	if snapshot.Version == "" {
 		return history
@@ -1150,6 +1376,7 @@ func cloneSchemaHistory(history []content.ContentTypeSchemaSnapshot) []content.C
 			UISchema:     cloneMap(snapshot.UISchema),
 			Capabilities: cloneMap(snapshot.Capabilities),
 			Status:       snapshot.Status,
+			Migration:    schema.CloneMigrationOperations(snapshot.Migration),
 			UpdatedAt:    snapshot.UpdatedAt,
 		}
 		if snapshot.UpdatedBy != nil {
@@ -2,25 +2,49 @@ package promotions
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Entity kinds reported by promotions and environment comparisons.
+const (
+	KindContentType     = "content_type"
+	KindContentEntry    = "content_entry"
+	KindPage            = "page"
+	KindMenu            = "menu"
+	KindBlockDefinition = "block_definition"
+	KindWidgetArea      = "widget_area"
+)
+
 // Service defines promotion orchestration across environments.
 type Service interface {
 	PromoteEnvironment(ctx context.Context, req PromoteEnvironmentRequest) (*PromoteEnvironmentResult, error)
 	PromoteContentType(ctx context.Context, req PromoteContentTypeRequest) (*PromoteItem, error)
 	PromoteContentEntry(ctx context.Context, req PromoteContentEntryRequest) (*PromoteItem, error)
+	PromoteBlockDefinition(ctx context.Context, req PromoteBlockDefinitionRequest) (*PromoteItem, error)
+	PromotePage(ctx context.Context, req PromotePageRequest) (*PromoteItem, error)
+	PromoteMenu(ctx context.Context, req PromoteMenuRequest) (*PromoteItem, error)
+	PromoteWidgetArea(ctx context.Context, req PromoteWidgetAreaRequest) (*PromoteItem, error)
+	CompareEnvironments(ctx context.Context, req CompareEnvironmentsRequest) (*EnvironmentComparison, error)
+	ListPromotions(ctx context.Context, req ListPromotionsRequest) ([]*PromotionBatch, error)
+	GetPromotion(ctx context.Context, batchID uuid.UUID) (*PromotionBatch, error)
+	RollbackPromotion(ctx context.Context, batchID uuid.UUID, opts RollbackOptions) (*RollbackResult, error)
 }
 
 // PromoteScope describes the scope for bulk promotions.
 type PromoteScope string
 
 const (
-	ScopeContentTypes   PromoteScope = "content_types"
-	ScopeContentEntries PromoteScope = "content_entries"
-	ScopeAll            PromoteScope = "all"
+	ScopeContentTypes     PromoteScope = "content_types"
+	ScopeContentEntries   PromoteScope = "content_entries"
+	ScopeBlockDefinitions PromoteScope = "block_definitions"
+	ScopePages            PromoteScope = "pages"
+	ScopeMenus            PromoteScope = "menus"
+	ScopeWidgets          PromoteScope = "widgets"
+	ScopeAll              PromoteScope = "all"
 )
 
 // PromoteMode controls how conflicts are handled.
@@ -35,6 +59,12 @@ var (
 	ErrContentTypeRequiredForSlugs = errors.New("promotions: content type required for content slugs")
 	ErrContentTypeEnvMismatch      = errors.New("promotions: content type does not belong to source environment")
 	ErrContentTypeFilterMismatch   = errors.New("promotions: content type id and slug do not match")
+	ErrDependencyMissing           = errors.New("promotions: dependency missing in target environment")
+	ErrPromotionHistoryUnavailable = errors.New("promotions: promotion history unavailable")
+	ErrPromotionNotFound           = errors.New("promotions: promotion batch not found")
+	ErrPromotionRolledBack         = errors.New("promotions: promotion batch already rolled back")
+	ErrPromotionTargetChanged      = errors.New("promotions: target changed since promotion")
+	ErrWidgetAreaNotEmpty          = errors.New("promotions: widget area already has widgets in target environment")
 )
 
 // PromoteOptions captures common promotion flags.
@@ -48,8 +78,13 @@ type PromoteOptions struct {
 	IncludeVersions      bool        `json:"include_versions,omitempty"`
 	MigrateOnPromote     *bool       `json:"migrate_on_promote,omitempty"`
 	AutoPromoteType      bool        `json:"auto_promote_type,omitempty"`
-	Force                bool        `json:"force,omitempty"`
-	DryRun               bool        `json:"dry_run,omitempty"`
+	// AutoPromoteDependencies promotes missing content entries, parent pages, block
+	// definitions and menu target pages instead of failing with ErrDependencyMissing.
+	AutoPromoteDependencies bool `json:"auto_promote_dependencies,omitempty"`
+	Force                   bool `json:"force,omitempty"`
+	DryRun                  bool `json:"dry_run,omitempty"`
+	// ActorID records who ran the promotion in the batch history.
+	ActorID uuid.UUID `json:"actor_id,omitempty"`
 }
 
 // PromoteEnvironmentRequest describes a bulk environment promotion request.
@@ -64,6 +99,11 @@ type PromoteEnvironmentRequest struct {
 	ContentSlugs         []string       `json:"content_slugs,omitempty"`
 	ContentEntryTypeID   *uuid.UUID     `json:"content_entry_type_id,omitempty"`
 	ContentEntryTypeSlug string         `json:"content_entry_type_slug,omitempty"`
+	BlockDefinitionSlugs []string       `json:"block_definition_slugs,omitempty"`
+	PageIDs              []uuid.UUID    `json:"page_ids,omitempty"`
+	PageSlugs            []string       `json:"page_slugs,omitempty"`
+	MenuCodes            []string       `json:"menu_codes,omitempty"`
+	WidgetAreaCodes      []string       `json:"widget_area_codes,omitempty"`
 	Options              PromoteOptions `json:"options"`
 }
 
@@ -83,6 +123,43 @@ type PromoteContentEntryRequest struct {
 	Options             PromoteOptions `json:"options"`
 }
 
+// PromoteBlockDefinitionRequest describes a single block definition promotion.
+type PromoteBlockDefinitionRequest struct {
+	DefinitionID        uuid.UUID      `json:"-"`
+	TargetEnvironment   string         `json:"target_environment,omitempty"`
+	TargetEnvironmentID *uuid.UUID     `json:"target_environment_id,omitempty"`
+	Options             PromoteOptions `json:"options"`
+}
+
+// PromotePageRequest describes a single page promotion, including its block
+// instances and the widget instances bound to them.
+type PromotePageRequest struct {
+	PageID              uuid.UUID      `json:"-"`
+	TargetEnvironment   string         `json:"target_environment,omitempty"`
+	TargetEnvironmentID *uuid.UUID     `json:"target_environment_id,omitempty"`
+	Options             PromoteOptions `json:"options"`
+}
+
+// PromoteMenuRequest describes a single menu promotion, including its items and
+// location bindings.
+type PromoteMenuRequest struct {
+	MenuID              uuid.UUID      `json:"-"`
+	TargetEnvironment   string         `json:"target_environment,omitempty"`
+	TargetEnvironmentID *uuid.UUID     `json:"target_environment_id,omitempty"`
+	Options             PromoteOptions `json:"options"`
+}
+
+// PromoteWidgetAreaRequest describes the promotion of the widgets placed in
+// one area, including their translations and area placements. Widgets bound
+// to block instances travel with their page instead.
+type PromoteWidgetAreaRequest struct {
+	AreaCode            string         `json:"-"`
+	SourceEnvironment   string         `json:"source_environment,omitempty"`
+	TargetEnvironment   string         `json:"target_environment,omitempty"`
+	TargetEnvironmentID *uuid.UUID     `json:"target_environment_id,omitempty"`
+	Options             PromoteOptions `json:"options"`
+}
+
 // EnvironmentRef references an environment in promotion responses.
 type EnvironmentRef struct {
 	ID  uuid.UUID `json:"id"`
@@ -99,8 +176,14 @@ type PromoteSummaryCounts struct {
 
 // PromoteSummary aggregates counts for bulk promotions.
 type PromoteSummary struct {
-	ContentTypes   PromoteSummaryCounts `json:"content_types"`
-	ContentEntries PromoteSummaryCounts `json:"content_entries"`
+	ContentTypes     PromoteSummaryCounts `json:"content_types"`
+	ContentEntries   PromoteSummaryCounts `json:"content_entries"`
+	BlockDefinitions PromoteSummaryCounts `json:"block_definitions"`
+	Pages            PromoteSummaryCounts `json:"pages"`
+	Menus            PromoteSummaryCounts `json:"menus"`
+	WidgetAreas      PromoteSummaryCounts `json:"widget_areas"`
+	BlockInstances   PromoteSummaryCounts `json:"block_instances"`
+	WidgetInstances  PromoteSummaryCounts `json:"widget_instances"`
 }
 
 // PromoteItem reports a single promoted entity.
@@ -111,6 +194,8 @@ type PromoteItem struct {
 	Status   string         `json:"status"`
 	Message  string         `json:"message,omitempty"`
 	Details  map[string]any `json:"details,omitempty"`
+	// BatchID references the recorded promotion batch for single-record promotions.
+	BatchID *uuid.UUID `json:"batch_id,omitempty"`
 }
 
 // PromoteError describes a promotion failure.
@@ -128,4 +213,173 @@ type PromoteEnvironmentResult struct {
 	Summary   PromoteSummary `json:"summary"`
 	Items     []PromoteItem  `json:"items,omitempty"`
 	Errors    []PromoteError `json:"errors,omitempty"`
+	BatchID   *uuid.UUID     `json:"batch_id,omitempty"`
+}
+
+// BatchStatus describes the outcome of a recorded promotion batch.
+type BatchStatus string
+
+const (
+	BatchCompleted  BatchStatus = "completed"
+	BatchPartial    BatchStatus = "partial"
+	BatchFailed     BatchStatus = "failed"
+	BatchRolledBack BatchStatus = "rolled_back"
+	// BatchRollbackPartial marks a batch whose rollback stopped on an error.
+	// Records already reverted carry RolledBack and are skipped on retry.
+	BatchRollbackPartial BatchStatus = "rollback_partial"
+)
+
+// Record actions captured in promotion batches.
+const (
+	RecordCreated = "created"
+	RecordUpdated = "updated"
+)
+
+// PromotionBatch captures a persisted promotion run together with the state
+// needed to roll it back.
+type PromotionBatch struct {
+	ID           uuid.UUID         `json:"id"`
+	SourceEnv    EnvironmentRef    `json:"source_env"`
+	TargetEnv    EnvironmentRef    `json:"target_env"`
+	Scope        PromoteScope      `json:"scope"`
+	Options      PromoteOptions    `json:"options"`
+	Status       BatchStatus       `json:"status"`
+	Summary      PromoteSummary    `json:"summary"`
+	Items        []PromoteItem     `json:"items,omitempty"`
+	Errors       []PromoteError    `json:"errors,omitempty"`
+	Records      []PromotionRecord `json:"records,omitempty"`
+	CreatedBy    uuid.UUID         `json:"created_by"`
+	CreatedAt    time.Time         `json:"created_at"`
+	RolledBackBy *uuid.UUID        `json:"rolled_back_by,omitempty"`
+	RolledBackAt *time.Time        `json:"rolled_back_at,omitempty"`
+}
+
+// PromotionRecord captures a target record written by a promotion batch.
+// Before holds the target state prior to the promotion and is empty for
+// records the batch created. Checksum fingerprints the state the promotion
+// left behind so rollbacks can detect later edits. RolledBack is set once a
+// rollback has reverted the record.
+type PromotionRecord struct {
+	Kind       string          `json:"kind"`
+	SourceID   uuid.UUID       `json:"source_id"`
+	TargetID   uuid.UUID       `json:"target_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	Checksum   string          `json:"checksum,omitempty"`
+	RolledBack bool            `json:"rolled_back,omitempty"`
+}
+
+// ListPromotionsRequest filters the promotion history.
+type ListPromotionsRequest struct {
+	SourceEnvironment string `json:"source_environment,omitempty"`
+	TargetEnvironment string `json:"target_environment,omitempty"`
+	Limit             int    `json:"limit,omitempty"`
+	Offset            int    `json:"offset,omitempty"`
+}
+
+// RollbackOptions controls promotion rollbacks.
+type RollbackOptions struct {
+	// Force restores records even when the target changed after the promotion.
+	Force   bool      `json:"force,omitempty"`
+	ActorID uuid.UUID `json:"actor_id,omitempty"`
+}
+
+// RollbackItem reports the rollback of a single target record.
+type RollbackItem struct {
+	Kind     string    `json:"kind"`
+	TargetID uuid.UUID `json:"target_id"`
+	Status   string    `json:"status"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// RollbackResult captures the rollback response.
+type RollbackResult struct {
+	Batch *PromotionBatch `json:"batch"`
+	Items []RollbackItem  `json:"items,omitempty"`
+}
+
+// ComparisonStatus describes how a record differs between two environments.
+type ComparisonStatus string
+
+const (
+	// ComparisonAdded marks records present in the source but missing from the target.
+	ComparisonAdded ComparisonStatus = "added"
+	// ComparisonRemoved marks records present in the target but missing from the source.
+	ComparisonRemoved ComparisonStatus = "removed"
+	// ComparisonChanged marks records present in both environments with differences.
+	ComparisonChanged ComparisonStatus = "changed"
+	// ComparisonUnchanged marks records that match across environments.
+	ComparisonUnchanged ComparisonStatus = "unchanged"
+)
+
+// CompareEnvironmentsRequest describes an environment comparison request.
+type CompareEnvironmentsRequest struct {
+	SourceEnvironment string `json:"-"`
+	TargetEnvironment string `json:"-"`
+
+	// Kinds limits the comparison to specific entity kinds; empty compares all supported kinds.
+	Kinds []string `json:"kinds,omitempty"`
+	// IncludeUnchanged reports records that match across environments.
+	IncludeUnchanged bool `json:"include_unchanged,omitempty"`
+}
+
+// ComparisonSummaryCounts reports comparison counts per entity kind.
+type ComparisonSummaryCounts struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// ComparisonSummary aggregates comparison counts.
+type ComparisonSummary struct {
+	ContentTypes     ComparisonSummaryCounts `json:"content_types"`
+	ContentEntries   ComparisonSummaryCounts `json:"content_entries"`
+	Pages            ComparisonSummaryCounts `json:"pages"`
+	Menus            ComparisonSummaryCounts `json:"menus"`
+	BlockDefinitions ComparisonSummaryCounts `json:"block_definitions"`
+	WidgetAreas      ComparisonSummaryCounts `json:"widget_areas"`
+}
+
+// SchemaBreakingChange mirrors schema.BreakingChange for JSON responses.
+type SchemaBreakingChange struct {
+	Type        string `json:"type"`
+	Field       string `json:"field"`
+	Description string `json:"description,omitempty"`
+}
+
+// SchemaCompatibility reports the compatibility of promoting a source schema onto the target.
+type SchemaCompatibility struct {
+	Compatible      bool                   `json:"compatible"`
+	ChangeLevel     string                 `json:"change_level"`
+	BreakingChanges []SchemaBreakingChange `json:"breaking_changes,omitempty"`
+	Warnings        []string               `json:"warnings,omitempty"`
+}
+
+// FieldChange describes a single field-level difference between environments.
+type FieldChange struct {
+	Path   string `json:"path"`
+	Locale string `json:"locale,omitempty"`
+	Change string `json:"change"`
+	Source any    `json:"source,omitempty"`
+	Target any    `json:"target,omitempty"`
+}
+
+// ComparisonItem reports the comparison of a single record matched by slug or code.
+type ComparisonItem struct {
+	Kind          string               `json:"kind"`
+	Key           string               `json:"key"`
+	Status        ComparisonStatus     `json:"status"`
+	SourceID      *uuid.UUID           `json:"source_id,omitempty"`
+	TargetID      *uuid.UUID           `json:"target_id,omitempty"`
+	Compatibility *SchemaCompatibility `json:"compatibility,omitempty"`
+	Changes       []FieldChange        `json:"changes,omitempty"`
+}
+
+// EnvironmentComparison captures the comparison response.
+type EnvironmentComparison struct {
+	SourceEnv EnvironmentRef    `json:"source_env"`
+	TargetEnv EnvironmentRef    `json:"target_env"`
+	Summary   ComparisonSummary `json:"summary"`
+	Items     []ComparisonItem  `json:"items,omitempty"`
 }
@@ -0,0 +1,159 @@
+package retention
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mode selects how services enforce a version retention policy.
+type Mode string
+
+const (
+	// ModeReject fails writes that would exceed KeepLast versions.
+	ModeReject Mode = "reject"
+	// ModePrune removes versions the policy no longer keeps after each write.
+	ModePrune Mode = "prune"
+	// ModeScheduled never blocks writes and leaves pruning to the retention job.
+	ModeScheduled Mode = "scheduled"
+)
+
+// Resource types reported in pruning results.
+const (
+	ResourceContent        = "content"
+	ResourcePage           = "page"
+	ResourceBlockInstance  = "block_instance"
+	ResourceWidgetInstance = "widget_instance"
+)
+
+// ErrModeInvalid reports an unknown retention mode.
+var ErrModeInvalid = errors.New("retention: mode must be reject, prune, or scheduled")
+
+// ParseMode normalises a configured mode. An empty value selects ModeReject so
+// configurations that only set a version limit keep failing writes at the
+// limit, as they did before pruning existed; set ModePrune or ModeScheduled to
+// stop blocking editors.
+func ParseMode(value string) (Mode, error) {
+	switch Mode(value) {
+	case "", ModeReject:
+		return ModeReject, nil
+	case ModePrune, ModeScheduled:
+		return Mode(value), nil
+	default:
+		return "", ErrModeInvalid
+	}
+}
+
+// Policy decides which versions of a record are kept. A version survives when
+// any keep rule matches it; the current and published versions always survive.
+type Policy struct {
+	Mode Mode
+	// KeepLast keeps the newest N versions (0 disables the rule).
+	KeepLast int
+	// KeepPublished keeps every version that was ever published.
+	KeepPublished bool
+	// KeepWithin keeps versions created within the window (0 disables the rule).
+	KeepWithin time.Duration
+}
+
+// Enabled reports whether the policy limits version history at all.
+func (p Policy) Enabled() bool {
+	return p.KeepLast > 0 || p.KeepWithin > 0
+}
+
+// Rejects reports whether a write adding a version to existing versions must
+// fail. A policy without a mode rejects, matching ParseMode.
+func (p Policy) Rejects(existing int) bool {
+	return (p.Mode == "" || p.Mode == ModeReject) && p.KeepLast > 0 && existing >= p.KeepLast
+}
+
+// PrunesOnWrite reports whether services prune right after creating a version.
+func (p Policy) PrunesOnWrite() bool {
+	return p.Mode == ModePrune && p.Enabled()
+}
+
+// Prunes reports whether the policy removes versions, on write or from the job.
+func (p Policy) Prunes() bool {
+	return (p.Mode == ModePrune || p.Mode == ModeScheduled) && p.Enabled()
+}
+
+// Version is the policy view of a stored version.
+type Version struct {
+	Number    int
+	CreatedAt time.Time
+	// Published marks versions that were published at some point.
+	Published bool
+}
+
+// Select returns the version numbers the policy prunes, in ascending order.
+// Protected lists version numbers that are never pruned, such as the record's
+// current and published versions.
+func (p Policy) Select(versions []Version, protected []int, now time.Time) []int {
+	if !p.Enabled() || len(versions) == 0 {
+		return nil
+	}
+	keep := make(map[int]struct{}, len(protected))
+	for _, number := range protected {
+		keep[number] = struct{}{}
+	}
+
+	ordered := append([]Version(nil), versions...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Number > ordered[j].Number
+	})
+
+	var pruned []int
+	for i, version := range ordered {
+		if _, ok := keep[version.Number]; ok {
+			continue
+		}
+		if p.KeepLast > 0 && i < p.KeepLast {
+			continue
+		}
+		if p.KeepWithin > 0 && now.Sub(version.CreatedAt) < p.KeepWithin {
+			continue
+		}
+		if p.KeepPublished && version.Published {
+			continue
+		}
+		pruned = append(pruned, version.Number)
+	}
+	sort.Ints(pruned)
+	return pruned
+}
+
+// Protected returns the version numbers a record pins: its current version
+// and, when set, its published version.
+func Protected(current int, published *int) []int {
+	numbers := []int{current}
+	if published != nil {
+		numbers = append(numbers, *published)
+	}
+	return numbers
+}
+
+// Pruned describes the versions removed from one record.
+type Pruned struct {
+	Resource string    `json:"resource"`
+	EntityID uuid.UUID `json:"entity_id"`
+	Versions []int     `json:"versions"`
+}
+
+// Report aggregates the outcome of a pruning run.
+type Report struct {
+	Pruned []Pruned `json:"pruned,omitempty"`
+}
+
+// Total returns the number of versions removed.
+func (r *Report) Total() int {
+	if r == nil {
+		return 0
+	}
+	total := 0
+	for _, entry := range r.Pruned {
+		total += len(entry.Versions)
+	}
+	return total
+}
@@ -0,0 +1,126 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/google/uuid"
+)
+
+func TestPolicySelect(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	versions := []retention.Version{
+		{Number: 1, CreatedAt: now.Add(-10 * 24 * time.Hour), Published: true},
+		{Number: 2, CreatedAt: now.Add(-9 * 24 * time.Hour)},
+		{Number: 3, CreatedAt: now.Add(-8 * 24 * time.Hour)},
+		{Number: 4, CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 5, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	cases := []struct {
+		name      string
+		policy    retention.Policy
+		protected []int
+		want      []int
+	}{
+		{
+			name:   "disabled policy keeps everything",
+			policy: retention.Policy{Mode: retention.ModePrune},
+		},
+		{
+			name:   "keep last",
+			policy: retention.Policy{Mode: retention.ModePrune, KeepLast: 2},
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "keep published",
+			policy: retention.Policy{Mode: retention.ModePrune, KeepLast: 2, KeepPublished: true},
+			want:   []int{2, 3},
+		},
+		{
+			name:   "keep within window",
+			policy: retention.Policy{Mode: retention.ModePrune, KeepWithin: 24 * time.Hour},
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:      "protected versions survive",
+			policy:    retention.Policy{Mode: retention.ModePrune, KeepLast: 1},
+			protected: []int{3, 5},
+			want:      []int{1, 2, 4},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.policy.Select(versions, tc.protected, now)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPolicyModes(t *testing.T) {
+	reject := retention.Policy{KeepLast: 2}
+	if !reject.Rejects(2) || reject.Rejects(1) || reject.PrunesOnWrite() || reject.Prunes() {
+		t.Fatalf("unexpected reject mode behaviour")
+	}
+	prune := retention.Policy{Mode: retention.ModePrune, KeepLast: 2}
+	if prune.Rejects(5) || !prune.PrunesOnWrite() || !prune.Prunes() {
+		t.Fatalf("unexpected prune mode behaviour")
+	}
+	scheduled := retention.Policy{Mode: retention.ModeScheduled, KeepWithin: time.Hour}
+	if scheduled.Rejects(5) || scheduled.PrunesOnWrite() || !scheduled.Prunes() {
+		t.Fatalf("unexpected scheduled mode behaviour")
+	}
+
+	if _, err := retention.ParseMode("archive"); !errors.Is(err, retention.ErrModeInvalid) {
+		t.Fatalf("expected ErrModeInvalid, got %v", err)
+	}
+}
+
+func TestEmptyModeKeepsRejectingAtTheLimit(t *testing.T) {
+	mode, err := retention.ParseMode("")
+	if err != nil || mode != retention.ModeReject {
+		t.Fatalf("expected empty mode to select reject, got %q %v", mode, err)
+	}
+	for _, policy := range []retention.Policy{
+		{Mode: mode, KeepLast: 2},
+		{KeepLast: 2},
+	} {
+		if policy.Rejects(1) || !policy.Rejects(2) {
+			t.Fatalf("expected %q policy to reject only at the limit", policy.Mode)
+		}
+		if policy.PrunesOnWrite() || policy.Prunes() {
+			t.Fatalf("expected %q policy to never prune", policy.Mode)
+		}
+	}
+}
+
+type stubSource struct {
+	pruned []retention.Pruned
+}
+
+func (s stubSource) PruneAllVersions(context.Context) ([]retention.Pruned, error) {
+	return s.pruned, nil
+}
+
+func TestPrunerAggregatesSources(t *testing.T) {
+	contentID := uuid.New()
+	pageID := uuid.New()
+	pruner := retention.NewPruner(
+		stubSource{pruned: []retention.Pruned{{Resource: retention.ResourceContent, EntityID: contentID, Versions: []int{1, 2}}}},
+		nil,
+		stubSource{pruned: []retention.Pruned{{Resource: retention.ResourcePage, EntityID: pageID, Versions: []int{4}}}},
+	)
+	report, err := pruner.Prune(context.Background())
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if len(report.Pruned) != 2 || report.Total() != 3 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
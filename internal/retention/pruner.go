@@ -0,0 +1,59 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+// Source prunes the version history of every record it owns. The content,
+// page, and block services implement it.
+type Source interface {
+	PruneAllVersions(ctx context.Context) ([]Pruned, error)
+}
+
+// Pruner runs the retention policies of several sources in one pass.
+type Pruner struct {
+	sources []Source
+}
+
+// NewPruner constructs a pruner over the supplied sources. Nil sources are ignored.
+func NewPruner(sources ...Source) *Pruner {
+	p := &Pruner{}
+	for _, source := range sources {
+		if source != nil {
+			p.sources = append(p.sources, source)
+		}
+	}
+	return p
+}
+
+// Prune applies each source's policy and reports the removed versions.
+func (p *Pruner) Prune(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	for _, source := range p.sources {
+		pruned, err := source.PruneAllVersions(ctx)
+		if err != nil {
+			return report, err
+		}
+		report.Pruned = append(report.Pruned, pruned...)
+	}
+	return report, nil
+}
+
+// SchedulePrune enqueues the version retention job at runAt. A positive
+// interval is stored in the payload so the worker schedules the next run.
+func SchedulePrune(ctx context.Context, scheduler interfaces.Scheduler, runAt time.Time, interval time.Duration) (*interfaces.Job, error) {
+	payload := map[string]any{}
+	if interval > 0 {
+		payload["interval"] = interval.String()
+	}
+	return scheduler.Enqueue(ctx, interfaces.JobSpec{
+		Key:     cmsscheduler.VersionPruneJobKey,
+		Type:    cmsscheduler.JobTypeVersionPrune,
+		RunAt:   runAt,
+		Payload: payload,
+	})
+}
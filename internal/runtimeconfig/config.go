@@ -31,6 +31,15 @@ var ErrLoggingProviderUnknown = errors.New("cms config: logging provider is inva
 var ErrLoggingLevelInvalid = errors.New("cms config: logging level is invalid")
 var ErrLoggingFormatInvalid = errors.New("cms config: logging format is invalid")
 var ErrVersionRetentionLimitInvalid = errors.New("cms config: version retention limit must be zero or positive")
+var ErrVersionRetentionModeInvalid = errors.New("cms config: version retention mode must be reject, prune, or scheduled")
+var ErrVersionRetentionWindowInvalid = errors.New("cms config: version retention window must be zero or positive")
+var ErrVersionPruneIntervalInvalid = errors.New("cms config: version prune interval must be zero or positive")
+var ErrTrashRetentionInvalid = errors.New("cms config: trash retention must be zero or positive")
+var ErrTrashPurgeIntervalInvalid = errors.New("cms config: trash purge interval must be zero or positive")
+var ErrWebhookMaxAttemptsInvalid = errors.New("cms config: webhook max attempts must be zero or positive")
+var ErrWebhookDurationInvalid = errors.New("cms config: webhook timeout and backoff must be zero or positive")
+var ErrAuditRetentionInvalid = errors.New("cms config: audit retention must be zero or positive")
+var ErrAuditPurgeIntervalInvalid = errors.New("cms config: audit purge interval must be zero or positive")
 var ErrWorkflowProviderUnknown = errors.New("cms config: workflow provider is invalid")
 var ErrWorkflowProviderConfiguredWhenDisabled = errors.New("cms config: workflow provider configured while workflow disabled")
 var ErrStorageProfileNameRequired = errors.New("cms config: storage profile name is required")
@@ -73,6 +82,9 @@ type Config struct {
 	Themes        ThemeConfig
 	Widgets       WidgetConfig
 	Retention     RetentionConfig
+	Trash         TrashConfig
+	Webhooks      WebhooksConfig
+	Audit         AuditConfig
 	Features      Features
 	Environments  EnvironmentsConfig
 	Shortcodes    ShortcodeConfig
@@ -158,6 +170,10 @@ type Features struct {
 	Shortcodes    bool
 	Activity      bool
 	Environments  bool
+	Sites         bool
+	Trash         bool
+	Experiments   bool
+	Webhooks      bool
 }
 
 // EnvironmentsConfig captures environment-specific configuration and defaults.
@@ -242,11 +258,51 @@ type WidgetDefinitionConfig struct {
 	Icon        string
 }
 
-// RetentionConfig captures per-module version retention limits.
+// RetentionConfig captures per-module version retention limits and how they
+// are enforced. The per-module counts keep the newest N versions. Mode
+// "reject" (the default, kept for configurations that predate pruning) fails
+// drafts once the limit is reached, "prune"
+// removes versions the policy no longer keeps after each write, and
+// "scheduled" leaves pruning to the recurring job run every PruneInterval.
+// The current and published versions are never pruned.
 type RetentionConfig struct {
 	Content int
 	Pages   int
 	Blocks  int
+	Widgets int
+
+	Mode          string
+	KeepPublished bool
+	KeepWithin    time.Duration
+	PruneInterval time.Duration
+}
+
+// TrashConfig controls how long soft-deleted records stay restorable.
+// Retention zero, the default, keeps trashed records until they are restored;
+// set it to purge them permanently once they are older. PurgeInterval
+// schedules the recurring purge job when scheduling is enabled and Retention
+// is set; zero leaves purging to the host application.
+type TrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+// WebhooksConfig controls outbound webhook deliveries. Failed deliveries are
+// retried through the scheduler, doubling Backoff after every attempt up to
+// MaxBackoff, until MaxAttempts is reached. Zero values use the defaults.
+type WebhooksConfig struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+}
+
+// AuditConfig controls how long audit events are kept. Retention zero keeps
+// events until they are removed explicitly. PurgeInterval schedules the
+// recurring purge job when scheduling is enabled and a retention is set.
+type AuditConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
 }
 
 // MarkdownConfig captures filesystem and parser behaviour for Markdown ingestion.
@@ -260,6 +316,38 @@ type MarkdownConfig struct {
 	Locales           []string
 	Parser            MarkdownParserConfig
 	ProcessShortcodes bool
+	// ContentTypeMappings maps content type IDs or slugs to the rules that
+	// route frontmatter keys and body content onto typed schema fields.
+	ContentTypeMappings map[string]MarkdownFieldMapping
+	// Assets controls importing local images referenced from documents.
+	Assets MarkdownAssetConfig
+}
+
+// MarkdownFieldMapping routes frontmatter keys (dot paths for nested keys) to
+// schema fields and optionally splits the body into embedded blocks.
+type MarkdownFieldMapping struct {
+	Fields    map[string]string
+	BodyField string
+	Blocks    MarkdownBlockSplitConfig
+}
+
+// MarkdownBlockSplitConfig splits a body on headings ("headings") or fenced
+// ":::type" directives ("directives") into blocks of the given type.
+type MarkdownBlockSplitConfig struct {
+	Mode       string
+	Level      int
+	Type       string
+	TitleField string
+	BodyField  string
+}
+
+// MarkdownAssetConfig uploads relative image links through the media provider
+// when it supports uploads. LinkMode is "url" (default) or "reference" and
+// BindingKey names the media binding group (defaults to "body_images").
+type MarkdownAssetConfig struct {
+	Enabled    bool
+	LinkMode   string
+	BindingKey string
 }
 
 // MarkdownParserConfig mirrors interfaces.ParseOptions for runtime configuration.
@@ -275,6 +363,7 @@ type ShortcodeConfig struct {
 	Enabled               bool
 	EnableWordPressSyntax bool
 	BuiltIns              []string
+	DataShortcodes        []string
 	CustomDefinitions     []ShortcodeDefinitionConfig
 	Security              ShortcodeSecurityConfig
 	Cache                 ShortcodeCacheConfig
@@ -320,6 +409,35 @@ type GeneratorConfig struct {
 	Menus            map[string]string
 	RenderTimeout    time.Duration
 	AssetCopyTimeout time.Duration
+	Collections      []GeneratorCollectionConfig
+	// RebuildOnChange registers a lifecycle hook that rebuilds the pages
+	// affected by content changes, coalescing events within RebuildDebounce.
+	RebuildOnChange bool
+	RebuildDebounce time.Duration
+	// LocaleFallbacks maps a locale code to the locales rendered in its place
+	// when a page has no translation; such pages declare the source locale
+	// as canonical.
+	LocaleFallbacks map[string][]string
+	// SitemapMaxURLs caps the URLs per sitemap before switching to a sitemap
+	// index with per-locale sitemaps. Zero uses the protocol limit (50,000).
+	SitemapMaxURLs int
+}
+
+// GeneratorCollectionConfig declares a paginated listing, such as a blog index
+// or yearly archive, rendered by the static generator.
+type GeneratorCollectionConfig struct {
+	Name        string
+	ContentType string
+	Template    string
+	Route       string
+	Routes      map[string]string
+	ItemRoute   string
+	PageSize    int
+	SortBy      string
+	SortOrder   string
+	Filters     map[string]string
+	GroupBy     string
+	Feed        bool
 }
 
 // DefaultConfig returns opinionated defaults matching Phase 1 expectations.
@@ -330,7 +448,21 @@ func DefaultConfig() Config {
 		Content: ContentConfig{
 			PageHierarchy: true,
 		},
-		Retention: RetentionConfig{},
+		Retention: RetentionConfig{
+			PruneInterval: 24 * time.Hour,
+		},
+		Trash: TrashConfig{
+			PurgeInterval: 24 * time.Hour,
+		},
+		Webhooks: WebhooksConfig{
+			MaxAttempts: 5,
+			Backoff:     30 * time.Second,
+			MaxBackoff:  time.Hour,
+			Timeout:     10 * time.Second,
+		},
+		Audit: AuditConfig{
+			PurgeInterval: 24 * time.Hour,
+		},
 		I18N: I18NConfig{
 			Enabled:               true,
 			Locales:               []string{"en"},
@@ -344,6 +476,7 @@ func DefaultConfig() Config {
 			Enabled:               false,
 			EnableWordPressSyntax: false,
 			BuiltIns:              []string{"youtube", "alert", "gallery", "figure", "code"},
+			DataShortcodes:        []string{"content", "content_list", "widget_area", "menu", "media"},
 			Security: ShortcodeSecurityConfig{
 				MaxNestingDepth:    5,
 				MaxExecutionTime:   5 * time.Second,
@@ -484,6 +617,38 @@ func (cfg Config) Validate() error {
 	if cfg.Retention.Blocks < 0 {
 		return fmt.Errorf("%w: blocks", ErrVersionRetentionLimitInvalid)
 	}
+	if cfg.Retention.Widgets < 0 {
+		return fmt.Errorf("%w: widgets", ErrVersionRetentionLimitInvalid)
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Retention.Mode)) {
+	case "", "reject", "prune", "scheduled":
+	default:
+		return ErrVersionRetentionModeInvalid
+	}
+	if cfg.Retention.KeepWithin < 0 {
+		return ErrVersionRetentionWindowInvalid
+	}
+	if cfg.Retention.PruneInterval < 0 {
+		return ErrVersionPruneIntervalInvalid
+	}
+	if cfg.Trash.Retention < 0 {
+		return ErrTrashRetentionInvalid
+	}
+	if cfg.Trash.PurgeInterval < 0 {
+		return ErrTrashPurgeIntervalInvalid
+	}
+	if cfg.Webhooks.MaxAttempts < 0 {
+		return ErrWebhookMaxAttemptsInvalid
+	}
+	if cfg.Webhooks.Backoff < 0 || cfg.Webhooks.MaxBackoff < 0 || cfg.Webhooks.Timeout < 0 {
+		return ErrWebhookDurationInvalid
+	}
+	if cfg.Audit.Retention < 0 {
+		return ErrAuditRetentionInvalid
+	}
+	if cfg.Audit.PurgeInterval < 0 {
+		return ErrAuditPurgeIntervalInvalid
+	}
 	if cfg.Features.Logger {
 		provider := normalizeProvider(cfg.Logging.Provider)
 		if provider == "" {
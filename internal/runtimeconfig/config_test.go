@@ -3,6 +3,7 @@ package runtimeconfig_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/goliatone/go-cms/internal/runtimeconfig"
 	"github.com/goliatone/go-cms/pkg/storage"
@@ -308,3 +309,77 @@ func TestConfigValidate_RequiresEnvironmentDefaultWhenMultipleDefinitions(t *tes
 		t.Fatalf("expected ErrEnvironmentDefaultRequired, got %v", err)
 	}
 }
+
+func TestDefaultConfigKeepsTrashUntilRestored(t *testing.T) {
+	if retention := runtimeconfig.DefaultConfig().Trash.Retention; retention != 0 {
+		t.Fatalf("expected trash purging to be opt-in, got retention %s", retention)
+	}
+}
+
+func TestConfigValidate_RejectsNegativeTrashDurations(t *testing.T) {
+	cfg := runtimeconfig.DefaultConfig()
+	cfg.Trash.Retention = -time.Hour
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrTrashRetentionInvalid) {
+		t.Fatalf("expected ErrTrashRetentionInvalid, got %v", err)
+	}
+
+	cfg = runtimeconfig.DefaultConfig()
+	cfg.Trash.PurgeInterval = -time.Minute
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrTrashPurgeIntervalInvalid) {
+		t.Fatalf("expected ErrTrashPurgeIntervalInvalid, got %v", err)
+	}
+}
+
+func TestConfigValidate_RejectsInvalidWebhookSettings(t *testing.T) {
+	cfg := runtimeconfig.DefaultConfig()
+	cfg.Webhooks.MaxAttempts = -1
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrWebhookMaxAttemptsInvalid) {
+		t.Fatalf("expected ErrWebhookMaxAttemptsInvalid, got %v", err)
+	}
+
+	cfg = runtimeconfig.DefaultConfig()
+	cfg.Webhooks.Timeout = -time.Second
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrWebhookDurationInvalid) {
+		t.Fatalf("expected ErrWebhookDurationInvalid, got %v", err)
+	}
+}
+
+func TestConfigValidate_RejectsNegativeAuditDurations(t *testing.T) {
+	cfg := runtimeconfig.DefaultConfig()
+	cfg.Audit.Retention = -time.Hour
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrAuditRetentionInvalid) {
+		t.Fatalf("expected ErrAuditRetentionInvalid, got %v", err)
+	}
+
+	cfg = runtimeconfig.DefaultConfig()
+	cfg.Audit.PurgeInterval = -time.Minute
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrAuditPurgeIntervalInvalid) {
+		t.Fatalf("expected ErrAuditPurgeIntervalInvalid, got %v", err)
+	}
+}
+
+func TestConfigValidate_VersionRetentionPolicy(t *testing.T) {
+	cfg := runtimeconfig.DefaultConfig()
+	cfg.Retention.Mode = "prune"
+	cfg.Retention.KeepWithin = 72 * time.Hour
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected prune mode to validate, got %v", err)
+	}
+
+	cfg.Retention.Mode = "archive"
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrVersionRetentionModeInvalid) {
+		t.Fatalf("expected ErrVersionRetentionModeInvalid, got %v", err)
+	}
+
+	cfg = runtimeconfig.DefaultConfig()
+	cfg.Retention.KeepWithin = -time.Hour
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrVersionRetentionWindowInvalid) {
+		t.Fatalf("expected ErrVersionRetentionWindowInvalid, got %v", err)
+	}
+
+	cfg = runtimeconfig.DefaultConfig()
+	cfg.Retention.PruneInterval = -time.Minute
+	if err := cfg.Validate(); !errors.Is(err, runtimeconfig.ErrVersionPruneIntervalInvalid) {
+		t.Fatalf("expected ErrVersionPruneIntervalInvalid, got %v", err)
+	}
+}
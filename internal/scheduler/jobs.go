@@ -1,14 +1,31 @@
 package scheduler
 
-import "github.com/google/uuid"
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+)
 
 const (
 	JobTypeContentPublish   = "cms.content.publish"
 	JobTypeContentUnpublish = "cms.content.unpublish"
 	JobTypePagePublish      = "cms.page.publish"
 	JobTypePageUnpublish    = "cms.page.unpublish"
+	JobTypeTrashPurge       = "cms.trash.purge"
+	JobTypeVersionPrune     = "cms.versions.prune"
+	JobTypeWebhookDeliver   = "cms.webhooks.deliver"
+	JobTypeAuditPurge       = "cms.audit.purge"
 )
 
+// TrashPurgeJobKey identifies the recurring trash purge job.
+const TrashPurgeJobKey = "trash:purge"
+
+// VersionPruneJobKey identifies the recurring version retention job.
+const VersionPruneJobKey = "versions:prune"
+
+// AuditPurgeJobKey identifies the recurring audit retention job.
+const AuditPurgeJobKey = "audit:purge"
+
 func ContentPublishJobKey(id uuid.UUID) string {
 	return "content:" + id.String() + ":publish"
 }
@@ -24,3 +41,9 @@ func PagePublishJobKey(id uuid.UUID) string {
 func PageUnpublishJobKey(id uuid.UUID) string {
 	return "page:" + id.String() + ":unpublish"
 }
+
+// WebhookDeliveryJobKey identifies one attempt of a webhook delivery so a
+// retry can be queued while the current attempt's job is still running.
+func WebhookDeliveryJobKey(id uuid.UUID, attempt int) string {
+	return "webhook:" + id.String() + ":attempt:" + strconv.Itoa(attempt)
+}
@@ -119,8 +119,10 @@ func CheckSchemaCompatibility(oldSchema, newSchema map[string]any) Compatibility
 }
 
 type fieldDescriptor struct {
-	Type     typeInfo
-	Required bool
+	Type       typeInfo
+	Required   bool
+	Default    any
+	HasDefault bool
 }
 
 type typeChange int
@@ -173,9 +175,12 @@ func walkSchemaFields(node map[string]any, prefix string, fields map[string]fiel
 				continue
 			}
 			path := joinFieldPath(prefix, name)
+			defaultValue, hasDefault := child["default"]
 			fields[path] = fieldDescriptor{
-				Type:     parseTypeInfo(child),
-				Required: required[name],
+				Type:       parseTypeInfo(child),
+				Required:   required[name],
+				Default:    defaultValue,
+				HasDefault: hasDefault,
 			}
 			walkSchemaFields(child, path, fields)
 		}
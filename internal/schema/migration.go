@@ -69,3 +69,75 @@ func (m *Migrator) Migrate(slug, from, to string, payload map[string]any) (map[s
 	}
 	return out, nil
 }
+
+// RegisterOperations adds a migration step built from declarative operations.
+func (m *Migrator) RegisterOperations(slug, from, to string, ops []MigrationOperation) error {
+	if err := ValidateMigrationOperations(ops); err != nil {
+		return err
+	}
+	return m.Register(slug, from, to, OperationsMigration(ops))
+}
+
+// HasStep reports whether a step starting at from is registered for slug.
+func (m *Migrator) HasStep(slug, from string) bool {
+	if m == nil || m.steps == nil {
+		return false
+	}
+	_, ok := m.steps[slug][from]
+	return ok
+}
+
+// Extend returns a copy of the migrator with additional steps for slug.
+// Steps already registered take precedence over the extra ones, so host
+// code registered at boot overrides stored operations for the same hop.
+func (m *Migrator) Extend(slug string, steps ...MigrationStep) *Migrator {
+	out := NewMigrator()
+	if m != nil {
+		for key, registered := range m.steps {
+			out.steps[key] = make(map[string]MigrationStep, len(registered))
+			for from, step := range registered {
+				out.steps[key][from] = step
+			}
+		}
+	}
+	for _, step := range steps {
+		if step.From == "" || step.To == "" || step.Apply == nil || out.HasStep(slug, step.From) {
+			continue
+		}
+		_ = out.Register(slug, step.From, step.To, step.Apply)
+	}
+	return out
+}
+
+// HistoryMigrationSteps derives migration steps between consecutive schema
+// versions. Hops carrying operations run them; hops without operations pass
+// payloads through unchanged when the schemas are compatible and are left
+// unregistered otherwise, so payloads still require a registered migration.
+func HistoryMigrationSteps(history []VersionedSchema) []MigrationStep {
+	steps := make([]MigrationStep, 0, len(history))
+	for idx := 1; idx < len(history); idx++ {
+		prev, next := history[idx-1], history[idx]
+		if prev.Version == "" || next.Version == "" || prev.Version == next.Version {
+			continue
+		}
+		switch {
+		case len(next.Migration) > 0:
+			steps = append(steps, MigrationStep{From: prev.Version, To: next.Version, Apply: OperationsMigration(next.Migration)})
+		case CheckSchemaCompatibility(prev.Schema, next.Schema).Compatible:
+			steps = append(steps, MigrationStep{From: prev.Version, To: next.Version, Apply: identityMigration})
+		}
+	}
+	return steps
+}
+
+// VersionedSchema is a schema version together with the operations that
+// migrate payloads from the preceding version.
+type VersionedSchema struct {
+	Version   string
+	Schema    map[string]any
+	Migration []MigrationOperation
+}
+
+func identityMigration(payload map[string]any) (map[string]any, error) {
+	return payload, nil
+}
@@ -0,0 +1,612 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goliatone/go-cms/domain"
+)
+
+// Migration operation kinds understood by ApplyMigrationOperations.
+const (
+	MigrationOpRename     = "rename"
+	MigrationOpMove       = "move"
+	MigrationOpCopy       = "copy"
+	MigrationOpDelete     = "delete"
+	MigrationOpSetDefault = "set_default"
+	MigrationOpWrapArray  = "wrap_array"
+	MigrationOpMapValues  = "map_values"
+	MigrationOpCoerce     = "coerce"
+)
+
+// ErrInvalidMigrationOperation indicates a malformed migration operation.
+var ErrInvalidMigrationOperation = errors.New("schema: invalid migration operation")
+
+// MigrationOperation is a declarative payload transformation between two
+// schema versions.
+type MigrationOperation = domain.SchemaMigrationOperation
+
+// ValidateMigrationOperations reports the first malformed operation.
+func ValidateMigrationOperations(ops []MigrationOperation) error {
+	for idx, op := range ops {
+		if _, err := compileMigrationOperation(op); err != nil {
+			return fmt.Errorf("%w: operation %d (%s): %v", ErrInvalidMigrationOperation, idx, op.Op, err)
+		}
+	}
+	return nil
+}
+
+// ApplyMigrationOperations runs the operations in order against a copy of
+// payload.
+func ApplyMigrationOperations(payload map[string]any, ops []MigrationOperation) (map[string]any, error) {
+	out := cloneMap(payload)
+	if out == nil {
+		out = map[string]any{}
+	}
+	for idx, op := range ops {
+		compiled, err := compileMigrationOperation(op)
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation %d (%s): %v", ErrInvalidMigrationOperation, idx, op.Op, err)
+		}
+		if err := compiled.apply(out); err != nil {
+			return nil, fmt.Errorf("schema: migration operation %d (%s %s): %w", idx, op.Op, op.Path, err)
+		}
+	}
+	return out, nil
+}
+
+// OperationsMigration adapts declarative operations to a MigrationFunc.
+func OperationsMigration(ops []MigrationOperation) MigrationFunc {
+	cloned := CloneMigrationOperations(ops)
+	return func(payload map[string]any) (map[string]any, error) {
+		return ApplyMigrationOperations(payload, cloned)
+	}
+}
+
+// CloneMigrationOperations deep copies operations.
+func CloneMigrationOperations(ops []MigrationOperation) []MigrationOperation {
+	if ops == nil {
+		return nil
+	}
+	out := make([]MigrationOperation, len(ops))
+	for i, op := range ops {
+		out[i] = op
+		out[i].Value = cloneValue(op.Value)
+		out[i].Values = cloneMap(op.Values)
+	}
+	return out
+}
+
+type compiledOperation struct {
+	op    MigrationOperation
+	scope []string
+	from  []string
+	to    []string
+}
+
+func compileMigrationOperation(op MigrationOperation) (compiledOperation, error) {
+	scope, from, err := splitMigrationPath(op.Path)
+	if err != nil {
+		return compiledOperation{}, err
+	}
+	compiled := compiledOperation{op: op, scope: scope, from: from}
+	switch op.Op {
+	case MigrationOpRename:
+		name := strings.TrimSpace(op.To)
+		if name == "" || strings.Contains(name, ".") || strings.HasSuffix(name, "[]") {
+			return compiledOperation{}, errors.New("rename requires a field name in to")
+		}
+		compiled.to = append(slices.Clone(from[:len(from)-1]), name)
+	case MigrationOpMove, MigrationOpCopy:
+		toScope, to, err := splitMigrationPath(op.To)
+		if err != nil {
+			return compiledOperation{}, fmt.Errorf("to: %w", err)
+		}
+		if !slices.Equal(scope, toScope) {
+			return compiledOperation{}, errors.New("path and to must share the same array scope")
+		}
+		compiled.to = to
+	case MigrationOpDelete, MigrationOpSetDefault, MigrationOpWrapArray:
+	case MigrationOpMapValues:
+		if len(op.Values) == 0 {
+			return compiledOperation{}, errors.New("map_values requires values")
+		}
+	case MigrationOpCoerce:
+		switch strings.ToLower(strings.TrimSpace(op.Type)) {
+		case "string", "number", "integer", "boolean", "array":
+		default:
+			return compiledOperation{}, fmt.Errorf("unsupported coerce type %q", op.Type)
+		}
+	default:
+		return compiledOperation{}, fmt.Errorf("unknown op %q", op.Op)
+	}
+	if slices.Equal(compiled.from, compiled.to) {
+		return compiledOperation{}, errors.New("path and to must differ")
+	}
+	return compiled, nil
+}
+
+// splitMigrationPath separates the array scope (segments up to the last
+// "[]") from the field path inside each scoped element.
+func splitMigrationPath(path string) ([]string, []string, error) {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return nil, nil, errors.New("path required")
+	}
+	segments := strings.Split(trimmed, ".")
+	last := -1
+	for idx, segment := range segments {
+		if strings.TrimSuffix(segment, "[]") == "" {
+			return nil, nil, fmt.Errorf("invalid path %q", path)
+		}
+		if strings.HasSuffix(segment, "[]") {
+			last = idx
+		}
+	}
+	if last == len(segments)-1 {
+		return nil, nil, fmt.Errorf("path %q must end in a field", path)
+	}
+	return segments[:last+1], segments[last+1:], nil
+}
+
+func (c compiledOperation) apply(payload map[string]any) error {
+	return walkMigrationScope(payload, c.scope, func(node map[string]any) error {
+		value, ok := getMigrationPath(node, c.from)
+		switch c.op.Op {
+		case MigrationOpRename, MigrationOpMove:
+			if ok {
+				deleteMigrationPath(node, c.from)
+				setMigrationPath(node, c.to, value)
+			}
+		case MigrationOpCopy:
+			if ok {
+				setMigrationPath(node, c.to, cloneValue(value))
+			}
+		case MigrationOpDelete:
+			deleteMigrationPath(node, c.from)
+		case MigrationOpSetDefault:
+			if !ok || value == nil {
+				setMigrationPath(node, c.from, cloneValue(c.op.Value))
+			}
+		case MigrationOpWrapArray:
+			if ok && value != nil {
+				setMigrationPath(node, c.from, wrapArray(value))
+			}
+		case MigrationOpMapValues:
+			if ok {
+				setMigrationPath(node, c.from, mapMigrationValues(value, c.op.Values))
+			}
+		case MigrationOpCoerce:
+			if !ok || value == nil {
+				return nil
+			}
+			coerced, err := coerceMigrationValue(value, strings.ToLower(strings.TrimSpace(c.op.Type)))
+			if err != nil {
+				return err
+			}
+			setMigrationPath(node, c.from, coerced)
+		}
+		return nil
+	})
+}
+
+func walkMigrationScope(node map[string]any, scope []string, fn func(map[string]any) error) error {
+	if node == nil {
+		return nil
+	}
+	if len(scope) == 0 {
+		return fn(node)
+	}
+	key, isArray := strings.CutSuffix(scope[0], "[]")
+	child, ok := node[key]
+	if !ok {
+		return nil
+	}
+	if !isArray {
+		nested, _ := child.(map[string]any)
+		return walkMigrationScope(nested, scope[1:], fn)
+	}
+	switch items := child.(type) {
+	case []any:
+		for _, item := range items {
+			nested, _ := item.(map[string]any)
+			if err := walkMigrationScope(nested, scope[1:], fn); err != nil {
+				return err
+			}
+		}
+	case []map[string]any:
+		for _, item := range items {
+			if err := walkMigrationScope(item, scope[1:], fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getMigrationPath(node map[string]any, path []string) (any, bool) {
+	current := node
+	for idx, key := range path {
+		value, ok := current[key]
+		if !ok {
+			return nil, false
+		}
+		if idx == len(path)-1 {
+			return value, true
+		}
+		next, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return nil, false
+}
+
+func setMigrationPath(node map[string]any, path []string, value any) {
+	current := node
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
+
+func deleteMigrationPath(node map[string]any, path []string) {
+	current := node
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, path[len(path)-1])
+}
+
+func wrapArray(value any) any {
+	switch value.(type) {
+	case []any, []string, []map[string]any:
+		return value
+	}
+	return []any{value}
+}
+
+func mapMigrationValues(value any, mapping map[string]any) any {
+	switch typed := value.(type) {
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = mapMigrationValues(item, mapping)
+		}
+		return out
+	case []string:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = mapMigrationValues(item, mapping)
+		}
+		return out
+	case map[string]any, nil:
+		return value
+	}
+	if mapped, ok := mapping[fmt.Sprint(value)]; ok {
+		return cloneValue(mapped)
+	}
+	return value
+}
+
+func coerceMigrationValue(value any, target string) (any, error) {
+	if target == "array" {
+		return wrapArray(value), nil
+	}
+	switch typed := value.(type) {
+	case string:
+		trimmed := strings.TrimSpace(typed)
+		switch target {
+		case "string":
+			return typed, nil
+		case "number":
+			parsed, err := strconv.ParseFloat(trimmed, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot coerce %q to number", typed)
+			}
+			return parsed, nil
+		case "integer":
+			parsed, err := strconv.ParseFloat(trimmed, 64)
+			if err != nil || parsed != math.Trunc(parsed) {
+				return nil, fmt.Errorf("cannot coerce %q to integer", typed)
+			}
+			return parsed, nil
+		case "boolean":
+			parsed, err := strconv.ParseBool(strings.ToLower(trimmed))
+			if err != nil {
+				return nil, fmt.Errorf("cannot coerce %q to boolean", typed)
+			}
+			return parsed, nil
+		}
+	case bool:
+		switch target {
+		case "string":
+			return strconv.FormatBool(typed), nil
+		case "number", "integer":
+			if typed {
+				return float64(1), nil
+			}
+			return float64(0), nil
+		case "boolean":
+			return typed, nil
+		}
+	default:
+		number, ok := numericValue(value)
+		if !ok {
+			return nil, fmt.Errorf("cannot coerce %T to %s", value, target)
+		}
+		switch target {
+		case "string":
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		case "number":
+			return number, nil
+		case "integer":
+			return math.Trunc(number), nil
+		case "boolean":
+			return number != 0, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot coerce %T to %s", value, target)
+}
+
+func numericValue(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case float32:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case int8:
+		return float64(typed), true
+	case int16:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint:
+		return float64(typed), true
+	case uint8:
+		return float64(typed), true
+	case uint16:
+		return float64(typed), true
+	case uint32:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	}
+	return 0, false
+}
+
+func cloneValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return cloneMap(typed)
+	case []any:
+		return cloneSlice(typed)
+	}
+	return value
+}
+
+// SuggestMigrationOperations proposes operations that carry payloads from
+// oldSchema to newSchema based on CheckSchemaCompatibility breaking changes.
+// A removed field pairs with a single added field of a compatible type as a
+// rename (or move); other removals become deletes. Type changes become
+// coerce or wrap_array, and newly required fields get set_default using the
+// schema default or the type's zero value. Suggestions are a starting point
+// for review, not a guarantee that payloads will validate.
+func SuggestMigrationOperations(oldSchema, newSchema map[string]any) []MigrationOperation {
+	result := CheckSchemaCompatibility(oldSchema, newSchema)
+	if len(result.BreakingChanges) == 0 {
+		return nil
+	}
+	oldFields := collectSchemaFields(normalizeCompatibilitySchema(oldSchema))
+	newFields := collectSchemaFields(normalizeCompatibilitySchema(newSchema))
+
+	removedSet := map[string]bool{}
+	var typeChanged, requiredAdded []string
+	for _, change := range result.BreakingChanges {
+		if !suggestablePath(change.Field) {
+			continue
+		}
+		switch change.Type {
+		case "field_removed":
+			removedSet[change.Field] = true
+		case "type_changed":
+			typeChanged = append(typeChanged, change.Field)
+		case "required_added":
+			requiredAdded = append(requiredAdded, change.Field)
+		}
+	}
+	removed := topLevelPaths(removedSet)
+
+	addedSet := map[string]bool{}
+	for path := range newFields {
+		if _, ok := oldFields[path]; !ok && suggestablePath(path) {
+			addedSet[path] = true
+		}
+	}
+	added := topLevelPaths(addedSet)
+
+	renameable := func(from, to string) bool {
+		return sameArrayScope(from, to) && compareTypeInfo(oldFields[from].Type, newFields[to].Type) != typeChangeBreaking
+	}
+	var moves, deletes []MigrationOperation
+	renamedTo := map[string]bool{}
+	for _, from := range removed {
+		var matches []string
+		for _, to := range added {
+			if renameable(from, to) {
+				matches = append(matches, to)
+			}
+		}
+		if len(matches) == 1 && countMatches(removed, func(other string) bool { return renameable(other, matches[0]) }) == 1 {
+			to := matches[0]
+			renamedTo[to] = true
+			fromParent, _ := splitParentPath(from)
+			toParent, toName := splitParentPath(to)
+			if fromParent == toParent {
+				moves = append(moves, MigrationOperation{Op: MigrationOpRename, Path: from, To: toName})
+			} else {
+				moves = append(moves, MigrationOperation{Op: MigrationOpMove, Path: from, To: to})
+			}
+			continue
+		}
+		deletes = append(deletes, MigrationOperation{Op: MigrationOpDelete, Path: from})
+	}
+
+	var conversions []MigrationOperation
+	slices.Sort(typeChanged)
+	for _, path := range typeChanged {
+		oldInfo, newInfo := oldFields[path].Type, newFields[path].Type
+		switch {
+		case newInfo.kind == "array" && newInfo.items != nil && oldInfo.kind != "array" &&
+			compareTypeInfo(oldInfo, *newInfo.items) != typeChangeBreaking:
+			conversions = append(conversions, MigrationOperation{Op: MigrationOpWrapArray, Path: path})
+		case oldInfo.kind == "scalar" && newInfo.kind == "scalar":
+			if target := preferredScalar(newInfo.scalars); target != "" {
+				conversions = append(conversions, MigrationOperation{Op: MigrationOpCoerce, Path: path, Type: target})
+			}
+		}
+	}
+
+	var defaults []MigrationOperation
+	slices.Sort(requiredAdded)
+	for _, path := range requiredAdded {
+		if renamedTo[path] {
+			if old, ok := oldFields[moveSource(moves, path)]; ok && old.Required {
+				continue
+			}
+		}
+		field := newFields[path]
+		value := field.Default
+		if !field.HasDefault {
+			value = zeroValueFor(field.Type)
+		}
+		defaults = append(defaults, MigrationOperation{Op: MigrationOpSetDefault, Path: path, Value: value})
+	}
+
+	ops := make([]MigrationOperation, 0, len(moves)+len(conversions)+len(deletes)+len(defaults))
+	ops = append(ops, moves...)
+	ops = append(ops, conversions...)
+	ops = append(ops, deletes...)
+	ops = append(ops, defaults...)
+	return ops
+}
+
+func suggestablePath(path string) bool {
+	for _, segment := range strings.Split(path, ".") {
+		switch {
+		case segment == "oneOf", segment == "allOf", segment == "$defs", strings.HasPrefix(segment, "["):
+			return false
+		}
+	}
+	return !strings.HasSuffix(path, "[]")
+}
+
+// topLevelPaths drops paths whose parent is also in the set, sorted.
+func topLevelPaths(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for path := range set {
+		parent, _ := splitParentPath(path)
+		covered := false
+		for parent != "" {
+			if set[strings.TrimSuffix(parent, "[]")] {
+				covered = true
+				break
+			}
+			parent, _ = splitParentPath(strings.TrimSuffix(parent, "[]"))
+		}
+		if !covered {
+			out = append(out, path)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+func splitParentPath(path string) (string, string) {
+	idx := strings.LastIndex(path, ".")
+	if idx < 0 {
+		return "", path
+	}
+	return path[:idx], path[idx+1:]
+}
+
+func sameArrayScope(a, b string) bool {
+	scopeA, _, errA := splitMigrationPath(a)
+	scopeB, _, errB := splitMigrationPath(b)
+	return errA == nil && errB == nil && slices.Equal(scopeA, scopeB)
+}
+
+func countMatches(values []string, fn func(string) bool) int {
+	count := 0
+	for _, value := range values {
+		if fn(value) {
+			count++
+		}
+	}
+	return count
+}
+
+func moveSource(moves []MigrationOperation, target string) string {
+	for _, op := range moves {
+		to := op.To
+		if op.Op == MigrationOpRename {
+			parent, _ := splitParentPath(op.Path)
+			to = joinFieldPath(parent, op.To)
+		}
+		if to == target {
+			return op.Path
+		}
+	}
+	return ""
+}
+
+func preferredScalar(scalars map[string]struct{}) string {
+	for _, candidate := range []string{"string", "number", "integer", "boolean"} {
+		if _, ok := scalars[candidate]; ok && len(scalars) == 1 {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func zeroValueFor(info typeInfo) any {
+	switch info.kind {
+	case "array":
+		return []any{}
+	case "object":
+		return map[string]any{}
+	case "scalar":
+		switch {
+		case hasScalar(info.scalars, "string"):
+			return ""
+		case hasScalar(info.scalars, "number"), hasScalar(info.scalars, "integer"):
+			return float64(0)
+		case hasScalar(info.scalars, "boolean"):
+			return false
+		}
+	}
+	return nil
+}
+
+func hasScalar(set map[string]struct{}, name string) bool {
+	_, ok := set[name]
+	return ok
+}
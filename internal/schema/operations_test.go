@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApplyMigrationOperationsTransformsPayload(t *testing.T) {
+	payload := map[string]any{
+		"headline": "Hello",
+		"status":   "live",
+		"count":    "3",
+		"tags":     "news",
+		"legacy":   true,
+		"items": []any{
+			map[string]any{"label": "One"},
+			map[string]any{"label": "Two"},
+		},
+	}
+	ops := []MigrationOperation{
+		{Op: MigrationOpRename, Path: "headline", To: "title"},
+		{Op: MigrationOpMove, Path: "items[].label", To: "items[].meta.title"},
+		{Op: MigrationOpCopy, Path: "title", To: "seo.title"},
+		{Op: MigrationOpMapValues, Path: "status", Values: map[string]any{"live": "published"}},
+		{Op: MigrationOpCoerce, Path: "count", Type: "integer"},
+		{Op: MigrationOpWrapArray, Path: "tags"},
+		{Op: MigrationOpDelete, Path: "legacy"},
+		{Op: MigrationOpSetDefault, Path: "summary", Value: ""},
+	}
+
+	migrated, err := ApplyMigrationOperations(payload, ops)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	expected := map[string]any{
+		"title":   "Hello",
+		"status":  "published",
+		"count":   float64(3),
+		"tags":    []any{"news"},
+		"summary": "",
+		"seo":     map[string]any{"title": "Hello"},
+		"items": []any{
+			map[string]any{"meta": map[string]any{"title": "One"}},
+			map[string]any{"meta": map[string]any{"title": "Two"}},
+		},
+	}
+	if !reflect.DeepEqual(migrated, expected) {
+		t.Fatalf("unexpected payload:\n got %#v\nwant %#v", migrated, expected)
+	}
+	if _, ok := payload["headline"]; !ok {
+		t.Fatalf("expected input payload to remain untouched")
+	}
+}
+
+func TestValidateMigrationOperationsRejectsMalformedOps(t *testing.T) {
+	cases := map[string]MigrationOperation{
+		"unknown op":     {Op: "explode", Path: "title"},
+		"missing path":   {Op: MigrationOpDelete},
+		"rename to path": {Op: MigrationOpRename, Path: "title", To: "meta.title"},
+		"scope mismatch": {Op: MigrationOpMove, Path: "items[].title", To: "title"},
+		"empty mapping":  {Op: MigrationOpMapValues, Path: "status"},
+		"bad coerce":     {Op: MigrationOpCoerce, Path: "count", Type: "date"},
+		"array suffix":   {Op: MigrationOpDelete, Path: "items[]"},
+	}
+	for name, op := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateMigrationOperations([]MigrationOperation{op})
+			if !errors.Is(err, ErrInvalidMigrationOperation) {
+				t.Fatalf("expected ErrInvalidMigrationOperation, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMigratorRunsOperationsAndPrefersRegisteredSteps(t *testing.T) {
+	history := []VersionedSchema{
+		{Version: "article@v1.0.0", Schema: objectSchema(map[string]any{"headline": map[string]any{"type": "string"}})},
+		{
+			Version:   "article@v2.0.0",
+			Schema:    objectSchema(map[string]any{"title": map[string]any{"type": "string"}}),
+			Migration: []MigrationOperation{{Op: MigrationOpRename, Path: "headline", To: "title"}},
+		},
+		{Version: "article@v2.1.0", Schema: objectSchema(map[string]any{
+			"title":   map[string]any{"type": "string"},
+			"summary": map[string]any{"type": "string"},
+		})},
+	}
+	steps := HistoryMigrationSteps(history)
+	if len(steps) != 2 {
+		t.Fatalf("expected two steps, got %d", len(steps))
+	}
+
+	migrated, err := (*Migrator)(nil).Extend("article", steps...).Migrate("article", "article@v1.0.0", "article@v2.1.0", map[string]any{"headline": "Hi"})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if migrated["title"] != "Hi" {
+		t.Fatalf("expected renamed title, got %#v", migrated)
+	}
+
+	registered := NewMigrator()
+	if err := registered.Register("article", "article@v1.0.0", "article@v2.0.0", func(payload map[string]any) (map[string]any, error) {
+		return map[string]any{"title": "from code"}, nil
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	migrated, err = registered.Extend("article", steps...).Migrate("article", "article@v1.0.0", "article@v2.1.0", map[string]any{"headline": "Hi"})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if migrated["title"] != "from code" {
+		t.Fatalf("expected registered step to win, got %#v", migrated)
+	}
+}
+
+func TestSuggestMigrationOperations(t *testing.T) {
+	oldSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"headline": map[string]any{"type": "string"},
+			"legacy":   map[string]any{"type": "number"},
+			"tags":     map[string]any{"type": "string"},
+		},
+	}
+	newSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"title":    map[string]any{"type": "string"},
+			"tags":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"featured": map[string]any{"type": "boolean", "default": false},
+		},
+		"required": []any{"featured"},
+	}
+
+	ops := SuggestMigrationOperations(oldSchema, newSchema)
+	expected := []MigrationOperation{
+		{Op: MigrationOpRename, Path: "headline", To: "title"},
+		{Op: MigrationOpWrapArray, Path: "tags"},
+		{Op: MigrationOpDelete, Path: "legacy"},
+		{Op: MigrationOpSetDefault, Path: "featured", Value: false},
+	}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Fatalf("unexpected suggestions:\n got %#v\nwant %#v", ops, expected)
+	}
+	if err := ValidateMigrationOperations(ops); err != nil {
+		t.Fatalf("expected valid suggestions: %v", err)
+	}
+	if ops := SuggestMigrationOperations(oldSchema, oldSchema); ops != nil {
+		t.Fatalf("expected no suggestions for identical schemas, got %#v", ops)
+	}
+}
+
+func objectSchema(properties map[string]any) map[string]any {
+	return map[string]any{"type": "object", "properties": properties}
+}
@@ -27,6 +27,7 @@ func youTubeDefinition() interfaces.ShortcodeDefinition {
 		Icon:        "youtube",
 		AllowInner:  false,
 		CacheTTL:    time.Hour,
+		CSP:         interfaces.ShortcodeCSPSources{FrameSrc: []string{"https://www.youtube.com"}},
 		Schema: interfaces.ShortcodeSchema{
 			Params: []interfaces.ShortcodeParam{
 				{
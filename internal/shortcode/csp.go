@@ -0,0 +1,82 @@
+package shortcode
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+// CSP is the default interfaces.ShortcodeCSP implementation. It collects the
+// sources declared by the shortcodes rendered for one response.
+type CSP struct {
+	nonce     string
+	scriptSrc []string
+	frameSrc  []string
+
+	mu sync.Mutex
+}
+
+// NewCSP returns an empty policy using nonce for inline scripts. An empty nonce
+// leaves the nonce out of the script-src directive.
+func NewCSP(nonce string) *CSP {
+	return &CSP{nonce: nonce}
+}
+
+// Nonce returns the nonce stamped on inline scripts.
+func (c *CSP) Nonce() string {
+	if c == nil {
+		return ""
+	}
+	return c.nonce
+}
+
+// Add merges the supplied sources, ignoring duplicates.
+func (c *CSP) Add(sources interfaces.ShortcodeCSPSources) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scriptSrc = appendCSPSources(c.scriptSrc, sources.ScriptSrc)
+	c.frameSrc = appendCSPSources(c.frameSrc, sources.FrameSrc)
+}
+
+// Header renders the collected directives as a Content-Security-Policy value.
+func (c *CSP) Header() string {
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var directives []string
+	scripts := slices.Clone(c.scriptSrc)
+	sort.Strings(scripts)
+	if c.nonce != "" {
+		scripts = append([]string{"'nonce-" + c.nonce + "'"}, scripts...)
+	}
+	if len(scripts) > 0 {
+		directives = append(directives, "script-src "+strings.Join(scripts, " "))
+	}
+	if len(c.frameSrc) > 0 {
+		frames := slices.Clone(c.frameSrc)
+		sort.Strings(frames)
+		directives = append(directives, "frame-src "+strings.Join(frames, " "))
+	}
+	return strings.Join(directives, "; ")
+}
+
+var _ interfaces.ShortcodeCSP = (*CSP)(nil)
+
+func appendCSPSources(existing, sources []string) []string {
+	for _, source := range sources {
+		trimmed := strings.TrimSpace(source)
+		if trimmed == "" || slices.Contains(existing, trimmed) {
+			continue
+		}
+		existing = append(existing, trimmed)
+	}
+	return existing
+}
@@ -0,0 +1,478 @@
+package shortcode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	cmswidgets "github.com/goliatone/go-cms/widgets"
+	"github.com/google/uuid"
+)
+
+// ErrDataNotFound indicates a data-aware shortcode referenced a missing CMS record.
+var ErrDataNotFound = errors.New("shortcode: referenced record not found")
+
+// dataCacheTTL bounds how long data-aware output is cached between invalidations.
+const dataCacheTTL = 5 * time.Minute
+
+// ContentTypeReader resolves content types by slug.
+type ContentTypeReader interface {
+	GetBySlug(ctx context.Context, slug string, env ...string) (*content.ContentType, error)
+}
+
+// ContentLister lists content entries. Implementations must honour the status,
+// order, limit and translation locale list options.
+type ContentLister interface {
+	List(ctx context.Context, opts ...content.ContentListOption) ([]*content.Content, error)
+}
+
+// ContentReader resolves a single content entry by slug within a content
+// type. Returned entries carry their translations.
+type ContentReader interface {
+	GetBySlug(ctx context.Context, slug string, contentTypeID uuid.UUID, env ...string) (*content.Content, error)
+}
+
+// LocaleReader resolves locale codes to locale records.
+type LocaleReader interface {
+	GetByCode(ctx context.Context, code string) (*content.Locale, error)
+}
+
+// WidgetAreaResolver resolves the widgets placed in an area.
+type WidgetAreaResolver interface {
+	ResolveArea(ctx context.Context, input cmswidgets.ResolveAreaInput) ([]*cmswidgets.ResolvedWidget, error)
+}
+
+// NavigationItem is a menu node rendered by the menu shortcode.
+type NavigationItem struct {
+	Label    string
+	URL      string
+	Children []NavigationItem
+}
+
+// NavigationResolver resolves the navigation bound to a menu location.
+type NavigationResolver interface {
+	ResolveNavigation(ctx context.Context, location string, locale string, env ...string) ([]NavigationItem, error)
+}
+
+// MediaResolver resolves media references into attachments.
+type MediaResolver interface {
+	ResolveBindings(ctx context.Context, bindings media.BindingSet, opts media.ResolveOptions) (map[string][]*media.Attachment, error)
+}
+
+// DataSources supplies the CMS services backing the data-aware shortcodes.
+// DefaultLocale names the translation used when an entry has none in the
+// requested locale.
+type DataSources struct {
+	ContentTypes  ContentTypeReader
+	Content       ContentLister
+	Entries       ContentReader
+	Locales       LocaleReader
+	Widgets       WidgetAreaResolver
+	Menus         NavigationResolver
+	Media         MediaResolver
+	DefaultLocale string
+}
+
+// DataShortcodeNames lists the data-aware shortcodes in registration order.
+func DataShortcodeNames() []string {
+	return []string{"content", "content_list", "widget_area", "menu", "media"}
+}
+
+// RegisterDataShortcodes registers the data-aware shortcodes backed by sources.
+// When names is empty every data shortcode is requested; requested shortcodes
+// whose sources are not configured are skipped.
+func RegisterDataShortcodes(registry interfaces.ShortcodeRegistry, sources DataSources, names []string) error {
+	if registry == nil {
+		return fmt.Errorf("shortcode: registry is required")
+	}
+
+	available := make(map[string]interfaces.ShortcodeDefinition)
+	if sources.ContentTypes != nil && sources.Entries != nil {
+		available["content"] = contentEmbedDefinition(sources)
+	}
+	if sources.ContentTypes != nil && sources.Content != nil {
+		available["content_list"] = contentListDefinition(sources)
+	}
+	if sources.Widgets != nil {
+		available["widget_area"] = widgetAreaDefinition(sources)
+	}
+	if sources.Menus != nil {
+		available["menu"] = menuDefinition(sources)
+	}
+	if sources.Media != nil {
+		available["media"] = mediaDefinition(sources)
+	}
+
+	if len(names) == 0 {
+		names = DataShortcodeNames()
+	}
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		def, ok := available[key]
+		if !ok {
+			if !isDataShortcode(key) {
+				return fmt.Errorf("shortcode: unknown data shortcode %q", name)
+			}
+			continue
+		}
+		if err := registry.Register(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isDataShortcode(name string) bool {
+	for _, candidate := range DataShortcodeNames() {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	contentEmbedTemplate = template.Must(template.New("content").Parse(`<article class="shortcode shortcode--content" data-content-type="{{ .Type }}" data-slug="{{ .Slug }}">
+  <h3 class="shortcode__title">{{ .Title }}</h3>
+  {{ if .Summary }}<div class="shortcode__summary">{{ .Summary }}</div>{{ end }}
+</article>`))
+	contentListTemplate = template.Must(template.New("content_list").Parse(`<ul class="shortcode shortcode--content-list" data-content-type="{{ .Type }}">
+  {{ range .Entries }}<li data-slug="{{ .Slug }}">{{ .Title }}</li>{{ end }}
+</ul>`))
+	widgetAreaTemplate = template.Must(template.New("widget_area").Parse(`<div class="shortcode shortcode--widget-area" data-area="{{ .Area }}">
+  {{ range .Widgets }}<div class="shortcode__widget" data-widget-id="{{ .ID }}">
+    {{ if .Title }}<h4 class="shortcode__widget-title">{{ .Title }}</h4>{{ end }}
+    {{ if .Body }}<div class="shortcode__widget-body">{{ .Body }}</div>{{ end }}
+  </div>{{ end }}
+</div>`))
+	menuTemplate  = template.Must(template.New("menu").Parse(`{{ define "nodes" }}<ul>{{ range . }}<li>{{ if .URL }}<a href="{{ .URL }}">{{ .Label }}</a>{{ else }}<span>{{ .Label }}</span>{{ end }}{{ if .Children }}{{ template "nodes" .Children }}{{ end }}</li>{{ end }}</ul>{{ end }}<nav class="shortcode shortcode--menu" data-location="{{ .Location }}">{{ template "nodes" .Nodes }}</nav>`))
+	mediaTemplate = template.Must(template.New("media").Parse(`<figure class="shortcode shortcode--media">
+  <img src="{{ .URL }}" alt="{{ .Alt }}" loading="lazy" />
+  {{ if .Caption }}<figcaption>{{ .Caption }}</figcaption>{{ end }}
+</figure>`))
+)
+
+type contentView struct {
+	Type    string
+	Slug    string
+	Title   string
+	Summary string
+}
+
+func contentEmbedDefinition(sources DataSources) interfaces.ShortcodeDefinition {
+	return interfaces.ShortcodeDefinition{
+		Name:        "content",
+		Version:     "1.0.0",
+		Description: "Embeds another published content entry by slug",
+		Category:    "data",
+		Icon:        "file-text",
+		CacheTTL:    dataCacheTTL,
+		Schema: interfaces.ShortcodeSchema{
+			Params: []interfaces.ShortcodeParam{
+				{Name: "type", Type: interfaces.ShortcodeParamString, Required: true},
+				{Name: "slug", Type: interfaces.ShortcodeParamString, Required: true},
+			},
+		},
+		CacheDependencies: contentDependencies,
+		Handler: func(ctx interfaces.ShortcodeContext, params map[string]any, _ string) (template.HTML, error) {
+			typeSlug, slug := stringParam(params, "type"), stringParam(params, "slug")
+			contentType, err := resolveContentType(ctx, sources, typeSlug)
+			if err != nil {
+				return "", err
+			}
+			envArgs := environmentArgs(ctx)
+			if contentType.EnvironmentID != uuid.Nil {
+				envArgs = []string{contentType.EnvironmentID.String()}
+			}
+			entry, err := sources.Entries.GetBySlug(ctx.Context, slug, contentType.ID, envArgs...)
+			var notFound *content.NotFoundError
+			if errors.As(err, &notFound) || (err == nil && (entry == nil || !isPublished(entry))) {
+				return "", fmt.Errorf("%w: content %s/%s", ErrDataNotFound, typeSlug, slug)
+			}
+			if err != nil {
+				return "", err
+			}
+			view := contentEntryView(typeSlug, entry, ctx.Locale, sources.DefaultLocale)
+			return executeDataTemplate(contentEmbedTemplate, view)
+		},
+	}
+}
+
+func contentListDefinition(sources DataSources) interfaces.ShortcodeDefinition {
+	return interfaces.ShortcodeDefinition{
+		Name:        "content_list",
+		Version:     "1.0.0",
+		Description: "Lists the most recently published entries of a content type",
+		Category:    "data",
+		Icon:        "list",
+		CacheTTL:    dataCacheTTL,
+		Schema: interfaces.ShortcodeSchema{
+			Params: []interfaces.ShortcodeParam{
+				{Name: "type", Type: interfaces.ShortcodeParamString, Required: true},
+				{Name: "limit", Type: interfaces.ShortcodeParamInt, Default: 5},
+			},
+		},
+		CacheDependencies: contentDependencies,
+		Handler: func(ctx interfaces.ShortcodeContext, params map[string]any, _ string) (template.HTML, error) {
+			typeSlug := stringParam(params, "type")
+			limit, _ := params["limit"].(int)
+			published, err := listContent(ctx, sources, typeSlug, limit)
+			if err != nil {
+				return "", err
+			}
+			views := make([]contentView, 0, len(published))
+			for _, entry := range published {
+				views = append(views, contentEntryView(typeSlug, entry, ctx.Locale, sources.DefaultLocale))
+			}
+			return executeDataTemplate(contentListTemplate, map[string]any{"Type": typeSlug, "Entries": views})
+		},
+	}
+}
+
+func widgetAreaDefinition(sources DataSources) interfaces.ShortcodeDefinition {
+	return interfaces.ShortcodeDefinition{
+		Name:        "widget_area",
+		Version:     "1.0.0",
+		Description: "Renders the widgets placed in a widget area",
+		Category:    "data",
+		Icon:        "layout",
+		CacheTTL:    dataCacheTTL,
+		Schema: interfaces.ShortcodeSchema{
+			Params: []interfaces.ShortcodeParam{
+				{Name: "code", Type: interfaces.ShortcodeParamString, Required: true},
+			},
+		},
+		CacheDependencies: func(map[string]any) []string {
+			return []string{"widget"}
+		},
+		Handler: func(ctx interfaces.ShortcodeContext, params map[string]any, _ string) (template.HTML, error) {
+			code := stringParam(params, "code")
+			input := cmswidgets.ResolveAreaInput{AreaCode: code, Now: time.Now(), EnvironmentKey: ctx.EnvironmentKey}
+			if sources.Locales != nil && ctx.Locale != "" {
+				if locale, err := sources.Locales.GetByCode(ctx.Context, ctx.Locale); err == nil && locale != nil {
+					input.LocaleID = &locale.ID
+				}
+			}
+			resolved, err := sources.Widgets.ResolveArea(ctx.Context, input)
+			if err != nil {
+				return "", err
+			}
+			views := make([]map[string]any, 0, len(resolved))
+			for _, widget := range resolved {
+				if widget == nil || widget.Instance == nil {
+					continue
+				}
+				views = append(views, map[string]any{
+					"ID":    widget.Instance.ID.String(),
+					"Title": configString(widget.Config, "title"),
+					"Body":  firstNonEmpty(configString(widget.Config, "body"), configString(widget.Config, "content")),
+				})
+			}
+			return executeDataTemplate(widgetAreaTemplate, map[string]any{"Area": code, "Widgets": views})
+		},
+	}
+}
+
+func menuDefinition(sources DataSources) interfaces.ShortcodeDefinition {
+	return interfaces.ShortcodeDefinition{
+		Name:        "menu",
+		Version:     "1.0.0",
+		Description: "Renders the menu bound to a location",
+		Category:    "data",
+		Icon:        "menu",
+		CacheTTL:    dataCacheTTL,
+		Schema: interfaces.ShortcodeSchema{
+			Params: []interfaces.ShortcodeParam{
+				{Name: "location", Type: interfaces.ShortcodeParamString, Required: true},
+			},
+		},
+		CacheDependencies: func(map[string]any) []string {
+			return []string{"menu", "menu_item"}
+		},
+		Handler: func(ctx interfaces.ShortcodeContext, params map[string]any, _ string) (template.HTML, error) {
+			location := stringParam(params, "location")
+			nodes, err := sources.Menus.ResolveNavigation(ctx.Context, location, ctx.Locale, environmentArgs(ctx)...)
+			if err != nil {
+				return "", err
+			}
+			return executeDataTemplate(menuTemplate, map[string]any{"Location": location, "Nodes": nodes})
+		},
+	}
+}
+
+func mediaDefinition(sources DataSources) interfaces.ShortcodeDefinition {
+	return interfaces.ShortcodeDefinition{
+		Name:        "media",
+		Version:     "1.0.0",
+		Description: "Resolves a media reference through the media service",
+		Category:    "data",
+		Icon:        "image",
+		// Not cached: assets change in the host media provider without
+		// lifecycle events, and the media service caches resolutions itself.
+		Schema: interfaces.ShortcodeSchema{
+			Params: []interfaces.ShortcodeParam{
+				{Name: "id", Type: interfaces.ShortcodeParamString, Required: true},
+				{Name: "rendition", Type: interfaces.ShortcodeParamString},
+				{Name: "alt", Type: interfaces.ShortcodeParamString},
+				{Name: "caption", Type: interfaces.ShortcodeParamString},
+			},
+		},
+		Handler: func(ctx interfaces.ShortcodeContext, params map[string]any, _ string) (template.HTML, error) {
+			binding := media.Binding{
+				Slot:      "shortcode",
+				Reference: interfaces.MediaReference{ID: stringParam(params, "id"), Locale: ctx.Locale},
+			}
+			rendition := stringParam(params, "rendition")
+			if rendition != "" {
+				binding.Renditions = []string{rendition}
+			}
+			resolved, err := sources.Media.ResolveBindings(ctx.Context, media.BindingSet{"shortcode": {binding}}, media.ResolveOptions{})
+			if err != nil {
+				return "", err
+			}
+			attachments := resolved["shortcode"]
+			if len(attachments) == 0 || attachments[0] == nil {
+				return "", fmt.Errorf("%w: media %s", ErrDataNotFound, binding.Reference.ID)
+			}
+			attachment := attachments[0]
+			url := ""
+			if res := attachment.Renditions[rendition]; rendition != "" && res != nil {
+				url = res.URL
+			} else if attachment.Source != nil {
+				url = attachment.Source.URL
+			}
+			return executeDataTemplate(mediaTemplate, map[string]any{
+				"URL":     url,
+				"Alt":     firstNonEmpty(stringParam(params, "alt"), attachment.Metadata.AltText),
+				"Caption": firstNonEmpty(stringParam(params, "caption"), attachment.Metadata.Caption),
+			})
+		},
+	}
+}
+
+// isPublished reports whether a content entry may appear in rendered output.
+func isPublished(entry *content.Content) bool {
+	return entry != nil && strings.EqualFold(entry.Status, "published")
+}
+
+// contentDependencies keys content output to lifecycle events for its content
+// type. Like every data shortcode dependency, the key is a lifecycle resource
+// type, optionally scoped as described on DependencyTracker.Notify.
+func contentDependencies(params map[string]any) []string {
+	return []string{"content:" + stringParam(params, "type")}
+}
+
+// resolveContentType looks up a content type by slug in the render
+// environment.
+func resolveContentType(ctx interfaces.ShortcodeContext, sources DataSources, typeSlug string) (*content.ContentType, error) {
+	contentType, err := sources.ContentTypes.GetBySlug(ctx.Context, typeSlug, environmentArgs(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == nil {
+		return nil, fmt.Errorf("%w: content type %s", ErrDataNotFound, typeSlug)
+	}
+	return contentType, nil
+}
+
+// listContent lists the newest published entries of a content type. Status,
+// order, limit and translation locales are pushed down to the content query,
+// so only the rendered rows and translations are loaded.
+func listContent(ctx interfaces.ShortcodeContext, sources DataSources, typeSlug string, limit int) ([]*content.Content, error) {
+	envArgs := environmentArgs(ctx)
+	contentType, err := resolveContentType(ctx, sources, typeSlug)
+	if err != nil {
+		return nil, err
+	}
+	opts := []content.ContentListOption{
+		content.WithContentTypeID(contentType.ID),
+		content.WithStatus("published"),
+		content.WithRecentFirst(),
+	}
+	if limit > 0 {
+		opts = append(opts, content.WithLimit(limit))
+	}
+	if locales := content.WithTranslationLocales(ctx.Locale, sources.DefaultLocale); locales != "" {
+		opts = append(opts, content.WithTranslations(), locales)
+	}
+	for _, env := range envArgs {
+		opts = append(opts, content.ContentListOption(env))
+	}
+	return sources.Content.List(ctx.Context, opts...)
+}
+
+// contentEntryView renders the translation for locale, falling back to the
+// default locale and then to the entry slug as title.
+func contentEntryView(typeSlug string, entry *content.Content, locale, defaultLocale string) contentView {
+	view := contentView{Type: typeSlug, Slug: entry.Slug}
+	match := translationForLocale(entry, locale)
+	if match == nil {
+		match = translationForLocale(entry, defaultLocale)
+	}
+	if match != nil {
+		view.Title = match.Title
+		if match.Summary != nil {
+			view.Summary = *match.Summary
+		}
+	}
+	if view.Title == "" {
+		view.Title = entry.Slug
+	}
+	return view
+}
+
+func translationForLocale(entry *content.Content, locale string) *content.ContentTranslation {
+	if strings.TrimSpace(locale) == "" {
+		return nil
+	}
+	for _, tr := range entry.Translations {
+		if tr != nil && tr.Locale != nil && strings.EqualFold(tr.Locale.Code, locale) {
+			return tr
+		}
+	}
+	return nil
+}
+
+func environmentArgs(ctx interfaces.ShortcodeContext) []string {
+	if env := strings.TrimSpace(ctx.EnvironmentKey); env != "" {
+		return []string{env}
+	}
+	return nil
+}
+
+func executeDataTemplate(tmpl *template.Template, data any) (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil // #nosec G203 -- output is produced by html/template with escaped data.
+}
+
+func stringParam(params map[string]any, key string) string {
+	value, _ := params[key].(string)
+	return strings.TrimSpace(value)
+}
+
+func configString(config map[string]any, key string) string {
+	value, _ := config[key].(string)
+	return value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
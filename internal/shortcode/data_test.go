@@ -0,0 +1,313 @@
+package shortcode
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/google/uuid"
+)
+
+func TestDataShortcodeContentEmbedsLocalizedEntry(t *testing.T) {
+	sources := newContentSourcesStub()
+	registry := NewRegistry(NewValidator())
+	if err := RegisterDataShortcodes(registry, DataSources{ContentTypes: sources, Content: sources, Entries: sources.entryReader()}, []string{"content", "content_list"}); err != nil {
+		t.Fatalf("register data shortcodes: %v", err)
+	}
+	renderer := NewRenderer(registry, NewValidator())
+
+	ctx := interfaces.ShortcodeContext{Context: context.Background(), Locale: "es", EnvironmentKey: "staging"}
+	output, err := renderer.Render(ctx, "content", map[string]any{"type": "article", "slug": "hello"}, "")
+	if err != nil {
+		t.Fatalf("render content: %v", err)
+	}
+	if !strings.Contains(string(output), "Hola") || !strings.Contains(string(output), "Resumen &lt;b&gt;") {
+		t.Fatalf("expected localized, escaped output, got %s", output)
+	}
+	if sources.lastEnv != "staging" {
+		t.Fatalf("expected environment forwarded, got %q", sources.lastEnv)
+	}
+	if sources.lookupTypeID != sources.contentType.ID || sources.lookupEnv != sources.contentType.EnvironmentID.String() {
+		t.Fatalf("expected lookup scoped to the content type and its environment, got %s in %q", sources.lookupTypeID, sources.lookupEnv)
+	}
+
+	if _, err := renderer.Render(ctx, "content", map[string]any{"type": "article", "slug": "missing"}, ""); !errors.Is(err, ErrDataNotFound) {
+		t.Fatalf("expected ErrDataNotFound, got %v", err)
+	}
+
+	draft, err := renderer.Render(ctx, "content", map[string]any{"type": "article", "slug": "draft"}, "")
+	if !errors.Is(err, ErrDataNotFound) || draft != "" {
+		t.Fatalf("expected draft entry to render nothing, got %q (%v)", draft, err)
+	}
+
+	list, err := renderer.Render(ctx, "content_list", map[string]any{"type": "article", "limit": 1}, "")
+	if err != nil {
+		t.Fatalf("render content_list: %v", err)
+	}
+	if !strings.Contains(string(list), `data-slug="newer"`) || strings.Contains(string(list), `data-slug="hello"`) || strings.Contains(string(list), "draft") {
+		t.Fatalf("expected newest published entry only, got %s", list)
+	}
+	if !slices.Contains(sources.listOpts, content.WithTranslationLocales("es")) || !slices.Contains(sources.listOpts, content.WithContentTypeID(sources.contentType.ID)) {
+		t.Fatalf("expected type and locale pushed to the list query, got %v", sources.listOpts)
+	}
+}
+
+func TestDataShortcodeContentFallsBackToDefaultLocale(t *testing.T) {
+	sources := newContentSourcesStub()
+	registry := NewRegistry(NewValidator())
+	if err := RegisterDataShortcodes(registry, DataSources{ContentTypes: sources, Content: sources, Entries: sources.entryReader(), DefaultLocale: "es"}, []string{"content"}); err != nil {
+		t.Fatalf("register data shortcodes: %v", err)
+	}
+	renderer := NewRenderer(registry, NewValidator())
+
+	ctx := interfaces.ShortcodeContext{Context: context.Background(), Locale: "fr"}
+	output, err := renderer.Render(ctx, "content", map[string]any{"type": "article", "slug": "hello"}, "")
+	if err != nil {
+		t.Fatalf("render content: %v", err)
+	}
+	if !strings.Contains(string(output), "Hola") {
+		t.Fatalf("expected default locale translation, got %s", output)
+	}
+}
+
+func TestDataShortcodeMenuRendersNavigation(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	navigation := navigationStub{nodes: []NavigationItem{
+		{Label: "Home", URL: "/"},
+		{Label: "Docs", URL: "/docs", Children: []NavigationItem{{Label: "Guide", URL: "/docs/guide"}}},
+	}}
+	if err := RegisterDataShortcodes(registry, DataSources{Menus: navigation}, nil); err != nil {
+		t.Fatalf("register data shortcodes: %v", err)
+	}
+	if _, ok := registry.Get("content"); ok {
+		t.Fatalf("expected content shortcode skipped without sources")
+	}
+
+	output, err := NewRenderer(registry, NewValidator()).Render(interfaces.ShortcodeContext{Context: context.Background()}, "menu", map[string]any{"location": "primary"}, "")
+	if err != nil {
+		t.Fatalf("render menu: %v", err)
+	}
+	want := `<nav class="shortcode shortcode--menu" data-location="primary"><ul><li><a href="/">Home</a></li><li><a href="/docs">Docs</a><ul><li><a href="/docs/guide">Guide</a></li></ul></li></ul></nav>`
+	if string(output) != want {
+		t.Fatalf("unexpected menu output:\n%s\nwant:\n%s", output, want)
+	}
+
+	if err := RegisterDataShortcodes(NewRegistry(NewValidator()), DataSources{}, []string{"unknown"}); err == nil {
+		t.Fatalf("expected error for unknown data shortcode")
+	}
+}
+
+func TestDataShortcodeCacheInvalidatedByLifecycleEvents(t *testing.T) {
+	sources := newContentSourcesStub()
+	registry := NewRegistry(NewValidator())
+	if err := RegisterDataShortcodes(registry, DataSources{ContentTypes: sources, Content: sources, Entries: sources.entryReader()}, []string{"content"}); err != nil {
+		t.Fatalf("register data shortcodes: %v", err)
+	}
+	tracker := NewDependencyTracker()
+	renderer := NewRenderer(registry, NewValidator(), WithRendererCache(newMemoryCache()), WithRendererDependencies(tracker))
+
+	ctx := interfaces.ShortcodeContext{Context: context.Background(), Locale: "en"}
+	params := map[string]any{"type": "article", "slug": "hello"}
+	render := func() string {
+		t.Helper()
+		output, err := renderer.Render(ctx, "content", params, "")
+		if err != nil {
+			t.Fatalf("render content: %v", err)
+		}
+		return string(output)
+	}
+
+	if !strings.Contains(render(), "Hello") {
+		t.Fatalf("expected initial title")
+	}
+	sources.entries[0].Translations[0].Title = "Hello again"
+	if !strings.Contains(render(), ">Hello<") {
+		t.Fatalf("expected cached output before invalidation")
+	}
+
+	if err := tracker.Notify(context.Background(), lifecycle.Event{ResourceType: "page"}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if !strings.Contains(render(), ">Hello<") {
+		t.Fatalf("expected unrelated events to keep cached output")
+	}
+
+	if err := tracker.Notify(context.Background(), lifecycle.Event{ResourceType: "content", ContentTypeSlug: "Article"}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if !strings.Contains(render(), "Hello again") {
+		t.Fatalf("expected content event to invalidate cached output")
+	}
+	if sources.lookupCalls != 2 || sources.listCalls != 0 {
+		t.Fatalf("expected two slug lookups and no listing, got %d lookups and %d lists", sources.lookupCalls, sources.listCalls)
+	}
+}
+
+func TestDataShortcodeCacheKeysMatchEmittedResourceTypes(t *testing.T) {
+	navigation := &mutableNavigationStub{label: "Home"}
+	mediaStub := &mediaResolverStub{url: "/v1.png"}
+	registry := NewRegistry(NewValidator())
+	if err := RegisterDataShortcodes(registry, DataSources{Menus: navigation, Media: mediaStub}, []string{"menu", "media"}); err != nil {
+		t.Fatalf("register data shortcodes: %v", err)
+	}
+	tracker := NewDependencyTracker()
+	renderer := NewRenderer(registry, NewValidator(), WithRendererCache(newMemoryCache()), WithRendererDependencies(tracker))
+	ctx := interfaces.ShortcodeContext{Context: context.Background()}
+	render := func(name string, params map[string]any) string {
+		t.Helper()
+		output, err := renderer.Render(ctx, name, params, "")
+		if err != nil {
+			t.Fatalf("render %s: %v", name, err)
+		}
+		return string(output)
+	}
+	menuParams := map[string]any{"location": "primary"}
+	mediaParams := map[string]any{"id": "hero"}
+
+	render("menu", menuParams)
+	render("media", mediaParams)
+	navigation.label = "Start"
+	mediaStub.url = "/v2.png"
+
+	if !strings.Contains(render("media", mediaParams), "/v2.png") {
+		t.Fatalf("expected media output not to be cached")
+	}
+
+	if !strings.Contains(render("menu", menuParams), "Home") {
+		t.Fatalf("expected cached menu before invalidation")
+	}
+	if err := tracker.Notify(context.Background(), lifecycle.Event{ResourceType: "menu_item", RecordID: uuid.NewString()}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if !strings.Contains(render("menu", menuParams), "Start") {
+		t.Fatalf("expected menu item event to invalidate cached menu")
+	}
+}
+
+type mutableNavigationStub struct {
+	label string
+}
+
+func (n *mutableNavigationStub) ResolveNavigation(context.Context, string, string, ...string) ([]NavigationItem, error) {
+	return []NavigationItem{{Label: n.label, URL: "/"}}, nil
+}
+
+type mediaResolverStub struct {
+	url string
+}
+
+func (m *mediaResolverStub) ResolveBindings(_ context.Context, bindings media.BindingSet, _ media.ResolveOptions) (map[string][]*media.Attachment, error) {
+	out := make(map[string][]*media.Attachment, len(bindings))
+	for slot := range bindings {
+		out[slot] = []*media.Attachment{{Source: &media.Resource{URL: m.url}}}
+	}
+	return out, nil
+}
+
+type contentSourcesStub struct {
+	contentType  *content.ContentType
+	entries      []*content.Content
+	lastEnv      string
+	listCalls    int
+	listOpts     []content.ContentListOption
+	lookupCalls  int
+	lookupTypeID uuid.UUID
+	lookupEnv    string
+}
+
+func newContentSourcesStub() *contentSourcesStub {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	summary := "Resumen <b>"
+	return &contentSourcesStub{
+		contentType: &content.ContentType{ID: uuid.New(), Slug: "article", EnvironmentID: uuid.New()},
+		entries: []*content.Content{
+			{
+				Slug:        "hello",
+				Status:      "published",
+				PublishedAt: &now,
+				Translations: []*content.ContentTranslation{
+					{Title: "Hello", Locale: &content.Locale{Code: "en"}},
+					{Title: "Hola", Summary: &summary, Locale: &content.Locale{Code: "es"}},
+				},
+			},
+			{Slug: "newer", Status: "published", PublishedAt: &later},
+			{Slug: "draft", Status: "draft", UpdatedAt: later.Add(time.Hour)},
+		},
+	}
+}
+
+func (s *contentSourcesStub) GetBySlug(_ context.Context, slug string, env ...string) (*content.ContentType, error) {
+	if len(env) > 0 {
+		s.lastEnv = env[0]
+	}
+	if slug != s.contentType.Slug {
+		return nil, errors.New("content type not found")
+	}
+	return s.contentType, nil
+}
+
+// List applies the status, order and limit options the way the content
+// repository does, so tests fail when the shortcode stops pushing them down.
+func (s *contentSourcesStub) List(_ context.Context, opts ...content.ContentListOption) ([]*content.Content, error) {
+	s.listCalls++
+	s.listOpts = opts
+	out := make([]*content.Content, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if slices.Contains(opts, content.WithStatus("published")) && entry.Status != "published" {
+			continue
+		}
+		out = append(out, entry)
+	}
+	if slices.Contains(opts, content.WithRecentFirst()) {
+		sort.SliceStable(out, func(i, j int) bool {
+			return out[i].PublishedAt != nil && (out[j].PublishedAt == nil || out[i].PublishedAt.After(*out[j].PublishedAt))
+		})
+	}
+	for limit := 1; limit < len(out); limit++ {
+		if slices.Contains(opts, content.WithLimit(limit)) {
+			out = out[:limit]
+		}
+	}
+	return out, nil
+}
+
+func (s *contentSourcesStub) entryReader() ContentReader {
+	return contentEntriesStub{sources: s}
+}
+
+// contentEntriesStub resolves entries by slug from the shared stub; it is a
+// separate type because content types are also looked up by GetBySlug.
+type contentEntriesStub struct {
+	sources *contentSourcesStub
+}
+
+func (s contentEntriesStub) GetBySlug(_ context.Context, slug string, contentTypeID uuid.UUID, env ...string) (*content.Content, error) {
+	s.sources.lookupCalls++
+	s.sources.lookupTypeID = contentTypeID
+	if len(env) > 0 {
+		s.sources.lookupEnv = env[0]
+	}
+	for _, entry := range s.sources.entries {
+		if entry.Slug == slug {
+			return entry, nil
+		}
+	}
+	return nil, &content.NotFoundError{Resource: "content", Key: slug}
+}
+
+type navigationStub struct {
+	nodes []NavigationItem
+}
+
+func (n navigationStub) ResolveNavigation(context.Context, string, string, ...string) ([]NavigationItem, error) {
+	return n.nodes, nil
+}
@@ -0,0 +1,88 @@
+package shortcode
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+)
+
+// DependencyTracker versions the CMS records that cached shortcode output
+// depends on. Renderers fold the current versions into cache keys, so bumping a
+// dependency makes every cached render that used it unreachable.
+type DependencyTracker struct {
+	mu       sync.RWMutex
+	versions map[string]uint64
+}
+
+// NewDependencyTracker constructs an empty dependency tracker.
+func NewDependencyTracker() *DependencyTracker {
+	return &DependencyTracker{versions: make(map[string]uint64)}
+}
+
+// Invalidate bumps the version of each supplied dependency key.
+func (t *DependencyTracker) Invalidate(keys ...string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range keys {
+		if normalized := normalizeDependency(key); normalized != "" {
+			t.versions[normalized]++
+		}
+	}
+}
+
+// Fingerprint returns a stable string describing the current version of keys.
+func (t *DependencyTracker) Fingerprint(keys []string) string {
+	if t == nil || len(keys) == 0 {
+		return ""
+	}
+	normalized := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if value := normalizeDependency(key); value != "" {
+			normalized = append(normalized, value)
+		}
+	}
+	sort.Strings(normalized)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var builder strings.Builder
+	for _, key := range normalized {
+		builder.WriteString(key)
+		builder.WriteString("@")
+		builder.WriteString(strconv.FormatUint(t.versions[key], 10))
+		builder.WriteString(";")
+	}
+	return builder.String()
+}
+
+// Notify implements lifecycle.Hook. Each event invalidates its resource type
+// and, when present, the resource type scoped to the content type slug and to
+// the record ID.
+func (t *DependencyTracker) Notify(_ context.Context, event lifecycle.Event) error {
+	resource := strings.TrimSpace(event.ResourceType)
+	if resource == "" {
+		return nil
+	}
+	keys := []string{resource}
+	if slug := strings.TrimSpace(event.ContentTypeSlug); slug != "" {
+		keys = append(keys, resource+":"+slug)
+	}
+	if id := strings.TrimSpace(event.RecordID); id != "" {
+		keys = append(keys, resource+":"+id)
+	}
+	t.Invalidate(keys...)
+	return nil
+}
+
+func normalizeDependency(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+var _ lifecycle.Hook = (*DependencyTracker)(nil)
@@ -7,4 +7,15 @@ var (
 	ErrDuplicateDefinition = errors.New("shortcode: duplicate definition")
 	// ErrInvalidDefinition occurs when a definition fails schema validation.
 	ErrInvalidDefinition = errors.New("shortcode: invalid definition")
+	// ErrDomainNotAllowed occurs when a URL parameter points outside the configured domain allowlist.
+	ErrDomainNotAllowed = errors.New("shortcode: domain not allowed")
+	// ErrRateLimited occurs when an actor exceeds the per-minute render limit for a shortcode.
+	ErrRateLimited = errors.New("shortcode: rate limit exceeded")
+)
+
+// Security rule names reported through ShortcodeSecurityMetrics.IncrementSecurityViolation.
+const (
+	SecurityRuleURL       = "url"
+	SecurityRuleRateLimit = "rate_limit"
+	SecurityRuleSanitize  = "sanitize"
 )
@@ -18,3 +18,5 @@ func (noopMetrics) ObserveRenderDuration(string, time.Duration) {}
 func (noopMetrics) IncrementRenderError(string) {}
 
 func (noopMetrics) IncrementCacheHit(string) {}
+
+func (noopMetrics) IncrementSecurityViolation(string, string) {}
@@ -0,0 +1,75 @@
+package shortcode
+
+import (
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/permissions"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+// rateLimiterPruneSize bounds how many windows accumulate before expired ones are swept.
+const rateLimiterPruneSize = 1024
+
+// rateLimiter enforces a fixed one-minute window per shortcode and actor.
+type rateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	now       func() time.Time
+	windows   map[string]*rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		perMinute: perMinute,
+		now:       time.Now,
+		windows:   make(map[string]*rateWindow),
+	}
+}
+
+// Allow records a render attempt and reports whether it fits in the current window.
+func (l *rateLimiter) Allow(shortcode, actor string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	key := shortcode + "|" + actor
+	window, ok := l.windows[key]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		if len(l.windows) >= rateLimiterPruneSize {
+			l.prune(now)
+		}
+		l.windows[key] = &rateWindow{start: now, count: 1}
+		return true
+	}
+	if window.count >= l.perMinute {
+		return false
+	}
+	window.count++
+	return true
+}
+
+// prune drops expired windows so idle actors do not accumulate.
+func (l *rateLimiter) prune(now time.Time) {
+	for key, window := range l.windows {
+		if now.Sub(window.start) >= time.Minute {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// rateLimitActor returns the actor a render is counted against: the context
+// actor, else the actor stored on the request context by host middleware. It
+// returns "" for unidentified renders, which are not rate limited, so that one
+// client cannot exhaust a window shared by every anonymous visitor.
+func rateLimitActor(ctx interfaces.ShortcodeContext) string {
+	if ctx.Actor != "" {
+		return ctx.Actor
+	}
+	return permissions.ActorIDFromContext(ctx.Context)
+}
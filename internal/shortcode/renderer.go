@@ -21,6 +21,8 @@ type Renderer struct {
 	sanitizer interfaces.ShortcodeSanitizer
 	cache     interfaces.CacheProvider
 	metrics   interfaces.ShortcodeMetrics
+	limiter   *rateLimiter
+	deps      *DependencyTracker
 }
 
 // RendererOption configures the renderer instance.
@@ -49,6 +51,27 @@ func WithRendererMetrics(metrics interfaces.ShortcodeMetrics) RendererOption {
 	}
 }
 
+// WithRendererDependencies folds dependency versions into cache keys for
+// definitions declaring CacheDependencies.
+func WithRendererDependencies(tracker *DependencyTracker) RendererOption {
+	return func(r *Renderer) {
+		r.deps = tracker
+	}
+}
+
+// WithRendererRateLimit caps renders per shortcode and actor to perMinute.
+// Renders without an actor (see ShortcodeProcessOptions.Actor) are not limited.
+// Values <= 0 disable rate limiting.
+func WithRendererRateLimit(perMinute int) RendererOption {
+	return func(r *Renderer) {
+		if perMinute > 0 {
+			r.limiter = newRateLimiter(perMinute)
+		} else {
+			r.limiter = nil
+		}
+	}
+}
+
 // NewRenderer constructs a renderer using the provided registry and validator.
 func NewRenderer(registry interfaces.ShortcodeRegistry, validator *Validator, opts ...RendererOption) *Renderer {
 	r := &Renderer{
@@ -63,7 +86,10 @@ func NewRenderer(registry interfaces.ShortcodeRegistry, validator *Validator, op
 	return r
 }
 
-// Render executes the shortcode and returns sanitised HTML.
+// Render executes the shortcode and returns sanitised HTML. URL parameters are
+// checked by the sanitizer, renders are rate limited per actor when configured,
+// and the definition's CSP sources are merged into ctx.CSP once the render is
+// allowed.
 //
 //nolint:gocyclo // Render coordinates validation, cache, execution, and sanitizer steps.
 func (r *Renderer) Render(ctx interfaces.ShortcodeContext, shortcode string, params map[string]any, inner string) (template.HTML, error) {
@@ -77,18 +103,46 @@ func (r *Renderer) Render(ctx interfaces.ShortcodeContext, shortcode string, par
 		return "", err
 	}
 
+	sanitizer := r.resolveSanitizer(ctx)
+	if sanitizer != nil {
+		if err := validateURLParams(sanitizer, def, coerced); err != nil {
+			r.recordViolation(shortcode, SecurityRuleURL)
+			return "", err
+		}
+	}
 	cacheProvider := r.resolveCache(ctx)
+	if ctx.CSP != nil && ctx.CSP.Nonce() != "" {
+		// The nonce changes per response, so output rendered under it must not
+		// be served to other requests.
+		cacheProvider = nil
+	}
 	cacheKey := ""
 	if cacheProvider != nil && def.CacheTTL > 0 {
-		cacheKey = r.buildCacheKey(ctx.Locale, shortcode, coerced, inner)
+		cacheKey = r.buildCacheKey(ctx, def, coerced, inner)
 		if cached, err := cacheProvider.Get(r.background(ctx.Context), cacheKey); err == nil {
 			if cachedHTML, ok := cached.(string); ok {
 				r.metrics.IncrementCacheHit(shortcode)
+				if ctx.CSP != nil {
+					ctx.CSP.Add(def.CSP)
+				}
 				return template.HTML(cachedHTML), nil // #nosec G203 -- cache stores HTML after shortcode sanitizer processing.
 			}
 		}
 	}
 
+	// Cache hits are free, so only renders count against the limit. Trusted
+	// server-side renders and renders without an identified actor are never
+	// limited.
+	if r.limiter != nil && !ctx.Trusted {
+		if actor := rateLimitActor(ctx); actor != "" && !r.limiter.Allow(def.Name, actor) {
+			r.recordViolation(shortcode, SecurityRuleRateLimit)
+			return "", fmt.Errorf("%w: %s", ErrRateLimited, shortcode)
+		}
+	}
+	if ctx.CSP != nil {
+		ctx.CSP.Add(def.CSP)
+	}
+
 	var output string
 	if def.Handler != nil {
 		result, err := def.Handler(ctx, coerced, inner)
@@ -97,7 +151,7 @@ func (r *Renderer) Render(ctx interfaces.ShortcodeContext, shortcode string, par
 		}
 		output = string(result)
 	} else if def.Template != "" {
-		rendered, err := r.renderTemplate(def, coerced, inner)
+		rendered, err := r.renderTemplate(def, coerced, inner, ctx.CSP)
 		if err != nil {
 			return "", err
 		}
@@ -106,10 +160,10 @@ func (r *Renderer) Render(ctx interfaces.ShortcodeContext, shortcode string, par
 		return "", fmt.Errorf("shortcode: definition %s has no handler or template", shortcode)
 	}
 
-	sanitizer := r.resolveSanitizer(ctx)
 	if sanitizer != nil {
 		sanitised, err := sanitizer.Sanitize(output)
 		if err != nil {
+			r.recordViolation(shortcode, SecurityRuleSanitize)
 			return "", err
 		}
 		output = sanitised
@@ -124,6 +178,14 @@ func (r *Renderer) Render(ctx interfaces.ShortcodeContext, shortcode string, par
 	return template.HTML(output), nil // #nosec G203 -- output has passed through the configured shortcode sanitizer.
 }
 
+// recordViolation reports a blocked render when the metrics recorder counts
+// security violations.
+func (r *Renderer) recordViolation(shortcode, rule string) {
+	if metrics, ok := r.metrics.(interfaces.ShortcodeSecurityMetrics); ok {
+		metrics.IncrementSecurityViolation(shortcode, rule)
+	}
+}
+
 // RenderAsync executes Render in a separate goroutine.
 func (r *Renderer) RenderAsync(ctx interfaces.ShortcodeContext, shortcode string, params map[string]any, inner string) (<-chan template.HTML, <-chan error) {
 	outputCh := make(chan template.HTML, 1)
@@ -144,10 +206,13 @@ func (r *Renderer) RenderAsync(ctx interfaces.ShortcodeContext, shortcode string
 	return outputCh, errCh
 }
 
-func (r *Renderer) renderTemplate(def interfaces.ShortcodeDefinition, params map[string]any, inner string) (string, error) {
-	data := make(map[string]any, len(params)+1)
+func (r *Renderer) renderTemplate(def interfaces.ShortcodeDefinition, params map[string]any, inner string, csp interfaces.ShortcodeCSP) (string, error) {
+	data := make(map[string]any, len(params)+2)
 	maps.Copy(data, params)
 	data["Inner"] = template.HTML(inner) // #nosec G203 -- rendered shortcode output is sanitized before being returned.
+	if csp != nil {
+		data["CSPNonce"] = csp.Nonce()
+	}
 
 	tmpl, err := template.New(def.Name).Parse(def.Template)
 	if err != nil {
@@ -161,6 +226,24 @@ func (r *Renderer) renderTemplate(def interfaces.ShortcodeDefinition, params map
 	return buf.String(), nil
 }
 
+// validateURLParams runs URL-typed parameters through the sanitizer so scheme
+// and domain rules apply before the shortcode executes.
+func validateURLParams(sanitizer interfaces.ShortcodeSanitizer, def interfaces.ShortcodeDefinition, params map[string]any) error {
+	for _, param := range def.Schema.Params {
+		if param.Type != interfaces.ShortcodeParamURL {
+			continue
+		}
+		value, ok := params[param.Name].(string)
+		if !ok {
+			continue
+		}
+		if err := sanitizer.ValidateURL(value); err != nil {
+			return fmt.Errorf("shortcode: %s parameter %q: %w", def.Name, param.Name, err)
+		}
+	}
+	return nil
+}
+
 func (r *Renderer) resolveSanitizer(ctx interfaces.ShortcodeContext) interfaces.ShortcodeSanitizer {
 	if ctx.Sanitizer != nil {
 		return ctx.Sanitizer
@@ -175,7 +258,7 @@ func (r *Renderer) resolveCache(ctx interfaces.ShortcodeContext) interfaces.Cach
 	return r.cache
 }
 
-func (r *Renderer) buildCacheKey(locale, shortcode string, params map[string]any, inner string) string {
+func (r *Renderer) buildCacheKey(ctx interfaces.ShortcodeContext, def interfaces.ShortcodeDefinition, params map[string]any, inner string) string {
 	keys := make([]string, 0, len(params))
 	for key := range params {
 		keys = append(keys, key)
@@ -183,9 +266,17 @@ func (r *Renderer) buildCacheKey(locale, shortcode string, params map[string]any
 	sort.Strings(keys)
 
 	var builder strings.Builder
-	builder.WriteString(locale)
+	builder.WriteString(ctx.Locale)
 	builder.WriteString("|")
-	builder.WriteString(shortcode)
+	builder.WriteString(def.Name)
+	if ctx.EnvironmentKey != "" {
+		builder.WriteString("|env=")
+		builder.WriteString(ctx.EnvironmentKey)
+	}
+	if def.CacheDependencies != nil {
+		builder.WriteString("|deps=")
+		builder.WriteString(r.deps.Fingerprint(def.CacheDependencies(params)))
+	}
 	for _, key := range keys {
 		builder.WriteString("|")
 		builder.WriteString(key)
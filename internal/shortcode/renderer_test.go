@@ -2,6 +2,7 @@ package shortcode
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html/template"
 	"strings"
@@ -195,3 +196,183 @@ func TestRenderer_EndToEnd(t *testing.T) {
 		t.Fatalf("expected figure markup in nested shortcode output: %s", output)
 	}
 }
+
+func TestRenderer_URLParamsRespectAllowedDomains(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	def := interfaces.ShortcodeDefinition{
+		Name: "embed",
+		Schema: interfaces.ShortcodeSchema{
+			Params: []interfaces.ShortcodeParam{{Name: "src", Type: interfaces.ShortcodeParamURL, Required: true}},
+		},
+		Template: `<iframe src="{{ .src }}"></iframe>`,
+	}
+	if err := registry.Register(def); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	metrics := newMetricsStub()
+	renderer := NewRenderer(registry, NewValidator(),
+		WithRendererSanitizer(NewSanitizer(WithAllowedDomains("example.com"))),
+		WithRendererMetrics(metrics),
+	)
+
+	if _, err := renderer.Render(interfaces.ShortcodeContext{}, "embed", map[string]any{"src": "https://cdn.example.com/player"}, ""); err != nil {
+		t.Fatalf("Render() allowed subdomain error: %v", err)
+	}
+	_, err := renderer.Render(interfaces.ShortcodeContext{}, "embed", map[string]any{"src": "https://evil.test/player"}, "")
+	if !errors.Is(err, ErrDomainNotAllowed) {
+		t.Fatalf("expected ErrDomainNotAllowed, got %v", err)
+	}
+	if got := metrics.violationCount("embed", SecurityRuleURL); got != 1 {
+		t.Fatalf("expected 1 url violation, got %d", got)
+	}
+}
+
+func TestRenderer_RateLimitPerActor(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	if err := registry.Register(interfaces.ShortcodeDefinition{Name: "ping", Template: "<p>pong</p>"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	metrics := newMetricsStub()
+	renderer := NewRenderer(registry, NewValidator(), WithRendererRateLimit(2), WithRendererMetrics(metrics))
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	renderer.limiter.now = func() time.Time { return now }
+
+	alice := interfaces.ShortcodeContext{Actor: "alice"}
+	for i := 0; i < 2; i++ {
+		if _, err := renderer.Render(alice, "ping", nil, ""); err != nil {
+			t.Fatalf("Render() call %d error: %v", i, err)
+		}
+	}
+	if _, err := renderer.Render(alice, "ping", nil, ""); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if got := metrics.violationCount("ping", SecurityRuleRateLimit); got != 1 {
+		t.Fatalf("expected 1 rate limit violation, got %d", got)
+	}
+	if _, err := renderer.Render(interfaces.ShortcodeContext{Actor: "bob"}, "ping", nil, ""); err != nil {
+		t.Fatalf("expected separate actor budget, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := renderer.Render(alice, "ping", nil, ""); err != nil {
+		t.Fatalf("expected window reset, got %v", err)
+	}
+}
+
+func TestRenderer_RateLimitExemptsTrustedRenders(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	if err := registry.Register(interfaces.ShortcodeDefinition{Name: "ping", Template: "<p>pong</p>"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	renderer := NewRenderer(registry, NewValidator(), WithRendererRateLimit(1))
+
+	trusted := interfaces.ShortcodeContext{Context: context.Background(), Trusted: true}
+	for i := 0; i < 3; i++ {
+		if _, err := renderer.Render(trusted, "ping", nil, ""); err != nil {
+			t.Fatalf("expected trusted render %d to bypass the limit, got %v", i, err)
+		}
+	}
+	if _, err := renderer.Render(interfaces.ShortcodeContext{Actor: "alice"}, "ping", nil, ""); err != nil {
+		t.Fatalf("expected trusted renders not to count against any window, got %v", err)
+	}
+}
+
+func TestRenderer_RateLimitSkipsCacheHitsAndUnidentifiedRenders(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	if err := registry.Register(interfaces.ShortcodeDefinition{Name: "cached", Template: "<p>cached</p>", CacheTTL: time.Hour}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := registry.Register(interfaces.ShortcodeDefinition{Name: "ping", Template: "<p>pong</p>"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	renderer := NewRenderer(registry, NewValidator(), WithRendererRateLimit(1), WithRendererCache(newMemoryCache()))
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	renderer.limiter.now = func() time.Time { return now }
+
+	alice := interfaces.ShortcodeContext{Actor: "alice"}
+	for i := 0; i < 3; i++ {
+		if _, err := renderer.Render(alice, "cached", nil, ""); err != nil {
+			t.Fatalf("expected cache hit %d to bypass the limit, got %v", i, err)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := renderer.Render(interfaces.ShortcodeContext{Context: context.Background()}, "ping", nil, ""); err != nil {
+			t.Fatalf("expected unidentified render %d not to be limited, got %v", i, err)
+		}
+	}
+
+	// Host middleware stores the actor on the request context.
+	requestCtx := context.WithValue(context.Background(), "actor_id", "bob") //nolint:staticcheck // mirrors host middleware keys
+	if _, err := renderer.Render(interfaces.ShortcodeContext{Context: requestCtx}, "ping", nil, ""); err != nil {
+		t.Fatalf("expected context actor to get its own window, got %v", err)
+	}
+	if _, err := renderer.Render(interfaces.ShortcodeContext{Context: requestCtx}, "ping", nil, ""); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected context actor to be limited, got %v", err)
+	}
+}
+
+func TestRenderer_CSPAddedOnlyForAllowedUncachedRenders(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	if err := registry.Register(interfaces.ShortcodeDefinition{
+		Name:     "frame",
+		Template: "<p>frame</p>",
+		CacheTTL: time.Hour,
+		CSP:      interfaces.ShortcodeCSPSources{FrameSrc: []string{"https://frames.example.com"}},
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	cache := newMemoryCache()
+	renderer := NewRenderer(registry, NewValidator(), WithRendererRateLimit(1), WithRendererCache(cache))
+
+	csp := NewCSP("abc")
+	ctx := interfaces.ShortcodeContext{Actor: "alice", CSP: csp}
+	if _, err := renderer.Render(ctx, "frame", nil, ""); err != nil {
+		t.Fatalf("first render: %v", err)
+	}
+	if len(cache.store) != 0 {
+		t.Fatalf("expected output rendered under a nonce not to be cached, got %d entries", len(cache.store))
+	}
+
+	limited := NewCSP("def")
+	if _, err := renderer.Render(interfaces.ShortcodeContext{Actor: "alice", CSP: limited}, "frame", nil, ""); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if header := limited.Header(); header != "script-src 'nonce-def'" {
+		t.Fatalf("expected rate limited render not to add sources, got %q", header)
+	}
+	if header := csp.Header(); header != "script-src 'nonce-abc'; frame-src https://frames.example.com" {
+		t.Fatalf("unexpected header %q", header)
+	}
+}
+
+type basicMetrics struct{}
+
+func (m *basicMetrics) ObserveRenderDuration(string, time.Duration) {}
+
+func (m *basicMetrics) IncrementRenderError(string) {}
+
+func (m *basicMetrics) IncrementCacheHit(string) {}
+
+func TestRenderer_SecurityViolationsNeedOptionalMetrics(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	if err := registry.Register(interfaces.ShortcodeDefinition{Name: "ping", Template: "<p>pong</p>"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	metrics := &basicMetrics{}
+	if _, ok := any(metrics).(interfaces.ShortcodeSecurityMetrics); ok {
+		t.Fatal("expected basic metrics to omit security violations")
+	}
+	renderer := NewRenderer(registry, NewValidator(), WithRendererRateLimit(1), WithRendererMetrics(metrics))
+	ctx := interfaces.ShortcodeContext{Actor: "alice"}
+	if _, err := renderer.Render(ctx, "ping", nil, ""); err != nil {
+		t.Fatalf("first render: %v", err)
+	}
+	if _, err := renderer.Render(ctx, "ping", nil, ""); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+}
@@ -11,17 +11,39 @@ import (
 // Sanitizer is a conservative implementation that rejects inline script tags and enforces URL schemes.
 type Sanitizer struct {
 	allowedSchemes map[string]struct{}
+	allowedDomains []string
+}
+
+// SanitizerOption configures the sanitizer instance.
+type SanitizerOption func(*Sanitizer)
+
+// WithAllowedDomains restricts absolute URLs to the supplied hosts. A domain
+// also matches its subdomains; an empty list allows any host.
+func WithAllowedDomains(domains ...string) SanitizerOption {
+	return func(s *Sanitizer) {
+		for _, domain := range domains {
+			normalized := strings.Trim(strings.ToLower(strings.TrimSpace(domain)), ".")
+			normalized = strings.TrimPrefix(normalized, "*.")
+			if normalized != "" {
+				s.allowedDomains = append(s.allowedDomains, normalized)
+			}
+		}
+	}
 }
 
 // NewSanitizer returns a sanitizer allowing http/https URLs.
-func NewSanitizer() *Sanitizer {
-	return &Sanitizer{
+func NewSanitizer(opts ...SanitizerOption) *Sanitizer {
+	s := &Sanitizer{
 		allowedSchemes: map[string]struct{}{
 			"http":  {},
 			"https": {},
 			"":      {},
 		},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // NoOpSanitizer bypasses all sanitisation checks and returns input verbatim.
@@ -62,9 +84,25 @@ func (s *Sanitizer) ValidateURL(raw string) error {
 	if _, ok := s.allowedSchemes[strings.ToLower(parsed.Scheme)]; !ok {
 		return fmt.Errorf("shortcode: url scheme %q not permitted", parsed.Scheme)
 	}
+	if host := parsed.Hostname(); host != "" && !s.domainAllowed(host) {
+		return fmt.Errorf("%w: %s", ErrDomainNotAllowed, host)
+	}
 	return nil
 }
 
+func (s *Sanitizer) domainAllowed(host string) bool {
+	if len(s.allowedDomains) == 0 {
+		return true
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, domain := range s.allowedDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateAttributes rejects inline event handlers like onload/onerror.
 func (s *Sanitizer) ValidateAttributes(attrs map[string]any) error {
 	for key := range attrs {
@@ -2,6 +2,8 @@ package shortcode
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 	"strings"
@@ -26,6 +28,7 @@ type Service struct {
 	logger           interfaces.Logger
 	metrics          interfaces.ShortcodeMetrics
 	wordpressEnabled bool
+	cspEnabled       bool
 }
 
 // ServiceOption customises service behaviour.
@@ -38,6 +41,13 @@ func WithWordPressSyntax(enabled bool) ServiceOption {
 	}
 }
 
+// WithCSP enables per-call nonce generation and CSP source collection in ProcessWithCSP.
+func WithCSP(enabled bool) ServiceOption {
+	return func(s *Service) {
+		s.cspEnabled = enabled
+	}
+}
+
 // WithDefaultSanitizer overrides the fallback sanitizer used when none is supplied at call time.
 func WithDefaultSanitizer(sanitizer interfaces.ShortcodeSanitizer) ServiceOption {
 	return func(s *Service) {
@@ -112,6 +122,30 @@ func NewService(registry interfaces.ShortcodeRegistry, renderer interfaces.Short
 
 // Process renders any shortcodes found within the content string, returning the resulting HTML.
 func (s *Service) Process(ctx context.Context, content string, opts interfaces.ShortcodeProcessOptions) (string, error) {
+	return s.process(ctx, content, opts, nil)
+}
+
+// ProcessWithCSP renders shortcodes like Process and also returns the CSP
+// nonce and script-src/frame-src sources required by the rendered output.
+// Sources are added to opts.CSP when set. The returned CSP is nil when CSP
+// support is disabled and no policy was supplied.
+func (s *Service) ProcessWithCSP(ctx context.Context, content string, opts interfaces.ShortcodeProcessOptions) (*interfaces.ShortcodeProcessResult, error) {
+	csp := opts.CSP
+	if csp == nil && s.cspEnabled {
+		nonce, err := generateNonce()
+		if err != nil {
+			return nil, err
+		}
+		csp = NewCSP(nonce)
+	}
+	output, err := s.process(ctx, content, opts, csp)
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.ShortcodeProcessResult{Content: output, CSP: csp}, nil
+}
+
+func (s *Service) process(ctx context.Context, content string, opts interfaces.ShortcodeProcessOptions, csp interfaces.ShortcodeCSP) (string, error) {
 	if strings.TrimSpace(content) == "" {
 		return content, nil
 	}
@@ -140,10 +174,14 @@ func (s *Service) Process(ctx context.Context, content string, opts interfaces.S
 	}
 
 	shortcodeCtx := interfaces.ShortcodeContext{
-		Context:   ctx,
-		Locale:    opts.Locale,
-		Cache:     opts.Cache,
-		Sanitizer: opts.Sanitizer,
+		Context:        ctx,
+		Locale:         opts.Locale,
+		Cache:          opts.Cache,
+		Sanitizer:      opts.Sanitizer,
+		EnvironmentKey: opts.EnvironmentKey,
+		Actor:          opts.Actor,
+		Trusted:        opts.Trusted,
+		CSP:            csp,
 	}
 	if shortcodeCtx.Context == nil {
 		shortcodeCtx.Context = context.Background()
@@ -231,6 +269,17 @@ func (s *Service) Registry() interfaces.ShortcodeRegistry {
 
 // Ensure Service complies with interfaces.ShortcodeService.
 var _ interfaces.ShortcodeService = (*Service)(nil)
+var _ interfaces.ShortcodeCSPService = (*Service)(nil)
+
+// generateNonce uses the URL-safe alphabet so templates can embed the nonce in
+// attributes without HTML escaping.
+func generateNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("shortcode: generate csp nonce: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
 
 type noOpService struct{}
 
@@ -243,6 +292,10 @@ func (noOpService) Process(_ context.Context, content string, _ interfaces.Short
 	return content, nil
 }
 
+func (noOpService) ProcessWithCSP(_ context.Context, content string, opts interfaces.ShortcodeProcessOptions) (*interfaces.ShortcodeProcessResult, error) {
+	return &interfaces.ShortcodeProcessResult{Content: content, CSP: opts.CSP}, nil
+}
+
 func (noOpService) Render(_ interfaces.ShortcodeContext, _ string, _ map[string]any, _ string) (template.HTML, error) {
 	return template.HTML(""), nil
 }
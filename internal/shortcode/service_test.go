@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"html/template"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -139,17 +140,19 @@ func (p stubParser) Extract(string) (string, []interfaces.ParsedShortcode, error
 }
 
 type metricsStub struct {
-	mu        sync.Mutex
-	durations map[string][]time.Duration
-	errors    map[string]int
-	cacheHits map[string]int
+	mu         sync.Mutex
+	durations  map[string][]time.Duration
+	errors     map[string]int
+	cacheHits  map[string]int
+	violations map[string]int
 }
 
 func newMetricsStub() *metricsStub {
 	return &metricsStub{
-		durations: map[string][]time.Duration{},
-		errors:    map[string]int{},
-		cacheHits: map[string]int{},
+		durations:  map[string][]time.Duration{},
+		errors:     map[string]int{},
+		cacheHits:  map[string]int{},
+		violations: map[string]int{},
 	}
 }
 
@@ -171,6 +174,18 @@ func (m *metricsStub) IncrementCacheHit(shortcode string) {
 	m.cacheHits[shortcode]++
 }
 
+func (m *metricsStub) IncrementSecurityViolation(shortcode string, rule string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.violations[shortcode+":"+rule]++
+}
+
+func (m *metricsStub) violationCount(shortcode, rule string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.violations[shortcode+":"+rule]
+}
+
 func (m *metricsStub) durationCount(shortcode string) int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -188,3 +203,40 @@ func (m *metricsStub) cacheHitCount(shortcode string) int {
 	defer m.mu.Unlock()
 	return m.cacheHits[shortcode]
 }
+
+func TestServiceProcessWithCSPCollectsSources(t *testing.T) {
+	registry := NewRegistry(NewValidator())
+	if err := RegisterBuiltIns(registry, []string{"youtube"}); err != nil {
+		t.Fatalf("register built-ins: %v", err)
+	}
+	script := interfaces.ShortcodeDefinition{
+		Name:     "widget",
+		CSP:      interfaces.ShortcodeCSPSources{ScriptSrc: []string{"https://js.example.com"}},
+		Template: `<div data-nonce="{{ .CSPNonce }}"></div>`,
+	}
+	if err := registry.Register(script); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	service := NewService(registry, NewRenderer(registry, NewValidator()), WithCSP(true))
+	result, err := service.ProcessWithCSP(context.Background(), `{{< youtube id="abc" >}}{{< widget >}}`, interfaces.ShortcodeProcessOptions{})
+	if err != nil {
+		t.Fatalf("ProcessWithCSP returned error: %v", err)
+	}
+	if result.CSP == nil || result.CSP.Nonce() == "" {
+		t.Fatalf("expected nonce to be generated, got %+v", result.CSP)
+	}
+	if !strings.Contains(result.Content, `data-nonce="`+result.CSP.Nonce()+`"`) {
+		t.Fatalf("expected nonce exposed to templates, got %s", result.Content)
+	}
+	want := "script-src 'nonce-" + result.CSP.Nonce() + "' https://js.example.com; frame-src https://www.youtube.com"
+	if header := result.CSP.Header(); header != want {
+		t.Fatalf("unexpected CSP header %q, want %q", header, want)
+	}
+
+	disabled := NewService(registry, NewRenderer(registry, NewValidator()))
+	plain, err := disabled.ProcessWithCSP(context.Background(), `{{< youtube id="abc" >}}`, interfaces.ShortcodeProcessOptions{})
+	if err != nil || plain.CSP != nil {
+		t.Fatalf("expected no CSP when disabled, got %+v (%v)", plain, err)
+	}
+}
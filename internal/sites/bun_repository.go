@@ -0,0 +1,117 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goliatone/go-errors"
+	repository "github.com/goliatone/go-repository-bun"
+	"github.com/goliatone/go-repository-cache/cache"
+	repositorycache "github.com/goliatone/go-repository-cache/repositorycache"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BunSiteRepository implements SiteRepository with optional caching.
+type BunSiteRepository struct {
+	repo repository.Repository[*Site]
+}
+
+// NewBunSiteRepository creates a site repository without caching.
+func NewBunSiteRepository(db *bun.DB) *BunSiteRepository {
+	return NewBunSiteRepositoryWithCache(db, nil, nil)
+}
+
+// NewBunSiteRepositoryWithCache creates a site repository with caching support.
+func NewBunSiteRepositoryWithCache(db *bun.DB, cacheService cache.CacheService, serializer cache.KeySerializer) *BunSiteRepository {
+	base := NewSiteRepository(db)
+	if cacheService != nil && serializer != nil {
+		base = repositorycache.New(base, cacheService, serializer)
+	}
+	return &BunSiteRepository{repo: base}
+}
+
+func (r *BunSiteRepository) Create(ctx context.Context, site *Site) (*Site, error) {
+	return r.repo.Create(ctx, site)
+}
+
+func (r *BunSiteRepository) Update(ctx context.Context, site *Site) (*Site, error) {
+	updated, err := r.repo.Update(ctx, site,
+		repository.UpdateByID(site.ID.String()),
+		repository.UpdateColumns(
+			"key",
+			"name",
+			"hostnames",
+			"base_url",
+			"default_locale",
+			"locales",
+			"theme",
+			"theme_variant",
+			"menu_locations",
+			"is_active",
+			"is_default",
+			"updated_at",
+			"deleted_at",
+		),
+	)
+	if err != nil {
+		return nil, mapRepositoryError(err, "site", site.ID.String())
+	}
+	return updated, nil
+}
+
+func (r *BunSiteRepository) GetByID(ctx context.Context, id uuid.UUID) (*Site, error) {
+	record, err := r.repo.GetByID(ctx, id.String())
+	if err != nil {
+		return nil, mapRepositoryError(err, "site", id.String())
+	}
+	return record, nil
+}
+
+func (r *BunSiteRepository) GetByKey(ctx context.Context, key string) (*Site, error) {
+	record, err := r.repo.GetByIdentifier(ctx, normalizeSiteKey(key))
+	if err != nil {
+		return nil, mapRepositoryError(err, "site", key)
+	}
+	return record, nil
+}
+
+func (r *BunSiteRepository) List(ctx context.Context) ([]*Site, error) {
+	records, _, err := r.repo.List(ctx, repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.OrderExpr("?TableAlias.key ASC")
+	}))
+	return records, err
+}
+
+func (r *BunSiteRepository) GetDefault(ctx context.Context) (*Site, error) {
+	records, _, err := r.repo.List(ctx,
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.is_default = TRUE")
+		}),
+		repository.SelectPaginate(1, 0),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, &NotFoundError{Resource: "site", Key: "default"}
+	}
+	return records[0], nil
+}
+
+func (r *BunSiteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.repo.Delete(ctx, &Site{ID: id}); err != nil {
+		return mapRepositoryError(err, "site", id.String())
+	}
+	return nil
+}
+
+func mapRepositoryError(err error, resource, key string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.IsCategory(err, repository.CategoryDatabaseNotFound) {
+		return &NotFoundError{Resource: resource, Key: key}
+	}
+	return fmt.Errorf("%s repository error: %w", resource, err)
+}
@@ -0,0 +1,113 @@
+package sites_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/sites"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestBunSiteRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+
+	if _, err := bunDB.NewCreateTable().Model((*sites.Site)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create sites table: %v", err)
+	}
+
+	repo := sites.NewBunSiteRepository(bunDB)
+	now := time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC)
+
+	site := &sites.Site{
+		ID:            uuid.MustParse("00000000-0000-0000-0000-00000000e001"),
+		Key:           "main",
+		Name:          "Main",
+		Hostnames:     []string{"example.com", "*.example.com"},
+		BaseURL:       "https://example.com",
+		DefaultLocale: "en",
+		Locales:       []string{"en", "es"},
+		MenuLocations: map[string]string{"primary": "main-nav"},
+		IsActive:      true,
+		IsDefault:     true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if _, err := repo.Create(ctx, site); err != nil {
+		t.Fatalf("create site: %v", err)
+	}
+	docs := &sites.Site{
+		ID:        uuid.MustParse("00000000-0000-0000-0000-00000000e002"),
+		Key:       "docs",
+		Name:      "Docs",
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := repo.Create(ctx, docs); err != nil {
+		t.Fatalf("create docs: %v", err)
+	}
+
+	byKey, err := repo.GetByKey(ctx, "main")
+	if err != nil {
+		t.Fatalf("get by key: %v", err)
+	}
+	if !slices.Equal(byKey.Hostnames, site.Hostnames) || !slices.Equal(byKey.Locales, site.Locales) {
+		t.Fatalf("expected json columns to round-trip, got %+v", byKey)
+	}
+	if byKey.MenuLocations["primary"] != "main-nav" {
+		t.Fatalf("expected menu locations to round-trip, got %v", byKey.MenuLocations)
+	}
+
+	listed, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Key != "docs" || listed[1].Key != "main" {
+		t.Fatalf("expected sites ordered by key, got %d records", len(listed))
+	}
+
+	defaultSite, err := repo.GetDefault(ctx)
+	if err != nil {
+		t.Fatalf("get default: %v", err)
+	}
+	if defaultSite.ID != site.ID {
+		t.Fatalf("expected main to be default, got %s", defaultSite.Key)
+	}
+
+	byKey.Theme = "aurora"
+	byKey.Hostnames = []string{"example.org"}
+	if _, err := repo.Update(ctx, byKey); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	byID, err := repo.GetByID(ctx, site.ID)
+	if err != nil {
+		t.Fatalf("get by id: %v", err)
+	}
+	if byID.Theme != "aurora" || !slices.Equal(byID.Hostnames, []string{"example.org"}) {
+		t.Fatalf("expected update to persist, got %+v", byID)
+	}
+
+	if err := repo.Delete(ctx, docs.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = repo.GetByID(ctx, docs.ID)
+	var notFound *sites.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
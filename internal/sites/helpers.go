@@ -0,0 +1,163 @@
+package sites
+
+import (
+	"maps"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goliatone/go-cms/internal/identity"
+	"github.com/google/uuid"
+)
+
+var siteKeyPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+var hostnamePattern = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// IDForKey derives deterministic UUIDs for site keys so the same site keeps
+// its ID across environments and databases.
+func IDForKey(key string) uuid.UUID {
+	return identity.UUID("go-cms:site:" + normalizeSiteKey(key))
+}
+
+func normalizeSiteKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+func deriveSiteName(key string) string {
+	if key == "" {
+		return ""
+	}
+	return strings.ToUpper(key[:1]) + key[1:]
+}
+
+// NormalizeHost lowercases host and strips any port and trailing dot.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimPrefix(strings.TrimSuffix(host, "]"), "[")
+	return strings.TrimSuffix(host, ".")
+}
+
+// normalizeHostnames validates, normalizes and deduplicates hostnames.
+// Entries may start with "*." to match every subdomain.
+func normalizeHostnames(values []string) ([]string, error) {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		host := NormalizeHost(value)
+		if host == "" {
+			continue
+		}
+		if !hostnamePattern.MatchString(host) && net.ParseIP(host) == nil {
+			return nil, ErrSiteHostnameInvalid
+		}
+		if !slices.Contains(out, host) {
+			out = append(out, host)
+		}
+	}
+	return out, nil
+}
+
+// hostMatch scores how well pattern matches host: exact matches beat
+// wildcards, and longer wildcard suffixes beat shorter ones. Zero means no
+// match.
+func hostMatch(pattern, host string) int {
+	if pattern == host {
+		return len(pattern) + 1
+	}
+	suffix, ok := strings.CutPrefix(pattern, "*")
+	if ok && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+		return len(suffix)
+	}
+	return 0
+}
+
+func normalizeBaseURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", ErrSiteBaseURLInvalid
+	}
+	return strings.TrimSuffix(parsed.String(), "/"), nil
+}
+
+// deriveBaseURL builds an https URL from the first non-wildcard hostname.
+func deriveBaseURL(hostnames []string) string {
+	for _, host := range hostnames {
+		if !strings.HasPrefix(host, "*.") {
+			return "https://" + host
+		}
+	}
+	return ""
+}
+
+// normalizeLocales trims and deduplicates locale codes and makes sure the
+// default locale is listed first when it is missing.
+func normalizeLocales(defaultLocale string, locales []string) (string, []string) {
+	defaultLocale = strings.TrimSpace(defaultLocale)
+	out := make([]string, 0, len(locales)+1)
+	for _, locale := range locales {
+		locale = strings.TrimSpace(locale)
+		if locale == "" || slices.ContainsFunc(out, func(existing string) bool { return strings.EqualFold(existing, locale) }) {
+			continue
+		}
+		out = append(out, locale)
+	}
+	if defaultLocale == "" && len(out) > 0 {
+		defaultLocale = out[0]
+	}
+	if defaultLocale != "" && !slices.ContainsFunc(out, func(existing string) bool { return strings.EqualFold(existing, defaultLocale) }) {
+		out = append([]string{defaultLocale}, out...)
+	}
+	if len(out) == 0 {
+		out = nil
+	}
+	return defaultLocale, out
+}
+
+func normalizeMenuLocations(values map[string]string) map[string]string {
+	out := make(map[string]string, len(values))
+	for location, code := range values {
+		location, code = strings.TrimSpace(location), strings.TrimSpace(code)
+		if location != "" && code != "" {
+			out[location] = code
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func cloneSite(site *Site) *Site {
+	if site == nil {
+		return nil
+	}
+	cloned := *site
+	cloned.Hostnames = slices.Clone(site.Hostnames)
+	cloned.Locales = slices.Clone(site.Locales)
+	cloned.MenuLocations = maps.Clone(site.MenuLocations)
+	if site.DeletedAt != nil {
+		deletedAt := *site.DeletedAt
+		cloned.DeletedAt = &deletedAt
+	}
+	return &cloned
+}
+
+func cloneSiteSlice(src []*Site) []*Site {
+	if len(src) == 0 {
+		return nil
+	}
+	out := make([]*Site, len(src))
+	for i, site := range src {
+		out[i] = cloneSite(site)
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package sites
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type memoryRepository struct {
+	mu    sync.RWMutex
+	byID  map[uuid.UUID]*Site
+	byKey map[string]uuid.UUID
+}
+
+// NewMemoryRepository constructs an in-memory site repository.
+func NewMemoryRepository() SiteRepository {
+	return &memoryRepository{
+		byID:  make(map[uuid.UUID]*Site),
+		byKey: make(map[string]uuid.UUID),
+	}
+}
+
+func (m *memoryRepository) Create(_ context.Context, site *Site) (*Site, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cloned := cloneSite(site)
+	cloned.Key = normalizeSiteKey(cloned.Key)
+	m.byID[cloned.ID] = cloned
+	if cloned.Key != "" {
+		m.byKey[cloned.Key] = cloned.ID
+	}
+	return cloneSite(cloned), nil
+}
+
+func (m *memoryRepository) Update(_ context.Context, site *Site) (*Site, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.byID[site.ID]
+	if !ok {
+		return nil, &NotFoundError{Resource: "site", Key: site.ID.String()}
+	}
+	cloned := cloneSite(site)
+	cloned.Key = normalizeSiteKey(cloned.Key)
+	m.byID[cloned.ID] = cloned
+	if existing.Key != "" && existing.Key != cloned.Key {
+		delete(m.byKey, existing.Key)
+	}
+	if cloned.Key != "" {
+		m.byKey[cloned.Key] = cloned.ID
+	}
+	return cloneSite(cloned), nil
+}
+
+func (m *memoryRepository) GetByID(_ context.Context, id uuid.UUID) (*Site, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.byID[id]
+	if !ok {
+		return nil, &NotFoundError{Resource: "site", Key: id.String()}
+	}
+	return cloneSite(record), nil
+}
+
+func (m *memoryRepository) GetByKey(_ context.Context, key string) (*Site, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	normalized := normalizeSiteKey(key)
+	id, ok := m.byKey[normalized]
+	if !ok {
+		return nil, &NotFoundError{Resource: "site", Key: normalized}
+	}
+	return cloneSite(m.byID[id]), nil
+}
+
+func (m *memoryRepository) List(_ context.Context) ([]*Site, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	records := make([]*Site, 0, len(m.byID))
+	for _, record := range m.byID {
+		records = append(records, cloneSite(record))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	return records, nil
+}
+
+func (m *memoryRepository) GetDefault(_ context.Context) (*Site, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, record := range m.byID {
+		if record != nil && record.IsDefault {
+			return cloneSite(record), nil
+		}
+	}
+	return nil, &NotFoundError{Resource: "site", Key: "default"}
+}
+
+func (m *memoryRepository) Delete(_ context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.byID[id]
+	if !ok {
+		return &NotFoundError{Resource: "site", Key: id.String()}
+	}
+	delete(m.byID, id)
+	if record.Key != "" {
+		delete(m.byKey, record.Key)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package sites
+
+import (
+	"context"
+
+	cmssites "github.com/goliatone/go-cms/sites"
+	"github.com/google/uuid"
+)
+
+type (
+	Site            = cmssites.Site
+	Service         = cmssites.Service
+	CreateSiteInput = cmssites.CreateSiteInput
+	UpdateSiteInput = cmssites.UpdateSiteInput
+)
+
+var (
+	ErrFeatureDisabled        = cmssites.ErrFeatureDisabled
+	ErrSiteKeyRequired        = cmssites.ErrSiteKeyRequired
+	ErrSiteKeyInvalid         = cmssites.ErrSiteKeyInvalid
+	ErrSiteKeyExists          = cmssites.ErrSiteKeyExists
+	ErrSiteNotFound           = cmssites.ErrSiteNotFound
+	ErrSiteHostnameInvalid    = cmssites.ErrSiteHostnameInvalid
+	ErrSiteHostnameExists     = cmssites.ErrSiteHostnameExists
+	ErrSiteBaseURLInvalid     = cmssites.ErrSiteBaseURLInvalid
+	ErrSiteDefaultProtected   = cmssites.ErrSiteDefaultProtected
+	ErrSiteScopeMismatch      = cmssites.ErrSiteScopeMismatch
+	ErrSiteRepositoryRequired = cmssites.ErrSiteRepositoryRequired
+)
+
+var (
+	WithSite    = cmssites.WithSite
+	WithSiteID  = cmssites.WithSiteID
+	FromContext = cmssites.FromContext
+	SameScope   = cmssites.SameScope
+)
+
+// Select picks the record visible to the site carried by ctx; see
+// cmssites.Select.
+func Select[T any](ctx context.Context, candidates []T, siteOf func(T) *uuid.UUID) (T, bool) {
+	return cmssites.Select(ctx, candidates, siteOf)
+}
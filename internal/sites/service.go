@@ -0,0 +1,397 @@
+package sites
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDDeriver produces deterministic site IDs from keys.
+type IDDeriver func(key string) uuid.UUID
+
+// ServiceOption configures service behaviour.
+type ServiceOption func(*service)
+
+// WithIDDeriver overrides site ID derivation.
+func WithIDDeriver(deriver IDDeriver) ServiceOption {
+	return func(s *service) {
+		if deriver != nil {
+			s.id = deriver
+		}
+	}
+}
+
+// WithNow overrides the time source (primarily for tests).
+func WithNow(now func() time.Time) ServiceOption {
+	return func(s *service) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+type service struct {
+	repo SiteRepository
+	id   IDDeriver
+	now  func() time.Time
+}
+
+// NewService constructs a site service instance.
+func NewService(repo SiteRepository, opts ...ServiceOption) Service {
+	if repo == nil {
+		panic(ErrSiteRepositoryRequired)
+	}
+	s := &service{
+		repo: repo,
+		id:   IDForKey,
+		now:  time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) CreateSite(ctx context.Context, input CreateSiteInput) (*Site, error) {
+	key := normalizeSiteKey(input.Key)
+	if key == "" {
+		return nil, ErrSiteKeyRequired
+	}
+	if !siteKeyPattern.MatchString(key) {
+		return nil, ErrSiteKeyInvalid
+	}
+	if existing, err := s.repo.GetByKey(ctx, key); err == nil && existing != nil {
+		return nil, ErrSiteKeyExists
+	} else if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = deriveSiteName(key)
+	}
+	hostnames, err := normalizeHostnames(input.Hostnames)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := normalizeBaseURL(input.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = deriveBaseURL(hostnames)
+	}
+	defaultLocale, locales := normalizeLocales(input.DefaultLocale, input.Locales)
+
+	existing, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := s.id(key)
+	if err := ensureHostnamesAvailable(existing, id, hostnames); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	isActive := true
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+	created, err := s.repo.Create(ctx, &Site{
+		ID:            id,
+		Key:           key,
+		Name:          name,
+		Hostnames:     hostnames,
+		BaseURL:       baseURL,
+		DefaultLocale: defaultLocale,
+		Locales:       locales,
+		Theme:         strings.TrimSpace(input.Theme),
+		ThemeVariant:  strings.TrimSpace(input.ThemeVariant),
+		MenuLocations: normalizeMenuLocations(input.MenuLocations),
+		IsActive:      isActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The first site becomes the default so unknown hosts always resolve.
+	if input.IsDefault || len(existing) == 0 {
+		if err := s.setDefault(ctx, created.ID); err != nil {
+			return nil, err
+		}
+		created, err = s.repo.GetByID(ctx, created.ID)
+		if err != nil {
+			return nil, translateRepoError(err)
+		}
+	}
+	return cloneSite(created), nil
+}
+
+func (s *service) UpdateSite(ctx context.Context, input UpdateSiteInput) (*Site, error) {
+	if input.ID == uuid.Nil {
+		return nil, ErrSiteNotFound
+	}
+	site, err := s.repo.GetByID(ctx, input.ID)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	if input.IsDefault != nil && !*input.IsDefault && site.IsDefault {
+		return nil, ErrSiteDefaultProtected
+	}
+
+	if input.Name != nil {
+		if name := strings.TrimSpace(*input.Name); name != "" {
+			site.Name = name
+		}
+	}
+	if input.Hostnames != nil {
+		hostnames, err := normalizeHostnames(input.Hostnames)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := s.repo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureHostnamesAvailable(existing, site.ID, hostnames); err != nil {
+			return nil, err
+		}
+		site.Hostnames = hostnames
+	}
+	if input.BaseURL != nil {
+		baseURL, err := normalizeBaseURL(*input.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		site.BaseURL = baseURL
+	}
+	if input.DefaultLocale != nil || input.Locales != nil {
+		defaultLocale := site.DefaultLocale
+		if input.DefaultLocale != nil {
+			defaultLocale = *input.DefaultLocale
+		}
+		locales := site.Locales
+		if input.Locales != nil {
+			locales = input.Locales
+		}
+		site.DefaultLocale, site.Locales = normalizeLocales(defaultLocale, locales)
+	}
+	if input.Theme != nil {
+		site.Theme = strings.TrimSpace(*input.Theme)
+	}
+	if input.ThemeVariant != nil {
+		site.ThemeVariant = strings.TrimSpace(*input.ThemeVariant)
+	}
+	if input.MenuLocations != nil {
+		site.MenuLocations = normalizeMenuLocations(input.MenuLocations)
+	}
+	if input.IsActive != nil {
+		site.IsActive = *input.IsActive
+	}
+	site.UpdatedAt = s.now().UTC()
+
+	updated, err := s.repo.Update(ctx, site)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	if input.IsDefault != nil && *input.IsDefault {
+		if err := s.setDefault(ctx, updated.ID); err != nil {
+			return nil, err
+		}
+		if updated, err = s.repo.GetByID(ctx, updated.ID); err != nil {
+			return nil, translateRepoError(err)
+		}
+	}
+	return cloneSite(updated), nil
+}
+
+func (s *service) DeleteSite(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrSiteNotFound
+	}
+	site, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return translateRepoError(err)
+	}
+	if site.IsDefault {
+		return ErrSiteDefaultProtected
+	}
+	return translateRepoError(s.repo.Delete(ctx, id))
+}
+
+func (s *service) GetSite(ctx context.Context, id uuid.UUID) (*Site, error) {
+	if id == uuid.Nil {
+		return nil, ErrSiteNotFound
+	}
+	site, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	return cloneSite(site), nil
+}
+
+func (s *service) GetSiteByKey(ctx context.Context, key string) (*Site, error) {
+	key = normalizeSiteKey(key)
+	if key == "" {
+		return nil, ErrSiteNotFound
+	}
+	site, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	return cloneSite(site), nil
+}
+
+func (s *service) ListSites(ctx context.Context) ([]*Site, error) {
+	records, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cloneSiteSlice(records), nil
+}
+
+func (s *service) GetDefaultSite(ctx context.Context) (*Site, error) {
+	site, err := s.repo.GetDefault(ctx)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+	return cloneSite(site), nil
+}
+
+// ResolveHost maps a request host to an active site. Exact hostnames win
+// over "*." wildcards, and longer wildcards over shorter ones. Hosts that
+// match no site resolve to the default site when it is active.
+func (s *service) ResolveHost(ctx context.Context, host string) (*Site, error) {
+	host = NormalizeHost(host)
+	records, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		best      *Site
+		bestScore int
+		fallback  *Site
+	)
+	for _, record := range records {
+		if record == nil || !record.IsActive || record.DeletedAt != nil {
+			continue
+		}
+		if record.IsDefault {
+			fallback = record
+		}
+		if host == "" {
+			continue
+		}
+		for _, pattern := range record.Hostnames {
+			if score := hostMatch(pattern, host); score > bestScore {
+				best, bestScore = record, score
+			}
+		}
+	}
+	if best == nil {
+		best = fallback
+	}
+	if best == nil {
+		return nil, ErrSiteNotFound
+	}
+	return cloneSite(best), nil
+}
+
+func (s *service) setDefault(ctx context.Context, id uuid.UUID) error {
+	current, err := s.repo.GetDefault(ctx)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	if current != nil && current.ID == id {
+		return nil
+	}
+	now := s.now().UTC()
+	if current != nil {
+		current.IsDefault = false
+		current.UpdatedAt = now
+		if _, err := s.repo.Update(ctx, current); err != nil {
+			return err
+		}
+	}
+	site, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return translateRepoError(err)
+	}
+	site.IsDefault = true
+	site.UpdatedAt = now
+	_, err = s.repo.Update(ctx, site)
+	return err
+}
+
+func ensureHostnamesAvailable(sites []*Site, id uuid.UUID, hostnames []string) error {
+	for _, site := range sites {
+		if site == nil || site.ID == id || site.DeletedAt != nil {
+			continue
+		}
+		for _, host := range hostnames {
+			for _, taken := range site.Hostnames {
+				if host == taken {
+					return ErrSiteHostnameExists
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
+
+func translateRepoError(err error) error {
+	if err != nil && isNotFound(err) {
+		return ErrSiteNotFound
+	}
+	return err
+}
+
+type disabledService struct{}
+
+// NewDisabledService returns a Service that fails all operations with
+// ErrFeatureDisabled.
+func NewDisabledService() Service {
+	return disabledService{}
+}
+
+func (disabledService) CreateSite(context.Context, CreateSiteInput) (*Site, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) UpdateSite(context.Context, UpdateSiteInput) (*Site, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) DeleteSite(context.Context, uuid.UUID) error {
+	return ErrFeatureDisabled
+}
+
+func (disabledService) GetSite(context.Context, uuid.UUID) (*Site, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) GetSiteByKey(context.Context, string) (*Site, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) ListSites(context.Context) ([]*Site, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) GetDefaultSite(context.Context) (*Site, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) ResolveHost(context.Context, string) (*Site, error) {
+	return nil, ErrFeatureDisabled
+}
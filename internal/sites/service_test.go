@@ -0,0 +1,230 @@
+package sites
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestServiceCreateSite(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	id := uuid.MustParse("00000000-0000-0000-0000-00000000b001")
+
+	svc := NewService(NewMemoryRepository(),
+		WithIDDeriver(func(string) uuid.UUID { return id }),
+		WithNow(func() time.Time { return now }),
+	)
+
+	site, err := svc.CreateSite(ctx, CreateSiteInput{
+		Key:           " Blog ",
+		Hostnames:     []string{"Blog.Example.com:8080", "*.blog.example.com", "blog.example.com"},
+		Locales:       []string{"es", "en"},
+		DefaultLocale: "en",
+		MenuLocations: map[string]string{"primary": "blog-main", "": "ignored"},
+	})
+	if err != nil {
+		t.Fatalf("create site: %v", err)
+	}
+	if site.ID != id || site.Key != "blog" || site.Name != "Blog" {
+		t.Fatalf("unexpected identity: %+v", site)
+	}
+	if want := []string{"blog.example.com", "*.blog.example.com"}; !slices.Equal(site.Hostnames, want) {
+		t.Fatalf("expected hostnames %v, got %v", want, site.Hostnames)
+	}
+	if site.BaseURL != "https://blog.example.com" {
+		t.Fatalf("expected derived base url, got %q", site.BaseURL)
+	}
+	if site.DefaultLocale != "en" || !slices.Equal(site.Locales, []string{"es", "en"}) {
+		t.Fatalf("unexpected locales %q %v", site.DefaultLocale, site.Locales)
+	}
+	if len(site.MenuLocations) != 1 || site.MenuLocations["primary"] != "blog-main" {
+		t.Fatalf("unexpected menu locations %v", site.MenuLocations)
+	}
+	if !site.IsActive || !site.IsDefault {
+		t.Fatalf("expected first site to be active and default")
+	}
+	if !site.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected created_at %v", site.CreatedAt)
+	}
+}
+
+func TestServiceCreateSiteValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryRepository())
+
+	if _, err := svc.CreateSite(ctx, CreateSiteInput{Key: "main", Hostnames: []string{"example.com"}}); err != nil {
+		t.Fatalf("create main: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		input CreateSiteInput
+		want  error
+	}{
+		{"missing key", CreateSiteInput{}, ErrSiteKeyRequired},
+		{"invalid key", CreateSiteInput{Key: "bad key"}, ErrSiteKeyInvalid},
+		{"duplicate key", CreateSiteInput{Key: "MAIN"}, ErrSiteKeyExists},
+		{"invalid hostname", CreateSiteInput{Key: "docs", Hostnames: []string{"bad_host"}}, ErrSiteHostnameInvalid},
+		{"taken hostname", CreateSiteInput{Key: "docs", Hostnames: []string{"EXAMPLE.com"}}, ErrSiteHostnameExists},
+		{"invalid base url", CreateSiteInput{Key: "docs", BaseURL: "ftp://docs.example.com"}, ErrSiteBaseURLInvalid},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := svc.CreateSite(ctx, tc.input); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestServiceDefaultSiteProtection(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryRepository())
+
+	main, err := svc.CreateSite(ctx, CreateSiteInput{Key: "main"})
+	if err != nil {
+		t.Fatalf("create main: %v", err)
+	}
+	docs, err := svc.CreateSite(ctx, CreateSiteInput{Key: "docs"})
+	if err != nil {
+		t.Fatalf("create docs: %v", err)
+	}
+	if docs.IsDefault {
+		t.Fatalf("expected only the first site to become default")
+	}
+
+	if err := svc.DeleteSite(ctx, main.ID); !errors.Is(err, ErrSiteDefaultProtected) {
+		t.Fatalf("expected default protection on delete, got %v", err)
+	}
+	if _, err := svc.UpdateSite(ctx, UpdateSiteInput{ID: main.ID, IsDefault: new(false)}); !errors.Is(err, ErrSiteDefaultProtected) {
+		t.Fatalf("expected default protection on unset, got %v", err)
+	}
+
+	if _, err := svc.UpdateSite(ctx, UpdateSiteInput{ID: docs.ID, IsDefault: new(true)}); err != nil {
+		t.Fatalf("promote docs: %v", err)
+	}
+	current, err := svc.GetDefaultSite(ctx)
+	if err != nil {
+		t.Fatalf("get default: %v", err)
+	}
+	if current.ID != docs.ID {
+		t.Fatalf("expected docs to be default, got %s", current.Key)
+	}
+	if err := svc.DeleteSite(ctx, main.ID); err != nil {
+		t.Fatalf("delete former default: %v", err)
+	}
+	if _, err := svc.GetSite(ctx, main.ID); !errors.Is(err, ErrSiteNotFound) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
+
+func TestServiceUpdateSite(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryRepository())
+
+	if _, err := svc.CreateSite(ctx, CreateSiteInput{Key: "main", Hostnames: []string{"example.com"}}); err != nil {
+		t.Fatalf("create main: %v", err)
+	}
+	docs, err := svc.CreateSite(ctx, CreateSiteInput{Key: "docs", Hostnames: []string{"docs.example.com"}})
+	if err != nil {
+		t.Fatalf("create docs: %v", err)
+	}
+
+	if _, err := svc.UpdateSite(ctx, UpdateSiteInput{ID: docs.ID, Hostnames: []string{"example.com"}}); !errors.Is(err, ErrSiteHostnameExists) {
+		t.Fatalf("expected hostname conflict, got %v", err)
+	}
+
+	updated, err := svc.UpdateSite(ctx, UpdateSiteInput{
+		ID:           docs.ID,
+		Name:         new("Documentation"),
+		BaseURL:      new("https://example.com/docs/"),
+		Theme:        new("docs"),
+		ThemeVariant: new("dark"),
+		Locales:      []string{"fr"},
+		IsActive:     new(false),
+	})
+	if err != nil {
+		t.Fatalf("update docs: %v", err)
+	}
+	if updated.Name != "Documentation" || updated.BaseURL != "https://example.com/docs" {
+		t.Fatalf("unexpected update result: %+v", updated)
+	}
+	if updated.Theme != "docs" || updated.ThemeVariant != "dark" {
+		t.Fatalf("unexpected theme %q/%q", updated.Theme, updated.ThemeVariant)
+	}
+	if updated.DefaultLocale != "fr" || !slices.Equal(updated.Locales, []string{"fr"}) {
+		t.Fatalf("unexpected locales %q %v", updated.DefaultLocale, updated.Locales)
+	}
+	if updated.IsActive {
+		t.Fatalf("expected site to be deactivated")
+	}
+}
+
+func TestServiceResolveHost(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryRepository())
+
+	mustCreate := func(input CreateSiteInput) *Site {
+		t.Helper()
+		site, err := svc.CreateSite(ctx, input)
+		if err != nil {
+			t.Fatalf("create %s: %v", input.Key, err)
+		}
+		return site
+	}
+	main := mustCreate(CreateSiteInput{Key: "main", Hostnames: []string{"example.com", "*.example.com"}})
+	shop := mustCreate(CreateSiteInput{Key: "shop", Hostnames: []string{"shop.example.com"}})
+	eu := mustCreate(CreateSiteInput{Key: "eu", Hostnames: []string{"*.eu.example.com"}})
+	mustCreate(CreateSiteInput{Key: "legacy", Hostnames: []string{"old.example.org"}, IsActive: new(false)})
+
+	cases := []struct {
+		host string
+		want uuid.UUID
+	}{
+		{"example.com", main.ID},
+		{"EXAMPLE.COM:443", main.ID},
+		{"shop.example.com", shop.ID},
+		{"blog.example.com", main.ID},
+		{"de.eu.example.com", eu.ID},
+		{"unknown.test", main.ID},
+		{"old.example.org", main.ID},
+		{"", main.ID},
+	}
+	for _, tc := range cases {
+		site, err := svc.ResolveHost(ctx, tc.host)
+		if err != nil {
+			t.Fatalf("resolve %q: %v", tc.host, err)
+		}
+		if site.ID != tc.want {
+			t.Fatalf("resolve %q: expected %s, got %s", tc.host, tc.want, site.Key)
+		}
+	}
+}
+
+func TestServiceResolveHostWithoutDefault(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryRepository())
+
+	if _, err := svc.ResolveHost(ctx, "example.com"); !errors.Is(err, ErrSiteNotFound) {
+		t.Fatalf("expected not found without sites, got %v", err)
+	}
+
+	if _, err := svc.CreateSite(ctx, CreateSiteInput{Key: "main", Hostnames: []string{"example.com"}, IsActive: new(false)}); err != nil {
+		t.Fatalf("create main: %v", err)
+	}
+	if _, err := svc.ResolveHost(ctx, "example.com"); !errors.Is(err, ErrSiteNotFound) {
+		t.Fatalf("expected inactive default to be skipped, got %v", err)
+	}
+}
+
+func TestDisabledService(t *testing.T) {
+	svc := NewDisabledService()
+	if _, err := svc.ResolveHost(context.Background(), "example.com"); !errors.Is(err, ErrFeatureDisabled) {
+		t.Fatalf("expected feature disabled, got %v", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/internal/identity"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/google/uuid"
 )
 
@@ -91,12 +92,23 @@ func WithNow(now func() time.Time) ServiceOption {
 	}
 }
 
+// WithLifecycleEmitter wires the emitter notified when themes or templates
+// change.
+func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
+	return func(s *service) {
+		if emitter != nil {
+			s.lifecycle = emitter
+		}
+	}
+}
+
 type service struct {
 	themes    ThemeRepository
 	templates TemplateRepository
 	id        IDGenerator
 	idCustom  bool
 	now       func() time.Time
+	lifecycle *lifecycle.Emitter
 }
 
 // NewService constructs a theme service instance.
@@ -113,6 +125,7 @@ func NewService(themeRepo ThemeRepository, templateRepo TemplateRepository, opts
 		templates: templateRepo,
 		id:        uuid.New,
 		now:       time.Now,
+		lifecycle: lifecycle.NewEmitter(nil, lifecycle.Config{}),
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -167,9 +180,23 @@ func (s *service) RegisterTheme(ctx context.Context, input RegisterThemeInput) (
 	if err != nil {
 		return nil, err
 	}
+	s.emitLifecycle(ctx, "theme", created.ID, "create", map[string]any{"name": created.Name})
 	return cloneTheme(created), nil
 }
 
+func (s *service) emitLifecycle(ctx context.Context, resourceType string, id uuid.UUID, transition string, metadata map[string]any) {
+	if s.lifecycle == nil || !s.lifecycle.Enabled() {
+		return
+	}
+	_ = s.lifecycle.Emit(ctx, lifecycle.Event{
+		ResourceType: resourceType,
+		RecordID:     id.String(),
+		Transition:   transition,
+		OccurredAt:   s.now().UTC(),
+		Metadata:     metadata,
+	})
+}
+
 func (s *service) GetTheme(ctx context.Context, id uuid.UUID) (*Theme, error) {
 	if id == uuid.Nil {
 		return nil, ErrThemeNotFound
@@ -238,6 +265,7 @@ func (s *service) ActivateTheme(ctx context.Context, id uuid.UUID) (*Theme, erro
 	if err != nil {
 		return nil, err
 	}
+	s.emitLifecycle(ctx, "theme", updated.ID, "activate", map[string]any{"name": updated.Name})
 	return cloneTheme(updated), nil
 }
 
@@ -252,6 +280,7 @@ func (s *service) DeactivateTheme(ctx context.Context, id uuid.UUID) (*Theme, er
 	if err != nil {
 		return nil, err
 	}
+	s.emitLifecycle(ctx, "theme", updated.ID, "deactivate", map[string]any{"name": updated.Name})
 	return cloneTheme(updated), nil
 }
 
@@ -280,6 +309,7 @@ func (s *service) RegisterTemplate(ctx context.Context, input RegisterTemplateIn
 	if err != nil {
 		return nil, err
 	}
+	s.emitLifecycle(ctx, "template", created.ID, "create", map[string]any{"theme_id": created.ThemeID.String()})
 	return cloneTemplate(created), nil
 }
 
@@ -317,6 +347,7 @@ func (s *service) UpdateTemplate(ctx context.Context, input UpdateTemplateInput)
 	if err != nil {
 		return nil, err
 	}
+	s.emitLifecycle(ctx, "template", updated.ID, "update", map[string]any{"theme_id": updated.ThemeID.String()})
 	return cloneTemplate(updated), nil
 }
 
@@ -327,6 +358,7 @@ func (s *service) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
 	if err := s.templates.Delete(ctx, id); err != nil {
 		return translateRepoError(err, ErrTemplateNotFound)
 	}
+	s.emitLifecycle(ctx, "template", id, "delete", nil)
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/goliatone/go-cms/pkg/testsupport"
 	"github.com/google/uuid"
 )
@@ -70,6 +71,49 @@ func TestServiceRegisterAndActivateTheme(t *testing.T) {
 	}
 }
 
+func TestServiceWritesEmitLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	hook := &lifecycle.CaptureHook{}
+	svc := NewService(NewMemoryThemeRepository(), NewMemoryTemplateRepository(),
+		WithLifecycleEmitter(lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})),
+	)
+
+	theme, err := svc.RegisterTheme(ctx, RegisterThemeInput{Name: "Aurora", Version: "1.0.0", ThemePath: "themes/aurora"})
+	if err != nil {
+		t.Fatalf("register theme: %v", err)
+	}
+	template, err := svc.RegisterTemplate(ctx, RegisterTemplateInput{
+		ThemeID:      theme.ID,
+		Name:         "Landing",
+		Slug:         "landing",
+		TemplatePath: "templates/landing.html.tmpl",
+		Regions:      map[string]TemplateRegion{"hero": {Name: "Hero", AcceptsBlocks: true}},
+	})
+	if err != nil {
+		t.Fatalf("register template: %v", err)
+	}
+	if _, err := svc.ActivateTheme(ctx, theme.ID); err != nil {
+		t.Fatalf("activate theme: %v", err)
+	}
+	if err := svc.DeleteTemplate(ctx, template.ID); err != nil {
+		t.Fatalf("delete template: %v", err)
+	}
+
+	var got []string
+	for _, event := range hook.Events {
+		got = append(got, event.ResourceType+":"+event.Transition+":"+event.RecordID)
+	}
+	want := []string{
+		"theme:create:" + theme.ID.String(),
+		"template:create:" + template.ID.String(),
+		"theme:activate:" + theme.ID.String(),
+		"template:delete:" + template.ID.String(),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected lifecycle events %v, want %v", got, want)
+	}
+}
+
 func TestServiceRegisterTemplateSlugConflict(t *testing.T) {
 	ctx := context.Background()
 	themeRepo := NewMemoryThemeRepository()
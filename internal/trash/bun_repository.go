@@ -0,0 +1,145 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BunRepository persists trash entries using a Bun-backed database.
+type BunRepository struct {
+	db *bun.DB
+}
+
+// NewBunRepository constructs a Bun-backed trash repository.
+func NewBunRepository(db *bun.DB) *BunRepository {
+	return &BunRepository{db: db}
+}
+
+func (r *BunRepository) Create(ctx context.Context, entry *Entry) (*Entry, error) {
+	if r.db == nil {
+		return nil, errors.New("trash: bun repository requires a database")
+	}
+	record := cloneEntry(entry)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return Insert(ctx, tx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cloneEntry(record), nil
+}
+
+// Insert stores entry through db, replacing any entry held for the same
+// resource. Repositories call it with their own transaction so the snapshot
+// commits or rolls back together with the delete of the live rows.
+func Insert(ctx context.Context, db bun.IDB, entry *Entry) error {
+	if _, err := db.NewDelete().
+		Model((*Entry)(nil)).
+		Where("?TableAlias.resource_type = ?", entry.ResourceType).
+		Where("?TableAlias.resource_id = ?", entry.ResourceID).
+		Exec(ctx); err != nil {
+		return err
+	}
+	_, err := db.NewInsert().Model(entry).Exec(ctx)
+	return err
+}
+
+func (r *BunRepository) GetByResource(ctx context.Context, resourceType string, resourceID uuid.UUID) (*Entry, error) {
+	if r.db == nil {
+		return nil, errors.New("trash: bun repository requires a database")
+	}
+	record := new(Entry)
+	err := r.db.NewSelect().
+		Model(record).
+		Where("?TableAlias.resource_type = ?", resourceType).
+		Where("?TableAlias.resource_id = ?", resourceID).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return record, nil
+}
+
+func (r *BunRepository) List(ctx context.Context, query ListQuery) ([]*Entry, error) {
+	if r.db == nil {
+		return nil, errors.New("trash: bun repository requires a database")
+	}
+	var records []*Entry
+	q := r.db.NewSelect().
+		Model(&records).
+		Where("?TableAlias.resource_type = ?", query.ResourceType)
+	if query.EnvironmentID != uuid.Nil {
+		q = q.Where("?TableAlias.environment_id = ?", query.EnvironmentID)
+	}
+	q = q.OrderExpr("?TableAlias.deleted_at DESC").
+		OrderExpr("?TableAlias.id ASC")
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		q = q.Offset(query.Offset)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *BunRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if r.db == nil {
+		return errors.New("trash: bun repository requires a database")
+	}
+	return Remove(ctx, r.db, id)
+}
+
+// Remove deletes the entry with the given ID through db. Like Insert it lets
+// repositories drop the snapshot in the transaction that restores the rows.
+func Remove(ctx context.Context, db bun.IDB, id uuid.UUID) error {
+	res, err := db.NewDelete().Model((*Entry)(nil)).Where("?TableAlias.id = ?", id).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+func (r *BunRepository) DeleteBefore(ctx context.Context, cutoff time.Time) ([]*Entry, error) {
+	if r.db == nil {
+		return nil, errors.New("trash: bun repository requires a database")
+	}
+	var removed []*Entry
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewSelect().
+			Model(&removed).
+			Where("?TableAlias.deleted_at < ?", cutoff.UTC()).
+			OrderExpr("?TableAlias.deleted_at DESC").
+			OrderExpr("?TableAlias.id ASC").
+			Scan(ctx); err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, 0, len(removed))
+		for _, entry := range removed {
+			ids = append(ids, entry.ID)
+		}
+		_, err := tx.NewDelete().Model((*Entry)(nil)).Where("?TableAlias.id IN (?)", bun.In(ids)).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
@@ -0,0 +1,112 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestBunRepositoryRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	if _, err := bunDB.NewCreateTable().Model((*trash.Entry)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create trash table: %v", err)
+	}
+
+	repo := trash.NewBunRepository(bunDB)
+	now := time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC)
+	resourceID := uuid.New()
+	actor := uuid.New()
+
+	if _, err := trash.Put(ctx, repo, trash.ResourceContent, resourceID, map[string]string{"slug": "first"}, actor, now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	// A second delete of the same resource replaces the earlier snapshot.
+	if _, err := trash.Put(ctx, repo, trash.ResourceContent, resourceID, map[string]string{"slug": "second"}, actor, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("put replacement: %v", err)
+	}
+	otherID := uuid.New()
+	if _, err := trash.Put(ctx, repo, trash.ResourceContent, otherID, map[string]string{"slug": "other"}, uuid.Nil, now.Add(-72*time.Hour)); err != nil {
+		t.Fatalf("put other: %v", err)
+	}
+
+	entry, err := repo.GetByResource(ctx, trash.ResourceContent, resourceID)
+	if err != nil {
+		t.Fatalf("get by resource: %v", err)
+	}
+	var snapshot map[string]string
+	if err := trash.Decode(entry, &snapshot); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if snapshot["slug"] != "second" || entry.DeletedBy != actor {
+		t.Fatalf("expected replacement snapshot, got %v by %s", snapshot, entry.DeletedBy)
+	}
+
+	listed, err := repo.List(ctx, trash.ListQuery{ResourceType: trash.ResourceContent})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(listed) != 2 || listed[0].ResourceID != resourceID || listed[1].ResourceID != otherID {
+		t.Fatalf("expected entries most recent first, got %+v", listed)
+	}
+	paged, err := repo.List(ctx, trash.ListQuery{ResourceType: trash.ResourceContent, Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("list page: %v", err)
+	}
+	if len(paged) != 1 || paged[0].ResourceID != otherID {
+		t.Fatalf("expected the second entry on the second page, got %+v", paged)
+	}
+
+	envID := uuid.New()
+	envEntry, err := trash.NewEntry(trash.ResourceContent, uuid.New(), map[string]string{"slug": "staged"}, actor, now)
+	if err != nil {
+		t.Fatalf("new entry: %v", err)
+	}
+	envEntry.EnvironmentID = envID
+	if _, err := repo.Create(ctx, envEntry); err != nil {
+		t.Fatalf("create env entry: %v", err)
+	}
+	scoped, err := repo.List(ctx, trash.ListQuery{ResourceType: trash.ResourceContent, EnvironmentID: envID})
+	if err != nil {
+		t.Fatalf("list environment: %v", err)
+	}
+	if len(scoped) != 1 || scoped[0].ID != envEntry.ID || scoped[0].EnvironmentID != envID {
+		t.Fatalf("expected only the environment entry, got %+v", scoped)
+	}
+	if err := repo.Delete(ctx, envEntry.ID); err != nil {
+		t.Fatalf("delete env entry: %v", err)
+	}
+
+	removed, err := repo.DeleteBefore(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("delete before: %v", err)
+	}
+	if len(removed) != 1 || removed[0].ResourceID != otherID {
+		t.Fatalf("expected the oldest entry to be removed, got %+v", removed)
+	}
+
+	if err := repo.Delete(ctx, entry.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := repo.GetByResource(ctx, trash.ResourceContent, resourceID); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, entry.ID); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound on repeated delete, got %v", err)
+	}
+}
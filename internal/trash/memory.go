@@ -0,0 +1,122 @@
+package trash
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type resourceKey struct {
+	resourceType string
+	resourceID   uuid.UUID
+}
+
+type memoryRepository struct {
+	mu         sync.RWMutex
+	byID       map[uuid.UUID]*Entry
+	byResource map[resourceKey]uuid.UUID
+}
+
+// NewMemoryRepository constructs an in-memory trash repository.
+func NewMemoryRepository() Repository {
+	return &memoryRepository{
+		byID:       make(map[uuid.UUID]*Entry),
+		byResource: make(map[resourceKey]uuid.UUID),
+	}
+}
+
+func (m *memoryRepository) Create(_ context.Context, entry *Entry) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cloned := cloneEntry(entry)
+	key := resourceKey{resourceType: cloned.ResourceType, resourceID: cloned.ResourceID}
+	if existing, ok := m.byResource[key]; ok {
+		delete(m.byID, existing)
+	}
+	m.byID[cloned.ID] = cloned
+	m.byResource[key] = cloned.ID
+	return cloneEntry(cloned), nil
+}
+
+func (m *memoryRepository) GetByResource(_ context.Context, resourceType string, resourceID uuid.UUID) (*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.byResource[resourceKey{resourceType: resourceType, resourceID: resourceID}]
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+	return cloneEntry(m.byID[id]), nil
+}
+
+func (m *memoryRepository) List(_ context.Context, query ListQuery) ([]*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]*Entry, 0)
+	for _, entry := range m.byID {
+		if entry.ResourceType != query.ResourceType {
+			continue
+		}
+		if query.EnvironmentID != uuid.Nil && entry.EnvironmentID != query.EnvironmentID {
+			continue
+		}
+		entries = append(entries, cloneEntry(entry))
+	}
+	sortEntries(entries)
+	if query.Offset > 0 {
+		if query.Offset >= len(entries) {
+			return []*Entry{}, nil
+		}
+		entries = entries[query.Offset:]
+	}
+	if query.Limit > 0 && query.Limit < len(entries) {
+		entries = entries[:query.Limit]
+	}
+	return entries, nil
+}
+
+func (m *memoryRepository) Delete(_ context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.byID[id]
+	if !ok {
+		return ErrEntryNotFound
+	}
+	m.remove(entry)
+	return nil
+}
+
+func (m *memoryRepository) DeleteBefore(_ context.Context, cutoff time.Time) ([]*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := make([]*Entry, 0)
+	for _, entry := range m.byID {
+		if entry.DeletedAt.Before(cutoff) {
+			removed = append(removed, cloneEntry(entry))
+			m.remove(entry)
+		}
+	}
+	sortEntries(removed)
+	return removed, nil
+}
+
+func (m *memoryRepository) remove(entry *Entry) {
+	delete(m.byID, entry.ID)
+	delete(m.byResource, resourceKey{resourceType: entry.ResourceType, resourceID: entry.ResourceID})
+}
+
+func sortEntries(entries []*Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].DeletedAt.Equal(entries[j].DeletedAt) {
+			return entries[i].DeletedAt.After(entries[j].DeletedAt)
+		}
+		return entries[i].ID.String() < entries[j].ID.String()
+	})
+}
@@ -0,0 +1,100 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/google/uuid"
+)
+
+// PurgedEntry describes a trash entry removed by a purge run.
+type PurgedEntry struct {
+	ResourceType string    `json:"resource_type"`
+	ResourceID   uuid.UUID `json:"resource_id"`
+	DeletedAt    time.Time `json:"deleted_at"`
+}
+
+// PurgeResult reports the outcome of a purge run.
+type PurgeResult struct {
+	Cutoff  time.Time     `json:"cutoff"`
+	Removed []PurgedEntry `json:"removed,omitempty"`
+}
+
+// Purger permanently removes trash entries older than the retention window.
+type Purger struct {
+	repo      Repository
+	retention time.Duration
+	now       func() time.Time
+}
+
+// PurgerOption customises a Purger.
+type PurgerOption func(*Purger)
+
+// WithPurgeClock overrides the purge clock.
+func WithPurgeClock(clock func() time.Time) PurgerOption {
+	return func(p *Purger) {
+		if clock != nil {
+			p.now = clock
+		}
+	}
+}
+
+// NewPurger constructs a purger. A zero retention keeps entries until they
+// are restored, so Purge removes nothing.
+func NewPurger(repo Repository, retention time.Duration, opts ...PurgerOption) (*Purger, error) {
+	if retention < 0 {
+		return nil, ErrRetentionInvalid
+	}
+	p := &Purger{
+		repo:      repo,
+		retention: retention,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// Retention returns the configured retention window.
+func (p *Purger) Retention() time.Duration {
+	return p.retention
+}
+
+// Purge removes entries deleted before now minus the retention window.
+func (p *Purger) Purge(ctx context.Context) (*PurgeResult, error) {
+	if p.retention == 0 {
+		return &PurgeResult{}, nil
+	}
+	cutoff := p.now().UTC().Add(-p.retention)
+	removed, err := p.repo.DeleteBefore(ctx, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	result := &PurgeResult{Cutoff: cutoff, Removed: make([]PurgedEntry, 0, len(removed))}
+	for _, entry := range removed {
+		result.Removed = append(result.Removed, PurgedEntry{
+			ResourceType: entry.ResourceType,
+			ResourceID:   entry.ResourceID,
+			DeletedAt:    entry.DeletedAt,
+		})
+	}
+	return result, nil
+}
+
+// SchedulePurge enqueues the trash purge job at runAt. A positive interval is
+// stored in the payload so the worker schedules the next run after each purge.
+func SchedulePurge(ctx context.Context, scheduler interfaces.Scheduler, runAt time.Time, interval time.Duration) (*interfaces.Job, error) {
+	payload := map[string]any{}
+	if interval > 0 {
+		payload["interval"] = interval.String()
+	}
+	return scheduler.Enqueue(ctx, interfaces.JobSpec{
+		Key:     cmsscheduler.TrashPurgeJobKey,
+		Type:    cmsscheduler.JobTypeTrashPurge,
+		RunAt:   runAt,
+		Payload: payload,
+	})
+}
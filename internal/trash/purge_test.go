@@ -0,0 +1,85 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+func TestPurgerRemovesEntriesOlderThanRetention(t *testing.T) {
+	ctx := context.Background()
+	repo := trash.NewMemoryRepository()
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	oldID := uuid.New()
+	freshID := uuid.New()
+	if _, err := trash.Put(ctx, repo, trash.ResourceContent, oldID, map[string]string{"slug": "old"}, uuid.Nil, now.Add(-40*24*time.Hour)); err != nil {
+		t.Fatalf("put old: %v", err)
+	}
+	if _, err := trash.Put(ctx, repo, trash.ResourcePage, freshID, map[string]string{"slug": "fresh"}, uuid.Nil, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("put fresh: %v", err)
+	}
+
+	purger, err := trash.NewPurger(repo, 30*24*time.Hour, trash.WithPurgeClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("new purger: %v", err)
+	}
+	result, err := purger.Purge(ctx)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if !result.Cutoff.Equal(now.Add(-30 * 24 * time.Hour)) {
+		t.Fatalf("unexpected cutoff %s", result.Cutoff)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].ResourceID != oldID || result.Removed[0].ResourceType != trash.ResourceContent {
+		t.Fatalf("expected only the old entry to be purged, got %+v", result.Removed)
+	}
+
+	if _, err := repo.GetByResource(ctx, trash.ResourceContent, oldID); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected purged entry to be gone, got %v", err)
+	}
+	if _, err := repo.GetByResource(ctx, trash.ResourcePage, freshID); err != nil {
+		t.Fatalf("expected fresh entry to remain: %v", err)
+	}
+}
+
+func TestPurgerZeroRetentionKeepsEntries(t *testing.T) {
+	ctx := context.Background()
+	repo := trash.NewMemoryRepository()
+	id := uuid.New()
+	if _, err := trash.Put(ctx, repo, trash.ResourceMenuItem, id, struct{}{}, uuid.Nil, time.Now().Add(-365*24*time.Hour)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	purger, err := trash.NewPurger(repo, 0)
+	if err != nil {
+		t.Fatalf("new purger: %v", err)
+	}
+	result, err := purger.Purge(ctx)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if len(result.Removed) != 0 {
+		t.Fatalf("expected nothing purged, got %+v", result.Removed)
+	}
+	if _, err := repo.GetByResource(ctx, trash.ResourceMenuItem, id); err != nil {
+		t.Fatalf("expected entry to remain: %v", err)
+	}
+
+	if _, err := trash.NewPurger(repo, -time.Hour); !errors.Is(err, trash.ErrRetentionInvalid) {
+		t.Fatalf("expected ErrRetentionInvalid, got %v", err)
+	}
+}
+
+func TestSuffixedSlug(t *testing.T) {
+	if got := trash.SuffixedSlug("about", 1); got != "about-restored" {
+		t.Fatalf("unexpected first candidate %q", got)
+	}
+	if got := trash.SuffixedSlug("about", 3); got != "about-restored-3" {
+		t.Fatalf("unexpected third candidate %q", got)
+	}
+}
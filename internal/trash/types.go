@@ -0,0 +1,116 @@
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Resource types recorded in trash entries.
+const (
+	ResourceContent        = "content"
+	ResourcePage           = "page"
+	ResourceBlockInstance  = "block_instance"
+	ResourceWidgetInstance = "widget_instance"
+	ResourceMenuItem       = "menu_item"
+)
+
+var (
+	// ErrEntryNotFound reports a resource that is not in the trash.
+	ErrEntryNotFound = errors.New("trash: entry not found")
+	// ErrRetentionInvalid reports a negative retention window.
+	ErrRetentionInvalid = errors.New("trash: retention must be zero or positive")
+)
+
+// Entry holds the snapshot of a deleted record graph until it is restored or
+// purged. Services delete the live rows when they trash a record, so the
+// snapshot is the only copy left.
+type Entry struct {
+	bun.BaseModel `bun:"table:trash_entries,alias:te"`
+
+	ID            uuid.UUID       `bun:",pk,type:uuid" json:"id"`
+	ResourceType  string          `bun:"resource_type,notnull" json:"resource_type"`
+	ResourceID    uuid.UUID       `bun:"resource_id,notnull,type:uuid" json:"resource_id"`
+	EnvironmentID uuid.UUID       `bun:"environment_id,nullzero,type:uuid" json:"environment_id,omitempty"`
+	Snapshot      json.RawMessage `bun:"snapshot,type:jsonb,notnull" json:"snapshot"`
+	DeletedBy     uuid.UUID       `bun:"deleted_by,type:uuid" json:"deleted_by"`
+	DeletedAt     time.Time       `bun:"deleted_at,notnull" json:"deleted_at"`
+}
+
+// ListQuery selects trash entries of a resource type. A zero EnvironmentID
+// matches every environment and a zero Limit returns all remaining entries.
+type ListQuery struct {
+	ResourceType  string
+	EnvironmentID uuid.UUID
+	Limit         int
+	Offset        int
+}
+
+// Repository persists trash entries.
+type Repository interface {
+	// Create stores an entry, replacing any entry held for the same resource.
+	Create(ctx context.Context, entry *Entry) (*Entry, error)
+	GetByResource(ctx context.Context, resourceType string, resourceID uuid.UUID) (*Entry, error)
+	// List returns the entries matching the query, most recently deleted first.
+	List(ctx context.Context, query ListQuery) ([]*Entry, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteBefore removes entries deleted before the cutoff and returns them.
+	DeleteBefore(ctx context.Context, cutoff time.Time) ([]*Entry, error)
+}
+
+// Put snapshots value into a new trash entry for the resource.
+func Put(ctx context.Context, repo Repository, resourceType string, resourceID uuid.UUID, value any, deletedBy uuid.UUID, deletedAt time.Time) (*Entry, error) {
+	entry, err := NewEntry(resourceType, resourceID, value, deletedBy, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return repo.Create(ctx, entry)
+}
+
+// NewEntry snapshots value into a trash entry without storing it, for
+// repositories that write the entry in the same transaction as the delete.
+func NewEntry(resourceType string, resourceID uuid.UUID, value any, deletedBy uuid.UUID, deletedAt time.Time) (*Entry, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{
+		ID:           uuid.New(),
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Snapshot:     payload,
+		DeletedBy:    deletedBy,
+		DeletedAt:    deletedAt.UTC(),
+	}, nil
+}
+
+// Decode unmarshals the entry snapshot into dst.
+func Decode(entry *Entry, dst any) error {
+	if entry == nil || len(entry.Snapshot) == 0 {
+		return ErrEntryNotFound
+	}
+	return json.Unmarshal(entry.Snapshot, dst)
+}
+
+// SuffixedSlug returns the candidate slug for a restore rename attempt.
+// Attempt 1 yields "<slug>-restored", later attempts "<slug>-restored-<n>".
+func SuffixedSlug(slug string, attempt int) string {
+	if attempt <= 1 {
+		return slug + "-restored"
+	}
+	return slug + "-restored-" + strconv.Itoa(attempt)
+}
+
+func cloneEntry(entry *Entry) *Entry {
+	if entry == nil {
+		return nil
+	}
+	cloned := *entry
+	cloned.Snapshot = append(json.RawMessage(nil), entry.Snapshot...)
+	return &cloned
+}
@@ -0,0 +1,149 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goliatone/go-errors"
+	repository "github.com/goliatone/go-repository-bun"
+	"github.com/goliatone/go-repository-cache/cache"
+	repositorycache "github.com/goliatone/go-repository-cache/repositorycache"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BunSubscriptionRepository implements SubscriptionRepository with optional caching.
+type BunSubscriptionRepository struct {
+	repo repository.Repository[*Subscription]
+}
+
+// NewBunSubscriptionRepository creates a subscription repository without caching.
+func NewBunSubscriptionRepository(db *bun.DB) *BunSubscriptionRepository {
+	return NewBunSubscriptionRepositoryWithCache(db, nil, nil)
+}
+
+// NewBunSubscriptionRepositoryWithCache creates a subscription repository with caching support.
+func NewBunSubscriptionRepositoryWithCache(db *bun.DB, cacheService cache.CacheService, serializer cache.KeySerializer) *BunSubscriptionRepository {
+	base := NewSubscriptionRepository(db)
+	if cacheService != nil && serializer != nil {
+		base = repositorycache.New(base, cacheService, serializer)
+	}
+	return &BunSubscriptionRepository{repo: base}
+}
+
+func (r *BunSubscriptionRepository) Create(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	return r.repo.Create(ctx, subscription)
+}
+
+func (r *BunSubscriptionRepository) Update(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	updated, err := r.repo.Update(ctx, subscription,
+		repository.UpdateByID(subscription.ID.String()),
+		repository.UpdateColumns(
+			"name",
+			"url",
+			"secret",
+			"sources",
+			"resource_types",
+			"transitions",
+			"environments",
+			"disabled",
+			"updated_by",
+			"updated_at",
+		),
+	)
+	if err != nil {
+		return nil, mapRepositoryError(err, "webhook_subscription", subscription.ID.String())
+	}
+	return updated, nil
+}
+
+func (r *BunSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*Subscription, error) {
+	record, err := r.repo.GetByID(ctx, id.String())
+	if err != nil {
+		return nil, mapRepositoryError(err, "webhook_subscription", id.String())
+	}
+	return record, nil
+}
+
+func (r *BunSubscriptionRepository) List(ctx context.Context) ([]*Subscription, error) {
+	records, _, err := r.repo.List(ctx, repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.OrderExpr("?TableAlias.created_at ASC, ?TableAlias.id ASC")
+	}))
+	return records, err
+}
+
+func (r *BunSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.repo.Delete(ctx, &Subscription{ID: id}); err != nil {
+		return mapRepositoryError(err, "webhook_subscription", id.String())
+	}
+	return nil
+}
+
+// BunDeliveryRepository implements DeliveryRepository. Deliveries change on
+// every attempt, so they are never cached.
+type BunDeliveryRepository struct {
+	repo repository.Repository[*Delivery]
+}
+
+// NewBunDeliveryRepository creates a delivery repository.
+func NewBunDeliveryRepository(db *bun.DB) *BunDeliveryRepository {
+	return &BunDeliveryRepository{repo: NewDeliveryRepository(db)}
+}
+
+func (r *BunDeliveryRepository) Create(ctx context.Context, delivery *Delivery) (*Delivery, error) {
+	return r.repo.Create(ctx, delivery)
+}
+
+func (r *BunDeliveryRepository) Update(ctx context.Context, delivery *Delivery) (*Delivery, error) {
+	updated, err := r.repo.Update(ctx, delivery,
+		repository.UpdateByID(delivery.ID.String()),
+		repository.UpdateColumns(
+			"status",
+			"attempts",
+			"response_code",
+			"response_body",
+			"error",
+			"next_attempt_at",
+			"delivered_at",
+			"updated_at",
+		),
+	)
+	if err != nil {
+		return nil, mapRepositoryError(err, "webhook_delivery", delivery.ID.String())
+	}
+	return updated, nil
+}
+
+func (r *BunDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	record, err := r.repo.GetByID(ctx, id.String())
+	if err != nil {
+		return nil, mapRepositoryError(err, "webhook_delivery", id.String())
+	}
+	return record, nil
+}
+
+func (r *BunDeliveryRepository) List(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error) {
+	records, _, err := r.repo.List(ctx, repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+		if filter.SubscriptionID != uuid.Nil {
+			q = q.Where("?TableAlias.subscription_id = ?", filter.SubscriptionID)
+		}
+		if filter.Status != "" {
+			q = q.Where("?TableAlias.status = ?", string(filter.Status))
+		}
+		if filter.Limit > 0 {
+			q = q.Limit(filter.Limit)
+		}
+		return q.OrderExpr("?TableAlias.created_at DESC, ?TableAlias.id DESC")
+	}))
+	return records, err
+}
+
+func mapRepositoryError(err error, resource, key string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.IsCategory(err, repository.CategoryDatabaseNotFound) {
+		return &NotFoundError{Resource: resource, Key: key}
+	}
+	return fmt.Errorf("%s repository error: %w", resource, err)
+}
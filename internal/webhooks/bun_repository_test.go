@@ -0,0 +1,131 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/webhooks"
+	"github.com/goliatone/go-cms/pkg/testsupport"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func TestBunWebhookRepositories(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+
+	for _, model := range []any{(*webhooks.Subscription)(nil), (*webhooks.Delivery)(nil)} {
+		if _, err := bunDB.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+
+	subscriptions := webhooks.NewBunSubscriptionRepository(bunDB)
+	deliveries := webhooks.NewBunDeliveryRepository(bunDB)
+	now := time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC)
+
+	subscription := &webhooks.Subscription{
+		ID:            uuid.MustParse("00000000-0000-0000-0000-00000000a001"),
+		Name:          "search",
+		URL:           "https://search.example.com/hooks",
+		Secret:        "secret",
+		Sources:       []webhooks.Source{webhooks.SourceLifecycle},
+		ResourceTypes: []string{"page"},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if _, err := subscriptions.Create(ctx, subscription); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	subscription.Transitions = []string{"publish"}
+	subscription.Disabled = true
+	if _, err := subscriptions.Update(ctx, subscription); err != nil {
+		t.Fatalf("update subscription: %v", err)
+	}
+	loaded, err := subscriptions.GetByID(ctx, subscription.ID)
+	if err != nil {
+		t.Fatalf("get subscription: %v", err)
+	}
+	if !loaded.Disabled || len(loaded.Transitions) != 1 || loaded.Secret != "secret" || loaded.Sources[0] != webhooks.SourceLifecycle {
+		t.Fatalf("unexpected subscription %+v", loaded)
+	}
+
+	event := webhooks.Event{
+		ID:           uuid.MustParse("00000000-0000-0000-0000-00000000e001"),
+		Source:       webhooks.SourceLifecycle,
+		ResourceType: "page",
+		RecordID:     "p-1",
+		Transition:   "publish",
+		OccurredAt:   now,
+		Metadata:     map[string]any{"slug": "home"},
+	}
+	for i, status := range []webhooks.DeliveryStatus{webhooks.DeliveryFailed, webhooks.DeliveryPending} {
+		if _, err := deliveries.Create(ctx, &webhooks.Delivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventName:      event.Name(),
+			Event:          event,
+			Status:         status,
+			MaxAttempts:    5,
+			CreatedAt:      now.Add(time.Duration(i) * time.Minute),
+			UpdatedAt:      now,
+		}); err != nil {
+			t.Fatalf("create delivery: %v", err)
+		}
+	}
+
+	pending, err := deliveries.List(ctx, webhooks.DeliveryFilter{SubscriptionID: subscription.ID, Status: webhooks.DeliveryPending})
+	if err != nil {
+		t.Fatalf("list pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Event.RecordID != "p-1" || pending[0].Event.Metadata["slug"] != "home" {
+		t.Fatalf("unexpected pending deliveries %+v", pending)
+	}
+
+	delivery := pending[0]
+	deliveredAt := now.Add(time.Hour)
+	delivery.Status = webhooks.DeliverySucceeded
+	delivery.Attempts = 2
+	delivery.ResponseCode = 204
+	delivery.DeliveredAt = &deliveredAt
+	if _, err := deliveries.Update(ctx, delivery); err != nil {
+		t.Fatalf("update delivery: %v", err)
+	}
+	stored, err := deliveries.GetByID(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("get delivery: %v", err)
+	}
+	if stored.Status != webhooks.DeliverySucceeded || stored.ResponseCode != 204 || stored.DeliveredAt == nil {
+		t.Fatalf("unexpected delivery %+v", stored)
+	}
+
+	all, err := deliveries.List(ctx, webhooks.DeliveryFilter{Limit: 1})
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(all) != 1 || all[0].ID != delivery.ID {
+		t.Fatalf("expected newest delivery first, got %+v", all)
+	}
+
+	if err := subscriptions.Delete(ctx, subscription.ID); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	var nf *webhooks.NotFoundError
+	if _, err := subscriptions.GetByID(ctx, subscription.ID); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if _, err := deliveries.GetByID(ctx, uuid.New()); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError for delivery, got %v", err)
+	}
+}
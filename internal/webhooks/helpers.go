@@ -0,0 +1,143 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func normalizeURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", ErrSubscriptionURLRequired
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return "", ErrSubscriptionURLInvalid
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", ErrSubscriptionURLInvalid
+	}
+	return parsed.String(), nil
+}
+
+func normalizeSources(sources []Source) ([]Source, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	out := make([]Source, 0, len(sources))
+	for _, source := range sources {
+		normalized := Source(strings.ToLower(strings.TrimSpace(string(source))))
+		if normalized != SourceLifecycle && normalized != SourceActivity {
+			return nil, ErrSubscriptionSourceInvalid
+		}
+		if !slices.Contains(out, normalized) {
+			out = append(out, normalized)
+		}
+	}
+	return out, nil
+}
+
+func normalizeFilter(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		normalized := strings.ToLower(strings.TrimSpace(value))
+		if normalized != "" && !slices.Contains(out, normalized) {
+			out = append(out, normalized)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// redactSecret returns a copy of the subscription with its secret cleared.
+func redactSecret(subscription *Subscription) *Subscription {
+	if subscription == nil {
+		return nil
+	}
+	clone := *subscription
+	clone.Secret = ""
+	return &clone
+}
+
+// backoffDelay doubles the base delay for every failed attempt, capped at ceiling.
+func backoffDelay(base, ceiling time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < ceiling; i++ {
+		delay *= 2
+	}
+	return min(delay, ceiling)
+}
+
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	return value[:limit]
+}
+
+func cloneSubscription(subscription *Subscription) *Subscription {
+	if subscription == nil {
+		return nil
+	}
+	cloned := *subscription
+	cloned.Sources = slices.Clone(subscription.Sources)
+	cloned.ResourceTypes = slices.Clone(subscription.ResourceTypes)
+	cloned.Transitions = slices.Clone(subscription.Transitions)
+	cloned.Environments = slices.Clone(subscription.Environments)
+	return &cloned
+}
+
+func cloneDelivery(delivery *Delivery) *Delivery {
+	if delivery == nil {
+		return nil
+	}
+	cloned := *delivery
+	cloned.Event = cloneEvent(delivery.Event)
+	cloned.NextAttemptAt = cloneTime(delivery.NextAttemptAt)
+	cloned.DeliveredAt = cloneTime(delivery.DeliveredAt)
+	if delivery.ReplayOf != nil {
+		replayOf := *delivery.ReplayOf
+		cloned.ReplayOf = &replayOf
+	}
+	return &cloned
+}
+
+func cloneEvent(event Event) Event {
+	cloned := event
+	if event.Metadata != nil {
+		cloned.Metadata = maps.Clone(event.Metadata)
+	}
+	return cloned
+}
+
+func cloneTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+func uuidPtr(id uuid.UUID) *uuid.UUID {
+	return &id
+}
@@ -0,0 +1,151 @@
+package webhooks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type memorySubscriptionRepository struct {
+	mu   sync.RWMutex
+	byID map[uuid.UUID]*Subscription
+}
+
+// NewMemorySubscriptionRepository constructs an in-memory subscription repository.
+func NewMemorySubscriptionRepository() SubscriptionRepository {
+	return &memorySubscriptionRepository{
+		byID: make(map[uuid.UUID]*Subscription),
+	}
+}
+
+func (m *memorySubscriptionRepository) Create(_ context.Context, subscription *Subscription) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cloned := cloneSubscription(subscription)
+	m.byID[cloned.ID] = cloned
+	return cloneSubscription(cloned), nil
+}
+
+func (m *memorySubscriptionRepository) Update(_ context.Context, subscription *Subscription) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.byID[subscription.ID]; !ok {
+		return nil, &NotFoundError{Resource: "webhook_subscription", Key: subscription.ID.String()}
+	}
+	cloned := cloneSubscription(subscription)
+	m.byID[cloned.ID] = cloned
+	return cloneSubscription(cloned), nil
+}
+
+func (m *memorySubscriptionRepository) GetByID(_ context.Context, id uuid.UUID) (*Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.byID[id]
+	if !ok {
+		return nil, &NotFoundError{Resource: "webhook_subscription", Key: id.String()}
+	}
+	return cloneSubscription(record), nil
+}
+
+func (m *memorySubscriptionRepository) List(_ context.Context) ([]*Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	records := make([]*Subscription, 0, len(m.byID))
+	for _, record := range m.byID {
+		records = append(records, cloneSubscription(record))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].ID.String() < records[j].ID.String()
+		}
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+	return records, nil
+}
+
+func (m *memorySubscriptionRepository) Delete(_ context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.byID[id]; !ok {
+		return &NotFoundError{Resource: "webhook_subscription", Key: id.String()}
+	}
+	delete(m.byID, id)
+	return nil
+}
+
+type memoryDeliveryRepository struct {
+	mu   sync.RWMutex
+	byID map[uuid.UUID]*Delivery
+}
+
+// NewMemoryDeliveryRepository constructs an in-memory delivery log.
+func NewMemoryDeliveryRepository() DeliveryRepository {
+	return &memoryDeliveryRepository{
+		byID: make(map[uuid.UUID]*Delivery),
+	}
+}
+
+func (m *memoryDeliveryRepository) Create(_ context.Context, delivery *Delivery) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cloned := cloneDelivery(delivery)
+	m.byID[cloned.ID] = cloned
+	return cloneDelivery(cloned), nil
+}
+
+func (m *memoryDeliveryRepository) Update(_ context.Context, delivery *Delivery) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.byID[delivery.ID]; !ok {
+		return nil, &NotFoundError{Resource: "webhook_delivery", Key: delivery.ID.String()}
+	}
+	cloned := cloneDelivery(delivery)
+	m.byID[cloned.ID] = cloned
+	return cloneDelivery(cloned), nil
+}
+
+func (m *memoryDeliveryRepository) GetByID(_ context.Context, id uuid.UUID) (*Delivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.byID[id]
+	if !ok {
+		return nil, &NotFoundError{Resource: "webhook_delivery", Key: id.String()}
+	}
+	return cloneDelivery(record), nil
+}
+
+func (m *memoryDeliveryRepository) List(_ context.Context, filter DeliveryFilter) ([]*Delivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var records []*Delivery
+	for _, record := range m.byID {
+		if filter.SubscriptionID != uuid.Nil && record.SubscriptionID != filter.SubscriptionID {
+			continue
+		}
+		if filter.Status != "" && record.Status != filter.Status {
+			continue
+		}
+		records = append(records, cloneDelivery(record))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].ID.String() > records[j].ID.String()
+		}
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
@@ -0,0 +1,42 @@
+package webhooks
+
+import cmswebhooks "github.com/goliatone/go-cms/webhooks"
+
+type (
+	Source                        = cmswebhooks.Source
+	DeliveryStatus                = cmswebhooks.DeliveryStatus
+	Event                         = cmswebhooks.Event
+	Subscription                  = cmswebhooks.Subscription
+	Delivery                      = cmswebhooks.Delivery
+	Service                       = cmswebhooks.Service
+	Dispatcher                    = cmswebhooks.Dispatcher
+	CreateSubscriptionInput       = cmswebhooks.CreateSubscriptionInput
+	UpdateSubscriptionInput       = cmswebhooks.UpdateSubscriptionInput
+	RotateSubscriptionSecretInput = cmswebhooks.RotateSubscriptionSecretInput
+	DeliveryFilter                = cmswebhooks.DeliveryFilter
+	LifecycleHook                 = cmswebhooks.LifecycleHook
+	ActivityHook                  = cmswebhooks.ActivityHook
+)
+
+const (
+	HeaderDeliveryID  = cmswebhooks.HeaderDeliveryID
+	HeaderEvent       = cmswebhooks.HeaderEvent
+	HeaderTimestamp   = cmswebhooks.HeaderTimestamp
+	HeaderSignature   = cmswebhooks.HeaderSignature
+	SourceLifecycle   = cmswebhooks.SourceLifecycle
+	SourceActivity    = cmswebhooks.SourceActivity
+	DeliveryPending   = cmswebhooks.DeliveryPending
+	DeliverySucceeded = cmswebhooks.DeliverySucceeded
+	DeliveryFailed    = cmswebhooks.DeliveryFailed
+)
+
+var (
+	ErrFeatureDisabled                = cmswebhooks.ErrFeatureDisabled
+	ErrSubscriptionNotFound           = cmswebhooks.ErrSubscriptionNotFound
+	ErrSubscriptionURLRequired        = cmswebhooks.ErrSubscriptionURLRequired
+	ErrSubscriptionURLInvalid         = cmswebhooks.ErrSubscriptionURLInvalid
+	ErrSubscriptionSourceInvalid      = cmswebhooks.ErrSubscriptionSourceInvalid
+	ErrDeliveryNotFound               = cmswebhooks.ErrDeliveryNotFound
+	ErrSubscriptionRepositoryRequired = cmswebhooks.ErrSubscriptionRepositoryRequired
+	ErrDeliveryRepositoryRequired     = cmswebhooks.ErrDeliveryRepositoryRequired
+)
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	repository "github.com/goliatone/go-repository-bun"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// NewSubscriptionRepository creates a repository for webhook subscriptions.
+func NewSubscriptionRepository(db *bun.DB) repository.Repository[*Subscription] {
+	return repository.MustNewRepositoryWithConfig(db, repository.ModelHandlers[*Subscription]{
+		NewRecord: func() *Subscription { return &Subscription{} },
+		GetID: func(subscription *Subscription) uuid.UUID {
+			return subscription.ID
+		},
+		SetID: func(subscription *Subscription, id uuid.UUID) {
+			subscription.ID = id
+		},
+		GetIdentifier: func() string {
+			return "id"
+		},
+		GetIdentifierValue: func(subscription *Subscription) string {
+			return subscription.ID.String()
+		},
+	}, nil, repository.WithDefaultListPagination(0, 0))
+}
+
+// NewDeliveryRepository creates a repository for webhook deliveries.
+func NewDeliveryRepository(db *bun.DB) repository.Repository[*Delivery] {
+	return repository.MustNewRepositoryWithConfig(db, repository.ModelHandlers[*Delivery]{
+		NewRecord: func() *Delivery { return &Delivery{} },
+		GetID: func(delivery *Delivery) uuid.UUID {
+			return delivery.ID
+		},
+		SetID: func(delivery *Delivery, id uuid.UUID) {
+			delivery.ID = id
+		},
+		GetIdentifier: func() string {
+			return "id"
+		},
+		GetIdentifierValue: func(delivery *Delivery) string {
+			return delivery.ID.String()
+		},
+	}, nil, repository.WithDefaultListPagination(0, 0))
+}
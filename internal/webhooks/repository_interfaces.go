@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionRepository exposes persistence operations for subscriptions.
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	Update(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	List(ctx context.Context) ([]*Subscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// DeliveryRepository exposes persistence operations for the delivery log.
+type DeliveryRepository interface {
+	Create(ctx context.Context, delivery *Delivery) (*Delivery, error)
+	Update(ctx context.Context, delivery *Delivery) (*Delivery, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	List(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+}
+
+// NotFoundError is returned when a subscription or delivery cannot be located.
+type NotFoundError struct {
+	Resource string
+	Key      string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s not found", e.Resource)
+	}
+	return fmt.Sprintf("%s %q not found", e.Resource, e.Key)
+}
@@ -0,0 +1,564 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	cmswebhooks "github.com/goliatone/go-cms/webhooks"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxAttempts   = 5
+	defaultBackoff       = 30 * time.Second
+	defaultMaxBackoff    = time.Hour
+	defaultTimeout       = 10 * time.Second
+	defaultDeliveryLimit = 100
+	maxResponseBody      = 4096
+	userAgent            = "go-cms-webhooks"
+)
+
+// IDGenerator produces unique identifiers.
+type IDGenerator func() uuid.UUID
+
+// ServiceOption configures service behaviour.
+type ServiceOption func(*service)
+
+// WithIDGenerator overrides the ID generator.
+func WithIDGenerator(generator IDGenerator) ServiceOption {
+	return func(s *service) {
+		if generator != nil {
+			s.id = generator
+		}
+	}
+}
+
+// WithNow overrides the time source (primarily for tests).
+func WithNow(now func() time.Time) ServiceOption {
+	return func(s *service) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+// WithScheduler queues deliveries and retries as scheduler jobs. Without a
+// scheduler each delivery is attempted once, inline with the event.
+func WithScheduler(scheduler interfaces.Scheduler) ServiceOption {
+	return func(s *service) {
+		if scheduler != nil {
+			s.scheduler = scheduler
+		}
+	}
+}
+
+// WithHTTPClient overrides the client used to post deliveries.
+func WithHTTPClient(client *http.Client) ServiceOption {
+	return func(s *service) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// WithTimeout bounds each delivery request.
+func WithTimeout(timeout time.Duration) ServiceOption {
+	return func(s *service) {
+		if timeout > 0 {
+			s.timeout = timeout
+		}
+	}
+}
+
+// WithMaxAttempts caps how often a delivery is attempted before it is marked
+// failed.
+func WithMaxAttempts(attempts int) ServiceOption {
+	return func(s *service) {
+		if attempts > 0 {
+			s.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the ceiling the
+// doubling delay is capped at.
+func WithBackoff(base, ceiling time.Duration) ServiceOption {
+	return func(s *service) {
+		if base > 0 {
+			s.backoff = base
+		}
+		if ceiling > 0 {
+			s.maxBackoff = ceiling
+		}
+	}
+}
+
+type service struct {
+	subscriptions SubscriptionRepository
+	deliveries    DeliveryRepository
+	scheduler     interfaces.Scheduler
+	client        *http.Client
+	timeout       time.Duration
+	maxAttempts   int
+	backoff       time.Duration
+	maxBackoff    time.Duration
+	id            IDGenerator
+	now           func() time.Time
+}
+
+// NewService constructs a webhook service instance.
+func NewService(subscriptions SubscriptionRepository, deliveries DeliveryRepository, opts ...ServiceOption) Service {
+	if subscriptions == nil {
+		panic(ErrSubscriptionRepositoryRequired)
+	}
+	if deliveries == nil {
+		panic(ErrDeliveryRepositoryRequired)
+	}
+	s := &service{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        http.DefaultClient,
+		timeout:       defaultTimeout,
+		maxAttempts:   defaultMaxAttempts,
+		backoff:       defaultBackoff,
+		maxBackoff:    defaultMaxBackoff,
+		id:            uuid.New,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) CreateSubscription(ctx context.Context, input CreateSubscriptionInput) (*Subscription, error) {
+	endpoint, err := normalizeURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := normalizeSources(input.Sources)
+	if err != nil {
+		return nil, err
+	}
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = endpoint
+	}
+
+	now := s.now().UTC()
+	return s.subscriptions.Create(ctx, &Subscription{
+		ID:            s.id(),
+		Name:          name,
+		URL:           endpoint,
+		Secret:        secret,
+		Sources:       sources,
+		ResourceTypes: normalizeFilter(input.ResourceTypes),
+		Transitions:   normalizeFilter(input.Transitions),
+		Environments:  normalizeFilter(input.Environments),
+		Disabled:      input.Disabled,
+		CreatedBy:     input.CreatedBy,
+		UpdatedBy:     input.CreatedBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+func (s *service) UpdateSubscription(ctx context.Context, input UpdateSubscriptionInput) (*Subscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, input.ID)
+	if err != nil {
+		return nil, translateRepoError(err, ErrSubscriptionNotFound)
+	}
+	if input.URL != nil {
+		endpoint, err := normalizeURL(*input.URL)
+		if err != nil {
+			return nil, err
+		}
+		subscription.URL = endpoint
+	}
+	if input.Name != nil {
+		subscription.Name = strings.TrimSpace(*input.Name)
+		if subscription.Name == "" {
+			subscription.Name = subscription.URL
+		}
+	}
+	if input.Sources != nil {
+		sources, err := normalizeSources(input.Sources)
+		if err != nil {
+			return nil, err
+		}
+		subscription.Sources = sources
+	}
+	if input.ResourceTypes != nil {
+		subscription.ResourceTypes = normalizeFilter(input.ResourceTypes)
+	}
+	if input.Transitions != nil {
+		subscription.Transitions = normalizeFilter(input.Transitions)
+	}
+	if input.Environments != nil {
+		subscription.Environments = normalizeFilter(input.Environments)
+	}
+	if input.Disabled != nil {
+		subscription.Disabled = *input.Disabled
+	}
+	subscription.UpdatedBy = input.UpdatedBy
+	subscription.UpdatedAt = s.now().UTC()
+
+	updated, err := s.subscriptions.Update(ctx, subscription)
+	if err != nil {
+		return nil, translateRepoError(err, ErrSubscriptionNotFound)
+	}
+	return redactSecret(updated), nil
+}
+
+// RotateSubscriptionSecret replaces the signing secret and returns the
+// subscription with the new secret, the only read besides creation that
+// exposes it.
+func (s *service) RotateSubscriptionSecret(ctx context.Context, input RotateSubscriptionSecretInput) (*Subscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, input.ID)
+	if err != nil {
+		return nil, translateRepoError(err, ErrSubscriptionNotFound)
+	}
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	subscription.Secret = secret
+	subscription.UpdatedBy = input.UpdatedBy
+	subscription.UpdatedAt = s.now().UTC()
+
+	updated, err := s.subscriptions.Update(ctx, subscription)
+	if err != nil {
+		return nil, translateRepoError(err, ErrSubscriptionNotFound)
+	}
+	return updated, nil
+}
+
+// GetSubscription returns the subscription without its secret.
+func (s *service) GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error) {
+	record, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err, ErrSubscriptionNotFound)
+	}
+	return redactSecret(record), nil
+}
+
+// ListSubscriptions returns every subscription without its secret.
+func (s *service) ListSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	records, err := s.subscriptions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Subscription, 0, len(records))
+	for _, record := range records {
+		out = append(out, redactSecret(record))
+	}
+	return out, nil
+}
+
+// DeleteSubscription removes the subscription. Its delivery log is kept;
+// pending deliveries fail on their next attempt.
+func (s *service) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return translateRepoError(s.subscriptions.Delete(ctx, id), ErrSubscriptionNotFound)
+}
+
+// Dispatch records a pending delivery for every subscription matching the
+// event and queues its first attempt. Events without a source are treated as
+// lifecycle events.
+func (s *service) Dispatch(ctx context.Context, event Event) ([]*Delivery, error) {
+	if strings.TrimSpace(event.ResourceType) == "" || strings.TrimSpace(event.Transition) == "" {
+		return nil, nil
+	}
+	if event.ID == uuid.Nil {
+		event.ID = s.id()
+	}
+	if event.Source == "" {
+		event.Source = SourceLifecycle
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.now().UTC()
+	}
+	subscriptions, err := s.subscriptions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		deliveries []*Delivery
+		errs       []error
+	)
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event) {
+			continue
+		}
+		delivery, err := s.queue(ctx, subscription.ID, event, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhooks: dispatch to %s: %w", subscription.ID, err))
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, errors.Join(errs...)
+}
+
+// Deliver performs the next attempt of a pending delivery. Endpoint failures
+// are recorded on the delivery rather than returned: a retry is queued with
+// exponential backoff until the attempts are exhausted, after which the
+// delivery is marked failed. Deliveries that are no longer pending are
+// returned unchanged.
+func (s *service) Deliver(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	delivery, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status != DeliveryPending {
+		return delivery, nil
+	}
+
+	subscription, err := s.subscriptions.GetByID(ctx, delivery.SubscriptionID)
+	switch {
+	case err != nil && isNotFound(err):
+		return s.fail(ctx, delivery, "subscription not found")
+	case err != nil:
+		return nil, err
+	case subscription.Disabled:
+		return s.fail(ctx, delivery, "subscription disabled")
+	}
+
+	code, body, postErr := s.post(ctx, subscription, delivery)
+	now := s.now().UTC()
+	delivery.Attempts++
+	delivery.ResponseCode = code
+	delivery.ResponseBody = body
+	delivery.Error = ""
+	delivery.NextAttemptAt = nil
+	delivery.UpdatedAt = now
+
+	switch {
+	case postErr == nil && code >= 200 && code < 300:
+		delivery.Status = DeliverySucceeded
+		delivery.DeliveredAt = &now
+	case delivery.Attempts < delivery.MaxAttempts && s.scheduler != nil:
+		next := now.Add(backoffDelay(s.backoff, s.maxBackoff, delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.Error = failureMessage(code, postErr)
+	default:
+		delivery.Status = DeliveryFailed
+		delivery.Error = failureMessage(code, postErr)
+	}
+
+	updated, err := s.deliveries.Update(ctx, delivery)
+	if err != nil {
+		return nil, translateRepoError(err, ErrDeliveryNotFound)
+	}
+	if updated.Status == DeliveryPending {
+		if err := s.enqueue(ctx, updated); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+func (s *service) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	record, err := s.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err, ErrDeliveryNotFound)
+	}
+	return record, nil
+}
+
+func (s *service) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	return s.deliveries.List(ctx, filter)
+}
+
+// ReplayDelivery sends the event of an earlier delivery again as a new
+// delivery with a fresh attempt budget. The event ID is preserved.
+func (s *service) ReplayDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	original, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetSubscription(ctx, original.SubscriptionID); err != nil {
+		return nil, err
+	}
+	return s.queue(ctx, original.SubscriptionID, original.Event, uuidPtr(original.ID))
+}
+
+func (s *service) queue(ctx context.Context, subscriptionID uuid.UUID, event Event, replayOf *uuid.UUID) (*Delivery, error) {
+	now := s.now().UTC()
+	maxAttempts := s.maxAttempts
+	if s.scheduler == nil {
+		maxAttempts = 1
+	}
+	created, err := s.deliveries.Create(ctx, &Delivery{
+		ID:             s.id(),
+		SubscriptionID: subscriptionID,
+		EventName:      event.Name(),
+		Event:          cloneEvent(event),
+		Status:         DeliveryPending,
+		MaxAttempts:    maxAttempts,
+		NextAttemptAt:  &now,
+		ReplayOf:       replayOf,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.scheduler == nil {
+		return s.Deliver(ctx, created.ID)
+	}
+	if err := s.enqueue(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *service) enqueue(ctx context.Context, delivery *Delivery) error {
+	runAt := s.now().UTC()
+	if delivery.NextAttemptAt != nil {
+		runAt = *delivery.NextAttemptAt
+	}
+	_, err := s.scheduler.Enqueue(ctx, interfaces.JobSpec{
+		Key:   cmsscheduler.WebhookDeliveryJobKey(delivery.ID, delivery.Attempts+1),
+		Type:  cmsscheduler.JobTypeWebhookDeliver,
+		RunAt: runAt,
+		Payload: map[string]any{
+			"delivery_id": delivery.ID.String(),
+		},
+		MaxAttempts: 1,
+	})
+	return err
+}
+
+func (s *service) post(ctx context.Context, subscription *Subscription, delivery *Delivery) (int, string, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(cmswebhooks.HeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(cmswebhooks.HeaderEvent, delivery.EventName)
+	req.Header.Set(cmswebhooks.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(cmswebhooks.HeaderSignature, cmswebhooks.Sign(subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	payload, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, string(payload), nil
+}
+
+func (s *service) fail(ctx context.Context, delivery *Delivery, reason string) (*Delivery, error) {
+	delivery.Status = DeliveryFailed
+	delivery.Error = reason
+	delivery.NextAttemptAt = nil
+	delivery.UpdatedAt = s.now().UTC()
+	updated, err := s.deliveries.Update(ctx, delivery)
+	if err != nil {
+		return nil, translateRepoError(err, ErrDeliveryNotFound)
+	}
+	return updated, nil
+}
+
+func failureMessage(code int, err error) string {
+	if err != nil {
+		return truncate(err.Error(), maxResponseBody)
+	}
+	return fmt.Sprintf("unexpected status %d", code)
+}
+
+func isNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
+
+func translateRepoError(err, notFound error) error {
+	if err != nil && isNotFound(err) {
+		return notFound
+	}
+	return err
+}
+
+type disabledService struct{}
+
+// NewDisabledService returns a Service that fails all operations with
+// ErrFeatureDisabled. Dispatch drops events.
+func NewDisabledService() Service {
+	return disabledService{}
+}
+
+func (disabledService) CreateSubscription(context.Context, CreateSubscriptionInput) (*Subscription, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) UpdateSubscription(context.Context, UpdateSubscriptionInput) (*Subscription, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) RotateSubscriptionSecret(context.Context, RotateSubscriptionSecretInput) (*Subscription, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) GetSubscription(context.Context, uuid.UUID) (*Subscription, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) ListSubscriptions(context.Context) ([]*Subscription, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) DeleteSubscription(context.Context, uuid.UUID) error {
+	return ErrFeatureDisabled
+}
+
+func (disabledService) Dispatch(context.Context, Event) ([]*Delivery, error) {
+	return nil, nil
+}
+
+func (disabledService) Deliver(context.Context, uuid.UUID) (*Delivery, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) GetDelivery(context.Context, uuid.UUID) (*Delivery, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) ListDeliveries(context.Context, DeliveryFilter) ([]*Delivery, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (disabledService) ReplayDelivery(context.Context, uuid.UUID) (*Delivery, error) {
+	return nil, ErrFeatureDisabled
+}
@@ -0,0 +1,446 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	cmsscheduler "github.com/goliatone/go-cms/internal/scheduler"
+	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	cmswebhooks "github.com/goliatone/go-cms/webhooks"
+	"github.com/google/uuid"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type receiver struct {
+	mu       sync.Mutex
+	requests []receivedRequest
+	statuses []int
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*receiver, *httptest.Server) {
+	t.Helper()
+	r := &receiver{statuses: statuses}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status = r.statuses[0]
+			r.statuses = r.statuses[1:]
+		}
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ack"))
+	}))
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func TestServiceDeliversSignedEventsInline(t *testing.T) {
+	ctx := context.Background()
+	recv, server := newReceiver(t)
+	svc := NewService(NewMemorySubscriptionRepository(), NewMemoryDeliveryRepository())
+
+	subscription, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{
+		Name:   "cdn purge",
+		URL:    server.URL,
+		Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+
+	hook := cmswebhooks.LifecycleHook{Dispatcher: svc}
+	if err := hook.Notify(ctx, lifecycle.Event{
+		ResourceType:   "page",
+		RecordID:       "page-1",
+		Transition:     "publish",
+		Status:         "published",
+		EnvironmentKey: "default",
+	}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	requests := recv.received()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+	timestamp, err := strconv.ParseInt(req.header.Get(cmswebhooks.HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("parse timestamp: %v", err)
+	}
+	if !cmswebhooks.Verify("s3cret", timestamp, req.body, req.header.Get(cmswebhooks.HeaderSignature)) {
+		t.Fatalf("signature did not verify: %s", req.header.Get(cmswebhooks.HeaderSignature))
+	}
+	if got := req.header.Get(cmswebhooks.HeaderEvent); got != "page.publish" {
+		t.Fatalf("expected event header page.publish, got %q", got)
+	}
+	var event Event
+	if err := json.Unmarshal(req.body, &event); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if event.Source != SourceLifecycle || event.RecordID != "page-1" || event.EnvironmentKey != "default" {
+		t.Fatalf("unexpected event body %+v", event)
+	}
+
+	deliveries, err := svc.ListDeliveries(ctx, DeliveryFilter{SubscriptionID: subscription.ID})
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(deliveries))
+	}
+	delivery := deliveries[0]
+	if delivery.Status != DeliverySucceeded || delivery.ResponseCode != http.StatusOK || delivery.Attempts != 1 {
+		t.Fatalf("unexpected delivery %+v", delivery)
+	}
+	if delivery.ResponseBody != "ack" || delivery.DeliveredAt == nil {
+		t.Fatalf("expected response body and delivered_at, got %+v", delivery)
+	}
+	if req.header.Get(cmswebhooks.HeaderDeliveryID) != delivery.ID.String() {
+		t.Fatalf("expected delivery header %s, got %s", delivery.ID, req.header.Get(cmswebhooks.HeaderDeliveryID))
+	}
+}
+
+func TestServiceDispatchAppliesFilters(t *testing.T) {
+	ctx := context.Background()
+	_, server := newReceiver(t)
+	svc := NewService(NewMemorySubscriptionRepository(), NewMemoryDeliveryRepository())
+
+	pages, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{
+		URL:           server.URL,
+		Sources:       []Source{SourceLifecycle},
+		ResourceTypes: []string{"Page"},
+		Transitions:   []string{"publish", "unpublish"},
+		Environments:  []string{"production"},
+	})
+	if err != nil {
+		t.Fatalf("create pages subscription: %v", err)
+	}
+	everything, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: server.URL})
+	if err != nil {
+		t.Fatalf("create catch-all subscription: %v", err)
+	}
+	activityOnly, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: server.URL, Sources: []Source{SourceActivity}})
+	if err != nil {
+		t.Fatalf("create activity subscription: %v", err)
+	}
+	if _, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: server.URL, Disabled: true}); err != nil {
+		t.Fatalf("create disabled subscription: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		event Event
+		want  []uuid.UUID
+	}{
+		{
+			name:  "matching lifecycle event",
+			event: Event{Source: SourceLifecycle, ResourceType: "page", RecordID: "1", Transition: "publish", EnvironmentKey: "production"},
+			want:  []uuid.UUID{pages.ID, everything.ID},
+		},
+		{
+			name:  "other environment",
+			event: Event{Source: SourceLifecycle, ResourceType: "page", RecordID: "1", Transition: "publish", EnvironmentKey: "staging"},
+			want:  []uuid.UUID{everything.ID},
+		},
+		{
+			name:  "other transition",
+			event: Event{Source: SourceLifecycle, ResourceType: "page", RecordID: "1", Transition: "update", EnvironmentKey: "production"},
+			want:  []uuid.UUID{everything.ID},
+		},
+		{
+			name:  "activity source",
+			event: cmswebhooks.EventFromActivity(activity.Event{Verb: "publish", ObjectType: "page", ObjectID: "1", Metadata: map[string]any{"environment_key": "production"}}),
+			want:  []uuid.UUID{activityOnly.ID},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deliveries, err := svc.Dispatch(ctx, tc.event)
+			if err != nil {
+				t.Fatalf("dispatch: %v", err)
+			}
+			if len(deliveries) != len(tc.want) {
+				t.Fatalf("expected %d deliveries, got %d", len(tc.want), len(deliveries))
+			}
+			for _, delivery := range deliveries {
+				if !slices.Contains(tc.want, delivery.SubscriptionID) {
+					t.Fatalf("unexpected delivery to subscription %s", delivery.SubscriptionID)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceRetriesWithBackoffThroughScheduler(t *testing.T) {
+	ctx := context.Background()
+	recv, server := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent)
+	now := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	scheduler := cmsscheduler.NewInMemory(cmsscheduler.WithClock(clock))
+	svc := NewService(NewMemorySubscriptionRepository(), NewMemoryDeliveryRepository(),
+		WithScheduler(scheduler),
+		WithNow(clock),
+		WithBackoff(time.Minute, time.Hour),
+	)
+
+	if _, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: server.URL}); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	deliveries, err := svc.Dispatch(ctx, Event{ResourceType: "content", RecordID: "c-1", Transition: "publish"})
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != DeliveryPending || deliveries[0].MaxAttempts != defaultMaxAttempts {
+		t.Fatalf("expected one pending delivery, got %+v", deliveries)
+	}
+	if len(recv.received()) != 0 {
+		t.Fatalf("expected no request before the job runs")
+	}
+	id := deliveries[0].ID
+
+	runDue := func() *Delivery {
+		t.Helper()
+		jobs, err := scheduler.ListDue(ctx, now, 10)
+		if err != nil {
+			t.Fatalf("list due: %v", err)
+		}
+		if len(jobs) != 1 || jobs[0].Type != cmsscheduler.JobTypeWebhookDeliver {
+			t.Fatalf("expected one due delivery job, got %+v", jobs)
+		}
+		delivery, err := svc.Deliver(ctx, id)
+		if err != nil {
+			t.Fatalf("deliver: %v", err)
+		}
+		if err := scheduler.MarkDone(ctx, jobs[0].ID); err != nil {
+			t.Fatalf("mark done: %v", err)
+		}
+		return delivery
+	}
+
+	first := runDue()
+	if first.Status != DeliveryPending || first.Attempts != 1 || first.ResponseCode != http.StatusInternalServerError {
+		t.Fatalf("unexpected delivery after first attempt %+v", first)
+	}
+	if first.NextAttemptAt == nil || !first.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected retry after 1m, got %v", first.NextAttemptAt)
+	}
+	if due, _ := scheduler.ListDue(ctx, now, 10); len(due) != 0 {
+		t.Fatalf("expected retry to wait for its backoff, got %d due jobs", len(due))
+	}
+
+	now = now.Add(time.Minute)
+	second := runDue()
+	if second.Attempts != 2 || second.ResponseCode != http.StatusBadGateway || second.Error != "unexpected status 502" {
+		t.Fatalf("unexpected delivery after second attempt %+v", second)
+	}
+	if second.NextAttemptAt == nil || !second.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("expected retry after 2m, got %v", second.NextAttemptAt)
+	}
+
+	now = now.Add(2 * time.Minute)
+	third := runDue()
+	if third.Status != DeliverySucceeded || third.Attempts != 3 || third.ResponseCode != http.StatusNoContent || third.Error != "" {
+		t.Fatalf("unexpected delivery after third attempt %+v", third)
+	}
+	if len(recv.received()) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(recv.received()))
+	}
+	if _, err := scheduler.GetByKey(ctx, cmsscheduler.WebhookDeliveryJobKey(id, 4)); !errors.Is(err, interfaces.ErrJobNotFound) {
+		t.Fatalf("expected no further attempts to be queued, got %v", err)
+	}
+}
+
+func TestServiceMarksDeliveryFailedAndReplays(t *testing.T) {
+	ctx := context.Background()
+	recv, server := newReceiver(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	svc := NewService(NewMemorySubscriptionRepository(), NewMemoryDeliveryRepository())
+
+	subscription, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: server.URL})
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	deliveries, err := svc.Dispatch(ctx, Event{ResourceType: "media", RecordID: "m-1", Transition: "delete"})
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	failed := deliveries[0]
+	if failed.Status != DeliveryFailed || failed.Attempts != 1 || failed.ResponseCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected failed delivery without a scheduler, got %+v", failed)
+	}
+
+	replayed, err := svc.ReplayDelivery(ctx, failed.ID)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed.ID == failed.ID || replayed.ReplayOf == nil || *replayed.ReplayOf != failed.ID {
+		t.Fatalf("expected a new delivery replaying %s, got %+v", failed.ID, replayed)
+	}
+	if replayed.Event.ID != failed.Event.ID {
+		t.Fatalf("expected replay to keep event id %s, got %s", failed.Event.ID, replayed.Event.ID)
+	}
+	if replayed.Status != DeliveryFailed {
+		t.Fatalf("expected replay to fail against the still unavailable receiver, got %s", replayed.Status)
+	}
+
+	replayed, err = svc.ReplayDelivery(ctx, failed.ID)
+	if err != nil {
+		t.Fatalf("second replay: %v", err)
+	}
+	if replayed.Status != DeliverySucceeded {
+		t.Fatalf("expected second replay to succeed, got %+v", replayed)
+	}
+	if len(recv.received()) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(recv.received()))
+	}
+
+	log, err := svc.ListDeliveries(ctx, DeliveryFilter{SubscriptionID: subscription.ID, Status: DeliveryFailed})
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(log) != 2 {
+		t.Fatalf("expected 2 failed deliveries in the log, got %d", len(log))
+	}
+
+	if err := svc.DeleteSubscription(ctx, subscription.ID); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	if _, err := svc.ReplayDelivery(ctx, failed.ID); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound after delete, got %v", err)
+	}
+}
+
+func TestServiceSubscriptionValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemorySubscriptionRepository(), NewMemoryDeliveryRepository())
+
+	cases := []struct {
+		name  string
+		input CreateSubscriptionInput
+		want  error
+	}{
+		{name: "missing url", input: CreateSubscriptionInput{}, want: ErrSubscriptionURLRequired},
+		{name: "relative url", input: CreateSubscriptionInput{URL: "/hooks"}, want: ErrSubscriptionURLInvalid},
+		{name: "unsupported scheme", input: CreateSubscriptionInput{URL: "ftp://example.com/hooks"}, want: ErrSubscriptionURLInvalid},
+		{name: "unknown source", input: CreateSubscriptionInput{URL: "https://example.com/hooks", Sources: []Source{"audit"}}, want: ErrSubscriptionSourceInvalid},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := svc.CreateSubscription(ctx, tc.input); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	created, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: "https://example.com/hooks"})
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	if len(created.Secret) != 64 || created.Name != "https://example.com/hooks" {
+		t.Fatalf("expected generated secret and default name, got %+v", created)
+	}
+
+	disabled := true
+	updated, err := svc.UpdateSubscription(ctx, UpdateSubscriptionInput{
+		ID:            created.ID,
+		ResourceTypes: []string{" Page ", "page"},
+		Disabled:      &disabled,
+	})
+	if err != nil {
+		t.Fatalf("update subscription: %v", err)
+	}
+	if !updated.Disabled || len(updated.ResourceTypes) != 1 || updated.ResourceTypes[0] != "page" || updated.Secret != "" {
+		t.Fatalf("unexpected updated subscription %+v", updated)
+	}
+	if _, err := svc.UpdateSubscription(ctx, UpdateSubscriptionInput{ID: uuid.New()}); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+}
+
+func TestServiceRedactsSecretsOutsideCreateAndRotate(t *testing.T) {
+	ctx := context.Background()
+	recv, server := newReceiver(t, http.StatusOK)
+	svc := NewService(NewMemorySubscriptionRepository(), NewMemoryDeliveryRepository())
+
+	created, err := svc.CreateSubscription(ctx, CreateSubscriptionInput{URL: server.URL, Secret: "first"})
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	if created.Secret != "first" {
+		t.Fatalf("expected create to return the secret, got %q", created.Secret)
+	}
+
+	loaded, err := svc.GetSubscription(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("get subscription: %v", err)
+	}
+	listed, err := svc.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("list subscriptions: %v", err)
+	}
+	if loaded.Secret != "" || len(listed) != 1 || listed[0].Secret != "" {
+		t.Fatalf("expected secrets redacted on reads, got %q and %+v", loaded.Secret, listed)
+	}
+
+	rotated, err := svc.RotateSubscriptionSecret(ctx, RotateSubscriptionSecretInput{ID: created.ID})
+	if err != nil {
+		t.Fatalf("rotate secret: %v", err)
+	}
+	if len(rotated.Secret) != 64 || rotated.Secret == created.Secret {
+		t.Fatalf("expected a new generated secret, got %q", rotated.Secret)
+	}
+	if _, err := svc.RotateSubscriptionSecret(ctx, RotateSubscriptionSecretInput{ID: uuid.New()}); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+
+	if _, err := svc.Dispatch(ctx, Event{ResourceType: "page", RecordID: "1", Transition: "publish"}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	requests := recv.received()
+	if len(requests) != 1 {
+		t.Fatalf("expected one delivery, got %d", len(requests))
+	}
+	req := requests[0]
+	timestamp, err := strconv.ParseInt(req.header.Get(cmswebhooks.HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("parse timestamp: %v", err)
+	}
+	if !cmswebhooks.Verify(rotated.Secret, timestamp, req.body, req.header.Get(cmswebhooks.HeaderSignature)) {
+		t.Fatalf("expected delivery signed with the rotated secret")
+	}
+}
+
+func TestDisabledServiceDropsEvents(t *testing.T) {
+	svc := NewDisabledService()
+	deliveries, err := svc.Dispatch(context.Background(), Event{ResourceType: "page", RecordID: "1", Transition: "publish"})
+	if err != nil || deliveries != nil {
+		t.Fatalf("expected dispatch to be a no-op, got %v %v", deliveries, err)
+	}
+	if _, err := svc.ListSubscriptions(context.Background()); !errors.Is(err, ErrFeatureDisabled) {
+		t.Fatalf("expected ErrFeatureDisabled, got %v", err)
+	}
+}
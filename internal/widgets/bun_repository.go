@@ -6,6 +6,7 @@ import (
 	"maps"
 	"time"
 
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-errors"
 	repository "github.com/goliatone/go-repository-bun"
 	"github.com/goliatone/go-repository-cache/cache"
@@ -87,6 +88,7 @@ func (r *BunDefinitionRepository) Delete(ctx context.Context, id uuid.UUID) erro
 
 // BunInstanceRepository implements InstanceRepository with optional caching.
 type BunInstanceRepository struct {
+	db   *bun.DB
 	repo repository.Repository[*Instance]
 }
 
@@ -101,7 +103,7 @@ func NewBunInstanceRepositoryWithCache(db *bun.DB, cacheService cache.CacheServi
 	if cacheService != nil && serializer != nil {
 		base = repositorycache.New(base, cacheService, serializer)
 	}
-	return &BunInstanceRepository{repo: base}
+	return &BunInstanceRepository{db: db, repo: base}
 }
 
 func (r *BunInstanceRepository) Create(ctx context.Context, instance *Instance) (*Instance, error) {
@@ -151,6 +153,155 @@ func (r *BunInstanceRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return r.repo.Delete(ctx, &Instance{ID: id})
 }
 
+// DeleteToTrash stores the trash entry and removes the instance with its
+// translations, versions and area placements in one transaction, so a failed
+// delete never leaves a snapshot next to a partially removed instance.
+func (r *BunInstanceRepository) DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error {
+	if r.db == nil {
+		return fmt.Errorf("widget instance repository: database not configured")
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := trash.Insert(ctx, tx, entry); err != nil {
+			return fmt.Errorf("insert trash entry: %w", err)
+		}
+		if _, err := tx.NewDelete().
+			Model((*InstanceVersion)(nil)).
+			Where("?TableAlias.widget_instance_id = ?", id).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete widget versions: %w", err)
+		}
+		if _, err := tx.NewDelete().
+			Model((*AreaPlacement)(nil)).
+			Where("?TableAlias.instance_id = ?", id).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete widget placements: %w", err)
+		}
+		if _, err := tx.NewDelete().
+			Model((*Translation)(nil)).
+			Where("?TableAlias.widget_instance_id = ?", id).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete widget translations: %w", err)
+		}
+		result, err := tx.NewDelete().
+			Model((*Instance)(nil)).
+			Where("?TableAlias.id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete widget instance: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("widget instance delete rows affected: %w", err)
+		}
+		if affected == 0 {
+			return &NotFoundError{Resource: "widget_instance", Key: id.String()}
+		}
+		return nil
+	})
+}
+
+// BunInstanceVersionRepository implements InstanceVersionRepository with optional caching.
+type BunInstanceVersionRepository struct {
+	repo repository.Repository[*InstanceVersion]
+}
+
+// NewBunInstanceVersionRepository creates a version repository without caching.
+func NewBunInstanceVersionRepository(db *bun.DB) *BunInstanceVersionRepository {
+	return NewBunInstanceVersionRepositoryWithCache(db, nil, nil)
+}
+
+// NewBunInstanceVersionRepositoryWithCache creates a version repository with caching.
+func NewBunInstanceVersionRepositoryWithCache(db *bun.DB, cacheService cache.CacheService, serializer cache.KeySerializer) *BunInstanceVersionRepository {
+	base := NewInstanceVersionRepository(db)
+	if cacheService != nil && serializer != nil {
+		base = repositorycache.New(base, cacheService, serializer)
+	}
+	return &BunInstanceVersionRepository{repo: base}
+}
+
+func (r *BunInstanceVersionRepository) Create(ctx context.Context, version *InstanceVersion) (*InstanceVersion, error) {
+	record, err := r.repo.Create(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (r *BunInstanceVersionRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*InstanceVersion, error) {
+	records, _, err := r.repo.List(ctx,
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.widget_instance_id = ?", instanceID)
+		}),
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("?TableAlias.version ASC")
+		}),
+	)
+	return records, err
+}
+
+func (r *BunInstanceVersionRepository) GetVersion(ctx context.Context, instanceID uuid.UUID, number int) (*InstanceVersion, error) {
+	records, _, err := r.repo.List(ctx,
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.widget_instance_id = ?", instanceID)
+		}),
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.version = ?", number)
+		}),
+		repository.SelectPaginate(1, 0),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, &NotFoundError{Resource: "widget_version", Key: versionKey(instanceID, number)}
+	}
+	return records[0], nil
+}
+
+func (r *BunInstanceVersionRepository) GetLatest(ctx context.Context, instanceID uuid.UUID) (*InstanceVersion, error) {
+	records, _, err := r.repo.List(ctx,
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.widget_instance_id = ?", instanceID)
+		}),
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("?TableAlias.version DESC")
+		}),
+		repository.SelectPaginate(1, 0),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, &NotFoundError{Resource: "widget_version", Key: instanceID.String()}
+	}
+	return records[0], nil
+}
+
+func (r *BunInstanceVersionRepository) Update(ctx context.Context, version *InstanceVersion) (*InstanceVersion, error) {
+	record, err := r.repo.Update(ctx, version,
+		repository.UpdateByID(version.ID.String()),
+		repository.UpdateColumns(
+			"status",
+			"published_at",
+			"published_by",
+		),
+	)
+	if err != nil {
+		return nil, mapRepositoryError(err, "widget_version", versionKey(version.WidgetInstanceID, version.Version))
+	}
+	return record, nil
+}
+
+func (r *BunInstanceVersionRepository) DeleteVersions(ctx context.Context, instanceID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	return r.repo.DeleteMany(ctx,
+		repository.DeleteBy("widget_instance_id", "=", instanceID.String()),
+		repository.DeleteColumnIn("version", numbers),
+	)
+}
+
 // BunTranslationRepository implements TranslationRepository with optional caching.
 type BunTranslationRepository struct {
 	repo repository.Repository[*Translation]
@@ -281,6 +432,18 @@ func (r *BunAreaPlacementRepository) ListByAreaAndLocale(ctx context.Context, ar
 	return records, err
 }
 
+func (r *BunAreaPlacementRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*AreaPlacement, error) {
+	records, _, err := r.repo.List(ctx,
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("?TableAlias.instance_id = ?", instanceID)
+		}),
+		repository.SelectRawProcessor(func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("?TableAlias.area_code ASC, ?TableAlias.position ASC")
+		}),
+	)
+	return records, err
+}
+
 func (r *BunAreaPlacementRepository) Replace(ctx context.Context, areaCode string, localeID *uuid.UUID, placements []*AreaPlacement) error {
 	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		deleteQuery := tx.NewDelete().Model((*AreaPlacement)(nil)).Where("area_code = ?", areaCode)
@@ -0,0 +1,71 @@
+package widgets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/experiments"
+	"github.com/google/uuid"
+)
+
+type stubSelector struct {
+	inputs     []experiments.SelectVariantInput
+	assignment *experiments.Assignment
+}
+
+func (s *stubSelector) SelectVariant(_ context.Context, input experiments.SelectVariantInput) (*experiments.Assignment, error) {
+	s.inputs = append(s.inputs, input)
+	return s.assignment, nil
+}
+
+func TestResolveAreaAppliesExperimentVariant(t *testing.T) {
+	selector := &stubSelector{assignment: &experiments.Assignment{
+		ExperimentKey: "newsletter-headline",
+		VariantKey:    "bold",
+		Configuration: map[string]any{"headline": "Join 10k readers"},
+	}}
+	svc := newServiceWithAreas(WithVariantSelector(selector))
+	instance := seedVersionedInstance(t, svc, uuid.New())
+
+	resolve := func(ctx context.Context, input ResolveAreaInput) []*ResolvedWidget {
+		t.Helper()
+		input.AreaCode = "sidebar.primary"
+		input.Now = time.Now()
+		resolved, err := svc.ResolveArea(ctx, input)
+		if err != nil {
+			t.Fatalf("resolve area: %v", err)
+		}
+		if len(resolved) != 1 {
+			t.Fatalf("expected one widget, got %d", len(resolved))
+		}
+		return resolved
+	}
+
+	if base := resolve(context.Background(), ResolveAreaInput{}); base[0].Config["headline"] != "Live" || base[0].Variant != "" {
+		t.Fatalf("expected anonymous visitors to get the base widget, got %+v", base[0])
+	}
+	if len(selector.inputs) != 0 {
+		t.Fatalf("expected no selection without a visitor key, got %+v", selector.inputs)
+	}
+
+	variant := resolve(context.Background(), ResolveAreaInput{VisitorKey: "visitor-1"})
+	if variant[0].Config["headline"] != "Join 10k readers" || variant[0].Config["cta"] != "Subscribe" {
+		t.Fatalf("expected variant overlay, got %+v", variant[0].Config)
+	}
+	if variant[0].Experiment != "newsletter-headline" || variant[0].Variant != "bold" {
+		t.Fatalf("expected experiment metadata, got %+v", variant[0])
+	}
+	if got := selector.inputs[0]; got.TargetType != experiments.TargetWidgetInstance || got.TargetID != instance.ID || got.VisitorKey != "visitor-1" {
+		t.Fatalf("unexpected selection input %+v", got)
+	}
+
+	fromContext := resolve(experiments.WithVisitorKey(context.Background(), "visitor-2"), ResolveAreaInput{})
+	if fromContext[0].Variant != "bold" || selector.inputs[1].VisitorKey != "visitor-2" {
+		t.Fatalf("expected visitor key from context, got %+v", selector.inputs)
+	}
+
+	if preview := resolve(context.Background(), ResolveAreaInput{VisitorKey: "visitor-1", Preview: true}); preview[0].Variant != "" {
+		t.Fatalf("expected previews to skip experiments, got %+v", preview[0])
+	}
+}
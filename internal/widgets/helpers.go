@@ -1,6 +1,10 @@
 package widgets
 
-import "github.com/google/uuid"
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+)
 
 // translationKey formats a composite cache key for widget instance translations.
 func translationKey(instanceID uuid.UUID, localeID uuid.UUID) string {
@@ -14,3 +18,8 @@ func areaLocaleKey(areaCode string, localeID *uuid.UUID) string {
 	}
 	return areaCode + ":" + localeID.String()
 }
+
+// versionKey formats a composite key for a numbered widget instance version.
+func versionKey(instanceID uuid.UUID, version int) string {
+	return instanceID.String() + ":" + strconv.Itoa(version)
+}
@@ -235,6 +235,98 @@ func (m *memoryInstanceRepository) Delete(_ context.Context, id uuid.UUID) error
 	return nil
 }
 
+// NewMemoryInstanceVersionRepository constructs an in-memory widget version repository.
+func NewMemoryInstanceVersionRepository() InstanceVersionRepository {
+	return &memoryInstanceVersionRepository{
+		byInstance: make(map[uuid.UUID][]*InstanceVersion),
+	}
+}
+
+type memoryInstanceVersionRepository struct {
+	mu         sync.RWMutex
+	byInstance map[uuid.UUID][]*InstanceVersion
+}
+
+func (m *memoryInstanceVersionRepository) Create(_ context.Context, version *InstanceVersion) (*InstanceVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cloned := cloneInstanceVersion(version)
+	m.byInstance[cloned.WidgetInstanceID] = append(m.byInstance[cloned.WidgetInstanceID], cloned)
+	return cloneInstanceVersion(cloned), nil
+}
+
+func (m *memoryInstanceVersionRepository) ListByInstance(_ context.Context, instanceID uuid.UUID) ([]*InstanceVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	queue := m.byInstance[instanceID]
+	versions := make([]*InstanceVersion, 0, len(queue))
+	for _, version := range queue {
+		versions = append(versions, cloneInstanceVersion(version))
+	}
+	return versions, nil
+}
+
+func (m *memoryInstanceVersionRepository) GetVersion(_ context.Context, instanceID uuid.UUID, number int) (*InstanceVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, version := range m.byInstance[instanceID] {
+		if version.Version == number {
+			return cloneInstanceVersion(version), nil
+		}
+	}
+	return nil, &NotFoundError{Resource: "widget_version", Key: versionKey(instanceID, number)}
+}
+
+func (m *memoryInstanceVersionRepository) GetLatest(_ context.Context, instanceID uuid.UUID) (*InstanceVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	queue := m.byInstance[instanceID]
+	if len(queue) == 0 {
+		return nil, &NotFoundError{Resource: "widget_version", Key: instanceID.String()}
+	}
+	return cloneInstanceVersion(queue[len(queue)-1]), nil
+}
+
+func (m *memoryInstanceVersionRepository) Update(_ context.Context, version *InstanceVersion) (*InstanceVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queue := m.byInstance[version.WidgetInstanceID]
+	for idx, existing := range queue {
+		if existing.ID == version.ID {
+			queue[idx] = cloneInstanceVersion(version)
+			return cloneInstanceVersion(queue[idx]), nil
+		}
+	}
+	return nil, &NotFoundError{Resource: "widget_version", Key: versionKey(version.WidgetInstanceID, version.Version)}
+}
+
+func (m *memoryInstanceVersionRepository) DeleteVersions(_ context.Context, instanceID uuid.UUID, numbers []int) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queue := m.byInstance[instanceID]
+	kept := make([]*InstanceVersion, 0, len(queue))
+	for _, version := range queue {
+		if !slices.Contains(numbers, version.Version) {
+			kept = append(kept, version)
+		}
+	}
+	if len(kept) == 0 {
+		delete(m.byInstance, instanceID)
+		return nil
+	}
+	m.byInstance[instanceID] = kept
+	return nil
+}
+
 // NewMemoryTranslationRepository constructs an in-memory widget translation repository.
 func NewMemoryTranslationRepository() TranslationRepository {
 	return &memoryTranslationRepository{
@@ -365,6 +457,25 @@ func (m *memoryAreaPlacementRepository) ListByAreaAndLocale(_ context.Context, a
 	return out, nil
 }
 
+func (m *memoryAreaPlacementRepository) ListByInstance(_ context.Context, instanceID uuid.UUID) ([]*AreaPlacement, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*AreaPlacement, 0)
+	for _, record := range m.byID {
+		if record.InstanceID == instanceID {
+			out = append(out, cloneAreaPlacement(record))
+		}
+	}
+	slices.SortFunc(out, func(a, b *AreaPlacement) int {
+		if cmp := strings.Compare(a.AreaCode, b.AreaCode); cmp != 0 {
+			return cmp
+		}
+		return a.Position - b.Position
+	})
+	return out, nil
+}
+
 func (m *memoryAreaPlacementRepository) Replace(_ context.Context, areaCode string, localeID *uuid.UUID, placements []*AreaPlacement) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -549,6 +660,15 @@ func cloneInstance(src *Instance) *Instance {
 		blockID := *src.BlockInstanceID
 		cloned.BlockInstanceID = &blockID
 	}
+	if src.PublishedVersion != nil {
+		published := *src.PublishedVersion
+		cloned.PublishedVersion = &published
+	}
+	if src.PublishedAt != nil {
+		publishedAt := *src.PublishedAt
+		cloned.PublishedAt = &publishedAt
+	}
+	cloned.PublishedBy = cloneUUIDPtr(src.PublishedBy)
 	if src.Definition != nil {
 		cloned.Definition = cloneDefinition(src.Definition)
 	}
@@ -573,6 +693,37 @@ func cloneTranslation(src *Translation) *Translation {
 	return &cloned
 }
 
+func cloneInstanceVersion(src *InstanceVersion) *InstanceVersion {
+	if src == nil {
+		return nil
+	}
+	cloned := *src
+	cloned.Snapshot = cloneInstanceVersionSnapshot(src.Snapshot)
+	if src.PublishedAt != nil {
+		publishedAt := *src.PublishedAt
+		cloned.PublishedAt = &publishedAt
+	}
+	cloned.PublishedBy = cloneUUIDPtr(src.PublishedBy)
+	return &cloned
+}
+
+func cloneInstanceVersionSnapshot(src InstanceVersionSnapshot) InstanceVersionSnapshot {
+	target := InstanceVersionSnapshot{
+		Configuration:   deepCloneMap(src.Configuration),
+		VisibilityRules: deepCloneMap(src.VisibilityRules),
+	}
+	if len(src.Translations) > 0 {
+		target.Translations = make([]InstanceVersionTranslationSnapshot, len(src.Translations))
+		for i, tr := range src.Translations {
+			target.Translations[i] = InstanceVersionTranslationSnapshot{
+				LocaleID: tr.LocaleID,
+				Content:  deepCloneMap(tr.Content),
+			}
+		}
+	}
+	return target
+}
+
 func cloneAreaDefinition(src *AreaDefinition) *AreaDefinition {
 	if src == nil {
 		return nil
@@ -3,13 +3,17 @@ package widgets
 import cmswidgets "github.com/goliatone/go-cms/widgets"
 
 type (
-	Definition     = cmswidgets.Definition
-	Instance       = cmswidgets.Instance
-	Translation    = cmswidgets.Translation
-	ResolvedWidget = cmswidgets.ResolvedWidget
-	AreaScope      = cmswidgets.AreaScope
-	AreaDefinition = cmswidgets.AreaDefinition
-	AreaPlacement  = cmswidgets.AreaPlacement
+	Definition                         = cmswidgets.Definition
+	Instance                           = cmswidgets.Instance
+	Translation                        = cmswidgets.Translation
+	VersionStatus                      = cmswidgets.VersionStatus
+	InstanceVersion                    = cmswidgets.InstanceVersion
+	InstanceVersionSnapshot            = cmswidgets.InstanceVersionSnapshot
+	InstanceVersionTranslationSnapshot = cmswidgets.InstanceVersionTranslationSnapshot
+	ResolvedWidget                     = cmswidgets.ResolvedWidget
+	AreaScope                          = cmswidgets.AreaScope
+	AreaDefinition                     = cmswidgets.AreaDefinition
+	AreaPlacement                      = cmswidgets.AreaPlacement
 )
 
 const (
@@ -17,4 +21,8 @@ const (
 	AreaScopeTheme    = cmswidgets.AreaScopeTheme
 	AreaScopeTemplate = cmswidgets.AreaScopeTemplate
 	AreaScopePage     = cmswidgets.AreaScopePage
+
+	VersionStatusDraft     = cmswidgets.VersionStatusDraft
+	VersionStatusPublished = cmswidgets.VersionStatusPublished
+	VersionStatusArchived  = cmswidgets.VersionStatusArchived
 )
@@ -65,6 +65,34 @@ func (noOpService) DeleteInstance(context.Context, DeleteInstanceRequest) error
 	return ErrFeatureDisabled
 }
 
+func (noOpService) ListTrashedInstances(context.Context, ListTrashedInstancesRequest) ([]*Instance, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (noOpService) RestoreDeletedInstance(context.Context, RestoreInstanceRequest) (*Instance, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (noOpService) RestoreInstanceSnapshot(context.Context, RestoreInstanceSnapshotRequest) (*Instance, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (noOpService) CreateDraft(context.Context, CreateInstanceDraftRequest) (*InstanceVersion, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (noOpService) PublishDraft(context.Context, PublishInstanceDraftRequest) (*InstanceVersion, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (noOpService) ListVersions(context.Context, uuid.UUID) ([]*InstanceVersion, error) {
+	return nil, ErrFeatureDisabled
+}
+
+func (noOpService) RestoreVersion(context.Context, RestoreInstanceVersionRequest) (*InstanceVersion, error) {
+	return nil, ErrFeatureDisabled
+}
+
 func (noOpService) AddTranslation(context.Context, AddTranslationInput) (*Translation, error) {
 	return nil, ErrFeatureDisabled
 }
@@ -101,6 +129,10 @@ func (noOpService) ReorderAreaWidgets(context.Context, ReorderAreaWidgetsInput)
 	return nil, ErrFeatureDisabled
 }
 
+func (noOpService) ListInstancePlacements(context.Context, uuid.UUID) ([]*AreaPlacement, error) {
+	return nil, ErrFeatureDisabled
+}
+
 func (noOpService) ResolveArea(context.Context, ResolveAreaInput) ([]*ResolvedWidget, error) {
 	return nil, ErrFeatureDisabled
 }
@@ -3,24 +3,30 @@ package widgets
 import cmswidgets "github.com/goliatone/go-cms/widgets"
 
 type (
-	Service                     = cmswidgets.Service
-	RegisterDefinitionInput     = cmswidgets.RegisterDefinitionInput
-	DefinitionSyncStatus        = cmswidgets.DefinitionSyncStatus
-	DefinitionSyncResult        = cmswidgets.DefinitionSyncResult
-	DeleteDefinitionRequest     = cmswidgets.DeleteDefinitionRequest
-	CreateInstanceInput         = cmswidgets.CreateInstanceInput
-	UpdateInstanceInput         = cmswidgets.UpdateInstanceInput
-	DeleteInstanceRequest       = cmswidgets.DeleteInstanceRequest
-	AddTranslationInput         = cmswidgets.AddTranslationInput
-	UpdateTranslationInput      = cmswidgets.UpdateTranslationInput
-	DeleteTranslationRequest    = cmswidgets.DeleteTranslationRequest
-	RegisterAreaDefinitionInput = cmswidgets.RegisterAreaDefinitionInput
-	AssignWidgetToAreaInput     = cmswidgets.AssignWidgetToAreaInput
-	RemoveWidgetFromAreaInput   = cmswidgets.RemoveWidgetFromAreaInput
-	ReorderAreaWidgetsInput     = cmswidgets.ReorderAreaWidgetsInput
-	AreaWidgetOrder             = cmswidgets.AreaWidgetOrder
-	ResolveAreaInput            = cmswidgets.ResolveAreaInput
-	VisibilityContext           = cmswidgets.VisibilityContext
+	Service                        = cmswidgets.Service
+	RegisterDefinitionInput        = cmswidgets.RegisterDefinitionInput
+	DefinitionSyncStatus           = cmswidgets.DefinitionSyncStatus
+	DefinitionSyncResult           = cmswidgets.DefinitionSyncResult
+	DeleteDefinitionRequest        = cmswidgets.DeleteDefinitionRequest
+	CreateInstanceInput            = cmswidgets.CreateInstanceInput
+	UpdateInstanceInput            = cmswidgets.UpdateInstanceInput
+	DeleteInstanceRequest          = cmswidgets.DeleteInstanceRequest
+	ListTrashedInstancesRequest    = cmswidgets.ListTrashedInstancesRequest
+	RestoreInstanceRequest         = cmswidgets.RestoreInstanceRequest
+	RestoreInstanceSnapshotRequest = cmswidgets.RestoreInstanceSnapshotRequest
+	CreateInstanceDraftRequest     = cmswidgets.CreateInstanceDraftRequest
+	PublishInstanceDraftRequest    = cmswidgets.PublishInstanceDraftRequest
+	RestoreInstanceVersionRequest  = cmswidgets.RestoreInstanceVersionRequest
+	AddTranslationInput            = cmswidgets.AddTranslationInput
+	UpdateTranslationInput         = cmswidgets.UpdateTranslationInput
+	DeleteTranslationRequest       = cmswidgets.DeleteTranslationRequest
+	RegisterAreaDefinitionInput    = cmswidgets.RegisterAreaDefinitionInput
+	AssignWidgetToAreaInput        = cmswidgets.AssignWidgetToAreaInput
+	RemoveWidgetFromAreaInput      = cmswidgets.RemoveWidgetFromAreaInput
+	ReorderAreaWidgetsInput        = cmswidgets.ReorderAreaWidgetsInput
+	AreaWidgetOrder                = cmswidgets.AreaWidgetOrder
+	ResolveAreaInput               = cmswidgets.ResolveAreaInput
+	VisibilityContext              = cmswidgets.VisibilityContext
 )
 
 var (
@@ -44,6 +50,13 @@ var (
 	ErrVisibilityRulesInvalid        = cmswidgets.ErrVisibilityRulesInvalid
 	ErrVisibilityScheduleInvalid     = cmswidgets.ErrVisibilityScheduleInvalid
 	ErrInstanceSoftDeleteUnsupported = cmswidgets.ErrInstanceSoftDeleteUnsupported
+	ErrInstanceNotTrashed            = cmswidgets.ErrInstanceNotTrashed
+
+	ErrVersioningDisabled               = cmswidgets.ErrVersioningDisabled
+	ErrInstanceVersionRequired          = cmswidgets.ErrInstanceVersionRequired
+	ErrInstanceVersionConflict          = cmswidgets.ErrInstanceVersionConflict
+	ErrInstanceVersionAlreadyPublished  = cmswidgets.ErrInstanceVersionAlreadyPublished
+	ErrInstanceVersionRetentionExceeded = cmswidgets.ErrInstanceVersionRetentionExceeded
 
 	ErrTranslationContentRequired = cmswidgets.ErrTranslationContentRequired
 	ErrTranslationLocaleRequired  = cmswidgets.ErrTranslationLocaleRequired
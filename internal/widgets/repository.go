@@ -28,6 +28,17 @@ func NewInstanceRepository(db *bun.DB) repository.Repository[*Instance] {
 	})
 }
 
+// NewInstanceVersionRepository creates a repository for widget instance versions.
+func NewInstanceVersionRepository(db *bun.DB) repository.Repository[*InstanceVersion] {
+	return repository.MustNewRepository(db, repository.ModelHandlers[*InstanceVersion]{
+		NewRecord:          func() *InstanceVersion { return &InstanceVersion{} },
+		GetID:              func(version *InstanceVersion) uuid.UUID { return version.ID },
+		SetID:              func(version *InstanceVersion, id uuid.UUID) { version.ID = id },
+		GetIdentifier:      func() string { return "id" },
+		GetIdentifierValue: func(version *InstanceVersion) string { return version.ID.String() },
+	})
+}
+
 // NewTranslationRepository creates a repository for widget translations.
 func NewTranslationRepository(db *bun.DB) repository.Repository[*Translation] {
 	return repository.MustNewRepository(db, repository.ModelHandlers[*Translation]{
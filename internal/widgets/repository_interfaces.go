@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/google/uuid"
 )
 
@@ -28,6 +29,25 @@ type InstanceRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// InstanceTrasher is implemented by instance repositories that can store a
+// trash entry and remove an instance with its translations, versions and area
+// placements in a single transaction. Repositories without transactional
+// support return errors.ErrUnsupported and the service falls back to writing
+// the entry through its trash repository.
+type InstanceTrasher interface {
+	DeleteToTrash(ctx context.Context, id uuid.UUID, entry *trash.Entry) error
+}
+
+// InstanceVersionRepository exposes persistence operations for widget instance versions.
+type InstanceVersionRepository interface {
+	Create(ctx context.Context, version *InstanceVersion) (*InstanceVersion, error)
+	ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*InstanceVersion, error)
+	GetVersion(ctx context.Context, instanceID uuid.UUID, number int) (*InstanceVersion, error)
+	GetLatest(ctx context.Context, instanceID uuid.UUID) (*InstanceVersion, error)
+	Update(ctx context.Context, version *InstanceVersion) (*InstanceVersion, error)
+	DeleteVersions(ctx context.Context, instanceID uuid.UUID, numbers []int) error
+}
+
 // TranslationRepository exposes persistence operations for widget translations.
 type TranslationRepository interface {
 	Create(ctx context.Context, translation *Translation) (*Translation, error)
@@ -47,6 +67,7 @@ type AreaDefinitionRepository interface {
 // AreaPlacementRepository manages widget placements within areas.
 type AreaPlacementRepository interface {
 	ListByAreaAndLocale(ctx context.Context, areaCode string, localeID *uuid.UUID) ([]*AreaPlacement, error)
+	ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*AreaPlacement, error)
 	Replace(ctx context.Context, areaCode string, localeID *uuid.UUID, placements []*AreaPlacement) error
 	DeleteByAreaLocaleInstance(ctx context.Context, areaCode string, localeID *uuid.UUID, instanceID uuid.UUID) error
 	DeleteByInstance(ctx context.Context, instanceID uuid.UUID) error
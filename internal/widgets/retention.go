@@ -0,0 +1,72 @@
+package widgets
+
+import (
+	"context"
+
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/google/uuid"
+)
+
+// pruneVersions removes the versions of an instance that the retention policy
+// no longer keeps and returns the removed version numbers.
+func (s *service) pruneVersions(ctx context.Context, instance *Instance, actor uuid.UUID) ([]int, error) {
+	versions, err := s.versions.ListByInstance(ctx, instance.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]retention.Version, 0, len(versions))
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		candidates = append(candidates, retention.Version{
+			Number:    version.Version,
+			CreatedAt: version.CreatedAt,
+			Published: version.PublishedAt != nil || version.Status == VersionStatusPublished,
+		})
+	}
+	numbers := s.retention.Select(candidates, retention.Protected(instance.CurrentVersion, instance.PublishedVersion), s.now())
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+	if err := s.versions.DeleteVersions(ctx, instance.ID, numbers); err != nil {
+		return nil, err
+	}
+
+	s.emitActivity(ctx, actor, "prune_versions", "widget_instance", instance.ID, map[string]any{
+		"versions":      numbers,
+		"definition_id": instance.DefinitionID.String(),
+	})
+	return numbers, nil
+}
+
+// PruneAllVersions applies the retention policy to every widget instance.
+func (s *service) PruneAllVersions(ctx context.Context) ([]retention.Pruned, error) {
+	if !s.versioningEnabled || s.versions == nil || !s.retention.Prunes() {
+		return nil, nil
+	}
+	instances, err := s.instances.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []retention.Pruned
+	for _, instance := range instances {
+		if instance == nil {
+			continue
+		}
+		numbers, err := s.pruneVersions(ctx, instance, uuid.Nil)
+		if err != nil {
+			return pruned, err
+		}
+		if len(numbers) > 0 {
+			pruned = append(pruned, retention.Pruned{
+				Resource: retention.ResourceWidgetInstance,
+				EntityID: instance.ID,
+				Versions: numbers,
+			})
+		}
+	}
+	return pruned, nil
+}
+
+var _ retention.Source = (*service)(nil)
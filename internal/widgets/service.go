@@ -7,14 +7,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
 
+	"github.com/goliatone/go-cms/experiments"
+	cmsenv "github.com/goliatone/go-cms/internal/environments"
 	"github.com/goliatone/go-cms/internal/identity"
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/internal/validation"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/google/uuid"
 )
 
@@ -79,6 +86,15 @@ func WithAreaDefinitionRepository(repo AreaDefinitionRepository) ServiceOption {
 	}
 }
 
+// WithTrashRepository enables soft deletes of instances through the trash.
+func WithTrashRepository(repo trash.Repository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.trash = repo
+		}
+	}
+}
+
 // WithAreaPlacementRepository wires the area placement repository.
 func WithAreaPlacementRepository(repo AreaPlacementRepository) ServiceOption {
 	return func(s *service) {
@@ -88,6 +104,77 @@ func WithAreaPlacementRepository(repo AreaPlacementRepository) ServiceOption {
 	}
 }
 
+// WithInstanceVersionRepository wires the repository storing widget drafts and published versions.
+func WithInstanceVersionRepository(repo InstanceVersionRepository) ServiceOption {
+	return func(s *service) {
+		if repo != nil {
+			s.versions = repo
+		}
+	}
+}
+
+// WithVersioningEnabled toggles the draft/publish lifecycle for widget instances.
+func WithVersioningEnabled(enabled bool) ServiceOption {
+	return func(s *service) {
+		s.versioningEnabled = enabled
+	}
+}
+
+// WithVersionRetentionPolicy controls how many widget versions are kept.
+func WithVersionRetentionPolicy(policy retention.Policy) ServiceOption {
+	return func(s *service) {
+		s.retention = policy
+	}
+}
+
+// WithLifecycleEmitter wires the emitter notified when widget instances, their
+// translations or area placements change and when drafts are published.
+func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
+	return func(s *service) {
+		if emitter != nil {
+			s.lifecycle = emitter
+		}
+	}
+}
+
+// WithVariantSelector wires the experiments selector used to bucket visitors
+// into widget instance variants during area resolution.
+func WithVariantSelector(selector experiments.Selector) ServiceOption {
+	return func(s *service) {
+		if selector != nil {
+			s.variants = selector
+		}
+	}
+}
+
+// WithLogger assigns the logger used by the service. When omitted, a no-op logger is used.
+func WithLogger(logger interfaces.Logger) ServiceOption {
+	return func(s *service) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// WithEnvironmentService wires the environment service used to resolve the
+// environment keys of widget instances.
+func WithEnvironmentService(envSvc cmsenv.Service) ServiceOption {
+	return func(s *service) {
+		if envSvc != nil {
+			s.envSvc = envSvc
+		}
+	}
+}
+
+// WithDefaultEnvironmentKey overrides the default environment key.
+func WithDefaultEnvironmentKey(key string) ServiceOption {
+	return func(s *service) {
+		if strings.TrimSpace(key) != "" {
+			s.defaultEnvKey = key
+		}
+	}
+}
+
 type service struct {
 	definitions  DefinitionRepository
 	instances    InstanceRepository
@@ -100,17 +187,31 @@ type service struct {
 	registry     *Registry
 	shortcodes   interfaces.ShortcodeService
 	activity     *activity.Emitter
+	lifecycle    *lifecycle.Emitter
+	trash        trash.Repository
+	variants     experiments.Selector
+
+	envSvc        cmsenv.Service
+	defaultEnvKey string
+
+	versions          InstanceVersionRepository
+	versioningEnabled bool
+	retention         retention.Policy
+	logger            interfaces.Logger
 }
 
 // NewService constructs a widget service instance.
 func NewService(defRepo DefinitionRepository, instRepo InstanceRepository, trRepo TranslationRepository, opts ...ServiceOption) Service {
 	s := &service{
-		definitions:  defRepo,
-		instances:    instRepo,
-		translations: trRepo,
-		now:          time.Now,
-		id:           uuid.New,
-		activity:     activity.NewEmitter(nil, activity.Config{}),
+		definitions:   defRepo,
+		instances:     instRepo,
+		translations:  trRepo,
+		now:           time.Now,
+		id:            uuid.New,
+		activity:      activity.NewEmitter(nil, activity.Config{}),
+		lifecycle:     lifecycle.NewEmitter(nil, lifecycle.Config{}),
+		logger:        logging.ModuleLogger(nil, "cms.widgets"),
+		defaultEnvKey: cmsenv.DefaultKey,
 	}
 
 	for _, opt := range opts {
@@ -122,6 +223,51 @@ func NewService(defRepo DefinitionRepository, instRepo InstanceRepository, trRep
 	return s
 }
 
+func (s *service) opLogger(ctx context.Context, operation string, extra map[string]any) interfaces.Logger {
+	logger := s.logger
+	if ctx != nil {
+		logger = logger.WithContext(ctx)
+	}
+	fields := map[string]any{"operation": operation}
+	maps.Copy(fields, extra)
+	return logging.WithFields(logger, fields)
+}
+
+func (s *service) resolveEnvironment(ctx context.Context, key string) (uuid.UUID, error) {
+	trimmed := strings.TrimSpace(key)
+	if trimmed != "" {
+		if parsed, err := uuid.Parse(trimmed); err == nil {
+			return parsed, nil
+		}
+	}
+	normalized, err := cmsenv.ResolveKey(trimmed, s.defaultEnvKey, false)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if s.envSvc == nil {
+		return cmsenv.IDForKey(normalized), nil
+	}
+	env, err := s.envSvc.GetEnvironmentByKey(ctx, normalized)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return env.ID, nil
+}
+
+// instanceInEnvironment reports whether instance belongs to envID. Instances
+// stored before widgets were environment scoped belong to the default
+// environment.
+func (s *service) instanceInEnvironment(ctx context.Context, instance *Instance, envID uuid.UUID) (bool, error) {
+	if instance.EnvironmentID != uuid.Nil {
+		return instance.EnvironmentID == envID, nil
+	}
+	defaultID, err := s.resolveEnvironment(ctx, "")
+	if err != nil {
+		return false, err
+	}
+	return defaultID == envID, nil
+}
+
 func (s *service) emitActivity(ctx context.Context, actor uuid.UUID, verb, objectType string, objectID uuid.UUID, meta map[string]any) {
 	if s.activity == nil || !s.activity.Enabled() || objectID == uuid.Nil {
 		return
@@ -136,6 +282,27 @@ func (s *service) emitActivity(ctx context.Context, actor uuid.UUID, verb, objec
 	_ = s.activity.Emit(ctx, event)
 }
 
+func (s *service) emitLifecycle(ctx context.Context, event lifecycle.Event) {
+	if s.lifecycle == nil || !s.lifecycle.Enabled() {
+		return
+	}
+	_ = s.lifecycle.Emit(ctx, event)
+}
+
+// emitInstanceLifecycle reports a change to the rendered output of a widget
+// instance. Dependent caches and builds key on the instance ID.
+func (s *service) emitInstanceLifecycle(ctx context.Context, instanceID uuid.UUID, transition string, meta map[string]any) {
+	if instanceID == uuid.Nil {
+		return
+	}
+	s.emitLifecycle(ctx, lifecycle.Event{
+		ResourceType: "widget",
+		RecordID:     instanceID.String(),
+		Transition:   transition,
+		Metadata:     meta,
+	})
+}
+
 func (s *service) RegisterDefinition(ctx context.Context, input RegisterDefinitionInput) (*Definition, error) {
 	name, err := validateDefinitionInput(input)
 	if err != nil {
@@ -276,6 +443,11 @@ func (s *service) CreateInstance(ctx context.Context, input CreateInstanceInput)
 		return nil, err
 	}
 
+	envID, err := s.resolveEnvironment(ctx, input.EnvironmentKey)
+	if err != nil {
+		return nil, err
+	}
+
 	definition, err := s.definitions.GetByID(ctx, input.DefinitionID)
 	if err != nil {
 		return nil, err
@@ -303,6 +475,7 @@ func (s *service) CreateInstance(ctx context.Context, input CreateInstanceInput)
 	instance := &Instance{
 		ID:              s.id(),
 		DefinitionID:    definition.ID,
+		EnvironmentID:   envID,
 		Configuration:   config,
 		Placement:       deepCloneMap(input.Placement),
 		VisibilityRules: deepCloneMap(input.VisibilityRules),
@@ -331,12 +504,14 @@ func (s *service) CreateInstance(ctx context.Context, input CreateInstanceInput)
 		return nil, err
 	}
 
-	s.emitActivity(ctx, pickActor(input.CreatedBy, input.UpdatedBy), "create", "widget_instance", created.ID, map[string]any{
+	meta := map[string]any{
 		"area_code":    created.AreaCode,
 		"position":     created.Position,
 		"publish_on":   created.PublishOn,
 		"unpublish_on": created.UnpublishOn,
-	})
+	}
+	s.emitActivity(ctx, pickActor(input.CreatedBy, input.UpdatedBy), "create", "widget_instance", created.ID, meta)
+	s.emitInstanceLifecycle(ctx, created.ID, "create", meta)
 
 	return created, nil
 }
@@ -433,12 +608,14 @@ func (s *service) UpdateInstance(ctx context.Context, input UpdateInstanceInput)
 	if originalPosition != record.Position || originalArea != currentArea {
 		verb = "reorder"
 	}
-	s.emitActivity(ctx, input.UpdatedBy, verb, "widget_instance", record.ID, map[string]any{
+	meta := map[string]any{
 		"area_code":    record.AreaCode,
 		"position":     record.Position,
 		"publish_on":   record.PublishOn,
 		"unpublish_on": record.UnpublishOn,
-	})
+	}
+	s.emitActivity(ctx, input.UpdatedBy, verb, "widget_instance", record.ID, meta)
+	s.emitInstanceLifecycle(ctx, record.ID, verb, meta)
 
 	return record, nil
 }
@@ -486,36 +663,38 @@ func (s *service) DeleteInstance(ctx context.Context, req DeleteInstanceRequest)
 	if req.InstanceID == uuid.Nil {
 		return ErrInstanceIDRequired
 	}
-	if !req.HardDelete {
+	if !req.HardDelete && s.trash == nil {
 		return ErrInstanceSoftDeleteUnsupported
 	}
 	record, err := s.instances.GetByID(ctx, req.InstanceID)
 	if err != nil {
 		return err
 	}
-	if s.placements != nil {
-		if err := s.placements.DeleteByInstance(ctx, req.InstanceID); err != nil {
-			return err
-		}
-	}
 	translations, err := s.translations.ListByInstance(ctx, req.InstanceID)
 	if err != nil {
 		return err
 	}
-	for _, tr := range translations {
-		if err := s.translations.Delete(ctx, tr.ID); err != nil {
+	var versions []*InstanceVersion
+	if s.versions != nil {
+		if versions, err = s.versions.ListByInstance(ctx, req.InstanceID); err != nil {
 			return err
 		}
 	}
-	if err := s.instances.Delete(ctx, req.InstanceID); err != nil {
+	if !req.HardDelete {
+		if err := s.trashInstance(ctx, record, translations, versions, req.DeletedBy); err != nil {
+			return err
+		}
+	} else if err := s.deleteInstanceRows(ctx, req.InstanceID, translations, versions); err != nil {
 		return err
 	}
-	s.emitActivity(ctx, pickActor(req.DeletedBy, record.UpdatedBy, record.CreatedBy), "delete", "widget_instance", record.ID, map[string]any{
+	meta := map[string]any{
 		"area_code":    record.AreaCode,
 		"position":     record.Position,
 		"publish_on":   record.PublishOn,
 		"unpublish_on": record.UnpublishOn,
-	})
+	}
+	s.emitActivity(ctx, pickActor(req.DeletedBy, record.UpdatedBy, record.CreatedBy), "delete", "widget_instance", record.ID, meta)
+	s.emitInstanceLifecycle(ctx, record.ID, "delete", meta)
 	return nil
 }
 
@@ -573,6 +752,7 @@ func (s *service) AddTranslation(ctx context.Context, input AddTranslationInput)
 		meta["position"] = instance.Position
 	}
 	s.emitActivity(ctx, uuid.Nil, "create", "widget_translation", created.ID, meta)
+	s.emitInstanceLifecycle(ctx, input.InstanceID, "update", meta)
 
 	return record, nil
 }
@@ -621,6 +801,7 @@ func (s *service) UpdateTranslation(ctx context.Context, input UpdateTranslation
 		meta["position"] = instance.Position
 	}
 	s.emitActivity(ctx, uuid.Nil, "update", "widget_translation", translation.ID, meta)
+	s.emitInstanceLifecycle(ctx, input.InstanceID, "update", meta)
 
 	return record, nil
 }
@@ -660,6 +841,7 @@ func (s *service) DeleteTranslation(ctx context.Context, req DeleteTranslationRe
 		meta["position"] = instance.Position
 	}
 	s.emitActivity(ctx, uuid.Nil, "delete", "widget_translation", translation.ID, meta)
+	s.emitInstanceLifecycle(ctx, req.InstanceID, "update", meta)
 	return nil
 }
 
@@ -701,6 +883,7 @@ func (s *service) RegisterAreaDefinition(ctx context.Context, input RegisterArea
 		Scope:       scope,
 		ThemeID:     cloneUUIDPtr(input.ThemeID),
 		TemplateID:  cloneUUIDPtr(input.TemplateID),
+		SiteID:      cloneUUIDPtr(input.SiteID),
 		CreatedAt:   s.now(),
 		UpdatedAt:   s.now(),
 	}
@@ -796,6 +979,10 @@ func (s *service) AssignWidgetToArea(ctx context.Context, input AssignWidgetToAr
 	if err := s.placements.Replace(ctx, code, input.LocaleID, updated); err != nil {
 		return nil, err
 	}
+	s.emitInstanceLifecycle(ctx, instance.ID, "assign", map[string]any{
+		"area_code": code,
+		"position":  position,
+	})
 	return s.placements.ListByAreaAndLocale(ctx, code, input.LocaleID)
 }
 
@@ -826,7 +1013,13 @@ func (s *service) RemoveWidgetFromArea(ctx context.Context, input RemoveWidgetFr
 		return ErrAreaPlacementNotFound
 	}
 
-	return s.placements.DeleteByAreaLocaleInstance(ctx, code, input.LocaleID, input.InstanceID)
+	if err := s.placements.DeleteByAreaLocaleInstance(ctx, code, input.LocaleID, input.InstanceID); err != nil {
+		return err
+	}
+	s.emitInstanceLifecycle(ctx, input.InstanceID, "unassign", map[string]any{
+		"area_code": code,
+	})
+	return nil
 }
 
 func (s *service) ReorderAreaWidgets(ctx context.Context, input ReorderAreaWidgetsInput) ([]*AreaPlacement, error) {
@@ -887,9 +1080,26 @@ func (s *service) ReorderAreaWidgets(ctx context.Context, input ReorderAreaWidge
 		"locale_id": input.LocaleID,
 		"count":     len(updated),
 	})
+	for _, placement := range updated {
+		s.emitInstanceLifecycle(ctx, placement.InstanceID, "reorder", map[string]any{
+			"area_code": code,
+			"position":  placement.Position,
+		})
+	}
 	return updated, nil
 }
 
+// ListInstancePlacements returns the area placements of a widget instance.
+func (s *service) ListInstancePlacements(ctx context.Context, instanceID uuid.UUID) ([]*AreaPlacement, error) {
+	if err := s.ensureAreaSupport(); err != nil {
+		return nil, err
+	}
+	if instanceID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	return s.placements.ListByInstance(ctx, instanceID)
+}
+
 func (s *service) ResolveArea(ctx context.Context, input ResolveAreaInput) ([]*ResolvedWidget, error) {
 	if err := s.ensureAreaSupport(); err != nil {
 		return nil, err
@@ -899,15 +1109,36 @@ func (s *service) ResolveArea(ctx context.Context, input ResolveAreaInput) ([]*R
 		return nil, ErrAreaCodeRequired
 	}
 
+	envID, err := s.resolveEnvironment(ctx, input.EnvironmentKey)
+	if err != nil {
+		return nil, err
+	}
+
 	localeChain := buildLocaleChain(input.LocaleID, input.FallbackLocaleIDs)
-	var placements []*AreaPlacement
+	var (
+		placements []*AreaPlacement
+		instances  []*Instance
+	)
 	for _, locale := range localeChain {
 		records, err := s.placements.ListByAreaAndLocale(ctx, code, locale)
 		if err != nil {
 			return nil, err
 		}
-		if len(records) > 0 {
-			placements = records
+		for _, placement := range records {
+			instance, err := s.instances.GetByID(ctx, placement.InstanceID)
+			if err != nil {
+				return nil, err
+			}
+			ok, err := s.instanceInEnvironment(ctx, instance, envID)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				placements = append(placements, placement)
+				instances = append(instances, instance)
+			}
+		}
+		if len(placements) > 0 {
 			break
 		}
 	}
@@ -917,16 +1148,24 @@ func (s *service) ResolveArea(ctx context.Context, input ResolveAreaInput) ([]*R
 
 	result := make([]*ResolvedWidget, 0, len(placements))
 	visCtx := VisibilityContext{
-		Now:      input.Now,
-		LocaleID: input.LocaleID,
-		Audience: input.Audience,
-		Segments: input.Segments,
+		Now:        input.Now,
+		LocaleID:   input.LocaleID,
+		Audience:   input.Audience,
+		Segments:   input.Segments,
+		VisitorKey: input.VisitorKey,
+	}
+	if visCtx.VisitorKey == "" {
+		visCtx.VisitorKey = experiments.VisitorKeyFromContext(ctx)
 	}
 
-	for _, placement := range placements {
-		instance, err := s.instances.GetByID(ctx, placement.InstanceID)
-		if err != nil {
-			return nil, err
+	for idx, placement := range placements {
+		instance := instances[idx]
+		var draft *InstanceVersion
+		if input.Preview {
+			instance, draft, err = s.previewInstance(ctx, instance)
+			if err != nil {
+				return nil, err
+			}
 		}
 		visible, err := s.EvaluateVisibility(ctx, instance, visCtx)
 		if err != nil {
@@ -942,6 +1181,11 @@ func (s *service) ResolveArea(ctx context.Context, input ResolveAreaInput) ([]*R
 		if err != nil {
 			return nil, err
 		}
+		if draft != nil {
+			if err := s.overlayDraftTranslations(ctx, enriched[0], draft.Snapshot); err != nil {
+				return nil, err
+			}
+		}
 		resolvedConfig, resolvedTranslation, resolvedLocaleID := resolveLocalizedConfiguration(enriched[0], localeChain)
 		resolved := &ResolvedWidget{
 			Instance:            enriched[0],
@@ -950,12 +1194,37 @@ func (s *service) ResolveArea(ctx context.Context, input ResolveAreaInput) ([]*R
 			ResolvedLocaleID:    resolvedLocaleID,
 			Placement:           cloneAreaPlacement(placement),
 		}
+		if !input.Preview {
+			if err := s.applyVariant(ctx, resolved, visCtx.VisitorKey); err != nil {
+				return nil, err
+			}
+		}
 		result = append(result, resolved)
 	}
 
 	return result, nil
 }
 
+// applyVariant overlays the experiment variant the visitor is bucketed into on
+// the resolved configuration.
+func (s *service) applyVariant(ctx context.Context, resolved *ResolvedWidget, visitorKey string) error {
+	if s.variants == nil || visitorKey == "" || resolved.Instance == nil {
+		return nil
+	}
+	assignment, err := s.variants.SelectVariant(ctx, experiments.SelectVariantInput{
+		TargetType: experiments.TargetWidgetInstance,
+		TargetID:   resolved.Instance.ID,
+		VisitorKey: visitorKey,
+	})
+	if err != nil || assignment == nil {
+		return err
+	}
+	resolved.Config = mergeConfiguration(resolved.Config, assignment.Configuration)
+	resolved.Experiment = assignment.ExperimentKey
+	resolved.Variant = assignment.VariantKey
+	return nil
+}
+
 func (s *service) EvaluateVisibility(_ context.Context, instance *Instance, input VisibilityContext) (bool, error) {
 	if instance == nil {
 		return false, nil
@@ -1114,7 +1383,7 @@ func (s *service) renderShortcodeValue(ctx context.Context, value any, locale st
 		if !containsShortcodeSyntax(v) {
 			return v, nil
 		}
-		output, err := s.shortcodes.Process(ctx, v, interfaces.ShortcodeProcessOptions{Locale: locale})
+		output, err := s.shortcodes.Process(ctx, v, interfaces.ShortcodeProcessOptions{Locale: locale, Trusted: true})
 		if err != nil {
 			return nil, err
 		}
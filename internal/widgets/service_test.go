@@ -12,6 +12,7 @@ import (
 	shortcodepkg "github.com/goliatone/go-cms/internal/shortcode"
 	"github.com/goliatone/go-cms/pkg/activity"
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 	"github.com/goliatone/go-cms/pkg/testsupport"
 	"github.com/google/uuid"
 )
@@ -400,6 +401,51 @@ func TestServiceUpdateInstance(t *testing.T) {
 	}
 }
 
+func TestServiceInstanceWritesEmitLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	hook := &lifecycle.CaptureHook{}
+	svc := NewService(
+		NewMemoryDefinitionRepository(),
+		NewMemoryInstanceRepository(),
+		NewMemoryTranslationRepository(),
+		WithLifecycleEmitter(lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})),
+	)
+
+	definition, err := svc.RegisterDefinition(ctx, RegisterDefinitionInput{
+		Name:   "promo",
+		Schema: map[string]any{"fields": []any{map[string]any{"name": "headline"}}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	userID := uuid.New()
+	instance, err := svc.CreateInstance(ctx, CreateInstanceInput{DefinitionID: definition.ID, CreatedBy: userID, UpdatedBy: userID})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	if _, err := svc.UpdateInstance(ctx, UpdateInstanceInput{
+		InstanceID:    instance.ID,
+		Configuration: map[string]any{"headline": "Updated"},
+		UpdatedBy:     userID,
+	}); err != nil {
+		t.Fatalf("update instance: %v", err)
+	}
+	if err := svc.DeleteInstance(ctx, DeleteInstanceRequest{InstanceID: instance.ID, DeletedBy: userID, HardDelete: true}); err != nil {
+		t.Fatalf("delete instance: %v", err)
+	}
+
+	transitions := make([]string, 0, len(hook.Events))
+	for _, event := range hook.Events {
+		if event.ResourceType != "widget" || event.RecordID != instance.ID.String() {
+			t.Fatalf("unexpected lifecycle event %+v", event)
+		}
+		transitions = append(transitions, event.Transition)
+	}
+	if !reflect.DeepEqual(transitions, []string{"create", "update", "delete"}) {
+		t.Fatalf("unexpected lifecycle transitions %v", transitions)
+	}
+}
+
 func TestServiceUpdateInstanceRejectsInvalidConfiguration(t *testing.T) {
 	ctx := context.Background()
 	svc := NewService(
@@ -0,0 +1,279 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+// trashedInstance is the trash snapshot of a deleted widget instance.
+type trashedInstance struct {
+	Instance   *Instance          `json:"instance"`
+	Placements []*AreaPlacement   `json:"placements,omitempty"`
+	Versions   []*InstanceVersion `json:"versions,omitempty"`
+}
+
+// trashInstance snapshots the instance with its translations, versions and
+// area placements and removes the live rows. Instances without an environment
+// are filed under the default environment. Repositories implementing
+// InstanceTrasher store the snapshot in the same transaction as the delete;
+// otherwise the entry is removed again when a later delete fails.
+func (s *service) trashInstance(ctx context.Context, record *Instance, translations []*Translation, versions []*InstanceVersion, deletedBy uuid.UUID) error {
+	now := s.now()
+	instance := *record
+	instance.Definition = nil
+	instance.Translations = translations
+	instance.DeletedAt = &now
+	snapshot := trashedInstance{Instance: &instance, Versions: versions}
+	if s.placements != nil {
+		placements, err := s.placements.ListByInstance(ctx, record.ID)
+		if err != nil {
+			return err
+		}
+		for _, placement := range placements {
+			if placement != nil {
+				placement.Instance = nil
+			}
+		}
+		snapshot.Placements = placements
+	}
+	entry, err := trash.NewEntry(trash.ResourceWidgetInstance, record.ID, &snapshot, deletedBy, now)
+	if err != nil {
+		return err
+	}
+	entry.EnvironmentID = record.EnvironmentID
+	if entry.EnvironmentID == uuid.Nil {
+		if entry.EnvironmentID, err = s.resolveEnvironment(ctx, ""); err != nil {
+			return err
+		}
+	}
+
+	if trasher, ok := s.instances.(InstanceTrasher); ok {
+		err := trasher.DeleteToTrash(ctx, record.ID, entry)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+
+	stored, err := s.trash.Create(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if err := s.deleteInstanceRows(ctx, record.ID, translations, versions); err != nil {
+		_ = s.trash.Delete(ctx, stored.ID)
+		return err
+	}
+	return nil
+}
+
+// deleteInstanceRows removes the instance with its versions, area placements
+// and translations.
+func (s *service) deleteInstanceRows(ctx context.Context, id uuid.UUID, translations []*Translation, versions []*InstanceVersion) error {
+	if len(versions) > 0 {
+		numbers := make([]int, 0, len(versions))
+		for _, version := range versions {
+			numbers = append(numbers, version.Version)
+		}
+		if err := s.versions.DeleteVersions(ctx, id, numbers); err != nil {
+			return err
+		}
+	}
+	if s.placements != nil {
+		if err := s.placements.DeleteByInstance(ctx, id); err != nil {
+			return err
+		}
+	}
+	for _, tr := range translations {
+		if err := s.translations.Delete(ctx, tr.ID); err != nil {
+			return err
+		}
+	}
+	return s.instances.Delete(ctx, id)
+}
+
+// ListTrashedInstances returns a page of soft-deleted widget instances of the
+// environment, most recently deleted first.
+func (s *service) ListTrashedInstances(ctx context.Context, req ListTrashedInstancesRequest) ([]*Instance, error) {
+	if s.trash == nil {
+		return nil, ErrInstanceSoftDeleteUnsupported
+	}
+	envID, err := s.resolveEnvironment(ctx, req.Environment)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.trash.List(ctx, trash.ListQuery{
+		ResourceType:  trash.ResourceWidgetInstance,
+		EnvironmentID: envID,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Instance, 0, len(entries))
+	for _, entry := range entries {
+		var snapshot trashedInstance
+		if err := trash.Decode(entry, &snapshot); err != nil {
+			return nil, err
+		}
+		if snapshot.Instance != nil {
+			records = append(records, snapshot.Instance)
+		}
+	}
+	return records, nil
+}
+
+// RestoreDeletedInstance recreates a trashed widget instance with its
+// translations and versions and returns it to the areas it was placed in.
+func (s *service) RestoreDeletedInstance(ctx context.Context, req RestoreInstanceRequest) (*Instance, error) {
+	if req.InstanceID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	if s.trash == nil {
+		return nil, ErrInstanceSoftDeleteUnsupported
+	}
+	entry, err := s.trash.GetByResource(ctx, trash.ResourceWidgetInstance, req.InstanceID)
+	if err != nil {
+		if errors.Is(err, trash.ErrEntryNotFound) {
+			return nil, ErrInstanceNotTrashed
+		}
+		return nil, err
+	}
+	var snapshot trashedInstance
+	if err := trash.Decode(entry, &snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Instance == nil {
+		return nil, ErrInstanceNotTrashed
+	}
+	created, err := s.restoreInstanceRecord(ctx, snapshot.Instance, snapshot.Versions, req.RestoredBy)
+	if err != nil {
+		return nil, err
+	}
+	if s.placements != nil {
+		for _, placement := range snapshot.Placements {
+			if err := s.restorePlacement(ctx, placement); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.trash.Delete(ctx, entry.ID); err != nil && !errors.Is(err, trash.ErrEntryNotFound) {
+		return nil, err
+	}
+
+	meta := map[string]any{
+		"area_code":    created.AreaCode,
+		"position":     created.Position,
+		"placements":   len(snapshot.Placements),
+		"publish_on":   created.PublishOn,
+		"unpublish_on": created.UnpublishOn,
+	}
+	s.emitActivity(ctx, pickActor(req.RestoredBy, created.UpdatedBy, created.CreatedBy), "restore", "widget_instance", created.ID, meta)
+	s.emitInstanceLifecycle(ctx, created.ID, "restore", meta)
+	return created, nil
+}
+
+// RestoreInstanceSnapshot recreates a widget instance from a captured copy
+// with its original ID, translations and versions. A live instance with the
+// same ID is removed first.
+func (s *service) RestoreInstanceSnapshot(ctx context.Context, req RestoreInstanceSnapshotRequest) (*Instance, error) {
+	if req.Instance == nil || req.Instance.ID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	if _, err := s.instances.GetByID(ctx, req.Instance.ID); err == nil {
+		if err := s.DeleteInstance(ctx, DeleteInstanceRequest{InstanceID: req.Instance.ID, DeletedBy: req.RestoredBy, HardDelete: true}); err != nil {
+			return nil, err
+		}
+	} else {
+		var nf *NotFoundError
+		if !errors.As(err, &nf) {
+			return nil, err
+		}
+	}
+	record := *req.Instance
+	record.Definition = nil
+	record.Translations = make([]*Translation, 0, len(req.Instance.Translations))
+	for _, tr := range req.Instance.Translations {
+		if tr == nil {
+			continue
+		}
+		copied := *tr
+		copied.Instance = nil
+		record.Translations = append(record.Translations, &copied)
+	}
+	created, err := s.restoreInstanceRecord(ctx, &record, req.Versions, req.RestoredBy)
+	if err != nil {
+		return nil, err
+	}
+	meta := map[string]any{
+		"area_code":    created.AreaCode,
+		"position":     created.Position,
+		"publish_on":   created.PublishOn,
+		"unpublish_on": created.UnpublishOn,
+	}
+	s.emitActivity(ctx, pickActor(req.RestoredBy, created.UpdatedBy, created.CreatedBy), "restore", "widget_instance", created.ID, meta)
+	s.emitInstanceLifecycle(ctx, created.ID, "restore", meta)
+	return created, nil
+}
+
+// restoreInstanceRecord inserts record under its own ID together with its
+// translations and versions.
+func (s *service) restoreInstanceRecord(ctx context.Context, record *Instance, versions []*InstanceVersion, restoredBy uuid.UUID) (*Instance, error) {
+	if _, err := s.definitions.GetByID(ctx, record.DefinitionID); err != nil {
+		return nil, ErrInstanceDefinitionRequired
+	}
+
+	translations := record.Translations
+	record.Translations = nil
+	record.DeletedAt = nil
+	record.UpdatedAt = s.now()
+	if restoredBy != uuid.Nil {
+		record.UpdatedBy = restoredBy
+	}
+
+	created, err := s.instances.Create(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	for _, tr := range translations {
+		if tr == nil {
+			continue
+		}
+		tr.DeletedAt = nil
+		if _, err := s.translations.Create(ctx, tr); err != nil {
+			return nil, err
+		}
+	}
+	if s.versions != nil {
+		for _, version := range versions {
+			if version == nil {
+				continue
+			}
+			if _, err := s.versions.Create(ctx, version); err != nil {
+				return nil, err
+			}
+		}
+	}
+	created.Translations = translations
+	return created, nil
+}
+
+// restorePlacement reinserts a placement at its original position, clamped to
+// the current length of the area list.
+func (s *service) restorePlacement(ctx context.Context, placement *AreaPlacement) error {
+	if placement == nil {
+		return nil
+	}
+	current, err := s.placements.ListByAreaAndLocale(ctx, placement.AreaCode, placement.LocaleID)
+	if err != nil {
+		return err
+	}
+	position := min(max(placement.Position, 0), len(current))
+	next := make([]*AreaPlacement, 0, len(current)+1)
+	next = append(next, current[:position]...)
+	next = append(next, placement)
+	next = append(next, current[position:]...)
+	return s.placements.Replace(ctx, placement.AreaCode, placement.LocaleID, next)
+}
@@ -0,0 +1,143 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/google/uuid"
+)
+
+func TestServiceSoftDeleteInstanceRestoresPlacement(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc := newServiceWithAreas(WithTrashRepository(trash.NewMemoryRepository()))
+
+	if _, err := svc.RegisterAreaDefinition(ctx, RegisterAreaDefinitionInput{Code: "sidebar.primary", Name: "Primary Sidebar"}); err != nil {
+		t.Fatalf("register area: %v", err)
+	}
+	def, err := svc.RegisterDefinition(ctx, RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+
+	instances := make([]*Instance, 0, 2)
+	for range 2 {
+		instance, err := svc.CreateInstance(ctx, CreateInstanceInput{
+			DefinitionID: def.ID,
+			CreatedBy:    userID,
+			UpdatedBy:    userID,
+		})
+		if err != nil {
+			t.Fatalf("create instance: %v", err)
+		}
+		if _, err := svc.AssignWidgetToArea(ctx, AssignWidgetToAreaInput{
+			AreaCode:   "sidebar.primary",
+			InstanceID: instance.ID,
+		}); err != nil {
+			t.Fatalf("assign widget: %v", err)
+		}
+		instances = append(instances, instance)
+	}
+	first := instances[0]
+	if _, err := svc.AddTranslation(ctx, AddTranslationInput{
+		InstanceID: first.ID,
+		LocaleID:   uuid.New(),
+		Content:    map[string]any{"title": "Hello"},
+	}); err != nil {
+		t.Fatalf("add translation: %v", err)
+	}
+
+	if err := svc.DeleteInstance(ctx, DeleteInstanceRequest{InstanceID: first.ID, DeletedBy: userID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	resolved, err := svc.ResolveArea(ctx, ResolveAreaInput{AreaCode: "sidebar.primary", Now: time.Now()})
+	if err != nil {
+		t.Fatalf("resolve area: %v", err)
+	}
+	if len(resolved) != 1 || resolved[0].Instance.ID != instances[1].ID {
+		t.Fatalf("expected only the remaining widget, got %d", len(resolved))
+	}
+
+	trashed, err := svc.ListTrashedInstances(ctx, ListTrashedInstancesRequest{})
+	if err != nil {
+		t.Fatalf("list trashed: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != first.ID {
+		t.Fatalf("expected trashed instance, got %+v", trashed)
+	}
+
+	restored, err := svc.RestoreDeletedInstance(ctx, RestoreInstanceRequest{InstanceID: first.ID, RestoredBy: userID})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.ID != first.ID || len(restored.Translations) != 1 {
+		t.Fatalf("expected instance with translation, got %+v", restored)
+	}
+
+	resolved, err = svc.ResolveArea(ctx, ResolveAreaInput{AreaCode: "sidebar.primary", Now: time.Now()})
+	if err != nil {
+		t.Fatalf("resolve area after restore: %v", err)
+	}
+	if len(resolved) != 2 || resolved[0].Instance.ID != first.ID || resolved[1].Instance.ID != instances[1].ID {
+		t.Fatalf("expected restored widget back at its original position")
+	}
+
+	if _, err := svc.RestoreDeletedInstance(ctx, RestoreInstanceRequest{InstanceID: first.ID}); !errors.Is(err, ErrInstanceNotTrashed) {
+		t.Fatalf("expected ErrInstanceNotTrashed, got %v", err)
+	}
+}
+
+func TestServiceListTrashedInstancesPagesByEnvironment(t *testing.T) {
+	ctx := context.Background()
+	svc := newServiceWithAreas(WithTrashRepository(trash.NewMemoryRepository()))
+
+	def, err := svc.RegisterDefinition(ctx, RegisterDefinitionInput{
+		Name:   "hero",
+		Schema: map[string]any{"fields": []any{"title"}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	for _, env := range []string{"", "", "staging"} {
+		instance, err := svc.CreateInstance(ctx, CreateInstanceInput{
+			DefinitionID:   def.ID,
+			CreatedBy:      uuid.New(),
+			UpdatedBy:      uuid.New(),
+			EnvironmentKey: env,
+		})
+		if err != nil {
+			t.Fatalf("create instance: %v", err)
+		}
+		if err := svc.DeleteInstance(ctx, DeleteInstanceRequest{InstanceID: instance.ID}); err != nil {
+			t.Fatalf("soft delete: %v", err)
+		}
+	}
+
+	first, err := svc.ListTrashedInstances(ctx, ListTrashedInstancesRequest{Limit: 1})
+	if err != nil {
+		t.Fatalf("list trashed: %v", err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("expected a page of 1 trashed instance, got %d", len(first))
+	}
+	rest, err := svc.ListTrashedInstances(ctx, ListTrashedInstancesRequest{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("list trashed offset: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID == first[0].ID {
+		t.Fatalf("expected the other default instance on the second page, got %+v", rest)
+	}
+	staging, err := svc.ListTrashedInstances(ctx, ListTrashedInstancesRequest{Environment: "staging"})
+	if err != nil {
+		t.Fatalf("list trashed staging: %v", err)
+	}
+	if len(staging) != 1 {
+		t.Fatalf("expected one trashed staging instance, got %d", len(staging))
+	}
+}
@@ -0,0 +1,405 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/google/uuid"
+)
+
+// CreateDraft stores a new draft version of an instance. The live instance is
+// left untouched until the draft is published.
+func (s *service) CreateDraft(ctx context.Context, req CreateInstanceDraftRequest) (*InstanceVersion, error) {
+	if !s.versioningEnabled || s.versions == nil {
+		return nil, ErrVersioningDisabled
+	}
+	if req.InstanceID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+
+	instance, err := s.instances.GetByID(ctx, req.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := s.definitions.GetByID(ctx, instance.DefinitionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSnapshot(definition, req.Snapshot); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.versions.ListByInstance(ctx, req.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	if s.retention.Rejects(len(versions)) {
+		return nil, ErrInstanceVersionRetentionExceeded
+	}
+
+	next := nextInstanceVersionNumber(versions)
+	if req.BaseVersion != nil && *req.BaseVersion != next-1 {
+		return nil, ErrInstanceVersionConflict
+	}
+
+	now := s.now()
+	created, err := s.versions.Create(ctx, &InstanceVersion{
+		ID:               s.id(),
+		WidgetInstanceID: req.InstanceID,
+		Version:          next,
+		Status:           VersionStatusDraft,
+		Snapshot:         cloneInstanceVersionSnapshot(req.Snapshot),
+		CreatedBy:        req.CreatedBy,
+		CreatedAt:        now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instance.CurrentVersion = created.Version
+	instance.UpdatedAt = now
+	if actor := pickActor(req.UpdatedBy, req.CreatedBy); actor != uuid.Nil {
+		instance.UpdatedBy = actor
+	}
+	if _, err := s.instances.Update(ctx, instance); err != nil {
+		if deleteErr := s.versions.DeleteVersions(ctx, req.InstanceID, []int{created.Version}); deleteErr != nil {
+			s.opLogger(ctx, "widgets.instance.create_draft", map[string]any{
+				"instance_id": instance.ID,
+				"version":     created.Version,
+			}).Warn("widget draft rollback failed", "error", deleteErr)
+		}
+		return nil, err
+	}
+	if s.retention.PrunesOnWrite() {
+		if _, err := s.pruneVersions(ctx, instance, instance.UpdatedBy); err != nil {
+			s.opLogger(ctx, "widgets.instance.create_draft", map[string]any{
+				"instance_id": instance.ID,
+			}).Warn("widget version prune failed", "error", err)
+		}
+	}
+
+	return cloneInstanceVersion(created), nil
+}
+
+// PublishDraft makes a draft live: the snapshot replaces the configuration and
+// visibility rules of the instance and its translations are written per locale.
+// The previously published version is archived. When a write fails, the
+// translations and versions already written are restored.
+func (s *service) PublishDraft(ctx context.Context, req PublishInstanceDraftRequest) (*InstanceVersion, error) {
+	if !s.versioningEnabled || s.versions == nil {
+		return nil, ErrVersioningDisabled
+	}
+	if req.InstanceID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	if req.Version <= 0 {
+		return nil, ErrInstanceVersionRequired
+	}
+
+	instance, err := s.instances.GetByID(ctx, req.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.versions.GetVersion(ctx, req.InstanceID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if version.Status == VersionStatusPublished {
+		return nil, ErrInstanceVersionAlreadyPublished
+	}
+	definition, err := s.definitions.GetByID(ctx, instance.DefinitionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSnapshot(definition, version.Snapshot); err != nil {
+		return nil, err
+	}
+
+	publishedAt := s.now()
+	if req.PublishedAt != nil && !req.PublishedAt.IsZero() {
+		publishedAt = *req.PublishedAt
+	}
+
+	undo := &publishUndo{}
+	fail := func(err error) (*InstanceVersion, error) {
+		if undoErr := undo.revert(ctx, s); undoErr != nil {
+			s.opLogger(ctx, "widgets.instance.publish_draft", map[string]any{
+				"instance_id": instance.ID,
+				"version":     req.Version,
+			}).Warn("widget publish rollback failed", "error", undoErr)
+		}
+		return nil, err
+	}
+
+	if err := s.applySnapshotTranslations(ctx, instance.ID, version.Snapshot, undo); err != nil {
+		return fail(err)
+	}
+
+	original := cloneInstanceVersion(version)
+	version.Status = VersionStatusPublished
+	version.PublishedAt = &publishedAt
+	if req.PublishedBy != uuid.Nil {
+		version.PublishedBy = &req.PublishedBy
+	}
+	updatedVersion, err := s.versions.Update(ctx, version)
+	if err != nil {
+		return fail(err)
+	}
+	undo.versions = append(undo.versions, original)
+
+	if instance.PublishedVersion != nil && *instance.PublishedVersion != updatedVersion.Version {
+		previous, prevErr := s.versions.GetVersion(ctx, req.InstanceID, *instance.PublishedVersion)
+		if prevErr == nil && previous.Status == VersionStatusPublished {
+			archived := cloneInstanceVersion(previous)
+			previous.Status = VersionStatusArchived
+			if _, err := s.versions.Update(ctx, previous); err != nil {
+				return fail(err)
+			}
+			undo.versions = append(undo.versions, archived)
+		}
+	}
+
+	applySnapshot(instance, definition, version.Snapshot)
+	instance.PublishedVersion = &updatedVersion.Version
+	instance.PublishedAt = &publishedAt
+	if req.PublishedBy != uuid.Nil {
+		instance.PublishedBy = &req.PublishedBy
+		instance.UpdatedBy = req.PublishedBy
+	}
+	if updatedVersion.Version > instance.CurrentVersion {
+		instance.CurrentVersion = updatedVersion.Version
+	}
+	instance.UpdatedAt = s.now()
+	if _, err := s.instances.Update(ctx, instance); err != nil {
+		return fail(err)
+	}
+
+	meta := map[string]any{
+		"version":       updatedVersion.Version,
+		"status":        updatedVersion.Status,
+		"definition_id": instance.DefinitionID.String(),
+		"area_code":     instance.AreaCode,
+		"position":      instance.Position,
+	}
+	s.emitActivity(ctx, req.PublishedBy, "publish", "widget_instance", instance.ID, meta)
+	s.emitLifecycle(ctx, lifecycle.Event{
+		ResourceType: "widget",
+		RecordID:     instance.ID.String(),
+		Transition:   "publish",
+		Status:       string(updatedVersion.Status),
+		OccurredAt:   publishedAt,
+		Metadata:     meta,
+	})
+
+	return cloneInstanceVersion(updatedVersion), nil
+}
+
+// ListVersions returns the stored versions of an instance, oldest first.
+func (s *service) ListVersions(ctx context.Context, instanceID uuid.UUID) ([]*InstanceVersion, error) {
+	if !s.versioningEnabled || s.versions == nil {
+		return nil, ErrVersioningDisabled
+	}
+	if instanceID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	return s.versions.ListByInstance(ctx, instanceID)
+}
+
+// RestoreVersion copies an earlier version into a new draft.
+func (s *service) RestoreVersion(ctx context.Context, req RestoreInstanceVersionRequest) (*InstanceVersion, error) {
+	if !s.versioningEnabled || s.versions == nil {
+		return nil, ErrVersioningDisabled
+	}
+	if req.InstanceID == uuid.Nil {
+		return nil, ErrInstanceIDRequired
+	}
+	if req.Version <= 0 {
+		return nil, ErrInstanceVersionRequired
+	}
+
+	version, err := s.versions.GetVersion(ctx, req.InstanceID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateDraft(ctx, CreateInstanceDraftRequest{
+		InstanceID: req.InstanceID,
+		Snapshot:   cloneInstanceVersionSnapshot(version.Snapshot),
+		CreatedBy:  req.RestoredBy,
+		UpdatedBy:  req.RestoredBy,
+	})
+}
+
+// pendingDraft returns the newest version of an instance when it is a draft
+// that has not been published yet.
+func (s *service) pendingDraft(ctx context.Context, instance *Instance) (*InstanceVersion, error) {
+	if !s.versioningEnabled || s.versions == nil || instance == nil {
+		return nil, nil
+	}
+	latest, err := s.versions.GetLatest(ctx, instance.ID)
+	if err != nil {
+		var nf *NotFoundError
+		if errors.As(err, &nf) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if latest.Status != VersionStatusDraft {
+		return nil, nil
+	}
+	if instance.PublishedVersion != nil && latest.Version <= *instance.PublishedVersion {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// previewInstance overlays the pending draft of an instance, if any, on a copy
+// of the live record. Translations are overlaid once they have been attached.
+func (s *service) previewInstance(ctx context.Context, instance *Instance) (*Instance, *InstanceVersion, error) {
+	draft, err := s.pendingDraft(ctx, instance)
+	if err != nil || draft == nil {
+		return instance, nil, err
+	}
+	definition, err := s.definitions.GetByID(ctx, instance.DefinitionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	preview := cloneInstance(instance)
+	applySnapshot(preview, definition, draft.Snapshot)
+	return preview, draft, nil
+}
+
+// overlayDraftTranslations replaces attached translations with the localized
+// content of a draft snapshot.
+func (s *service) overlayDraftTranslations(ctx context.Context, instance *Instance, snapshot InstanceVersionSnapshot) error {
+	for _, entry := range snapshot.Translations {
+		rendered, err := s.cloneAndRenderTranslation(ctx, &Translation{
+			WidgetInstanceID: instance.ID,
+			LocaleID:         entry.LocaleID,
+			Content:          entry.Content,
+		})
+		if err != nil {
+			return err
+		}
+		replaced := false
+		for i, existing := range instance.Translations {
+			if existing != nil && existing.LocaleID == entry.LocaleID {
+				rendered.ID = existing.ID
+				instance.Translations[i] = rendered
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			instance.Translations = append(instance.Translations, rendered)
+		}
+	}
+	return nil
+}
+
+// publishUndo records the writes of a publish so they can be reverted when a
+// later write fails.
+type publishUndo struct {
+	createdTranslations []uuid.UUID
+	updatedTranslations []*Translation
+	versions            []*InstanceVersion
+}
+
+// revert restores the recorded versions and translations, newest write first.
+func (u *publishUndo) revert(ctx context.Context, s *service) error {
+	var errs []error
+	for i := len(u.versions) - 1; i >= 0; i-- {
+		if _, err := s.versions.Update(ctx, u.versions[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(u.updatedTranslations) - 1; i >= 0; i-- {
+		if _, err := s.translations.Update(ctx, u.updatedTranslations[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, id := range u.createdTranslations {
+		if err := s.translations.Delete(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applySnapshotTranslations writes the localized content of a snapshot to the
+// live translations, creating translations for new locales. Each write is
+// recorded on undo.
+func (s *service) applySnapshotTranslations(ctx context.Context, instanceID uuid.UUID, snapshot InstanceVersionSnapshot, undo *publishUndo) error {
+	now := s.now()
+	for _, entry := range snapshot.Translations {
+		existing, err := s.translations.GetByInstanceAndLocale(ctx, instanceID, entry.LocaleID)
+		if err != nil {
+			var nf *NotFoundError
+			if !errors.As(err, &nf) {
+				return err
+			}
+			created, err := s.translations.Create(ctx, &Translation{
+				ID:               s.id(),
+				WidgetInstanceID: instanceID,
+				LocaleID:         entry.LocaleID,
+				Content:          deepCloneMap(entry.Content),
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			})
+			if err != nil {
+				return err
+			}
+			undo.createdTranslations = append(undo.createdTranslations, created.ID)
+			continue
+		}
+		original := cloneTranslation(existing)
+		existing.Content = deepCloneMap(entry.Content)
+		existing.UpdatedAt = now
+		if _, err := s.translations.Update(ctx, existing); err != nil {
+			return err
+		}
+		undo.updatedTranslations = append(undo.updatedTranslations, original)
+	}
+	return nil
+}
+
+// applySnapshot sets the configuration and visibility rules of a snapshot on
+// an instance. Configuration is merged over the definition defaults.
+func applySnapshot(instance *Instance, definition *Definition, snapshot InstanceVersionSnapshot) {
+	var defaults map[string]any
+	if definition != nil {
+		defaults = definition.Defaults
+	}
+	instance.Configuration = mergeConfiguration(defaults, snapshot.Configuration)
+	instance.VisibilityRules = deepCloneMap(snapshot.VisibilityRules)
+}
+
+func validateSnapshot(definition *Definition, snapshot InstanceVersionSnapshot) error {
+	if snapshot.Configuration != nil {
+		if err := validateConfiguration(definition.Schema, snapshot.Configuration); err != nil {
+			return err
+		}
+	}
+	if err := validateVisibilityRules(snapshot.VisibilityRules); err != nil {
+		return err
+	}
+	for _, entry := range snapshot.Translations {
+		if entry.LocaleID == uuid.Nil {
+			return ErrTranslationLocaleRequired
+		}
+		if entry.Content == nil {
+			return ErrTranslationContentRequired
+		}
+	}
+	return nil
+}
+
+func nextInstanceVersionNumber(records []*InstanceVersion) int {
+	highest := 0
+	for _, version := range records {
+		if version != nil && version.Version > highest {
+			highest = version.Version
+		}
+	}
+	return highest + 1
+}
@@ -0,0 +1,358 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-cms/internal/retention"
+	"github.com/goliatone/go-cms/internal/trash"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/google/uuid"
+)
+
+func TestServiceDraftPublishLifecycle(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	localeID := uuid.New()
+	hook := &lifecycle.CaptureHook{}
+	svc := newServiceWithAreas(
+		WithVersioningEnabled(true),
+		WithInstanceVersionRepository(NewMemoryInstanceVersionRepository()),
+		WithLifecycleEmitter(lifecycle.NewEmitter(lifecycle.Hooks{hook}, lifecycle.Config{Enabled: true})),
+	)
+	instance := seedVersionedInstance(t, svc, userID)
+	if _, err := svc.AddTranslation(ctx, AddTranslationInput{
+		InstanceID: instance.ID,
+		LocaleID:   localeID,
+		Content:    map[string]any{"headline": "Live"},
+	}); err != nil {
+		t.Fatalf("add translation: %v", err)
+	}
+
+	draft, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{
+		InstanceID: instance.ID,
+		Snapshot: InstanceVersionSnapshot{
+			Configuration:   map[string]any{"headline": "Draft"},
+			VisibilityRules: map[string]any{"audience": []any{"members"}},
+			Translations:    []InstanceVersionTranslationSnapshot{{LocaleID: localeID, Content: map[string]any{"headline": "Entwurf"}}},
+		},
+		CreatedBy: userID,
+	})
+	if err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	if draft.Version != 1 || draft.Status != VersionStatusDraft {
+		t.Fatalf("unexpected draft %+v", draft)
+	}
+
+	live := resolveSidebar(t, svc, localeID, false, "members")
+	if len(live) != 1 || live[0].Config["headline"] != "Live" {
+		t.Fatalf("expected live widget to ignore draft, got %+v", live)
+	}
+	preview := resolveSidebar(t, svc, localeID, true, "members")
+	if len(preview) != 1 || preview[0].Config["headline"] != "Entwurf" || preview[0].Config["cta"] != "Subscribe" {
+		t.Fatalf("expected preview to apply draft, got %+v", preview)
+	}
+	if hidden := resolveSidebar(t, svc, localeID, true, "guests"); len(hidden) != 0 {
+		t.Fatalf("expected preview to apply draft visibility rules, got %d widgets", len(hidden))
+	}
+
+	if _, err := svc.PublishDraft(ctx, PublishInstanceDraftRequest{InstanceID: instance.ID, Version: draft.Version, PublishedBy: userID}); err != nil {
+		t.Fatalf("publish draft: %v", err)
+	}
+	if _, err := svc.PublishDraft(ctx, PublishInstanceDraftRequest{InstanceID: instance.ID, Version: draft.Version}); !errors.Is(err, ErrInstanceVersionAlreadyPublished) {
+		t.Fatalf("expected ErrInstanceVersionAlreadyPublished, got %v", err)
+	}
+	published, err := svc.GetInstance(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("get instance: %v", err)
+	}
+	if published.PublishedVersion == nil || *published.PublishedVersion != 1 || published.Configuration["headline"] != "Draft" {
+		t.Fatalf("expected published configuration, got %+v", published)
+	}
+	live = resolveSidebar(t, svc, localeID, false, "members")
+	if len(live) != 1 || live[0].Config["headline"] != "Entwurf" {
+		t.Fatalf("expected published translation, got %+v", live)
+	}
+	transitions := make([]string, 0, len(hook.Events))
+	for _, event := range hook.Events {
+		if event.ResourceType != "widget" || event.RecordID != instance.ID.String() {
+			t.Fatalf("unexpected lifecycle event %+v", event)
+		}
+		transitions = append(transitions, event.Transition)
+	}
+	// Creating a draft leaves the live widget untouched and emits nothing.
+	if !reflect.DeepEqual(transitions, []string{"create", "assign", "update", "publish"}) {
+		t.Fatalf("unexpected lifecycle transitions %v", transitions)
+	}
+
+	restored, err := svc.RestoreVersion(ctx, RestoreInstanceVersionRequest{InstanceID: instance.ID, Version: 1, RestoredBy: userID})
+	if err != nil {
+		t.Fatalf("restore version: %v", err)
+	}
+	if _, err := svc.PublishDraft(ctx, PublishInstanceDraftRequest{InstanceID: instance.ID, Version: restored.Version, PublishedBy: userID}); err != nil {
+		t.Fatalf("publish restored draft: %v", err)
+	}
+	versions, err := svc.ListVersions(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	statuses := make([]VersionStatus, 0, len(versions))
+	for _, version := range versions {
+		statuses = append(statuses, version.Status)
+	}
+	if want := []VersionStatus{VersionStatusArchived, VersionStatusPublished}; !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("expected statuses %v, got %v", want, statuses)
+	}
+
+	base := 1
+	if _, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: instance.ID, BaseVersion: &base}); !errors.Is(err, ErrInstanceVersionConflict) {
+		t.Fatalf("expected ErrInstanceVersionConflict, got %v", err)
+	}
+}
+
+func TestServiceVersioningDisabled(t *testing.T) {
+	svc := newServiceWithAreas()
+	if _, err := svc.CreateDraft(context.Background(), CreateInstanceDraftRequest{InstanceID: uuid.New()}); !errors.Is(err, ErrVersioningDisabled) {
+		t.Fatalf("expected ErrVersioningDisabled, got %v", err)
+	}
+}
+
+func TestServiceRetentionPrunesWidgetVersions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc := newServiceWithAreas(
+		WithVersioningEnabled(true),
+		WithInstanceVersionRepository(NewMemoryInstanceVersionRepository()),
+		WithVersionRetentionPolicy(retention.Policy{Mode: retention.ModeScheduled, KeepLast: 2}),
+	)
+	instance := seedVersionedInstance(t, svc, userID)
+	for range 4 {
+		if _, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: instance.ID, CreatedBy: userID}); err != nil {
+			t.Fatalf("create draft: %v", err)
+		}
+	}
+
+	pruned, err := svc.(retention.Source).PruneAllVersions(ctx)
+	if err != nil {
+		t.Fatalf("prune all versions: %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].Resource != retention.ResourceWidgetInstance || !reflect.DeepEqual(pruned[0].Versions, []int{1, 2}) {
+		t.Fatalf("unexpected prune report %+v", pruned)
+	}
+
+	reject := newServiceWithAreas(
+		WithVersioningEnabled(true),
+		WithInstanceVersionRepository(NewMemoryInstanceVersionRepository()),
+		WithVersionRetentionPolicy(retention.Policy{KeepLast: 1}),
+	)
+	limited := seedVersionedInstance(t, reject, userID)
+	if _, err := reject.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: limited.ID}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	if _, err := reject.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: limited.ID}); !errors.Is(err, ErrInstanceVersionRetentionExceeded) {
+		t.Fatalf("expected ErrInstanceVersionRetentionExceeded, got %v", err)
+	}
+}
+
+func TestServiceRetentionPruneFailureKeepsDraft(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	versions := &failingPruneVersionRepository{InstanceVersionRepository: NewMemoryInstanceVersionRepository()}
+	svc := newServiceWithAreas(
+		WithVersioningEnabled(true),
+		WithInstanceVersionRepository(versions),
+		WithVersionRetentionPolicy(retention.Policy{Mode: retention.ModePrune, KeepLast: 1}),
+	)
+	instance := seedVersionedInstance(t, svc, userID)
+	if _, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: instance.ID, CreatedBy: userID}); err != nil {
+		t.Fatalf("create first draft: %v", err)
+	}
+	draft, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: instance.ID, CreatedBy: userID})
+	if err != nil {
+		t.Fatalf("create second draft: %v", err)
+	}
+	if draft == nil || draft.Version != 2 {
+		t.Fatalf("expected created draft version 2, got %+v", draft)
+	}
+	if versions.attempts == 0 {
+		t.Fatal("expected pruning to be attempted")
+	}
+	listed, err := svc.ListVersions(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected both versions kept, got %d", len(listed))
+	}
+}
+
+type failingPruneVersionRepository struct {
+	InstanceVersionRepository
+	attempts int
+}
+
+func (r *failingPruneVersionRepository) DeleteVersions(context.Context, uuid.UUID, []int) error {
+	r.attempts++
+	return errors.New("prune unavailable")
+}
+
+func TestServicePublishDraftRevertsWritesWhenInstanceUpdateFails(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	liveLocale := uuid.New()
+	newLocale := uuid.New()
+	instances := &failingUpdateInstanceRepository{InstanceRepository: NewMemoryInstanceRepository()}
+	translations := NewMemoryTranslationRepository()
+	svc := NewService(NewMemoryDefinitionRepository(), instances, translations,
+		WithVersioningEnabled(true),
+		WithInstanceVersionRepository(NewMemoryInstanceVersionRepository()),
+		WithAreaDefinitionRepository(NewMemoryAreaDefinitionRepository()),
+		WithAreaPlacementRepository(NewMemoryAreaPlacementRepository()),
+	)
+	instance := seedVersionedInstance(t, svc, userID)
+	if _, err := svc.AddTranslation(ctx, AddTranslationInput{
+		InstanceID: instance.ID,
+		LocaleID:   liveLocale,
+		Content:    map[string]any{"headline": "Live"},
+	}); err != nil {
+		t.Fatalf("add translation: %v", err)
+	}
+	draft, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{
+		InstanceID: instance.ID,
+		Snapshot: InstanceVersionSnapshot{
+			Configuration: map[string]any{"headline": "Draft"},
+			Translations: []InstanceVersionTranslationSnapshot{
+				{LocaleID: liveLocale, Content: map[string]any{"headline": "Entwurf"}},
+				{LocaleID: newLocale, Content: map[string]any{"headline": "Brouillon"}},
+			},
+		},
+		CreatedBy: userID,
+	})
+	if err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	instances.fail = true
+	if _, err := svc.PublishDraft(ctx, PublishInstanceDraftRequest{InstanceID: instance.ID, Version: draft.Version, PublishedBy: userID}); !errors.Is(err, errInstanceUpdate) {
+		t.Fatalf("expected instance update error, got %v", err)
+	}
+	versions, err := svc.ListVersions(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 1 || versions[0].Status != VersionStatusDraft || versions[0].PublishedAt != nil {
+		t.Fatalf("expected the draft to stay unpublished, got %+v", versions)
+	}
+	live, err := translations.GetByInstanceAndLocale(ctx, instance.ID, liveLocale)
+	if err != nil || live.Content["headline"] != "Live" {
+		t.Fatalf("expected live translation restored, got %+v (%v)", live, err)
+	}
+	var nf *NotFoundError
+	if _, err := translations.GetByInstanceAndLocale(ctx, instance.ID, newLocale); !errors.As(err, &nf) {
+		t.Fatalf("expected created translation removed, got %v", err)
+	}
+
+	if _, err := svc.RestoreVersion(ctx, RestoreInstanceVersionRequest{InstanceID: instance.ID, Version: draft.Version, RestoredBy: userID}); !errors.Is(err, errInstanceUpdate) {
+		t.Fatalf("expected instance update error on restore, got %v", err)
+	}
+	if versions, err := svc.ListVersions(ctx, instance.ID); err != nil || len(versions) != 1 {
+		t.Fatalf("expected the restored draft removed, got %d (%v)", len(versions), err)
+	}
+
+	instances.fail = false
+	if _, err := svc.PublishDraft(ctx, PublishInstanceDraftRequest{InstanceID: instance.ID, Version: draft.Version, PublishedBy: userID}); err != nil {
+		t.Fatalf("publish after failure: %v", err)
+	}
+}
+
+var errInstanceUpdate = errors.New("instance update unavailable")
+
+type failingUpdateInstanceRepository struct {
+	InstanceRepository
+	fail bool
+}
+
+func (r *failingUpdateInstanceRepository) Update(ctx context.Context, instance *Instance) (*Instance, error) {
+	if r.fail {
+		return nil, errInstanceUpdate
+	}
+	return r.InstanceRepository.Update(ctx, instance)
+}
+
+func TestServiceSoftDeleteKeepsWidgetVersions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc := newServiceWithAreas(
+		WithVersioningEnabled(true),
+		WithInstanceVersionRepository(NewMemoryInstanceVersionRepository()),
+		WithTrashRepository(trash.NewMemoryRepository()),
+	)
+	instance := seedVersionedInstance(t, svc, userID)
+	if _, err := svc.CreateDraft(ctx, CreateInstanceDraftRequest{InstanceID: instance.ID}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	if err := svc.DeleteInstance(ctx, DeleteInstanceRequest{InstanceID: instance.ID, DeletedBy: userID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	if versions, err := svc.ListVersions(ctx, instance.ID); err != nil || len(versions) != 0 {
+		t.Fatalf("expected versions removed with the instance, got %d (%v)", len(versions), err)
+	}
+	if _, err := svc.RestoreDeletedInstance(ctx, RestoreInstanceRequest{InstanceID: instance.ID, RestoredBy: userID}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if versions, err := svc.ListVersions(ctx, instance.ID); err != nil || len(versions) != 1 {
+		t.Fatalf("expected restored version, got %d (%v)", len(versions), err)
+	}
+}
+
+func seedVersionedInstance(t *testing.T, svc Service, userID uuid.UUID) *Instance {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := svc.RegisterAreaDefinition(ctx, RegisterAreaDefinitionInput{Code: "sidebar.primary", Name: "Primary Sidebar"}); err != nil {
+		t.Fatalf("register area: %v", err)
+	}
+	def, err := svc.RegisterDefinition(ctx, RegisterDefinitionInput{
+		Name: "newsletter",
+		Schema: map[string]any{"fields": []any{
+			map[string]any{"name": "headline"},
+			map[string]any{"name": "cta"},
+		}},
+		Defaults: map[string]any{"cta": "Subscribe"},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	instance, err := svc.CreateInstance(ctx, CreateInstanceInput{
+		DefinitionID:  def.ID,
+		Configuration: map[string]any{"headline": "Live"},
+		CreatedBy:     userID,
+		UpdatedBy:     userID,
+	})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	if _, err := svc.AssignWidgetToArea(ctx, AssignWidgetToAreaInput{AreaCode: "sidebar.primary", InstanceID: instance.ID}); err != nil {
+		t.Fatalf("assign widget: %v", err)
+	}
+	return instance
+}
+
+func resolveSidebar(t *testing.T, svc Service, localeID uuid.UUID, preview bool, audience ...string) []*ResolvedWidget {
+	t.Helper()
+	resolved, err := svc.ResolveArea(context.Background(), ResolveAreaInput{
+		AreaCode: "sidebar.primary",
+		LocaleID: &localeID,
+		Audience: audience,
+		Now:      time.Now(),
+		Preview:  preview,
+	})
+	if err != nil {
+		t.Fatalf("resolve area: %v", err)
+	}
+	return resolved
+}
@@ -2,9 +2,11 @@ package widgets_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/goliatone/go-cms/internal/trash"
 	"github.com/goliatone/go-cms/internal/widgets"
 	"github.com/goliatone/go-cms/pkg/testsupport"
 	repocache "github.com/goliatone/go-repository-cache/cache"
@@ -181,6 +183,91 @@ func TestWidgetsService_WithBunStorageAndCache(t *testing.T) {
 	}
 }
 
+func TestWidgetsService_SoftDeleteWithBunStorageIsAtomic(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	registerWidgetModels(t, bunDB)
+	if _, err := bunDB.NewCreateTable().Model((*trash.Entry)(nil)).IfNotExists().Exec(ctx); err != nil {
+		t.Fatalf("create trash table: %v", err)
+	}
+
+	instRepo := widgets.NewBunInstanceRepository(bunDB)
+	placementRepo := widgets.NewBunAreaPlacementRepository(bunDB)
+	trashRepo := trash.NewBunRepository(bunDB)
+	service := widgets.NewService(
+		widgets.NewBunDefinitionRepository(bunDB),
+		instRepo,
+		widgets.NewBunTranslationRepository(bunDB),
+		widgets.WithAreaDefinitionRepository(widgets.NewBunAreaDefinitionRepository(bunDB)),
+		widgets.WithAreaPlacementRepository(placementRepo),
+		widgets.WithTrashRepository(trashRepo),
+	)
+
+	if _, err := service.RegisterAreaDefinition(ctx, widgets.RegisterAreaDefinitionInput{Code: "sidebar.primary", Name: "Primary Sidebar"}); err != nil {
+		t.Fatalf("register area definition: %v", err)
+	}
+	definition, err := service.RegisterDefinition(ctx, widgets.RegisterDefinitionInput{
+		Name:   "promo",
+		Schema: map[string]any{"fields": []any{map[string]any{"name": "headline"}}},
+	})
+	if err != nil {
+		t.Fatalf("register definition: %v", err)
+	}
+	instance, err := service.CreateInstance(ctx, widgets.CreateInstanceInput{
+		DefinitionID:  definition.ID,
+		Configuration: map[string]any{"headline": "Spring sale"},
+		CreatedBy:     uuid.New(),
+		UpdatedBy:     uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create instance: %v", err)
+	}
+	if _, err := service.AssignWidgetToArea(ctx, widgets.AssignWidgetToAreaInput{AreaCode: "sidebar.primary", InstanceID: instance.ID}); err != nil {
+		t.Fatalf("assign widget: %v", err)
+	}
+
+	if err := service.DeleteInstance(ctx, widgets.DeleteInstanceRequest{InstanceID: instance.ID}); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	var notFound *widgets.NotFoundError
+	if _, err := instRepo.GetByID(ctx, instance.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected instance to be removed, got %v", err)
+	}
+	placements, err := placementRepo.ListByInstance(ctx, instance.ID)
+	if err != nil || len(placements) != 0 {
+		t.Fatalf("expected placements to be removed, got %d (%v)", len(placements), err)
+	}
+	if _, err := service.RestoreDeletedInstance(ctx, widgets.RestoreInstanceRequest{InstanceID: instance.ID}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if placements, err := placementRepo.ListByInstance(ctx, instance.ID); err != nil || len(placements) != 1 {
+		t.Fatalf("expected placement to be restored, got %d (%v)", len(placements), err)
+	}
+
+	// A failed delete rolls the snapshot back with it.
+	missing := uuid.New()
+	entry, err := trash.NewEntry(trash.ResourceWidgetInstance, missing, map[string]string{"area": "sidebar.primary"}, uuid.Nil, time.Now())
+	if err != nil {
+		t.Fatalf("new entry: %v", err)
+	}
+	if err := instRepo.DeleteToTrash(ctx, missing, entry); !errors.As(err, &notFound) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if _, err := trashRepo.GetByResource(ctx, trash.ResourceWidgetInstance, missing); !errors.Is(err, trash.ErrEntryNotFound) {
+		t.Fatalf("expected the snapshot to be rolled back, got %v", err)
+	}
+}
+
 func registerWidgetModels(t *testing.T, db *bun.DB) {
 	t.Helper()
 	ctx := context.Background()
@@ -201,6 +288,7 @@ func registerWidgetModels(t *testing.T, db *bun.DB) {
 		`CREATE TABLE IF NOT EXISTS widget_instances (
 			id TEXT PRIMARY KEY,
 			definition_id TEXT NOT NULL,
+			environment_id TEXT,
 			block_instance_id TEXT,
 			area_code TEXT,
 			placement_metadata TEXT,
@@ -209,12 +297,27 @@ func registerWidgetModels(t *testing.T, db *bun.DB) {
 			publish_on TEXT,
 			unpublish_on TEXT,
 			position INTEGER NOT NULL DEFAULT 0,
+			current_version INTEGER NOT NULL DEFAULT 0,
+			published_version INTEGER,
+			published_at TEXT,
+			published_by TEXT,
 			created_by TEXT NOT NULL,
 			updated_by TEXT NOT NULL,
 			deleted_at TEXT,
 			created_at TEXT,
 			updated_at TEXT
 		)`,
+		`CREATE TABLE IF NOT EXISTS widget_versions (
+			id TEXT PRIMARY KEY,
+			widget_instance_id TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'draft',
+			snapshot TEXT NOT NULL,
+			created_by TEXT NOT NULL,
+			created_at TEXT,
+			published_at TEXT,
+			published_by TEXT
+		)`,
 		`CREATE TABLE IF NOT EXISTS widget_translations (
 			id TEXT PRIMARY KEY,
 			widget_instance_id TEXT NOT NULL,
@@ -232,6 +335,7 @@ func registerWidgetModels(t *testing.T, db *bun.DB) {
 			scope TEXT NOT NULL DEFAULT 'global',
 			theme_id TEXT,
 			template_id TEXT,
+			site_id TEXT,
 			created_at TEXT,
 			updated_at TEXT
 		)`,
@@ -253,3 +357,62 @@ func registerWidgetModels(t *testing.T, db *bun.DB) {
 		}
 	}
 }
+
+func TestBunInstanceVersionRepository(t *testing.T) {
+	ctx := context.Background()
+	sqlDB, err := testsupport.NewSQLiteMemoryDB()
+	if err != nil {
+		t.Fatalf("new sqlite db: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	bunDB := bun.NewDB(sqlDB, sqlitedialect.New())
+	bunDB.SetMaxOpenConns(1)
+	registerWidgetModels(t, bunDB)
+
+	cacheSvc, err := repocache.NewCacheService(repocache.DefaultConfig())
+	if err != nil {
+		t.Fatalf("cache service: %v", err)
+	}
+	repo := widgets.NewBunInstanceVersionRepositoryWithCache(bunDB, cacheSvc, repocache.NewDefaultKeySerializer())
+
+	instanceID := uuid.New()
+	for number := 1; number <= 3; number++ {
+		if _, err := repo.Create(ctx, &widgets.InstanceVersion{
+			ID:               uuid.New(),
+			WidgetInstanceID: instanceID,
+			Version:          number,
+			Status:           widgets.VersionStatusDraft,
+			Snapshot:         widgets.InstanceVersionSnapshot{Configuration: map[string]any{"headline": number}},
+			CreatedBy:        uuid.New(),
+			CreatedAt:        time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("create version: %v", err)
+		}
+	}
+
+	latest, err := repo.GetLatest(ctx, instanceID)
+	if err != nil {
+		t.Fatalf("get latest: %v", err)
+	}
+	if latest.Version != 3 {
+		t.Fatalf("expected latest version 3, got %d", latest.Version)
+	}
+	latest.Status = widgets.VersionStatusPublished
+	if _, err := repo.Update(ctx, latest); err != nil {
+		t.Fatalf("update version: %v", err)
+	}
+
+	if err := repo.DeleteVersions(ctx, instanceID, []int{1, 2}); err != nil {
+		t.Fatalf("delete versions: %v", err)
+	}
+	versions, err := repo.ListByInstance(ctx, instanceID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 1 || versions[0].Version != 3 || versions[0].Status != widgets.VersionStatusPublished {
+		t.Fatalf("unexpected versions after delete: %+v", versions)
+	}
+}
@@ -0,0 +1,4 @@
+// Package wxr imports WordPress eXtended RSS (WXR) exports into the CMS,
+// mapping posts and pages onto content and the page hierarchy, navigation menus
+// onto menu items and attachments onto media references.
+package wxr
@@ -0,0 +1,726 @@
+package wxr
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/internal/logging"
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/shortcode/parser"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+var (
+	ErrContentServiceRequired = errors.New("wxr: content service is required")
+	ErrPageServiceRequired    = errors.New("wxr: page service is required to import pages")
+	ErrTemplateRequired       = errors.New("wxr: template is required to import pages")
+	ErrLocaleRequired         = errors.New("wxr: locale is required")
+)
+
+// Report actions recorded for each WordPress item.
+const (
+	ActionCreated  = "created"
+	ActionUpdated  = "updated"
+	ActionResumed  = "resumed"
+	ActionSkipped  = "skipped"
+	ActionUnmapped = "unmapped"
+	ActionFailed   = "failed"
+)
+
+// MenuWriter is the subset of menus.Service used to import nav_menu_item posts.
+type MenuWriter interface {
+	UpsertMenu(ctx context.Context, input menus.UpsertMenuInput) (*menus.Menu, error)
+	UpsertMenuItem(ctx context.Context, input menus.UpsertMenuItemInput) (*menus.MenuItem, error)
+	ReconcileMenu(ctx context.Context, req menus.ReconcileMenuRequest) (*menus.ReconcileResult, error)
+}
+
+// ShortcodeConverter rewrites WordPress shortcodes in post content, typically
+// parser.WordPressPreprocessor.
+type ShortcodeConverter interface {
+	Process(content string) string
+}
+
+// Config wires the services an Importer writes to. Pages is required when
+// Options.PageTypes are imported and Menus when menus should be imported;
+// Progress enables resuming interrupted runs.
+type Config struct {
+	Content    interfaces.ContentService
+	Pages      interfaces.PageService
+	Menus      MenuWriter
+	Shortcodes ShortcodeConverter
+	Progress   ProgressStore
+	Logger     interfaces.Logger
+}
+
+// Options controls how a WXR export is mapped onto the CMS.
+//
+// ContentTypes maps WordPress post types to content type IDs; items of other
+// types are reported as unmapped. PageTypes lists the post types that also get
+// a page (defaults to "page"), nested by post_parent and using TemplateID.
+// Authors maps author logins to actor IDs, falling back to DefaultAuthor.
+type Options struct {
+	ContentTypes   map[string]uuid.UUID
+	PageTypes      []string
+	TemplateID     uuid.UUID
+	Locale         string
+	Authors        map[string]uuid.UUID
+	DefaultAuthor  uuid.UUID
+	EnvironmentKey string
+	SkipMenus      bool
+	DryRun         bool
+}
+
+// Report summarises how WordPress items were mapped.
+type Report struct {
+	Items           []ItemReport         `json:"items"`
+	Authors         map[string]uuid.UUID `json:"authors"`
+	UnmappedAuthors []string             `json:"unmapped_authors,omitempty"`
+	UnmappedTypes   map[string]int       `json:"unmapped_types,omitempty"`
+	Attachments     int                  `json:"attachments"`
+	Menus           map[string]int       `json:"menus,omitempty"`
+	Errors          []error              `json:"-"`
+}
+
+// ItemReport describes the outcome for a single WordPress item.
+type ItemReport struct {
+	WordPressID int       `json:"wordpress_id"`
+	Type        string    `json:"type"`
+	Slug        string    `json:"slug,omitempty"`
+	Action      string    `json:"action"`
+	ContentID   uuid.UUID `json:"content_id,omitzero"`
+	PageID      uuid.UUID `json:"page_id,omitzero"`
+	MenuItemID  uuid.UUID `json:"menu_item_id,omitzero"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Count returns how many items were recorded with the given action.
+func (r *Report) Count(action string) int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Importer maps parsed WXR exports onto CMS services.
+type Importer struct {
+	content    interfaces.ContentService
+	pages      interfaces.PageService
+	menus      MenuWriter
+	shortcodes ShortcodeConverter
+	progress   ProgressStore
+	logger     interfaces.Logger
+}
+
+// NewImporter builds an Importer from cfg. When no shortcode converter is
+// supplied the WordPress preprocessor is used.
+func NewImporter(cfg Config) *Importer {
+	importer := &Importer{
+		content:    cfg.Content,
+		pages:      cfg.Pages,
+		menus:      cfg.Menus,
+		shortcodes: cfg.Shortcodes,
+		progress:   cfg.Progress,
+		logger:     cfg.Logger,
+	}
+	if importer.shortcodes == nil {
+		importer.shortcodes = parser.NewWordPressPreprocessor()
+	}
+	if importer.logger == nil {
+		importer.logger = logging.NoOp()
+	}
+	return importer
+}
+
+// run carries the state of a single Import call.
+type run struct {
+	opts        Options
+	report      *Report
+	progress    *Progress
+	items       map[int]Item
+	attachments map[int]interfaces.MediaReference
+	pageTypes   map[string]bool
+	pagePaths   map[int]string
+	pageIDs     map[int]uuid.UUID
+}
+
+// Import writes export into the CMS. Items recorded in the progress store are
+// skipped, so re-running after a failure resumes where the last run stopped.
+// Per-item failures are collected in the report; the first is returned.
+func (i *Importer) Import(ctx context.Context, export *Export, opts Options) (*Report, error) {
+	if i.content == nil {
+		return nil, ErrContentServiceRequired
+	}
+	if strings.TrimSpace(opts.Locale) == "" {
+		return nil, ErrLocaleRequired
+	}
+	if len(opts.PageTypes) == 0 {
+		opts.PageTypes = []string{"page"}
+	}
+
+	r := &run{
+		opts: opts,
+		report: &Report{
+			Authors:       map[string]uuid.UUID{},
+			UnmappedTypes: map[string]int{},
+			Menus:         map[string]int{},
+		},
+		items:       map[int]Item{},
+		attachments: map[int]interfaces.MediaReference{},
+		pageTypes:   map[string]bool{},
+		pagePaths:   map[int]string{},
+		pageIDs:     map[int]uuid.UUID{},
+	}
+	for _, typ := range opts.PageTypes {
+		r.pageTypes[typ] = true
+	}
+	for typ := range r.pageTypes {
+		if _, mapped := opts.ContentTypes[typ]; mapped && i.pages == nil {
+			return nil, ErrPageServiceRequired
+		}
+		if _, mapped := opts.ContentTypes[typ]; mapped && opts.TemplateID == uuid.Nil {
+			return nil, ErrTemplateRequired
+		}
+	}
+
+	r.progress = newProgress()
+	if i.progress != nil && !opts.DryRun {
+		loaded, err := i.progress.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r.progress = loaded
+	}
+
+	i.mapAuthors(export, r)
+	for _, item := range export.Items {
+		r.items[item.ID] = item
+		if item.Type == "attachment" {
+			r.attachments[item.ID] = attachmentReference(item)
+			r.report.Attachments++
+		}
+	}
+
+	for _, item := range orderItems(export.Items, r.items) {
+		if err := ctx.Err(); err != nil {
+			return r.report, err
+		}
+		switch {
+		case item.Type == "attachment" || item.Type == "nav_menu_item":
+			continue
+		case skippedStatus(item.Status):
+			r.report.Items = append(r.report.Items, ItemReport{WordPressID: item.ID, Type: item.Type, Slug: item.Slug, Action: ActionSkipped})
+			continue
+		}
+		if _, mapped := opts.ContentTypes[item.Type]; !mapped {
+			r.report.UnmappedTypes[item.Type]++
+			r.report.Items = append(r.report.Items, ItemReport{WordPressID: item.ID, Type: item.Type, Slug: item.Slug, Action: ActionUnmapped})
+			continue
+		}
+		i.importItem(ctx, item, r)
+	}
+
+	if i.menus != nil && !opts.SkipMenus {
+		i.importMenus(ctx, export, r)
+	}
+
+	logging.WithFields(i.logger, map[string]any{
+		"items":   len(r.report.Items),
+		"created": r.report.Count(ActionCreated),
+		"updated": r.report.Count(ActionUpdated),
+		"resumed": r.report.Count(ActionResumed),
+		"failed":  r.report.Count(ActionFailed),
+		"dry_run": opts.DryRun,
+	}).Info("wxr.import.completed")
+
+	if len(r.report.Errors) > 0 {
+		return r.report, r.report.Errors[0]
+	}
+	return r.report, nil
+}
+
+func (i *Importer) mapAuthors(export *Export, r *run) {
+	for _, author := range export.Authors {
+		if id, ok := r.opts.Authors[author.Login]; ok && id != uuid.Nil {
+			r.report.Authors[author.Login] = id
+			continue
+		}
+		r.report.Authors[author.Login] = r.opts.DefaultAuthor
+		r.report.UnmappedAuthors = append(r.report.UnmappedAuthors, author.Login)
+	}
+	slices.Sort(r.report.UnmappedAuthors)
+}
+
+func (r *run) actor(login string) uuid.UUID {
+	if id, ok := r.report.Authors[login]; ok {
+		return id
+	}
+	if id, ok := r.opts.Authors[login]; ok {
+		return id
+	}
+	return r.opts.DefaultAuthor
+}
+
+// importItem creates or updates the content (and page) for a post.
+func (i *Importer) importItem(ctx context.Context, item Item, r *run) {
+	slug := itemSlug(item)
+	key := progressKey("post", item.ID)
+	entry := ItemReport{WordPressID: item.ID, Type: item.Type, Slug: slug}
+	isPage := r.pageTypes[item.Type]
+	if isPage {
+		r.pagePaths[item.ID] = r.pagePath(item, slug)
+	}
+
+	if done, ok := r.progress.Items[key]; ok {
+		entry.Action, entry.ContentID, entry.PageID = ActionResumed, done.ContentID, done.PageID
+		if done.PageID != uuid.Nil {
+			r.pageIDs[item.ID] = done.PageID
+		}
+		r.report.Items = append(r.report.Items, entry)
+		return
+	}
+
+	fail := func(err error) {
+		entry.Action, entry.Error = ActionFailed, err.Error()
+		r.report.Items = append(r.report.Items, entry)
+		r.report.Errors = append(r.report.Errors, fmt.Errorf("wxr: item %d (%s): %w", item.ID, slug, err))
+	}
+
+	actor := r.actor(item.Creator)
+	translation := interfaces.ContentTranslationInput{
+		Locale:  r.opts.Locale,
+		Title:   cmp.Or(item.Title, slug),
+		Summary: optionalString(item.Excerpt),
+		Fields:  i.contentFields(item, r),
+	}
+	metadata := contentMetadata(item)
+
+	existing, err := i.content.GetBySlug(ctx, slug, interfaces.ContentReadOptions{
+		EnvironmentKey: r.opts.EnvironmentKey,
+		ContentTypeID:  r.opts.ContentTypes[item.Type],
+	})
+	if err != nil {
+		fail(fmt.Errorf("content lookup: %w", err))
+		return
+	}
+	if r.opts.DryRun {
+		entry.Action = ActionCreated
+		if existing != nil {
+			entry.Action, entry.ContentID = ActionUpdated, existing.ID
+		}
+		r.report.Items = append(r.report.Items, entry)
+		return
+	}
+
+	if existing == nil {
+		record, err := i.content.Create(ctx, interfaces.ContentCreateRequest{
+			ContentTypeID:            r.opts.ContentTypes[item.Type],
+			Slug:                     slug,
+			Status:                   mapStatus(item.Status),
+			CreatedBy:                actor,
+			UpdatedBy:                actor,
+			Translations:             []interfaces.ContentTranslationInput{translation},
+			Metadata:                 metadata,
+			AllowMissingTranslations: true,
+		})
+		if err != nil {
+			fail(fmt.Errorf("create content: %w", err))
+			return
+		}
+		entry.Action, entry.ContentID = ActionCreated, record.ID
+	} else {
+		record, err := i.content.Update(ctx, interfaces.ContentUpdateRequest{
+			ID:                       existing.ID,
+			Status:                   mapStatus(item.Status),
+			UpdatedBy:                actor,
+			Translations:             []interfaces.ContentTranslationInput{translation},
+			Metadata:                 metadata,
+			AllowMissingTranslations: true,
+		})
+		if err != nil {
+			fail(fmt.Errorf("update content: %w", err))
+			return
+		}
+		entry.Action, entry.ContentID = ActionUpdated, record.ID
+	}
+
+	if isPage {
+		pageID, err := i.applyPage(ctx, item, slug, entry.ContentID, actor, r)
+		if err != nil {
+			fail(err)
+			return
+		}
+		entry.PageID = pageID
+		r.pageIDs[item.ID] = pageID
+	}
+
+	r.report.Items = append(r.report.Items, entry)
+	i.saveProgress(ctx, r, key, ProgressEntry{Slug: slug, Path: r.pagePaths[item.ID], ContentID: entry.ContentID, PageID: entry.PageID})
+}
+
+// applyPage creates or updates the page for item beneath its parent page.
+func (i *Importer) applyPage(ctx context.Context, item Item, slug string, contentID, actor uuid.UUID, r *run) (uuid.UUID, error) {
+	var parentID *uuid.UUID
+	if item.ParentID != 0 {
+		id, ok := r.pageIDs[item.ParentID]
+		if !ok {
+			return uuid.Nil, fmt.Errorf("parent page %d was not imported", item.ParentID)
+		}
+		parentID = &id
+	}
+	translations := []interfaces.PageTranslationInput{{
+		Locale:  r.opts.Locale,
+		Title:   cmp.Or(item.Title, slug),
+		Path:    r.pagePaths[item.ID],
+		Summary: optionalString(item.Excerpt),
+	}}
+	metadata := map[string]any{
+		"source":       "wordpress",
+		"wordpress_id": item.ID,
+		"menu_order":   item.MenuOrder,
+	}
+
+	existing, err := i.pages.GetBySlug(ctx, slug, interfaces.PageReadOptions{EnvironmentKey: r.opts.EnvironmentKey})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("page lookup: %w", err)
+	}
+	if existing == nil {
+		record, err := i.pages.Create(ctx, interfaces.PageCreateRequest{
+			ContentID:                contentID,
+			TemplateID:               r.opts.TemplateID,
+			ParentID:                 parentID,
+			Slug:                     slug,
+			Status:                   mapStatus(item.Status),
+			CreatedBy:                actor,
+			UpdatedBy:                actor,
+			Translations:             translations,
+			Metadata:                 metadata,
+			AllowMissingTranslations: true,
+		})
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("create page: %w", err)
+		}
+		return record.ID, nil
+	}
+	if _, err := i.pages.Update(ctx, interfaces.PageUpdateRequest{
+		ID:                       existing.ID,
+		TemplateID:               &r.opts.TemplateID,
+		Status:                   mapStatus(item.Status),
+		UpdatedBy:                actor,
+		Translations:             translations,
+		Metadata:                 metadata,
+		AllowMissingTranslations: true,
+	}); err != nil {
+		return uuid.Nil, fmt.Errorf("update page: %w", err)
+	}
+	if !sameParent(existing.ParentID, parentID) {
+		if _, err := i.pages.Move(ctx, interfaces.PageMoveRequest{PageID: existing.ID, NewParentID: parentID, ActorID: actor}); err != nil {
+			return uuid.Nil, fmt.Errorf("move page: %w", err)
+		}
+	}
+	return existing.ID, nil
+}
+
+// contentFields builds the translation fields for a post: the converted body,
+// the excerpt, WordPress identifiers and media bindings for the featured image
+// and attached files.
+func (i *Importer) contentFields(item Item, r *run) map[string]any {
+	fields := map[string]any{
+		"body": i.shortcodes.Process(item.Content),
+		"wordpress": map[string]any{
+			"id":   item.ID,
+			"link": item.Link,
+			"guid": item.GUID,
+			"date": item.Date,
+			"type": item.Type,
+		},
+	}
+	if item.Excerpt != "" {
+		fields["excerpt"] = item.Excerpt
+	}
+
+	bindings := media.BindingSet{}
+	if ref, ok := r.attachments[atoi(item.Meta["_thumbnail_id"])]; ok {
+		bindings["featured_image"] = []media.Binding{{Slot: "featured_image", Reference: ref, Locale: r.opts.Locale}}
+	}
+	var attached []media.Binding
+	for _, id := range sortedKeys(r.attachments) {
+		if r.items[id].ParentID == item.ID {
+			attached = append(attached, media.Binding{Slot: "attachments", Reference: r.attachments[id], Locale: r.opts.Locale, Gallery: true, Position: len(attached)})
+		}
+	}
+	if len(attached) > 0 {
+		bindings["attachments"] = attached
+	}
+	if len(bindings) > 0 {
+		fields["media_bindings"] = bindings
+	}
+	return fields
+}
+
+// importMenus upserts one menu per nav_menu term and its items in menu order.
+// Parents are referenced by external code so the menu service can link them.
+func (i *Importer) importMenus(ctx context.Context, export *Export, r *run) {
+	grouped := map[string][]Item{}
+	for _, item := range export.Items {
+		if item.Type != "nav_menu_item" {
+			continue
+		}
+		for _, slug := range item.TermSlugs("nav_menu") {
+			grouped[slug] = append(grouped[slug], item)
+		}
+	}
+	names := map[string]string{}
+	for _, term := range export.Terms {
+		if term.Taxonomy == "nav_menu" {
+			names[term.Slug] = term.Name
+		}
+	}
+
+	for _, slug := range sortedKeys(grouped) {
+		items := grouped[slug]
+		slices.SortStableFunc(items, func(a, b Item) int { return cmp.Compare(a.MenuOrder, b.MenuOrder) })
+		code := menuCode(slug)
+		if r.opts.DryRun {
+			for _, item := range items {
+				r.report.Items = append(r.report.Items, ItemReport{WordPressID: item.ID, Type: item.Type, Slug: code, Action: ActionCreated})
+			}
+			r.report.Menus[code] = len(items)
+			continue
+		}
+		menu, err := i.menus.UpsertMenu(ctx, menus.UpsertMenuInput{
+			Code:           code,
+			Description:    optionalString(names[slug]),
+			Actor:          r.opts.DefaultAuthor,
+			EnvironmentKey: r.opts.EnvironmentKey,
+		})
+		if err != nil {
+			r.report.Errors = append(r.report.Errors, fmt.Errorf("wxr: menu %s: %w", code, err))
+			continue
+		}
+		for position, item := range items {
+			i.importMenuItem(ctx, menu, item, position, r)
+		}
+		r.report.Menus[code] = len(items)
+		if _, err := i.menus.ReconcileMenu(ctx, menus.ReconcileMenuRequest{MenuID: menu.ID, UpdatedBy: r.opts.DefaultAuthor}); err != nil {
+			r.report.Errors = append(r.report.Errors, fmt.Errorf("wxr: reconcile menu %s: %w", code, err))
+		}
+	}
+}
+
+func (i *Importer) importMenuItem(ctx context.Context, menu *menus.Menu, item Item, position int, r *run) {
+	key := progressKey("menu_item", item.ID)
+	entry := ItemReport{WordPressID: item.ID, Type: item.Type, Slug: menu.Code}
+	if done, ok := r.progress.Items[key]; ok {
+		entry.Action, entry.MenuItemID = ActionResumed, done.MenuItemID
+		r.report.Items = append(r.report.Items, entry)
+		return
+	}
+
+	target, label := r.menuTarget(item)
+	input := menus.UpsertMenuItemInput{
+		MenuID:         &menu.ID,
+		EnvironmentKey: r.opts.EnvironmentKey,
+		ExternalCode:   menuItemCode(item.ID),
+		Position:       &position,
+		Type:           menus.MenuItemTypeItem,
+		Target:         target,
+		Metadata:       map[string]any{"source": "wordpress", "wordpress_id": item.ID},
+		Actor:          r.actor(item.Creator),
+		Translations: []menus.MenuItemTranslationInput{{
+			Locale: r.opts.Locale,
+			Label:  label,
+		}},
+		AllowMissingTranslations: true,
+	}
+	if parent := atoi(item.Meta["_menu_item_menu_item_parent"]); parent != 0 {
+		input.ParentCode = menuItemCode(parent)
+	}
+	created, err := i.menus.UpsertMenuItem(ctx, input)
+	if err != nil {
+		entry.Action, entry.Error = ActionFailed, err.Error()
+		r.report.Items = append(r.report.Items, entry)
+		r.report.Errors = append(r.report.Errors, fmt.Errorf("wxr: menu item %d: %w", item.ID, err))
+		return
+	}
+	entry.Action, entry.MenuItemID = ActionCreated, created.ID
+	r.report.Items = append(r.report.Items, entry)
+	i.saveProgress(ctx, r, key, ProgressEntry{Slug: menu.Code, MenuItemID: created.ID})
+}
+
+// menuTarget maps a nav_menu_item onto a menu target and label. Items linking
+// to imported pages target the page by slug; other posts target content.
+func (r *run) menuTarget(item Item) (map[string]any, string) {
+	label := item.Title
+	switch item.Meta["_menu_item_type"] {
+	case "post_type":
+		linked, ok := r.items[atoi(item.Meta["_menu_item_object_id"])]
+		if !ok {
+			break
+		}
+		label = cmp.Or(label, linked.Title)
+		slug := itemSlug(linked)
+		if r.pageTypes[linked.Type] {
+			return map[string]any{"type": "page", "slug": slug}, label
+		}
+		return map[string]any{"type": "content", "slug": slug, "path": "/" + slug}, label
+	case "taxonomy":
+		taxonomy := item.Meta["_menu_item_object"]
+		return map[string]any{"type": "route", "path": "/" + taxonomy + "/" + item.Meta["_menu_item_object_id"]}, label
+	}
+	return map[string]any{"type": "external", "url": cmp.Or(item.Meta["_menu_item_url"], item.Link)}, label
+}
+
+func (i *Importer) saveProgress(ctx context.Context, r *run, key string, entry ProgressEntry) {
+	r.progress.Items[key] = entry
+	if i.progress == nil || r.opts.DryRun {
+		return
+	}
+	if err := i.progress.Save(ctx, r.progress); err != nil {
+		r.report.Errors = append(r.report.Errors, err)
+	}
+}
+
+func (r *run) pagePath(item Item, slug string) string {
+	if parent, ok := r.pagePaths[item.ParentID]; ok && item.ParentID != 0 {
+		return strings.TrimSuffix(parent, "/") + "/" + slug
+	}
+	return "/" + slug
+}
+
+// orderItems sorts items so parents precede their children, then by menu
+// order and ID, letting pages reference already imported parents.
+func orderItems(items []Item, byID map[int]Item) []Item {
+	depth := func(item Item) int {
+		d := 0
+		seen := map[int]bool{item.ID: true}
+		for parent := item.ParentID; parent != 0 && !seen[parent]; d++ {
+			seen[parent] = true
+			next, ok := byID[parent]
+			if !ok {
+				break
+			}
+			parent = next.ParentID
+		}
+		return d
+	}
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b Item) int {
+		return cmp.Or(
+			cmp.Compare(depth(a), depth(b)),
+			cmp.Compare(a.MenuOrder, b.MenuOrder),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return ordered
+}
+
+func attachmentReference(item Item) interfaces.MediaReference {
+	ref := interfaces.MediaReference{
+		ID:   "wp-" + strconv.Itoa(item.ID),
+		Path: item.AttachmentURL,
+		Attributes: map[string]string{
+			"wordpress_id": strconv.Itoa(item.ID),
+		},
+	}
+	if file := item.Meta["_wp_attached_file"]; file != "" {
+		ref.Attributes["file"] = file
+	}
+	if alt := item.Meta["_wp_attachment_image_alt"]; alt != "" {
+		ref.Attributes["alt"] = alt
+	}
+	return ref
+}
+
+func contentMetadata(item Item) map[string]any {
+	metadata := map[string]any{
+		"source":       "wordpress",
+		"wordpress_id": item.ID,
+		"author":       item.Creator,
+		"published_at": item.Date,
+		"link":         item.Link,
+	}
+	if categories := item.TermSlugs("category"); len(categories) > 0 {
+		metadata["categories"] = categories
+	}
+	if tags := item.TermSlugs("post_tag"); len(tags) > 0 {
+		metadata["tags"] = tags
+	}
+	return metadata
+}
+
+// mapStatus converts WordPress post statuses to CMS statuses. Scheduled
+// ("future") and private posts are imported as drafts.
+func mapStatus(status string) string {
+	if status == "publish" {
+		return "published"
+	}
+	return "draft"
+}
+
+func skippedStatus(status string) bool {
+	switch status {
+	case "trash", "auto-draft", "inherit":
+		return true
+	}
+	return false
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func itemSlug(item Item) string {
+	if slug := strings.TrimSpace(item.Slug); slug != "" {
+		return slug
+	}
+	if slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(item.Title), "-"), "-"); slug != "" {
+		return slug
+	}
+	return "wp-" + strconv.Itoa(item.ID)
+}
+
+func menuCode(slug string) string {
+	code := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(slug), "-"), "-")
+	return cmp.Or(code, "menu")
+}
+
+func menuItemCode(id int) string {
+	return "wp-" + strconv.Itoa(id)
+}
+
+func progressKey(kind string, id int) string {
+	return kind + ":" + strconv.Itoa(id)
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func optionalString(value string) *string {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return &value
+}
+
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,369 @@
+package wxr_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/goliatone/go-cms/internal/content"
+	"github.com/goliatone/go-cms/internal/media"
+	"github.com/goliatone/go-cms/internal/menus"
+	"github.com/goliatone/go-cms/internal/wxr"
+	"github.com/goliatone/go-cms/pkg/interfaces"
+)
+
+func TestParseReadsWXRExport(t *testing.T) {
+	export := loadExport(t)
+
+	if export.Title != "Example Blog" || export.BaseURL != "https://blog.example.com" {
+		t.Fatalf("unexpected channel: %+v", export)
+	}
+	if len(export.Authors) != 2 || export.Authors[1].Login != "editor" {
+		t.Fatalf("unexpected authors: %+v", export.Authors)
+	}
+	if len(export.Categories) != 1 || len(export.Tags) != 1 || len(export.Terms) != 1 {
+		t.Fatalf("unexpected terms: %+v %+v %+v", export.Categories, export.Tags, export.Terms)
+	}
+	if len(export.Items) != 8 {
+		t.Fatalf("expected 8 items, got %d", len(export.Items))
+	}
+
+	var post wxr.Item
+	for _, item := range export.Items {
+		if item.ID == 20 {
+			post = item
+		}
+	}
+	if post.Excerpt != "A first post" || !strings.Contains(post.Content, "[caption") {
+		t.Fatalf("expected content and excerpt to be split, got %+v", post)
+	}
+	if got := post.TermSlugs("post_tag"); len(got) != 1 || got[0] != "golang" {
+		t.Fatalf("unexpected tags: %v", got)
+	}
+	if post.Meta["_thumbnail_id"] != "30" {
+		t.Fatalf("expected postmeta, got %v", post.Meta)
+	}
+}
+
+func TestParseRejectsPlainRSS(t *testing.T) {
+	_, err := wxr.Parse(strings.NewReader(`<rss><channel><title>Feed</title></channel></rss>`))
+	if !errors.Is(err, wxr.ErrNotWXR) {
+		t.Fatalf("expected ErrNotWXR, got %v", err)
+	}
+}
+
+func TestImportMapsPostsPagesAndMenus(t *testing.T) {
+	ctx := context.Background()
+	contentSvc := newStubContentService()
+	pageSvc := newStubPageService()
+	menuSvc := newMenuService(t)
+	importer := wxr.NewImporter(wxr.Config{Content: contentSvc, Pages: pageSvc, Menus: menuSvc})
+
+	admin := uuid.New()
+	fallback := uuid.New()
+	report, err := importer.Import(ctx, loadExport(t), importOptions(admin, fallback))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if report.Count(wxr.ActionCreated) != 5 || report.Count(wxr.ActionSkipped) != 1 || report.UnmappedTypes["product"] != 1 {
+		t.Fatalf("unexpected report: %+v", report.Items)
+	}
+	if report.Authors["admin"] != admin || report.Authors["editor"] != fallback {
+		t.Fatalf("unexpected author mapping: %v", report.Authors)
+	}
+	if len(report.UnmappedAuthors) != 1 || report.UnmappedAuthors[0] != "editor" || report.Attachments != 1 {
+		t.Fatalf("unexpected report summary: %+v", report)
+	}
+
+	post := contentSvc.created["hello-world"]
+	if post.Status != "draft" || post.CreatedBy != fallback {
+		t.Fatalf("unexpected post request: status=%s actor=%s", post.Status, post.CreatedBy)
+	}
+	fields := post.Translations[0].Fields
+	if body, _ := fields["body"].(string); !strings.Contains(body, "{{< caption") {
+		t.Fatalf("expected shortcodes to be converted, got %q", body)
+	}
+	bindings, _ := fields["media_bindings"].(media.BindingSet)
+	if featured := bindings["featured_image"]; len(featured) != 1 || featured[0].Reference.Path != "https://blog.example.com/wp-content/uploads/hero.jpg" {
+		t.Fatalf("expected featured image binding, got %+v", bindings)
+	}
+	if featured := bindings["featured_image"][0].Reference; featured.Attributes["alt"] != "Hero image" {
+		t.Fatalf("expected attachment alt text, got %+v", featured)
+	}
+	if tags, _ := post.Metadata["tags"].([]string); len(tags) != 1 || tags[0] != "golang" {
+		t.Fatalf("expected tags in metadata, got %v", post.Metadata)
+	}
+
+	about, team := pageSvc.bySlug["about"], pageSvc.bySlug["team"]
+	if about == nil || team == nil || team.ParentID == nil || *team.ParentID != about.ID {
+		t.Fatalf("expected team nested under about, got %+v %+v", about, team)
+	}
+	if path := pageSvc.paths["team"]; path != "/about/team" {
+		t.Fatalf("expected nested page path, got %q", path)
+	}
+
+	nav, err := menuSvc.ResolveNavigation(ctx, "main-menu", "en")
+	if err != nil {
+		t.Fatalf("ResolveNavigation: %v", err)
+	}
+	if len(nav) != 1 || nav[0].Label != "About" || len(nav[0].Children) != 1 || nav[0].Children[0].Label != "GitHub" {
+		t.Fatalf("unexpected navigation: %+v", nav)
+	}
+	if report.Menus["main-menu"] != 2 {
+		t.Fatalf("expected menu counts, got %v", report.Menus)
+	}
+}
+
+func TestImportResumesFromProgress(t *testing.T) {
+	ctx := context.Background()
+	contentSvc := newStubContentService()
+	contentSvc.fail = map[string]error{"hello-world": errors.New("database unavailable")}
+	pageSvc := newStubPageService()
+	store := wxr.NewFileProgressStore(filepath.Join(t.TempDir(), "progress.json"))
+	cfg := wxr.Config{Content: contentSvc, Pages: pageSvc, Menus: newMenuService(t), Progress: store}
+	opts := importOptions(uuid.New(), uuid.New())
+
+	report, err := wxr.NewImporter(cfg).Import(ctx, loadExport(t), opts)
+	if err == nil || report.Count(wxr.ActionFailed) != 1 {
+		t.Fatalf("expected one failed item, got err=%v items=%+v", err, report.Items)
+	}
+
+	contentSvc.fail = nil
+	report, err = wxr.NewImporter(cfg).Import(ctx, loadExport(t), opts)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if report.Count(wxr.ActionResumed) != 4 || report.Count(wxr.ActionCreated) != 1 {
+		t.Fatalf("expected pages and menu items to resume, got %+v", report.Items)
+	}
+	if contentSvc.creates != 3 {
+		t.Fatalf("expected only the failed post to be created again, got %d creates", contentSvc.creates)
+	}
+}
+
+func TestImportDryRunWritesNothing(t *testing.T) {
+	contentSvc := newStubContentService()
+	pageSvc := newStubPageService()
+	opts := importOptions(uuid.New(), uuid.New())
+	opts.DryRun = true
+
+	report, err := wxr.NewImporter(wxr.Config{Content: contentSvc, Pages: pageSvc, Menus: newMenuService(t)}).Import(context.Background(), loadExport(t), opts)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if report.Count(wxr.ActionCreated) != 5 || contentSvc.creates != 0 || len(pageSvc.bySlug) != 0 {
+		t.Fatalf("expected dry run to only report, got %+v", report.Items)
+	}
+}
+
+func TestImportScopesSlugLookupsByContentType(t *testing.T) {
+	contentSvc := newStubContentService()
+	opts := importOptions(uuid.New(), uuid.New())
+	pageRecord := &interfaces.ContentRecord{ID: uuid.New(), ContentType: opts.ContentTypes["page"], Slug: "hello-world"}
+	contentSvc.records["hello-world"] = pageRecord
+
+	report, err := wxr.NewImporter(wxr.Config{Content: contentSvc, Pages: newStubPageService(), Menus: newMenuService(t)}).Import(context.Background(), loadExport(t), opts)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if _, ok := contentSvc.created["hello-world"]; !ok || report.Count(wxr.ActionUpdated) != 0 {
+		t.Fatalf("expected the post to be created beside the page with the same slug, got %+v", report.Items)
+	}
+}
+
+func TestImportFailsItemsOnLookupErrors(t *testing.T) {
+	contentSvc := newStubContentService()
+	contentSvc.lookupErr = errors.New("database unavailable")
+
+	report, err := wxr.NewImporter(wxr.Config{Content: contentSvc, Pages: newStubPageService(), Menus: newMenuService(t)}).Import(context.Background(), loadExport(t), importOptions(uuid.New(), uuid.New()))
+	if err == nil || !errors.Is(err, contentSvc.lookupErr) {
+		t.Fatalf("expected lookup error to be reported, got %v", err)
+	}
+	if contentSvc.creates != 0 || report.Count(wxr.ActionFailed) != 3 {
+		t.Fatalf("expected posts and pages to fail without creating content, got %d creates %+v", contentSvc.creates, report.Items)
+	}
+}
+
+func loadExport(t *testing.T) *wxr.Export {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", "export.xml"))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+	export, err := wxr.Parse(file)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return export
+}
+
+func importOptions(admin, fallback uuid.UUID) wxr.Options {
+	return wxr.Options{
+		ContentTypes:  map[string]uuid.UUID{"post": uuid.New(), "page": uuid.New()},
+		TemplateID:    uuid.New(),
+		Locale:        "en",
+		Authors:       map[string]uuid.UUID{"admin": admin},
+		DefaultAuthor: fallback,
+	}
+}
+
+func newMenuService(t *testing.T) menus.Service {
+	t.Helper()
+	localeRepo := content.NewMemoryLocaleRepository()
+	localeRepo.Put(&content.Locale{ID: uuid.New(), Code: "en", Display: "English", IsActive: true, IsDefault: true})
+	return menus.NewService(
+		menus.NewMemoryMenuRepository(),
+		menus.NewMemoryMenuItemRepository(),
+		menus.NewMemoryMenuItemTranslationRepository(),
+		localeRepo,
+	)
+}
+
+// Stub implementations -------------------------------------------------------
+
+type stubContentService struct {
+	records   map[string]*interfaces.ContentRecord
+	created   map[string]interfaces.ContentCreateRequest
+	fail      map[string]error
+	lookupErr error
+	creates   int
+}
+
+func newStubContentService() *stubContentService {
+	return &stubContentService{
+		records: map[string]*interfaces.ContentRecord{},
+		created: map[string]interfaces.ContentCreateRequest{},
+	}
+}
+
+func (s *stubContentService) Create(_ context.Context, req interfaces.ContentCreateRequest) (*interfaces.ContentRecord, error) {
+	if err := s.fail[req.Slug]; err != nil {
+		return nil, err
+	}
+	s.creates++
+	record := &interfaces.ContentRecord{ID: uuid.New(), ContentType: req.ContentTypeID, Slug: req.Slug, Status: req.Status, Metadata: req.Metadata}
+	s.records[req.Slug] = record
+	s.created[req.Slug] = req
+	return record, nil
+}
+
+func (s *stubContentService) Update(_ context.Context, req interfaces.ContentUpdateRequest) (*interfaces.ContentRecord, error) {
+	for _, record := range s.records {
+		if record.ID == req.ID {
+			record.Status = req.Status
+			return record, nil
+		}
+	}
+	return nil, errors.New("content not found")
+}
+
+func (s *stubContentService) GetBySlug(_ context.Context, slug string, opts interfaces.ContentReadOptions) (*interfaces.ContentRecord, error) {
+	if s.lookupErr != nil {
+		return nil, s.lookupErr
+	}
+	record := s.records[slug]
+	if record != nil && opts.ContentTypeID != uuid.Nil && record.ContentType != opts.ContentTypeID {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (s *stubContentService) List(context.Context, interfaces.ContentReadOptions) ([]*interfaces.ContentRecord, error) {
+	return nil, nil
+}
+
+func (s *stubContentService) CheckTranslations(context.Context, uuid.UUID, []string, interfaces.TranslationCheckOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubContentService) AvailableLocales(context.Context, uuid.UUID, interfaces.TranslationCheckOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubContentService) Delete(context.Context, interfaces.ContentDeleteRequest) error {
+	return nil
+}
+
+func (s *stubContentService) UpdateTranslation(context.Context, interfaces.ContentUpdateTranslationRequest) (*interfaces.ContentTranslation, error) {
+	return nil, nil
+}
+
+func (s *stubContentService) DeleteTranslation(context.Context, interfaces.ContentDeleteTranslationRequest) error {
+	return nil
+}
+
+type stubPageService struct {
+	bySlug map[string]*interfaces.PageRecord
+	paths  map[string]string
+}
+
+func newStubPageService() *stubPageService {
+	return &stubPageService{bySlug: map[string]*interfaces.PageRecord{}, paths: map[string]string{}}
+}
+
+func (s *stubPageService) Create(_ context.Context, req interfaces.PageCreateRequest) (*interfaces.PageRecord, error) {
+	record := &interfaces.PageRecord{ID: uuid.New(), ContentID: req.ContentID, TemplateID: req.TemplateID, ParentID: req.ParentID, Slug: req.Slug, Status: req.Status}
+	s.bySlug[req.Slug] = record
+	s.paths[req.Slug] = req.Translations[0].Path
+	return record, nil
+}
+
+func (s *stubPageService) Update(_ context.Context, req interfaces.PageUpdateRequest) (*interfaces.PageRecord, error) {
+	for slug, record := range s.bySlug {
+		if record.ID == req.ID {
+			record.Status = req.Status
+			s.paths[slug] = req.Translations[0].Path
+			return record, nil
+		}
+	}
+	return nil, errors.New("page not found")
+}
+
+func (s *stubPageService) GetBySlug(_ context.Context, slug string, _ interfaces.PageReadOptions) (*interfaces.PageRecord, error) {
+	return s.bySlug[slug], nil
+}
+
+func (s *stubPageService) List(context.Context, interfaces.PageReadOptions) ([]*interfaces.PageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubPageService) CheckTranslations(context.Context, uuid.UUID, []string, interfaces.TranslationCheckOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubPageService) AvailableLocales(context.Context, uuid.UUID, interfaces.TranslationCheckOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubPageService) Delete(context.Context, interfaces.PageDeleteRequest) error {
+	return nil
+}
+
+func (s *stubPageService) UpdateTranslation(context.Context, interfaces.PageUpdateTranslationRequest) (*interfaces.PageTranslation, error) {
+	return nil, nil
+}
+
+func (s *stubPageService) DeleteTranslation(context.Context, interfaces.PageDeleteTranslationRequest) error {
+	return nil
+}
+
+func (s *stubPageService) Move(_ context.Context, req interfaces.PageMoveRequest) (*interfaces.PageRecord, error) {
+	for _, record := range s.bySlug {
+		if record.ID == req.PageID {
+			record.ParentID = req.NewParentID
+			return record, nil
+		}
+	}
+	return nil, errors.New("page not found")
+}
+
+func (s *stubPageService) Duplicate(context.Context, interfaces.PageDuplicateRequest) (*interfaces.PageRecord, error) {
+	return nil, nil
+}
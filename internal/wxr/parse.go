@@ -0,0 +1,275 @@
+package wxr
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrNotWXR reports input that is not a WordPress export.
+var ErrNotWXR = errors.New("wxr: input is not a WordPress export")
+
+// Export is the parsed content of a WXR file.
+type Export struct {
+	Title      string
+	Link       string
+	BaseURL    string
+	Language   string
+	Authors    []Author
+	Categories []Term
+	Tags       []Term
+	Terms      []Term
+	Items      []Item
+}
+
+// Author is a WordPress user referenced by exported items.
+type Author struct {
+	ID          int
+	Login       string
+	Email       string
+	DisplayName string
+}
+
+// Term is a category, tag or generic taxonomy term such as a nav_menu.
+type Term struct {
+	ID       int
+	Taxonomy string
+	Slug     string
+	Name     string
+	Parent   string
+}
+
+// ItemTerm links an item to a term; Domain is the taxonomy.
+type ItemTerm struct {
+	Domain string
+	Slug   string
+	Name   string
+}
+
+// Item is a single exported post of any type (post, page, attachment,
+// nav_menu_item or a custom post type). Meta holds wp:postmeta values.
+type Item struct {
+	ID            int
+	Title         string
+	Link          string
+	GUID          string
+	Creator       string
+	Content       string
+	Excerpt       string
+	Date          string
+	Slug          string
+	Type          string
+	Status        string
+	ParentID      int
+	MenuOrder     int
+	AttachmentURL string
+	Terms         []ItemTerm
+	Meta          map[string]string
+}
+
+// TermSlugs returns the slugs of the item's terms in the given taxonomy.
+func (i Item) TermSlugs(domain string) []string {
+	var out []string
+	for _, term := range i.Terms {
+		if term.Domain == domain && term.Slug != "" {
+			out = append(out, term.Slug)
+		}
+	}
+	return out
+}
+
+type rawRSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Channel rawChannel `xml:"channel"`
+}
+
+type rawChannel struct {
+	Title      string        `xml:"title"`
+	Link       string        `xml:"link"`
+	Language   string        `xml:"language"`
+	Version    string        `xml:"wxr_version"`
+	BaseURL    string        `xml:"base_blog_url"`
+	Authors    []rawAuthor   `xml:"author"`
+	Categories []rawCategory `xml:"category"`
+	Tags       []rawTag      `xml:"tag"`
+	Terms      []rawTerm     `xml:"term"`
+	Items      []rawItem     `xml:"item"`
+}
+
+type rawAuthor struct {
+	ID          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type rawCategory struct {
+	ID       string `xml:"term_id"`
+	Nicename string `xml:"category_nicename"`
+	Parent   string `xml:"category_parent"`
+	Name     string `xml:"cat_name"`
+}
+
+type rawTag struct {
+	ID   string `xml:"term_id"`
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+type rawTerm struct {
+	ID       string `xml:"term_id"`
+	Taxonomy string `xml:"term_taxonomy"`
+	Slug     string `xml:"term_slug"`
+	Parent   string `xml:"term_parent"`
+	Name     string `xml:"term_name"`
+}
+
+type rawItem struct {
+	Title         string        `xml:"title"`
+	Link          string        `xml:"link"`
+	GUID          string        `xml:"guid"`
+	Creator       string        `xml:"creator"`
+	Encoded       []rawEncoded  `xml:"encoded"`
+	ID            string        `xml:"post_id"`
+	Date          string        `xml:"post_date_gmt"`
+	LocalDate     string        `xml:"post_date"`
+	Name          string        `xml:"post_name"`
+	Status        string        `xml:"status"`
+	Parent        string        `xml:"post_parent"`
+	MenuOrder     string        `xml:"menu_order"`
+	Type          string        `xml:"post_type"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Categories    []rawItemTerm `xml:"category"`
+	Meta          []rawMeta     `xml:"postmeta"`
+}
+
+// rawEncoded captures content:encoded and excerpt:encoded, which share a local
+// name and differ only by namespace.
+type rawEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type rawItemTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type rawMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// Parse decodes a WXR document.
+func Parse(r io.Reader) (*Export, error) {
+	var doc rawRSS
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("wxr: decode: %w", err)
+	}
+	channel := doc.Channel
+	if strings.TrimSpace(channel.Version) == "" {
+		return nil, ErrNotWXR
+	}
+
+	export := &Export{
+		Title:    strings.TrimSpace(channel.Title),
+		Link:     strings.TrimSpace(channel.Link),
+		BaseURL:  strings.TrimSpace(channel.BaseURL),
+		Language: strings.TrimSpace(channel.Language),
+	}
+	for _, author := range channel.Authors {
+		export.Authors = append(export.Authors, Author{
+			ID:          atoi(author.ID),
+			Login:       strings.TrimSpace(author.Login),
+			Email:       strings.TrimSpace(author.Email),
+			DisplayName: strings.TrimSpace(author.DisplayName),
+		})
+	}
+	for _, category := range channel.Categories {
+		export.Categories = append(export.Categories, Term{
+			ID:       atoi(category.ID),
+			Taxonomy: "category",
+			Slug:     strings.TrimSpace(category.Nicename),
+			Name:     strings.TrimSpace(category.Name),
+			Parent:   strings.TrimSpace(category.Parent),
+		})
+	}
+	for _, tag := range channel.Tags {
+		export.Tags = append(export.Tags, Term{
+			ID:       atoi(tag.ID),
+			Taxonomy: "post_tag",
+			Slug:     strings.TrimSpace(tag.Slug),
+			Name:     strings.TrimSpace(tag.Name),
+		})
+	}
+	for _, term := range channel.Terms {
+		export.Terms = append(export.Terms, Term{
+			ID:       atoi(term.ID),
+			Taxonomy: strings.TrimSpace(term.Taxonomy),
+			Slug:     strings.TrimSpace(term.Slug),
+			Name:     strings.TrimSpace(term.Name),
+			Parent:   strings.TrimSpace(term.Parent),
+		})
+	}
+	for _, raw := range channel.Items {
+		export.Items = append(export.Items, convertItem(raw))
+	}
+	return export, nil
+}
+
+func convertItem(raw rawItem) Item {
+	item := Item{
+		ID:            atoi(raw.ID),
+		Title:         strings.TrimSpace(raw.Title),
+		Link:          strings.TrimSpace(raw.Link),
+		GUID:          strings.TrimSpace(raw.GUID),
+		Creator:       strings.TrimSpace(raw.Creator),
+		Date:          strings.TrimSpace(raw.Date),
+		Slug:          strings.TrimSpace(raw.Name),
+		Type:          strings.TrimSpace(raw.Type),
+		Status:        strings.TrimSpace(raw.Status),
+		ParentID:      atoi(raw.Parent),
+		MenuOrder:     atoi(raw.MenuOrder),
+		AttachmentURL: strings.TrimSpace(raw.AttachmentURL),
+		Meta:          map[string]string{},
+	}
+	if item.Date == "" || strings.HasPrefix(item.Date, "0000") {
+		item.Date = strings.TrimSpace(raw.LocalDate)
+	}
+	for _, encoded := range raw.Encoded {
+		switch {
+		case strings.Contains(encoded.XMLName.Space, "excerpt"):
+			item.Excerpt = strings.TrimSpace(encoded.Value)
+		default:
+			item.Content = encoded.Value
+		}
+	}
+	for _, term := range raw.Categories {
+		item.Terms = append(item.Terms, ItemTerm{
+			Domain: strings.TrimSpace(term.Domain),
+			Slug:   strings.TrimSpace(term.Nicename),
+			Name:   strings.TrimSpace(term.Name),
+		})
+	}
+	for _, meta := range raw.Meta {
+		if key := strings.TrimSpace(meta.Key); key != "" {
+			item.Meta[key] = meta.Value
+		}
+	}
+	return item
+}
+
+func atoi(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+package wxr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Progress records what an import has already written so an interrupted run
+// can resume. Items are keyed by "post:<id>" and "menu_item:<id>".
+type Progress struct {
+	Items map[string]ProgressEntry `json:"items"`
+}
+
+// ProgressEntry holds the CMS identifiers created for a WordPress item.
+type ProgressEntry struct {
+	Slug       string    `json:"slug,omitempty"`
+	Path       string    `json:"path,omitempty"`
+	ContentID  uuid.UUID `json:"content_id,omitzero"`
+	PageID     uuid.UUID `json:"page_id,omitzero"`
+	MenuItemID uuid.UUID `json:"menu_item_id,omitzero"`
+}
+
+// ProgressStore persists import progress between runs.
+type ProgressStore interface {
+	Load(ctx context.Context) (*Progress, error)
+	Save(ctx context.Context, progress *Progress) error
+}
+
+// NewFileProgressStore stores progress as JSON at path. A missing file is an
+// empty progress; saves write a temporary file and rename it into place.
+func NewFileProgressStore(path string) ProgressStore {
+	return &fileProgressStore{path: path}
+}
+
+type fileProgressStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (s *fileProgressStore) Load(context.Context) (*Progress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return newProgress(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("wxr: read progress: %w", err)
+	}
+	progress := newProgress()
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, fmt.Errorf("wxr: decode progress %s: %w", s.path, err)
+	}
+	if progress.Items == nil {
+		progress.Items = map[string]ProgressEntry{}
+	}
+	return progress, nil
+}
+
+func (s *fileProgressStore) Save(_ context.Context, progress *Progress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return fmt.Errorf("wxr: encode progress: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("wxr: create progress dir: %w", err)
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("wxr: write progress: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("wxr: write progress: %w", err)
+	}
+	return nil
+}
+
+func newProgress() *Progress {
+	return &Progress{Items: map[string]ProgressEntry{}}
+}
@@ -6,11 +6,36 @@ import (
 )
 
 type (
-	Config        = internal.Config
-	Service       = internal.Service
-	ServiceOption = internal.ServiceOption
+	Config             = internal.Config
+	Service            = internal.Service
+	ServiceOption      = internal.ServiceOption
+	FieldMapping       = internal.FieldMapping
+	BlockSplit         = internal.BlockSplit
+	DocumentError      = internal.DocumentError
+	ContentTypeSchemas = internal.ContentTypeSchemas
+	BlockValidator     = internal.BlockValidator
+	PageBuilder        = internal.PageBuilder
+	WatchOptions       = internal.WatchOptions
+	WatchReport        = internal.WatchReport
+	FileChange         = internal.FileChange
+	MediaUploader      = internal.MediaUploader
+	MediaUpload        = internal.MediaUpload
 )
 
+const (
+	BlockSplitHeadings   = internal.BlockSplitHeadings
+	BlockSplitDirectives = internal.BlockSplitDirectives
+
+	FileAdded    = internal.FileAdded
+	FileModified = internal.FileModified
+	FileDeleted  = internal.FileDeleted
+
+	AssetLinkURL       = internal.AssetLinkURL
+	AssetLinkReference = internal.AssetLinkReference
+)
+
+var ErrAssetMissing = internal.ErrAssetMissing
+
 func NewService(cfg Config, parser interfaces.MarkdownParser, opts ...ServiceOption) (*Service, error) {
 	return internal.NewService(cfg, parser, opts...)
 }
@@ -30,3 +55,15 @@ func WithLogger(logger interfaces.Logger) ServiceOption {
 func WithShortcodeService(svc interfaces.ShortcodeService) ServiceOption {
 	return internal.WithShortcodeService(svc)
 }
+
+func WithContentTypeSchemas(schemas ContentTypeSchemas) ServiceOption {
+	return internal.WithContentTypeSchemas(schemas)
+}
+
+func WithBlockValidator(validator BlockValidator) ServiceOption {
+	return internal.WithBlockValidator(validator)
+}
+
+func WithMediaUploader(uploader MediaUploader) ServiceOption {
+	return internal.WithMediaUploader(uploader)
+}
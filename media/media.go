@@ -5,6 +5,7 @@ import (
 
 	internalmedia "github.com/goliatone/go-cms/internal/media"
 	"github.com/goliatone/go-cms/pkg/interfaces"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
 )
 
 // Re-exported errors from the internal media package.
@@ -65,6 +66,11 @@ func WithDefaultCacheTTL(ttl time.Duration) ServiceOption {
 	return internalmedia.WithDefaultCacheTTL(ttl)
 }
 
+// WithLifecycleEmitter wires the emitter notified with a "media" event for each reference passed to Invalidate.
+func WithLifecycleEmitter(emitter *lifecycle.Emitter) ServiceOption {
+	return internalmedia.WithLifecycleEmitter(emitter)
+}
+
 // NewService constructs a media helper service that delegates to the configured provider.
 func NewService(provider interfaces.MediaProvider, opts ...ServiceOption) Service {
 	return internalmedia.NewService(provider, opts...)
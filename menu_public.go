@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/goliatone/go-cms/domain"
 	"github.com/goliatone/go-cms/internal/menus"
 	"github.com/google/uuid"
 )
@@ -151,6 +152,8 @@ type MenuService interface {
 	UpsertMenuItemByPath(ctx context.Context, input UpsertMenuItemByPathInput) (*MenuItemInfo, error)
 	UpdateMenuItemByPath(ctx context.Context, menuCode string, path string, input UpdateMenuItemByPathInput) (*MenuItemInfo, error)
 	DeleteMenuItemByPath(ctx context.Context, menuCode string, path string, actor uuid.UUID, cascadeChildren bool) error
+	ListTrashedMenuItemsByCode(ctx context.Context, menuCode string) ([]*MenuItemInfo, error)
+	RestoreMenuItemByPath(ctx context.Context, menuCode string, path string, actor uuid.UUID, onConflict domain.RestoreConflict) (*MenuItemInfo, error)
 	UpsertMenuItemTranslationByPath(ctx context.Context, menuCode string, path string, input MenuItemTranslationInput) error
 
 	MoveMenuItemToTop(ctx context.Context, menuCode string, path string, actor uuid.UUID) error
@@ -766,6 +769,73 @@ func (s *menuService) DeleteMenuItemByPath(ctx context.Context, menuCode string,
 	})
 }
 
+func (s *menuService) ListTrashedMenuItemsByCode(ctx context.Context, menuCode string) ([]*MenuItemInfo, error) {
+	if s == nil || s.module == nil || s.module.container == nil || s.svc == nil {
+		return nil, errNilModule
+	}
+	menuCode = CanonicalMenuCode(menuCode)
+	if menuCode == "" {
+		return nil, ErrMenuCodeRequired
+	}
+
+	menu, err := s.svc.GetMenuByCode(ctx, menuCode)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.svc.ListTrashedMenuItems(ctx, menus.ListTrashedMenuItemsRequest{MenuID: menu.ID})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*MenuItemInfo, 0, len(items))
+	for _, item := range items {
+		out = append(out, toPublicMenuItemInfo(item))
+	}
+	return out, nil
+}
+
+func (s *menuService) RestoreMenuItemByPath(ctx context.Context, menuCode string, path string, actor uuid.UUID, onConflict domain.RestoreConflict) (*MenuItemInfo, error) {
+	if s == nil || s.module == nil || s.module.container == nil || s.svc == nil {
+		return nil, errNilModule
+	}
+	menuCode = CanonicalMenuCode(menuCode)
+	if menuCode == "" {
+		return nil, ErrMenuCodeRequired
+	}
+
+	canonicalPath, err := CanonicalMenuItemPath(menuCode, path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := ParseMenuItemPathForMenu(menuCode, canonicalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := s.svc.GetMenuByCode(ctx, menuCode)
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := s.svc.ListTrashedMenuItems(ctx, menus.ListTrashedMenuItemsRequest{MenuID: menu.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range trashed {
+		if item == nil || item.ExternalCode != parsed.Path {
+			continue
+		}
+		restored, err := s.svc.RestoreDeletedMenuItem(ctx, menus.RestoreMenuItemRequest{
+			ItemID:     item.ID,
+			RestoredBy: actor,
+			OnConflict: onConflict,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return toPublicMenuItemInfo(restored), nil
+	}
+	return nil, menus.ErrMenuItemNotTrashed
+}
+
 func (s *menuService) UpsertMenuItemTranslationByPath(ctx context.Context, menuCode string, path string, input MenuItemTranslationInput) error {
 	if s == nil || s.module == nil || s.module.container == nil || s.svc == nil {
 		return errNilModule
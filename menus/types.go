@@ -30,6 +30,7 @@ type Menu struct {
 	FamilyID      *uuid.UUID  `bun:"family_id,type:uuid,nullzero" json:"family_id,omitempty"`
 	PublishedAt   *time.Time  `bun:"published_at,nullzero" json:"published_at,omitempty"`
 	EnvironmentID uuid.UUID   `bun:"environment_id,type:uuid" json:"environment_id,omitempty"`
+	SiteID        *uuid.UUID  `bun:"site_id,type:uuid" json:"site_id,omitempty"`
 	CreatedBy     uuid.UUID   `bun:"created_by,notnull,type:uuid" json:"created_by"`
 	UpdatedBy     uuid.UUID   `bun:"updated_by,notnull,type:uuid" json:"updated_by"`
 	CreatedAt     time.Time   `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
@@ -97,6 +97,44 @@ func TestMigrationRegistrationSQLiteApplyRollbackReapply(t *testing.T) {
 	}
 }
 
+func TestMigrationSQLiteMenuCodesUniquePerSite(t *testing.T) {
+	t.Parallel()
+
+	client, db := newSQLiteMigrationClient(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	registerCMSDialectMigrations(t, client)
+	if err := client.Migrate(ctx); err != nil {
+		t.Fatalf("migrate sqlite: %v", err)
+	}
+
+	const actor = "00000000-0000-0000-0000-0000000000aa"
+	insert := func(id, siteID any) error {
+		_, err := db.Exec(
+			`INSERT INTO menus (id, code, site_id, created_by, updated_by) VALUES (?, 'primary', ?, ?, ?)`,
+			id, siteID, actor, actor,
+		)
+		return err
+	}
+
+	if err := insert("00000000-0000-0000-0000-000000000101", nil); err != nil {
+		t.Fatalf("insert shared menu: %v", err)
+	}
+	if err := insert("00000000-0000-0000-0000-000000000102", "00000000-0000-0000-0000-0000000000b1"); err != nil {
+		t.Fatalf("insert blog menu: %v", err)
+	}
+	if err := insert("00000000-0000-0000-0000-000000000103", "00000000-0000-0000-0000-0000000000b2"); err != nil {
+		t.Fatalf("insert shop menu: %v", err)
+	}
+	if err := insert("00000000-0000-0000-0000-000000000104", nil); err == nil {
+		t.Fatalf("expected duplicate shared menu code to be rejected")
+	}
+	if err := insert("00000000-0000-0000-0000-000000000105", "00000000-0000-0000-0000-0000000000b1"); err == nil {
+		t.Fatalf("expected duplicate menu code within a site to be rejected")
+	}
+}
+
 func TestMigrationRegistrationPostgresApplyRollbackReapply(t *testing.T) {
 	dsn := strings.TrimSpace(os.Getenv("GO_CMS_TEST_POSTGRES_DSN"))
 	if dsn == "" {
@@ -37,6 +37,7 @@ var (
 	ErrScheduleTimestampInvalid      = errors.New("pages: schedule timestamp is invalid")
 	ErrPageMediaReferenceRequired    = errors.New("pages: media reference requires id or path")
 	ErrPageSoftDeleteUnsupported     = errors.New("pages: soft delete not supported")
+	ErrPageNotTrashed                = errors.New("pages: page not found in trash")
 	ErrPageTranslationsDisabled      = errors.New("pages: translations feature disabled")
 	ErrPageTranslationNotFound       = errors.New("pages: translation not found")
 	ErrPageParentCycle               = errors.New("pages: parent assignment creates hierarchy cycle")
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/goliatone/go-cms/content"
+	"github.com/goliatone/go-cms/domain"
 	"github.com/goliatone/go-cms/media"
 	"github.com/goliatone/go-cms/pkg/interfaces"
 	"github.com/google/uuid"
@@ -22,6 +23,8 @@ type Service interface {
 	AvailableLocales(ctx context.Context, id uuid.UUID, opts TranslationCheckOptions) ([]string, error)
 	Update(ctx context.Context, req UpdatePageRequest) (*Page, error)
 	Delete(ctx context.Context, req DeletePageRequest) error
+	ListTrashed(ctx context.Context, req ListTrashedPagesRequest) ([]*Page, error)
+	RestoreDeleted(ctx context.Context, req RestorePageRequest) (*Page, error)
 	UpdateTranslation(ctx context.Context, req UpdatePageTranslationRequest) (*PageTranslation, error)
 	DeleteTranslation(ctx context.Context, req DeletePageTranslationRequest) error
 	Move(ctx context.Context, req MovePageRequest) (*Page, error)
@@ -42,6 +45,7 @@ type CreatePageRequest struct {
 	Slug                     string
 	Status                   string
 	EnvironmentKey           string
+	SiteID                   *uuid.UUID
 	CreatedBy                uuid.UUID
 	UpdatedBy                uuid.UUID
 	Translations             []PageTranslationInput
@@ -75,6 +79,24 @@ type DeletePageRequest struct {
 	HardDelete bool
 }
 
+// ListTrashedPagesRequest pages through the trashed page subtrees of an
+// environment. An empty Environment selects the default environment and a zero
+// Limit returns every remaining subtree.
+type ListTrashedPagesRequest struct {
+	Environment string
+	Limit       int
+	Offset      int
+}
+
+// RestorePageRequest brings a soft-deleted page back from the trash together
+// with its descendants, translations and block instances. OnConflict selects
+// how slugs and paths taken since the delete are handled.
+type RestorePageRequest struct {
+	ID         uuid.UUID
+	RestoredBy uuid.UUID
+	OnConflict domain.RestoreConflict
+}
+
 // UpdatePageTranslationRequest mutates a specific translation for a page.
 type UpdatePageTranslationRequest struct {
 	PageID        uuid.UUID
@@ -18,6 +18,7 @@ type (
 	LocaleLookup      = internal.LocaleLookup
 	AssetResolver     = internal.AssetResolver
 	NoOpAssetResolver = internal.NoOpAssetResolver
+	CollectionConfig  = internal.CollectionConfig
 )
 
 var (
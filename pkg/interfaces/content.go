@@ -26,6 +26,15 @@ type ContentTranslationCreator interface {
 	CreateTranslation(ctx context.Context, req ContentCreateTranslationRequest) (*ContentRecord, error)
 }
 
+// ContentTranslationFieldsUpdater is an additive capability interface for
+// bookkeeping writes, such as markdown export checksums, that replace the
+// stored fields of one translation without validation, a new content version
+// or lifecycle events. Implementations return errors.ErrUnsupported when their
+// storage cannot write translations in place.
+type ContentTranslationFieldsUpdater interface {
+	UpdateTranslationFields(ctx context.Context, req ContentUpdateTranslationFieldsRequest) error
+}
+
 // ContentReadOptions defines read-time locale resolution and metadata behaviour.
 //
 // Behavior contract:
@@ -42,6 +51,9 @@ type ContentReadOptions struct {
 	AllowMissingTranslations bool
 	IncludeAvailableLocales  bool
 	EnvironmentKey           string
+	// ContentTypeID restricts slug lookups to one content type when set, since
+	// slugs are only unique per content type.
+	ContentTypeID uuid.UUID
 }
 
 // ContentCreateRequest captures the details required to create a content record.
@@ -98,6 +110,14 @@ type ContentUpdateTranslationRequest struct {
 	UpdatedBy uuid.UUID
 }
 
+// ContentUpdateTranslationFieldsRequest replaces the stored fields of a single
+// locale entry.
+type ContentUpdateTranslationFieldsRequest struct {
+	ContentID uuid.UUID
+	Locale    string
+	Fields    map[string]any
+}
+
 // ContentDeleteTranslationRequest removes a locale entry.
 type ContentDeleteTranslationRequest struct {
 	ContentID uuid.UUID
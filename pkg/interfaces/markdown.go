@@ -40,6 +40,8 @@ type MarkdownService interface {
 	Import(ctx context.Context, doc *Document, opts ImportOptions) (*ImportResult, error)
 	ImportDirectory(ctx context.Context, dir string, opts ImportOptions) (*ImportResult, error)
 	Sync(ctx context.Context, dir string, opts SyncOptions) (*SyncResult, error)
+	Export(ctx context.Context, slug string, dir string, opts ExportOptions) (*ExportResult, error)
+	ExportDirectory(ctx context.Context, dir string, opts ExportOptions) (*ExportResult, error)
 }
 
 // Document represents a Markdown file with parsed metadata and content. The
@@ -55,6 +57,9 @@ type Document struct {
 	// Checksum stores a digest of the original file content (typically SHA-256)
 	// so sync workflows can detect changes without re-importing unchanged files.
 	Checksum []byte
+	// BodyLine is the 1-based line in the source file where Body starts, used
+	// to report mapping errors against the original file.
+	BodyLine int
 }
 
 // FrontMatter models metadata extracted from Markdown files. Fields align with
@@ -72,6 +77,9 @@ type FrontMatter struct {
 	Draft    bool           `yaml:"draft" json:"draft"`
 	Custom   map[string]any `yaml:",inline" json:"custom"`
 	Raw      map[string]any `yaml:"-" json:"raw"`
+	// Lines records the source line of each frontmatter key, using dot paths
+	// for nested keys.
+	Lines map[string]int `yaml:"-" json:"-"`
 }
 
 // LoadOptions fine-tunes how documents are discovered and parsed from disk.
@@ -84,6 +92,8 @@ type LoadOptions struct {
 
 // ImportOptions controls how Markdown documents are converted into CMS content.
 // UUID fields reference existing CMS entities (content types, authors, etc.).
+// CreatePages additionally creates a page per document, nesting pages by
+// directory; TemplateID is used when a document does not name a template.
 type ImportOptions struct {
 	ContentTypeID                   uuid.UUID
 	AuthorID                        uuid.UUID
@@ -91,6 +101,8 @@ type ImportOptions struct {
 	EnvironmentKey                  string
 	ContentAllowMissingTranslations bool
 	ProcessShortcodes               bool
+	CreatePages                     bool
+	TemplateID                      uuid.UUID
 }
 
 // SyncOptions extends ImportOptions to handle update/delete semantics for
@@ -107,14 +119,37 @@ type ImportResult struct {
 	CreatedContentIDs []uuid.UUID
 	UpdatedContentIDs []uuid.UUID
 	SkippedContentIDs []uuid.UUID
+	CreatedPageIDs    []uuid.UUID
+	UpdatedPageIDs    []uuid.UUID
 	Errors            []error
 }
 
 // SyncResult summarises a bulk sync run across many files.
 type SyncResult struct {
-	Created int
-	Updated int
-	Deleted int
-	Skipped int
-	Errors  []error
+	Created      int
+	Updated      int
+	Deleted      int
+	Skipped      int
+	PagesCreated int
+	PagesUpdated int
+	PagesDeleted int
+	Errors       []error
+}
+
+// ExportOptions controls how CMS content is written back to Markdown files.
+// ContentTypeID and Locales narrow the export; zero values export everything.
+type ExportOptions struct {
+	ContentTypeID  uuid.UUID
+	EnvironmentKey string
+	Locales        []string
+	AuthorID       uuid.UUID
+	DryRun         bool
+}
+
+// ExportResult summarises an export run. Paths are relative to the Markdown
+// content root so they can be fed straight back into Sync.
+type ExportResult struct {
+	Written   []string
+	Unchanged []string
+	Errors    []error
 }
@@ -121,6 +121,7 @@ type PageRecord struct {
 	ID                 uuid.UUID
 	ContentID          uuid.UUID
 	TemplateID         uuid.UUID
+	ParentID           *uuid.UUID
 	Slug               string
 	Status             string
 	Translation        TranslationBundle[PageTranslation]    `json:"translation"`
@@ -49,12 +49,48 @@ type ShortcodeMetrics interface {
 	IncrementCacheHit(shortcode string)
 }
 
+// ShortcodeSecurityMetrics is implemented by ShortcodeMetrics recorders that
+// also count renders blocked by a security rule. The renderer detects it with a
+// type assertion, so existing recorders keep working unchanged.
+type ShortcodeSecurityMetrics interface {
+	// IncrementSecurityViolation records when a security rule (url, rate_limit,
+	// sanitize) blocked a render request.
+	IncrementSecurityViolation(shortcode string, rule string)
+}
+
 // ShortcodeProcessOptions customises shortcode processing behaviour for a given invocation.
 type ShortcodeProcessOptions struct {
 	Locale          string
 	Cache           CacheProvider
 	Sanitizer       ShortcodeSanitizer
 	EnableWordPress bool
+	// EnvironmentKey scopes data-aware shortcodes to a CMS environment.
+	EnvironmentKey string
+	// Actor identifies the caller for rate limiting (user ID, session, or client
+	// address). Calls without an actor fall back to the actor stored on ctx.
+	// Renders with no identified actor are not rate limited, so public callers
+	// should pass the client address here.
+	Actor string
+	// Trusted marks server-side renders, such as static builds and block or
+	// widget reads, which are exempt from rate limiting.
+	Trusted bool
+	// CSP, when set, collects the CSP sources of this call into an existing
+	// policy and reuses its nonce, so one response can span several
+	// ProcessWithCSP calls.
+	CSP ShortcodeCSP
+}
+
+// ShortcodeCSPService is implemented by shortcode services that report the
+// Content-Security-Policy sources required by the content they render.
+type ShortcodeCSPService interface {
+	ProcessWithCSP(ctx context.Context, content string, opts ShortcodeProcessOptions) (*ShortcodeProcessResult, error)
+}
+
+// ShortcodeProcessResult carries processed content together with the CSP
+// sources collected while rendering it. CSP is nil when CSP support is disabled.
+type ShortcodeProcessResult struct {
+	Content string
+	CSP     ShortcodeCSP
 }
 
 // ShortcodeParser extracts shortcode invocations from arbitrary content.
@@ -84,6 +120,19 @@ type ShortcodeDefinition struct {
 	Schema      ShortcodeSchema
 	Template    string
 	Handler     ShortcodeHandler
+	CSP         ShortcodeCSPSources
+	// CacheDependencies names the CMS records the output depends on (for
+	// example "content:article"). Cached output is keyed on the current
+	// version of each dependency so invalidating one evicts dependent renders.
+	CacheDependencies func(params map[string]any) []string
+}
+
+// ShortcodeCSPSources lists the external sources a shortcode needs allowed by
+// the page Content-Security-Policy. Shortcode output is not cached while a
+// nonce is active, since the nonce is per request.
+type ShortcodeCSPSources struct {
+	ScriptSrc []string
+	FrameSrc  []string
 }
 
 // ShortcodeSchema defines the contract for parameters accepted by a shortcode.
@@ -120,10 +169,24 @@ type ShortcodeHandler func(ctx ShortcodeContext, params map[string]any, inner st
 
 // ShortcodeContext provides runtime metadata surfaced during rendering.
 type ShortcodeContext struct {
-	Context   context.Context
-	Locale    string
-	Cache     CacheProvider
-	Sanitizer ShortcodeSanitizer
+	Context        context.Context
+	Locale         string
+	Cache          CacheProvider
+	Sanitizer      ShortcodeSanitizer
+	EnvironmentKey string
+	Actor          string
+	Trusted        bool
+	CSP            ShortcodeCSP
+}
+
+// ShortcodeCSP collects the script-src and frame-src sources required by the
+// shortcodes rendered for a single response. Nonce returns the value stamped on
+// inline scripts emitted by shortcodes, or "" when none is active.
+// Implementations must be safe for concurrent use.
+type ShortcodeCSP interface {
+	Nonce() string
+	Add(sources ShortcodeCSPSources)
+	Header() string
 }
 
 // ParsedShortcode represents a parsed invocation discovered by the parser layer.
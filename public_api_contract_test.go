@@ -6,9 +6,13 @@ import (
 	"testing"
 
 	"github.com/goliatone/go-cms"
+	"github.com/goliatone/go-cms/audit"
 	"github.com/goliatone/go-cms/blocks"
 	"github.com/goliatone/go-cms/content"
+	"github.com/goliatone/go-cms/experiments"
 	"github.com/goliatone/go-cms/pages"
+	"github.com/goliatone/go-cms/sites"
+	"github.com/goliatone/go-cms/webhooks"
 )
 
 var _ func(*cms.Module) content.Service = (*cms.Module).Content
@@ -22,6 +26,10 @@ var _ func(*cms.Module) cms.AdminContentWriteService = (*cms.Module).AdminConten
 var _ func(*cms.Module) cms.AdminBlockReadService = (*cms.Module).AdminBlockRead
 var _ func(*cms.Module) cms.AdminBlockWriteService = (*cms.Module).AdminBlockWrite
 var _ func(*cms.Module) cms.LocaleService = (*cms.Module).Locales
+var _ func(*cms.Module) sites.Service = (*cms.Module).Sites
+var _ func(*cms.Module) experiments.Service = (*cms.Module).Experiments
+var _ func(*cms.Module) webhooks.Service = (*cms.Module).Webhooks
+var _ func(*cms.Module) audit.Log = (*cms.Module).Audit
 
 var _ content.Service = (cms.ContentService)(nil)
 var _ content.ContentTypeService = (cms.ContentTypeService)(nil)
@@ -34,6 +42,10 @@ var _ cms.AdminContentWriteService = (cms.AdminContentWriteService)(nil)
 var _ cms.AdminBlockReadService = (cms.AdminBlockReadService)(nil)
 var _ cms.AdminBlockWriteService = (cms.AdminBlockWriteService)(nil)
 var _ cms.LocaleService = (cms.LocaleService)(nil)
+var _ sites.Service = (cms.SiteService)(nil)
+var _ experiments.Service = (cms.ExperimentService)(nil)
+var _ webhooks.Service = (cms.WebhookService)(nil)
+var _ audit.Log = (cms.AuditLog)(nil)
 
 func TestPublicContractsDoNotReferenceInternalPackages(t *testing.T) {
 	t.Parallel()
@@ -51,6 +63,7 @@ func TestPublicContractsDoNotReferenceInternalPackages(t *testing.T) {
 		"content.PublishContentDraftRequest":      reflect.TypeFor[content.PublishContentDraftRequest](),
 		"content.PreviewContentDraftRequest":      reflect.TypeFor[content.PreviewContentDraftRequest](),
 		"content.RestoreContentVersionRequest":    reflect.TypeFor[content.RestoreContentVersionRequest](),
+		"content.RestoreContentRequest":           reflect.TypeFor[content.RestoreContentRequest](),
 		"content.ContentPreview":                  reflect.TypeFor[content.ContentPreview](),
 		"content.ScheduleContentRequest":          reflect.TypeFor[content.ScheduleContentRequest](),
 		"content.CreateContentTypeRequest":        reflect.TypeFor[content.CreateContentTypeRequest](),
@@ -70,6 +83,7 @@ func TestPublicContractsDoNotReferenceInternalPackages(t *testing.T) {
 		"pages.PublishPageDraftRequest":      reflect.TypeFor[pages.PublishPageDraftRequest](),
 		"pages.PreviewPageDraftRequest":      reflect.TypeFor[pages.PreviewPageDraftRequest](),
 		"pages.RestorePageVersionRequest":    reflect.TypeFor[pages.RestorePageVersionRequest](),
+		"pages.RestorePageRequest":           reflect.TypeFor[pages.RestorePageRequest](),
 		"pages.PagePreview":                  reflect.TypeFor[pages.PagePreview](),
 		"pages.SchedulePageRequest":          reflect.TypeFor[pages.SchedulePageRequest](),
 
@@ -87,6 +101,7 @@ func TestPublicContractsDoNotReferenceInternalPackages(t *testing.T) {
 		"blocks.CreateInstanceDraftRequest":    reflect.TypeFor[blocks.CreateInstanceDraftRequest](),
 		"blocks.PublishInstanceDraftRequest":   reflect.TypeFor[blocks.PublishInstanceDraftRequest](),
 		"blocks.RestoreInstanceVersionRequest": reflect.TypeFor[blocks.RestoreInstanceVersionRequest](),
+		"blocks.RestoreInstanceRequest":        reflect.TypeFor[blocks.RestoreInstanceRequest](),
 
 		"cms.MenuService":               reflect.TypeFor[cms.MenuService](),
 		"cms.MenuInfo":                  reflect.TypeFor[cms.MenuInfo](),
@@ -137,6 +152,33 @@ func TestPublicContractsDoNotReferenceInternalPackages(t *testing.T) {
 
 		"cms.LocaleService": reflect.TypeFor[cms.LocaleService](),
 		"cms.LocaleInfo":    reflect.TypeFor[cms.LocaleInfo](),
+
+		"sites.Service":         reflect.TypeFor[sites.Service](),
+		"sites.Site":            reflect.TypeFor[sites.Site](),
+		"sites.CreateSiteInput": reflect.TypeFor[sites.CreateSiteInput](),
+		"sites.UpdateSiteInput": reflect.TypeFor[sites.UpdateSiteInput](),
+
+		"experiments.Service":                     reflect.TypeFor[experiments.Service](),
+		"experiments.Experiment":                  reflect.TypeFor[experiments.Experiment](),
+		"experiments.CreateExperimentInput":       reflect.TypeFor[experiments.CreateExperimentInput](),
+		"experiments.TransitionExperimentRequest": reflect.TypeFor[experiments.TransitionExperimentRequest](),
+		"experiments.PromoteVariantRequest":       reflect.TypeFor[experiments.PromoteVariantRequest](),
+		"experiments.SelectVariantInput":          reflect.TypeFor[experiments.SelectVariantInput](),
+		"experiments.Assignment":                  reflect.TypeFor[experiments.Assignment](),
+
+		"webhooks.Service":                       reflect.TypeFor[webhooks.Service](),
+		"webhooks.Subscription":                  reflect.TypeFor[webhooks.Subscription](),
+		"webhooks.Delivery":                      reflect.TypeFor[webhooks.Delivery](),
+		"webhooks.Event":                         reflect.TypeFor[webhooks.Event](),
+		"webhooks.CreateSubscriptionInput":       reflect.TypeFor[webhooks.CreateSubscriptionInput](),
+		"webhooks.UpdateSubscriptionInput":       reflect.TypeFor[webhooks.UpdateSubscriptionInput](),
+		"webhooks.RotateSubscriptionSecretInput": reflect.TypeFor[webhooks.RotateSubscriptionSecretInput](),
+		"webhooks.DeliveryFilter":                reflect.TypeFor[webhooks.DeliveryFilter](),
+
+		"audit.Log":   reflect.TypeFor[audit.Log](),
+		"audit.Event": reflect.TypeFor[audit.Event](),
+		"audit.Query": reflect.TypeFor[audit.Query](),
+		"audit.Page":  reflect.TypeFor[audit.Page](),
 	}
 
 	for name, typ := range types {
@@ -197,7 +239,12 @@ func assertNoInternalTypeRefs(t *testing.T, name string, typ reflect.Type, seen
 func isAllowedInternalAliasType(typ reflect.Type) bool {
 	switch typ.PkgPath() {
 	case "github.com/goliatone/go-cms/internal/domain":
-		return typ.Name() == "Status"
+		switch typ.Name() {
+		case "Status", "RestoreConflict":
+			return true
+		default:
+			return false
+		}
 	case "github.com/goliatone/go-cms/internal/media":
 		return true
 	default:
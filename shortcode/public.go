@@ -12,7 +12,12 @@ type (
 	DefinitionValidator = internal.DefinitionValidator
 	Renderer            = internal.Renderer
 	RendererOption      = internal.RendererOption
+	SanitizerOption     = internal.SanitizerOption
 	Validator           = internal.Validator
+	DependencyTracker   = internal.DependencyTracker
+	DataSources         = internal.DataSources
+	NavigationItem      = internal.NavigationItem
+	CSP                 = internal.CSP
 )
 
 var (
@@ -21,6 +26,15 @@ var (
 	ErrUnknownParameter    = internal.ErrUnknownParameter
 	ErrMissingParameter    = internal.ErrMissingParameter
 	ErrParameterType       = internal.ErrParameterType
+	ErrDomainNotAllowed    = internal.ErrDomainNotAllowed
+	ErrRateLimited         = internal.ErrRateLimited
+	ErrDataNotFound        = internal.ErrDataNotFound
+)
+
+const (
+	SecurityRuleURL       = internal.SecurityRuleURL
+	SecurityRuleRateLimit = internal.SecurityRuleRateLimit
+	SecurityRuleSanitize  = internal.SecurityRuleSanitize
 )
 
 func NewValidator() *Validator {
@@ -51,8 +65,28 @@ func BuiltInDefinitions() []interfaces.ShortcodeDefinition {
 	return internal.BuiltInDefinitions()
 }
 
-func NewSanitizer() interfaces.ShortcodeSanitizer {
-	return internal.NewSanitizer()
+func RegisterDataShortcodes(registry interfaces.ShortcodeRegistry, sources DataSources, names []string) error {
+	return internal.RegisterDataShortcodes(registry, sources, names)
+}
+
+func DataShortcodeNames() []string {
+	return internal.DataShortcodeNames()
+}
+
+func NewCSP(nonce string) *CSP {
+	return internal.NewCSP(nonce)
+}
+
+func NewDependencyTracker() *DependencyTracker {
+	return internal.NewDependencyTracker()
+}
+
+func NewSanitizer(opts ...SanitizerOption) interfaces.ShortcodeSanitizer {
+	return internal.NewSanitizer(opts...)
+}
+
+func WithAllowedDomains(domains ...string) SanitizerOption {
+	return internal.WithAllowedDomains(domains...)
 }
 
 func WithRendererSanitizer(s interfaces.ShortcodeSanitizer) RendererOption {
@@ -67,6 +101,18 @@ func WithRendererMetrics(metrics interfaces.ShortcodeMetrics) RendererOption {
 	return internal.WithRendererMetrics(metrics)
 }
 
+func WithRendererRateLimit(perMinute int) RendererOption {
+	return internal.WithRendererRateLimit(perMinute)
+}
+
+func WithRendererDependencies(tracker *DependencyTracker) RendererOption {
+	return internal.WithRendererDependencies(tracker)
+}
+
+func WithCSP(enabled bool) ServiceOption {
+	return internal.WithCSP(enabled)
+}
+
 func WithWordPressSyntax(enabled bool) ServiceOption {
 	return internal.WithWordPressSyntax(enabled)
 }
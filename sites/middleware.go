@@ -0,0 +1,92 @@
+package sites
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// WithSite returns a copy of ctx carrying site.
+func WithSite(ctx context.Context, site *Site) context.Context {
+	return context.WithValue(ctx, contextKey{}, site)
+}
+
+// FromContext returns the site stored by WithSite or Middleware.
+func FromContext(ctx context.Context) (*Site, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	site, ok := ctx.Value(contextKey{}).(*Site)
+	return site, ok && site != nil
+}
+
+// WithSiteID returns a copy of ctx scoped to siteID. A nil or zero siteID
+// clears any site carried by ctx so lookups resolve shared records.
+func WithSiteID(ctx context.Context, siteID *uuid.UUID) context.Context {
+	if siteID == nil || *siteID == uuid.Nil {
+		return WithSite(ctx, nil)
+	}
+	return WithSite(ctx, &Site{ID: *siteID})
+}
+
+// Select picks the record visible to the site carried by ctx among records
+// sharing a natural key such as a slug or code. A record scoped to the
+// context site wins over a shared one and records of other sites are
+// skipped. Without a site in ctx shared records win, falling back to the
+// first candidate.
+func Select[T any](ctx context.Context, candidates []T, siteOf func(T) *uuid.UUID) (T, bool) {
+	var zero T
+	site, scoped := FromContext(ctx)
+	shared, sharedFound := zero, false
+	for _, candidate := range candidates {
+		siteID := siteOf(candidate)
+		if siteID == nil || *siteID == uuid.Nil {
+			if !sharedFound {
+				shared, sharedFound = candidate, true
+			}
+			continue
+		}
+		if scoped && site.ID == *siteID {
+			return candidate, true
+		}
+	}
+	if sharedFound {
+		return shared, true
+	}
+	if !scoped && len(candidates) > 0 {
+		return candidates[0], true
+	}
+	return zero, false
+}
+
+// SameScope reports whether two site references name the same scope. Nil
+// and zero IDs both denote shared records.
+func SameScope(a, b *uuid.UUID) bool {
+	aShared := a == nil || *a == uuid.Nil
+	bShared := b == nil || *b == uuid.Nil
+	if aShared || bShared {
+		return aShared == bShared
+	}
+	return *a == *b
+}
+
+// Middleware resolves the request host to a site and stores it in the request
+// context. Requests for unknown hosts receive 404 Not Found.
+func Middleware(resolver HostResolver, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site, err := resolver.ResolveHost(r.Context(), r.Host)
+		if err != nil {
+			if errors.Is(err, ErrSiteNotFound) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithSite(r.Context(), site)))
+	})
+}
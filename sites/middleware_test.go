@@ -0,0 +1,98 @@
+package sites_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goliatone/go-cms/sites"
+	"github.com/google/uuid"
+)
+
+type resolverFunc func(ctx context.Context, host string) (*sites.Site, error)
+
+func (f resolverFunc) ResolveHost(ctx context.Context, host string) (*sites.Site, error) {
+	return f(ctx, host)
+}
+
+func TestMiddlewareStoresResolvedSite(t *testing.T) {
+	resolver := resolverFunc(func(_ context.Context, host string) (*sites.Site, error) {
+		switch host {
+		case "blog.example.com":
+			return &sites.Site{Key: "blog"}, nil
+		case "broken.example.com":
+			return nil, errors.New("boom")
+		}
+		return nil, sites.ErrSiteNotFound
+	})
+	handler := sites.Middleware(resolver, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site, ok := sites.FromContext(r.Context())
+		if !ok {
+			t.Fatalf("expected site in request context")
+		}
+		_, _ = w.Write([]byte(site.Key))
+	}))
+
+	cases := []struct {
+		host   string
+		status int
+		body   string
+	}{
+		{"blog.example.com", http.StatusOK, "blog"},
+		{"unknown.example.com", http.StatusNotFound, ""},
+		{"broken.example.com", http.StatusInternalServerError, ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "http://"+tc.host+"/", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.status {
+			t.Fatalf("%s: expected status %d, got %d", tc.host, tc.status, rec.Code)
+		}
+		if tc.body != "" && rec.Body.String() != tc.body {
+			t.Fatalf("%s: expected body %q, got %q", tc.host, tc.body, rec.Body.String())
+		}
+	}
+}
+
+func TestSelectPrefersContextSite(t *testing.T) {
+	type record struct {
+		name   string
+		siteID *uuid.UUID
+	}
+	blogID, shopID, docsID := uuid.New(), uuid.New(), uuid.New()
+	candidates := []record{
+		{name: "shop", siteID: &shopID},
+		{name: "shared"},
+		{name: "blog", siteID: &blogID},
+	}
+	siteOf := func(r record) *uuid.UUID { return r.siteID }
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"unscoped prefers shared", context.Background(), "shared"},
+		{"site record wins", sites.WithSite(context.Background(), &sites.Site{ID: blogID}), "blog"},
+		{"falls back to shared", sites.WithSiteID(context.Background(), &docsID), "shared"},
+	}
+	for _, tc := range cases {
+		got, ok := sites.Select(tc.ctx, candidates, siteOf)
+		if !ok || got.name != tc.want {
+			t.Fatalf("%s: expected %q, got %q (ok=%v)", tc.name, tc.want, got.name, ok)
+		}
+	}
+
+	if _, ok := sites.Select(sites.WithSiteID(context.Background(), &docsID), candidates[:1], siteOf); ok {
+		t.Fatalf("expected records of other sites to be skipped")
+	}
+	if got, ok := sites.Select(context.Background(), candidates[:1], siteOf); !ok || got.name != "shop" {
+		t.Fatalf("expected unscoped lookups to fall back to the first record")
+	}
+	if !sites.SameScope(nil, &uuid.Nil) || sites.SameScope(nil, &blogID) || !sites.SameScope(&blogID, &blogID) {
+		t.Fatalf("unexpected SameScope results")
+	}
+}
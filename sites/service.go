@@ -0,0 +1,69 @@
+package sites
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Service manages sites and resolves request hosts to sites.
+type Service interface {
+	CreateSite(ctx context.Context, input CreateSiteInput) (*Site, error)
+	UpdateSite(ctx context.Context, input UpdateSiteInput) (*Site, error)
+	DeleteSite(ctx context.Context, id uuid.UUID) error
+	GetSite(ctx context.Context, id uuid.UUID) (*Site, error)
+	GetSiteByKey(ctx context.Context, key string) (*Site, error)
+	ListSites(ctx context.Context) ([]*Site, error)
+	GetDefaultSite(ctx context.Context) (*Site, error)
+	ResolveHost(ctx context.Context, host string) (*Site, error)
+}
+
+// CreateSiteInput captures the information required to register a site.
+type CreateSiteInput struct {
+	Key           string
+	Name          string
+	Hostnames     []string
+	BaseURL       string
+	DefaultLocale string
+	Locales       []string
+	Theme         string
+	ThemeVariant  string
+	MenuLocations map[string]string
+	IsActive      *bool
+	IsDefault     bool
+}
+
+// UpdateSiteInput captures mutable site fields. Nil fields are left unchanged.
+type UpdateSiteInput struct {
+	ID            uuid.UUID
+	Name          *string
+	Hostnames     []string
+	BaseURL       *string
+	DefaultLocale *string
+	Locales       []string
+	Theme         *string
+	ThemeVariant  *string
+	MenuLocations map[string]string
+	IsActive      *bool
+	IsDefault     *bool
+}
+
+// HostResolver resolves a request host to a site.
+type HostResolver interface {
+	ResolveHost(ctx context.Context, host string) (*Site, error)
+}
+
+var (
+	ErrFeatureDisabled        = errors.New("sites: feature disabled")
+	ErrSiteKeyRequired        = errors.New("sites: key is required")
+	ErrSiteKeyInvalid         = errors.New("sites: key is invalid")
+	ErrSiteKeyExists          = errors.New("sites: key already exists")
+	ErrSiteNotFound           = errors.New("sites: site not found")
+	ErrSiteHostnameInvalid    = errors.New("sites: hostname is invalid")
+	ErrSiteHostnameExists     = errors.New("sites: hostname already assigned to another site")
+	ErrSiteBaseURLInvalid     = errors.New("sites: base url must be an absolute http(s) url")
+	ErrSiteDefaultProtected   = errors.New("sites: default site cannot be unset or deleted")
+	ErrSiteScopeMismatch      = errors.New("sites: record belongs to another site")
+	ErrSiteRepositoryRequired = errors.New("sites: repository required")
+)
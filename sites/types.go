@@ -0,0 +1,39 @@
+package sites
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Site describes a brand site served from the shared CMS database. Pages,
+// menus, widget areas and content without a site are shared by every site.
+type Site struct {
+	bun.BaseModel `bun:"table:sites,alias:st"`
+
+	ID            uuid.UUID         `bun:",pk,type:uuid" json:"id"`
+	Key           string            `bun:"key,notnull" json:"key"`
+	Name          string            `bun:"name,notnull" json:"name"`
+	Hostnames     []string          `bun:"hostnames,type:jsonb" json:"hostnames,omitempty"`
+	BaseURL       string            `bun:"base_url" json:"base_url,omitempty"`
+	DefaultLocale string            `bun:"default_locale" json:"default_locale,omitempty"`
+	Locales       []string          `bun:"locales,type:jsonb" json:"locales,omitempty"`
+	Theme         string            `bun:"theme" json:"theme,omitempty"`
+	ThemeVariant  string            `bun:"theme_variant" json:"theme_variant,omitempty"`
+	MenuLocations map[string]string `bun:"menu_locations,type:jsonb" json:"menu_locations,omitempty"`
+	IsActive      bool              `bun:"is_active,notnull,default:true" json:"is_active"`
+	IsDefault     bool              `bun:"is_default,notnull,default:false" json:"is_default"`
+	CreatedAt     time.Time         `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	UpdatedAt     time.Time         `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+	DeletedAt     *time.Time        `bun:"deleted_at,nullzero" json:"deleted_at,omitempty"`
+}
+
+// Includes reports whether a record scoped to siteID belongs to the site.
+// Records without a site are shared and belong to every site.
+func (s *Site) Includes(siteID *uuid.UUID) bool {
+	if siteID == nil || *siteID == uuid.Nil {
+		return true
+	}
+	return s != nil && s.ID == *siteID
+}
@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goliatone/go-cms/pkg/activity"
+	"github.com/goliatone/go-cms/pkg/lifecycle"
+	"github.com/google/uuid"
+)
+
+// LifecycleHook forwards lifecycle events to a dispatcher.
+type LifecycleHook struct {
+	Dispatcher Dispatcher
+}
+
+// Notify converts the lifecycle event and dispatches it.
+func (h LifecycleHook) Notify(ctx context.Context, event lifecycle.Event) error {
+	if h.Dispatcher == nil {
+		return nil
+	}
+	_, err := h.Dispatcher.Dispatch(ctx, EventFromLifecycle(event))
+	return err
+}
+
+// ActivityHook forwards activity events to a dispatcher.
+type ActivityHook struct {
+	Dispatcher Dispatcher
+}
+
+// Notify converts the activity event and dispatches it.
+func (h ActivityHook) Notify(ctx context.Context, event activity.Event) error {
+	if h.Dispatcher == nil {
+		return nil
+	}
+	_, err := h.Dispatcher.Dispatch(ctx, EventFromActivity(event))
+	return err
+}
+
+// EventFromLifecycle maps a lifecycle event to a webhook event.
+func EventFromLifecycle(event lifecycle.Event) Event {
+	normalized := lifecycle.NormalizeEvent(event)
+	return Event{
+		ID:             uuid.New(),
+		Source:         SourceLifecycle,
+		ResourceType:   normalized.ResourceType,
+		RecordID:       normalized.RecordID,
+		Transition:     normalized.Transition,
+		Status:         normalized.Status,
+		Locale:         normalized.Locale,
+		EnvironmentKey: normalized.EnvironmentKey,
+		OccurredAt:     normalized.OccurredAt.UTC(),
+		Metadata:       normalized.Metadata,
+	}
+}
+
+// EventFromActivity maps an activity event to a webhook event. The
+// environment is read from the "environment_key" metadata entry.
+func EventFromActivity(event activity.Event) Event {
+	normalized := activity.NormalizeEvent(event)
+	return Event{
+		ID:             uuid.New(),
+		Source:         SourceActivity,
+		ResourceType:   normalized.ObjectType,
+		RecordID:       normalized.ObjectID,
+		Transition:     normalized.Verb,
+		Status:         metadataString(normalized.Metadata, "status"),
+		Locale:         metadataString(normalized.Metadata, "locale"),
+		EnvironmentKey: metadataString(normalized.Metadata, "environment_key"),
+		ActorID:        normalized.ActorID,
+		OccurredAt:     normalized.OccurredAt.UTC(),
+		Metadata:       normalized.Metadata,
+	}
+}
+
+func metadataString(meta map[string]any, key string) string {
+	value, ok := meta[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return strings.TrimSpace(str)
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Service manages webhook subscriptions and their delivery log. Subscription
+// secrets are only returned by CreateSubscription and RotateSubscriptionSecret;
+// every other read leaves Secret empty.
+type Service interface {
+	CreateSubscription(ctx context.Context, input CreateSubscriptionInput) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, input UpdateSubscriptionInput) (*Subscription, error)
+	RotateSubscriptionSecret(ctx context.Context, input RotateSubscriptionSecretInput) (*Subscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	Dispatch(ctx context.Context, event Event) ([]*Delivery, error)
+	Deliver(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+	ReplayDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+}
+
+// Dispatcher fans an event out to matching subscriptions. The lifecycle and
+// activity hook adapters depend on this narrow contract.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event Event) ([]*Delivery, error)
+}
+
+// CreateSubscriptionInput captures the information required to register an
+// endpoint. A secret is generated when none is supplied.
+type CreateSubscriptionInput struct {
+	Name          string
+	URL           string
+	Secret        string
+	Sources       []Source
+	ResourceTypes []string
+	Transitions   []string
+	Environments  []string
+	Disabled      bool
+	CreatedBy     uuid.UUID
+}
+
+// UpdateSubscriptionInput changes a subscription. Nil fields are left
+// unchanged; an empty, non-nil filter slice clears the filter. Secrets are
+// changed with RotateSubscriptionSecret.
+type UpdateSubscriptionInput struct {
+	ID            uuid.UUID
+	Name          *string
+	URL           *string
+	Sources       []Source
+	ResourceTypes []string
+	Transitions   []string
+	Environments  []string
+	Disabled      *bool
+	UpdatedBy     uuid.UUID
+}
+
+// RotateSubscriptionSecretInput replaces a subscription secret. A secret is
+// generated when none is supplied.
+type RotateSubscriptionSecretInput struct {
+	ID        uuid.UUID
+	Secret    string
+	UpdatedBy uuid.UUID
+}
+
+// DeliveryFilter narrows the delivery log. Results are ordered newest first.
+type DeliveryFilter struct {
+	SubscriptionID uuid.UUID
+	Status         DeliveryStatus
+	Limit          int
+}
+
+var (
+	ErrFeatureDisabled                = errors.New("webhooks: feature disabled")
+	ErrSubscriptionNotFound           = errors.New("webhooks: subscription not found")
+	ErrSubscriptionURLRequired        = errors.New("webhooks: url is required")
+	ErrSubscriptionURLInvalid         = errors.New("webhooks: url must be an absolute http or https url")
+	ErrSubscriptionSourceInvalid      = errors.New("webhooks: source is invalid")
+	ErrDeliveryNotFound               = errors.New("webhooks: delivery not found")
+	ErrSubscriptionRepositoryRequired = errors.New("webhooks: subscription repository required")
+	ErrDeliveryRepositoryRequired     = errors.New("webhooks: delivery repository required")
+)
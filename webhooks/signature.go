@@ -0,0 +1,39 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderDeliveryID = "X-CMS-Webhook-Delivery"
+	HeaderEvent      = "X-CMS-Webhook-Event"
+	HeaderTimestamp  = "X-CMS-Webhook-Timestamp"
+	HeaderSignature  = "X-CMS-Webhook-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns the signature header value for a request body: the hex
+// HMAC-SHA256 of "<timestamp>.<body>" keyed by the subscription secret.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header produced by Sign. Receivers should also
+// reject timestamps outside their tolerance window to prevent replays.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	signature = strings.TrimSpace(signature)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
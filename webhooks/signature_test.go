@@ -0,0 +1,50 @@
+package webhooks
+
+import "testing"
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"resource_type":"page"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	if !Verify("secret", 1700000000, body, signature) {
+		t.Fatalf("expected signature to verify")
+	}
+	if Verify("other", 1700000000, body, signature) {
+		t.Fatalf("expected wrong secret to fail")
+	}
+	if Verify("secret", 1700000001, body, signature) {
+		t.Fatalf("expected wrong timestamp to fail")
+	}
+	if Verify("secret", 1700000000, []byte(`{}`), signature) {
+		t.Fatalf("expected tampered body to fail")
+	}
+	if Verify("secret", 1700000000, body, signature[len("sha256="):]) {
+		t.Fatalf("expected signature without prefix to fail")
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	subscription := &Subscription{
+		Sources:       []Source{SourceLifecycle},
+		ResourceTypes: []string{"page", "content"},
+		Environments:  []string{"production"},
+	}
+	event := Event{Source: SourceLifecycle, ResourceType: "Page", Transition: "publish", EnvironmentKey: "production"}
+	if !subscription.Matches(event) {
+		t.Fatalf("expected match")
+	}
+	event.Source = SourceActivity
+	if subscription.Matches(event) {
+		t.Fatalf("expected source filter to reject activity events")
+	}
+	event.Source = SourceLifecycle
+	event.EnvironmentKey = ""
+	if subscription.Matches(event) {
+		t.Fatalf("expected environment filter to reject events without an environment")
+	}
+	subscription.Environments = nil
+	subscription.Disabled = true
+	if subscription.Matches(event) {
+		t.Fatalf("expected disabled subscription to match nothing")
+	}
+}
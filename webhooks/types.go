@@ -0,0 +1,124 @@
+package webhooks
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Source identifies the in-process hook an event was received from.
+type Source string
+
+const (
+	SourceLifecycle Source = "lifecycle"
+	SourceActivity  Source = "activity"
+)
+
+// DeliveryStatus describes where a delivery is in its retry cycle.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Event is the JSON body posted to subscribers. Lifecycle events map their
+// transition to Transition; activity events map ObjectType, ObjectID and Verb
+// to ResourceType, RecordID and Transition. The ID stays the same across
+// retries and replays so receivers can deduplicate.
+type Event struct {
+	ID             uuid.UUID      `json:"id"`
+	Source         Source         `json:"source"`
+	ResourceType   string         `json:"resource_type"`
+	RecordID       string         `json:"record_id"`
+	Transition     string         `json:"transition"`
+	Status         string         `json:"status,omitempty"`
+	Locale         string         `json:"locale,omitempty"`
+	EnvironmentKey string         `json:"environment_key,omitempty"`
+	ActorID        string         `json:"actor_id,omitempty"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+	Metadata       map[string]any `json:"metadata,omitempty"`
+}
+
+// Name returns the event name sent in the event header, e.g. "page.publish".
+func (e Event) Name() string {
+	return e.ResourceType + "." + e.Transition
+}
+
+// Subscription registers an endpoint for events. Empty filter lists match
+// every value; values are compared case-insensitively. Empty Sources match
+// lifecycle events only, because a publish is reported by both the lifecycle
+// and the activity hooks and would otherwise be delivered twice.
+type Subscription struct {
+	bun.BaseModel `bun:"table:webhook_subscriptions,alias:whs"`
+
+	ID            uuid.UUID `bun:",pk,type:uuid" json:"id"`
+	Name          string    `bun:"name,notnull" json:"name"`
+	URL           string    `bun:"url,notnull" json:"url"`
+	Secret        string    `bun:"secret,notnull" json:"-"`
+	Sources       []Source  `bun:"sources,type:jsonb" json:"sources,omitempty"`
+	ResourceTypes []string  `bun:"resource_types,type:jsonb" json:"resource_types,omitempty"`
+	Transitions   []string  `bun:"transitions,type:jsonb" json:"transitions,omitempty"`
+	Environments  []string  `bun:"environments,type:jsonb" json:"environments,omitempty"`
+	Disabled      bool      `bun:"disabled,notnull,default:false" json:"disabled"`
+	CreatedBy     uuid.UUID `bun:"created_by,type:uuid" json:"created_by"`
+	UpdatedBy     uuid.UUID `bun:"updated_by,type:uuid" json:"updated_by"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	UpdatedAt     time.Time `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+}
+
+// Matches reports whether the subscription is active and its filters accept
+// the event.
+func (s *Subscription) Matches(event Event) bool {
+	if s == nil || s.Disabled {
+		return false
+	}
+	sources := s.Sources
+	if len(sources) == 0 {
+		sources = []Source{SourceLifecycle}
+	}
+	if !slices.Contains(sources, event.Source) {
+		return false
+	}
+	return matchesFilter(s.ResourceTypes, event.ResourceType) &&
+		matchesFilter(s.Transitions, event.Transition) &&
+		matchesFilter(s.Environments, event.EnvironmentKey)
+}
+
+func matchesFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, candidate := range filter {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery records one event sent to one subscription, including the outcome
+// of the most recent attempt.
+type Delivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:whd"`
+
+	ID             uuid.UUID      `bun:",pk,type:uuid" json:"id"`
+	SubscriptionID uuid.UUID      `bun:"subscription_id,notnull,type:uuid" json:"subscription_id"`
+	EventName      string         `bun:"event_name,notnull" json:"event_name"`
+	Event          Event          `bun:"event,type:jsonb,notnull" json:"event"`
+	Status         DeliveryStatus `bun:"status,notnull" json:"status"`
+	Attempts       int            `bun:"attempts,notnull,default:0" json:"attempts"`
+	MaxAttempts    int            `bun:"max_attempts,notnull,default:0" json:"max_attempts"`
+	ResponseCode   int            `bun:"response_code,notnull,default:0" json:"response_code,omitempty"`
+	ResponseBody   string         `bun:"response_body" json:"response_body,omitempty"`
+	Error          string         `bun:"error" json:"error,omitempty"`
+	NextAttemptAt  *time.Time     `bun:"next_attempt_at,nullzero" json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time     `bun:"delivered_at,nullzero" json:"delivered_at,omitempty"`
+	ReplayOf       *uuid.UUID     `bun:"replay_of,type:uuid,nullzero" json:"replay_of,omitempty"`
+	CreatedAt      time.Time      `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	UpdatedAt      time.Time      `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+}
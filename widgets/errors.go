@@ -23,6 +23,13 @@ var (
 	ErrVisibilityRulesInvalid        = errors.New("widgets: visibility_rules contains unsupported keys")
 	ErrVisibilityScheduleInvalid     = errors.New("widgets: visibility schedule timestamps must be RFC3339")
 	ErrInstanceSoftDeleteUnsupported = errors.New("widgets: soft delete not supported for instances")
+	ErrInstanceNotTrashed            = errors.New("widgets: instance not found in trash")
+
+	ErrVersioningDisabled               = errors.New("widgets: versioning feature disabled")
+	ErrInstanceVersionRequired          = errors.New("widgets: version identifier required")
+	ErrInstanceVersionConflict          = errors.New("widgets: base version mismatch")
+	ErrInstanceVersionAlreadyPublished  = errors.New("widgets: version already published")
+	ErrInstanceVersionRetentionExceeded = errors.New("widgets: version retention limit reached")
 
 	ErrTranslationContentRequired = errors.New("widgets: translation content required")
 	ErrTranslationLocaleRequired  = errors.New("widgets: translation locale required")
@@ -16,27 +16,31 @@ func TestPublicWidgetSignaturesDoNotReferenceInternalPackages(t *testing.T) {
 	t.Parallel()
 
 	types := map[string]reflect.Type{
-		"widgets.Service":                     reflect.TypeFor[widgets.Service](),
-		"widgets.RegisterDefinitionInput":     reflect.TypeFor[widgets.RegisterDefinitionInput](),
-		"widgets.DeleteDefinitionRequest":     reflect.TypeFor[widgets.DeleteDefinitionRequest](),
-		"widgets.CreateInstanceInput":         reflect.TypeFor[widgets.CreateInstanceInput](),
-		"widgets.UpdateInstanceInput":         reflect.TypeFor[widgets.UpdateInstanceInput](),
-		"widgets.DeleteInstanceRequest":       reflect.TypeFor[widgets.DeleteInstanceRequest](),
-		"widgets.AddTranslationInput":         reflect.TypeFor[widgets.AddTranslationInput](),
-		"widgets.UpdateTranslationInput":      reflect.TypeFor[widgets.UpdateTranslationInput](),
-		"widgets.DeleteTranslationRequest":    reflect.TypeFor[widgets.DeleteTranslationRequest](),
-		"widgets.RegisterAreaDefinitionInput": reflect.TypeFor[widgets.RegisterAreaDefinitionInput](),
-		"widgets.AssignWidgetToAreaInput":     reflect.TypeFor[widgets.AssignWidgetToAreaInput](),
-		"widgets.RemoveWidgetFromAreaInput":   reflect.TypeFor[widgets.RemoveWidgetFromAreaInput](),
-		"widgets.ReorderAreaWidgetsInput":     reflect.TypeFor[widgets.ReorderAreaWidgetsInput](),
-		"widgets.ResolveAreaInput":            reflect.TypeFor[widgets.ResolveAreaInput](),
-		"widgets.VisibilityContext":           reflect.TypeFor[widgets.VisibilityContext](),
-		"widgets.Definition":                  reflect.TypeFor[widgets.Definition](),
-		"widgets.Instance":                    reflect.TypeFor[widgets.Instance](),
-		"widgets.Translation":                 reflect.TypeFor[widgets.Translation](),
-		"widgets.AreaDefinition":              reflect.TypeFor[widgets.AreaDefinition](),
-		"widgets.AreaPlacement":               reflect.TypeFor[widgets.AreaPlacement](),
-		"widgets.ResolvedWidget":              reflect.TypeFor[widgets.ResolvedWidget](),
+		"widgets.Service":                       reflect.TypeFor[widgets.Service](),
+		"widgets.RegisterDefinitionInput":       reflect.TypeFor[widgets.RegisterDefinitionInput](),
+		"widgets.DeleteDefinitionRequest":       reflect.TypeFor[widgets.DeleteDefinitionRequest](),
+		"widgets.CreateInstanceInput":           reflect.TypeFor[widgets.CreateInstanceInput](),
+		"widgets.UpdateInstanceInput":           reflect.TypeFor[widgets.UpdateInstanceInput](),
+		"widgets.DeleteInstanceRequest":         reflect.TypeFor[widgets.DeleteInstanceRequest](),
+		"widgets.CreateInstanceDraftRequest":    reflect.TypeFor[widgets.CreateInstanceDraftRequest](),
+		"widgets.PublishInstanceDraftRequest":   reflect.TypeFor[widgets.PublishInstanceDraftRequest](),
+		"widgets.RestoreInstanceVersionRequest": reflect.TypeFor[widgets.RestoreInstanceVersionRequest](),
+		"widgets.AddTranslationInput":           reflect.TypeFor[widgets.AddTranslationInput](),
+		"widgets.UpdateTranslationInput":        reflect.TypeFor[widgets.UpdateTranslationInput](),
+		"widgets.DeleteTranslationRequest":      reflect.TypeFor[widgets.DeleteTranslationRequest](),
+		"widgets.RegisterAreaDefinitionInput":   reflect.TypeFor[widgets.RegisterAreaDefinitionInput](),
+		"widgets.AssignWidgetToAreaInput":       reflect.TypeFor[widgets.AssignWidgetToAreaInput](),
+		"widgets.RemoveWidgetFromAreaInput":     reflect.TypeFor[widgets.RemoveWidgetFromAreaInput](),
+		"widgets.ReorderAreaWidgetsInput":       reflect.TypeFor[widgets.ReorderAreaWidgetsInput](),
+		"widgets.ResolveAreaInput":              reflect.TypeFor[widgets.ResolveAreaInput](),
+		"widgets.VisibilityContext":             reflect.TypeFor[widgets.VisibilityContext](),
+		"widgets.Definition":                    reflect.TypeFor[widgets.Definition](),
+		"widgets.Instance":                      reflect.TypeFor[widgets.Instance](),
+		"widgets.Translation":                   reflect.TypeFor[widgets.Translation](),
+		"widgets.InstanceVersion":               reflect.TypeFor[widgets.InstanceVersion](),
+		"widgets.AreaDefinition":                reflect.TypeFor[widgets.AreaDefinition](),
+		"widgets.AreaPlacement":                 reflect.TypeFor[widgets.AreaPlacement](),
+		"widgets.ResolvedWidget":                reflect.TypeFor[widgets.ResolvedWidget](),
 	}
 
 	for name, typ := range types {
@@ -16,4 +16,8 @@ type ResolvedWidget struct {
 	// produced the final localized Config when a translation match was found.
 	ResolvedLocaleID *uuid.UUID     `json:"resolved_locale_id,omitempty"`
 	Placement        *AreaPlacement `json:"placement"`
+	// Experiment and Variant identify the experiment variant overlaid on Config
+	// when the visitor was bucketed into a running experiment.
+	Experiment string `json:"experiment,omitempty"`
+	Variant    string `json:"variant,omitempty"`
 }
@@ -23,6 +23,14 @@ type Service interface {
 	ListInstancesByArea(ctx context.Context, areaCode string) ([]*Instance, error)
 	ListAllInstances(ctx context.Context) ([]*Instance, error)
 	DeleteInstance(ctx context.Context, req DeleteInstanceRequest) error
+	ListTrashedInstances(ctx context.Context, req ListTrashedInstancesRequest) ([]*Instance, error)
+	RestoreDeletedInstance(ctx context.Context, req RestoreInstanceRequest) (*Instance, error)
+	RestoreInstanceSnapshot(ctx context.Context, req RestoreInstanceSnapshotRequest) (*Instance, error)
+
+	CreateDraft(ctx context.Context, req CreateInstanceDraftRequest) (*InstanceVersion, error)
+	PublishDraft(ctx context.Context, req PublishInstanceDraftRequest) (*InstanceVersion, error)
+	ListVersions(ctx context.Context, instanceID uuid.UUID) ([]*InstanceVersion, error)
+	RestoreVersion(ctx context.Context, req RestoreInstanceVersionRequest) (*InstanceVersion, error)
 
 	AddTranslation(ctx context.Context, input AddTranslationInput) (*Translation, error)
 	UpdateTranslation(ctx context.Context, input UpdateTranslationInput) (*Translation, error)
@@ -34,6 +42,7 @@ type Service interface {
 	AssignWidgetToArea(ctx context.Context, input AssignWidgetToAreaInput) ([]*AreaPlacement, error)
 	RemoveWidgetFromArea(ctx context.Context, input RemoveWidgetFromAreaInput) error
 	ReorderAreaWidgets(ctx context.Context, input ReorderAreaWidgetsInput) ([]*AreaPlacement, error)
+	ListInstancePlacements(ctx context.Context, instanceID uuid.UUID) ([]*AreaPlacement, error)
 	ResolveArea(ctx context.Context, input ResolveAreaInput) ([]*ResolvedWidget, error)
 	EvaluateVisibility(ctx context.Context, instance *Instance, input VisibilityContext) (bool, error)
 }
@@ -82,6 +91,9 @@ type CreateInstanceInput struct {
 	Position        int
 	CreatedBy       uuid.UUID
 	UpdatedBy       uuid.UUID
+	// EnvironmentKey selects the environment the instance belongs to; empty
+	// uses the default environment.
+	EnvironmentKey string
 }
 
 // UpdateInstanceInput defines mutable fields for a widget instance.
@@ -104,6 +116,55 @@ type DeleteInstanceRequest struct {
 	HardDelete bool
 }
 
+// ListTrashedInstancesRequest pages through the trashed widget instances of an
+// environment. An empty Environment selects the default environment and a zero
+// Limit returns every remaining instance.
+type ListTrashedInstancesRequest struct {
+	Environment string
+	Limit       int
+	Offset      int
+}
+
+// RestoreInstanceRequest brings a soft-deleted widget instance back from the
+// trash together with its translations and area placements.
+type RestoreInstanceRequest struct {
+	InstanceID uuid.UUID
+	RestoredBy uuid.UUID
+}
+
+// RestoreInstanceSnapshotRequest recreates a widget instance from a
+// previously captured copy, keeping its ID, translations and versions. A live
+// instance with the same ID is replaced.
+type RestoreInstanceSnapshotRequest struct {
+	Instance   *Instance
+	Versions   []*InstanceVersion
+	RestoredBy uuid.UUID
+}
+
+// CreateInstanceDraftRequest captures draft snapshot data for a widget instance.
+type CreateInstanceDraftRequest struct {
+	InstanceID  uuid.UUID
+	Snapshot    InstanceVersionSnapshot
+	CreatedBy   uuid.UUID
+	UpdatedBy   uuid.UUID
+	BaseVersion *int
+}
+
+// PublishInstanceDraftRequest captures publish inputs for a widget draft.
+type PublishInstanceDraftRequest struct {
+	InstanceID  uuid.UUID
+	Version     int
+	PublishedBy uuid.UUID
+	PublishedAt *time.Time
+}
+
+// RestoreInstanceVersionRequest copies an earlier widget version into a new draft.
+type RestoreInstanceVersionRequest struct {
+	InstanceID uuid.UUID
+	Version    int
+	RestoredBy uuid.UUID
+}
+
 // AddTranslationInput describes the payload to add localized widget content.
 type AddTranslationInput struct {
 	InstanceID uuid.UUID
@@ -132,6 +193,7 @@ type RegisterAreaDefinitionInput struct {
 	Scope       AreaScope
 	ThemeID     *uuid.UUID
 	TemplateID  *uuid.UUID
+	SiteID      *uuid.UUID
 }
 
 // AssignWidgetToAreaInput describes how to bind a widget instance to an area.
@@ -171,6 +233,14 @@ type ResolveAreaInput struct {
 	Audience          []string
 	Segments          []string
 	Now               time.Time
+	// Preview resolves instances with their latest unpublished draft applied.
+	Preview bool
+	// VisitorKey buckets the visitor into running experiment variants. When
+	// empty, the key stored on the context by experiments.WithVisitorKey is used.
+	VisitorKey string
+	// EnvironmentKey limits the area to instances of one environment; empty
+	// uses the default environment.
+	EnvironmentKey string
 }
 
 // VisibilityContext provides ambient information for visibility evaluation.
@@ -180,4 +250,6 @@ type VisibilityContext struct {
 	Audience    []string
 	Segments    []string
 	CustomRules map[string]any
+	// VisitorKey identifies the visitor for experiment variant bucketing.
+	VisitorKey string
 }
@@ -30,26 +30,72 @@ type Definition struct {
 type Instance struct {
 	bun.BaseModel `bun:"table:widget_instances,alias:wi"`
 
-	ID              uuid.UUID      `bun:",pk,type:uuid" json:"id"`
-	DefinitionID    uuid.UUID      `bun:"definition_id,notnull,type:uuid" json:"definition_id"`
-	BlockInstanceID *uuid.UUID     `bun:"block_instance_id,type:uuid" json:"block_instance_id,omitempty"`
-	AreaCode        *string        `bun:"area_code" json:"area_code,omitempty"`
-	Placement       map[string]any `bun:"placement_metadata,type:jsonb" json:"placement,omitempty"`
-	Configuration   map[string]any `bun:"configuration,type:jsonb,notnull,default:'{}'::jsonb" json:"configuration"`
-	VisibilityRules map[string]any `bun:"visibility_rules,type:jsonb" json:"visibility_rules,omitempty"`
-	PublishOn       *time.Time     `bun:"publish_on" json:"publish_on,omitempty"`
-	UnpublishOn     *time.Time     `bun:"unpublish_on" json:"unpublish_on,omitempty"`
-	Position        int            `bun:"position,notnull,default:0" json:"position"`
-	CreatedBy       uuid.UUID      `bun:"created_by,notnull,type:uuid" json:"created_by"`
-	UpdatedBy       uuid.UUID      `bun:"updated_by,notnull,type:uuid" json:"updated_by"`
-	DeletedAt       *time.Time     `bun:"deleted_at,nullzero" json:"deleted_at,omitempty"`
-	CreatedAt       time.Time      `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
-	UpdatedAt       time.Time      `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
+	ID               uuid.UUID      `bun:",pk,type:uuid" json:"id"`
+	DefinitionID     uuid.UUID      `bun:"definition_id,notnull,type:uuid" json:"definition_id"`
+	EnvironmentID    uuid.UUID      `bun:"environment_id,type:uuid" json:"environment_id,omitempty"`
+	BlockInstanceID  *uuid.UUID     `bun:"block_instance_id,type:uuid" json:"block_instance_id,omitempty"`
+	AreaCode         *string        `bun:"area_code" json:"area_code,omitempty"`
+	Placement        map[string]any `bun:"placement_metadata,type:jsonb" json:"placement,omitempty"`
+	Configuration    map[string]any `bun:"configuration,type:jsonb,notnull,default:'{}'::jsonb" json:"configuration"`
+	VisibilityRules  map[string]any `bun:"visibility_rules,type:jsonb" json:"visibility_rules,omitempty"`
+	PublishOn        *time.Time     `bun:"publish_on" json:"publish_on,omitempty"`
+	UnpublishOn      *time.Time     `bun:"unpublish_on" json:"unpublish_on,omitempty"`
+	Position         int            `bun:"position,notnull,default:0" json:"position"`
+	CurrentVersion   int            `bun:"current_version,notnull,default:0" json:"current_version"`
+	PublishedVersion *int           `bun:"published_version" json:"published_version,omitempty"`
+	PublishedAt      *time.Time     `bun:"published_at,nullzero" json:"published_at,omitempty"`
+	PublishedBy      *uuid.UUID     `bun:"published_by,type:uuid" json:"published_by,omitempty"`
+	CreatedBy        uuid.UUID      `bun:"created_by,notnull,type:uuid" json:"created_by"`
+	UpdatedBy        uuid.UUID      `bun:"updated_by,notnull,type:uuid" json:"updated_by"`
+	DeletedAt        *time.Time     `bun:"deleted_at,nullzero" json:"deleted_at,omitempty"`
+	CreatedAt        time.Time      `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	UpdatedAt        time.Time      `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
 
 	Definition   *Definition    `bun:"rel:belongs-to,join:definition_id=id" json:"definition,omitempty"`
 	Translations []*Translation `bun:"rel:has-many,join:id=widget_instance_id" json:"translations,omitempty"`
 }
 
+// VersionStatus describes where a widget version sits in the publish lifecycle.
+type VersionStatus string
+
+const (
+	// VersionStatusDraft marks a version that has not been published yet.
+	VersionStatusDraft VersionStatus = "draft"
+	// VersionStatusPublished marks the version currently live for the instance.
+	VersionStatusPublished VersionStatus = "published"
+	// VersionStatusArchived marks a previously published version.
+	VersionStatusArchived VersionStatus = "archived"
+)
+
+// InstanceVersion captures a draft or published snapshot of a widget instance.
+type InstanceVersion struct {
+	bun.BaseModel `bun:"table:widget_versions,alias:wv"`
+
+	ID               uuid.UUID               `bun:",pk,type:uuid" json:"id"`
+	WidgetInstanceID uuid.UUID               `bun:"widget_instance_id,notnull,type:uuid" json:"widget_instance_id"`
+	Version          int                     `bun:"version,notnull" json:"version"`
+	Status           VersionStatus           `bun:"status,notnull,default:'draft'" json:"status"`
+	Snapshot         InstanceVersionSnapshot `bun:"snapshot,type:jsonb,notnull" json:"snapshot"`
+	CreatedBy        uuid.UUID               `bun:"created_by,notnull,type:uuid" json:"created_by"`
+	CreatedAt        time.Time               `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
+	PublishedAt      *time.Time              `bun:"published_at,nullzero" json:"published_at,omitempty"`
+	PublishedBy      *uuid.UUID              `bun:"published_by,type:uuid" json:"published_by,omitempty"`
+}
+
+// InstanceVersionSnapshot holds the configuration, visibility rules and
+// translations a widget instance takes on when the version is published.
+type InstanceVersionSnapshot struct {
+	Configuration   map[string]any                       `json:"configuration,omitempty"`
+	VisibilityRules map[string]any                       `json:"visibility_rules,omitempty"`
+	Translations    []InstanceVersionTranslationSnapshot `json:"translations,omitempty"`
+}
+
+// InstanceVersionTranslationSnapshot encodes localized content within a widget snapshot.
+type InstanceVersionTranslationSnapshot struct {
+	LocaleID uuid.UUID      `json:"locale_id"`
+	Content  map[string]any `json:"content"`
+}
+
 // Translation stores localized data for a widget instance.
 type Translation struct {
 	bun.BaseModel `bun:"table:widget_translations,alias:wt"`
@@ -90,6 +136,7 @@ type AreaDefinition struct {
 	Scope       AreaScope  `bun:"scope,notnull,default:'global'" json:"scope"`
 	ThemeID     *uuid.UUID `bun:"theme_id,type:uuid" json:"theme_id,omitempty"`
 	TemplateID  *uuid.UUID `bun:"template_id,type:uuid" json:"template_id,omitempty"`
+	SiteID      *uuid.UUID `bun:"site_id,type:uuid" json:"site_id,omitempty"`
 	CreatedAt   time.Time  `bun:"created_at,nullzero,default:current_timestamp" json:"created_at"`
 	UpdatedAt   time.Time  `bun:"updated_at,nullzero,default:current_timestamp" json:"updated_at"`
 }
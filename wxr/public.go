@@ -0,0 +1,54 @@
+package wxr
+
+import (
+	"io"
+
+	internal "github.com/goliatone/go-cms/internal/wxr"
+)
+
+type (
+	Export             = internal.Export
+	Author             = internal.Author
+	Term               = internal.Term
+	ItemTerm           = internal.ItemTerm
+	Item               = internal.Item
+	Config             = internal.Config
+	Options            = internal.Options
+	Importer           = internal.Importer
+	Report             = internal.Report
+	ItemReport         = internal.ItemReport
+	MenuWriter         = internal.MenuWriter
+	ShortcodeConverter = internal.ShortcodeConverter
+	Progress           = internal.Progress
+	ProgressEntry      = internal.ProgressEntry
+	ProgressStore      = internal.ProgressStore
+)
+
+const (
+	ActionCreated  = internal.ActionCreated
+	ActionUpdated  = internal.ActionUpdated
+	ActionResumed  = internal.ActionResumed
+	ActionSkipped  = internal.ActionSkipped
+	ActionUnmapped = internal.ActionUnmapped
+	ActionFailed   = internal.ActionFailed
+)
+
+var (
+	ErrNotWXR                 = internal.ErrNotWXR
+	ErrContentServiceRequired = internal.ErrContentServiceRequired
+	ErrPageServiceRequired    = internal.ErrPageServiceRequired
+	ErrTemplateRequired       = internal.ErrTemplateRequired
+	ErrLocaleRequired         = internal.ErrLocaleRequired
+)
+
+func Parse(r io.Reader) (*Export, error) {
+	return internal.Parse(r)
+}
+
+func NewImporter(cfg Config) *Importer {
+	return internal.NewImporter(cfg)
+}
+
+func NewFileProgressStore(path string) ProgressStore {
+	return internal.NewFileProgressStore(path)
+}